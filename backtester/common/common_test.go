@@ -117,6 +117,11 @@ func TestDataTypeConversion(t *testing.T) {
 			dataType: TradeStr,
 			want:     DataTrade,
 		},
+		{
+			title:    "Basis data type",
+			dataType: BasisStr,
+			want:     DataBasis,
+		},
 		{
 			title:     "Unknown data type",
 			dataType:  "unknown",
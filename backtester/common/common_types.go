@@ -23,6 +23,11 @@ const (
 	DataCandle int64 = iota
 	// DataTrade is an int64 representation of a trade data type
 	DataTrade
+	// DataBasis is an int64 representation of a basis history data type
+	DataBasis
+	// BasisStr is a config readable data type to tell the backtester to replay
+	// basis history recorded by the GoCryptoTrader basis service
+	BasisStr = "basis"
 )
 
 var (
@@ -14,6 +14,7 @@ import (
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -50,6 +51,10 @@ func (c *Config) Validate() error {
 	if err != nil {
 		return err
 	}
+	err = c.validateBarSettings()
+	if err != nil {
+		return err
+	}
 	return c.validateMinMaxes()
 }
 
@@ -133,6 +138,41 @@ func (c *Config) validateStrategySettings() error {
 	return fmt.Errorf("strategty %v %w", c.StrategySettings.Name, base.ErrStrategyNotFound)
 }
 
+// validateBarSettings ensures alternative bars can be built from the
+// configured data source
+func (c *Config) validateBarSettings() error {
+	if c.DataSettings.Bars == nil {
+		return nil
+	}
+	if c.DataSettings.LiveData != nil {
+		return fmt.Errorf("%w bars cannot be used with live data", errFeatureIncompatible)
+	}
+	settings, err := c.DataSettings.Bars.GetSettings()
+	if err != nil {
+		return err
+	}
+	if settings.Type.RequiresTrades() {
+		return fmt.Errorf("%w '%v'", errBarsRequireCandles, settings.Type)
+	}
+	return nil
+}
+
+// GetSettings converts the config bar settings into kline bar settings
+func (b *BarSettings) GetSettings() (kline.BarSettings, error) {
+	if b == nil {
+		return kline.BarSettings{}, fmt.Errorf("%w bar settings", gctcommon.ErrNilPointer)
+	}
+	barType, err := kline.BarTypeFromString(b.Type)
+	if err != nil {
+		return kline.BarSettings{}, err
+	}
+	settings := kline.BarSettings{
+		Type:      barType,
+		Threshold: b.Threshold.InexactFloat64(),
+	}
+	return settings, settings.Validate()
+}
+
 // validateDate checks whether someone has set a date poorly in their config
 func (c *Config) validateDate() error {
 	if c.DataSettings.DatabaseData != nil {
@@ -145,6 +185,11 @@ func (c *Config) validateDate() error {
 			return err
 		}
 	}
+	if c.DataSettings.JournalData != nil {
+		if err := gctcommon.StartEndTimeCheck(c.DataSettings.JournalData.StartDate, c.DataSettings.JournalData.EndDate); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -333,4 +378,16 @@ func (c *Config) PrintSetting() {
 		log.Infof(common.Config, "Start date: %v", c.DataSettings.DatabaseData.StartDate.Format(time.RFC3339))
 		log.Infof(common.Config, "End date: %v", c.DataSettings.DatabaseData.EndDate.Format(time.RFC3339))
 	}
+	if c.DataSettings.JournalData != nil {
+		log.Infoln(common.Config, common.CMDColours.H2+"------------------Journal Settings---------------------------"+common.CMDColours.Default)
+		log.Infof(common.Config, "Data type: %v", c.DataSettings.DataType)
+		log.Infof(common.Config, "Interval: %v", c.DataSettings.Interval)
+		log.Infof(common.Config, "Journal directory: %v", c.DataSettings.JournalData.Directory)
+		log.Infof(common.Config, "Start date: %v", c.DataSettings.JournalData.StartDate.Format(time.RFC3339))
+		log.Infof(common.Config, "End date: %v", c.DataSettings.JournalData.EndDate.Format(time.RFC3339))
+	}
+	if c.DataSettings.Bars != nil {
+		log.Infof(common.Config, "Bar type: %v", c.DataSettings.Bars.Type)
+		log.Infof(common.Config, "Bar threshold: %v", c.DataSettings.Bars.Threshold)
+	}
 }
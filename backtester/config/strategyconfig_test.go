@@ -308,6 +308,55 @@ func TestValidateMinMaxes(t *testing.T) {
 	}
 }
 
+func TestValidateBarSettings(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	err := c.validateBarSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.DataSettings.Bars = &BarSettings{Type: "kagi"}
+	err = c.validateBarSettings()
+	if !errors.Is(err, kline.ErrInvalidBarType) {
+		t.Errorf("received: %v, expected: %v", err, kline.ErrInvalidBarType)
+	}
+	c.DataSettings.Bars.Type = kline.RenkoBar.String()
+	err = c.validateBarSettings()
+	if !errors.Is(err, kline.ErrInvalidBarThreshold) {
+		t.Errorf("received: %v, expected: %v", err, kline.ErrInvalidBarThreshold)
+	}
+	c.DataSettings.Bars.Type = kline.TickBar.String()
+	c.DataSettings.Bars.Threshold = decimal.NewFromInt(100)
+	err = c.validateBarSettings()
+	if !errors.Is(err, errBarsRequireCandles) {
+		t.Errorf("received: %v, expected: %v", err, errBarsRequireCandles)
+	}
+	c.DataSettings.Bars.Type = kline.DollarBar.String()
+	err = c.validateBarSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	c.DataSettings.LiveData = &LiveData{}
+	err = c.validateBarSettings()
+	if !errors.Is(err, errFeatureIncompatible) {
+		t.Errorf("received: %v, expected: %v", err, errFeatureIncompatible)
+	}
+
+	var b *BarSettings
+	_, err = b.GetSettings()
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received: %v, expected: %v", err, gctcommon.ErrNilPointer)
+	}
+	b = &BarSettings{Type: "Heikin-Ashi"}
+	settings, err := b.GetSettings()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: %v, expected: %v", err, nil)
+	}
+	if settings.Type != kline.HeikinAshiBar {
+		t.Errorf("received: %v, expected: %v", settings.Type, kline.HeikinAshiBar)
+	}
+}
+
 func TestValidateStrategySettings(t *testing.T) {
 	t.Parallel()
 	c := &Config{}
@@ -1075,6 +1124,68 @@ func TestGenerateConfigForDCACSVCandles(t *testing.T) {
 	}
 }
 
+func TestGenerateConfigForDCACSVVolumeBars(t *testing.T) {
+	if !saveConfig {
+		t.Skip("saveConfig false, skipping")
+	}
+	fp := filepath.Join("..", "testdata", "binance_BTCUSDT_24h_2019_01_01_2020_01_01.csv")
+	cfg := Config{
+		Nickname: "ExampleStrategyDCACSVVolumeBars",
+		Goal:     "To demonstrate the DCA strategy using volume bars built from CSV candle data",
+		StrategySettings: StrategySettings{
+			Name:               dca,
+			DisableUSDTracking: true,
+		},
+		CurrencySettings: []CurrencySettings{
+			{
+				ExchangeName: mainExchange,
+				Asset:        asset.Spot,
+				Base:         mainCurrencyPair.Base,
+				Quote:        mainCurrencyPair.Quote,
+				SpotDetails: &SpotDetails{
+					InitialQuoteFunds: initialFunds100000,
+				},
+				BuySide:  minMax,
+				SellSide: minMax,
+				MakerFee: &makerFee,
+				TakerFee: &takerFee,
+			},
+		},
+		DataSettings: DataSettings{
+			Interval: kline.OneDay,
+			DataType: common.CandleStr,
+			CSVData: &CSVData{
+				FullPath: fp,
+			},
+			Bars: &BarSettings{
+				Type:      kline.VolumeBar.String(),
+				Threshold: decimal.NewFromInt(250000),
+			},
+		},
+		PortfolioSettings: PortfolioSettings{
+			BuySide:  minMax,
+			SellSide: minMax,
+		},
+		StatisticSettings: StatisticSettings{
+			RiskFreeRate: decimal.NewFromFloat(0.03),
+		},
+	}
+	if saveConfig {
+		result, err := json.MarshalIndent(cfg, "", " ")
+		if err != nil {
+			t.Fatal(err)
+		}
+		p, err := os.Getwd()
+		if err != nil {
+			t.Fatal(err)
+		}
+		err = os.WriteFile(filepath.Join(p, "strategyexamples", "dca-csv-volume-bars.strat"), result, file.DefaultPermissionOctal)
+		if err != nil {
+			t.Error(err)
+		}
+	}
+}
+
 func TestGenerateConfigForDCACSVTrades(t *testing.T) {
 	if !saveConfig {
 		t.Skip("saveConfig false, skipping")
@@ -26,6 +26,7 @@ var (
 	errMinMaxEqual                      = errors.New("minimum and maximum limits cannot be equal")
 	errPerpetualsUnsupported            = errors.New("perpetual futures not yet supported")
 	errFeatureIncompatible              = errors.New("feature is not compatible")
+	errBarsRequireCandles               = errors.New("bars are built from candle data, bar types which require individual trades are not supported")
 )
 
 // Config defines what is in an individual strategy config
@@ -50,6 +51,15 @@ type DataSettings struct {
 	DatabaseData            *DatabaseData  `json:"database-data,omitempty"`
 	LiveData                *LiveData      `json:"live-data,omitempty"`
 	CSVData                 *CSVData       `json:"csv-data,omitempty"`
+	JournalData             *JournalData   `json:"journal-data,omitempty"`
+	Bars                    *BarSettings   `json:"bars,omitempty"`
+}
+
+// BarSettings converts loaded candle data into alternative bars such as
+// volume, dollar, heikin-ashi or renko bars before running the strategy
+type BarSettings struct {
+	Type      string          `json:"type"`
+	Threshold decimal.Decimal `json:"threshold"`
 }
 
 // FundingSettings contains funding details for individual currencies
@@ -179,6 +189,15 @@ type CSVData struct {
 	FullPath string `json:"full-path"`
 }
 
+// JournalData defines all fields to configure data recorded by the
+// GoCryptoTrader event journal
+type JournalData struct {
+	Directory        string    `json:"directory"`
+	StartDate        time.Time `json:"start-date"`
+	EndDate          time.Time `json:"end-date"`
+	InclusiveEndDate bool      `json:"inclusive-end-date"`
+}
+
 // DatabaseData defines all fields to configure database based data
 type DatabaseData struct {
 	StartDate        time.Time       `json:"start-date"`
@@ -192,7 +192,34 @@ func parseDataSettings(cfg *config.Config, reader *bufio.Reader) error {
 	case "Live":
 		parseLive(reader, cfg)
 	}
-	return err
+	if err != nil || choice == "Live" {
+		return err
+	}
+	return parseBars(reader, cfg)
+}
+
+func parseBars(reader *bufio.Reader, cfg *config.Config) error {
+	fmt.Println("Will candles be converted into volume, dollar, heikin-ashi or renko bars? y/n")
+	yn := quickParse(reader)
+	if yn != y && yn != yes {
+		return nil
+	}
+	bars := &config.BarSettings{}
+	fmt.Println("What bar type will you use?")
+	bars.Type = quickParse(reader)
+	fmt.Println("What is the bar threshold? eg the volume per bar or renko brick size")
+	if threshold := quickParse(reader); threshold != "" {
+		var err error
+		bars.Threshold, err = decimal.NewFromString(threshold)
+		if err != nil {
+			return err
+		}
+	}
+	if _, err := bars.GetSettings(); err != nil {
+		return err
+	}
+	cfg.DataSettings.Bars = bars
+	return nil
 }
 
 func parsePortfolioSettings(reader *bufio.Reader, cfg *config.Config) error {
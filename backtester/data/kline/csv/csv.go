@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"math"
 	"os"
 	"strconv"
 	"strings"
@@ -20,7 +21,14 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
-var errNoUSDData = errors.New("could not retrieve USD CSV candle data")
+var (
+	errNoUSDData         = errors.New("could not retrieve USD CSV candle data")
+	errMissingBasisField = errors.New("basis history missing column")
+	errNoBasisData       = errors.New("no basis history found")
+)
+
+// basisFields are the basis history columns required to replay prices
+var basisFields = []string{"timestamp", "exchange", "asset", "pair", "spot_exchange", "spot_pair", "spot_mid", "contract_mid"}
 
 // LoadData is a basic csv reader which converts the found CSV file into a kline item
 func LoadData(dataType int64, filepath, exchangeName string, interval time.Duration, fPair currency.Pair, a asset.Item, isUSDTrackingPair bool) (*kline.DataFromKline, error) {
@@ -150,6 +158,11 @@ func LoadData(dataType int64, filepath, exchangeName string, interval time.Durat
 		if err != nil {
 			return nil, fmt.Errorf("could not read csv trade data for %v %v %v, %v", exchangeName, a, fPair, err)
 		}
+	case common.DataBasis:
+		resp.Item, err = loadBasisCandles(csvData, exchangeName, gctkline.Interval(interval), fPair, a)
+		if err != nil {
+			return nil, fmt.Errorf("could not read csv basis data for %v %v %v, %w", exchangeName, a, fPair, err)
+		}
 	default:
 		if isUSDTrackingPair {
 			return nil, fmt.Errorf("%w for %v %v %v. Please add USD pair data to your CSV or set `disable-usd-tracking` to `true` in your config. %v", errNoUSDData, exchangeName, a, fPair, err)
@@ -163,3 +176,88 @@ func LoadData(dataType int64, filepath, exchangeName string, interval time.Durat
 
 	return resp, nil
 }
+
+// loadBasisCandles converts basis history recorded by the GoCryptoTrader basis
+// service into candles. Spot pairs are priced from the spot mid of rows which
+// used the exchange as their spot reference and futures contracts from the
+// contract mid of their own rows. Columns are located by the header row
+func loadBasisCandles(r *csv.Reader, exchangeName string, interval gctkline.Interval, fPair currency.Pair, a asset.Item) (*gctkline.Item, error) {
+	header, err := r.Read()
+	if err != nil {
+		return nil, err
+	}
+	columns := make(map[string]int, len(header))
+	for i := range header {
+		columns[strings.ToLower(strings.TrimSpace(header[i]))] = i
+	}
+	for i := range basisFields {
+		if _, ok := columns[basisFields[i]]; !ok {
+			return nil, fmt.Errorf("%w %v", errMissingBasisField, basisFields[i])
+		}
+	}
+	exchangeColumn, pairColumn, priceColumn := columns["exchange"], columns["pair"], columns["contract_mid"]
+	if a == asset.Spot {
+		exchangeColumn, pairColumn, priceColumn = columns["spot_exchange"], columns["spot_pair"], columns["spot_mid"]
+	}
+	pair := basisPairString(fPair.Base.String() + fPair.Quote.String())
+
+	resp := &gctkline.Item{
+		Exchange: exchangeName,
+		Pair:     fPair,
+		Asset:    a,
+		Interval: interval,
+	}
+	var lastTime time.Time
+	for {
+		row, errCSV := r.Read()
+		if errCSV != nil {
+			if errCSV == io.EOF {
+				break
+			}
+			return nil, errCSV
+		}
+		if !strings.EqualFold(row[exchangeColumn], exchangeName) ||
+			basisPairString(row[pairColumn]) != pair ||
+			(a != asset.Spot && !strings.EqualFold(row[columns["asset"]], a.String())) {
+			continue
+		}
+		v, errParse := strconv.ParseInt(row[columns["timestamp"]], 10, 64)
+		if errParse != nil {
+			return nil, errParse
+		}
+		tt := time.Unix(v, 0).UTC()
+		if tt.Equal(lastTime) {
+			// a spot price is repeated for every contract of its underlying
+			continue
+		}
+		lastTime = tt
+		price, errParse := strconv.ParseFloat(row[priceColumn], 64)
+		if errParse != nil {
+			return nil, fmt.Errorf("could not process basis price %v %v", row[priceColumn], errParse)
+		}
+		tt = tt.Truncate(interval.Duration())
+		if len(resp.Candles) > 0 && resp.Candles[len(resp.Candles)-1].Time.Equal(tt) {
+			c := &resp.Candles[len(resp.Candles)-1]
+			c.High = math.Max(c.High, price)
+			c.Low = math.Min(c.Low, price)
+			c.Close = price
+			continue
+		}
+		resp.Candles = append(resp.Candles, gctkline.Candle{
+			Time:  tt,
+			Open:  price,
+			High:  price,
+			Low:   price,
+			Close: price,
+		})
+	}
+	if len(resp.Candles) == 0 {
+		return nil, errNoBasisData
+	}
+	return resp, nil
+}
+
+// basisPairString normalises a pair so differently delimited pairs match
+func basisPairString(p string) string {
+	return strings.ToUpper(strings.NewReplacer("-", "", "_", "", "/", "").Replace(p))
+}
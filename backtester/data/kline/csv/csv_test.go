@@ -2,6 +2,7 @@ package csv
 
 import (
 	"errors"
+	"os"
 	"path/filepath"
 	"testing"
 
@@ -47,6 +48,47 @@ func TestLoadDataTrades(t *testing.T) {
 	}
 }
 
+func TestLoadDataBasis(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "basis.csv")
+	data := "timestamp,exchange,asset,pair,underlying,spot_exchange,spot_pair,spot_mid,contract_mid,perpetual,expiry,basis,basis_rate,annualised_basis\n" +
+		"1672531200,binance,coinmarginedfutures,BTCUSD-PERP,BTC,binance,BTC-USDT,20000,20010,true,0,10,0.0005,0\n" +
+		"1672531200,binance,coinmarginedfutures,BTCUSD-230331,BTC,binance,BTC-USDT,20000,20200,false,1680249600,200,0.01,0.04\n" +
+		"1672531260,binance,coinmarginedfutures,BTCUSD-PERP,BTC,binance,BTC-USDT,20100,20120,true,0,20,0.001,0\n" +
+		"1672532100,binance,coinmarginedfutures,BTCUSD-PERP,BTC,binance,BTC-USDT,19900,19950,true,0,50,0.0025,0\n"
+	if err := os.WriteFile(path, []byte(data), 0o600); !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+
+	resp, err := LoadData(common.DataBasis, path, testExchange, gctkline.FifteenMin.Duration(), currency.NewPair(currency.BTC, currency.USDT), asset.Spot, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	if len(resp.Item.Candles) != 2 {
+		t.Fatalf("received: %v, expected: %v", len(resp.Item.Candles), 2)
+	}
+	if c := resp.Item.Candles[0]; c.Open != 20000 || c.High != 20100 || c.Close != 20100 {
+		t.Errorf("received: %+v, expected open 20000 high and close 20100", c)
+	}
+
+	resp, err = LoadData(common.DataBasis, path, testExchange, gctkline.FifteenMin.Duration(), currency.NewPairWithDelimiter("BTCUSD", "PERP", "_"), asset.CoinMarginedFutures, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	if len(resp.Item.Candles) != 2 || resp.Item.Candles[1].Close != 19950 {
+		t.Errorf("received: %+v, expected two perpetual candles", resp.Item.Candles)
+	}
+
+	_, err = LoadData(common.DataBasis, path, testExchange, gctkline.FifteenMin.Duration(), currency.NewPair(currency.ETH, currency.USDT), asset.Spot, false)
+	if !errors.Is(err, errNoBasisData) {
+		t.Errorf("received: %v, expected: %v", err, errNoBasisData)
+	}
+	_, err = LoadData(common.DataBasis, filepath.Join("..", "..", "..", "..", "testdata", "binance_BTCUSDT_24h-trades_2020_11_16.csv"), testExchange, gctkline.FifteenMin.Duration(), currency.NewPair(currency.BTC, currency.USDT), asset.Spot, false)
+	if !errors.Is(err, errMissingBasisField) {
+		t.Errorf("received: %v, expected: %v", err, errMissingBasisField)
+	}
+}
+
 func TestLoadDataInvalid(t *testing.T) {
 	exch := testExchange
 	a := asset.Spot
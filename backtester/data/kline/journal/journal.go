@@ -0,0 +1,115 @@
+package journal
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	gctjournal "github.com/thrasher-corp/gocryptotrader/journal"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var errNoJournalData = errors.New("no journaled data found")
+
+// LoadData reads events recorded by the GoCryptoTrader event journal between
+// start and end and converts them into candles. Trade data is built from
+// journaled trades and candle data from journaled websocket klines whose
+// period matches the interval, using the last update received for each candle
+func LoadData(dataType int64, directory, exchangeName string, interval time.Duration, fPair currency.Pair, a asset.Item, start, end time.Time) (*kline.DataFromKline, error) {
+	if interval <= 0 {
+		return nil, gctkline.ErrInvalidInterval
+	}
+	var kind gctjournal.Kind
+	switch dataType {
+	case common.DataCandle:
+		kind = gctjournal.KindKline
+	case common.DataTrade:
+		kind = gctjournal.KindTrade
+	default:
+		return nil, fmt.Errorf("could not process journal data for %v %v %v, %w", exchangeName, a, fPair, common.ErrInvalidDataType)
+	}
+	r, err := gctjournal.NewReader(directory, start, end, kind)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if closeErr := r.Close(); closeErr != nil {
+			log.Errorln(common.Data, closeErr)
+		}
+	}()
+
+	var trades []trade.Data
+	candles := make(map[time.Time]gctkline.Candle)
+	var rec *gctjournal.Record
+	for {
+		rec, err = r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("could not read journal data for %v %v %v, %w", exchangeName, a, fPair, err)
+		}
+		switch d := rec.Data.(type) {
+		case *trade.Data:
+			if strings.EqualFold(d.Exchange, exchangeName) && d.AssetType == a && d.CurrencyPair.Equal(fPair) {
+				trades = append(trades, *d)
+			}
+		case *stream.KlineData:
+			if !strings.EqualFold(d.Exchange, exchangeName) || d.AssetType != a || !d.Pair.Equal(fPair) {
+				continue
+			}
+			// exchanges commonly close a candle a millisecond before the
+			// next one opens
+			if d.CloseTime.Sub(d.StartTime).Round(time.Second) != interval {
+				continue
+			}
+			openTime := d.StartTime.UTC()
+			candles[openTime] = gctkline.Candle{
+				Time:   openTime,
+				Open:   d.OpenPrice,
+				High:   d.HighPrice,
+				Low:    d.LowPrice,
+				Close:  d.ClosePrice,
+				Volume: d.Volume,
+			}
+		}
+	}
+
+	resp := kline.NewDataFromKline()
+	switch dataType {
+	case common.DataCandle:
+		if len(candles) == 0 {
+			return nil, fmt.Errorf("%w for %v %v %v %v candles", errNoJournalData, exchangeName, a, fPair, gctkline.Interval(interval))
+		}
+		resp.Item = &gctkline.Item{Candles: make([]gctkline.Candle, 0, len(candles))}
+		for _, c := range candles {
+			resp.Item.Candles = append(resp.Item.Candles, c)
+		}
+		sort.Slice(resp.Item.Candles, func(i, j int) bool {
+			return resp.Item.Candles[i].Time.Before(resp.Item.Candles[j].Time)
+		})
+	case common.DataTrade:
+		if len(trades) == 0 {
+			return nil, fmt.Errorf("%w for %v %v %v trades", errNoJournalData, exchangeName, a, fPair)
+		}
+		resp.Item, err = trade.ConvertTradesToCandles(gctkline.Interval(interval), trades...)
+		if err != nil {
+			return nil, fmt.Errorf("could not convert journal trade data for %v %v %v, %v", exchangeName, a, fPair, err)
+		}
+	}
+	resp.Item.Exchange = strings.ToLower(exchangeName)
+	resp.Item.Pair = fPair
+	resp.Item.Asset = a
+	resp.Item.Interval = gctkline.Interval(interval)
+	return resp, nil
+}
@@ -0,0 +1,92 @@
+package journal
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/backtester/common"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	gctjournal "github.com/thrasher-corp/gocryptotrader/journal"
+)
+
+const testExchange = "binance"
+
+var (
+	testPair  = currency.NewPair(currency.BTC, currency.USDT)
+	testStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func writeTestJournal(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	w, err := gctjournal.NewWriter(gctjournal.WriterConfig{Directory: dir})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	events := []interface{}{
+		&trade.Data{Exchange: testExchange, CurrencyPair: testPair, AssetType: asset.Spot, Side: order.Buy, Price: 100, Amount: 1, Timestamp: testStart},
+		&stream.KlineData{Exchange: testExchange, Pair: testPair, AssetType: asset.Spot, StartTime: testStart, CloseTime: testStart.Add(time.Minute - time.Millisecond), OpenPrice: 100, HighPrice: 100, LowPrice: 100, ClosePrice: 100, Volume: 1},
+		&trade.Data{Exchange: testExchange, CurrencyPair: testPair, AssetType: asset.Spot, Side: order.Sell, Price: 110, Amount: 2, Timestamp: testStart.Add(time.Second * 30)},
+		&stream.KlineData{Exchange: testExchange, Pair: testPair, AssetType: asset.Spot, StartTime: testStart, CloseTime: testStart.Add(time.Minute - time.Millisecond), OpenPrice: 100, HighPrice: 110, LowPrice: 100, ClosePrice: 110, Volume: 3},
+		// other pairs and intervals are ignored
+		&trade.Data{Exchange: testExchange, CurrencyPair: currency.NewPair(currency.ETH, currency.USDT), AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: testStart.Add(time.Second * 40)},
+		&stream.KlineData{Exchange: testExchange, Pair: testPair, AssetType: asset.Spot, StartTime: testStart, CloseTime: testStart.Add(time.Hour), OpenPrice: 1, Volume: 1},
+		&trade.Data{Exchange: testExchange, CurrencyPair: testPair, AssetType: asset.Spot, Side: order.Buy, Price: 105, Amount: 1, Timestamp: testStart.Add(time.Minute)},
+		&stream.KlineData{Exchange: testExchange, Pair: testPair, AssetType: asset.Spot, StartTime: testStart.Add(time.Minute), CloseTime: testStart.Add(time.Minute*2 - time.Millisecond), OpenPrice: 105, HighPrice: 105, LowPrice: 105, ClosePrice: 105, Volume: 1},
+	}
+	for i := range events {
+		err = w.Write(testStart.Add(time.Duration(i)*time.Second*10), events[i])
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: %v, expected: %v", err, nil)
+		}
+	}
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	return dir
+}
+
+func TestLoadData(t *testing.T) {
+	t.Parallel()
+	dir := writeTestJournal(t)
+	end := testStart.Add(time.Hour)
+	_, err := LoadData(common.DataCandle, dir, testExchange, 0, testPair, asset.Spot, testStart, end)
+	if !errors.Is(err, gctkline.ErrInvalidInterval) {
+		t.Errorf("received: %v, expected: %v", err, gctkline.ErrInvalidInterval)
+	}
+	_, err = LoadData(1337, dir, testExchange, time.Minute, testPair, asset.Spot, testStart, end)
+	if !errors.Is(err, common.ErrInvalidDataType) {
+		t.Errorf("received: %v, expected: %v", err, common.ErrInvalidDataType)
+	}
+	_, err = LoadData(common.DataTrade, dir, testExchange, time.Minute, testPair, asset.Margin, testStart, end)
+	if !errors.Is(err, errNoJournalData) {
+		t.Errorf("received: %v, expected: %v", err, errNoJournalData)
+	}
+
+	var resp *kline.DataFromKline
+	for _, dataType := range []int64{common.DataCandle, common.DataTrade} {
+		resp, err = LoadData(dataType, dir, "Binance", time.Minute, testPair, asset.Spot, testStart, end)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: %v, expected: %v", err, nil)
+		}
+		if resp.Item.Exchange != testExchange || resp.Item.Interval != gctkline.OneMin {
+			t.Errorf("received: %v %v, expected: %v %v", resp.Item.Exchange, resp.Item.Interval, testExchange, gctkline.OneMin)
+		}
+		resp.Item.SortCandlesByTimestamp(false)
+		if len(resp.Item.Candles) != 2 {
+			t.Fatalf("received: %v, expected: %v", len(resp.Item.Candles), 2)
+		}
+		first := resp.Item.Candles[0]
+		if !first.Time.Equal(testStart) || first.Open != 100 || first.High != 110 || first.Close != 110 || first.Volume != 3 {
+			t.Errorf("received: %+v, expected: %v", first, "open 100 high 110 close 110 volume 3")
+		}
+	}
+}
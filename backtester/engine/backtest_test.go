@@ -46,6 +46,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binanceus"
 	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	gctorder "github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	gctjournal "github.com/thrasher-corp/gocryptotrader/journal"
 )
 
 const testExchange = "binanceus"
@@ -245,6 +247,148 @@ func TestLoadDataCSV(t *testing.T) {
 	}
 }
 
+func TestLoadDataCSVBars(t *testing.T) {
+	t.Parallel()
+	bt := BackTest{
+		Reports: &report.Data{},
+	}
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	cfg := &config.Config{
+		DataSettings: config.DataSettings{
+			DataType: common.CandleStr,
+			Interval: gctkline.OneDay,
+			CSVData: &config.CSVData{
+				FullPath: filepath.Join("..", "..", "testdata", "binance_BTCUSDT_24h_2019_01_01_2020_01_01.csv"),
+			},
+			Bars: &config.BarSettings{
+				Type:      gctkline.VolumeBar.String(),
+				Threshold: decimal.NewFromInt(250000),
+			},
+		},
+	}
+	em := engine.ExchangeManager{}
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	d, err := bt.loadData(cfg, exch, cp, asset.Spot, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(d.Item.Candles) == 0 || len(d.Item.Candles) >= 365 {
+		t.Fatalf("unexpected bar count %v", len(d.Item.Candles))
+	}
+	for i := range d.Item.Candles {
+		if d.Item.Candles[i].Volume < 250000 {
+			t.Errorf("bar %v volume %v below threshold", i, d.Item.Candles[i].Volume)
+		}
+		ok, err := d.HasDataAtTime(d.Item.Candles[i].Time)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if !ok {
+			t.Errorf("expected data at %v", d.Item.Candles[i].Time)
+		}
+	}
+}
+
+func TestLoadDataJournal(t *testing.T) {
+	t.Parallel()
+	bt := BackTest{
+		Reports: &report.Data{},
+	}
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	dir := t.TempDir()
+	w, err := gctjournal.NewWriter(gctjournal.WriterConfig{Directory: dir})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	tt := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i := 0; i < 3; i++ {
+		err = w.Write(tt.Add(time.Minute*time.Duration(i)), &trade.Data{
+			Exchange:     testExchange,
+			CurrencyPair: cp,
+			AssetType:    asset.Spot,
+			Side:         gctorder.Buy,
+			Price:        float64(100 + i),
+			Amount:       1,
+			Timestamp:    tt.Add(time.Minute * time.Duration(i)),
+		})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+	}
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	cfg := &config.Config{
+		DataSettings: config.DataSettings{
+			DataType: common.TradeStr,
+			JournalData: &config.JournalData{
+				Directory: dir,
+				StartDate: tt,
+				EndDate:   tt.Add(time.Minute * 3),
+			},
+		},
+	}
+	em := engine.ExchangeManager{}
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	_, err = bt.loadData(cfg, exch, cp, asset.Spot, false)
+	if !errors.Is(err, errIntervalUnset) {
+		t.Errorf("received '%v' expected '%v'", err, errIntervalUnset)
+	}
+	cfg.DataSettings.Interval = gctkline.OneMin
+	d, err := bt.loadData(cfg, exch, cp, asset.Spot, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(d.Item.Candles) != 3 {
+		t.Errorf("received '%v' expected '%v'", len(d.Item.Candles), 3)
+	}
+}
+
+func TestConvertToBars(t *testing.T) {
+	t.Parallel()
+	err := convertToBars(nil, nil)
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
+	}
+	tt := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	d := kline.NewDataFromKline()
+	d.Item = &gctkline.Item{
+		Interval: gctkline.OneDay,
+		Candles: []gctkline.Candle{
+			{Time: tt, Open: 10, High: 11, Low: 9, Close: 10, Volume: 1},
+			{Time: tt.Add(gctkline.OneDay.Duration()), Open: 10, High: 14, Low: 10, Close: 14, Volume: 1},
+		},
+	}
+	err = convertToBars(d, nil)
+	if !errors.Is(err, gctcommon.ErrNilPointer) {
+		t.Errorf("received '%v' expected '%v'", err, gctcommon.ErrNilPointer)
+	}
+	err = convertToBars(d, &config.BarSettings{Type: gctkline.VolumeBar.String(), Threshold: decimal.NewFromInt(5)})
+	if !errors.Is(err, errNoBarsBuilt) {
+		t.Errorf("received '%v' expected '%v'", err, errNoBarsBuilt)
+	}
+	err = convertToBars(d, &config.BarSettings{Type: gctkline.RenkoBar.String(), Threshold: decimal.NewFromInt(1)})
+	if !errors.Is(err, errBarTimesNotUnique) {
+		t.Errorf("received '%v' expected '%v'", err, errBarTimesNotUnique)
+	}
+	err = convertToBars(d, &config.BarSettings{Type: gctkline.HeikinAshiBar.String()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(d.Item.Candles) != 2 || d.Item.Candles[1].Close != 12 {
+		t.Errorf("unexpected heikin-ashi bars %+v", d.Item.Candles)
+	}
+}
+
 func TestLoadDataDatabase(t *testing.T) {
 	t.Parallel()
 	bt := BackTest{
@@ -26,6 +26,8 @@ var (
 	errNilData             = errors.New("nil data received")
 	errLiveOnly            = errors.New("close all positions is only supported by live data type")
 	errNotSetup            = errors.New("backtesting task not setup")
+	errNoBarsBuilt         = errors.New("no bars built from candle data")
+	errBarTimesNotUnique   = errors.New("bar times are not unique")
 )
 
 // BackTest is the main holder of all backtesting functionality
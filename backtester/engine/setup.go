@@ -17,6 +17,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/api"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/csv"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/database"
+	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline/journal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/eventholder"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/exchange/slippage"
@@ -694,18 +695,22 @@ func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange,
 		return nil, engine.ErrExchangeNotFound
 	}
 	b := exch.GetBase()
-	if cfg.DataSettings.DatabaseData == nil &&
-		cfg.DataSettings.LiveData == nil &&
-		cfg.DataSettings.APIData == nil &&
-		cfg.DataSettings.CSVData == nil {
+	var sources int
+	for _, set := range []bool{
+		cfg.DataSettings.DatabaseData != nil,
+		cfg.DataSettings.LiveData != nil,
+		cfg.DataSettings.APIData != nil,
+		cfg.DataSettings.CSVData != nil,
+		cfg.DataSettings.JournalData != nil,
+	} {
+		if set {
+			sources++
+		}
+	}
+	if sources == 0 {
 		return nil, errNoDataSource
 	}
-	if (cfg.DataSettings.APIData != nil && cfg.DataSettings.DatabaseData != nil) ||
-		(cfg.DataSettings.APIData != nil && cfg.DataSettings.LiveData != nil) ||
-		(cfg.DataSettings.APIData != nil && cfg.DataSettings.CSVData != nil) ||
-		(cfg.DataSettings.DatabaseData != nil && cfg.DataSettings.LiveData != nil) ||
-		(cfg.DataSettings.CSVData != nil && cfg.DataSettings.LiveData != nil) ||
-		(cfg.DataSettings.CSVData != nil && cfg.DataSettings.DatabaseData != nil) {
+	if sources > 1 {
 		return nil, errAmbiguousDataSource
 	}
 
@@ -810,6 +815,47 @@ func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange,
 			return nil, err
 		}
 
+		summary := resp.RangeHolder.DataSummary(false)
+		if len(summary) > 0 {
+			log.Warnf(common.Setup, "%v", summary)
+		}
+	case cfg.DataSettings.JournalData != nil:
+		if cfg.DataSettings.Interval <= 0 {
+			return nil, errIntervalUnset
+		}
+		if cfg.DataSettings.JournalData.InclusiveEndDate {
+			cfg.DataSettings.JournalData.EndDate = cfg.DataSettings.JournalData.EndDate.Add(cfg.DataSettings.Interval.Duration())
+		}
+		if cfg.DataSettings.JournalData.Directory == "" {
+			cfg.DataSettings.JournalData.Directory = filepath.Join(gctcommon.GetDefaultDataDir(runtime.GOOS), "journal")
+		}
+		resp, err = journal.LoadData(
+			dataType,
+			cfg.DataSettings.JournalData.Directory,
+			strings.ToLower(exch.GetName()),
+			cfg.DataSettings.Interval.Duration(),
+			fPair,
+			a,
+			cfg.DataSettings.JournalData.StartDate,
+			cfg.DataSettings.JournalData.EndDate)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve data from the GoCryptoTrader event journal. Error: %v. Please ensure the event journal recorded data for the period", err)
+		}
+		resp.Item.RemoveDuplicates()
+		resp.Item.SortCandlesByTimestamp(false)
+		resp.RangeHolder, err = gctkline.CalculateCandleDateRanges(
+			cfg.DataSettings.JournalData.StartDate,
+			cfg.DataSettings.JournalData.EndDate,
+			cfg.DataSettings.Interval,
+			0,
+		)
+		if err != nil {
+			return nil, err
+		}
+		err = resp.RangeHolder.SetHasDataFromCandles(resp.Item.Candles)
+		if err != nil {
+			return nil, err
+		}
 		summary := resp.RangeHolder.DataSummary(false)
 		if len(summary) > 0 {
 			log.Warnf(common.Setup, "%v", summary)
@@ -861,6 +907,12 @@ func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange,
 	if resp == nil {
 		return nil, fmt.Errorf("processing error, response returned nil")
 	}
+	if cfg.DataSettings.Bars != nil {
+		err = convertToBars(resp, cfg.DataSettings.Bars)
+		if err != nil {
+			return nil, err
+		}
+	}
 
 	resp.Item.UnderlyingPair = underlyingPair
 	err = resp.Load()
@@ -874,6 +926,34 @@ func (bt *BackTest) loadData(cfg *config.Config, exch gctexchange.IBotExchange,
 	return resp, nil
 }
 
+// convertToBars replaces loaded candle data with alternative bars. Bars take
+// the time of the first candle they are built from, so the range holder built
+// from the candles still reflects when data is present
+func convertToBars(d *kline.DataFromKline, cfg *config.BarSettings) error {
+	if d == nil || d.Item == nil {
+		return fmt.Errorf("%w kline data", gctcommon.ErrNilPointer)
+	}
+	settings, err := cfg.GetSettings()
+	if err != nil {
+		return err
+	}
+	bars, err := d.Item.ConvertToBars(settings)
+	if err != nil {
+		return err
+	}
+	if len(bars.Candles) == 0 {
+		return fmt.Errorf("%w for %v bars with a threshold of %v, please lower the threshold", errNoBarsBuilt, settings.Type, settings.Threshold)
+	}
+	for i := 1; i < len(bars.Candles); i++ {
+		if !bars.Candles[i].Time.After(bars.Candles[i-1].Time) {
+			return fmt.Errorf("%w, multiple %v bars were built at %v. Please raise the threshold or use a shorter interval", errBarTimesNotUnique, settings.Type, bars.Candles[i].Time)
+		}
+	}
+	log.Infof(common.Setup, "Converted %v candles into %v %v bars for %v %v %v\n", len(d.Item.Candles), len(bars.Candles), settings.Type, d.Item.Exchange, d.Item.Asset, d.Item.Pair)
+	d.Item = bars
+	return nil
+}
+
 func loadDatabaseData(cfg *config.Config, name string, fPair currency.Pair, a asset.Item, dataType int64, isUSDTrackingPair bool) (*kline.DataFromKline, error) {
 	if cfg == nil || cfg.DataSettings.DatabaseData == nil {
 		return nil, errors.New("nil config data received")
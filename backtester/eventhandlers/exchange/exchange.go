@@ -15,6 +15,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/order"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/engine"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
@@ -360,6 +361,7 @@ func (e *Exchange) placeOrder(ctx context.Context, price, amount, fee decimal.De
 
 	var resp *engine.OrderSubmitResponse
 	if useRealOrders {
+		ctx, _ = tracing.EnsureCorrelationID(ctx)
 		resp, err = orderManager.Submit(ctx, submit)
 	} else {
 		var submitResponse *gctorder.SubmitResponse
@@ -5,7 +5,6 @@ import (
 	"time"
 
 	"github.com/shopspring/decimal"
-	"github.com/thrasher-corp/gct-ta/indicators"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventhandlers/portfolio"
@@ -13,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/backtester/eventtypes/signal"
 	"github.com/thrasher-corp/gocryptotrader/backtester/funding"
 	gctcommon "github.com/thrasher-corp/gocryptotrader/common"
+	gctkline "github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -31,6 +31,18 @@ type Strategy struct {
 	rsiPeriod decimal.Decimal
 	rsiLow    decimal.Decimal
 	rsiHigh   decimal.Decimal
+	streams   map[string]*rsiStream
+}
+
+// rsiStream holds the streaming RSI state of a single data handler so each
+// signal only processes new candles
+type rsiStream struct {
+	period            int64
+	rsi               *gctkline.RelativeStrengthIndexStream
+	consumed          int
+	previous          decimal.Decimal
+	missingDataStreak int64
+	value             float64
 }
 
 // Name returns the name of the strategy
@@ -69,17 +81,11 @@ func (s *Strategy) OnSignal(d data.Handler, _ funding.IFundingTransferer, _ port
 		return &es, nil
 	}
 
-	dataRange, err := d.StreamClose()
+	rsi, err := s.updateRSI(d, latest, es.GetTime())
 	if err != nil {
 		return nil, err
 	}
-	var massagedData []float64
-	massagedData, err = s.massageMissingData(dataRange, es.GetTime())
-	if err != nil {
-		return nil, err
-	}
-	rsi := indicators.RSI(massagedData, int(s.rsiPeriod.IntPart()))
-	latestRSIValue := decimal.NewFromFloat(rsi[len(rsi)-1])
+	latestRSIValue := decimal.NewFromFloat(rsi)
 	hasDataAtTime, err := d.HasDataAtTime(latest.GetTime())
 	if err != nil {
 		return nil, err
@@ -171,27 +177,59 @@ func (s *Strategy) SetDefaults() {
 	s.rsiPeriod = decimal.NewFromInt(14)
 }
 
-// massageMissingData will replace missing data with the previous candle's data
-// this will ensure that RSI can be calculated correctly
-// the decision to handle missing data occurs at the strategy level, not all strategies
-// may wish to modify data
-func (s *Strategy) massageMissingData(data []decimal.Decimal, t time.Time) ([]float64, error) {
-	resp := make([]float64, len(data))
-	var missingDataStreak int64
-	for i := range data {
-		if data[i].IsZero() && i > int(s.rsiPeriod.IntPart()) {
-			data[i] = data[i-1]
+// updateRSI feeds any closes not yet seen to the data handler's RSI stream
+// and returns the latest RSI value. Missing data is replaced with the previous
+// candle's close so that RSI can be calculated correctly, the decision to
+// handle missing data occurs at the strategy level, not all strategies may
+// wish to modify data
+func (s *Strategy) updateRSI(d data.Handler, latest data.Event, t time.Time) (float64, error) {
+	period := s.rsiPeriod.IntPart()
+	key := latest.GetExchange() + latest.GetAssetType().String() + latest.Pair().String()
+	stream, ok := s.streams[key]
+	if !ok || stream.period != period {
+		stream = &rsiStream{period: period}
+		// the batch RSI returns no values below a period of two
+		stream.rsi, _ = gctkline.NewRelativeStrengthIndexStream(period)
+		if s.streams == nil {
+			s.streams = make(map[string]*rsiStream)
+		}
+		s.streams[key] = stream
+	}
+
+	closes := []decimal.Decimal{latest.GetClosePrice()}
+	if latest.GetOffset() != int64(stream.consumed+1) {
+		history, err := d.StreamClose()
+		if err != nil {
+			return 0, err
+		}
+		if len(history) < stream.consumed {
+			*stream = rsiStream{period: period}
+			stream.rsi, _ = gctkline.NewRelativeStrengthIndexStream(period)
+		}
+		closes = history[stream.consumed:]
+	}
+
+	for i := range closes {
+		closePrice := closes[i]
+		missingDataStreak := stream.missingDataStreak
+		if closePrice.IsZero() && stream.consumed > int(period) {
+			closePrice = stream.previous
 			missingDataStreak++
 		} else {
 			missingDataStreak = 0
 		}
-		if missingDataStreak >= s.rsiPeriod.IntPart() {
-			return nil, fmt.Errorf("missing data exceeds RSI period length of %v at %s and will distort results. %w",
+		if missingDataStreak >= period {
+			return 0, fmt.Errorf("missing data exceeds RSI period length of %v at %s and will distort results. %w",
 				s.rsiPeriod,
 				t.Format(time.RFC3339),
 				base.ErrTooMuchBadData)
 		}
-		resp[i] = data[i].InexactFloat64()
+		stream.missingDataStreak = missingDataStreak
+		stream.previous = closePrice
+		stream.consumed++
+		if stream.rsi != nil {
+			stream.value = stream.rsi.Update(gctkline.Candle{Close: closePrice.InexactFloat64()})[0]
+		}
 	}
-	return resp, nil
+	return stream.value, nil
 }
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gct-ta/indicators"
 	"github.com/thrasher-corp/gocryptotrader/backtester/common"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data"
 	"github.com/thrasher-corp/gocryptotrader/backtester/data/kline"
@@ -234,3 +235,56 @@ func TestSetDefaults(t *testing.T) {
 		t.Error("expected 14")
 	}
 }
+
+func TestUpdateRSI(t *testing.T) {
+	t.Parallel()
+	s := Strategy{}
+	s.SetDefaults()
+	dStart := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	closes := make([]float64, 60)
+	events := make([]data.Event, len(closes))
+	for i := range closes {
+		closes[i] = 1337 + float64(i%7)*float64(i%3) - float64(i%5)
+		if i == 30 {
+			closes[i] = 0
+		}
+		events[i] = &eventkline.Kline{
+			Base: &event.Base{
+				Exchange:     "binance",
+				Time:         dStart.Add(gctkline.OneDay.Duration() * time.Duration(i)),
+				Interval:     gctkline.OneDay,
+				CurrencyPair: p,
+				AssetType:    asset.Spot,
+			},
+			Close: decimal.NewFromFloat(closes[i]),
+		}
+	}
+	d := &data.Base{}
+	err := d.SetStream(events)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v, expected: %v", err, nil)
+	}
+	da := &kline.DataFromKline{Base: d}
+	closes[30] = closes[29]
+	expected := indicators.RSI(closes, 14)
+	for i := range closes {
+		var latest data.Event
+		latest, err = d.Next()
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: %v, expected: %v", err, nil)
+		}
+		if i < 5 {
+			// feed the first candles in bulk to cover catching up
+			continue
+		}
+		var rsi float64
+		rsi, err = s.updateRSI(da, latest, latest.GetTime())
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: %v, expected: %v", err, nil)
+		}
+		if rsi != expected[i] {
+			t.Fatalf("candle %v received: %v, expected: %v", i, rsi, expected[i])
+		}
+	}
+}
@@ -2,6 +2,7 @@ package funding
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sort"
 	"strings"
@@ -176,21 +177,35 @@ func (f *FundManager) AddUSDTrackingData(k *kline.DataFromKline) error {
 		if strings.EqualFold(f.items[i].exchange, k.Item.Exchange) &&
 			f.items[i].asset == k.Item.Asset {
 			if f.items[i].currency.Equal(k.Item.Pair.Base) {
-				if trackingcurrencies.CurrencyIsUSDTracked(k.Item.Pair.Quote) {
-					f.items[i].trackingCandles = k
+				usdQuote := trackingcurrencies.CurrencyIsUSDTracked(k.Item.Pair.Quote)
+				fxQuote := trackingcurrencies.CurrencyIsFXTracked(k.Item.Pair.Quote)
+				if usdQuote || fxQuote {
+					if usdQuote {
+						f.items[i].trackingCandles = k
+					} else if err := f.setFXConvertedCandles(k, f.items[i]); err != nil {
+						if !errors.Is(err, errNoUSDConversionRate) {
+							return err
+						}
+						log.Warnf(common.FundManager, "%v %v %v %v, USD tracking relies on a USD pair being present", f.items[i].exchange, f.items[i].asset, f.items[i].currency, err)
+					}
 					if f.items[i].pairedWith != nil {
 						basePairedWith = f.items[i].pairedWith.currency
 					}
 				}
 				baseSet = true
 			}
-			if trackingcurrencies.CurrencyIsUSDTracked(f.items[i].currency) {
+			if trackingcurrencies.CurrencyIsUSDTracked(f.items[i].currency) ||
+				trackingcurrencies.CurrencyIsFXTracked(f.items[i].currency) {
 				if f.items[i].pairedWith != nil && !f.items[i].currency.Equal(basePairedWith) {
 					continue
 				}
 				err := f.setUSDCandles(k, f.items[i])
 				if err != nil {
-					return err
+					if !errors.Is(err, errNoUSDConversionRate) {
+						return err
+					}
+					log.Warnf(common.FundManager, "%v %v %v %v, USD tracking relies on a USD pair being present", f.items[i].exchange, f.items[i].asset, f.items[i].currency, err)
+					continue
 				}
 				quoteSet = true
 			}
@@ -205,7 +220,9 @@ func (f *FundManager) AddUSDTrackingData(k *kline.DataFromKline) error {
 // setUSDCandles sets usd tracking candles
 // usd stablecoins do not always match in value,
 // this is a simplified implementation that can allow
-// USD tracking for many currencies across many exchanges
+// USD tracking for many currencies across many exchanges.
+// Fiat currencies other than USD are priced using the
+// historical foreign exchange rate at each candle's time
 func (f *FundManager) setUSDCandles(k *kline.DataFromKline, i *Item) error {
 	usdCandles := gctkline.Item{
 		Exchange: k.Item.Exchange,
@@ -214,13 +231,62 @@ func (f *FundManager) setUSDCandles(k *kline.DataFromKline, i *Item) error {
 		Interval: k.Item.Interval,
 		Candles:  make([]gctkline.Candle, len(k.Item.Candles)),
 	}
+	var rates []float64
+	if trackingcurrencies.CurrencyIsFXTracked(i.currency) {
+		var err error
+		rates, err = usdConversionRates(i.currency, k.Item.Candles)
+		if err != nil {
+			return err
+		}
+	}
 	for x := range usdCandles.Candles {
+		rate := 1.0
+		if rates != nil {
+			rate = rates[x]
+		}
 		usdCandles.Candles[x] = gctkline.Candle{
 			Time:  k.Item.Candles[x].Time,
-			Open:  1,
-			High:  1,
-			Low:   1,
-			Close: 1,
+			Open:  rate,
+			High:  rate,
+			Low:   rate,
+			Close: rate,
+		}
+	}
+	cpy := *k
+	cpy.Item = &usdCandles
+	cpy.Base = &data.Base{}
+	if err := cpy.Load(); err != nil {
+		return err
+	}
+	i.trackingCandles = &cpy
+	return nil
+}
+
+// setFXConvertedCandles sets usd tracking candles for a currency
+// quoted in a fiat currency other than USD by converting its
+// candles into USD using the historical foreign exchange rate
+// at each candle's time
+func (f *FundManager) setFXConvertedCandles(k *kline.DataFromKline, i *Item) error {
+	quote := k.Item.Pair.Quote
+	usdCandles := gctkline.Item{
+		Exchange: k.Item.Exchange,
+		Pair:     currency.Pair{Delimiter: k.Item.Pair.Delimiter, Base: i.currency, Quote: currency.USD},
+		Asset:    k.Item.Asset,
+		Interval: k.Item.Interval,
+		Candles:  make([]gctkline.Candle, len(k.Item.Candles)),
+	}
+	rates, err := usdConversionRates(quote, k.Item.Candles)
+	if err != nil {
+		return err
+	}
+	for x := range usdCandles.Candles {
+		usdCandles.Candles[x] = gctkline.Candle{
+			Time:   k.Item.Candles[x].Time,
+			Open:   k.Item.Candles[x].Open * rates[x],
+			High:   k.Item.Candles[x].High * rates[x],
+			Low:    k.Item.Candles[x].Low * rates[x],
+			Close:  k.Item.Candles[x].Close * rates[x],
+			Volume: k.Item.Candles[x].Volume,
 		}
 	}
 	cpy := *k
@@ -233,6 +299,51 @@ func (f *FundManager) setUSDCandles(k *kline.DataFromKline, i *Item) error {
 	return nil
 }
 
+// usdConversionRates returns the rate to convert a fiat currency into USD
+// at each candle's time. Stored historical rates are preferred, when there
+// is no historical rate for a candle the current foreign exchange rate is
+// used instead
+func usdConversionRates(c currency.Code, candles []gctkline.Candle) ([]float64, error) {
+	if err := loadHistoricalRates(c, candles); err != nil {
+		return nil, err
+	}
+	rates := make([]float64, len(candles))
+	var current float64
+	for x := range candles {
+		rate, err := currency.ConvertAt(c, currency.USD, candles[x].Time)
+		if err == nil {
+			rates[x] = rate
+			continue
+		}
+		if !errors.Is(err, currency.ErrHistoricalRateNotFound) {
+			return nil, err
+		}
+		if current == 0 {
+			current, err = currency.ConvertFiat(1, c, currency.USD)
+			if err != nil {
+				return nil, fmt.Errorf("%w %v at %v, no historical rate and current rate unavailable: %v", errNoUSDConversionRate, c, candles[x].Time, err)
+			}
+			log.Warnf(common.FundManager, "No historical %v rate at %v, using the current rate of %v USD", c, candles[x].Time, current)
+		}
+		rates[x] = current
+	}
+	return rates, nil
+}
+
+// loadHistoricalRates retrieves the historical rates to convert a fiat
+// currency to USD over the candle range in one request, rather than
+// one day at a time, when historical rates are stored in a database
+func loadHistoricalRates(c currency.Code, candles []gctkline.Candle) error {
+	if len(candles) == 0 {
+		return nil
+	}
+	err := currency.LoadHistoricalRates(c, currency.USD, candles[0].Time, candles[len(candles)-1].Time)
+	if err != nil && !errors.Is(err, currency.ErrNoHistoricalRateLoader) {
+		return err
+	}
+	return nil
+}
+
 // CreatePair adds two funding items and associates them with one another
 // the association allows for the same currency to be used multiple times when
 // usingExchangeLevelFunding is false. eg BTC-USDT and LTC-USDT do not share the same
@@ -578,6 +578,153 @@ func TestAddUSDTrackingData(t *testing.T) {
 	}
 }
 
+func TestAddUSDTrackingDataFX(t *testing.T) {
+	t.Parallel()
+	f := FundManager{}
+	baseItem, err := CreateItem(exchName, a, currency.BTC, decimal.NewFromInt(1), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	quoteItem, err := CreateItem(exchName, a, currency.EUR, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	_, err = CreatePair(baseItem, quoteItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(baseItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(quoteItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	day := time.Date(2020, 2, 3, 0, 0, 0, 0, time.UTC)
+	dfk := &kline.DataFromKline{
+		Base: &data.Base{},
+		Item: &gctkline.Item{
+			Exchange: exchName,
+			Pair:     currency.NewPair(currency.BTC, currency.EUR),
+			Asset:    a,
+			Interval: gctkline.OneDay,
+			Candles: []gctkline.Candle{
+				{Time: day, Open: 8000, High: 8200, Low: 7900, Close: 8100, Volume: 1},
+				{Time: day.AddDate(0, 0, 1), Open: 8100, High: 8300, Low: 8000, Close: 8200, Volume: 1},
+			},
+		},
+	}
+	err = dfk.Load()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	// without a historical or current rate the items are left for USD pairs
+	// to track
+	err = f.AddUSDTrackingData(dfk)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if baseItem.trackingCandles != nil || quoteItem.trackingCandles != nil {
+		t.Error("expected tracking candles to be unset")
+	}
+
+	err = currency.AddHistoricalRates(
+		currency.HistoricalRate{From: currency.EUR, To: currency.USD, Date: day, Rate: 1.1},
+		currency.HistoricalRate{From: currency.EUR, To: currency.USD, Date: day.AddDate(0, 0, 1), Rate: 1.2},
+	)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddUSDTrackingData(dfk)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if baseItem.trackingCandles == nil || quoteItem.trackingCandles == nil {
+		t.Fatal("expected tracking candles to be set")
+	}
+	if c := baseItem.trackingCandles.Item.Candles[1].Close; c != 8200*1.2 {
+		t.Errorf("received '%v' expected '%v'", c, 8200*1.2)
+	}
+	if c := quoteItem.trackingCandles.Item.Candles[0].Close; c != 1.1 {
+		t.Errorf("received '%v' expected '%v'", c, 1.1)
+	}
+	if !quoteItem.trackingCandles.Item.Pair.Quote.Equal(currency.USD) {
+		t.Errorf("received '%v' expected '%v'", quoteItem.trackingCandles.Item.Pair.Quote, currency.USD)
+	}
+}
+
+func TestAddUSDTrackingDataFXWithoutLoader(t *testing.T) {
+	t.Parallel()
+	f := FundManager{}
+	baseItem, err := CreateItem(exchName, a, currency.BTC, decimal.NewFromInt(1), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	quoteItem, err := CreateItem(exchName, a, currency.AUD, decimal.NewFromInt(1000), decimal.Zero)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	_, err = CreatePair(baseItem, quoteItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(baseItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddItem(quoteItem)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+
+	day := time.Date(2020, 3, 3, 0, 0, 0, 0, time.UTC)
+	load := func(pair currency.Pair, price float64) *kline.DataFromKline {
+		t.Helper()
+		dfk := &kline.DataFromKline{
+			Base: &data.Base{},
+			Item: &gctkline.Item{
+				Exchange: exchName,
+				Pair:     pair,
+				Asset:    a,
+				Interval: gctkline.OneDay,
+				Candles: []gctkline.Candle{
+					{Time: day, Open: price, High: price, Low: price, Close: price, Volume: 1},
+				},
+			},
+		}
+		if err = dfk.Load(); !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		return dfk
+	}
+
+	// no historical rate loader is set and there is no current rate, the
+	// backtest continues using the USD pairs
+	err = f.AddUSDTrackingData(load(currency.NewPair(currency.BTC, currency.AUD), 12000))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddUSDTrackingData(load(currency.NewPair(currency.BTC, currency.USDT), 8000))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	err = f.AddUSDTrackingData(load(currency.NewPair(currency.AUD, currency.USDT), 0.66))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if baseItem.trackingCandles == nil || quoteItem.trackingCandles == nil {
+		t.Fatal("expected tracking candles to be set")
+	}
+	if c := baseItem.trackingCandles.Item.Candles[0].Close; c != 8000 {
+		t.Errorf("received '%v' expected '%v'", c, 8000)
+	}
+	if c := quoteItem.trackingCandles.Item.Candles[0].Close; c != 0.66 {
+		t.Errorf("received '%v' expected '%v'", c, 0.66)
+	}
+}
+
 func TestUSDTrackingDisabled(t *testing.T) {
 	t.Parallel()
 	f := FundManager{}
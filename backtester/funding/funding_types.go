@@ -31,6 +31,7 @@ var (
 	errCannotMatchTrackingToItem  = errors.New("cannot match tracking data to funding items")
 	errNotFutures                 = errors.New("item linking collateral currencies must be a futures asset")
 	errExchangeManagerRequired    = errors.New("exchange manager required")
+	errNoUSDConversionRate        = errors.New("no rate to convert to USD")
 )
 
 // IFundingManager limits funding usage for portfolio event handling
@@ -79,6 +79,12 @@ func CreateUSDTrackingPairs(tp []TrackingPair, em *engine.ExchangeManager) ([]Tr
 			pairs := b.CurrencyPairs.Pairs[a]
 			basePair, quotePair, err := findMatchingUSDPairs(pair, pairs)
 			if err != nil {
+				if CurrencyIsFXTracked(tp[i].Quote) {
+					// without USD pairs the fiat quote is converted to
+					// USD using foreign exchange rates
+					resp = append(resp, tp[i])
+					continue
+				}
 				return nil, err
 			}
 			resp = append(resp,
@@ -112,6 +118,13 @@ func CurrencyIsUSDTracked(code currency.Code) bool {
 	return false
 }
 
+// CurrencyIsFXTracked checks if the currency passed in is a fiat
+// currency without a USD equivalent, whose USD value is tracked
+// using historical foreign exchange rates
+func CurrencyIsFXTracked(code currency.Code) bool {
+	return code.IsFiatCurrency() && !CurrencyIsUSDTracked(code)
+}
+
 // pairContainsUSD is a simple check to ensure that the currency pair
 // has some sort of matching USD currency
 func pairContainsUSD(pair currency.Pair) bool {
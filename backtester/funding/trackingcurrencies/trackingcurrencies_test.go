@@ -82,6 +82,27 @@ func TestCreateUSDTrackingPairs(t *testing.T) {
 	if len(resp) != 3 {
 		t.Error("expected 3 currency settings as it did not contain a USDT equiv")
 	}
+
+	s1.Base = currency.BTC
+	s1.Quote = currency.AUD
+	resp, err = CreateUSDTrackingPairs([]TrackingPair{s1}, em)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp) != 1 {
+		t.Error("expected 1 currency setting as the fiat quote is converted using foreign exchange rates")
+	}
+	cp4 := currency.NewPair(currency.BTC, currency.AUD)
+	cp5 := currency.NewPair(currency.AUD, currency.USDT)
+	eba.Available = eba.Available.Add(cp4)
+	eba.Available = eba.Available.Add(cp5)
+	resp, err = CreateUSDTrackingPairs([]TrackingPair{s1}, em)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if len(resp) != 3 {
+		t.Error("expected 3 currency settings as USD pairs are available for the fiat quote")
+	}
 }
 
 func TestFindMatchingUSDPairs(t *testing.T) {
@@ -205,6 +226,11 @@ func TestPairContainsUSD(t *testing.T) {
 			false,
 			currency.NewPair(currency.BTC, currency.AUD),
 		},
+		{
+			"audbtc",
+			false,
+			currency.NewPair(currency.AUD, currency.BTC),
+		},
 		{
 			"btcusdc",
 			true,
@@ -237,3 +263,19 @@ func TestPairContainsUSD(t *testing.T) {
 		})
 	}
 }
+
+func TestCurrencyIsFXTracked(t *testing.T) {
+	t.Parallel()
+	if !CurrencyIsFXTracked(currency.EUR) {
+		t.Errorf("received '%v' expected '%v'", false, true)
+	}
+	if CurrencyIsFXTracked(currency.USD) {
+		t.Errorf("received '%v' expected '%v'", true, false)
+	}
+	if CurrencyIsFXTracked(currency.USDT) {
+		t.Errorf("received '%v' expected '%v'", true, false)
+	}
+	if CurrencyIsFXTracked(currency.BTC) {
+		t.Errorf("received '%v' expected '%v'", true, false)
+	}
+}
@@ -0,0 +1,296 @@
+package main
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+const (
+	archiveFormat  = "gocryptotrader-database-archive"
+	archiveVersion = 1
+	// maxArchiveLine bounds the size of a single encoded record
+	maxArchiveLine = 64 * 1024 * 1024
+)
+
+var (
+	errInvalidArchive   = errors.New("invalid database archive")
+	errTruncatedArchive = errors.New("database archive is truncated")
+	errArchiveCounts    = errors.New("database archive record counts do not match")
+)
+
+// archiveHeader is the first line of an archive and describes its contents
+type archiveHeader struct {
+	Format        string    `json:"format"`
+	Version       int       `json:"version"`
+	Created       time.Time `json:"created"`
+	Source        string    `json:"source"`
+	SchemaVersion int64     `json:"schemaVersion"`
+	Tables        []string  `json:"tables"`
+}
+
+// archiveEntry is a single line of an archive. Every line after the header
+// holds one record, apart from the final line which holds the number of
+// records written for each table
+type archiveEntry struct {
+	Table  string          `json:"table,omitempty"`
+	Record json.RawMessage `json:"record,omitempty"`
+	Counts map[string]int  `json:"counts,omitempty"`
+}
+
+// exportArchive writes every table of the source database to a gzip
+// compressed archive of JSON lines. All tables are read in a single
+// transaction so the archive is a consistent snapshot. The archive is written
+// to a temporary file and only moved into place once complete
+func exportArchive(ctx context.Context, source *endpoint, path string, batchSize int, verbose bool) (map[string]int, error) {
+	if source == nil {
+		return nil, errNilEndpoint
+	}
+	if batchSize <= 0 {
+		return nil, errInvalidBatchSize
+	}
+	version, err := source.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	tmp := path + ".tmp"
+	f, err := os.Create(tmp)
+	if err != nil {
+		return nil, err
+	}
+	counts, err := writeArchive(ctx, source, f, version, batchSize, verbose)
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, common.AppendError(err, os.Remove(tmp))
+	}
+	return counts, os.Rename(tmp, path)
+}
+
+func writeArchive(ctx context.Context, source *endpoint, w io.Writer, schemaVersion int64, batchSize int, verbose bool) (map[string]int, error) {
+	gz := gzip.NewWriter(w)
+	enc := json.NewEncoder(gz)
+	header := archiveHeader{
+		Format:        archiveFormat,
+		Version:       archiveVersion,
+		Created:       time.Now().UTC(),
+		Source:        source.name,
+		SchemaVersion: schemaVersion,
+		Tables:        make([]string, len(tables)),
+	}
+	for i := range tables {
+		header.Tables[i] = tables[i].name
+	}
+	if err := enc.Encode(&header); err != nil {
+		return nil, err
+	}
+
+	tx, err := source.db.BeginTx(ctx, source.readTxOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = tx.Rollback()
+	}()
+
+	counts := make(map[string]int, len(tables))
+	for i := range tables {
+		t := &tables[i]
+		counts[t.name] = 0
+		for {
+			records, err := t.read(ctx, source, tx, counts[t.name], batchSize)
+			if err != nil {
+				return nil, fmt.Errorf("%s: %w", t.name, err)
+			}
+			if len(records) == 0 {
+				break
+			}
+			for j := range records {
+				data, err := json.Marshal(records[j])
+				if err != nil {
+					return nil, fmt.Errorf("%s: %w", t.name, err)
+				}
+				if err = enc.Encode(&archiveEntry{Table: t.name, Record: data}); err != nil {
+					return nil, err
+				}
+			}
+			counts[t.name] += len(records)
+			if verbose {
+				fmt.Printf("%s: %d rows exported\n", t.name, counts[t.name])
+			}
+		}
+	}
+	if err = enc.Encode(&archiveEntry{Counts: counts}); err != nil {
+		return nil, err
+	}
+	return counts, gz.Close()
+}
+
+// importArchive restores an archive into the destination database. Records
+// are written in batches, each in its own transaction, with progress stored
+// in the checkpoint so an interrupted import can be resumed
+func importArchive(ctx context.Context, destination *endpoint, path string, cp *checkpoint, batchSize int, verbose bool) ([]copyResult, error) {
+	if destination == nil {
+		return nil, errNilEndpoint
+	}
+	if batchSize <= 0 {
+		return nil, errInvalidBatchSize
+	}
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidArchive, err)
+	}
+	defer gz.Close()
+
+	scanner := bufio.NewScanner(gz)
+	scanner.Buffer(make([]byte, 0, 64*1024), maxArchiveLine)
+	if !scanner.Scan() {
+		return nil, common.AppendError(errInvalidArchive, scanner.Err())
+	}
+	var header archiveHeader
+	if err = json.Unmarshal(scanner.Bytes(), &header); err != nil {
+		return nil, fmt.Errorf("%w: %v", errInvalidArchive, err)
+	}
+	if header.Format != archiveFormat || header.Version != archiveVersion {
+		return nil, fmt.Errorf("%w: unsupported format %q version %d", errInvalidArchive, header.Format, header.Version)
+	}
+	version, err := destination.schemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if version != header.SchemaVersion {
+		return nil, fmt.Errorf("%w: %d != %d", errSchemaMismatch, header.SchemaVersion, version)
+	}
+
+	imp := &archiveImporter{
+		destination: destination,
+		checkpoint:  cp,
+		batchSize:   batchSize,
+		verbose:     verbose,
+		seen:        make(map[string]int),
+		results:     make(map[string]*copyResult),
+	}
+	var trailer map[string]int
+	for scanner.Scan() {
+		var entry archiveEntry
+		if err = json.Unmarshal(scanner.Bytes(), &entry); err != nil {
+			return imp.summary(), fmt.Errorf("%w: %v", errInvalidArchive, err)
+		}
+		if entry.Counts != nil {
+			trailer = entry.Counts
+			break
+		}
+		if err = imp.add(ctx, &entry); err != nil {
+			return imp.summary(), err
+		}
+	}
+	if err = scanner.Err(); err != nil {
+		return imp.summary(), fmt.Errorf("%w: %v", errTruncatedArchive, err)
+	}
+	if trailer == nil {
+		return imp.summary(), errTruncatedArchive
+	}
+	if err = imp.flush(ctx); err != nil {
+		return imp.summary(), err
+	}
+	for name, count := range trailer {
+		if imp.seen[name] != count {
+			return imp.summary(), fmt.Errorf("%w: %s expected %d received %d", errArchiveCounts, name, count, imp.seen[name])
+		}
+	}
+	for i := range tables {
+		if err = tables[i].finalise(ctx, destination, destination.db); err != nil {
+			return imp.summary(), fmt.Errorf("%s: %w", tables[i].name, err)
+		}
+	}
+	return imp.summary(), cp.remove()
+}
+
+// archiveImporter batches archive records by table before writing them
+type archiveImporter struct {
+	destination *endpoint
+	checkpoint  *checkpoint
+	batchSize   int
+	verbose     bool
+	table       *table
+	pending     []record
+	seen        map[string]int
+	results     map[string]*copyResult
+}
+
+func (a *archiveImporter) add(ctx context.Context, entry *archiveEntry) error {
+	t, err := getTable(entry.Table)
+	if err != nil {
+		return fmt.Errorf("%w: %v", errInvalidArchive, err)
+	}
+	if a.table != nil && a.table.name != t.name {
+		if err = a.flush(ctx); err != nil {
+			return err
+		}
+	}
+	a.table = t
+	a.seen[t.name]++
+	if a.seen[t.name] <= a.checkpoint.Offsets[t.name] {
+		// already imported before the previous run was interrupted
+		return nil
+	}
+	r := t.newRecord()
+	if err = json.Unmarshal(entry.Record, r); err != nil {
+		return fmt.Errorf("%w: %s: %v", errInvalidArchive, t.name, err)
+	}
+	a.pending = append(a.pending, r)
+	if len(a.pending) >= a.batchSize {
+		return a.flush(ctx)
+	}
+	return nil
+}
+
+func (a *archiveImporter) flush(ctx context.Context) error {
+	if len(a.pending) == 0 {
+		return nil
+	}
+	name := a.table.name
+	created, err := writeBatch(ctx, a.destination, a.pending)
+	if err != nil {
+		return fmt.Errorf("%s: %w", name, err)
+	}
+	result, ok := a.results[name]
+	if !ok {
+		result = &copyResult{Table: name}
+		a.results[name] = result
+	}
+	result.Read += len(a.pending)
+	result.Created += created
+	a.checkpoint.Offsets[name] += len(a.pending)
+	a.pending = a.pending[:0]
+	if a.verbose {
+		fmt.Printf("%s: %d rows imported\n", name, a.checkpoint.Offsets[name])
+	}
+	return a.checkpoint.save()
+}
+
+func (a *archiveImporter) summary() []copyResult {
+	resp := make([]copyResult, 0, len(tables))
+	for i := range tables {
+		if result, ok := a.results[tables[i].name]; ok {
+			resp = append(resp, *result)
+			continue
+		}
+		resp = append(resp, copyResult{Table: tables[i].name})
+	}
+	return resp
+}
@@ -0,0 +1,196 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/urfave/cli/v2"
+)
+
+const defaultCheckpointFile = "dbcopy.checkpoint"
+
+var copyCommand = &cli.Command{
+	Name:  "copy",
+	Usage: "copy every table from one database to another",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:      "source",
+			Usage:     "config file describing the database to copy from",
+			TakesFile: true,
+			Required:  true,
+		},
+		&cli.StringFlag{
+			Name:      "destination",
+			Usage:     "config file describing the database to copy to",
+			TakesFile: true,
+			Required:  true,
+		},
+		&cli.StringFlag{
+			Name:      "checkpoint",
+			Usage:     "file used to resume an interrupted copy",
+			Value:     defaultCheckpointFile,
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  "migrate",
+			Usage: "apply outstanding migrations to the destination before copying",
+			Value: true,
+		},
+	},
+	Action: copyDatabase,
+}
+
+var exportCommand = &cli.Command{
+	Name:  "export",
+	Usage: "export every table to a portable archive",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:      "config",
+			Usage:     "config file describing the database to export",
+			TakesFile: true,
+			Required:  true,
+		},
+		&cli.StringFlag{
+			Name:      "output",
+			Usage:     "archive file to create",
+			TakesFile: true,
+			Required:  true,
+		},
+	},
+	Action: exportDatabase,
+}
+
+var importCommand = &cli.Command{
+	Name:  "import",
+	Usage: "import a portable archive into a database",
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:      "config",
+			Usage:     "config file describing the database to import into",
+			TakesFile: true,
+			Required:  true,
+		},
+		&cli.StringFlag{
+			Name:      "input",
+			Usage:     "archive file to import",
+			TakesFile: true,
+			Required:  true,
+		},
+		&cli.StringFlag{
+			Name:      "checkpoint",
+			Usage:     "file used to resume an interrupted import, defaults to the input file with a .checkpoint suffix",
+			TakesFile: true,
+		},
+		&cli.BoolFlag{
+			Name:  "migrate",
+			Usage: "apply outstanding migrations to the database before importing",
+			Value: true,
+		},
+	},
+	Action: importDatabase,
+}
+
+func copyDatabase(c *cli.Context) error {
+	source, err := openEndpoint(c.String("source"))
+	if err != nil {
+		return fmt.Errorf("source: %w", err)
+	}
+	defer source.close()
+	destination, err := openEndpoint(c.String("destination"))
+	if err != nil {
+		return fmt.Errorf("destination: %w", err)
+	}
+	defer destination.close()
+	if c.Bool("migrate") {
+		if err = destination.migrate(migrationDir); err != nil {
+			return err
+		}
+	}
+
+	cp, err := loadCheckpoint(c.String("checkpoint"), source.name, destination.name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Copying %s to %s\n", source.name, destination.name)
+	cpy := &copier{
+		source:      source,
+		destination: destination,
+		batchSize:   batchSize,
+		verbose:     verbose,
+	}
+	results, err := cpy.run(c.Context, cp)
+	printResults(results)
+	if err != nil {
+		return err
+	}
+	fmt.Println("command completed successfully")
+	return nil
+}
+
+func exportDatabase(c *cli.Context) error {
+	source, err := openEndpoint(c.String("config"))
+	if err != nil {
+		return err
+	}
+	defer source.close()
+
+	fmt.Printf("Exporting %s to %s\n", source.name, c.String("output"))
+	counts, err := exportArchive(c.Context, source, c.String("output"), batchSize, verbose)
+	if err != nil {
+		return err
+	}
+	results := make([]copyResult, len(tables))
+	for i := range tables {
+		results[i] = copyResult{
+			Table: tables[i].name,
+			Read:  counts[tables[i].name],
+		}
+	}
+	printResults(results)
+	fmt.Println("command completed successfully")
+	return nil
+}
+
+func importDatabase(c *cli.Context) error {
+	destination, err := openEndpoint(c.String("config"))
+	if err != nil {
+		return err
+	}
+	defer destination.close()
+	if c.Bool("migrate") {
+		if err = destination.migrate(migrationDir); err != nil {
+			return err
+		}
+	}
+
+	input := c.String("input")
+	checkpointFile := c.String("checkpoint")
+	if checkpointFile == "" {
+		checkpointFile = input + ".checkpoint"
+	}
+	cp, err := loadCheckpoint(checkpointFile, input, destination.name)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("Importing %s into %s\n", input, destination.name)
+	results, err := importArchive(c.Context, destination, input, cp, batchSize, verbose)
+	printResults(results)
+	if err != nil {
+		return err
+	}
+	fmt.Println("command completed successfully")
+	return nil
+}
+
+func printResults(results []copyResult) {
+	if len(results) == 0 {
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "TABLE\tREAD\tCREATED")
+	for i := range results {
+		fmt.Fprintf(w, "%s\t%d\t%d\n", results[i].Table, results[i].Read, results[i].Created)
+	}
+	_ = w.Flush()
+}
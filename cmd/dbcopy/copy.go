@@ -0,0 +1,235 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/goose"
+)
+
+const defaultBatchSize = 1000
+
+var (
+	errCheckpointMismatch = errors.New("checkpoint was created for a different source or destination")
+	errSchemaMismatch     = errors.New("schema versions differ, run dbmigrate to bring both databases up to date")
+	errInvalidBatchSize   = errors.New("batch size must be greater than zero")
+	errNilEndpoint        = errors.New("source and destination must be set")
+)
+
+// checkpoint records how far a copy or import has progressed so an
+// interrupted run can continue where it stopped
+type checkpoint struct {
+	Source      string          `json:"source"`
+	Destination string          `json:"destination"`
+	Offsets     map[string]int  `json:"offsets"`
+	Completed   map[string]bool `json:"completed"`
+	path        string
+}
+
+// loadCheckpoint reads an existing checkpoint from path or creates a new one
+// when none exists. An empty path disables checkpointing
+func loadCheckpoint(path, source, destination string) (*checkpoint, error) {
+	cp := &checkpoint{
+		Source:      source,
+		Destination: destination,
+		Offsets:     make(map[string]int),
+		Completed:   make(map[string]bool),
+		path:        path,
+	}
+	if path == "" {
+		return cp, nil
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if errors.Is(err, os.ErrNotExist) {
+			return cp, nil
+		}
+		return nil, err
+	}
+	var stored checkpoint
+	if err = json.Unmarshal(data, &stored); err != nil {
+		return nil, err
+	}
+	if stored.Source != source || stored.Destination != destination {
+		return nil, fmt.Errorf("%w: %s -> %s", errCheckpointMismatch, stored.Source, stored.Destination)
+	}
+	if stored.Offsets != nil {
+		cp.Offsets = stored.Offsets
+	}
+	if stored.Completed != nil {
+		cp.Completed = stored.Completed
+	}
+	return cp, nil
+}
+
+// save writes the checkpoint to a temporary file before moving it into place
+// so an interruption never leaves a partially written checkpoint
+func (c *checkpoint) save() error {
+	if c.path == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(c, "", " ")
+	if err != nil {
+		return err
+	}
+	tmp := c.path + ".tmp"
+	if err = os.WriteFile(tmp, data, 0o600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, c.path)
+}
+
+// remove deletes the checkpoint once a run has completed
+func (c *checkpoint) remove() error {
+	if c.path == "" {
+		return nil
+	}
+	err := os.Remove(c.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// dialect returns the goose dialect for the endpoint
+func (e *endpoint) dialect() string {
+	if e.isSQLite() {
+		return database.DBSQLite3
+	}
+	return database.DBPostgreSQL
+}
+
+// migrate brings the endpoint schema up to date
+func (e *endpoint) migrate(migrationDir string) error {
+	return goose.Run("up", e.db, e.dialect(), migrationDir, "")
+}
+
+// schemaVersion returns the migration version the endpoint is at
+func (e *endpoint) schemaVersion() (int64, error) {
+	if err := goose.SetDialect(e.dialect()); err != nil {
+		return 0, err
+	}
+	return goose.GetDBVersion(e.db)
+}
+
+// readTxOptions returns options for a read only transaction giving a
+// consistent view of the endpoint for the duration of a copy or export
+func (e *endpoint) readTxOptions() *sql.TxOptions {
+	if e.isSQLite() {
+		return nil
+	}
+	return &sql.TxOptions{Isolation: sql.LevelRepeatableRead, ReadOnly: true}
+}
+
+// copier streams every table from one database to another
+type copier struct {
+	source      *endpoint
+	destination *endpoint
+	batchSize   int
+	verbose     bool
+}
+
+// copyResult holds the number of rows read and created for each table
+type copyResult struct {
+	Table   string
+	Read    int
+	Created int
+}
+
+// run copies every table in dependency order, committing each batch in its
+// own transaction and recording progress in the checkpoint after each commit
+func (c *copier) run(ctx context.Context, cp *checkpoint) ([]copyResult, error) {
+	if c.source == nil || c.destination == nil {
+		return nil, errNilEndpoint
+	}
+	if c.batchSize <= 0 {
+		return nil, errInvalidBatchSize
+	}
+	srcVersion, err := c.source.schemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("source: %w", err)
+	}
+	dstVersion, err := c.destination.schemaVersion()
+	if err != nil {
+		return nil, fmt.Errorf("destination: %w", err)
+	}
+	if srcVersion != dstVersion {
+		return nil, fmt.Errorf("%w: %d != %d", errSchemaMismatch, srcVersion, dstVersion)
+	}
+
+	srcTx, err := c.source.db.BeginTx(ctx, c.source.readTxOptions())
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = srcTx.Rollback()
+	}()
+
+	results := make([]copyResult, 0, len(tables))
+	for i := range tables {
+		t := &tables[i]
+		result := copyResult{Table: t.name}
+		if cp.Completed[t.name] {
+			results = append(results, result)
+			continue
+		}
+		for {
+			offset := cp.Offsets[t.name]
+			records, err := t.read(ctx, c.source, srcTx, offset, c.batchSize)
+			if err != nil {
+				return results, fmt.Errorf("%s: %w", t.name, err)
+			}
+			if len(records) == 0 {
+				break
+			}
+			created, err := writeBatch(ctx, c.destination, records)
+			if err != nil {
+				return results, fmt.Errorf("%s: %w", t.name, err)
+			}
+			result.Read += len(records)
+			result.Created += created
+			cp.Offsets[t.name] = offset + len(records)
+			if err = cp.save(); err != nil {
+				return results, err
+			}
+			if c.verbose {
+				fmt.Printf("%s: %d rows processed\n", t.name, cp.Offsets[t.name])
+			}
+		}
+		if err = t.finalise(ctx, c.destination, c.destination.db); err != nil {
+			return results, fmt.Errorf("%s: %w", t.name, err)
+		}
+		cp.Completed[t.name] = true
+		if err = cp.save(); err != nil {
+			return results, err
+		}
+		results = append(results, result)
+	}
+	return results, cp.remove()
+}
+
+// writeBatch inserts records into the destination in a single transaction
+// and returns how many rows were created
+func writeBatch(ctx context.Context, e *endpoint, records []record) (int, error) {
+	tx, err := e.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	var created int
+	for i := range records {
+		inserted, err := writeRecord(ctx, e, tx, records[i])
+		if err != nil {
+			return 0, common.AppendError(err, tx.Rollback())
+		}
+		if inserted {
+			created++
+		}
+	}
+	return created, tx.Commit()
+}
@@ -0,0 +1,64 @@
+package main
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	dbPSQL "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
+	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+)
+
+// openEndpoint opens a standalone connection to the database described by a
+// GoCryptoTrader config file so that a source and destination can be open at
+// the same time
+func openEndpoint(configFile string) (*endpoint, error) {
+	var conf config.Config
+	err := conf.LoadConfig(configFile, true)
+	if err != nil {
+		return nil, err
+	}
+	if !conf.Database.Enabled {
+		return nil, fmt.Errorf("%s: %w", configFile, database.ErrDatabaseSupportDisabled)
+	}
+
+	e := &endpoint{driver: conf.Database.Driver}
+	if err = e.validate(); err != nil {
+		return nil, err
+	}
+	if e.isSQLite() {
+		location := filepath.Join(conf.GetDataPath("database"), conf.Database.Database)
+		e.name = e.driver + ":" + location
+		e.db, err = dbsqlite3.Open(location)
+		if err != nil {
+			return nil, err
+		}
+		e.db.SetMaxOpenConns(1)
+		return e, nil
+	}
+
+	e.name = fmt.Sprintf("%s:%s@%s:%d/%s",
+		e.driver,
+		conf.Database.Username,
+		conf.Database.Host,
+		conf.Database.Port,
+		conf.Database.Database)
+	e.db, err = dbPSQL.Open(&conf.Database)
+	if err != nil {
+		return nil, err
+	}
+	if err = e.db.Ping(); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+func (e *endpoint) close() {
+	if e == nil || e.db == nil {
+		return
+	}
+	if err := e.db.Close(); err != nil {
+		fmt.Printf("%s: %v\n", e.name, err)
+	}
+}
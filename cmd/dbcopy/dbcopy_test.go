@@ -0,0 +1,585 @@
+package main
+
+import (
+	"compress/gzip"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+	"github.com/volatiletech/null"
+)
+
+var testTime = time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+
+func newSQLiteEndpoint(t *testing.T, name string) *endpoint {
+	t.Helper()
+	location := filepath.Join(t.TempDir(), name)
+	db, err := dbsqlite3.Open(location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	db.SetMaxOpenConns(1)
+	e := &endpoint{driver: database.DBSQLite3, name: name, db: db}
+	t.Cleanup(e.close)
+	if err = e.migrate(database.MigrationDir); err != nil {
+		t.Fatal(err)
+	}
+	return e
+}
+
+func testRecords() []record {
+	return []record{
+		&exchangeRecord{ID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1", Name: "binance"},
+		&exchangeRecord{ID: "b9ee7c3d-2db5-4f2c-8f0b-1f8f4d6c5e22", Name: "bitstamp"},
+		&scriptRecord{
+			ID:             "c0f7b3aa-2fd3-4b40-9b5f-7e1c6d3a8f33",
+			ScriptID:       "1",
+			ScriptName:     "test.gct",
+			ScriptPath:     "scripts",
+			ScriptData:     []byte("fmt.println(1)"),
+			LastExecutedAt: testTime,
+			CreatedAt:      testTime,
+		},
+		&scriptExecutionRecord{
+			ID:              "1",
+			ScriptID:        "c0f7b3aa-2fd3-4b40-9b5f-7e1c6d3a8f33",
+			ExecutionType:   "executed",
+			ExecutionStatus: "success",
+			ExecutionTime:   testTime,
+		},
+		&auditEventRecord{ID: 7, Type: "gctscript", Identifier: "test", Message: "hello", CreatedAt: testTime},
+		&withdrawalHistoryRecord{
+			ID:             "d1e2f3a4-5b6c-4d7e-8f90-a1b2c3d4e5f6",
+			ExchangeNameID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			ExchangeID:     "withdraw-1",
+			Status:         "complete",
+			Currency:       "BTC",
+			Amount:         1.5,
+			Description:    null.StringFrom("test withdrawal"),
+			WithdrawType:   1,
+			CreatedAt:      testTime,
+			UpdatedAt:      testTime.Add(time.Hour),
+		},
+		&withdrawalCryptoRecord{
+			ID:                  3,
+			WithdrawalHistoryID: "d1e2f3a4-5b6c-4d7e-8f90-a1b2c3d4e5f6",
+			Address:             "1BvBMSEYstWetqTFn5Au4m4GFg7xJaNVN2",
+			AddressTag:          null.StringFrom("tag"),
+			Fee:                 0.001,
+		},
+		&withdrawalFiatRecord{
+			ID:                  4,
+			WithdrawalHistoryID: "d1e2f3a4-5b6c-4d7e-8f90-a1b2c3d4e5f6",
+			BankName:            "bank",
+			BankAddress:         "street",
+			BankAccountName:     "name",
+			BankAccountNumber:   "123",
+			BSB:                 "456",
+			SwiftCode:           "swift",
+			Iban:                "iban",
+			BankCode:            789,
+		},
+		&dataHistoryJobRecord{
+			ID:                     "e5d4c3b2-a190-4f8e-9d7c-6b5a4f3e2d11",
+			Nickname:               "prerequisite",
+			ExchangeNameID:         "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Asset:                  "spot",
+			Base:                   "BTC",
+			Quote:                  "USDT",
+			StartTime:              testTime,
+			EndTime:                testTime.Add(time.Hour),
+			Interval:               60,
+			DataType:               1,
+			RequestSize:            500,
+			MaxRetries:             3,
+			BatchCount:             1,
+			Status:                 2,
+			Created:                testTime,
+			OverwriteData:          null.BoolFrom(true),
+			DecimalPlaceComparison: null.Int64From(3),
+			ReplaceOnIssue:         null.BoolFrom(false),
+		},
+		&dataHistoryJobRecord{
+			ID:                       "f6e5d4c3-b2a1-4f9e-8d7c-5b4a3f2e1d22",
+			Nickname:                 "dependent",
+			ExchangeNameID:           "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Asset:                    "spot",
+			Base:                     "BTC",
+			Quote:                    "USDT",
+			StartTime:                testTime,
+			EndTime:                  testTime.Add(time.Hour),
+			Interval:                 60,
+			DataType:                 3,
+			RequestSize:              500,
+			MaxRetries:               3,
+			BatchCount:               1,
+			Status:                   0,
+			Created:                  testTime,
+			SecondaryExchangeID:      null.StringFrom("b9ee7c3d-2db5-4f2c-8f0b-1f8f4d6c5e22"),
+			IssueTolerancePercentage: null.Float64From(0.5),
+		},
+		&dataHistoryJobRelationRecord{
+			PrerequisiteJobID: "e5d4c3b2-a190-4f8e-9d7c-6b5a4f3e2d11",
+			JobID:             "f6e5d4c3-b2a1-4f9e-8d7c-5b4a3f2e1d22",
+		},
+		&dataHistoryJobResultRecord{
+			ID:                "a7b6c5d4-e3f2-4a1b-9c8d-7e6f5a4b3c22",
+			JobID:             "e5d4c3b2-a190-4f8e-9d7c-6b5a4f3e2d11",
+			Result:            null.StringFrom("ok"),
+			Status:            1,
+			IntervalStartTime: testTime,
+			IntervalEndTime:   testTime.Add(time.Minute),
+			RunTime:           testTime,
+		},
+		&candleRecord{
+			ID:             "b8c7d6e5-f4a3-4b2c-8d1e-0f9a8b7c6d33",
+			ExchangeNameID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Base:           "BTC",
+			Quote:          "USDT",
+			Interval:       60,
+			Timestamp:      testTime,
+			Open:           1,
+			High:           2,
+			Low:            0.5,
+			Close:          1.5,
+			Volume:         100,
+			Asset:          "spot",
+			SourceJobID:    null.StringFrom("e5d4c3b2-a190-4f8e-9d7c-6b5a4f3e2d11"),
+		},
+		&candleRecord{
+			ID:             "c9d8e7f6-a5b4-4c3d-9e2f-1a0b9c8d7e44",
+			ExchangeNameID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Base:           "BTC",
+			Quote:          "USDT",
+			Interval:       60,
+			Timestamp:      testTime.Add(time.Minute),
+			Open:           1.5,
+			High:           2.5,
+			Low:            1,
+			Close:          2,
+			Volume:         50,
+			Asset:          "spot",
+		},
+		&tradeRecord{
+			ID:             "d0e9f8a7-b6c5-4d4e-8f3a-2b1c0d9e8f55",
+			ExchangeNameID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Tid:            null.StringFrom("1337"),
+			Base:           "BTC",
+			Quote:          "USDT",
+			Asset:          "spot",
+			Price:          1.25,
+			Amount:         2,
+			Side:           null.StringFrom("BUY"),
+			Timestamp:      testTime,
+		},
+		&fundingRateRecord{
+			ID:             "e1f0a9b8-c7d6-4e5f-9a4b-3c2d1e0f9a66",
+			ExchangeNameID: "a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1",
+			Asset:          "usdtmarginedfutures",
+			Base:           "BTC",
+			Quote:          "USDT",
+			Rate:           0.0001,
+			Timestamp:      testTime,
+		},
+		&forexRateRecord{
+			ID:     "f2a1b0c9-d8e7-4f6a-8b5c-4d3e2f1a0b77",
+			Base:   "EUR",
+			Quote:  "USD",
+			Rate:   1.1,
+			Date:   testTime,
+			Source: "ECB",
+		},
+		&navSnapshotRecord{
+			ID:                "a3b2c1d0-e9f8-4a7b-9c6d-5e4f3a2b1c88",
+			Date:              testTime,
+			ReportingCurrency: "USD",
+			NAV:               10000,
+			RealisedPNL:       150,
+			UnrealisedPNL:     -25,
+			Fees:              3.5,
+			Funding:           -1.25,
+		},
+		&ledgerEntryRecord{
+			ID:           "b4c3d2e1-f0a9-4b8c-8d7e-6f5a4b3c2d99",
+			EntryKey:     "binance|order|1|0|BTC|trade",
+			ExchangeName: "binance",
+			SubAccount:   "main",
+			Asset:        "spot",
+			Kind:         "trade",
+			Reference:    "1",
+			Currency:     "BTC",
+			Amount:       decimal.RequireFromString("0.30000001"),
+			Occurred:     testTime.Add(time.Nanosecond),
+			Description:  "1337",
+		},
+		&accountingEventRecord{
+			ID:                "c5d4e3f2-a1b0-4c9d-9e8f-7a6b5c4d3e00",
+			ReportingCurrency: "USD",
+			Occurred:          testTime,
+			Kind:              "trade",
+			ExchangeName:      "binance",
+			Asset:             "spot",
+			Base:              "BTC",
+			Quote:             "USDT",
+			OrderID:           "1337",
+			Side:              "BUY",
+			Amount:            decimal.RequireFromString("0.5"),
+			Value:             decimal.RequireFromString("10000"),
+			RealisedPNL:       decimal.Zero,
+			Fees:              decimal.RequireFromString("1.25"),
+			Funding:           decimal.Zero,
+			Unmatched:         decimal.Zero,
+		},
+		&accountingLotRecord{
+			ID:                "d6e5f4a3-b2c1-4dae-8f90-8b7c6d5e4f11",
+			ReportingCurrency: "USD",
+			ExchangeName:      "binance",
+			Currency:          "BTC",
+			Reference:         "1337",
+			Acquired:          testTime,
+			Amount:            decimal.RequireFromString("0.5"),
+			Cost:              decimal.RequireFromString("10001.25"),
+		},
+		&accountingOrderRecord{
+			ID:       "e7f6a5b4-c3d2-4ebf-9a01-9c8d7e6f5a22",
+			OrderKey: "binance|spot|1337",
+			Executed: decimal.RequireFromString("0.5"),
+			Quote:    decimal.RequireFromString("10000"),
+			Fee:      decimal.RequireFromString("1.25"),
+			Finished: testTime,
+		},
+		&accountingPositionRecord{
+			ID:            "f8a7b6c5-d4e3-4fc0-8b12-ad9e8f7a6b33",
+			PositionKey:   "binance|usdtmarginedfutures|BTC-USDT",
+			ExchangeName:  "binance",
+			Asset:         "usdtmarginedfutures",
+			Base:          "BTC",
+			Quote:         "USDT",
+			Settlement:    "USDT",
+			RealisedGross: decimal.RequireFromString("12.5"),
+			Fees:          decimal.RequireFromString("0.75"),
+			Funding:       decimal.RequireFromString("-0.1"),
+		},
+	}
+}
+
+func TestTablesCoverMigrations(t *testing.T) {
+	t.Parallel()
+	e := newSQLiteEndpoint(t, "tables.db")
+	rows, err := e.db.Query("SELECT name FROM sqlite_master WHERE type = 'table' AND name NOT LIKE 'sqlite_%' AND name != 'goose_db_version'")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var name string
+		if err = rows.Scan(&name); !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if _, err = getTable(name); !errors.Is(err, nil) {
+			t.Errorf("migrated table %q is not copied, received '%v', expected '%v'", name, err, nil)
+		}
+	}
+	if err = rows.Err(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func seedEndpoint(t *testing.T, e *endpoint) {
+	t.Helper()
+	created, err := writeBatch(context.Background(), e, testRecords())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if created != len(testRecords()) {
+		t.Fatalf("received '%v', expected '%v'", created, len(testRecords()))
+	}
+}
+
+// readAll returns every record in the endpoint in table order
+func readAll(t *testing.T, e *endpoint) []record {
+	t.Helper()
+	var resp []record
+	for i := range tables {
+		records, err := tables[i].read(context.Background(), e, e.db, 0, 100)
+		if !errors.Is(err, nil) {
+			t.Fatalf("%s received '%v', expected '%v'", tables[i].name, err, nil)
+		}
+		resp = append(resp, records...)
+	}
+	return resp
+}
+
+func compareRecords(t *testing.T, received, expected []record) {
+	t.Helper()
+	if len(received) != len(expected) {
+		t.Fatalf("received '%v' records, expected '%v'", len(received), len(expected))
+	}
+	for i := range expected {
+		if !reflect.DeepEqual(received[i], expected[i]) {
+			t.Errorf("received '%+v', expected '%+v'", received[i], expected[i])
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	t.Parallel()
+	source := newSQLiteEndpoint(t, "source.db")
+	destination := newSQLiteEndpoint(t, "destination.db")
+	seedEndpoint(t, source)
+
+	c := &copier{source: source, destination: destination, batchSize: 1}
+	cpFile := filepath.Join(t.TempDir(), "copy.checkpoint")
+	cp, err := loadCheckpoint(cpFile, source.name, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	results, err := c.run(context.Background(), cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	var created int
+	for i := range results {
+		created += results[i].Created
+	}
+	if created != len(testRecords()) {
+		t.Errorf("received '%v', expected '%v'", created, len(testRecords()))
+	}
+	if _, err = os.Stat(cpFile); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received '%v', expected '%v'", err, os.ErrNotExist)
+	}
+	compareRecords(t, readAll(t, destination), readAll(t, source))
+
+	// a second run must not duplicate anything
+	cp, err = loadCheckpoint(cpFile, source.name, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	results, err = c.run(context.Background(), cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for i := range results {
+		if results[i].Created != 0 {
+			t.Errorf("%s received '%v', expected '%v'", results[i].Table, results[i].Created, 0)
+		}
+	}
+
+	_, err = (&copier{source: source, destination: destination}).run(context.Background(), cp)
+	if !errors.Is(err, errInvalidBatchSize) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidBatchSize)
+	}
+	_, err = (&copier{batchSize: 1}).run(context.Background(), cp)
+	if !errors.Is(err, errNilEndpoint) {
+		t.Errorf("received '%v', expected '%v'", err, errNilEndpoint)
+	}
+}
+
+func TestCopyResume(t *testing.T) {
+	t.Parallel()
+	source := newSQLiteEndpoint(t, "source.db")
+	destination := newSQLiteEndpoint(t, "destination.db")
+	seedEndpoint(t, source)
+
+	cpFile := filepath.Join(t.TempDir(), "copy.checkpoint")
+	cp, err := loadCheckpoint(cpFile, source.name, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// simulate an interruption after the exchanges and the first candle
+	for i := range tables {
+		if tables[i].name == "candle" {
+			break
+		}
+		cp.Completed[tables[i].name] = true
+	}
+	cp.Offsets["candle"] = 1
+	if err = cp.save(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = writeBatch(context.Background(), destination, testRecords()[:2])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	cp, err = loadCheckpoint(cpFile, source.name, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	results, err := (&copier{source: source, destination: destination, batchSize: 10}).run(context.Background(), cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for i := range results {
+		var expected int
+		switch results[i].Table {
+		case "candle", "trade", "funding_rate", "forex_rate", "nav_snapshot", "ledger_entry",
+			"accounting_event", "accounting_lot", "accounting_order", "accounting_position":
+			expected = 1
+		}
+		if results[i].Created != expected {
+			t.Errorf("%s received '%v', expected '%v'", results[i].Table, results[i].Created, expected)
+		}
+	}
+
+	_, err = loadCheckpoint(cpFile, source.name, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = cp.save(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = loadCheckpoint(cpFile, source.name, "elsewhere")
+	if !errors.Is(err, errCheckpointMismatch) {
+		t.Errorf("received '%v', expected '%v'", err, errCheckpointMismatch)
+	}
+}
+
+func TestExportImport(t *testing.T) {
+	t.Parallel()
+	source := newSQLiteEndpoint(t, "source.db")
+	destination := newSQLiteEndpoint(t, "destination.db")
+	seedEndpoint(t, source)
+
+	archive := filepath.Join(t.TempDir(), "backup.gz")
+	counts, err := exportArchive(context.Background(), source, archive, 1, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if counts["candle"] != 2 || counts["datahistoryjobrelations"] != 1 {
+		t.Errorf("received '%v', expected two candles and one relation", counts)
+	}
+	if _, err = os.Stat(archive + ".tmp"); !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received '%v', expected '%v'", err, os.ErrNotExist)
+	}
+
+	cp, err := loadCheckpoint(archive+".checkpoint", archive, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	results, err := importArchive(context.Background(), destination, archive, cp, 2, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(results) != len(tables) {
+		t.Errorf("received '%v', expected '%v'", len(results), len(tables))
+	}
+	compareRecords(t, readAll(t, destination), readAll(t, source))
+
+	// importing the same archive again is a no-op
+	results, err = importArchive(context.Background(), destination, archive, cp, 2, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for i := range results {
+		if results[i].Created != 0 {
+			t.Errorf("%s received '%v', expected '%v'", results[i].Table, results[i].Created, 0)
+		}
+	}
+}
+
+func TestExportImportEmpty(t *testing.T) {
+	t.Parallel()
+	source := newSQLiteEndpoint(t, "source.db")
+	destination := newSQLiteEndpoint(t, "destination.db")
+	archive := filepath.Join(t.TempDir(), "empty.gz")
+	_, err := exportArchive(context.Background(), source, archive, defaultBatchSize, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	cp, err := loadCheckpoint("", archive, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = importArchive(context.Background(), destination, archive, cp, defaultBatchSize, false)
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestImportTruncatedArchive(t *testing.T) {
+	t.Parallel()
+	destination := newSQLiteEndpoint(t, "destination.db")
+	archive := filepath.Join(t.TempDir(), "truncated.gz")
+	f, err := os.Create(archive)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	version, err := destination.schemaVersion()
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = gz.Write([]byte(`{"format":"` + archiveFormat + `","version":1,"schemaVersion":` +
+		strconv.FormatInt(version, 10) + "}\n" +
+		`{"table":"exchange","record":{"id":"a3bd9d62-36e6-4f6b-95c9-0bd0c3e7a1a1","name":"binance"}}` + "\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err = f.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	cp, err := loadCheckpoint("", archive, destination.name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = importArchive(context.Background(), destination, archive, cp, 10, false)
+	if !errors.Is(err, errTruncatedArchive) {
+		t.Errorf("received '%v', expected '%v'", err, errTruncatedArchive)
+	}
+}
+
+func TestParseSQLiteTime(t *testing.T) {
+	t.Parallel()
+	expected := time.Date(2021, 6, 1, 12, 30, 0, 0, time.UTC)
+	for _, s := range []string{
+		"2021-06-01T12:30:00Z",
+		"2021-06-01 12:30:00",
+		"2021-06-01 12:30:00 +0000 UTC",
+		"2021-06-01 22:30:00 +1000 AEST m=+0.000000001",
+	} {
+		received, err := parseSQLiteTime(s)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if !received.Equal(expected) {
+			t.Errorf("received '%v', expected '%v'", received, expected)
+		}
+	}
+	received, err := parseSQLiteTime("")
+	if !errors.Is(err, nil) || !received.IsZero() {
+		t.Errorf("received '%v' '%v', expected a zero time", received, err)
+	}
+	_, err = parseSQLiteTime("yesterday")
+	if !errors.Is(err, errUnknownTimeFormat) {
+		t.Errorf("received '%v', expected '%v'", err, errUnknownTimeFormat)
+	}
+}
+
+func TestGetTable(t *testing.T) {
+	t.Parallel()
+	_, err := getTable("candle")
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = getTable("orders")
+	if !errors.Is(err, errUnknownTable) {
+		t.Errorf("received '%v', expected '%v'", err, errUnknownTable)
+	}
+	err = (&endpoint{driver: "mysql"}).validate()
+	if !errors.Is(err, errUnsupportedDriver) {
+		t.Errorf("received '%v', expected '%v'", err, errUnsupportedDriver)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	app = &cli.App{
+		Name:                 "dbcopy",
+		Version:              core.Version(false),
+		EnableBashCompletion: true,
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:        "migrationdir",
+				Value:       database.MigrationDir,
+				Usage:       "folder containing the database migrations",
+				Destination: &migrationDir,
+			},
+			&cli.IntFlag{
+				Name:        "batchsize",
+				Value:       defaultBatchSize,
+				Usage:       "number of rows read and written per transaction",
+				Destination: &batchSize,
+			},
+			&cli.BoolFlag{
+				Name:        "verbose",
+				Usage:       "toggle verbose output",
+				Destination: &verbose,
+			},
+		},
+		Commands: []*cli.Command{
+			copyCommand,
+			exportCommand,
+			importCommand,
+		},
+	}
+	migrationDir string
+	batchSize    int
+	verbose      bool
+)
+
+func main() {
+	fmt.Println("GoCryptoTrader database copy tool")
+	fmt.Println(core.Copyright)
+	fmt.Println()
+
+	err := app.Run(os.Args)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
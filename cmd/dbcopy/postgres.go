@@ -0,0 +1,824 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gofrs/uuid"
+	modelPSQL "github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/volatiletech/null"
+)
+
+// scriptExecutionNamespace is used to derive stable UUIDs for script
+// executions which were stored with integer IDs, so repeated or resumed copies
+// resolve to the same row
+var scriptExecutionNamespace = uuid.Must(uuid.FromString("7d0d6c5e-6c1a-4a44-9a4c-2f1f63b7c0a1"))
+
+// resetPostgresSequence moves a serial sequence past the highest copied ID so
+// rows created after a copy do not collide with the preserved IDs
+func resetPostgresSequence(ctx context.Context, exec boil.ContextExecutor, tableName string) error {
+	_, err := exec.ExecContext(ctx,
+		fmt.Sprintf("SELECT setval(pg_get_serial_sequence('%[1]s', 'id'), COALESCE((SELECT MAX(id) FROM %[1]s), 0) + 1, false)", tableName))
+	return err
+}
+
+func readPostgresExchanges(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Exchanges(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &exchangeRecord{ID: rows[i].ID, Name: rows[i].Name}
+	}
+	return resp, nil
+}
+
+func (r *exchangeRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.ExchangeExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Exchange{ID: r.ID, Name: r.Name}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresScripts(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Scripts(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &scriptRecord{
+			ID:             rows[i].ID,
+			ScriptID:       rows[i].ScriptID,
+			ScriptName:     rows[i].ScriptName,
+			ScriptPath:     rows[i].ScriptPath,
+			ScriptData:     fromNullBytes(rows[i].ScriptData),
+			LastExecutedAt: fromNullTime(rows[i].LastExecutedAt),
+			CreatedAt:      fromNullTime(rows[i].CreatedAt),
+		}
+	}
+	return resp, nil
+}
+
+func (r *scriptRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.ScriptExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Script{
+		ID:             r.ID,
+		ScriptID:       r.ScriptID,
+		ScriptName:     r.ScriptName,
+		ScriptPath:     r.ScriptPath,
+		ScriptData:     toNullBytes(r.ScriptData),
+		LastExecutedAt: toNullTime(r.LastExecutedAt),
+		CreatedAt:      toNullTime(r.CreatedAt),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresScriptExecutions(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.ScriptExecutions(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &scriptExecutionRecord{
+			ID:              rows[i].ID,
+			ScriptID:        rows[i].ScriptID.String,
+			ExecutionType:   rows[i].ExecutionType,
+			ExecutionStatus: rows[i].ExecutionStatus,
+			ExecutionTime:   rows[i].ExecutionTime.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func (r *scriptExecutionRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	id := r.ID
+	if _, err := uuid.FromString(id); err != nil {
+		id = uuid.NewV5(scriptExecutionNamespace, id).String()
+	}
+	exists, err := modelPSQL.ScriptExecutionExists(ctx, exec, id)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.ScriptExecution{
+		ID:              id,
+		ExecutionType:   r.ExecutionType,
+		ExecutionStatus: r.ExecutionStatus,
+		ExecutionTime:   r.ExecutionTime,
+	}
+	if r.ScriptID != "" {
+		row.ScriptID = null.StringFrom(r.ScriptID)
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresAuditEvents(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.AuditEvents(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &auditEventRecord{
+			ID:         rows[i].ID,
+			Type:       rows[i].Type,
+			Identifier: rows[i].Identifier,
+			Message:    rows[i].Message,
+			CreatedAt:  rows[i].CreatedAt.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func (r *auditEventRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.AuditEventExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.AuditEvent{
+		ID:         r.ID,
+		Type:       r.Type,
+		Identifier: r.Identifier,
+		Message:    r.Message,
+		CreatedAt:  r.CreatedAt,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresWithdrawalHistory(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.WithdrawalHistories(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &withdrawalHistoryRecord{
+			ID:             rows[i].ID,
+			ExchangeNameID: rows[i].ExchangeNameID,
+			ExchangeID:     rows[i].ExchangeID,
+			Status:         rows[i].Status,
+			Currency:       rows[i].Currency,
+			Amount:         rows[i].Amount,
+			Description:    rows[i].Description,
+			WithdrawType:   int64(rows[i].WithdrawType),
+			CreatedAt:      rows[i].CreatedAt.UTC(),
+			UpdatedAt:      rows[i].UpdatedAt.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func (r *withdrawalHistoryRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.WithdrawalHistoryExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.WithdrawalHistory{
+		ID:             r.ID,
+		ExchangeNameID: r.ExchangeNameID,
+		ExchangeID:     r.ExchangeID,
+		Status:         r.Status,
+		Currency:       r.Currency,
+		Amount:         r.Amount,
+		Description:    r.Description,
+		WithdrawType:   int(r.WithdrawType),
+		CreatedAt:      r.CreatedAt,
+		UpdatedAt:      r.UpdatedAt,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresWithdrawalCrypto(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.WithdrawalCryptos(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &withdrawalCryptoRecord{
+			ID:                  rows[i].ID,
+			WithdrawalHistoryID: rows[i].WithdrawalCryptoID.String,
+			Address:             rows[i].Address,
+			AddressTag:          rows[i].AddressTag,
+			Fee:                 rows[i].Fee,
+		}
+	}
+	return resp, nil
+}
+
+func (r *withdrawalCryptoRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.WithdrawalCryptoExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.WithdrawalCrypto{
+		ID:         r.ID,
+		Address:    r.Address,
+		AddressTag: r.AddressTag,
+		Fee:        r.Fee,
+	}
+	if r.WithdrawalHistoryID != "" {
+		row.WithdrawalCryptoID = null.StringFrom(r.WithdrawalHistoryID)
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresWithdrawalFiat(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.WithdrawalFiats(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &withdrawalFiatRecord{
+			ID:                  rows[i].ID,
+			WithdrawalHistoryID: rows[i].WithdrawalFiatID.String,
+			BankName:            rows[i].BankName,
+			BankAddress:         rows[i].BankAddress,
+			BankAccountName:     rows[i].BankAccountName,
+			BankAccountNumber:   rows[i].BankAccountNumber,
+			BSB:                 rows[i].BSB,
+			SwiftCode:           rows[i].SwiftCode,
+			Iban:                rows[i].Iban,
+			BankCode:            rows[i].BankCode,
+		}
+	}
+	return resp, nil
+}
+
+func (r *withdrawalFiatRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.WithdrawalFiatExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.WithdrawalFiat{
+		ID:                r.ID,
+		BankName:          r.BankName,
+		BankAddress:       r.BankAddress,
+		BankAccountName:   r.BankAccountName,
+		BankAccountNumber: r.BankAccountNumber,
+		BSB:               r.BSB,
+		SwiftCode:         r.SwiftCode,
+		Iban:              r.Iban,
+		BankCode:          r.BankCode,
+	}
+	if r.WithdrawalHistoryID != "" {
+		row.WithdrawalFiatID = null.StringFrom(r.WithdrawalHistoryID)
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresDataHistoryJobs(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Datahistoryjobs(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &dataHistoryJobRecord{
+			ID:                       rows[i].ID,
+			Nickname:                 rows[i].Nickname,
+			ExchangeNameID:           rows[i].ExchangeNameID,
+			Asset:                    rows[i].Asset,
+			Base:                     rows[i].Base,
+			Quote:                    rows[i].Quote,
+			StartTime:                rows[i].StartTime.UTC(),
+			EndTime:                  rows[i].EndTime.UTC(),
+			Interval:                 rows[i].Interval,
+			DataType:                 rows[i].DataType,
+			RequestSize:              rows[i].RequestSize,
+			MaxRetries:               rows[i].MaxRetries,
+			BatchCount:               rows[i].BatchCount,
+			Status:                   rows[i].Status,
+			Created:                  rows[i].Created.UTC(),
+			ConversionInterval:       rows[i].ConversionInterval,
+			OverwriteData:            rows[i].OverwriteData,
+			SecondaryExchangeID:      rows[i].SecondaryExchangeID,
+			IssueTolerancePercentage: rows[i].IssueTolerancePercentage,
+			ReplaceOnIssue:           rows[i].ReplaceOnIssue,
+		}
+		if rows[i].DecimalPlaceComparison.Valid {
+			r.DecimalPlaceComparison = null.Int64From(int64(rows[i].DecimalPlaceComparison.Int))
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *dataHistoryJobRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.DatahistoryjobExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Datahistoryjob{
+		ID:                       r.ID,
+		Nickname:                 r.Nickname,
+		ExchangeNameID:           r.ExchangeNameID,
+		Asset:                    r.Asset,
+		Base:                     r.Base,
+		Quote:                    r.Quote,
+		StartTime:                r.StartTime,
+		EndTime:                  r.EndTime,
+		Interval:                 r.Interval,
+		DataType:                 r.DataType,
+		RequestSize:              r.RequestSize,
+		MaxRetries:               r.MaxRetries,
+		BatchCount:               r.BatchCount,
+		Status:                   r.Status,
+		Created:                  r.Created,
+		ConversionInterval:       r.ConversionInterval,
+		OverwriteData:            r.OverwriteData,
+		SecondaryExchangeID:      r.SecondaryExchangeID,
+		IssueTolerancePercentage: r.IssueTolerancePercentage,
+		ReplaceOnIssue:           r.ReplaceOnIssue,
+	}
+	if r.DecimalPlaceComparison.Valid {
+		row.DecimalPlaceComparison = null.IntFrom(int(r.DecimalPlaceComparison.Int64))
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresDataHistoryJobRelations(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT prerequisite_job_id, job_id FROM datahistoryjobrelations ORDER BY prerequisite_job_id, job_id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataHistoryJobRelations(rows)
+}
+
+func (r *dataHistoryJobRelationRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO datahistoryjobrelations (prerequisite_job_id, job_id) VALUES ($1, $2) ON CONFLICT DO NOTHING",
+		r.PrerequisiteJobID,
+		r.JobID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresDataHistoryJobResults(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Datahistoryjobresults(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &dataHistoryJobResultRecord{
+			ID:                rows[i].ID,
+			JobID:             rows[i].JobID,
+			Result:            rows[i].Result,
+			Status:            rows[i].Status,
+			IntervalStartTime: rows[i].IntervalStartTime.UTC(),
+			IntervalEndTime:   rows[i].IntervalEndTime.UTC(),
+			RunTime:           rows[i].RunTime.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func (r *dataHistoryJobResultRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.DatahistoryjobresultExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Datahistoryjobresult{
+		ID:                r.ID,
+		JobID:             r.JobID,
+		Result:            r.Result,
+		Status:            r.Status,
+		IntervalStartTime: r.IntervalStartTime,
+		IntervalEndTime:   r.IntervalEndTime,
+		RunTime:           r.RunTime,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresCandles(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Candles(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &candleRecord{
+			ID:               rows[i].ID,
+			ExchangeNameID:   rows[i].ExchangeNameID,
+			Base:             rows[i].Base,
+			Quote:            rows[i].Quote,
+			Interval:         rows[i].Interval,
+			Timestamp:        rows[i].Timestamp.UTC(),
+			Open:             rows[i].Open,
+			High:             rows[i].High,
+			Low:              rows[i].Low,
+			Close:            rows[i].Close,
+			Volume:           rows[i].Volume,
+			Asset:            rows[i].Asset,
+			SourceJobID:      rows[i].SourceJobID,
+			ValidationJobID:  rows[i].ValidationJobID,
+			ValidationIssues: rows[i].ValidationIssues,
+		}
+	}
+	return resp, nil
+}
+
+func (r *candleRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.CandleExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Candle{
+		ID:               r.ID,
+		ExchangeNameID:   r.ExchangeNameID,
+		Base:             r.Base,
+		Quote:            r.Quote,
+		Interval:         r.Interval,
+		Timestamp:        r.Timestamp,
+		Open:             r.Open,
+		High:             r.High,
+		Low:              r.Low,
+		Close:            r.Close,
+		Volume:           r.Volume,
+		Asset:            r.Asset,
+		SourceJobID:      r.SourceJobID,
+		ValidationJobID:  r.ValidationJobID,
+		ValidationIssues: r.ValidationIssues,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresTrades(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelPSQL.Trades(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &tradeRecord{
+			ID:             rows[i].ID,
+			ExchangeNameID: rows[i].ExchangeNameID,
+			Tid:            rows[i].Tid,
+			Base:           rows[i].Base,
+			Quote:          rows[i].Quote,
+			Asset:          rows[i].Asset,
+			Price:          rows[i].Price,
+			Amount:         rows[i].Amount,
+			Side:           rows[i].Side,
+			Timestamp:      rows[i].Timestamp.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func (r *tradeRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelPSQL.TradeExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelPSQL.Trade{
+		ID:             r.ID,
+		ExchangeNameID: r.ExchangeNameID,
+		Tid:            r.Tid,
+		Base:           r.Base,
+		Quote:          r.Quote,
+		Asset:          r.Asset,
+		Price:          r.Price,
+		Amount:         r.Amount,
+		Side:           r.Side,
+		Timestamp:      r.Timestamp,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readPostgresFundingRates(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, exchange_name_id, asset, base, quote, rate, timestamp FROM funding_rate ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &fundingRateRecord{}
+		if err = rows.Scan(&r.ID, &r.ExchangeNameID, &r.Asset, &r.Base, &r.Quote, &r.Rate, &r.Timestamp); err != nil {
+			return nil, err
+		}
+		r.Timestamp = r.Timestamp.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *fundingRateRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO funding_rate (id, exchange_name_id, asset, base, quote, rate, timestamp) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.ExchangeNameID,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.Rate,
+		r.Timestamp.UTC())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresForexRates(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, base, quote, rate, date, source FROM forex_rate ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &forexRateRecord{}
+		if err = rows.Scan(&r.ID, &r.Base, &r.Quote, &r.Rate, &r.Date, &r.Source); err != nil {
+			return nil, err
+		}
+		r.Date = r.Date.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *forexRateRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO forex_rate (id, base, quote, rate, date, source) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.Base,
+		r.Quote,
+		r.Rate,
+		r.Date.UTC(),
+		r.Source)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresNAVSnapshots(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, date, reporting_currency, nav, realised_pnl, unrealised_pnl, fees, funding FROM nav_snapshot ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &navSnapshotRecord{}
+		if err = rows.Scan(&r.ID, &r.Date, &r.ReportingCurrency, &r.NAV, &r.RealisedPNL, &r.UnrealisedPNL, &r.Fees, &r.Funding); err != nil {
+			return nil, err
+		}
+		r.Date = r.Date.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *navSnapshotRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO nav_snapshot (id, date, reporting_currency, nav, realised_pnl, unrealised_pnl, fees, funding) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.Date.UTC(),
+		r.ReportingCurrency,
+		r.NAV,
+		r.RealisedPNL,
+		r.UnrealisedPNL,
+		r.Fees,
+		r.Funding)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresLedgerEntries(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, entry_key, exchange_name, sub_account, asset, kind, reference, currency, amount, occurred, description FROM ledger_entry ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &ledgerEntryRecord{}
+		if err = rows.Scan(&r.ID, &r.EntryKey, &r.ExchangeName, &r.SubAccount, &r.Asset, &r.Kind, &r.Reference, &r.Currency, &r.Amount, &r.Occurred, &r.Description); err != nil {
+			return nil, err
+		}
+		r.Occurred = r.Occurred.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *ledgerEntryRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO ledger_entry (id, entry_key, exchange_name, sub_account, asset, kind, reference, currency, amount, occurred, description) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.EntryKey,
+		r.ExchangeName,
+		r.SubAccount,
+		r.Asset,
+		r.Kind,
+		r.Reference,
+		r.Currency,
+		r.Amount.String(),
+		r.Occurred.UTC(),
+		r.Description)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresAccountingEvents(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, reporting_currency, occurred, kind, exchange_name, asset, base, quote, order_id, side, amount, value, realised_pnl, fees, funding, unmatched FROM accounting_event ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingEventRecord{}
+		if err = rows.Scan(&r.ID, &r.ReportingCurrency, &r.Occurred, &r.Kind, &r.ExchangeName, &r.Asset, &r.Base, &r.Quote, &r.OrderID, &r.Side, &r.Amount, &r.Value, &r.RealisedPNL, &r.Fees, &r.Funding, &r.Unmatched); err != nil {
+			return nil, err
+		}
+		r.Occurred = r.Occurred.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingEventRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO accounting_event (id, reporting_currency, occurred, kind, exchange_name, asset, base, quote, order_id, side, amount, value, realised_pnl, fees, funding, unmatched) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.ReportingCurrency,
+		r.Occurred.UTC(),
+		r.Kind,
+		r.ExchangeName,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.OrderID,
+		r.Side,
+		r.Amount.String(),
+		r.Value.String(),
+		r.RealisedPNL.String(),
+		r.Fees.String(),
+		r.Funding.String(),
+		r.Unmatched.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresAccountingLots(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, reporting_currency, exchange_name, currency, sequence, reference, acquired, amount, cost FROM accounting_lot ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingLotRecord{}
+		if err = rows.Scan(&r.ID, &r.ReportingCurrency, &r.ExchangeName, &r.Currency, &r.Sequence, &r.Reference, &r.Acquired, &r.Amount, &r.Cost); err != nil {
+			return nil, err
+		}
+		r.Acquired = r.Acquired.UTC()
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingLotRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO accounting_lot (id, reporting_currency, exchange_name, currency, sequence, reference, acquired, amount, cost) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.ReportingCurrency,
+		r.ExchangeName,
+		r.Currency,
+		r.Sequence,
+		r.Reference,
+		r.Acquired.UTC(),
+		r.Amount.String(),
+		r.Cost.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresAccountingOrders(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, order_key, executed, quote, fee, finished FROM accounting_order ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingOrderRecord{}
+		var finished null.Time
+		if err = rows.Scan(&r.ID, &r.OrderKey, &r.Executed, &r.Quote, &r.Fee, &finished); err != nil {
+			return nil, err
+		}
+		r.Finished = fromNullTime(finished)
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingOrderRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO accounting_order (id, order_key, executed, quote, fee, finished) VALUES ($1, $2, $3, $4, $5, $6) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.OrderKey,
+		r.Executed.String(),
+		r.Quote.String(),
+		r.Fee.String(),
+		toNullTime(r.Finished))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readPostgresAccountingPositions(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, position_key, exchange_name, asset, base, quote, settlement, realised_gross, fees, funding FROM accounting_position ORDER BY id LIMIT $1 OFFSET $2",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingPositionRecord{}
+		if err = rows.Scan(&r.ID, &r.PositionKey, &r.ExchangeName, &r.Asset, &r.Base, &r.Quote, &r.Settlement, &r.RealisedGross, &r.Fees, &r.Funding); err != nil {
+			return nil, err
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingPositionRecord) writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO accounting_position (id, position_key, exchange_name, asset, base, quote, settlement, realised_gross, fees, funding) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.PositionKey,
+		r.ExchangeName,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.Settlement,
+		r.RealisedGross.String(),
+		r.Fees.String(),
+		r.Funding.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
@@ -0,0 +1,343 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/volatiletech/null"
+)
+
+// sqliteTimeFormats are the timestamp layouts which have been written to
+// sqlite databases by the repository packages and column defaults
+var sqliteTimeFormats = []string{
+	time.RFC3339Nano,
+	"2006-01-02 15:04:05.999999999 -0700 MST",
+	"2006-01-02 15:04:05.999999999-07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02T15:04:05",
+}
+
+var errUnknownTimeFormat = errors.New("unrecognised timestamp format")
+
+// record is a single table row held in a driver independent form
+type record interface {
+	// writeSQLite inserts the record when it does not already exist and
+	// reports whether a row was created
+	writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error)
+	// writePostgres inserts the record when it does not already exist and
+	// reports whether a row was created
+	writePostgres(ctx context.Context, exec boil.ContextExecutor) (bool, error)
+}
+
+type exchangeRecord struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type scriptRecord struct {
+	ID             string    `json:"id"`
+	ScriptID       string    `json:"scriptID"`
+	ScriptName     string    `json:"scriptName"`
+	ScriptPath     string    `json:"scriptPath"`
+	ScriptData     []byte    `json:"scriptData"`
+	LastExecutedAt time.Time `json:"lastExecutedAt"`
+	CreatedAt      time.Time `json:"createdAt"`
+}
+
+// scriptExecutionRecord IDs are integers on sqlite and UUIDs on postgres, so
+// the ID is kept as a string and translated when written
+type scriptExecutionRecord struct {
+	ID              string    `json:"id"`
+	ScriptID        string    `json:"scriptID"`
+	ExecutionType   string    `json:"executionType"`
+	ExecutionStatus string    `json:"executionStatus"`
+	ExecutionTime   time.Time `json:"executionTime"`
+}
+
+type auditEventRecord struct {
+	ID         int64     `json:"id"`
+	Type       string    `json:"type"`
+	Identifier string    `json:"identifier"`
+	Message    string    `json:"message"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+type withdrawalHistoryRecord struct {
+	ID             string      `json:"id"`
+	ExchangeNameID string      `json:"exchangeNameID"`
+	ExchangeID     string      `json:"exchangeID"`
+	Status         string      `json:"status"`
+	Currency       string      `json:"currency"`
+	Amount         float64     `json:"amount"`
+	Description    null.String `json:"description"`
+	WithdrawType   int64       `json:"withdrawType"`
+	CreatedAt      time.Time   `json:"createdAt"`
+	UpdatedAt      time.Time   `json:"updatedAt"`
+}
+
+type withdrawalCryptoRecord struct {
+	ID                  int64       `json:"id"`
+	WithdrawalHistoryID string      `json:"withdrawalHistoryID"`
+	Address             string      `json:"address"`
+	AddressTag          null.String `json:"addressTag"`
+	Fee                 float64     `json:"fee"`
+}
+
+type withdrawalFiatRecord struct {
+	ID                  int64   `json:"id"`
+	WithdrawalHistoryID string  `json:"withdrawalHistoryID"`
+	BankName            string  `json:"bankName"`
+	BankAddress         string  `json:"bankAddress"`
+	BankAccountName     string  `json:"bankAccountName"`
+	BankAccountNumber   string  `json:"bankAccountNumber"`
+	BSB                 string  `json:"bsb"`
+	SwiftCode           string  `json:"swiftCode"`
+	Iban                string  `json:"iban"`
+	BankCode            float64 `json:"bankCode"`
+}
+
+type dataHistoryJobRecord struct {
+	ID                       string       `json:"id"`
+	Nickname                 string       `json:"nickname"`
+	ExchangeNameID           string       `json:"exchangeNameID"`
+	Asset                    string       `json:"asset"`
+	Base                     string       `json:"base"`
+	Quote                    string       `json:"quote"`
+	StartTime                time.Time    `json:"startTime"`
+	EndTime                  time.Time    `json:"endTime"`
+	Interval                 float64      `json:"interval"`
+	DataType                 float64      `json:"dataType"`
+	RequestSize              float64      `json:"requestSize"`
+	MaxRetries               float64      `json:"maxRetries"`
+	BatchCount               float64      `json:"batchCount"`
+	Status                   float64      `json:"status"`
+	Created                  time.Time    `json:"created"`
+	ConversionInterval       null.Float64 `json:"conversionInterval"`
+	OverwriteData            null.Bool    `json:"overwriteData"`
+	DecimalPlaceComparison   null.Int64   `json:"decimalPlaceComparison"`
+	SecondaryExchangeID      null.String  `json:"secondaryExchangeID"`
+	IssueTolerancePercentage null.Float64 `json:"issueTolerancePercentage"`
+	ReplaceOnIssue           null.Bool    `json:"replaceOnIssue"`
+}
+
+type dataHistoryJobRelationRecord struct {
+	PrerequisiteJobID string `json:"prerequisiteJobID"`
+	JobID             string `json:"jobID"`
+}
+
+type dataHistoryJobResultRecord struct {
+	ID                string      `json:"id"`
+	JobID             string      `json:"jobID"`
+	Result            null.String `json:"result"`
+	Status            float64     `json:"status"`
+	IntervalStartTime time.Time   `json:"intervalStartTime"`
+	IntervalEndTime   time.Time   `json:"intervalEndTime"`
+	RunTime           time.Time   `json:"runTime"`
+}
+
+type candleRecord struct {
+	ID               string      `json:"id"`
+	ExchangeNameID   string      `json:"exchangeNameID"`
+	Base             string      `json:"base"`
+	Quote            string      `json:"quote"`
+	Interval         int64       `json:"interval"`
+	Timestamp        time.Time   `json:"timestamp"`
+	Open             float64     `json:"open"`
+	High             float64     `json:"high"`
+	Low              float64     `json:"low"`
+	Close            float64     `json:"close"`
+	Volume           float64     `json:"volume"`
+	Asset            string      `json:"asset"`
+	SourceJobID      null.String `json:"sourceJobID"`
+	ValidationJobID  null.String `json:"validationJobID"`
+	ValidationIssues null.String `json:"validationIssues"`
+}
+
+type tradeRecord struct {
+	ID             string      `json:"id"`
+	ExchangeNameID string      `json:"exchangeNameID"`
+	Tid            null.String `json:"tid"`
+	Base           string      `json:"base"`
+	Quote          string      `json:"quote"`
+	Asset          string      `json:"asset"`
+	Price          float64     `json:"price"`
+	Amount         float64     `json:"amount"`
+	Side           null.String `json:"side"`
+	Timestamp      time.Time   `json:"timestamp"`
+}
+
+type fundingRateRecord struct {
+	ID             string    `json:"id"`
+	ExchangeNameID string    `json:"exchangeNameID"`
+	Asset          string    `json:"asset"`
+	Base           string    `json:"base"`
+	Quote          string    `json:"quote"`
+	Rate           float64   `json:"rate"`
+	Timestamp      time.Time `json:"timestamp"`
+}
+
+type forexRateRecord struct {
+	ID     string    `json:"id"`
+	Base   string    `json:"base"`
+	Quote  string    `json:"quote"`
+	Rate   float64   `json:"rate"`
+	Date   time.Time `json:"date"`
+	Source string    `json:"source"`
+}
+
+type navSnapshotRecord struct {
+	ID                string    `json:"id"`
+	Date              time.Time `json:"date"`
+	ReportingCurrency string    `json:"reportingCurrency"`
+	NAV               float64   `json:"nav"`
+	RealisedPNL       float64   `json:"realisedPNL"`
+	UnrealisedPNL     float64   `json:"unrealisedPNL"`
+	Fees              float64   `json:"fees"`
+	Funding           float64   `json:"funding"`
+}
+
+type ledgerEntryRecord struct {
+	ID           string          `json:"id"`
+	EntryKey     string          `json:"entryKey"`
+	ExchangeName string          `json:"exchangeName"`
+	SubAccount   string          `json:"subAccount"`
+	Asset        string          `json:"asset"`
+	Kind         string          `json:"kind"`
+	Reference    string          `json:"reference"`
+	Currency     string          `json:"currency"`
+	Amount       decimal.Decimal `json:"amount"`
+	Occurred     time.Time       `json:"occurred"`
+	Description  string          `json:"description"`
+}
+
+type accountingEventRecord struct {
+	ID                string          `json:"id"`
+	ReportingCurrency string          `json:"reportingCurrency"`
+	Occurred          time.Time       `json:"occurred"`
+	Kind              string          `json:"kind"`
+	ExchangeName      string          `json:"exchangeName"`
+	Asset             string          `json:"asset"`
+	Base              string          `json:"base"`
+	Quote             string          `json:"quote"`
+	OrderID           string          `json:"orderID"`
+	Side              string          `json:"side"`
+	Amount            decimal.Decimal `json:"amount"`
+	Value             decimal.Decimal `json:"value"`
+	RealisedPNL       decimal.Decimal `json:"realisedPNL"`
+	Fees              decimal.Decimal `json:"fees"`
+	Funding           decimal.Decimal `json:"funding"`
+	Unmatched         decimal.Decimal `json:"unmatched"`
+}
+
+type accountingLotRecord struct {
+	ID                string          `json:"id"`
+	ReportingCurrency string          `json:"reportingCurrency"`
+	ExchangeName      string          `json:"exchangeName"`
+	Currency          string          `json:"currency"`
+	Sequence          int64           `json:"sequence"`
+	Reference         string          `json:"reference"`
+	Acquired          time.Time       `json:"acquired"`
+	Amount            decimal.Decimal `json:"amount"`
+	Cost              decimal.Decimal `json:"cost"`
+}
+
+type accountingOrderRecord struct {
+	ID       string          `json:"id"`
+	OrderKey string          `json:"orderKey"`
+	Executed decimal.Decimal `json:"executed"`
+	Quote    decimal.Decimal `json:"quote"`
+	Fee      decimal.Decimal `json:"fee"`
+	Finished time.Time       `json:"finished"`
+}
+
+type accountingPositionRecord struct {
+	ID            string          `json:"id"`
+	PositionKey   string          `json:"positionKey"`
+	ExchangeName  string          `json:"exchangeName"`
+	Asset         string          `json:"asset"`
+	Base          string          `json:"base"`
+	Quote         string          `json:"quote"`
+	Settlement    string          `json:"settlement"`
+	RealisedGross decimal.Decimal `json:"realisedGross"`
+	Fees          decimal.Decimal `json:"fees"`
+	Funding       decimal.Decimal `json:"funding"`
+}
+
+// parseSQLiteTime converts a stored sqlite timestamp into a UTC time. Empty
+// values are returned as a zero time
+func parseSQLiteTime(s string) (time.Time, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return time.Time{}, nil
+	}
+	// time.Time.String() output may carry a monotonic clock reading
+	if i := strings.Index(s, " m="); i > 0 {
+		s = s[:i]
+	}
+	for i := range sqliteTimeFormats {
+		t, err := time.Parse(sqliteTimeFormats[i], s)
+		if err == nil {
+			return t.UTC(), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("%w: %q", errUnknownTimeFormat, s)
+}
+
+// formatSQLiteTime converts a time into the format used by the repository
+// packages when writing to sqlite
+func formatSQLiteTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
+
+func toNullTime(t time.Time) null.Time {
+	if t.IsZero() {
+		return null.Time{}
+	}
+	return null.TimeFrom(t.UTC())
+}
+
+func fromNullTime(t null.Time) time.Time {
+	if !t.Valid {
+		return time.Time{}
+	}
+	return t.Time.UTC()
+}
+
+func toNullBytes(b []byte) null.Bytes {
+	if b == nil {
+		return null.Bytes{}
+	}
+	return null.BytesFrom(b)
+}
+
+func fromNullBytes(b null.Bytes) []byte {
+	if !b.Valid {
+		return nil
+	}
+	return b.Bytes
+}
+
+func nullBoolToInt64(b null.Bool) null.Int64 {
+	if !b.Valid {
+		return null.Int64{}
+	}
+	if b.Bool {
+		return null.Int64From(1)
+	}
+	return null.Int64From(0)
+}
+
+func nullInt64ToBool(i null.Int64) null.Bool {
+	if !i.Valid {
+		return null.Bool{}
+	}
+	return null.BoolFrom(i.Int64 != 0)
+}
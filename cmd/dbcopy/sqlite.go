@@ -0,0 +1,878 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	modelSQLite "github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/volatiletech/null"
+)
+
+func readSQLiteExchanges(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Exchanges(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &exchangeRecord{ID: rows[i].ID, Name: rows[i].Name}
+	}
+	return resp, nil
+}
+
+func (r *exchangeRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.ExchangeExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Exchange{ID: r.ID, Name: r.Name}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteScripts(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Scripts(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &scriptRecord{
+			ID:         rows[i].ID,
+			ScriptID:   rows[i].ScriptID,
+			ScriptName: rows[i].ScriptName,
+			ScriptPath: rows[i].ScriptPath,
+			ScriptData: fromNullBytes(rows[i].ScriptData),
+		}
+		if r.LastExecutedAt, err = parseSQLiteTime(rows[i].LastExecutedAt); err != nil {
+			return nil, fmt.Errorf("script %v: %w", r.ID, err)
+		}
+		if r.CreatedAt, err = parseSQLiteTime(rows[i].CreatedAt); err != nil {
+			return nil, fmt.Errorf("script %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *scriptRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.ScriptExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Script{
+		ID:             r.ID,
+		ScriptID:       r.ScriptID,
+		ScriptName:     r.ScriptName,
+		ScriptPath:     r.ScriptPath,
+		ScriptData:     toNullBytes(r.ScriptData),
+		LastExecutedAt: formatSQLiteTime(r.LastExecutedAt),
+		CreatedAt:      formatSQLiteTime(r.CreatedAt),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteScriptExecutions(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.ScriptExecutions(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &scriptExecutionRecord{
+			ID:              strconv.FormatInt(rows[i].ID, 10),
+			ScriptID:        rows[i].ScriptID,
+			ExecutionType:   rows[i].ExecutionType,
+			ExecutionStatus: rows[i].ExecutionStatus,
+		}
+		if r.ExecutionTime, err = parseSQLiteTime(rows[i].ExecutionTime); err != nil {
+			return nil, fmt.Errorf("script execution %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+// writeSQLite keeps integer IDs as is. UUIDs from a postgres source cannot be
+// stored, so the row is matched on its contents instead
+func (r *scriptExecutionRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	row := &modelSQLite.ScriptExecution{
+		ScriptID:        r.ScriptID,
+		ExecutionType:   r.ExecutionType,
+		ExecutionStatus: r.ExecutionStatus,
+		ExecutionTime:   formatSQLiteTime(r.ExecutionTime),
+	}
+	var exists bool
+	id, err := strconv.ParseInt(r.ID, 10, 64)
+	if err == nil {
+		row.ID = id
+		exists, err = modelSQLite.ScriptExecutionExists(ctx, exec, id)
+	} else {
+		exists, err = modelSQLite.ScriptExecutions(
+			qm.Where("script_id = ?", row.ScriptID),
+			qm.Where("execution_type = ?", row.ExecutionType),
+			qm.Where("execution_status = ?", row.ExecutionStatus),
+			qm.Where("execution_time = ?", row.ExecutionTime),
+		).Exists(ctx, exec)
+	}
+	if err != nil || exists {
+		return false, err
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteAuditEvents(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.AuditEvents(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &auditEventRecord{
+			ID:         rows[i].ID,
+			Type:       rows[i].Type,
+			Identifier: rows[i].Identifier,
+			Message:    rows[i].Message,
+		}
+		if r.CreatedAt, err = parseSQLiteTime(rows[i].CreatedAt); err != nil {
+			return nil, fmt.Errorf("audit event %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *auditEventRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.AuditEventExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.AuditEvent{
+		ID:         r.ID,
+		Type:       r.Type,
+		Identifier: r.Identifier,
+		Message:    r.Message,
+		CreatedAt:  formatSQLiteTime(r.CreatedAt),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteWithdrawalHistory(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.WithdrawalHistories(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &withdrawalHistoryRecord{
+			ID:             rows[i].ID,
+			ExchangeNameID: rows[i].ExchangeNameID,
+			ExchangeID:     rows[i].ExchangeID,
+			Status:         rows[i].Status,
+			Currency:       rows[i].Currency,
+			Amount:         rows[i].Amount,
+			Description:    rows[i].Description,
+			WithdrawType:   rows[i].WithdrawType,
+		}
+		if r.CreatedAt, err = parseSQLiteTime(rows[i].CreatedAt); err != nil {
+			return nil, fmt.Errorf("withdrawal history %v: %w", r.ID, err)
+		}
+		if r.UpdatedAt, err = parseSQLiteTime(rows[i].UpdatedAt); err != nil {
+			return nil, fmt.Errorf("withdrawal history %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *withdrawalHistoryRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.WithdrawalHistoryExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.WithdrawalHistory{
+		ID:             r.ID,
+		ExchangeNameID: r.ExchangeNameID,
+		ExchangeID:     r.ExchangeID,
+		Status:         r.Status,
+		Currency:       r.Currency,
+		Amount:         r.Amount,
+		Description:    r.Description,
+		WithdrawType:   r.WithdrawType,
+		CreatedAt:      formatSQLiteTime(r.CreatedAt),
+		UpdatedAt:      formatSQLiteTime(r.UpdatedAt),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteWithdrawalCrypto(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.WithdrawalCryptos(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &withdrawalCryptoRecord{
+			ID:                  rows[i].ID,
+			WithdrawalHistoryID: rows[i].WithdrawalHistoryID,
+			Address:             rows[i].Address,
+			AddressTag:          rows[i].AddressTag,
+			Fee:                 rows[i].Fee,
+		}
+	}
+	return resp, nil
+}
+
+func (r *withdrawalCryptoRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.WithdrawalCryptoExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.WithdrawalCrypto{
+		ID:                  r.ID,
+		WithdrawalHistoryID: r.WithdrawalHistoryID,
+		Address:             r.Address,
+		AddressTag:          r.AddressTag,
+		Fee:                 r.Fee,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteWithdrawalFiat(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.WithdrawalFiats(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		resp[i] = &withdrawalFiatRecord{
+			ID:                  rows[i].ID,
+			WithdrawalHistoryID: rows[i].WithdrawalHistoryID,
+			BankName:            rows[i].BankName,
+			BankAddress:         rows[i].BankAddress,
+			BankAccountName:     rows[i].BankAccountName,
+			BankAccountNumber:   rows[i].BankAccountNumber,
+			BSB:                 rows[i].BSB,
+			SwiftCode:           rows[i].SwiftCode,
+			Iban:                rows[i].Iban,
+			BankCode:            rows[i].BankCode,
+		}
+	}
+	return resp, nil
+}
+
+func (r *withdrawalFiatRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.WithdrawalFiatExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.WithdrawalFiat{
+		ID:                  r.ID,
+		WithdrawalHistoryID: r.WithdrawalHistoryID,
+		BankName:            r.BankName,
+		BankAddress:         r.BankAddress,
+		BankAccountName:     r.BankAccountName,
+		BankAccountNumber:   r.BankAccountNumber,
+		BSB:                 r.BSB,
+		SwiftCode:           r.SwiftCode,
+		Iban:                r.Iban,
+		BankCode:            r.BankCode,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteDataHistoryJobs(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Datahistoryjobs(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &dataHistoryJobRecord{
+			ID:                       rows[i].ID,
+			Nickname:                 rows[i].Nickname,
+			ExchangeNameID:           rows[i].ExchangeNameID,
+			Asset:                    rows[i].Asset,
+			Base:                     rows[i].Base,
+			Quote:                    rows[i].Quote,
+			Interval:                 rows[i].Interval,
+			DataType:                 rows[i].DataType,
+			RequestSize:              rows[i].RequestSize,
+			MaxRetries:               rows[i].MaxRetries,
+			BatchCount:               rows[i].BatchCount,
+			Status:                   rows[i].Status,
+			ConversionInterval:       rows[i].ConversionInterval,
+			OverwriteData:            nullInt64ToBool(rows[i].OverwriteData),
+			DecimalPlaceComparison:   rows[i].DecimalPlaceComparison,
+			SecondaryExchangeID:      rows[i].SecondaryExchangeID,
+			IssueTolerancePercentage: rows[i].IssueTolerancePercentage,
+			ReplaceOnIssue:           nullInt64ToBool(rows[i].ReplaceOnIssue),
+		}
+		if r.StartTime, err = parseSQLiteTime(rows[i].StartTime); err != nil {
+			return nil, fmt.Errorf("data history job %v: %w", r.ID, err)
+		}
+		if r.EndTime, err = parseSQLiteTime(rows[i].EndTime); err != nil {
+			return nil, fmt.Errorf("data history job %v: %w", r.ID, err)
+		}
+		if r.Created, err = parseSQLiteTime(rows[i].Created); err != nil {
+			return nil, fmt.Errorf("data history job %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *dataHistoryJobRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.DatahistoryjobExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Datahistoryjob{
+		ID:                       r.ID,
+		Nickname:                 r.Nickname,
+		ExchangeNameID:           r.ExchangeNameID,
+		Asset:                    r.Asset,
+		Base:                     r.Base,
+		Quote:                    r.Quote,
+		StartTime:                formatSQLiteTime(r.StartTime),
+		EndTime:                  formatSQLiteTime(r.EndTime),
+		Interval:                 r.Interval,
+		DataType:                 r.DataType,
+		RequestSize:              r.RequestSize,
+		MaxRetries:               r.MaxRetries,
+		BatchCount:               r.BatchCount,
+		Status:                   r.Status,
+		Created:                  formatSQLiteTime(r.Created),
+		ConversionInterval:       r.ConversionInterval,
+		OverwriteData:            nullBoolToInt64(r.OverwriteData),
+		DecimalPlaceComparison:   r.DecimalPlaceComparison,
+		SecondaryExchangeID:      r.SecondaryExchangeID,
+		IssueTolerancePercentage: r.IssueTolerancePercentage,
+		ReplaceOnIssue:           nullBoolToInt64(r.ReplaceOnIssue),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteDataHistoryJobRelations(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT prerequisite_job_id, job_id FROM datahistoryjobrelations ORDER BY prerequisite_job_id, job_id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	return scanDataHistoryJobRelations(rows)
+}
+
+func (r *dataHistoryJobRelationRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO datahistoryjobrelations (prerequisite_job_id, job_id) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		r.PrerequisiteJobID,
+		r.JobID)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteDataHistoryJobResults(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Datahistoryjobresults(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &dataHistoryJobResultRecord{
+			ID:     rows[i].ID,
+			JobID:  rows[i].JobID,
+			Result: rows[i].Result,
+			Status: rows[i].Status,
+		}
+		if r.IntervalStartTime, err = parseSQLiteTime(rows[i].IntervalStartTime); err != nil {
+			return nil, fmt.Errorf("data history job result %v: %w", r.ID, err)
+		}
+		if r.IntervalEndTime, err = parseSQLiteTime(rows[i].IntervalEndTime); err != nil {
+			return nil, fmt.Errorf("data history job result %v: %w", r.ID, err)
+		}
+		if r.RunTime, err = parseSQLiteTime(rows[i].RunTime); err != nil {
+			return nil, fmt.Errorf("data history job result %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *dataHistoryJobResultRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.DatahistoryjobresultExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Datahistoryjobresult{
+		ID:                r.ID,
+		JobID:             r.JobID,
+		Result:            r.Result,
+		Status:            r.Status,
+		IntervalStartTime: formatSQLiteTime(r.IntervalStartTime),
+		IntervalEndTime:   formatSQLiteTime(r.IntervalEndTime),
+		RunTime:           formatSQLiteTime(r.RunTime),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteCandles(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Candles(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &candleRecord{
+			ID:               rows[i].ID,
+			ExchangeNameID:   rows[i].ExchangeNameID,
+			Base:             rows[i].Base,
+			Quote:            rows[i].Quote,
+			Open:             rows[i].Open,
+			High:             rows[i].High,
+			Low:              rows[i].Low,
+			Close:            rows[i].Close,
+			Volume:           rows[i].Volume,
+			Asset:            rows[i].Asset,
+			SourceJobID:      rows[i].SourceJobID,
+			ValidationJobID:  rows[i].ValidationJobID,
+			ValidationIssues: rows[i].ValidationIssues,
+		}
+		if r.Interval, err = strconv.ParseInt(rows[i].Interval, 10, 64); err != nil {
+			return nil, fmt.Errorf("candle %v: %w", r.ID, err)
+		}
+		if r.Timestamp, err = parseSQLiteTime(rows[i].Timestamp); err != nil {
+			return nil, fmt.Errorf("candle %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *candleRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.CandleExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Candle{
+		ID:               r.ID,
+		ExchangeNameID:   r.ExchangeNameID,
+		Base:             r.Base,
+		Quote:            r.Quote,
+		Interval:         strconv.FormatInt(r.Interval, 10),
+		Timestamp:        formatSQLiteTime(r.Timestamp),
+		Open:             r.Open,
+		High:             r.High,
+		Low:              r.Low,
+		Close:            r.Close,
+		Volume:           r.Volume,
+		Asset:            r.Asset,
+		SourceJobID:      r.SourceJobID,
+		ValidationJobID:  r.ValidationJobID,
+		ValidationIssues: r.ValidationIssues,
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteTrades(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := modelSQLite.Trades(pageMods(offset, limit, "id")...).All(ctx, exec)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]record, len(rows))
+	for i := range rows {
+		r := &tradeRecord{
+			ID:             rows[i].ID,
+			ExchangeNameID: rows[i].ExchangeNameID,
+			Tid:            rows[i].Tid,
+			Base:           rows[i].Base,
+			Quote:          rows[i].Quote,
+			Asset:          rows[i].Asset,
+			Price:          rows[i].Price,
+			Amount:         rows[i].Amount,
+			Side:           rows[i].Side,
+		}
+		if r.Timestamp, err = parseSQLiteTime(rows[i].Timestamp); err != nil {
+			return nil, fmt.Errorf("trade %v: %w", r.ID, err)
+		}
+		resp[i] = r
+	}
+	return resp, nil
+}
+
+func (r *tradeRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	exists, err := modelSQLite.TradeExists(ctx, exec, r.ID)
+	if err != nil || exists {
+		return false, err
+	}
+	row := &modelSQLite.Trade{
+		ID:             r.ID,
+		ExchangeNameID: r.ExchangeNameID,
+		Tid:            r.Tid,
+		Base:           r.Base,
+		Quote:          r.Quote,
+		Asset:          r.Asset,
+		Price:          r.Price,
+		Amount:         r.Amount,
+		Side:           r.Side,
+		Timestamp:      formatSQLiteTime(r.Timestamp),
+	}
+	return true, row.Insert(ctx, exec, boil.Infer())
+}
+
+func readSQLiteFundingRates(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, exchange_name_id, asset, base, quote, rate, timestamp FROM funding_rate ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &fundingRateRecord{}
+		var timestamp string
+		if err = rows.Scan(&r.ID, &r.ExchangeNameID, &r.Asset, &r.Base, &r.Quote, &r.Rate, &timestamp); err != nil {
+			return nil, err
+		}
+		if r.Timestamp, err = parseSQLiteTime(timestamp); err != nil {
+			return nil, fmt.Errorf("funding rate %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *fundingRateRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT INTO funding_rate (id, exchange_name_id, asset, base, quote, rate, timestamp) VALUES (?, ?, ?, ?, ?, ?, ?) ON CONFLICT DO NOTHING",
+		r.ID,
+		r.ExchangeNameID,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.Rate,
+		formatSQLiteTime(r.Timestamp))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteForexRates(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, base, quote, rate, date, source FROM forex_rate ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &forexRateRecord{}
+		var date string
+		if err = rows.Scan(&r.ID, &r.Base, &r.Quote, &r.Rate, &date, &r.Source); err != nil {
+			return nil, err
+		}
+		if r.Date, err = parseSQLiteTime(date); err != nil {
+			return nil, fmt.Errorf("forex rate %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+// writeSQLite uses OR IGNORE as the unique constraint of the table replaces
+// conflicting rows instead of rejecting them
+func (r *forexRateRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO forex_rate (id, base, quote, rate, date, source) VALUES (?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.Base,
+		r.Quote,
+		r.Rate,
+		formatSQLiteTime(r.Date),
+		r.Source)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteNAVSnapshots(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, date, reporting_currency, nav, realised_pnl, unrealised_pnl, fees, funding FROM nav_snapshot ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &navSnapshotRecord{}
+		var date string
+		if err = rows.Scan(&r.ID, &date, &r.ReportingCurrency, &r.NAV, &r.RealisedPNL, &r.UnrealisedPNL, &r.Fees, &r.Funding); err != nil {
+			return nil, err
+		}
+		if r.Date, err = parseSQLiteTime(date); err != nil {
+			return nil, fmt.Errorf("nav snapshot %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+// writeSQLite uses OR IGNORE as the unique constraint of the table replaces
+// conflicting rows instead of rejecting them
+func (r *navSnapshotRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO nav_snapshot (id, date, reporting_currency, nav, realised_pnl, unrealised_pnl, fees, funding) VALUES (?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID,
+		formatSQLiteTime(r.Date),
+		r.ReportingCurrency,
+		r.NAV,
+		r.RealisedPNL,
+		r.UnrealisedPNL,
+		r.Fees,
+		r.Funding)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteLedgerEntries(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, entry_key, exchange_name, sub_account, asset, kind, reference, currency, amount, occurred, description FROM ledger_entry ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &ledgerEntryRecord{}
+		var occurred string
+		if err = rows.Scan(&r.ID, &r.EntryKey, &r.ExchangeName, &r.SubAccount, &r.Asset, &r.Kind, &r.Reference, &r.Currency, &r.Amount, &occurred, &r.Description); err != nil {
+			return nil, err
+		}
+		if r.Occurred, err = parseSQLiteTime(occurred); err != nil {
+			return nil, fmt.Errorf("ledger entry %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+// writeSQLite keeps the padded nanosecond timestamps written by the ledger
+// repository so entries keep sorting in the order they occurred
+func (r *ledgerEntryRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO ledger_entry (id, entry_key, exchange_name, sub_account, asset, kind, reference, currency, amount, occurred, description) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.EntryKey,
+		r.ExchangeName,
+		r.SubAccount,
+		r.Asset,
+		r.Kind,
+		r.Reference,
+		r.Currency,
+		r.Amount.String(),
+		r.Occurred.UTC().Format("2006-01-02T15:04:05.000000000Z07:00"),
+		r.Description)
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteAccountingEvents(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, reporting_currency, occurred, kind, exchange_name, asset, base, quote, order_id, side, amount, value, realised_pnl, fees, funding, unmatched FROM accounting_event ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingEventRecord{}
+		var occurred string
+		if err = rows.Scan(&r.ID, &r.ReportingCurrency, &occurred, &r.Kind, &r.ExchangeName, &r.Asset, &r.Base, &r.Quote, &r.OrderID, &r.Side, &r.Amount, &r.Value, &r.RealisedPNL, &r.Fees, &r.Funding, &r.Unmatched); err != nil {
+			return nil, err
+		}
+		if r.Occurred, err = parseSQLiteTime(occurred); err != nil {
+			return nil, fmt.Errorf("accounting event %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingEventRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO accounting_event (id, reporting_currency, occurred, kind, exchange_name, asset, base, quote, order_id, side, amount, value, realised_pnl, fees, funding, unmatched) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.ReportingCurrency,
+		formatSQLiteTime(r.Occurred),
+		r.Kind,
+		r.ExchangeName,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.OrderID,
+		r.Side,
+		r.Amount.String(),
+		r.Value.String(),
+		r.RealisedPNL.String(),
+		r.Fees.String(),
+		r.Funding.String(),
+		r.Unmatched.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteAccountingLots(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, reporting_currency, exchange_name, currency, sequence, reference, acquired, amount, cost FROM accounting_lot ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingLotRecord{}
+		var acquired string
+		if err = rows.Scan(&r.ID, &r.ReportingCurrency, &r.ExchangeName, &r.Currency, &r.Sequence, &r.Reference, &acquired, &r.Amount, &r.Cost); err != nil {
+			return nil, err
+		}
+		if r.Acquired, err = parseSQLiteTime(acquired); err != nil {
+			return nil, fmt.Errorf("accounting lot %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+func (r *accountingLotRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO accounting_lot (id, reporting_currency, exchange_name, currency, sequence, reference, acquired, amount, cost) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.ReportingCurrency,
+		r.ExchangeName,
+		r.Currency,
+		r.Sequence,
+		r.Reference,
+		formatSQLiteTime(r.Acquired),
+		r.Amount.String(),
+		r.Cost.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteAccountingOrders(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, order_key, executed, quote, fee, finished FROM accounting_order ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingOrderRecord{}
+		var finished null.String
+		if err = rows.Scan(&r.ID, &r.OrderKey, &r.Executed, &r.Quote, &r.Fee, &finished); err != nil {
+			return nil, err
+		}
+		if r.Finished, err = parseSQLiteTime(finished.String); err != nil {
+			return nil, fmt.Errorf("accounting order %v: %w", r.ID, err)
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+// writeSQLite uses OR IGNORE as the unique constraint of the table replaces
+// conflicting rows instead of rejecting them
+func (r *accountingOrderRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO accounting_order (id, order_key, executed, quote, fee, finished) VALUES (?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.OrderKey,
+		r.Executed.String(),
+		r.Quote.String(),
+		r.Fee.String(),
+		null.NewString(formatSQLiteTime(r.Finished), !r.Finished.IsZero()))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
+
+func readSQLiteAccountingPositions(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	rows, err := exec.QueryContext(ctx,
+		"SELECT id, position_key, exchange_name, asset, base, quote, settlement, realised_gross, fees, funding FROM accounting_position ORDER BY id LIMIT ? OFFSET ?",
+		limit,
+		offset)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &accountingPositionRecord{}
+		if err = rows.Scan(&r.ID, &r.PositionKey, &r.ExchangeName, &r.Asset, &r.Base, &r.Quote, &r.Settlement, &r.RealisedGross, &r.Fees, &r.Funding); err != nil {
+			return nil, err
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
+
+// writeSQLite uses OR IGNORE as the unique constraint of the table replaces
+// conflicting rows instead of rejecting them
+func (r *accountingPositionRecord) writeSQLite(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	result, err := exec.ExecContext(ctx,
+		"INSERT OR IGNORE INTO accounting_position (id, position_key, exchange_name, asset, base, quote, settlement, realised_gross, fees, funding) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		r.ID,
+		r.PositionKey,
+		r.ExchangeName,
+		r.Asset,
+		r.Base,
+		r.Quote,
+		r.Settlement,
+		r.RealisedGross.String(),
+		r.Fees.String(),
+		r.Funding.String())
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	return affected > 0, err
+}
@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+var (
+	errUnsupportedDriver = errors.New("unsupported database driver")
+	errUnknownTable      = errors.New("unknown table")
+)
+
+type readFunc func(ctx context.Context, exec boil.ContextExecutor, offset, limit int) ([]record, error)
+
+// table describes how a single table is read from each supported driver
+type table struct {
+	name string
+	// serial tables have postgres sequences which must be moved past copied
+	// IDs once the table is complete
+	serial       bool
+	newRecord    func() record
+	readSQLite   readFunc
+	readPostgres readFunc
+}
+
+// tables holds every copied table ordered so that rows are always inserted
+// after the rows they reference
+var tables = []table{
+	{name: "exchange", newRecord: func() record { return &exchangeRecord{} }, readSQLite: readSQLiteExchanges, readPostgres: readPostgresExchanges},
+	{name: "script", newRecord: func() record { return &scriptRecord{} }, readSQLite: readSQLiteScripts, readPostgres: readPostgresScripts},
+	{name: "script_execution", newRecord: func() record { return &scriptExecutionRecord{} }, readSQLite: readSQLiteScriptExecutions, readPostgres: readPostgresScriptExecutions},
+	{name: "audit_event", serial: true, newRecord: func() record { return &auditEventRecord{} }, readSQLite: readSQLiteAuditEvents, readPostgres: readPostgresAuditEvents},
+	{name: "withdrawal_history", newRecord: func() record { return &withdrawalHistoryRecord{} }, readSQLite: readSQLiteWithdrawalHistory, readPostgres: readPostgresWithdrawalHistory},
+	{name: "withdrawal_crypto", serial: true, newRecord: func() record { return &withdrawalCryptoRecord{} }, readSQLite: readSQLiteWithdrawalCrypto, readPostgres: readPostgresWithdrawalCrypto},
+	{name: "withdrawal_fiat", serial: true, newRecord: func() record { return &withdrawalFiatRecord{} }, readSQLite: readSQLiteWithdrawalFiat, readPostgres: readPostgresWithdrawalFiat},
+	{name: "datahistoryjob", newRecord: func() record { return &dataHistoryJobRecord{} }, readSQLite: readSQLiteDataHistoryJobs, readPostgres: readPostgresDataHistoryJobs},
+	{name: "datahistoryjobrelations", newRecord: func() record { return &dataHistoryJobRelationRecord{} }, readSQLite: readSQLiteDataHistoryJobRelations, readPostgres: readPostgresDataHistoryJobRelations},
+	{name: "datahistoryjobresult", newRecord: func() record { return &dataHistoryJobResultRecord{} }, readSQLite: readSQLiteDataHistoryJobResults, readPostgres: readPostgresDataHistoryJobResults},
+	{name: "candle", newRecord: func() record { return &candleRecord{} }, readSQLite: readSQLiteCandles, readPostgres: readPostgresCandles},
+	{name: "trade", newRecord: func() record { return &tradeRecord{} }, readSQLite: readSQLiteTrades, readPostgres: readPostgresTrades},
+	{name: "funding_rate", newRecord: func() record { return &fundingRateRecord{} }, readSQLite: readSQLiteFundingRates, readPostgres: readPostgresFundingRates},
+	{name: "forex_rate", newRecord: func() record { return &forexRateRecord{} }, readSQLite: readSQLiteForexRates, readPostgres: readPostgresForexRates},
+	{name: "nav_snapshot", newRecord: func() record { return &navSnapshotRecord{} }, readSQLite: readSQLiteNAVSnapshots, readPostgres: readPostgresNAVSnapshots},
+	{name: "ledger_entry", newRecord: func() record { return &ledgerEntryRecord{} }, readSQLite: readSQLiteLedgerEntries, readPostgres: readPostgresLedgerEntries},
+	{name: "accounting_event", newRecord: func() record { return &accountingEventRecord{} }, readSQLite: readSQLiteAccountingEvents, readPostgres: readPostgresAccountingEvents},
+	{name: "accounting_lot", newRecord: func() record { return &accountingLotRecord{} }, readSQLite: readSQLiteAccountingLots, readPostgres: readPostgresAccountingLots},
+	{name: "accounting_order", newRecord: func() record { return &accountingOrderRecord{} }, readSQLite: readSQLiteAccountingOrders, readPostgres: readPostgresAccountingOrders},
+	{name: "accounting_position", newRecord: func() record { return &accountingPositionRecord{} }, readSQLite: readSQLiteAccountingPositions, readPostgres: readPostgresAccountingPositions},
+}
+
+// endpoint is an open connection to a source or destination database
+type endpoint struct {
+	driver string
+	// name identifies the database in checkpoints and output without exposing
+	// credentials
+	name string
+	db   *sql.DB
+}
+
+func (e *endpoint) isSQLite() bool {
+	return e.driver == database.DBSQLite || e.driver == database.DBSQLite3
+}
+
+func (e *endpoint) validate() error {
+	if e.isSQLite() || e.driver == database.DBPostgreSQL {
+		return nil
+	}
+	return fmt.Errorf("%w %q", errUnsupportedDriver, e.driver)
+}
+
+// read returns up to limit records from the table ordered by primary key
+func (t *table) read(ctx context.Context, e *endpoint, exec boil.ContextExecutor, offset, limit int) ([]record, error) {
+	if e.isSQLite() {
+		return t.readSQLite(ctx, exec, offset, limit)
+	}
+	return t.readPostgres(ctx, exec, offset, limit)
+}
+
+// finalise runs any driver specific clean up once a table has been written
+func (t *table) finalise(ctx context.Context, e *endpoint, exec boil.ContextExecutor) error {
+	if !t.serial || e.isSQLite() {
+		return nil
+	}
+	return resetPostgresSequence(ctx, exec, t.name)
+}
+
+func writeRecord(ctx context.Context, e *endpoint, exec boil.ContextExecutor, r record) (bool, error) {
+	if e.isSQLite() {
+		return r.writeSQLite(ctx, exec)
+	}
+	return r.writePostgres(ctx, exec)
+}
+
+func getTable(name string) (*table, error) {
+	for i := range tables {
+		if tables[i].name == name {
+			return &tables[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownTable, name)
+}
+
+func pageMods(offset, limit int, orderBy string) []qm.QueryMod {
+	return []qm.QueryMod{
+		qm.OrderBy(orderBy),
+		qm.Limit(limit),
+		qm.Offset(offset),
+	}
+}
+
+func scanDataHistoryJobRelations(rows *sql.Rows) ([]record, error) {
+	defer rows.Close()
+	var resp []record
+	for rows.Next() {
+		r := &dataHistoryJobRelationRecord{}
+		if err := rows.Scan(&r.PrerequisiteJobID, &r.JobID); err != nil {
+			return nil, err
+		}
+		resp = append(resp, r)
+	}
+	return resp, rows.Err()
+}
@@ -18,19 +18,26 @@ var (
 )
 
 func load(c *cli.Context) error {
+	_, err := loadWithConfig(c)
+	return err
+}
+
+// loadWithConfig connects to the database and returns the loaded config for
+// commands which need further settings
+func loadWithConfig(c *cli.Context) (*config.Config, error) {
 	var conf config.Config
 	err := conf.LoadConfig(c.String("config"), true)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	if !conf.Database.Enabled {
-		return database.ErrDatabaseSupportDisabled
+		return nil, database.ErrDatabaseSupportDisabled
 	}
 
 	err = openDBConnection(c, &conf.Database)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
 	drv := repository.GetSQLDialect()
@@ -40,7 +47,7 @@ func load(c *cli.Context) error {
 		fmt.Printf("Connected to: %s\n", conf.Database.Host)
 	}
 
-	return nil
+	return &conf, nil
 }
 
 func openDBConnection(c *cli.Context, cfg *database.Config) (err error) {
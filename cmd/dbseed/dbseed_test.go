@@ -1,11 +1,14 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 	"github.com/urfave/cli/v2"
 )
 
@@ -30,6 +33,7 @@ var (
 		Commands: []*cli.Command{
 			seedExchangeCommand,
 			seedCandleCommand,
+			seedForexCommand,
 		},
 	}
 )
@@ -42,3 +46,37 @@ func TestLoad(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+type fakeHistoricalProvider struct {
+	base.IFXProvider
+	rates map[string]float64
+	err   error
+}
+
+func (f *fakeHistoricalProvider) GetName() string {
+	return "fake"
+}
+
+func (f *fakeHistoricalProvider) GetRatesForDate(time.Time, string, string) (map[string]float64, error) {
+	return f.rates, f.err
+}
+
+func TestBackfillForexRates(t *testing.T) {
+	day := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	errTest := errors.New("test error")
+	_, err := backfillForexRates(&fakeHistoricalProvider{err: errTest}, "USD", "", day, day)
+	if !errors.Is(err, errTest) {
+		t.Errorf("received '%v' expected '%v'", err, errTest)
+	}
+	_, err = backfillForexRates(&fakeHistoricalProvider{rates: map[string]float64{"USDAUDX": 1}}, "USD", "", day, day)
+	if !errors.Is(err, errInvalidForexRateKey) {
+		t.Errorf("received '%v' expected '%v'", err, errInvalidForexRateKey)
+	}
+	total, err := backfillForexRates(&fakeHistoricalProvider{rates: map[string]float64{"USDUSD": 1}}, "USD", "", day, day.AddDate(0, 0, 2))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v' expected '%v'", err, nil)
+	}
+	if total != 0 {
+		t.Errorf("received '%v' expected '%v'", total, 0)
+	}
+}
@@ -0,0 +1,193 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider"
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/forexrate"
+	"github.com/urfave/cli/v2"
+)
+
+var errInvalidForexRateKey = errors.New("invalid forex rate key")
+
+var seedForexCommand = &cli.Command{
+	Name:  "forex",
+	Usage: "seed daily historical forex rate data",
+	Subcommands: []*cli.Command{
+		{
+			Name:  "file",
+			Usage: "seed forex rate data from a file",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:      "filename",
+					Usage:     "csv file to load forex rate data from (see readme for formatting details)",
+					TakesFile: true,
+					FilePath:  workingDir,
+				},
+				&cli.StringFlag{
+					Name:  "source",
+					Usage: "source of the supplied forex rate data",
+					Value: "csv",
+				},
+			},
+			Action: seedForexFromFile,
+		},
+		{
+			Name:  "backfill",
+			Usage: "seed forex rate data from a provider which supports historical rates",
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:  "provider",
+					Usage: "forex provider name, settings such as API keys are loaded from the config",
+					Value: currency.DefaultForexProviderExchangeRatesAPI,
+				},
+				&cli.StringFlag{
+					Name:  "base",
+					Usage: "base currency of the rates",
+					Value: currency.USD.String(),
+				},
+				&cli.StringFlag{
+					Name:  "symbols",
+					Usage: "comma separated currencies to retrieve rates for, all provider currencies when unset",
+				},
+				&cli.StringFlag{
+					Name:  "start",
+					Usage: "first day to retrieve rates for, formatted as " + currency.HistoricalRateDateLayout,
+				},
+				&cli.StringFlag{
+					Name:  "end",
+					Usage: "last day to retrieve rates for, formatted as " + currency.HistoricalRateDateLayout,
+					Value: time.Now().UTC().Format(currency.HistoricalRateDateLayout),
+				},
+			},
+			Action: seedForexFromProvider,
+		},
+	},
+}
+
+func seedForexFromFile(c *cli.Context) error {
+	if c.NumFlags() == 0 && c.NArg() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var fileName string
+	if c.IsSet("filename") {
+		fileName = c.String("filename")
+	} else if c.Args().Get(0) != "" {
+		fileName = c.Args().Get(0)
+	}
+
+	_, err := os.Stat(fileName)
+	if err != nil {
+		return err
+	}
+
+	err = load(c)
+	if err != nil {
+		return err
+	}
+
+	totalInserted, err := forexrate.InsertFromCSV(fileName, c.String("source"))
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Inserted: %v records", totalInserted)
+	return nil
+}
+
+func seedForexFromProvider(c *cli.Context) error {
+	if c.NumFlags() == 0 && c.NArg() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	start, err := time.Parse(currency.HistoricalRateDateLayout, c.String("start"))
+	if err != nil {
+		return fmt.Errorf("invalid start date: %w", err)
+	}
+	end, err := time.Parse(currency.HistoricalRateDateLayout, c.String("end"))
+	if err != nil {
+		return fmt.Errorf("invalid end date: %w", err)
+	}
+	if start.After(end) {
+		return errors.New("start date cannot be after end date")
+	}
+
+	conf, err := loadWithConfig(c)
+	if err != nil {
+		return err
+	}
+
+	settings := base.Settings{
+		Name:    c.String("provider"),
+		Enabled: true,
+	}
+	for i := range conf.Currency.ForexProviders {
+		if strings.EqualFold(conf.Currency.ForexProviders[i].Name, settings.Name) {
+			settings = base.Settings(conf.Currency.ForexProviders[i])
+			settings.Enabled = true
+			break
+		}
+	}
+	provider, err := forexprovider.NewHistoricalProvider(settings)
+	if err != nil {
+		return err
+	}
+
+	totalInserted, err := backfillForexRates(provider,
+		strings.ToUpper(c.String("base")),
+		strings.ToUpper(c.String("symbols")),
+		start,
+		end)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("Inserted: %v records", totalInserted)
+	return nil
+}
+
+// backfillForexRates retrieves and stores the rates of each day between the
+// start and end dates inclusive
+func backfillForexRates(provider base.IFXHistoricalProvider, baseCurrency, symbols string, start, end time.Time) (uint64, error) {
+	var total uint64
+	for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+		rates, err := provider.GetRatesForDate(day, baseCurrency, symbols)
+		if err != nil {
+			return total, fmt.Errorf("%v %v: %w", provider.GetName(), day.Format(currency.HistoricalRateDateLayout), err)
+		}
+		data := make([]forexrate.Data, 0, len(rates))
+		for k, v := range rates {
+			// rate keys are formatted as the base and quote currency
+			// codes, such as USDAUD
+			if len(k) != 6 {
+				return total, fmt.Errorf("%w %v", errInvalidForexRateKey, k)
+			}
+			if v <= 0 || strings.EqualFold(k[:3], k[3:]) {
+				continue
+			}
+			data = append(data, forexrate.Data{
+				Base:   k[:3],
+				Quote:  k[3:],
+				Rate:   v,
+				Date:   day,
+				Source: provider.GetName(),
+			})
+		}
+		if len(data) == 0 {
+			continue
+		}
+		if err = forexrate.Insert(data...); err != nil {
+			return total, err
+		}
+		total += uint64(len(data))
+	}
+	return total, nil
+}
@@ -31,6 +31,7 @@ var (
 		Commands: []*cli.Command{
 			seedExchangeCommand,
 			seedCandleCommand,
+			seedForexCommand,
 		},
 	}
 	workingDir string
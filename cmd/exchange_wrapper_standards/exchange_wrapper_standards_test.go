@@ -491,6 +491,7 @@ var excludedMethodNames = map[string]struct{}{
 	"UpdateCurrencyStates":           {}, // Not widely supported/implemented feature
 	"UpdateOrderExecutionLimits":     {}, // Not widely supported/implemented feature
 	"CheckOrderExecutionLimits":      {}, // Not widely supported/implemented feature
+	"GetInstrument":                  {}, // Populated by UpdateOrderExecutionLimits
 	"CanTradePair":                   {}, // Not widely supported/implemented feature
 	"CanTrade":                       {}, // Not widely supported/implemented feature
 	"CanWithdraw":                    {}, // Not widely supported/implemented feature
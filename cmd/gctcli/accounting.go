@@ -0,0 +1,295 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+// taxYear is the previous calendar year, the default range of a tax report
+var taxYear = time.Date(time.Now().Year()-1, 1, 1, 0, 0, 0, 0, time.Local)
+
+var accountingCommands = &cli.Command{
+	Name:      "accounting",
+	Usage:     "PNL and net asset value from the accounting manager and tax reports",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "getpnlreport",
+			Aliases:   []string{"pnl"},
+			Usage:     "returns the realised and unrealised PNL, fees and funding over a time range in the reporting currency",
+			ArgsUsage: "<start> <end> <exchange> <asset> <currency>",
+			Action:    getPNLReport,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "start",
+					Usage:       "the start of the range, realised PNL before this date is excluded",
+					Value:       time.Now().AddDate(0, 0, -1).Format(time.RFC3339),
+					Destination: &startTime,
+				},
+				&cli.StringFlag{
+					Name:        "end",
+					Usage:       "the end of the range, realised PNL after this date is excluded",
+					Value:       time.Now().Format(time.RFC3339),
+					Destination: &endTime,
+				},
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only return an exchange",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "optional - only return an asset type",
+				},
+				&cli.StringFlag{
+					Name:    "currency",
+					Aliases: []string{"c"},
+					Usage:   "optional - only return a currency eg BTC",
+				},
+			},
+		},
+		{
+			Name:      "getnavhistory",
+			Aliases:   []string{"nav"},
+			Usage:     "returns the daily net asset value snapshots over a time range",
+			ArgsUsage: "<start> <end>",
+			Action:    getNAVHistory,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "start",
+					Usage:       "the start of the range",
+					Value:       time.Now().AddDate(0, -1, 0).Format(time.RFC3339),
+					Destination: &startTime,
+				},
+				&cli.StringFlag{
+					Name:        "end",
+					Usage:       "the end of the range",
+					Value:       time.Now().Format(time.RFC3339),
+					Destination: &endTime,
+				},
+			},
+		},
+		{
+			Name:      "gettaxreport",
+			Aliases:   []string{"tax"},
+			Usage:     "returns the capital gains and income of a range from exchange order, funding and withdrawal history, optionally writing CSV exports",
+			ArgsUsage: "<start> <end> <method> <base> <output>",
+			Action:    getTaxReport,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:        "start",
+					Usage:       "the start of the range, defaults to the start of the previous year",
+					Value:       taxYear.Format(time.RFC3339),
+					Destination: &startTime,
+				},
+				&cli.StringFlag{
+					Name:        "end",
+					Usage:       "the end of the range, defaults to the end of the previous year",
+					Value:       taxYear.AddDate(1, 0, 0).Add(-time.Second).Format(time.RFC3339),
+					Destination: &endTime,
+				},
+				&cli.StringFlag{
+					Name:    "method",
+					Aliases: []string{"m"},
+					Usage:   "optional - the cost basis method, fifo, lifo, hifo or average. Defaults to the accounting config",
+				},
+				&cli.StringFlag{
+					Name:    "base",
+					Aliases: []string{"b"},
+					Usage:   "optional - the fiat currency values are reported in, defaults to USD",
+				},
+				&cli.StringFlag{
+					Name:    "output",
+					Aliases: []string{"o"},
+					Usage:   "optional - a directory to write capital_gains.csv, income.csv and ledger.csv to",
+				},
+				&cli.StringFlag{
+					Name:  "historystart",
+					Usage: "optional - when to retrieve history from so earlier acquisitions can be matched, defaults to the start",
+				},
+				&cli.StringFlag{
+					Name:    "exchanges",
+					Aliases: []string{"e"},
+					Usage:   "optional - a comma separated list of exchanges, defaults to every exchange supporting authenticated requests",
+				},
+			},
+		},
+	},
+}
+
+// parseAccountingRange parses the start and end arguments into the RPC time
+// format
+func parseAccountingRange(c *cli.Context) (start, end string, err error) {
+	if !c.IsSet("start") && c.Args().Get(0) != "" {
+		startTime = c.Args().Get(0)
+	}
+	if !c.IsSet("end") && c.Args().Get(1) != "" {
+		endTime = c.Args().Get(1)
+	}
+	s, err := time.ParseInLocation(time.RFC3339, startTime, time.Local)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.ParseInLocation(time.RFC3339, endTime, time.Local)
+	if err != nil {
+		return "", "", fmt.Errorf("invalid time format for end: %v", err)
+	}
+	if e.Before(s) {
+		return "", "", errors.New("start cannot be after end")
+	}
+	return s.Format(common.SimpleTimeFormatWithTimezone), e.Format(common.SimpleTimeFormatWithTimezone), nil
+}
+
+func getPNLReport(c *cli.Context) error {
+	start, end, err := parseAccountingRange(c)
+	if err != nil {
+		return err
+	}
+	var exchangeName, assetType, code string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(2)
+	}
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(3)
+	}
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+	if c.IsSet("currency") {
+		code = c.String("currency")
+	} else {
+		code = c.Args().Get(4)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPNLReport(c.Context,
+		&gctrpc.GetPNLReportRequest{
+			Start:    start,
+			End:      end,
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Currency: code,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getNAVHistory(c *cli.Context) error {
+	start, end, err := parseAccountingRange(c)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetNAVHistory(c.Context,
+		&gctrpc.GetNAVHistoryRequest{
+			Start: start,
+			End:   end,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getTaxReport(c *cli.Context) error {
+	start, end, err := parseAccountingRange(c)
+	if err != nil {
+		return err
+	}
+	var method, base, output string
+	if c.IsSet("method") {
+		method = c.String("method")
+	} else {
+		method = c.Args().Get(2)
+	}
+	if c.IsSet("base") {
+		base = c.String("base")
+	} else {
+		base = c.Args().Get(3)
+	}
+	if c.IsSet("output") {
+		output = c.String("output")
+	} else {
+		output = c.Args().Get(4)
+	}
+	var historyStart string
+	if c.IsSet("historystart") {
+		h, parseErr := time.ParseInLocation(time.RFC3339, c.String("historystart"), time.Local)
+		if parseErr != nil {
+			return fmt.Errorf("invalid time format for history start: %v", parseErr)
+		}
+		historyStart = h.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	var exchanges []string
+	if c.IsSet("exchanges") {
+		exchanges = strings.Split(c.String("exchanges"), ",")
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetTaxReport(c.Context,
+		&gctrpc.GetTaxReportRequest{
+			Start:           start,
+			End:             end,
+			HistoryStart:    historyStart,
+			CostBasisMethod: method,
+			BaseCurrency:    base,
+			Exchanges:       exchanges,
+		})
+	if err != nil {
+		return err
+	}
+
+	if output != "" {
+		for name, data := range map[string]string{
+			"capital_gains.csv": result.CapitalGainsCsv,
+			"income.csv":        result.IncomeCsv,
+			"ledger.csv":        result.LedgerCsv,
+		} {
+			if err = file.Write(filepath.Join(output, name), []byte(data)); err != nil {
+				return err
+			}
+		}
+		result.CapitalGainsCsv, result.IncomeCsv, result.LedgerCsv = "", "", ""
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var basisCommands = &cli.Command{
+	Name:      "basis",
+	Usage:     "futures basis and term structure from the basis service",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "gettermstructure",
+			Aliases:   []string{"term"},
+			Usage:     "returns the basis curve of each underlying on each exchange",
+			ArgsUsage: "<underlying> <exchange> <refresh>",
+			Action:    getBasisTermStructure,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "optional - only return an underlying currency eg BTC",
+				},
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only return an exchange",
+				},
+				&cli.BoolFlag{
+					Name:    "refresh",
+					Aliases: []string{"r"},
+					Usage:   "optional - recalculate the basis instead of returning the latest calculation",
+				},
+			},
+		},
+		{
+			Name:      "gethistory",
+			Aliases:   []string{"history"},
+			Usage:     "returns the basis history of matching contracts",
+			ArgsUsage: "<underlying> <exchange> <asset> <pair>",
+			Action:    getBasisHistory,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "optional - only return an underlying currency eg BTC",
+				},
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only return an exchange",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "optional - only return an asset type",
+				},
+				&cli.StringFlag{
+					Name:    "pair",
+					Aliases: []string{"p"},
+					Usage:   "optional - only return a contract eg BTCUSD-PERP",
+				},
+			},
+		},
+	},
+}
+
+func getBasisTermStructure(c *cli.Context) error {
+	var (
+		underlying, exchangeName string
+		refresh                  bool
+		err                      error
+	)
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().First()
+	}
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(1)
+	}
+	if c.IsSet("refresh") {
+		refresh = c.Bool("refresh")
+	} else if c.Args().Get(2) != "" {
+		refresh, err = strconv.ParseBool(c.Args().Get(2))
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetBasisTermStructure(c.Context,
+		&gctrpc.GetBasisTermStructureRequest{
+			Refresh:    refresh,
+			Underlying: underlying,
+			Exchange:   exchangeName,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getBasisHistory(c *cli.Context) error {
+	var underlying, exchangeName, assetType, currencyPair string
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().First()
+	}
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().Get(1)
+	}
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	if assetType != "" {
+		if err := isFuturesAsset(assetType); err != nil {
+			return err
+		}
+	}
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(3)
+	}
+	var pair *gctrpc.CurrencyPair
+	if currencyPair != "" {
+		if !validPair(currencyPair) {
+			return errInvalidPair
+		}
+		p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+		if err != nil {
+			return err
+		}
+		pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetBasisHistory(c.Context,
+		&gctrpc.GetBasisHistoryRequest{
+			Underlying: underlying,
+			Exchange:   exchangeName,
+			Asset:      assetType,
+			Pair:       pair,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -800,6 +800,47 @@ func getConfig(c *cli.Context) error {
 	return nil
 }
 
+var reloadConfigCommand = &cli.Command{
+	Name:      "reloadconfig",
+	Usage:     "reads the config file and applies supported changes to the running engine",
+	ArgsUsage: "<dryrun>",
+	Action:    reloadConfig,
+	Flags: []cli.Flag{
+		&cli.BoolFlag{
+			Name:  "dryrun",
+			Usage: "reports the changes found without applying them",
+		},
+	},
+}
+
+func reloadConfig(c *cli.Context) error {
+	var dryRun bool
+	if c.IsSet("dryrun") {
+		dryRun = c.Bool("dryrun")
+	} else if c.Args().First() != "" {
+		b, err := strconv.ParseBool(c.Args().First())
+		if err != nil {
+			return err
+		}
+		dryRun = b
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ReloadConfig(c.Context, &gctrpc.ReloadConfigRequest{DryRun: dryRun})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var getPortfolioCommand = &cli.Command{
 	Name:   "getportfolio",
 	Usage:  "gets the portfolio",
@@ -824,9 +865,16 @@ func getPortfolio(c *cli.Context) error {
 }
 
 var getPortfolioSummaryCommand = &cli.Command{
-	Name:   "getportfoliosummary",
-	Usage:  "gets the portfolio summary",
-	Action: getPortfolioSummary,
+	Name:      "getportfoliosummary",
+	Usage:     "gets the portfolio summary",
+	ArgsUsage: "<value_currency>",
+	Action:    getPortfolioSummary,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  "value_currency",
+			Usage: "optional fiat currency to value the portfolio in, eg USD",
+		},
+	},
 }
 
 func getPortfolioSummary(c *cli.Context) error {
@@ -836,8 +884,17 @@ func getPortfolioSummary(c *cli.Context) error {
 	}
 	defer closeConn(conn, cancel)
 
+	var valueCurrency string
+	if c.IsSet("value_currency") {
+		valueCurrency = c.String("value_currency")
+	} else {
+		valueCurrency = c.Args().First()
+	}
+
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
-	result, err := client.GetPortfolioSummary(c.Context, &gctrpc.GetPortfolioSummaryRequest{})
+	result, err := client.GetPortfolioSummary(c.Context, &gctrpc.GetPortfolioSummaryRequest{
+		ValueCurrency: valueCurrency,
+	})
 	if err != nil {
 		return err
 	}
@@ -3089,6 +3146,30 @@ var setLoggerDetailsCommand = &cli.Command{
 			Name:  "flags",
 			Usage: "pipe separated value of loggers e.g INFO|WARN",
 		},
+		&cli.StringFlag{
+			Name:  "format",
+			Usage: "output format: text, json or logfmt",
+		},
+		&cli.StringFlag{
+			Name:  "output",
+			Usage: "pipe separated outputs e.g stdout|file|syslog|shipper",
+		},
+		&cli.Uint64Flag{
+			Name:  "sampleinitial",
+			Usage: "number of events of the same kind written each sampling interval before sampling",
+		},
+		&cli.Uint64Flag{
+			Name:  "samplethereafter",
+			Usage: "write every nth event after the initial events, 0 drops them",
+		},
+		&cli.StringFlag{
+			Name:  "sampleinterval",
+			Usage: "sampling interval e.g 1s, sampling is enabled when set",
+		},
+		&cli.BoolFlag{
+			Name:  "disablesampling",
+			Usage: "disables sampling of the logger",
+		},
 	},
 }
 
@@ -3116,8 +3197,20 @@ func setLoggerDetails(c *cli.Context) error {
 		level = c.Args().Get(1)
 	}
 
-	if level == "" {
-		return errors.New("level must be specified")
+	var sampling *gctrpc.LoggerSampling
+	switch {
+	case c.Bool("disablesampling"):
+		sampling = &gctrpc.LoggerSampling{Disabled: true}
+	case c.IsSet("sampleinterval"):
+		sampling = &gctrpc.LoggerSampling{
+			Initial:    c.Uint64("sampleinitial"),
+			Thereafter: c.Uint64("samplethereafter"),
+			Interval:   c.String("sampleinterval"),
+		}
+	}
+
+	if level == "" && !c.IsSet("format") && !c.IsSet("output") && sampling == nil {
+		return errors.New("a level, format, output or sampling must be specified")
 	}
 
 	conn, cancel, err := setupClient(c)
@@ -3130,8 +3223,11 @@ func setLoggerDetails(c *cli.Context) error {
 
 	result, err := client.SetLoggerDetails(c.Context,
 		&gctrpc.SetLoggerDetailsRequest{
-			Logger: logger,
-			Level:  level,
+			Logger:   logger,
+			Level:    level,
+			Format:   c.String("format"),
+			Output:   c.String("output"),
+			Sampling: sampling,
 		},
 	)
 	if err != nil {
@@ -3317,6 +3413,126 @@ func getExchangeTickerStream(c *cli.Context) error {
 	}
 }
 
+var getCandleStreamCommand = &cli.Command{
+	Name:      "getcandlestream",
+	Usage:     "gets a stream of candles built from websocket trades by the candle builder",
+	ArgsUsage: "<exchange> <pair> <asset> <granularity> <closedonly>",
+	Action:    getCandleStream,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "the exchange to get the candles from",
+		},
+		&cli.StringFlag{
+			Name:  "pair",
+			Usage: "the currency pair to get the candles for",
+		},
+		&cli.StringFlag{
+			Name:  "asset",
+			Usage: "the asset type of the currency pair",
+		},
+		&cli.Int64Flag{
+			Name:    "granularity",
+			Aliases: []string{"g"},
+			Usage:   "interval in seconds, must be an interval the candle builder is configured to build",
+			Value:   60,
+		},
+		&cli.BoolFlag{
+			Name:  "closedonly",
+			Usage: "only stream candles once they have closed",
+		},
+	},
+}
+
+func getCandleStream(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	var pair string
+	if c.IsSet("pair") {
+		pair = c.String("pair")
+	} else {
+		pair = c.Args().Get(1)
+	}
+	if !validPair(pair) {
+		return errInvalidPair
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	granularity := c.Int64("granularity")
+	if !c.IsSet("granularity") && c.Args().Get(3) != "" {
+		var err error
+		granularity, err = strconv.ParseInt(c.Args().Get(3), 10, 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	closedOnly := c.Bool("closedonly")
+	if !c.IsSet("closedonly") && c.Args().Get(4) != "" {
+		var err error
+		closedOnly, err = strconv.ParseBool(c.Args().Get(4))
+		if err != nil {
+			return err
+		}
+	}
+
+	p, err := currency.NewPairDelimiter(pair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetCandleStream(c.Context,
+		&gctrpc.GetCandleStreamRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:    assetType,
+			TimeInterval: int64(time.Duration(granularity) * time.Second),
+			ClosedOnly:   closedOnly,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
+
 var getAuditEventCommand = &cli.Command{
 	Name:      "getauditevent",
 	Usage:     "gets audit events matching query parameters",
@@ -4179,6 +4395,177 @@ func getHistoricCandlesExtended(c *cli.Context) error {
 	return nil
 }
 
+var getAlternativeBarsCommand = &cli.Command{
+	Name:      "getalternativebars",
+	Usage:     "builds volume, dollar, tick, imbalance, heikin-ashi or renko bars from trades or candles for the specified pair, asset & date range",
+	ArgsUsage: "<exchange> <pair> <asset> <bartype> <threshold> <start> <end>",
+	Action:    getAlternativeBars,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "the exchange to get the data from",
+		},
+		&cli.StringFlag{
+			Name:    "pair",
+			Aliases: []string{"p"},
+			Usage:   "the currency pair to build bars for",
+		},
+		&cli.StringFlag{
+			Name:    "asset",
+			Aliases: []string{"a"},
+			Usage:   "the asset type of the currency pair",
+		},
+		&cli.StringFlag{
+			Name:    "bartype",
+			Aliases: []string{"b"},
+			Usage:   "the bar type: volume, dollar, tick, tick-imbalance, volume-imbalance, heikin-ashi or renko",
+		},
+		&cli.Float64Flag{
+			Name:    "threshold",
+			Aliases: []string{"t"},
+			Usage:   "the amount of activity which closes a bar, eg the volume per bar, trades per bar or renko brick size",
+		},
+		&cli.StringFlag{
+			Name:        "start",
+			Usage:       "the date to begin retrieving data",
+			Value:       time.Now().AddDate(0, 0, -1).Format(time.RFC3339),
+			Destination: &startTime,
+		},
+		&cli.StringFlag{
+			Name:        "end",
+			Usage:       "the date to end retrieving data",
+			Value:       time.Now().Format(time.RFC3339),
+			Destination: &endTime,
+		},
+		&cli.BoolFlag{
+			Name:  "trades",
+			Usage: "build bars from trades instead of candles, required for tick and imbalance bars <true/false>",
+		},
+		&cli.Int64Flag{
+			Name:        "interval",
+			Aliases:     []string{"i"},
+			Usage:       "the candle interval bars are built from when not using trades. " + klineMessage,
+			Value:       60,
+			Destination: &candleGranularity,
+		},
+		&cli.BoolFlag{
+			Name:  "db",
+			Usage: "source data from database <true/false>",
+		},
+	},
+}
+
+func getAlternativeBars(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(1)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	var assetType string
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(2)
+	}
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+
+	var barType string
+	if c.IsSet("bartype") {
+		barType = c.String("bartype")
+	} else {
+		barType = c.Args().Get(3)
+	}
+
+	var threshold float64
+	if c.IsSet("threshold") {
+		threshold = c.Float64("threshold")
+	} else if c.Args().Get(4) != "" {
+		threshold, err = strconv.ParseFloat(c.Args().Get(4), 64)
+		if err != nil {
+			return err
+		}
+	}
+
+	if !c.IsSet("start") {
+		if c.Args().Get(5) != "" {
+			startTime = c.Args().Get(5)
+		}
+	}
+	if !c.IsSet("end") {
+		if c.Args().Get(6) != "" {
+			endTime = c.Args().Get(6)
+		}
+	}
+	s, err := time.ParseInLocation(time.RFC3339, startTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for start: %v", err)
+	}
+	e, err := time.ParseInLocation(time.RFC3339, endTime, time.Local)
+	if err != nil {
+		return fmt.Errorf("invalid time format for end: %v", err)
+	}
+	if e.Before(s) {
+		return errors.New("start cannot be after end")
+	}
+
+	if c.IsSet("interval") {
+		candleGranularity = c.Int64("interval")
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetAlternativeBars(c.Context,
+		&gctrpc.GetAlternativeBarsRequest{
+			Exchange: exchangeName,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+			AssetType:    assetType,
+			Start:        s.Format(common.SimpleTimeFormatWithTimezone),
+			End:          e.Format(common.SimpleTimeFormatWithTimezone),
+			BarType:      barType,
+			Threshold:    threshold,
+			UseTrades:    c.Bool("trades"),
+			TimeInterval: int64(time.Duration(candleGranularity) * time.Second),
+			UseDb:        c.Bool("db"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
 var findMissingSavedCandleIntervalsCommand = &cli.Command{
 	Name:      "findmissingsavedcandleintervals",
 	Usage:     "will highlight any interval that is missing candle data so you can fill that gap",
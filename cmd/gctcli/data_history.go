@@ -147,6 +147,12 @@ var dataHistoryJobCommands = &cli.Command{
 			Flags:  append(baseJobSubCommands, secondaryValidationJobSubCommands...),
 			Action: upsertDataHistoryJob,
 		},
+		{
+			Name:   "savefundingrates",
+			Usage:  "will fetch perpetual futures funding rates from an exchange and save them to the database",
+			Flags:  append(baseJobSubCommands, fundingRateJobSubCommands...),
+			Action: upsertDataHistoryJob,
+		},
 	},
 }
 
@@ -283,6 +289,9 @@ var (
 			Usage: "if true, when the intolerance percentage is exceeded, then the comparison API candle will replace the database candle",
 		},
 	}
+	fundingRateJobSubCommands = []cli.Flag{
+		requestSize500Flag,
+	}
 	secondaryValidationJobSubCommands = []cli.Flag{
 		&cli.StringFlag{
 			Name:  "secondary_exchange",
@@ -461,6 +470,8 @@ func upsertDataHistoryJob(c *cli.Context) error {
 		dataType = 4
 	case "secondaryvalidatecandles":
 		dataType = 5
+	case "savefundingrates":
+		dataType = 6
 	default:
 		return errors.New("unrecognised command, cannot set data type")
 	}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var dataRetentionCommands = &cli.Command{
+	Name:      "dataretention",
+	Usage:     "view and run the retention policies applied to stored candle and trade data",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:   "status",
+			Usage:  "returns the retention policies and the report of the last run",
+			Action: getDataRetentionStatus,
+		},
+		{
+			Name:      "run",
+			Usage:     "applies the retention policies immediately and reports what was removed",
+			ArgsUsage: "<dryrun>",
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:  "dryrun",
+					Usage: "reports what would be removed and created without changing the database",
+				},
+			},
+			Action: runDataRetention,
+		},
+	},
+}
+
+func getDataRetentionStatus(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetDataRetentionStatus(c.Context,
+		&gctrpc.GetDataRetentionStatusRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func runDataRetention(c *cli.Context) error {
+	var dryRun bool
+	if c.IsSet("dryrun") {
+		dryRun = c.Bool("dryrun")
+	} else if c.Args().First() != "" {
+		b, err := strconv.ParseBool(c.Args().First())
+		if err != nil {
+			return err
+		}
+		dryRun = b
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.RunDataRetention(c.Context,
+		&gctrpc.RunDataRetentionRequest{DryRun: dryRun})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
@@ -298,6 +298,25 @@ var futuresCommands = &cli.Command{
 				},
 			},
 		},
+		{
+			Name:      "getfundingrateopportunities",
+			Aliases:   []string{"opportunities", "fro"},
+			Usage:     "returns cross-exchange funding rate opportunities found by the funding rate scanner",
+			ArgsUsage: "<underlying> <refresh>",
+			Action:    getFundingRateOpportunities,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "underlying",
+					Aliases: []string{"u"},
+					Usage:   "optional - only return opportunities for an underlying currency eg BTC",
+				},
+				&cli.BoolFlag{
+					Name:    "refresh",
+					Aliases: []string{"r"},
+					Usage:   "optional - run a new scan instead of returning the last scan",
+				},
+			},
+		},
 	},
 }
 
@@ -942,3 +961,44 @@ func getLatestFundingRate(c *cli.Context) error {
 	jsonOutput(result)
 	return nil
 }
+
+func getFundingRateOpportunities(c *cli.Context) error {
+	var (
+		underlying string
+		refresh    bool
+		err        error
+	)
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().First()
+	}
+
+	if c.IsSet("refresh") {
+		refresh = c.Bool("refresh")
+	} else if c.Args().Get(1) != "" {
+		refresh, err = strconv.ParseBool(c.Args().Get(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetFundingRateOpportunities(c.Context,
+		&gctrpc.GetFundingRateOpportunitiesRequest{
+			Refresh:    refresh,
+			Underlying: underlying,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
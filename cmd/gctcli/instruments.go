@@ -0,0 +1,240 @@
+package main
+
+import (
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var instrumentCommands = &cli.Command{
+	Name:      "instruments",
+	Usage:     "instrument specifications loaded by exchanges such as contract size, expiry and limits",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "list",
+			Usage:     "returns the instruments matching the supplied filters",
+			ArgsUsage: "<exchange> <asset> <base> <quote> <contracttype> <status>",
+			Action:    getInstruments,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only return an exchange",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "optional - only return an asset type",
+				},
+				&cli.StringFlag{
+					Name:  "base",
+					Usage: "optional - only return a base currency eg BTC",
+				},
+				&cli.StringFlag{
+					Name:  "quote",
+					Usage: "optional - only return a quote currency eg USDT",
+				},
+				&cli.StringFlag{
+					Name:    "contracttype",
+					Aliases: []string{"c"},
+					Usage:   "optional - only return a contract type: spot, perpetual, delivery or option",
+				},
+				&cli.StringFlag{
+					Name:    "status",
+					Aliases: []string{"s"},
+					Usage:   "optional - only return a status: pretrading, trading, halted, settling or delisted",
+				},
+			},
+		},
+		{
+			Name:      "get",
+			Usage:     "returns the instrument of an exchange asset and pair",
+			ArgsUsage: "<exchange> <asset> <pair>",
+			Action:    getInstrument,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "the exchange the instrument is listed on",
+				},
+				&cli.StringFlag{
+					Name:    "asset",
+					Aliases: []string{"a"},
+					Usage:   "the asset type of the instrument",
+				},
+				&cli.StringFlag{
+					Name:    "pair",
+					Aliases: []string{"p"},
+					Usage:   "the currency pair of the instrument",
+				},
+			},
+		},
+		{
+			Name:      "stream",
+			Usage:     "streams instruments as they are added or their specification changes",
+			ArgsUsage: "<exchange>",
+			Action:    getInstrumentStream,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only stream an exchange",
+				},
+			},
+		},
+	},
+}
+
+func getInstruments(c *cli.Context) error {
+	var exchangeName, assetType, base, quote, contractType, status string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	assetType = strings.ToLower(assetType)
+	if assetType != "" && !validAsset(assetType) {
+		return errInvalidAsset
+	}
+	if c.IsSet("base") {
+		base = c.String("base")
+	} else {
+		base = c.Args().Get(2)
+	}
+	if c.IsSet("quote") {
+		quote = c.String("quote")
+	} else {
+		quote = c.Args().Get(3)
+	}
+	if c.IsSet("contracttype") {
+		contractType = c.String("contracttype")
+	} else {
+		contractType = c.Args().Get(4)
+	}
+	if c.IsSet("status") {
+		status = c.String("status")
+	} else {
+		status = c.Args().Get(5)
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetInstruments(c.Context,
+		&gctrpc.GetInstrumentsRequest{
+			Exchange:     exchangeName,
+			Asset:        assetType,
+			Base:         base,
+			Quote:        quote,
+			ContractType: contractType,
+			Status:       status,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getInstrument(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+
+	var exchangeName, assetType, currencyPair string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if c.IsSet("asset") {
+		assetType = c.String("asset")
+	} else {
+		assetType = c.Args().Get(1)
+	}
+	assetType = strings.ToLower(assetType)
+	if !validAsset(assetType) {
+		return errInvalidAsset
+	}
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+	if !validPair(currencyPair) {
+		return errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetInstrument(c.Context,
+		&gctrpc.GetInstrumentRequest{
+			Exchange: exchangeName,
+			Asset:    assetType,
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: p.Delimiter,
+				Base:      p.Base.String(),
+				Quote:     p.Quote.String(),
+			},
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getInstrumentStream(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetInstrumentStream(c.Context,
+		&gctrpc.GetInstrumentStreamRequest{
+			Exchange: exchangeName,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
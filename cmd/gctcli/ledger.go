@@ -0,0 +1,73 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var ledgerCommands = &cli.Command{
+	Name:      "ledger",
+	Usage:     "reconciliation of exchange balances against the imported transaction ledger",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "getreconciliation",
+			Aliases:   []string{"reconcile"},
+			Usage:     "returns the expected and exchange balances of the last reconciliation and any discrepancies",
+			ArgsUsage: "<exchange> <refresh>",
+			Action:    getLedgerReconciliation,
+			Flags: []cli.Flag{
+				&cli.StringFlag{
+					Name:    "exchange",
+					Aliases: []string{"e"},
+					Usage:   "optional - only return an exchange",
+				},
+				&cli.BoolFlag{
+					Name:    "refresh",
+					Aliases: []string{"r"},
+					Usage:   "imports history and reconciles balances before returning",
+				},
+			},
+		},
+	},
+}
+
+func getLedgerReconciliation(c *cli.Context) error {
+	var exchangeName string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	var refresh bool
+	if c.IsSet("refresh") {
+		refresh = c.Bool("refresh")
+	} else if c.Args().Get(1) != "" {
+		var err error
+		refresh, err = strconv.ParseBool(c.Args().Get(1))
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetLedgerReconciliation(c.Context,
+		&gctrpc.GetLedgerReconciliationRequest{
+			Exchange: exchangeName,
+			Refresh:  refresh,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
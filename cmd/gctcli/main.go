@@ -2,7 +2,10 @@ package main
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -25,6 +28,9 @@ var (
 	host          string
 	username      string
 	password      string
+	token         string
+	clientCert    string
+	clientKey     string
 	pairDelimiter string
 	certPath      string
 	timeout       time.Duration
@@ -44,16 +50,20 @@ func jsonOutput(in interface{}) {
 }
 
 func setupClient(c *cli.Context) (*grpc.ClientConn, context.CancelFunc, error) {
-	creds, err := credentials.NewClientTLSFromFile(certPath, "")
+	creds, err := clientTransportCredentials()
 	if err != nil {
 		return nil, nil, err
 	}
 
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
+	switch {
+	case token != "":
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.TokenAuth{Token: token}))
+	case clientCert == "":
+		opts = append(opts, grpc.WithPerRPCCredentials(auth.BasicAuth{
 			Username: username,
 			Password: password,
-		}),
+		}))
 	}
 
 	var cancel context.CancelFunc
@@ -71,6 +81,34 @@ func setupClient(c *cli.Context) (*grpc.ClientConn, context.CancelFunc, error) {
 	return conn, cancel, err
 }
 
+// clientTransportCredentials trusts the gRPC server certificate and presents
+// a client certificate when one is supplied
+func clientTransportCredentials() (credentials.TransportCredentials, error) {
+	if clientCert == "" && clientKey == "" {
+		return credentials.NewClientTLSFromFile(certPath, "")
+	}
+	if clientCert == "" || clientKey == "" {
+		return nil, errors.New("both a client certificate and key must be supplied")
+	}
+	serverCert, err := os.ReadFile(certPath)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(serverCert) {
+		return nil, fmt.Errorf("no certificates found in %s", certPath)
+	}
+	cert, err := tls.LoadX509KeyPair(clientCert, clientKey)
+	if err != nil {
+		return nil, err
+	}
+	return credentials.NewTLS(&tls.Config{
+		RootCAs:      pool,
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}), nil
+}
+
 func main() {
 	app := cli.NewApp()
 	app.Name = "gctcli"
@@ -96,6 +134,21 @@ func main() {
 			Usage:       "the gRPC password",
 			Destination: &password,
 		},
+		&cli.StringFlag{
+			Name:        "rpctoken",
+			Usage:       "the gRPC API token, used instead of the username and password",
+			Destination: &token,
+		},
+		&cli.StringFlag{
+			Name:        "rpcclientcert",
+			Usage:       "the path to a client certificate used to authenticate instead of the username and password",
+			Destination: &clientCert,
+		},
+		&cli.StringFlag{
+			Name:        "rpcclientkey",
+			Usage:       "the path to the private key of the client certificate",
+			Destination: &clientKey,
+		},
 		&cli.StringFlag{
 			Name:        "delimiter",
 			Value:       "-",
@@ -175,6 +228,7 @@ func main() {
 		getAccountInfoStreamCommand,
 		updateAccountInfoCommand,
 		getConfigCommand,
+		reloadConfigCommand,
 		getPortfolioCommand,
 		getPortfolioSummaryCommand,
 		addPortfolioAddressCommand,
@@ -204,14 +258,24 @@ func main() {
 		exchangePairManagerCommand,
 		getTickerStreamCommand,
 		getExchangeTickerStreamCommand,
+		getCandleStreamCommand,
 		getAuditEventCommand,
 		getHistoricCandlesCommand,
 		getHistoricCandlesExtendedCommand,
+		getAlternativeBarsCommand,
 		findMissingSavedCandleIntervalsCommand,
 		gctScriptCommand,
 		websocketManagerCommand,
 		tradeCommand,
 		dataHistoryCommands,
+		dataRetentionCommands,
+		optionsCommands,
+		basisCommands,
+		accountingCommands,
+		ledgerCommands,
+		rebalanceCommands,
+		instrumentCommands,
+		tailCommands,
 		currencyStateManagementCommand,
 		futuresCommands,
 		shutdownCommand,
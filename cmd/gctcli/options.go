@@ -0,0 +1,164 @@
+package main
+
+import (
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var optionsPricingFlags = []cli.Flag{
+	&cli.StringFlag{
+		Name:    "exchange",
+		Aliases: []string{"e"},
+		Usage:   "the exchange to retrieve options contracts from",
+	},
+	&cli.StringFlag{
+		Name:    "underlying",
+		Aliases: []string{"u"},
+		Usage:   "optional - the underlying pair eg BTC-USD, empty returns every underlying",
+	},
+	&cli.Float64Flag{
+		Name:    "riskfreerate",
+		Aliases: []string{"r"},
+		Usage:   "optional - the annualised risk free rate as a decimal eg 0.05",
+	},
+	&cli.Float64Flag{
+		Name:    "underlyingprice",
+		Aliases: []string{"price"},
+		Usage:   "optional - the underlying price, defaults to the exchange's spot ticker",
+	},
+}
+
+var optionsCommands = &cli.Command{
+	Name:      "options",
+	Usage:     "options chains with implied volatility and greeks",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "getchain",
+			Usage:     "returns options chains with the implied volatility and greeks of each contract from orderbook mids",
+			ArgsUsage: "<exchange> <underlying> <expiry>",
+			Action:    getOptionsChain,
+			Flags: append(optionsPricingFlags,
+				&cli.StringFlag{
+					Name:  "expiry",
+					Usage: "optional - only return contracts expiring on a date in the format 2006-01-02",
+				}),
+		},
+		{
+			Name:      "getportfoliogreeks",
+			Usage:     "returns the aggregated greeks of open options positions",
+			ArgsUsage: "<exchange> <underlying>",
+			Action:    getOptionsPortfolioGreeks,
+			Flags:     optionsPricingFlags,
+		},
+	},
+}
+
+func getOptionsChain(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+	var exchangeName, underlying, expiry string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().Get(1)
+	}
+	if c.IsSet("expiry") {
+		expiry = c.String("expiry")
+	} else {
+		expiry = c.Args().Get(2)
+	}
+	underlyingPair, err := optionsUnderlyingPair(underlying)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetOptionsChain(c.Context,
+		&gctrpc.GetOptionsChainRequest{
+			Exchange:        exchangeName,
+			Underlying:      underlyingPair,
+			Expiry:          expiry,
+			RiskFreeRate:    c.Float64("riskfreerate"),
+			UnderlyingPrice: c.Float64("underlyingprice"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func getOptionsPortfolioGreeks(c *cli.Context) error {
+	if c.NArg() == 0 && c.NumFlags() == 0 {
+		return cli.ShowSubcommandHelp(c)
+	}
+	var exchangeName, underlying string
+	if c.IsSet("exchange") {
+		exchangeName = c.String("exchange")
+	} else {
+		exchangeName = c.Args().First()
+	}
+	if c.IsSet("underlying") {
+		underlying = c.String("underlying")
+	} else {
+		underlying = c.Args().Get(1)
+	}
+	underlyingPair, err := optionsUnderlyingPair(underlying)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetOptionsPortfolioGreeks(c.Context,
+		&gctrpc.GetOptionsPortfolioGreeksRequest{
+			Exchange:        exchangeName,
+			Underlying:      underlyingPair,
+			RiskFreeRate:    c.Float64("riskfreerate"),
+			UnderlyingPrice: c.Float64("underlyingprice"),
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func optionsUnderlyingPair(underlying string) (*gctrpc.CurrencyPair, error) {
+	if underlying == "" {
+		return nil, nil
+	}
+	if !validPair(underlying) {
+		return nil, errInvalidPair
+	}
+	p, err := currency.NewPairDelimiter(underlying, pairDelimiter)
+	if err != nil {
+		return nil, err
+	}
+	return &gctrpc.CurrencyPair{
+		Delimiter: p.Delimiter,
+		Base:      p.Base.String(),
+		Quote:     p.Quote.String(),
+	}, nil
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"strconv"
+
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var rebalanceCommands = &cli.Command{
+	Name:      "rebalance",
+	Usage:     "previews or executes the trades which move holdings towards the target portfolio weights",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:    "getrebalanceplan",
+			Aliases: []string{"preview"},
+			Usage:   "returns the current and target weights and the trades a rebalance would place",
+			Action:  getRebalancePlan,
+		},
+		{
+			Name:      "execute",
+			Usage:     "submits the trades of a rebalance as market orders",
+			ArgsUsage: "<dryrun>",
+			Action:    executeRebalance,
+			Flags: []cli.Flag{
+				&cli.BoolFlag{
+					Name:    "dryrun",
+					Aliases: []string{"d"},
+					Usage:   "plans the trades without submitting them",
+				},
+			},
+		},
+	},
+}
+
+func getRebalancePlan(c *cli.Context) error {
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetRebalancePlan(c.Context, &gctrpc.GetRebalancePlanRequest{})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
+
+func executeRebalance(c *cli.Context) error {
+	var dryRun bool
+	if c.IsSet("dryrun") {
+		dryRun = c.Bool("dryrun")
+	} else if c.Args().First() != "" {
+		var err error
+		dryRun, err = strconv.ParseBool(c.Args().First())
+		if err != nil {
+			return err
+		}
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.ExecuteRebalance(c.Context,
+		&gctrpc.ExecuteRebalanceRequest{
+			DryRun: dryRun,
+		})
+	if err != nil {
+		return err
+	}
+
+	jsonOutput(result)
+	return nil
+}
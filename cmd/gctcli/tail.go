@@ -0,0 +1,236 @@
+package main
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"github.com/urfave/cli/v2"
+)
+
+var (
+	tailFilterFlags = []cli.Flag{
+		&cli.StringFlag{
+			Name:    "exchange",
+			Aliases: []string{"e"},
+			Usage:   "optional - only stream events of an exchange",
+		},
+		&cli.StringFlag{
+			Name:    "asset",
+			Aliases: []string{"a"},
+			Usage:   "optional - only stream events of an asset type",
+		},
+		&cli.StringFlag{
+			Name:    "pair",
+			Aliases: []string{"p"},
+			Usage:   "optional - only stream events of a currency pair",
+		},
+		&cli.Uint64Flag{
+			Name:    "fromsequence",
+			Aliases: []string{"from"},
+			Usage:   "optional - replay retained events from this sequence before streaming, use the sequence after the last one received to resume",
+		},
+	}
+	tailStatusFlag = &cli.StringFlag{
+		Name:    "status",
+		Aliases: []string{"s"},
+		Usage:   "optional - only stream events with this status",
+	}
+)
+
+var tailCommands = &cli.Command{
+	Name:      "tail",
+	Usage:     "streams order, fill and futures position lifecycle events as they occur",
+	ArgsUsage: "<command> <args>",
+	Subcommands: []*cli.Command{
+		{
+			Name:      "orders",
+			Usage:     "streams order status changes tracked by the order manager",
+			ArgsUsage: "<exchange> <asset> <pair> <status> <fromsequence>",
+			Action:    tailOrders,
+			Flags:     append(append([]cli.Flag{}, tailFilterFlags...), tailStatusFlag),
+		},
+		{
+			Name:      "fills",
+			Usage:     "streams fills received from exchange websockets",
+			ArgsUsage: "<exchange> <asset> <pair> <fromsequence>",
+			Action:    tailFills,
+			Flags:     tailFilterFlags,
+		},
+		{
+			Name:      "positions",
+			Usage:     "streams futures position updates tracked by the order manager",
+			ArgsUsage: "<exchange> <asset> <pair> <status> <fromsequence>",
+			Action:    tailPositions,
+			Flags:     append(append([]cli.Flag{}, tailFilterFlags...), tailStatusFlag),
+		},
+	},
+}
+
+// tailFilter holds the parsed filters shared by the tail commands
+type tailFilter struct {
+	exchange     string
+	asset        string
+	pair         *gctrpc.CurrencyPair
+	status       string
+	fromSequence uint64
+}
+
+func parseTailFilter(c *cli.Context, withStatus bool) (*tailFilter, error) {
+	f := &tailFilter{}
+	if c.IsSet("exchange") {
+		f.exchange = c.String("exchange")
+	} else {
+		f.exchange = c.Args().First()
+	}
+	if c.IsSet("asset") {
+		f.asset = c.String("asset")
+	} else {
+		f.asset = c.Args().Get(1)
+	}
+	f.asset = strings.ToLower(f.asset)
+	if f.asset != "" && !validAsset(f.asset) {
+		return nil, errInvalidAsset
+	}
+	var currencyPair string
+	if c.IsSet("pair") {
+		currencyPair = c.String("pair")
+	} else {
+		currencyPair = c.Args().Get(2)
+	}
+	if currencyPair != "" {
+		if !validPair(currencyPair) {
+			return nil, errInvalidPair
+		}
+		p, err := currency.NewPairDelimiter(currencyPair, pairDelimiter)
+		if err != nil {
+			return nil, err
+		}
+		f.pair = &gctrpc.CurrencyPair{
+			Delimiter: p.Delimiter,
+			Base:      p.Base.String(),
+			Quote:     p.Quote.String(),
+		}
+	}
+	seqArg := 3
+	if withStatus {
+		if c.IsSet("status") {
+			f.status = c.String("status")
+		} else {
+			f.status = c.Args().Get(3)
+		}
+		seqArg = 4
+	}
+	if c.IsSet("fromsequence") {
+		f.fromSequence = c.Uint64("fromsequence")
+	} else if c.Args().Get(seqArg) != "" {
+		var err error
+		f.fromSequence, err = strconv.ParseUint(c.Args().Get(seqArg), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+func tailOrders(c *cli.Context) error {
+	f, err := parseTailFilter(c, true)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetOrderUpdateStream(c.Context,
+		&gctrpc.GetOrderUpdateStreamRequest{
+			Exchange:     f.exchange,
+			Asset:        f.asset,
+			Pair:         f.pair,
+			Status:       f.status,
+			FromSequence: f.fromSequence,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
+
+func tailFills(c *cli.Context) error {
+	f, err := parseTailFilter(c, false)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetFillStream(c.Context,
+		&gctrpc.GetFillStreamRequest{
+			Exchange:     f.exchange,
+			Asset:        f.asset,
+			Pair:         f.pair,
+			FromSequence: f.fromSequence,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
+
+func tailPositions(c *cli.Context) error {
+	f, err := parseTailFilter(c, true)
+	if err != nil {
+		return err
+	}
+
+	conn, cancel, err := setupClient(c)
+	if err != nil {
+		return err
+	}
+	defer closeConn(conn, cancel)
+
+	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
+	result, err := client.GetPositionStream(c.Context,
+		&gctrpc.GetPositionStreamRequest{
+			Exchange:     f.exchange,
+			Asset:        f.asset,
+			Pair:         f.pair,
+			Status:       f.status,
+			FromSequence: f.fromSequence,
+		})
+	if err != nil {
+		return err
+	}
+
+	for {
+		resp, err := result.Recv()
+		if err != nil {
+			return err
+		}
+		jsonOutput(resp)
+	}
+}
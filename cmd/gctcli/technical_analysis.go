@@ -24,6 +24,18 @@ var (
 	taMovingAverageType string
 	taStdDevUp          float64
 	taStdDevDown        float64
+	taKSmoothing        int64
+	taDPeriod           int64
+	taStochasticPeriod  int64
+	taConversionPeriod  int64
+	taBasePeriod        int64
+	taSpanBPeriod       int64
+	taDisplacement      int64
+	taATRPeriod         int64
+	taMultiplier        float64
+	taStep              float64
+	taMaximum           float64
+	taAnchor            string
 )
 
 var commonFlag = []cli.Flag{
@@ -97,6 +109,83 @@ var (
 		Value:       "sma",
 		Destination: &taMovingAverageType,
 	}
+	kSmoothingFlag = &cli.Int64Flag{
+		Name:        "ksmoothing",
+		Usage:       "denotes the period used to smooth the stochastic %K line",
+		Value:       3,
+		Destination: &taKSmoothing,
+	}
+	dPeriodFlag = &cli.Int64Flag{
+		Name:        "dperiod",
+		Usage:       "denotes the period of the stochastic %D signal line",
+		Value:       3,
+		Destination: &taDPeriod,
+	}
+	stochasticPeriodFlag = &cli.Int64Flag{
+		Name:        "stochasticperiod",
+		Usage:       "denotes the rolling window of rsi values for the stochastic rsi",
+		Value:       14,
+		Destination: &taStochasticPeriod,
+	}
+	conversionPeriodFlag = &cli.Int64Flag{
+		Name:        "conversionperiod",
+		Usage:       "denotes the period of the ichimoku conversion line",
+		Value:       9,
+		Destination: &taConversionPeriod,
+	}
+	basePeriodFlag = &cli.Int64Flag{
+		Name:        "baseperiod",
+		Usage:       "denotes the period of the ichimoku base line",
+		Value:       26,
+		Destination: &taBasePeriod,
+	}
+	spanBPeriodFlag = &cli.Int64Flag{
+		Name:        "spanbperiod",
+		Usage:       "denotes the period of the ichimoku leading span b",
+		Value:       52,
+		Destination: &taSpanBPeriod,
+	}
+	displacementFlag = &cli.Int64Flag{
+		Name:        "displacement",
+		Usage:       "denotes how many candles the ichimoku spans are shifted",
+		Value:       26,
+		Destination: &taDisplacement,
+	}
+	atrPeriodFlag = &cli.Int64Flag{
+		Name:        "atrperiod",
+		Usage:       "denotes the average true range period used for the channel width",
+		Value:       10,
+		Destination: &taATRPeriod,
+	}
+	supertrendMultiplierFlag = &cli.Float64Flag{
+		Name:        "multiplier",
+		Usage:       "average true range multiplier for the supertrend bands",
+		Value:       3,
+		Destination: &taMultiplier,
+	}
+	keltnerMultiplierFlag = &cli.Float64Flag{
+		Name:        "multiplier",
+		Usage:       "average true range multiplier for the channel width",
+		Value:       2,
+		Destination: &taMultiplier,
+	}
+	stepFlag = &cli.Float64Flag{
+		Name:        "step",
+		Usage:       "acceleration factor step for the parabolic sar",
+		Value:       0.02,
+		Destination: &taStep,
+	}
+	maximumFlag = &cli.Float64Flag{
+		Name:        "maximum",
+		Usage:       "maximum acceleration factor for the parabolic sar",
+		Value:       0.2,
+		Destination: &taMaximum,
+	}
+	anchorFlag = &cli.StringFlag{
+		Name:        "anchor",
+		Usage:       "the anchor date for the vwap - if not supplied will default to the start date",
+		Destination: &taAnchor,
+	}
 
 	otherAssetFlag = []cli.Flag{
 		&cli.StringFlag{
@@ -200,6 +289,97 @@ var technicalAnalysisCommand = &cli.Command{
 			Flags:     append(commonFlag, periodFlag),
 			Action:    getRSI,
 		},
+		{
+			Name:      "stochastic",
+			Usage:     "returns the stochastic oscillator %K and %D lines",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, kSmoothingFlag, dPeriodFlag),
+			Action:    getStochastic,
+		},
+		{
+			Name:      "stochrsi",
+			Usage:     "returns the stochastic relative strength index",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, stochasticPeriodFlag, kSmoothingFlag, dPeriodFlag),
+			Action:    getStochasticRSI,
+		},
+		{
+			Name:      "adx",
+			Usage:     "returns the average directional index and directional indicators",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getADX,
+		},
+		{
+			Name:      "ichimoku",
+			Usage:     "returns the ichimoku cloud",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, conversionPeriodFlag, basePeriodFlag, spanBPeriodFlag, displacementFlag),
+			Action:    getIchimoku,
+		},
+		{
+			Name:      "supertrend",
+			Usage:     "returns the supertrend and its direction",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, supertrendMultiplierFlag),
+			Action:    getSupertrend,
+		},
+		{
+			Name:      "keltner",
+			Usage:     "returns the keltner channels",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag, atrPeriodFlag, keltnerMultiplierFlag),
+			Action:    getKeltner,
+		},
+		{
+			Name:      "donchian",
+			Usage:     "returns the donchian channels",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getDonchian,
+		},
+		{
+			Name:      "psar",
+			Usage:     "returns the parabolic stop and reverse",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, stepFlag, maximumFlag),
+			Action:    getPSAR,
+		},
+		{
+			Name:      "cci",
+			Usage:     "returns the commodity channel index",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getCCI,
+		},
+		{
+			Name:      "willr",
+			Usage:     "returns the williams percent range",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getWILLR,
+		},
+		{
+			Name:      "avwap",
+			Usage:     "returns the volume weighted average price anchored to a date",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end>",
+			Flags:     append(commonFlag, anchorFlag),
+			Action:    getAVWAP,
+		},
+		{
+			Name:      "zscore",
+			Usage:     "returns the rolling z-score of the close price",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getZScore,
+		},
+		{
+			Name:      "hurst",
+			Usage:     "returns the rolling hurst exponent of close price returns, period must be at least 16",
+			ArgsUsage: "<exchange> <pair> <asset> <granularity> <start> <end> <period>",
+			Flags:     append(commonFlag, periodFlag),
+			Action:    getHurst,
+		},
 	},
 }
 
@@ -235,6 +415,58 @@ func getRSI(c *cli.Context) error {
 	return getTecnicalAnalysis(c, "RSI")
 }
 
+func getStochastic(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "STOCH")
+}
+
+func getStochasticRSI(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "STOCHRSI")
+}
+
+func getADX(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "ADX")
+}
+
+func getIchimoku(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "ICHIMOKU")
+}
+
+func getSupertrend(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "SUPERTREND")
+}
+
+func getKeltner(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "KELTNER")
+}
+
+func getDonchian(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "DONCHIAN")
+}
+
+func getPSAR(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "PSAR")
+}
+
+func getCCI(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "CCI")
+}
+
+func getWILLR(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "WILLR")
+}
+
+func getAVWAP(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "AVWAP")
+}
+
+func getZScore(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "ZSCORE")
+}
+
+func getHurst(c *cli.Context) error {
+	return getTecnicalAnalysis(c, "HURST")
+}
+
 func getTecnicalAnalysis(c *cli.Context, algo string) error {
 	if c.NArg() == 0 && c.NumFlags() == 0 {
 		return cli.ShowSubcommandHelp(c)
@@ -320,6 +552,16 @@ func getTecnicalAnalysis(c *cli.Context, algo string) error {
 		taPeriod, _ = c.Value("period").(int64)
 	}
 
+	var anchor *timestamppb.Timestamp
+	if taAnchor != "" {
+		var a time.Time
+		a, err = time.ParseInLocation(time.RFC3339, taAnchor, time.Local)
+		if err != nil {
+			return fmt.Errorf("invalid time format for anchor: %v", err)
+		}
+		anchor = timestamppb.New(a)
+	}
+
 	conn, cancel, err := setupClient(c)
 	if err != nil {
 		return err
@@ -332,12 +574,24 @@ func getTecnicalAnalysis(c *cli.Context, algo string) error {
 			Base:  pair.Base.String(),
 			Quote: pair.Quote.String(),
 		},
-		AssetType:     asset,
-		AlgorithmType: algo,
-		Interval:      taGranularity * int64(time.Second),
-		Start:         timestamppb.New(s),
-		End:           timestamppb.New(e),
-		Period:        taPeriod,
+		AssetType:           asset,
+		AlgorithmType:       algo,
+		Interval:            taGranularity * int64(time.Second),
+		Start:               timestamppb.New(s),
+		End:                 timestamppb.New(e),
+		Period:              taPeriod,
+		KSmoothing:          taKSmoothing,
+		DPeriod:             taDPeriod,
+		StochasticPeriod:    taStochasticPeriod,
+		ConversionPeriod:    taConversionPeriod,
+		BasePeriod:          taBasePeriod,
+		SpanBPeriod:         taSpanBPeriod,
+		Displacement:        taDisplacement,
+		AtrPeriod:           taATRPeriod,
+		Multiplier:          taMultiplier,
+		AccelerationStep:    taStep,
+		AccelerationMaximum: taMaximum,
+		Anchor:              anchor,
 	}
 
 	client := gctrpc.NewGoCryptoTraderServiceClient(conn)
@@ -0,0 +1,86 @@
+package main
+
+import (
+	"flag"
+	"log"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
+	"github.com/thrasher-corp/gocryptotrader/config"
+)
+
+func main() {
+	var keystorePath, passwordEnv, name, value, valueEnv, listen, tokenEnv string
+	var list bool
+	flag.StringVar(&keystorePath, "keystore", "keystore.json", "The encrypted keystore file.")
+	flag.StringVar(&passwordEnv, "passwordenv", "GCT_KEYSTORE_PASSWORD", "The environment variable containing the keystore password, prompts if unset.")
+	flag.StringVar(&name, "set", "", "The name of a secret to add or rotate, e.g. binance.key")
+	flag.StringVar(&value, "value", "", "The secret value to store, if empty it is read from the environment variable set by -valueenv.")
+	flag.StringVar(&valueEnv, "valueenv", "GCT_SECRET_VALUE", "The environment variable containing the secret value to store.")
+	flag.BoolVar(&list, "list", false, "Lists the names of all stored secrets.")
+	flag.StringVar(&listen, "listen", "", "Serves the keystore as an HTTP secret store on the supplied address, e.g. 127.0.0.1:9055")
+	flag.StringVar(&tokenEnv, "tokenenv", "GCT_SECRET_STORE_TOKEN", "The environment variable containing the bearer token required by the HTTP secret store.")
+	flag.Parse()
+
+	log.Println("GoCryptoTrader: secret store tool.")
+
+	password := os.Getenv(passwordEnv)
+	if password == "" {
+		result, err := config.PromptForConfigKey(false)
+		if err != nil {
+			log.Fatalf("Unable to obtain keystore password: %s", err)
+		}
+		password = string(result)
+	}
+
+	keystore, err := secrets.LoadKeystore(keystorePath, []byte(password))
+	if err != nil {
+		log.Fatalf("Unable to load keystore %s. Error: %s", keystorePath, err)
+	}
+
+	if name != "" {
+		if value == "" {
+			value = os.Getenv(valueEnv)
+		}
+		if value == "" {
+			log.Fatalf("A secret value must be supplied with -value or %s.", valueEnv)
+		}
+		version, errSet := keystore.Set(name, value)
+		if errSet != nil {
+			log.Fatalf("Unable to set secret %s. Error: %s", name, errSet)
+		}
+		if errSet = keystore.Save(); errSet != nil {
+			log.Fatalf("Unable to save keystore %s. Error: %s", keystorePath, errSet)
+		}
+		log.Printf("Stored secret %s version %d, reference it with keystore://%s\n", name, version, name)
+	}
+
+	if list {
+		for _, n := range keystore.Names() {
+			log.Println(n)
+		}
+	}
+
+	if listen == "" {
+		return
+	}
+	token := os.Getenv(tokenEnv)
+	if token == "" {
+		log.Printf("Warning: %s is not set, the secret store will not require authentication\n", tokenEnv)
+	}
+	handler, err := secrets.NewHTTPStoreHandler(keystore, token)
+	if err != nil {
+		log.Fatal(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle(secrets.HTTPStorePath, handler)
+	server := &http.Server{
+		Addr:              listen,
+		Handler:           mux,
+		ReadHeaderTimeout: time.Second * 5,
+	}
+	log.Printf("Serving secrets from %s at http://%s%s<name>\n", keystorePath, listen, secrets.HTTPStorePath)
+	log.Fatal(server.ListenAndServe())
+}
@@ -559,6 +559,12 @@ func StartEndTimeCheck(start, end time.Time) error {
 	return nil
 }
 
+// TruncateDay returns the start of the UTC day of the time, used where data
+// is stored or looked up at a daily granularity
+func TruncateDay(t time.Time) time.Time {
+	return t.UTC().Truncate(time.Hour * 24)
+}
+
 // GenerateRandomString generates a random string provided a length and list of Character types { SmallLetters, CapitalLetters, NumberCharacters}.
 // if no characters are provided, the function uses a NumberCharacters(string of numeric characters).
 func GenerateRandomString(length uint, characters ...string) (string, error) {
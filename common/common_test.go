@@ -757,6 +757,16 @@ func TestErrors(t *testing.T) {
 	}
 }
 
+func TestTruncateDay(t *testing.T) {
+	t.Parallel()
+	loc := time.FixedZone("UTC+10", 10*60*60)
+	tt := time.Date(2021, 3, 2, 5, 30, 0, 0, loc)
+	expected := time.Date(2021, 3, 1, 0, 0, 0, 0, time.UTC)
+	if received := TruncateDay(tt); !received.Equal(expected) || received.Location() != time.UTC {
+		t.Errorf("received %v, expected %v", received, expected)
+	}
+}
+
 func TestParseStartEndDate(t *testing.T) {
 	t.Parallel()
 	pt := time.Date(1999, 1, 1, 0, 0, 0, 0, time.Local)
@@ -0,0 +1,65 @@
+package secrets
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// NewHTTPStoreHandler returns a handler which serves secrets from the store
+// at GET /secrets/<name>[?version=n]. When a token is supplied requests must
+// send it as a bearer token. This allows a keystore to act as a local stand-in
+// for a remote secret store
+func NewHTTPStoreHandler(store Store, token string) (http.Handler, error) {
+	if store == nil {
+		return nil, errors.New("secret store is nil")
+	}
+	return &httpStoreHandler{store: store, token: token}, nil
+}
+
+// ServeHTTP implements http.Handler
+func (h *httpStoreHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if h.token != "" {
+		token := strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+	}
+	name := strings.TrimPrefix(r.URL.Path, HTTPStorePath)
+	if name == "" || name == r.URL.Path {
+		http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+		return
+	}
+	var version int
+	if v := r.URL.Query().Get(versionParameter); v != "" {
+		var err error
+		version, err = strconv.Atoi(v)
+		if err != nil || version < 1 {
+			http.Error(w, errInvalidVersion.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	value, actualVersion, err := h.store.Get(name, version)
+	if err != nil {
+		if errors.Is(err, ErrSecretNotFound) {
+			http.Error(w, http.StatusText(http.StatusNotFound), http.StatusNotFound)
+			return
+		}
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	err = json.NewEncoder(w).Encode(&HTTPSecret{Name: name, Value: value, Version: actualVersion})
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,79 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+)
+
+func TestHTTPStore(t *testing.T) {
+	t.Parallel()
+	_, err := NewHTTPStoreHandler(nil, "")
+	if err == nil {
+		t.Error("expected error for nil store")
+	}
+	k, err := LoadKeystore(filepath.Join(t.TempDir(), "keystore.json"), []byte("password"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for _, value := range []string{"first", "second"} {
+		if _, err = k.Set("binance.key", value); !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+	}
+	h, err := NewHTTPStoreHandler(k, "token")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+
+	m := NewManager()
+	err = m.Setup(&Config{HTTPStore: HTTPStoreConfig{Token: "token"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	value, err := m.Resolve(context.Background(), srv.URL+HTTPStorePath+"binance.key")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "second" {
+		t.Errorf("received '%v', expected '%v'", value, "second")
+	}
+	value, err = m.Resolve(context.Background(), srv.URL+HTTPStorePath+"binance.key?version=1")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "first" {
+		t.Errorf("received '%v', expected '%v'", value, "first")
+	}
+	_, err = m.Resolve(context.Background(), srv.URL+HTTPStorePath+"missing")
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSecretNotFound)
+	}
+	_, err = m.Resolve(context.Background(), srv.URL+HTTPStorePath+"binance.key?version=0")
+	if !errors.Is(err, errUnexpectedStatus) {
+		t.Errorf("received '%v', expected '%v'", err, errUnexpectedStatus)
+	}
+
+	err = m.Setup(&Config{HTTPStore: HTTPStoreConfig{Token: "wrong"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = m.Resolve(context.Background(), srv.URL+HTTPStorePath+"binance.key")
+	if !errors.Is(err, errUnexpectedStatus) {
+		t.Errorf("received '%v', expected '%v'", err, errUnexpectedStatus)
+	}
+
+	resp, err := http.Post(srv.URL+HTTPStorePath+"binance.key", "application/json", http.NoBody) //nolint:noctx // test request
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusMethodNotAllowed {
+		t.Errorf("received '%v', expected '%v'", resp.StatusCode, http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,275 @@
+package secrets
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"golang.org/x/crypto/scrypt"
+)
+
+const keystoreSaltLength = 32
+
+var errKeystoreDecryptFailed = errors.New("unable to decrypt keystore, password may be incorrect")
+
+// LoadKeystore loads an encrypted keystore file, if the file does not exist
+// an empty keystore is returned which is created on the first Save
+func LoadKeystore(path string, password []byte) (*Keystore, error) {
+	if path == "" {
+		return nil, errKeystorePathEmpty
+	}
+	if len(password) == 0 {
+		return nil, errKeystorePasswordEmpty
+	}
+	k := &Keystore{
+		path:     path,
+		password: append([]byte(nil), password...),
+	}
+	err := k.load()
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return nil, err
+	}
+	if err == nil {
+		return k, nil
+	}
+	k.data.Salt = make([]byte, keystoreSaltLength)
+	if _, err = rand.Read(k.data.Salt); err != nil {
+		return nil, err
+	}
+	k.data.Keys = make(map[string][]keystoreEntry)
+	k.key, err = deriveKeystoreKey(k.password, k.data.Salt)
+	if err != nil {
+		return nil, err
+	}
+	return k, nil
+}
+
+// Get returns the requested version of a secret, a version of zero returns
+// the latest version
+func (k *Keystore) Get(name string, version int) (value string, actualVersion int, err error) {
+	if err = k.reloadIfModified(); err != nil {
+		return "", 0, err
+	}
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	entries := k.data.Keys[name]
+	if len(entries) == 0 {
+		return "", 0, fmt.Errorf("%s %w", name, ErrSecretNotFound)
+	}
+	entry := entries[len(entries)-1]
+	if version != 0 {
+		var found bool
+		for i := range entries {
+			if entries[i].Version == version {
+				entry, found = entries[i], true
+				break
+			}
+		}
+		if !found {
+			return "", 0, fmt.Errorf("%s version %d %w", name, version, ErrSecretNotFound)
+		}
+	}
+	plain, err := decryptEntry(k.key, name, &entry)
+	if err != nil {
+		return "", 0, err
+	}
+	return string(plain), entry.Version, nil
+}
+
+// Set stores a new version of a secret and returns its version number,
+// previous versions are kept so references to a specific version keep
+// resolving. Save must be called to persist the change
+func (k *Keystore) Set(name, value string) (int, error) {
+	if name == "" {
+		return 0, fmt.Errorf("%w: name is empty", errInvalidReference)
+	}
+	if value == "" {
+		return 0, errEmptySecret
+	}
+	if err := k.reloadIfModified(); err != nil {
+		return 0, err
+	}
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	gcm, err := newGCM(k.key)
+	if err != nil {
+		return 0, err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = rand.Read(nonce); err != nil {
+		return 0, err
+	}
+	entries := k.data.Keys[name]
+	version := 1
+	if len(entries) > 0 {
+		version = entries[len(entries)-1].Version + 1
+	}
+	k.data.Keys[name] = append(entries, keystoreEntry{
+		Version: version,
+		Created: time.Now().UTC(),
+		Nonce:   nonce,
+		Data:    gcm.Seal(nil, nonce, []byte(value), []byte(name)),
+	})
+	return version, nil
+}
+
+// Names returns the names of all stored secrets
+func (k *Keystore) Names() []string {
+	k.mtx.RLock()
+	defer k.mtx.RUnlock()
+	names := make([]string, 0, len(k.data.Keys))
+	for name := range k.data.Keys {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Save writes the keystore to disk
+func (k *Keystore) Save() error {
+	k.mtx.Lock()
+	defer k.mtx.Unlock()
+	data, err := json.MarshalIndent(k.data, "", " ")
+	if err != nil {
+		return err
+	}
+	err = file.Write(k.path, data)
+	if err != nil {
+		return err
+	}
+	err = os.Chmod(k.path, 0o600)
+	if err != nil {
+		return err
+	}
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+	k.modTime = info.ModTime()
+	return nil
+}
+
+// load reads and verifies the keystore file
+func (k *Keystore) load() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		return err
+	}
+	raw, err := os.ReadFile(k.path)
+	if err != nil {
+		return err
+	}
+	var data keystoreFile
+	err = json.Unmarshal(raw, &data)
+	if err != nil {
+		return err
+	}
+	if data.Keys == nil {
+		data.Keys = make(map[string][]keystoreEntry)
+	}
+	key, err := deriveKeystoreKey(k.password, data.Salt)
+	if err != nil {
+		return err
+	}
+	// Verify the password against an existing entry so a bad password is
+	// reported on load rather than on first use
+	for name, entries := range data.Keys {
+		if len(entries) == 0 {
+			continue
+		}
+		if _, err = decryptEntry(key, name, &entries[0]); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+		break
+	}
+	k.mtx.Lock()
+	k.data = data
+	k.key = key
+	k.modTime = info.ModTime()
+	k.mtx.Unlock()
+	return nil
+}
+
+// reloadIfModified reloads the keystore when the file has been changed by
+// another process, allowing keys to be rotated while the engine is running
+func (k *Keystore) reloadIfModified() error {
+	info, err := os.Stat(k.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil
+		}
+		return err
+	}
+	k.mtx.RLock()
+	modified := !info.ModTime().Equal(k.modTime)
+	k.mtx.RUnlock()
+	if !modified {
+		return nil
+	}
+	return k.load()
+}
+
+// decryptEntry decrypts a keystore entry, the secret name is used as
+// additional data so entries cannot be swapped between names
+func decryptEntry(key []byte, name string, entry *keystoreEntry) ([]byte, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(entry.Nonce) != gcm.NonceSize() {
+		return nil, errKeystoreDecryptFailed
+	}
+	plain, err := gcm.Open(nil, entry.Nonce, entry.Data, []byte(name))
+	if err != nil {
+		return nil, errKeystoreDecryptFailed
+	}
+	return plain, nil
+}
+
+func newGCM(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func deriveKeystoreKey(password, salt []byte) ([]byte, error) {
+	if len(salt) == 0 {
+		return nil, errors.New("keystore salt is empty")
+	}
+	return scrypt.Key(password, salt, 32768, 8, 1, 32)
+}
+
+// Resolve returns the referenced secret from the keystore, references are
+// formatted as keystore://<name> for the latest version or
+// keystore://<name>?version=<n> for a specific version
+func (p *keystoreProvider) Resolve(_ context.Context, ref string) (string, error) {
+	name, query, _ := strings.Cut(strings.TrimPrefix(ref, SchemeKeystore+schemeSeparator), "?")
+	var version int
+	if query != "" {
+		values, err := url.ParseQuery(query)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", errInvalidReference, err)
+		}
+		if v := values.Get(versionParameter); v != "" {
+			version, err = strconv.Atoi(v)
+			if err != nil || version < 1 {
+				return "", fmt.Errorf("%w %q", errInvalidVersion, v)
+			}
+		}
+	}
+	value, _, err := p.keystore.Get(name, version)
+	return value, err
+}
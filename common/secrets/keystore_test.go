@@ -0,0 +1,124 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestKeystore(t *testing.T) {
+	t.Parallel()
+	_, err := LoadKeystore("", []byte("password"))
+	if !errors.Is(err, errKeystorePathEmpty) {
+		t.Errorf("received '%v', expected '%v'", err, errKeystorePathEmpty)
+	}
+	path := filepath.Join(t.TempDir(), "keystore.json")
+	_, err = LoadKeystore(path, nil)
+	if !errors.Is(err, errKeystorePasswordEmpty) {
+		t.Errorf("received '%v', expected '%v'", err, errKeystorePasswordEmpty)
+	}
+
+	k, err := LoadKeystore(path, []byte("password"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, _, err = k.Get("binance.key", 0)
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSecretNotFound)
+	}
+	_, err = k.Set("binance.key", "")
+	if !errors.Is(err, errEmptySecret) {
+		t.Errorf("received '%v', expected '%v'", err, errEmptySecret)
+	}
+	for i, value := range []string{"first", "second"} {
+		var version int
+		version, err = k.Set("binance.key", value)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if version != i+1 {
+			t.Errorf("received '%v', expected '%v'", version, i+1)
+		}
+	}
+	err = k.Save()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	raw, err := os.ReadFile(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if string(raw) == "" || strings.Contains(string(raw), "first") || strings.Contains(string(raw), "second") {
+		t.Error("expected keystore file to be encrypted")
+	}
+
+	_, err = LoadKeystore(path, []byte("wrong"))
+	if !errors.Is(err, errKeystoreDecryptFailed) {
+		t.Errorf("received '%v', expected '%v'", err, errKeystoreDecryptFailed)
+	}
+
+	loaded, err := LoadKeystore(path, []byte("password"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	value, version, err := loaded.Get("binance.key", 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "second" || version != 2 {
+		t.Errorf("received '%v' version '%v', expected '%v' version '%v'", value, version, "second", 2)
+	}
+	value, _, err = loaded.Get("binance.key", 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "first" {
+		t.Errorf("received '%v', expected '%v'", value, "first")
+	}
+	_, _, err = loaded.Get("binance.key", 3)
+	if !errors.Is(err, ErrSecretNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSecretNotFound)
+	}
+	if names := loaded.Names(); len(names) != 1 || names[0] != "binance.key" {
+		t.Errorf("received '%v', expected '%v'", names, []string{"binance.key"})
+	}
+
+	// Rotate the key from another keystore instance and ensure the change is
+	// picked up without reloading
+	_, err = k.Set("binance.key", "third")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = k.Save()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	future := time.Now().Add(time.Minute)
+	err = os.Chtimes(path, future, future)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	p := &keystoreProvider{keystore: loaded}
+	value, err = p.Resolve(context.Background(), "keystore://binance.key")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "third" {
+		t.Errorf("received '%v', expected '%v'", value, "third")
+	}
+	value, err = p.Resolve(context.Background(), "keystore://binance.key?version=1")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if value != "first" {
+		t.Errorf("received '%v', expected '%v'", value, "first")
+	}
+	_, err = p.Resolve(context.Background(), "keystore://binance.key?version=abc")
+	if !errors.Is(err, errInvalidVersion) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidVersion)
+	}
+}
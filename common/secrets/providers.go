@@ -0,0 +1,68 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+)
+
+// Resolve returns the value of the referenced environment variable
+func (e *envProvider) Resolve(_ context.Context, ref string) (string, error) {
+	name := strings.TrimPrefix(ref, SchemeEnv+schemeSeparator)
+	value, ok := os.LookupEnv(name)
+	if !ok {
+		return "", fmt.Errorf("environment variable %s %w", name, ErrSecretNotFound)
+	}
+	return value, nil
+}
+
+// Resolve returns the contents of the referenced file with any trailing new
+// line removed
+func (f *fileProvider) Resolve(_ context.Context, ref string) (string, error) {
+	path := strings.TrimPrefix(ref, SchemeFile+schemeSeparator)
+	data, err := os.ReadFile(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("%w: %w", ErrSecretNotFound, err)
+		}
+		return "", err
+	}
+	return strings.TrimRight(string(data), "\r\n"), nil
+}
+
+// Resolve requests the referenced secret from an HTTP secret store
+func (h *httpProvider) Resolve(ctx context.Context, ref string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, ref, http.NoBody)
+	if err != nil {
+		return "", err
+	}
+	if h.token != "" {
+		token, err := h.manager.Resolve(ctx, h.token)
+		if err != nil {
+			return "", fmt.Errorf("secret store token: %w", err)
+		}
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return "", ErrSecretNotFound
+	default:
+		return "", fmt.Errorf("%w %d", errUnexpectedStatus, resp.StatusCode)
+	}
+	var secret HTTPSecret
+	err = json.NewDecoder(io.LimitReader(resp.Body, 1<<20)).Decode(&secret)
+	if err != nil {
+		return "", err
+	}
+	return secret.Value, nil
+}
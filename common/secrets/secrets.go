@@ -0,0 +1,336 @@
+package secrets
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// manager is the package secret manager used by the exported functions
+var manager = NewManager()
+
+// NewManager returns a secret manager with the environment and file providers
+// registered
+func NewManager() *Manager {
+	return &Manager{
+		interval: DefaultRefreshInterval,
+		providers: map[string]Provider{
+			SchemeEnv:  &envProvider{},
+			SchemeFile: &fileProvider{},
+		},
+		subscriptions: make(map[string]*subscription),
+	}
+}
+
+// Setup configures the package secret manager
+func Setup(cfg *Config) error {
+	return manager.Setup(cfg)
+}
+
+// Start starts refreshing subscribed secrets
+func Start() error {
+	return manager.Start()
+}
+
+// Stop stops refreshing subscribed secrets
+func Stop() error {
+	return manager.Stop()
+}
+
+// IsRunning returns whether subscribed secrets are being refreshed
+func IsRunning() bool {
+	return manager.IsRunning()
+}
+
+// Resolve returns the secret for a reference using the package secret
+// manager, values which are not references are returned as is
+func Resolve(ctx context.Context, ref string) (string, error) {
+	return manager.Resolve(ctx, ref)
+}
+
+// Subscribe resolves the supplied values and calls fn when any of them change
+func Subscribe(id string, refs []string, fn func(values []string)) ([]string, error) {
+	return manager.Subscribe(id, refs, fn)
+}
+
+// Unsubscribe removes a subscription from the package secret manager
+func Unsubscribe(id string) {
+	manager.Unsubscribe(id)
+}
+
+// IsReference returns whether a value is formatted as a secret reference
+func IsReference(value string) bool {
+	scheme, location, ok := strings.Cut(value, schemeSeparator)
+	if !ok || location == "" {
+		return false
+	}
+	switch scheme {
+	case SchemeEnv, SchemeFile, SchemeKeystore, SchemeHTTP, SchemeHTTPS:
+		return true
+	}
+	return false
+}
+
+// Setup registers the keystore and HTTP secret store providers and sets the
+// refresh interval
+func (m *Manager) Setup(cfg *Config) error {
+	if m == nil {
+		return errNilManager
+	}
+	if cfg == nil {
+		return errNilConfig
+	}
+	var keystore *Keystore
+	if cfg.Keystore.Path != "" {
+		password, err := m.Resolve(context.Background(), cfg.Keystore.Password)
+		if err != nil {
+			return fmt.Errorf("keystore password: %w", err)
+		}
+		keystore, err = LoadKeystore(cfg.Keystore.Path, []byte(password))
+		if err != nil {
+			return err
+		}
+	}
+	timeout := cfg.HTTPStore.Timeout
+	if timeout <= 0 {
+		timeout = DefaultHTTPTimeout
+	}
+	httpStore := &httpProvider{
+		client:  &http.Client{Timeout: timeout},
+		manager: m,
+		token:   cfg.HTTPStore.Token,
+	}
+
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if keystore != nil {
+		m.providers[SchemeKeystore] = &keystoreProvider{keystore: keystore}
+	} else {
+		delete(m.providers, SchemeKeystore)
+	}
+	m.providers[SchemeHTTP] = httpStore
+	m.providers[SchemeHTTPS] = httpStore
+	m.interval = cfg.RefreshInterval
+	if m.interval <= 0 {
+		m.interval = DefaultRefreshInterval
+	}
+	return nil
+}
+
+// RegisterProvider registers a provider for a scheme, replacing any existing
+// provider
+func (m *Manager) RegisterProvider(scheme string, p Provider) error {
+	if m == nil {
+		return errNilManager
+	}
+	if p == nil {
+		return errNilProvider
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	m.providers[scheme] = p
+	return nil
+}
+
+// Start starts refreshing subscribed secrets
+func (m *Manager) Start() error {
+	if m == nil {
+		return errNilManager
+	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.started {
+		return errAlreadyRunning
+	}
+	m.started = true
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run(m.interval)
+	return nil
+}
+
+// Stop stops refreshing subscribed secrets
+func (m *Manager) Stop() error {
+	if m == nil {
+		return errNilManager
+	}
+	m.mtx.Lock()
+	if !m.started {
+		m.mtx.Unlock()
+		return errNotRunning
+	}
+	m.started = false
+	close(m.shutdown)
+	m.mtx.Unlock()
+	m.wg.Wait()
+	return nil
+}
+
+// IsRunning returns whether subscribed secrets are being refreshed
+func (m *Manager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	m.mtx.RLock()
+	defer m.mtx.RUnlock()
+	return m.started
+}
+
+// Resolve returns the secret for a reference, values which are not
+// references are returned as is so plain text config values keep working
+func (m *Manager) Resolve(ctx context.Context, ref string) (string, error) {
+	if m == nil {
+		return "", errNilManager
+	}
+	if !IsReference(ref) {
+		return ref, nil
+	}
+	scheme, _, _ := strings.Cut(ref, schemeSeparator)
+	m.mtx.RLock()
+	p, ok := m.providers[scheme]
+	m.mtx.RUnlock()
+	if !ok {
+		return "", fmt.Errorf("%w %s", errProviderNotFound, scheme)
+	}
+	value, err := p.Resolve(ctx, ref)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", redact(ref), err)
+	}
+	if value == "" {
+		return "", fmt.Errorf("%s: %w", redact(ref), errEmptySecret)
+	}
+	return value, nil
+}
+
+// Subscribe resolves the supplied values and stores them against the ID. When
+// the manager is running and any of the values change fn is called with the
+// full set of newly resolved values. Subscribing with an existing ID replaces
+// the subscription
+func (m *Manager) Subscribe(id string, refs []string, fn func(values []string)) ([]string, error) {
+	if m == nil {
+		return nil, errNilManager
+	}
+	if id == "" {
+		return nil, errSubscriptionIDEmpty
+	}
+	if fn == nil {
+		return nil, errNilCallback
+	}
+	values, err := m.resolveAll(context.Background(), refs)
+	if err != nil {
+		return nil, err
+	}
+	m.mtx.Lock()
+	m.subscriptions[id] = &subscription{
+		refs:   append([]string(nil), refs...),
+		values: append([]string(nil), values...),
+		fn:     fn,
+	}
+	m.mtx.Unlock()
+	return values, nil
+}
+
+// Unsubscribe removes a subscription
+func (m *Manager) Unsubscribe(id string) {
+	if m == nil {
+		return
+	}
+	m.mtx.Lock()
+	delete(m.subscriptions, id)
+	m.mtx.Unlock()
+}
+
+// resolveAll resolves a set of values, empty values are left empty
+func (m *Manager) resolveAll(ctx context.Context, refs []string) ([]string, error) {
+	values := make([]string, len(refs))
+	for i := range refs {
+		if refs[i] == "" {
+			continue
+		}
+		var err error
+		values[i], err = m.Resolve(ctx, refs[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// run refreshes subscribed secrets on an interval until shutdown
+func (m *Manager) run(interval time.Duration) {
+	defer m.wg.Done()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			m.refresh()
+		}
+	}
+}
+
+// refresh resolves all subscribed secrets and notifies subscribers of any
+// rotated values
+func (m *Manager) refresh() {
+	m.mtx.RLock()
+	subs := make(map[string]*subscription, len(m.subscriptions))
+	for id, sub := range m.subscriptions {
+		subs[id] = sub
+	}
+	m.mtx.RUnlock()
+
+	for id, sub := range subs {
+		values, err := m.resolveAll(context.Background(), sub.refs)
+		if err != nil {
+			log.Errorf(log.ConfigMgr, "Secret manager unable to refresh secrets for %s: %v", id, err)
+			continue
+		}
+		if stringsEqual(values, sub.values) {
+			continue
+		}
+		m.mtx.Lock()
+		current, ok := m.subscriptions[id]
+		if !ok || current != sub {
+			// Unsubscribed or replaced during resolution
+			m.mtx.Unlock()
+			continue
+		}
+		sub.values = values
+		m.mtx.Unlock()
+		log.Infof(log.ConfigMgr, "Secret manager detected rotated secrets for %s", id)
+		sub.fn(append([]string(nil), values...))
+	}
+}
+
+// redact removes any query parameters and credentials from a reference so it
+// can be safely logged
+func redact(ref string) string {
+	if i := strings.IndexAny(ref, "?#"); i != -1 {
+		ref = ref[:i]
+	}
+	scheme, location, _ := strings.Cut(ref, schemeSeparator)
+	if scheme == SchemeHTTP || scheme == SchemeHTTPS {
+		if i := strings.Index(location, "@"); i != -1 && i < strings.IndexByte(location+"/", '/') {
+			location = location[i+1:]
+		}
+	}
+	return scheme + schemeSeparator + location
+}
+
+func stringsEqual(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Supported secret reference schemes, a reference is formatted as
+// <scheme>://<location> e.g. env://BINANCE_API_KEY
+const (
+	SchemeEnv      = "env"
+	SchemeFile     = "file"
+	SchemeKeystore = "keystore"
+	SchemeHTTP     = "http"
+	SchemeHTTPS    = "https"
+)
+
+const (
+	// DefaultRefreshInterval is the default interval between checks for
+	// rotated secrets
+	DefaultRefreshInterval = time.Minute
+	// DefaultHTTPTimeout is the default timeout for secret store requests
+	DefaultHTTPTimeout = time.Second * 10
+	// HTTPStorePath is the path prefix served by the HTTP secret store
+	HTTPStorePath = "/secrets/"
+
+	schemeSeparator  = "://"
+	versionParameter = "version"
+)
+
+var (
+	// ErrSecretNotFound is returned when a referenced secret does not exist
+	ErrSecretNotFound = errors.New("secret not found")
+
+	errNotRunning            = errors.New("secret manager not running")
+	errAlreadyRunning        = errors.New("secret manager already running")
+	errNilManager            = errors.New("secret manager is nil")
+	errNilConfig             = errors.New("secrets config is nil")
+	errNilProvider           = errors.New("secret provider is nil")
+	errProviderNotFound      = errors.New("no secret provider registered for scheme")
+	errInvalidReference      = errors.New("invalid secret reference")
+	errEmptySecret           = errors.New("resolved secret is empty")
+	errSubscriptionIDEmpty   = errors.New("subscription ID is empty")
+	errNilCallback           = errors.New("subscription callback is nil")
+	errKeystorePathEmpty     = errors.New("keystore path is empty")
+	errKeystorePasswordEmpty = errors.New("keystore password is empty")
+	errInvalidVersion        = errors.New("invalid secret version")
+	errUnexpectedStatus      = errors.New("unexpected secret store response status")
+)
+
+// Config defines the secret provider settings
+type Config struct {
+	// RefreshInterval determines how often subscribed secrets are resolved
+	// again to pick up rotated values
+	RefreshInterval time.Duration   `json:"refreshInterval"`
+	Keystore        KeystoreConfig  `json:"keystore"`
+	HTTPStore       HTTPStoreConfig `json:"httpStore"`
+}
+
+// KeystoreConfig defines the encrypted keystore file settings, the password
+// is itself a secret reference e.g. env://GCT_KEYSTORE_PASSWORD
+type KeystoreConfig struct {
+	Path     string `json:"path"`
+	Password string `json:"password"`
+}
+
+// HTTPStoreConfig defines the HTTP secret store client settings, the token is
+// a secret reference which is sent as a bearer token when set
+type HTTPStoreConfig struct {
+	Timeout time.Duration `json:"timeout"`
+	Token   string        `json:"token"`
+}
+
+// Provider resolves a secret reference for a specific scheme
+type Provider interface {
+	Resolve(ctx context.Context, ref string) (string, error)
+}
+
+// Manager holds the registered providers and keeps subscribed secrets up to
+// date
+type Manager struct {
+	started       bool
+	shutdown      chan struct{}
+	wg            sync.WaitGroup
+	interval      time.Duration
+	providers     map[string]Provider
+	subscriptions map[string]*subscription
+	mtx           sync.RWMutex
+}
+
+// subscription holds a set of references and their last resolved values
+type subscription struct {
+	refs   []string
+	values []string
+	fn     func(values []string)
+}
+
+// envProvider resolves env://<name> references from environment variables
+type envProvider struct{}
+
+// fileProvider resolves file://<path> references from individual files such
+// as Docker or Kubernetes secrets
+type fileProvider struct{}
+
+// httpProvider resolves http(s):// references from a remote secret store
+type httpProvider struct {
+	client  *http.Client
+	manager *Manager
+	token   string
+}
+
+// HTTPSecret is the response body served by an HTTP secret store
+type HTTPSecret struct {
+	Name    string `json:"name"`
+	Value   string `json:"value"`
+	Version int    `json:"version"`
+}
+
+// Store defines a versioned secret store which can be served over HTTP
+type Store interface {
+	Get(name string, version int) (value string, actualVersion int, err error)
+}
+
+// httpStoreHandler serves secrets from a Store
+type httpStoreHandler struct {
+	store Store
+	token string
+}
+
+// Keystore is an encrypted file of named secrets, each secret keeps all of
+// its versions so keys can be rotated individually
+type Keystore struct {
+	path     string
+	password []byte
+	key      []byte
+	data     keystoreFile
+	modTime  time.Time
+	mtx      sync.RWMutex
+}
+
+// keystoreFile is the on disk format of a keystore
+type keystoreFile struct {
+	Salt []byte                     `json:"salt"`
+	Keys map[string][]keystoreEntry `json:"keys"`
+}
+
+// keystoreEntry is a single encrypted version of a secret
+type keystoreEntry struct {
+	Version int       `json:"version"`
+	Created time.Time `json:"created"`
+	Nonce   []byte    `json:"nonce"`
+	Data    []byte    `json:"data"`
+}
+
+// keystoreProvider resolves keystore://<name>[?version=n] references
+type keystoreProvider struct {
+	keystore *Keystore
+}
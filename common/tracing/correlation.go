@@ -0,0 +1,72 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// NewCorrelationID returns a random correlation ID. IDs are 32 hexadecimal
+// characters so they can also be used as the trace ID of exported spans
+func NewCorrelationID() string {
+	var id [16]byte
+	// crypto/rand does not fail on supported platforms
+	_, _ = rand.Read(id[:])
+	return hex.EncodeToString(id[:])
+}
+
+// IsValidCorrelationID returns whether an ID supplied by a client can be used
+// as a correlation ID. IDs are limited in length and character set so they
+// are safe to log
+func IsValidCorrelationID(id string) bool {
+	if id == "" || len(id) > maxCorrelationIDLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case c >= 'a' && c <= 'z', c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '-', c == '_', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// WithCorrelationID returns a copy of the context carrying the correlation
+// ID. Spans started from the context are part of the same trace
+func WithCorrelationID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, correlationIDContextKey{}, id)
+}
+
+// CorrelationID returns the correlation ID carried by the context or an empty
+// string when there is none
+func CorrelationID(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+	id, _ := ctx.Value(correlationIDContextKey{}).(string)
+	return id
+}
+
+// EnsureCorrelationID returns the context and its correlation ID, a new ID is
+// generated and added to the context when it does not carry one
+func EnsureCorrelationID(ctx context.Context) (context.Context, string) {
+	if id := CorrelationID(ctx); id != "" {
+		return ctx, id
+	}
+	id := NewCorrelationID()
+	return WithCorrelationID(ctx, id), id
+}
+
+// LogFields returns the structured logging fields for the correlation ID
+// carried by the context, nil is returned when there is none
+func LogFields(ctx context.Context) log.ExtraFields {
+	id := CorrelationID(ctx)
+	if id == "" {
+		return nil
+	}
+	return log.ExtraFields{CorrelationIDKey: id}
+}
@@ -0,0 +1,57 @@
+package tracing
+
+import (
+	"context"
+	"strings"
+	"testing"
+)
+
+func TestNewCorrelationID(t *testing.T) {
+	t.Parallel()
+	a, b := NewCorrelationID(), NewCorrelationID()
+	if len(a) != 32 || a == b {
+		t.Errorf("received: '%v' '%v' but expected: '%v'", a, b, "two unique 32 character IDs")
+	}
+	if !IsValidCorrelationID(a) {
+		t.Errorf("received: '%v' but expected: '%v'", false, true)
+	}
+}
+
+func TestIsValidCorrelationID(t *testing.T) {
+	t.Parallel()
+	for id, expected := range map[string]bool{
+		"":                           false,
+		"order-1337_retry.2:binance": true,
+		"has space":                  false,
+		"new\nline":                  false,
+		strings.Repeat("a", 65):      false,
+	} {
+		if received := IsValidCorrelationID(id); received != expected {
+			t.Errorf("%q received: '%v' but expected: '%v'", id, received, expected)
+		}
+	}
+}
+
+func TestCorrelationID(t *testing.T) {
+	t.Parallel()
+	if id := CorrelationID(nil); id != "" { //nolint:staticcheck // nil context is handled
+		t.Errorf("received: '%v' but expected: '%v'", id, "")
+	}
+	ctx := context.Background()
+	if fields := LogFields(ctx); fields != nil {
+		t.Errorf("received: '%v' but expected: '%v'", fields, nil)
+	}
+	ctx, id := EnsureCorrelationID(ctx)
+	if id == "" || CorrelationID(ctx) != id {
+		t.Errorf("received: '%v' but expected: '%v'", CorrelationID(ctx), id)
+	}
+	// an existing ID is kept
+	ctx, again := EnsureCorrelationID(ctx)
+	if again != id {
+		t.Errorf("received: '%v' but expected: '%v'", again, id)
+	}
+	ctx = WithCorrelationID(ctx, "1337")
+	if fields := LogFields(ctx); fields[CorrelationIDKey] != "1337" {
+		t.Errorf("received: '%v' but expected: '%v'", fields[CorrelationIDKey], "1337")
+	}
+}
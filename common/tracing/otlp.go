@@ -0,0 +1,85 @@
+package tracing
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+)
+
+const (
+	statusCodeError        = 2
+	correlationIDAttribute = "gct.correlation_id"
+	serviceNameAttribute   = "service.name"
+)
+
+// encodeSpans returns the spans as an OTLP/JSON export trace service request
+func encodeSpans(serviceName string, spans []*Span) ([]byte, error) {
+	encoded := make([]otlpSpan, len(spans))
+	for i := range spans {
+		s := spans[i]
+		encoded[i] = otlpSpan{
+			TraceID:           hex.EncodeToString(s.TraceID[:]),
+			SpanID:            hex.EncodeToString(s.SpanID[:]),
+			Name:              s.Name,
+			Kind:              s.Kind,
+			StartTimeUnixNano: strconv.FormatInt(s.StartTime.UnixNano(), 10),
+			EndTimeUnixNano:   strconv.FormatInt(s.EndTime.UnixNano(), 10),
+			Attributes:        make([]otlpKeyValue, 0, len(s.Attributes)+1),
+		}
+		if s.ParentSpanID != [8]byte{} {
+			encoded[i].ParentSpanID = hex.EncodeToString(s.ParentSpanID[:])
+		}
+		if s.Error != "" {
+			encoded[i].Status = otlpStatus{Code: statusCodeError, Message: s.Error}
+		}
+		encoded[i].Attributes = append(encoded[i].Attributes, keyValue(correlationIDAttribute, s.CorrelationID))
+		for j := range s.Attributes {
+			encoded[i].Attributes = append(encoded[i].Attributes, keyValue(s.Attributes[j].Key, s.Attributes[j].Value))
+		}
+	}
+	return json.Marshal(otlpTraceRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{keyValue(serviceNameAttribute, serviceName)},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: scopeName},
+				Spans: encoded,
+			}},
+		}},
+	})
+}
+
+// keyValue converts an attribute value to its OTLP type, values which are not
+// strings, integers, floats or booleans are encoded as strings
+func keyValue(key string, value interface{}) otlpKeyValue {
+	kv := otlpKeyValue{Key: key}
+	switch v := value.(type) {
+	case string:
+		kv.Value.StringValue = &v
+	case bool:
+		kv.Value.BoolValue = &v
+	case int:
+		s := strconv.FormatInt(int64(v), 10)
+		kv.Value.IntValue = &s
+	case int32:
+		s := strconv.FormatInt(int64(v), 10)
+		kv.Value.IntValue = &s
+	case int64:
+		s := strconv.FormatInt(v, 10)
+		kv.Value.IntValue = &s
+	case uint32:
+		s := strconv.FormatUint(uint64(v), 10)
+		kv.Value.IntValue = &s
+	case float32:
+		f := float64(v)
+		kv.Value.DoubleValue = &f
+	case float64:
+		kv.Value.DoubleValue = &v
+	default:
+		s := fmt.Sprint(v)
+		kv.Value.StringValue = &s
+	}
+	return kv
+}
@@ -0,0 +1,103 @@
+package tracing
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"time"
+)
+
+// StartSpan starts a span as a child of the span carried by the context. The
+// trace ID is derived from the context's correlation ID, which is generated
+// when the context does not carry one. The returned context carries the span
+// and must be used for any work the span covers
+func StartSpan(ctx context.Context, kind SpanKind, name string) (context.Context, *Span) {
+	ctx, id := EnsureCorrelationID(ctx)
+	s := &Span{
+		Name:          name,
+		Kind:          kind,
+		CorrelationID: id,
+		StartTime:     time.Now(),
+	}
+	if parent, ok := ctx.Value(spanContextKey{}).(*Span); ok && parent.CorrelationID == id {
+		s.TraceID = parent.TraceID
+		s.ParentSpanID = parent.SpanID
+	} else {
+		s.TraceID = traceIDFromCorrelationID(id)
+	}
+	// crypto/rand does not fail on supported platforms
+	_, _ = rand.Read(s.SpanID[:])
+	return context.WithValue(ctx, spanContextKey{}, s), s
+}
+
+// SpanFromContext returns the span carried by the context or nil when there
+// is none
+func SpanFromContext(ctx context.Context) *Span {
+	if ctx == nil {
+		return nil
+	}
+	s, _ := ctx.Value(spanContextKey{}).(*Span)
+	return s
+}
+
+// SetAttribute adds an attribute to the span
+func (s *Span) SetAttribute(key string, value interface{}) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.ended {
+		return
+	}
+	s.Attributes = append(s.Attributes, Attribute{Key: key, Value: value})
+}
+
+// End completes the span and passes it to the package tracer for export. A
+// non-nil error marks the span as failed. Calling End more than once has no
+// effect
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+	s.mtx.Lock()
+	if s.ended {
+		s.mtx.Unlock()
+		return
+	}
+	s.ended = true
+	s.EndTime = time.Now()
+	if err != nil {
+		s.Error = err.Error()
+	}
+	s.mtx.Unlock()
+	tracer.record(s)
+}
+
+// Duration returns how long the span ran for, or has been running for when it
+// has not ended
+func (s *Span) Duration() time.Duration {
+	if s == nil {
+		return 0
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.ended {
+		return s.EndTime.Sub(s.StartTime)
+	}
+	return time.Since(s.StartTime)
+}
+
+// traceIDFromCorrelationID uses generated correlation IDs as the trace ID so
+// they can be searched for in a collector, IDs supplied by clients are hashed
+func traceIDFromCorrelationID(id string) [16]byte {
+	var traceID [16]byte
+	if b, err := hex.DecodeString(id); err == nil && len(b) == len(traceID) {
+		copy(traceID[:], b)
+		return traceID
+	}
+	sum := sha256.Sum256([]byte(id))
+	copy(traceID[:], sum[:])
+	return traceID
+}
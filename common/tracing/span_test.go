@@ -0,0 +1,58 @@
+package tracing
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+)
+
+func TestStartSpan(t *testing.T) {
+	t.Parallel()
+	ctx, root := StartSpan(context.Background(), KindServer, "root")
+	if SpanFromContext(ctx) != root {
+		t.Fatalf("received: '%v' but expected: '%v'", SpanFromContext(ctx), root)
+	}
+	// generated correlation IDs are the trace ID
+	if hex.EncodeToString(root.TraceID[:]) != CorrelationID(ctx) {
+		t.Errorf("received: '%x' but expected: '%v'", root.TraceID, CorrelationID(ctx))
+	}
+	if root.ParentSpanID != [8]byte{} {
+		t.Errorf("received: '%x' but expected: '%v'", root.ParentSpanID, "no parent")
+	}
+	_, child := StartSpan(ctx, KindClient, "child")
+	if child.TraceID != root.TraceID || child.ParentSpanID != root.SpanID || child.SpanID == root.SpanID {
+		t.Errorf("received: '%+v' but expected: '%v'", child, "child of root")
+	}
+
+	_, s := StartSpan(WithCorrelationID(context.Background(), "client-supplied"), KindInternal, "hashed")
+	if s.TraceID == [16]byte{} || s.CorrelationID != "client-supplied" {
+		t.Errorf("received: '%+v' but expected: '%v'", s, "trace ID derived from the correlation ID")
+	}
+	if SpanFromContext(context.Background()) != nil {
+		t.Error("expected no span")
+	}
+}
+
+func TestSpanEnd(t *testing.T) {
+	t.Parallel()
+	var s *Span
+	s.SetAttribute("nil", true)
+	s.End(nil)
+	if s.Duration() != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", s.Duration(), 0)
+	}
+
+	_, s = StartSpan(context.Background(), KindInternal, "test")
+	s.SetAttribute("exchange", "binance")
+	errTest := errors.New("test")
+	s.End(errTest)
+	s.SetAttribute("ignored", 1)
+	s.End(nil)
+	if s.Error != errTest.Error() || len(s.Attributes) != 1 || s.EndTime.IsZero() {
+		t.Errorf("received: '%+v' but expected: '%v'", s, "a single attribute and error")
+	}
+	if s.Duration() != s.EndTime.Sub(s.StartTime) {
+		t.Errorf("received: '%v' but expected: '%v'", s.Duration(), s.EndTime.Sub(s.StartTime))
+	}
+}
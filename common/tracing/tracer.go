@@ -0,0 +1,255 @@
+package tracing
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// tracer is the package tracer ended spans are passed to
+var tracer = &Tracer{}
+
+// Setup configures the package tracer
+func Setup(cfg *Config) error {
+	return tracer.Setup(cfg)
+}
+
+// Start starts exporting ended spans
+func Start() error {
+	return tracer.Start()
+}
+
+// Stop exports any buffered spans and stops the package tracer
+func Stop() error {
+	return tracer.Stop()
+}
+
+// IsRunning returns whether ended spans are being exported
+func IsRunning() bool {
+	return tracer.IsRunning()
+}
+
+// Setup sets the export destinations and batching of the tracer
+func (t *Tracer) Setup(cfg *Config) error {
+	if t == nil {
+		return errNilTracer
+	}
+	if cfg == nil {
+		return errNilConfig
+	}
+	var exporters []exporter
+	if cfg.File != "" {
+		if err := os.MkdirAll(filepath.Dir(cfg.File), file.DefaultPermissionOctal); err != nil {
+			return err
+		}
+		exporters = append(exporters, &fileExporter{path: cfg.File})
+	}
+	if cfg.CollectorURL != "" {
+		u, err := url.Parse(cfg.CollectorURL)
+		if err != nil {
+			return err
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("collector URL %q must use http or https", cfg.CollectorURL)
+		}
+		exporters = append(exporters, &collectorExporter{
+			url:    cfg.CollectorURL,
+			client: &http.Client{Timeout: DefaultCollectorTimeout},
+		})
+	}
+	if len(exporters) == 0 {
+		return errNoExportTarget
+	}
+
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.started {
+		return errAlreadyRunning
+	}
+	t.exporters = exporters
+	t.serviceName = cfg.ServiceName
+	if t.serviceName == "" {
+		t.serviceName = DefaultServiceName
+	}
+	t.flushInterval = cfg.FlushInterval
+	if t.flushInterval <= 0 {
+		t.flushInterval = DefaultFlushInterval
+	}
+	t.maxBatchSize = cfg.MaxBatchSize
+	if t.maxBatchSize <= 0 {
+		t.maxBatchSize = DefaultMaxBatchSize
+	}
+	return nil
+}
+
+// Start starts exporting ended spans
+func (t *Tracer) Start() error {
+	if t == nil {
+		return errNilTracer
+	}
+	t.mtx.Lock()
+	defer t.mtx.Unlock()
+	if t.started {
+		return errAlreadyRunning
+	}
+	if len(t.exporters) == 0 {
+		return errNoExportTarget
+	}
+	t.started = true
+	t.spans = make(chan *Span, spanBufferSize)
+	t.shutdown = make(chan struct{})
+	t.wg.Add(1)
+	go t.run()
+	return nil
+}
+
+// Stop exports any buffered spans and stops the tracer
+func (t *Tracer) Stop() error {
+	if t == nil {
+		return errNilTracer
+	}
+	t.mtx.Lock()
+	if !t.started {
+		t.mtx.Unlock()
+		return errNotRunning
+	}
+	t.started = false
+	close(t.shutdown)
+	t.mtx.Unlock()
+	t.wg.Wait()
+	if dropped := atomic.SwapUint64(&t.dropped, 0); dropped > 0 {
+		log.Warnf(log.Global, "Tracing dropped %d spans as the span buffer was full", dropped)
+	}
+	return nil
+}
+
+// IsRunning returns whether ended spans are being exported
+func (t *Tracer) IsRunning() bool {
+	if t == nil {
+		return false
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	return t.started
+}
+
+// record buffers an ended span for export, spans are dropped when the tracer
+// is not running or its buffer is full so tracing never blocks the caller
+func (t *Tracer) record(s *Span) {
+	if t == nil {
+		return
+	}
+	t.mtx.RLock()
+	defer t.mtx.RUnlock()
+	if !t.started {
+		return
+	}
+	select {
+	case t.spans <- s:
+	default:
+		atomic.AddUint64(&t.dropped, 1)
+	}
+}
+
+// run batches spans and exports them when the batch is full or the flush
+// interval elapses
+func (t *Tracer) run() {
+	defer t.wg.Done()
+	ticker := time.NewTicker(t.flushInterval)
+	defer ticker.Stop()
+	batch := make([]*Span, 0, t.maxBatchSize)
+	for {
+		select {
+		case s := <-t.spans:
+			batch = append(batch, s)
+			if len(batch) >= t.maxBatchSize {
+				t.export(batch)
+				batch = batch[:0]
+			}
+		case <-ticker.C:
+			t.export(batch)
+			batch = batch[:0]
+		case <-t.shutdown:
+			for len(t.spans) > 0 {
+				batch = append(batch, <-t.spans)
+			}
+			t.export(batch)
+			for i := range t.exporters {
+				if err := t.exporters[i].close(); err != nil {
+					log.Errorf(log.Global, "Tracing unable to close span exporter: %v", err)
+				}
+			}
+			return
+		}
+	}
+}
+
+// export encodes a batch of spans and writes it to every exporter
+func (t *Tracer) export(batch []*Span) {
+	if len(batch) == 0 {
+		return
+	}
+	data, err := encodeSpans(t.serviceName, batch)
+	if err != nil {
+		log.Errorf(log.Global, "Tracing unable to encode %d spans: %v", len(batch), err)
+		return
+	}
+	for i := range t.exporters {
+		if err = t.exporters[i].export(data); err != nil {
+			log.Errorf(log.Global, "Tracing unable to export %d spans: %v", len(batch), err)
+		}
+	}
+}
+
+// export appends the trace request to the file followed by a new line
+func (f *fileExporter) export(data []byte) error {
+	fd, err := os.OpenFile(f.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+	if err != nil {
+		return err
+	}
+	_, err = fd.Write(append(data, '\n'))
+	if closeErr := fd.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
+
+func (f *fileExporter) close() error {
+	return nil
+}
+
+// export posts the trace request to the collector
+func (c *collectorExporter) export(data []byte) error {
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodPost, c.url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if err = resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("%w %s", errUnexpectedStatus, resp.Status)
+	}
+	return nil
+}
+
+func (c *collectorExporter) close() error {
+	c.client.CloseIdleConnections()
+	return nil
+}
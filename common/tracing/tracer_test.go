@@ -0,0 +1,188 @@
+package tracing
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSetup(t *testing.T) {
+	t.Parallel()
+	var tr *Tracer
+	err := tr.Setup(&Config{})
+	if !errors.Is(err, errNilTracer) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNilTracer)
+	}
+	tr = &Tracer{}
+	err = tr.Setup(nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNilConfig)
+	}
+	err = tr.Setup(&Config{})
+	if !errors.Is(err, errNoExportTarget) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoExportTarget)
+	}
+	err = tr.Start()
+	if !errors.Is(err, errNoExportTarget) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoExportTarget)
+	}
+	err = tr.Setup(&Config{CollectorURL: "ftp://localhost"})
+	if err == nil {
+		t.Error("expected an error for a non HTTP collector")
+	}
+	err = tr.Setup(&Config{File: filepath.Join(t.TempDir(), "traces", "spans.json")})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if tr.serviceName != DefaultServiceName || tr.flushInterval != DefaultFlushInterval || tr.maxBatchSize != DefaultMaxBatchSize {
+		t.Errorf("received: '%v' '%v' '%v' but expected: '%v'", tr.serviceName, tr.flushInterval, tr.maxBatchSize, "defaults")
+	}
+}
+
+func TestTracerStartStop(t *testing.T) {
+	t.Parallel()
+	tr := &Tracer{}
+	if tr.IsRunning() {
+		t.Error("tracer should not be running")
+	}
+	err := tr.Stop()
+	if !errors.Is(err, errNotRunning) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNotRunning)
+	}
+	err = tr.Setup(&Config{File: filepath.Join(t.TempDir(), "spans.json")})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = tr.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = tr.Start()
+	if !errors.Is(err, errAlreadyRunning) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errAlreadyRunning)
+	}
+	err = tr.Setup(&Config{File: filepath.Join(t.TempDir(), "spans.json")})
+	if !errors.Is(err, errAlreadyRunning) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errAlreadyRunning)
+	}
+	if !tr.IsRunning() {
+		t.Error("tracer should be running")
+	}
+	err = tr.Stop()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestTracerExport(t *testing.T) {
+	t.Parallel()
+	received := make(chan []byte, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Error(err)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("received: '%v' but expected: '%v'", r.Header.Get("Content-Type"), "application/json")
+		}
+		received <- body
+	}))
+	defer srv.Close()
+
+	path := filepath.Join(t.TempDir(), "spans.json")
+	tr := &Tracer{}
+	err := tr.Setup(&Config{File: path, CollectorURL: srv.URL, ServiceName: "test", FlushInterval: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// spans ended before the tracer is started are not exported
+	_, s := StartSpan(context.Background(), KindInternal, "dropped")
+	tr.record(s)
+	err = tr.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx, parent := StartSpan(context.Background(), KindServer, "SubmitOrder")
+	_, child := StartSpan(ctx, KindClient, "HTTP POST")
+	child.SetAttribute("http.status_code", 400)
+	child.SetAttribute("exchange", "binance")
+	child.End(errors.New("bad request"))
+	parent.End(nil)
+	tr.record(child)
+	tr.record(parent)
+	err = tr.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	var fromCollector []byte
+	select {
+	case fromCollector = <-received:
+	default:
+		t.Fatal("collector did not receive spans")
+	}
+	f, err := os.Open(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer f.Close()
+	scanner := bufio.NewScanner(f)
+	if !scanner.Scan() {
+		t.Fatal("no spans written to file")
+	}
+	if string(scanner.Bytes()) != string(fromCollector) {
+		t.Errorf("received: '%s' but expected: '%s'", scanner.Bytes(), fromCollector)
+	}
+
+	var req otlpTraceRequest
+	err = json.Unmarshal(fromCollector, &req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(req.ResourceSpans) != 1 || len(req.ResourceSpans[0].ScopeSpans) != 1 {
+		t.Fatalf("received: '%s' but expected: '%v'", fromCollector, "one resource and scope")
+	}
+	if name := req.ResourceSpans[0].Resource.Attributes[0].Value.StringValue; name == nil || *name != "test" {
+		t.Errorf("received: '%v' but expected: '%v'", name, "test")
+	}
+	spans := req.ResourceSpans[0].ScopeSpans[0].Spans
+	if len(spans) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(spans), 2)
+	}
+	if spans[0].TraceID != CorrelationID(ctx) || spans[0].ParentSpanID != spans[1].SpanID {
+		t.Errorf("received: '%+v' but expected: '%v'", spans[0], "child of the SubmitOrder span")
+	}
+	if spans[0].Status.Code != statusCodeError || spans[0].Status.Message != "bad request" || spans[1].Status.Code != 0 {
+		t.Errorf("received: '%+v' '%+v' but expected: '%v'", spans[0].Status, spans[1].Status, "only the child failed")
+	}
+	if len(spans[0].Attributes) != 3 || spans[0].Attributes[1].Value.IntValue == nil || *spans[0].Attributes[1].Value.IntValue != "400" {
+		t.Errorf("received: '%+v' but expected: '%v'", spans[0].Attributes, "correlation ID, status code and exchange")
+	}
+}
+
+func TestPackageTracer(t *testing.T) {
+	t.Parallel()
+	if IsRunning() {
+		t.Fatal("package tracer should not be running")
+	}
+	err := Setup(&Config{File: filepath.Join(t.TempDir(), "spans.json")})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = Stop()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
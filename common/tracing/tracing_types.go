@@ -0,0 +1,178 @@
+package tracing
+
+import (
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+const (
+	// CorrelationIDKey is the structured logging field correlation IDs are
+	// logged under
+	CorrelationIDKey log.Key = "correlation_id"
+	// MetadataKey is the gRPC metadata key a correlation ID is received and
+	// returned under
+	MetadataKey = "x-correlation-id"
+
+	// DefaultServiceName is the service name spans are exported under
+	DefaultServiceName = "gocryptotrader"
+	// DefaultFlushInterval is the default interval between span exports
+	DefaultFlushInterval = time.Second * 5
+	// DefaultMaxBatchSize is the default number of spans buffered before they
+	// are exported
+	DefaultMaxBatchSize = 512
+	// DefaultCollectorTimeout is the default timeout for collector requests
+	DefaultCollectorTimeout = time.Second * 10
+
+	maxCorrelationIDLength = 64
+	spanBufferSize         = 4096
+	scopeName              = "github.com/thrasher-corp/gocryptotrader"
+)
+
+// SpanKind describes the relationship of a span to its caller
+type SpanKind uint8
+
+// Span kinds as defined by OpenTelemetry
+const (
+	KindInternal SpanKind = iota + 1
+	KindServer
+	KindClient
+)
+
+var (
+	errNilTracer        = errors.New("tracer is nil")
+	errNilConfig        = errors.New("tracing config is nil")
+	errAlreadyRunning   = errors.New("tracer already running")
+	errNotRunning       = errors.New("tracer not running")
+	errNoExportTarget   = errors.New("no span export file or collector URL set")
+	errUnexpectedStatus = errors.New("unexpected collector response status")
+)
+
+// Config defines where span timings are exported. Spans are exported as
+// OTLP/JSON trace requests appended to File, one request per line, and sent
+// to the OTLP/HTTP CollectorURL e.g. http://localhost:4318/v1/traces
+type Config struct {
+	Enabled       bool          `json:"enabled"`
+	ServiceName   string        `json:"serviceName"`
+	File          string        `json:"file,omitempty"`
+	CollectorURL  string        `json:"collectorURL,omitempty"`
+	FlushInterval time.Duration `json:"flushInterval"`
+	MaxBatchSize  int           `json:"maxBatchSize"`
+}
+
+// Tracer batches ended spans and exports them
+type Tracer struct {
+	serviceName   string
+	flushInterval time.Duration
+	maxBatchSize  int
+	exporters     []exporter
+	spans         chan *Span
+	dropped       uint64
+	started       bool
+	shutdown      chan struct{}
+	wg            sync.WaitGroup
+	mtx           sync.RWMutex
+}
+
+// exporter writes an encoded OTLP/JSON trace request to a destination
+type exporter interface {
+	export(data []byte) error
+	close() error
+}
+
+// fileExporter appends trace requests to a file
+type fileExporter struct {
+	path string
+}
+
+// collectorExporter posts trace requests to an OTLP/HTTP collector
+type collectorExporter struct {
+	url    string
+	client *http.Client
+}
+
+// Span records the timing of a unit of work. A span is started with
+// StartSpan and is exported once End is called
+type Span struct {
+	Name          string
+	Kind          SpanKind
+	TraceID       [16]byte
+	SpanID        [8]byte
+	ParentSpanID  [8]byte
+	CorrelationID string
+	StartTime     time.Time
+	EndTime       time.Time
+	Attributes    []Attribute
+	Error         string
+	ended         bool
+	mtx           sync.Mutex
+}
+
+// Attribute is a key value pair attached to a span. Values are exported as
+// strings, integers, floats or booleans
+type Attribute struct {
+	Key   string
+	Value interface{}
+}
+
+type correlationIDContextKey struct{}
+
+type spanContextKey struct{}
+
+// otlpTraceRequest is the OTLP/JSON encoding of an export trace service
+// request
+type otlpTraceRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              SpanKind       `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes,omitempty"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+// otlpAnyValue holds one of its values, 64 bit integers are encoded as
+// strings
+type otlpAnyValue struct {
+	StringValue *string  `json:"stringValue,omitempty"`
+	BoolValue   *bool    `json:"boolValue,omitempty"`
+	IntValue    *string  `json:"intValue,omitempty"`
+	DoubleValue *float64 `json:"doubleValue,omitempty"`
+}
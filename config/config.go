@@ -17,6 +17,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -26,6 +28,7 @@ import (
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
 )
 
 var (
@@ -1404,6 +1407,208 @@ func (c *Config) CheckDataHistoryMonitorConfig() {
 	if c.DataHistoryManager.MaxJobsPerCycle == 0 {
 		c.DataHistoryManager.MaxJobsPerCycle = defaultMaxJobsPerCycle
 	}
+	if c.DataHistoryManager.FundingRateCollection.Lookback <= 0 {
+		c.DataHistoryManager.FundingRateCollection.Lookback = defaultFundingRateCollectionLookback
+	}
+}
+
+// CheckFundingRateScannerConfig ensures the funding rate scanner config is
+// valid, or sets default values
+func (c *Config) CheckFundingRateScannerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.FundingRateScanner.CheckInterval <= 0 {
+		c.FundingRateScanner.CheckInterval = defaultFundingRateScannerCheckInterval
+	}
+	if c.FundingRateScanner.HoldingPeriod <= 0 {
+		c.FundingRateScanner.HoldingPeriod = defaultFundingRateScannerHoldingPeriod
+	}
+	if c.FundingRateScanner.DefaultTakerFee <= 0 {
+		c.FundingRateScanner.DefaultTakerFee = defaultFundingRateScannerTakerFee
+	}
+	if len(c.FundingRateScanner.ExchangeTakerFees) == 0 {
+		return
+	}
+	fees := make(map[string]float64, len(c.FundingRateScanner.ExchangeTakerFees))
+	for k, v := range c.FundingRateScanner.ExchangeTakerFees {
+		if v < 0 {
+			log.Warnf(log.ConfigMgr, "Funding rate scanner taker fee %v for %s cannot be negative, ignoring\n", v, k)
+			continue
+		}
+		fees[strings.ToLower(k)] = v
+	}
+	c.FundingRateScanner.ExchangeTakerFees = fees
+}
+
+// CheckBasisServiceConfig ensures the basis service config is valid, or sets
+// default values
+func (c *Config) CheckBasisServiceConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.BasisService.CheckInterval <= 0 {
+		c.BasisService.CheckInterval = defaultBasisServiceCheckInterval
+	}
+	if c.BasisService.HistoryLength <= 0 {
+		c.BasisService.HistoryLength = defaultBasisServiceHistoryLength
+	}
+}
+
+// CheckCandleBuilderConfig ensures the candle builder config is valid, or sets
+// default values
+func (c *Config) CheckCandleBuilderConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.CandleBuilder.CloseDelay <= 0 {
+		c.CandleBuilder.CloseDelay = defaultCandleBuilderCloseDelay
+	}
+	intervals := make([]time.Duration, 0, len(c.CandleBuilder.Intervals))
+	seen := make(map[time.Duration]bool, len(c.CandleBuilder.Intervals))
+	for _, interval := range c.CandleBuilder.Intervals {
+		if interval < time.Second || interval%time.Second != 0 {
+			log.Warnf(log.ConfigMgr, "Candle builder interval %v must be whole seconds, ignoring\n", interval)
+			continue
+		}
+		if seen[interval] {
+			continue
+		}
+		seen[interval] = true
+		intervals = append(intervals, interval)
+	}
+	if len(intervals) == 0 {
+		intervals = append(intervals, defaultCandleBuilderInterval)
+	}
+	c.CandleBuilder.Intervals = intervals
+}
+
+// CheckEventJournalConfig ensures the event journal config is valid, or sets
+// default values
+func (c *Config) CheckEventJournalConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.EventJournal.MaxSegmentSize <= 0 {
+		c.EventJournal.MaxSegmentSize = defaultEventJournalMaxSegmentSize
+	}
+	if c.EventJournal.MaxSegmentAge <= 0 {
+		c.EventJournal.MaxSegmentAge = defaultEventJournalMaxSegmentAge
+	}
+	if c.EventJournal.MaxAge <= 0 {
+		c.EventJournal.MaxAge = defaultEventJournalMaxAge
+	}
+	if c.EventJournal.MaxTotalSize < 0 {
+		c.EventJournal.MaxTotalSize = 0
+	}
+	if c.EventJournal.FlushInterval <= 0 {
+		c.EventJournal.FlushInterval = defaultEventJournalFlushInterval
+	}
+	for i := range c.EventJournal.Kinds {
+		c.EventJournal.Kinds[i] = strings.ToLower(strings.TrimSpace(c.EventJournal.Kinds[i]))
+	}
+}
+
+// CheckAccountingConfig ensures the accounting config is valid, or sets
+// default values
+func (c *Config) CheckAccountingConfig() {
+	m.Lock()
+	defer m.Unlock()
+	method, err := costbasis.ParseMethod(c.Accounting.CostBasisMethod)
+	if err != nil {
+		log.Warnf(log.ConfigMgr, "Accounting %v, using %s\n", err, method)
+	}
+	c.Accounting.CostBasisMethod = method.String()
+	if c.Accounting.ReportingCurrency == "" {
+		c.Accounting.ReportingCurrency = defaultAccountingReportingCurrency
+	}
+	c.Accounting.ReportingCurrency = strings.ToUpper(c.Accounting.ReportingCurrency)
+	if c.Accounting.SnapshotInterval <= 0 {
+		c.Accounting.SnapshotInterval = defaultAccountingSnapshotInterval
+	}
+	if c.Accounting.MaxEvents <= 0 {
+		c.Accounting.MaxEvents = defaultAccountingMaxEvents
+	}
+	if c.Accounting.MaxSnapshots <= 0 {
+		c.Accounting.MaxSnapshots = defaultAccountingMaxSnapshots
+	}
+}
+
+// CheckLedgerConfig ensures the ledger config is valid, or sets default values
+func (c *Config) CheckLedgerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Ledger.ImportInterval <= 0 {
+		c.Ledger.ImportInterval = defaultLedgerImportInterval
+	}
+	if c.Ledger.Tolerance <= 0 {
+		c.Ledger.Tolerance = defaultLedgerTolerance
+	}
+	if c.Ledger.DustThreshold < 0 {
+		c.Ledger.DustThreshold = defaultLedgerDustThreshold
+	}
+}
+
+// CheckRebalancerConfig ensures the rebalancer config is valid, or sets
+// default values. Invalid targets and allocations are removed
+func (c *Config) CheckRebalancerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Rebalancer.ReportingCurrency == "" {
+		c.Rebalancer.ReportingCurrency = defaultRebalancerReportingCurrency
+	}
+	c.Rebalancer.ReportingCurrency = strings.ToUpper(c.Rebalancer.ReportingCurrency)
+	if c.Rebalancer.QuoteCurrency == "" {
+		c.Rebalancer.QuoteCurrency = defaultRebalancerQuoteCurrency
+	}
+	c.Rebalancer.QuoteCurrency = strings.ToUpper(c.Rebalancer.QuoteCurrency)
+	if c.Rebalancer.MinimumTradeValue <= 0 {
+		c.Rebalancer.MinimumTradeValue = defaultRebalancerMinimumTradeValue
+	}
+	if c.Rebalancer.Interval < 0 {
+		c.Rebalancer.Interval = 0
+	}
+	targets := c.Rebalancer.Targets[:0]
+	var total float64
+	for i := range c.Rebalancer.Targets {
+		t := c.Rebalancer.Targets[i]
+		t.Currency = strings.ToUpper(t.Currency)
+		if t.Currency == "" || t.Weight < 0 || t.Weight > 1 {
+			log.Warnf(log.ConfigMgr, "Rebalancer target %q weight %v is invalid, removing\n", t.Currency, t.Weight)
+			continue
+		}
+		if t.Band < 0 {
+			t.Band = 0
+		}
+		total += t.Weight
+		targets = append(targets, t)
+	}
+	c.Rebalancer.Targets = targets
+	if total > 1 {
+		log.Warnf(log.ConfigMgr, "Rebalancer target weights total %v exceeds 1, disabling\n", total)
+		c.Rebalancer.Enabled = false
+	}
+	allocations := c.Rebalancer.Allocations[:0]
+	for i := range c.Rebalancer.Allocations {
+		a := c.Rebalancer.Allocations[i]
+		if a.Exchange == "" {
+			continue
+		}
+		if a.Weight <= 0 {
+			a.Weight = 1
+		}
+		allocations = append(allocations, a)
+	}
+	c.Rebalancer.Allocations = allocations
+}
+
+// CheckDataRetentionManagerConfig ensures the data retention manager has a
+// valid check interval
+func (c *Config) CheckDataRetentionManagerConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.DataRetentionManager.CheckInterval <= 0 {
+		c.DataRetentionManager.CheckInterval = defaultDataRetentionCheckInterval
+	}
+	for i := range c.DataRetentionManager.Policies {
+		c.DataRetentionManager.Policies[i].DataType = strings.ToLower(c.DataRetentionManager.Policies[i].DataType)
+	}
 }
 
 // CheckCurrencyStateManager ensures the currency state config is valid, or sets
@@ -1419,6 +1624,52 @@ func (c *Config) CheckCurrencyStateManager() {
 	}
 }
 
+// CheckHotReloadConfig ensures the config file watcher has a valid check
+// interval
+func (c *Config) CheckHotReloadConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.HotReload.CheckInterval <= 0 {
+		c.HotReload.CheckInterval = defaultHotReloadCheckInterval
+	}
+}
+
+// CheckSecretsConfig ensures the secret providers have a valid refresh
+// interval and secret store timeout
+func (c *Config) CheckSecretsConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Secrets.RefreshInterval <= 0 {
+		c.Secrets.RefreshInterval = secrets.DefaultRefreshInterval
+	}
+	if c.Secrets.HTTPStore.Timeout <= 0 {
+		c.Secrets.HTTPStore.Timeout = secrets.DefaultHTTPTimeout
+	}
+	if c.Secrets.Keystore.Path != "" && !secrets.IsReference(c.Secrets.Keystore.Password) {
+		log.Warnln(log.ConfigMgr, "Secrets keystore password is stored in plain text, use a secret reference e.g. env://GCT_KEYSTORE_PASSWORD")
+	}
+}
+
+// CheckTracingConfig sets the span export defaults and disables tracing when
+// there is nowhere to export spans to
+func (c *Config) CheckTracingConfig() {
+	m.Lock()
+	defer m.Unlock()
+	if c.Tracing.ServiceName == "" {
+		c.Tracing.ServiceName = tracing.DefaultServiceName
+	}
+	if c.Tracing.FlushInterval <= 0 {
+		c.Tracing.FlushInterval = tracing.DefaultFlushInterval
+	}
+	if c.Tracing.MaxBatchSize <= 0 {
+		c.Tracing.MaxBatchSize = tracing.DefaultMaxBatchSize
+	}
+	if c.Tracing.Enabled && c.Tracing.File == "" && c.Tracing.CollectorURL == "" {
+		log.Warnln(log.ConfigMgr, "Tracing enabled without an export file or collector URL, disabling tracing")
+		c.Tracing.Enabled = false
+	}
+}
+
 // CheckOrderManagerConfig ensures the order manager is setup correctly
 func (c *Config) CheckOrderManagerConfig() {
 	m.Lock()
@@ -1432,6 +1683,19 @@ func (c *Config) CheckOrderManagerConfig() {
 		// for longer than a year
 		c.OrderManager.FuturesTrackingSeekDuration = -time.Hour * 24 * 365
 	}
+	me := &c.OrderManager.MarginEngine
+	if me.WarningMarginRatio <= 0 || me.CriticalMarginRatio > 1 || me.WarningMarginRatio >= me.CriticalMarginRatio {
+		if me.Enabled {
+			log.Warnf(log.ConfigMgr, "Margin engine warning ratio %v and critical ratio %v are invalid, setting defaults %v and %v\n",
+				me.WarningMarginRatio, me.CriticalMarginRatio, defaultMarginWarningRatio, defaultMarginCriticalRatio)
+		}
+		me.WarningMarginRatio = defaultMarginWarningRatio
+		me.CriticalMarginRatio = defaultMarginCriticalRatio
+	}
+	for i := range me.Exchanges {
+		me.Exchanges[i].Asset = strings.ToLower(me.Exchanges[i].Asset)
+		me.Exchanges[i].Mode = strings.ToLower(me.Exchanges[i].Mode)
+	}
 }
 
 // CheckConnectionMonitorConfig checks and if zero value assigns default values
@@ -1648,6 +1912,49 @@ func readEncryptedConf(reader io.Reader, key []byte) (*Config, error) {
 	return c, err
 }
 
+// ReadConfigForReload reads and checks the configuration at the given path
+// without altering the current configuration, so that it can be compared and
+// applied to a running instance. Encrypted configuration can only be read if it
+// was last saved using the current session key.
+func (c *Config) ReadConfigForReload(configPath string) (*Config, error) {
+	defaultPath, _, err := GetFilePath(configPath)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(defaultPath)
+	if err != nil {
+		return nil, err
+	}
+	if ConfirmECS(data) {
+		data, err = c.decryptConfigDataWithSession(bytes.NewReader(data))
+		if err != nil {
+			return nil, err
+		}
+	}
+	result := &Config{}
+	err = json.Unmarshal(data, result)
+	if err != nil {
+		return nil, err
+	}
+	result.sessionDK, result.storedSalt = c.sessionDK, c.storedSalt
+
+	err = result.CheckConfig()
+	// Checking the logger config applies it globally, so restore the current
+	// logging settings until the changes are deliberately applied
+	if logErr := log.SetGlobalLogConfig(&c.Logging); logErr != nil {
+		log.Errorf(log.ConfigMgr, "Failed to restore logging config: %s\n", logErr)
+	}
+	if c.Database.Enabled {
+		if dbErr := database.DB.SetConfig(&c.Database); dbErr != nil {
+			log.Errorf(log.ConfigMgr, "Failed to restore database config: %s\n", dbErr)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
 // SaveConfigToFile saves your configuration to your desired path as a JSON object.
 // The function encrypts the data and prompts for encryption key, if necessary
 func (c *Config) SaveConfigToFile(configPath string) error {
@@ -1749,6 +2056,28 @@ func (c *Config) CheckRemoteControlConfig() {
 		// Then flush the old webserver settings
 		c.Webserver = nil
 	}
+
+	users := c.RemoteControl.Users[:0]
+	for i := range c.RemoteControl.Users {
+		u := c.RemoteControl.Users[i]
+		if u.Username == "" {
+			log.Warnf(log.ConfigMgr, "Remote control user #%d has no username, removing.\n", i)
+			continue
+		}
+		if u.Password == "" && u.Token == "" && u.CertificateCommonName == "" {
+			log.Warnf(log.ConfigMgr, "Remote control user %s has no password, token or certificate common name, removing.\n", u.Username)
+			continue
+		}
+		u.Role = strings.ToLower(u.Role)
+		switch u.Role {
+		case RPCRoleReadOnly, RPCRoleTrader, RPCRoleRisk, RPCRoleAdmin:
+		default:
+			log.Warnf(log.ConfigMgr, "Remote control user %s has invalid role %q, defaulting to %s.\n", u.Username, u.Role, RPCRoleReadOnly)
+			u.Role = RPCRoleReadOnly
+		}
+		users = append(users, u)
+	}
+	c.RemoteControl.Users = users
 }
 
 // CheckConfig checks all config settings
@@ -1782,6 +2111,17 @@ func (c *Config) CheckConfig() error {
 	c.CheckConnectionMonitorConfig()
 	c.CheckDataHistoryMonitorConfig()
 	c.CheckCurrencyStateManager()
+	c.CheckHotReloadConfig()
+	c.CheckSecretsConfig()
+	c.CheckTracingConfig()
+	c.CheckDataRetentionManagerConfig()
+	c.CheckFundingRateScannerConfig()
+	c.CheckBasisServiceConfig()
+	c.CheckCandleBuilderConfig()
+	c.CheckEventJournalConfig()
+	c.CheckAccountingConfig()
+	c.CheckLedgerConfig()
+	c.CheckRebalancerConfig()
 	c.CheckOrderManagerConfig()
 	c.CheckCommunicationsConfig()
 	c.CheckClientBankAccounts()
@@ -27,6 +27,8 @@ const (
 	errAESBlockSize = "config file data is too small for the AES required block size"
 )
 
+var errSessionKeyMismatch = errors.New("config file was not encrypted with the current session key, a restart is required to supply the key")
+
 // promptForConfigEncryption asks for encryption confirmation
 // returns true if encryption was desired, false otherwise
 func promptForConfigEncryption() (bool, error) {
@@ -178,6 +180,43 @@ func (c *Config) decryptConfigData(configReader io.Reader, key []byte) ([]byte,
 	return result, nil
 }
 
+// decryptConfigDataWithSession decrypts configuration data using the session
+// derived key, which is only possible when the data was last encrypted by this
+// session
+func (c *Config) decryptConfigDataWithSession(configReader io.Reader) ([]byte, error) {
+	if len(c.sessionDK) == 0 || len(c.storedSalt) == 0 {
+		return nil, errSessionKeyMismatch
+	}
+	err := skipECS(configReader)
+	if err != nil {
+		return nil, err
+	}
+	configData, err := io.ReadAll(configReader)
+	if err != nil {
+		return nil, err
+	}
+	if !bytes.HasPrefix(configData, c.storedSalt) {
+		return nil, errSessionKeyMismatch
+	}
+	configData = configData[len(c.storedSalt):]
+
+	blockDecrypt, err := aes.NewCipher(c.sessionDK)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(configData) < aes.BlockSize {
+		return nil, errors.New(errAESBlockSize)
+	}
+
+	iv := configData[:aes.BlockSize]
+	configData = configData[aes.BlockSize:]
+
+	stream := cipher.NewCFBDecrypter(blockDecrypt, iv)
+	stream.XORKeyStream(configData, configData)
+	return configData, nil
+}
+
 // ConfirmSalt checks whether the encrypted data contains a salt
 func ConfirmSalt(file []byte) bool {
 	return bytes.Contains(file, []byte(SaltPrefix))
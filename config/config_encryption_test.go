@@ -2,6 +2,7 @@ package config
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -373,3 +374,37 @@ func withInteractiveResponse(t *testing.T, response string, body func() error) e
 	defer cleanup()
 	return body()
 }
+
+func TestDecryptConfigDataWithSession(t *testing.T) {
+	t.Parallel()
+	c := &Config{}
+	_, err := c.decryptConfigDataWithSession(bytes.NewReader(nil))
+	if !errors.Is(err, errSessionKeyMismatch) {
+		t.Fatalf("received '%v', expected '%v'", err, errSessionKeyMismatch)
+	}
+
+	c.sessionDK, c.storedSalt, err = makeNewSessionDK([]byte("pass"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	encrypted, err := c.encryptConfigFile([]byte(`{"name":"test"}`))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	decrypted, err := c.decryptConfigDataWithSession(bytes.NewReader(encrypted))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if string(decrypted) != `{"name":"test"}` {
+		t.Errorf("received '%s', expected '%s'", decrypted, `{"name":"test"}`)
+	}
+
+	other, err := EncryptConfigFile([]byte(`{"name":"test"}`), []byte("pass"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = c.decryptConfigDataWithSession(bytes.NewReader(other))
+	if !errors.Is(err, errSessionKeyMismatch) {
+		t.Errorf("received '%v', expected '%v'", err, errSessionKeyMismatch)
+	}
+}
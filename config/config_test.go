@@ -12,6 +12,8 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/connchecker"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -1752,6 +1754,295 @@ func TestSaveConfigToFile(t *testing.T) {
 	}
 }
 
+func TestReadConfigForReload(t *testing.T) {
+	cfg := &Config{}
+	err := cfg.LoadConfig(TestFile, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	cfg.Name = "reloaded"
+	path := filepath.Join(t.TempDir(), File)
+	err = cfg.SaveConfigToFile(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	cfg.Name = "running"
+	candidate, err := cfg.ReadConfigForReload(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if candidate.Name != "reloaded" {
+		t.Errorf("received '%v', expected '%v'", candidate.Name, "reloaded")
+	}
+	if cfg.Name != "running" {
+		t.Errorf("received '%v', expected '%v'", cfg.Name, "running")
+	}
+
+	_, err = cfg.ReadConfigForReload(filepath.Join(t.TempDir(), "bla.json"))
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received '%v', expected '%v'", err, os.ErrNotExist)
+	}
+}
+
+func TestCheckHotReloadConfig(t *testing.T) {
+	t.Parallel()
+	var c Config
+	c.CheckHotReloadConfig()
+	if c.HotReload.CheckInterval != defaultHotReloadCheckInterval {
+		t.Errorf("received '%v', expected '%v'", c.HotReload.CheckInterval, defaultHotReloadCheckInterval)
+	}
+}
+
+func TestCheckSecretsConfig(t *testing.T) {
+	t.Parallel()
+	var c Config
+	c.CheckSecretsConfig()
+	if c.Secrets.RefreshInterval != secrets.DefaultRefreshInterval {
+		t.Errorf("received '%v', expected '%v'", c.Secrets.RefreshInterval, secrets.DefaultRefreshInterval)
+	}
+	if c.Secrets.HTTPStore.Timeout != secrets.DefaultHTTPTimeout {
+		t.Errorf("received '%v', expected '%v'", c.Secrets.HTTPStore.Timeout, secrets.DefaultHTTPTimeout)
+	}
+}
+
+func TestCheckTracingConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{Tracing: tracing.Config{Enabled: true}}
+	c.CheckTracingConfig()
+	if c.Tracing.Enabled {
+		t.Errorf("received '%v', expected '%v'", c.Tracing.Enabled, false)
+	}
+	if c.Tracing.ServiceName != tracing.DefaultServiceName {
+		t.Errorf("received '%v', expected '%v'", c.Tracing.ServiceName, tracing.DefaultServiceName)
+	}
+	if c.Tracing.FlushInterval != tracing.DefaultFlushInterval {
+		t.Errorf("received '%v', expected '%v'", c.Tracing.FlushInterval, tracing.DefaultFlushInterval)
+	}
+	if c.Tracing.MaxBatchSize != tracing.DefaultMaxBatchSize {
+		t.Errorf("received '%v', expected '%v'", c.Tracing.MaxBatchSize, tracing.DefaultMaxBatchSize)
+	}
+	c = Config{Tracing: tracing.Config{Enabled: true, File: "spans.json"}}
+	c.CheckTracingConfig()
+	if !c.Tracing.Enabled {
+		t.Errorf("received '%v', expected '%v'", c.Tracing.Enabled, true)
+	}
+}
+
+func TestCheckDataRetentionManagerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{DataRetentionManager: DataRetentionManager{Policies: []DataRetentionPolicy{{DataType: "Trades"}}}}
+	c.CheckDataRetentionManagerConfig()
+	if c.DataRetentionManager.CheckInterval != defaultDataRetentionCheckInterval {
+		t.Errorf("received '%v', expected '%v'", c.DataRetentionManager.CheckInterval, defaultDataRetentionCheckInterval)
+	}
+	if c.DataRetentionManager.Policies[0].DataType != "trades" {
+		t.Errorf("received '%v', expected '%v'", c.DataRetentionManager.Policies[0].DataType, "trades")
+	}
+}
+
+func TestCheckOrderManagerMarginEngineConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{OrderManager: OrderManager{MarginEngine: MarginEngine{
+		Enabled:             true,
+		WarningMarginRatio:  0.9,
+		CriticalMarginRatio: 0.7,
+		Exchanges:           []MarginEngineTiers{{Exchange: "Binance", Asset: "USDTMarginedFutures", Mode: "Isolated"}},
+	}}}
+	c.CheckOrderManagerConfig()
+	me := c.OrderManager.MarginEngine
+	if me.WarningMarginRatio != defaultMarginWarningRatio {
+		t.Errorf("received '%v', expected '%v'", me.WarningMarginRatio, defaultMarginWarningRatio)
+	}
+	if me.CriticalMarginRatio != defaultMarginCriticalRatio {
+		t.Errorf("received '%v', expected '%v'", me.CriticalMarginRatio, defaultMarginCriticalRatio)
+	}
+	if me.Exchanges[0].Asset != "usdtmarginedfutures" || me.Exchanges[0].Mode != "isolated" {
+		t.Errorf("received '%+v', expected lower case asset and mode", me.Exchanges[0])
+	}
+}
+
+func TestCheckFundingRateScannerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{FundingRateScanner: FundingRateScanner{ExchangeTakerFees: map[string]float64{"Binance": 0.0004, "okx": -1}}}
+	c.CheckFundingRateScannerConfig()
+	if c.FundingRateScanner.CheckInterval != defaultFundingRateScannerCheckInterval {
+		t.Errorf("received '%v', expected '%v'", c.FundingRateScanner.CheckInterval, defaultFundingRateScannerCheckInterval)
+	}
+	if c.FundingRateScanner.HoldingPeriod != defaultFundingRateScannerHoldingPeriod {
+		t.Errorf("received '%v', expected '%v'", c.FundingRateScanner.HoldingPeriod, defaultFundingRateScannerHoldingPeriod)
+	}
+	if c.FundingRateScanner.DefaultTakerFee != defaultFundingRateScannerTakerFee {
+		t.Errorf("received '%v', expected '%v'", c.FundingRateScanner.DefaultTakerFee, defaultFundingRateScannerTakerFee)
+	}
+	if len(c.FundingRateScanner.ExchangeTakerFees) != 1 || c.FundingRateScanner.ExchangeTakerFees["binance"] != 0.0004 {
+		t.Errorf("received '%v', expected '%v'", c.FundingRateScanner.ExchangeTakerFees, map[string]float64{"binance": 0.0004})
+	}
+	c.DataHistoryManager.FundingRateCollection.Lookback = 0
+	c.CheckDataHistoryMonitorConfig()
+	if c.DataHistoryManager.FundingRateCollection.Lookback != defaultFundingRateCollectionLookback {
+		t.Errorf("received '%v', expected '%v'", c.DataHistoryManager.FundingRateCollection.Lookback, defaultFundingRateCollectionLookback)
+	}
+}
+
+func TestCheckBasisServiceConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{BasisService: BasisService{HistoryLength: -1}}
+	c.CheckBasisServiceConfig()
+	if c.BasisService.CheckInterval != defaultBasisServiceCheckInterval {
+		t.Errorf("received '%v', expected '%v'", c.BasisService.CheckInterval, defaultBasisServiceCheckInterval)
+	}
+	if c.BasisService.HistoryLength != defaultBasisServiceHistoryLength {
+		t.Errorf("received '%v', expected '%v'", c.BasisService.HistoryLength, defaultBasisServiceHistoryLength)
+	}
+}
+
+func TestCheckEventJournalConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.EventJournal.MaxTotalSize = -1
+	c.EventJournal.Kinds = []string{" Trade", "ORDERBOOK"}
+	c.CheckEventJournalConfig()
+	if c.EventJournal.MaxSegmentSize != defaultEventJournalMaxSegmentSize {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.MaxSegmentSize, defaultEventJournalMaxSegmentSize)
+	}
+	if c.EventJournal.MaxSegmentAge != defaultEventJournalMaxSegmentAge {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.MaxSegmentAge, defaultEventJournalMaxSegmentAge)
+	}
+	if c.EventJournal.MaxAge != defaultEventJournalMaxAge {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.MaxAge, defaultEventJournalMaxAge)
+	}
+	if c.EventJournal.MaxTotalSize != 0 {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.MaxTotalSize, 0)
+	}
+	if c.EventJournal.FlushInterval != defaultEventJournalFlushInterval {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.FlushInterval, defaultEventJournalFlushInterval)
+	}
+	if c.EventJournal.Kinds[0] != "trade" || c.EventJournal.Kinds[1] != "orderbook" {
+		t.Errorf("received '%v', expected '%v'", c.EventJournal.Kinds, []string{"trade", "orderbook"})
+	}
+}
+
+func TestCheckAccountingConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckAccountingConfig()
+	if c.Accounting.CostBasisMethod != "fifo" {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.CostBasisMethod, "fifo")
+	}
+	if c.Accounting.ReportingCurrency != defaultAccountingReportingCurrency {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.ReportingCurrency, defaultAccountingReportingCurrency)
+	}
+	if c.Accounting.SnapshotInterval != defaultAccountingSnapshotInterval {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.SnapshotInterval, defaultAccountingSnapshotInterval)
+	}
+	if c.Accounting.MaxEvents != defaultAccountingMaxEvents {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.MaxEvents, defaultAccountingMaxEvents)
+	}
+	if c.Accounting.MaxSnapshots != defaultAccountingMaxSnapshots {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.MaxSnapshots, defaultAccountingMaxSnapshots)
+	}
+	c.Accounting.CostBasisMethod = "HIFO"
+	c.Accounting.ReportingCurrency = "aud"
+	c.CheckAccountingConfig()
+	if c.Accounting.CostBasisMethod != "hifo" {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.CostBasisMethod, "hifo")
+	}
+	if c.Accounting.ReportingCurrency != "AUD" {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.ReportingCurrency, "AUD")
+	}
+	c.Accounting.CostBasisMethod = "random"
+	c.CheckAccountingConfig()
+	if c.Accounting.CostBasisMethod != "fifo" {
+		t.Errorf("received '%v', expected '%v'", c.Accounting.CostBasisMethod, "fifo")
+	}
+}
+
+func TestCheckLedgerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.Ledger.DustThreshold = -1
+	c.CheckLedgerConfig()
+	if c.Ledger.ImportInterval != defaultLedgerImportInterval {
+		t.Errorf("received '%v', expected '%v'", c.Ledger.ImportInterval, defaultLedgerImportInterval)
+	}
+	if c.Ledger.Tolerance != defaultLedgerTolerance {
+		t.Errorf("received '%v', expected '%v'", c.Ledger.Tolerance, defaultLedgerTolerance)
+	}
+	if c.Ledger.DustThreshold != defaultLedgerDustThreshold {
+		t.Errorf("received '%v', expected '%v'", c.Ledger.DustThreshold, defaultLedgerDustThreshold)
+	}
+	c.Ledger.DustThreshold = 0
+	c.CheckLedgerConfig()
+	if c.Ledger.DustThreshold != 0 {
+		t.Errorf("received '%v', expected '%v'", c.Ledger.DustThreshold, 0)
+	}
+}
+
+func TestCheckRebalancerConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.Rebalancer.Interval = -time.Hour
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.ReportingCurrency != defaultRebalancerReportingCurrency {
+		t.Errorf("received '%v', expected '%v'", c.Rebalancer.ReportingCurrency, defaultRebalancerReportingCurrency)
+	}
+	if c.Rebalancer.QuoteCurrency != defaultRebalancerQuoteCurrency {
+		t.Errorf("received '%v', expected '%v'", c.Rebalancer.QuoteCurrency, defaultRebalancerQuoteCurrency)
+	}
+	if c.Rebalancer.MinimumTradeValue != defaultRebalancerMinimumTradeValue {
+		t.Errorf("received '%v', expected '%v'", c.Rebalancer.MinimumTradeValue, defaultRebalancerMinimumTradeValue)
+	}
+	if c.Rebalancer.Interval != 0 {
+		t.Errorf("received '%v', expected '%v'", c.Rebalancer.Interval, 0)
+	}
+
+	c.Rebalancer.Enabled = true
+	c.Rebalancer.QuoteCurrency = "usdc"
+	c.Rebalancer.Targets = []RebalanceTarget{
+		{Currency: "btc", Weight: 0.5, Band: -1},
+		{Currency: "", Weight: 0.1},
+		{Currency: "eth", Weight: 1.5},
+		{Currency: "ltc", Weight: 0.2, Band: 0.05},
+	}
+	c.Rebalancer.Allocations = []RebalanceAllocation{{Exchange: "binance"}, {Weight: 1}, {Exchange: "kraken", Weight: 2}}
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.QuoteCurrency != "USDC" {
+		t.Errorf("received '%v', expected '%v'", c.Rebalancer.QuoteCurrency, "USDC")
+	}
+	if len(c.Rebalancer.Targets) != 2 || c.Rebalancer.Targets[0].Currency != "BTC" || c.Rebalancer.Targets[0].Band != 0 || c.Rebalancer.Targets[1].Band != 0.05 {
+		t.Errorf("received '%+v', expected the BTC and LTC targets", c.Rebalancer.Targets)
+	}
+	if len(c.Rebalancer.Allocations) != 2 || c.Rebalancer.Allocations[0].Weight != 1 || c.Rebalancer.Allocations[1].Weight != 2 {
+		t.Errorf("received '%+v', expected the binance and kraken allocations", c.Rebalancer.Allocations)
+	}
+	if !c.Rebalancer.Enabled {
+		t.Error("rebalancer should be enabled")
+	}
+
+	c.Rebalancer.Targets = append(c.Rebalancer.Targets, RebalanceTarget{Currency: "ETH", Weight: 0.5})
+	c.CheckRebalancerConfig()
+	if c.Rebalancer.Enabled {
+		t.Error("rebalancer should be disabled when target weights exceed 1")
+	}
+}
+
+func TestCheckCandleBuilderConfig(t *testing.T) {
+	t.Parallel()
+	c := Config{}
+	c.CheckCandleBuilderConfig()
+	if c.CandleBuilder.CloseDelay != defaultCandleBuilderCloseDelay {
+		t.Errorf("received '%v', expected '%v'", c.CandleBuilder.CloseDelay, defaultCandleBuilderCloseDelay)
+	}
+	if len(c.CandleBuilder.Intervals) != 1 || c.CandleBuilder.Intervals[0] != defaultCandleBuilderInterval {
+		t.Errorf("received '%v', expected '%v'", c.CandleBuilder.Intervals, []time.Duration{defaultCandleBuilderInterval})
+	}
+	c.CandleBuilder.Intervals = []time.Duration{time.Minute * 5, time.Millisecond * 1500, time.Minute * 5, -time.Hour, time.Hour}
+	c.CheckCandleBuilderConfig()
+	if len(c.CandleBuilder.Intervals) != 2 || c.CandleBuilder.Intervals[0] != time.Minute*5 || c.CandleBuilder.Intervals[1] != time.Hour {
+		t.Errorf("received '%v', expected '%v'", c.CandleBuilder.Intervals, []time.Duration{time.Minute * 5, time.Hour})
+	}
+}
+
 func TestCheckConnectionMonitorConfig(t *testing.T) {
 	t.Parallel()
 
@@ -1848,6 +2139,30 @@ func TestCheckRemoteControlConfig(t *testing.T) {
 	}
 }
 
+func TestCheckRemoteControlConfigUsers(t *testing.T) {
+	t.Parallel()
+	c := Config{
+		RemoteControl: RemoteControlConfig{
+			Users: []RPCUser{
+				{Password: "nousername", Role: RPCRoleAdmin},
+				{Username: "nocreds", Role: RPCRoleAdmin},
+				{Username: "trader", Token: "token", Role: "TRADER"},
+				{Username: "mystery", CertificateCommonName: "mystery", Role: "superuser"},
+			},
+		},
+	}
+	c.CheckRemoteControlConfig()
+	if len(c.RemoteControl.Users) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(c.RemoteControl.Users), 2)
+	}
+	if c.RemoteControl.Users[0].Role != RPCRoleTrader {
+		t.Errorf("received: '%v' but expected: '%v'", c.RemoteControl.Users[0].Role, RPCRoleTrader)
+	}
+	if c.RemoteControl.Users[1].Role != RPCRoleReadOnly {
+		t.Errorf("received: '%v' but expected: '%v'", c.RemoteControl.Users[1].Role, RPCRoleReadOnly)
+	}
+}
+
 func TestCheckConfig(t *testing.T) {
 	t.Parallel()
 	cp1 := currency.NewPair(currency.DOGE, currency.XRP)
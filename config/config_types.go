@@ -5,6 +5,8 @@ import (
 	"sync"
 	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -40,6 +42,8 @@ const (
 	defaultDataHistoryMonitorCheckTimer  = time.Minute
 	defaultCurrencyStateManagerDelay     = time.Minute
 	defaultMaxJobsPerCycle               = 5
+	defaultHotReloadCheckInterval        = time.Second * 5
+	defaultDataRetentionCheckInterval    = time.Hour
 	DefaultOrderbookPublishPeriod        = time.Second * 10
 	// DefaultSyncerWorkers limits the number of sync workers
 	DefaultSyncerWorkers = 15
@@ -47,6 +51,33 @@ const (
 	DefaultSyncerTimeoutREST = time.Second * 15
 	// DefaultSyncerTimeoutWebsocket the default time to switch from websocket to REST protocols without a response
 	DefaultSyncerTimeoutWebsocket = time.Minute
+
+	// defaultFundingRateCollectionLookback is how far back funding rates are
+	// collected for a pair which has no stored rates
+	defaultFundingRateCollectionLookback   = time.Hour * 24 * 30
+	defaultFundingRateScannerCheckInterval = time.Minute * 5
+	defaultFundingRateScannerHoldingPeriod = time.Hour * 24 * 7
+	defaultFundingRateScannerTakerFee      = 0.0005
+	defaultMarginWarningRatio              = 0.5
+	defaultMarginCriticalRatio             = 0.8
+	defaultBasisServiceCheckInterval       = time.Minute
+	defaultBasisServiceHistoryLength       = 1440
+	defaultCandleBuilderInterval           = time.Minute
+	defaultCandleBuilderCloseDelay         = time.Second * 5
+	defaultEventJournalMaxSegmentSize      = 64 << 20
+	defaultEventJournalMaxSegmentAge       = time.Hour
+	defaultEventJournalMaxAge              = time.Hour * 24 * 7
+	defaultEventJournalFlushInterval       = time.Second
+	defaultAccountingReportingCurrency     = "USD"
+	defaultAccountingSnapshotInterval      = time.Hour
+	defaultAccountingMaxEvents             = 10000
+	defaultAccountingMaxSnapshots          = 366
+	defaultLedgerImportInterval            = time.Minute * 15
+	defaultLedgerTolerance                 = 0.0001
+	defaultLedgerDustThreshold             = 1
+	defaultRebalancerReportingCurrency     = "USD"
+	defaultRebalancerQuoteCurrency         = "USDT"
+	defaultRebalancerMinimumTradeValue     = 10
 )
 
 // Constants here hold some messages
@@ -70,6 +101,15 @@ const (
 	DefaultForexProviderExchangeRatesAPI = "ExchangeRateHost"
 )
 
+// Remote control user roles, a role grants the permissions of the roles
+// required by each gRPC method
+const (
+	RPCRoleReadOnly = "readonly"
+	RPCRoleTrader   = "trader"
+	RPCRoleRisk     = "risk"
+	RPCRoleAdmin    = "admin"
+)
+
 // Variables here are used for configuration
 var (
 	Cfg                 Config
@@ -91,7 +131,18 @@ type Config struct {
 	ConnectionMonitor    ConnectionMonitorConfig   `json:"connectionMonitor"`
 	OrderManager         OrderManager              `json:"orderManager"`
 	DataHistoryManager   DataHistoryManager        `json:"dataHistoryManager"`
+	DataRetentionManager DataRetentionManager      `json:"dataRetentionManager"`
+	FundingRateScanner   FundingRateScanner        `json:"fundingRateScanner"`
+	BasisService         BasisService              `json:"basisService"`
+	CandleBuilder        CandleBuilder             `json:"candleBuilder"`
+	EventJournal         EventJournal              `json:"eventJournal"`
+	Accounting           Accounting                `json:"accounting"`
+	Ledger               Ledger                    `json:"ledger"`
+	Rebalancer           Rebalancer                `json:"rebalancer"`
 	CurrencyStateManager CurrencyStateManager      `json:"currencyStateManager"`
+	HotReload            HotReload                 `json:"hotReload"`
+	Secrets              secrets.Config            `json:"secrets"`
+	Tracing              tracing.Config            `json:"tracing"`
 	Profiler             Profiler                  `json:"profiler"`
 	NTPClient            NTPClientConfig           `json:"ntpclient"`
 	GCTScript            gctscript.Config          `json:"gctscript"`
@@ -119,6 +170,36 @@ type OrderManager struct {
 	Verbose                       bool          `json:"verbose"`
 	ActivelyTrackFuturesPositions bool          `json:"activelyTrackFuturesPositions"`
 	FuturesTrackingSeekDuration   time.Duration `json:"futuresTrackingSeekDuration"`
+	MarginEngine                  MarginEngine  `json:"marginEngine"`
+}
+
+// MarginEngine holds the maintenance margin tiers used to estimate where
+// tracked futures positions would be liquidated. Margin ratios are the
+// maintenance margin divided by the margin balance, positions are liquidated
+// once the ratio reaches 1
+type MarginEngine struct {
+	Enabled             bool                `json:"enabled"`
+	WarningMarginRatio  float64             `json:"warningMarginRatio"`
+	CriticalMarginRatio float64             `json:"criticalMarginRatio"`
+	Exchanges           []MarginEngineTiers `json:"exchanges"`
+}
+
+// MarginEngineTiers sets the margin mode, isolated or cross, and the
+// maintenance margin tiers of an exchange's futures asset
+type MarginEngineTiers struct {
+	Exchange string                  `json:"exchange"`
+	Asset    string                  `json:"asset"`
+	Mode     string                  `json:"mode"`
+	Tiers    []MaintenanceMarginTier `json:"tiers"`
+}
+
+// MaintenanceMarginTier is a bracket of position notional value, a zero max
+// notional is unbounded. Maintenance amount is the tier's cumulative
+// deduction from the maintenance margin
+type MaintenanceMarginTier struct {
+	MaxNotional           float64 `json:"maxNotional"`
+	MaintenanceMarginRate float64 `json:"maintenanceMarginRate"`
+	MaintenanceAmount     float64 `json:"maintenanceAmount"`
 }
 
 // DataHistoryManager holds all information required for the data history manager
@@ -128,6 +209,156 @@ type DataHistoryManager struct {
 	MaxJobsPerCycle     int64         `json:"maxJobsPerCycle"`
 	MaxResultInsertions int64         `json:"maxResultInsertions"`
 	Verbose             bool          `json:"verbose"`
+	// FundingRateCollection creates funding rate jobs for every enabled
+	// perpetual pair so rate history is stored continuously
+	FundingRateCollection FundingRateCollection `json:"fundingRateCollection"`
+}
+
+// FundingRateCollection defines whether funding rates are collected for all
+// enabled perpetual pairs and how far back collection starts
+type FundingRateCollection struct {
+	Enabled  bool          `json:"enabled"`
+	Lookback time.Duration `json:"lookback"`
+}
+
+// FundingRateScanner holds the settings used to compare funding rates for the
+// same underlying across exchanges. Rates and fees are expressed as fractions,
+// eg 0.0005 is 0.05%
+type FundingRateScanner struct {
+	Enabled       bool          `json:"enabled"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	// HoldingPeriod is the length of time a position is expected to be held,
+	// entry and exit fees are spread across it when annualising
+	HoldingPeriod           time.Duration      `json:"holdingPeriod"`
+	MinimumAnnualisedSpread float64            `json:"minimumAnnualisedSpread"`
+	DefaultTakerFee         float64            `json:"defaultTakerFee"`
+	ExchangeTakerFees       map[string]float64 `json:"exchangeTakerFees"`
+	Verbose                 bool               `json:"verbose"`
+}
+
+// BasisService holds the settings used to calculate the basis between spot
+// and futures contracts. HistoryLength is the number of snapshots kept in
+// memory, when HistoryFile is set every snapshot is also appended to it as CSV
+// which can be replayed by the backtester
+type BasisService struct {
+	Enabled       bool          `json:"enabled"`
+	CheckInterval time.Duration `json:"checkInterval"`
+	HistoryLength int           `json:"historyLength"`
+	HistoryFile   string        `json:"historyFile"`
+	Verbose       bool          `json:"verbose"`
+}
+
+// CandleBuilder holds the settings used to build candles from websocket trade
+// streams. CloseDelay is how long after an interval ends a candle is held open
+// for late trades before being closed
+type CandleBuilder struct {
+	Enabled        bool            `json:"enabled"`
+	Intervals      []time.Duration `json:"intervals"`
+	CloseDelay     time.Duration   `json:"closeDelay"`
+	SaveToDatabase bool            `json:"saveToDatabase"`
+	Verbose        bool            `json:"verbose"`
+}
+
+// EventJournal holds the settings of the on disk journal of market data and
+// order events. Kinds limits which events are recorded, when empty every kind
+// is recorded. Segments older than MaxAge are removed, as are the oldest
+// segments once the journal exceeds a non-zero MaxTotalSize
+type EventJournal struct {
+	Enabled        bool          `json:"enabled"`
+	Directory      string        `json:"directory,omitempty"`
+	Kinds          []string      `json:"kinds,omitempty"`
+	MaxSegmentSize int64         `json:"maxSegmentSize"`
+	MaxSegmentAge  time.Duration `json:"maxSegmentAge"`
+	MaxAge         time.Duration `json:"maxAge"`
+	MaxTotalSize   int64         `json:"maxTotalSize"`
+	FlushInterval  time.Duration `json:"flushInterval"`
+}
+
+// Accounting holds the settings of the live accounting subsystem.
+// CostBasisMethod is one of fifo, lifo, hifo or average. NAV is sampled every
+// SnapshotInterval and the latest sample of each day is stored. MaxEvents
+// limits the realised PNL events and MaxSnapshots the daily NAV snapshots kept
+// in memory
+type Accounting struct {
+	Enabled           bool          `json:"enabled"`
+	CostBasisMethod   string        `json:"costBasisMethod"`
+	ReportingCurrency string        `json:"reportingCurrency"`
+	SnapshotInterval  time.Duration `json:"snapshotInterval"`
+	MaxEvents         int           `json:"maxEvents"`
+	MaxSnapshots      int           `json:"maxSnapshots"`
+	Verbose           bool          `json:"verbose"`
+}
+
+// Ledger holds the settings of the ledger subsystem. History is imported and
+// reconciled against exchange balances every ImportInterval. Differences
+// within Tolerance, relative to the exchange balance, are cleared and those
+// worth less than DustThreshold in USD are reported as dust
+type Ledger struct {
+	Enabled        bool          `json:"enabled"`
+	ImportInterval time.Duration `json:"importInterval"`
+	Tolerance      float64       `json:"tolerance"`
+	DustThreshold  float64       `json:"dustThreshold"`
+	Verbose        bool          `json:"verbose"`
+}
+
+// Rebalancer holds the settings of the portfolio rebalancing subsystem.
+// Holdings on the allocated exchanges, and offline holdings when
+// IncludeOffline is set, are valued in ReportingCurrency and traded against
+// QuoteCurrency towards the target weights. Trades worth less than
+// MinimumTradeValue are skipped. A rebalance is executed every Interval, or
+// only on demand when it is zero
+type Rebalancer struct {
+	Enabled           bool                  `json:"enabled"`
+	ReportingCurrency string                `json:"reportingCurrency"`
+	QuoteCurrency     string                `json:"quoteCurrency"`
+	Targets           []RebalanceTarget     `json:"targets"`
+	Allocations       []RebalanceAllocation `json:"allocations"`
+	MinimumTradeValue float64               `json:"minimumTradeValue"`
+	IncludeOffline    bool                  `json:"includeOffline"`
+	Interval          time.Duration         `json:"interval"`
+	DryRun            bool                  `json:"dryRun"`
+	Verbose           bool                  `json:"verbose"`
+}
+
+// RebalanceTarget is the target weight of a currency as a fraction of the
+// portfolio value. A currency is only traded when its weight differs from the
+// target by more than Band
+type RebalanceTarget struct {
+	Currency string  `json:"currency"`
+	Weight   float64 `json:"weight"`
+	Band     float64 `json:"band"`
+}
+
+// RebalanceAllocation is an exchange trades are placed on and the share of
+// each trade it receives relative to the other allocations
+type RebalanceAllocation struct {
+	Exchange string  `json:"exchange"`
+	Weight   float64 `json:"weight"`
+}
+
+// DataRetentionManager holds the retention policies applied to candle and
+// trade data stored in the database
+type DataRetentionManager struct {
+	Enabled       bool                  `json:"enabled"`
+	CheckInterval time.Duration         `json:"checkInterval"`
+	Compact       bool                  `json:"compact"`
+	Verbose       bool                  `json:"verbose"`
+	Policies      []DataRetentionPolicy `json:"policies"`
+}
+
+// DataRetentionPolicy defines how long a matching candle or trade series is
+// kept. An empty exchange or asset and a zero interval match everything, the
+// first matching policy is applied. When a rollup interval is set, data is
+// converted into candles of that interval before it is removed. Trades are
+// only removed without a rollup when DiscardTrades is set
+type DataRetentionPolicy struct {
+	Exchange       string        `json:"exchange"`
+	Asset          string        `json:"asset"`
+	DataType       string        `json:"dataType"`
+	Interval       time.Duration `json:"interval"`
+	MaxAge         time.Duration `json:"maxAge"`
+	RollupInterval time.Duration `json:"rollupInterval"`
+	DiscardTrades  bool          `json:"discardTrades"`
 }
 
 // CurrencyStateManager defines a set of configuration options for the currency
@@ -137,6 +368,13 @@ type CurrencyStateManager struct {
 	Delay   time.Duration `json:"delay"`
 }
 
+// HotReload defines whether the config file is watched for changes, which are
+// then applied to the running engine
+type HotReload struct {
+	Enabled       bool          `json:"enabled"`
+	CheckInterval time.Duration `json:"checkInterval"`
+}
+
 // SyncManagerConfig stores the currency pair synchronization manager config
 type SyncManagerConfig struct {
 	Enabled                 bool                 `json:"enabled"`
@@ -229,6 +467,27 @@ type GRPCConfig struct {
 	GRPCProxyListenAddress string `json:"grpcProxyListenAddress"`
 	GRPCAllowBotShutdown   bool   `json:"grpcAllowBotShutdown"`
 	TimeInNanoSeconds      bool   `json:"timeInNanoSeconds"`
+	// ClientCertificateAuth allows clients presenting a certificate signed
+	// by ClientCAFile to authenticate as the user with a matching
+	// certificate common name instead of using basic auth or a token
+	ClientCertificateAuth bool   `json:"clientCertificateAuth"`
+	ClientCAFile          string `json:"clientCAFile,omitempty"`
+}
+
+// RPCUser stores a remote control user, its credentials and the role which
+// determines the gRPC methods it is permitted to call
+type RPCUser struct {
+	Username string `json:"username"`
+	Password string `json:"password,omitempty"`
+	// Token authenticates the user via a bearer authorization header
+	Token string `json:"token,omitempty"`
+	// CertificateCommonName authenticates the user via a client certificate
+	// when gRPC client certificate authentication is enabled
+	CertificateCommonName string `json:"certificateCommonName,omitempty"`
+	Role                  string `json:"role"`
+	// Exchanges restricts the user to requests for the listed exchanges, an
+	// empty list permits every exchange
+	Exchanges []string `json:"exchanges,omitempty"`
 }
 
 // DepcrecatedRPCConfig stores the deprecatedRPCConfig settings
@@ -250,6 +509,9 @@ type WebsocketRPCConfig struct {
 type RemoteControlConfig struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	// Users are additional remote control users, the Username and Password
+	// above are always granted the admin role
+	Users []RPCUser `json:"users,omitempty"`
 
 	GRPC          GRPCConfig           `json:"gRPC"`
 	DeprecatedRPC DepcrecatedRPCConfig `json:"deprecatedRPC"`
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 )
 
 var errEmptyPairString = errors.New("empty pair string")
@@ -69,6 +70,29 @@ func ConvertFiat(amount float64, from, to Code) (float64, error) {
 	return storage.ConvertCurrency(amount, from, to)
 }
 
+// ConvertAt returns the rate to convert one unit of a currency into another at
+// a point in time using stored daily historical rates
+func ConvertAt(from, to Code, t time.Time) (float64, error) {
+	return storage.historicalRates.GetRate(from, to, t)
+}
+
+// AddHistoricalRates stores daily historical rates for use by ConvertAt
+func AddHistoricalRates(rates ...HistoricalRate) error {
+	return storage.historicalRates.Add(rates...)
+}
+
+// SetHistoricalRateLoader sets the loader ConvertAt uses to retrieve
+// historical rates which have not been added, such as from a database
+func SetHistoricalRateLoader(l HistoricalRateLoader) {
+	storage.historicalRates.SetLoader(l)
+}
+
+// LoadHistoricalRates retrieves the historical rates between two currencies
+// for a date range using the historical rate loader
+func LoadHistoricalRates(from, to Code, start, end time.Time) error {
+	return storage.historicalRates.Load(from, to, start, end)
+}
+
 // GetForeignExchangeRate returns the foreign exchange rate for a fiat pair.
 func GetForeignExchangeRate(quotation Pair) (float64, error) {
 	return storage.ConvertCurrency(1, quotation.Base, quotation.Quote)
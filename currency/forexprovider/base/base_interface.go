@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -21,6 +22,13 @@ type IFXProvider interface {
 	GetSupportedCurrencies() ([]string, error)
 }
 
+// IFXHistoricalProvider is implemented by foreign exchange providers which
+// support retrieving the rates of a previous day
+type IFXHistoricalProvider interface {
+	IFXProvider
+	GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error)
+}
+
 // FXHandler defines a full suite of FX data providers with failure backup with
 // unsupported currency shunt procedure
 type FXHandler struct {
@@ -20,6 +20,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -54,6 +55,15 @@ func (c *CurrencyLayer) GetRates(baseCurrency, symbols string) (map[string]float
 	return c.GetliveData(symbols, baseCurrency)
 }
 
+// GetRatesForDate returns the rates of a previous day for the base currency
+func (c *CurrencyLayer) GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	var symbolList []string
+	if symbols != "" {
+		symbolList = strings.Split(symbols, ",")
+	}
+	return c.GetHistoricalData(date.UTC().Format("2006-01-02"), symbolList, baseCurrency)
+}
+
 // GetSupportedCurrencies returns supported currencies
 func (c *CurrencyLayer) GetSupportedCurrencies() ([]string, error) {
 	var resp SupportedCurrencies
@@ -2,6 +2,7 @@ package currencylayer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 )
@@ -97,6 +98,19 @@ func TestGetHistoricalData(t *testing.T) {
 	}
 }
 
+func TestGetRatesForDate(t *testing.T) {
+	err := setup()
+	if err != nil {
+		t.Fatal("CurrencyLayer GetRatesForDate error", err)
+	}
+	_, err = c.GetRatesForDate(time.Date(2016, 12, 15, 0, 0, 0, 0, time.UTC), "USD", "AUD")
+	if areAPIKeysSet() && err != nil {
+		t.Error("test error - currencylayer GetRatesForDate() error", err)
+	} else if !areAPIKeysSet() && err == nil {
+		t.Error("test error - currencylayer GetRatesForDate() error cannot be nil")
+	}
+}
+
 func TestConvert(t *testing.T) {
 	err := setup()
 	if err != nil {
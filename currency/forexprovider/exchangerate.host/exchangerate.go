@@ -250,6 +250,20 @@ func (e *ExchangeRateHost) GetRates(baseCurrency, symbols string) (map[string]fl
 	return rates, nil
 }
 
+// GetRatesForDate returns the rates of a previous day for the base currency
+func (e *ExchangeRateHost) GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	h, err := e.GetHistoricalRates(date, baseCurrency, symbols, 0, 0, "")
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make(map[string]float64)
+	for k, v := range h.Rates {
+		rates[baseCurrency+k] = v
+	}
+	return rates, nil
+}
+
 // SendHTTPRequest sends a typical get request
 func (e *ExchangeRateHost) SendHTTPRequest(endpoint string, v url.Values, result interface{}) error {
 	path := common.EncodeURLValues(exchangeRateHostURL+"/"+endpoint, v)
@@ -108,3 +108,13 @@ func TestGetRates(t *testing.T) {
 		t.Error("rate of USDAUD should be set")
 	}
 }
+
+func TestGetRatesForDate(t *testing.T) {
+	r, err := e.GetRatesForDate(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), "USD", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rate := r["USDAUD"]; rate == 0 {
+		t.Error("rate of USDAUD should be set")
+	}
+}
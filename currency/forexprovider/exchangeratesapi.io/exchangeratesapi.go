@@ -238,6 +238,26 @@ func (e *ExchangeRates) GetRates(baseCurrency, symbols string) (map[string]float
 	return standardisedRates, nil
 }
 
+// GetRatesForDate returns the rates of a previous day for the base currency
+func (e *ExchangeRates) GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	var symbolList []string
+	if symbols != "" {
+		symbolList = strings.Split(symbols, ",")
+	}
+	result, err := e.GetHistoricalRates(date, baseCurrency, symbolList)
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range result.Rates.Rates {
+		curr := baseCurrency + k
+		standardisedRates[curr] = v
+	}
+
+	return standardisedRates, nil
+}
+
 // GetSupportedCurrencies returns the supported currency list
 func (e *ExchangeRates) GetSupportedCurrencies() ([]string, error) {
 	symbols, err := e.GetSymbols()
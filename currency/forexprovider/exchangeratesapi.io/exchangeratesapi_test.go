@@ -216,6 +216,16 @@ func TestGetRates(t *testing.T) {
 	}
 }
 
+func TestGetRatesForDate(t *testing.T) {
+	if !isAPIKeySet() {
+		t.Skip("API key not set, skipping test")
+	}
+
+	if _, err := e.GetRatesForDate(time.Date(2021, 10, 1, 0, 0, 0, 0, time.UTC), "EUR", ""); err != nil {
+		t.Fatalf("failed to GetRatesForDate. Err: %s", err)
+	}
+}
+
 func TestGetSupportedCurrencies(t *testing.T) {
 	if !isAPIKeySet() {
 		t.Skip("API key not set, skipping test")
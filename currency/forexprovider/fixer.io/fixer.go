@@ -15,6 +15,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -83,6 +84,32 @@ func (f *Fixer) GetRates(baseCurrency, symbols string) (map[string]float64, erro
 	return standardisedRates, nil
 }
 
+// GetRatesForDate returns the rates of a previous day for the base currency.
+// Free plans only support EUR as the base currency
+func (f *Fixer) GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	var symbolList []string
+	if symbols != "" {
+		symbolList = strings.Split(symbols, ",")
+	}
+	requestBase := baseCurrency
+	if f.APIKeyLvl == fixerAPIFree {
+		requestBase = ""
+		baseCurrency = "EUR"
+	}
+	rates, err := f.GetHistoricalRates(date.UTC().Format("2006-01-02"), requestBase, symbolList)
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range rates {
+		curr := baseCurrency + k
+		standardisedRates[curr] = v
+	}
+
+	return standardisedRates, nil
+}
+
 // GetLatestRates returns real-time exchange rate data for all available or a
 // specific set of currencies. NOTE DEFAULT BASE CURRENCY IS EUR
 func (f *Fixer) GetLatestRates(baseCurrency, symbols string) (map[string]float64, error) {
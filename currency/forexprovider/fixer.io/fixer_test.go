@@ -2,6 +2,7 @@ package fixer
 
 import (
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 )
@@ -49,6 +50,14 @@ func TestGetHistoricalRates(t *testing.T) {
 	}
 }
 
+func TestGetRatesForDate(t *testing.T) {
+	setup(t)
+	_, err := f.GetRatesForDate(time.Date(2013, 12, 24, 0, 0, 0, 0, time.UTC), "EUR", "AUD,KRW")
+	if err == nil {
+		t.Error("fixer GetRatesForDate() Expected error")
+	}
+}
+
 func TestConvertCurrency(t *testing.T) {
 	setup(t)
 	_, err := f.ConvertCurrency("AUD", "EUR", "", 1337)
@@ -18,6 +18,7 @@ import (
 var (
 	errUnhandledForeignExchangeProvider = errors.New("unhandled foreign exchange provider")
 	errNoPrimaryForexProviderEnabled    = errors.New("no primary forex provider enabled")
+	errHistoricalRatesNotSupported      = errors.New("foreign exchange provider does not support historical rates")
 )
 
 // ForexProviders is a foreign exchange handler type
@@ -90,25 +91,11 @@ func (f *ForexProviders) SetProvider(b base.IFXProvider) error {
 func StartFXService(fxProviders []base.Settings) (*ForexProviders, error) {
 	handler := new(ForexProviders)
 	for i := range fxProviders {
-		var provider base.IFXProvider
-		switch fxProviders[i].Name {
-		case "CurrencyConverter":
-			provider = new(currencyconverter.CurrencyConverter)
-		case "CurrencyLayer":
-			provider = new(currencylayer.CurrencyLayer)
-		case "ExchangeRates":
-			provider = new(exchangerates.ExchangeRates)
-		case "Fixer":
-			provider = new(fixer.Fixer)
-		case "OpenExchangeRates":
-			provider = new(openexchangerates.OXR)
-		case "ExchangeRateHost":
-			provider = new(exchangeratehost.ExchangeRateHost)
-		default:
-			return nil, fmt.Errorf("%s %w", fxProviders[i].Name,
-				errUnhandledForeignExchangeProvider)
+		provider, err := newProvider(fxProviders[i].Name)
+		if err != nil {
+			return nil, err
 		}
-		err := provider.Setup(fxProviders[i])
+		err = provider.Setup(fxProviders[i])
 		if err != nil {
 			return nil, err
 		}
@@ -124,3 +111,41 @@ func StartFXService(fxProviders []base.Settings) (*ForexProviders, error) {
 
 	return handler, nil
 }
+
+// NewHistoricalProvider sets up a foreign exchange provider which supports
+// retrieving the rates of previous days, for backfilling historical rates
+func NewHistoricalProvider(settings base.Settings) (base.IFXHistoricalProvider, error) {
+	provider, err := newProvider(settings.Name)
+	if err != nil {
+		return nil, err
+	}
+	historical, ok := provider.(base.IFXHistoricalProvider)
+	if !ok {
+		return nil, fmt.Errorf("%s %w", settings.Name, errHistoricalRatesNotSupported)
+	}
+	err = historical.Setup(settings)
+	if err != nil {
+		return nil, err
+	}
+	return historical, nil
+}
+
+// newProvider returns an unconfigured foreign exchange provider by name
+func newProvider(name string) (base.IFXProvider, error) {
+	switch name {
+	case "CurrencyConverter":
+		return new(currencyconverter.CurrencyConverter), nil
+	case "CurrencyLayer":
+		return new(currencylayer.CurrencyLayer), nil
+	case "ExchangeRates":
+		return new(exchangerates.ExchangeRates), nil
+	case "Fixer":
+		return new(fixer.Fixer), nil
+	case "OpenExchangeRates":
+		return new(openexchangerates.OXR), nil
+	case "ExchangeRateHost":
+		return new(exchangeratehost.ExchangeRateHost), nil
+	default:
+		return nil, fmt.Errorf("%s %w", name, errUnhandledForeignExchangeProvider)
+	}
+}
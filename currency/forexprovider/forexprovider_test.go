@@ -1 +1,32 @@
 package forexprovider
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
+)
+
+func TestNewHistoricalProvider(t *testing.T) {
+	t.Parallel()
+	_, err := NewHistoricalProvider(base.Settings{Name: "bad"})
+	if !errors.Is(err, errUnhandledForeignExchangeProvider) {
+		t.Fatalf("received '%v' expected '%v'", err, errUnhandledForeignExchangeProvider)
+	}
+	_, err = NewHistoricalProvider(base.Settings{Name: "CurrencyConverter"})
+	if !errors.Is(err, errHistoricalRatesNotSupported) {
+		t.Fatalf("received '%v' expected '%v'", err, errHistoricalRatesNotSupported)
+	}
+	p, err := NewHistoricalProvider(base.Settings{Name: "ExchangeRateHost", Enabled: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if p.GetName() != "ExchangeRateHost" {
+		t.Errorf("received '%v' expected '%v'", p.GetName(), "ExchangeRateHost")
+	}
+	for _, name := range GetSupportedForexProviders() {
+		if _, err = newProvider(name); err != nil {
+			t.Errorf("received '%v' expected '%v'", err, nil)
+		}
+	}
+}
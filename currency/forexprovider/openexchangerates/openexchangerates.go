@@ -16,6 +16,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
@@ -59,6 +60,26 @@ func (o *OXR) GetRates(baseCurrency, symbols string) (map[string]float64, error)
 	return standardisedRates, nil
 }
 
+// GetRatesForDate returns the rates of a previous day for the base currency
+func (o *OXR) GetRatesForDate(date time.Time, baseCurrency, symbols string) (map[string]float64, error) {
+	var symbolList []string
+	if symbols != "" {
+		symbolList = strings.Split(symbols, ",")
+	}
+	rates, err := o.GetHistoricalRates(date.UTC().Format("2006-01-02"), baseCurrency, symbolList, false, false)
+	if err != nil {
+		return nil, err
+	}
+
+	standardisedRates := make(map[string]float64)
+	for k, v := range rates {
+		curr := baseCurrency + k
+		standardisedRates[curr] = v
+	}
+
+	return standardisedRates, nil
+}
+
 // GetLatest returns the latest exchange rates available from the Open Exchange
 // Rates
 func (o *OXR) GetLatest(baseCurrency, symbols string, prettyPrint, showAlternative bool) (map[string]float64, error) {
@@ -4,6 +4,7 @@ import (
 	"log"
 	"os"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/currency/forexprovider/base"
 )
@@ -54,6 +55,14 @@ func TestGetHistoricalRates(t *testing.T) {
 	}
 }
 
+func TestGetRatesForDate(t *testing.T) {
+	t.Parallel()
+	_, err := o.GetRatesForDate(time.Date(2017, 12, 1, 0, 0, 0, 0, time.UTC), "USD", "CNH,AUD,ANG")
+	if err == nil {
+		t.Error("GetRatesForDate() Expected error")
+	}
+}
+
 func TestGetCurrencies(t *testing.T) {
 	t.Parallel()
 	_, err := o.GetCurrencies(true, true, true)
@@ -0,0 +1,283 @@
+package currency
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// MaxHistoricalRateAge is the maximum age of a daily rate which can be used
+// for a conversion. Foreign exchange markets close on weekends and public
+// holidays, so the latest rate before the conversion date is used
+const MaxHistoricalRateAge = time.Hour * 24 * 7
+
+// HistoricalRateDateLayout is the layout of dates in historical rate CSV files
+const HistoricalRateDateLayout = "2006-01-02"
+
+var (
+	// ErrHistoricalRateNotFound is returned when there is no stored rate to
+	// convert between two currencies at a point in time
+	ErrHistoricalRateNotFound = errors.New("historical rate not found")
+	// ErrNoHistoricalRateLoader is returned when attempting to load
+	// historical rates without a loader set
+	ErrNoHistoricalRateLoader = errors.New("no historical rate loader set")
+
+	errInvalidHistoricalRate   = errors.New("invalid historical rate")
+	errInvalidHistoricalCSVRow = errors.New("invalid historical rate CSV row")
+)
+
+// Validate checks the historical rate can be stored
+func (h *HistoricalRate) Validate() error {
+	if h.From.IsEmpty() || h.To.IsEmpty() {
+		return fmt.Errorf("%w %v", errInvalidHistoricalRate, ErrCurrencyCodeEmpty)
+	}
+	if h.From.Equal(h.To) {
+		return fmt.Errorf("%w %v to %v is the same currency", errInvalidHistoricalRate, h.From, h.To)
+	}
+	if h.Date.IsZero() {
+		return fmt.Errorf("%w %v", errInvalidHistoricalRate, common.ErrDateUnset)
+	}
+	if h.Rate <= 0 || math.IsInf(h.Rate, 0) || math.IsNaN(h.Rate) {
+		return fmt.Errorf("%w %v to %v rate %v", errInvalidHistoricalRate, h.From, h.To, h.Rate)
+	}
+	return nil
+}
+
+// Add stores daily rates, replacing any rate already stored for the same
+// currencies and day
+func (h *HistoricalRates) Add(rates ...HistoricalRate) error {
+	for i := range rates {
+		if err := rates[i].Validate(); err != nil {
+			return err
+		}
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.add(rates)
+	return nil
+}
+
+// SetLoader sets the loader used to retrieve rates which have not been added
+// directly. Setting a loader resets which days have already been loaded
+func (h *HistoricalRates) SetLoader(l HistoricalRateLoader) {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	h.loader = l
+	h.loaded = nil
+}
+
+// Load retrieves rates in both directions between two currencies for a date
+// range using the loader. Loading a range up front avoids retrieving rates
+// one day at a time when converting a large amount of data
+func (h *HistoricalRates) Load(from, to Code, start, end time.Time) error {
+	if from.IsEmpty() || to.IsEmpty() {
+		return ErrCurrencyCodeEmpty
+	}
+	if start.IsZero() || end.IsZero() {
+		return common.ErrDateUnset
+	}
+	if start.After(end) {
+		return common.ErrStartAfterEnd
+	}
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if h.loader == nil {
+		return ErrNoHistoricalRateLoader
+	}
+	start, end = common.TruncateDay(start), common.TruncateDay(end)
+	for _, c := range [2][2]Code{{from, to}, {to, from}} {
+		rates, err := h.loader(c[0], c[1], start.Add(-MaxHistoricalRateAge), end)
+		if err != nil {
+			return err
+		}
+		for i := range rates {
+			if err = rates[i].Validate(); err != nil {
+				return err
+			}
+		}
+		h.add(rates)
+		for day := start; !day.After(end); day = day.AddDate(0, 0, 1) {
+			h.markLoaded(c[0], c[1], day)
+		}
+	}
+	return nil
+}
+
+// GetRate returns the rate to convert one unit of a currency into another at
+// the supplied time. The rate of the time's day is used, otherwise the latest
+// rate within MaxHistoricalRateAge. When there is no direct or inverse rate
+// between the currencies, the rate is triangulated through USD
+func (h *HistoricalRates) GetRate(from, to Code, t time.Time) (float64, error) {
+	if from.IsEmpty() || to.IsEmpty() {
+		return 0, ErrCurrencyCodeEmpty
+	}
+	if t.IsZero() {
+		return 0, common.ErrDateUnset
+	}
+	if from.Equal(to) {
+		return 1, nil
+	}
+	day := common.TruncateDay(t)
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+	if rate, ok := h.pairRate(from, to, day); ok {
+		return rate, nil
+	}
+	if !from.Equal(USD) && !to.Equal(USD) {
+		if fromUSD, ok := h.pairRate(from, USD, day); ok {
+			if usdTo, ok := h.pairRate(USD, to, day); ok {
+				return fromUSD * usdTo, nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("%w %v to %v on %v", ErrHistoricalRateNotFound, from, to, day.Format(HistoricalRateDateLayout))
+}
+
+// pairRate returns the direct or inverse rate between two currencies
+func (h *HistoricalRates) pairRate(from, to Code, day time.Time) (float64, bool) {
+	if rate, ok := h.lookup(from, to, day); ok {
+		return rate, true
+	}
+	if rate, ok := h.lookup(to, from, day); ok {
+		return 1 / rate, true
+	}
+	return 0, false
+}
+
+// lookup returns the latest rate on or before the day, loading rates for the
+// day when a loader is set and the day has not been loaded before. A day is
+// only recorded as loaded once the loader succeeds so failures are retried
+func (h *HistoricalRates) lookup(from, to Code, day time.Time) (float64, bool) {
+	if h.loader != nil && !h.isLoaded(from, to, day) {
+		rates, err := h.loader(from, to, day.Add(-MaxHistoricalRateAge), day)
+		if err != nil {
+			log.Errorf(log.Global, "Failed to load historical rates for %v to %v on %v: %v",
+				from, to, day.Format(HistoricalRateDateLayout), err)
+		} else {
+			h.markLoaded(from, to, day)
+			valid := rates[:0]
+			for i := range rates {
+				if err = rates[i].Validate(); err != nil {
+					log.Errorln(log.Global, err)
+					continue
+				}
+				valid = append(valid, rates[i])
+			}
+			h.add(valid)
+		}
+	}
+	series := h.m[from.Item][to.Item]
+	idx := sort.Search(len(series), func(i int) bool {
+		return series[i].date.After(day)
+	})
+	if idx == 0 || day.Sub(series[idx-1].date) > MaxHistoricalRateAge {
+		return 0, false
+	}
+	return series[idx-1].rate, true
+}
+
+// isLoaded returns whether the loader has been queried for the day
+func (h *HistoricalRates) isLoaded(from, to Code, day time.Time) bool {
+	_, ok := h.loaded[from.Item][to.Item][day.Unix()]
+	return ok
+}
+
+// markLoaded records that a day has been loaded
+func (h *HistoricalRates) markLoaded(from, to Code, day time.Time) {
+	if h.loaded == nil {
+		h.loaded = make(map[*Item]map[*Item]map[int64]struct{})
+	}
+	toMap, ok := h.loaded[from.Item]
+	if !ok {
+		toMap = make(map[*Item]map[int64]struct{})
+		h.loaded[from.Item] = toMap
+	}
+	days, ok := toMap[to.Item]
+	if !ok {
+		days = make(map[int64]struct{})
+		toMap[to.Item] = days
+	}
+	days[day.Unix()] = struct{}{}
+}
+
+// add inserts validated rates in date order
+func (h *HistoricalRates) add(rates []HistoricalRate) {
+	if h.m == nil {
+		h.m = make(map[*Item]map[*Item][]dailyRate)
+	}
+	for i := range rates {
+		toMap, ok := h.m[rates[i].From.Item]
+		if !ok {
+			toMap = make(map[*Item][]dailyRate)
+			h.m[rates[i].From.Item] = toMap
+		}
+		day := common.TruncateDay(rates[i].Date)
+		series := toMap[rates[i].To.Item]
+		idx := sort.Search(len(series), func(j int) bool {
+			return !series[j].date.Before(day)
+		})
+		if idx < len(series) && series[idx].date.Equal(day) {
+			series[idx].rate = rates[i].Rate
+			continue
+		}
+		series = append(series, dailyRate{})
+		copy(series[idx+1:], series[idx:])
+		series[idx] = dailyRate{date: day, rate: rates[i].Rate}
+		toMap[rates[i].To.Item] = series
+	}
+}
+
+// ReadHistoricalRatesCSV parses daily rates from CSV data with the columns
+// date, from currency, to currency and rate. Dates can either be formatted as
+// HistoricalRateDateLayout or as a unix timestamp in seconds. A header row
+// beginning with "date" is skipped
+func ReadHistoricalRatesCSV(r io.Reader) ([]HistoricalRate, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+	var resp []HistoricalRate
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return resp, nil
+			}
+			return nil, err
+		}
+		if row == 1 && strings.EqualFold(record[0], "date") {
+			continue
+		}
+		var date time.Time
+		if unix, errParse := strconv.ParseInt(record[0], 10, 64); errParse == nil {
+			date = time.Unix(unix, 0)
+		} else {
+			date, err = time.Parse(HistoricalRateDateLayout, record[0])
+			if err != nil {
+				return nil, fmt.Errorf("%w %d date: %v", errInvalidHistoricalCSVRow, row, err)
+			}
+		}
+		rate, err := strconv.ParseFloat(record[3], 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w %d rate: %v", errInvalidHistoricalCSVRow, row, err)
+		}
+		h := HistoricalRate{
+			From: NewCode(record[1]),
+			To:   NewCode(record[2]),
+			Date: common.TruncateDay(date),
+			Rate: rate,
+		}
+		if err = h.Validate(); err != nil {
+			return nil, fmt.Errorf("%w %d: %v", errInvalidHistoricalCSVRow, row, err)
+		}
+		resp = append(resp, h)
+	}
+}
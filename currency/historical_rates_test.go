@@ -0,0 +1,249 @@
+package currency
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+var historicalTestDay = time.Date(2021, 10, 4, 0, 0, 0, 0, time.UTC)
+
+func TestHistoricalRateValidate(t *testing.T) {
+	t.Parallel()
+	h := HistoricalRate{}
+	if err := h.Validate(); !errors.Is(err, errInvalidHistoricalRate) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalRate)
+	}
+	h.From, h.To = EUR, EUR
+	if err := h.Validate(); !errors.Is(err, errInvalidHistoricalRate) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalRate)
+	}
+	h.To = USD
+	if err := h.Validate(); !errors.Is(err, errInvalidHistoricalRate) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalRate)
+	}
+	h.Date = historicalTestDay
+	for _, rate := range []float64{0, -1, math.Inf(1), math.NaN()} {
+		h.Rate = rate
+		if err := h.Validate(); !errors.Is(err, errInvalidHistoricalRate) {
+			t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalRate)
+		}
+	}
+	h.Rate = 1.16
+	if err := h.Validate(); !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestHistoricalRatesGetRate(t *testing.T) {
+	t.Parallel()
+	var h HistoricalRates
+	_, err := h.GetRate(EMPTYCODE, USD, historicalTestDay)
+	if !errors.Is(err, ErrCurrencyCodeEmpty) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrCurrencyCodeEmpty)
+	}
+	_, err = h.GetRate(EUR, USD, time.Time{})
+	if !errors.Is(err, common.ErrDateUnset) {
+		t.Errorf("received: '%v' but expected: '%v'", err, common.ErrDateUnset)
+	}
+	rate, err := h.GetRate(EUR, EUR, historicalTestDay)
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if rate != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", rate, 1)
+	}
+	_, err = h.GetRate(EUR, USD, historicalTestDay)
+	if !errors.Is(err, ErrHistoricalRateNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrHistoricalRateNotFound)
+	}
+
+	err = h.Add(HistoricalRate{From: EUR, To: USD, Date: historicalTestDay})
+	if !errors.Is(err, errInvalidHistoricalRate) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalRate)
+	}
+	err = h.Add(
+		HistoricalRate{From: EUR, To: USD, Date: historicalTestDay.AddDate(0, 0, 1), Rate: 1.2},
+		HistoricalRate{From: EUR, To: USD, Date: historicalTestDay, Rate: 1.1},
+		HistoricalRate{From: USD, To: JPY, Date: historicalTestDay, Rate: 100},
+		// replaces the first rate for the day
+		HistoricalRate{From: EUR, To: USD, Date: historicalTestDay.Add(time.Hour), Rate: 1.15},
+	)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	for _, tc := range []struct {
+		from, to Code
+		t        time.Time
+		expected float64
+		err      error
+	}{
+		{from: EUR, to: USD, t: historicalTestDay.Add(time.Hour * 12), expected: 1.15},
+		{from: EUR, to: USD, t: historicalTestDay.AddDate(0, 0, 1), expected: 1.2},
+		{from: USD, to: EUR, t: historicalTestDay, expected: 1 / 1.15},
+		// uses the latest rate over a weekend
+		{from: EUR, to: USD, t: historicalTestDay.AddDate(0, 0, 3), expected: 1.2},
+		{from: EUR, to: USD, t: historicalTestDay.AddDate(0, 0, 9), err: ErrHistoricalRateNotFound},
+		{from: EUR, to: USD, t: historicalTestDay.AddDate(0, 0, -1), err: ErrHistoricalRateNotFound},
+		// triangulated through USD
+		{from: EUR, to: JPY, t: historicalTestDay, expected: 1.15 * 100},
+		{from: JPY, to: EUR, t: historicalTestDay, expected: 1 / 100.0 / 1.15},
+		{from: AUD, to: JPY, t: historicalTestDay, err: ErrHistoricalRateNotFound},
+	} {
+		rate, err = h.GetRate(tc.from, tc.to, tc.t)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%v-%v received: '%v' but expected: '%v'", tc.from, tc.to, err, tc.err)
+		}
+		if math.Abs(rate-tc.expected) > 1e-12 {
+			t.Errorf("%v-%v received: '%v' but expected: '%v'", tc.from, tc.to, rate, tc.expected)
+		}
+	}
+}
+
+func TestHistoricalRatesLoader(t *testing.T) {
+	t.Parallel()
+	var h HistoricalRates
+	err := h.Load(EUR, USD, historicalTestDay, historicalTestDay)
+	if !errors.Is(err, ErrNoHistoricalRateLoader) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrNoHistoricalRateLoader)
+	}
+	err = h.Load(EUR, USD, historicalTestDay, historicalTestDay.AddDate(0, 0, -1))
+	if !errors.Is(err, common.ErrStartAfterEnd) {
+		t.Errorf("received: '%v' but expected: '%v'", err, common.ErrStartAfterEnd)
+	}
+
+	var calls int
+	h.SetLoader(func(from, to Code, start, end time.Time) ([]HistoricalRate, error) {
+		calls++
+		if !from.Equal(USD) || !to.Equal(EUR) {
+			return nil, nil
+		}
+		var resp []HistoricalRate
+		for d := start; !d.After(end); d = d.AddDate(0, 0, 1) {
+			resp = append(resp, HistoricalRate{From: USD, To: EUR, Date: d, Rate: float64(d.Day())})
+		}
+		return resp, nil
+	})
+	rate, err := h.GetRate(EUR, USD, historicalTestDay)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if rate != 1/float64(historicalTestDay.Day()) {
+		t.Errorf("received: '%v' but expected: '%v'", rate, 1/float64(historicalTestDay.Day()))
+	}
+	if calls != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", calls, 2)
+	}
+	// days which have been loaded are not requested again
+	_, err = h.GetRate(EUR, USD, historicalTestDay.Add(time.Hour))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if calls != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", calls, 2)
+	}
+
+	err = h.Load(EUR, USD, historicalTestDay.AddDate(0, 0, 1), historicalTestDay.AddDate(0, 0, 5))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if calls != 4 {
+		t.Errorf("received: '%v' but expected: '%v'", calls, 4)
+	}
+	rate, err = h.GetRate(USD, EUR, historicalTestDay.AddDate(0, 0, 5))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if rate != float64(historicalTestDay.AddDate(0, 0, 5).Day()) {
+		t.Errorf("received: '%v' but expected: '%v'", rate, historicalTestDay.AddDate(0, 0, 5).Day())
+	}
+	if calls != 4 {
+		t.Errorf("received: '%v' but expected: '%v'", calls, 4)
+	}
+}
+
+func TestHistoricalRatesLoaderRetry(t *testing.T) {
+	t.Parallel()
+	var h HistoricalRates
+	var calls int
+	h.SetLoader(func(from, to Code, start, end time.Time) ([]HistoricalRate, error) {
+		calls++
+		if calls == 1 {
+			return nil, errInvalidHistoricalRate
+		}
+		if !from.Equal(EUR) || !to.Equal(USD) {
+			return nil, nil
+		}
+		return []HistoricalRate{{From: EUR, To: USD, Date: end, Rate: 1.16}}, nil
+	})
+	// the first load fails so the direct rate is not found, the inverse load
+	// succeeds without a rate
+	_, err := h.GetRate(EUR, USD, historicalTestDay)
+	if !errors.Is(err, ErrHistoricalRateNotFound) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrHistoricalRateNotFound)
+	}
+	// the failed day is requested again
+	rate, err := h.GetRate(EUR, USD, historicalTestDay)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if rate != 1.16 {
+		t.Errorf("received: '%v' but expected: '%v'", rate, 1.16)
+	}
+	if calls != 3 {
+		t.Errorf("received: '%v' but expected: '%v'", calls, 3)
+	}
+}
+
+func TestReadHistoricalRatesCSV(t *testing.T) {
+	t.Parallel()
+	rates, err := ReadHistoricalRatesCSV(strings.NewReader("date,from,to,rate\n2021-10-04,EUR,USD,1.16\n1633392000, usd, jpy, 111.4\n"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(rates) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(rates), 2)
+	}
+	if !rates[0].From.Equal(EUR) || !rates[0].To.Equal(USD) || !rates[0].Date.Equal(historicalTestDay) || rates[0].Rate != 1.16 {
+		t.Errorf("received: '%+v' unexpected rate", rates[0])
+	}
+	if !rates[1].From.Equal(USD) || !rates[1].To.Equal(JPY) || !rates[1].Date.Equal(historicalTestDay.AddDate(0, 0, 1)) || rates[1].Rate != 111.4 {
+		t.Errorf("received: '%+v' unexpected rate", rates[1])
+	}
+
+	for _, data := range []string{
+		"04/10/2021,EUR,USD,1.16\n",
+		"2021-10-04,EUR,USD,rate\n",
+		"2021-10-04,EUR,USD,-1\n",
+	} {
+		_, err = ReadHistoricalRatesCSV(strings.NewReader(data))
+		if !errors.Is(err, errInvalidHistoricalCSVRow) {
+			t.Errorf("received: '%v' but expected: '%v'", err, errInvalidHistoricalCSVRow)
+		}
+	}
+	_, err = ReadHistoricalRatesCSV(strings.NewReader("2021-10-04,EUR,USD\n"))
+	if err == nil {
+		t.Error("expected error for missing column")
+	}
+}
+
+func TestConvertAt(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	err := AddHistoricalRates(HistoricalRate{From: GBP, To: USD, Date: day, Rate: 1.32})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	rate, err := ConvertAt(USD, GBP, day.Add(time.Hour))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if rate != 1/1.32 {
+		t.Errorf("received: '%v' but expected: '%v'", rate, 1/1.32)
+	}
+}
@@ -0,0 +1,37 @@
+package currency
+
+import (
+	"sync"
+	"time"
+)
+
+// HistoricalRate defines the daily foreign exchange rate to convert one unit
+// of the From currency into the To currency
+type HistoricalRate struct {
+	From Code
+	To   Code
+	Date time.Time
+	Rate float64
+}
+
+// HistoricalRateLoader retrieves stored daily rates to convert from one
+// currency to another between the start and end dates inclusive. It allows the
+// currency storage to source rates from a database without depending on it
+type HistoricalRateLoader func(from, to Code, start, end time.Time) ([]HistoricalRate, error)
+
+// HistoricalRates defines a protected store of daily foreign exchange rates
+// used for time-accurate conversions
+type HistoricalRates struct {
+	// m holds rates by from and to currency, sorted by date
+	m map[*Item]map[*Item][]dailyRate
+	// loaded tracks the days a loader has been queried for so that missing
+	// rates are not repeatedly requested
+	loaded map[*Item]map[*Item]map[int64]struct{}
+	loader HistoricalRateLoader
+	mtx    sync.Mutex
+}
+
+type dailyRate struct {
+	date time.Time
+	rate float64
+}
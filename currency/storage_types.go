@@ -27,6 +27,9 @@ type Storage struct {
 	baseCurrency Code
 	// FXRates defines a protected conversion rate map
 	fxRates ConversionRates
+	// historicalRates defines protected daily conversion rates for
+	// time-accurate conversions
+	historicalRates HistoricalRates
 	// DefaultBaseCurrency is the base currency used for conversion
 	defaultBaseCurrency Code
 	// DefaultFiatCurrencies has the default minimum of FIAT values
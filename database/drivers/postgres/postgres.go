@@ -13,6 +13,20 @@ import (
 
 // Connect opens a connection to Postgres database and returns a pointer to database.DB
 func Connect(cfg *database.Config) (*database.Instance, error) {
+	db, err := Open(cfg)
+	if err != nil {
+		return nil, err
+	}
+	err = database.DB.SetPostgresConnection(db)
+	if err != nil {
+		return nil, err
+	}
+	return database.DB, nil
+}
+
+// Open opens a standalone connection to a Postgres database without altering
+// the global database instance
+func Open(cfg *database.Config) (*sql.DB, error) {
 	if cfg == nil {
 		return nil, database.ErrNilConfig
 	}
@@ -22,7 +36,6 @@ func Connect(cfg *database.Config) (*database.Instance, error) {
 	if cfg.SSLMode == "" {
 		cfg.SSLMode = "disable"
 	}
-
 	host := net.JoinHostPort(cfg.Host, strconv.FormatUint(uint64(cfg.Port), 10))
 	configDSN := fmt.Sprintf("postgres://%s:%s@%s/%s?sslmode=%s",
 		cfg.Username,
@@ -30,14 +43,5 @@ func Connect(cfg *database.Config) (*database.Instance, error) {
 		host,
 		cfg.Database,
 		cfg.SSLMode)
-
-	db, err := sql.Open(database.DBPostgreSQL, configDSN)
-	if err != nil {
-		return nil, err
-	}
-	err = database.DB.SetPostgresConnection(db)
-	if err != nil {
-		return nil, err
-	}
-	return database.DB, nil
+	return sql.Open(database.DBPostgreSQL, configDSN)
 }
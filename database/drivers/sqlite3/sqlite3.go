@@ -14,17 +14,22 @@ func Connect(db string) (*database.Instance, error) {
 	if db == "" {
 		return nil, database.ErrNoDatabaseProvided
 	}
-
-	databaseFullLocation := filepath.Join(database.DB.DataPath, db)
-	dbConn, err := sql.Open("sqlite3", databaseFullLocation)
+	dbConn, err := Open(filepath.Join(database.DB.DataPath, db))
 	if err != nil {
 		return nil, err
 	}
-
 	err = database.DB.SetSQLiteConnection(dbConn)
 	if err != nil {
 		return nil, err
 	}
-
 	return database.DB, nil
 }
+
+// Open opens a standalone connection to the sqlite database at the supplied
+// location without altering the global database instance
+func Open(location string) (*sql.DB, error) {
+	if location == "" {
+		return nil, database.ErrNoDatabaseProvided
+	}
+	return sql.Open("sqlite3", location)
+}
@@ -0,0 +1,15 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS funding_rate
+(
+    id text not null primary key,
+    exchange_name_id uuid REFERENCES exchange(id) NOT NULL,
+    asset TEXT NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    rate REAL NOT NULL,
+    timestamp TIMESTAMP NOT NULL,
+    CONSTRAINT uniquefundingrate
+        unique(exchange_name_id, asset, base, quote, timestamp) ON CONFLICT IGNORE
+);
+-- +goose Down
+DROP TABLE funding_rate;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS forex_rate
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    rate DOUBLE PRECISION NOT NULL,
+    date TIMESTAMPTZ NOT NULL,
+    source varchar NOT NULL,
+    CONSTRAINT uniqueforexrate
+        unique(base, quote, date)
+);
+-- +goose Down
+DROP TABLE forex_rate;
@@ -0,0 +1,14 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS forex_rate
+(
+    id text not null primary key,
+    base text NOT NULL,
+    quote text NOT NULL,
+    rate REAL NOT NULL,
+    date TIMESTAMP NOT NULL,
+    source text NOT NULL,
+    CONSTRAINT uniqueforexrate
+        unique(base, quote, date) ON CONFLICT REPLACE
+);
+-- +goose Down
+DROP TABLE forex_rate;
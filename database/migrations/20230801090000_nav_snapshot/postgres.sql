@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS nav_snapshot
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    date TIMESTAMPTZ NOT NULL,
+    reporting_currency varchar(30) NOT NULL,
+    nav DOUBLE PRECISION NOT NULL,
+    realised_pnl DOUBLE PRECISION NOT NULL,
+    unrealised_pnl DOUBLE PRECISION NOT NULL,
+    fees DOUBLE PRECISION NOT NULL,
+    funding DOUBLE PRECISION NOT NULL,
+    CONSTRAINT uniquenavsnapshot
+        unique(date, reporting_currency)
+);
+-- +goose Down
+DROP TABLE nav_snapshot;
@@ -0,0 +1,16 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS nav_snapshot
+(
+    id text not null primary key,
+    date TIMESTAMP NOT NULL,
+    reporting_currency text NOT NULL,
+    nav REAL NOT NULL,
+    realised_pnl REAL NOT NULL,
+    unrealised_pnl REAL NOT NULL,
+    fees REAL NOT NULL,
+    funding REAL NOT NULL,
+    CONSTRAINT uniquenavsnapshot
+        unique(date, reporting_currency) ON CONFLICT REPLACE
+);
+-- +goose Down
+DROP TABLE nav_snapshot;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ledger_entry
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    entry_key text NOT NULL,
+    exchange_name varchar(128) NOT NULL,
+    sub_account varchar(128) NOT NULL,
+    asset varchar(30) NOT NULL,
+    kind varchar(30) NOT NULL,
+    reference text NOT NULL,
+    currency varchar(30) NOT NULL,
+    amount NUMERIC NOT NULL,
+    occurred TIMESTAMPTZ NOT NULL,
+    description text NOT NULL,
+    CONSTRAINT uniqueledgerentry
+        unique(entry_key)
+);
+CREATE INDEX IF NOT EXISTS ledger_entry_exchange_occurred ON ledger_entry (exchange_name, occurred);
+-- +goose Down
+DROP TABLE ledger_entry;
@@ -0,0 +1,20 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS ledger_entry
+(
+    id text not null primary key,
+    entry_key text NOT NULL,
+    exchange_name text NOT NULL,
+    sub_account text NOT NULL,
+    asset text NOT NULL,
+    kind text NOT NULL,
+    reference text NOT NULL,
+    currency text NOT NULL,
+    amount TEXT NOT NULL,
+    occurred TIMESTAMP NOT NULL,
+    description text NOT NULL,
+    CONSTRAINT uniqueledgerentry
+        unique(entry_key) ON CONFLICT IGNORE
+);
+CREATE INDEX IF NOT EXISTS ledger_entry_exchange_occurred ON ledger_entry (exchange_name, occurred);
+-- +goose Down
+DROP TABLE ledger_entry;
@@ -0,0 +1,4 @@
+-- +goose Up
+ALTER TABLE ledger_entry ALTER COLUMN amount TYPE text USING amount::text;
+-- +goose Down
+ALTER TABLE ledger_entry ALTER COLUMN amount TYPE NUMERIC USING amount::numeric;
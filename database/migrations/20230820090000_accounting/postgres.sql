@@ -0,0 +1,68 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS accounting_event
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporting_currency varchar(30) NOT NULL,
+    occurred TIMESTAMPTZ NOT NULL,
+    kind varchar(30) NOT NULL,
+    exchange_name varchar(128) NOT NULL,
+    asset varchar(30) NOT NULL,
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    order_id text NOT NULL,
+    side varchar(30) NOT NULL,
+    amount text NOT NULL,
+    value text NOT NULL,
+    realised_pnl text NOT NULL,
+    fees text NOT NULL,
+    funding text NOT NULL,
+    unmatched text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS accounting_event_reporting_occurred ON accounting_event (reporting_currency, occurred);
+
+CREATE TABLE IF NOT EXISTS accounting_lot
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    reporting_currency varchar(30) NOT NULL,
+    exchange_name varchar(128) NOT NULL,
+    currency varchar(30) NOT NULL,
+    sequence INTEGER NOT NULL,
+    reference text NOT NULL,
+    acquired TIMESTAMPTZ NOT NULL,
+    amount text NOT NULL,
+    cost text NOT NULL
+);
+CREATE INDEX IF NOT EXISTS accounting_lot_book ON accounting_lot (reporting_currency, exchange_name, currency);
+
+CREATE TABLE IF NOT EXISTS accounting_order
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    order_key text NOT NULL,
+    executed text NOT NULL,
+    quote text NOT NULL,
+    fee text NOT NULL,
+    finished TIMESTAMPTZ,
+    CONSTRAINT uniqueaccountingorder
+        unique(order_key)
+);
+
+CREATE TABLE IF NOT EXISTS accounting_position
+(
+    id uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+    position_key text NOT NULL,
+    exchange_name varchar(128) NOT NULL,
+    asset varchar(30) NOT NULL,
+    base varchar(30) NOT NULL,
+    quote varchar(30) NOT NULL,
+    settlement varchar(30) NOT NULL,
+    realised_gross text NOT NULL,
+    fees text NOT NULL,
+    funding text NOT NULL,
+    CONSTRAINT uniqueaccountingposition
+        unique(position_key)
+);
+-- +goose Down
+DROP TABLE accounting_position;
+DROP TABLE accounting_order;
+DROP TABLE accounting_lot;
+DROP TABLE accounting_event;
@@ -0,0 +1,68 @@
+-- +goose Up
+CREATE TABLE IF NOT EXISTS accounting_event
+(
+    id text not null primary key,
+    reporting_currency text NOT NULL,
+    occurred TIMESTAMP NOT NULL,
+    kind text NOT NULL,
+    exchange_name text NOT NULL,
+    asset text NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    order_id text NOT NULL,
+    side text NOT NULL,
+    amount TEXT NOT NULL,
+    value TEXT NOT NULL,
+    realised_pnl TEXT NOT NULL,
+    fees TEXT NOT NULL,
+    funding TEXT NOT NULL,
+    unmatched TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS accounting_event_reporting_occurred ON accounting_event (reporting_currency, occurred);
+
+CREATE TABLE IF NOT EXISTS accounting_lot
+(
+    id text not null primary key,
+    reporting_currency text NOT NULL,
+    exchange_name text NOT NULL,
+    currency text NOT NULL,
+    sequence INTEGER NOT NULL,
+    reference text NOT NULL,
+    acquired TIMESTAMP NOT NULL,
+    amount TEXT NOT NULL,
+    cost TEXT NOT NULL
+);
+CREATE INDEX IF NOT EXISTS accounting_lot_book ON accounting_lot (reporting_currency, exchange_name, currency);
+
+CREATE TABLE IF NOT EXISTS accounting_order
+(
+    id text not null primary key,
+    order_key text NOT NULL,
+    executed TEXT NOT NULL,
+    quote TEXT NOT NULL,
+    fee TEXT NOT NULL,
+    finished TIMESTAMP,
+    CONSTRAINT uniqueaccountingorder
+        unique(order_key) ON CONFLICT REPLACE
+);
+
+CREATE TABLE IF NOT EXISTS accounting_position
+(
+    id text not null primary key,
+    position_key text NOT NULL,
+    exchange_name text NOT NULL,
+    asset text NOT NULL,
+    base text NOT NULL,
+    quote text NOT NULL,
+    settlement text NOT NULL,
+    realised_gross TEXT NOT NULL,
+    fees TEXT NOT NULL,
+    funding TEXT NOT NULL,
+    CONSTRAINT uniqueaccountingposition
+        unique(position_key) ON CONFLICT REPLACE
+);
+-- +goose Down
+DROP TABLE accounting_position;
+DROP TABLE accounting_order;
+DROP TABLE accounting_lot;
+DROP TABLE accounting_event;
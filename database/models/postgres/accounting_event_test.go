@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAccountingEvents(t *testing.T) {
+	t.Parallel()
+
+	query := AccountingEvents()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAccountingEventsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingEventsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AccountingEvents().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingEventsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingEventSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingEventsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AccountingEventExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if AccountingEvent exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AccountingEventExists to return true, but got false.")
+	}
+}
+
+func testAccountingEventsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	accountingEventFound, err := FindAccountingEvent(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if accountingEventFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAccountingEventsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AccountingEvents().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingEventsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AccountingEvents().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAccountingEventsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	accountingEventOne := &AccountingEvent{}
+	accountingEventTwo := &AccountingEvent{}
+	if err = randomize.Struct(seed, accountingEventOne, accountingEventDBTypes, false, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingEventTwo, accountingEventDBTypes, false, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingEventOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingEventTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingEvents().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAccountingEventsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	accountingEventOne := &AccountingEvent{}
+	accountingEventTwo := &AccountingEvent{}
+	if err = randomize.Struct(seed, accountingEventOne, accountingEventDBTypes, false, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingEventTwo, accountingEventDBTypes, false, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingEventOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingEventTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func accountingEventBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func accountingEventAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingEvent) error {
+	*o = AccountingEvent{}
+	return nil
+}
+
+func testAccountingEventsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &AccountingEvent{}
+	o := &AccountingEvent{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent object: %s", err)
+	}
+
+	AddAccountingEventHook(boil.BeforeInsertHook, accountingEventBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingEventBeforeInsertHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.AfterInsertHook, accountingEventAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingEventAfterInsertHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.AfterSelectHook, accountingEventAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	accountingEventAfterSelectHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.BeforeUpdateHook, accountingEventBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingEventBeforeUpdateHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.AfterUpdateHook, accountingEventAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingEventAfterUpdateHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.BeforeDeleteHook, accountingEventBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingEventBeforeDeleteHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.AfterDeleteHook, accountingEventAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingEventAfterDeleteHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.BeforeUpsertHook, accountingEventBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingEventBeforeUpsertHooks = []AccountingEventHook{}
+
+	AddAccountingEventHook(boil.AfterUpsertHook, accountingEventAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingEventAfterUpsertHooks = []AccountingEventHook{}
+}
+
+func testAccountingEventsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingEventsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(accountingEventColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingEventsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingEventsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingEventSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingEventsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingEvents().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	accountingEventDBTypes = map[string]string{`ID`: `uuid`, `ReportingCurrency`: `character varying`, `Occurred`: `timestamp with time zone`, `Kind`: `character varying`, `ExchangeName`: `character varying`, `Asset`: `character varying`, `Base`: `character varying`, `Quote`: `character varying`, `OrderID`: `text`, `Side`: `character varying`, `Amount`: `text`, `Value`: `text`, `RealisedPNL`: `text`, `Fees`: `text`, `Funding`: `text`, `Unmatched`: `text`}
+	_                      = bytes.MinRead
+)
+
+func testAccountingEventsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(accountingEventPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(accountingEventAllColumns) == len(accountingEventPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAccountingEventsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingEventAllColumns) == len(accountingEventPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingEvent{}
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingEventDBTypes, true, accountingEventPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(accountingEventAllColumns, accountingEventPrimaryKeyColumns) {
+		fields = accountingEventAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			accountingEventAllColumns,
+			accountingEventPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AccountingEventSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAccountingEventsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingEventAllColumns) == len(accountingEventPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AccountingEvent{}
+	if err = randomize.Struct(seed, &o, accountingEventDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingEvent: %s", err)
+	}
+
+	count, err := AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, accountingEventDBTypes, false, accountingEventPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingEvent struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingEvent: %s", err)
+	}
+
+	count, err = AccountingEvents().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
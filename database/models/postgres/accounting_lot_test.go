@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAccountingLots(t *testing.T) {
+	t.Parallel()
+
+	query := AccountingLots()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAccountingLotsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingLotsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AccountingLots().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingLotsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingLotSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingLotsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AccountingLotExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if AccountingLot exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AccountingLotExists to return true, but got false.")
+	}
+}
+
+func testAccountingLotsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	accountingLotFound, err := FindAccountingLot(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if accountingLotFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAccountingLotsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AccountingLots().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingLotsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AccountingLots().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAccountingLotsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	accountingLotOne := &AccountingLot{}
+	accountingLotTwo := &AccountingLot{}
+	if err = randomize.Struct(seed, accountingLotOne, accountingLotDBTypes, false, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingLotTwo, accountingLotDBTypes, false, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingLotOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingLotTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingLots().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAccountingLotsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	accountingLotOne := &AccountingLot{}
+	accountingLotTwo := &AccountingLot{}
+	if err = randomize.Struct(seed, accountingLotOne, accountingLotDBTypes, false, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingLotTwo, accountingLotDBTypes, false, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingLotOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingLotTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func accountingLotBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func accountingLotAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingLot) error {
+	*o = AccountingLot{}
+	return nil
+}
+
+func testAccountingLotsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &AccountingLot{}
+	o := &AccountingLot{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize AccountingLot object: %s", err)
+	}
+
+	AddAccountingLotHook(boil.BeforeInsertHook, accountingLotBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingLotBeforeInsertHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.AfterInsertHook, accountingLotAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingLotAfterInsertHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.AfterSelectHook, accountingLotAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	accountingLotAfterSelectHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.BeforeUpdateHook, accountingLotBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingLotBeforeUpdateHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.AfterUpdateHook, accountingLotAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingLotAfterUpdateHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.BeforeDeleteHook, accountingLotBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingLotBeforeDeleteHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.AfterDeleteHook, accountingLotAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingLotAfterDeleteHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.BeforeUpsertHook, accountingLotBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingLotBeforeUpsertHooks = []AccountingLotHook{}
+
+	AddAccountingLotHook(boil.AfterUpsertHook, accountingLotAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingLotAfterUpsertHooks = []AccountingLotHook{}
+}
+
+func testAccountingLotsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingLotsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(accountingLotColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingLotsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingLotsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingLotSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingLotsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingLots().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	accountingLotDBTypes = map[string]string{`ID`: `uuid`, `ReportingCurrency`: `character varying`, `ExchangeName`: `character varying`, `Currency`: `character varying`, `Sequence`: `integer`, `Reference`: `text`, `Acquired`: `timestamp with time zone`, `Amount`: `text`, `Cost`: `text`}
+	_                    = bytes.MinRead
+)
+
+func testAccountingLotsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(accountingLotPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(accountingLotAllColumns) == len(accountingLotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAccountingLotsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingLotAllColumns) == len(accountingLotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingLot{}
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingLotDBTypes, true, accountingLotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(accountingLotAllColumns, accountingLotPrimaryKeyColumns) {
+		fields = accountingLotAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			accountingLotAllColumns,
+			accountingLotPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AccountingLotSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAccountingLotsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingLotAllColumns) == len(accountingLotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AccountingLot{}
+	if err = randomize.Struct(seed, &o, accountingLotDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingLot: %s", err)
+	}
+
+	count, err := AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, accountingLotDBTypes, false, accountingLotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingLot struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingLot: %s", err)
+	}
+
+	count, err = AccountingLots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,908 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+	"github.com/volatiletech/null"
+)
+
+// AccountingOrder is an object representing the database table.
+type AccountingOrder struct {
+	ID       string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	OrderKey string    `boil:"order_key" json:"order_key" toml:"order_key" yaml:"order_key"`
+	Executed string    `boil:"executed" json:"executed" toml:"executed" yaml:"executed"`
+	Quote    string    `boil:"quote" json:"quote" toml:"quote" yaml:"quote"`
+	Fee      string    `boil:"fee" json:"fee" toml:"fee" yaml:"fee"`
+	Finished null.Time `boil:"finished" json:"finished,omitempty" toml:"finished" yaml:"finished,omitempty"`
+
+	R *accountingOrderR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L accountingOrderL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AccountingOrderColumns = struct {
+	ID       string
+	OrderKey string
+	Executed string
+	Quote    string
+	Fee      string
+	Finished string
+}{
+	ID:       "id",
+	OrderKey: "order_key",
+	Executed: "executed",
+	Quote:    "quote",
+	Fee:      "fee",
+	Finished: "finished",
+}
+
+// Generated where
+
+type whereHelpernull_Time struct{ field string }
+
+func (w whereHelpernull_Time) EQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, false, x)
+}
+func (w whereHelpernull_Time) NEQ(x null.Time) qm.QueryMod {
+	return qmhelper.WhereNullEQ(w.field, true, x)
+}
+func (w whereHelpernull_Time) IsNull() qm.QueryMod    { return qmhelper.WhereIsNull(w.field) }
+func (w whereHelpernull_Time) IsNotNull() qm.QueryMod { return qmhelper.WhereIsNotNull(w.field) }
+func (w whereHelpernull_Time) LT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LT, x)
+}
+func (w whereHelpernull_Time) LTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.LTE, x)
+}
+func (w whereHelpernull_Time) GT(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GT, x)
+}
+func (w whereHelpernull_Time) GTE(x null.Time) qm.QueryMod {
+	return qmhelper.Where(w.field, qmhelper.GTE, x)
+}
+
+var AccountingOrderWhere = struct {
+	ID       whereHelperstring
+	OrderKey whereHelperstring
+	Executed whereHelperstring
+	Quote    whereHelperstring
+	Fee      whereHelperstring
+	Finished whereHelpernull_Time
+}{
+	ID:       whereHelperstring{field: "\"accounting_order\".\"id\""},
+	OrderKey: whereHelperstring{field: "\"accounting_order\".\"order_key\""},
+	Executed: whereHelperstring{field: "\"accounting_order\".\"executed\""},
+	Quote:    whereHelperstring{field: "\"accounting_order\".\"quote\""},
+	Fee:      whereHelperstring{field: "\"accounting_order\".\"fee\""},
+	Finished: whereHelpernull_Time{field: "\"accounting_order\".\"finished\""},
+}
+
+// AccountingOrderRels is where relationship names are stored.
+var AccountingOrderRels = struct {
+}{}
+
+// accountingOrderR is where relationships are stored.
+type accountingOrderR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*accountingOrderR) NewStruct() *accountingOrderR {
+	return &accountingOrderR{}
+}
+
+// accountingOrderL is where Load methods for each relationship are stored.
+type accountingOrderL struct{}
+
+var (
+	accountingOrderAllColumns            = []string{"id", "order_key", "executed", "quote", "fee", "finished"}
+	accountingOrderColumnsWithoutDefault = []string{"order_key", "executed", "quote", "fee", "finished"}
+	accountingOrderColumnsWithDefault    = []string{"id"}
+	accountingOrderPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AccountingOrderSlice is an alias for a slice of pointers to AccountingOrder.
+	// This should generally be used opposed to []AccountingOrder.
+	AccountingOrderSlice []*AccountingOrder
+	// AccountingOrderHook is the signature for custom AccountingOrder hook methods
+	AccountingOrderHook func(context.Context, boil.ContextExecutor, *AccountingOrder) error
+
+	accountingOrderQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	accountingOrderType                 = reflect.TypeOf(&AccountingOrder{})
+	accountingOrderMapping              = queries.MakeStructMapping(accountingOrderType)
+	accountingOrderPrimaryKeyMapping, _ = queries.BindMapping(accountingOrderType, accountingOrderMapping, accountingOrderPrimaryKeyColumns)
+	accountingOrderInsertCacheMut       sync.RWMutex
+	accountingOrderInsertCache          = make(map[string]insertCache)
+	accountingOrderUpdateCacheMut       sync.RWMutex
+	accountingOrderUpdateCache          = make(map[string]updateCache)
+	accountingOrderUpsertCacheMut       sync.RWMutex
+	accountingOrderUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var accountingOrderBeforeInsertHooks []AccountingOrderHook
+var accountingOrderBeforeUpdateHooks []AccountingOrderHook
+var accountingOrderBeforeDeleteHooks []AccountingOrderHook
+var accountingOrderBeforeUpsertHooks []AccountingOrderHook
+
+var accountingOrderAfterInsertHooks []AccountingOrderHook
+var accountingOrderAfterSelectHooks []AccountingOrderHook
+var accountingOrderAfterUpdateHooks []AccountingOrderHook
+var accountingOrderAfterDeleteHooks []AccountingOrderHook
+var accountingOrderAfterUpsertHooks []AccountingOrderHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AccountingOrder) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AccountingOrder) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AccountingOrder) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AccountingOrder) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AccountingOrder) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AccountingOrder) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AccountingOrder) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AccountingOrder) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AccountingOrder) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingOrderAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAccountingOrderHook registers your hook function for all future operations.
+func AddAccountingOrderHook(hookPoint boil.HookPoint, accountingOrderHook AccountingOrderHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		accountingOrderBeforeInsertHooks = append(accountingOrderBeforeInsertHooks, accountingOrderHook)
+	case boil.BeforeUpdateHook:
+		accountingOrderBeforeUpdateHooks = append(accountingOrderBeforeUpdateHooks, accountingOrderHook)
+	case boil.BeforeDeleteHook:
+		accountingOrderBeforeDeleteHooks = append(accountingOrderBeforeDeleteHooks, accountingOrderHook)
+	case boil.BeforeUpsertHook:
+		accountingOrderBeforeUpsertHooks = append(accountingOrderBeforeUpsertHooks, accountingOrderHook)
+	case boil.AfterInsertHook:
+		accountingOrderAfterInsertHooks = append(accountingOrderAfterInsertHooks, accountingOrderHook)
+	case boil.AfterSelectHook:
+		accountingOrderAfterSelectHooks = append(accountingOrderAfterSelectHooks, accountingOrderHook)
+	case boil.AfterUpdateHook:
+		accountingOrderAfterUpdateHooks = append(accountingOrderAfterUpdateHooks, accountingOrderHook)
+	case boil.AfterDeleteHook:
+		accountingOrderAfterDeleteHooks = append(accountingOrderAfterDeleteHooks, accountingOrderHook)
+	case boil.AfterUpsertHook:
+		accountingOrderAfterUpsertHooks = append(accountingOrderAfterUpsertHooks, accountingOrderHook)
+	}
+}
+
+// One returns a single accountingOrder record from the query.
+func (q accountingOrderQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AccountingOrder, error) {
+	o := &AccountingOrder{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: failed to execute a one query for accounting_order")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AccountingOrder records from the query.
+func (q accountingOrderQuery) All(ctx context.Context, exec boil.ContextExecutor) (AccountingOrderSlice, error) {
+	var o []*AccountingOrder
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres: failed to assign all query results to AccountingOrder slice")
+	}
+
+	if len(accountingOrderAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AccountingOrder records in the query.
+func (q accountingOrderQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to count accounting_order rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q accountingOrderQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: failed to check if accounting_order exists")
+	}
+
+	return count > 0, nil
+}
+
+// AccountingOrders retrieves all the records using an executor.
+func AccountingOrders(mods ...qm.QueryMod) accountingOrderQuery {
+	mods = append(mods, qm.From("\"accounting_order\""))
+	return accountingOrderQuery{NewQuery(mods...)}
+}
+
+// FindAccountingOrder retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAccountingOrder(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*AccountingOrder, error) {
+	accountingOrderObj := &AccountingOrder{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounting_order\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, accountingOrderObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: unable to select from accounting_order")
+	}
+
+	return accountingOrderObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AccountingOrder) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no accounting_order provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accountingOrderColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	accountingOrderInsertCacheMut.RLock()
+	cache, cached := accountingOrderInsertCache[key]
+	accountingOrderInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			accountingOrderAllColumns,
+			accountingOrderColumnsWithDefault,
+			accountingOrderColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(accountingOrderType, accountingOrderMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(accountingOrderType, accountingOrderMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounting_order\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounting_order\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to insert into accounting_order")
+	}
+
+	if !cached {
+		accountingOrderInsertCacheMut.Lock()
+		accountingOrderInsertCache[key] = cache
+		accountingOrderInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AccountingOrder.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AccountingOrder) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	accountingOrderUpdateCacheMut.RLock()
+	cache, cached := accountingOrderUpdateCache[key]
+	accountingOrderUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			accountingOrderAllColumns,
+			accountingOrderPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("postgres: unable to update accounting_order, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounting_order\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, accountingOrderPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(accountingOrderType, accountingOrderMapping, append(wl, accountingOrderPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update accounting_order row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by update for accounting_order")
+	}
+
+	if !cached {
+		accountingOrderUpdateCacheMut.Lock()
+		accountingOrderUpdateCache[key] = cache
+		accountingOrderUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q accountingOrderQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all for accounting_order")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected for accounting_order")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AccountingOrderSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("postgres: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingOrderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounting_order\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, accountingOrderPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all in accountingOrder slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected all in update all accountingOrder")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *AccountingOrder) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no accounting_order provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accountingOrderColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	accountingOrderUpsertCacheMut.RLock()
+	cache, cached := accountingOrderUpsertCache[key]
+	accountingOrderUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			accountingOrderAllColumns,
+			accountingOrderColumnsWithDefault,
+			accountingOrderColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			accountingOrderAllColumns,
+			accountingOrderPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("postgres: unable to upsert accounting_order, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(accountingOrderPrimaryKeyColumns))
+			copy(conflict, accountingOrderPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"accounting_order\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(accountingOrderType, accountingOrderMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(accountingOrderType, accountingOrderMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to upsert accounting_order")
+	}
+
+	if !cached {
+		accountingOrderUpsertCacheMut.Lock()
+		accountingOrderUpsertCache[key] = cache
+		accountingOrderUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single AccountingOrder record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AccountingOrder) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("postgres: no AccountingOrder provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), accountingOrderPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounting_order\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete from accounting_order")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by delete for accounting_order")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q accountingOrderQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("postgres: no accountingOrderQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from accounting_order")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for accounting_order")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AccountingOrderSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(accountingOrderBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingOrderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounting_order\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, accountingOrderPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from accountingOrder slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for accounting_order")
+	}
+
+	if len(accountingOrderAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AccountingOrder) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAccountingOrder(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AccountingOrderSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AccountingOrderSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingOrderPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounting_order\".* FROM \"accounting_order\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, accountingOrderPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to reload all in AccountingOrderSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AccountingOrderExists checks if the AccountingOrder row exists.
+func AccountingOrderExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounting_order\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: unable to check if accounting_order exists")
+	}
+
+	return exists, nil
+}
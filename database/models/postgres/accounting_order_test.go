@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAccountingOrders(t *testing.T) {
+	t.Parallel()
+
+	query := AccountingOrders()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAccountingOrdersDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingOrdersQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AccountingOrders().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingOrdersSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingOrderSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingOrdersExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AccountingOrderExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if AccountingOrder exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AccountingOrderExists to return true, but got false.")
+	}
+}
+
+func testAccountingOrdersFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	accountingOrderFound, err := FindAccountingOrder(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if accountingOrderFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAccountingOrdersBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AccountingOrders().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingOrdersOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AccountingOrders().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAccountingOrdersAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	accountingOrderOne := &AccountingOrder{}
+	accountingOrderTwo := &AccountingOrder{}
+	if err = randomize.Struct(seed, accountingOrderOne, accountingOrderDBTypes, false, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingOrderTwo, accountingOrderDBTypes, false, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingOrderOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingOrderTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingOrders().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAccountingOrdersCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	accountingOrderOne := &AccountingOrder{}
+	accountingOrderTwo := &AccountingOrder{}
+	if err = randomize.Struct(seed, accountingOrderOne, accountingOrderDBTypes, false, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingOrderTwo, accountingOrderDBTypes, false, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingOrderOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingOrderTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func accountingOrderBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func accountingOrderAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingOrder) error {
+	*o = AccountingOrder{}
+	return nil
+}
+
+func testAccountingOrdersHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &AccountingOrder{}
+	o := &AccountingOrder{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder object: %s", err)
+	}
+
+	AddAccountingOrderHook(boil.BeforeInsertHook, accountingOrderBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderBeforeInsertHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.AfterInsertHook, accountingOrderAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderAfterInsertHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.AfterSelectHook, accountingOrderAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderAfterSelectHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.BeforeUpdateHook, accountingOrderBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderBeforeUpdateHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.AfterUpdateHook, accountingOrderAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderAfterUpdateHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.BeforeDeleteHook, accountingOrderBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderBeforeDeleteHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.AfterDeleteHook, accountingOrderAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderAfterDeleteHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.BeforeUpsertHook, accountingOrderBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderBeforeUpsertHooks = []AccountingOrderHook{}
+
+	AddAccountingOrderHook(boil.AfterUpsertHook, accountingOrderAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingOrderAfterUpsertHooks = []AccountingOrderHook{}
+}
+
+func testAccountingOrdersInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingOrdersInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(accountingOrderColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingOrdersReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingOrdersReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingOrderSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingOrdersSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingOrders().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	accountingOrderDBTypes = map[string]string{`ID`: `uuid`, `OrderKey`: `text`, `Executed`: `text`, `Quote`: `text`, `Fee`: `text`, `Finished`: `timestamp with time zone`}
+	_                      = bytes.MinRead
+)
+
+func testAccountingOrdersUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(accountingOrderPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(accountingOrderAllColumns) == len(accountingOrderPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAccountingOrdersSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingOrderAllColumns) == len(accountingOrderPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingOrder{}
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingOrderDBTypes, true, accountingOrderPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(accountingOrderAllColumns, accountingOrderPrimaryKeyColumns) {
+		fields = accountingOrderAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			accountingOrderAllColumns,
+			accountingOrderPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AccountingOrderSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAccountingOrdersUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingOrderAllColumns) == len(accountingOrderPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AccountingOrder{}
+	if err = randomize.Struct(seed, &o, accountingOrderDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingOrder: %s", err)
+	}
+
+	count, err := AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, accountingOrderDBTypes, false, accountingOrderPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingOrder struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingOrder: %s", err)
+	}
+
+	count, err = AccountingOrders().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
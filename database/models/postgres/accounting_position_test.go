@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testAccountingPositions(t *testing.T) {
+	t.Parallel()
+
+	query := AccountingPositions()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testAccountingPositionsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingPositionsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := AccountingPositions().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingPositionsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingPositionSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testAccountingPositionsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := AccountingPositionExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if AccountingPosition exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected AccountingPositionExists to return true, but got false.")
+	}
+}
+
+func testAccountingPositionsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	accountingPositionFound, err := FindAccountingPosition(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if accountingPositionFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testAccountingPositionsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = AccountingPositions().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingPositionsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := AccountingPositions().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testAccountingPositionsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	accountingPositionOne := &AccountingPosition{}
+	accountingPositionTwo := &AccountingPosition{}
+	if err = randomize.Struct(seed, accountingPositionOne, accountingPositionDBTypes, false, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingPositionTwo, accountingPositionDBTypes, false, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingPositionOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingPositionTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingPositions().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testAccountingPositionsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	accountingPositionOne := &AccountingPosition{}
+	accountingPositionTwo := &AccountingPosition{}
+	if err = randomize.Struct(seed, accountingPositionOne, accountingPositionDBTypes, false, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+	if err = randomize.Struct(seed, accountingPositionTwo, accountingPositionDBTypes, false, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = accountingPositionOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = accountingPositionTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func accountingPositionBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func accountingPositionAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *AccountingPosition) error {
+	*o = AccountingPosition{}
+	return nil
+}
+
+func testAccountingPositionsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &AccountingPosition{}
+	o := &AccountingPosition{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition object: %s", err)
+	}
+
+	AddAccountingPositionHook(boil.BeforeInsertHook, accountingPositionBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionBeforeInsertHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.AfterInsertHook, accountingPositionAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionAfterInsertHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.AfterSelectHook, accountingPositionAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionAfterSelectHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.BeforeUpdateHook, accountingPositionBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionBeforeUpdateHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.AfterUpdateHook, accountingPositionAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionAfterUpdateHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.BeforeDeleteHook, accountingPositionBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionBeforeDeleteHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.AfterDeleteHook, accountingPositionAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionAfterDeleteHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.BeforeUpsertHook, accountingPositionBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionBeforeUpsertHooks = []AccountingPositionHook{}
+
+	AddAccountingPositionHook(boil.AfterUpsertHook, accountingPositionAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	accountingPositionAfterUpsertHooks = []AccountingPositionHook{}
+}
+
+func testAccountingPositionsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingPositionsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(accountingPositionColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testAccountingPositionsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingPositionsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := AccountingPositionSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testAccountingPositionsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := AccountingPositions().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	accountingPositionDBTypes = map[string]string{`ID`: `uuid`, `PositionKey`: `text`, `ExchangeName`: `character varying`, `Asset`: `character varying`, `Base`: `character varying`, `Quote`: `character varying`, `Settlement`: `character varying`, `RealisedGross`: `text`, `Fees`: `text`, `Funding`: `text`}
+	_                         = bytes.MinRead
+)
+
+func testAccountingPositionsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(accountingPositionPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(accountingPositionAllColumns) == len(accountingPositionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testAccountingPositionsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingPositionAllColumns) == len(accountingPositionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &AccountingPosition{}
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, accountingPositionDBTypes, true, accountingPositionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(accountingPositionAllColumns, accountingPositionPrimaryKeyColumns) {
+		fields = accountingPositionAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			accountingPositionAllColumns,
+			accountingPositionPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := AccountingPositionSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testAccountingPositionsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(accountingPositionAllColumns) == len(accountingPositionPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := AccountingPosition{}
+	if err = randomize.Struct(seed, &o, accountingPositionDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingPosition: %s", err)
+	}
+
+	count, err := AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, accountingPositionDBTypes, false, accountingPositionPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize AccountingPosition struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert AccountingPosition: %s", err)
+	}
+
+	count, err = AccountingPositions().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
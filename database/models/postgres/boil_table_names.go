@@ -4,12 +4,20 @@
 package postgres
 
 var TableNames = struct {
+	AccountingEvent         string
+	AccountingLot           string
+	AccountingOrder         string
+	AccountingPosition      string
 	AuditEvent              string
 	Candle                  string
 	Datahistoryjob          string
 	Datahistoryjobrelations string
 	Datahistoryjobresult    string
 	Exchange                string
+	ForexRate               string
+	FundingRate             string
+	LedgerEntry             string
+	NavSnapshot             string
 	Script                  string
 	ScriptExecution         string
 	Trade                   string
@@ -17,12 +25,20 @@ var TableNames = struct {
 	WithdrawalFiat          string
 	WithdrawalHistory       string
 }{
+	AccountingEvent:         "accounting_event",
+	AccountingLot:           "accounting_lot",
+	AccountingOrder:         "accounting_order",
+	AccountingPosition:      "accounting_position",
 	AuditEvent:              "audit_event",
 	Candle:                  "candle",
 	Datahistoryjob:          "datahistoryjob",
 	Datahistoryjobrelations: "datahistoryjobrelations",
 	Datahistoryjobresult:    "datahistoryjobresult",
 	Exchange:                "exchange",
+	ForexRate:               "forex_rate",
+	FundingRate:             "funding_rate",
+	LedgerEntry:             "ledger_entry",
+	NavSnapshot:             "nav_snapshot",
 	Script:                  "script",
 	ScriptExecution:         "script_execution",
 	Trade:                   "trade",
@@ -53,12 +53,14 @@ var ExchangeRels = struct {
 	ExchangeNameCandles              string
 	ExchangeNameDatahistoryjobs      string
 	SecondaryExchangeDatahistoryjobs string
+	ExchangeNameFundingRates         string
 	ExchangeNameTrades               string
 	ExchangeNameWithdrawalHistories  string
 }{
 	ExchangeNameCandles:              "ExchangeNameCandles",
 	ExchangeNameDatahistoryjobs:      "ExchangeNameDatahistoryjobs",
 	SecondaryExchangeDatahistoryjobs: "SecondaryExchangeDatahistoryjobs",
+	ExchangeNameFundingRates:         "ExchangeNameFundingRates",
 	ExchangeNameTrades:               "ExchangeNameTrades",
 	ExchangeNameWithdrawalHistories:  "ExchangeNameWithdrawalHistories",
 }
@@ -68,6 +70,7 @@ type exchangeR struct {
 	ExchangeNameCandles              CandleSlice
 	ExchangeNameDatahistoryjobs      DatahistoryjobSlice
 	SecondaryExchangeDatahistoryjobs DatahistoryjobSlice
+	ExchangeNameFundingRates         FundingRateSlice
 	ExchangeNameTrades               TradeSlice
 	ExchangeNameWithdrawalHistories  WithdrawalHistorySlice
 }
@@ -425,6 +428,27 @@ func (o *Exchange) SecondaryExchangeDatahistoryjobs(mods ...qm.QueryMod) datahis
 	return query
 }
 
+// ExchangeNameFundingRates retrieves all the funding_rate's FundingRates with an executor via exchange_name_id column.
+func (o *Exchange) ExchangeNameFundingRates(mods ...qm.QueryMod) fundingRateQuery {
+	var queryMods []qm.QueryMod
+	if len(mods) != 0 {
+		queryMods = append(queryMods, mods...)
+	}
+
+	queryMods = append(queryMods,
+		qm.Where("\"funding_rate\".\"exchange_name_id\"=?", o.ID),
+	)
+
+	query := FundingRates(queryMods...)
+	queries.SetFrom(query.Query, "\"funding_rate\"")
+
+	if len(queries.GetSelect(query.Query)) == 0 {
+		queries.SetSelect(query.Query, []string{"\"funding_rate\".*"})
+	}
+
+	return query
+}
+
 // ExchangeNameTrades retrieves all the trade's Trades with an executor via exchange_name_id column.
 func (o *Exchange) ExchangeNameTrades(mods ...qm.QueryMod) tradeQuery {
 	var queryMods []qm.QueryMod
@@ -752,6 +776,101 @@ func (exchangeL) LoadSecondaryExchangeDatahistoryjobs(ctx context.Context, e boi
 	return nil
 }
 
+// LoadExchangeNameFundingRates allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-M or N-M relationship.
+func (exchangeL) LoadExchangeNameFundingRates(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
+	var slice []*Exchange
+	var object *Exchange
+
+	if singular {
+		object = maybeExchange.(*Exchange)
+	} else {
+		slice = *maybeExchange.(*[]*Exchange)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &exchangeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &exchangeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`funding_rate`), qm.WhereIn(`funding_rate.exchange_name_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load funding_rate")
+	}
+
+	var resultSlice []*FundingRate
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice funding_rate")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results in eager load on funding_rate")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for funding_rate")
+	}
+
+	if len(fundingRateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+	if singular {
+		object.R.ExchangeNameFundingRates = resultSlice
+		for _, foreign := range resultSlice {
+			if foreign.R == nil {
+				foreign.R = &fundingRateR{}
+			}
+			foreign.R.ExchangeName = object
+		}
+		return nil
+	}
+
+	for _, foreign := range resultSlice {
+		for _, local := range slice {
+			if local.ID == foreign.ExchangeNameID {
+				local.R.ExchangeNameFundingRates = append(local.R.ExchangeNameFundingRates, foreign)
+				if foreign.R == nil {
+					foreign.R = &fundingRateR{}
+				}
+				foreign.R.ExchangeName = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadExchangeNameTrades allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-M or N-M relationship.
 func (exchangeL) LoadExchangeNameTrades(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
@@ -1171,6 +1290,59 @@ func (o *Exchange) RemoveSecondaryExchangeDatahistoryjobs(ctx context.Context, e
 	return nil
 }
 
+// AddExchangeNameFundingRates adds the given related objects to the existing relationships
+// of the exchange, optionally inserting them as new records.
+// Appends related to o.R.ExchangeNameFundingRates.
+// Sets related.R.ExchangeName appropriately.
+func (o *Exchange) AddExchangeNameFundingRates(ctx context.Context, exec boil.ContextExecutor, insert bool, related ...*FundingRate) error {
+	var err error
+	for _, rel := range related {
+		if insert {
+			rel.ExchangeNameID = o.ID
+			if err = rel.Insert(ctx, exec, boil.Infer()); err != nil {
+				return errors.Wrap(err, "failed to insert into foreign table")
+			}
+		} else {
+			updateQuery := fmt.Sprintf(
+				"UPDATE \"funding_rate\" SET %s WHERE %s",
+				strmangle.SetParamNames("\"", "\"", 1, []string{"exchange_name_id"}),
+				strmangle.WhereClause("\"", "\"", 2, fundingRatePrimaryKeyColumns),
+			)
+			values := []interface{}{o.ID, rel.ID}
+
+			if boil.DebugMode {
+				fmt.Fprintln(boil.DebugWriter, updateQuery)
+				fmt.Fprintln(boil.DebugWriter, values)
+			}
+
+			if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+				return errors.Wrap(err, "failed to update foreign table")
+			}
+
+			rel.ExchangeNameID = o.ID
+		}
+	}
+
+	if o.R == nil {
+		o.R = &exchangeR{
+			ExchangeNameFundingRates: related,
+		}
+	} else {
+		o.R.ExchangeNameFundingRates = append(o.R.ExchangeNameFundingRates, related...)
+	}
+
+	for _, rel := range related {
+		if rel.R == nil {
+			rel.R = &fundingRateR{
+				ExchangeName: o,
+			}
+		} else {
+			rel.R.ExchangeName = o
+		}
+	}
+	return nil
+}
+
 // AddExchangeNameTrades adds the given related objects to the existing relationships
 // of the exchange, optionally inserting them as new records.
 // Appends related to o.R.ExchangeNameTrades.
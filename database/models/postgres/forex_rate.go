@@ -0,0 +1,884 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// ForexRate is an object representing the database table.
+type ForexRate struct {
+	ID     string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Base   string    `boil:"base" json:"base" toml:"base" yaml:"base"`
+	Quote  string    `boil:"quote" json:"quote" toml:"quote" yaml:"quote"`
+	Rate   float64   `boil:"rate" json:"rate" toml:"rate" yaml:"rate"`
+	Date   time.Time `boil:"date" json:"date" toml:"date" yaml:"date"`
+	Source string    `boil:"source" json:"source" toml:"source" yaml:"source"`
+
+	R *forexRateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L forexRateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var ForexRateColumns = struct {
+	ID     string
+	Base   string
+	Quote  string
+	Rate   string
+	Date   string
+	Source string
+}{
+	ID:     "id",
+	Base:   "base",
+	Quote:  "quote",
+	Rate:   "rate",
+	Date:   "date",
+	Source: "source",
+}
+
+// Generated where
+
+var ForexRateWhere = struct {
+	ID     whereHelperstring
+	Base   whereHelperstring
+	Quote  whereHelperstring
+	Rate   whereHelperfloat64
+	Date   whereHelpertime_Time
+	Source whereHelperstring
+}{
+	ID:     whereHelperstring{field: "\"forex_rate\".\"id\""},
+	Base:   whereHelperstring{field: "\"forex_rate\".\"base\""},
+	Quote:  whereHelperstring{field: "\"forex_rate\".\"quote\""},
+	Rate:   whereHelperfloat64{field: "\"forex_rate\".\"rate\""},
+	Date:   whereHelpertime_Time{field: "\"forex_rate\".\"date\""},
+	Source: whereHelperstring{field: "\"forex_rate\".\"source\""},
+}
+
+// ForexRateRels is where relationship names are stored.
+var ForexRateRels = struct {
+}{}
+
+// forexRateR is where relationships are stored.
+type forexRateR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*forexRateR) NewStruct() *forexRateR {
+	return &forexRateR{}
+}
+
+// forexRateL is where Load methods for each relationship are stored.
+type forexRateL struct{}
+
+var (
+	forexRateAllColumns            = []string{"id", "base", "quote", "rate", "date", "source"}
+	forexRateColumnsWithoutDefault = []string{"base", "quote", "rate", "date", "source"}
+	forexRateColumnsWithDefault    = []string{"id"}
+	forexRatePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// ForexRateSlice is an alias for a slice of pointers to ForexRate.
+	// This should generally be used opposed to []ForexRate.
+	ForexRateSlice []*ForexRate
+	// ForexRateHook is the signature for custom ForexRate hook methods
+	ForexRateHook func(context.Context, boil.ContextExecutor, *ForexRate) error
+
+	forexRateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	forexRateType                 = reflect.TypeOf(&ForexRate{})
+	forexRateMapping              = queries.MakeStructMapping(forexRateType)
+	forexRatePrimaryKeyMapping, _ = queries.BindMapping(forexRateType, forexRateMapping, forexRatePrimaryKeyColumns)
+	forexRateInsertCacheMut       sync.RWMutex
+	forexRateInsertCache          = make(map[string]insertCache)
+	forexRateUpdateCacheMut       sync.RWMutex
+	forexRateUpdateCache          = make(map[string]updateCache)
+	forexRateUpsertCacheMut       sync.RWMutex
+	forexRateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var forexRateBeforeInsertHooks []ForexRateHook
+var forexRateBeforeUpdateHooks []ForexRateHook
+var forexRateBeforeDeleteHooks []ForexRateHook
+var forexRateBeforeUpsertHooks []ForexRateHook
+
+var forexRateAfterInsertHooks []ForexRateHook
+var forexRateAfterSelectHooks []ForexRateHook
+var forexRateAfterUpdateHooks []ForexRateHook
+var forexRateAfterDeleteHooks []ForexRateHook
+var forexRateAfterUpsertHooks []ForexRateHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *ForexRate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *ForexRate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *ForexRate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *ForexRate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *ForexRate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *ForexRate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *ForexRate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *ForexRate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *ForexRate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range forexRateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddForexRateHook registers your hook function for all future operations.
+func AddForexRateHook(hookPoint boil.HookPoint, forexRateHook ForexRateHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		forexRateBeforeInsertHooks = append(forexRateBeforeInsertHooks, forexRateHook)
+	case boil.BeforeUpdateHook:
+		forexRateBeforeUpdateHooks = append(forexRateBeforeUpdateHooks, forexRateHook)
+	case boil.BeforeDeleteHook:
+		forexRateBeforeDeleteHooks = append(forexRateBeforeDeleteHooks, forexRateHook)
+	case boil.BeforeUpsertHook:
+		forexRateBeforeUpsertHooks = append(forexRateBeforeUpsertHooks, forexRateHook)
+	case boil.AfterInsertHook:
+		forexRateAfterInsertHooks = append(forexRateAfterInsertHooks, forexRateHook)
+	case boil.AfterSelectHook:
+		forexRateAfterSelectHooks = append(forexRateAfterSelectHooks, forexRateHook)
+	case boil.AfterUpdateHook:
+		forexRateAfterUpdateHooks = append(forexRateAfterUpdateHooks, forexRateHook)
+	case boil.AfterDeleteHook:
+		forexRateAfterDeleteHooks = append(forexRateAfterDeleteHooks, forexRateHook)
+	case boil.AfterUpsertHook:
+		forexRateAfterUpsertHooks = append(forexRateAfterUpsertHooks, forexRateHook)
+	}
+}
+
+// One returns a single forexRate record from the query.
+func (q forexRateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*ForexRate, error) {
+	o := &ForexRate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: failed to execute a one query for forex_rate")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all ForexRate records from the query.
+func (q forexRateQuery) All(ctx context.Context, exec boil.ContextExecutor) (ForexRateSlice, error) {
+	var o []*ForexRate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres: failed to assign all query results to ForexRate slice")
+	}
+
+	if len(forexRateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all ForexRate records in the query.
+func (q forexRateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to count forex_rate rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q forexRateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: failed to check if forex_rate exists")
+	}
+
+	return count > 0, nil
+}
+
+// ForexRates retrieves all the records using an executor.
+func ForexRates(mods ...qm.QueryMod) forexRateQuery {
+	mods = append(mods, qm.From("\"forex_rate\""))
+	return forexRateQuery{NewQuery(mods...)}
+}
+
+// FindForexRate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindForexRate(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*ForexRate, error) {
+	forexRateObj := &ForexRate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"forex_rate\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, forexRateObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: unable to select from forex_rate")
+	}
+
+	return forexRateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *ForexRate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no forex_rate provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forexRateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	forexRateInsertCacheMut.RLock()
+	cache, cached := forexRateInsertCache[key]
+	forexRateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			forexRateAllColumns,
+			forexRateColumnsWithDefault,
+			forexRateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(forexRateType, forexRateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(forexRateType, forexRateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"forex_rate\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"forex_rate\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to insert into forex_rate")
+	}
+
+	if !cached {
+		forexRateInsertCacheMut.Lock()
+		forexRateInsertCache[key] = cache
+		forexRateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the ForexRate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *ForexRate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	forexRateUpdateCacheMut.RLock()
+	cache, cached := forexRateUpdateCache[key]
+	forexRateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			forexRateAllColumns,
+			forexRatePrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("postgres: unable to update forex_rate, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"forex_rate\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, forexRatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(forexRateType, forexRateMapping, append(wl, forexRatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update forex_rate row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by update for forex_rate")
+	}
+
+	if !cached {
+		forexRateUpdateCacheMut.Lock()
+		forexRateUpdateCache[key] = cache
+		forexRateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q forexRateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all for forex_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected for forex_rate")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o ForexRateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("postgres: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forexRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"forex_rate\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, forexRatePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all in forexRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected all in update all forexRate")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *ForexRate) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no forex_rate provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(forexRateColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	forexRateUpsertCacheMut.RLock()
+	cache, cached := forexRateUpsertCache[key]
+	forexRateUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			forexRateAllColumns,
+			forexRateColumnsWithDefault,
+			forexRateColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			forexRateAllColumns,
+			forexRatePrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("postgres: unable to upsert forex_rate, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(forexRatePrimaryKeyColumns))
+			copy(conflict, forexRatePrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"forex_rate\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(forexRateType, forexRateMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(forexRateType, forexRateMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to upsert forex_rate")
+	}
+
+	if !cached {
+		forexRateUpsertCacheMut.Lock()
+		forexRateUpsertCache[key] = cache
+		forexRateUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single ForexRate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *ForexRate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("postgres: no ForexRate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), forexRatePrimaryKeyMapping)
+	sql := "DELETE FROM \"forex_rate\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete from forex_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by delete for forex_rate")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q forexRateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("postgres: no forexRateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from forex_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for forex_rate")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o ForexRateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(forexRateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forexRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"forex_rate\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, forexRatePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from forexRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for forex_rate")
+	}
+
+	if len(forexRateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *ForexRate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindForexRate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *ForexRateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := ForexRateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), forexRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"forex_rate\".* FROM \"forex_rate\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, forexRatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to reload all in ForexRateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// ForexRateExists checks if the ForexRate row exists.
+func ForexRateExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"forex_rate\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: unable to check if forex_rate exists")
+	}
+
+	return exists, nil
+}
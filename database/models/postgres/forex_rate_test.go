@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testForexRates(t *testing.T) {
+	t.Parallel()
+
+	query := ForexRates()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testForexRatesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForexRatesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := ForexRates().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForexRatesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ForexRateSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testForexRatesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := ForexRateExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if ForexRate exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected ForexRateExists to return true, but got false.")
+	}
+}
+
+func testForexRatesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	forexRateFound, err := FindForexRate(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if forexRateFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testForexRatesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = ForexRates().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForexRatesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := ForexRates().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testForexRatesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	forexRateOne := &ForexRate{}
+	forexRateTwo := &ForexRate{}
+	if err = randomize.Struct(seed, forexRateOne, forexRateDBTypes, false, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+	if err = randomize.Struct(seed, forexRateTwo, forexRateDBTypes, false, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = forexRateOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = forexRateTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ForexRates().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testForexRatesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	forexRateOne := &ForexRate{}
+	forexRateTwo := &ForexRate{}
+	if err = randomize.Struct(seed, forexRateOne, forexRateDBTypes, false, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+	if err = randomize.Struct(seed, forexRateTwo, forexRateDBTypes, false, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = forexRateOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = forexRateTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func forexRateBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func forexRateAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *ForexRate) error {
+	*o = ForexRate{}
+	return nil
+}
+
+func testForexRatesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &ForexRate{}
+	o := &ForexRate{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, forexRateDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize ForexRate object: %s", err)
+	}
+
+	AddForexRateHook(boil.BeforeInsertHook, forexRateBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	forexRateBeforeInsertHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.AfterInsertHook, forexRateAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	forexRateAfterInsertHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.AfterSelectHook, forexRateAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	forexRateAfterSelectHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.BeforeUpdateHook, forexRateBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	forexRateBeforeUpdateHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.AfterUpdateHook, forexRateAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	forexRateAfterUpdateHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.BeforeDeleteHook, forexRateBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	forexRateBeforeDeleteHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.AfterDeleteHook, forexRateAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	forexRateAfterDeleteHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.BeforeUpsertHook, forexRateBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	forexRateBeforeUpsertHooks = []ForexRateHook{}
+
+	AddForexRateHook(boil.AfterUpsertHook, forexRateAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	forexRateAfterUpsertHooks = []ForexRateHook{}
+}
+
+func testForexRatesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testForexRatesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(forexRateColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testForexRatesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForexRatesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := ForexRateSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testForexRatesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := ForexRates().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	forexRateDBTypes = map[string]string{`ID`: `uuid`, `Base`: `character varying`, `Quote`: `character varying`, `Rate`: `double precision`, `Date`: `timestamp with time zone`, `Source`: `character varying`}
+	_                = bytes.MinRead
+)
+
+func testForexRatesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(forexRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(forexRateAllColumns) == len(forexRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testForexRatesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(forexRateAllColumns) == len(forexRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &ForexRate{}
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, forexRateDBTypes, true, forexRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(forexRateAllColumns, forexRatePrimaryKeyColumns) {
+		fields = forexRateAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			forexRateAllColumns,
+			forexRatePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := ForexRateSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testForexRatesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(forexRateAllColumns) == len(forexRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := ForexRate{}
+	if err = randomize.Struct(seed, &o, forexRateDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ForexRate: %s", err)
+	}
+
+	count, err := ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, forexRateDBTypes, false, forexRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize ForexRate struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert ForexRate: %s", err)
+	}
+
+	count, err = ForexRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,841 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testFundingRates(t *testing.T) {
+	t.Parallel()
+
+	query := FundingRates()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testFundingRatesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFundingRatesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := FundingRates().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFundingRatesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := FundingRateSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testFundingRatesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := FundingRateExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if FundingRate exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected FundingRateExists to return true, but got false.")
+	}
+}
+
+func testFundingRatesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	fundingRateFound, err := FindFundingRate(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if fundingRateFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testFundingRatesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = FundingRates().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFundingRatesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := FundingRates().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testFundingRatesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	fundingRateOne := &FundingRate{}
+	fundingRateTwo := &FundingRate{}
+	if err = randomize.Struct(seed, fundingRateOne, fundingRateDBTypes, false, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+	if err = randomize.Struct(seed, fundingRateTwo, fundingRateDBTypes, false, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = fundingRateOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = fundingRateTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := FundingRates().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testFundingRatesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	fundingRateOne := &FundingRate{}
+	fundingRateTwo := &FundingRate{}
+	if err = randomize.Struct(seed, fundingRateOne, fundingRateDBTypes, false, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+	if err = randomize.Struct(seed, fundingRateTwo, fundingRateDBTypes, false, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = fundingRateOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = fundingRateTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func fundingRateBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func fundingRateAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *FundingRate) error {
+	*o = FundingRate{}
+	return nil
+}
+
+func testFundingRatesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &FundingRate{}
+	o := &FundingRate{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize FundingRate object: %s", err)
+	}
+
+	AddFundingRateHook(boil.BeforeInsertHook, fundingRateBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	fundingRateBeforeInsertHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.AfterInsertHook, fundingRateAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	fundingRateAfterInsertHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.AfterSelectHook, fundingRateAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	fundingRateAfterSelectHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.BeforeUpdateHook, fundingRateBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	fundingRateBeforeUpdateHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.AfterUpdateHook, fundingRateAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	fundingRateAfterUpdateHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.BeforeDeleteHook, fundingRateBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	fundingRateBeforeDeleteHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.AfterDeleteHook, fundingRateAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	fundingRateAfterDeleteHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.BeforeUpsertHook, fundingRateBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	fundingRateBeforeUpsertHooks = []FundingRateHook{}
+
+	AddFundingRateHook(boil.AfterUpsertHook, fundingRateAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	fundingRateAfterUpsertHooks = []FundingRateHook{}
+}
+
+func testFundingRatesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testFundingRatesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(fundingRateColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testFundingRateToOneExchangeUsingExchangeName(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var local FundingRate
+	var foreign Exchange
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &local, fundingRateDBTypes, false, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &foreign, exchangeDBTypes, false, exchangeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Exchange struct: %s", err)
+	}
+
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	local.ExchangeNameID = foreign.ID
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.ExchangeName().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ID != foreign.ID {
+		t.Errorf("want: %v, got %v", foreign.ID, check.ID)
+	}
+
+	slice := FundingRateSlice{&local}
+	if err = local.L.LoadExchangeName(ctx, tx, false, (*[]*FundingRate)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeName == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.ExchangeName = nil
+	if err = local.L.LoadExchangeName(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeName == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
+func testFundingRateToOneSetOpExchangeUsingExchangeName(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a FundingRate
+	var b, c Exchange
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, fundingRateDBTypes, false, strmangle.SetComplement(fundingRatePrimaryKeyColumns, fundingRateColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, exchangeDBTypes, false, strmangle.SetComplement(exchangePrimaryKeyColumns, exchangeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, exchangeDBTypes, false, strmangle.SetComplement(exchangePrimaryKeyColumns, exchangeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*Exchange{&b, &c} {
+		err = a.SetExchangeName(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.ExchangeName != x {
+			t.Error("relationship struct not set to correct value")
+		}
+
+		if x.R.ExchangeNameFundingRates[0] != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+		if a.ExchangeNameID != x.ID {
+			t.Error("foreign key was wrong value", a.ExchangeNameID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(a.ExchangeNameID))
+		reflect.Indirect(reflect.ValueOf(&a.ExchangeNameID)).Set(zero)
+
+		if err = a.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ExchangeNameID != x.ID {
+			t.Error("foreign key was wrong value", a.ExchangeNameID, x.ID)
+		}
+	}
+}
+
+func testFundingRatesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFundingRatesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := FundingRateSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testFundingRatesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := FundingRates().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	fundingRateDBTypes = map[string]string{`ID`: `uuid`, `ExchangeNameID`: `uuid`, `Asset`: `character varying`, `Base`: `character varying`, `Quote`: `character varying`, `Rate`: `double precision`, `Timestamp`: `timestamp with time zone`}
+	_                  = bytes.MinRead
+)
+
+func testFundingRatesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(fundingRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(fundingRateAllColumns) == len(fundingRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testFundingRatesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(fundingRateAllColumns) == len(fundingRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &FundingRate{}
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, fundingRateDBTypes, true, fundingRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(fundingRateAllColumns, fundingRatePrimaryKeyColumns) {
+		fields = fundingRateAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			fundingRateAllColumns,
+			fundingRatePrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := FundingRateSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testFundingRatesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(fundingRateAllColumns) == len(fundingRatePrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := FundingRate{}
+	if err = randomize.Struct(seed, &o, fundingRateDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert FundingRate: %s", err)
+	}
+
+	count, err := FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, fundingRateDBTypes, false, fundingRatePrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert FundingRate: %s", err)
+	}
+
+	count, err = FundingRates().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
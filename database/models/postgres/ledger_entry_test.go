@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testLedgerEntries(t *testing.T) {
+	t.Parallel()
+
+	query := LedgerEntries()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testLedgerEntriesDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLedgerEntriesQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := LedgerEntries().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLedgerEntriesSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LedgerEntrySlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testLedgerEntriesExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := LedgerEntryExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if LedgerEntry exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected LedgerEntryExists to return true, but got false.")
+	}
+}
+
+func testLedgerEntriesFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	ledgerEntryFound, err := FindLedgerEntry(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if ledgerEntryFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testLedgerEntriesBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = LedgerEntries().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLedgerEntriesOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := LedgerEntries().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testLedgerEntriesAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	ledgerEntryOne := &LedgerEntry{}
+	ledgerEntryTwo := &LedgerEntry{}
+	if err = randomize.Struct(seed, ledgerEntryOne, ledgerEntryDBTypes, false, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+	if err = randomize.Struct(seed, ledgerEntryTwo, ledgerEntryDBTypes, false, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = ledgerEntryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = ledgerEntryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := LedgerEntries().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testLedgerEntriesCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	ledgerEntryOne := &LedgerEntry{}
+	ledgerEntryTwo := &LedgerEntry{}
+	if err = randomize.Struct(seed, ledgerEntryOne, ledgerEntryDBTypes, false, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+	if err = randomize.Struct(seed, ledgerEntryTwo, ledgerEntryDBTypes, false, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = ledgerEntryOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = ledgerEntryTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func ledgerEntryBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func ledgerEntryAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *LedgerEntry) error {
+	*o = LedgerEntry{}
+	return nil
+}
+
+func testLedgerEntriesHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &LedgerEntry{}
+	o := &LedgerEntry{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry object: %s", err)
+	}
+
+	AddLedgerEntryHook(boil.BeforeInsertHook, ledgerEntryBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryBeforeInsertHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.AfterInsertHook, ledgerEntryAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryAfterInsertHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.AfterSelectHook, ledgerEntryAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryAfterSelectHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.BeforeUpdateHook, ledgerEntryBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryBeforeUpdateHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.AfterUpdateHook, ledgerEntryAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryAfterUpdateHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.BeforeDeleteHook, ledgerEntryBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryBeforeDeleteHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.AfterDeleteHook, ledgerEntryAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryAfterDeleteHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.BeforeUpsertHook, ledgerEntryBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryBeforeUpsertHooks = []LedgerEntryHook{}
+
+	AddLedgerEntryHook(boil.AfterUpsertHook, ledgerEntryAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	ledgerEntryAfterUpsertHooks = []LedgerEntryHook{}
+}
+
+func testLedgerEntriesInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLedgerEntriesInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(ledgerEntryColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testLedgerEntriesReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLedgerEntriesReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := LedgerEntrySlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testLedgerEntriesSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := LedgerEntries().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	ledgerEntryDBTypes = map[string]string{`ID`: `uuid`, `EntryKey`: `text`, `ExchangeName`: `character varying`, `SubAccount`: `character varying`, `Asset`: `character varying`, `Kind`: `character varying`, `Reference`: `text`, `Currency`: `character varying`, `Amount`: `text`, `Occurred`: `timestamp with time zone`, `Description`: `text`}
+	_                  = bytes.MinRead
+)
+
+func testLedgerEntriesUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(ledgerEntryPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(ledgerEntryAllColumns) == len(ledgerEntryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testLedgerEntriesSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(ledgerEntryAllColumns) == len(ledgerEntryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &LedgerEntry{}
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, ledgerEntryDBTypes, true, ledgerEntryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(ledgerEntryAllColumns, ledgerEntryPrimaryKeyColumns) {
+		fields = ledgerEntryAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			ledgerEntryAllColumns,
+			ledgerEntryPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := LedgerEntrySlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testLedgerEntriesUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(ledgerEntryAllColumns) == len(ledgerEntryPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := LedgerEntry{}
+	if err = randomize.Struct(seed, &o, ledgerEntryDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert LedgerEntry: %s", err)
+	}
+
+	count, err := LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, ledgerEntryDBTypes, false, ledgerEntryPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize LedgerEntry struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert LedgerEntry: %s", err)
+	}
+
+	count, err = LedgerEntries().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
@@ -0,0 +1,894 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// NavSnapshot is an object representing the database table.
+type NavSnapshot struct {
+	ID                string    `boil:"id" json:"id" toml:"id" yaml:"id"`
+	Date              time.Time `boil:"date" json:"date" toml:"date" yaml:"date"`
+	ReportingCurrency string    `boil:"reporting_currency" json:"reporting_currency" toml:"reporting_currency" yaml:"reporting_currency"`
+	Nav               float64   `boil:"nav" json:"nav" toml:"nav" yaml:"nav"`
+	RealisedPNL       float64   `boil:"realised_pnl" json:"realised_pnl" toml:"realised_pnl" yaml:"realised_pnl"`
+	UnrealisedPNL     float64   `boil:"unrealised_pnl" json:"unrealised_pnl" toml:"unrealised_pnl" yaml:"unrealised_pnl"`
+	Fees              float64   `boil:"fees" json:"fees" toml:"fees" yaml:"fees"`
+	Funding           float64   `boil:"funding" json:"funding" toml:"funding" yaml:"funding"`
+
+	R *navSnapshotR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L navSnapshotL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var NavSnapshotColumns = struct {
+	ID                string
+	Date              string
+	ReportingCurrency string
+	Nav               string
+	RealisedPNL       string
+	UnrealisedPNL     string
+	Fees              string
+	Funding           string
+}{
+	ID:                "id",
+	Date:              "date",
+	ReportingCurrency: "reporting_currency",
+	Nav:               "nav",
+	RealisedPNL:       "realised_pnl",
+	UnrealisedPNL:     "unrealised_pnl",
+	Fees:              "fees",
+	Funding:           "funding",
+}
+
+// Generated where
+
+var NavSnapshotWhere = struct {
+	ID                whereHelperstring
+	Date              whereHelpertime_Time
+	ReportingCurrency whereHelperstring
+	Nav               whereHelperfloat64
+	RealisedPNL       whereHelperfloat64
+	UnrealisedPNL     whereHelperfloat64
+	Fees              whereHelperfloat64
+	Funding           whereHelperfloat64
+}{
+	ID:                whereHelperstring{field: "\"nav_snapshot\".\"id\""},
+	Date:              whereHelpertime_Time{field: "\"nav_snapshot\".\"date\""},
+	ReportingCurrency: whereHelperstring{field: "\"nav_snapshot\".\"reporting_currency\""},
+	Nav:               whereHelperfloat64{field: "\"nav_snapshot\".\"nav\""},
+	RealisedPNL:       whereHelperfloat64{field: "\"nav_snapshot\".\"realised_pnl\""},
+	UnrealisedPNL:     whereHelperfloat64{field: "\"nav_snapshot\".\"unrealised_pnl\""},
+	Fees:              whereHelperfloat64{field: "\"nav_snapshot\".\"fees\""},
+	Funding:           whereHelperfloat64{field: "\"nav_snapshot\".\"funding\""},
+}
+
+// NavSnapshotRels is where relationship names are stored.
+var NavSnapshotRels = struct {
+}{}
+
+// navSnapshotR is where relationships are stored.
+type navSnapshotR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*navSnapshotR) NewStruct() *navSnapshotR {
+	return &navSnapshotR{}
+}
+
+// navSnapshotL is where Load methods for each relationship are stored.
+type navSnapshotL struct{}
+
+var (
+	navSnapshotAllColumns            = []string{"id", "date", "reporting_currency", "nav", "realised_pnl", "unrealised_pnl", "fees", "funding"}
+	navSnapshotColumnsWithoutDefault = []string{"date", "reporting_currency", "nav", "realised_pnl", "unrealised_pnl", "fees", "funding"}
+	navSnapshotColumnsWithDefault    = []string{"id"}
+	navSnapshotPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// NavSnapshotSlice is an alias for a slice of pointers to NavSnapshot.
+	// This should generally be used opposed to []NavSnapshot.
+	NavSnapshotSlice []*NavSnapshot
+	// NavSnapshotHook is the signature for custom NavSnapshot hook methods
+	NavSnapshotHook func(context.Context, boil.ContextExecutor, *NavSnapshot) error
+
+	navSnapshotQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	navSnapshotType                 = reflect.TypeOf(&NavSnapshot{})
+	navSnapshotMapping              = queries.MakeStructMapping(navSnapshotType)
+	navSnapshotPrimaryKeyMapping, _ = queries.BindMapping(navSnapshotType, navSnapshotMapping, navSnapshotPrimaryKeyColumns)
+	navSnapshotInsertCacheMut       sync.RWMutex
+	navSnapshotInsertCache          = make(map[string]insertCache)
+	navSnapshotUpdateCacheMut       sync.RWMutex
+	navSnapshotUpdateCache          = make(map[string]updateCache)
+	navSnapshotUpsertCacheMut       sync.RWMutex
+	navSnapshotUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var navSnapshotBeforeInsertHooks []NavSnapshotHook
+var navSnapshotBeforeUpdateHooks []NavSnapshotHook
+var navSnapshotBeforeDeleteHooks []NavSnapshotHook
+var navSnapshotBeforeUpsertHooks []NavSnapshotHook
+
+var navSnapshotAfterInsertHooks []NavSnapshotHook
+var navSnapshotAfterSelectHooks []NavSnapshotHook
+var navSnapshotAfterUpdateHooks []NavSnapshotHook
+var navSnapshotAfterDeleteHooks []NavSnapshotHook
+var navSnapshotAfterUpsertHooks []NavSnapshotHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *NavSnapshot) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *NavSnapshot) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *NavSnapshot) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *NavSnapshot) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *NavSnapshot) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *NavSnapshot) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *NavSnapshot) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *NavSnapshot) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *NavSnapshot) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range navSnapshotAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddNavSnapshotHook registers your hook function for all future operations.
+func AddNavSnapshotHook(hookPoint boil.HookPoint, navSnapshotHook NavSnapshotHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		navSnapshotBeforeInsertHooks = append(navSnapshotBeforeInsertHooks, navSnapshotHook)
+	case boil.BeforeUpdateHook:
+		navSnapshotBeforeUpdateHooks = append(navSnapshotBeforeUpdateHooks, navSnapshotHook)
+	case boil.BeforeDeleteHook:
+		navSnapshotBeforeDeleteHooks = append(navSnapshotBeforeDeleteHooks, navSnapshotHook)
+	case boil.BeforeUpsertHook:
+		navSnapshotBeforeUpsertHooks = append(navSnapshotBeforeUpsertHooks, navSnapshotHook)
+	case boil.AfterInsertHook:
+		navSnapshotAfterInsertHooks = append(navSnapshotAfterInsertHooks, navSnapshotHook)
+	case boil.AfterSelectHook:
+		navSnapshotAfterSelectHooks = append(navSnapshotAfterSelectHooks, navSnapshotHook)
+	case boil.AfterUpdateHook:
+		navSnapshotAfterUpdateHooks = append(navSnapshotAfterUpdateHooks, navSnapshotHook)
+	case boil.AfterDeleteHook:
+		navSnapshotAfterDeleteHooks = append(navSnapshotAfterDeleteHooks, navSnapshotHook)
+	case boil.AfterUpsertHook:
+		navSnapshotAfterUpsertHooks = append(navSnapshotAfterUpsertHooks, navSnapshotHook)
+	}
+}
+
+// One returns a single navSnapshot record from the query.
+func (q navSnapshotQuery) One(ctx context.Context, exec boil.ContextExecutor) (*NavSnapshot, error) {
+	o := &NavSnapshot{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: failed to execute a one query for nav_snapshot")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all NavSnapshot records from the query.
+func (q navSnapshotQuery) All(ctx context.Context, exec boil.ContextExecutor) (NavSnapshotSlice, error) {
+	var o []*NavSnapshot
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "postgres: failed to assign all query results to NavSnapshot slice")
+	}
+
+	if len(navSnapshotAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all NavSnapshot records in the query.
+func (q navSnapshotQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to count nav_snapshot rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q navSnapshotQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: failed to check if nav_snapshot exists")
+	}
+
+	return count > 0, nil
+}
+
+// NavSnapshots retrieves all the records using an executor.
+func NavSnapshots(mods ...qm.QueryMod) navSnapshotQuery {
+	mods = append(mods, qm.From("\"nav_snapshot\""))
+	return navSnapshotQuery{NewQuery(mods...)}
+}
+
+// FindNavSnapshot retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindNavSnapshot(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*NavSnapshot, error) {
+	navSnapshotObj := &NavSnapshot{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"nav_snapshot\" where \"id\"=$1", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, navSnapshotObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "postgres: unable to select from nav_snapshot")
+	}
+
+	return navSnapshotObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *NavSnapshot) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no nav_snapshot provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(navSnapshotColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	navSnapshotInsertCacheMut.RLock()
+	cache, cached := navSnapshotInsertCache[key]
+	navSnapshotInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			navSnapshotAllColumns,
+			navSnapshotColumnsWithDefault,
+			navSnapshotColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(navSnapshotType, navSnapshotMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(navSnapshotType, navSnapshotMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"nav_snapshot\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"nav_snapshot\" %sDEFAULT VALUES%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			queryReturning = fmt.Sprintf(" RETURNING \"%s\"", strings.Join(returnColumns, "\",\""))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to insert into nav_snapshot")
+	}
+
+	if !cached {
+		navSnapshotInsertCacheMut.Lock()
+		navSnapshotInsertCache[key] = cache
+		navSnapshotInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the NavSnapshot.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *NavSnapshot) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	navSnapshotUpdateCacheMut.RLock()
+	cache, cached := navSnapshotUpdateCache[key]
+	navSnapshotUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			navSnapshotAllColumns,
+			navSnapshotPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("postgres: unable to update nav_snapshot, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"nav_snapshot\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 1, wl),
+			strmangle.WhereClause("\"", "\"", len(wl)+1, navSnapshotPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(navSnapshotType, navSnapshotMapping, append(wl, navSnapshotPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update nav_snapshot row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by update for nav_snapshot")
+	}
+
+	if !cached {
+		navSnapshotUpdateCacheMut.Lock()
+		navSnapshotUpdateCache[key] = cache
+		navSnapshotUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q navSnapshotQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all for nav_snapshot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected for nav_snapshot")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o NavSnapshotSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("postgres: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), navSnapshotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"nav_snapshot\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 1, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), len(colNames)+1, navSnapshotPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to update all in navSnapshot slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to retrieve rows affected all in update all navSnapshot")
+	}
+	return rowsAff, nil
+}
+
+// Upsert attempts an insert using an executor, and does an update or ignore on conflict.
+// See boil.Columns documentation for how to properly use updateColumns and insertColumns.
+func (o *NavSnapshot) Upsert(ctx context.Context, exec boil.ContextExecutor, updateOnConflict bool, conflictColumns []string, updateColumns, insertColumns boil.Columns) error {
+	if o == nil {
+		return errors.New("postgres: no nav_snapshot provided for upsert")
+	}
+
+	if err := o.doBeforeUpsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(navSnapshotColumnsWithDefault, o)
+
+	// Build cache key in-line uglily - mysql vs psql problems
+	buf := strmangle.GetBuffer()
+	if updateOnConflict {
+		buf.WriteByte('t')
+	} else {
+		buf.WriteByte('f')
+	}
+	buf.WriteByte('.')
+	for _, c := range conflictColumns {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(updateColumns.Kind))
+	for _, c := range updateColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	buf.WriteString(strconv.Itoa(insertColumns.Kind))
+	for _, c := range insertColumns.Cols {
+		buf.WriteString(c)
+	}
+	buf.WriteByte('.')
+	for _, c := range nzDefaults {
+		buf.WriteString(c)
+	}
+	key := buf.String()
+	strmangle.PutBuffer(buf)
+
+	navSnapshotUpsertCacheMut.RLock()
+	cache, cached := navSnapshotUpsertCache[key]
+	navSnapshotUpsertCacheMut.RUnlock()
+
+	var err error
+
+	if !cached {
+		insert, ret := insertColumns.InsertColumnSet(
+			navSnapshotAllColumns,
+			navSnapshotColumnsWithDefault,
+			navSnapshotColumnsWithoutDefault,
+			nzDefaults,
+		)
+		update := updateColumns.UpdateColumnSet(
+			navSnapshotAllColumns,
+			navSnapshotPrimaryKeyColumns,
+		)
+
+		if updateOnConflict && len(update) == 0 {
+			return errors.New("postgres: unable to upsert nav_snapshot, could not build update column list")
+		}
+
+		conflict := conflictColumns
+		if len(conflict) == 0 {
+			conflict = make([]string, len(navSnapshotPrimaryKeyColumns))
+			copy(conflict, navSnapshotPrimaryKeyColumns)
+		}
+		cache.query = buildUpsertQueryPostgres(dialect, "\"nav_snapshot\"", updateOnConflict, ret, update, conflict, insert)
+
+		cache.valueMapping, err = queries.BindMapping(navSnapshotType, navSnapshotMapping, insert)
+		if err != nil {
+			return err
+		}
+		if len(ret) != 0 {
+			cache.retMapping, err = queries.BindMapping(navSnapshotType, navSnapshotMapping, ret)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+	var returns []interface{}
+	if len(cache.retMapping) != 0 {
+		returns = queries.PtrsFromMapping(value, cache.retMapping)
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	if len(cache.retMapping) != 0 {
+		err = exec.QueryRowContext(ctx, cache.query, vals...).Scan(returns...)
+		if err == sql.ErrNoRows {
+			err = nil // Postgres doesn't return anything when there's no update
+		}
+	} else {
+		_, err = exec.ExecContext(ctx, cache.query, vals...)
+	}
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to upsert nav_snapshot")
+	}
+
+	if !cached {
+		navSnapshotUpsertCacheMut.Lock()
+		navSnapshotUpsertCache[key] = cache
+		navSnapshotUpsertCacheMut.Unlock()
+	}
+
+	return o.doAfterUpsertHooks(ctx, exec)
+}
+
+// Delete deletes a single NavSnapshot record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *NavSnapshot) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("postgres: no NavSnapshot provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), navSnapshotPrimaryKeyMapping)
+	sql := "DELETE FROM \"nav_snapshot\" WHERE \"id\"=$1"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete from nav_snapshot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by delete for nav_snapshot")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q navSnapshotQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("postgres: no navSnapshotQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from nav_snapshot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for nav_snapshot")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o NavSnapshotSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(navSnapshotBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), navSnapshotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"nav_snapshot\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, navSnapshotPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: unable to delete all from navSnapshot slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "postgres: failed to get rows affected by deleteall for nav_snapshot")
+	}
+
+	if len(navSnapshotAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *NavSnapshot) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindNavSnapshot(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *NavSnapshotSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := NavSnapshotSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), navSnapshotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"nav_snapshot\".* FROM \"nav_snapshot\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 1, navSnapshotPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "postgres: unable to reload all in NavSnapshotSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// NavSnapshotExists checks if the NavSnapshot row exists.
+func NavSnapshotExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"nav_snapshot\" where \"id\"=$1 limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "postgres: unable to check if nav_snapshot exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,732 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package postgres
+
+import (
+	"bytes"
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/randomize"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+var (
+	// Relationships sometimes use the reflection helper queries.Equal/queries.Assign
+	// so force a package dependency in case they don't.
+	_ = queries.Equal
+)
+
+func testNavSnapshots(t *testing.T) {
+	t.Parallel()
+
+	query := NavSnapshots()
+
+	if query.Query == nil {
+		t.Error("expected a query, got nothing")
+	}
+}
+
+func testNavSnapshotsDelete(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := o.Delete(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testNavSnapshotsQueryDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if rowsAff, err := NavSnapshots().DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testNavSnapshotsSliceDeleteAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := NavSnapshotSlice{o}
+
+	if rowsAff, err := slice.DeleteAll(ctx, tx); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only have deleted one row, but affected:", rowsAff)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 0 {
+		t.Error("want zero records, got:", count)
+	}
+}
+
+func testNavSnapshotsExists(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	e, err := NavSnapshotExists(ctx, tx, o.ID)
+	if err != nil {
+		t.Errorf("Unable to check if NavSnapshot exists: %s", err)
+	}
+	if !e {
+		t.Errorf("Expected NavSnapshotExists to return true, but got false.")
+	}
+}
+
+func testNavSnapshotsFind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	navSnapshotFound, err := FindNavSnapshot(ctx, tx, o.ID)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if navSnapshotFound == nil {
+		t.Error("want a record, got nil")
+	}
+}
+
+func testNavSnapshotsBind(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = NavSnapshots().Bind(ctx, tx, o); err != nil {
+		t.Error(err)
+	}
+}
+
+func testNavSnapshotsOne(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if x, err := NavSnapshots().One(ctx, tx); err != nil {
+		t.Error(err)
+	} else if x == nil {
+		t.Error("expected to get a non nil record")
+	}
+}
+
+func testNavSnapshotsAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	navSnapshotOne := &NavSnapshot{}
+	navSnapshotTwo := &NavSnapshot{}
+	if err = randomize.Struct(seed, navSnapshotOne, navSnapshotDBTypes, false, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+	if err = randomize.Struct(seed, navSnapshotTwo, navSnapshotDBTypes, false, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = navSnapshotOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = navSnapshotTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := NavSnapshots().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 2 {
+		t.Error("want 2 records, got:", len(slice))
+	}
+}
+
+func testNavSnapshotsCount(t *testing.T) {
+	t.Parallel()
+
+	var err error
+	seed := randomize.NewSeed()
+	navSnapshotOne := &NavSnapshot{}
+	navSnapshotTwo := &NavSnapshot{}
+	if err = randomize.Struct(seed, navSnapshotOne, navSnapshotDBTypes, false, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+	if err = randomize.Struct(seed, navSnapshotTwo, navSnapshotDBTypes, false, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = navSnapshotOne.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+	if err = navSnapshotTwo.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 2 {
+		t.Error("want 2 records, got:", count)
+	}
+}
+
+func navSnapshotBeforeInsertHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotAfterInsertHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotAfterSelectHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotBeforeUpdateHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotAfterUpdateHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotBeforeDeleteHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotAfterDeleteHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotBeforeUpsertHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func navSnapshotAfterUpsertHook(ctx context.Context, e boil.ContextExecutor, o *NavSnapshot) error {
+	*o = NavSnapshot{}
+	return nil
+}
+
+func testNavSnapshotsHooks(t *testing.T) {
+	t.Parallel()
+
+	var err error
+
+	ctx := context.Background()
+	empty := &NavSnapshot{}
+	o := &NavSnapshot{}
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, false); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot object: %s", err)
+	}
+
+	AddNavSnapshotHook(boil.BeforeInsertHook, navSnapshotBeforeInsertHook)
+	if err = o.doBeforeInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeInsertHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotBeforeInsertHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.AfterInsertHook, navSnapshotAfterInsertHook)
+	if err = o.doAfterInsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterInsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterInsertHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotAfterInsertHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.AfterSelectHook, navSnapshotAfterSelectHook)
+	if err = o.doAfterSelectHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterSelectHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterSelectHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotAfterSelectHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.BeforeUpdateHook, navSnapshotBeforeUpdateHook)
+	if err = o.doBeforeUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpdateHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotBeforeUpdateHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.AfterUpdateHook, navSnapshotAfterUpdateHook)
+	if err = o.doAfterUpdateHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpdateHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpdateHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotAfterUpdateHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.BeforeDeleteHook, navSnapshotBeforeDeleteHook)
+	if err = o.doBeforeDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeDeleteHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotBeforeDeleteHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.AfterDeleteHook, navSnapshotAfterDeleteHook)
+	if err = o.doAfterDeleteHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterDeleteHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterDeleteHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotAfterDeleteHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.BeforeUpsertHook, navSnapshotBeforeUpsertHook)
+	if err = o.doBeforeUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doBeforeUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected BeforeUpsertHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotBeforeUpsertHooks = []NavSnapshotHook{}
+
+	AddNavSnapshotHook(boil.AfterUpsertHook, navSnapshotAfterUpsertHook)
+	if err = o.doAfterUpsertHooks(ctx, nil); err != nil {
+		t.Errorf("Unable to execute doAfterUpsertHooks: %s", err)
+	}
+	if !reflect.DeepEqual(o, empty) {
+		t.Errorf("Expected AfterUpsertHook function to empty object, but got: %#v", o)
+	}
+	navSnapshotAfterUpsertHooks = []NavSnapshotHook{}
+}
+
+func testNavSnapshotsInsert(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testNavSnapshotsInsertWhitelist(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Whitelist(navSnapshotColumnsWithoutDefault...)); err != nil {
+		t.Error(err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
+
+func testNavSnapshotsReload(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	if err = o.Reload(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testNavSnapshotsReloadAll(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice := NavSnapshotSlice{o}
+
+	if err = slice.ReloadAll(ctx, tx); err != nil {
+		t.Error(err)
+	}
+}
+
+func testNavSnapshotsSelect(t *testing.T) {
+	t.Parallel()
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	slice, err := NavSnapshots().All(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if len(slice) != 1 {
+		t.Error("want one record, got:", len(slice))
+	}
+}
+
+var (
+	navSnapshotDBTypes = map[string]string{`ID`: `uuid`, `Date`: `timestamp with time zone`, `ReportingCurrency`: `character varying`, `Nav`: `double precision`, `RealisedPNL`: `double precision`, `UnrealisedPNL`: `double precision`, `Fees`: `double precision`, `Funding`: `double precision`}
+	_                  = bytes.MinRead
+)
+
+func testNavSnapshotsUpdate(t *testing.T) {
+	t.Parallel()
+
+	if 0 == len(navSnapshotPrimaryKeyColumns) {
+		t.Skip("Skipping table with no primary key columns")
+	}
+	if len(navSnapshotAllColumns) == len(navSnapshotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	if rowsAff, err := o.Update(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("should only affect one row but affected", rowsAff)
+	}
+}
+
+func testNavSnapshotsSliceUpdateAll(t *testing.T) {
+	t.Parallel()
+
+	if len(navSnapshotAllColumns) == len(navSnapshotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	o := &NavSnapshot{}
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Error(err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	if err = randomize.Struct(seed, o, navSnapshotDBTypes, true, navSnapshotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	// Remove Primary keys and unique columns from what we plan to update
+	var fields []string
+	if strmangle.StringSliceMatch(navSnapshotAllColumns, navSnapshotPrimaryKeyColumns) {
+		fields = navSnapshotAllColumns
+	} else {
+		fields = strmangle.SetComplement(
+			navSnapshotAllColumns,
+			navSnapshotPrimaryKeyColumns,
+		)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	typ := reflect.TypeOf(o).Elem()
+	n := typ.NumField()
+
+	updateMap := M{}
+	for _, col := range fields {
+		for i := 0; i < n; i++ {
+			f := typ.Field(i)
+			if f.Tag.Get("boil") == col {
+				updateMap[col] = value.Field(i).Interface()
+			}
+		}
+	}
+
+	slice := NavSnapshotSlice{o}
+	if rowsAff, err := slice.UpdateAll(ctx, tx, updateMap); err != nil {
+		t.Error(err)
+	} else if rowsAff != 1 {
+		t.Error("wanted one record updated but got", rowsAff)
+	}
+}
+
+func testNavSnapshotsUpsert(t *testing.T) {
+	t.Parallel()
+
+	if len(navSnapshotAllColumns) == len(navSnapshotPrimaryKeyColumns) {
+		t.Skip("Skipping table with only primary key columns")
+	}
+
+	seed := randomize.NewSeed()
+	var err error
+	// Attempt the INSERT side of an UPSERT
+	o := NavSnapshot{}
+	if err = randomize.Struct(seed, &o, navSnapshotDBTypes, true); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+	if err = o.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert NavSnapshot: %s", err)
+	}
+
+	count, err := NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+
+	// Attempt the UPDATE side of an UPSERT
+	if err = randomize.Struct(seed, &o, navSnapshotDBTypes, false, navSnapshotPrimaryKeyColumns...); err != nil {
+		t.Errorf("Unable to randomize NavSnapshot struct: %s", err)
+	}
+
+	if err = o.Upsert(ctx, tx, true, nil, boil.Infer(), boil.Infer()); err != nil {
+		t.Errorf("Unable to upsert NavSnapshot: %s", err)
+	}
+
+	count, err = NavSnapshots().Count(ctx, tx)
+	if err != nil {
+		t.Error(err)
+	}
+	if count != 1 {
+		t.Error("want one record, got:", count)
+	}
+}
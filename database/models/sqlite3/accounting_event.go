@@ -0,0 +1,851 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// AccountingEvent is an object representing the database table.
+type AccountingEvent struct {
+	ID                string `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ReportingCurrency string `boil:"reporting_currency" json:"reporting_currency" toml:"reporting_currency" yaml:"reporting_currency"`
+	Occurred          string `boil:"occurred" json:"occurred" toml:"occurred" yaml:"occurred"`
+	Kind              string `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	ExchangeName      string `boil:"exchange_name" json:"exchange_name" toml:"exchange_name" yaml:"exchange_name"`
+	Asset             string `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
+	Base              string `boil:"base" json:"base" toml:"base" yaml:"base"`
+	Quote             string `boil:"quote" json:"quote" toml:"quote" yaml:"quote"`
+	OrderID           string `boil:"order_id" json:"order_id" toml:"order_id" yaml:"order_id"`
+	Side              string `boil:"side" json:"side" toml:"side" yaml:"side"`
+	Amount            string `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Value             string `boil:"value" json:"value" toml:"value" yaml:"value"`
+	RealisedPNL       string `boil:"realised_pnl" json:"realised_pnl" toml:"realised_pnl" yaml:"realised_pnl"`
+	Fees              string `boil:"fees" json:"fees" toml:"fees" yaml:"fees"`
+	Funding           string `boil:"funding" json:"funding" toml:"funding" yaml:"funding"`
+	Unmatched         string `boil:"unmatched" json:"unmatched" toml:"unmatched" yaml:"unmatched"`
+
+	R *accountingEventR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L accountingEventL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AccountingEventColumns = struct {
+	ID                string
+	ReportingCurrency string
+	Occurred          string
+	Kind              string
+	ExchangeName      string
+	Asset             string
+	Base              string
+	Quote             string
+	OrderID           string
+	Side              string
+	Amount            string
+	Value             string
+	RealisedPNL       string
+	Fees              string
+	Funding           string
+	Unmatched         string
+}{
+	ID:                "id",
+	ReportingCurrency: "reporting_currency",
+	Occurred:          "occurred",
+	Kind:              "kind",
+	ExchangeName:      "exchange_name",
+	Asset:             "asset",
+	Base:              "base",
+	Quote:             "quote",
+	OrderID:           "order_id",
+	Side:              "side",
+	Amount:            "amount",
+	Value:             "value",
+	RealisedPNL:       "realised_pnl",
+	Fees:              "fees",
+	Funding:           "funding",
+	Unmatched:         "unmatched",
+}
+
+// Generated where
+
+type whereHelperstring struct{ field string }
+
+func (w whereHelperstring) EQ(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperstring) NEQ(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperstring) LT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperstring) LTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperstring) GT(x string) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperstring) GTE(x string) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+func (w whereHelperstring) IN(slice []string) qm.QueryMod {
+	values := make([]interface{}, 0, len(slice))
+	for _, value := range slice {
+		values = append(values, value)
+	}
+	return qm.WhereIn(fmt.Sprintf("%s IN ?", w.field), values...)
+}
+
+var AccountingEventWhere = struct {
+	ID                whereHelperstring
+	ReportingCurrency whereHelperstring
+	Occurred          whereHelperstring
+	Kind              whereHelperstring
+	ExchangeName      whereHelperstring
+	Asset             whereHelperstring
+	Base              whereHelperstring
+	Quote             whereHelperstring
+	OrderID           whereHelperstring
+	Side              whereHelperstring
+	Amount            whereHelperstring
+	Value             whereHelperstring
+	RealisedPNL       whereHelperstring
+	Fees              whereHelperstring
+	Funding           whereHelperstring
+	Unmatched         whereHelperstring
+}{
+	ID:                whereHelperstring{field: "\"accounting_event\".\"id\""},
+	ReportingCurrency: whereHelperstring{field: "\"accounting_event\".\"reporting_currency\""},
+	Occurred:          whereHelperstring{field: "\"accounting_event\".\"occurred\""},
+	Kind:              whereHelperstring{field: "\"accounting_event\".\"kind\""},
+	ExchangeName:      whereHelperstring{field: "\"accounting_event\".\"exchange_name\""},
+	Asset:             whereHelperstring{field: "\"accounting_event\".\"asset\""},
+	Base:              whereHelperstring{field: "\"accounting_event\".\"base\""},
+	Quote:             whereHelperstring{field: "\"accounting_event\".\"quote\""},
+	OrderID:           whereHelperstring{field: "\"accounting_event\".\"order_id\""},
+	Side:              whereHelperstring{field: "\"accounting_event\".\"side\""},
+	Amount:            whereHelperstring{field: "\"accounting_event\".\"amount\""},
+	Value:             whereHelperstring{field: "\"accounting_event\".\"value\""},
+	RealisedPNL:       whereHelperstring{field: "\"accounting_event\".\"realised_pnl\""},
+	Fees:              whereHelperstring{field: "\"accounting_event\".\"fees\""},
+	Funding:           whereHelperstring{field: "\"accounting_event\".\"funding\""},
+	Unmatched:         whereHelperstring{field: "\"accounting_event\".\"unmatched\""},
+}
+
+// AccountingEventRels is where relationship names are stored.
+var AccountingEventRels = struct {
+}{}
+
+// accountingEventR is where relationships are stored.
+type accountingEventR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*accountingEventR) NewStruct() *accountingEventR {
+	return &accountingEventR{}
+}
+
+// accountingEventL is where Load methods for each relationship are stored.
+type accountingEventL struct{}
+
+var (
+	accountingEventAllColumns            = []string{"id", "reporting_currency", "occurred", "kind", "exchange_name", "asset", "base", "quote", "order_id", "side", "amount", "value", "realised_pnl", "fees", "funding", "unmatched"}
+	accountingEventColumnsWithoutDefault = []string{"id", "reporting_currency", "occurred", "kind", "exchange_name", "asset", "base", "quote", "order_id", "side", "amount", "value", "realised_pnl", "fees", "funding", "unmatched"}
+	accountingEventColumnsWithDefault    = []string{}
+	accountingEventPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AccountingEventSlice is an alias for a slice of pointers to AccountingEvent.
+	// This should generally be used opposed to []AccountingEvent.
+	AccountingEventSlice []*AccountingEvent
+	// AccountingEventHook is the signature for custom AccountingEvent hook methods
+	AccountingEventHook func(context.Context, boil.ContextExecutor, *AccountingEvent) error
+
+	accountingEventQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	accountingEventType                 = reflect.TypeOf(&AccountingEvent{})
+	accountingEventMapping              = queries.MakeStructMapping(accountingEventType)
+	accountingEventPrimaryKeyMapping, _ = queries.BindMapping(accountingEventType, accountingEventMapping, accountingEventPrimaryKeyColumns)
+	accountingEventInsertCacheMut       sync.RWMutex
+	accountingEventInsertCache          = make(map[string]insertCache)
+	accountingEventUpdateCacheMut       sync.RWMutex
+	accountingEventUpdateCache          = make(map[string]updateCache)
+	accountingEventUpsertCacheMut       sync.RWMutex
+	accountingEventUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var accountingEventBeforeInsertHooks []AccountingEventHook
+var accountingEventBeforeUpdateHooks []AccountingEventHook
+var accountingEventBeforeDeleteHooks []AccountingEventHook
+var accountingEventBeforeUpsertHooks []AccountingEventHook
+
+var accountingEventAfterInsertHooks []AccountingEventHook
+var accountingEventAfterSelectHooks []AccountingEventHook
+var accountingEventAfterUpdateHooks []AccountingEventHook
+var accountingEventAfterDeleteHooks []AccountingEventHook
+var accountingEventAfterUpsertHooks []AccountingEventHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AccountingEvent) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AccountingEvent) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AccountingEvent) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AccountingEvent) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AccountingEvent) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AccountingEvent) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AccountingEvent) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AccountingEvent) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AccountingEvent) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingEventAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAccountingEventHook registers your hook function for all future operations.
+func AddAccountingEventHook(hookPoint boil.HookPoint, accountingEventHook AccountingEventHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		accountingEventBeforeInsertHooks = append(accountingEventBeforeInsertHooks, accountingEventHook)
+	case boil.BeforeUpdateHook:
+		accountingEventBeforeUpdateHooks = append(accountingEventBeforeUpdateHooks, accountingEventHook)
+	case boil.BeforeDeleteHook:
+		accountingEventBeforeDeleteHooks = append(accountingEventBeforeDeleteHooks, accountingEventHook)
+	case boil.BeforeUpsertHook:
+		accountingEventBeforeUpsertHooks = append(accountingEventBeforeUpsertHooks, accountingEventHook)
+	case boil.AfterInsertHook:
+		accountingEventAfterInsertHooks = append(accountingEventAfterInsertHooks, accountingEventHook)
+	case boil.AfterSelectHook:
+		accountingEventAfterSelectHooks = append(accountingEventAfterSelectHooks, accountingEventHook)
+	case boil.AfterUpdateHook:
+		accountingEventAfterUpdateHooks = append(accountingEventAfterUpdateHooks, accountingEventHook)
+	case boil.AfterDeleteHook:
+		accountingEventAfterDeleteHooks = append(accountingEventAfterDeleteHooks, accountingEventHook)
+	case boil.AfterUpsertHook:
+		accountingEventAfterUpsertHooks = append(accountingEventAfterUpsertHooks, accountingEventHook)
+	}
+}
+
+// One returns a single accountingEvent record from the query.
+func (q accountingEventQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AccountingEvent, error) {
+	o := &AccountingEvent{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for accounting_event")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AccountingEvent records from the query.
+func (q accountingEventQuery) All(ctx context.Context, exec boil.ContextExecutor) (AccountingEventSlice, error) {
+	var o []*AccountingEvent
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to AccountingEvent slice")
+	}
+
+	if len(accountingEventAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AccountingEvent records in the query.
+func (q accountingEventQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count accounting_event rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q accountingEventQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if accounting_event exists")
+	}
+
+	return count > 0, nil
+}
+
+// AccountingEvents retrieves all the records using an executor.
+func AccountingEvents(mods ...qm.QueryMod) accountingEventQuery {
+	mods = append(mods, qm.From("\"accounting_event\""))
+	return accountingEventQuery{NewQuery(mods...)}
+}
+
+// FindAccountingEvent retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAccountingEvent(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*AccountingEvent, error) {
+	accountingEventObj := &AccountingEvent{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounting_event\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, accountingEventObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from accounting_event")
+	}
+
+	return accountingEventObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AccountingEvent) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no accounting_event provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accountingEventColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	accountingEventInsertCacheMut.RLock()
+	cache, cached := accountingEventInsertCache[key]
+	accountingEventInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			accountingEventAllColumns,
+			accountingEventColumnsWithDefault,
+			accountingEventColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(accountingEventType, accountingEventMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(accountingEventType, accountingEventMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounting_event\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounting_event\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"accounting_event\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, accountingEventPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into accounting_event")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for accounting_event")
+	}
+
+CacheNoHooks:
+	if !cached {
+		accountingEventInsertCacheMut.Lock()
+		accountingEventInsertCache[key] = cache
+		accountingEventInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AccountingEvent.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AccountingEvent) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	accountingEventUpdateCacheMut.RLock()
+	cache, cached := accountingEventUpdateCache[key]
+	accountingEventUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			accountingEventAllColumns,
+			accountingEventPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update accounting_event, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounting_event\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, accountingEventPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(accountingEventType, accountingEventMapping, append(wl, accountingEventPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update accounting_event row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for accounting_event")
+	}
+
+	if !cached {
+		accountingEventUpdateCacheMut.Lock()
+		accountingEventUpdateCache[key] = cache
+		accountingEventUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q accountingEventQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for accounting_event")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for accounting_event")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AccountingEventSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounting_event\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingEventPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in accountingEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all accountingEvent")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single AccountingEvent record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AccountingEvent) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no AccountingEvent provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), accountingEventPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounting_event\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from accounting_event")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for accounting_event")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q accountingEventQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no accountingEventQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accounting_event")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_event")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AccountingEventSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(accountingEventBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounting_event\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingEventPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accountingEvent slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_event")
+	}
+
+	if len(accountingEventAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AccountingEvent) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAccountingEvent(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AccountingEventSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AccountingEventSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingEventPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounting_event\".* FROM \"accounting_event\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingEventPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in AccountingEventSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AccountingEventExists checks if the AccountingEvent row exists.
+func AccountingEventExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounting_event\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if accounting_event exists")
+	}
+
+	return exists, nil
+}
@@ -0,0 +1,809 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// AccountingLot is an object representing the database table.
+type AccountingLot struct {
+	ID                string `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ReportingCurrency string `boil:"reporting_currency" json:"reporting_currency" toml:"reporting_currency" yaml:"reporting_currency"`
+	ExchangeName      string `boil:"exchange_name" json:"exchange_name" toml:"exchange_name" yaml:"exchange_name"`
+	Currency          string `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	Sequence          int64  `boil:"sequence" json:"sequence" toml:"sequence" yaml:"sequence"`
+	Reference         string `boil:"reference" json:"reference" toml:"reference" yaml:"reference"`
+	Acquired          string `boil:"acquired" json:"acquired" toml:"acquired" yaml:"acquired"`
+	Amount            string `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Cost              string `boil:"cost" json:"cost" toml:"cost" yaml:"cost"`
+
+	R *accountingLotR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L accountingLotL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AccountingLotColumns = struct {
+	ID                string
+	ReportingCurrency string
+	ExchangeName      string
+	Currency          string
+	Sequence          string
+	Reference         string
+	Acquired          string
+	Amount            string
+	Cost              string
+}{
+	ID:                "id",
+	ReportingCurrency: "reporting_currency",
+	ExchangeName:      "exchange_name",
+	Currency:          "currency",
+	Sequence:          "sequence",
+	Reference:         "reference",
+	Acquired:          "acquired",
+	Amount:            "amount",
+	Cost:              "cost",
+}
+
+// Generated where
+
+type whereHelperint64 struct{ field string }
+
+func (w whereHelperint64) EQ(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.EQ, x) }
+func (w whereHelperint64) NEQ(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.NEQ, x) }
+func (w whereHelperint64) LT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.LT, x) }
+func (w whereHelperint64) LTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.LTE, x) }
+func (w whereHelperint64) GT(x int64) qm.QueryMod  { return qmhelper.Where(w.field, qmhelper.GT, x) }
+func (w whereHelperint64) GTE(x int64) qm.QueryMod { return qmhelper.Where(w.field, qmhelper.GTE, x) }
+
+var AccountingLotWhere = struct {
+	ID                whereHelperstring
+	ReportingCurrency whereHelperstring
+	ExchangeName      whereHelperstring
+	Currency          whereHelperstring
+	Sequence          whereHelperint64
+	Reference         whereHelperstring
+	Acquired          whereHelperstring
+	Amount            whereHelperstring
+	Cost              whereHelperstring
+}{
+	ID:                whereHelperstring{field: "\"accounting_lot\".\"id\""},
+	ReportingCurrency: whereHelperstring{field: "\"accounting_lot\".\"reporting_currency\""},
+	ExchangeName:      whereHelperstring{field: "\"accounting_lot\".\"exchange_name\""},
+	Currency:          whereHelperstring{field: "\"accounting_lot\".\"currency\""},
+	Sequence:          whereHelperint64{field: "\"accounting_lot\".\"sequence\""},
+	Reference:         whereHelperstring{field: "\"accounting_lot\".\"reference\""},
+	Acquired:          whereHelperstring{field: "\"accounting_lot\".\"acquired\""},
+	Amount:            whereHelperstring{field: "\"accounting_lot\".\"amount\""},
+	Cost:              whereHelperstring{field: "\"accounting_lot\".\"cost\""},
+}
+
+// AccountingLotRels is where relationship names are stored.
+var AccountingLotRels = struct {
+}{}
+
+// accountingLotR is where relationships are stored.
+type accountingLotR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*accountingLotR) NewStruct() *accountingLotR {
+	return &accountingLotR{}
+}
+
+// accountingLotL is where Load methods for each relationship are stored.
+type accountingLotL struct{}
+
+var (
+	accountingLotAllColumns            = []string{"id", "reporting_currency", "exchange_name", "currency", "sequence", "reference", "acquired", "amount", "cost"}
+	accountingLotColumnsWithoutDefault = []string{"id", "reporting_currency", "exchange_name", "currency", "sequence", "reference", "acquired", "amount", "cost"}
+	accountingLotColumnsWithDefault    = []string{}
+	accountingLotPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AccountingLotSlice is an alias for a slice of pointers to AccountingLot.
+	// This should generally be used opposed to []AccountingLot.
+	AccountingLotSlice []*AccountingLot
+	// AccountingLotHook is the signature for custom AccountingLot hook methods
+	AccountingLotHook func(context.Context, boil.ContextExecutor, *AccountingLot) error
+
+	accountingLotQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	accountingLotType                 = reflect.TypeOf(&AccountingLot{})
+	accountingLotMapping              = queries.MakeStructMapping(accountingLotType)
+	accountingLotPrimaryKeyMapping, _ = queries.BindMapping(accountingLotType, accountingLotMapping, accountingLotPrimaryKeyColumns)
+	accountingLotInsertCacheMut       sync.RWMutex
+	accountingLotInsertCache          = make(map[string]insertCache)
+	accountingLotUpdateCacheMut       sync.RWMutex
+	accountingLotUpdateCache          = make(map[string]updateCache)
+	accountingLotUpsertCacheMut       sync.RWMutex
+	accountingLotUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var accountingLotBeforeInsertHooks []AccountingLotHook
+var accountingLotBeforeUpdateHooks []AccountingLotHook
+var accountingLotBeforeDeleteHooks []AccountingLotHook
+var accountingLotBeforeUpsertHooks []AccountingLotHook
+
+var accountingLotAfterInsertHooks []AccountingLotHook
+var accountingLotAfterSelectHooks []AccountingLotHook
+var accountingLotAfterUpdateHooks []AccountingLotHook
+var accountingLotAfterDeleteHooks []AccountingLotHook
+var accountingLotAfterUpsertHooks []AccountingLotHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AccountingLot) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AccountingLot) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AccountingLot) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AccountingLot) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AccountingLot) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AccountingLot) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AccountingLot) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AccountingLot) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AccountingLot) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingLotAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAccountingLotHook registers your hook function for all future operations.
+func AddAccountingLotHook(hookPoint boil.HookPoint, accountingLotHook AccountingLotHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		accountingLotBeforeInsertHooks = append(accountingLotBeforeInsertHooks, accountingLotHook)
+	case boil.BeforeUpdateHook:
+		accountingLotBeforeUpdateHooks = append(accountingLotBeforeUpdateHooks, accountingLotHook)
+	case boil.BeforeDeleteHook:
+		accountingLotBeforeDeleteHooks = append(accountingLotBeforeDeleteHooks, accountingLotHook)
+	case boil.BeforeUpsertHook:
+		accountingLotBeforeUpsertHooks = append(accountingLotBeforeUpsertHooks, accountingLotHook)
+	case boil.AfterInsertHook:
+		accountingLotAfterInsertHooks = append(accountingLotAfterInsertHooks, accountingLotHook)
+	case boil.AfterSelectHook:
+		accountingLotAfterSelectHooks = append(accountingLotAfterSelectHooks, accountingLotHook)
+	case boil.AfterUpdateHook:
+		accountingLotAfterUpdateHooks = append(accountingLotAfterUpdateHooks, accountingLotHook)
+	case boil.AfterDeleteHook:
+		accountingLotAfterDeleteHooks = append(accountingLotAfterDeleteHooks, accountingLotHook)
+	case boil.AfterUpsertHook:
+		accountingLotAfterUpsertHooks = append(accountingLotAfterUpsertHooks, accountingLotHook)
+	}
+}
+
+// One returns a single accountingLot record from the query.
+func (q accountingLotQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AccountingLot, error) {
+	o := &AccountingLot{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for accounting_lot")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AccountingLot records from the query.
+func (q accountingLotQuery) All(ctx context.Context, exec boil.ContextExecutor) (AccountingLotSlice, error) {
+	var o []*AccountingLot
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to AccountingLot slice")
+	}
+
+	if len(accountingLotAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AccountingLot records in the query.
+func (q accountingLotQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count accounting_lot rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q accountingLotQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if accounting_lot exists")
+	}
+
+	return count > 0, nil
+}
+
+// AccountingLots retrieves all the records using an executor.
+func AccountingLots(mods ...qm.QueryMod) accountingLotQuery {
+	mods = append(mods, qm.From("\"accounting_lot\""))
+	return accountingLotQuery{NewQuery(mods...)}
+}
+
+// FindAccountingLot retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAccountingLot(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*AccountingLot, error) {
+	accountingLotObj := &AccountingLot{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounting_lot\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, accountingLotObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from accounting_lot")
+	}
+
+	return accountingLotObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AccountingLot) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no accounting_lot provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accountingLotColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	accountingLotInsertCacheMut.RLock()
+	cache, cached := accountingLotInsertCache[key]
+	accountingLotInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			accountingLotAllColumns,
+			accountingLotColumnsWithDefault,
+			accountingLotColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(accountingLotType, accountingLotMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(accountingLotType, accountingLotMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounting_lot\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounting_lot\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"accounting_lot\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, accountingLotPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into accounting_lot")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for accounting_lot")
+	}
+
+CacheNoHooks:
+	if !cached {
+		accountingLotInsertCacheMut.Lock()
+		accountingLotInsertCache[key] = cache
+		accountingLotInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AccountingLot.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AccountingLot) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	accountingLotUpdateCacheMut.RLock()
+	cache, cached := accountingLotUpdateCache[key]
+	accountingLotUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			accountingLotAllColumns,
+			accountingLotPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update accounting_lot, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounting_lot\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, accountingLotPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(accountingLotType, accountingLotMapping, append(wl, accountingLotPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update accounting_lot row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for accounting_lot")
+	}
+
+	if !cached {
+		accountingLotUpdateCacheMut.Lock()
+		accountingLotUpdateCache[key] = cache
+		accountingLotUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q accountingLotQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for accounting_lot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for accounting_lot")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AccountingLotSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingLotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounting_lot\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingLotPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in accountingLot slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all accountingLot")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single AccountingLot record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AccountingLot) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no AccountingLot provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), accountingLotPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounting_lot\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from accounting_lot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for accounting_lot")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q accountingLotQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no accountingLotQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accounting_lot")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_lot")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AccountingLotSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(accountingLotBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingLotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounting_lot\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingLotPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accountingLot slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_lot")
+	}
+
+	if len(accountingLotAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AccountingLot) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAccountingLot(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AccountingLotSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AccountingLotSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingLotPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounting_lot\".* FROM \"accounting_lot\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingLotPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in AccountingLotSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AccountingLotExists checks if the AccountingLot row exists.
+func AccountingLotExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounting_lot\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if accounting_lot exists")
+	}
+
+	return exists, nil
+}
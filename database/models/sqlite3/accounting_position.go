@@ -0,0 +1,805 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// AccountingPosition is an object representing the database table.
+type AccountingPosition struct {
+	ID            string `boil:"id" json:"id" toml:"id" yaml:"id"`
+	PositionKey   string `boil:"position_key" json:"position_key" toml:"position_key" yaml:"position_key"`
+	ExchangeName  string `boil:"exchange_name" json:"exchange_name" toml:"exchange_name" yaml:"exchange_name"`
+	Asset         string `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
+	Base          string `boil:"base" json:"base" toml:"base" yaml:"base"`
+	Quote         string `boil:"quote" json:"quote" toml:"quote" yaml:"quote"`
+	Settlement    string `boil:"settlement" json:"settlement" toml:"settlement" yaml:"settlement"`
+	RealisedGross string `boil:"realised_gross" json:"realised_gross" toml:"realised_gross" yaml:"realised_gross"`
+	Fees          string `boil:"fees" json:"fees" toml:"fees" yaml:"fees"`
+	Funding       string `boil:"funding" json:"funding" toml:"funding" yaml:"funding"`
+
+	R *accountingPositionR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L accountingPositionL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var AccountingPositionColumns = struct {
+	ID            string
+	PositionKey   string
+	ExchangeName  string
+	Asset         string
+	Base          string
+	Quote         string
+	Settlement    string
+	RealisedGross string
+	Fees          string
+	Funding       string
+}{
+	ID:            "id",
+	PositionKey:   "position_key",
+	ExchangeName:  "exchange_name",
+	Asset:         "asset",
+	Base:          "base",
+	Quote:         "quote",
+	Settlement:    "settlement",
+	RealisedGross: "realised_gross",
+	Fees:          "fees",
+	Funding:       "funding",
+}
+
+// Generated where
+
+var AccountingPositionWhere = struct {
+	ID            whereHelperstring
+	PositionKey   whereHelperstring
+	ExchangeName  whereHelperstring
+	Asset         whereHelperstring
+	Base          whereHelperstring
+	Quote         whereHelperstring
+	Settlement    whereHelperstring
+	RealisedGross whereHelperstring
+	Fees          whereHelperstring
+	Funding       whereHelperstring
+}{
+	ID:            whereHelperstring{field: "\"accounting_position\".\"id\""},
+	PositionKey:   whereHelperstring{field: "\"accounting_position\".\"position_key\""},
+	ExchangeName:  whereHelperstring{field: "\"accounting_position\".\"exchange_name\""},
+	Asset:         whereHelperstring{field: "\"accounting_position\".\"asset\""},
+	Base:          whereHelperstring{field: "\"accounting_position\".\"base\""},
+	Quote:         whereHelperstring{field: "\"accounting_position\".\"quote\""},
+	Settlement:    whereHelperstring{field: "\"accounting_position\".\"settlement\""},
+	RealisedGross: whereHelperstring{field: "\"accounting_position\".\"realised_gross\""},
+	Fees:          whereHelperstring{field: "\"accounting_position\".\"fees\""},
+	Funding:       whereHelperstring{field: "\"accounting_position\".\"funding\""},
+}
+
+// AccountingPositionRels is where relationship names are stored.
+var AccountingPositionRels = struct {
+}{}
+
+// accountingPositionR is where relationships are stored.
+type accountingPositionR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*accountingPositionR) NewStruct() *accountingPositionR {
+	return &accountingPositionR{}
+}
+
+// accountingPositionL is where Load methods for each relationship are stored.
+type accountingPositionL struct{}
+
+var (
+	accountingPositionAllColumns            = []string{"id", "position_key", "exchange_name", "asset", "base", "quote", "settlement", "realised_gross", "fees", "funding"}
+	accountingPositionColumnsWithoutDefault = []string{"id", "position_key", "exchange_name", "asset", "base", "quote", "settlement", "realised_gross", "fees", "funding"}
+	accountingPositionColumnsWithDefault    = []string{}
+	accountingPositionPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// AccountingPositionSlice is an alias for a slice of pointers to AccountingPosition.
+	// This should generally be used opposed to []AccountingPosition.
+	AccountingPositionSlice []*AccountingPosition
+	// AccountingPositionHook is the signature for custom AccountingPosition hook methods
+	AccountingPositionHook func(context.Context, boil.ContextExecutor, *AccountingPosition) error
+
+	accountingPositionQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	accountingPositionType                 = reflect.TypeOf(&AccountingPosition{})
+	accountingPositionMapping              = queries.MakeStructMapping(accountingPositionType)
+	accountingPositionPrimaryKeyMapping, _ = queries.BindMapping(accountingPositionType, accountingPositionMapping, accountingPositionPrimaryKeyColumns)
+	accountingPositionInsertCacheMut       sync.RWMutex
+	accountingPositionInsertCache          = make(map[string]insertCache)
+	accountingPositionUpdateCacheMut       sync.RWMutex
+	accountingPositionUpdateCache          = make(map[string]updateCache)
+	accountingPositionUpsertCacheMut       sync.RWMutex
+	accountingPositionUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var accountingPositionBeforeInsertHooks []AccountingPositionHook
+var accountingPositionBeforeUpdateHooks []AccountingPositionHook
+var accountingPositionBeforeDeleteHooks []AccountingPositionHook
+var accountingPositionBeforeUpsertHooks []AccountingPositionHook
+
+var accountingPositionAfterInsertHooks []AccountingPositionHook
+var accountingPositionAfterSelectHooks []AccountingPositionHook
+var accountingPositionAfterUpdateHooks []AccountingPositionHook
+var accountingPositionAfterDeleteHooks []AccountingPositionHook
+var accountingPositionAfterUpsertHooks []AccountingPositionHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *AccountingPosition) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *AccountingPosition) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *AccountingPosition) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *AccountingPosition) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *AccountingPosition) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *AccountingPosition) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *AccountingPosition) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *AccountingPosition) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *AccountingPosition) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range accountingPositionAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddAccountingPositionHook registers your hook function for all future operations.
+func AddAccountingPositionHook(hookPoint boil.HookPoint, accountingPositionHook AccountingPositionHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		accountingPositionBeforeInsertHooks = append(accountingPositionBeforeInsertHooks, accountingPositionHook)
+	case boil.BeforeUpdateHook:
+		accountingPositionBeforeUpdateHooks = append(accountingPositionBeforeUpdateHooks, accountingPositionHook)
+	case boil.BeforeDeleteHook:
+		accountingPositionBeforeDeleteHooks = append(accountingPositionBeforeDeleteHooks, accountingPositionHook)
+	case boil.BeforeUpsertHook:
+		accountingPositionBeforeUpsertHooks = append(accountingPositionBeforeUpsertHooks, accountingPositionHook)
+	case boil.AfterInsertHook:
+		accountingPositionAfterInsertHooks = append(accountingPositionAfterInsertHooks, accountingPositionHook)
+	case boil.AfterSelectHook:
+		accountingPositionAfterSelectHooks = append(accountingPositionAfterSelectHooks, accountingPositionHook)
+	case boil.AfterUpdateHook:
+		accountingPositionAfterUpdateHooks = append(accountingPositionAfterUpdateHooks, accountingPositionHook)
+	case boil.AfterDeleteHook:
+		accountingPositionAfterDeleteHooks = append(accountingPositionAfterDeleteHooks, accountingPositionHook)
+	case boil.AfterUpsertHook:
+		accountingPositionAfterUpsertHooks = append(accountingPositionAfterUpsertHooks, accountingPositionHook)
+	}
+}
+
+// One returns a single accountingPosition record from the query.
+func (q accountingPositionQuery) One(ctx context.Context, exec boil.ContextExecutor) (*AccountingPosition, error) {
+	o := &AccountingPosition{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for accounting_position")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all AccountingPosition records from the query.
+func (q accountingPositionQuery) All(ctx context.Context, exec boil.ContextExecutor) (AccountingPositionSlice, error) {
+	var o []*AccountingPosition
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to AccountingPosition slice")
+	}
+
+	if len(accountingPositionAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all AccountingPosition records in the query.
+func (q accountingPositionQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count accounting_position rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q accountingPositionQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if accounting_position exists")
+	}
+
+	return count > 0, nil
+}
+
+// AccountingPositions retrieves all the records using an executor.
+func AccountingPositions(mods ...qm.QueryMod) accountingPositionQuery {
+	mods = append(mods, qm.From("\"accounting_position\""))
+	return accountingPositionQuery{NewQuery(mods...)}
+}
+
+// FindAccountingPosition retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindAccountingPosition(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*AccountingPosition, error) {
+	accountingPositionObj := &AccountingPosition{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"accounting_position\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, accountingPositionObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from accounting_position")
+	}
+
+	return accountingPositionObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *AccountingPosition) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no accounting_position provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(accountingPositionColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	accountingPositionInsertCacheMut.RLock()
+	cache, cached := accountingPositionInsertCache[key]
+	accountingPositionInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			accountingPositionAllColumns,
+			accountingPositionColumnsWithDefault,
+			accountingPositionColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(accountingPositionType, accountingPositionMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(accountingPositionType, accountingPositionMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"accounting_position\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"accounting_position\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"accounting_position\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, accountingPositionPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into accounting_position")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for accounting_position")
+	}
+
+CacheNoHooks:
+	if !cached {
+		accountingPositionInsertCacheMut.Lock()
+		accountingPositionInsertCache[key] = cache
+		accountingPositionInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the AccountingPosition.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *AccountingPosition) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	accountingPositionUpdateCacheMut.RLock()
+	cache, cached := accountingPositionUpdateCache[key]
+	accountingPositionUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			accountingPositionAllColumns,
+			accountingPositionPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update accounting_position, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"accounting_position\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, accountingPositionPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(accountingPositionType, accountingPositionMapping, append(wl, accountingPositionPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update accounting_position row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for accounting_position")
+	}
+
+	if !cached {
+		accountingPositionUpdateCacheMut.Lock()
+		accountingPositionUpdateCache[key] = cache
+		accountingPositionUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q accountingPositionQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for accounting_position")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for accounting_position")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o AccountingPositionSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingPositionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"accounting_position\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingPositionPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in accountingPosition slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all accountingPosition")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single AccountingPosition record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *AccountingPosition) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no AccountingPosition provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), accountingPositionPrimaryKeyMapping)
+	sql := "DELETE FROM \"accounting_position\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from accounting_position")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for accounting_position")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q accountingPositionQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no accountingPositionQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accounting_position")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_position")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o AccountingPositionSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(accountingPositionBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingPositionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"accounting_position\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingPositionPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from accountingPosition slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for accounting_position")
+	}
+
+	if len(accountingPositionAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *AccountingPosition) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindAccountingPosition(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *AccountingPositionSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := AccountingPositionSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), accountingPositionPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"accounting_position\".* FROM \"accounting_position\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, accountingPositionPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in AccountingPositionSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// AccountingPositionExists checks if the AccountingPosition row exists.
+func AccountingPositionExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"accounting_position\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if accounting_position exists")
+	}
+
+	return exists, nil
+}
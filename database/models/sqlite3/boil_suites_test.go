@@ -12,11 +12,19 @@ import "testing"
 // It does NOT run each operation group in parallel.
 // Separating the tests thusly grants avoidance of Postgres deadlocks.
 func TestParent(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEvents)
+	t.Run("AccountingLots", testAccountingLots)
+	t.Run("AccountingOrders", testAccountingOrders)
+	t.Run("AccountingPositions", testAccountingPositions)
 	t.Run("AuditEvents", testAuditEvents)
 	t.Run("Candles", testCandles)
 	t.Run("Datahistoryjobs", testDatahistoryjobs)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresults)
 	t.Run("Exchanges", testExchanges)
+	t.Run("ForexRates", testForexRates)
+	t.Run("FundingRates", testFundingRates)
+	t.Run("LedgerEntries", testLedgerEntries)
+	t.Run("NavSnapshots", testNavSnapshots)
 	t.Run("Scripts", testScripts)
 	t.Run("ScriptExecutions", testScriptExecutions)
 	t.Run("Trades", testTrades)
@@ -26,11 +34,19 @@ func TestParent(t *testing.T) {
 }
 
 func TestDelete(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsDelete)
+	t.Run("AccountingLots", testAccountingLotsDelete)
+	t.Run("AccountingOrders", testAccountingOrdersDelete)
+	t.Run("AccountingPositions", testAccountingPositionsDelete)
 	t.Run("AuditEvents", testAuditEventsDelete)
 	t.Run("Candles", testCandlesDelete)
 	t.Run("Datahistoryjobs", testDatahistoryjobsDelete)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsDelete)
 	t.Run("Exchanges", testExchangesDelete)
+	t.Run("ForexRates", testForexRatesDelete)
+	t.Run("FundingRates", testFundingRatesDelete)
+	t.Run("LedgerEntries", testLedgerEntriesDelete)
+	t.Run("NavSnapshots", testNavSnapshotsDelete)
 	t.Run("Scripts", testScriptsDelete)
 	t.Run("ScriptExecutions", testScriptExecutionsDelete)
 	t.Run("Trades", testTradesDelete)
@@ -40,11 +56,19 @@ func TestDelete(t *testing.T) {
 }
 
 func TestQueryDeleteAll(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsQueryDeleteAll)
+	t.Run("AccountingLots", testAccountingLotsQueryDeleteAll)
+	t.Run("AccountingOrders", testAccountingOrdersQueryDeleteAll)
+	t.Run("AccountingPositions", testAccountingPositionsQueryDeleteAll)
 	t.Run("AuditEvents", testAuditEventsQueryDeleteAll)
 	t.Run("Candles", testCandlesQueryDeleteAll)
 	t.Run("Datahistoryjobs", testDatahistoryjobsQueryDeleteAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsQueryDeleteAll)
 	t.Run("Exchanges", testExchangesQueryDeleteAll)
+	t.Run("ForexRates", testForexRatesQueryDeleteAll)
+	t.Run("FundingRates", testFundingRatesQueryDeleteAll)
+	t.Run("LedgerEntries", testLedgerEntriesQueryDeleteAll)
+	t.Run("NavSnapshots", testNavSnapshotsQueryDeleteAll)
 	t.Run("Scripts", testScriptsQueryDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsQueryDeleteAll)
 	t.Run("Trades", testTradesQueryDeleteAll)
@@ -54,11 +78,19 @@ func TestQueryDeleteAll(t *testing.T) {
 }
 
 func TestSliceDeleteAll(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsSliceDeleteAll)
+	t.Run("AccountingLots", testAccountingLotsSliceDeleteAll)
+	t.Run("AccountingOrders", testAccountingOrdersSliceDeleteAll)
+	t.Run("AccountingPositions", testAccountingPositionsSliceDeleteAll)
 	t.Run("AuditEvents", testAuditEventsSliceDeleteAll)
 	t.Run("Candles", testCandlesSliceDeleteAll)
 	t.Run("Datahistoryjobs", testDatahistoryjobsSliceDeleteAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSliceDeleteAll)
 	t.Run("Exchanges", testExchangesSliceDeleteAll)
+	t.Run("ForexRates", testForexRatesSliceDeleteAll)
+	t.Run("FundingRates", testFundingRatesSliceDeleteAll)
+	t.Run("LedgerEntries", testLedgerEntriesSliceDeleteAll)
+	t.Run("NavSnapshots", testNavSnapshotsSliceDeleteAll)
 	t.Run("Scripts", testScriptsSliceDeleteAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceDeleteAll)
 	t.Run("Trades", testTradesSliceDeleteAll)
@@ -68,11 +100,19 @@ func TestSliceDeleteAll(t *testing.T) {
 }
 
 func TestExists(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsExists)
+	t.Run("AccountingLots", testAccountingLotsExists)
+	t.Run("AccountingOrders", testAccountingOrdersExists)
+	t.Run("AccountingPositions", testAccountingPositionsExists)
 	t.Run("AuditEvents", testAuditEventsExists)
 	t.Run("Candles", testCandlesExists)
 	t.Run("Datahistoryjobs", testDatahistoryjobsExists)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsExists)
 	t.Run("Exchanges", testExchangesExists)
+	t.Run("ForexRates", testForexRatesExists)
+	t.Run("FundingRates", testFundingRatesExists)
+	t.Run("LedgerEntries", testLedgerEntriesExists)
+	t.Run("NavSnapshots", testNavSnapshotsExists)
 	t.Run("Scripts", testScriptsExists)
 	t.Run("ScriptExecutions", testScriptExecutionsExists)
 	t.Run("Trades", testTradesExists)
@@ -82,11 +122,19 @@ func TestExists(t *testing.T) {
 }
 
 func TestFind(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsFind)
+	t.Run("AccountingLots", testAccountingLotsFind)
+	t.Run("AccountingOrders", testAccountingOrdersFind)
+	t.Run("AccountingPositions", testAccountingPositionsFind)
 	t.Run("AuditEvents", testAuditEventsFind)
 	t.Run("Candles", testCandlesFind)
 	t.Run("Datahistoryjobs", testDatahistoryjobsFind)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsFind)
 	t.Run("Exchanges", testExchangesFind)
+	t.Run("ForexRates", testForexRatesFind)
+	t.Run("FundingRates", testFundingRatesFind)
+	t.Run("LedgerEntries", testLedgerEntriesFind)
+	t.Run("NavSnapshots", testNavSnapshotsFind)
 	t.Run("Scripts", testScriptsFind)
 	t.Run("ScriptExecutions", testScriptExecutionsFind)
 	t.Run("Trades", testTradesFind)
@@ -96,11 +144,19 @@ func TestFind(t *testing.T) {
 }
 
 func TestBind(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsBind)
+	t.Run("AccountingLots", testAccountingLotsBind)
+	t.Run("AccountingOrders", testAccountingOrdersBind)
+	t.Run("AccountingPositions", testAccountingPositionsBind)
 	t.Run("AuditEvents", testAuditEventsBind)
 	t.Run("Candles", testCandlesBind)
 	t.Run("Datahistoryjobs", testDatahistoryjobsBind)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsBind)
 	t.Run("Exchanges", testExchangesBind)
+	t.Run("ForexRates", testForexRatesBind)
+	t.Run("FundingRates", testFundingRatesBind)
+	t.Run("LedgerEntries", testLedgerEntriesBind)
+	t.Run("NavSnapshots", testNavSnapshotsBind)
 	t.Run("Scripts", testScriptsBind)
 	t.Run("ScriptExecutions", testScriptExecutionsBind)
 	t.Run("Trades", testTradesBind)
@@ -110,11 +166,19 @@ func TestBind(t *testing.T) {
 }
 
 func TestOne(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsOne)
+	t.Run("AccountingLots", testAccountingLotsOne)
+	t.Run("AccountingOrders", testAccountingOrdersOne)
+	t.Run("AccountingPositions", testAccountingPositionsOne)
 	t.Run("AuditEvents", testAuditEventsOne)
 	t.Run("Candles", testCandlesOne)
 	t.Run("Datahistoryjobs", testDatahistoryjobsOne)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsOne)
 	t.Run("Exchanges", testExchangesOne)
+	t.Run("ForexRates", testForexRatesOne)
+	t.Run("FundingRates", testFundingRatesOne)
+	t.Run("LedgerEntries", testLedgerEntriesOne)
+	t.Run("NavSnapshots", testNavSnapshotsOne)
 	t.Run("Scripts", testScriptsOne)
 	t.Run("ScriptExecutions", testScriptExecutionsOne)
 	t.Run("Trades", testTradesOne)
@@ -124,11 +188,19 @@ func TestOne(t *testing.T) {
 }
 
 func TestAll(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsAll)
+	t.Run("AccountingLots", testAccountingLotsAll)
+	t.Run("AccountingOrders", testAccountingOrdersAll)
+	t.Run("AccountingPositions", testAccountingPositionsAll)
 	t.Run("AuditEvents", testAuditEventsAll)
 	t.Run("Candles", testCandlesAll)
 	t.Run("Datahistoryjobs", testDatahistoryjobsAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsAll)
 	t.Run("Exchanges", testExchangesAll)
+	t.Run("ForexRates", testForexRatesAll)
+	t.Run("FundingRates", testFundingRatesAll)
+	t.Run("LedgerEntries", testLedgerEntriesAll)
+	t.Run("NavSnapshots", testNavSnapshotsAll)
 	t.Run("Scripts", testScriptsAll)
 	t.Run("ScriptExecutions", testScriptExecutionsAll)
 	t.Run("Trades", testTradesAll)
@@ -138,11 +210,19 @@ func TestAll(t *testing.T) {
 }
 
 func TestCount(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsCount)
+	t.Run("AccountingLots", testAccountingLotsCount)
+	t.Run("AccountingOrders", testAccountingOrdersCount)
+	t.Run("AccountingPositions", testAccountingPositionsCount)
 	t.Run("AuditEvents", testAuditEventsCount)
 	t.Run("Candles", testCandlesCount)
 	t.Run("Datahistoryjobs", testDatahistoryjobsCount)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsCount)
 	t.Run("Exchanges", testExchangesCount)
+	t.Run("ForexRates", testForexRatesCount)
+	t.Run("FundingRates", testFundingRatesCount)
+	t.Run("LedgerEntries", testLedgerEntriesCount)
+	t.Run("NavSnapshots", testNavSnapshotsCount)
 	t.Run("Scripts", testScriptsCount)
 	t.Run("ScriptExecutions", testScriptExecutionsCount)
 	t.Run("Trades", testTradesCount)
@@ -152,11 +232,19 @@ func TestCount(t *testing.T) {
 }
 
 func TestHooks(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsHooks)
+	t.Run("AccountingLots", testAccountingLotsHooks)
+	t.Run("AccountingOrders", testAccountingOrdersHooks)
+	t.Run("AccountingPositions", testAccountingPositionsHooks)
 	t.Run("AuditEvents", testAuditEventsHooks)
 	t.Run("Candles", testCandlesHooks)
 	t.Run("Datahistoryjobs", testDatahistoryjobsHooks)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsHooks)
 	t.Run("Exchanges", testExchangesHooks)
+	t.Run("ForexRates", testForexRatesHooks)
+	t.Run("FundingRates", testFundingRatesHooks)
+	t.Run("LedgerEntries", testLedgerEntriesHooks)
+	t.Run("NavSnapshots", testNavSnapshotsHooks)
 	t.Run("Scripts", testScriptsHooks)
 	t.Run("ScriptExecutions", testScriptExecutionsHooks)
 	t.Run("Trades", testTradesHooks)
@@ -166,6 +254,14 @@ func TestHooks(t *testing.T) {
 }
 
 func TestInsert(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsInsert)
+	t.Run("AccountingEvents", testAccountingEventsInsertWhitelist)
+	t.Run("AccountingLots", testAccountingLotsInsert)
+	t.Run("AccountingLots", testAccountingLotsInsertWhitelist)
+	t.Run("AccountingOrders", testAccountingOrdersInsert)
+	t.Run("AccountingOrders", testAccountingOrdersInsertWhitelist)
+	t.Run("AccountingPositions", testAccountingPositionsInsert)
+	t.Run("AccountingPositions", testAccountingPositionsInsertWhitelist)
 	t.Run("AuditEvents", testAuditEventsInsert)
 	t.Run("AuditEvents", testAuditEventsInsertWhitelist)
 	t.Run("Candles", testCandlesInsert)
@@ -176,6 +272,14 @@ func TestInsert(t *testing.T) {
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsInsertWhitelist)
 	t.Run("Exchanges", testExchangesInsert)
 	t.Run("Exchanges", testExchangesInsertWhitelist)
+	t.Run("ForexRates", testForexRatesInsert)
+	t.Run("ForexRates", testForexRatesInsertWhitelist)
+	t.Run("FundingRates", testFundingRatesInsert)
+	t.Run("FundingRates", testFundingRatesInsertWhitelist)
+	t.Run("LedgerEntries", testLedgerEntriesInsert)
+	t.Run("LedgerEntries", testLedgerEntriesInsertWhitelist)
+	t.Run("NavSnapshots", testNavSnapshotsInsert)
+	t.Run("NavSnapshots", testNavSnapshotsInsertWhitelist)
 	t.Run("Scripts", testScriptsInsert)
 	t.Run("Scripts", testScriptsInsertWhitelist)
 	t.Run("ScriptExecutions", testScriptExecutionsInsert)
@@ -199,6 +303,7 @@ func TestToOne(t *testing.T) {
 	t.Run("DatahistoryjobToExchangeUsingExchangeName", testDatahistoryjobToOneExchangeUsingExchangeName)
 	t.Run("DatahistoryjobToExchangeUsingSecondaryExchange", testDatahistoryjobToOneExchangeUsingSecondaryExchange)
 	t.Run("DatahistoryjobresultToDatahistoryjobUsingJob", testDatahistoryjobresultToOneDatahistoryjobUsingJob)
+	t.Run("FundingRateToExchangeUsingExchangeName", testFundingRateToOneExchangeUsingExchangeName)
 	t.Run("ScriptExecutionToScriptUsingScript", testScriptExecutionToOneScriptUsingScript)
 	t.Run("TradeToExchangeUsingExchangeName", testTradeToOneExchangeUsingExchangeName)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalHistory", testWithdrawalCryptoToOneWithdrawalHistoryUsingWithdrawalHistory)
@@ -210,6 +315,7 @@ func TestToOne(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOne(t *testing.T) {
 	t.Run("ExchangeToCandleUsingExchangeNameCandle", testExchangeOneToOneCandleUsingExchangeNameCandle)
+	t.Run("ExchangeToFundingRateUsingExchangeNameFundingRate", testExchangeOneToOneFundingRateUsingExchangeNameFundingRate)
 	t.Run("ExchangeToTradeUsingExchangeNameTrade", testExchangeOneToOneTradeUsingExchangeNameTrade)
 }
 
@@ -238,6 +344,7 @@ func TestToOneSet(t *testing.T) {
 	t.Run("DatahistoryjobToExchangeUsingExchangeNameDatahistoryjobs", testDatahistoryjobToOneSetOpExchangeUsingExchangeName)
 	t.Run("DatahistoryjobToExchangeUsingSecondaryExchangeDatahistoryjobs", testDatahistoryjobToOneSetOpExchangeUsingSecondaryExchange)
 	t.Run("DatahistoryjobresultToDatahistoryjobUsingJobDatahistoryjobresults", testDatahistoryjobresultToOneSetOpDatahistoryjobUsingJob)
+	t.Run("FundingRateToExchangeUsingExchangeNameFundingRate", testFundingRateToOneSetOpExchangeUsingExchangeName)
 	t.Run("ScriptExecutionToScriptUsingScriptExecutions", testScriptExecutionToOneSetOpScriptUsingScript)
 	t.Run("TradeToExchangeUsingExchangeNameTrade", testTradeToOneSetOpExchangeUsingExchangeName)
 	t.Run("WithdrawalCryptoToWithdrawalHistoryUsingWithdrawalCryptos", testWithdrawalCryptoToOneSetOpWithdrawalHistoryUsingWithdrawalHistory)
@@ -257,6 +364,7 @@ func TestToOneRemove(t *testing.T) {
 // or deadlocks can occur.
 func TestOneToOneSet(t *testing.T) {
 	t.Run("ExchangeToCandleUsingExchangeNameCandle", testExchangeOneToOneSetOpCandleUsingExchangeNameCandle)
+	t.Run("ExchangeToFundingRateUsingExchangeNameFundingRate", testExchangeOneToOneSetOpFundingRateUsingExchangeNameFundingRate)
 	t.Run("ExchangeToTradeUsingExchangeNameTrade", testExchangeOneToOneSetOpTradeUsingExchangeNameTrade)
 }
 
@@ -301,11 +409,19 @@ func TestToManyRemove(t *testing.T) {
 }
 
 func TestReload(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsReload)
+	t.Run("AccountingLots", testAccountingLotsReload)
+	t.Run("AccountingOrders", testAccountingOrdersReload)
+	t.Run("AccountingPositions", testAccountingPositionsReload)
 	t.Run("AuditEvents", testAuditEventsReload)
 	t.Run("Candles", testCandlesReload)
 	t.Run("Datahistoryjobs", testDatahistoryjobsReload)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsReload)
 	t.Run("Exchanges", testExchangesReload)
+	t.Run("ForexRates", testForexRatesReload)
+	t.Run("FundingRates", testFundingRatesReload)
+	t.Run("LedgerEntries", testLedgerEntriesReload)
+	t.Run("NavSnapshots", testNavSnapshotsReload)
 	t.Run("Scripts", testScriptsReload)
 	t.Run("ScriptExecutions", testScriptExecutionsReload)
 	t.Run("Trades", testTradesReload)
@@ -315,11 +431,19 @@ func TestReload(t *testing.T) {
 }
 
 func TestReloadAll(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsReloadAll)
+	t.Run("AccountingLots", testAccountingLotsReloadAll)
+	t.Run("AccountingOrders", testAccountingOrdersReloadAll)
+	t.Run("AccountingPositions", testAccountingPositionsReloadAll)
 	t.Run("AuditEvents", testAuditEventsReloadAll)
 	t.Run("Candles", testCandlesReloadAll)
 	t.Run("Datahistoryjobs", testDatahistoryjobsReloadAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsReloadAll)
 	t.Run("Exchanges", testExchangesReloadAll)
+	t.Run("ForexRates", testForexRatesReloadAll)
+	t.Run("FundingRates", testFundingRatesReloadAll)
+	t.Run("LedgerEntries", testLedgerEntriesReloadAll)
+	t.Run("NavSnapshots", testNavSnapshotsReloadAll)
 	t.Run("Scripts", testScriptsReloadAll)
 	t.Run("ScriptExecutions", testScriptExecutionsReloadAll)
 	t.Run("Trades", testTradesReloadAll)
@@ -329,11 +453,19 @@ func TestReloadAll(t *testing.T) {
 }
 
 func TestSelect(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsSelect)
+	t.Run("AccountingLots", testAccountingLotsSelect)
+	t.Run("AccountingOrders", testAccountingOrdersSelect)
+	t.Run("AccountingPositions", testAccountingPositionsSelect)
 	t.Run("AuditEvents", testAuditEventsSelect)
 	t.Run("Candles", testCandlesSelect)
 	t.Run("Datahistoryjobs", testDatahistoryjobsSelect)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSelect)
 	t.Run("Exchanges", testExchangesSelect)
+	t.Run("ForexRates", testForexRatesSelect)
+	t.Run("FundingRates", testFundingRatesSelect)
+	t.Run("LedgerEntries", testLedgerEntriesSelect)
+	t.Run("NavSnapshots", testNavSnapshotsSelect)
 	t.Run("Scripts", testScriptsSelect)
 	t.Run("ScriptExecutions", testScriptExecutionsSelect)
 	t.Run("Trades", testTradesSelect)
@@ -343,11 +475,19 @@ func TestSelect(t *testing.T) {
 }
 
 func TestUpdate(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsUpdate)
+	t.Run("AccountingLots", testAccountingLotsUpdate)
+	t.Run("AccountingOrders", testAccountingOrdersUpdate)
+	t.Run("AccountingPositions", testAccountingPositionsUpdate)
 	t.Run("AuditEvents", testAuditEventsUpdate)
 	t.Run("Candles", testCandlesUpdate)
 	t.Run("Datahistoryjobs", testDatahistoryjobsUpdate)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsUpdate)
 	t.Run("Exchanges", testExchangesUpdate)
+	t.Run("ForexRates", testForexRatesUpdate)
+	t.Run("FundingRates", testFundingRatesUpdate)
+	t.Run("LedgerEntries", testLedgerEntriesUpdate)
+	t.Run("NavSnapshots", testNavSnapshotsUpdate)
 	t.Run("Scripts", testScriptsUpdate)
 	t.Run("ScriptExecutions", testScriptExecutionsUpdate)
 	t.Run("Trades", testTradesUpdate)
@@ -357,11 +497,19 @@ func TestUpdate(t *testing.T) {
 }
 
 func TestSliceUpdateAll(t *testing.T) {
+	t.Run("AccountingEvents", testAccountingEventsSliceUpdateAll)
+	t.Run("AccountingLots", testAccountingLotsSliceUpdateAll)
+	t.Run("AccountingOrders", testAccountingOrdersSliceUpdateAll)
+	t.Run("AccountingPositions", testAccountingPositionsSliceUpdateAll)
 	t.Run("AuditEvents", testAuditEventsSliceUpdateAll)
 	t.Run("Candles", testCandlesSliceUpdateAll)
 	t.Run("Datahistoryjobs", testDatahistoryjobsSliceUpdateAll)
 	t.Run("Datahistoryjobresults", testDatahistoryjobresultsSliceUpdateAll)
 	t.Run("Exchanges", testExchangesSliceUpdateAll)
+	t.Run("ForexRates", testForexRatesSliceUpdateAll)
+	t.Run("FundingRates", testFundingRatesSliceUpdateAll)
+	t.Run("LedgerEntries", testLedgerEntriesSliceUpdateAll)
+	t.Run("NavSnapshots", testNavSnapshotsSliceUpdateAll)
 	t.Run("Scripts", testScriptsSliceUpdateAll)
 	t.Run("ScriptExecutions", testScriptExecutionsSliceUpdateAll)
 	t.Run("Trades", testTradesSliceUpdateAll)
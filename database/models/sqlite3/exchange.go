@@ -50,12 +50,14 @@ var ExchangeWhere = struct {
 // ExchangeRels is where relationship names are stored.
 var ExchangeRels = struct {
 	ExchangeNameCandle               string
+	ExchangeNameFundingRate          string
 	ExchangeNameTrade                string
 	ExchangeNameDatahistoryjobs      string
 	SecondaryExchangeDatahistoryjobs string
 	ExchangeNameWithdrawalHistories  string
 }{
 	ExchangeNameCandle:               "ExchangeNameCandle",
+	ExchangeNameFundingRate:          "ExchangeNameFundingRate",
 	ExchangeNameTrade:                "ExchangeNameTrade",
 	ExchangeNameDatahistoryjobs:      "ExchangeNameDatahistoryjobs",
 	SecondaryExchangeDatahistoryjobs: "SecondaryExchangeDatahistoryjobs",
@@ -65,6 +67,7 @@ var ExchangeRels = struct {
 // exchangeR is where relationships are stored.
 type exchangeR struct {
 	ExchangeNameCandle               *Candle
+	ExchangeNameFundingRate          *FundingRate
 	ExchangeNameTrade                *Trade
 	ExchangeNameDatahistoryjobs      DatahistoryjobSlice
 	SecondaryExchangeDatahistoryjobs DatahistoryjobSlice
@@ -375,6 +378,20 @@ func (o *Exchange) ExchangeNameCandle(mods ...qm.QueryMod) candleQuery {
 	return query
 }
 
+// ExchangeNameFundingRate pointed to by the foreign key.
+func (o *Exchange) ExchangeNameFundingRate(mods ...qm.QueryMod) fundingRateQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"exchange_name_id\" = ?", o.ID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := FundingRates(queryMods...)
+	queries.SetFrom(query.Query, "\"funding_rate\"")
+
+	return query
+}
+
 // ExchangeNameTrade pointed to by the foreign key.
 func (o *Exchange) ExchangeNameTrade(mods ...qm.QueryMod) tradeQuery {
 	queryMods := []qm.QueryMod{
@@ -550,6 +567,104 @@ func (exchangeL) LoadExchangeNameCandle(ctx context.Context, e boil.ContextExecu
 	return nil
 }
 
+// LoadExchangeNameFundingRate allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for a 1-1 relationship.
+func (exchangeL) LoadExchangeNameFundingRate(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
+	var slice []*Exchange
+	var object *Exchange
+
+	if singular {
+		object = maybeExchange.(*Exchange)
+	} else {
+		slice = *maybeExchange.(*[]*Exchange)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &exchangeR{}
+		}
+		args = append(args, object.ID)
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &exchangeR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ID)
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`funding_rate`), qm.WhereIn(`funding_rate.exchange_name_id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load FundingRate")
+	}
+
+	var resultSlice []*FundingRate
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice FundingRate")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for funding_rate")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for funding_rate")
+	}
+
+	if len(exchangeAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ExchangeNameFundingRate = foreign
+		if foreign.R == nil {
+			foreign.R = &fundingRateR{}
+		}
+		foreign.R.ExchangeName = object
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ID == foreign.ExchangeNameID {
+				local.R.ExchangeNameFundingRate = foreign
+				if foreign.R == nil {
+					foreign.R = &fundingRateR{}
+				}
+				foreign.R.ExchangeName = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
 // LoadExchangeNameTrade allows an eager lookup of values, cached into the
 // loaded structs of the objects. This is for a 1-1 relationship.
 func (exchangeL) LoadExchangeNameTrade(ctx context.Context, e boil.ContextExecutor, singular bool, maybeExchange interface{}, mods queries.Applicator) error {
@@ -984,6 +1099,57 @@ func (o *Exchange) SetExchangeNameCandle(ctx context.Context, exec boil.ContextE
 	return nil
 }
 
+// SetExchangeNameFundingRate of the exchange to the related item.
+// Sets o.R.ExchangeNameFundingRate to related.
+// Adds o to related.R.ExchangeName.
+func (o *Exchange) SetExchangeNameFundingRate(ctx context.Context, exec boil.ContextExecutor, insert bool, related *FundingRate) error {
+	var err error
+
+	if insert {
+		related.ExchangeNameID = o.ID
+
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	} else {
+		updateQuery := fmt.Sprintf(
+			"UPDATE \"funding_rate\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, []string{"exchange_name_id"}),
+			strmangle.WhereClause("\"", "\"", 0, fundingRatePrimaryKeyColumns),
+		)
+		values := []interface{}{o.ID, related.ID}
+
+		if boil.DebugMode {
+			fmt.Fprintln(boil.DebugWriter, updateQuery)
+			fmt.Fprintln(boil.DebugWriter, values)
+		}
+
+		if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+			return errors.Wrap(err, "failed to update foreign table")
+		}
+
+		related.ExchangeNameID = o.ID
+
+	}
+
+	if o.R == nil {
+		o.R = &exchangeR{
+			ExchangeNameFundingRate: related,
+		}
+	} else {
+		o.R.ExchangeNameFundingRate = related
+	}
+
+	if related.R == nil {
+		related.R = &fundingRateR{
+			ExchangeName: o,
+		}
+	} else {
+		related.R.ExchangeName = o
+	}
+	return nil
+}
+
 // SetExchangeNameTrade of the exchange to the related item.
 // Sets o.R.ExchangeNameTrade to related.
 // Adds o to related.R.ExchangeName.
@@ -545,6 +545,57 @@ func testExchangeOneToOneCandleUsingExchangeNameCandle(t *testing.T) {
 	}
 }
 
+func testExchangeOneToOneFundingRateUsingExchangeNameFundingRate(t *testing.T) {
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var foreign FundingRate
+	var local Exchange
+
+	seed := randomize.NewSeed()
+	if err := randomize.Struct(seed, &foreign, fundingRateDBTypes, true, fundingRateColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize FundingRate struct: %s", err)
+	}
+	if err := randomize.Struct(seed, &local, exchangeDBTypes, true, exchangeColumnsWithDefault...); err != nil {
+		t.Errorf("Unable to randomize Exchange struct: %s", err)
+	}
+
+	if err := local.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	foreign.ExchangeNameID = local.ID
+	if err := foreign.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	check, err := local.ExchangeNameFundingRate().One(ctx, tx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if check.ExchangeNameID != foreign.ExchangeNameID {
+		t.Errorf("want: %v, got %v", foreign.ExchangeNameID, check.ExchangeNameID)
+	}
+
+	slice := ExchangeSlice{&local}
+	if err = local.L.LoadExchangeNameFundingRate(ctx, tx, false, (*[]*Exchange)(&slice), nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeNameFundingRate == nil {
+		t.Error("struct should have been eager loaded")
+	}
+
+	local.R.ExchangeNameFundingRate = nil
+	if err = local.L.LoadExchangeNameFundingRate(ctx, tx, true, &local, nil); err != nil {
+		t.Fatal(err)
+	}
+	if local.R.ExchangeNameFundingRate == nil {
+		t.Error("struct should have been eager loaded")
+	}
+}
+
 func testExchangeOneToOneTradeUsingExchangeNameTrade(t *testing.T) {
 	ctx := context.Background()
 	tx := MustTx(boil.BeginTx(ctx, nil))
@@ -657,6 +708,67 @@ func testExchangeOneToOneSetOpCandleUsingExchangeNameCandle(t *testing.T) {
 		}
 	}
 }
+func testExchangeOneToOneSetOpFundingRateUsingExchangeNameFundingRate(t *testing.T) {
+	var err error
+
+	ctx := context.Background()
+	tx := MustTx(boil.BeginTx(ctx, nil))
+	defer func() { _ = tx.Rollback() }()
+
+	var a Exchange
+	var b, c FundingRate
+
+	seed := randomize.NewSeed()
+	if err = randomize.Struct(seed, &a, exchangeDBTypes, false, strmangle.SetComplement(exchangePrimaryKeyColumns, exchangeColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &b, fundingRateDBTypes, false, strmangle.SetComplement(fundingRatePrimaryKeyColumns, fundingRateColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+	if err = randomize.Struct(seed, &c, fundingRateDBTypes, false, strmangle.SetComplement(fundingRatePrimaryKeyColumns, fundingRateColumnsWithoutDefault)...); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := a.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+	if err = b.Insert(ctx, tx, boil.Infer()); err != nil {
+		t.Fatal(err)
+	}
+
+	for i, x := range []*FundingRate{&b, &c} {
+		err = a.SetExchangeNameFundingRate(ctx, tx, i != 0, x)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if a.R.ExchangeNameFundingRate != x {
+			t.Error("relationship struct not set to correct value")
+		}
+		if x.R.ExchangeName != &a {
+			t.Error("failed to append to foreign relationship struct")
+		}
+
+		if a.ID != x.ExchangeNameID {
+			t.Error("foreign key was wrong value", a.ID)
+		}
+
+		zero := reflect.Zero(reflect.TypeOf(x.ExchangeNameID))
+		reflect.Indirect(reflect.ValueOf(&x.ExchangeNameID)).Set(zero)
+
+		if err = x.Reload(ctx, tx); err != nil {
+			t.Fatal("failed to reload", err)
+		}
+
+		if a.ID != x.ExchangeNameID {
+			t.Error("foreign key was wrong value", a.ID, x.ExchangeNameID)
+		}
+
+		if _, err = x.Delete(ctx, tx); err != nil {
+			t.Fatal("failed to delete x", err)
+		}
+	}
+}
 func testExchangeOneToOneSetOpTradeUsingExchangeNameTrade(t *testing.T) {
 	var err error
 
@@ -0,0 +1,956 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// FundingRate is an object representing the database table.
+type FundingRate struct {
+	ID             string  `boil:"id" json:"id" toml:"id" yaml:"id"`
+	ExchangeNameID string  `boil:"exchange_name_id" json:"exchange_name_id" toml:"exchange_name_id" yaml:"exchange_name_id"`
+	Asset          string  `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
+	Base           string  `boil:"base" json:"base" toml:"base" yaml:"base"`
+	Quote          string  `boil:"quote" json:"quote" toml:"quote" yaml:"quote"`
+	Rate           float64 `boil:"rate" json:"rate" toml:"rate" yaml:"rate"`
+	Timestamp      string  `boil:"timestamp" json:"timestamp" toml:"timestamp" yaml:"timestamp"`
+
+	R *fundingRateR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L fundingRateL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var FundingRateColumns = struct {
+	ID             string
+	ExchangeNameID string
+	Asset          string
+	Base           string
+	Quote          string
+	Rate           string
+	Timestamp      string
+}{
+	ID:             "id",
+	ExchangeNameID: "exchange_name_id",
+	Asset:          "asset",
+	Base:           "base",
+	Quote:          "quote",
+	Rate:           "rate",
+	Timestamp:      "timestamp",
+}
+
+// Generated where
+
+var FundingRateWhere = struct {
+	ID             whereHelperstring
+	ExchangeNameID whereHelperstring
+	Asset          whereHelperstring
+	Base           whereHelperstring
+	Quote          whereHelperstring
+	Rate           whereHelperfloat64
+	Timestamp      whereHelperstring
+}{
+	ID:             whereHelperstring{field: "\"funding_rate\".\"id\""},
+	ExchangeNameID: whereHelperstring{field: "\"funding_rate\".\"exchange_name_id\""},
+	Asset:          whereHelperstring{field: "\"funding_rate\".\"asset\""},
+	Base:           whereHelperstring{field: "\"funding_rate\".\"base\""},
+	Quote:          whereHelperstring{field: "\"funding_rate\".\"quote\""},
+	Rate:           whereHelperfloat64{field: "\"funding_rate\".\"rate\""},
+	Timestamp:      whereHelperstring{field: "\"funding_rate\".\"timestamp\""},
+}
+
+// FundingRateRels is where relationship names are stored.
+var FundingRateRels = struct {
+	ExchangeName string
+}{
+	ExchangeName: "ExchangeName",
+}
+
+// fundingRateR is where relationships are stored.
+type fundingRateR struct {
+	ExchangeName *Exchange
+}
+
+// NewStruct creates a new relationship struct
+func (*fundingRateR) NewStruct() *fundingRateR {
+	return &fundingRateR{}
+}
+
+// fundingRateL is where Load methods for each relationship are stored.
+type fundingRateL struct{}
+
+var (
+	fundingRateAllColumns            = []string{"id", "exchange_name_id", "asset", "base", "quote", "rate", "timestamp"}
+	fundingRateColumnsWithoutDefault = []string{"id", "exchange_name_id", "asset", "base", "quote", "rate", "timestamp"}
+	fundingRateColumnsWithDefault    = []string{}
+	fundingRatePrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// FundingRateSlice is an alias for a slice of pointers to FundingRate.
+	// This should generally be used opposed to []FundingRate.
+	FundingRateSlice []*FundingRate
+	// FundingRateHook is the signature for custom FundingRate hook methods
+	FundingRateHook func(context.Context, boil.ContextExecutor, *FundingRate) error
+
+	fundingRateQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	fundingRateType                 = reflect.TypeOf(&FundingRate{})
+	fundingRateMapping              = queries.MakeStructMapping(fundingRateType)
+	fundingRatePrimaryKeyMapping, _ = queries.BindMapping(fundingRateType, fundingRateMapping, fundingRatePrimaryKeyColumns)
+	fundingRateInsertCacheMut       sync.RWMutex
+	fundingRateInsertCache          = make(map[string]insertCache)
+	fundingRateUpdateCacheMut       sync.RWMutex
+	fundingRateUpdateCache          = make(map[string]updateCache)
+	fundingRateUpsertCacheMut       sync.RWMutex
+	fundingRateUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var fundingRateBeforeInsertHooks []FundingRateHook
+var fundingRateBeforeUpdateHooks []FundingRateHook
+var fundingRateBeforeDeleteHooks []FundingRateHook
+var fundingRateBeforeUpsertHooks []FundingRateHook
+
+var fundingRateAfterInsertHooks []FundingRateHook
+var fundingRateAfterSelectHooks []FundingRateHook
+var fundingRateAfterUpdateHooks []FundingRateHook
+var fundingRateAfterDeleteHooks []FundingRateHook
+var fundingRateAfterUpsertHooks []FundingRateHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *FundingRate) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *FundingRate) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *FundingRate) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *FundingRate) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *FundingRate) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *FundingRate) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *FundingRate) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *FundingRate) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *FundingRate) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range fundingRateAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddFundingRateHook registers your hook function for all future operations.
+func AddFundingRateHook(hookPoint boil.HookPoint, fundingRateHook FundingRateHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		fundingRateBeforeInsertHooks = append(fundingRateBeforeInsertHooks, fundingRateHook)
+	case boil.BeforeUpdateHook:
+		fundingRateBeforeUpdateHooks = append(fundingRateBeforeUpdateHooks, fundingRateHook)
+	case boil.BeforeDeleteHook:
+		fundingRateBeforeDeleteHooks = append(fundingRateBeforeDeleteHooks, fundingRateHook)
+	case boil.BeforeUpsertHook:
+		fundingRateBeforeUpsertHooks = append(fundingRateBeforeUpsertHooks, fundingRateHook)
+	case boil.AfterInsertHook:
+		fundingRateAfterInsertHooks = append(fundingRateAfterInsertHooks, fundingRateHook)
+	case boil.AfterSelectHook:
+		fundingRateAfterSelectHooks = append(fundingRateAfterSelectHooks, fundingRateHook)
+	case boil.AfterUpdateHook:
+		fundingRateAfterUpdateHooks = append(fundingRateAfterUpdateHooks, fundingRateHook)
+	case boil.AfterDeleteHook:
+		fundingRateAfterDeleteHooks = append(fundingRateAfterDeleteHooks, fundingRateHook)
+	case boil.AfterUpsertHook:
+		fundingRateAfterUpsertHooks = append(fundingRateAfterUpsertHooks, fundingRateHook)
+	}
+}
+
+// One returns a single fundingRate record from the query.
+func (q fundingRateQuery) One(ctx context.Context, exec boil.ContextExecutor) (*FundingRate, error) {
+	o := &FundingRate{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for funding_rate")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all FundingRate records from the query.
+func (q fundingRateQuery) All(ctx context.Context, exec boil.ContextExecutor) (FundingRateSlice, error) {
+	var o []*FundingRate
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to FundingRate slice")
+	}
+
+	if len(fundingRateAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all FundingRate records in the query.
+func (q fundingRateQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count funding_rate rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q fundingRateQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if funding_rate exists")
+	}
+
+	return count > 0, nil
+}
+
+// ExchangeName pointed to by the foreign key.
+func (o *FundingRate) ExchangeName(mods ...qm.QueryMod) exchangeQuery {
+	queryMods := []qm.QueryMod{
+		qm.Where("\"id\" = ?", o.ExchangeNameID),
+	}
+
+	queryMods = append(queryMods, mods...)
+
+	query := Exchanges(queryMods...)
+	queries.SetFrom(query.Query, "\"exchange\"")
+
+	return query
+}
+
+// LoadExchangeName allows an eager lookup of values, cached into the
+// loaded structs of the objects. This is for an N-1 relationship.
+func (fundingRateL) LoadExchangeName(ctx context.Context, e boil.ContextExecutor, singular bool, maybeFundingRate interface{}, mods queries.Applicator) error {
+	var slice []*FundingRate
+	var object *FundingRate
+
+	if singular {
+		object = maybeFundingRate.(*FundingRate)
+	} else {
+		slice = *maybeFundingRate.(*[]*FundingRate)
+	}
+
+	args := make([]interface{}, 0, 1)
+	if singular {
+		if object.R == nil {
+			object.R = &fundingRateR{}
+		}
+		args = append(args, object.ExchangeNameID)
+
+	} else {
+	Outer:
+		for _, obj := range slice {
+			if obj.R == nil {
+				obj.R = &fundingRateR{}
+			}
+
+			for _, a := range args {
+				if a == obj.ExchangeNameID {
+					continue Outer
+				}
+			}
+
+			args = append(args, obj.ExchangeNameID)
+
+		}
+	}
+
+	if len(args) == 0 {
+		return nil
+	}
+
+	query := NewQuery(qm.From(`exchange`), qm.WhereIn(`exchange.id in ?`, args...))
+	if mods != nil {
+		mods.Apply(query)
+	}
+
+	results, err := query.QueryContext(ctx, e)
+	if err != nil {
+		return errors.Wrap(err, "failed to eager load Exchange")
+	}
+
+	var resultSlice []*Exchange
+	if err = queries.Bind(results, &resultSlice); err != nil {
+		return errors.Wrap(err, "failed to bind eager loaded slice Exchange")
+	}
+
+	if err = results.Close(); err != nil {
+		return errors.Wrap(err, "failed to close results of eager load for exchange")
+	}
+	if err = results.Err(); err != nil {
+		return errors.Wrap(err, "error occurred during iteration of eager loaded relations for exchange")
+	}
+
+	if len(fundingRateAfterSelectHooks) != 0 {
+		for _, obj := range resultSlice {
+			if err := obj.doAfterSelectHooks(ctx, e); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(resultSlice) == 0 {
+		return nil
+	}
+
+	if singular {
+		foreign := resultSlice[0]
+		object.R.ExchangeName = foreign
+		if foreign.R == nil {
+			foreign.R = &exchangeR{}
+		}
+		foreign.R.ExchangeNameFundingRate = object
+		return nil
+	}
+
+	for _, local := range slice {
+		for _, foreign := range resultSlice {
+			if local.ExchangeNameID == foreign.ID {
+				local.R.ExchangeName = foreign
+				if foreign.R == nil {
+					foreign.R = &exchangeR{}
+				}
+				foreign.R.ExchangeNameFundingRate = local
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// SetExchangeName of the fundingRate to the related item.
+// Sets o.R.ExchangeName to related.
+// Adds o to related.R.ExchangeNameFundingRate.
+func (o *FundingRate) SetExchangeName(ctx context.Context, exec boil.ContextExecutor, insert bool, related *Exchange) error {
+	var err error
+	if insert {
+		if err = related.Insert(ctx, exec, boil.Infer()); err != nil {
+			return errors.Wrap(err, "failed to insert into foreign table")
+		}
+	}
+
+	updateQuery := fmt.Sprintf(
+		"UPDATE \"funding_rate\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, []string{"exchange_name_id"}),
+		strmangle.WhereClause("\"", "\"", 0, fundingRatePrimaryKeyColumns),
+	)
+	values := []interface{}{related.ID, o.ID}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, updateQuery)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	if _, err = exec.ExecContext(ctx, updateQuery, values...); err != nil {
+		return errors.Wrap(err, "failed to update local table")
+	}
+
+	o.ExchangeNameID = related.ID
+	if o.R == nil {
+		o.R = &fundingRateR{
+			ExchangeName: related,
+		}
+	} else {
+		o.R.ExchangeName = related
+	}
+
+	if related.R == nil {
+		related.R = &exchangeR{
+			ExchangeNameFundingRate: o,
+		}
+	} else {
+		related.R.ExchangeNameFundingRate = o
+	}
+
+	return nil
+}
+
+// FundingRates retrieves all the records using an executor.
+func FundingRates(mods ...qm.QueryMod) fundingRateQuery {
+	mods = append(mods, qm.From("\"funding_rate\""))
+	return fundingRateQuery{NewQuery(mods...)}
+}
+
+// FindFundingRate retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindFundingRate(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*FundingRate, error) {
+	fundingRateObj := &FundingRate{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"funding_rate\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, fundingRateObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from funding_rate")
+	}
+
+	return fundingRateObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *FundingRate) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no funding_rate provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(fundingRateColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	fundingRateInsertCacheMut.RLock()
+	cache, cached := fundingRateInsertCache[key]
+	fundingRateInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			fundingRateAllColumns,
+			fundingRateColumnsWithDefault,
+			fundingRateColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(fundingRateType, fundingRateMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(fundingRateType, fundingRateMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"funding_rate\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"funding_rate\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"funding_rate\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, fundingRatePrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into funding_rate")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for funding_rate")
+	}
+
+CacheNoHooks:
+	if !cached {
+		fundingRateInsertCacheMut.Lock()
+		fundingRateInsertCache[key] = cache
+		fundingRateInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the FundingRate.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *FundingRate) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	fundingRateUpdateCacheMut.RLock()
+	cache, cached := fundingRateUpdateCache[key]
+	fundingRateUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			fundingRateAllColumns,
+			fundingRatePrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update funding_rate, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"funding_rate\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, fundingRatePrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(fundingRateType, fundingRateMapping, append(wl, fundingRatePrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update funding_rate row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for funding_rate")
+	}
+
+	if !cached {
+		fundingRateUpdateCacheMut.Lock()
+		fundingRateUpdateCache[key] = cache
+		fundingRateUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q fundingRateQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for funding_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for funding_rate")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o FundingRateSlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fundingRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"funding_rate\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fundingRatePrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in fundingRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all fundingRate")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single FundingRate record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *FundingRate) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no FundingRate provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), fundingRatePrimaryKeyMapping)
+	sql := "DELETE FROM \"funding_rate\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from funding_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for funding_rate")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q fundingRateQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no fundingRateQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from funding_rate")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for funding_rate")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o FundingRateSlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(fundingRateBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fundingRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"funding_rate\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fundingRatePrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from fundingRate slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for funding_rate")
+	}
+
+	if len(fundingRateAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *FundingRate) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindFundingRate(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *FundingRateSlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := FundingRateSlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), fundingRatePrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"funding_rate\".* FROM \"funding_rate\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, fundingRatePrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in FundingRateSlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// FundingRateExists checks if the FundingRate row exists.
+func FundingRateExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"funding_rate\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if funding_rate exists")
+	}
+
+	return exists, nil
+}
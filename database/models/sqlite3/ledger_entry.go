@@ -0,0 +1,810 @@
+// Code generated by SQLBoiler 3.5.0-gct (https://github.com/thrasher-corp/sqlboiler). DO NOT EDIT.
+// This file is meant to be re-generated in place and/or deleted at any time.
+
+package sqlite3
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"reflect"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/thrasher-corp/sqlboiler/queries/qmhelper"
+	"github.com/thrasher-corp/sqlboiler/strmangle"
+)
+
+// LedgerEntry is an object representing the database table.
+type LedgerEntry struct {
+	ID           string `boil:"id" json:"id" toml:"id" yaml:"id"`
+	EntryKey     string `boil:"entry_key" json:"entry_key" toml:"entry_key" yaml:"entry_key"`
+	ExchangeName string `boil:"exchange_name" json:"exchange_name" toml:"exchange_name" yaml:"exchange_name"`
+	SubAccount   string `boil:"sub_account" json:"sub_account" toml:"sub_account" yaml:"sub_account"`
+	Asset        string `boil:"asset" json:"asset" toml:"asset" yaml:"asset"`
+	Kind         string `boil:"kind" json:"kind" toml:"kind" yaml:"kind"`
+	Reference    string `boil:"reference" json:"reference" toml:"reference" yaml:"reference"`
+	Currency     string `boil:"currency" json:"currency" toml:"currency" yaml:"currency"`
+	Amount       string `boil:"amount" json:"amount" toml:"amount" yaml:"amount"`
+	Occurred     string `boil:"occurred" json:"occurred" toml:"occurred" yaml:"occurred"`
+	Description  string `boil:"description" json:"description" toml:"description" yaml:"description"`
+
+	R *ledgerEntryR `boil:"-" json:"-" toml:"-" yaml:"-"`
+	L ledgerEntryL  `boil:"-" json:"-" toml:"-" yaml:"-"`
+}
+
+var LedgerEntryColumns = struct {
+	ID           string
+	EntryKey     string
+	ExchangeName string
+	SubAccount   string
+	Asset        string
+	Kind         string
+	Reference    string
+	Currency     string
+	Amount       string
+	Occurred     string
+	Description  string
+}{
+	ID:           "id",
+	EntryKey:     "entry_key",
+	ExchangeName: "exchange_name",
+	SubAccount:   "sub_account",
+	Asset:        "asset",
+	Kind:         "kind",
+	Reference:    "reference",
+	Currency:     "currency",
+	Amount:       "amount",
+	Occurred:     "occurred",
+	Description:  "description",
+}
+
+// Generated where
+
+var LedgerEntryWhere = struct {
+	ID           whereHelperstring
+	EntryKey     whereHelperstring
+	ExchangeName whereHelperstring
+	SubAccount   whereHelperstring
+	Asset        whereHelperstring
+	Kind         whereHelperstring
+	Reference    whereHelperstring
+	Currency     whereHelperstring
+	Amount       whereHelperstring
+	Occurred     whereHelperstring
+	Description  whereHelperstring
+}{
+	ID:           whereHelperstring{field: "\"ledger_entry\".\"id\""},
+	EntryKey:     whereHelperstring{field: "\"ledger_entry\".\"entry_key\""},
+	ExchangeName: whereHelperstring{field: "\"ledger_entry\".\"exchange_name\""},
+	SubAccount:   whereHelperstring{field: "\"ledger_entry\".\"sub_account\""},
+	Asset:        whereHelperstring{field: "\"ledger_entry\".\"asset\""},
+	Kind:         whereHelperstring{field: "\"ledger_entry\".\"kind\""},
+	Reference:    whereHelperstring{field: "\"ledger_entry\".\"reference\""},
+	Currency:     whereHelperstring{field: "\"ledger_entry\".\"currency\""},
+	Amount:       whereHelperstring{field: "\"ledger_entry\".\"amount\""},
+	Occurred:     whereHelperstring{field: "\"ledger_entry\".\"occurred\""},
+	Description:  whereHelperstring{field: "\"ledger_entry\".\"description\""},
+}
+
+// LedgerEntryRels is where relationship names are stored.
+var LedgerEntryRels = struct {
+}{}
+
+// ledgerEntryR is where relationships are stored.
+type ledgerEntryR struct {
+}
+
+// NewStruct creates a new relationship struct
+func (*ledgerEntryR) NewStruct() *ledgerEntryR {
+	return &ledgerEntryR{}
+}
+
+// ledgerEntryL is where Load methods for each relationship are stored.
+type ledgerEntryL struct{}
+
+var (
+	ledgerEntryAllColumns            = []string{"id", "entry_key", "exchange_name", "sub_account", "asset", "kind", "reference", "currency", "amount", "occurred", "description"}
+	ledgerEntryColumnsWithoutDefault = []string{"id", "entry_key", "exchange_name", "sub_account", "asset", "kind", "reference", "currency", "amount", "occurred", "description"}
+	ledgerEntryColumnsWithDefault    = []string{}
+	ledgerEntryPrimaryKeyColumns     = []string{"id"}
+)
+
+type (
+	// LedgerEntrySlice is an alias for a slice of pointers to LedgerEntry.
+	// This should generally be used opposed to []LedgerEntry.
+	LedgerEntrySlice []*LedgerEntry
+	// LedgerEntryHook is the signature for custom LedgerEntry hook methods
+	LedgerEntryHook func(context.Context, boil.ContextExecutor, *LedgerEntry) error
+
+	ledgerEntryQuery struct {
+		*queries.Query
+	}
+)
+
+// Cache for insert, update and upsert
+var (
+	ledgerEntryType                 = reflect.TypeOf(&LedgerEntry{})
+	ledgerEntryMapping              = queries.MakeStructMapping(ledgerEntryType)
+	ledgerEntryPrimaryKeyMapping, _ = queries.BindMapping(ledgerEntryType, ledgerEntryMapping, ledgerEntryPrimaryKeyColumns)
+	ledgerEntryInsertCacheMut       sync.RWMutex
+	ledgerEntryInsertCache          = make(map[string]insertCache)
+	ledgerEntryUpdateCacheMut       sync.RWMutex
+	ledgerEntryUpdateCache          = make(map[string]updateCache)
+	ledgerEntryUpsertCacheMut       sync.RWMutex
+	ledgerEntryUpsertCache          = make(map[string]insertCache)
+)
+
+var (
+	// Force time package dependency for automated UpdatedAt/CreatedAt.
+	_ = time.Second
+	// Force qmhelper dependency for where clause generation (which doesn't
+	// always happen)
+	_ = qmhelper.Where
+)
+
+var ledgerEntryBeforeInsertHooks []LedgerEntryHook
+var ledgerEntryBeforeUpdateHooks []LedgerEntryHook
+var ledgerEntryBeforeDeleteHooks []LedgerEntryHook
+var ledgerEntryBeforeUpsertHooks []LedgerEntryHook
+
+var ledgerEntryAfterInsertHooks []LedgerEntryHook
+var ledgerEntryAfterSelectHooks []LedgerEntryHook
+var ledgerEntryAfterUpdateHooks []LedgerEntryHook
+var ledgerEntryAfterDeleteHooks []LedgerEntryHook
+var ledgerEntryAfterUpsertHooks []LedgerEntryHook
+
+// doBeforeInsertHooks executes all "before insert" hooks.
+func (o *LedgerEntry) doBeforeInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryBeforeInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpdateHooks executes all "before Update" hooks.
+func (o *LedgerEntry) doBeforeUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryBeforeUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeDeleteHooks executes all "before Delete" hooks.
+func (o *LedgerEntry) doBeforeDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryBeforeDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doBeforeUpsertHooks executes all "before Upsert" hooks.
+func (o *LedgerEntry) doBeforeUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryBeforeUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterInsertHooks executes all "after Insert" hooks.
+func (o *LedgerEntry) doAfterInsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryAfterInsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterSelectHooks executes all "after Select" hooks.
+func (o *LedgerEntry) doAfterSelectHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryAfterSelectHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpdateHooks executes all "after Update" hooks.
+func (o *LedgerEntry) doAfterUpdateHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryAfterUpdateHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterDeleteHooks executes all "after Delete" hooks.
+func (o *LedgerEntry) doAfterDeleteHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryAfterDeleteHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// doAfterUpsertHooks executes all "after Upsert" hooks.
+func (o *LedgerEntry) doAfterUpsertHooks(ctx context.Context, exec boil.ContextExecutor) (err error) {
+	if boil.HooksAreSkipped(ctx) {
+		return nil
+	}
+
+	for _, hook := range ledgerEntryAfterUpsertHooks {
+		if err := hook(ctx, exec, o); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// AddLedgerEntryHook registers your hook function for all future operations.
+func AddLedgerEntryHook(hookPoint boil.HookPoint, ledgerEntryHook LedgerEntryHook) {
+	switch hookPoint {
+	case boil.BeforeInsertHook:
+		ledgerEntryBeforeInsertHooks = append(ledgerEntryBeforeInsertHooks, ledgerEntryHook)
+	case boil.BeforeUpdateHook:
+		ledgerEntryBeforeUpdateHooks = append(ledgerEntryBeforeUpdateHooks, ledgerEntryHook)
+	case boil.BeforeDeleteHook:
+		ledgerEntryBeforeDeleteHooks = append(ledgerEntryBeforeDeleteHooks, ledgerEntryHook)
+	case boil.BeforeUpsertHook:
+		ledgerEntryBeforeUpsertHooks = append(ledgerEntryBeforeUpsertHooks, ledgerEntryHook)
+	case boil.AfterInsertHook:
+		ledgerEntryAfterInsertHooks = append(ledgerEntryAfterInsertHooks, ledgerEntryHook)
+	case boil.AfterSelectHook:
+		ledgerEntryAfterSelectHooks = append(ledgerEntryAfterSelectHooks, ledgerEntryHook)
+	case boil.AfterUpdateHook:
+		ledgerEntryAfterUpdateHooks = append(ledgerEntryAfterUpdateHooks, ledgerEntryHook)
+	case boil.AfterDeleteHook:
+		ledgerEntryAfterDeleteHooks = append(ledgerEntryAfterDeleteHooks, ledgerEntryHook)
+	case boil.AfterUpsertHook:
+		ledgerEntryAfterUpsertHooks = append(ledgerEntryAfterUpsertHooks, ledgerEntryHook)
+	}
+}
+
+// One returns a single ledgerEntry record from the query.
+func (q ledgerEntryQuery) One(ctx context.Context, exec boil.ContextExecutor) (*LedgerEntry, error) {
+	o := &LedgerEntry{}
+
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Bind(ctx, exec, o)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: failed to execute a one query for ledger_entry")
+	}
+
+	if err := o.doAfterSelectHooks(ctx, exec); err != nil {
+		return o, err
+	}
+
+	return o, nil
+}
+
+// All returns all LedgerEntry records from the query.
+func (q ledgerEntryQuery) All(ctx context.Context, exec boil.ContextExecutor) (LedgerEntrySlice, error) {
+	var o []*LedgerEntry
+
+	err := q.Bind(ctx, exec, &o)
+	if err != nil {
+		return nil, errors.Wrap(err, "sqlite3: failed to assign all query results to LedgerEntry slice")
+	}
+
+	if len(ledgerEntryAfterSelectHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterSelectHooks(ctx, exec); err != nil {
+				return o, err
+			}
+		}
+	}
+
+	return o, nil
+}
+
+// Count returns the count of all LedgerEntry records in the query.
+func (q ledgerEntryQuery) Count(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to count ledger_entry rows")
+	}
+
+	return count, nil
+}
+
+// Exists checks if the row exists in the table.
+func (q ledgerEntryQuery) Exists(ctx context.Context, exec boil.ContextExecutor) (bool, error) {
+	var count int64
+
+	queries.SetSelect(q.Query, nil)
+	queries.SetCount(q.Query)
+	queries.SetLimit(q.Query, 1)
+
+	err := q.Query.QueryRowContext(ctx, exec).Scan(&count)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: failed to check if ledger_entry exists")
+	}
+
+	return count > 0, nil
+}
+
+// LedgerEntries retrieves all the records using an executor.
+func LedgerEntries(mods ...qm.QueryMod) ledgerEntryQuery {
+	mods = append(mods, qm.From("\"ledger_entry\""))
+	return ledgerEntryQuery{NewQuery(mods...)}
+}
+
+// FindLedgerEntry retrieves a single record by ID with an executor.
+// If selectCols is empty Find will return all columns.
+func FindLedgerEntry(ctx context.Context, exec boil.ContextExecutor, iD string, selectCols ...string) (*LedgerEntry, error) {
+	ledgerEntryObj := &LedgerEntry{}
+
+	sel := "*"
+	if len(selectCols) > 0 {
+		sel = strings.Join(strmangle.IdentQuoteSlice(dialect.LQ, dialect.RQ, selectCols), ",")
+	}
+	query := fmt.Sprintf(
+		"select %s from \"ledger_entry\" where \"id\"=?", sel,
+	)
+
+	q := queries.Raw(query, iD)
+
+	err := q.Bind(ctx, exec, ledgerEntryObj)
+	if err != nil {
+		if errors.Cause(err) == sql.ErrNoRows {
+			return nil, sql.ErrNoRows
+		}
+		return nil, errors.Wrap(err, "sqlite3: unable to select from ledger_entry")
+	}
+
+	return ledgerEntryObj, nil
+}
+
+// Insert a single record using an executor.
+// See boil.Columns.InsertColumnSet documentation to understand column list inference for inserts.
+func (o *LedgerEntry) Insert(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) error {
+	if o == nil {
+		return errors.New("sqlite3: no ledger_entry provided for insertion")
+	}
+
+	var err error
+
+	if err := o.doBeforeInsertHooks(ctx, exec); err != nil {
+		return err
+	}
+
+	nzDefaults := queries.NonZeroDefaultSet(ledgerEntryColumnsWithDefault, o)
+
+	key := makeCacheKey(columns, nzDefaults)
+	ledgerEntryInsertCacheMut.RLock()
+	cache, cached := ledgerEntryInsertCache[key]
+	ledgerEntryInsertCacheMut.RUnlock()
+
+	if !cached {
+		wl, returnColumns := columns.InsertColumnSet(
+			ledgerEntryAllColumns,
+			ledgerEntryColumnsWithDefault,
+			ledgerEntryColumnsWithoutDefault,
+			nzDefaults,
+		)
+
+		cache.valueMapping, err = queries.BindMapping(ledgerEntryType, ledgerEntryMapping, wl)
+		if err != nil {
+			return err
+		}
+		cache.retMapping, err = queries.BindMapping(ledgerEntryType, ledgerEntryMapping, returnColumns)
+		if err != nil {
+			return err
+		}
+		if len(wl) != 0 {
+			cache.query = fmt.Sprintf("INSERT INTO \"ledger_entry\" (\"%s\") %%sVALUES (%s)%%s", strings.Join(wl, "\",\""), strmangle.Placeholders(dialect.UseIndexPlaceholders, len(wl), 1, 1))
+		} else {
+			cache.query = "INSERT INTO \"ledger_entry\" () VALUES ()%s%s"
+		}
+
+		var queryOutput, queryReturning string
+
+		if len(cache.retMapping) != 0 {
+			cache.retQuery = fmt.Sprintf("SELECT \"%s\" FROM \"ledger_entry\" WHERE %s", strings.Join(returnColumns, "\",\""), strmangle.WhereClause("\"", "\"", 0, ledgerEntryPrimaryKeyColumns))
+		}
+
+		cache.query = fmt.Sprintf(cache.query, queryOutput, queryReturning)
+	}
+
+	value := reflect.Indirect(reflect.ValueOf(o))
+	vals := queries.ValuesFromMapping(value, cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, vals)
+	}
+
+	_, err = exec.ExecContext(ctx, cache.query, vals...)
+
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to insert into ledger_entry")
+	}
+
+	var identifierCols []interface{}
+
+	if len(cache.retMapping) == 0 {
+		goto CacheNoHooks
+	}
+
+	identifierCols = []interface{}{
+		o.ID,
+	}
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.retQuery)
+		fmt.Fprintln(boil.DebugWriter, identifierCols...)
+	}
+
+	err = exec.QueryRowContext(ctx, cache.retQuery, identifierCols...).Scan(queries.PtrsFromMapping(value, cache.retMapping)...)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to populate default values for ledger_entry")
+	}
+
+CacheNoHooks:
+	if !cached {
+		ledgerEntryInsertCacheMut.Lock()
+		ledgerEntryInsertCache[key] = cache
+		ledgerEntryInsertCacheMut.Unlock()
+	}
+
+	return o.doAfterInsertHooks(ctx, exec)
+}
+
+// Update uses an executor to update the LedgerEntry.
+// See boil.Columns.UpdateColumnSet documentation to understand column list inference for updates.
+// Update does not automatically update the record in case of default values. Use .Reload() to refresh the records.
+func (o *LedgerEntry) Update(ctx context.Context, exec boil.ContextExecutor, columns boil.Columns) (int64, error) {
+	var err error
+	if err = o.doBeforeUpdateHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+	key := makeCacheKey(columns, nil)
+	ledgerEntryUpdateCacheMut.RLock()
+	cache, cached := ledgerEntryUpdateCache[key]
+	ledgerEntryUpdateCacheMut.RUnlock()
+
+	if !cached {
+		wl := columns.UpdateColumnSet(
+			ledgerEntryAllColumns,
+			ledgerEntryPrimaryKeyColumns,
+		)
+
+		if len(wl) == 0 {
+			return 0, errors.New("sqlite3: unable to update ledger_entry, could not build whitelist")
+		}
+
+		cache.query = fmt.Sprintf("UPDATE \"ledger_entry\" SET %s WHERE %s",
+			strmangle.SetParamNames("\"", "\"", 0, wl),
+			strmangle.WhereClause("\"", "\"", 0, ledgerEntryPrimaryKeyColumns),
+		)
+		cache.valueMapping, err = queries.BindMapping(ledgerEntryType, ledgerEntryMapping, append(wl, ledgerEntryPrimaryKeyColumns...))
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	values := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), cache.valueMapping)
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, cache.query)
+		fmt.Fprintln(boil.DebugWriter, values)
+	}
+
+	var result sql.Result
+	result, err = exec.ExecContext(ctx, cache.query, values...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update ledger_entry row")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by update for ledger_entry")
+	}
+
+	if !cached {
+		ledgerEntryUpdateCacheMut.Lock()
+		ledgerEntryUpdateCache[key] = cache
+		ledgerEntryUpdateCacheMut.Unlock()
+	}
+
+	return rowsAff, o.doAfterUpdateHooks(ctx, exec)
+}
+
+// UpdateAll updates all rows with the specified column values.
+func (q ledgerEntryQuery) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	queries.SetUpdate(q.Query, cols)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all for ledger_entry")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected for ledger_entry")
+	}
+
+	return rowsAff, nil
+}
+
+// UpdateAll updates all rows with the specified column values, using an executor.
+func (o LedgerEntrySlice) UpdateAll(ctx context.Context, exec boil.ContextExecutor, cols M) (int64, error) {
+	ln := int64(len(o))
+	if ln == 0 {
+		return 0, nil
+	}
+
+	if len(cols) == 0 {
+		return 0, errors.New("sqlite3: update all requires at least one column argument")
+	}
+
+	colNames := make([]string, len(cols))
+	args := make([]interface{}, len(cols))
+
+	i := 0
+	for name, value := range cols {
+		colNames[i] = name
+		args[i] = value
+		i++
+	}
+
+	// Append all of the primary key values for each column
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ledgerEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := fmt.Sprintf("UPDATE \"ledger_entry\" SET %s WHERE %s",
+		strmangle.SetParamNames("\"", "\"", 0, colNames),
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, ledgerEntryPrimaryKeyColumns, len(o)))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to update all in ledgerEntry slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to retrieve rows affected all in update all ledgerEntry")
+	}
+	return rowsAff, nil
+}
+
+// Delete deletes a single LedgerEntry record with an executor.
+// Delete will match against the primary key column to find the record to delete.
+func (o *LedgerEntry) Delete(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if o == nil {
+		return 0, errors.New("sqlite3: no LedgerEntry provided for delete")
+	}
+
+	if err := o.doBeforeDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	args := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(o)), ledgerEntryPrimaryKeyMapping)
+	sql := "DELETE FROM \"ledger_entry\" WHERE \"id\"=?"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args...)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete from ledger_entry")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by delete for ledger_entry")
+	}
+
+	if err := o.doAfterDeleteHooks(ctx, exec); err != nil {
+		return 0, err
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all matching rows.
+func (q ledgerEntryQuery) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if q.Query == nil {
+		return 0, errors.New("sqlite3: no ledgerEntryQuery provided for delete all")
+	}
+
+	queries.SetDelete(q.Query)
+
+	result, err := q.Query.ExecContext(ctx, exec)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from ledger_entry")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for ledger_entry")
+	}
+
+	return rowsAff, nil
+}
+
+// DeleteAll deletes all rows in the slice, using an executor.
+func (o LedgerEntrySlice) DeleteAll(ctx context.Context, exec boil.ContextExecutor) (int64, error) {
+	if len(o) == 0 {
+		return 0, nil
+	}
+
+	if len(ledgerEntryBeforeDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doBeforeDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	var args []interface{}
+	for _, obj := range o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ledgerEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "DELETE FROM \"ledger_entry\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, ledgerEntryPrimaryKeyColumns, len(o))
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, args)
+	}
+
+	result, err := exec.ExecContext(ctx, sql, args...)
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: unable to delete all from ledgerEntry slice")
+	}
+
+	rowsAff, err := result.RowsAffected()
+	if err != nil {
+		return 0, errors.Wrap(err, "sqlite3: failed to get rows affected by deleteall for ledger_entry")
+	}
+
+	if len(ledgerEntryAfterDeleteHooks) != 0 {
+		for _, obj := range o {
+			if err := obj.doAfterDeleteHooks(ctx, exec); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	return rowsAff, nil
+}
+
+// Reload refetches the object from the database
+// using the primary keys with an executor.
+func (o *LedgerEntry) Reload(ctx context.Context, exec boil.ContextExecutor) error {
+	ret, err := FindLedgerEntry(ctx, exec, o.ID)
+	if err != nil {
+		return err
+	}
+
+	*o = *ret
+	return nil
+}
+
+// ReloadAll refetches every row with matching primary key column values
+// and overwrites the original object slice with the newly updated slice.
+func (o *LedgerEntrySlice) ReloadAll(ctx context.Context, exec boil.ContextExecutor) error {
+	if o == nil || len(*o) == 0 {
+		return nil
+	}
+
+	slice := LedgerEntrySlice{}
+	var args []interface{}
+	for _, obj := range *o {
+		pkeyArgs := queries.ValuesFromMapping(reflect.Indirect(reflect.ValueOf(obj)), ledgerEntryPrimaryKeyMapping)
+		args = append(args, pkeyArgs...)
+	}
+
+	sql := "SELECT \"ledger_entry\".* FROM \"ledger_entry\" WHERE " +
+		strmangle.WhereClauseRepeated(string(dialect.LQ), string(dialect.RQ), 0, ledgerEntryPrimaryKeyColumns, len(*o))
+
+	q := queries.Raw(sql, args...)
+
+	err := q.Bind(ctx, exec, &slice)
+	if err != nil {
+		return errors.Wrap(err, "sqlite3: unable to reload all in LedgerEntrySlice")
+	}
+
+	*o = slice
+
+	return nil
+}
+
+// LedgerEntryExists checks if the LedgerEntry row exists.
+func LedgerEntryExists(ctx context.Context, exec boil.ContextExecutor, iD string) (bool, error) {
+	var exists bool
+	sql := "select exists(select 1 from \"ledger_entry\" where \"id\"=? limit 1)"
+
+	if boil.DebugMode {
+		fmt.Fprintln(boil.DebugWriter, sql)
+		fmt.Fprintln(boil.DebugWriter, iD)
+	}
+
+	row := exec.QueryRowContext(ctx, sql, iD)
+
+	err := row.Scan(&exists)
+	if err != nil {
+		return false, errors.Wrap(err, "sqlite3: unable to check if ledger_entry exists")
+	}
+
+	return exists, nil
+}
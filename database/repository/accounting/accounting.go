@@ -0,0 +1,569 @@
+package accounting
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+	"github.com/volatiletech/null"
+)
+
+// Store saves accounting state changes to the database in a single
+// transaction so the stored events, lots and progress stay consistent
+func Store(c *Changes) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	if c.ReportingCurrency == "" {
+		return errReportingCurrencyNotSet
+	}
+
+	ctx := context.TODO()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Store tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = storeSQLite(ctx, tx, c)
+	} else {
+		err = storePostgres(ctx, tx, c)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func storeSQLite(ctx context.Context, tx *sql.Tx, c *Changes) error {
+	reporting := strings.ToUpper(c.ReportingCurrency)
+	for i := range c.Events {
+		id, err := newID(c.Events[i].ID)
+		if err != nil {
+			return err
+		}
+		var tempEvent = sqlite3.AccountingEvent{
+			ID:                id,
+			ReportingCurrency: reporting,
+			Occurred:          c.Events[i].Time.UTC().Format(time.RFC3339),
+			Kind:              c.Events[i].Kind,
+			ExchangeName:      c.Events[i].Exchange,
+			Asset:             strings.ToLower(c.Events[i].Asset),
+			Base:              strings.ToUpper(c.Events[i].Base),
+			Quote:             strings.ToUpper(c.Events[i].Quote),
+			OrderID:           c.Events[i].OrderID,
+			Side:              c.Events[i].Side,
+			Amount:            c.Events[i].Amount.String(),
+			Value:             c.Events[i].Value.String(),
+			RealisedPNL:       c.Events[i].RealisedPNL.String(),
+			Fees:              c.Events[i].Fees.String(),
+			Funding:           c.Events[i].Funding.String(),
+			Unmatched:         c.Events[i].Unmatched.String(),
+		}
+		if err = tempEvent.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	for i := range c.Books {
+		_, err := sqlite3.AccountingLots(
+			qm.Where("reporting_currency = ? AND exchange_name = ? AND currency = ?",
+				reporting, c.Books[i].Exchange, strings.ToUpper(c.Books[i].Currency))).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for j := range c.Books[i].Lots {
+			id, err := newID("")
+			if err != nil {
+				return err
+			}
+			lot := &c.Books[i].Lots[j]
+			var tempLot = sqlite3.AccountingLot{
+				ID:                id,
+				ReportingCurrency: reporting,
+				ExchangeName:      c.Books[i].Exchange,
+				Currency:          strings.ToUpper(c.Books[i].Currency),
+				Sequence:          int64(j),
+				Reference:         lot.Reference,
+				Acquired:          lot.Acquired.UTC().Format(time.RFC3339),
+				Amount:            lot.Amount.String(),
+				Cost:              lot.Cost.String(),
+			}
+			if err = tempLot.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range c.Orders {
+		id, err := newID("")
+		if err != nil {
+			return err
+		}
+		var tempOrder = sqlite3.AccountingOrder{
+			ID:       id,
+			OrderKey: c.Orders[i].Key,
+			Executed: c.Orders[i].Executed.String(),
+			Quote:    c.Orders[i].Quote.String(),
+			Fee:      c.Orders[i].Fee.String(),
+		}
+		if !c.Orders[i].Finished.IsZero() {
+			tempOrder.Finished.SetValid(c.Orders[i].Finished.UTC().Format(time.RFC3339))
+		}
+		// the unique constraint replaces the progress already stored
+		if err = tempOrder.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	if len(c.DeletedOrders) > 0 {
+		_, err := sqlite3.AccountingOrders(qm.WhereIn("order_key IN ?", toInterfaces(c.DeletedOrders)...)).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	for i := range c.Positions {
+		id, err := newID("")
+		if err != nil {
+			return err
+		}
+		var tempPosition = sqlite3.AccountingPosition{
+			ID:            id,
+			PositionKey:   c.Positions[i].Key,
+			ExchangeName:  c.Positions[i].Exchange,
+			Asset:         strings.ToLower(c.Positions[i].Asset),
+			Base:          strings.ToUpper(c.Positions[i].Base),
+			Quote:         strings.ToUpper(c.Positions[i].Quote),
+			Settlement:    strings.ToUpper(c.Positions[i].Settlement),
+			RealisedGross: c.Positions[i].RealisedGross.String(),
+			Fees:          c.Positions[i].Fees.String(),
+			Funding:       c.Positions[i].Funding.String(),
+		}
+		if err = tempPosition.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	if len(c.DeletedPositions) > 0 {
+		_, err := sqlite3.AccountingPositions(qm.WhereIn("position_key IN ?", toInterfaces(c.DeletedPositions)...)).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func storePostgres(ctx context.Context, tx *sql.Tx, c *Changes) error {
+	reporting := strings.ToUpper(c.ReportingCurrency)
+	for i := range c.Events {
+		id, err := newID(c.Events[i].ID)
+		if err != nil {
+			return err
+		}
+		var tempEvent = postgres.AccountingEvent{
+			ID:                id,
+			ReportingCurrency: reporting,
+			Occurred:          c.Events[i].Time.UTC(),
+			Kind:              c.Events[i].Kind,
+			ExchangeName:      c.Events[i].Exchange,
+			Asset:             strings.ToLower(c.Events[i].Asset),
+			Base:              strings.ToUpper(c.Events[i].Base),
+			Quote:             strings.ToUpper(c.Events[i].Quote),
+			OrderID:           c.Events[i].OrderID,
+			Side:              c.Events[i].Side,
+			Amount:            c.Events[i].Amount.String(),
+			Value:             c.Events[i].Value.String(),
+			RealisedPNL:       c.Events[i].RealisedPNL.String(),
+			Fees:              c.Events[i].Fees.String(),
+			Funding:           c.Events[i].Funding.String(),
+			Unmatched:         c.Events[i].Unmatched.String(),
+		}
+		if err = tempEvent.Insert(ctx, tx, boil.Infer()); err != nil {
+			return err
+		}
+	}
+	for i := range c.Books {
+		_, err := postgres.AccountingLots(
+			qm.Where("reporting_currency = ? AND exchange_name = ? AND currency = ?",
+				reporting, c.Books[i].Exchange, strings.ToUpper(c.Books[i].Currency))).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+		for j := range c.Books[i].Lots {
+			id, err := newID("")
+			if err != nil {
+				return err
+			}
+			lot := &c.Books[i].Lots[j]
+			var tempLot = postgres.AccountingLot{
+				ID:                id,
+				ReportingCurrency: reporting,
+				ExchangeName:      c.Books[i].Exchange,
+				Currency:          strings.ToUpper(c.Books[i].Currency),
+				Sequence:          j,
+				Reference:         lot.Reference,
+				Acquired:          lot.Acquired.UTC(),
+				Amount:            lot.Amount.String(),
+				Cost:              lot.Cost.String(),
+			}
+			if err = tempLot.Insert(ctx, tx, boil.Infer()); err != nil {
+				return err
+			}
+		}
+	}
+	for i := range c.Orders {
+		id, err := newID("")
+		if err != nil {
+			return err
+		}
+		var tempOrder = postgres.AccountingOrder{
+			ID:       id,
+			OrderKey: c.Orders[i].Key,
+			Executed: c.Orders[i].Executed.String(),
+			Quote:    c.Orders[i].Quote.String(),
+			Fee:      c.Orders[i].Fee.String(),
+		}
+		if !c.Orders[i].Finished.IsZero() {
+			tempOrder.Finished = null.TimeFrom(c.Orders[i].Finished.UTC())
+		}
+		err = tempOrder.Upsert(ctx, tx, true,
+			[]string{postgres.AccountingOrderColumns.OrderKey},
+			boil.Whitelist(postgres.AccountingOrderColumns.Executed,
+				postgres.AccountingOrderColumns.Quote,
+				postgres.AccountingOrderColumns.Fee,
+				postgres.AccountingOrderColumns.Finished),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.DeletedOrders) > 0 {
+		_, err := postgres.AccountingOrders(qm.WhereIn("order_key IN ?", toInterfaces(c.DeletedOrders)...)).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	for i := range c.Positions {
+		id, err := newID("")
+		if err != nil {
+			return err
+		}
+		var tempPosition = postgres.AccountingPosition{
+			ID:            id,
+			PositionKey:   c.Positions[i].Key,
+			ExchangeName:  c.Positions[i].Exchange,
+			Asset:         strings.ToLower(c.Positions[i].Asset),
+			Base:          strings.ToUpper(c.Positions[i].Base),
+			Quote:         strings.ToUpper(c.Positions[i].Quote),
+			Settlement:    strings.ToUpper(c.Positions[i].Settlement),
+			RealisedGross: c.Positions[i].RealisedGross.String(),
+			Fees:          c.Positions[i].Fees.String(),
+			Funding:       c.Positions[i].Funding.String(),
+		}
+		err = tempPosition.Upsert(ctx, tx, true,
+			[]string{postgres.AccountingPositionColumns.PositionKey},
+			boil.Whitelist(postgres.AccountingPositionColumns.RealisedGross,
+				postgres.AccountingPositionColumns.Fees,
+				postgres.AccountingPositionColumns.Funding),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+	if len(c.DeletedPositions) > 0 {
+		_, err := postgres.AccountingPositions(qm.WhereIn("position_key IN ?", toInterfaces(c.DeletedPositions)...)).DeleteAll(ctx, tx)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Load returns the stored accounting state of a reporting currency. Only the
+// latest maxEvents events are returned, in the order they occurred
+func Load(reportingCurrency string, maxEvents int) (s *State, err error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if reportingCurrency == "" {
+		return nil, errReportingCurrencyNotSet
+	}
+	reportingCurrency = strings.ToUpper(reportingCurrency)
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		s, err = loadSQLite(reportingCurrency, maxEvents)
+		if err != nil {
+			return nil, fmt.Errorf("accounting.Load loadSQLite %w", err)
+		}
+	} else {
+		s, err = loadPostgres(reportingCurrency, maxEvents)
+		if err != nil {
+			return nil, fmt.Errorf("accounting.Load loadPostgres %w", err)
+		}
+	}
+	return s, nil
+}
+
+func loadSQLite(reportingCurrency string, maxEvents int) (*State, error) {
+	ctx := context.TODO()
+	s := &State{}
+	events, err := sqlite3.AccountingEvents(
+		qm.Where("reporting_currency = ?", reportingCurrency),
+		qm.OrderBy("occurred DESC"),
+		qm.Limit(maxEvents)).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Events = make([]Event, len(events))
+	for i := range events {
+		occurred, err := time.Parse(time.RFC3339, events[i].Occurred)
+		if err != nil {
+			return nil, err
+		}
+		values, err := toDecimals(events[i].Amount, events[i].Value, events[i].RealisedPNL, events[i].Fees, events[i].Funding, events[i].Unmatched)
+		if err != nil {
+			return nil, fmt.Errorf("event %s %w", events[i].ID, err)
+		}
+		// events are selected latest first and returned in order
+		s.Events[len(events)-1-i] = Event{
+			ID:          events[i].ID,
+			Time:        occurred.UTC(),
+			Kind:        events[i].Kind,
+			Exchange:    events[i].ExchangeName,
+			Asset:       events[i].Asset,
+			Base:        events[i].Base,
+			Quote:       events[i].Quote,
+			OrderID:     events[i].OrderID,
+			Side:        events[i].Side,
+			Amount:      values[0],
+			Value:       values[1],
+			RealisedPNL: values[2],
+			Fees:        values[3],
+			Funding:     values[4],
+			Unmatched:   values[5],
+		}
+	}
+
+	lots, err := sqlite3.AccountingLots(
+		qm.Where("reporting_currency = ?", reportingCurrency),
+		qm.OrderBy("exchange_name, currency, sequence")).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lots {
+		acquired, err := time.Parse(time.RFC3339, lots[i].Acquired)
+		if err != nil {
+			return nil, err
+		}
+		values, err := toDecimals(lots[i].Amount, lots[i].Cost)
+		if err != nil {
+			return nil, fmt.Errorf("lot %s %w", lots[i].ID, err)
+		}
+		s.addLot(lots[i].ExchangeName, lots[i].Currency, Lot{
+			Reference: lots[i].Reference,
+			Acquired:  acquired.UTC(),
+			Amount:    values[0],
+			Cost:      values[1],
+		})
+	}
+
+	orders, err := sqlite3.AccountingOrders().All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Orders = make([]Order, len(orders))
+	for i := range orders {
+		values, err := toDecimals(orders[i].Executed, orders[i].Quote, orders[i].Fee)
+		if err != nil {
+			return nil, fmt.Errorf("order %s %w", orders[i].OrderKey, err)
+		}
+		s.Orders[i] = Order{Key: orders[i].OrderKey, Executed: values[0], Quote: values[1], Fee: values[2]}
+		if orders[i].Finished.Valid {
+			finished, err := time.Parse(time.RFC3339, orders[i].Finished.String)
+			if err != nil {
+				return nil, err
+			}
+			s.Orders[i].Finished = finished.UTC()
+		}
+	}
+
+	positions, err := sqlite3.AccountingPositions().All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Positions = make([]Position, len(positions))
+	for i := range positions {
+		values, err := toDecimals(positions[i].RealisedGross, positions[i].Fees, positions[i].Funding)
+		if err != nil {
+			return nil, fmt.Errorf("position %s %w", positions[i].PositionKey, err)
+		}
+		s.Positions[i] = Position{
+			Key:           positions[i].PositionKey,
+			Exchange:      positions[i].ExchangeName,
+			Asset:         positions[i].Asset,
+			Base:          positions[i].Base,
+			Quote:         positions[i].Quote,
+			Settlement:    positions[i].Settlement,
+			RealisedGross: values[0],
+			Fees:          values[1],
+			Funding:       values[2],
+		}
+	}
+	return s, nil
+}
+
+func loadPostgres(reportingCurrency string, maxEvents int) (*State, error) {
+	ctx := context.TODO()
+	s := &State{}
+	events, err := postgres.AccountingEvents(
+		qm.Where("reporting_currency = ?", reportingCurrency),
+		qm.OrderBy("occurred DESC"),
+		qm.Limit(maxEvents)).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Events = make([]Event, len(events))
+	for i := range events {
+		values, err := toDecimals(events[i].Amount, events[i].Value, events[i].RealisedPNL, events[i].Fees, events[i].Funding, events[i].Unmatched)
+		if err != nil {
+			return nil, fmt.Errorf("event %s %w", events[i].ID, err)
+		}
+		// events are selected latest first and returned in order
+		s.Events[len(events)-1-i] = Event{
+			ID:          events[i].ID,
+			Time:        events[i].Occurred.UTC(),
+			Kind:        events[i].Kind,
+			Exchange:    events[i].ExchangeName,
+			Asset:       events[i].Asset,
+			Base:        events[i].Base,
+			Quote:       events[i].Quote,
+			OrderID:     events[i].OrderID,
+			Side:        events[i].Side,
+			Amount:      values[0],
+			Value:       values[1],
+			RealisedPNL: values[2],
+			Fees:        values[3],
+			Funding:     values[4],
+			Unmatched:   values[5],
+		}
+	}
+
+	lots, err := postgres.AccountingLots(
+		qm.Where("reporting_currency = ?", reportingCurrency),
+		qm.OrderBy("exchange_name, currency, sequence")).All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	for i := range lots {
+		values, err := toDecimals(lots[i].Amount, lots[i].Cost)
+		if err != nil {
+			return nil, fmt.Errorf("lot %s %w", lots[i].ID, err)
+		}
+		s.addLot(lots[i].ExchangeName, lots[i].Currency, Lot{
+			Reference: lots[i].Reference,
+			Acquired:  lots[i].Acquired.UTC(),
+			Amount:    values[0],
+			Cost:      values[1],
+		})
+	}
+
+	orders, err := postgres.AccountingOrders().All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Orders = make([]Order, len(orders))
+	for i := range orders {
+		values, err := toDecimals(orders[i].Executed, orders[i].Quote, orders[i].Fee)
+		if err != nil {
+			return nil, fmt.Errorf("order %s %w", orders[i].OrderKey, err)
+		}
+		s.Orders[i] = Order{Key: orders[i].OrderKey, Executed: values[0], Quote: values[1], Fee: values[2]}
+		if orders[i].Finished.Valid {
+			s.Orders[i].Finished = orders[i].Finished.Time.UTC()
+		}
+	}
+
+	positions, err := postgres.AccountingPositions().All(ctx, database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	s.Positions = make([]Position, len(positions))
+	for i := range positions {
+		values, err := toDecimals(positions[i].RealisedGross, positions[i].Fees, positions[i].Funding)
+		if err != nil {
+			return nil, fmt.Errorf("position %s %w", positions[i].PositionKey, err)
+		}
+		s.Positions[i] = Position{
+			Key:           positions[i].PositionKey,
+			Exchange:      positions[i].ExchangeName,
+			Asset:         positions[i].Asset,
+			Base:          positions[i].Base,
+			Quote:         positions[i].Quote,
+			Settlement:    positions[i].Settlement,
+			RealisedGross: values[0],
+			Fees:          values[1],
+			Funding:       values[2],
+		}
+	}
+	return s, nil
+}
+
+// addLot appends a lot to the last book when it matches, lots are loaded
+// ordered by book
+func (s *State) addLot(exch, code string, lot Lot) {
+	if last := len(s.Books) - 1; last >= 0 && s.Books[last].Exchange == exch && s.Books[last].Currency == code {
+		s.Books[last].Lots = append(s.Books[last].Lots, lot)
+		return
+	}
+	s.Books = append(s.Books, Book{Exchange: exch, Currency: code, Lots: []Lot{lot}})
+}
+
+// newID returns the ID when set, otherwise a new UUID
+func newID(id string) (string, error) {
+	if id != "" {
+		return id, nil
+	}
+	freshUUID, err := uuid.NewV4()
+	if err != nil {
+		return "", err
+	}
+	return freshUUID.String(), nil
+}
+
+// toDecimals parses stored decimal text
+func toDecimals(values ...string) ([]decimal.Decimal, error) {
+	resp := make([]decimal.Decimal, len(values))
+	for i := range values {
+		d, err := decimal.NewFromString(values[i])
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = d
+	}
+	return resp, nil
+}
+
+func toInterfaces(values []string) []interface{} {
+	resp := make([]interface{}, len(values))
+	for i := range values {
+		resp[i] = values[i]
+	}
+	return resp
+}
@@ -0,0 +1,163 @@
+package accounting
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestAccountingState(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			accountingSQLTester(t)
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func accountingSQLTester(t *testing.T) {
+	t.Helper()
+	err := Store(&Changes{})
+	if !errors.Is(err, errReportingCurrencyNotSet) {
+		t.Fatalf("received '%v', expected '%v'", err, errReportingCurrencyNotSet)
+	}
+
+	opened := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	err = Store(&Changes{
+		ReportingCurrency: "usd",
+		Events: []Event{
+			{Time: opened, Kind: "trade", Exchange: "one", Asset: "spot", Base: "btc", Quote: "usdt", OrderID: "1", Side: "BUY", Amount: decimal.RequireFromString("1"), Value: decimal.RequireFromString("20000")},
+			{Time: opened.Add(time.Hour), Kind: "trade", Exchange: "one", Asset: "spot", Base: "btc", Quote: "usdt", OrderID: "2", Side: "SELL", Amount: decimal.RequireFromString("0.5"), Value: decimal.RequireFromString("11000"), RealisedPNL: decimal.RequireFromString("999.9"), Fees: decimal.RequireFromString("0.1")},
+			{Time: opened.Add(time.Hour * 2), Kind: "funding", Exchange: "two", Asset: "perpetualswap", Base: "eth", Quote: "usdt", Funding: decimal.RequireFromString("-0.3")},
+		},
+		Books: []Book{
+			{Exchange: "one", Currency: "btc", Lots: []Lot{
+				{Reference: "1", Acquired: opened, Amount: decimal.RequireFromString("0.25"), Cost: decimal.RequireFromString("5000")},
+				{Reference: "3", Acquired: opened, Amount: decimal.RequireFromString("0.25"), Cost: decimal.RequireFromString("5500")},
+			}},
+		},
+		Orders: []Order{
+			{Key: "one|1", Executed: decimal.RequireFromString("1"), Quote: decimal.RequireFromString("20000"), Finished: opened},
+			{Key: "one|2", Executed: decimal.RequireFromString("0.25"), Quote: decimal.RequireFromString("5500")},
+		},
+		Positions: []Position{
+			{Key: "two|perpetualswap|ETH-USDT|1", Exchange: "two", Asset: "perpetualswap", Base: "eth", Quote: "usdt", Settlement: "usdt", Funding: decimal.RequireFromString("-0.3")},
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	// books replace their stored lots and progress replaces or deletes the
+	// stored progress
+	err = Store(&Changes{
+		ReportingCurrency: "USD",
+		Books: []Book{
+			{Exchange: "one", Currency: "BTC", Lots: []Lot{
+				{Reference: "3", Acquired: opened, Amount: decimal.RequireFromString("0.2"), Cost: decimal.RequireFromString("4400")},
+			}},
+		},
+		Orders:           []Order{{Key: "one|2", Executed: decimal.RequireFromString("0.5"), Quote: decimal.RequireFromString("11000"), Fee: decimal.RequireFromString("0.1"), Finished: opened.Add(time.Hour)}},
+		DeletedOrders:    []string{"one|1"},
+		DeletedPositions: []string{"two|perpetualswap|ETH-USDT|1"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	_, err = Load("", 10)
+	if !errors.Is(err, errReportingCurrencyNotSet) {
+		t.Fatalf("received '%v', expected '%v'", err, errReportingCurrencyNotSet)
+	}
+	s, err := Load("usd", 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(s.Events) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(s.Events), 2)
+	}
+	if s.Events[0].OrderID != "2" || !s.Events[0].RealisedPNL.Equal(decimal.RequireFromString("999.9")) || !s.Events[0].Time.Equal(opened.Add(time.Hour)) {
+		t.Errorf("received '%+v', expected the sell event", s.Events[0])
+	}
+	if s.Events[1].Kind != "funding" || !s.Events[1].Funding.Equal(decimal.RequireFromString("-0.3")) {
+		t.Errorf("received '%+v', expected the funding event", s.Events[1])
+	}
+	if len(s.Books) != 1 || len(s.Books[0].Lots) != 1 || s.Books[0].Currency != "BTC" ||
+		!s.Books[0].Lots[0].Cost.Equal(decimal.RequireFromString("4400")) {
+		t.Fatalf("received '%+v', expected the replaced BTC book", s.Books)
+	}
+	if len(s.Orders) != 1 || s.Orders[0].Key != "one|2" || !s.Orders[0].Fee.Equal(decimal.RequireFromString("0.1")) ||
+		!s.Orders[0].Finished.Equal(opened.Add(time.Hour)) {
+		t.Errorf("received '%+v', expected the updated order", s.Orders)
+	}
+	if len(s.Positions) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(s.Positions), 0)
+	}
+
+	s, err = Load("EUR", 10)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(s.Events) != 0 || len(s.Books) != 0 {
+		t.Errorf("received '%+v', expected no events or books in another reporting currency", s)
+	}
+}
@@ -0,0 +1,91 @@
+package accounting
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var errReportingCurrencyNotSet = errors.New("reporting currency not set")
+
+// Event is a realised PNL event of the accounting manager in its simplest db
+// friendly form. Values are held in the reporting currency as exact decimal
+// text
+type Event struct {
+	ID          string
+	Time        time.Time
+	Kind        string
+	Exchange    string
+	Asset       string
+	Base        string
+	Quote       string
+	OrderID     string
+	Side        string
+	Amount      decimal.Decimal
+	Value       decimal.Decimal
+	RealisedPNL decimal.Decimal
+	Fees        decimal.Decimal
+	Funding     decimal.Decimal
+	Unmatched   decimal.Decimal
+}
+
+// Book is the open lots of a currency held on an exchange in acquisition
+// order. Costs are held in the reporting currency
+type Book struct {
+	Exchange string
+	Currency string
+	Lots     []Lot
+}
+
+// Lot is an open amount of a book and the cost it was acquired at
+type Lot struct {
+	Reference string
+	Acquired  time.Time
+	Amount    decimal.Decimal
+	Cost      decimal.Decimal
+}
+
+// Order is the cumulative execution of a spot order which has already been
+// booked. Finished is zero while the order is active
+type Order struct {
+	Key      string
+	Executed decimal.Decimal
+	Quote    decimal.Decimal
+	Fee      decimal.Decimal
+	Finished time.Time
+}
+
+// Position is the realised PNL, fees and funding of a futures position which
+// have already been recorded, in the settlement currency
+type Position struct {
+	Key           string
+	Exchange      string
+	Asset         string
+	Base          string
+	Quote         string
+	Settlement    string
+	RealisedGross decimal.Decimal
+	Fees          decimal.Decimal
+	Funding       decimal.Decimal
+}
+
+// Changes are the accounting state changes to store in a single transaction.
+// Books replace all stored lots of their exchange and currency
+type Changes struct {
+	ReportingCurrency string
+	Events            []Event
+	Books             []Book
+	Orders            []Order
+	DeletedOrders     []string
+	Positions         []Position
+	DeletedPositions  []string
+}
+
+// State is the stored accounting state of a reporting currency
+type State struct {
+	Events    []Event
+	Books     []Book
+	Orders    []Order
+	Positions []Position
+}
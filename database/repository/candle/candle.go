@@ -362,3 +362,67 @@ func InsertFromCSV(exchangeName, base, quote string, interval int64, asset, file
 
 	return Insert(tempCandle)
 }
+
+// ListSeries returns every stored candle series along with the timestamp of
+// its oldest candle
+func ListSeries() ([]SeriesSummary, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	rows, err := database.DB.SQL.QueryContext(context.TODO(), `SELECT exchange.name, candle.base, candle.quote, candle.interval, candle.asset, MIN(candle.timestamp)
+		FROM candle INNER JOIN exchange ON candle.exchange_name_id = exchange.id
+		GROUP BY exchange.name, candle.base, candle.quote, candle.interval, candle.asset`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	isSQLite := repository.GetSQLDialect() == database.DBSQLite3
+	var resp []SeriesSummary
+	for rows.Next() {
+		var s SeriesSummary
+		if isSQLite {
+			var oldest string
+			err = rows.Scan(&s.Exchange, &s.Base, &s.Quote, &s.Interval, &s.Asset, &oldest)
+			if err == nil {
+				s.Oldest, err = time.Parse(time.RFC3339, oldest)
+			}
+		} else {
+			err = rows.Scan(&s.Exchange, &s.Base, &s.Quote, &s.Interval, &s.Asset, &s.Oldest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.Oldest = s.Oldest.UTC()
+		resp = append(resp, s)
+	}
+	return resp, rows.Err()
+}
+
+// DeleteInRange removes all candles for a series with timestamps from start
+// up to but excluding end
+func DeleteInRange(exchangeName, base, quote string, interval int64, asset string, start, end time.Time) (int64, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	if exchangeName == "" || base == "" || quote == "" || asset == "" || interval <= 0 {
+		return 0, errInvalidInput
+	}
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return 0, err
+	}
+	queries := []qm.QueryMod{
+		qm.Where("base = ?", strings.ToUpper(base)),
+		qm.Where("quote = ?", strings.ToUpper(quote)),
+		qm.Where("interval = ?", interval),
+		qm.Where("asset = ?", strings.ToLower(asset)),
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+	}
+	ctx := context.TODO()
+	if repository.GetSQLDialect() == database.DBSQLite3 {
+		queries = append(queries, qm.Where("timestamp >= ? and timestamp < ?", start.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339)))
+		return modelSQLite.Candles(queries...).DeleteAll(ctx, database.DB.SQL)
+	}
+	queries = append(queries, qm.Where("timestamp >= ? and timestamp < ?", start.UTC(), end.UTC()))
+	return modelPSQL.Candles(queries...).DeleteAll(ctx, database.DB.SQL)
+}
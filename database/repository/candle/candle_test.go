@@ -307,3 +307,83 @@ func genOHCLVData() (out Item, err error) {
 
 	return out, nil
 }
+
+func TestListSeriesAndDeleteInRange(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+		seedDB func(includeOHLCVData bool) error
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+			seedDB: seedDB,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./retentiondb"},
+			},
+			seedDB: seedDB,
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			exchange.ResetExchangeCache()
+			if err = test.seedDB(true); err != nil {
+				t.Fatal(err)
+			}
+
+			series, err := ListSeries()
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			start := time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC)
+			if len(series) != 1 || !series[0].Oldest.Equal(start) || series[0].Interval != 86400 || series[0].Exchange != testExchanges[0].Name {
+				t.Fatalf("received '%+v', expected a single series starting at %v", series, start)
+			}
+
+			_, err = DeleteInRange("", "BTC", "USDT", 86400, "spot", start, start)
+			if !errors.Is(err, errInvalidInput) {
+				t.Errorf("received '%v', expected '%v'", err, errInvalidInput)
+			}
+
+			cutoff := time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC)
+			deleted, err := DeleteInRange(testExchanges[0].Name, "BTC", "USDT", 86400, "spot", start, cutoff)
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			if deleted != 31 {
+				t.Errorf("received '%v', expected '%v'", deleted, 31)
+			}
+
+			series, err = ListSeries()
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			if len(series) != 1 || !series[0].Oldest.Equal(cutoff) {
+				t.Errorf("received '%+v', expected a single series starting at %v", series, cutoff)
+			}
+
+			_, err = DeleteInRange(testExchanges[0].Name, "BTC", "USDT", 86400, "spot", cutoff, start.AddDate(1, 0, 0))
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
@@ -35,3 +35,13 @@ type Candle struct {
 	ValidationJobID  string
 	ValidationIssues string
 }
+
+// SeriesSummary describes a stored candle series
+type SeriesSummary struct {
+	Exchange string
+	Base     string
+	Quote    string
+	Interval int64
+	Asset    string
+	Oldest   time.Time
+}
@@ -0,0 +1,242 @@
+package forexrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Insert saves daily forex rates to the database. Dates are stored as the
+// start of their UTC day and a rate which has already been stored for the
+// same currencies and day is replaced
+func Insert(rates ...Data) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	for i := range rates {
+		if rates[i].Base == "" || rates[i].Quote == "" || rates[i].Date.IsZero() || rates[i].Rate <= 0 {
+			return fmt.Errorf("%w %+v", errInvalidRate, rates[i])
+		}
+	}
+
+	ctx := context.TODO()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = insertSQLite(ctx, tx, rates...)
+	} else {
+		err = insertPostgres(ctx, tx, rates...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	for i := range rates {
+		if rates[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempRate = sqlite3.ForexRate{
+			ID:     rates[i].ID,
+			Base:   strings.ToUpper(rates[i].Base),
+			Quote:  strings.ToUpper(rates[i].Quote),
+			Rate:   rates[i].Rate,
+			Date:   common.TruncateDay(rates[i].Date).Format(time.RFC3339),
+			Source: rates[i].Source,
+		}
+		// the unique constraint replaces any rate already stored for the day
+		err := tempRate.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	for i := range rates {
+		if rates[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempRate = postgres.ForexRate{
+			ID:     rates[i].ID,
+			Base:   strings.ToUpper(rates[i].Base),
+			Quote:  strings.ToUpper(rates[i].Quote),
+			Rate:   rates[i].Rate,
+			Date:   common.TruncateDay(rates[i].Date),
+			Source: rates[i].Source,
+		}
+		err := tempRate.Upsert(ctx, tx, true,
+			[]string{postgres.ForexRateColumns.Base, postgres.ForexRateColumns.Quote, postgres.ForexRateColumns.Date},
+			boil.Whitelist(postgres.ForexRateColumns.Rate, postgres.ForexRateColumns.Source),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInRange returns all stored forex rates to convert base into quote
+// between the start and end dates inclusive, ordered by date
+func GetInRange(base, quote string, startDate, endDate time.Time) (resp []Data, err error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		resp, err = getInRangeSQLite(base, quote, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("forexrate.GetInRange getInRangeSQLite %w", err)
+		}
+	} else {
+		resp, err = getInRangePostgres(base, quote, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("forexrate.GetInRange getInRangePostgres %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func getInRangeSQLite(base, quote string, startDate, endDate time.Time) ([]Data, error) {
+	result, err := sqlite3.ForexRates(
+		qm.Where("base = ? AND quote = ?", strings.ToUpper(base), strings.ToUpper(quote)),
+		qm.Where("date >= ? AND date <= ?",
+			common.TruncateDay(startDate).Format(time.RFC3339),
+			endDate.UTC().Format(time.RFC3339)),
+		qm.OrderBy("date")).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		date, err := time.Parse(time.RFC3339, result[i].Date)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Data{
+			ID:     result[i].ID,
+			Base:   result[i].Base,
+			Quote:  result[i].Quote,
+			Rate:   result[i].Rate,
+			Date:   date.UTC(),
+			Source: result[i].Source,
+		}
+	}
+	return resp, nil
+}
+
+func getInRangePostgres(base, quote string, startDate, endDate time.Time) ([]Data, error) {
+	result, err := postgres.ForexRates(
+		qm.Where("base = ? AND quote = ?", strings.ToUpper(base), strings.ToUpper(quote)),
+		qm.Where("date >= ? AND date <= ?", common.TruncateDay(startDate), endDate.UTC()),
+		qm.OrderBy("date")).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		resp[i] = Data{
+			ID:     result[i].ID,
+			Base:   result[i].Base,
+			Quote:  result[i].Quote,
+			Rate:   result[i].Rate,
+			Date:   result[i].Date.UTC(),
+			Source: result[i].Source,
+		}
+	}
+	return resp, nil
+}
+
+// LoadHistoricalRates retrieves stored rates for the currency package,
+// it satisfies currency.HistoricalRateLoader
+func LoadHistoricalRates(from, to currency.Code, start, end time.Time) ([]currency.HistoricalRate, error) {
+	rates, err := GetInRange(from.String(), to.String(), start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]currency.HistoricalRate, len(rates))
+	for i := range rates {
+		resp[i] = currency.HistoricalRate{
+			From: from,
+			To:   to,
+			Date: rates[i].Date,
+			Rate: rates[i].Rate,
+		}
+	}
+	return resp, nil
+}
+
+// InsertFromCSV loads a CSV file of daily rates and inserts them into the
+// database. See currency.ReadHistoricalRatesCSV for the file format
+func InsertFromCSV(file, source string) (uint64, error) {
+	csvFile, err := os.Open(file)
+	if err != nil {
+		return 0, err
+	}
+	defer func() {
+		err = csvFile.Close()
+		if err != nil {
+			log.Errorln(log.DatabaseMgr, err)
+		}
+	}()
+
+	rates, err := currency.ReadHistoricalRatesCSV(csvFile)
+	if err != nil {
+		return 0, err
+	}
+	data := make([]Data, len(rates))
+	for i := range rates {
+		data[i] = Data{
+			Base:   rates[i].From.String(),
+			Quote:  rates[i].To.String(),
+			Rate:   rates[i].Rate,
+			Date:   rates[i].Date,
+			Source: source,
+		}
+	}
+	err = Insert(data...)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(len(data)), nil
+}
@@ -0,0 +1,167 @@
+package forexrate
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestForexRates(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			forexRateSQLTester(t)
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func forexRateSQLTester(t *testing.T) {
+	t.Helper()
+	err := Insert(Data{Base: currency.EUR.String(), Quote: currency.USD.String(), Date: time.Now()})
+	if !errors.Is(err, errInvalidRate) {
+		t.Fatalf("received '%v', expected '%v'", err, errInvalidRate)
+	}
+
+	firstDay := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	rates := make([]Data, 10)
+	for i := range rates {
+		rates[i] = Data{
+			Base:  currency.EUR.String(),
+			Quote: currency.USD.String(),
+			Rate:  1 + float64(i)/100,
+			// dates are stored as the start of their day
+			Date:   firstDay.AddDate(0, 0, i).Add(time.Hour * 13),
+			Source: "test",
+		}
+	}
+	err = Insert(rates...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	// stored rates are replaced
+	err = Insert(Data{
+		Base:   currency.EUR.String(),
+		Quote:  currency.USD.String(),
+		Rate:   2,
+		Date:   firstDay.AddDate(0, 0, 4),
+		Source: "replaced",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	resp, err := GetInRange(currency.EUR.String(), currency.USD.String(), firstDay, firstDay.AddDate(0, 0, 4))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 5 {
+		t.Fatalf("received '%v', expected '%v'", len(resp), 5)
+	}
+	if !resp[4].Date.Equal(firstDay.AddDate(0, 0, 4)) || resp[4].Rate != 2 || resp[4].Source != "replaced" {
+		t.Errorf("received '%+v', expected replaced rate 2 on %v", resp[4], firstDay.AddDate(0, 0, 4))
+	}
+
+	loaded, err := LoadHistoricalRates(currency.EUR, currency.USD, firstDay.AddDate(0, 0, 8), firstDay.AddDate(0, 0, 20))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(loaded) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(loaded), 2)
+	}
+	if !loaded[1].From.Equal(currency.EUR) || !loaded[1].To.Equal(currency.USD) || loaded[1].Rate != 1.09 {
+		t.Errorf("received '%+v', expected EUR to USD rate 1.09", loaded[1])
+	}
+
+	resp, err = GetInRange(currency.USD.String(), currency.EUR.String(), firstDay, firstDay.AddDate(0, 0, 10))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(resp), 0)
+	}
+
+	_, err = InsertFromCSV(filepath.Join(testhelpers.TempDir, "missing.csv"), "csv")
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received '%v', expected '%v'", err, os.ErrNotExist)
+	}
+	csvPath := filepath.Join(testhelpers.TempDir, "rates.csv")
+	err = os.WriteFile(csvPath, []byte("date,from,to,rate\n2021-01-01,USD,JPY,103.2\n2021-01-04,USD,JPY,103.1\n"), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inserted, err := InsertFromCSV(csvPath, "csv")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if inserted != 2 {
+		t.Errorf("received '%v', expected '%v'", inserted, 2)
+	}
+	resp, err = GetInRange(currency.USD.String(), currency.JPY.String(), firstDay, firstDay.AddDate(0, 0, 10))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 2 || resp[1].Rate != 103.1 || resp[1].Source != "csv" {
+		t.Errorf("received '%+v', expected two csv rates", resp)
+	}
+}
@@ -0,0 +1,20 @@
+package forexrate
+
+import (
+	"errors"
+	"time"
+)
+
+var errInvalidRate = errors.New("invalid forex rate, cannot insert")
+
+// Data defines a daily historical foreign exchange rate in its simplest db
+// friendly form. Rate is the amount of quote currency for one unit of base
+// currency
+type Data struct {
+	ID     string
+	Base   string
+	Quote  string
+	Rate   float64
+	Date   time.Time
+	Source string
+}
@@ -0,0 +1,242 @@
+package fundingrate
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Insert saves funding rates to the database, rates which have already been
+// stored are ignored
+func Insert(rates ...Data) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	for i := range rates {
+		if rates[i].ExchangeNameID == "" && rates[i].Exchange != "" {
+			exchangeUUID, err := exchange.UUIDByName(rates[i].Exchange)
+			if err != nil {
+				return err
+			}
+			rates[i].ExchangeNameID = exchangeUUID.String()
+		} else if rates[i].ExchangeNameID == "" && rates[i].Exchange == "" {
+			return errExchangeNotSet
+		}
+	}
+
+	ctx := context.TODO()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = insertSQLite(ctx, tx, rates...)
+	} else {
+		err = insertPostgres(ctx, tx, rates...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	for i := range rates {
+		if rates[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempRate = sqlite3.FundingRate{
+			ID:             rates[i].ID,
+			ExchangeNameID: rates[i].ExchangeNameID,
+			Asset:          strings.ToLower(rates[i].AssetType),
+			Base:           strings.ToUpper(rates[i].Base),
+			Quote:          strings.ToUpper(rates[i].Quote),
+			Rate:           rates[i].Rate,
+			Timestamp:      rates[i].Timestamp.UTC().Format(time.RFC3339),
+		}
+		err := tempRate.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, rates ...Data) error {
+	for i := range rates {
+		if rates[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			rates[i].ID = freshUUID.String()
+		}
+		var tempRate = postgres.FundingRate{
+			ID:             rates[i].ID,
+			ExchangeNameID: rates[i].ExchangeNameID,
+			Asset:          strings.ToLower(rates[i].AssetType),
+			Base:           strings.ToUpper(rates[i].Base),
+			Quote:          strings.ToUpper(rates[i].Quote),
+			Rate:           rates[i].Rate,
+			Timestamp:      rates[i].Timestamp.UTC(),
+		}
+		err := tempRate.Upsert(ctx, tx, false, nil, boil.Infer(), boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInRange returns all funding rates for an exchange's pair between the
+// start and end dates inclusive, ordered by time
+func GetInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (resp []Data, err error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		resp, err = getSQLite(exchangeName, assetType, base, quote, func(mods []qm.QueryMod) []qm.QueryMod {
+			return append(mods,
+				qm.Where("timestamp >= ? AND timestamp <= ?",
+					startDate.UTC().Format(time.RFC3339),
+					endDate.UTC().Format(time.RFC3339)),
+				qm.OrderBy("timestamp"))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fundingrate.GetInRange getSQLite %w", err)
+		}
+	} else {
+		resp, err = getPostgres(exchangeName, assetType, base, quote, func(mods []qm.QueryMod) []qm.QueryMod {
+			return append(mods,
+				qm.Where("timestamp >= ? AND timestamp <= ?", startDate.UTC(), endDate.UTC()),
+				qm.OrderBy("timestamp"))
+		})
+		if err != nil {
+			return nil, fmt.Errorf("fundingrate.GetInRange getPostgres %w", err)
+		}
+	}
+	return resp, nil
+}
+
+// GetLatest returns the most recent stored funding rate for an exchange's
+// pair. sql.ErrNoRows is returned when no rates have been stored
+func GetLatest(exchangeName, assetType, base, quote string) (Data, error) {
+	if database.DB.SQL == nil {
+		return Data{}, database.ErrDatabaseSupportDisabled
+	}
+	latest := func(mods []qm.QueryMod) []qm.QueryMod {
+		return append(mods, qm.OrderBy("timestamp DESC"), qm.Limit(1))
+	}
+	var resp []Data
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		resp, err = getSQLite(exchangeName, assetType, base, quote, latest)
+		if err != nil {
+			return Data{}, fmt.Errorf("fundingrate.GetLatest getSQLite %w", err)
+		}
+	} else {
+		resp, err = getPostgres(exchangeName, assetType, base, quote, latest)
+		if err != nil {
+			return Data{}, fmt.Errorf("fundingrate.GetLatest getPostgres %w", err)
+		}
+	}
+	if len(resp) == 0 {
+		return Data{}, sql.ErrNoRows
+	}
+	return resp[0], nil
+}
+
+// pairMods returns the query mods selecting a single exchange pair, extended
+// by the supplied function to add any range and ordering
+func pairMods(exchangeUUID uuid.UUID, assetType, base, quote string, extend func([]qm.QueryMod) []qm.QueryMod) []qm.QueryMod {
+	return extend([]qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.Where("asset = ?", strings.ToLower(assetType)),
+		qm.Where("base = ?", strings.ToUpper(base)),
+		qm.Where("quote = ?", strings.ToUpper(quote)),
+	})
+}
+
+func getSQLite(exchangeName, assetType, base, quote string, extend func([]qm.QueryMod) []qm.QueryMod) ([]Data, error) {
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := sqlite3.FundingRates(pairMods(exchangeUUID, assetType, base, quote, extend)...).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		ts, err := time.Parse(time.RFC3339, result[i].Timestamp)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Data{
+			ID:             result[i].ID,
+			Exchange:       strings.ToLower(exchangeName),
+			ExchangeNameID: result[i].ExchangeNameID,
+			AssetType:      result[i].Asset,
+			Base:           result[i].Base,
+			Quote:          result[i].Quote,
+			Rate:           result[i].Rate,
+			Timestamp:      ts.UTC(),
+		}
+	}
+	return resp, nil
+}
+
+func getPostgres(exchangeName, assetType, base, quote string, extend func([]qm.QueryMod) []qm.QueryMod) ([]Data, error) {
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	result, err := postgres.FundingRates(pairMods(exchangeUUID, assetType, base, quote, extend)...).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		resp[i] = Data{
+			ID:             result[i].ID,
+			Exchange:       strings.ToLower(exchangeName),
+			ExchangeNameID: result[i].ExchangeNameID,
+			AssetType:      result[i].Asset,
+			Base:           result[i].Base,
+			Quote:          result[i].Quote,
+			Rate:           result[i].Rate,
+			Timestamp:      result[i].Timestamp.UTC(),
+		}
+	}
+	return resp, nil
+}
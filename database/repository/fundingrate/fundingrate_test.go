@@ -0,0 +1,166 @@
+package fundingrate
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	verbose       = false
+	testExchanges = []exchange.Details{
+		{
+			Name: "one",
+		},
+		{
+			Name: "two",
+		},
+	}
+)
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestFundingRates(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = exchange.InsertMany(testExchanges); err != nil {
+				t.Fatal(err)
+			}
+			fundingRateSQLTester(t)
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func fundingRateSQLTester(t *testing.T) {
+	t.Helper()
+	_, err := GetLatest(testExchanges[0].Name, asset.USDTMarginedFutures.String(), currency.BTC.String(), currency.USDT.String())
+	if !errors.Is(err, sql.ErrNoRows) {
+		t.Fatalf("received '%v', expected '%v'", err, sql.ErrNoRows)
+	}
+
+	err = Insert(Data{Rate: 1})
+	if !errors.Is(err, errExchangeNotSet) {
+		t.Fatalf("received '%v', expected '%v'", err, errExchangeNotSet)
+	}
+
+	firstTime := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	rates := make([]Data, 10)
+	for i := range rates {
+		rates[i] = Data{
+			Exchange:  testExchanges[0].Name,
+			AssetType: asset.USDTMarginedFutures.String(),
+			Base:      currency.BTC.String(),
+			Quote:     currency.USDT.String(),
+			Rate:      float64(i) / 10000,
+			Timestamp: firstTime.Add(time.Hour * 8 * time.Duration(i)),
+		}
+	}
+	err = Insert(rates...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// duplicate rates are ignored
+	for i := range rates {
+		rates[i].ID = ""
+	}
+	err = Insert(rates...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	resp, err := GetInRange(testExchanges[0].Name,
+		asset.USDTMarginedFutures.String(),
+		currency.BTC.String(),
+		currency.USDT.String(),
+		firstTime,
+		firstTime.Add(time.Hour*8*4))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 5 {
+		t.Fatalf("received '%v', expected '%v'", len(resp), 5)
+	}
+	if !resp[4].Timestamp.Equal(firstTime.Add(time.Hour*8*4)) || resp[4].Rate != 0.0004 {
+		t.Errorf("received '%+v', expected rate 0.0004 at %v", resp[4], firstTime.Add(time.Hour*8*4))
+	}
+
+	latest, err := GetLatest(testExchanges[0].Name, asset.USDTMarginedFutures.String(), currency.BTC.String(), currency.USDT.String())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !latest.Timestamp.Equal(rates[9].Timestamp) {
+		t.Errorf("received '%v', expected '%v'", latest.Timestamp, rates[9].Timestamp)
+	}
+
+	resp, err = GetInRange(testExchanges[1].Name,
+		asset.USDTMarginedFutures.String(),
+		currency.BTC.String(),
+		currency.USDT.String(),
+		firstTime,
+		firstTime.Add(time.Hour*24*7))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(resp), 0)
+	}
+}
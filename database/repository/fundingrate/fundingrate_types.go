@@ -0,0 +1,21 @@
+package fundingrate
+
+import (
+	"errors"
+	"time"
+)
+
+var errExchangeNotSet = errors.New("exchange name/uuid not set, cannot insert")
+
+// Data defines a historical funding rate in its simplest
+// db friendly form
+type Data struct {
+	ID             string
+	Exchange       string
+	ExchangeNameID string
+	AssetType      string
+	Base           string
+	Quote          string
+	Rate           float64
+	Timestamp      time.Time
+}
@@ -0,0 +1,251 @@
+package ledger
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// sqliteTimeLayout keeps and pads nanoseconds so sqlite orders the stored
+// strings the same as the times
+const sqliteTimeLayout = "2006-01-02T15:04:05.000000000Z07:00"
+
+// Insert saves ledger entries to the database and returns the entries which
+// were inserted. Entries with a key which has already been stored are
+// skipped
+func Insert(entries ...Data) (inserted []Data, err error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	for i := range entries {
+		if entries[i].Key == "" || entries[i].Exchange == "" || entries[i].Currency == "" || entries[i].Occurred.IsZero() {
+			return nil, fmt.Errorf("%w %+v", errInvalidEntry, entries[i])
+		}
+	}
+
+	ctx := context.TODO()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Insert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		inserted, err = insertSQLite(ctx, tx, entries...)
+	} else {
+		inserted, err = insertPostgres(ctx, tx, entries...)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return inserted, nil
+}
+
+func insertSQLite(ctx context.Context, tx *sql.Tx, entries ...Data) ([]Data, error) {
+	var inserted []Data
+	for i := range entries {
+		exists, err := sqlite3.LedgerEntries(qm.Where("entry_key = ?", entries[i].Key)).Exists(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if entries[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return nil, err
+			}
+			entries[i].ID = freshUUID.String()
+		}
+		var tempEntry = sqlite3.LedgerEntry{
+			ID:           entries[i].ID,
+			EntryKey:     entries[i].Key,
+			ExchangeName: entries[i].Exchange,
+			SubAccount:   entries[i].SubAccount,
+			Asset:        strings.ToLower(entries[i].Asset),
+			Kind:         entries[i].Kind,
+			Reference:    entries[i].Reference,
+			Currency:     strings.ToUpper(entries[i].Currency),
+			Amount:       entries[i].Amount.String(),
+			Occurred:     entries[i].Occurred.UTC().Format(sqliteTimeLayout),
+			Description:  entries[i].Description,
+		}
+		err = tempEntry.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return nil, err
+		}
+		inserted = append(inserted, entries[i])
+	}
+	return inserted, nil
+}
+
+func insertPostgres(ctx context.Context, tx *sql.Tx, entries ...Data) ([]Data, error) {
+	var inserted []Data
+	for i := range entries {
+		exists, err := postgres.LedgerEntries(qm.Where("entry_key = ?", entries[i].Key)).Exists(ctx, tx)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			continue
+		}
+		if entries[i].ID == "" {
+			var freshUUID uuid.UUID
+			freshUUID, err = uuid.NewV4()
+			if err != nil {
+				return nil, err
+			}
+			entries[i].ID = freshUUID.String()
+		}
+		var tempEntry = postgres.LedgerEntry{
+			ID:           entries[i].ID,
+			EntryKey:     entries[i].Key,
+			ExchangeName: entries[i].Exchange,
+			SubAccount:   entries[i].SubAccount,
+			Asset:        strings.ToLower(entries[i].Asset),
+			Kind:         entries[i].Kind,
+			Reference:    entries[i].Reference,
+			Currency:     strings.ToUpper(entries[i].Currency),
+			Amount:       entries[i].Amount.String(),
+			Occurred:     entries[i].Occurred.UTC(),
+			Description:  entries[i].Description,
+		}
+		err = tempEntry.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return nil, err
+		}
+		inserted = append(inserted, entries[i])
+	}
+	return inserted, nil
+}
+
+// balanceRow is a stored amount of a currency in a sub account
+type balanceRow struct {
+	Exchange   string `boil:"exchange_name"`
+	SubAccount string `boil:"sub_account"`
+	Asset      string `boil:"asset"`
+	Currency   string `boil:"currency"`
+	Amount     string `boil:"amount"`
+}
+
+// GetBalances returns the sum of the stored entries of each currency in each
+// sub account. Amounts are summed as decimals rather than by the database so
+// the balances are exact
+func GetBalances() ([]Balance, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	mods := []qm.QueryMod{
+		qm.Select("exchange_name", "sub_account", "asset", "currency", "amount"),
+		qm.OrderBy("exchange_name, sub_account, asset, currency"),
+	}
+	var rows []balanceRow
+	var err error
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = sqlite3.LedgerEntries(mods...).Bind(context.TODO(), database.DB.SQL, &rows)
+	} else {
+		err = postgres.LedgerEntries(mods...).Bind(context.TODO(), database.DB.SQL, &rows)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("ledger.GetBalances %w", err)
+	}
+	var resp []Balance
+	for i := range rows {
+		amount, err := decimal.NewFromString(rows[i].Amount)
+		if err != nil {
+			return nil, fmt.Errorf("ledger.GetBalances %s %s amount %w", rows[i].Exchange, rows[i].Currency, err)
+		}
+		if last := len(resp) - 1; last >= 0 && resp[last].Exchange == rows[i].Exchange &&
+			resp[last].SubAccount == rows[i].SubAccount && resp[last].Asset == rows[i].Asset && resp[last].Currency == rows[i].Currency {
+			resp[last].Amount = resp[last].Amount.Add(amount)
+			continue
+		}
+		resp = append(resp, Balance{
+			Exchange:   rows[i].Exchange,
+			SubAccount: rows[i].SubAccount,
+			Asset:      rows[i].Asset,
+			Currency:   rows[i].Currency,
+			Amount:     amount,
+		})
+	}
+	return resp, nil
+}
+
+// GetExchangeRanges returns the time of the first and last stored entries of
+// each exchange
+func GetExchangeRanges() ([]ExchangeRange, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	mods := []qm.QueryMod{
+		qm.Select("exchange_name", "MIN(occurred) AS first", "MAX(occurred) AS last"),
+		qm.GroupBy("exchange_name"),
+		qm.OrderBy("exchange_name"),
+	}
+	var resp []ExchangeRange
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		var rows []struct {
+			Exchange string `boil:"exchange_name"`
+			First    string `boil:"first"`
+			Last     string `boil:"last"`
+		}
+		err := sqlite3.LedgerEntries(mods...).Bind(context.TODO(), database.DB.SQL, &rows)
+		if err != nil {
+			return nil, fmt.Errorf("ledger.GetExchangeRanges %w", err)
+		}
+		resp = make([]ExchangeRange, len(rows))
+		for i := range rows {
+			resp[i].Exchange = rows[i].Exchange
+			if resp[i].First, err = time.Parse(time.RFC3339Nano, rows[i].First); err != nil {
+				return nil, err
+			}
+			if resp[i].Last, err = time.Parse(time.RFC3339Nano, rows[i].Last); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		var rows []struct {
+			Exchange string    `boil:"exchange_name"`
+			First    time.Time `boil:"first"`
+			Last     time.Time `boil:"last"`
+		}
+		err := postgres.LedgerEntries(mods...).Bind(context.TODO(), database.DB.SQL, &rows)
+		if err != nil {
+			return nil, fmt.Errorf("ledger.GetExchangeRanges %w", err)
+		}
+		resp = make([]ExchangeRange, len(rows))
+		for i := range rows {
+			resp[i] = ExchangeRange{Exchange: rows[i].Exchange, First: rows[i].First, Last: rows[i].Last}
+		}
+	}
+	for i := range resp {
+		resp[i].First, resp[i].Last = resp[i].First.UTC(), resp[i].Last.UTC()
+	}
+	return resp, nil
+}
@@ -0,0 +1,141 @@
+package ledger
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestLedgerEntries(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			ledgerSQLTester(t)
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func ledgerSQLTester(t *testing.T) {
+	t.Helper()
+	_, err := Insert(Data{Key: "bad"})
+	if !errors.Is(err, errInvalidEntry) {
+		t.Fatalf("received '%v', expected '%v'", err, errInvalidEntry)
+	}
+
+	opened := time.Date(2023, 8, 1, 0, 0, 0, 0, time.UTC)
+	entries := []Data{
+		{Key: "a", Exchange: "one", Asset: "spot", Kind: "opening", Currency: "btc", Amount: decimal.RequireFromString("1"), Occurred: opened},
+		{Key: "b", Exchange: "one", Asset: "spot", Kind: "trade", Currency: "BTC", Amount: decimal.RequireFromString("-0.25"), Occurred: opened.Add(time.Hour)},
+		{Key: "c", Exchange: "one", Asset: "spot", Kind: "trade", Currency: "USDT", Amount: decimal.RequireFromString("5000"), Occurred: opened.Add(time.Hour)},
+		{Key: "d", Exchange: "two", SubAccount: "sub", Asset: "spot", Kind: "deposit", Currency: "ETH", Amount: decimal.RequireFromString("2"), Occurred: opened.Add(time.Minute * 90)},
+		{Key: "f", Exchange: "two", SubAccount: "sub", Asset: "spot", Kind: "deposit", Currency: "USDT", Amount: decimal.RequireFromString("0.1"), Occurred: opened.Add(time.Minute * 90)},
+		{Key: "g", Exchange: "two", SubAccount: "sub", Asset: "spot", Kind: "deposit", Currency: "USDT", Amount: decimal.RequireFromString("0.2"), Occurred: opened.Add(time.Minute * 90)},
+	}
+	inserted, err := Insert(entries...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(inserted) != 6 {
+		t.Fatalf("received '%v', expected '%v'", len(inserted), 6)
+	}
+	// entries already stored are skipped
+	inserted, err = Insert(Data{Key: "b", Exchange: "one", Asset: "spot", Kind: "trade", Currency: "BTC", Amount: decimal.RequireFromString("-0.25"), Occurred: opened.Add(time.Hour)},
+		Data{Key: "e", Exchange: "one", Asset: "spot", Kind: "fee", Currency: "USDT", Amount: decimal.RequireFromString("-5"), Occurred: opened.Add(time.Hour * 2)})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(inserted) != 1 || inserted[0].Key != "e" {
+		t.Fatalf("received '%+v', expected only entry e", inserted)
+	}
+
+	balances, err := GetBalances()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	expected := []Balance{
+		{Exchange: "one", Asset: "spot", Currency: "BTC", Amount: decimal.RequireFromString("0.75")},
+		{Exchange: "one", Asset: "spot", Currency: "USDT", Amount: decimal.RequireFromString("4995")},
+		{Exchange: "two", SubAccount: "sub", Asset: "spot", Currency: "ETH", Amount: decimal.RequireFromString("2")},
+		// summed exactly, as floats 0.1 and 0.2 total 0.30000000000000004
+		{Exchange: "two", SubAccount: "sub", Asset: "spot", Currency: "USDT", Amount: decimal.RequireFromString("0.3")},
+	}
+	if len(balances) != len(expected) {
+		t.Fatalf("received '%v', expected '%v'", len(balances), len(expected))
+	}
+	for i := range expected {
+		if balances[i].Exchange != expected[i].Exchange || balances[i].SubAccount != expected[i].SubAccount ||
+			balances[i].Currency != expected[i].Currency || !balances[i].Amount.Equal(expected[i].Amount) {
+			t.Errorf("received '%+v', expected '%+v'", balances[i], expected[i])
+		}
+	}
+
+	ranges, err := GetExchangeRanges()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(ranges) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(ranges), 2)
+	}
+	if ranges[0].Exchange != "one" || !ranges[0].First.Equal(opened) || !ranges[0].Last.Equal(opened.Add(time.Hour*2)) {
+		t.Errorf("received '%+v', expected the range of exchange one", ranges[0])
+	}
+}
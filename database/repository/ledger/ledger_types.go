@@ -0,0 +1,46 @@
+package ledger
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+var errInvalidEntry = errors.New("invalid ledger entry, cannot insert")
+
+// Data defines a ledger entry in its simplest db friendly form. Amount is
+// positive when a currency is received and negative when it is spent, it is
+// stored as exact decimal text so balances do not drift with rounding. Key
+// uniquely identifies the movement so it is only stored once however many
+// times it is imported
+type Data struct {
+	ID          string
+	Key         string
+	Exchange    string
+	SubAccount  string
+	Asset       string
+	Kind        string
+	Reference   string
+	Currency    string
+	Amount      decimal.Decimal
+	Occurred    time.Time
+	Description string
+}
+
+// Balance is the sum of the stored entries of a currency in a sub account
+type Balance struct {
+	Exchange   string
+	SubAccount string
+	Asset      string
+	Currency   string
+	Amount     decimal.Decimal
+}
+
+// ExchangeRange is the time of the first and last stored entries of an
+// exchange
+type ExchangeRange struct {
+	Exchange string
+	First    time.Time
+	Last     time.Time
+}
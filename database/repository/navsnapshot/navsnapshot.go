@@ -0,0 +1,198 @@
+package navsnapshot
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/models/postgres"
+	"github.com/thrasher-corp/gocryptotrader/database/models/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/sqlboiler/boil"
+	"github.com/thrasher-corp/sqlboiler/queries/qm"
+)
+
+// Upsert saves daily NAV snapshots to the database. Dates are stored as the
+// start of their UTC day and a snapshot which has already been stored for
+// the same day and reporting currency is replaced
+func Upsert(snapshots ...Data) error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	for i := range snapshots {
+		if snapshots[i].ReportingCurrency == "" || snapshots[i].Date.IsZero() {
+			return fmt.Errorf("%w %+v", errInvalidSnapshot, snapshots[i])
+		}
+	}
+
+	ctx := context.TODO()
+	ctx = boil.SkipTimestamps(ctx)
+
+	tx, err := database.DB.SQL.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("beginTx %w", err)
+	}
+	defer func() {
+		if err != nil {
+			errRB := tx.Rollback()
+			if errRB != nil {
+				log.Errorf(log.DatabaseMgr, "Upsert tx.Rollback %v", errRB)
+			}
+		}
+	}()
+
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		err = upsertSQLite(ctx, tx, snapshots...)
+	} else {
+		err = upsertPostgres(ctx, tx, snapshots...)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func upsertSQLite(ctx context.Context, tx *sql.Tx, snapshots ...Data) error {
+	for i := range snapshots {
+		if snapshots[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			snapshots[i].ID = freshUUID.String()
+		}
+		var tempSnapshot = sqlite3.NavSnapshot{
+			ID:                snapshots[i].ID,
+			Date:              common.TruncateDay(snapshots[i].Date).Format(time.RFC3339),
+			ReportingCurrency: strings.ToUpper(snapshots[i].ReportingCurrency),
+			Nav:               snapshots[i].NAV,
+			RealisedPNL:       snapshots[i].RealisedPNL,
+			UnrealisedPNL:     snapshots[i].UnrealisedPNL,
+			Fees:              snapshots[i].Fees,
+			Funding:           snapshots[i].Funding,
+		}
+		// the unique constraint replaces any snapshot already stored for the day
+		err := tempSnapshot.Insert(ctx, tx, boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func upsertPostgres(ctx context.Context, tx *sql.Tx, snapshots ...Data) error {
+	for i := range snapshots {
+		if snapshots[i].ID == "" {
+			freshUUID, err := uuid.NewV4()
+			if err != nil {
+				return err
+			}
+			snapshots[i].ID = freshUUID.String()
+		}
+		var tempSnapshot = postgres.NavSnapshot{
+			ID:                snapshots[i].ID,
+			Date:              common.TruncateDay(snapshots[i].Date),
+			ReportingCurrency: strings.ToUpper(snapshots[i].ReportingCurrency),
+			Nav:               snapshots[i].NAV,
+			RealisedPNL:       snapshots[i].RealisedPNL,
+			UnrealisedPNL:     snapshots[i].UnrealisedPNL,
+			Fees:              snapshots[i].Fees,
+			Funding:           snapshots[i].Funding,
+		}
+		err := tempSnapshot.Upsert(ctx, tx, true,
+			[]string{postgres.NavSnapshotColumns.Date, postgres.NavSnapshotColumns.ReportingCurrency},
+			boil.Whitelist(postgres.NavSnapshotColumns.Nav,
+				postgres.NavSnapshotColumns.RealisedPNL,
+				postgres.NavSnapshotColumns.UnrealisedPNL,
+				postgres.NavSnapshotColumns.Fees,
+				postgres.NavSnapshotColumns.Funding),
+			boil.Infer())
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetInRange returns the stored snapshots of a reporting currency between
+// the start and end dates inclusive, ordered by date
+func GetInRange(reportingCurrency string, startDate, endDate time.Time) (resp []Data, err error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		resp, err = getInRangeSQLite(reportingCurrency, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("navsnapshot.GetInRange getInRangeSQLite %w", err)
+		}
+	} else {
+		resp, err = getInRangePostgres(reportingCurrency, startDate, endDate)
+		if err != nil {
+			return nil, fmt.Errorf("navsnapshot.GetInRange getInRangePostgres %w", err)
+		}
+	}
+	return resp, nil
+}
+
+func getInRangeSQLite(reportingCurrency string, startDate, endDate time.Time) ([]Data, error) {
+	result, err := sqlite3.NavSnapshots(
+		qm.Where("reporting_currency = ?", strings.ToUpper(reportingCurrency)),
+		qm.Where("date >= ? AND date <= ?",
+			common.TruncateDay(startDate).Format(time.RFC3339),
+			endDate.UTC().Format(time.RFC3339)),
+		qm.OrderBy("date")).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		date, err := time.Parse(time.RFC3339, result[i].Date)
+		if err != nil {
+			return nil, err
+		}
+		resp[i] = Data{
+			ID:                result[i].ID,
+			Date:              date.UTC(),
+			ReportingCurrency: result[i].ReportingCurrency,
+			NAV:               result[i].Nav,
+			RealisedPNL:       result[i].RealisedPNL,
+			UnrealisedPNL:     result[i].UnrealisedPNL,
+			Fees:              result[i].Fees,
+			Funding:           result[i].Funding,
+		}
+	}
+	return resp, nil
+}
+
+func getInRangePostgres(reportingCurrency string, startDate, endDate time.Time) ([]Data, error) {
+	result, err := postgres.NavSnapshots(
+		qm.Where("reporting_currency = ?", strings.ToUpper(reportingCurrency)),
+		qm.Where("date >= ? AND date <= ?", common.TruncateDay(startDate), endDate.UTC()),
+		qm.OrderBy("date")).All(context.TODO(), database.DB.SQL)
+	if err != nil {
+		return nil, err
+	}
+	resp := make([]Data, len(result))
+	for i := range result {
+		resp[i] = Data{
+			ID:                result[i].ID,
+			Date:              result[i].Date.UTC(),
+			ReportingCurrency: result[i].ReportingCurrency,
+			NAV:               result[i].Nav,
+			RealisedPNL:       result[i].RealisedPNL,
+			UnrealisedPNL:     result[i].UnrealisedPNL,
+			Fees:              result[i].Fees,
+			Funding:           result[i].Funding,
+		}
+	}
+	return resp, nil
+}
@@ -0,0 +1,133 @@
+package navsnapshot
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/drivers"
+	"github.com/thrasher-corp/gocryptotrader/database/testhelpers"
+)
+
+var verbose = false
+
+func TestMain(m *testing.M) {
+	if verbose {
+		err := testhelpers.EnableVerboseTestOutput()
+		if err != nil {
+			fmt.Printf("failed to enable verbose test output: %v", err)
+			os.Exit(1)
+		}
+	}
+	var err error
+	testhelpers.PostgresTestDatabase = testhelpers.GetConnectionDetails()
+	testhelpers.TempDir, err = os.MkdirTemp("", "gct-temp")
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	exitCode := m.Run()
+	if err = os.RemoveAll(testhelpers.TempDir); err != nil {
+		fmt.Printf("failed to remove temp dir: %s", err)
+	}
+	os.Exit(exitCode)
+}
+
+func TestNAVSnapshots(t *testing.T) {
+	testCases := []struct {
+		name   string
+		config *database.Config
+	}{
+		{
+			name:   "postgresql",
+			config: testhelpers.PostgresTestDatabase,
+		},
+		{
+			name: "SQLite",
+			config: &database.Config{
+				Driver:            database.DBSQLite3,
+				ConnectionDetails: drivers.ConnectionDetails{Database: "./testdb"},
+			},
+		},
+	}
+
+	for x := range testCases {
+		test := testCases[x]
+		t.Run(test.name, func(t *testing.T) {
+			if !testhelpers.CheckValidConfig(&test.config.ConnectionDetails) {
+				t.Skip("database not configured skipping test")
+			}
+			dbConn, err := testhelpers.ConnectToDatabase(test.config)
+			if err != nil {
+				t.Fatal(err)
+			}
+			navSnapshotSQLTester(t)
+			if err = testhelpers.CloseDatabase(dbConn); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func navSnapshotSQLTester(t *testing.T) {
+	t.Helper()
+	err := Upsert(Data{Date: time.Now()})
+	if !errors.Is(err, errInvalidSnapshot) {
+		t.Fatalf("received '%v', expected '%v'", err, errInvalidSnapshot)
+	}
+
+	firstDay := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	snapshots := make([]Data, 5)
+	for i := range snapshots {
+		snapshots[i] = Data{
+			// dates are stored as the start of their day
+			Date:              firstDay.AddDate(0, 0, i).Add(time.Hour * 13),
+			ReportingCurrency: "usd",
+			NAV:               1000 + float64(i),
+			RealisedPNL:       float64(i),
+			Fees:              0.5,
+		}
+	}
+	err = Upsert(snapshots...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	// a later snapshot on the same day replaces the stored snapshot
+	err = Upsert(Data{
+		Date:              firstDay.AddDate(0, 0, 2).Add(time.Hour * 23),
+		ReportingCurrency: "USD",
+		NAV:               2000,
+		UnrealisedPNL:     -5,
+		Funding:           1.5,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	resp, err := GetInRange("USD", firstDay.Add(time.Hour), firstDay.AddDate(0, 0, 2))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(resp), 3)
+	}
+	if !resp[2].Date.Equal(firstDay.AddDate(0, 0, 2)) || resp[2].NAV != 2000 || resp[2].UnrealisedPNL != -5 || resp[2].Funding != 1.5 {
+		t.Errorf("received '%+v', expected replaced snapshot with NAV 2000 on %v", resp[2], firstDay.AddDate(0, 0, 2))
+	}
+	if resp[0].ReportingCurrency != "USD" || resp[0].Fees != 0.5 {
+		t.Errorf("received '%+v', expected USD snapshot with fees 0.5", resp[0])
+	}
+
+	resp, err = GetInRange("EUR", firstDay, firstDay.AddDate(0, 0, 10))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(resp) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(resp), 0)
+	}
+}
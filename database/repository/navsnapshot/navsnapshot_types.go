@@ -0,0 +1,21 @@
+package navsnapshot
+
+import (
+	"errors"
+	"time"
+)
+
+var errInvalidSnapshot = errors.New("invalid nav snapshot, cannot insert")
+
+// Data defines a daily net asset value snapshot in its simplest db friendly
+// form. Every value is held in the reporting currency
+type Data struct {
+	ID                string
+	Date              time.Time
+	ReportingCurrency string
+	NAV               float64
+	RealisedPNL       float64
+	UnrealisedPNL     float64
+	Fees              float64
+	Funding           float64
+}
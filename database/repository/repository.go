@@ -1,6 +1,8 @@
 package repository
 
 import (
+	"context"
+
 	"github.com/thrasher-corp/gocryptotrader/database"
 )
 
@@ -15,3 +17,13 @@ func GetSQLDialect() string {
 	}
 	return "invalid driver"
 }
+
+// Compact reclaims the storage left behind by deleted rows, VACUUM is
+// supported by both SQLite and PostgreSQL
+func Compact() error {
+	if database.DB.SQL == nil {
+		return database.ErrDatabaseSupportDisabled
+	}
+	_, err := database.DB.SQL.ExecContext(context.TODO(), "VACUUM")
+	return err
+}
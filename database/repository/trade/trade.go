@@ -448,3 +448,63 @@ func generateQuery(clauses map[string]interface{}, start, end time.Time, isSQLit
 
 	return query
 }
+
+// ListSeries returns every stored trade series along with the timestamp of
+// its oldest trade
+func ListSeries() ([]SeriesSummary, error) {
+	if database.DB.SQL == nil {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	rows, err := database.DB.SQL.QueryContext(context.TODO(), `SELECT exchange.name, trade.asset, trade.base, trade.quote, MIN(trade.timestamp)
+		FROM trade INNER JOIN exchange ON trade.exchange_name_id = exchange.id
+		GROUP BY exchange.name, trade.asset, trade.base, trade.quote`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	isSQLite := repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite
+	var resp []SeriesSummary
+	for rows.Next() {
+		var s SeriesSummary
+		if isSQLite {
+			var oldest string
+			err = rows.Scan(&s.Exchange, &s.AssetType, &s.Base, &s.Quote, &oldest)
+			if err == nil {
+				s.Oldest, err = time.Parse(time.RFC3339, oldest)
+			}
+		} else {
+			err = rows.Scan(&s.Exchange, &s.AssetType, &s.Base, &s.Quote, &s.Oldest)
+		}
+		if err != nil {
+			return nil, err
+		}
+		s.Oldest = s.Oldest.UTC()
+		resp = append(resp, s)
+	}
+	return resp, rows.Err()
+}
+
+// DeleteInRange removes all trades for a series with timestamps from start
+// up to but excluding end
+func DeleteInRange(exchangeName, assetType, base, quote string, startDate, endDate time.Time) (int64, error) {
+	if database.DB.SQL == nil {
+		return 0, database.ErrDatabaseSupportDisabled
+	}
+	exchangeUUID, err := exchange.UUIDByName(exchangeName)
+	if err != nil {
+		return 0, err
+	}
+	query := []qm.QueryMod{
+		qm.Where("exchange_name_id = ?", exchangeUUID.String()),
+		qm.Where("asset = ?", strings.ToLower(assetType)),
+		qm.Where("base = ?", strings.ToUpper(base)),
+		qm.Where("quote = ?", strings.ToUpper(quote)),
+	}
+	ctx := context.TODO()
+	if repository.GetSQLDialect() == database.DBSQLite3 || repository.GetSQLDialect() == database.DBSQLite {
+		query = append(query, qm.Where("timestamp >= ? AND timestamp < ?", startDate.UTC().Format(time.RFC3339), endDate.UTC().Format(time.RFC3339)))
+		return sqlite3.Trades(query...).DeleteAll(ctx, database.DB.SQL)
+	}
+	query = append(query, qm.Where("timestamp >= ? AND timestamp < ?", startDate.UTC(), endDate.UTC()))
+	return postgres.Trades(query...).DeleteAll(ctx, database.DB.SQL)
+}
@@ -1,6 +1,7 @@
 package trade
 
 import (
+	"errors"
 	"fmt"
 	"log"
 	"os"
@@ -189,6 +190,27 @@ func tradeSQLTester(t *testing.T) {
 		t.Error(err)
 	}
 
+	series, err := ListSeries()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(series) != 1 || !series[0].Oldest.Equal(firstTime.Add(time.Minute)) {
+		t.Errorf("received '%+v', expected a single series starting at %v", series, firstTime.Add(time.Minute))
+	}
+	deleted, err := DeleteInRange(
+		testExchanges[0].Name,
+		asset.Spot.String(),
+		currency.BTC.String(),
+		currency.USD.String(),
+		firstTime,
+		firstTime.Add(time.Minute*11))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if deleted != 10 {
+		t.Errorf("received '%v', expected '%v'", deleted, 10)
+	}
+
 	err = DeleteTrades(trades...)
 	if err != nil {
 		t.Error(err)
@@ -17,3 +17,12 @@ type Data struct {
 	Side           string
 	Timestamp      time.Time
 }
+
+// SeriesSummary describes a stored trade series
+type SeriesSummary struct {
+	Exchange  string
+	AssetType string
+	Base      string
+	Quote     string
+	Oldest    time.Time
+}
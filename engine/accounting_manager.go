@@ -0,0 +1,1075 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	accountingsql "github.com/thrasher-corp/gocryptotrader/database/repository/accounting"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/navsnapshot"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// SetupAccountingManager creates an accounting manager subsystem. The
+// database connection manager is only required when NAV snapshots and the
+// accounting state are stored
+func SetupAccountingManager(em iExchangeManager, of accountingOrderFeed, dcm iDatabaseConnectionManager, cfg *config.Accounting) (*AccountingManager, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if of == nil {
+		return nil, errNilOrderFeed
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	method, err := costbasis.ParseMethod(cfg.CostBasisMethod)
+	if err != nil {
+		return nil, err
+	}
+	reportingCurrency := currency.NewCode(cfg.ReportingCurrency)
+	if reportingCurrency.IsEmpty() {
+		reportingCurrency = currency.USD
+	}
+	interval := cfg.SnapshotInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	maxEvents := cfg.MaxEvents
+	if maxEvents <= 0 {
+		maxEvents = 10000
+	}
+	maxSnapshots := cfg.MaxSnapshots
+	if maxSnapshots <= 0 {
+		maxSnapshots = 366
+	}
+	return &AccountingManager{
+		exchangeManager:   em,
+		orderFeed:         of,
+		dbManager:         dcm,
+		shutdown:          make(chan struct{}),
+		method:            method,
+		reportingCurrency: reportingCurrency,
+		snapshotInterval:  interval,
+		maxEvents:         maxEvents,
+		maxSnapshots:      maxSnapshots,
+		verbose:           cfg.Verbose,
+		orders:            make(map[string]*accountingOrder),
+		books:             make(map[string]*accountingBook),
+		positions:         make(map[string]*accountingPosition),
+		unsaved:           newAccountingChanges(),
+		snapshotSaver:     navsnapshot.Upsert,
+		snapshotLoader:    navsnapshot.GetInRange,
+		stateSaver:        accountingsql.Store,
+		stateLoader:       accountingsql.Load,
+	}, nil
+}
+
+// Start runs the subsystem
+func (a *AccountingManager) Start() error {
+	if a == nil {
+		return fmt.Errorf("%s %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&a.started, 0, 1) {
+		return fmt.Errorf("%s %w", AccountingManagerName, ErrSubSystemAlreadyStarted)
+	}
+	if a.databaseConnected() {
+		if err := a.restore(); err != nil {
+			atomic.StoreInt32(&a.started, 0)
+			return fmt.Errorf("%s unable to restore accounting state: %w", AccountingManagerName, err)
+		}
+	}
+	orderSub, err := a.orderFeed.subscribeOrderUpdates(0)
+	if err != nil {
+		atomic.StoreInt32(&a.started, 0)
+		return err
+	}
+	positionSub, err := a.orderFeed.subscribePositions(0)
+	if err != nil {
+		orderSub.Release()
+		atomic.StoreInt32(&a.started, 0)
+		return err
+	}
+	a.shutdown = make(chan struct{})
+	a.wg.Add(2)
+	go a.ingest(orderSub, positionSub)
+	go a.run()
+	log.Debugf(log.Global, "Accounting manager %s, reporting in %s using %s cost basis", MsgSubSystemStarted, a.reportingCurrency, a.method)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (a *AccountingManager) IsRunning() bool {
+	if a == nil {
+		return false
+	}
+	return atomic.LoadInt32(&a.started) == 1
+}
+
+// Stop stops the subsystem
+func (a *AccountingManager) Stop() error {
+	if a == nil {
+		return fmt.Errorf("%s %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&a.started, 1, 0) {
+		return fmt.Errorf("%s %w", AccountingManagerName, ErrSubSystemNotStarted)
+	}
+	close(a.shutdown)
+	a.wg.Wait()
+	a.save()
+	log.Debugf(log.Global, "Accounting manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (a *AccountingManager) run() {
+	defer a.wg.Done()
+	t := time.NewTicker(a.snapshotInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-a.shutdown:
+			return
+		case <-t.C:
+			if _, err := a.Snapshot(context.TODO()); err != nil && !errors.Is(err, errAlreadyRunning) {
+				log.Errorf(log.Global, "Accounting manager: %v", err)
+			}
+		}
+	}
+}
+
+// ingest books order updates and futures positions until shutdown. A
+// subscription which falls behind is resumed from the event after the last
+// one processed so that no fill is missed
+func (a *AccountingManager) ingest(orderSub, positionSub *feedSubscription) {
+	defer a.wg.Done()
+	defer func() {
+		orderSub.Release()
+		positionSub.Release()
+	}()
+	var orderSeq, positionSeq uint64
+	for {
+		select {
+		case <-a.shutdown:
+			return
+		case ev, ok := <-orderSub.Channel():
+			if !ok {
+				orderSub = a.resubscribe(orderSub, a.orderFeed.subscribeOrderUpdates, orderSeq)
+				if orderSub == nil {
+					return
+				}
+				orderSeq = a.process(orderSub.Replay(), orderSeq)
+				continue
+			}
+			orderSeq = a.process([]sequencedEvent{ev}, orderSeq)
+		case ev, ok := <-positionSub.Channel():
+			if !ok {
+				positionSub = a.resubscribe(positionSub, a.orderFeed.subscribePositions, positionSeq)
+				if positionSub == nil {
+					return
+				}
+				positionSeq = a.process(positionSub.Replay(), positionSeq)
+				continue
+			}
+			positionSeq = a.process([]sequencedEvent{ev}, positionSeq)
+		}
+	}
+}
+
+// resubscribe resumes a closed subscription from the event after the last
+// sequence processed. When the events are no longer retained a new
+// subscription is started and the gap is logged. Returns nil on shutdown
+func (a *AccountingManager) resubscribe(sub *feedSubscription, subscribe func(uint64) (*feedSubscription, error), lastSequence uint64) *feedSubscription {
+	log.Warnf(log.Global, "Accounting manager feed closed: %v, resuming from sequence %d", sub.Err(), lastSequence+1)
+	for {
+		resumed, err := subscribe(lastSequence + 1)
+		if errors.Is(err, errSequenceNotRetained) || errors.Is(err, errSequenceAhead) {
+			log.Errorf(log.Global, "Accounting manager missed events after sequence %d, PNL may be incomplete: %v", lastSequence, err)
+			resumed, err = subscribe(0)
+		}
+		if err == nil {
+			return resumed
+		}
+		log.Errorf(log.Global, "Accounting manager unable to resubscribe: %v", err)
+		select {
+		case <-a.shutdown:
+			return nil
+		case <-time.After(time.Second):
+		}
+	}
+}
+
+// process books sequenced order and position events and returns the last
+// sequence processed
+func (a *AccountingManager) process(events []sequencedEvent, lastSequence uint64) uint64 {
+	for i := range events {
+		if events[i].Sequence <= lastSequence {
+			continue
+		}
+		lastSequence = events[i].Sequence
+		var err error
+		switch d := events[i].Data.(type) {
+		case order.Detail:
+			err = a.processOrder(&d)
+		case order.Position:
+			err = a.processPosition(&d)
+		default:
+			err = common.GetTypeAssertError("order.Detail or order.Position", events[i].Data)
+		}
+		if err != nil {
+			log.Errorf(log.Global, "Accounting manager: %v", err)
+		}
+	}
+	a.save()
+	return lastSequence
+}
+
+// processOrder books the execution of a spot order since its last update.
+// Futures are accounted for through their positions and margin is not booked
+func (a *AccountingManager) processOrder(d *order.Detail) error {
+	if d.AssetType != asset.Spot || d.OrderID == "" {
+		return nil
+	}
+	a.m.Lock()
+	defer a.m.Unlock()
+	key := strings.ToLower(d.Exchange) + "|" + d.OrderID
+	prev, ok := a.orders[key]
+	if !ok {
+		prev = &accountingOrder{}
+		a.orders[key] = prev
+	}
+	if d.IsInactive() && prev.finished.IsZero() {
+		prev.finished = time.Now()
+		a.unsaved.orders[key] = struct{}{}
+	}
+	executed := decimal.NewFromFloat(d.ExecutedAmount)
+	if executed.IsZero() && d.Status == order.Filled {
+		executed = decimal.NewFromFloat(d.Amount)
+	}
+	price := d.AverageExecutedPrice
+	if price <= 0 {
+		price = d.Price
+	}
+	quote := executed.Mul(decimal.NewFromFloat(price))
+	fee := decimal.NewFromFloat(d.Fee)
+	amount := executed.Sub(prev.executed)
+	quoteAmount := quote.Sub(prev.quote)
+	feeAmount := fee.Sub(prev.fee)
+	if amount.IsNegative() {
+		amount = decimal.Zero
+	}
+	if !amount.IsPositive() {
+		quoteAmount = decimal.Zero
+	}
+	if feeAmount.IsNegative() {
+		feeAmount = decimal.Zero
+	}
+	if !amount.IsPositive() && !feeAmount.IsPositive() {
+		return nil
+	}
+	t := d.LastUpdated
+	if t.IsZero() {
+		t = time.Now()
+	}
+	if err := a.bookFill(d, t, amount, quoteAmount, feeAmount); err != nil {
+		return err
+	}
+	prev.executed = decimal.Max(prev.executed, executed)
+	prev.quote = decimal.Max(prev.quote, quote)
+	prev.fee = decimal.Max(prev.fee, fee)
+	a.unsaved.orders[key] = struct{}{}
+	return nil
+}
+
+// bookFill books both legs of a spot fill at its gross value. The fee is an
+// expense at its market value and disposes of the fee currency. Must be
+// called with the lock held
+func (a *AccountingManager) bookFill(d *order.Detail, t time.Time, amount, quoteAmount, fee decimal.Decimal) error {
+	quoteRate, err := a.rate(d.Exchange, d.Pair.Quote)
+	if err != nil {
+		return fmt.Errorf("%s %s %s order %s: %w", d.Exchange, d.AssetType, d.Pair, d.OrderID, err)
+	}
+	value := quoteAmount.Mul(quoteRate)
+	feeAsset := d.FeeAsset
+	if feeAsset.IsEmpty() {
+		feeAsset = d.Pair.Quote
+	}
+	var feeValue decimal.Decimal
+	if fee.IsPositive() {
+		var feeRate decimal.Decimal
+		feeRate, err = a.rate(d.Exchange, feeAsset)
+		if err != nil {
+			return fmt.Errorf("%s %s %s order %s fee: %w", d.Exchange, d.AssetType, d.Pair, d.OrderID, err)
+		}
+		feeValue = fee.Mul(feeRate)
+	}
+
+	base := accountingLeg{currency: d.Pair.Base, amount: amount, value: value}
+	quote := accountingLeg{currency: d.Pair.Quote, amount: quoteAmount, value: value}
+	acquired, disposed := base, quote
+	if d.Side.IsShort() {
+		acquired, disposed = quote, base
+	}
+	ev := AccountingEvent{
+		Time:        t,
+		Kind:        AccountingTrade,
+		Exchange:    d.Exchange,
+		Asset:       d.AssetType,
+		Pair:        d.Pair,
+		OrderID:     d.OrderID,
+		Side:        d.Side,
+		Amount:      amount,
+		Value:       value,
+		Fees:        feeValue,
+		RealisedPNL: feeValue.Neg(),
+	}
+	if amount.IsPositive() {
+		if err = a.acquire(d.Exchange, d.OrderID, t, &acquired); err != nil {
+			return err
+		}
+		if err = a.dispose(d.Exchange, d.OrderID, t, &disposed, &ev); err != nil {
+			return err
+		}
+	}
+	if fee.IsPositive() {
+		if err = a.dispose(d.Exchange, d.OrderID, t, &accountingLeg{currency: feeAsset, amount: fee, value: feeValue}, &ev); err != nil {
+			return err
+		}
+	}
+	a.addEvent(&ev)
+	return nil
+}
+
+// acquire adds an acquired amount to the book of its currency. The reporting
+// currency has no cost basis and is not booked. Must be called with the lock
+// held
+func (a *AccountingManager) acquire(exch, ref string, t time.Time, leg *accountingLeg) error {
+	if leg.currency.Equal(a.reportingCurrency) || !leg.amount.IsPositive() {
+		return nil
+	}
+	b, err := a.getBook(exch, leg.currency)
+	if err != nil {
+		return err
+	}
+	if err = b.book.Acquire(ref, t, leg.amount, leg.value); err != nil {
+		return err
+	}
+	a.unsaved.books[accountingBookKey(exch, leg.currency)] = struct{}{}
+	return nil
+}
+
+// dispose matches a disposed amount against the book of its currency and
+// adds the realised gain and any unmatched amount to the event. Must be
+// called with the lock held
+func (a *AccountingManager) dispose(exch, ref string, t time.Time, leg *accountingLeg, ev *AccountingEvent) error {
+	if leg.currency.Equal(a.reportingCurrency) || !leg.amount.IsPositive() {
+		return nil
+	}
+	b, err := a.getBook(exch, leg.currency)
+	if err != nil {
+		return err
+	}
+	disposals, unmatched, err := b.book.Dispose(ref, t, leg.amount, leg.value)
+	if err != nil {
+		return err
+	}
+	a.unsaved.books[accountingBookKey(exch, leg.currency)] = struct{}{}
+	for i := range disposals {
+		ev.RealisedPNL = ev.RealisedPNL.Add(disposals[i].Gain())
+	}
+	if unmatched.IsPositive() {
+		ev.Unmatched = ev.Unmatched.Add(unmatched)
+		if a.verbose {
+			log.Warnf(log.Global, "Accounting manager %s %s disposed of %s %s without a cost basis", exch, ref, unmatched, leg.currency)
+		}
+	}
+	return nil
+}
+
+// getBook returns the book of a currency on an exchange, creating it when
+// required. Must be called with the lock held
+func (a *AccountingManager) getBook(exch string, code currency.Code) (*accountingBook, error) {
+	key := accountingBookKey(exch, code)
+	b, ok := a.books[key]
+	if ok {
+		return b, nil
+	}
+	book, err := costbasis.NewBook(a.method)
+	if err != nil {
+		return nil, err
+	}
+	b = &accountingBook{exchange: exch, currency: code, book: book}
+	a.books[key] = b
+	return b, nil
+}
+
+func accountingBookKey(exch string, code currency.Code) string {
+	return strings.ToLower(exch) + "|" + code.Upper().String()
+}
+
+// processPosition records the change in realised PNL, fees and funding of a
+// futures position since its last update and keeps its unrealised PNL
+func (a *AccountingManager) processPosition(p *order.Position) error {
+	a.m.Lock()
+	defer a.m.Unlock()
+	key := strings.ToLower(p.Exchange) + "|" + p.Asset.String() + "|" + p.Pair.String() + "|" + strconv.FormatInt(p.OpeningDate.UnixNano(), 10)
+	prev, ok := a.positions[key]
+	if !ok {
+		settlement := p.CollateralCurrency
+		if settlement.IsEmpty() {
+			settlement = p.Pair.Quote
+		}
+		prev = &accountingPosition{exchange: p.Exchange, asset: p.Asset, pair: p.Pair, settlement: settlement}
+		a.positions[key] = prev
+	}
+	if p.Status != order.Open {
+		defer func() {
+			delete(a.positions, key)
+			a.unsaved.positions[key] = struct{}{}
+		}()
+	}
+	var gross, fees decimal.Decimal
+	for i := range p.PNLHistory {
+		if !p.PNLHistory[i].IsOrder {
+			continue
+		}
+		gross = gross.Add(p.PNLHistory[i].RealisedPNLBeforeFees)
+		fees = fees.Add(p.PNLHistory[i].Fee)
+	}
+	grossChange := gross.Sub(prev.realisedGross)
+	feeChange := fees.Sub(prev.fees)
+	fundingChange := p.FundingRates.PaymentSum.Sub(prev.funding)
+	prev.unrealised = p.UnrealisedPNL
+	if grossChange.IsZero() && feeChange.IsZero() && fundingChange.IsZero() {
+		return nil
+	}
+	rate, err := a.rate(p.Exchange, prev.settlement)
+	if err != nil {
+		return fmt.Errorf("%s %s %s position: %w", p.Exchange, p.Asset, p.Pair, err)
+	}
+	prev.realisedGross, prev.fees, prev.funding = gross, fees, p.FundingRates.PaymentSum
+	a.unsaved.positions[key] = struct{}{}
+	t := p.LastUpdated
+	if t.IsZero() {
+		t = time.Now()
+	}
+	if !grossChange.IsZero() || !feeChange.IsZero() {
+		a.addEvent(&AccountingEvent{
+			Time:        t,
+			Kind:        AccountingTrade,
+			Exchange:    p.Exchange,
+			Asset:       p.Asset,
+			Pair:        p.Pair,
+			Side:        p.LatestDirection,
+			RealisedPNL: grossChange.Sub(feeChange).Mul(rate),
+			Fees:        feeChange.Mul(rate),
+		})
+	}
+	if !fundingChange.IsZero() {
+		a.addEvent(&AccountingEvent{
+			Time:     t,
+			Kind:     AccountingFunding,
+			Exchange: p.Exchange,
+			Asset:    p.Asset,
+			Pair:     p.Pair,
+			Funding:  fundingChange.Mul(rate),
+		})
+	}
+	return nil
+}
+
+// addEvent records an event, dropping the oldest events once the maximum is
+// reached. Must be called with the lock held
+func (a *AccountingManager) addEvent(ev *AccountingEvent) {
+	a.events = append(a.events, *ev)
+	if len(a.events) > a.maxEvents {
+		a.events = a.events[len(a.events)-a.maxEvents:]
+	}
+	a.unsaved.events = append(a.unsaved.events, *ev)
+	if a.verbose {
+		log.Debugf(log.Global, "Accounting manager %s %s %s %s realised %s fees %s funding %s %s",
+			ev.Kind, ev.Exchange, ev.Asset, ev.Pair, ev.RealisedPNL, ev.Fees, ev.Funding, a.reportingCurrency)
+	}
+}
+
+// rate returns the rate to convert one unit of a currency into the reporting
+// currency. Market rates of the exchange are preferred over other exchanges
+// and a route through a common intermediary is used when there is no direct
+// rate
+func (a *AccountingManager) rate(exch string, from currency.Code) (decimal.Decimal, error) {
+	to := a.reportingCurrency
+	if r, ok := a.marketRate(exch, from, to); ok {
+		return r, nil
+	}
+	for _, via := range accountingIntermediaries {
+		if via.Equal(from) || via.Equal(to) {
+			continue
+		}
+		r1, ok := a.marketRate(exch, from, via)
+		if !ok {
+			continue
+		}
+		r2, ok := a.marketRate(exch, via, to)
+		if !ok {
+			continue
+		}
+		return r1.Mul(r2), nil
+	}
+	return decimal.Zero, fmt.Errorf("%w for %s to %s", errNoConversionRate, from, to)
+}
+
+// marketRate returns the direct rate between two currencies, falling back to
+// the spot tickers of the other loaded exchanges
+func (a *AccountingManager) marketRate(exch string, from, to currency.Code) (decimal.Decimal, bool) {
+	if r, ok := directRate(exch, from, to); ok {
+		return r, true
+	}
+	exchanges, err := a.exchangeManager.GetExchanges()
+	if err != nil {
+		return decimal.Zero, false
+	}
+	for i := range exchanges {
+		name := exchanges[i].GetName()
+		if strings.EqualFold(name, exch) {
+			continue
+		}
+		if r, ok := tickerRate(name, from, to); ok {
+			return r, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// directRate returns the rate between two currencies from fiat exchange
+// rates, USD stablecoin parity or a spot ticker of an exchange
+func directRate(exch string, from, to currency.Code) (decimal.Decimal, bool) {
+	if from.Equal(to) {
+		return decimal.NewFromInt(1), true
+	}
+	if from.IsFiatCurrency() && to.IsFiatCurrency() {
+		if r, err := currency.ConvertFiat(1, from, to); err == nil && r > 0 {
+			return decimal.NewFromFloat(r), true
+		}
+	}
+	if (to.Equal(currency.USD) && accountingUSDStables.Contains(from)) ||
+		(from.Equal(currency.USD) && accountingUSDStables.Contains(to)) {
+		return decimal.NewFromInt(1), true
+	}
+	return tickerRate(exch, from, to)
+}
+
+// tickerRate returns the rate between two currencies from the last price of
+// the direct or inverse spot ticker of an exchange
+func tickerRate(exch string, from, to currency.Code) (decimal.Decimal, bool) {
+	if exch == "" {
+		return decimal.Zero, false
+	}
+	if t, err := ticker.GetTicker(exch, currency.NewPair(from, to), asset.Spot); err == nil {
+		if p := tickerPrice(t); p > 0 {
+			return decimal.NewFromFloat(p), true
+		}
+	}
+	if t, err := ticker.GetTicker(exch, currency.NewPair(to, from), asset.Spot); err == nil {
+		if p := tickerPrice(t); p > 0 {
+			return decimal.NewFromInt(1).Div(decimal.NewFromFloat(p)), true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// tickerPrice returns the last price of a ticker, or the mid price when
+// there is no last price
+func tickerPrice(t *ticker.Price) float64 {
+	if t.Last > 0 {
+		return t.Last
+	}
+	if t.Bid > 0 && t.Ask > 0 {
+		return (t.Bid + t.Ask) / 2
+	}
+	return 0
+}
+
+// PNLReport returns the realised PNL, fees and funding recorded between the
+// start and end times and the current unrealised PNL, optionally filtered
+func (a *AccountingManager) PNLReport(start, end time.Time, f *PNLFilter) (*PNLReport, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&a.started) == 0 {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrSubSystemNotStarted)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if end.Before(start) {
+		return nil, errInvalidTimeRange
+	}
+	if f == nil {
+		f = &PNLFilter{}
+	}
+	report := &PNLReport{
+		Start:             start,
+		End:               end,
+		ReportingCurrency: a.reportingCurrency,
+		CostBasisMethod:   a.method,
+	}
+	breakdown := make(map[string]*PNLBreakdown)
+	getBreakdown := func(exch string, item asset.Item, code currency.Code) *PNLBreakdown {
+		key := accountingBookKey(exch, code) + "|" + item.String()
+		b, ok := breakdown[key]
+		if !ok {
+			b = &PNLBreakdown{Exchange: exch, Asset: item, Currency: code}
+			breakdown[key] = b
+		}
+		return b
+	}
+
+	a.m.Lock()
+	defer a.m.Unlock()
+	for i := range a.events {
+		ev := &a.events[i]
+		if ev.Time.Before(start) || ev.Time.After(end) || !f.match(ev.Exchange, ev.Asset, ev.Pair.Base) {
+			continue
+		}
+		b := getBreakdown(ev.Exchange, ev.Asset, ev.Pair.Base)
+		b.RealisedPNL = b.RealisedPNL.Add(ev.RealisedPNL)
+		b.Fees = b.Fees.Add(ev.Fees)
+		b.Funding = b.Funding.Add(ev.Funding)
+		b.Unmatched = b.Unmatched.Add(ev.Unmatched)
+	}
+	for _, book := range a.books {
+		amount := book.book.Amount()
+		if !amount.IsPositive() || !f.match(book.exchange, asset.Spot, book.currency) {
+			continue
+		}
+		b := getBreakdown(book.exchange, asset.Spot, book.currency)
+		b.Amount = amount
+		b.CostBasis = book.book.Cost()
+		r, err := a.rate(book.exchange, book.currency)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s %s: %v", book.exchange, book.currency, err))
+			continue
+		}
+		b.MarketValue = amount.Mul(r)
+		b.UnrealisedPNL = b.MarketValue.Sub(b.CostBasis)
+	}
+	for _, p := range a.positions {
+		if p.unrealised.IsZero() || !f.match(p.exchange, p.asset, p.pair.Base) {
+			continue
+		}
+		r, err := a.rate(p.exchange, p.settlement)
+		if err != nil {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s %s %s: %v", p.exchange, p.asset, p.pair, err))
+			continue
+		}
+		b := getBreakdown(p.exchange, p.asset, p.pair.Base)
+		b.UnrealisedPNL = b.UnrealisedPNL.Add(p.unrealised.Mul(r))
+	}
+
+	report.Breakdown = make([]PNLBreakdown, 0, len(breakdown))
+	for _, b := range breakdown {
+		report.RealisedPNL = report.RealisedPNL.Add(b.RealisedPNL)
+		report.UnrealisedPNL = report.UnrealisedPNL.Add(b.UnrealisedPNL)
+		report.Fees = report.Fees.Add(b.Fees)
+		report.Funding = report.Funding.Add(b.Funding)
+		report.Breakdown = append(report.Breakdown, *b)
+	}
+	report.TotalPNL = report.RealisedPNL.Add(report.UnrealisedPNL).Add(report.Funding)
+	sort.Slice(report.Breakdown, func(i, j int) bool {
+		if report.Breakdown[i].Exchange != report.Breakdown[j].Exchange {
+			return report.Breakdown[i].Exchange < report.Breakdown[j].Exchange
+		}
+		if report.Breakdown[i].Asset != report.Breakdown[j].Asset {
+			return report.Breakdown[i].Asset < report.Breakdown[j].Asset
+		}
+		return report.Breakdown[i].Currency.String() < report.Breakdown[j].Currency.String()
+	})
+	sort.Strings(report.Errors)
+	return report, nil
+}
+
+// match returns whether the filter includes the exchange, asset and currency
+func (f *PNLFilter) match(exch string, item asset.Item, code currency.Code) bool {
+	return (f.Exchange == "" || strings.EqualFold(f.Exchange, exch)) &&
+		(f.Asset == asset.Empty || f.Asset == item) &&
+		(f.Currency.IsEmpty() || f.Currency.Equal(code))
+}
+
+// Snapshot values exchange holdings and open futures positions in the
+// reporting currency. The snapshot replaces any earlier snapshot of the same
+// UTC day and is stored in the database when connected
+func (a *AccountingManager) Snapshot(ctx context.Context) (*NAVSnapshot, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&a.started) == 0 {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrSubSystemNotStarted)
+	}
+	if !atomic.CompareAndSwapInt32(&a.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot snapshot nav, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&a.processing, 0)
+
+	exchanges, err := a.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &NAVSnapshot{Time: time.Now().UTC(), ReportingCurrency: a.reportingCurrency}
+	for i := range exchanges {
+		if !exchanges[i].IsRESTAuthenticationSupported() {
+			continue
+		}
+		name := exchanges[i].GetName()
+		assets := exchanges[i].GetAssetTypes(true)
+		for j := range assets {
+			holdings, fetchErr := exchanges[i].FetchAccountInfo(ctx, assets[j])
+			if fetchErr != nil {
+				snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("%s %s: %v", name, assets[j], fetchErr))
+				continue
+			}
+			for k := range holdings.Accounts {
+				for l := range holdings.Accounts[k].Currencies {
+					bal := &holdings.Accounts[k].Currencies[l]
+					total := decimal.NewFromFloat(bal.Total - bal.Borrowed)
+					if total.IsZero() {
+						continue
+					}
+					r, rateErr := a.rate(name, bal.Currency)
+					if rateErr != nil {
+						snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("%s %s %s: %v", name, assets[j], bal.Currency, rateErr))
+						continue
+					}
+					snapshot.NAV = snapshot.NAV.Add(total.Mul(r))
+				}
+			}
+		}
+	}
+
+	day := common.TruncateDay(snapshot.Time)
+	report, err := a.PNLReport(day, snapshot.Time, nil)
+	if err != nil {
+		return nil, err
+	}
+	snapshot.RealisedPNL = report.RealisedPNL
+	snapshot.Fees = report.Fees
+	snapshot.Funding = report.Funding
+	a.m.Lock()
+	for _, p := range a.positions {
+		if p.unrealised.IsZero() {
+			continue
+		}
+		r, rateErr := a.rate(p.exchange, p.settlement)
+		if rateErr != nil {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("%s %s %s: %v", p.exchange, p.asset, p.pair, rateErr))
+			continue
+		}
+		snapshot.UnrealisedPNL = snapshot.UnrealisedPNL.Add(p.unrealised.Mul(r))
+	}
+	snapshot.NAV = snapshot.NAV.Add(snapshot.UnrealisedPNL)
+	sort.Strings(snapshot.Errors)
+	for k, o := range a.orders {
+		if !o.finished.IsZero() && snapshot.Time.Sub(o.finished) > accountingOrderRetention {
+			delete(a.orders, k)
+			a.unsaved.orders[k] = struct{}{}
+		}
+	}
+	if n := len(a.snapshots); n > 0 && common.TruncateDay(a.snapshots[n-1].Time).Equal(day) {
+		a.snapshots[n-1] = *snapshot
+	} else {
+		a.snapshots = append(a.snapshots, *snapshot)
+		if len(a.snapshots) > a.maxSnapshots {
+			a.snapshots = a.snapshots[len(a.snapshots)-a.maxSnapshots:]
+		}
+	}
+	a.m.Unlock()
+	a.save()
+
+	if a.databaseConnected() {
+		nav, _ := snapshot.NAV.Float64()
+		realised, _ := snapshot.RealisedPNL.Float64()
+		unrealised, _ := snapshot.UnrealisedPNL.Float64()
+		fees, _ := snapshot.Fees.Float64()
+		funding, _ := snapshot.Funding.Float64()
+		err = a.snapshotSaver(navsnapshot.Data{
+			Date:              snapshot.Time,
+			ReportingCurrency: snapshot.ReportingCurrency.String(),
+			NAV:               nav,
+			RealisedPNL:       realised,
+			UnrealisedPNL:     unrealised,
+			Fees:              fees,
+			Funding:           funding,
+		})
+		if err != nil {
+			log.Errorf(log.Global, "Accounting manager unable to store nav snapshot: %v", err)
+		}
+	}
+	if a.verbose {
+		log.Debugf(log.Global, "Accounting manager nav %s %s", snapshot.NAV, snapshot.ReportingCurrency)
+		for i := range snapshot.Errors {
+			log.Warnf(log.Global, "Accounting manager: %s", snapshot.Errors[i])
+		}
+	}
+	cpy := *snapshot
+	return &cpy, nil
+}
+
+// NAVHistory returns the daily NAV snapshots between the start and end times.
+// Stored snapshots are returned when the database is connected, otherwise
+// the snapshots taken since the subsystem started
+func (a *AccountingManager) NAVHistory(start, end time.Time) ([]NAVSnapshot, error) {
+	if a == nil {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&a.started) == 0 {
+		return nil, fmt.Errorf("%s %w", AccountingManagerName, ErrSubSystemNotStarted)
+	}
+	if end.IsZero() {
+		end = time.Now()
+	}
+	if end.Before(start) {
+		return nil, errInvalidTimeRange
+	}
+	var resp []NAVSnapshot
+	if a.databaseConnected() {
+		stored, err := a.snapshotLoader(a.reportingCurrency.String(), start, end)
+		if err != nil {
+			return nil, err
+		}
+		resp = make([]NAVSnapshot, len(stored))
+		for i := range stored {
+			resp[i] = NAVSnapshot{
+				Time:              stored[i].Date,
+				ReportingCurrency: currency.NewCode(stored[i].ReportingCurrency),
+				NAV:               decimal.NewFromFloat(stored[i].NAV),
+				RealisedPNL:       decimal.NewFromFloat(stored[i].RealisedPNL),
+				UnrealisedPNL:     decimal.NewFromFloat(stored[i].UnrealisedPNL),
+				Fees:              decimal.NewFromFloat(stored[i].Fees),
+				Funding:           decimal.NewFromFloat(stored[i].Funding),
+			}
+		}
+	} else {
+		a.m.Lock()
+		for i := range a.snapshots {
+			day := common.TruncateDay(a.snapshots[i].Time)
+			if day.Before(common.TruncateDay(start)) || a.snapshots[i].Time.After(end) {
+				continue
+			}
+			s := a.snapshots[i]
+			s.Errors = append([]string(nil), s.Errors...)
+			resp = append(resp, s)
+		}
+		a.m.Unlock()
+	}
+	if len(resp) == 0 {
+		return nil, errNoNAVSnapshots
+	}
+	return resp, nil
+}
+
+// databaseConnected returns whether NAV snapshots and the accounting state
+// can be stored
+func (a *AccountingManager) databaseConnected() bool {
+	if a.dbManager == nil {
+		return false
+	}
+	db := a.dbManager.GetInstance()
+	return db != nil && db.IsConnected()
+}
+
+func newAccountingChanges() accountingChanges {
+	return accountingChanges{
+		books:     make(map[string]struct{}),
+		orders:    make(map[string]struct{}),
+		positions: make(map[string]struct{}),
+	}
+}
+
+// save stores the events, books, orders and positions changed since the last
+// save. Changes are kept and retried on the next save when they cannot be
+// stored, and are discarded while the database is not connected
+func (a *AccountingManager) save() {
+	a.m.Lock()
+	defer a.m.Unlock()
+	if len(a.unsaved.events) == 0 && len(a.unsaved.books) == 0 && len(a.unsaved.orders) == 0 && len(a.unsaved.positions) == 0 {
+		return
+	}
+	if !a.databaseConnected() {
+		a.unsaved = newAccountingChanges()
+		return
+	}
+	c := &accountingsql.Changes{
+		ReportingCurrency: a.reportingCurrency.String(),
+		Events:            make([]accountingsql.Event, len(a.unsaved.events)),
+	}
+	for i := range a.unsaved.events {
+		ev := &a.unsaved.events[i]
+		var side string
+		if ev.Side != order.UnknownSide {
+			side = ev.Side.String()
+		}
+		c.Events[i] = accountingsql.Event{
+			Time:        ev.Time,
+			Kind:        string(ev.Kind),
+			Exchange:    ev.Exchange,
+			Asset:       ev.Asset.String(),
+			Base:        ev.Pair.Base.String(),
+			Quote:       ev.Pair.Quote.String(),
+			OrderID:     ev.OrderID,
+			Side:        side,
+			Amount:      ev.Amount,
+			Value:       ev.Value,
+			RealisedPNL: ev.RealisedPNL,
+			Fees:        ev.Fees,
+			Funding:     ev.Funding,
+			Unmatched:   ev.Unmatched,
+		}
+	}
+	for key := range a.unsaved.books {
+		b, ok := a.books[key]
+		if !ok {
+			continue
+		}
+		lots := b.book.Lots()
+		book := accountingsql.Book{Exchange: b.exchange, Currency: b.currency.String(), Lots: make([]accountingsql.Lot, len(lots))}
+		for i := range lots {
+			book.Lots[i] = accountingsql.Lot{Reference: lots[i].Reference, Acquired: lots[i].Acquired, Amount: lots[i].Amount, Cost: lots[i].Cost}
+		}
+		c.Books = append(c.Books, book)
+	}
+	for key := range a.unsaved.orders {
+		o, ok := a.orders[key]
+		if !ok {
+			c.DeletedOrders = append(c.DeletedOrders, key)
+			continue
+		}
+		c.Orders = append(c.Orders, accountingsql.Order{Key: key, Executed: o.executed, Quote: o.quote, Fee: o.fee, Finished: o.finished})
+	}
+	for key := range a.unsaved.positions {
+		p, ok := a.positions[key]
+		if !ok {
+			c.DeletedPositions = append(c.DeletedPositions, key)
+			continue
+		}
+		c.Positions = append(c.Positions, accountingsql.Position{
+			Key:           key,
+			Exchange:      p.exchange,
+			Asset:         p.asset.String(),
+			Base:          p.pair.Base.String(),
+			Quote:         p.pair.Quote.String(),
+			Settlement:    p.settlement.String(),
+			RealisedGross: p.realisedGross,
+			Fees:          p.fees,
+			Funding:       p.funding,
+		})
+	}
+	if err := a.stateSaver(c); err != nil {
+		log.Errorf(log.Global, "Accounting manager unable to store accounting state: %v", err)
+		if len(a.unsaved.events) > a.maxEvents {
+			a.unsaved.events = a.unsaved.events[len(a.unsaved.events)-a.maxEvents:]
+		}
+		return
+	}
+	a.unsaved = newAccountingChanges()
+}
+
+// restore replaces the in memory state with the stored events, books, orders
+// and positions so that PNL continues from where it was left and fills
+// already booked are not booked again. Books and events are those of the
+// reporting currency
+func (a *AccountingManager) restore() error {
+	s, err := a.stateLoader(a.reportingCurrency.String(), a.maxEvents)
+	if err != nil {
+		return err
+	}
+	events := make([]AccountingEvent, len(s.Events))
+	for i := range s.Events {
+		ev := &s.Events[i]
+		item, err := asset.New(ev.Asset)
+		if err != nil {
+			return fmt.Errorf("event %s: %w", ev.ID, err)
+		}
+		var side order.Side
+		if ev.Side != "" {
+			if side, err = order.StringToOrderSide(ev.Side); err != nil {
+				return fmt.Errorf("event %s: %w", ev.ID, err)
+			}
+		}
+		events[i] = AccountingEvent{
+			Time:        ev.Time,
+			Kind:        AccountingEventKind(ev.Kind),
+			Exchange:    ev.Exchange,
+			Asset:       item,
+			Pair:        currency.NewPair(currency.NewCode(ev.Base), currency.NewCode(ev.Quote)),
+			OrderID:     ev.OrderID,
+			Side:        side,
+			Amount:      ev.Amount,
+			Value:       ev.Value,
+			RealisedPNL: ev.RealisedPNL,
+			Fees:        ev.Fees,
+			Funding:     ev.Funding,
+			Unmatched:   ev.Unmatched,
+		}
+	}
+	books := make(map[string]*accountingBook, len(s.Books))
+	for i := range s.Books {
+		code := currency.NewCode(s.Books[i].Currency)
+		book, err := costbasis.NewBook(a.method)
+		if err != nil {
+			return err
+		}
+		for j := range s.Books[i].Lots {
+			lot := &s.Books[i].Lots[j]
+			if err = book.Acquire(lot.Reference, lot.Acquired, lot.Amount, lot.Cost); err != nil {
+				return fmt.Errorf("%s %s lot %s: %w", s.Books[i].Exchange, code, lot.Reference, err)
+			}
+		}
+		books[accountingBookKey(s.Books[i].Exchange, code)] = &accountingBook{exchange: s.Books[i].Exchange, currency: code, book: book}
+	}
+	orders := make(map[string]*accountingOrder, len(s.Orders))
+	for i := range s.Orders {
+		orders[s.Orders[i].Key] = &accountingOrder{
+			executed: s.Orders[i].Executed,
+			quote:    s.Orders[i].Quote,
+			fee:      s.Orders[i].Fee,
+			finished: s.Orders[i].Finished,
+		}
+	}
+	positions := make(map[string]*accountingPosition, len(s.Positions))
+	for i := range s.Positions {
+		item, err := asset.New(s.Positions[i].Asset)
+		if err != nil {
+			return fmt.Errorf("position %s: %w", s.Positions[i].Key, err)
+		}
+		positions[s.Positions[i].Key] = &accountingPosition{
+			exchange:      s.Positions[i].Exchange,
+			asset:         item,
+			pair:          currency.NewPair(currency.NewCode(s.Positions[i].Base), currency.NewCode(s.Positions[i].Quote)),
+			settlement:    currency.NewCode(s.Positions[i].Settlement),
+			realisedGross: s.Positions[i].RealisedGross,
+			fees:          s.Positions[i].Fees,
+			funding:       s.Positions[i].Funding,
+		}
+	}
+
+	a.m.Lock()
+	a.events, a.books, a.orders, a.positions = events, books, orders, positions
+	a.unsaved = newAccountingChanges()
+	a.m.Unlock()
+	if a.verbose {
+		log.Debugf(log.Global, "Accounting manager restored %d events, %d books, %d orders and %d positions",
+			len(events), len(books), len(orders), len(positions))
+	}
+	return nil
+}
@@ -0,0 +1,609 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	accountingsql "github.com/thrasher-corp/gocryptotrader/database/repository/accounting"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/navsnapshot"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// amExchange aka accounting manager fake exchange returns fixed spot
+// holdings under a custom name
+type amExchange struct {
+	exchange.IBotExchange
+	name     string
+	holdings []account.Balance
+}
+
+func (a *amExchange) GetName() string {
+	return a.name
+}
+
+func (a *amExchange) IsRESTAuthenticationSupported() bool {
+	return true
+}
+
+func (a *amExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Spot}
+}
+
+func (a *amExchange) FetchAccountInfo(context.Context, asset.Item) (account.Holdings, error) {
+	return account.Holdings{
+		Exchange: a.name,
+		Accounts: []account.SubAccount{{AssetType: asset.Spot, Currencies: a.holdings}},
+	}, nil
+}
+
+// newTestAccountingManager returns a started accounting manager reporting in
+// USD without subscribing to an order manager
+func newTestAccountingManager(t *testing.T, em iExchangeManager, method string) *AccountingManager {
+	t.Helper()
+	a, err := SetupAccountingManager(em, &OrderManager{}, nil, &config.Accounting{CostBasisMethod: method, ReportingCurrency: "USD"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	a.started = 1
+	return a
+}
+
+func addAccountingTicker(t *testing.T, exch string, p currency.Pair, last float64) {
+	t.Helper()
+	err := ticker.ProcessTicker(&ticker.Price{ExchangeName: exch, Pair: p, AssetType: asset.Spot, Last: last})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestSetupAccountingManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupAccountingManager(nil, nil, nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupAccountingManager(NewExchangeManager(), nil, nil, nil)
+	if !errors.Is(err, errNilOrderFeed) {
+		t.Errorf("received '%v', expected '%v'", err, errNilOrderFeed)
+	}
+	_, err = SetupAccountingManager(NewExchangeManager(), &OrderManager{}, nil, nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = SetupAccountingManager(NewExchangeManager(), &OrderManager{}, nil, &config.Accounting{CostBasisMethod: "random"})
+	if !errors.Is(err, costbasis.ErrUnknownMethod) {
+		t.Errorf("received '%v', expected '%v'", err, costbasis.ErrUnknownMethod)
+	}
+	a, err := SetupAccountingManager(NewExchangeManager(), &OrderManager{}, nil, &config.Accounting{CostBasisMethod: "lifo"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if a.method != costbasis.LIFO || !a.reportingCurrency.Equal(currency.USD) || a.snapshotInterval != time.Hour || a.maxEvents != 10000 || a.maxSnapshots != 366 {
+		t.Errorf("received '%v %v %v %v %v', expected defaults", a.method, a.reportingCurrency, a.snapshotInterval, a.maxEvents, a.maxSnapshots)
+	}
+}
+
+func TestAccountingManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var a *AccountingManager
+	if err := a.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := a.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if a.IsRunning() {
+		t.Error("nil accounting manager should not be running")
+	}
+
+	a, err := SetupAccountingManager(NewExchangeManager(), &OrderManager{}, nil, &config.Accounting{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = a.Start(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if a.IsRunning() {
+		t.Error("accounting manager should not be running without the order manager")
+	}
+
+	m := offlineOrderManager(t)
+	a, err = SetupAccountingManager(m.orderStore.exchangeManager, m, nil, &config.Accounting{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = a.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = a.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !a.IsRunning() {
+		t.Error("accounting manager should be running")
+	}
+
+	// orders added to the order manager are booked by the ingest routine
+	err = m.Add(&order.Detail{
+		Exchange:       testExchange,
+		OrderID:        "1",
+		AssetType:      asset.Spot,
+		Pair:           currency.NewPair(currency.BTC, currency.USD),
+		Side:           order.Buy,
+		Status:         order.Filled,
+		Amount:         1,
+		ExecutedAmount: 1,
+		Price:          100,
+		LastUpdated:    time.Now(),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for i := 0; i < 100; i++ {
+		report, reportErr := a.PNLReport(time.Time{}, time.Time{}, nil)
+		if !errors.Is(reportErr, nil) {
+			t.Fatalf("received '%v', expected '%v'", reportErr, nil)
+		}
+		if len(report.Breakdown) == 1 {
+			break
+		}
+		time.Sleep(time.Millisecond * 10)
+	}
+	a.m.Lock()
+	books := len(a.books)
+	a.m.Unlock()
+	if books != 1 {
+		t.Errorf("received '%v', expected '%v'", books, 1)
+	}
+
+	if err = a.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err = a.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestAccountingManagerRate(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	for _, name := range []string{"amratealpha", "amratebeta"} {
+		if err := em.Add(&amExchange{name: name}); !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+	}
+	addAccountingTicker(t, "amratealpha", currency.NewPair(currency.BTC, currency.USDT), 20000)
+	addAccountingTicker(t, "amratealpha", currency.NewPair(currency.USD, currency.XRP), 2)
+	addAccountingTicker(t, "amratebeta", currency.NewPair(currency.ETH, currency.USDC), 1000)
+	a := newTestAccountingManager(t, em, "")
+
+	for _, tc := range []struct {
+		code     currency.Code
+		expected float64
+	}{
+		{code: currency.USD, expected: 1},
+		{code: currency.USDC, expected: 1},
+		// inverse ticker
+		{code: currency.XRP, expected: 0.5},
+		// routed through USDT at par
+		{code: currency.BTC, expected: 20000},
+		// routed through USDC using another exchange
+		{code: currency.ETH, expected: 1000},
+	} {
+		r, err := a.rate("amratealpha", tc.code)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if !r.Equal(decimal.NewFromFloat(tc.expected)) {
+			t.Errorf("%s received '%v', expected '%v'", tc.code, r, tc.expected)
+		}
+	}
+	if _, err := a.rate("amratealpha", currency.LTC); !errors.Is(err, errNoConversionRate) {
+		t.Errorf("received '%v', expected '%v'", err, errNoConversionRate)
+	}
+}
+
+func TestDirectRate(t *testing.T) {
+	t.Parallel()
+	addAccountingTicker(t, "amdirectrate", currency.NewPair(currency.BTC, currency.USDT), 20000)
+	for _, tc := range []struct {
+		from, to currency.Code
+		expected float64
+		ok       bool
+	}{
+		{from: currency.BTC, to: currency.BTC, expected: 1, ok: true},
+		{from: currency.USDT, to: currency.USD, expected: 1, ok: true},
+		{from: currency.USD, to: currency.USDC, expected: 1, ok: true},
+		{from: currency.BTC, to: currency.USDT, expected: 20000, ok: true},
+		{from: currency.USDT, to: currency.BTC, expected: 0.00005, ok: true},
+		// no ticker and no route through other exchanges
+		{from: currency.BTC, to: currency.USD},
+	} {
+		r, ok := directRate("amdirectrate", tc.from, tc.to)
+		if ok != tc.ok {
+			t.Fatalf("%s-%s received '%v', expected '%v'", tc.from, tc.to, ok, tc.ok)
+		}
+		if ok && !r.Equal(decimal.NewFromFloat(tc.expected)) {
+			t.Errorf("%s-%s received '%v', expected '%v'", tc.from, tc.to, r, tc.expected)
+		}
+	}
+}
+
+func TestAccountingManagerSpotFills(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	if err := em.Add(&amExchange{name: "amspot"}); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	addAccountingTicker(t, "amspot", pair, 20000)
+	a := newTestAccountingManager(t, em, "fifo")
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	buy := order.Detail{
+		Exchange:             "amspot",
+		OrderID:              "buy",
+		AssetType:            asset.Spot,
+		Pair:                 pair,
+		Side:                 order.Buy,
+		Status:               order.PartiallyFilled,
+		Amount:               1,
+		ExecutedAmount:       0.5,
+		AverageExecutedPrice: 10000,
+		Fee:                  5,
+		FeeAsset:             currency.USDT,
+		LastUpdated:          start,
+	}
+	filled := buy
+	filled.Status = order.Filled
+	filled.ExecutedAmount = 1
+	filled.Fee = 10
+	filled.LastUpdated = start.Add(time.Minute)
+	sell := order.Detail{
+		Exchange:             "amspot",
+		OrderID:              "sell",
+		AssetType:            asset.Spot,
+		Pair:                 pair,
+		Side:                 order.Sell,
+		Status:               order.Filled,
+		Amount:               0.5,
+		ExecutedAmount:       0.5,
+		AverageExecutedPrice: 12000,
+		Fee:                  6,
+		LastUpdated:          start.Add(time.Hour),
+	}
+	last := a.process([]sequencedEvent{
+		{Sequence: 1, Data: buy},
+		{Sequence: 2, Data: filled},
+		// repeated updates are not booked twice
+		{Sequence: 2, Data: filled},
+		{Sequence: 3, Data: filled},
+		{Sequence: 4, Data: sell},
+		{Sequence: 5, Data: "bad"},
+		{Sequence: 6, Data: order.Detail{Exchange: "amspot", OrderID: "futures", AssetType: asset.Futures, Pair: pair, Amount: 1, ExecutedAmount: 1}},
+	}, 0)
+	if last != 6 {
+		t.Errorf("received '%v', expected '%v'", last, 6)
+	}
+	for k, o := range a.orders {
+		if o.finished.IsZero() {
+			t.Errorf("order %s should be finished", k)
+		}
+	}
+	if len(a.events) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(a.events), 3)
+	}
+	// the buy disposes of USDT which was held before the manager started
+	if !a.events[0].Unmatched.Equal(decimal.NewFromInt(5005)) {
+		t.Errorf("received '%v', expected '%v'", a.events[0].Unmatched, 5005)
+	}
+
+	report, err := a.PNLReport(start, start.Add(time.Hour*2), nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// sell gain 1000, less fees of 10 on the buy and 6 on the sell
+	if !report.RealisedPNL.Equal(decimal.NewFromInt(984)) {
+		t.Errorf("received '%v', expected '%v'", report.RealisedPNL, 984)
+	}
+	if !report.Fees.Equal(decimal.NewFromInt(16)) {
+		t.Errorf("received '%v', expected '%v'", report.Fees, 16)
+	}
+	// 0.5 BTC held at a cost of 5000 is worth 10000
+	if !report.UnrealisedPNL.Equal(decimal.NewFromInt(5000)) {
+		t.Errorf("received '%v', expected '%v'", report.UnrealisedPNL, 5000)
+	}
+	if !report.TotalPNL.Equal(decimal.NewFromInt(5984)) {
+		t.Errorf("received '%v', expected '%v'", report.TotalPNL, 5984)
+	}
+	if len(report.Breakdown) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(report.Breakdown), 2)
+	}
+	btc := report.Breakdown[0]
+	if !btc.Currency.Equal(currency.BTC) || !btc.Amount.Equal(decimal.NewFromFloat(0.5)) || !btc.CostBasis.Equal(decimal.NewFromInt(5000)) || !btc.MarketValue.Equal(decimal.NewFromInt(10000)) {
+		t.Errorf("received '%+v', expected 0.5 BTC at a cost of 5000", btc)
+	}
+	// USDT received from the sale less the fee
+	usdt := report.Breakdown[1]
+	if !usdt.Currency.Equal(currency.USDT) || !usdt.Amount.Equal(decimal.NewFromInt(5994)) || !usdt.UnrealisedPNL.IsZero() {
+		t.Errorf("received '%+v', expected 5994 USDT", usdt)
+	}
+
+	report, err = a.PNLReport(start.Add(time.Minute*30), start.Add(time.Hour*2), &PNLFilter{Exchange: "AMSPOT", Asset: asset.Spot, Currency: currency.BTC})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(report.Breakdown) != 1 || !report.RealisedPNL.Equal(decimal.NewFromInt(994)) {
+		t.Errorf("received '%v', expected '%v'", report.RealisedPNL, 994)
+	}
+
+	_, err = a.PNLReport(start, start.Add(-time.Hour), nil)
+	if !errors.Is(err, errInvalidTimeRange) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidTimeRange)
+	}
+	a.started = 0
+	_, err = a.PNLReport(start, start, nil)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestAccountingManagerPositions(t *testing.T) {
+	t.Parallel()
+	a := newTestAccountingManager(t, NewExchangeManager(), "")
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	opened := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	pos := order.Position{
+		Exchange:      "ampositions",
+		Asset:         asset.Futures,
+		Pair:          pair,
+		Status:        order.Open,
+		OpeningDate:   opened,
+		LastUpdated:   opened,
+		UnrealisedPNL: decimal.NewFromInt(50),
+		PNLHistory: []order.PNLResult{
+			{IsOrder: true, Fee: decimal.NewFromInt(2)},
+		},
+	}
+	if err := a.processPosition(&pos); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pos.LastUpdated = opened.Add(time.Hour)
+	pos.PNLHistory = append(pos.PNLHistory, order.PNLResult{IsOrder: true, RealisedPNLBeforeFees: decimal.NewFromInt(100), Fee: decimal.NewFromInt(3)})
+	pos.FundingRates = fundingrate.Rates{PaymentSum: decimal.NewFromInt(-4)}
+	if err := a.processPosition(&pos); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// unchanged positions add no events
+	if err := a.processPosition(&pos); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(a.events) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(a.events), 3)
+	}
+
+	report, err := a.PNLReport(opened, opened.Add(time.Hour), nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !report.RealisedPNL.Equal(decimal.NewFromInt(95)) || !report.Fees.Equal(decimal.NewFromInt(5)) || !report.Funding.Equal(decimal.NewFromInt(-4)) {
+		t.Errorf("received '%v %v %v', expected '95 5 -4'", report.RealisedPNL, report.Fees, report.Funding)
+	}
+	if !report.UnrealisedPNL.Equal(decimal.NewFromInt(50)) || !report.TotalPNL.Equal(decimal.NewFromInt(141)) {
+		t.Errorf("received '%v %v', expected '50 141'", report.UnrealisedPNL, report.TotalPNL)
+	}
+
+	pos.Status = order.Closed
+	pos.UnrealisedPNL = decimal.Zero
+	if err = a.processPosition(&pos); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(a.positions) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(a.positions), 0)
+	}
+
+	a.maxEvents = 2
+	a.addEvent(&AccountingEvent{Time: opened})
+	if len(a.events) != 2 {
+		t.Errorf("received '%v', expected '%v'", len(a.events), 2)
+	}
+}
+
+func TestAccountingManagerSnapshot(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	err := em.Add(&amExchange{name: "amsnapshot", holdings: []account.Balance{
+		{Currency: currency.BTC, Total: 2, Borrowed: 1},
+		{Currency: currency.USDT, Total: 500},
+		{Currency: currency.LTC, Total: 1},
+	}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	addAccountingTicker(t, "amsnapshot", currency.NewPair(currency.BTC, currency.USDT), 20000)
+	a := newTestAccountingManager(t, em, "")
+	a.positions["open"] = &accountingPosition{exchange: "amsnapshot", asset: asset.Futures, pair: currency.NewPair(currency.BTC, currency.USDT), settlement: currency.USDT, unrealised: decimal.NewFromInt(-100)}
+
+	_, err = a.NAVHistory(time.Time{}, time.Time{})
+	if !errors.Is(err, errNoNAVSnapshots) {
+		t.Errorf("received '%v', expected '%v'", err, errNoNAVSnapshots)
+	}
+	snapshot, err := a.Snapshot(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// 1 BTC net of borrowing, 500 USDT and the unrealised futures loss
+	if !snapshot.NAV.Equal(decimal.NewFromInt(20400)) {
+		t.Errorf("received '%v', expected '%v'", snapshot.NAV, 20400)
+	}
+	if !snapshot.UnrealisedPNL.Equal(decimal.NewFromInt(-100)) {
+		t.Errorf("received '%v', expected '%v'", snapshot.UnrealisedPNL, -100)
+	}
+	// LTC has no rate
+	if len(snapshot.Errors) != 1 {
+		t.Errorf("received '%v', expected one error", snapshot.Errors)
+	}
+	// snapshots on the same day replace each other
+	if _, err = a.Snapshot(context.Background()); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	history, err := a.NAVHistory(time.Now().Add(-time.Hour), time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(history) != 1 || !history[0].NAV.Equal(decimal.NewFromInt(20400)) {
+		t.Errorf("received '%+v', expected one snapshot", history)
+	}
+	_, err = a.NAVHistory(time.Now(), time.Now().Add(-time.Hour))
+	if !errors.Is(err, errInvalidTimeRange) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidTimeRange)
+	}
+
+	// in memory snapshots are limited separately to events
+	a.maxEvents = 1
+	a.maxSnapshots = 2
+	a.snapshots = []NAVSnapshot{{Time: time.Now().AddDate(0, 0, -2)}, {Time: time.Now().AddDate(0, 0, -1)}}
+	if _, err = a.Snapshot(context.Background()); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(a.snapshots) != 2 || !a.snapshots[1].NAV.Equal(decimal.NewFromInt(20400)) {
+		t.Errorf("received '%+v', expected the latest two snapshots", a.snapshots)
+	}
+
+	var saved []navsnapshot.Data
+	a.dbManager = &cbDatabase{}
+	a.snapshotSaver = func(d ...navsnapshot.Data) error {
+		saved = append(saved, d...)
+		return nil
+	}
+	a.snapshotLoader = func(reportingCurrency string, _, _ time.Time) ([]navsnapshot.Data, error) {
+		return []navsnapshot.Data{{ReportingCurrency: reportingCurrency, NAV: 1}}, nil
+	}
+	if _, err = a.Snapshot(context.Background()); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(saved) != 1 || saved[0].NAV != 20400 || saved[0].ReportingCurrency != "USD" {
+		t.Errorf("received '%+v', expected a stored snapshot", saved)
+	}
+	history, err = a.NAVHistory(time.Now().Add(-time.Hour), time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(history) != 1 || !history[0].NAV.Equal(decimal.NewFromInt(1)) || !history[0].ReportingCurrency.Equal(currency.USD) {
+		t.Errorf("received '%+v', expected the stored snapshot", history)
+	}
+}
+
+func TestAccountingManagerPersistence(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	if err := em.Add(&amExchange{name: "ampersist"}); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	addAccountingTicker(t, "ampersist", pair, 20000)
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	buy := order.Detail{
+		Exchange:             "ampersist",
+		OrderID:              "buy",
+		AssetType:            asset.Spot,
+		Pair:                 pair,
+		Side:                 order.Buy,
+		Status:               order.PartiallyFilled,
+		Amount:               1,
+		ExecutedAmount:       0.5,
+		AverageExecutedPrice: 10000,
+		LastUpdated:          start,
+	}
+	pos := order.Position{
+		Exchange:     "ampersist",
+		Asset:        asset.Futures,
+		Pair:         pair,
+		Status:       order.Open,
+		OpeningDate:  start,
+		LastUpdated:  start,
+		FundingRates: fundingrate.Rates{PaymentSum: decimal.NewFromInt(-4)},
+	}
+
+	a := newTestAccountingManager(t, em, "fifo")
+	a.process([]sequencedEvent{{Sequence: 1, Data: buy}, {Sequence: 2, Data: pos}}, 0)
+	if len(a.unsaved.events) != 0 {
+		t.Errorf("received '%v', expected changes to be discarded without a database", len(a.unsaved.events))
+	}
+
+	a = newTestAccountingManager(t, em, "fifo")
+	a.dbManager = &cbDatabase{}
+	errSave := errors.New("save failed")
+	a.stateSaver = func(*accountingsql.Changes) error { return errSave }
+	a.process([]sequencedEvent{{Sequence: 1, Data: buy}, {Sequence: 2, Data: pos}}, 0)
+	if len(a.unsaved.events) != 2 || len(a.unsaved.books) != 2 || len(a.unsaved.orders) != 1 || len(a.unsaved.positions) != 1 {
+		t.Fatalf("received '%+v', expected changes to be kept when they cannot be stored", a.unsaved)
+	}
+	var stored *accountingsql.Changes
+	a.stateSaver = func(c *accountingsql.Changes) error {
+		stored = c
+		return nil
+	}
+	a.save()
+	if stored == nil || len(stored.Events) != 2 || len(stored.Books) != 2 || len(stored.Orders) != 1 || len(stored.Positions) != 1 {
+		t.Fatalf("received '%+v', expected the changes to be stored", stored)
+	}
+	if stored.ReportingCurrency != "USD" || !stored.Orders[0].Executed.Equal(decimal.NewFromFloat(0.5)) ||
+		!stored.Positions[0].Funding.Equal(decimal.NewFromInt(-4)) {
+		t.Errorf("received '%+v', expected the order and position progress", stored)
+	}
+	if len(a.unsaved.events) != 0 || len(a.unsaved.orders) != 0 {
+		t.Errorf("received '%+v', expected no unsaved changes", a.unsaved)
+	}
+
+	// a restarted manager continues from the stored state and does not book
+	// the fills or funding again
+	b := newTestAccountingManager(t, em, "fifo")
+	b.stateLoader = func(reportingCurrency string, maxEvents int) (*accountingsql.State, error) {
+		if reportingCurrency != "USD" || maxEvents != b.maxEvents {
+			t.Errorf("received '%v %v', expected '%v %v'", reportingCurrency, maxEvents, "USD", b.maxEvents)
+		}
+		return &accountingsql.State{Events: stored.Events, Books: stored.Books, Orders: stored.Orders, Positions: stored.Positions}, nil
+	}
+	if err := b.restore(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(b.events) != 2 || len(b.books) != 2 || len(b.orders) != 1 || len(b.positions) != 1 {
+		t.Fatalf("received '%v %v %v %v', expected the stored state", len(b.events), len(b.books), len(b.orders), len(b.positions))
+	}
+	b.process([]sequencedEvent{{Sequence: 1, Data: buy}, {Sequence: 2, Data: pos}}, 0)
+	if len(b.events) != 2 {
+		t.Errorf("received '%v', expected '%v'", len(b.events), 2)
+	}
+	if amount := b.books[accountingBookKey("ampersist", currency.BTC)].book.Amount(); !amount.Equal(decimal.NewFromFloat(0.5)) {
+		t.Errorf("received '%v', expected '%v'", amount, 0.5)
+	}
+
+	// positions no longer held are deleted from the database
+	b.dbManager = &cbDatabase{}
+	b.stateSaver = func(c *accountingsql.Changes) error {
+		stored = c
+		return nil
+	}
+	pos.Status = order.Closed
+	b.process([]sequencedEvent{{Sequence: 1, Data: pos}}, 0)
+	if len(stored.DeletedPositions) != 1 || len(stored.Positions) != 0 {
+		t.Errorf("received '%+v', expected the position to be deleted", stored)
+	}
+
+	errLoad := errors.New("load failed")
+	b.stateLoader = func(string, int) (*accountingsql.State, error) { return nil, errLoad }
+	if err := b.restore(); !errors.Is(err, errLoad) {
+		t.Errorf("received '%v', expected '%v'", err, errLoad)
+	}
+}
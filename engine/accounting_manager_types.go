@@ -0,0 +1,223 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	accountingsql "github.com/thrasher-corp/gocryptotrader/database/repository/accounting"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/navsnapshot"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// AccountingManagerName is an exported subsystem name
+const AccountingManagerName = "accounting_manager"
+
+// accountingOrderRetention is how long a finished order is remembered so
+// that repeated updates of it are not booked again
+const accountingOrderRetention = time.Hour * 24
+
+// AccountingEventKind describes what caused an accounting event
+type AccountingEventKind string
+
+// Accounting event kinds
+const (
+	// AccountingTrade is a spot fill or a change in the realised PNL of a
+	// futures position
+	AccountingTrade AccountingEventKind = "trade"
+	// AccountingFunding is a funding payment of a futures position
+	AccountingFunding AccountingEventKind = "funding"
+)
+
+var (
+	errNoConversionRate = errors.New("no conversion rate")
+	errInvalidTimeRange = errors.New("end time cannot be before start time")
+	errNilOrderFeed     = errors.New("order feed is nil")
+	errNoNAVSnapshots   = errors.New("no nav snapshots")
+)
+
+// accountingUSDStables are valued at par with USD when no market rate is
+// available
+var accountingUSDStables = currency.Currencies{
+	currency.USDT,
+	currency.USDC,
+	currency.BUSD,
+	currency.TUSD,
+	currency.DAI,
+	currency.USDP,
+}
+
+// accountingIntermediaries are the currencies a value is routed through when
+// there is no direct rate to the reporting currency
+var accountingIntermediaries = []currency.Code{currency.USDT, currency.USDC, currency.USD, currency.BTC}
+
+// accountingOrderFeed is the order manager feed the accounting manager
+// ingests
+type accountingOrderFeed interface {
+	subscribeOrderUpdates(uint64) (*feedSubscription, error)
+	subscribePositions(uint64) (*feedSubscription, error)
+}
+
+// AccountingManager ingests spot fills and futures positions from the order
+// manager and keeps a cost basis book per exchange and currency. Realised
+// PNL, fees and funding payments are recorded as events valued in the
+// reporting currency at the time they occur. Net asset value is sampled on an
+// interval and the latest sample of each day is stored in the database. When
+// the database is connected the events, cost basis lots and booking progress
+// are stored as they change and restored on start
+type AccountingManager struct {
+	started           int32
+	processing        int32
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	m                 sync.Mutex
+	exchangeManager   iExchangeManager
+	orderFeed         accountingOrderFeed
+	dbManager         iDatabaseConnectionManager
+	method            costbasis.Method
+	reportingCurrency currency.Code
+	snapshotInterval  time.Duration
+	maxEvents         int
+	maxSnapshots      int
+	verbose           bool
+
+	orders    map[string]*accountingOrder
+	books     map[string]*accountingBook
+	positions map[string]*accountingPosition
+	events    []AccountingEvent
+	snapshots []NAVSnapshot
+	unsaved   accountingChanges
+
+	snapshotSaver  func(...navsnapshot.Data) error
+	snapshotLoader func(string, time.Time, time.Time) ([]navsnapshot.Data, error)
+	stateSaver     func(*accountingsql.Changes) error
+	stateLoader    func(string, int) (*accountingsql.State, error)
+}
+
+// accountingChanges are the events and the keys of the books, orders and
+// positions changed since the state was last stored. Orders and positions
+// which are no longer held are deleted from the database
+type accountingChanges struct {
+	events    []AccountingEvent
+	books     map[string]struct{}
+	orders    map[string]struct{}
+	positions map[string]struct{}
+}
+
+// accountingOrder is the cumulative execution of a spot order which has
+// already been booked
+type accountingOrder struct {
+	executed decimal.Decimal
+	quote    decimal.Decimal
+	fee      decimal.Decimal
+	finished time.Time
+}
+
+// accountingBook is the cost basis book of a currency held on an exchange
+type accountingBook struct {
+	exchange string
+	currency currency.Code
+	book     *costbasis.Book
+}
+
+// accountingPosition is the realised PNL, fees and funding of a futures
+// position which have already been recorded
+type accountingPosition struct {
+	exchange      string
+	asset         asset.Item
+	pair          currency.Pair
+	settlement    currency.Code
+	realisedGross decimal.Decimal
+	fees          decimal.Decimal
+	funding       decimal.Decimal
+	unrealised    decimal.Decimal
+}
+
+// accountingLeg is an amount of a currency acquired or disposed of by a fill
+// and its value in the reporting currency
+type accountingLeg struct {
+	currency currency.Code
+	amount   decimal.Decimal
+	value    decimal.Decimal
+}
+
+// AccountingEvent is a realised change in PNL. Values are held in the
+// reporting currency converted at the time of the event. RealisedPNL is net of
+// fees and excludes funding
+type AccountingEvent struct {
+	Time     time.Time
+	Kind     AccountingEventKind
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	OrderID  string
+	Side     order.Side
+	Amount   decimal.Decimal
+	// Value is the gross value of the fill
+	Value       decimal.Decimal
+	RealisedPNL decimal.Decimal
+	Fees        decimal.Decimal
+	Funding     decimal.Decimal
+	// Unmatched is the amount disposed of which had no cost basis, such as
+	// holdings acquired before the accounting manager started. No PNL is
+	// realised for it
+	Unmatched decimal.Decimal
+}
+
+// PNLFilter limits a PNL report to an exchange, asset or currency
+type PNLFilter struct {
+	Exchange string
+	Asset    asset.Item
+	Currency currency.Code
+}
+
+// PNLReport holds the PNL of a time range in the reporting currency.
+// Realised PNL, fees and funding are the events within the range, unrealised
+// PNL is as at the time of the report
+type PNLReport struct {
+	Start             time.Time
+	End               time.Time
+	ReportingCurrency currency.Code
+	CostBasisMethod   costbasis.Method
+	RealisedPNL       decimal.Decimal
+	UnrealisedPNL     decimal.Decimal
+	Fees              decimal.Decimal
+	Funding           decimal.Decimal
+	TotalPNL          decimal.Decimal
+	Breakdown         []PNLBreakdown
+	Errors            []string
+}
+
+// PNLBreakdown is the PNL of a currency on an exchange asset. Amount,
+// CostBasis and MarketValue are the open spot holdings being tracked
+type PNLBreakdown struct {
+	Exchange      string
+	Asset         asset.Item
+	Currency      currency.Code
+	Amount        decimal.Decimal
+	CostBasis     decimal.Decimal
+	MarketValue   decimal.Decimal
+	RealisedPNL   decimal.Decimal
+	UnrealisedPNL decimal.Decimal
+	Fees          decimal.Decimal
+	Funding       decimal.Decimal
+	Unmatched     decimal.Decimal
+}
+
+// NAVSnapshot is the net asset value at a point in time, the value of
+// exchange holdings plus the unrealised PNL of futures positions. Realised
+// PNL, fees and funding are those of the UTC day of the snapshot
+type NAVSnapshot struct {
+	Time              time.Time
+	ReportingCurrency currency.Code
+	NAV               decimal.Decimal
+	RealisedPNL       decimal.Decimal
+	UnrealisedPNL     decimal.Decimal
+	Fees              decimal.Decimal
+	Funding           decimal.Decimal
+	Errors            []string
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"runtime"
+	"sort"
 	"strconv"
 	"strings"
 	"sync/atomic"
@@ -21,6 +22,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
 )
 
 // setupAPIServerManager checks and creates an api server manager
@@ -469,7 +471,7 @@ func (h *websocketHub) run() {
 			if _, ok := h.Clients[client]; ok {
 				log.Debugln(log.APIServerMgr, "websocket: disconnected client")
 				delete(h.Clients, client)
-				close(client.Send)
+				client.closeSend()
 			}
 		case message := <-h.Broadcast:
 			for client := range h.Clients {
@@ -477,7 +479,7 @@ func (h *websocketHub) run() {
 				case client.Send <- message:
 				default:
 					log.Debugln(log.APIServerMgr, "websocket: disconnected client")
-					close(client.Send)
+					client.closeSend()
 					delete(h.Clients, client)
 				}
 			}
@@ -499,6 +501,7 @@ func (c *websocketClient) SendWebsocketMessage(evt interface{}) error {
 
 func (c *websocketClient) read() {
 	defer func() {
+		c.unsubscribeAll()
 		c.Hub.Unregister <- c
 		conErr := c.Conn.Close()
 		if conErr != nil {
@@ -534,31 +537,52 @@ func (c *websocketClient) read() {
 				break
 			}
 
-			req := strings.ToLower(evt.Event)
-			log.Debugf(log.APIServerMgr, "websocket: request received: %s\n", req)
-
-			result, ok := wsHandlers[req]
-			if !ok {
+			err = c.handleEvent(evt.Event, dataJSON)
+			switch {
+			case errors.Is(err, errUnsupportedWebsocketEvent):
 				log.Debugln(log.APIServerMgr, "websocket: unsupported event")
-				continue
+			case err != nil:
+				log.Errorf(log.APIServerMgr, "websocket: request %s failed. Error %s\n", evt.Event, err)
 			}
+		}
+	}
+}
 
-			if result.authRequired && !c.Authenticated {
-				log.Warnf(log.APIServerMgr, "Websocket: request %s failed due to unauthenticated request on an authenticated API\n", evt.Event)
-				err = c.SendWebsocketMessage(WebsocketEventResponse{Event: evt.Event, Error: "unauthorised request on authenticated API"})
-				if err != nil {
-					log.Errorln(log.APIServerMgr, err)
-				}
-				continue
-			}
+// handleEvent runs the handler of an event once the client is authenticated
+// as a user whose role is permitted to send it
+func (c *websocketClient) handleEvent(event string, data []byte) error {
+	req := strings.ToLower(event)
+	log.Debugf(log.APIServerMgr, "websocket: request received: %s\n", req)
 
-			err = result.handler(c, dataJSON)
-			if err != nil {
-				log.Errorf(log.APIServerMgr, "websocket: request %s failed. Error %s\n", evt.Event, err)
-				continue
-			}
+	result, ok := wsHandlers[req]
+	if !ok {
+		return fmt.Errorf("%w %s", errUnsupportedWebsocketEvent, req)
+	}
+
+	if result.authRequired && !c.Authenticated {
+		log.Warnf(log.APIServerMgr, "Websocket: request %s failed due to unauthenticated request on an authenticated API\n", event)
+		err := c.SendWebsocketMessage(WebsocketEventResponse{Event: event, Error: "unauthorised request on authenticated API"})
+		if err != nil {
+			log.Errorln(log.APIServerMgr, err)
+		}
+		return nil
+	}
+
+	if result.roles != 0 && (c.user == nil || c.user.Role&result.roles == 0) {
+		var username string
+		var role rpcRole
+		if c.user != nil {
+			username, role = c.user.Username, c.user.Role
+		}
+		err := fmt.Errorf("%w %s cannot call %s as %s", errRPCMethodNotPermitted, username, req, role)
+		sendErr := c.SendWebsocketMessage(WebsocketEventResponse{Event: event, Error: err.Error()})
+		if sendErr != nil {
+			log.Errorln(log.APIServerMgr, sendErr)
 		}
+		return err
 	}
+
+	return result.handler(c, data)
 }
 
 func (c *websocketClient) write() {
@@ -673,6 +697,7 @@ func (m *apiServerManager) WebsocketClientHandler(w http.ResponseWriter, r *http
 		username:         m.remoteConfig.Username,
 		password:         m.remoteConfig.Password,
 		configPath:       m.gctConfigPath,
+		users:            m.remoteConfig.Users,
 		exchangeManager:  m.exchangeManager,
 		bot:              m.bot,
 		portfolioManager: m.portfolioManager,
@@ -715,10 +740,18 @@ func wsAuth(client *websocketClient, data interface{}) error {
 
 	hashPW := crypto.HexEncodeToString(hash)
 	if auth.Username == client.username && auth.Password == hashPW {
+		client.user = &rpcUser{Username: client.username, Role: rpcRoleAdmin}
+	} else {
+		client.user, err = client.identifyUser(&auth)
+		if err != nil {
+			return err
+		}
+	}
+	if client.user != nil {
 		client.Authenticated = true
 		wsResp.Data = WebsocketResponseSuccess
-		log.Debugln(log.APIServerMgr,
-			"websocket: client authenticated successfully")
+		log.Debugf(log.APIServerMgr,
+			"websocket: client authenticated successfully as %s role %s\n", client.user.Username, client.user.Role)
 		return client.SendWebsocketMessage(wsResp)
 	}
 
@@ -742,6 +775,27 @@ func wsAuth(client *websocketClient, data interface{}) error {
 	return nil
 }
 
+// identifyUser returns the remote control user matching the username and
+// SHA256 hashed password or token, nil is returned when no user matches
+func (c *websocketClient) identifyUser(auth *WebsocketAuth) (*rpcUser, error) {
+	for i := range c.users {
+		if c.users[i].Token != "" && auth.Token != "" && secureCompare(auth.Token, c.users[i].Token) {
+			return newRPCUserFromConfig(&c.users[i])
+		}
+		if c.users[i].Password == "" || !secureCompare(auth.Username, c.users[i].Username) {
+			continue
+		}
+		hash, err := crypto.GetSHA256([]byte(c.users[i].Password))
+		if err != nil {
+			return nil, err
+		}
+		if secureCompare(auth.Password, crypto.HexEncodeToString(hash)) {
+			return newRPCUserFromConfig(&c.users[i])
+		}
+	}
+	return nil, nil
+}
+
 func wsGetConfig(client *websocketClient, _ interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetConfig",
@@ -795,7 +849,7 @@ func wsSaveConfig(client *websocketClient, data interface{}) error {
 }
 
 func wsGetAccountInfo(client *websocketClient, _ interface{}) error {
-	accountInfo := getAllActiveAccounts(client.exchangeManager)
+	accountInfo := scopeAccounts(getAllActiveAccounts(client.exchangeManager), client.user)
 	wsResp := WebsocketEventResponse{
 		Event: "GetAccountInfo",
 		Data:  accountInfo,
@@ -803,6 +857,27 @@ func wsGetAccountInfo(client *websocketClient, _ interface{}) error {
 	return client.SendWebsocketMessage(wsResp)
 }
 
+// scopeAccounts removes the holdings of exchanges outside of the user's
+// exchange scope
+func scopeAccounts(accounts []AllEnabledExchangeAccounts, u *rpcUser) []AllEnabledExchangeAccounts {
+	if u == nil || len(u.Exchanges) == 0 {
+		return accounts
+	}
+	scoped := make([]AllEnabledExchangeAccounts, 0, len(accounts))
+	for i := range accounts {
+		var holdings AllEnabledExchangeAccounts
+		for j := range accounts[i].Data {
+			if u.permitsExchange(accounts[i].Data[j].Exchange) {
+				holdings.Data = append(holdings.Data, accounts[i].Data[j])
+			}
+		}
+		if len(holdings.Data) > 0 {
+			scoped = append(scoped, holdings)
+		}
+	}
+	return scoped
+}
+
 func wsGetTickers(client *websocketClient, _ interface{}) error {
 	wsResp := WebsocketEventResponse{
 		Event: "GetTickers",
@@ -942,6 +1017,46 @@ func wsGetPortfolio(client *websocketClient, _ interface{}) error {
 		Event: "GetPortfolio",
 	}
 
-	wsResp.Data = client.portfolioManager.GetPortfolioSummary()
+	wsResp.Data = scopePortfolioSummary(client.portfolioManager.GetPortfolioSummary(), client.user)
 	return client.SendWebsocketMessage(wsResp)
 }
+
+// scopePortfolioSummary limits a portfolio summary to the exchange holdings
+// in the user's exchange scope. Offline addresses are not tied to an exchange
+// so they are removed and the totals and percentages are rebuilt from the
+// remaining holdings
+func scopePortfolioSummary(s portfolio.Summary, u *rpcUser) portfolio.Summary {
+	if u == nil || len(u.Exchanges) == 0 {
+		return s
+	}
+	scoped := portfolio.Summary{
+		OnlineSummary: make(map[string]map[currency.Code]portfolio.OnlineCoinSummary),
+	}
+	totals := make(map[currency.Code]float64)
+	for exch, coins := range s.OnlineSummary {
+		if !u.permitsExchange(exch) {
+			continue
+		}
+		scoped.OnlineSummary[exch] = make(map[currency.Code]portfolio.OnlineCoinSummary, len(coins))
+		for code, coin := range coins {
+			totals[code] += coin.Balance
+			scoped.OnlineSummary[exch][code] = portfolio.OnlineCoinSummary{Balance: coin.Balance}
+		}
+	}
+	for exch, coins := range scoped.OnlineSummary {
+		for code, coin := range coins {
+			if totals[code] > 0 {
+				coin.Percentage = coin.Balance / totals[code] * 100
+			}
+			scoped.OnlineSummary[exch][code] = coin
+		}
+	}
+	for code, balance := range totals {
+		scoped.Online = append(scoped.Online, portfolio.Coin{Coin: code, Balance: balance})
+	}
+	sort.Slice(scoped.Online, func(i, j int) bool {
+		return scoped.Online[i].Coin.String() < scoped.Online[j].Coin.String()
+	})
+	scoped.Totals = append([]portfolio.Coin(nil), scoped.Online...)
+	return scoped
+}
@@ -0,0 +1,555 @@
+package engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+func wsSubscribe(client *websocketClient, data interface{}) error {
+	d, ok := data.([]byte)
+	if !ok {
+		return common.GetTypeAssertError("[]byte", data)
+	}
+	var req WebsocketSubscriptionRequest
+	err := json.Unmarshal(d, &req)
+	if err == nil {
+		err = client.subscribe(&req)
+	}
+	if err != nil {
+		sendErr := client.SendWebsocketMessage(WebsocketEventResponse{Event: "Subscribe", Data: req, Error: err.Error()})
+		if sendErr != nil {
+			log.Errorln(log.APIServerMgr, sendErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func wsUnsubscribe(client *websocketClient, data interface{}) error {
+	d, ok := data.([]byte)
+	if !ok {
+		return common.GetTypeAssertError("[]byte", data)
+	}
+	wsResp := WebsocketEventResponse{Event: "Unsubscribe"}
+	var req WebsocketSubscriptionRequest
+	err := json.Unmarshal(d, &req)
+	if err == nil {
+		var s *websocketSubscription
+		s, err = client.unsubscribe(&req)
+		if err == nil {
+			wsResp.Data = s.request
+			return client.SendWebsocketMessage(wsResp)
+		}
+	}
+	wsResp.Data = req
+	wsResp.Error = err.Error()
+	sendErr := client.SendWebsocketMessage(wsResp)
+	if sendErr != nil {
+		log.Errorln(log.APIServerMgr, sendErr)
+	}
+	return err
+}
+
+// newSubscription validates a subscription request against the scope of the
+// client and normalises it
+func (c *websocketClient) newSubscription(req *WebsocketSubscriptionRequest) (*websocketSubscription, error) {
+	channel := strings.ToLower(req.Channel)
+	var marketData bool
+	switch channel {
+	case WebsocketChannelTicker, WebsocketChannelOrderbook, WebsocketChannelTrades:
+		marketData = true
+	case WebsocketChannelOrders, WebsocketChannelFills, WebsocketChannelPositions, WebsocketChannelBalances:
+		if c.user == nil {
+			return nil, fmt.Errorf("%s %w", channel, errSubscriptionRequiresAuth)
+		}
+	default:
+		return nil, fmt.Errorf("%w %q", errUnknownSubscriptionChannel, req.Channel)
+	}
+
+	s := &websocketSubscription{request: WebsocketSubscriptionRequest{Channel: channel}}
+	if req.Exchange != "" {
+		exch, err := c.exchangeManager.GetExchangeByName(req.Exchange)
+		if err != nil {
+			return nil, err
+		}
+		s.exchange = exch.GetName()
+	} else if marketData || channel == WebsocketChannelBalances {
+		return nil, errExchangeNameUnset
+	}
+	if c.user != nil && (s.exchange != "" || len(c.user.Exchanges) > 0) && !c.user.permitsExchange(s.exchange) {
+		return nil, fmt.Errorf("%w %s %s %s", errRPCExchangeNotInScope, c.user.Username, channel, s.exchange)
+	}
+
+	if req.AssetType != "" {
+		a, err := asset.New(req.AssetType)
+		if err != nil {
+			return nil, err
+		}
+		s.asset = a
+	} else if marketData || channel == WebsocketChannelBalances {
+		return nil, errAssetTypeUnset
+	}
+
+	if req.Currency != "" {
+		p, err := currency.NewPairFromString(req.Currency)
+		if err != nil {
+			return nil, err
+		}
+		s.pair = p
+	} else if marketData {
+		return nil, errCurrencyPairUnset
+	}
+
+	s.request.Exchange = s.exchange
+	if s.asset != asset.Empty {
+		s.request.AssetType = s.asset.String()
+	}
+	if !s.pair.IsEmpty() {
+		s.request.Currency = s.pair.String()
+	}
+	s.key = strings.Join([]string{
+		channel,
+		strings.ToLower(s.exchange),
+		s.asset.String(),
+		s.pair.Base.Upper().String(),
+		s.pair.Quote.Upper().String(),
+	}, "|")
+	return s, nil
+}
+
+// subscribe sends the subscription snapshot followed by updates until the
+// client unsubscribes or disconnects
+func (c *websocketClient) subscribe(req *WebsocketSubscriptionRequest) error {
+	s, err := c.newSubscription(req)
+	if err != nil {
+		return err
+	}
+	c.subscriptionsMtx.Lock()
+	_, exists := c.subscriptions[s.key]
+	total := len(c.subscriptions)
+	c.subscriptionsMtx.Unlock()
+	if exists {
+		return fmt.Errorf("%w %s", errAlreadySubscribed, s.key)
+	}
+	if total >= maxWebsocketSubscriptions {
+		return fmt.Errorf("%w limit %d", errTooManySubscriptions, maxWebsocketSubscriptions)
+	}
+
+	src, err := c.subscriptionSource(s)
+	if err != nil {
+		return err
+	}
+	s.shutdown = make(chan struct{})
+	c.subscriptionsMtx.Lock()
+	if c.subscriptions == nil {
+		c.subscriptions = make(map[string]*websocketSubscription)
+	}
+	c.subscriptions[s.key] = s
+	c.subscriptionsWG.Add(1)
+	c.subscriptionsMtx.Unlock()
+
+	err = c.SendWebsocketMessage(WebsocketEventResponse{Event: "Subscribe", Data: s.request})
+	if err != nil {
+		log.Errorln(log.APIServerMgr, err)
+	}
+	if !c.pushUpdate(s, src.snapshot, true) && src.resync != nil {
+		src.resync()
+	}
+	go c.runSubscription(s, src)
+	return nil
+}
+
+// unsubscribe stops the subscription matching the request
+func (c *websocketClient) unsubscribe(req *WebsocketSubscriptionRequest) (*websocketSubscription, error) {
+	s, err := c.newSubscription(req)
+	if err != nil {
+		return nil, err
+	}
+	c.subscriptionsMtx.Lock()
+	defer c.subscriptionsMtx.Unlock()
+	existing, ok := c.subscriptions[s.key]
+	if !ok {
+		return nil, fmt.Errorf("%w %s", errNotSubscribed, s.key)
+	}
+	delete(c.subscriptions, s.key)
+	close(existing.shutdown)
+	return existing, nil
+}
+
+// unsubscribeAll stops every subscription of the client and waits for them
+// to release their sources
+func (c *websocketClient) unsubscribeAll() {
+	c.subscriptionsMtx.Lock()
+	for k, s := range c.subscriptions {
+		delete(c.subscriptions, k)
+		close(s.shutdown)
+	}
+	c.subscriptionsMtx.Unlock()
+	c.subscriptionsWG.Wait()
+}
+
+// endSubscription removes a subscription whose source has closed and
+// notifies the client
+func (c *websocketClient) endSubscription(s *websocketSubscription, err error) {
+	c.subscriptionsMtx.Lock()
+	if c.subscriptions[s.key] == s {
+		delete(c.subscriptions, s.key)
+		close(s.shutdown)
+	}
+	c.subscriptionsMtx.Unlock()
+	if err == nil {
+		err = errDispatchSystem
+	}
+	log.Warnf(log.APIServerMgr, "websocket: subscription %s ended: %v\n", s.key, err)
+	c.push(WebsocketEventResponse{Event: "Unsubscribe", Data: s.request, Error: err.Error()})
+}
+
+func (c *websocketClient) runSubscription(s *websocketSubscription, src *subscriptionSource) {
+	defer c.subscriptionsWG.Done()
+	defer src.release()
+	for {
+		var received interface{}
+		select {
+		case <-s.shutdown:
+			return
+		case d, ok := <-src.pipe:
+			if !ok {
+				c.endSubscription(s, errDispatchSystem)
+				return
+			}
+			received = d
+		case ev, ok := <-src.feed:
+			if !ok {
+				c.endSubscription(s, src.err())
+				return
+			}
+			received = ev.Data
+		}
+		data, snapshot, ok := src.convert(received)
+		if !ok {
+			continue
+		}
+		if !c.pushUpdate(s, data, snapshot) && src.resync != nil {
+			src.resync()
+		}
+	}
+}
+
+// pushUpdate sequences and queues an update for the client, returning false
+// when it was dropped
+func (c *websocketClient) pushUpdate(s *websocketSubscription, data interface{}, snapshot bool) bool {
+	s.sequence++
+	return c.push(WebsocketSubscriptionUpdate{
+		Event:     WebsocketUpdateEvent,
+		Channel:   s.request.Channel,
+		Exchange:  s.request.Exchange,
+		Currency:  s.request.Currency,
+		AssetType: s.request.AssetType,
+		Sequence:  s.sequence,
+		Snapshot:  snapshot,
+		Data:      data,
+	})
+}
+
+// push queues a message for the client without blocking, the message is
+// dropped when the client is disconnected or cannot keep up
+func (c *websocketClient) push(evt interface{}) bool {
+	data, err := json.Marshal(evt)
+	if err != nil {
+		log.Errorf(log.APIServerMgr, "websocket: failed to send message: %s\n", err)
+		return false
+	}
+	c.sendMtx.Lock()
+	defer c.sendMtx.Unlock()
+	if c.sendClosed {
+		return false
+	}
+	select {
+	case c.Send <- data:
+		return true
+	default:
+		return false
+	}
+}
+
+// closeSend closes the send channel once so subscriptions stop queueing
+// messages for a disconnected client
+func (c *websocketClient) closeSend() {
+	c.sendMtx.Lock()
+	defer c.sendMtx.Unlock()
+	if c.sendClosed {
+		return
+	}
+	c.sendClosed = true
+	close(c.Send)
+}
+
+// subscriptionSource returns the snapshot and update source of a
+// subscription channel
+func (c *websocketClient) subscriptionSource(s *websocketSubscription) (*subscriptionSource, error) {
+	switch s.request.Channel {
+	case WebsocketChannelTicker:
+		return tickerSubscriptionSource(s)
+	case WebsocketChannelOrderbook:
+		return orderbookSubscriptionSource(s)
+	case WebsocketChannelTrades:
+		sub, err := c.bot.getWebsocketRoutineManager().subscribeTrades()
+		if err != nil {
+			return nil, err
+		}
+		match := func(data interface{}) bool {
+			t, ok := data.(trade.Data)
+			return ok && s.matches(t.Exchange, t.AssetType, t.CurrencyPair)
+		}
+		return feedSubscriptionSource(sub, recentSnapshot(sub.Replay(), match), match), nil
+	case WebsocketChannelOrders:
+		om := c.bot.getOrderManager()
+		sub, err := om.subscribeOrderUpdates(0)
+		if err != nil {
+			return nil, err
+		}
+		active, err := om.GetOrdersActive(&order.Filter{Exchange: s.exchange, AssetType: s.asset, Pair: s.pair})
+		if err != nil {
+			sub.Release()
+			return nil, err
+		}
+		if active == nil {
+			active = []order.Detail{}
+		}
+		return feedSubscriptionSource(sub, active, func(data interface{}) bool {
+			d, ok := data.(order.Detail)
+			return ok && s.matches(d.Exchange, d.AssetType, d.Pair)
+		}), nil
+	case WebsocketChannelFills:
+		sub, err := c.bot.getOrderManager().subscribeRecentFills()
+		if err != nil {
+			return nil, err
+		}
+		match := func(data interface{}) bool {
+			f, ok := data.(fill.Data)
+			return ok && s.matches(f.Exchange, f.AssetType, f.CurrencyPair)
+		}
+		return feedSubscriptionSource(sub, recentSnapshot(sub.Replay(), match), match), nil
+	case WebsocketChannelPositions:
+		om := c.bot.getOrderManager()
+		sub, err := om.subscribePositions(0)
+		if err != nil {
+			return nil, err
+		}
+		positions, err := om.GetAllOpenFuturesPositions()
+		if err != nil && !errors.Is(err, errFuturesTrackingDisabled) {
+			sub.Release()
+			return nil, err
+		}
+		open := make([]order.Position, 0, len(positions))
+		for i := range positions {
+			if s.matches(positions[i].Exchange, positions[i].Asset, positions[i].Pair) {
+				open = append(open, positions[i])
+			}
+		}
+		return feedSubscriptionSource(sub, open, func(data interface{}) bool {
+			p, ok := data.(order.Position)
+			return ok && s.matches(p.Exchange, p.Asset, p.Pair)
+		}), nil
+	case WebsocketChannelBalances:
+		return c.balancesSubscriptionSource(s)
+	}
+	return nil, fmt.Errorf("%w %q", errUnknownSubscriptionChannel, s.request.Channel)
+}
+
+// matches returns whether data for the exchange, asset and pair is included
+// in the subscription, an unset asset or pair matches every asset or pair
+func (s *websocketSubscription) matches(exchangeName string, a asset.Item, p currency.Pair) bool {
+	return (s.exchange == "" || strings.EqualFold(s.exchange, exchangeName)) &&
+		(s.asset == asset.Empty || s.asset == a) &&
+		(s.pair.IsEmpty() || s.pair.Equal(p))
+}
+
+// feedSubscriptionSource returns a source which sends sequenced feed events
+// matching the subscription as updates
+func feedSubscriptionSource(sub *feedSubscription, snapshot interface{}, match func(interface{}) bool) *subscriptionSource {
+	return &subscriptionSource{
+		snapshot: snapshot,
+		feed:     sub.Channel(),
+		convert: func(data interface{}) (interface{}, bool, bool) {
+			return data, false, match(data)
+		},
+		err:     sub.Err,
+		release: sub.Release,
+	}
+}
+
+// recentSnapshot returns the most recent replayed events which match
+func recentSnapshot(replay []sequencedEvent, match func(interface{}) bool) []interface{} {
+	snapshot := make([]interface{}, 0)
+	for i := len(replay) - 1; i >= 0 && len(snapshot) < maxWebsocketSnapshotEvents; i-- {
+		if match(replay[i].Data) {
+			snapshot = append(snapshot, replay[i].Data)
+		}
+	}
+	for i, j := 0, len(snapshot)-1; i < j; i, j = i+1, j-1 {
+		snapshot[i], snapshot[j] = snapshot[j], snapshot[i]
+	}
+	return snapshot
+}
+
+func tickerSubscriptionSource(s *websocketSubscription) (*subscriptionSource, error) {
+	pipe, err := ticker.SubscribeToExchangeTickers(s.exchange)
+	if err != nil {
+		return nil, err
+	}
+	src := &subscriptionSource{
+		pipe: pipe.Channel(),
+		convert: func(data interface{}) (interface{}, bool, bool) {
+			t, ok := data.(*ticker.Price)
+			return t, false, ok && s.matches(t.ExchangeName, t.AssetType, t.Pair)
+		},
+		release: func() {
+			if pipeErr := pipe.Release(); pipeErr != nil {
+				log.Errorln(log.DispatchMgr, pipeErr)
+			}
+		},
+	}
+	if t, tickErr := ticker.GetTicker(s.exchange, s.pair, s.asset); tickErr == nil {
+		src.snapshot = t
+	}
+	return src, nil
+}
+
+// orderbookSubscriptionSource sends the changed price levels of each
+// orderbook update, a full orderbook is sent as a snapshot when there is no
+// previous orderbook or an update was dropped
+func orderbookSubscriptionSource(s *websocketSubscription) (*subscriptionSource, error) {
+	pipe, err := orderbook.SubscribeToExchangeOrderbooks(s.exchange)
+	if err != nil {
+		return nil, err
+	}
+	last, err := orderbook.Get(s.exchange, s.pair, s.asset)
+	if err != nil {
+		last = nil
+	}
+	src := &subscriptionSource{
+		pipe: pipe.Channel(),
+		convert: func(data interface{}) (interface{}, bool, bool) {
+			d, ok := data.(orderbook.Outbound)
+			if !ok {
+				return nil, false, false
+			}
+			book, retrieveErr := d.Retrieve()
+			if retrieveErr != nil || !s.matches(book.Exchange, book.Asset, book.Pair) {
+				return nil, false, false
+			}
+			if last == nil {
+				last = book
+				return book, true, true
+			}
+			delta := orderbookDelta(last, book)
+			last = book
+			if len(delta.Bids) == 0 && len(delta.Asks) == 0 {
+				return nil, false, false
+			}
+			return delta, false, true
+		},
+		resync: func() {
+			last = nil
+		},
+		release: func() {
+			if pipeErr := pipe.Release(); pipeErr != nil {
+				log.Errorln(log.DispatchMgr, pipeErr)
+			}
+		},
+	}
+	if last != nil {
+		src.snapshot = last
+	}
+	return src, nil
+}
+
+// orderbookDelta returns the price levels which were added, changed or
+// removed between two orderbooks
+func orderbookDelta(previous, current *orderbook.Base) *WebsocketOrderbookDelta {
+	return &WebsocketOrderbookDelta{
+		Bids:         levelDelta(previous.Bids, current.Bids),
+		Asks:         levelDelta(previous.Asks, current.Asks),
+		LastUpdated:  current.LastUpdated,
+		LastUpdateID: current.LastUpdateID,
+	}
+}
+
+func levelDelta(previous, current orderbook.Items) []orderbook.Item {
+	amounts := make(map[float64]float64, len(previous))
+	for i := range previous {
+		amounts[previous[i].Price] = previous[i].Amount
+	}
+	var delta []orderbook.Item
+	for i := range current {
+		amount, ok := amounts[current[i].Price]
+		delete(amounts, current[i].Price)
+		if !ok || amount != current[i].Amount {
+			delta = append(delta, current[i])
+		}
+	}
+	for i := range previous {
+		if _, removed := amounts[previous[i].Price]; removed {
+			delta = append(delta, orderbook.Item{Price: previous[i].Price})
+		}
+	}
+	return delta
+}
+
+func (c *websocketClient) balancesSubscriptionSource(s *websocketSubscription) (*subscriptionSource, error) {
+	exch, err := c.exchangeManager.GetExchangeByName(s.exchange)
+	if err != nil {
+		return nil, err
+	}
+	holdings, err := exch.FetchAccountInfo(context.TODO(), s.asset)
+	if err != nil {
+		return nil, err
+	}
+	pipe, err := account.SubscribeToExchangeAccount(s.exchange)
+	if err != nil {
+		return nil, err
+	}
+	return &subscriptionSource{
+		snapshot: filterHoldings(&holdings, s.asset),
+		pipe:     pipe.Channel(),
+		convert: func(data interface{}) (interface{}, bool, bool) {
+			h, ok := data.(*account.Holdings)
+			if !ok {
+				return nil, false, false
+			}
+			filtered := filterHoldings(h, s.asset)
+			return filtered, false, len(filtered.Accounts) > 0
+		},
+		release: func() {
+			if pipeErr := pipe.Release(); pipeErr != nil {
+				log.Errorln(log.DispatchMgr, pipeErr)
+			}
+		},
+	}, nil
+}
+
+// filterHoldings returns the sub accounts of the holdings for the asset
+func filterHoldings(h *account.Holdings, a asset.Item) *account.Holdings {
+	filtered := &account.Holdings{Exchange: h.Exchange}
+	for i := range h.Accounts {
+		if h.Accounts[i].AssetType == a {
+			filtered.Accounts = append(filtered.Accounts, h.Accounts[i])
+		}
+	}
+	return filtered
+}
@@ -0,0 +1,392 @@
+package engine
+
+import (
+	"encoding/json"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/portfolio"
+)
+
+func newSubscriptionTestClient(t *testing.T, bot iBot) *websocketClient {
+	t.Helper()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	err = em.Add(exch)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if fb, ok := bot.(*fakeBot); ok && fb.orderManager != nil {
+		fb.orderManager.orderStore.exchangeManager = em
+	}
+	return &websocketClient{
+		Send:            make(chan []byte, 16),
+		exchangeManager: em,
+		bot:             bot,
+		maxAuthFailures: 3,
+	}
+}
+
+// receiveUpdate returns the next message queued for the client
+func receiveUpdate(t *testing.T, c *websocketClient) WebsocketSubscriptionUpdate {
+	t.Helper()
+	select {
+	case data := <-c.Send:
+		var update WebsocketSubscriptionUpdate
+		if err := json.Unmarshal(data, &update); err != nil {
+			t.Fatal(err)
+		}
+		return update
+	case <-time.After(time.Second * 5):
+		t.Fatal("timed out waiting for websocket message")
+	}
+	return WebsocketSubscriptionUpdate{}
+}
+
+func TestWebsocketNewSubscription(t *testing.T) {
+	t.Parallel()
+	c := newSubscriptionTestClient(t, &fakeBot{})
+	for _, tc := range []struct {
+		req WebsocketSubscriptionRequest
+		err error
+	}{
+		{WebsocketSubscriptionRequest{Channel: "candles"}, errUnknownSubscriptionChannel},
+		{WebsocketSubscriptionRequest{Channel: "orders"}, errSubscriptionRequiresAuth},
+		{WebsocketSubscriptionRequest{Channel: "ticker"}, errExchangeNameUnset},
+		{WebsocketSubscriptionRequest{Channel: "ticker", Exchange: testExchange}, errAssetTypeUnset},
+		{WebsocketSubscriptionRequest{Channel: "ticker", Exchange: testExchange, AssetType: "spot"}, errCurrencyPairUnset},
+	} {
+		_, err := c.newSubscription(&tc.req)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%+v received: '%v' but expected: '%v'", tc.req, err, tc.err)
+		}
+	}
+
+	s, err := c.newSubscription(&WebsocketSubscriptionRequest{Channel: "TICKER", Exchange: "bitstamp", AssetType: "SPOT", Currency: "btc-usd"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	expected := WebsocketSubscriptionRequest{Channel: WebsocketChannelTicker, Exchange: testExchange, AssetType: "spot", Currency: "btc-usd"}
+	if s.request != expected {
+		t.Errorf("received: '%+v' but expected: '%+v'", s.request, expected)
+	}
+
+	c.user = &rpcUser{Username: "bot", Role: rpcRoleTrader, Exchanges: []string{"Binance"}}
+	_, err = c.newSubscription(&WebsocketSubscriptionRequest{Channel: "orders"})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+	_, err = c.newSubscription(&WebsocketSubscriptionRequest{Channel: "ticker", Exchange: testExchange, AssetType: "spot", Currency: "BTC-USD"})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+	c.user.Exchanges = []string{testExchange}
+	_, err = c.newSubscription(&WebsocketSubscriptionRequest{Channel: "balances", Exchange: testExchange})
+	if !errors.Is(err, errAssetTypeUnset) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errAssetTypeUnset)
+	}
+	s, err = c.newSubscription(&WebsocketSubscriptionRequest{Channel: "fills", Exchange: testExchange})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !s.matches("BITSTAMP", asset.Futures, currency.NewPair(currency.ETH, currency.USD)) || s.matches("Binance", asset.Spot, currency.EMPTYPAIR) {
+		t.Error("fills subscription should match every asset and pair of the exchange only")
+	}
+}
+
+func TestWebsocketOrderSubscription(t *testing.T) {
+	t.Parallel()
+	om, err := SetupOrderManager(NewExchangeManager(), &CommunicationManager{}, &sync.WaitGroup{}, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	om.started = 1
+	c := newSubscriptionTestClient(t, &fakeBot{orderManager: om})
+	c.user = &rpcUser{Username: "viewer", Role: rpcRoleReadOnly}
+
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	err = om.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "1", AssetType: asset.Spot, Pair: pair, Amount: 1, Status: order.New})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	req := []byte(`{"channel":"orders","exchangeName":"bitstamp"}`)
+	err = wsSubscribe(c, req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if ack := receiveUpdate(t, c); ack.Event != "Subscribe" {
+		t.Fatalf("received: '%v' but expected: '%v'", ack.Event, "Subscribe")
+	}
+	snapshot := receiveUpdate(t, c)
+	if !snapshot.Snapshot || snapshot.Sequence != 1 || snapshot.Exchange != testExchange {
+		t.Errorf("received: '%+v' but expected: '%v'", snapshot, "the first update to be a snapshot")
+	}
+	if orders, ok := snapshot.Data.([]interface{}); !ok || len(orders) != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", snapshot.Data, "the active order")
+	}
+
+	err = wsSubscribe(c, req)
+	if !errors.Is(err, errAlreadySubscribed) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errAlreadySubscribed)
+	}
+	if resp := receiveUpdate(t, c); resp.Event != "Subscribe" {
+		t.Errorf("received: '%v' but expected: '%v'", resp.Event, "Subscribe")
+	}
+
+	err = om.orderStore.add(&order.Detail{Exchange: testExchange, OrderID: "2", AssetType: asset.Spot, Pair: pair, Amount: 1, Status: order.New})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	update := receiveUpdate(t, c)
+	if update.Snapshot || update.Sequence != 2 || update.Event != WebsocketUpdateEvent {
+		t.Errorf("received: '%+v' but expected: '%v'", update, "an order update with sequence 2")
+	}
+
+	err = wsUnsubscribe(c, req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if ack := receiveUpdate(t, c); ack.Event != "Unsubscribe" {
+		t.Errorf("received: '%v' but expected: '%v'", ack.Event, "Unsubscribe")
+	}
+	err = wsUnsubscribe(c, req)
+	if !errors.Is(err, errNotSubscribed) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNotSubscribed)
+	}
+	c.unsubscribeAll()
+}
+
+func TestWebsocketTradeSubscription(t *testing.T) {
+	t.Parallel()
+	wr := &WebsocketRoutineManager{tradeFeed: newSequencedFeed(10)}
+	c := newSubscriptionTestClient(t, &fakeBot{websocketRoutineManager: wr})
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	err := wr.websocketDataHandler(testExchange, []trade.Data{
+		{Exchange: testExchange, AssetType: asset.Spot, CurrencyPair: pair, TID: "1"},
+		{Exchange: testExchange, AssetType: asset.Spot, CurrencyPair: currency.NewPair(currency.ETH, currency.USD), TID: "2"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	err = c.subscribe(&WebsocketSubscriptionRequest{Channel: "trades", Exchange: testExchange, AssetType: "spot", Currency: "BTC-USD"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	receiveUpdate(t, c)
+	snapshot := receiveUpdate(t, c)
+	if trades, ok := snapshot.Data.([]interface{}); !snapshot.Snapshot || !ok || len(trades) != 1 {
+		t.Errorf("received: '%+v' but expected: '%v'", snapshot, "a snapshot of the retained BTC-USD trade")
+	}
+
+	err = wr.websocketDataHandler(testExchange, []trade.Data{
+		{Exchange: testExchange, AssetType: asset.Spot, CurrencyPair: currency.NewPair(currency.ETH, currency.USD), TID: "3"},
+		{Exchange: testExchange, AssetType: asset.Spot, CurrencyPair: pair, TID: "4"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	update := receiveUpdate(t, c)
+	if td, ok := update.Data.(map[string]interface{}); update.Sequence != 2 || !ok || td["TID"] != "4" {
+		t.Errorf("received: '%+v' but expected: '%v'", update, "trade 4 with sequence 2")
+	}
+	c.unsubscribeAll()
+	if len(c.subscriptions) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(c.subscriptions), 0)
+	}
+}
+
+func TestOrderbookDelta(t *testing.T) {
+	t.Parallel()
+	previous := &orderbook.Base{
+		Bids: orderbook.Items{{Price: 100, Amount: 1}, {Price: 99, Amount: 2}},
+		Asks: orderbook.Items{{Price: 101, Amount: 1}},
+	}
+	current := &orderbook.Base{
+		Bids:         orderbook.Items{{Price: 100, Amount: 1}, {Price: 99, Amount: 3}, {Price: 98, Amount: 1}},
+		Asks:         orderbook.Items{{Price: 102, Amount: 1}},
+		LastUpdateID: 1337,
+	}
+	delta := orderbookDelta(previous, current)
+	if len(delta.Bids) != 2 || delta.Bids[0].Price != 99 || delta.Bids[0].Amount != 3 || delta.Bids[1].Price != 98 {
+		t.Errorf("received: '%+v' but expected: '%v'", delta.Bids, "changed 99 and added 98")
+	}
+	if len(delta.Asks) != 2 || delta.Asks[0].Price != 102 || delta.Asks[1].Price != 101 || delta.Asks[1].Amount != 0 {
+		t.Errorf("received: '%+v' but expected: '%v'", delta.Asks, "added 102 and removed 101")
+	}
+	if delta.LastUpdateID != 1337 {
+		t.Errorf("received: '%v' but expected: '%v'", delta.LastUpdateID, 1337)
+	}
+	if delta = orderbookDelta(current, current); len(delta.Bids) != 0 || len(delta.Asks) != 0 {
+		t.Errorf("received: '%+v' but expected: '%v'", delta, "no changes")
+	}
+}
+
+func TestWebsocketAuthUsers(t *testing.T) {
+	t.Parallel()
+	c := newSubscriptionTestClient(t, &fakeBot{})
+	c.username = "admin"
+	c.password = "Password"
+	c.users = []config.RPCUser{
+		{Username: "desk", Password: "deskpass", Role: config.RPCRoleRisk},
+		{Username: "bot", Token: "bottoken", Role: config.RPCRoleTrader, Exchanges: []string{testExchange}},
+	}
+	hash, err := crypto.GetSHA256([]byte("deskpass"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for _, tc := range []struct {
+		auth     WebsocketAuth
+		username string
+		role     rpcRole
+	}{
+		{WebsocketAuth{Username: "desk", Password: "deskpass"}, "", 0},
+		{WebsocketAuth{Token: "wrong"}, "", 0},
+		{WebsocketAuth{Username: "desk", Password: crypto.HexEncodeToString(hash)}, "desk", rpcRoleRisk},
+		{WebsocketAuth{Token: "bottoken"}, "bot", rpcRoleTrader},
+	} {
+		c.user, c.Authenticated = nil, false
+		data, err := json.Marshal(tc.auth)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		err = wsAuth(c, data)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		<-c.Send
+		if tc.username == "" {
+			if c.Authenticated || c.user != nil {
+				t.Errorf("%+v should not authenticate", tc.auth)
+			}
+			continue
+		}
+		if !c.Authenticated || c.user == nil || c.user.Username != tc.username || c.user.Role != tc.role {
+			t.Errorf("received: '%+v' but expected: '%v' '%v'", c.user, tc.username, tc.role)
+		}
+	}
+	if len(c.user.Exchanges) != 1 || c.user.Exchanges[0] != testExchange {
+		t.Errorf("received: '%v' but expected: '%v'", c.user.Exchanges, testExchange)
+	}
+}
+
+func TestWebsocketClientPush(t *testing.T) {
+	t.Parallel()
+	c := &websocketClient{Send: make(chan []byte, 1)}
+	if !c.push(WebsocketEventResponse{Event: "test"}) {
+		t.Error("push should queue the message")
+	}
+	if c.push(WebsocketEventResponse{Event: "test"}) {
+		t.Error("push should drop the message when the client cannot keep up")
+	}
+	c.closeSend()
+	c.closeSend()
+	if c.push(WebsocketEventResponse{Event: "test"}) {
+		t.Error("push should drop the message when the client is disconnected")
+	}
+}
+
+func TestWebsocketEventRoles(t *testing.T) {
+	t.Parallel()
+	c := newSubscriptionTestClient(t, &fakeBot{})
+	c.Authenticated = true
+	c.user = &rpcUser{Username: "viewer", Role: rpcRoleReadOnly}
+	for _, event := range []string{"getconfig", "SaveConfig"} {
+		err := c.handleEvent(event, []byte("{}"))
+		if !errors.Is(err, errRPCMethodNotPermitted) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, errRPCMethodNotPermitted)
+		}
+		var resp WebsocketEventResponse
+		if err = json.Unmarshal(<-c.Send, &resp); !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if resp.Event != event || resp.Error == "" || resp.Data != nil {
+			t.Errorf("received: '%+v' but expected an error response", resp)
+		}
+	}
+
+	c.user = nil
+	if err := c.handleEvent("getconfig", nil); !errors.Is(err, errRPCMethodNotPermitted) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errRPCMethodNotPermitted)
+	}
+	<-c.Send
+
+	c.user = &rpcUser{Username: "admin", Role: rpcRoleAdmin}
+	if err := c.handleEvent("getconfig", nil); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var resp WebsocketEventResponse
+	if err := json.Unmarshal(<-c.Send, &resp); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Error != "" {
+		t.Errorf("received: '%v' but expected: '%v'", resp.Error, "")
+	}
+
+	c.Authenticated = false
+	if err := c.handleEvent("getconfig", nil); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if err := json.Unmarshal(<-c.Send, &resp); !errors.Is(err, nil) || resp.Error == "" {
+		t.Errorf("received: '%v' '%v' but expected an unauthorised response", err, resp.Error)
+	}
+	if err := c.handleEvent("unknown", nil); !errors.Is(err, errUnsupportedWebsocketEvent) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnsupportedWebsocketEvent)
+	}
+}
+
+func TestScopeAccounts(t *testing.T) {
+	t.Parallel()
+	accounts := []AllEnabledExchangeAccounts{
+		{Data: []account.Holdings{{Exchange: testExchange}}},
+		{Data: []account.Holdings{{Exchange: "other"}}},
+	}
+	if scoped := scopeAccounts(accounts, &rpcUser{Role: rpcRoleAdmin}); len(scoped) != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", len(scoped), 2)
+	}
+	scoped := scopeAccounts(accounts, &rpcUser{Role: rpcRoleReadOnly, Exchanges: []string{testExchange}})
+	if len(scoped) != 1 || scoped[0].Data[0].Exchange != testExchange {
+		t.Errorf("received: '%+v' but expected only: '%v'", scoped, testExchange)
+	}
+}
+
+func TestScopePortfolioSummary(t *testing.T) {
+	t.Parallel()
+	s := portfolio.Summary{
+		Totals:  []portfolio.Coin{{Coin: currency.BTC, Balance: 4}},
+		Offline: []portfolio.Coin{{Coin: currency.BTC, Balance: 1}},
+		Online:  []portfolio.Coin{{Coin: currency.BTC, Balance: 3}},
+		OnlineSummary: map[string]map[currency.Code]portfolio.OnlineCoinSummary{
+			testExchange: {currency.BTC: {Balance: 1, Percentage: 33}},
+			"other":      {currency.BTC: {Balance: 2, Percentage: 66}},
+		},
+	}
+	if scoped := scopePortfolioSummary(s, nil); len(scoped.Offline) != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", len(scoped.Offline), 1)
+	}
+	scoped := scopePortfolioSummary(s, &rpcUser{Role: rpcRoleReadOnly, Exchanges: []string{testExchange}})
+	if len(scoped.Offline) != 0 || len(scoped.OnlineSummary) != 1 {
+		t.Fatalf("received: '%+v' but expected only: '%v'", scoped, testExchange)
+	}
+	if coin := scoped.OnlineSummary[testExchange][currency.BTC]; coin.Balance != 1 || coin.Percentage != 100 {
+		t.Errorf("received: '%+v' but expected: '%v'", coin, "1 BTC at 100%")
+	}
+	if len(scoped.Totals) != 1 || scoped.Totals[0].Balance != 1 {
+		t.Errorf("received: '%+v' but expected: '%v'", scoped.Totals, "1 BTC")
+	}
+}
@@ -283,9 +283,20 @@ func TestConfigAllJsonResponse(t *testing.T) {
 }
 
 // fakeBot is a basic implementation of the iBot interface used for testing
-type fakeBot struct{}
+type fakeBot struct {
+	orderManager            *OrderManager
+	websocketRoutineManager *WebsocketRoutineManager
+}
 
 // SetupExchanges is a basic implementation of the iBot interface used for testing
 func (f *fakeBot) SetupExchanges() error {
 	return nil
 }
+
+func (f *fakeBot) getOrderManager() *OrderManager {
+	return f.orderManager
+}
+
+func (f *fakeBot) getWebsocketRoutineManager() *WebsocketRoutineManager {
+	return f.websocketRoutineManager
+}
@@ -4,11 +4,14 @@ import (
 	"errors"
 	"net/http"
 	"sync"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/gorilla/websocket"
 	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 )
@@ -19,17 +22,42 @@ const (
 	restIndexResponse        = "<html>GoCryptoTrader RESTful interface. For the web GUI, please visit the <a href=https://github.com/thrasher-corp/gocryptotrader/blob/master/web/README.md>web GUI readme.</a></html>"
 	DeprecatedName           = "deprecated_rpc"
 	WebsocketName            = "websocket_rpc"
+
+	// WebsocketUpdateEvent is the event of updates sent to subscribers
+	WebsocketUpdateEvent = "Update"
+
+	// Websocket subscription channels, ticker, orderbook and trades are
+	// available to every client while the remaining channels require an
+	// authenticated client
+	WebsocketChannelTicker    = "ticker"
+	WebsocketChannelOrderbook = "orderbook"
+	WebsocketChannelTrades    = "trades"
+	WebsocketChannelOrders    = "orders"
+	WebsocketChannelFills     = "fills"
+	WebsocketChannelPositions = "positions"
+	WebsocketChannelBalances  = "balances"
+
+	maxWebsocketSubscriptions = 100
+	// maxWebsocketSnapshotEvents limits the retained trades and fills sent
+	// as a snapshot on subscribe
+	maxWebsocketSnapshotEvents = 100
 )
 
 var (
-	wsHub              *websocketHub
-	wsHubStarted       bool
-	errNilRemoteConfig = errors.New("received nil remote config")
-	errNilPProfConfig  = errors.New("received nil pprof config")
-	errNilBot          = errors.New("received nil engine bot")
-	errEmptyConfigPath = errors.New("received empty config path")
-	errServerDisabled  = errors.New("server disabled")
-	errAlreadyRunning  = errors.New("already running")
+	wsHub                         *websocketHub
+	wsHubStarted                  bool
+	errNilRemoteConfig            = errors.New("received nil remote config")
+	errNilPProfConfig             = errors.New("received nil pprof config")
+	errNilBot                     = errors.New("received nil engine bot")
+	errEmptyConfigPath            = errors.New("received empty config path")
+	errServerDisabled             = errors.New("server disabled")
+	errAlreadyRunning             = errors.New("already running")
+	errUnknownSubscriptionChannel = errors.New("unknown subscription channel")
+	errSubscriptionRequiresAuth   = errors.New("channel requires an authenticated client")
+	errAlreadySubscribed          = errors.New("already subscribed")
+	errNotSubscribed              = errors.New("not subscribed")
+	errUnsupportedWebsocketEvent  = errors.New("unsupported websocket event")
+	errTooManySubscriptions       = errors.New("too many subscriptions")
 	// ErrWebsocketServiceNotRunning occurs when a message is sent to be broadcast via websocket
 	// and its not running
 	ErrWebsocketServiceNotRunning = errors.New("websocket service not started")
@@ -73,6 +101,43 @@ type websocketClient struct {
 	bot              iBot
 	portfolioManager iPortfolioManager
 	configPath       string
+	// users are the remote control users which can authenticate, user is the
+	// authenticated user whose role and exchange scope apply to subscriptions
+	users []config.RPCUser
+	user  *rpcUser
+
+	sendMtx    sync.Mutex
+	sendClosed bool
+
+	subscriptionsMtx sync.Mutex
+	subscriptions    map[string]*websocketSubscription
+	subscriptionsWG  sync.WaitGroup
+}
+
+// websocketSubscription is a channel a websocket client is subscribed to
+type websocketSubscription struct {
+	key      string
+	request  WebsocketSubscriptionRequest
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
+	sequence uint64
+	shutdown chan struct{}
+}
+
+// subscriptionSource provides the snapshot and updates of a subscription,
+// updates are received from either a dispatch pipe or a sequenced feed
+type subscriptionSource struct {
+	snapshot interface{}
+	pipe     <-chan interface{}
+	feed     <-chan sequencedEvent
+	// convert returns the update sent for received data, whether it is a
+	// snapshot and whether it matches the subscription
+	convert func(interface{}) (data interface{}, snapshot, ok bool)
+	// resync is called when an update could not be queued for the client
+	resync  func()
+	err     func() error
+	release func()
 }
 
 // websocketHub stores the data for managing websocket clients
@@ -106,10 +171,44 @@ type WebsocketOrderbookTickerRequest struct {
 	AssetType string `json:"assetType"`
 }
 
-// WebsocketAuth is a struct used for
+// WebsocketAuth is a struct used for authenticating a websocket client with
+// a username and SHA256 hashed password or a remote control user token
 type WebsocketAuth struct {
 	Username string `json:"username"`
 	Password string `json:"password"`
+	Token    string `json:"token,omitempty"`
+}
+
+// WebsocketSubscriptionRequest subscribes to or unsubscribes from a channel
+// for an exchange, asset and currency pair
+type WebsocketSubscriptionRequest struct {
+	Channel   string `json:"channel"`
+	Exchange  string `json:"exchangeName,omitempty"`
+	Currency  string `json:"currency,omitempty"`
+	AssetType string `json:"assetType,omitempty"`
+}
+
+// WebsocketSubscriptionUpdate is sent to a subscribed client, the sequence
+// increases by one for each update of the subscription so a gap shows an
+// update was dropped. The first update is a snapshot
+type WebsocketSubscriptionUpdate struct {
+	Event     string      `json:"event"`
+	Channel   string      `json:"channel"`
+	Exchange  string      `json:"exchangeName,omitempty"`
+	Currency  string      `json:"currency,omitempty"`
+	AssetType string      `json:"assetType,omitempty"`
+	Sequence  uint64      `json:"sequence"`
+	Snapshot  bool        `json:"snapshot"`
+	Data      interface{} `json:"data"`
+}
+
+// WebsocketOrderbookDelta holds the orderbook price levels which changed since
+// the previous update, a level with a zero amount has been removed
+type WebsocketOrderbookDelta struct {
+	Bids         []orderbook.Item `json:"bids"`
+	Asks         []orderbook.Item `json:"asks"`
+	LastUpdated  time.Time        `json:"lastUpdated"`
+	LastUpdateID int64            `json:"lastUpdateID"`
 }
 
 // Route is a sub type that holds the request routes
@@ -149,20 +248,26 @@ type AllEnabledExchangeAccounts struct {
 	Data []account.Holdings `json:"data"`
 }
 
+// wsHandlers maps every websocket event to its handler. Events which require
+// authentication are limited to the roles permitted to call the matching gRPC
+// method
 var wsHandlers = map[string]wsCommandHandler{
 	"auth":             {authRequired: false, handler: wsAuth},
-	"getconfig":        {authRequired: true, handler: wsGetConfig},
-	"saveconfig":       {authRequired: true, handler: wsSaveConfig},
-	"getaccountinfo":   {authRequired: true, handler: wsGetAccountInfo},
+	"getconfig":        {authRequired: true, roles: rpcRoleAdmin, handler: wsGetConfig},
+	"saveconfig":       {authRequired: true, roles: rpcRoleAdmin, handler: wsSaveConfig},
+	"getaccountinfo":   {authRequired: true, roles: rpcRolesAll, handler: wsGetAccountInfo},
 	"gettickers":       {authRequired: false, handler: wsGetTickers},
 	"getticker":        {authRequired: false, handler: wsGetTicker},
 	"getorderbooks":    {authRequired: false, handler: wsGetOrderbooks},
 	"getorderbook":     {authRequired: false, handler: wsGetOrderbook},
 	"getexchangerates": {authRequired: false, handler: wsGetExchangeRates},
-	"getportfolio":     {authRequired: true, handler: wsGetPortfolio},
+	"getportfolio":     {authRequired: true, roles: rpcRolesAll, handler: wsGetPortfolio},
+	"subscribe":        {authRequired: false, handler: wsSubscribe},
+	"unsubscribe":      {authRequired: false, handler: wsUnsubscribe},
 }
 
 type wsCommandHandler struct {
 	authRequired bool
+	roles        rpcRole
 	handler      func(client *websocketClient, data interface{}) error
 }
@@ -0,0 +1,506 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupBasisService creates a basis service subsystem
+func SetupBasisService(em iExchangeManager, cfg *config.BasisService) (*BasisService, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	historyLength := cfg.HistoryLength
+	if historyLength <= 0 {
+		historyLength = 1440
+	}
+	return &BasisService{
+		exchangeManager: em,
+		shutdown:        make(chan struct{}),
+		interval:        interval,
+		historyLength:   historyLength,
+		historyFile:     cfg.HistoryFile,
+		verbose:         cfg.Verbose,
+	}, nil
+}
+
+// Start runs the subsystem
+func (b *BasisService) Start() error {
+	if b == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&b.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	b.shutdown = make(chan struct{})
+	b.wg.Add(1)
+	go b.run()
+	log.Debugf(log.Global, "Basis service %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (b *BasisService) IsRunning() bool {
+	if b == nil {
+		return false
+	}
+	return atomic.LoadInt32(&b.started) == 1
+}
+
+// Stop stops the subsystem
+func (b *BasisService) Stop() error {
+	if b == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&b.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(b.shutdown)
+	b.wg.Wait()
+	log.Debugf(log.Global, "Basis service %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (b *BasisService) run() {
+	defer b.wg.Done()
+	t := time.NewTicker(b.interval)
+	defer t.Stop()
+	for {
+		if _, err := b.Calculate(context.TODO()); err != nil && !errors.Is(err, errAlreadyRunning) {
+			log.Errorf(log.Global, "Basis service: %v", err)
+		}
+		select {
+		case <-b.shutdown:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// LatestSnapshot returns the most recently calculated basis of every contract
+func (b *BasisService) LatestSnapshot() (*BasisSnapshot, error) {
+	if b == nil {
+		return nil, ErrNilSubsystem
+	}
+	b.m.Lock()
+	defer b.m.Unlock()
+	if len(b.history) == 0 {
+		return nil, errNoBasisSnapshot
+	}
+	return copyBasisSnapshot(&b.history[len(b.history)-1]), nil
+}
+
+// Calculate prices every enabled futures contract and the spot pair of its
+// underlying then calculates the basis between them. The snapshot is added to
+// the rolling history and appended to the history file when configured
+func (b *BasisService) Calculate(ctx context.Context) (*BasisSnapshot, error) {
+	if b == nil {
+		return nil, ErrNilSubsystem
+	}
+	if atomic.LoadInt32(&b.started) == 0 {
+		return nil, ErrSubSystemNotStarted
+	}
+	if !atomic.CompareAndSwapInt32(&b.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot calculate basis, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&b.processing, 0)
+
+	exchanges, err := b.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	snapshot := &BasisSnapshot{Time: time.Now().UTC()}
+	contracts := make([][]basisContract, len(exchanges))
+	underlyings := make(map[*currency.Item]bool)
+	for i := range exchanges {
+		contracts[i] = getBasisContracts(exchanges[i], snapshot.Time)
+		for j := range contracts[i] {
+			underlyings[contracts[i][j].underlying.Item] = true
+		}
+	}
+
+	spot := make(map[string]map[*currency.Item]spotReference, len(exchanges))
+	var points []BasisPoint
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	for i := range exchanges {
+		wg.Add(1)
+		go func(exch exchange.IBotExchange, contracts []basisContract) {
+			defer wg.Done()
+			refs, pts, errs := priceBasisExchange(ctx, exch, contracts, underlyings)
+			mtx.Lock()
+			spot[exch.GetName()] = refs
+			points = append(points, pts...)
+			snapshot.Errors = append(snapshot.Errors, errs...)
+			mtx.Unlock()
+		}(exchanges[i], contracts[i])
+	}
+	wg.Wait()
+
+	spotExchanges := make([]string, 0, len(spot))
+	for k := range spot {
+		spotExchanges = append(spotExchanges, k)
+	}
+	sort.Strings(spotExchanges)
+	for i := range points {
+		points[i].Time = snapshot.Time
+		ref, ok := spot[points[i].Exchange][points[i].Underlying.Item]
+		for j := 0; !ok && j < len(spotExchanges); j++ {
+			ref, ok = spot[spotExchanges[j]][points[i].Underlying.Item]
+		}
+		if !ok {
+			snapshot.Errors = append(snapshot.Errors, fmt.Sprintf("%s %s %s: %v %v", points[i].Exchange, points[i].Asset, points[i].Pair, errNoSpotReference, points[i].Underlying))
+			continue
+		}
+		calculateBasis(&points[i], &ref)
+		snapshot.Points = append(snapshot.Points, points[i])
+	}
+	sortBasisPoints(snapshot.Points)
+	sort.Strings(snapshot.Errors)
+
+	b.m.Lock()
+	b.history = append(b.history, *snapshot)
+	if len(b.history) > b.historyLength {
+		b.history = b.history[len(b.history)-b.historyLength:]
+	}
+	b.m.Unlock()
+
+	if b.historyFile != "" {
+		if err = writeBasisHistory(b.historyFile, snapshot); err != nil {
+			log.Errorf(log.Global, "Basis service unable to write history: %v", err)
+		}
+	}
+	if b.verbose {
+		log.Debugf(log.Global, "Basis service calculated the basis of %d contracts", len(snapshot.Points))
+		for i := range snapshot.Errors {
+			log.Warnf(log.Global, "Basis service: %s", snapshot.Errors[i])
+		}
+	}
+	return copyBasisSnapshot(snapshot), nil
+}
+
+// TermStructures returns the basis curve of each exchange and underlying from
+// the latest snapshot. An empty underlying or exchange matches all
+func (b *BasisService) TermStructures(underlying currency.Code, exch string) ([]TermStructure, error) {
+	snapshot, err := b.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	var resp []TermStructure
+	for i := range snapshot.Points {
+		p := &snapshot.Points[i]
+		if !basisPointMatches(p, underlying, exch, asset.Empty, currency.EMPTYPAIR) {
+			continue
+		}
+		if len(resp) == 0 ||
+			resp[len(resp)-1].Exchange != p.Exchange ||
+			!resp[len(resp)-1].Underlying.Equal(p.Underlying) {
+			resp = append(resp, TermStructure{
+				Time:       snapshot.Time,
+				Exchange:   p.Exchange,
+				Underlying: p.Underlying,
+			})
+		}
+		resp[len(resp)-1].Points = append(resp[len(resp)-1].Points, *p)
+	}
+	if len(resp) == 0 {
+		return nil, errNoBasisForFilter
+	}
+	return resp, nil
+}
+
+// History returns the basis of matching contracts from the rolling history
+// oldest first. Empty filter values match all
+func (b *BasisService) History(underlying currency.Code, exch string, a asset.Item, pair currency.Pair) ([]BasisPoint, error) {
+	if b == nil {
+		return nil, ErrNilSubsystem
+	}
+	b.m.Lock()
+	defer b.m.Unlock()
+	if len(b.history) == 0 {
+		return nil, errNoBasisSnapshot
+	}
+	var resp []BasisPoint
+	for i := range b.history {
+		for j := range b.history[i].Points {
+			if basisPointMatches(&b.history[i].Points[j], underlying, exch, a, pair) {
+				resp = append(resp, b.history[i].Points[j])
+			}
+		}
+	}
+	if len(resp) == 0 {
+		return nil, errNoBasisForFilter
+	}
+	return resp, nil
+}
+
+// getBasisContracts returns the enabled perpetual and unexpired dated futures
+// contracts of an exchange. Dated contracts whose expiry cannot be determined
+// from their name are skipped
+func getBasisContracts(exch exchange.IBotExchange, at time.Time) []basisContract {
+	var resp []basisContract
+	assets := exch.GetAssetTypes(true)
+	for i := range assets {
+		if !assets[i].IsFutures() {
+			continue
+		}
+		pairs, err := exch.GetEnabledPairs(assets[i])
+		if err != nil {
+			continue
+		}
+		for j := range pairs {
+			c := basisContract{
+				asset:      assets[i],
+				pair:       pairs[j],
+				underlying: basisUnderlying(pairs[j]),
+			}
+			expiry, dated := basisContractExpiry(pairs[j])
+			c.isPerpetual, err = exch.IsPerpetualFutureCurrency(assets[i], pairs[j])
+			if err != nil {
+				// not all exchanges can identify perpetuals, so an
+				// undated contract is assumed to be one
+				c.isPerpetual = !dated
+			}
+			if !c.isPerpetual {
+				if !dated || !expiry.After(at) {
+					continue
+				}
+				c.expiry = expiry
+			}
+			resp = append(resp, c)
+		}
+	}
+	return resp
+}
+
+// priceBasisExchange retrieves the orderbook mid of each contract and the spot
+// pair of each required underlying on an exchange
+func priceBasisExchange(ctx context.Context, exch exchange.IBotExchange, contracts []basisContract, underlyings map[*currency.Item]bool) (map[*currency.Item]spotReference, []BasisPoint, []string) {
+	var errs []string
+	refs := make(map[*currency.Item]spotReference)
+	if spotPairs, err := exch.GetEnabledPairs(asset.Spot); err == nil {
+		preferred := make(map[*currency.Item]currency.Pair)
+		rank := make(map[*currency.Item]int)
+		for i := range spotPairs {
+			if !underlyings[spotPairs[i].Base.Item] {
+				continue
+			}
+			for j := range basisSpotQuotes {
+				if !spotPairs[i].Quote.Equal(basisSpotQuotes[j]) {
+					continue
+				}
+				if r, ok := rank[spotPairs[i].Base.Item]; !ok || j < r {
+					rank[spotPairs[i].Base.Item] = j
+					preferred[spotPairs[i].Base.Item] = spotPairs[i]
+				}
+				break
+			}
+		}
+		for k, cp := range preferred {
+			mid, err := orderbookMid(ctx, exch, cp, asset.Spot)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s %s %s: %v", exch.GetName(), asset.Spot, cp, err))
+				continue
+			}
+			refs[k] = spotReference{exchange: exch.GetName(), pair: cp, mid: mid}
+		}
+	}
+	points := make([]BasisPoint, 0, len(contracts))
+	for i := range contracts {
+		mid, err := orderbookMid(ctx, exch, contracts[i].pair, contracts[i].asset)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s %s: %v", exch.GetName(), contracts[i].asset, contracts[i].pair, err))
+			continue
+		}
+		points = append(points, BasisPoint{
+			Exchange:    exch.GetName(),
+			Asset:       contracts[i].asset,
+			Pair:        contracts[i].pair,
+			Underlying:  contracts[i].underlying,
+			ContractMid: mid,
+			IsPerpetual: contracts[i].isPerpetual,
+			Expiry:      contracts[i].expiry,
+		})
+	}
+	return refs, points, errs
+}
+
+// orderbookMid returns the mid of the best bid and ask
+func orderbookMid(ctx context.Context, exch exchange.IBotExchange, cp currency.Pair, a asset.Item) (decimal.Decimal, error) {
+	ob, err := exch.FetchOrderbook(ctx, cp, a)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	if len(ob.Bids) == 0 || len(ob.Asks) == 0 {
+		return decimal.Zero, errEmptyOrderbook
+	}
+	return decimal.NewFromFloat(ob.Bids[0].Price).Add(decimal.NewFromFloat(ob.Asks[0].Price)).Div(decimal.NewFromInt(2)), nil
+}
+
+// calculateBasis sets the spot reference of a point and calculates its basis.
+// Dated contracts are annualised over the time remaining until expiry
+func calculateBasis(p *BasisPoint, ref *spotReference) {
+	p.SpotExchange = ref.exchange
+	p.SpotPair = ref.pair
+	p.SpotMid = ref.mid
+	p.Basis = p.ContractMid.Sub(p.SpotMid)
+	if p.SpotMid.IsZero() {
+		return
+	}
+	p.BasisRate = p.Basis.Div(p.SpotMid)
+	if p.IsPerpetual || p.Expiry.IsZero() {
+		return
+	}
+	remaining := p.Expiry.Sub(p.Time)
+	if remaining <= 0 {
+		return
+	}
+	p.AnnualisedBasis = p.BasisRate.Mul(decimal.NewFromInt(int64(basisYear))).Div(decimal.NewFromInt(int64(remaining)))
+}
+
+// sortBasisPoints orders points by exchange and underlying with perpetuals
+// first followed by ascending expiry
+func sortBasisPoints(points []BasisPoint) {
+	sort.Slice(points, func(i, j int) bool {
+		if points[i].Exchange != points[j].Exchange {
+			return points[i].Exchange < points[j].Exchange
+		}
+		if u1, u2 := points[i].Underlying.String(), points[j].Underlying.String(); u1 != u2 {
+			return u1 < u2
+		}
+		if points[i].IsPerpetual != points[j].IsPerpetual {
+			return points[i].IsPerpetual
+		}
+		if !points[i].Expiry.Equal(points[j].Expiry) {
+			return points[i].Expiry.Before(points[j].Expiry)
+		}
+		return points[i].Pair.String() < points[j].Pair.String()
+	})
+}
+
+func basisPointMatches(p *BasisPoint, underlying currency.Code, exch string, a asset.Item, pair currency.Pair) bool {
+	return (underlying.IsEmpty() || p.Underlying.Equal(underlying)) &&
+		(exch == "" || strings.EqualFold(p.Exchange, exch)) &&
+		(a == asset.Empty || p.Asset == a) &&
+		(pair.IsEmpty() || p.Pair.Equal(pair))
+}
+
+func copyBasisSnapshot(s *BasisSnapshot) *BasisSnapshot {
+	resp := *s
+	resp.Points = append([]BasisPoint(nil), s.Points...)
+	resp.Errors = append([]string(nil), s.Errors...)
+	return &resp
+}
+
+// basisContractExpiry returns the expiry of a dated contract from a YYMMDD or
+// YYYYMMDD date in its name eg BTCUSD_230929, BTC-USD-20230929 or BTC230929
+func basisContractExpiry(cp currency.Pair) (time.Time, bool) {
+	name := strings.ReplaceAll(strings.ToUpper(cp.Base.String()+"-"+cp.Quote.String()), "_", "-")
+	parts := strings.Split(name, "-")
+	for i := len(parts) - 1; i >= 0; i-- {
+		digits := len(parts[i])
+		for digits > 0 && parts[i][digits-1] >= '0' && parts[i][digits-1] <= '9' {
+			digits--
+		}
+		date := parts[i][digits:]
+		var layout string
+		switch len(date) {
+		case 6:
+			layout = "060102"
+		case 8:
+			layout = "20060102"
+		default:
+			continue
+		}
+		expiry, err := time.Parse(layout, date)
+		if err != nil {
+			continue
+		}
+		return expiry.Add(time.Hour * basisExpiryHour), true
+	}
+	return time.Time{}, false
+}
+
+// basisUnderlying returns the underlying currency of a futures contract.
+// Dated contracts quoted in or suffixed with their expiry, eg BTCUSD_230929 or
+// BTC230929, hold their settlement currency in the base like PERP quoted
+// perpetuals
+func basisUnderlying(cp currency.Pair) currency.Code {
+	base := cp.Base.Upper().String()
+	if trimmed := strings.TrimRight(base, "0123456789"); trimmed != "" && trimmed != base {
+		return fundingRateUnderlying(currency.NewPair(currency.NewCode(trimmed), currency.PERP))
+	}
+	if _, dated := basisContractExpiry(currency.NewPair(currency.EMPTYCODE, cp.Quote)); dated {
+		cp.Quote = currency.PERP
+	}
+	return fundingRateUnderlying(cp)
+}
+
+// writeBasisHistory appends a snapshot to a CSV file, writing the header when
+// the file is new
+func writeBasisHistory(path string, s *BasisSnapshot) error {
+	info, err := os.Stat(path)
+	writeHeader := err != nil || info.Size() == 0
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, file.DefaultPermissionOctal)
+	if err != nil {
+		return err
+	}
+	w := csv.NewWriter(f)
+	if writeHeader {
+		if err = w.Write(basisHistoryHeader); err != nil {
+			return common.AppendError(err, f.Close())
+		}
+	}
+	for i := range s.Points {
+		p := &s.Points[i]
+		var expiry int64
+		if !p.Expiry.IsZero() {
+			expiry = p.Expiry.Unix()
+		}
+		if err = w.Write([]string{
+			strconv.FormatInt(s.Time.Unix(), 10),
+			p.Exchange,
+			p.Asset.String(),
+			p.Pair.String(),
+			p.Underlying.String(),
+			p.SpotExchange,
+			p.SpotPair.String(),
+			p.SpotMid.String(),
+			p.ContractMid.String(),
+			strconv.FormatBool(p.IsPerpetual),
+			strconv.FormatInt(expiry, 10),
+			p.Basis.String(),
+			p.BasisRate.String(),
+			p.AnnualisedBasis.String(),
+		}); err != nil {
+			return common.AppendError(err, f.Close())
+		}
+	}
+	w.Flush()
+	return common.AppendError(w.Error(), f.Close())
+}
@@ -0,0 +1,293 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+)
+
+// bsExchange aka basis service fake exchange returns orderbooks with fixed
+// best bids and asks under a custom name
+type bsExchange struct {
+	exchange.IBotExchange
+	name  string
+	books map[string][2]float64
+}
+
+func (b *bsExchange) GetName() string {
+	return b.name
+}
+
+func (b *bsExchange) IsPerpetualFutureCurrency(asset.Item, currency.Pair) (bool, error) {
+	return false, common.ErrNotYetImplemented
+}
+
+func (b *bsExchange) FetchOrderbook(_ context.Context, cp currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	book, ok := b.books[bsBookKey(a, cp)]
+	if !ok {
+		return &orderbook.Base{Exchange: b.name, Pair: cp, Asset: a}, nil
+	}
+	return &orderbook.Base{
+		Exchange: b.name,
+		Pair:     cp,
+		Asset:    a,
+		Bids:     orderbook.Items{{Price: book[0], Amount: 1}},
+		Asks:     orderbook.Items{{Price: book[1], Amount: 1}},
+	}, nil
+}
+
+func bsBookKey(a asset.Item, cp currency.Pair) string {
+	return a.String() + cp.Base.Upper().String() + cp.Quote.Upper().String()
+}
+
+func addBasisServiceExchange(t *testing.T, em *ExchangeManager, b *bsExchange, pairs map[asset.Item]currency.Pairs) {
+	t.Helper()
+	exch, err := em.NewExchangeByName("Binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	base := exch.GetBase()
+	base.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	for a, p := range pairs {
+		base.CurrencyPairs.Pairs[a] = &currency.PairStore{
+			Available:     p,
+			Enabled:       p,
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Uppercase: true, Delimiter: currency.DashDelimiter},
+			RequestFormat: &currency.PairFormat{Uppercase: true},
+		}
+	}
+	b.IBotExchange = exch
+	if err = em.Add(b); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestSetupBasisService(t *testing.T) {
+	t.Parallel()
+	_, err := SetupBasisService(nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupBasisService(NewExchangeManager(), nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	b, err := SetupBasisService(NewExchangeManager(), &config.BasisService{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if b.interval <= 0 || b.historyLength <= 0 {
+		t.Error("expected default interval and history length")
+	}
+}
+
+func TestBasisServiceStartStop(t *testing.T) {
+	t.Parallel()
+	var b *BasisService
+	if err := b.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := b.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if b.IsRunning() {
+		t.Error("expected false")
+	}
+	b, err := SetupBasisService(NewExchangeManager(), &config.BasisService{CheckInterval: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = b.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if err = b.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = b.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !b.IsRunning() {
+		t.Error("expected true")
+	}
+	if err = b.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestBasisServiceCalculate(t *testing.T) {
+	t.Parallel()
+	spot := currency.NewPair(currency.BTC, currency.USDT)
+	perp := currency.NewPair(currency.NewCode("BTCUSD"), currency.PERP)
+	dated := currency.NewPair(currency.NewCode("BTCUSD"), currency.NewCode(time.Now().AddDate(0, 0, 90).Format("060102")))
+	expired := currency.NewPair(currency.NewCode("BTCUSD"), currency.NewCode("200925"))
+	far := currency.NewPair(currency.NewCode("BTC"+time.Now().AddDate(0, 0, 180).Format("060102")), currency.USD)
+	eth := currency.NewPair(currency.NewCode("ETHUSD"), currency.PERP)
+
+	em := NewExchangeManager()
+	addBasisServiceExchange(t, em, &bsExchange{
+		name: "alpha",
+		books: map[string][2]float64{
+			bsBookKey(asset.Spot, spot):                   {19990, 20010},
+			bsBookKey(asset.CoinMarginedFutures, perp):    {20000, 20020},
+			bsBookKey(asset.CoinMarginedFutures, dated):   {20190, 20210},
+			bsBookKey(asset.CoinMarginedFutures, expired): {1, 2},
+		},
+	}, map[asset.Item]currency.Pairs{
+		asset.Spot:                {spot},
+		asset.CoinMarginedFutures: {perp, dated, expired},
+	})
+	addBasisServiceExchange(t, em, &bsExchange{
+		name: "beta",
+		books: map[string][2]float64{
+			bsBookKey(asset.Futures, far): {20390, 20410},
+			bsBookKey(asset.Futures, eth): {1500, 1502},
+		},
+	}, map[asset.Item]currency.Pairs{
+		asset.Futures: {far, eth},
+	})
+
+	historyFile := filepath.Join(t.TempDir(), "basis.csv")
+	b, err := SetupBasisService(em, &config.BasisService{
+		CheckInterval: time.Hour,
+		HistoryLength: 1,
+		HistoryFile:   historyFile,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = b.LatestSnapshot()
+	if !errors.Is(err, errNoBasisSnapshot) {
+		t.Errorf("received '%v', expected '%v'", err, errNoBasisSnapshot)
+	}
+	_, err = b.Calculate(context.Background())
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	b.started = 1
+	snapshot, err := b.Calculate(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// ETH has no spot pair on any exchange
+	if len(snapshot.Errors) != 1 {
+		t.Errorf("received '%v', expected one error", snapshot.Errors)
+	}
+	if len(snapshot.Points) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(snapshot.Points), 3)
+	}
+
+	p := snapshot.Points[0]
+	if p.Exchange != "alpha" || !p.IsPerpetual || !p.Pair.Equal(perp) {
+		t.Errorf("received '%+v', expected alpha perpetual first", p)
+	}
+	if expected := decimal.NewFromInt(10); !p.Basis.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", p.Basis, expected)
+	}
+	if !p.AnnualisedBasis.IsZero() {
+		t.Errorf("received '%v', expected '%v'", p.AnnualisedBasis, 0)
+	}
+
+	p = snapshot.Points[1]
+	if !p.Pair.Equal(dated) || p.IsPerpetual || p.Expiry.IsZero() {
+		t.Errorf("received '%+v', expected alpha dated contract", p)
+	}
+	if expected := decimal.NewFromFloat(0.01); !p.BasisRate.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", p.BasisRate, expected)
+	}
+	// roughly 0.01 over 90 days
+	if p.AnnualisedBasis.LessThan(decimal.NewFromFloat(0.039)) || p.AnnualisedBasis.GreaterThan(decimal.NewFromFloat(0.042)) {
+		t.Errorf("received '%v', expected about '%v'", p.AnnualisedBasis, 0.0406)
+	}
+
+	p = snapshot.Points[2]
+	if p.Exchange != "beta" || p.SpotExchange != "alpha" || !p.Underlying.Equal(currency.BTC) {
+		t.Errorf("received '%+v', expected beta contract priced against alpha spot", p)
+	}
+
+	terms, err := b.TermStructures(currency.BTC, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(terms) != 2 || len(terms[0].Points) != 2 || len(terms[1].Points) != 1 {
+		t.Errorf("received '%+v', expected alpha and beta term structures", terms)
+	}
+	_, err = b.TermStructures(currency.ETH, "")
+	if !errors.Is(err, errNoBasisForFilter) {
+		t.Errorf("received '%v', expected '%v'", err, errNoBasisForFilter)
+	}
+
+	if _, err = b.Calculate(context.Background()); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	history, err := b.History(currency.BTC, "ALPHA", asset.CoinMarginedFutures, perp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(history) != 1 {
+		t.Errorf("received '%v', expected '%v'", len(history), 1)
+	}
+
+	f, err := os.Open(historyFile)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	defer f.Close()
+	records, err := csv.NewReader(f).ReadAll()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(records) != 7 {
+		t.Errorf("received '%v', expected '%v'", len(records), 7)
+	}
+}
+
+func TestBasisContractExpiry(t *testing.T) {
+	t.Parallel()
+	expected := time.Date(2023, 9, 29, basisExpiryHour, 0, 0, 0, time.UTC)
+	for _, cp := range []currency.Pair{
+		currency.NewPair(currency.NewCode("BTCUSD"), currency.NewCode("230929")),
+		currency.NewPair(currency.BTC, currency.NewCode("USD-20230929")),
+		currency.NewPair(currency.NewCode("BTC230929"), currency.USD),
+	} {
+		expiry, ok := basisContractExpiry(cp)
+		if !ok || !expiry.Equal(expected) {
+			t.Errorf("%v received '%v', expected '%v'", cp, expiry, expected)
+		}
+	}
+	if _, ok := basisContractExpiry(currency.NewPair(currency.NewCode("BTCUSD"), currency.PERP)); ok {
+		t.Error("expected no expiry")
+	}
+}
+
+func TestBasisUnderlying(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		pair     currency.Pair
+		expected currency.Code
+	}{
+		{currency.NewPair(currency.BTC, currency.USDT), currency.BTC},
+		{currency.NewPair(currency.NewCode("BTCUSD"), currency.PERP), currency.BTC},
+		{currency.NewPair(currency.NewCode("ETHUSD"), currency.NewCode("230929")), currency.ETH},
+		{currency.NewPair(currency.NewCode("BTC230929"), currency.USD), currency.BTC},
+	}
+	for i := range testCases {
+		if c := basisUnderlying(testCases[i].pair); !c.Equal(testCases[i].expected) {
+			t.Errorf("received '%v', expected '%v'", c, testCases[i].expected)
+		}
+	}
+}
@@ -0,0 +1,125 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// BasisServiceName is an exported subsystem name
+const BasisServiceName = "basis_service"
+
+const (
+	// basisYear is the period basis is annualised over
+	basisYear = time.Hour * 24 * 365
+	// basisExpiryHour is the UTC hour dated contracts are assumed to expire
+	// on their expiry date, most exchanges settle at 08:00 UTC
+	basisExpiryHour = 8
+)
+
+var (
+	errNoBasisSnapshot  = errors.New("basis has not been calculated")
+	errNoSpotReference  = errors.New("no spot pair available for underlying")
+	errEmptyOrderbook   = errors.New("orderbook has no bids or asks")
+	errNoBasisForFilter = errors.New("no basis found for the supplied filter")
+)
+
+// basisSpotQuotes are the spot quote currencies used to price an underlying
+// in order of preference
+var basisSpotQuotes = []currency.Code{currency.USDT, currency.USD, currency.USDC, currency.BUSD}
+
+// basisHistoryHeader is the header of the basis history CSV file
+var basisHistoryHeader = []string{
+	"timestamp",
+	"exchange",
+	"asset",
+	"pair",
+	"underlying",
+	"spot_exchange",
+	"spot_pair",
+	"spot_mid",
+	"contract_mid",
+	"perpetual",
+	"expiry",
+	"basis",
+	"basis_rate",
+	"annualised_basis",
+}
+
+// BasisService periodically pairs the spot price of each underlying with
+// every enabled dated and perpetual futures contract across exchanges and
+// calculates the basis between them from orderbook mid prices
+type BasisService struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	m               sync.Mutex
+	exchangeManager iExchangeManager
+	interval        time.Duration
+	historyLength   int
+	historyFile     string
+	verbose         bool
+	history         []BasisSnapshot
+}
+
+// BasisSnapshot holds the basis of every contract at a point in time
+type BasisSnapshot struct {
+	Time   time.Time
+	Points []BasisPoint
+	Errors []string
+}
+
+// BasisPoint is the basis of a futures contract against the spot price of its
+// underlying
+type BasisPoint struct {
+	Time       time.Time
+	Exchange   string
+	Asset      asset.Item
+	Pair       currency.Pair
+	Underlying currency.Code
+	// SpotExchange is the exchange the spot price was taken from, the
+	// contract's exchange is preferred
+	SpotExchange string
+	SpotPair     currency.Pair
+	SpotMid      decimal.Decimal
+	ContractMid  decimal.Decimal
+	IsPerpetual  bool
+	Expiry       time.Time
+	// Basis is the contract mid less the spot mid
+	Basis decimal.Decimal
+	// BasisRate is the basis as a fraction of the spot mid
+	BasisRate decimal.Decimal
+	// AnnualisedBasis is the basis rate annualised over the time remaining
+	// until expiry, it is zero for perpetuals
+	AnnualisedBasis decimal.Decimal
+}
+
+// TermStructure is the basis curve of an underlying on an exchange ordered by
+// expiry with perpetuals first
+type TermStructure struct {
+	Time       time.Time
+	Exchange   string
+	Underlying currency.Code
+	Points     []BasisPoint
+}
+
+// basisContract is an enabled futures contract awaiting pricing
+type basisContract struct {
+	asset       asset.Item
+	pair        currency.Pair
+	underlying  currency.Code
+	isPerpetual bool
+	expiry      time.Time
+}
+
+// spotReference is the spot price of an underlying on an exchange
+type spotReference struct {
+	exchange string
+	pair     currency.Pair
+	mid      decimal.Decimal
+}
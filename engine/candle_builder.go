@@ -0,0 +1,511 @@
+package engine
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupCandleBuilder creates a candle builder subsystem. The database
+// connection manager is only required when closed candles are stored
+func SetupCandleBuilder(exchangeManager iExchangeManager, dcm iDatabaseConnectionManager, cfg *config.CandleBuilder) (*CandleBuilder, error) {
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.SaveToDatabase && dcm == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	if len(cfg.Intervals) == 0 {
+		return nil, fmt.Errorf("%s %w", CandleBuilderName, kline.ErrInvalidInterval)
+	}
+	intervals := make([]kline.Interval, len(cfg.Intervals))
+	for i := range cfg.Intervals {
+		if cfg.Intervals[i] <= 0 {
+			return nil, fmt.Errorf("%s %w %v", CandleBuilderName, kline.ErrInvalidInterval, cfg.Intervals[i])
+		}
+		intervals[i] = kline.Interval(cfg.Intervals[i])
+	}
+	c := &CandleBuilder{
+		verbose:         cfg.Verbose,
+		saveToDatabase:  cfg.SaveToDatabase,
+		intervals:       intervals,
+		closeDelay:      cfg.CloseDelay,
+		exchangeManager: exchangeManager,
+		mux:             dispatch.GetNewMux(nil),
+		series:          make(map[candleSeriesKey]*candleSeries),
+		pending:         make(map[candleSeriesKey]*kline.Item),
+		shutdown:        make(chan struct{}),
+		candleSaver:     kline.StoreInDatabase,
+	}
+	if cfg.SaveToDatabase {
+		c.databaseConnectionManager = dcm
+	}
+	return c, nil
+}
+
+// Start runs the subsystem
+func (c *CandleBuilder) Start() error {
+	if c == nil {
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrNilSubsystem)
+	}
+	c.m.Lock()
+	if !atomic.CompareAndSwapInt32(&c.started, 0, 1) {
+		c.m.Unlock()
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrSubSystemAlreadyStarted)
+	}
+	c.shutdown = make(chan struct{})
+	c.ctx, c.cancel = context.WithCancel(context.Background())
+	c.m.Unlock()
+	c.wg.Add(1)
+	go c.run()
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (c *CandleBuilder) IsRunning() bool {
+	if c == nil {
+		return false
+	}
+	return atomic.LoadInt32(&c.started) == 1
+}
+
+// Stop stops the subsystem, any closed candles which have not been stored
+// are stored before returning
+func (c *CandleBuilder) Stop() error {
+	if c == nil {
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrNilSubsystem)
+	}
+	// trades are processed with the lock held, so once the state has changed
+	// no further backfills can be started
+	c.m.Lock()
+	if !atomic.CompareAndSwapInt32(&c.started, 1, 0) {
+		c.m.Unlock()
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrSubSystemNotStarted)
+	}
+	c.cancel()
+	c.m.Unlock()
+	close(c.shutdown)
+	c.wg.Wait()
+	c.savePending()
+	log.Debugf(log.Trade, "Candle builder %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// Intervals returns the candle intervals being built
+func (c *CandleBuilder) Intervals() []kline.Interval {
+	if c == nil {
+		return nil
+	}
+	return append([]kline.Interval(nil), c.intervals...)
+}
+
+// Subscribe returns a pipe which receives a *CandleUpdate every time a candle
+// for the pair and interval is updated or closed
+func (c *CandleBuilder) Subscribe(exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval) (dispatch.Pipe, error) {
+	if c == nil {
+		return dispatch.Pipe{}, fmt.Errorf("%s %w", CandleBuilderName, ErrNilSubsystem)
+	}
+	if err := c.validateSeries(exchangeName, p, a, interval); err != nil {
+		return dispatch.Pipe{}, err
+	}
+	c.m.Lock()
+	s, err := c.getSeries(exchangeName, p, a, interval)
+	c.m.Unlock()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	return c.mux.Subscribe(s.id)
+}
+
+// GetCurrentCandle returns the candle currently being built for the pair and
+// interval
+func (c *CandleBuilder) GetCurrentCandle(exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval) (*CandleUpdate, error) {
+	if c == nil {
+		return nil, fmt.Errorf("%s %w", CandleBuilderName, ErrNilSubsystem)
+	}
+	if err := c.validateSeries(exchangeName, p, a, interval); err != nil {
+		return nil, err
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	s, ok := c.series[newCandleSeriesKey(exchangeName, p, a, interval)]
+	if !ok || !s.building {
+		return nil, fmt.Errorf("%w %s %s %s %s", errCandleSeriesNotFound, exchangeName, a, p, interval)
+	}
+	return s.update(a, interval, false), nil
+}
+
+// ProcessTrades adds trades to the candles of every interval being built.
+// Trades for candles which have already been closed are ignored
+func (c *CandleBuilder) ProcessTrades(trades ...trade.Data) error {
+	if c == nil {
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrNilSubsystem)
+	}
+	var updates []*CandleUpdate
+	var errs error
+	c.m.Lock()
+	if !c.IsRunning() {
+		c.m.Unlock()
+		return fmt.Errorf("%s %w", CandleBuilderName, ErrSubSystemNotStarted)
+	}
+	for i := range trades {
+		if trades[i].Timestamp.IsZero() || trades[i].Price == 0 {
+			errs = common.AppendError(errs, fmt.Errorf("%s %s %s %w", trades[i].Exchange, trades[i].AssetType, trades[i].CurrencyPair, errInvalidTrade))
+			continue
+		}
+		for j := range c.intervals {
+			s, err := c.getSeries(trades[i].Exchange, trades[i].CurrencyPair, trades[i].AssetType, c.intervals[j])
+			if err != nil {
+				errs = common.AppendError(errs, err)
+				break
+			}
+			if s.backfilling {
+				// trades are replayed once the backfilled candles have been
+				// published so subscribers receive candles in order
+				s.queued = append(s.queued, trades[i])
+				continue
+			}
+			updates = append(updates, c.addTrade(s, &trades[i], c.intervals[j])...)
+		}
+	}
+	c.m.Unlock()
+	c.publish(updates...)
+	return errs
+}
+
+// websocketDataHandler receives trades and reconnection notifications from
+// the websocket routine manager
+func (c *CandleBuilder) websocketDataHandler(exchangeName string, data interface{}) error {
+	if !c.IsRunning() {
+		return nil
+	}
+	switch d := data.(type) {
+	case []trade.Data:
+		return c.ProcessTrades(d...)
+	case stream.Reconnected:
+		c.reconnected(exchangeName, d.Timestamp)
+	}
+	return nil
+}
+
+// reconnected backfills candles for every series of the exchange from the
+// exchange's REST API, as trades sent while the websocket was disconnected
+// have been missed. Candles which opened before the reconnection time are
+// retrieved
+func (c *CandleBuilder) reconnected(exchangeName string, at time.Time) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if !c.IsRunning() {
+		return
+	}
+	for k, s := range c.series {
+		if !strings.EqualFold(s.exchange, exchangeName) || s.backfilling {
+			continue
+		}
+		var start time.Time
+		switch {
+		case s.building:
+			start = s.current.Time
+		case !s.lastClosed.IsZero():
+			start = s.lastClosed.Add(k.Interval.Duration())
+		default:
+			continue
+		}
+		end := at.UTC().Truncate(k.Interval.Duration())
+		if !start.Before(end) {
+			continue
+		}
+		s.backfilling = true
+		c.backfill(s, k.Asset, k.Interval, start, end)
+	}
+}
+
+// validateSeries ensures candles can be built for the supplied details
+func (c *CandleBuilder) validateSeries(exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval) error {
+	if exchangeName == "" {
+		return errExchangeNameUnset
+	}
+	if p.IsEmpty() {
+		return currency.ErrCurrencyPairEmpty
+	}
+	if !a.IsValid() {
+		return fmt.Errorf("%w %v", asset.ErrNotSupported, a)
+	}
+	for i := range c.intervals {
+		if c.intervals[i] == interval {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w %s", errCandleIntervalNotBuilt, interval)
+}
+
+func newCandleSeriesKey(exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval) candleSeriesKey {
+	return candleSeriesKey{
+		Exchange: strings.ToLower(exchangeName),
+		Base:     p.Base.Item,
+		Quote:    p.Quote.Item,
+		Asset:    a,
+		Interval: interval,
+	}
+}
+
+// getSeries returns the series for the pair and interval, creating it if it
+// does not exist. Must be called with the lock held
+func (c *CandleBuilder) getSeries(exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval) (*candleSeries, error) {
+	key := newCandleSeriesKey(exchangeName, p, a, interval)
+	s, ok := c.series[key]
+	if ok {
+		return s, nil
+	}
+	if exchangeName == "" || p.IsEmpty() || !a.IsValid() {
+		return nil, fmt.Errorf("%s %s %s %w", exchangeName, a, p, errInvalidTrade)
+	}
+	id, err := c.mux.GetID()
+	if err != nil {
+		return nil, err
+	}
+	s = &candleSeries{id: id, exchange: exchangeName, pair: p}
+	c.series[key] = s
+	return s, nil
+}
+
+// addTrade adds a trade to a series, closing the previous candle when the
+// trade is for a later interval. Must be called with the lock held
+func (c *CandleBuilder) addTrade(s *candleSeries, t *trade.Data, interval kline.Interval) []*CandleUpdate {
+	openTime := t.Timestamp.UTC().Truncate(interval.Duration())
+	price := math.Abs(t.Price)
+	amount := math.Abs(t.Amount)
+	var updates []*CandleUpdate
+	switch {
+	case s.building && openTime.Equal(s.current.Time):
+		s.current.High = math.Max(s.current.High, price)
+		s.current.Low = math.Min(s.current.Low, price)
+		s.current.Close = price
+		s.current.Volume += amount
+	case s.building && openTime.Before(s.current.Time),
+		!s.building && !s.lastClosed.IsZero() && !openTime.After(s.lastClosed):
+		if c.verbose {
+			log.Debugf(log.Trade, "Candle builder %s %s %s %s ignoring trade at %v for closed candle",
+				t.Exchange, t.AssetType, t.CurrencyPair, interval, t.Timestamp)
+		}
+		return nil
+	default:
+		if s.building {
+			updates = append(updates, c.closeCandle(s, t.AssetType, interval))
+		}
+		s.current = kline.Candle{
+			Time:   openTime,
+			Open:   price,
+			High:   price,
+			Low:    price,
+			Close:  price,
+			Volume: amount,
+		}
+		s.building = true
+	}
+	return append(updates, s.update(t.AssetType, interval, false))
+}
+
+// closeCandle closes the candle being built and queues it to be stored. Must
+// be called with the lock held
+func (c *CandleBuilder) closeCandle(s *candleSeries, a asset.Item, interval kline.Interval) *CandleUpdate {
+	s.building = false
+	s.lastClosed = s.current.Time
+	c.queueSave(s, a, interval, s.current)
+	return s.update(a, interval, true)
+}
+
+// queueSave adds closed candles to be stored on the next check. Must be
+// called with the lock held
+func (c *CandleBuilder) queueSave(s *candleSeries, a asset.Item, interval kline.Interval, candles ...kline.Candle) {
+	if !c.saveToDatabase {
+		return
+	}
+	key := newCandleSeriesKey(s.exchange, s.pair, a, interval)
+	item, ok := c.pending[key]
+	if !ok {
+		item = &kline.Item{
+			Exchange: s.exchange,
+			Pair:     s.pair,
+			Asset:    a,
+			Interval: interval,
+		}
+		c.pending[key] = item
+	}
+	item.Candles = append(item.Candles, candles...)
+}
+
+// update returns a candle update for the series current candle
+func (s *candleSeries) update(a asset.Item, interval kline.Interval, closed bool) *CandleUpdate {
+	return &CandleUpdate{
+		Exchange: s.exchange,
+		Pair:     s.pair,
+		Asset:    a,
+		Interval: interval,
+		Candle:   s.current,
+		Closed:   closed,
+		id:       s.id,
+	}
+}
+
+// publish sends candle updates to subscribers
+func (c *CandleBuilder) publish(updates ...*CandleUpdate) {
+	for i := range updates {
+		if err := c.mux.Publish(updates[i], updates[i].id); err != nil {
+			log.Errorf(log.Trade, "Candle builder unable to publish %s %s %s %s candle: %v",
+				updates[i].Exchange, updates[i].Asset, updates[i].Pair, updates[i].Interval, err)
+		}
+	}
+}
+
+func (c *CandleBuilder) run() {
+	defer c.wg.Done()
+	t := time.NewTicker(candleBuilderCheckInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-c.shutdown:
+			return
+		case now := <-t.C:
+			c.closeExpired(now)
+			c.savePending()
+		}
+	}
+}
+
+// closeExpired closes candles which have not received a trade for the next
+// interval once their interval and the close delay have passed
+func (c *CandleBuilder) closeExpired(now time.Time) {
+	var updates []*CandleUpdate
+	c.m.Lock()
+	for k, s := range c.series {
+		if !s.building || s.backfilling || now.Before(s.current.Time.Add(k.Interval.Duration()+c.closeDelay)) {
+			continue
+		}
+		updates = append(updates, c.closeCandle(s, k.Asset, k.Interval))
+	}
+	c.m.Unlock()
+	c.publish(updates...)
+}
+
+// savePending stores closed candles in the database
+func (c *CandleBuilder) savePending() {
+	c.m.Lock()
+	if len(c.pending) == 0 {
+		c.m.Unlock()
+		return
+	}
+	pending := c.pending
+	c.pending = make(map[candleSeriesKey]*kline.Item)
+	c.m.Unlock()
+
+	var db database.IDatabase
+	if c.databaseConnectionManager != nil {
+		db = c.databaseConnectionManager.GetInstance()
+	}
+	if db == nil || !db.IsConnected() {
+		if c.verbose {
+			log.Warnf(log.Trade, "Candle builder database is not connected, %d closed candle series not stored", len(pending))
+		}
+		return
+	}
+	for _, item := range pending {
+		if _, err := c.candleSaver(item, false); err != nil {
+			log.Errorf(log.Trade, "Candle builder unable to store %s %s %s %s candles: %v",
+				item.Exchange, item.Asset, item.Pair, item.Interval, err)
+		}
+	}
+}
+
+// backfill retrieves candles missed while the websocket was disconnected
+// from the exchange's REST API. Trades received in the meantime are queued
+// and replayed once the backfilled candles have been published. Must be
+// called with the lock held
+func (c *CandleBuilder) backfill(s *candleSeries, a asset.Item, interval kline.Interval, start, end time.Time) {
+	if c.verbose {
+		log.Debugf(log.Trade, "Candle builder %s %s %s %s backfilling candles from %v to %v",
+			s.exchange, a, s.pair, interval, start, end)
+	}
+	ctx := c.ctx
+	c.wg.Add(1)
+	go func() {
+		defer c.wg.Done()
+		candles, err := c.getMissingCandles(ctx, s.exchange, s.pair, a, interval, start, end)
+		if err != nil {
+			log.Errorf(log.Trade, "Candle builder unable to backfill %s %s %s %s candles from %v to %v: %v",
+				s.exchange, a, s.pair, interval, start, end, err)
+		}
+		// updates are published with the lock held so live updates for the
+		// series cannot be published before the backfilled candles
+		c.m.Lock()
+		defer c.m.Unlock()
+		c.publish(c.completeBackfill(s, a, interval, candles)...)
+	}()
+}
+
+// completeBackfill closes the backfilled candles and replays trades queued
+// during the backfill. An exchange candle replaces the candle being built for
+// the same interval as it includes trades missed while disconnected. Must be
+// called with the lock held
+func (c *CandleBuilder) completeBackfill(s *candleSeries, a asset.Item, interval kline.Interval, candles []kline.Candle) []*CandleUpdate {
+	var updates []*CandleUpdate
+	for i := range candles {
+		if !s.lastClosed.IsZero() && !candles[i].Time.After(s.lastClosed) {
+			continue
+		}
+		if s.building && s.current.Time.Before(candles[i].Time) {
+			updates = append(updates, c.closeCandle(s, a, interval))
+		}
+		s.current = candles[i]
+		updates = append(updates, c.closeCandle(s, a, interval))
+	}
+	s.backfilling = false
+	queued := s.queued
+	s.queued = nil
+	for i := range queued {
+		updates = append(updates, c.addTrade(s, &queued[i], interval)...)
+	}
+	return updates
+}
+
+// getMissingCandles returns exchange candles which open within the start and
+// end times
+func (c *CandleBuilder) getMissingCandles(ctx context.Context, exchangeName string, p currency.Pair, a asset.Item, interval kline.Interval, start, end time.Time) ([]kline.Candle, error) {
+	exch, err := c.exchangeManager.GetExchangeByName(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	ctx, cancel := context.WithTimeout(ctx, candleBuilderBackfillTimeout)
+	defer cancel()
+	item, err := exch.GetHistoricCandles(ctx, p, a, interval, start, end)
+	if err != nil {
+		return nil, err
+	}
+	item.SortCandlesByTimestamp(false)
+	candles := make([]kline.Candle, 0, len(item.Candles))
+	for i := range item.Candles {
+		if item.Candles[i].Time.Before(start) || !item.Candles[i].Time.Before(end) {
+			continue
+		}
+		candles = append(candles, item.Candles[i])
+	}
+	return candles, nil
+}
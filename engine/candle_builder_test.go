@@ -0,0 +1,373 @@
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// cbExchange aka candle builder fake exchange returns a candle for every
+// interval requested, once wait is closed if set
+type cbExchange struct {
+	exchange.IBotExchange
+	wait chan struct{}
+}
+
+func (c *cbExchange) GetHistoricCandles(_ context.Context, p currency.Pair, a asset.Item, interval kline.Interval, start, end time.Time) (*kline.Item, error) {
+	if c.wait != nil {
+		<-c.wait
+	}
+	item := &kline.Item{Exchange: "fake", Pair: p, Asset: a, Interval: interval}
+	for t := start.Add(-interval.Duration()); !t.After(end); t = t.Add(interval.Duration()) {
+		item.Candles = append(item.Candles, kline.Candle{Time: t, Open: 1, High: 2, Low: 0.5, Close: 1.5, Volume: 10})
+	}
+	return item, nil
+}
+
+type cbExchangeManager struct {
+	wait chan struct{}
+}
+
+func (c *cbExchangeManager) GetExchanges() ([]exchange.IBotExchange, error) {
+	return []exchange.IBotExchange{&cbExchange{wait: c.wait}}, nil
+}
+
+func (c *cbExchangeManager) GetExchangeByName(string) (exchange.IBotExchange, error) {
+	return &cbExchange{wait: c.wait}, nil
+}
+
+// cbDatabase is a connected database and database connection manager
+type cbDatabase struct{}
+
+func (c *cbDatabase) IsConnected() bool {
+	return true
+}
+
+func (c *cbDatabase) GetSQL() (*sql.DB, error) {
+	return nil, nil
+}
+
+func (c *cbDatabase) GetConfig() *database.Config {
+	return nil
+}
+
+func (c *cbDatabase) GetInstance() database.IDatabase {
+	return c
+}
+
+func newCandleBuilderTestTrade(t time.Time, price, amount float64) trade.Data {
+	return trade.Data{
+		Exchange:     "fake",
+		CurrencyPair: currency.NewPair(currency.BTC, currency.USDT),
+		AssetType:    asset.Spot,
+		Price:        price,
+		Amount:       amount,
+		Timestamp:    t,
+	}
+}
+
+func TestSetupCandleBuilder(t *testing.T) {
+	t.Parallel()
+	_, err := SetupCandleBuilder(nil, nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupCandleBuilder(&cbExchangeManager{}, nil, nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{SaveToDatabase: true})
+	if !errors.Is(err, errNilDatabaseConnectionManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilDatabaseConnectionManager)
+	}
+	_, err = SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{})
+	if !errors.Is(err, kline.ErrInvalidInterval) {
+		t.Errorf("received '%v', expected '%v'", err, kline.ErrInvalidInterval)
+	}
+	_, err = SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{-time.Minute}})
+	if !errors.Is(err, kline.ErrInvalidInterval) {
+		t.Errorf("received '%v', expected '%v'", err, kline.ErrInvalidInterval)
+	}
+	c, err := SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{time.Minute, time.Hour}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if intervals := c.Intervals(); len(intervals) != 2 || intervals[0] != kline.OneMin || intervals[1] != kline.OneHour {
+		t.Errorf("received '%v', expected '%v'", intervals, []kline.Interval{kline.OneMin, kline.OneHour})
+	}
+}
+
+func TestCandleBuilderStartStop(t *testing.T) {
+	t.Parallel()
+	var c *CandleBuilder
+	if err := c.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := c.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if c.IsRunning() {
+		t.Error("expected candle builder not to be running")
+	}
+	c, err := SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{time.Minute}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if err = c.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !c.IsRunning() {
+		t.Error("expected candle builder to be running")
+	}
+	if err = c.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestCandleBuilderProcessTrades(t *testing.T) {
+	t.Parallel()
+	wait := make(chan struct{})
+	c, err := SetupCandleBuilder(&cbExchangeManager{wait: wait}, &cbDatabase{}, &config.CandleBuilder{
+		Intervals:      []time.Duration{time.Minute, time.Minute * 5},
+		CloseDelay:     time.Second,
+		SaveToDatabase: true,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	var saved []*kline.Item
+	var savedMtx sync.Mutex
+	c.candleSaver = func(item *kline.Item, _ bool) (uint64, error) {
+		savedMtx.Lock()
+		defer savedMtx.Unlock()
+		saved = append(saved, item)
+		return uint64(len(item.Candles)), nil
+	}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	err = c.ProcessTrades(newCandleBuilderTestTrade(start, 100, 1))
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if err = c.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	err = c.ProcessTrades(
+		newCandleBuilderTestTrade(start.Add(time.Second), 100, 1),
+		newCandleBuilderTestTrade(start.Add(time.Second*10), 105, -2),
+		newCandleBuilderTestTrade(start.Add(time.Second*20), 95, 1),
+		newCandleBuilderTestTrade(start.Add(time.Second*30), 0, 1),
+	)
+	if !errors.Is(err, errInvalidTrade) {
+		t.Fatalf("received '%v', expected '%v'", err, errInvalidTrade)
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	current, err := c.GetCurrentCandle("FAKE", p, asset.Spot, kline.OneMin)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	expected := kline.Candle{Time: start, Open: 100, High: 105, Low: 95, Close: 95, Volume: 4}
+	if current.Closed || current.Candle != expected {
+		t.Errorf("received '%+v', expected '%+v'", current.Candle, expected)
+	}
+
+	_, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneHour)
+	if !errors.Is(err, errCandleIntervalNotBuilt) {
+		t.Errorf("received '%v', expected '%v'", err, errCandleIntervalNotBuilt)
+	}
+	_, err = c.GetCurrentCandle("fake", p, asset.Futures, kline.OneMin)
+	if !errors.Is(err, errCandleSeriesNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, errCandleSeriesNotFound)
+	}
+	_, err = c.Subscribe("", p, asset.Spot, kline.OneMin)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Errorf("received '%v', expected '%v'", err, errExchangeNameUnset)
+	}
+	_, err = c.Subscribe("fake", currency.EMPTYPAIR, asset.Spot, kline.OneMin)
+	if !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Errorf("received '%v', expected '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
+	_, err = c.Subscribe("fake", p, asset.Empty, kline.OneMin)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Errorf("received '%v', expected '%v'", err, asset.ErrNotSupported)
+	}
+
+	// a trade in the next minute closes the first candle, a late trade for
+	// the closed candle is ignored
+	err = c.ProcessTrades(
+		newCandleBuilderTestTrade(start.Add(time.Minute), 110, 1),
+		newCandleBuilderTestTrade(start.Add(time.Second*59), 1000, 1),
+	)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	current, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneMin)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if current.Candle.Time != start.Add(time.Minute) || current.Candle.Open != 110 {
+		t.Errorf("received '%+v', expected open of 110 at '%v'", current.Candle, start.Add(time.Minute))
+	}
+	current, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.FiveMin)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if current.Candle.High != 1000 || current.Candle.Volume != 6 {
+		t.Errorf("received '%+v', expected high of 1000 and volume of 6", current.Candle)
+	}
+
+	// no trades are received so the candle is closed once the interval and
+	// close delay have passed
+	c.closeExpired(start.Add(time.Minute * 2))
+	if _, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneMin); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	c.closeExpired(start.Add(time.Minute*2 + time.Second))
+	if _, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneMin); !errors.Is(err, errCandleSeriesNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, errCandleSeriesNotFound)
+	}
+
+	// a reconnection backfills the missed candles, trades received during the
+	// backfill are queued until the backfilled candles have been closed
+	err = c.websocketDataHandler("fake", stream.Reconnected{Exchange: "fake", Timestamp: start.Add(time.Minute*4 + time.Second*30)})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = c.ProcessTrades(newCandleBuilderTestTrade(start.Add(time.Minute*4), 120, 1))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if _, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneMin); !errors.Is(err, errCandleSeriesNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, errCandleSeriesNotFound)
+	}
+	close(wait)
+	if err = c.Stop(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	current, err = c.GetCurrentCandle("fake", p, asset.Spot, kline.OneMin)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if current.Candle.Time != start.Add(time.Minute*4) || current.Candle.Open != 120 {
+		t.Errorf("received '%+v', expected open of 120 at '%v'", current.Candle, start.Add(time.Minute*4))
+	}
+
+	savedMtx.Lock()
+	defer savedMtx.Unlock()
+	var oneMinute []kline.Candle
+	for i := range saved {
+		if saved[i].Interval == kline.OneMin {
+			oneMinute = append(oneMinute, saved[i].Candles...)
+		}
+	}
+	if len(oneMinute) != 4 {
+		t.Fatalf("received '%v' saved candles, expected '%v'", len(oneMinute), 4)
+	}
+	for i, expectedTime := range []time.Time{start, start.Add(time.Minute), start.Add(time.Minute * 2), start.Add(time.Minute * 3)} {
+		if !oneMinute[i].Time.Equal(expectedTime) {
+			t.Errorf("received '%v', expected '%v'", oneMinute[i].Time, expectedTime)
+		}
+	}
+	if oneMinute[2].Volume != 10 {
+		t.Errorf("received '%v', expected backfilled volume of '%v'", oneMinute[2].Volume, 10)
+	}
+}
+
+func TestCandleBuilderCompleteBackfill(t *testing.T) {
+	t.Parallel()
+	c, err := SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{time.Minute}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	s := &candleSeries{
+		exchange:    "fake",
+		pair:        currency.NewPair(currency.BTC, currency.USDT),
+		current:     kline.Candle{Time: start, Open: 100, High: 100, Low: 100, Close: 100, Volume: 1},
+		building:    true,
+		lastClosed:  start.Add(-time.Minute),
+		backfilling: true,
+		queued:      []trade.Data{newCandleBuilderTestTrade(start.Add(time.Minute*3), 120, 1)},
+	}
+	candles := []kline.Candle{
+		{Time: start.Add(-time.Minute), Volume: 5},
+		{Time: start, Volume: 10},
+		{Time: start.Add(time.Minute), Volume: 10},
+		{Time: start.Add(time.Minute * 2), Volume: 10},
+	}
+	updates := c.completeBackfill(s, asset.Spot, kline.OneMin, candles)
+	if len(updates) != 4 {
+		t.Fatalf("received '%v' updates, expected '%v'", len(updates), 4)
+	}
+	// the exchange candle replaces the candle being built, backfilled candles
+	// are closed in order before the queued trade is replayed
+	for i := 0; i < 3; i++ {
+		if !updates[i].Closed || updates[i].Candle != candles[i+1] {
+			t.Errorf("received '%+v', expected closed '%+v'", updates[i].Candle, candles[i+1])
+		}
+	}
+	if updates[3].Closed || updates[3].Candle.Time != start.Add(time.Minute*3) || updates[3].Candle.Open != 120 {
+		t.Errorf("received '%+v', expected open of 120 at '%v'", updates[3].Candle, start.Add(time.Minute*3))
+	}
+	if s.backfilling || len(s.queued) != 0 {
+		t.Errorf("received backfilling '%v' with '%v' queued trades, expected '%v' with '%v'", s.backfilling, len(s.queued), false, 0)
+	}
+
+	// a candle being built before the backfilled candles is closed first
+	s.backfilling = true
+	updates = c.completeBackfill(s, asset.Spot, kline.OneMin, []kline.Candle{{Time: start.Add(time.Minute * 5), Volume: 10}})
+	if len(updates) != 2 {
+		t.Fatalf("received '%v' updates, expected '%v'", len(updates), 2)
+	}
+	if !updates[0].Closed || updates[0].Candle.Time != start.Add(time.Minute*3) {
+		t.Errorf("received '%+v', expected closed candle at '%v'", updates[0].Candle, start.Add(time.Minute*3))
+	}
+	if !updates[1].Closed || updates[1].Candle.Time != start.Add(time.Minute*5) {
+		t.Errorf("received '%+v', expected closed candle at '%v'", updates[1].Candle, start.Add(time.Minute*5))
+	}
+}
+
+func TestCandleBuilderWebsocketDataHandler(t *testing.T) {
+	t.Parallel()
+	c, err := SetupCandleBuilder(&cbExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{time.Minute}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	tr := newCandleBuilderTestTrade(time.Now(), 100, 1)
+	if err = c.websocketDataHandler("fake", []trade.Data{tr}); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.websocketDataHandler("fake", "not trades"); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.websocketDataHandler("fake", []trade.Data{tr}); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if _, err = c.GetCurrentCandle("fake", tr.CurrencyPair, tr.AssetType, kline.OneMin); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err = c.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
@@ -0,0 +1,88 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// CandleBuilderName is an exported subsystem name
+const CandleBuilderName = "candle_builder"
+
+const (
+	// candleBuilderCheckInterval is how often candles are checked to see if
+	// they should be closed and closed candles are stored
+	candleBuilderCheckInterval = time.Second
+	// candleBuilderBackfillTimeout limits how long a REST backfill can take
+	candleBuilderBackfillTimeout = time.Minute
+)
+
+var (
+	errCandleIntervalNotBuilt = errors.New("candle interval is not built by the candle builder")
+	errCandleSeriesNotFound   = errors.New("candle series not found")
+	errInvalidTrade           = errors.New("invalid trade")
+)
+
+// CandleBuilder builds candles for configured intervals from websocket trade
+// streams. In progress and closed candles are published via dispatch, closed
+// candles can be stored in the database and candles missed while a websocket
+// was disconnected are backfilled from the exchange's REST API
+type CandleBuilder struct {
+	started                   int32
+	verbose                   bool
+	saveToDatabase            bool
+	intervals                 []kline.Interval
+	closeDelay                time.Duration
+	exchangeManager           iExchangeManager
+	databaseConnectionManager iDatabaseConnectionManager
+	mux                       *dispatch.Mux
+	series                    map[candleSeriesKey]*candleSeries
+	pending                   map[candleSeriesKey]*kline.Item
+	shutdown                  chan struct{}
+	ctx                       context.Context
+	cancel                    context.CancelFunc
+	wg                        sync.WaitGroup
+	m                         sync.Mutex
+	candleSaver               func(*kline.Item, bool) (uint64, error)
+}
+
+// candleSeriesKey identifies candles of a single interval for a pair
+type candleSeriesKey struct {
+	Exchange string
+	Base     *currency.Item
+	Quote    *currency.Item
+	Asset    asset.Item
+	Interval kline.Interval
+}
+
+// candleSeries holds the candle being built for a single interval of a pair
+type candleSeries struct {
+	id          uuid.UUID
+	exchange    string
+	pair        currency.Pair
+	current     kline.Candle
+	building    bool
+	lastClosed  time.Time
+	backfilling bool
+	queued      []trade.Data
+}
+
+// CandleUpdate is published whenever a candle built from trades changes or
+// is closed
+type CandleUpdate struct {
+	Exchange string
+	Pair     currency.Pair
+	Asset    asset.Item
+	Interval kline.Interval
+	Candle   kline.Candle
+	Closed   bool
+	id       uuid.UUID
+}
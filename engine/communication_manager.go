@@ -2,6 +2,7 @@ package engine
 
 import (
 	"fmt"
+	"sync"
 	"sync/atomic"
 
 	"github.com/thrasher-corp/gocryptotrader/communications"
@@ -18,6 +19,7 @@ type CommunicationManager struct {
 	shutdown chan struct{}
 	relayMsg chan base.Event
 	comms    *communications.Communications
+	mtx      sync.Mutex
 }
 
 // SetupCommunicationManager creates a communications manager
@@ -64,9 +66,30 @@ func (m *CommunicationManager) GetStatus() (map[string]base.CommsStatus, error)
 	if !m.IsRunning() {
 		return nil, fmt.Errorf("communications manager %w", ErrSubSystemNotStarted)
 	}
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
 	return m.comms.GetStatus(), nil
 }
 
+// UpdateConfig replaces the communication relayers with those enabled in the
+// supplied config. Existing relayer connections are not closed.
+func (m *CommunicationManager) UpdateConfig(cfg *base.CommunicationsConfig) error {
+	if m == nil {
+		return fmt.Errorf("communications manager server %w", ErrNilSubsystem)
+	}
+	if cfg == nil {
+		return errNilConfig
+	}
+	comms, err := communications.NewComm(cfg)
+	if err != nil {
+		return err
+	}
+	m.mtx.Lock()
+	m.comms = comms
+	m.mtx.Unlock()
+	return nil
+}
+
 // Stop attempts to shutdown the subsystem
 func (m *CommunicationManager) Stop() error {
 	if m == nil {
@@ -106,7 +129,9 @@ func (m *CommunicationManager) run() {
 	for {
 		select {
 		case msg := <-m.relayMsg:
+			m.mtx.Lock()
 			m.comms.PushEvent(msg)
+			m.mtx.Unlock()
 		case <-m.shutdown:
 			return
 		}
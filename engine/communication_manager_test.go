@@ -154,3 +154,40 @@ func TestPushEvent(t *testing.T) {
 	m = nil
 	m.PushEvent(base.Event{})
 }
+
+func TestCommunicationManagerUpdateConfig(t *testing.T) {
+	t.Parallel()
+	var m *CommunicationManager
+	err := m.UpdateConfig(nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	m, err = SetupCommunicationManager(&base.CommunicationsConfig{
+		SlackConfig: base.SlackConfig{
+			Enabled: true,
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	err = m.UpdateConfig(nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("error '%v', expected '%v'", err, errNilConfig)
+	}
+	err = m.UpdateConfig(&base.CommunicationsConfig{})
+	if !errors.Is(err, communications.ErrNoRelayersEnabled) {
+		t.Errorf("error '%v', expected '%v'", err, communications.ErrNoRelayersEnabled)
+	}
+	err = m.UpdateConfig(&base.CommunicationsConfig{
+		SMTPConfig: base.SMTPConfig{
+			Name:    "SMTP",
+			Enabled: true,
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+	if len(m.comms.IComm) != 1 || m.comms.IComm[0].GetName() != "SMTP" {
+		t.Error("expected SMTP relayer to replace slack")
+	}
+}
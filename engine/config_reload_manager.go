@@ -0,0 +1,815 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/vm"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// configReloadMtx ensures only one config reload is processed at a time
+var configReloadMtx sync.Mutex
+
+// setupConfigReloadManager creates a new config reload manager which watches
+// the config file at the supplied path
+func setupConfigReloadManager(cfg *config.HotReload, filePath string, reloader iConfigReloader) (*configReloadManager, error) {
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.CheckInterval <= 0 {
+		return nil, errInvalidReloadInterval
+	}
+	if filePath == "" {
+		return nil, errConfigFilePathEmpty
+	}
+	if reloader == nil {
+		return nil, errNilConfigReloader
+	}
+	return &configReloadManager{
+		interval: cfg.CheckInterval,
+		filePath: filePath,
+		reloader: reloader,
+		shutdown: make(chan struct{}),
+	}, nil
+}
+
+// IsRunning safely checks whether the subsystem is running
+func (m *configReloadManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Start runs the subsystem
+func (m *configReloadManager) Start() error {
+	if m == nil {
+		return fmt.Errorf("config reload manager %w", ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return fmt.Errorf("config reload manager %w", ErrSubSystemAlreadyStarted)
+	}
+	checksum, err := m.getChecksum()
+	if err != nil {
+		atomic.StoreInt32(&m.started, 0)
+		return err
+	}
+	m.checksum = checksum
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.ConfigMgr, "Config reload manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// Stop attempts to shutdown the subsystem
+func (m *configReloadManager) Stop() error {
+	if m == nil {
+		return fmt.Errorf("config reload manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("config reload manager %w", ErrSubSystemNotStarted)
+	}
+	defer func() {
+		atomic.CompareAndSwapInt32(&m.started, 1, 0)
+	}()
+	log.Debugf(log.ConfigMgr, "Config reload manager %s", MsgSubSystemShuttingDown)
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.ConfigMgr, "Config reload manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run checks the config file for changes on each interval
+func (m *configReloadManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			if _, err := m.checkForChanges(); err != nil {
+				log.Errorf(log.ConfigMgr, "Config reload manager: %v", err)
+			}
+		}
+	}
+}
+
+// checkForChanges reloads the config when the contents of the config file
+// have changed since the last check
+func (m *configReloadManager) checkForChanges() (*ConfigReloadResult, error) {
+	checksum, err := m.getChecksum()
+	if err != nil {
+		return nil, err
+	}
+	if checksum == m.checksum {
+		return nil, nil
+	}
+	// The checksum is updated regardless of the outcome, so that a config
+	// which cannot be applied is only reported once per change
+	m.checksum = checksum
+	result, err := m.reloader.ReloadConfig(false)
+	if err != nil {
+		return result, err
+	}
+	for i := range result.Changes {
+		switch {
+		case result.Changes[i].Applied:
+			log.Infof(log.ConfigMgr, "Config reload applied %s %s: %s", result.Changes[i].Section, result.Changes[i].Item, result.Changes[i].Description)
+		case result.Changes[i].RestartRequired:
+			log.Warnf(log.ConfigMgr, "Config reload %s %s requires a restart: %s", result.Changes[i].Section, result.Changes[i].Item, result.Changes[i].Description)
+		}
+	}
+	return result, nil
+}
+
+// getChecksum returns the checksum of the config file contents
+func (m *configReloadManager) getChecksum() ([sha256.Size]byte, error) {
+	data, err := os.ReadFile(m.filePath)
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+	return sha256.Sum256(data), nil
+}
+
+// ReloadConfig reads the config file and compares it with the running config.
+// Supported changes are applied through their relevant subsystems unless dry
+// run is set. If any change fails to apply, all previously applied changes are
+// rolled back. Changes which cannot be applied while running are reported as
+// requiring a restart and are not applied.
+func (bot *Engine) ReloadConfig(dryRun bool) (*ConfigReloadResult, error) {
+	if bot == nil {
+		return nil, errNilBot
+	}
+	if bot.Config == nil {
+		return nil, errNilConfig
+	}
+	configReloadMtx.Lock()
+	defer configReloadMtx.Unlock()
+
+	newCfg, err := bot.Config.ReadConfigForReload(bot.Settings.ConfigFile)
+	if err != nil {
+		return nil, err
+	}
+	changes, err := bot.getConfigChanges(newCfg)
+	if err != nil {
+		return nil, err
+	}
+	result := &ConfigReloadResult{Changes: changes}
+	for i := range changes {
+		if changes[i].RestartRequired {
+			result.RestartRequired = true
+		}
+	}
+	if dryRun {
+		return result, nil
+	}
+	err = applyConfigChanges(result.Changes)
+	if err != nil {
+		return result, err
+	}
+	for i := range result.Changes {
+		if result.Changes[i].Applied {
+			result.Applied = true
+			break
+		}
+	}
+	return result, nil
+}
+
+// applyConfigChanges applies each supported change in order, rolling back any
+// applied changes in reverse order if one fails
+func applyConfigChanges(changes []ConfigChange) error {
+	for i := range changes {
+		if changes[i].RestartRequired || changes[i].apply == nil {
+			continue
+		}
+		err := changes[i].apply()
+		if err == nil {
+			changes[i].Applied = true
+			continue
+		}
+		for j := i - 1; j >= 0; j-- {
+			if !changes[j].Applied {
+				continue
+			}
+			if changes[j].rollback != nil {
+				if rbErr := changes[j].rollback(); rbErr != nil {
+					log.Errorf(log.ConfigMgr, "Config reload failed to roll back %s %s: %v", changes[j].Section, changes[j].Item, rbErr)
+				}
+			}
+			changes[j].Applied = false
+		}
+		return fmt.Errorf("%w: %s %s: %w", errConfigChangeApplyFault, changes[i].Section, changes[i].Item, err)
+	}
+	return nil
+}
+
+// getConfigChanges compares the running config with the supplied config and
+// returns the differences
+func (bot *Engine) getConfigChanges(newCfg *config.Config) ([]ConfigChange, error) {
+	if newCfg == nil {
+		return nil, errNilConfig
+	}
+	changes, err := bot.getExchangeConfigChanges(newCfg)
+	if err != nil {
+		return nil, err
+	}
+	changes = append(changes, bot.getSubsystemConfigChanges(newCfg)...)
+	changes = append(changes, bot.getSyncManagerConfigChanges(newCfg)...)
+	changes = append(changes, bot.getCommunicationsConfigChanges(newCfg)...)
+	changes = append(changes, bot.getLoggingConfigChanges(newCfg)...)
+	changes = append(changes, getRestartRequiredConfigChanges(bot.Config, newCfg)...)
+	return changes, nil
+}
+
+// getExchangeConfigChanges returns the changes for each exchange. Exchanges
+// cannot be added or removed while running.
+func (bot *Engine) getExchangeConfigChanges(newCfg *config.Config) ([]ConfigChange, error) {
+	var changes []ConfigChange
+	for i := range newCfg.Exchanges {
+		newExch := newCfg.Exchanges[i]
+		exchCfg, err := bot.Config.GetExchangeConfig(newExch.Name)
+		if err != nil {
+			if !errors.Is(err, config.ErrExchangeNotFound) {
+				return nil, err
+			}
+			changes = append(changes, ConfigChange{
+				Section:         configSectionExchanges,
+				Item:            newExch.Name,
+				Description:     "exchange added",
+				RestartRequired: true,
+			})
+			continue
+		}
+		if bot.Settings.EnableAllExchanges {
+			newExch.Enabled = true
+		}
+		if _, err = bot.GetExchangeByName(exchCfg.Name); err == nil && newExch.Enabled {
+			// Loaded exchanges have the command line settings applied to
+			// their config, apply them to the incoming config to match
+			bot.applyExchangeSettingOverrides(&newExch)
+		}
+		change, err := bot.getExchangeConfigChange(exchCfg, &newExch)
+		if err != nil {
+			return nil, err
+		}
+		if change != nil {
+			changes = append(changes, *change)
+		}
+	}
+	for i := range bot.Config.Exchanges {
+		_, err := newCfg.GetExchangeConfig(bot.Config.Exchanges[i].Name)
+		if errors.Is(err, config.ErrExchangeNotFound) {
+			changes = append(changes, ConfigChange{
+				Section:         configSectionExchanges,
+				Item:            bot.Config.Exchanges[i].Name,
+				Description:     "exchange removed",
+				RestartRequired: true,
+			})
+		}
+	}
+	return changes, nil
+}
+
+// getExchangeConfigChange returns the change required to bring a running
+// exchange in line with its new config, or nil if there is no difference.
+// Settings changes reload the exchange, enabled pair and asset changes are
+// applied to the loaded exchange directly.
+func (bot *Engine) getExchangeConfigChange(exchCfg, newExch *config.Exchange) (*ConfigChange, error) {
+	if configEqual(exchCfg, newExch) {
+		return nil, nil
+	}
+	name := exchCfg.Name
+	previous := *exchCfg
+	replacement := *newExch
+	change := &ConfigChange{
+		Section:  configSectionExchanges,
+		Item:     name,
+		apply:    func() error { return bot.replaceExchangeConfig(exchCfg, &replacement) },
+		rollback: func() error { return bot.replaceExchangeConfig(exchCfg, &previous) },
+	}
+	switch {
+	case exchCfg.Enabled != newExch.Enabled:
+		if newExch.Enabled {
+			change.Description = "exchange enabled"
+		} else {
+			change.Description = "exchange disabled"
+		}
+		return change, nil
+	case !newExch.Enabled:
+		change.Description = "settings updated for disabled exchange"
+		return change, nil
+	}
+
+	currentSettings, newSettings := *exchCfg, *newExch
+	currentSettings.CurrencyPairs, newSettings.CurrencyPairs = nil, nil
+	if !configEqual(&currentSettings, &newSettings) {
+		change.Description = "settings updated, exchange reloaded"
+		return change, nil
+	}
+
+	updates, previousState, descriptions, err := getPairStoreUpdates(exchCfg.CurrencyPairs, newExch.CurrencyPairs)
+	if err != nil {
+		return nil, err
+	}
+	if len(updates) == 0 {
+		// Only available pairs differ, which are managed by the exchange
+		return nil, nil
+	}
+	change.Description = strings.Join(descriptions, ", ")
+	change.apply = func() error { return bot.setExchangePairStores(name, exchCfg, updates) }
+	change.rollback = func() error { return bot.setExchangePairStores(name, exchCfg, previousState) }
+	return change, nil
+}
+
+// pairStoreUpdate holds the enabled state and enabled pairs for an asset
+type pairStoreUpdate struct {
+	asset        asset.Item
+	assetEnabled bool
+	enabled      currency.Pairs
+}
+
+// getPairStoreUpdates compares the enabled assets and pairs of two pair
+// managers, returning the updates to apply, the current state to roll back to
+// and a description of each change
+func getPairStoreUpdates(current, incoming *currency.PairsManager) (updates, previous []pairStoreUpdate, descriptions []string, err error) {
+	if current == nil || incoming == nil {
+		return nil, nil, nil, nil
+	}
+	assets := incoming.GetAssetTypes(false)
+	for i := range assets {
+		var currentPairs, incomingPairs currency.Pairs
+		currentPairs, err = current.GetPairs(assets[i], true)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		incomingPairs, err = incoming.GetPairs(assets[i], true)
+		if err != nil {
+			return nil, nil, nil, err
+		}
+		currentEnabled := current.IsAssetEnabled(assets[i]) == nil
+		incomingEnabled := incoming.IsAssetEnabled(assets[i]) == nil
+
+		var changed []string
+		if currentEnabled != incomingEnabled {
+			if incomingEnabled {
+				changed = append(changed, assets[i].String()+" asset enabled")
+			} else {
+				changed = append(changed, assets[i].String()+" asset disabled")
+			}
+		}
+		if !pairsMatch(currentPairs, incomingPairs) {
+			changed = append(changed, assets[i].String()+" enabled pairs updated")
+		}
+		if len(changed) == 0 {
+			continue
+		}
+		descriptions = append(descriptions, changed...)
+		updates = append(updates, pairStoreUpdate{asset: assets[i], assetEnabled: incomingEnabled, enabled: incomingPairs})
+		previous = append(previous, pairStoreUpdate{asset: assets[i], assetEnabled: currentEnabled, enabled: currentPairs})
+	}
+	return updates, previous, descriptions, nil
+}
+
+// pairsMatch returns whether two pair lists contain the same pairs regardless
+// of order
+func pairsMatch(a, b currency.Pairs) bool {
+	return len(a) == len(b) && a.ContainsAll(b, true) == nil
+}
+
+// setExchangePairStores stores the enabled pairs and asset states in both the
+// exchange config and the loaded exchange
+func (bot *Engine) setExchangePairStores(name string, exchCfg *config.Exchange, updates []pairStoreUpdate) error {
+	exch, err := bot.GetExchangeByName(name)
+	if err != nil {
+		return err
+	}
+	b := exch.GetBase()
+	if b == nil {
+		return errExchangeBaseNotFound
+	}
+	for i := range updates {
+		for _, pm := range []*currency.PairsManager{exchCfg.CurrencyPairs, &b.CurrencyPairs} {
+			err = pm.StorePairs(updates[i].asset, updates[i].enabled, true)
+			if err != nil {
+				return err
+			}
+			if (pm.IsAssetEnabled(updates[i].asset) == nil) == updates[i].assetEnabled {
+				continue
+			}
+			err = pm.SetAssetEnabled(updates[i].asset, updates[i].assetEnabled)
+			if err != nil {
+				return err
+			}
+		}
+	}
+	if exch.IsWebsocketEnabled() && b.Websocket.IsConnected() {
+		return exch.FlushWebsocketChannels()
+	}
+	return nil
+}
+
+// replaceExchangeConfig unloads the exchange if loaded, replaces its config
+// and loads it again if enabled
+func (bot *Engine) replaceExchangeConfig(exchCfg, replacement *config.Exchange) error {
+	name := exchCfg.Name
+	if _, err := bot.GetExchangeByName(name); err == nil {
+		err = bot.ExchangeManager.RemoveExchange(name)
+		if err != nil {
+			return err
+		}
+	}
+	*exchCfg = *replacement
+	if !exchCfg.Enabled {
+		return nil
+	}
+	err := bot.LoadExchange(name, nil)
+	if err != nil {
+		if _, getErr := bot.GetExchangeByName(name); getErr == nil {
+			if rmErr := bot.ExchangeManager.RemoveExchange(name); rmErr != nil {
+				log.Errorf(log.ConfigMgr, "Config reload failed to unload %s: %v", name, rmErr)
+			}
+		}
+		return err
+	}
+	return nil
+}
+
+// subsystemToggle defines a subsystem which can be enabled or disabled by its
+// config
+type subsystemToggle struct {
+	name     string
+	item     string
+	current  bool
+	incoming bool
+	// settingsChanged is set when any setting other than enabled differs
+	settingsChanged bool
+	set             func(bool)
+}
+
+// getSubsystemConfigChanges returns subsystems which have been enabled or
+// disabled. Any other subsystem setting changes require a restart.
+func (bot *Engine) getSubsystemConfigChanges(newCfg *config.Config) []ConfigChange {
+	currentOrderManager, newOrderManager := bot.Config.OrderManager, newCfg.OrderManager
+	currentOrderManager.Enabled, newOrderManager.Enabled = nil, nil
+	currentDataHistory, newDataHistory := bot.Config.DataHistoryManager, newCfg.DataHistoryManager
+	currentDataHistory.Enabled, newDataHistory.Enabled = false, false
+	currentCurrencyState, newCurrencyState := bot.Config.CurrencyStateManager, newCfg.CurrencyStateManager
+	currentCurrencyState.Enabled, newCurrencyState.Enabled = nil, nil
+	currentScript, newScript := bot.Config.GCTScript, newCfg.GCTScript
+	currentScript.Enabled, newScript.Enabled = false, false
+
+	toggles := []subsystemToggle{
+		{
+			name:            OrderManagerName,
+			item:            "orderManager",
+			current:         isEnabled(bot.Config.OrderManager.Enabled),
+			incoming:        isEnabled(newCfg.OrderManager.Enabled),
+			settingsChanged: !configEqual(currentOrderManager, newOrderManager),
+			set:             func(enabled bool) { bot.Config.OrderManager.Enabled = convert.BoolPtr(enabled) },
+		},
+		{
+			name:            dataHistoryManagerName,
+			item:            "dataHistoryManager",
+			current:         bot.Config.DataHistoryManager.Enabled,
+			incoming:        newCfg.DataHistoryManager.Enabled,
+			settingsChanged: !configEqual(currentDataHistory, newDataHistory),
+			set:             func(enabled bool) { bot.Config.DataHistoryManager.Enabled = enabled },
+		},
+		{
+			name:            CurrencyStateManagementName,
+			item:            "currencyStateManager",
+			current:         isEnabled(bot.Config.CurrencyStateManager.Enabled),
+			incoming:        isEnabled(newCfg.CurrencyStateManager.Enabled),
+			settingsChanged: !configEqual(currentCurrencyState, newCurrencyState),
+			set:             func(enabled bool) { bot.Config.CurrencyStateManager.Enabled = convert.BoolPtr(enabled) },
+		},
+		{
+			name:            vm.Name,
+			item:            "gctscript",
+			current:         bot.Config.GCTScript.Enabled,
+			incoming:        newCfg.GCTScript.Enabled,
+			settingsChanged: !configEqual(currentScript, newScript),
+			set:             func(enabled bool) { bot.Config.GCTScript.Enabled = enabled },
+		},
+		{
+			name:     SyncManagerName,
+			item:     "syncManager",
+			current:  bot.Config.SyncManagerConfig.Enabled,
+			incoming: newCfg.SyncManagerConfig.Enabled,
+			set:      func(enabled bool) { bot.Config.SyncManagerConfig.Enabled = enabled },
+		},
+	}
+
+	var changes []ConfigChange
+	for i := range toggles {
+		toggle := toggles[i]
+		if toggle.settingsChanged {
+			changes = append(changes, ConfigChange{
+				Section:         configSectionSubsystems,
+				Item:            toggle.item,
+				Description:     "settings updated",
+				RestartRequired: true,
+			})
+		}
+		if toggle.current == toggle.incoming {
+			continue
+		}
+		description := "subsystem disabled"
+		if toggle.incoming {
+			description = "subsystem enabled"
+		}
+		changes = append(changes, ConfigChange{
+			Section:     configSectionSubsystems,
+			Item:        toggle.item,
+			Description: description,
+			apply: func() error {
+				if err := bot.setSubsystemState(toggle.name, toggle.incoming); err != nil {
+					return err
+				}
+				toggle.set(toggle.incoming)
+				return nil
+			},
+			rollback: func() error {
+				toggle.set(toggle.current)
+				return bot.setSubsystemState(toggle.name, toggle.current)
+			},
+		})
+	}
+	return changes
+}
+
+// setSubsystemState enables or disables a subsystem if it is not already in
+// the requested state
+func (bot *Engine) setSubsystemState(name string, enable bool) error {
+	running := bot.GetSubsystemsStatus()[name]
+	if name == SyncManagerName {
+		running = bot.currencyPairSyncer.IsRunning()
+	}
+	if running == enable {
+		return nil
+	}
+	return bot.SetSubsystem(name, enable)
+}
+
+// getSyncManagerConfigChanges returns changes to the sync manager timeouts,
+// worker count and logging which are applied by restarting the sync manager.
+// Changes to what is synchronised require a restart.
+func (bot *Engine) getSyncManagerConfigChanges(newCfg *config.Config) []ConfigChange {
+	current, incoming := bot.Config.SyncManagerConfig, newCfg.SyncManagerConfig
+	current.Enabled, incoming.Enabled = false, false
+	if configEqual(current, incoming) {
+		return nil
+	}
+	var changes []ConfigChange
+	currentSync, incomingSync := current, incoming
+	clearSyncManagerTunables(&currentSync)
+	clearSyncManagerTunables(&incomingSync)
+	if !configEqual(currentSync, incomingSync) {
+		changes = append(changes, ConfigChange{
+			Section:         configSectionSyncManager,
+			Item:            "synchronisation",
+			Description:     "synchronised items, fiat display currency or pair format updated",
+			RestartRequired: true,
+		})
+	}
+	incomingSync, currentSync = newCfg.SyncManagerConfig, bot.Config.SyncManagerConfig
+	if syncManagerTunablesEqual(&currentSync, &incomingSync) {
+		return changes
+	}
+	return append(changes, ConfigChange{
+		Section:     configSectionSyncManager,
+		Item:        "settings",
+		Description: "timeouts, workers or logging updated",
+		apply: func() error {
+			return bot.setSyncManagerTunables(&incomingSync)
+		},
+		rollback: func() error {
+			return bot.setSyncManagerTunables(&currentSync)
+		},
+	})
+}
+
+// clearSyncManagerTunables clears the sync manager settings which can be
+// changed while running
+func clearSyncManagerTunables(c *config.SyncManagerConfig) {
+	c.TimeoutREST = 0
+	c.TimeoutWebsocket = 0
+	c.NumWorkers = 0
+	c.Verbose = false
+	c.LogSyncUpdateEvents = false
+	c.LogSwitchProtocolEvents = false
+	c.LogInitialSyncEvents = false
+}
+
+// syncManagerTunablesEqual returns whether the sync manager settings which can
+// be changed while running are equal
+func syncManagerTunablesEqual(a, b *config.SyncManagerConfig) bool {
+	return a.TimeoutREST == b.TimeoutREST &&
+		a.TimeoutWebsocket == b.TimeoutWebsocket &&
+		a.NumWorkers == b.NumWorkers &&
+		a.Verbose == b.Verbose &&
+		a.LogSyncUpdateEvents == b.LogSyncUpdateEvents &&
+		a.LogSwitchProtocolEvents == b.LogSwitchProtocolEvents &&
+		a.LogInitialSyncEvents == b.LogInitialSyncEvents
+}
+
+// setSyncManagerTunables stores the sync manager settings which can be
+// changed while running and restarts the sync manager with them
+func (bot *Engine) setSyncManagerTunables(c *config.SyncManagerConfig) error {
+	bot.Config.SyncManagerConfig.TimeoutREST = c.TimeoutREST
+	bot.Config.SyncManagerConfig.TimeoutWebsocket = c.TimeoutWebsocket
+	bot.Config.SyncManagerConfig.NumWorkers = c.NumWorkers
+	bot.Config.SyncManagerConfig.Verbose = c.Verbose
+	bot.Config.SyncManagerConfig.LogSyncUpdateEvents = c.LogSyncUpdateEvents
+	bot.Config.SyncManagerConfig.LogSwitchProtocolEvents = c.LogSwitchProtocolEvents
+	bot.Config.SyncManagerConfig.LogInitialSyncEvents = c.LogInitialSyncEvents
+	if bot.currencyPairSyncer == nil {
+		return nil
+	}
+	wasRunning := bot.currencyPairSyncer.IsRunning()
+	if wasRunning {
+		if err := bot.currencyPairSyncer.Stop(); err != nil {
+			return err
+		}
+	}
+	cfg := bot.getSyncManagerConfig()
+	if err := bot.currencyPairSyncer.updateConfig(&cfg); err != nil {
+		return err
+	}
+	if wasRunning {
+		return bot.currencyPairSyncer.Start()
+	}
+	return nil
+}
+
+// getCommunicationsConfigChanges returns changes to the communication
+// relayers. Relayers are replaced while running, except for Telegram which
+// cannot be shutdown once connected and requires a restart.
+func (bot *Engine) getCommunicationsConfigChanges(newCfg *config.Config) []ConfigChange {
+	current, incoming := bot.Config.Communications, newCfg.Communications
+	if configEqual(current, incoming) {
+		return nil
+	}
+	change := ConfigChange{
+		Section:     configSectionComms,
+		Item:        "relayers",
+		Description: "communication relayers updated",
+	}
+	if current.TelegramConfig.Enabled && bot.CommunicationsManager.IsRunning() {
+		change.Description = "telegram relayer cannot be replaced while running"
+		change.RestartRequired = true
+		return []ConfigChange{change}
+	}
+	change.apply = func() error { return bot.setCommunicationsConfig(&incoming) }
+	change.rollback = func() error { return bot.setCommunicationsConfig(&current) }
+	return []ConfigChange{change}
+}
+
+// setCommunicationsConfig stores the communications config and replaces the
+// relayers of the communications manager if it has been set up. The manager
+// is stopped if no relayers are enabled.
+func (bot *Engine) setCommunicationsConfig(c *base.CommunicationsConfig) error {
+	if bot.CommunicationsManager == nil {
+		bot.Config.Communications = *c
+		return nil
+	}
+	if !c.IsAnyEnabled() {
+		if bot.CommunicationsManager.IsRunning() {
+			if err := bot.CommunicationsManager.Stop(); err != nil {
+				return err
+			}
+		}
+		bot.Config.Communications = *c
+		return nil
+	}
+	if err := bot.CommunicationsManager.UpdateConfig(c); err != nil {
+		return err
+	}
+	bot.Config.Communications = *c
+	if !bot.CommunicationsManager.IsRunning() && bot.Settings.EnableCommsRelayer {
+		return bot.CommunicationsManager.Start()
+	}
+	return nil
+}
+
+// getLoggingConfigChanges returns changes to the logger levels and outputs.
+// Enabling or disabling logging or changing the log file requires a restart.
+func (bot *Engine) getLoggingConfigChanges(newCfg *config.Config) []ConfigChange {
+	current, incoming := bot.Config.Logging, newCfg.Logging
+	if configEqual(current, incoming) {
+		return nil
+	}
+	change := ConfigChange{
+		Section:     configSectionLogging,
+		Item:        "loggers",
+		Description: "log levels and outputs updated",
+	}
+	if isEnabled(current.Enabled) != isEnabled(incoming.Enabled) ||
+		!configEqual(current.LoggerFileConfig, incoming.LoggerFileConfig) {
+		change.Description = "logging enabled state or log file settings updated"
+		change.RestartRequired = true
+		return []ConfigChange{change}
+	}
+	change.apply = func() error { return bot.setLoggingConfig(&incoming) }
+	change.rollback = func() error { return bot.setLoggingConfig(&current) }
+	return []ConfigChange{change}
+}
+
+// setLoggingConfig stores and applies the logging config
+func (bot *Engine) setLoggingConfig(c *log.Config) error {
+	bot.Config.Logging = *c
+	if !isEnabled(c.Enabled) {
+		return nil
+	}
+	err := log.SetGlobalLogConfig(c)
+	if err != nil {
+		return err
+	}
+	err = log.SetupGlobalLogger(bot.Config.Name, c.AdvancedSettings.StructuredLogging)
+	if err != nil {
+		return err
+	}
+	return log.SetupSubLoggers(c.SubLoggers)
+}
+
+// getRestartRequiredConfigChanges returns changes to config sections which are
+// only read when the engine starts
+func getRestartRequiredConfigChanges(current, incoming *config.Config) []ConfigChange {
+	sections := []struct {
+		item              string
+		current, incoming interface{}
+	}{
+		{"name", current.Name, incoming.Name},
+		{"dataDirectory", current.DataDirectory, incoming.DataDirectory},
+		{"encryptConfig", current.EncryptConfig, incoming.EncryptConfig},
+		{"globalHTTPTimeout", current.GlobalHTTPTimeout, incoming.GlobalHTTPTimeout},
+		{"database", current.Database, incoming.Database},
+		{"connectionMonitor", current.ConnectionMonitor, incoming.ConnectionMonitor},
+		{"dataRetentionManager", current.DataRetentionManager, incoming.DataRetentionManager},
+		{"fundingRateScanner", current.FundingRateScanner, incoming.FundingRateScanner},
+		{"basisService", current.BasisService, incoming.BasisService},
+		{"candleBuilder", current.CandleBuilder, incoming.CandleBuilder},
+		{"eventJournal", current.EventJournal, incoming.EventJournal},
+		{"accounting", current.Accounting, incoming.Accounting},
+		{"ledger", current.Ledger, incoming.Ledger},
+		{"rebalancer", current.Rebalancer, incoming.Rebalancer},
+		{"hotReload", current.HotReload, incoming.HotReload},
+		{"secrets", current.Secrets, incoming.Secrets},
+		{"tracing", current.Tracing, incoming.Tracing},
+		{"profiler", current.Profiler, incoming.Profiler},
+		{"ntpclient", current.NTPClient, incoming.NTPClient},
+		{"currencyConfig", current.Currency, incoming.Currency},
+		{"remoteControl", current.RemoteControl, incoming.RemoteControl},
+		{"portfolioAddresses", current.Portfolio, incoming.Portfolio},
+		{"bankAccounts", current.BankAccounts, incoming.BankAccounts},
+	}
+	var changes []ConfigChange
+	for i := range sections {
+		if configEqual(sections[i].current, sections[i].incoming) {
+			continue
+		}
+		changes = append(changes, ConfigChange{
+			Section:         configSectionRestartNeeded,
+			Item:            sections[i].item,
+			Description:     "settings updated",
+			RestartRequired: true,
+		})
+	}
+	return changes
+}
+
+// configEqual compares config values by their JSON representation, which is
+// how they are stored in the config file
+func configEqual(a, b interface{}) bool {
+	aJSON, err := json.Marshal(a)
+	if err != nil {
+		return false
+	}
+	bJSON, err := json.Marshal(b)
+	if err != nil {
+		return false
+	}
+	return string(aJSON) == string(bJSON)
+}
+
+// isEnabled safely dereferences an optional enabled setting
+func isEnabled(b *bool) bool {
+	return b != nil && *b
+}
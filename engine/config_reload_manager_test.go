@@ -0,0 +1,336 @@
+package engine
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+type fakeConfigReloader struct {
+	calls int
+	err   error
+}
+
+func (f *fakeConfigReloader) ReloadConfig(bool) (*ConfigReloadResult, error) {
+	f.calls++
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &ConfigReloadResult{Changes: []ConfigChange{{Section: "test", Item: "test", Applied: true}}, Applied: true}, nil
+}
+
+func TestSetupConfigReloadManager(t *testing.T) {
+	t.Parallel()
+	_, err := setupConfigReloadManager(nil, "", nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = setupConfigReloadManager(&config.HotReload{}, "", nil)
+	if !errors.Is(err, errInvalidReloadInterval) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidReloadInterval)
+	}
+	cfg := &config.HotReload{CheckInterval: time.Second}
+	_, err = setupConfigReloadManager(cfg, "", nil)
+	if !errors.Is(err, errConfigFilePathEmpty) {
+		t.Errorf("received '%v', expected '%v'", err, errConfigFilePathEmpty)
+	}
+	_, err = setupConfigReloadManager(cfg, "config.json", nil)
+	if !errors.Is(err, errNilConfigReloader) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfigReloader)
+	}
+	m, err := setupConfigReloadManager(cfg, "config.json", &fakeConfigReloader{})
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if m == nil {
+		t.Error("expected manager")
+	}
+}
+
+func TestConfigReloadManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *configReloadManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Error("expected false")
+	}
+
+	path := filepath.Join(t.TempDir(), config.File)
+	m, err = setupConfigReloadManager(&config.HotReload{CheckInterval: time.Hour}, path, &fakeConfigReloader{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received '%v', expected '%v'", err, os.ErrNotExist)
+	}
+	if m.IsRunning() {
+		t.Error("expected false")
+	}
+
+	err = os.WriteFile(path, []byte("{}"), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !m.IsRunning() {
+		t.Error("expected true")
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestConfigReloadManagerCheckForChanges(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), config.File)
+	err := os.WriteFile(path, []byte("{}"), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	reloader := &fakeConfigReloader{}
+	m, err := setupConfigReloadManager(&config.HotReload{CheckInterval: time.Hour}, path, reloader)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	m.checksum, err = m.getChecksum()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	result, err := m.checkForChanges()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if result != nil || reloader.calls != 0 {
+		t.Error("expected no reload for an unchanged file")
+	}
+
+	err = os.WriteFile(path, []byte(`{"name":"changed"}`), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	result, err = m.checkForChanges()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if result == nil || !result.Applied || reloader.calls != 1 {
+		t.Error("expected config to be reloaded")
+	}
+
+	_, err = m.checkForChanges()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if reloader.calls != 1 {
+		t.Errorf("received '%v', expected '%v'", reloader.calls, 1)
+	}
+
+	reloader.err = errExpectedTestError
+	err = os.WriteFile(path, []byte(`{"name":"changed again"}`), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = m.checkForChanges()
+	if !errors.Is(err, errExpectedTestError) {
+		t.Errorf("received '%v', expected '%v'", err, errExpectedTestError)
+	}
+}
+
+func TestApplyConfigChanges(t *testing.T) {
+	t.Parallel()
+	var applied, rolledBack []string
+	change := func(item string, applyErr error) ConfigChange {
+		return ConfigChange{
+			Item: item,
+			apply: func() error {
+				if applyErr != nil {
+					return applyErr
+				}
+				applied = append(applied, item)
+				return nil
+			},
+			rollback: func() error {
+				rolledBack = append(rolledBack, item)
+				return nil
+			},
+		}
+	}
+
+	changes := []ConfigChange{change("a", nil), {Item: "restart", RestartRequired: true}, change("b", nil)}
+	err := applyConfigChanges(changes)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !changes[0].Applied || changes[1].Applied || !changes[2].Applied {
+		t.Error("expected supported changes to be applied")
+	}
+
+	applied = nil
+	changes = []ConfigChange{change("a", nil), change("b", nil), change("c", errExpectedTestError), change("d", nil)}
+	err = applyConfigChanges(changes)
+	if !errors.Is(err, errConfigChangeApplyFault) {
+		t.Errorf("received '%v', expected '%v'", err, errConfigChangeApplyFault)
+	}
+	if !errors.Is(err, errExpectedTestError) {
+		t.Errorf("received '%v', expected '%v'", err, errExpectedTestError)
+	}
+	if len(applied) != 2 || len(rolledBack) != 2 || rolledBack[0] != "b" || rolledBack[1] != "a" {
+		t.Errorf("expected changes to be rolled back in reverse order, applied %v rolled back %v", applied, rolledBack)
+	}
+	for i := range changes {
+		if changes[i].Applied {
+			t.Errorf("expected %s to not be applied", changes[i].Item)
+		}
+	}
+}
+
+func TestGetPairStoreUpdates(t *testing.T) {
+	t.Parallel()
+	btcusd := currency.NewPair(currency.BTC, currency.USD)
+	ethusd := currency.NewPair(currency.ETH, currency.USD)
+	newManager := func(assetEnabled bool, enabled currency.Pairs) *currency.PairsManager {
+		return &currency.PairsManager{Pairs: map[asset.Item]*currency.PairStore{
+			asset.Spot: {AssetEnabled: &assetEnabled, Available: currency.Pairs{btcusd, ethusd}, Enabled: enabled},
+		}}
+	}
+
+	updates, previous, descriptions, err := getPairStoreUpdates(newManager(true, currency.Pairs{btcusd}), newManager(true, currency.Pairs{btcusd}))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(updates) != 0 || len(previous) != 0 || len(descriptions) != 0 {
+		t.Error("expected no updates")
+	}
+
+	updates, previous, descriptions, err = getPairStoreUpdates(newManager(true, currency.Pairs{btcusd}), newManager(false, currency.Pairs{ethusd, btcusd}))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(updates) != 1 || len(previous) != 1 || len(descriptions) != 2 {
+		t.Fatalf("received %v updates and %v descriptions, expected 1 and 2", len(updates), len(descriptions))
+	}
+	if updates[0].assetEnabled || len(updates[0].enabled) != 2 {
+		t.Error("expected asset to be disabled with two pairs enabled")
+	}
+	if !previous[0].assetEnabled || len(previous[0].enabled) != 1 {
+		t.Error("expected previous state to hold the current settings")
+	}
+}
+
+func TestGetRestartRequiredConfigChanges(t *testing.T) {
+	t.Parallel()
+	current := &config.Config{Name: "test"}
+	incoming := &config.Config{Name: "test"}
+	if changes := getRestartRequiredConfigChanges(current, incoming); len(changes) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(changes), 0)
+	}
+	incoming.Name = "changed"
+	incoming.Database.Enabled = true
+	changes := getRestartRequiredConfigChanges(current, incoming)
+	if len(changes) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(changes), 2)
+	}
+	for i := range changes {
+		if !changes[i].RestartRequired {
+			t.Errorf("expected %s to require a restart", changes[i].Item)
+		}
+	}
+}
+
+func TestReloadConfig(t *testing.T) {
+	var bot *Engine
+	_, err := bot.ReloadConfig(true)
+	if !errors.Is(err, errNilBot) {
+		t.Errorf("received '%v', expected '%v'", err, errNilBot)
+	}
+	_, err = (&Engine{}).ReloadConfig(true)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+
+	path := filepath.Join(t.TempDir(), config.File)
+	cfg := &config.Config{}
+	err = cfg.LoadConfig(config.TestFile, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = cfg.SaveConfigToFile(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	bot = &Engine{
+		Config:          cfg,
+		ExchangeManager: NewExchangeManager(),
+		Settings:        Settings{ConfigFile: path},
+	}
+	result, err := bot.ReloadConfig(true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(result.Changes) != 0 {
+		t.Fatalf("expected no changes for an unchanged file, received %+v", result.Changes)
+	}
+
+	candidate := &config.Config{}
+	err = candidate.LoadConfig(path, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	candidate.Name = "changed"
+	candidate.Logging.Level = "ERROR"
+	err = candidate.SaveConfigToFile(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	result, err = bot.ReloadConfig(true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(result.Changes) != 2 || !result.RestartRequired || result.Applied {
+		t.Fatalf("expected a logging change and a restart required name change, received %+v", result)
+	}
+	if bot.Config.Logging.Level == "ERROR" {
+		t.Error("dry run should not apply changes")
+	}
+
+	result, err = bot.ReloadConfig(false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !result.Applied {
+		t.Error("expected changes to be applied")
+	}
+	if bot.Config.Logging.Level != "ERROR" {
+		t.Errorf("received '%v', expected '%v'", bot.Config.Logging.Level, "ERROR")
+	}
+	if bot.Config.Name == "changed" {
+		t.Error("restart required changes should not be applied")
+	}
+}
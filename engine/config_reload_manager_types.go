@@ -0,0 +1,67 @@
+package engine
+
+import (
+	"crypto/sha256"
+	"errors"
+	"sync"
+	"time"
+)
+
+// ConfigReloadManagerName is an exported subsystem name
+const ConfigReloadManagerName = "config_reloader"
+
+var (
+	errConfigFilePathEmpty    = errors.New("config file path is empty")
+	errNilConfigReloader      = errors.New("config reloader is nil")
+	errInvalidReloadInterval  = errors.New("config reload check interval must be greater than zero")
+	errConfigChangeApplyFault = errors.New("config change could not be applied, all changes have been rolled back")
+)
+
+// Config sections used to describe config changes
+const (
+	configSectionExchanges     = "exchanges"
+	configSectionSubsystems    = "subsystems"
+	configSectionSyncManager   = "syncManager"
+	configSectionComms         = "communications"
+	configSectionLogging       = "logging"
+	configSectionRestartNeeded = "general"
+)
+
+// configReloadManager polls the config file for changes and applies them to
+// the running engine
+type configReloadManager struct {
+	started  int32
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	interval time.Duration
+	filePath string
+	reloader iConfigReloader
+	checksum [sha256.Size]byte
+}
+
+// iConfigReloader limits exposure of accessible functions to the config
+// reload manager
+type iConfigReloader interface {
+	ReloadConfig(dryRun bool) (*ConfigReloadResult, error)
+}
+
+// ConfigChange describes a single difference between the running config and
+// the config file
+type ConfigChange struct {
+	Section         string
+	Item            string
+	Description     string
+	RestartRequired bool
+	Applied         bool
+
+	apply    func() error
+	rollback func() error
+}
+
+// ConfigReloadResult holds the changes found when reloading the config file
+// and whether they were applied
+type ConfigReloadResult struct {
+	Changes         []ConfigChange
+	Applied         bool
+	RestartRequired bool
+}
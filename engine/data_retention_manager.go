@@ -0,0 +1,479 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupDataRetentionManager creates a data retention manager subsystem
+func SetupDataRetentionManager(dcm iDatabaseConnectionManager, cfg *config.DataRetentionManager) (*DataRetentionManager, error) {
+	if dcm == nil {
+		return nil, errNilDatabaseConnectionManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	for i := range cfg.Policies {
+		if err := validateRetentionPolicy(&cfg.Policies[i]); err != nil {
+			return nil, fmt.Errorf("policy %d: %w", i+1, err)
+		}
+	}
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	return &DataRetentionManager{
+		databaseConnectionInstance: dcm.GetInstance(),
+		shutdown:                   make(chan struct{}),
+		interval:                   interval,
+		compact:                    cfg.Compact,
+		verbose:                    cfg.Verbose,
+		policies:                   append([]config.DataRetentionPolicy(nil), cfg.Policies...),
+		candleSeriesLister:         candle.ListSeries,
+		tradeSeriesLister:          tradesql.ListSeries,
+		candleLoader:               kline.LoadFromDatabase,
+		tradeLoader:                trade.GetTradesInRange,
+		candleSaver:                kline.StoreInDatabase,
+		candleDeleter:              candle.DeleteInRange,
+		tradeDeleter:               tradesql.DeleteInRange,
+		compactor:                  repository.Compact,
+	}, nil
+}
+
+// validateRetentionPolicy ensures a policy can be applied without removing
+// data it cannot roll up
+func validateRetentionPolicy(p *config.DataRetentionPolicy) error {
+	p.DataType = strings.ToLower(p.DataType)
+	if p.DataType != DataRetentionCandles && p.DataType != DataRetentionTrades {
+		return fmt.Errorf("%w: unsupported data type %q, expected %s or %s", errInvalidRetentionPolicy, p.DataType, DataRetentionCandles, DataRetentionTrades)
+	}
+	if p.MaxAge <= 0 {
+		return fmt.Errorf("%w: max age must be greater than zero", errInvalidRetentionPolicy)
+	}
+	if p.Interval < 0 || p.RollupInterval < 0 {
+		return fmt.Errorf("%w: intervals cannot be negative", errInvalidRetentionPolicy)
+	}
+	if p.Interval%time.Second != 0 || p.RollupInterval%time.Second != 0 {
+		return fmt.Errorf("%w: intervals must be in whole seconds", errInvalidRetentionPolicy)
+	}
+	if p.Asset != "" {
+		if _, err := asset.New(p.Asset); err != nil {
+			return fmt.Errorf("%w: %w", errInvalidRetentionPolicy, err)
+		}
+	}
+	if p.DataType == DataRetentionTrades && p.Interval != 0 {
+		return fmt.Errorf("%w: interval cannot be set for trades", errInvalidRetentionPolicy)
+	}
+	if p.DataType == DataRetentionTrades && p.RollupInterval == 0 && !p.DiscardTrades {
+		return fmt.Errorf("%w: trades require a rollup interval unless discard trades is set", errInvalidRetentionPolicy)
+	}
+	if p.DiscardTrades && (p.DataType != DataRetentionTrades || p.RollupInterval != 0) {
+		return fmt.Errorf("%w: discard trades only applies to trades without a rollup interval", errInvalidRetentionPolicy)
+	}
+	if p.DataType == DataRetentionCandles && p.Interval > 0 && p.RollupInterval > 0 &&
+		(p.RollupInterval <= p.Interval || p.RollupInterval%p.Interval != 0) {
+		return fmt.Errorf("%w: rollup interval %s must be a multiple of %s", errInvalidRetentionPolicy, kline.Interval(p.RollupInterval), kline.Interval(p.Interval))
+	}
+	return nil
+}
+
+// Start runs the subsystem
+func (m *DataRetentionManager) Start() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if m.databaseConnectionInstance == nil {
+		return errNilDatabaseConnectionManager
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	m.shutdown = make(chan struct{})
+	m.wg.Add(1)
+	go m.run()
+	log.Debugf(log.DatabaseMgr, "Data retention manager %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (m *DataRetentionManager) IsRunning() bool {
+	if m == nil {
+		return false
+	}
+	return atomic.LoadInt32(&m.started) == 1
+}
+
+// Stop stops the subsystem
+func (m *DataRetentionManager) Stop() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&m.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(m.shutdown)
+	m.wg.Wait()
+	log.Debugf(log.DatabaseMgr, "Data retention manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// Policies returns the retention policies being applied
+func (m *DataRetentionManager) Policies() ([]config.DataRetentionPolicy, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	return append([]config.DataRetentionPolicy(nil), m.policies...), nil
+}
+
+// LastReport returns the report of the most recent retention run
+func (m *DataRetentionManager) LastReport() (*DataRetentionReport, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	if m.lastReport == nil {
+		return nil, errNoRetentionReport
+	}
+	report := *m.lastReport
+	report.Series = append([]DataRetentionSeriesResult(nil), m.lastReport.Series...)
+	return &report, nil
+}
+
+func (m *DataRetentionManager) run() {
+	defer m.wg.Done()
+	t := time.NewTicker(m.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-m.shutdown:
+			return
+		case <-t.C:
+			if !m.databaseConnectionInstance.IsConnected() {
+				continue
+			}
+			if _, err := m.Run(false); err != nil && !errors.Is(err, errAlreadyRunning) {
+				log.Errorf(log.DatabaseMgr, "Data retention manager: %v", err)
+			}
+		}
+	}
+}
+
+// Run applies all retention policies to the stored data. When dryRun is
+// set, the data which would be removed and created is reported but the
+// database is left untouched
+func (m *DataRetentionManager) Run(dryRun bool) (*DataRetentionReport, error) {
+	if m == nil {
+		return nil, ErrNilSubsystem
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return nil, ErrSubSystemNotStarted
+	}
+	if !m.databaseConnectionInstance.IsConnected() {
+		return nil, database.ErrDatabaseNotConnected
+	}
+	if !atomic.CompareAndSwapInt32(&m.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot apply data retention, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&m.processing, 0)
+
+	report := &DataRetentionReport{
+		DryRun:  dryRun,
+		Started: time.Now().UTC(),
+	}
+	candleSeries, err := m.candleSeriesLister()
+	if err != nil {
+		return nil, err
+	}
+	for i := range candleSeries {
+		if atomic.LoadInt32(&m.started) == 0 {
+			return nil, ErrSubSystemNotStarted
+		}
+		m.addResult(report, m.applyCandlePolicy(&candleSeries[i], report.Started, dryRun))
+	}
+	tradeSeries, err := m.tradeSeriesLister()
+	if err != nil {
+		return nil, err
+	}
+	for i := range tradeSeries {
+		if atomic.LoadInt32(&m.started) == 0 {
+			return nil, ErrSubSystemNotStarted
+		}
+		m.addResult(report, m.applyTradePolicy(&tradeSeries[i], report.Started, dryRun))
+	}
+	if !dryRun && m.compact && report.Removed > 0 {
+		if err = m.compactor(); err != nil {
+			log.Errorf(log.DatabaseMgr, "Data retention manager could not compact database: %v", err)
+		} else {
+			report.Compacted = true
+		}
+	}
+	report.Completed = time.Now().UTC()
+	if m.verbose || report.Removed > 0 {
+		log.Infof(log.DatabaseMgr, "Data retention manager removed %d and created %d records across %d series, dry run: %v",
+			report.Removed, report.Created, len(report.Series), dryRun)
+	}
+	m.m.Lock()
+	m.lastReport = report
+	m.m.Unlock()
+	return report, nil
+}
+
+func (m *DataRetentionManager) addResult(report *DataRetentionReport, result *DataRetentionSeriesResult) {
+	if result == nil || (result.Removed == 0 && result.Created == 0 && result.Error == "") {
+		return
+	}
+	if result.Error != "" {
+		log.Errorf(log.DatabaseMgr, "Data retention manager %s %s %s %s: %s",
+			result.Exchange, result.Asset, result.Pair, result.DataType, result.Error)
+	} else if m.verbose {
+		log.Debugf(log.DatabaseMgr, "Data retention manager %s %s %s %s %s removed %d created %d before %s",
+			result.Exchange, result.Asset, result.Pair, result.DataType, result.Interval, result.Removed, result.Created, result.Cutoff)
+	}
+	report.Removed += result.Removed
+	report.Created += result.Created
+	report.Series = append(report.Series, *result)
+}
+
+// matchPolicy returns the first policy which applies to a series. Candle
+// policies do not apply to series at or above their rollup interval, as those
+// series are where the rolled up data is stored
+func (m *DataRetentionManager) matchPolicy(dataType, exchangeName string, a asset.Item, interval kline.Interval) *config.DataRetentionPolicy {
+	for i := range m.policies {
+		p := &m.policies[i]
+		if p.DataType != dataType ||
+			(p.Exchange != "" && !strings.EqualFold(p.Exchange, exchangeName)) ||
+			(p.Asset != "" && !strings.EqualFold(p.Asset, a.String())) {
+			continue
+		}
+		if dataType == DataRetentionCandles {
+			if p.Interval != 0 && p.Interval != interval.Duration() {
+				continue
+			}
+			if p.RollupInterval > 0 && (interval.Duration() >= p.RollupInterval || p.RollupInterval%interval.Duration() != 0) {
+				continue
+			}
+		}
+		return p
+	}
+	return nil
+}
+
+// retentionWindows returns the cutoff for a policy, aligned to the interval
+// new candles are created at, along with the window size used to walk
+// through the data up to it
+func retentionWindows(p *config.DataRetentionPolicy, interval kline.Interval, now time.Time) (cutoff time.Time, window time.Duration) {
+	align := interval.Duration()
+	if p.RollupInterval > 0 {
+		align = p.RollupInterval
+	}
+	if align <= 0 {
+		align = time.Second
+	}
+	window = align
+	if window < dataRetentionWindow {
+		window = ((dataRetentionWindow + align - 1) / align) * align
+	}
+	return now.Add(-p.MaxAge).Truncate(align), window
+}
+
+func (m *DataRetentionManager) applyCandlePolicy(s *candle.SeriesSummary, now time.Time, dryRun bool) *DataRetentionSeriesResult {
+	a, err := asset.New(s.Asset)
+	if err != nil {
+		return &DataRetentionSeriesResult{DataType: DataRetentionCandles, Exchange: s.Exchange, Error: err.Error()}
+	}
+	interval := kline.Interval(time.Duration(s.Interval) * time.Second)
+	p := m.matchPolicy(DataRetentionCandles, s.Exchange, a, interval)
+	if p == nil {
+		return nil
+	}
+	result := &DataRetentionSeriesResult{
+		DataType:       DataRetentionCandles,
+		Exchange:       s.Exchange,
+		Asset:          a,
+		Interval:       interval,
+		RollupInterval: kline.Interval(p.RollupInterval),
+	}
+	result.Pair, err = currency.NewPairFromStrings(s.Base, s.Quote)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	var window time.Duration
+	result.Cutoff, window = retentionWindows(p, interval, now)
+	start := s.Oldest.Truncate(window)
+	for start.Before(result.Cutoff) {
+		end := start.Add(window)
+		if end.After(result.Cutoff) {
+			end = result.Cutoff
+		}
+		var candles *kline.Item
+		candles, err = m.candleLoader(s.Exchange, result.Pair, a, interval, start, end.Add(-time.Nanosecond))
+		if err != nil && !errors.Is(err, candle.ErrNoCandleDataFound) {
+			result.Error = err.Error()
+			return result
+		}
+		if err == nil && len(candles.Candles) > 0 {
+			if result.RollupInterval > 0 {
+				var created int64
+				created, err = m.downsampleCandles(candles, result.RollupInterval, start, end, dryRun)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+				result.Created += created
+			}
+			removed := int64(len(candles.Candles))
+			if !dryRun {
+				removed, err = m.candleDeleter(s.Exchange, s.Base, s.Quote, s.Interval, s.Asset, start, end)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+			}
+			result.Removed += removed
+		}
+		start = end
+	}
+	return result
+}
+
+// downsampleCandles converts candles between start and end into the rollup
+// interval using the same conversion as the data history manager. Candles
+// which already exist at the rollup interval are left untouched
+func (m *DataRetentionManager) downsampleCandles(candles *kline.Item, rollup kline.Interval, start, end time.Time, dryRun bool) (int64, error) {
+	interval := candles.Interval.Duration()
+	padded := *candles
+	padded.Candles = make([]kline.Candle, 0, end.Sub(start)/interval)
+	for t := start; t.Before(end); t = t.Add(interval) {
+		padded.Candles = append(padded.Candles, kline.Candle{Time: t})
+	}
+	for i := range candles.Candles {
+		offset := candles.Candles[i].Time.Sub(start)
+		if offset < 0 || offset%interval != 0 || int(offset/interval) >= len(padded.Candles) {
+			continue
+		}
+		padded.Candles[offset/interval] = candles.Candles[i]
+	}
+	converted, err := padded.ConvertToNewInterval(rollup)
+	if err != nil {
+		return 0, err
+	}
+	return m.saveRollup(converted, start, end, dryRun)
+}
+
+// saveRollup stores newly created candles, skipping any which are empty or
+// already stored
+func (m *DataRetentionManager) saveRollup(k *kline.Item, start, end time.Time, dryRun bool) (int64, error) {
+	stored, err := m.candleLoader(k.Exchange, k.Pair, k.Asset, k.Interval, start, end.Add(-time.Nanosecond))
+	if err != nil && !errors.Is(err, candle.ErrNoCandleDataFound) {
+		return 0, err
+	}
+	skip := make(map[int64]struct{})
+	if err == nil {
+		for i := range stored.Candles {
+			skip[stored.Candles[i].Time.Truncate(k.Interval.Duration()).Unix()] = struct{}{}
+		}
+	}
+	candles := make([]kline.Candle, 0, len(k.Candles))
+	for i := range k.Candles {
+		if k.Candles[i].Time.IsZero() {
+			continue
+		}
+		k.Candles[i].Time = k.Candles[i].Time.Truncate(k.Interval.Duration())
+		if _, ok := skip[k.Candles[i].Time.Unix()]; ok {
+			continue
+		}
+		candles = append(candles, k.Candles[i])
+	}
+	if len(candles) == 0 {
+		return 0, nil
+	}
+	k.Candles = candles
+	k.SortCandlesByTimestamp(false)
+	if !dryRun {
+		if _, err = m.candleSaver(k, false); err != nil {
+			return 0, err
+		}
+	}
+	return int64(len(candles)), nil
+}
+
+func (m *DataRetentionManager) applyTradePolicy(s *tradesql.SeriesSummary, now time.Time, dryRun bool) *DataRetentionSeriesResult {
+	a, err := asset.New(s.AssetType)
+	if err != nil {
+		return &DataRetentionSeriesResult{DataType: DataRetentionTrades, Exchange: s.Exchange, Error: err.Error()}
+	}
+	p := m.matchPolicy(DataRetentionTrades, s.Exchange, a, 0)
+	if p == nil {
+		return nil
+	}
+	result := &DataRetentionSeriesResult{
+		DataType:       DataRetentionTrades,
+		Exchange:       s.Exchange,
+		Asset:          a,
+		RollupInterval: kline.Interval(p.RollupInterval),
+	}
+	result.Pair, err = currency.NewPairFromStrings(s.Base, s.Quote)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	var window time.Duration
+	result.Cutoff, window = retentionWindows(p, 0, now)
+	start := s.Oldest.Truncate(window)
+	for start.Before(result.Cutoff) {
+		end := start.Add(window)
+		if end.After(result.Cutoff) {
+			end = result.Cutoff
+		}
+		var trades []trade.Data
+		trades, err = m.tradeLoader(s.Exchange, s.AssetType, s.Base, s.Quote, start, end.Add(-time.Nanosecond))
+		if err != nil {
+			result.Error = err.Error()
+			return result
+		}
+		if len(trades) > 0 {
+			if result.RollupInterval > 0 {
+				var candles *kline.Item
+				candles, err = trade.ConvertTradesToCandles(result.RollupInterval, trades...)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+				var created int64
+				created, err = m.saveRollup(candles, start, end, dryRun)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+				result.Created += created
+			}
+			removed := int64(len(trades))
+			if !dryRun {
+				removed, err = m.tradeDeleter(s.Exchange, s.AssetType, s.Base, s.Quote, start, end)
+				if err != nil {
+					result.Error = err.Error()
+					return result
+				}
+			}
+			result.Removed += removed
+		}
+		start = end
+	}
+	return result
+}
@@ -0,0 +1,290 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+type connectedDatabase struct {
+	dataBaseConnection
+}
+
+func (d *connectedDatabase) IsConnected() bool {
+	return true
+}
+
+func TestSetupDataRetentionManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupDataRetentionManager(nil, nil)
+	if !errors.Is(err, errNilDatabaseConnectionManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilDatabaseConnectionManager)
+	}
+	_, err = SetupDataRetentionManager(&DatabaseConnectionManager{}, nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = SetupDataRetentionManager(&DatabaseConnectionManager{}, &config.DataRetentionManager{
+		Policies: []config.DataRetentionPolicy{{DataType: "orders", MaxAge: time.Hour}},
+	})
+	if !errors.Is(err, errInvalidRetentionPolicy) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidRetentionPolicy)
+	}
+	m, err := SetupDataRetentionManager(&DatabaseConnectionManager{}, &config.DataRetentionManager{
+		Policies: []config.DataRetentionPolicy{{DataType: "Trades", MaxAge: time.Hour, DiscardTrades: true}},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if m.interval != time.Hour {
+		t.Errorf("received '%v', expected '%v'", m.interval, time.Hour)
+	}
+	policies, err := m.Policies()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(policies) != 1 || policies[0].DataType != DataRetentionTrades {
+		t.Errorf("received '%v', expected a single trade policy", policies)
+	}
+}
+
+func TestValidateRetentionPolicy(t *testing.T) {
+	t.Parallel()
+	for name, tc := range map[string]struct {
+		policy config.DataRetentionPolicy
+		err    error
+	}{
+		"valid candles":       {config.DataRetentionPolicy{DataType: "candles", Interval: time.Minute, MaxAge: time.Hour, RollupInterval: time.Hour}, nil},
+		"valid trades":        {config.DataRetentionPolicy{DataType: "trades", Asset: "spot", MaxAge: time.Hour, RollupInterval: time.Minute}, nil},
+		"bad data type":       {config.DataRetentionPolicy{DataType: "orders", MaxAge: time.Hour}, errInvalidRetentionPolicy},
+		"no max age":          {config.DataRetentionPolicy{DataType: "trades"}, errInvalidRetentionPolicy},
+		"negative interval":   {config.DataRetentionPolicy{DataType: "candles", MaxAge: time.Hour, RollupInterval: -time.Hour}, errInvalidRetentionPolicy},
+		"partial seconds":     {config.DataRetentionPolicy{DataType: "trades", MaxAge: time.Hour, RollupInterval: time.Millisecond}, errInvalidRetentionPolicy},
+		"bad asset":           {config.DataRetentionPolicy{DataType: "trades", Asset: "bananas", MaxAge: time.Hour}, errInvalidRetentionPolicy},
+		"trade interval":      {config.DataRetentionPolicy{DataType: "trades", Interval: time.Minute, MaxAge: time.Hour, DiscardTrades: true}, errInvalidRetentionPolicy},
+		"trades no rollup":    {config.DataRetentionPolicy{DataType: "trades", MaxAge: time.Hour}, errInvalidRetentionPolicy},
+		"discard trades":      {config.DataRetentionPolicy{DataType: "trades", MaxAge: time.Hour, DiscardTrades: true}, nil},
+		"discard and rollup":  {config.DataRetentionPolicy{DataType: "trades", MaxAge: time.Hour, RollupInterval: time.Minute, DiscardTrades: true}, errInvalidRetentionPolicy},
+		"discard candles":     {config.DataRetentionPolicy{DataType: "candles", MaxAge: time.Hour, DiscardTrades: true}, errInvalidRetentionPolicy},
+		"rollup not multiple": {config.DataRetentionPolicy{DataType: "candles", Interval: time.Hour, MaxAge: time.Hour, RollupInterval: time.Hour * 3 / 2}, errInvalidRetentionPolicy},
+		"rollup smaller":      {config.DataRetentionPolicy{DataType: "candles", Interval: time.Hour, MaxAge: time.Hour, RollupInterval: time.Minute}, errInvalidRetentionPolicy},
+	} {
+		p := tc.policy
+		if err := validateRetentionPolicy(&p); !errors.Is(err, tc.err) {
+			t.Errorf("%s received '%v', expected '%v'", name, err, tc.err)
+		}
+	}
+}
+
+func TestDataRetentionManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var m *DataRetentionManager
+	err := m.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if m.IsRunning() {
+		t.Error("expected nil manager to not be running")
+	}
+
+	m = &DataRetentionManager{interval: time.Hour}
+	err = m.Start()
+	if !errors.Is(err, errNilDatabaseConnectionManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilDatabaseConnectionManager)
+	}
+	m.databaseConnectionInstance = &dataBaseConnection{}
+	err = m.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !m.IsRunning() {
+		t.Error("expected manager to be running")
+	}
+	_, err = m.Run(true)
+	if !errors.Is(err, database.ErrDatabaseNotConnected) {
+		t.Errorf("received '%v', expected '%v'", err, database.ErrDatabaseNotConnected)
+	}
+	err = m.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = m.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	_, err = m.Run(true)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestDataRetentionMatchPolicy(t *testing.T) {
+	t.Parallel()
+	m := &DataRetentionManager{policies: []config.DataRetentionPolicy{
+		{DataType: DataRetentionCandles, Exchange: "Binance", Interval: time.Minute, MaxAge: time.Hour, RollupInterval: time.Hour},
+		{DataType: DataRetentionCandles, MaxAge: time.Hour * 24, RollupInterval: time.Hour * 24},
+		{DataType: DataRetentionTrades, Asset: "spot", MaxAge: time.Hour},
+	}}
+	if p := m.matchPolicy(DataRetentionCandles, "binance", asset.Spot, kline.OneMin); p != &m.policies[0] {
+		t.Errorf("received '%+v', expected '%+v'", p, m.policies[0])
+	}
+	if p := m.matchPolicy(DataRetentionCandles, "bitstamp", asset.Spot, kline.OneMin); p != &m.policies[1] {
+		t.Errorf("received '%+v', expected '%+v'", p, m.policies[1])
+	}
+	if p := m.matchPolicy(DataRetentionCandles, "binance", asset.Spot, kline.OneDay); p != nil {
+		t.Errorf("received '%+v', expected rollup destination to be kept", p)
+	}
+	if p := m.matchPolicy(DataRetentionTrades, "binance", asset.Spot, 0); p != &m.policies[2] {
+		t.Errorf("received '%+v', expected '%+v'", p, m.policies[2])
+	}
+	if p := m.matchPolicy(DataRetentionTrades, "binance", asset.Futures, 0); p != nil {
+		t.Errorf("received '%+v', expected no policy", p)
+	}
+}
+
+func TestDataRetentionRun(t *testing.T) {
+	t.Parallel()
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	oldest := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	var fineCandles []kline.Candle
+	for i := 0; i < 120; i++ {
+		fineCandles = append(fineCandles, kline.Candle{
+			Time:   oldest.Add(time.Minute * time.Duration(i)),
+			Open:   1,
+			High:   float64(i + 1),
+			Low:    1,
+			Close:  1,
+			Volume: 1,
+		})
+	}
+	tradesOldest := oldest.AddDate(0, 0, 2)
+	trades := []trade.Data{
+		{Exchange: testExchange, CurrencyPair: pair, AssetType: asset.Spot, Price: 10, Amount: 1, Side: order.Buy, Timestamp: tradesOldest.Add(time.Second)},
+		{Exchange: testExchange, CurrencyPair: pair, AssetType: asset.Spot, Price: 12, Amount: 2, Side: order.Sell, Timestamp: tradesOldest.Add(time.Minute)},
+	}
+
+	var saved []*kline.Item
+	var candlesDeleted, tradesDeleted int
+	var compacted bool
+	m := &DataRetentionManager{
+		started:                    1,
+		databaseConnectionInstance: &connectedDatabase{},
+		compact:                    true,
+		verbose:                    true,
+		policies: []config.DataRetentionPolicy{
+			{DataType: DataRetentionCandles, Interval: time.Minute, MaxAge: time.Hour * 24, RollupInterval: time.Hour},
+			{DataType: DataRetentionTrades, MaxAge: time.Hour * 24, RollupInterval: time.Minute},
+		},
+		candleSeriesLister: func() ([]candle.SeriesSummary, error) {
+			return []candle.SeriesSummary{
+				{Exchange: testExchange, Base: "BTC", Quote: "USDT", Interval: 60, Asset: "spot", Oldest: oldest},
+				{Exchange: testExchange, Base: "BTC", Quote: "USDT", Interval: 3600, Asset: "spot", Oldest: oldest},
+			}, nil
+		},
+		tradeSeriesLister: func() ([]tradesql.SeriesSummary, error) {
+			return []tradesql.SeriesSummary{{Exchange: testExchange, AssetType: "spot", Base: "BTC", Quote: "USDT", Oldest: tradesOldest}}, nil
+		},
+		candleLoader: func(exch string, p currency.Pair, a asset.Item, i kline.Interval, start, end time.Time) (*kline.Item, error) {
+			if i != kline.OneMin || !start.Equal(oldest) {
+				return nil, candle.ErrNoCandleDataFound
+			}
+			return &kline.Item{Exchange: exch, Pair: p, Asset: a, Interval: i, Candles: append([]kline.Candle(nil), fineCandles...)}, nil
+		},
+		tradeLoader: func(_, _, _, _ string, start, _ time.Time) ([]trade.Data, error) {
+			if !start.Equal(tradesOldest) {
+				return nil, nil
+			}
+			return append([]trade.Data(nil), trades...), nil
+		},
+		candleSaver: func(k *kline.Item, _ bool) (uint64, error) {
+			saved = append(saved, k)
+			return uint64(len(k.Candles)), nil
+		},
+		candleDeleter: func(_, _, _ string, interval int64, _ string, _, _ time.Time) (int64, error) {
+			if interval != 60 {
+				t.Errorf("received '%v', expected '%v'", interval, 60)
+			}
+			candlesDeleted++
+			return int64(len(fineCandles)), nil
+		},
+		tradeDeleter: func(_, _, _, _ string, _, _ time.Time) (int64, error) {
+			tradesDeleted++
+			return int64(len(trades)), nil
+		},
+		compactor: func() error {
+			compacted = true
+			return nil
+		},
+	}
+
+	_, err := m.LastReport()
+	if !errors.Is(err, errNoRetentionReport) {
+		t.Errorf("received '%v', expected '%v'", err, errNoRetentionReport)
+	}
+
+	report, err := m.Run(true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if report.Removed != 122 || report.Created != 4 || len(report.Series) != 2 {
+		t.Errorf("received removed %v created %v series %v, expected removed 122 created 4 series 2", report.Removed, report.Created, len(report.Series))
+	}
+	if len(saved) != 0 || candlesDeleted != 0 || tradesDeleted != 0 || compacted || report.Compacted {
+		t.Error("expected dry run to leave the database untouched")
+	}
+
+	report, err = m.Run(false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if report.Removed != 122 || report.Created != 4 {
+		t.Errorf("received removed %v created %v, expected removed 122 created 4", report.Removed, report.Created)
+	}
+	if candlesDeleted != 1 || tradesDeleted != 1 || !compacted || !report.Compacted {
+		t.Errorf("received candle deletes %v trade deletes %v compacted %v", candlesDeleted, tradesDeleted, compacted)
+	}
+	if len(saved) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(saved), 2)
+	}
+	hourly := saved[0]
+	if hourly.Interval != kline.OneHour || len(hourly.Candles) != 2 {
+		t.Fatalf("received '%v' candles at '%v', expected 2 candles at '%v'", len(hourly.Candles), hourly.Interval, kline.OneHour)
+	}
+	if !hourly.Candles[0].Time.Equal(oldest) || hourly.Candles[0].High != 60 || hourly.Candles[0].Volume != 60 {
+		t.Errorf("received '%+v', expected first hourly candle to be rolled up from 60 minutes", hourly.Candles[0])
+	}
+	if hourly.Candles[1].High != 120 {
+		t.Errorf("received '%v', expected '%v'", hourly.Candles[1].High, 120)
+	}
+	fromTrades := saved[1]
+	if fromTrades.Interval != kline.OneMin || len(fromTrades.Candles) != 2 ||
+		!fromTrades.Candles[0].Time.Before(fromTrades.Candles[1].Time) || fromTrades.Candles[1].Close != 12 {
+		t.Errorf("received '%+v', expected two sorted minute candles rolled up from trades", fromTrades.Candles)
+	}
+
+	last, err := m.LastReport()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if last.DryRun || last.Removed != report.Removed {
+		t.Errorf("received '%+v', expected '%+v'", last, report)
+	}
+}
@@ -0,0 +1,85 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
+	tradesql "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// DataRetentionManagerName is an exported subsystem name
+const DataRetentionManagerName = "data_retention_manager"
+
+// Data retention data types
+const (
+	DataRetentionCandles = "candles"
+	DataRetentionTrades  = "trades"
+)
+
+// dataRetentionWindow is the minimum amount of data loaded into memory at a
+// time while a series is being rolled up
+const dataRetentionWindow = time.Hour * 24
+
+var (
+	errInvalidRetentionPolicy = errors.New("invalid data retention policy")
+	errNoRetentionReport      = errors.New("data retention has not been run")
+)
+
+// DataRetentionManager periodically applies retention policies to candle and
+// trade data stored in the database. Data older than a policy's max age is
+// rolled up into coarser candles before being removed
+type DataRetentionManager struct {
+	started                    int32
+	processing                 int32
+	shutdown                   chan struct{}
+	wg                         sync.WaitGroup
+	m                          sync.Mutex
+	databaseConnectionInstance database.IDatabase
+	interval                   time.Duration
+	compact                    bool
+	verbose                    bool
+	policies                   []config.DataRetentionPolicy
+	lastReport                 *DataRetentionReport
+	candleSeriesLister         func() ([]candle.SeriesSummary, error)
+	tradeSeriesLister          func() ([]tradesql.SeriesSummary, error)
+	candleLoader               func(string, currency.Pair, asset.Item, kline.Interval, time.Time, time.Time) (*kline.Item, error)
+	tradeLoader                func(string, string, string, string, time.Time, time.Time) ([]trade.Data, error)
+	candleSaver                func(*kline.Item, bool) (uint64, error)
+	candleDeleter              func(string, string, string, int64, string, time.Time, time.Time) (int64, error)
+	tradeDeleter               func(string, string, string, string, time.Time, time.Time) (int64, error)
+	compactor                  func() error
+}
+
+// DataRetentionReport summarises a single retention run
+type DataRetentionReport struct {
+	DryRun    bool
+	Started   time.Time
+	Completed time.Time
+	Removed   int64
+	Created   int64
+	Compacted bool
+	Series    []DataRetentionSeriesResult
+}
+
+// DataRetentionSeriesResult holds what was removed from, and created for, a
+// single stored series
+type DataRetentionSeriesResult struct {
+	DataType       string
+	Exchange       string
+	Asset          asset.Item
+	Pair           currency.Pair
+	Interval       kline.Interval
+	RollupInterval kline.Interval
+	Cutoff         time.Time
+	Removed        int64
+	Created        int64
+	Error          string
+}
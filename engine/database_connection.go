@@ -7,9 +7,11 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	dbpsql "github.com/thrasher-corp/gocryptotrader/database/drivers/postgres"
 	dbsqlite3 "github.com/thrasher-corp/gocryptotrader/database/drivers/sqlite3"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/forexrate"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
@@ -110,6 +112,9 @@ func (m *DatabaseConnectionManager) Start(wg *sync.WaitGroup) (err error) {
 			return fmt.Errorf("%w: %v Some features that utilise a database will be unavailable", database.ErrFailedToConnect, err)
 		}
 		m.dbConn.SetConnected(true)
+		// historical forex rates stored in the database are used for
+		// time-accurate currency conversions
+		currency.SetHistoricalRateLoader(forexrate.LoadHistoricalRates)
 		wg.Add(1)
 		m.wg.Add(1)
 		go m.run(wg)
@@ -132,6 +137,7 @@ func (m *DatabaseConnectionManager) Stop() error {
 		atomic.CompareAndSwapInt32(&m.started, 1, 0)
 	}()
 
+	currency.SetHistoricalRateLoader(nil)
 	err := m.dbConn.CloseConnection()
 	if err != nil {
 		log.Errorf(log.DatabaseMgr, "Failed to close database: %v", err)
@@ -18,8 +18,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjob"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjobresult"
+	fundingratesql "github.com/thrasher-corp/gocryptotrader/database/repository/fundingrate"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -69,6 +71,10 @@ func SetupDataHistoryManager(em iExchangeManager, dcm iDatabaseConnectionManager
 		tradeSaver:                 trade.SaveTradesToDatabase,
 		candleLoader:               kline.LoadFromDatabase,
 		candleSaver:                kline.StoreInDatabase,
+		fundingRateLoader:          fundingratesql.GetInRange,
+		fundingRateSaver:           fundingratesql.Insert,
+		latestFundingRate:          fundingratesql.GetLatest,
+		fundingRateCollection:      cfg.FundingRateCollection,
 	}, nil
 }
 
@@ -220,6 +226,13 @@ func (m *DataHistoryManager) compareJobsToData(jobs ...*DataHistoryJob) error {
 			if err != nil {
 				return err
 			}
+		case dataHistoryFundingRateDataType:
+			var rates []fundingratesql.Data
+			rates, err = m.fundingRateLoader(jobs[i].Exchange, jobs[i].Asset.String(), jobs[i].Pair.Base.String(), jobs[i].Pair.Quote.String(), jobs[i].StartDate, jobs[i].EndDate)
+			if err != nil {
+				return fmt.Errorf("%s could not load funding rate data: %w", jobs[i].Nickname, err)
+			}
+			setHasDataFromFundingRates(jobs[i].rangeHolder, rates)
 		default:
 			return fmt.Errorf("%s %w %s", jobs[i].Nickname, errUnknownDataType, jobs[i].DataType)
 		}
@@ -259,6 +272,12 @@ func (m *DataHistoryManager) runJobs() error {
 	}
 	defer atomic.StoreInt32(&m.processing, 0)
 
+	if m.fundingRateCollection.Enabled {
+		if err := m.createFundingRateJobs(); err != nil {
+			log.Errorln(log.DataHistory, err)
+		}
+	}
+
 	validJobs, err := m.PrepareJobs()
 	if err != nil {
 		return err
@@ -285,6 +304,154 @@ func (m *DataHistoryManager) runJobs() error {
 	return nil
 }
 
+// createFundingRateJobs creates a funding rate job for every enabled perpetual
+// pair which has a funding rate due that has not yet been stored. Jobs start
+// from the period after the latest stored rate, or the configured lookback
+// when no rates are stored. Periods within the lookback which earlier jobs
+// failed to store are queued again, the same as failed ranges of candle and
+// trade jobs are retried, once the pair has no active funding rate job
+func (m *DataHistoryManager) createFundingRateJobs() error {
+	if m == nil {
+		return ErrNilSubsystem
+	}
+	if !m.IsRunning() {
+		return ErrSubSystemNotStarted
+	}
+	exchanges, err := m.exchangeManager.GetExchanges()
+	if err != nil {
+		return err
+	}
+	activeJobs, err := m.GetActiveJobs()
+	if err != nil {
+		return err
+	}
+	active := make(map[string]bool)
+	for i := range activeJobs {
+		if activeJobs[i].DataType == dataHistoryFundingRateDataType {
+			active[fundingRateJobKey(activeJobs[i].Exchange, activeJobs[i].Asset, activeJobs[i].Pair)] = true
+		}
+	}
+	var errs error
+	for i := range exchanges {
+		frequency := exchanges[i].GetBase().Features.Supports.FuturesCapabilities.FundingRateFrequency
+		if frequency <= 0 {
+			continue
+		}
+		assets := exchanges[i].GetAssetTypes(true)
+		for j := range assets {
+			if !assets[j].IsFutures() {
+				continue
+			}
+			pairs, err := exchanges[i].GetEnabledPairs(assets[j])
+			if err != nil {
+				errs = common.AppendError(errs, err)
+				continue
+			}
+			for k := range pairs {
+				isPerp, err := exchanges[i].IsPerpetualFutureCurrency(assets[j], pairs[k])
+				if err != nil || !isPerp {
+					continue
+				}
+				if active[fundingRateJobKey(exchanges[i].GetName(), assets[j], pairs[k])] {
+					// wait for the job to finish before queueing anything
+					// it could not store
+					continue
+				}
+				err = m.createFundingRateJob(exchanges[i].GetName(), assets[j], pairs[k], frequency)
+				if err != nil {
+					errs = common.AppendError(errs, err)
+				}
+			}
+		}
+	}
+	return errs
+}
+
+func (m *DataHistoryManager) createFundingRateJob(exchangeName string, a asset.Item, cp currency.Pair, frequency time.Duration) error {
+	end := time.Now().Truncate(frequency)
+	lookback := end.Add(-m.fundingRateCollection.Lookback).Truncate(frequency)
+	start := lookback
+	latest, err := m.latestFundingRate(exchangeName, a.String(), cp.Base.String(), cp.Quote.String())
+	switch {
+	case err == nil:
+		start = latest.Timestamp.Truncate(frequency).Add(frequency)
+	case !errors.Is(err, sql.ErrNoRows):
+		return fmt.Errorf("%s %s %s could not load latest funding rate: %w", exchangeName, a, cp, err)
+	}
+	stored := start
+	if end.Before(stored) {
+		stored = end
+	}
+	if lookback.Before(stored) {
+		var missing time.Time
+		missing, err = m.firstMissingFundingRate(exchangeName, a, cp, lookback, stored, frequency)
+		if err != nil {
+			return err
+		}
+		if !missing.IsZero() {
+			start = missing
+		}
+	}
+	if !start.Before(end) {
+		return nil
+	}
+	nickname := fmt.Sprintf("fundingrates-%s-%s-%s-%d-%d", strings.ToLower(exchangeName), a, cp.Lower(), start.Unix(), end.Unix())
+	_, err = m.GetByNickname(nickname, false)
+	if err == nil {
+		// the job for this period has already run, anything it could not
+		// store is queued again next period
+		return nil
+	}
+	if !errors.Is(err, errJobNotFound) {
+		return err
+	}
+	if m.verbose {
+		log.Debugf(log.DataHistory, "creating funding rate job %s", nickname)
+	}
+	return m.UpsertJob(&DataHistoryJob{
+		Nickname:         nickname,
+		Exchange:         exchangeName,
+		Asset:            a,
+		Pair:             cp,
+		StartDate:        start,
+		EndDate:          end,
+		Interval:         kline.Interval(frequency),
+		RunBatchLimit:    defaultDataHistoryBatchLimit,
+		RequestSizeLimit: defaultDataHistoryRequestSizeLimit,
+		DataType:         dataHistoryFundingRateDataType,
+		MaxRetryAttempts: defaultDataHistoryRetryAttempts,
+		Status:           dataHistoryStatusActive,
+	}, true)
+}
+
+// firstMissingFundingRate returns the start of the earliest period between
+// start and end without a stored funding rate, or a zero time when every
+// period has one
+func (m *DataHistoryManager) firstMissingFundingRate(exchangeName string, a asset.Item, cp currency.Pair, start, end time.Time, frequency time.Duration) (time.Time, error) {
+	irh, err := kline.CalculateCandleDateRanges(start, end, kline.Interval(frequency), uint32(defaultDataHistoryRequestSizeLimit))
+	if err != nil {
+		return time.Time{}, err
+	}
+	rates, err := m.fundingRateLoader(exchangeName, a.String(), cp.Base.String(), cp.Quote.String(), start, end)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%s %s %s could not load funding rates: %w", exchangeName, a, cp, err)
+	}
+	setHasDataFromFundingRates(irh, rates)
+	for i := range irh.Ranges {
+		for j := range irh.Ranges[i].Intervals {
+			if !irh.Ranges[i].Intervals[j].HasData {
+				return irh.Ranges[i].Intervals[j].Start.Time, nil
+			}
+		}
+	}
+	return time.Time{}, nil
+}
+
+// fundingRateJobKey identifies the pair a funding rate job collects
+func fundingRateJobKey(exchangeName string, a asset.Item, cp currency.Pair) string {
+	return strings.ToLower(exchangeName + "|" + a.String() + "|" + cp.Base.String() + "|" + cp.Quote.String())
+}
+
 // runJob processes an active job, retrieves candle or trade data
 // for a given date range and saves all results to the database
 func (m *DataHistoryManager) runJob(job *DataHistoryJob) error {
@@ -450,6 +617,8 @@ ranges:
 			result, err = m.convertTradesToCandles(job, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time)
 		case dataHistoryConvertCandlesDataType:
 			result, err = m.convertCandleData(job, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time)
+		case dataHistoryFundingRateDataType:
+			result, err = m.processFundingRateData(job, exch, job.rangeHolder.Ranges[i].Start.Time, job.rangeHolder.Ranges[i].End.Time, int64(i))
 		default:
 			return errUnknownDataType
 		}
@@ -815,6 +984,103 @@ func (m *DataHistoryManager) processTradeData(job *DataHistoryJob, exch exchange
 	return r, nil
 }
 
+func (m *DataHistoryManager) processFundingRateData(job *DataHistoryJob, exch exchange.IBotExchange, startRange, endRange time.Time, intervalIndex int64) (*DataHistoryJobResult, error) {
+	if !m.IsRunning() {
+		return nil, ErrSubSystemNotStarted
+	}
+	if job == nil {
+		return nil, errNilJob
+	}
+	if exch == nil {
+		return nil, ErrExchangeNotFound
+	}
+	if err := common.StartEndTimeCheck(startRange, endRange); err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewV4()
+	if err != nil {
+		return nil, err
+	}
+	r := &DataHistoryJobResult{
+		ID:                id,
+		JobID:             job.ID,
+		IntervalStartDate: startRange,
+		IntervalEndDate:   endRange,
+		Status:            dataHistoryStatusComplete,
+		Date:              time.Now(),
+	}
+	rates, err := exch.GetFundingRates(context.TODO(), &fundingrate.RatesRequest{
+		Asset:                job.Asset,
+		Pair:                 job.Pair,
+		StartDate:            startRange,
+		EndDate:              endRange,
+		RespectHistoryLimits: true,
+	})
+	if err != nil {
+		r.Result += "could not get funding rates: " + err.Error() + ". "
+		r.Status = dataHistoryStatusFailed
+		return r, nil //nolint:nilerr // error is returned in the job result
+	}
+	toSave := make([]fundingratesql.Data, 0, len(rates.FundingRates))
+	for i := range rates.FundingRates {
+		if rates.FundingRates[i].Time.Before(startRange) || !rates.FundingRates[i].Time.Before(endRange) {
+			continue
+		}
+		rate, _ := rates.FundingRates[i].Rate.Float64()
+		toSave = append(toSave, fundingratesql.Data{
+			Exchange:  job.Exchange,
+			AssetType: job.Asset.String(),
+			Base:      job.Pair.Base.String(),
+			Quote:     job.Pair.Quote.String(),
+			Rate:      rate,
+			Timestamp: rates.FundingRates[i].Time,
+		})
+	}
+	setHasDataFromFundingRates(job.rangeHolder, toSave)
+	for i := range job.rangeHolder.Ranges[intervalIndex].Intervals {
+		if !job.rangeHolder.Ranges[intervalIndex].Intervals[i].HasData {
+			r.Status = dataHistoryStatusFailed
+			r.Result += fmt.Sprintf("missing data from %v - %v. ",
+				job.rangeHolder.Ranges[intervalIndex].Intervals[i].Start.Time.Format(common.SimpleTimeFormatWithTimezone),
+				job.rangeHolder.Ranges[intervalIndex].Intervals[i].End.Time.Format(common.SimpleTimeFormatWithTimezone))
+		}
+	}
+	if len(toSave) == 0 {
+		return r, nil
+	}
+	if m.verbose {
+		log.Debugf(log.DataHistory, "Saving %v funding rates for job %v", len(toSave), job.Nickname)
+	}
+	err = m.fundingRateSaver(toSave...)
+	if err != nil {
+		r.Result += "could not save results: " + err.Error() + ". "
+		r.Status = dataHistoryStatusFailed
+	}
+	return r, nil
+}
+
+// setHasDataFromFundingRates marks each interval which contains a funding
+// rate as having data
+func setHasDataFromFundingRates(irh *kline.IntervalRangeHolder, rates []fundingratesql.Data) {
+	if irh == nil {
+		return
+	}
+	for i := range irh.Ranges {
+		for j := range irh.Ranges[i].Intervals {
+			if irh.Ranges[i].Intervals[j].HasData {
+				continue
+			}
+			for k := range rates {
+				if !rates[k].Timestamp.Before(irh.Ranges[i].Intervals[j].Start.Time) &&
+					rates[k].Timestamp.Before(irh.Ranges[i].Intervals[j].End.Time) {
+					irh.Ranges[i].Intervals[j].HasData = true
+					break
+				}
+			}
+		}
+	}
+}
+
 func (m *DataHistoryManager) convertTradesToCandles(job *DataHistoryJob, startRange, endRange time.Time) (*DataHistoryJobResult, error) {
 	if !m.IsRunning() {
 		return nil, ErrSubSystemNotStarted
@@ -1242,6 +1508,27 @@ func (m *DataHistoryManager) validateJob(job *DataHistoryJob) error {
 	}
 
 	b := exch.GetBase()
+	if job.DataType == dataHistoryFundingRateDataType {
+		if !job.Asset.IsFutures() {
+			return fmt.Errorf("job %s %w %s", job.Nickname, errNotPerpetualFuture, job.Asset)
+		}
+		var isPerp bool
+		isPerp, err = exch.IsPerpetualFutureCurrency(job.Asset, job.Pair)
+		if err != nil {
+			return fmt.Errorf("job %s %w", job.Nickname, err)
+		}
+		if !isPerp {
+			return fmt.Errorf("job %s %w %s %s", job.Nickname, errNotPerpetualFuture, job.Asset, job.Pair)
+		}
+		frequency := kline.Interval(b.Features.Supports.FuturesCapabilities.FundingRateFrequency)
+		if frequency <= 0 {
+			return fmt.Errorf("job %s %w %s", job.Nickname, errFundingRatesUnsupported, job.Exchange)
+		}
+		if job.Interval < frequency {
+			log.Warnf(log.DataHistory, "job %s interval %v below the funding rate frequency of %v, defaulting to %v", job.Nickname, job.Interval.Word(), frequency.Word(), frequency.Word())
+			job.Interval = frequency
+		}
+	}
 	// TODO: In future allow custom candles.
 	if !b.Features.Enabled.Kline.Intervals.ExchangeSupported(job.Interval) &&
 		(job.DataType == dataHistoryCandleDataType || job.DataType == dataHistoryCandleValidationDataType) {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/config"
@@ -17,8 +18,10 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjob"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjobresult"
+	fundingratesql "github.com/thrasher-corp/gocryptotrader/database/repository/fundingrate"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -631,6 +634,16 @@ func TestCompareJobsToData(t *testing.T) {
 		t.Errorf("error '%v', expected '%v'", err, nil)
 	}
 
+	dhj.DataType = dataHistoryFundingRateDataType
+	dhj.Interval = kline.OneMin
+	err = m.compareJobsToData(dhj)
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+	if !dhj.rangeHolder.Ranges[0].Intervals[0].HasData {
+		t.Error("expected funding rate data to be found")
+	}
+
 	dhj.DataType = 1337
 	err = m.compareJobsToData(dhj)
 	if !errors.Is(err, errUnknownDataType) {
@@ -979,6 +992,7 @@ func createDHM(t *testing.T) (*DataHistoryManager, *datahistoryjob.DataHistoryJo
 		started:                    1,
 		exchangeManager:            em,
 		candleLoader:               dataHistoryCandleLoader,
+		fundingRateLoader:          dataHistoryFundingRateLoader,
 		interval:                   time.NewTicker(time.Minute),
 		verbose:                    true,
 		maxResultInsertions:        defaultMaxResultInsertions,
@@ -1111,6 +1125,274 @@ func TestProcessTradeData(t *testing.T) {
 	}
 }
 
+func TestProcessFundingRateData(t *testing.T) {
+	t.Parallel()
+	m, _ := createDHM(t)
+	_, err := m.processFundingRateData(nil, nil, time.Time{}, time.Time{}, 0)
+	if !errors.Is(err, errNilJob) {
+		t.Errorf("received %v expected %v", err, errNilJob)
+	}
+	j := &DataHistoryJob{
+		Exchange:  "Binance",
+		Asset:     asset.USDTMarginedFutures,
+		Pair:      currency.NewPair(currency.BTC, currency.USDT),
+		StartDate: time.Now().Add(-kline.EightHour.Duration() * 3).Truncate(kline.EightHour.Duration()),
+		EndDate:   time.Now().Truncate(kline.EightHour.Duration()),
+		Interval:  kline.EightHour,
+	}
+	_, err = m.processFundingRateData(j, nil, time.Time{}, time.Time{}, 0)
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("received %v expected %v", err, ErrExchangeNotFound)
+	}
+	exch, err := m.exchangeManager.GetExchangeByName("Binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	fakeExchange := dhmExchange{
+		IBotExchange: exch,
+	}
+	_, err = m.processFundingRateData(j, fakeExchange, time.Time{}, time.Time{}, 0)
+	if !errors.Is(err, common.ErrDateUnset) {
+		t.Errorf("received %v expected %v", err, common.ErrDateUnset)
+	}
+	j.rangeHolder, err = kline.CalculateCandleDateRanges(j.StartDate, j.EndDate, j.Interval, 1337)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+
+	var saved int
+	m.fundingRateSaver = func(rates ...fundingratesql.Data) error {
+		saved += len(rates)
+		return nil
+	}
+	r, err := m.processFundingRateData(j, fakeExchange, j.StartDate, j.EndDate, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	if r.Status != dataHistoryStatusComplete {
+		t.Errorf("received %v expected %v %v", r.Status, dataHistoryStatusComplete, r.Result)
+	}
+	if saved != 3 {
+		t.Errorf("received %v expected %v", saved, 3)
+	}
+
+	m.fundingRateSaver = func(...fundingratesql.Data) error {
+		return database.ErrDatabaseSupportDisabled
+	}
+	r, err = m.processFundingRateData(j, fakeExchange, j.StartDate, j.EndDate, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	if r.Status != dataHistoryStatusFailed {
+		t.Errorf("received %v expected %v", r.Status, dataHistoryStatusFailed)
+	}
+
+	// the exchange itself returns an error as it cannot reach the API
+	r, err = m.processFundingRateData(j, exch, j.StartDate, j.EndDate, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	if r.Status != dataHistoryStatusFailed {
+		t.Errorf("received %v expected %v", r.Status, dataHistoryStatusFailed)
+	}
+}
+
+func TestValidateFundingRateJob(t *testing.T) {
+	t.Parallel()
+	m, _ := createDHM(t)
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	enableFundingRatePairs(t, m, cp)
+	dhj := &DataHistoryJob{
+		Nickname:  "funding",
+		Exchange:  "Binance",
+		Asset:     asset.Spot,
+		Pair:      cp,
+		StartDate: time.Now().Add(-time.Hour * 72),
+		EndDate:   time.Now().Add(-time.Hour),
+		Interval:  kline.OneHour,
+		DataType:  dataHistoryFundingRateDataType,
+	}
+	err := m.validateJob(dhj)
+	if !errors.Is(err, errNotPerpetualFuture) {
+		t.Errorf("error '%v', expected '%v'", err, errNotPerpetualFuture)
+	}
+
+	dhj.Asset = asset.USDTMarginedFutures
+	err = m.validateJob(dhj)
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+	if dhj.Interval != kline.EightHour {
+		t.Errorf("received '%v', expected '%v'", dhj.Interval, kline.EightHour)
+	}
+
+	dhj.Exchange = testExchange
+	dhj.Asset = asset.Spot
+	dhj.Pair = currency.NewPair(currency.BTC, currency.USD)
+	err = m.validateJob(dhj)
+	if !errors.Is(err, errNotPerpetualFuture) {
+		t.Errorf("error '%v', expected '%v'", err, errNotPerpetualFuture)
+	}
+}
+
+func TestCreateFundingRateJobs(t *testing.T) {
+	t.Parallel()
+	var m *DataHistoryManager
+	err := m.createFundingRateJobs()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	m, _ = createDHM(t)
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	enableFundingRatePairs(t, m, cp)
+	m.fundingRateCollection.Lookback = kline.OneDay.Duration()
+
+	// a rate for the latest period has been stored, nothing is due
+	m.latestFundingRate = func(string, string, string, string) (fundingratesql.Data, error) {
+		return fundingratesql.Data{Timestamp: time.Now()}, nil
+	}
+	err = m.createFundingRateJobs()
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+
+	// the mock job service always returns an existing job
+	m.latestFundingRate = func(string, string, string, string) (fundingratesql.Data, error) {
+		return fundingratesql.Data{}, sql.ErrNoRows
+	}
+	err = m.createFundingRateJobs()
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+
+	m.latestFundingRate = func(string, string, string, string) (fundingratesql.Data, error) {
+		return fundingratesql.Data{}, database.ErrDatabaseSupportDisabled
+	}
+	err = m.createFundingRateJobs()
+	if !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		t.Errorf("error '%v', expected '%v'", err, database.ErrDatabaseSupportDisabled)
+	}
+
+	m.started = 0
+	err = m.createFundingRateJobs()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("error '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestCreateFundingRateJob(t *testing.T) {
+	t.Parallel()
+	m, j := createDHM(t)
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	enableFundingRatePairs(t, m, cp)
+	jobDB := &fundingRateJobService{dataHistoryJobService: dataHistoryJobService{job: j}}
+	m.jobDB = jobDB
+	frequency := kline.EightHour.Duration()
+	m.fundingRateCollection.Lookback = kline.OneDay.Duration()
+	end := time.Now().Truncate(frequency)
+	lookback := end.Add(-m.fundingRateCollection.Lookback)
+	m.latestFundingRate = func(string, string, string, string) (fundingratesql.Data, error) {
+		return fundingratesql.Data{Timestamp: end}, nil
+	}
+	stored := []fundingratesql.Data{
+		{Timestamp: lookback},
+		{Timestamp: lookback.Add(frequency)},
+		{Timestamp: lookback.Add(frequency * 2)},
+	}
+	m.fundingRateLoader = func(string, string, string, string, time.Time, time.Time) ([]fundingratesql.Data, error) {
+		return stored, nil
+	}
+	err := m.createFundingRateJob("Binance", asset.USDTMarginedFutures, cp, frequency)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if len(jobDB.upserted) != 0 {
+		t.Fatalf("received '%v' jobs, expected '%v'", len(jobDB.upserted), 0)
+	}
+
+	// a failed period is queued again even though later rates are stored
+	stored = append(stored[:1], stored[2:]...)
+	err = m.createFundingRateJob("Binance", asset.USDTMarginedFutures, cp, frequency)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if len(jobDB.upserted) != 1 {
+		t.Fatalf("received '%v' jobs, expected '%v'", len(jobDB.upserted), 1)
+	}
+	if !jobDB.upserted[0].StartDate.Equal(lookback.Add(frequency)) {
+		t.Errorf("received '%v', expected '%v'", jobDB.upserted[0].StartDate, lookback.Add(frequency))
+	}
+	if !jobDB.upserted[0].EndDate.Equal(end) {
+		t.Errorf("received '%v', expected '%v'", jobDB.upserted[0].EndDate, end)
+	}
+
+	m.fundingRateLoader = func(string, string, string, string, time.Time, time.Time) ([]fundingratesql.Data, error) {
+		return nil, database.ErrDatabaseSupportDisabled
+	}
+	err = m.createFundingRateJob("Binance", asset.USDTMarginedFutures, cp, frequency)
+	if !errors.Is(err, database.ErrDatabaseSupportDisabled) {
+		t.Errorf("error '%v', expected '%v'", err, database.ErrDatabaseSupportDisabled)
+	}
+
+	// nothing is queued while the pair has an active funding rate job
+	m.fundingRateLoader = func(string, string, string, string, time.Time, time.Time) ([]fundingratesql.Data, error) {
+		return nil, nil
+	}
+	j.ExchangeName = "Binance"
+	j.Asset = asset.USDTMarginedFutures.String()
+	j.Base = "btc"
+	j.Quote = "usdt"
+	j.DataType = int64(dataHistoryFundingRateDataType)
+	j.Status = int64(dataHistoryStatusActive)
+	jobDB.upserted = nil
+	err = m.createFundingRateJobs()
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	if len(jobDB.upserted) != 0 {
+		t.Errorf("received '%v' jobs, expected '%v'", len(jobDB.upserted), 0)
+	}
+}
+
+func TestSetHasDataFromFundingRates(t *testing.T) {
+	t.Parallel()
+	setHasDataFromFundingRates(nil, nil)
+	start := time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)
+	irh, err := kline.CalculateCandleDateRanges(start, start.Add(kline.EightHour.Duration()*3), kline.EightHour, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	setHasDataFromFundingRates(irh, []fundingratesql.Data{
+		{Timestamp: start},
+		{Timestamp: start.Add(kline.EightHour.Duration()*2 + time.Millisecond)},
+	})
+	if !irh.Ranges[0].Intervals[0].HasData {
+		t.Error("expected first interval to have data")
+	}
+	if irh.Ranges[0].Intervals[1].HasData {
+		t.Error("expected second interval to be missing data")
+	}
+	if !irh.Ranges[1].Intervals[0].HasData {
+		t.Error("expected third interval to have data")
+	}
+}
+
+func enableFundingRatePairs(t *testing.T, m *DataHistoryManager, cp currency.Pair) {
+	t.Helper()
+	exch, err := m.exchangeManager.GetExchangeByName("Binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v expected %v", err, nil)
+	}
+	b := exch.GetBase()
+	b.CurrencyPairs.Pairs[asset.USDTMarginedFutures] = &currency.PairStore{
+		Available:     currency.Pairs{cp},
+		Enabled:       currency.Pairs{cp},
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Uppercase: true},
+		RequestFormat: &currency.PairFormat{Uppercase: true},
+	}
+}
+
 func TestConvertJobTradesToCandles(t *testing.T) {
 	t.Parallel()
 	m, _ := createDHM(t)
@@ -1430,6 +1712,21 @@ type dataHistoryJobService struct {
 	job *datahistoryjob.DataHistoryJob
 }
 
+// fundingRateJobService stores no jobs and records the jobs upserted
+type fundingRateJobService struct {
+	dataHistoryJobService
+	upserted []*datahistoryjob.DataHistoryJob
+}
+
+func (f *fundingRateJobService) GetByNickName(_ string) (*datahistoryjob.DataHistoryJob, error) {
+	return nil, sql.ErrNoRows
+}
+
+func (f *fundingRateJobService) Upsert(jobs ...*datahistoryjob.DataHistoryJob) error {
+	f.upserted = append(f.upserted, jobs...)
+	return nil
+}
+
 type dataHistoryJobResultService struct {
 	datahistoryjobresult.IDBService
 }
@@ -1546,6 +1843,19 @@ func dataHistoryCandleLoader(exch string, cp currency.Pair, a asset.Item, interv
 	}, nil
 }
 
+func dataHistoryFundingRateLoader(exch, a, base, quote string, start, _ time.Time) ([]fundingratesql.Data, error) {
+	return []fundingratesql.Data{
+		{
+			Exchange:  exch,
+			AssetType: a,
+			Base:      base,
+			Quote:     quote,
+			Rate:      0.0001,
+			Timestamp: start,
+		},
+	}, nil
+}
+
 func dataHistoryTradeSaver(...trade.Data) error {
 	return nil
 }
@@ -1595,6 +1905,23 @@ func (f dhmExchange) GetHistoricCandlesExtended(_ context.Context, p currency.Pa
 	}, nil
 }
 
+func (f dhmExchange) GetFundingRates(_ context.Context, r *fundingrate.RatesRequest) (*fundingrate.Rates, error) {
+	resp := &fundingrate.Rates{
+		Exchange:  f.GetName(),
+		Asset:     r.Asset,
+		Pair:      r.Pair,
+		StartDate: r.StartDate,
+		EndDate:   r.EndDate,
+	}
+	for t := r.StartDate; !t.After(r.EndDate); t = t.Add(kline.EightHour.Duration()) {
+		resp.FundingRates = append(resp.FundingRates, fundingrate.Rate{
+			Time: t,
+			Rate: decimal.NewFromFloat(0.0001),
+		})
+	}
+	return resp, nil
+}
+
 func (f dhmExchange) GetHistoricTrades(_ context.Context, p currency.Pair, a asset.Item, startTime, _ time.Time) ([]trade.Data, error) {
 	return []trade.Data{
 		{
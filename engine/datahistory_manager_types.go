@@ -5,10 +5,12 @@ import (
 	"time"
 
 	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjob"
 	"github.com/thrasher-corp/gocryptotrader/database/repository/datahistoryjobresult"
+	fundingratesql "github.com/thrasher-corp/gocryptotrader/database/repository/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
@@ -27,6 +29,7 @@ const (
 	dataHistoryConvertCandlesDataType
 	dataHistoryCandleValidationDataType
 	dataHistoryCandleValidationSecondarySourceType
+	dataHistoryFundingRateDataType
 )
 
 // DataHistoryJob status descriptors
@@ -79,13 +82,15 @@ func (d dataHistoryDataType) String() string {
 		return "conversion validation"
 	case 5:
 		return "conversion validation secondary source"
+	case 6:
+		return "funding rates"
 	}
 	return ""
 }
 
 // Valid ensures the value set is legitimate
 func (d dataHistoryDataType) Valid() bool {
-	return int64(d) >= 0 && int64(d) <= 5
+	return int64(d) >= 0 && int64(d) <= 6
 }
 
 var (
@@ -104,6 +109,8 @@ var (
 	errNilResult                  = errors.New("received nil job result")
 	errJobMustBeActiveOrPaused    = errors.New("job must be active or paused to be set as a prerequisite")
 	errNilCandles                 = errors.New("received nil candles")
+	errNotPerpetualFuture         = errors.New("funding rates are only available for perpetual futures")
+	errFundingRatesUnsupported    = errors.New("exchange does not support funding rates")
 
 	// defaultDataHistoryTradeInterval is the default interval size used to verify whether there is any database data
 	// for a trade job
@@ -136,6 +143,10 @@ type DataHistoryManager struct {
 	tradeLoader                func(string, string, string, string, time.Time, time.Time) ([]trade.Data, error)
 	tradeSaver                 func(...trade.Data) error
 	candleSaver                func(*kline.Item, bool) (uint64, error)
+	fundingRateLoader          func(string, string, string, string, time.Time, time.Time) ([]fundingratesql.Data, error)
+	fundingRateSaver           func(...fundingratesql.Data) error
+	latestFundingRate          func(string, string, string, string) (fundingratesql.Data, error)
+	fundingRateCollection      config.FundingRateCollection
 }
 
 // DataHistoryJob used to gather candle/trade history and save
@@ -14,6 +14,8 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/database"
@@ -48,7 +50,16 @@ type Engine struct {
 	WebsocketRoutineManager *WebsocketRoutineManager
 	WithdrawManager         *WithdrawManager
 	dataHistoryManager      *DataHistoryManager
+	dataRetentionManager    *DataRetentionManager
+	fundingRateScanner      *FundingRateScanner
+	basisService            *BasisService
+	candleBuilder           *CandleBuilder
+	eventJournal            *EventJournal
+	accountingManager       *AccountingManager
+	ledgerManager           *LedgerManager
+	rebalancer              *Rebalancer
 	currencyStateManager    *CurrencyStateManager
+	configReloadManager     *configReloadManager
 	Settings                Settings
 	uptime                  time.Time
 	GRPCShutdownSignal      chan struct{}
@@ -176,6 +187,14 @@ func validateSettings(b *Engine, s *Settings, flagSet FlagSet) {
 	flagSet.WithBool("exchangeratehost", &b.Settings.EnableExchangeRateHost, b.Config.Currency.ForexProviders.IsEnabled("exchangeratehost"))
 
 	flagSet.WithBool("datahistorymanager", &b.Settings.EnableDataHistoryManager, b.Config.DataHistoryManager.Enabled)
+	flagSet.WithBool("dataretentionmanager", &b.Settings.EnableDataRetentionManager, b.Config.DataRetentionManager.Enabled)
+	flagSet.WithBool("fundingratescanner", &b.Settings.EnableFundingRateScanner, b.Config.FundingRateScanner.Enabled)
+	flagSet.WithBool("basisservice", &b.Settings.EnableBasisService, b.Config.BasisService.Enabled)
+	flagSet.WithBool("candlebuilder", &b.Settings.EnableCandleBuilder, b.Config.CandleBuilder.Enabled)
+	flagSet.WithBool("eventjournal", &b.Settings.EnableEventJournal, b.Config.EventJournal.Enabled)
+	flagSet.WithBool("accountingmanager", &b.Settings.EnableAccountingManager, b.Config.Accounting.Enabled)
+	flagSet.WithBool("ledgermanager", &b.Settings.EnableLedgerManager, b.Config.Ledger.Enabled)
+	flagSet.WithBool("rebalancer", &b.Settings.EnableRebalancer, b.Config.Rebalancer.Enabled)
 	flagSet.WithBool("currencystatemanager", &b.Settings.EnableCurrencyStateManager, b.Config.CurrencyStateManager.Enabled != nil && *b.Config.CurrencyStateManager.Enabled)
 	flagSet.WithBool("gctscriptmanager", &b.Settings.EnableGCTScriptManager, b.Config.GCTScript.Enabled)
 
@@ -374,6 +393,22 @@ func (bot *Engine) Start() error {
 	gctlog.Debugf(gctlog.Global, "\t Available Exchanges: %d. Enabled Exchanges: %d.\n",
 		len(bot.Config.Exchanges), enabledExchanges)
 
+	// Secret providers must be setup before the exchanges so API credentials
+	// which reference secrets can be resolved
+	if err := secrets.Setup(&bot.Config.Secrets); err != nil {
+		gctlog.Errorf(gctlog.Global, "Secret providers unable to setup: %v", err)
+	} else if err := secrets.Start(); err != nil {
+		gctlog.Errorf(gctlog.Global, "Secret manager unable to start: %v", err)
+	}
+
+	if bot.Config.Tracing.Enabled {
+		if err := tracing.Setup(&bot.Config.Tracing); err != nil {
+			gctlog.Errorf(gctlog.Global, "Tracing unable to setup: %v", err)
+		} else if err := tracing.Start(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Tracing unable to start: %v", err)
+		}
+	}
+
 	if bot.Settings.ExchangePurgeCredentials {
 		gctlog.Debugln(gctlog.Global, "Purging exchange API credentials.")
 		bot.Config.PurgeExchangeAPICredentials()
@@ -441,6 +476,45 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnableDataRetentionManager {
+		if bot.dataRetentionManager == nil {
+			if d, err := SetupDataRetentionManager(bot.DatabaseManager, &bot.Config.DataRetentionManager); err != nil {
+				gctlog.Errorf(gctlog.Global, "data retention manager unable to setup: %s", err)
+			} else {
+				bot.dataRetentionManager = d
+				if err := bot.dataRetentionManager.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "data retention manager unable to start: %s", err)
+				}
+			}
+		}
+	}
+
+	if bot.Settings.EnableFundingRateScanner {
+		if bot.fundingRateScanner == nil {
+			if f, err := SetupFundingRateScanner(bot.ExchangeManager, &bot.Config.FundingRateScanner); err != nil {
+				gctlog.Errorf(gctlog.Global, "funding rate scanner unable to setup: %s", err)
+			} else {
+				bot.fundingRateScanner = f
+				if err := bot.fundingRateScanner.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "funding rate scanner unable to start: %s", err)
+				}
+			}
+		}
+	}
+
+	if bot.Settings.EnableBasisService {
+		if bot.basisService == nil {
+			if b, err := SetupBasisService(bot.ExchangeManager, &bot.Config.BasisService); err != nil {
+				gctlog.Errorf(gctlog.Global, "basis service unable to setup: %s", err)
+			} else {
+				bot.basisService = b
+				if err := bot.basisService.Start(); err != nil {
+					gctlog.Errorf(gctlog.Global, "basis service unable to start: %s", err)
+				}
+			}
+		}
+	}
+
 	if w, err := SetupWithdrawManager(bot.ExchangeManager, bot.portfolioManager, bot.Settings.EnableDryRun); err != nil {
 		return err
 	} else { //nolint:revive // TODO: revive false positive, see https://github.com/mgechev/revive/pull/832 for more information
@@ -490,6 +564,11 @@ func (bot *Engine) Start() error {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to setup: %s", err)
 		} else {
 			bot.OrderManager = o
+			if bot.Config.OrderManager.MarginEngine.Enabled {
+				if err = bot.OrderManager.SetupMarginEngine(&bot.Config.OrderManager.MarginEngine); err != nil {
+					gctlog.Errorf(gctlog.Global, "Order manager unable to setup margin engine: %s", err)
+				}
+			}
 			if err = bot.OrderManager.Start(); err != nil {
 				gctlog.Errorf(gctlog.Global, "Order manager unable to start: %s", err)
 			}
@@ -497,25 +576,7 @@ func (bot *Engine) Start() error {
 	}
 
 	if bot.Settings.EnableExchangeSyncManager {
-		cfg := bot.Config.SyncManagerConfig
-		cfg.SynchronizeTicker = bot.Settings.EnableTickerSyncing
-		cfg.SynchronizeOrderbook = bot.Settings.EnableOrderbookSyncing
-		cfg.SynchronizeContinuously = bot.Settings.SyncContinuously
-		cfg.SynchronizeTrades = bot.Settings.EnableTradeSyncing
-		cfg.Verbose = bot.Settings.Verbose || cfg.Verbose
-
-		if cfg.TimeoutREST != bot.Settings.SyncTimeoutREST &&
-			bot.Settings.SyncTimeoutREST != config.DefaultSyncerTimeoutREST {
-			cfg.TimeoutREST = bot.Settings.SyncTimeoutREST
-		}
-		if cfg.TimeoutWebsocket != bot.Settings.SyncTimeoutWebsocket &&
-			bot.Settings.SyncTimeoutWebsocket != config.DefaultSyncerTimeoutWebsocket {
-			cfg.TimeoutWebsocket = bot.Settings.SyncTimeoutWebsocket
-		}
-		if cfg.NumWorkers != bot.Settings.SyncWorkersCount &&
-			bot.Settings.SyncWorkersCount != config.DefaultSyncerWorkers {
-			cfg.NumWorkers = bot.Settings.SyncWorkersCount
-		}
+		cfg := bot.getSyncManagerConfig()
 		if s, err := setupSyncManager(
 			&cfg,
 			bot.ExchangeManager,
@@ -555,6 +616,71 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Settings.EnableCandleBuilder {
+		if bot.candleBuilder == nil {
+			if err := bot.setupCandleBuilder(); err != nil {
+				gctlog.Errorf(gctlog.Global, "candle builder unable to setup: %s", err)
+			}
+		}
+		if bot.candleBuilder != nil {
+			if err := bot.candleBuilder.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "candle builder unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableEventJournal {
+		if bot.eventJournal == nil {
+			if err := bot.setupEventJournal(); err != nil {
+				gctlog.Errorf(gctlog.Global, "event journal unable to setup: %s", err)
+			}
+		}
+		if bot.eventJournal != nil {
+			if err := bot.eventJournal.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "event journal unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableAccountingManager {
+		if bot.accountingManager == nil {
+			if err := bot.setupAccountingManager(); err != nil {
+				gctlog.Errorf(gctlog.Global, "accounting manager unable to setup: %s", err)
+			}
+		}
+		if bot.accountingManager != nil {
+			if err := bot.accountingManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "accounting manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableLedgerManager {
+		if bot.ledgerManager == nil {
+			if err := bot.setupLedgerManager(); err != nil {
+				gctlog.Errorf(gctlog.Global, "ledger manager unable to setup: %s", err)
+			}
+		}
+		if bot.ledgerManager != nil {
+			if err := bot.ledgerManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "ledger manager unable to start: %s", err)
+			}
+		}
+	}
+
+	if bot.Settings.EnableRebalancer {
+		if bot.rebalancer == nil {
+			if err := bot.setupRebalancer(); err != nil {
+				gctlog.Errorf(gctlog.Global, "rebalancer unable to setup: %s", err)
+			}
+		}
+		if bot.rebalancer != nil {
+			if err := bot.rebalancer.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "rebalancer unable to start: %s", err)
+			}
+		}
+	}
+
 	if bot.Settings.EnableGCTScriptManager {
 		if g, err := gctscript.NewManager(&bot.Config.GCTScript); err != nil {
 			gctlog.Errorf(gctlog.Global, "failed to create script manager. Err: %s", err)
@@ -586,6 +712,19 @@ func (bot *Engine) Start() error {
 		}
 	}
 
+	if bot.Config.HotReload.Enabled {
+		if filePath, _, err := config.GetFilePath(bot.Settings.ConfigFile); err != nil {
+			gctlog.Errorf(gctlog.Global, "Config reload manager unable to setup: %s", err)
+		} else if c, err := setupConfigReloadManager(&bot.Config.HotReload, filePath, bot); err != nil {
+			gctlog.Errorf(gctlog.Global, "Config reload manager unable to setup: %s", err)
+		} else {
+			bot.configReloadManager = c
+			if err := bot.configReloadManager.Start(); err != nil {
+				gctlog.Errorf(gctlog.Global, "Config reload manager unable to start: %s", err)
+			}
+		}
+	}
+
 	return nil
 }
 
@@ -596,6 +735,12 @@ func (bot *Engine) Stop() {
 
 	gctlog.Debugln(gctlog.Global, "Engine shutting down..")
 
+	if bot.configReloadManager.IsRunning() {
+		if err := bot.configReloadManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Config reload manager unable to stop. Error: %v", err)
+		}
+	}
+
 	if len(bot.portfolioManager.GetAddresses()) != 0 {
 		bot.Config.Portfolio = *bot.portfolioManager.GetPortfolio()
 	}
@@ -605,6 +750,21 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.Global, "GCTScript manager unable to stop. Error: %v", err)
 		}
 	}
+	if bot.accountingManager.IsRunning() {
+		if err := bot.accountingManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "accounting manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.ledgerManager.IsRunning() {
+		if err := bot.ledgerManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "ledger manager unable to stop. Error: %v", err)
+		}
+	}
+	if bot.rebalancer.IsRunning() {
+		if err := bot.rebalancer.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "rebalancer unable to stop. Error: %v", err)
+		}
+	}
 	if bot.OrderManager.IsRunning() {
 		if err := bot.OrderManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Order manager unable to stop. Error: %v", err)
@@ -650,6 +810,31 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.DataHistory, "data history manager unable to stop. Error: %v", err)
 		}
 	}
+	if bot.fundingRateScanner.IsRunning() {
+		if err := bot.fundingRateScanner.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "funding rate scanner unable to stop. Error: %v", err)
+		}
+	}
+	if bot.basisService.IsRunning() {
+		if err := bot.basisService.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "basis service unable to stop. Error: %v", err)
+		}
+	}
+	if bot.candleBuilder.IsRunning() {
+		if err := bot.candleBuilder.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "candle builder unable to stop. Error: %v", err)
+		}
+	}
+	if bot.eventJournal.IsRunning() {
+		if err := bot.eventJournal.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "event journal unable to stop. Error: %v", err)
+		}
+	}
+	if bot.dataRetentionManager.IsRunning() {
+		if err := bot.dataRetentionManager.Stop(); err != nil {
+			gctlog.Errorf(gctlog.DatabaseMgr, "data retention manager unable to stop. Error: %v", err)
+		}
+	}
 	if bot.DatabaseManager.IsRunning() {
 		if err := bot.DatabaseManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "Database manager unable to stop. Error: %v", err)
@@ -660,6 +845,16 @@ func (bot *Engine) Stop() {
 			gctlog.Errorf(gctlog.DispatchMgr, "Dispatch system unable to stop. Error: %v", err)
 		}
 	}
+	if secrets.IsRunning() {
+		if err := secrets.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Secret manager unable to stop. Error: %v", err)
+		}
+	}
+	if tracing.IsRunning() {
+		if err := tracing.Stop(); err != nil {
+			gctlog.Errorf(gctlog.Global, "Tracing unable to stop. Error: %v", err)
+		}
+	}
 	if bot.WebsocketRoutineManager.IsRunning() {
 		if err := bot.WebsocketRoutineManager.Stop(); err != nil {
 			gctlog.Errorf(gctlog.Global, "websocket routine manager unable to stop. Error: %v", err)
@@ -769,36 +964,7 @@ func (bot *Engine) LoadExchange(name string, wg *sync.WaitGroup) error {
 		}
 	}
 
-	if bot.Settings.EnableExchangeVerbose {
-		exchCfg.Verbose = true
-	}
-	if exchCfg.Features != nil {
-		if bot.Settings.EnableExchangeWebsocketSupport &&
-			exchCfg.Features.Supports.Websocket {
-			exchCfg.Features.Enabled.Websocket = true
-		}
-		if bot.Settings.EnableExchangeAutoPairUpdates &&
-			exchCfg.Features.Supports.RESTCapabilities.AutoPairUpdates {
-			exchCfg.Features.Enabled.AutoPairUpdates = true
-		}
-		if bot.Settings.DisableExchangeAutoPairUpdates {
-			if exchCfg.Features.Supports.RESTCapabilities.AutoPairUpdates {
-				exchCfg.Features.Enabled.AutoPairUpdates = false
-			}
-		}
-	}
-	if bot.Settings.HTTPUserAgent != "" {
-		exchCfg.HTTPUserAgent = bot.Settings.HTTPUserAgent
-	}
-	if bot.Settings.HTTPProxy != "" {
-		exchCfg.ProxyAddress = bot.Settings.HTTPProxy
-	}
-	if bot.Settings.HTTPTimeout != exchange.DefaultHTTPTimeout {
-		exchCfg.HTTPTimeout = bot.Settings.HTTPTimeout
-	}
-	if bot.Settings.EnableExchangeHTTPDebugging {
-		exchCfg.HTTPDebugging = bot.Settings.EnableExchangeHTTPDebugging
-	}
+	bot.applyExchangeSettingOverrides(exchCfg)
 
 	localWG.Wait()
 	if !bot.Settings.EnableExchangeHTTPRateLimiter {
@@ -864,6 +1030,66 @@ func (bot *Engine) LoadExchange(name string, wg *sync.WaitGroup) error {
 	return exch.Start(context.TODO(), wg)
 }
 
+// applyExchangeSettingOverrides applies the exchange related command line
+// settings to an exchange config
+func (bot *Engine) applyExchangeSettingOverrides(exchCfg *config.Exchange) {
+	if bot.Settings.EnableExchangeVerbose {
+		exchCfg.Verbose = true
+	}
+	if exchCfg.Features != nil {
+		if bot.Settings.EnableExchangeWebsocketSupport &&
+			exchCfg.Features.Supports.Websocket {
+			exchCfg.Features.Enabled.Websocket = true
+		}
+		if bot.Settings.EnableExchangeAutoPairUpdates &&
+			exchCfg.Features.Supports.RESTCapabilities.AutoPairUpdates {
+			exchCfg.Features.Enabled.AutoPairUpdates = true
+		}
+		if bot.Settings.DisableExchangeAutoPairUpdates {
+			if exchCfg.Features.Supports.RESTCapabilities.AutoPairUpdates {
+				exchCfg.Features.Enabled.AutoPairUpdates = false
+			}
+		}
+	}
+	if bot.Settings.HTTPUserAgent != "" {
+		exchCfg.HTTPUserAgent = bot.Settings.HTTPUserAgent
+	}
+	if bot.Settings.HTTPProxy != "" {
+		exchCfg.ProxyAddress = bot.Settings.HTTPProxy
+	}
+	if bot.Settings.HTTPTimeout != exchange.DefaultHTTPTimeout {
+		exchCfg.HTTPTimeout = bot.Settings.HTTPTimeout
+	}
+	if bot.Settings.EnableExchangeHTTPDebugging {
+		exchCfg.HTTPDebugging = bot.Settings.EnableExchangeHTTPDebugging
+	}
+}
+
+// getSyncManagerConfig returns the sync manager config with the command line
+// settings applied
+func (bot *Engine) getSyncManagerConfig() config.SyncManagerConfig {
+	cfg := bot.Config.SyncManagerConfig
+	cfg.SynchronizeTicker = bot.Settings.EnableTickerSyncing
+	cfg.SynchronizeOrderbook = bot.Settings.EnableOrderbookSyncing
+	cfg.SynchronizeContinuously = bot.Settings.SyncContinuously
+	cfg.SynchronizeTrades = bot.Settings.EnableTradeSyncing
+	cfg.Verbose = bot.Settings.Verbose || cfg.Verbose
+
+	if cfg.TimeoutREST != bot.Settings.SyncTimeoutREST &&
+		bot.Settings.SyncTimeoutREST != config.DefaultSyncerTimeoutREST {
+		cfg.TimeoutREST = bot.Settings.SyncTimeoutREST
+	}
+	if cfg.TimeoutWebsocket != bot.Settings.SyncTimeoutWebsocket &&
+		bot.Settings.SyncTimeoutWebsocket != config.DefaultSyncerTimeoutWebsocket {
+		cfg.TimeoutWebsocket = bot.Settings.SyncTimeoutWebsocket
+	}
+	if cfg.NumWorkers != bot.Settings.SyncWorkersCount &&
+		bot.Settings.SyncWorkersCount != config.DefaultSyncerWorkers {
+		cfg.NumWorkers = bot.Settings.SyncWorkersCount
+	}
+	return cfg
+}
+
 func (bot *Engine) dryRunParamInteraction(param string) {
 	if !bot.Settings.CheckParamInteraction {
 		return
@@ -958,6 +1184,76 @@ func (bot *Engine) RegisterWebsocketDataHandler(fn WebsocketDataHandler, interce
 	return bot.WebsocketRoutineManager.registerWebsocketDataHandler(fn, interceptorOnly)
 }
 
+// setupCandleBuilder creates the candle builder and registers it to receive
+// trades from the websocket routine manager
+func (bot *Engine) setupCandleBuilder() error {
+	c, err := SetupCandleBuilder(bot.ExchangeManager, bot.DatabaseManager, &bot.Config.CandleBuilder)
+	if err != nil {
+		return err
+	}
+	if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(c.websocketDataHandler, false); err != nil {
+		return fmt.Errorf("%s requires the websocket routine manager: %w", CandleBuilderName, err)
+	}
+	bot.candleBuilder = c
+	return nil
+}
+
+// setupEventJournal creates the event journal and registers it to receive
+// events from the websocket routine manager
+func (bot *Engine) setupEventJournal() error {
+	j, err := SetupEventJournal(bot.ExchangeManager, &bot.Config.EventJournal, bot.Settings.DataDir)
+	if err != nil {
+		return err
+	}
+	if err = bot.WebsocketRoutineManager.registerWebsocketDataHandler(j.websocketDataHandler, false); err != nil {
+		return fmt.Errorf("%s requires the websocket routine manager: %w", EventJournalName, err)
+	}
+	bot.eventJournal = j
+	return nil
+}
+
+// setupAccountingManager creates the accounting manager which books fills and
+// positions from the order manager
+func (bot *Engine) setupAccountingManager() error {
+	if bot.OrderManager == nil {
+		return fmt.Errorf("%s requires the order manager: %w", AccountingManagerName, ErrNilSubsystem)
+	}
+	a, err := SetupAccountingManager(bot.ExchangeManager, bot.OrderManager, bot.DatabaseManager, &bot.Config.Accounting)
+	if err != nil {
+		return err
+	}
+	bot.accountingManager = a
+	return nil
+}
+
+// setupLedgerManager creates the ledger manager which imports exchange
+// history and reconciles it against exchange balances
+func (bot *Engine) setupLedgerManager() error {
+	if bot.ExchangeManager == nil {
+		return fmt.Errorf("%s requires the exchange manager: %w", LedgerManagerName, ErrNilSubsystem)
+	}
+	l, err := SetupLedgerManager(bot.ExchangeManager, bot.DatabaseManager, bot.CommunicationsManager, &bot.Config.Ledger)
+	if err != nil {
+		return err
+	}
+	bot.ledgerManager = l
+	return nil
+}
+
+// setupRebalancer creates the rebalancer which trades holdings towards target
+// weights through the order manager
+func (bot *Engine) setupRebalancer() error {
+	if bot.OrderManager == nil {
+		return fmt.Errorf("%s requires the order manager: %w", RebalancerName, ErrNilSubsystem)
+	}
+	r, err := SetupRebalancer(bot.ExchangeManager, bot.OrderManager, bot.portfolioManager, &bot.Config.Rebalancer, bot.Settings.EnableDryRun)
+	if err != nil {
+		return err
+	}
+	bot.rebalancer = r
+	return nil
+}
+
 // SetDefaultWebsocketDataHandler sets the default websocket handler and
 // removing all pre-existing handlers
 func (bot *Engine) SetDefaultWebsocketDataHandler() error {
@@ -974,3 +1270,15 @@ func (bot *Engine) waitForGPRCShutdown() {
 	gctlog.Warnln(gctlog.Global, "Captured gRPC shutdown request.")
 	bot.Settings.Shutdown <- struct{}{}
 }
+
+// getOrderManager returns the order manager websocket API subscriptions
+// stream order, fill and position updates from
+func (bot *Engine) getOrderManager() *OrderManager {
+	return bot.OrderManager
+}
+
+// getWebsocketRoutineManager returns the websocket routine manager websocket
+// API subscriptions stream trades from
+func (bot *Engine) getWebsocketRoutineManager() *WebsocketRoutineManager {
+	return bot.WebsocketRoutineManager
+}
@@ -37,6 +37,14 @@ type CoreSettings struct {
 	EnableCoinmarketcapAnalysis bool
 	EnablePortfolioManager      bool
 	EnableDataHistoryManager    bool
+	EnableDataRetentionManager  bool
+	EnableFundingRateScanner    bool
+	EnableBasisService          bool
+	EnableCandleBuilder         bool
+	EnableEventJournal          bool
+	EnableAccountingManager     bool
+	EnableLedgerManager         bool
+	EnableRebalancer            bool
 	PortfolioManagerDelay       time.Duration
 	EnableGRPC                  bool
 	EnableGRPCProxy             bool
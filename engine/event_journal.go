@@ -0,0 +1,276 @@
+package engine
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/journal"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupEventJournal creates an event journal subsystem. Events are written to
+// the configured directory, or a journal folder within the data directory
+func SetupEventJournal(exchangeManager iExchangeManager, cfg *config.EventJournal, dataDir string) (*EventJournal, error) {
+	if exchangeManager == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	directory := cfg.Directory
+	if directory == "" {
+		directory = filepath.Join(dataDir, eventJournalDirectory)
+	}
+	kinds := make(map[journal.Kind]bool)
+	for i := range cfg.Kinds {
+		k, err := journal.KindFromString(strings.ToLower(cfg.Kinds[i]))
+		if err != nil {
+			return nil, fmt.Errorf("%s %w", EventJournalName, err)
+		}
+		kinds[k] = true
+	}
+	if len(kinds) == 0 {
+		for _, k := range []journal.Kind{journal.KindTicker, journal.KindOrderbook, journal.KindTrade,
+			journal.KindOrder, journal.KindFill, journal.KindKline} {
+			kinds[k] = true
+		}
+	}
+	flushInterval := cfg.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = time.Second
+	}
+	return &EventJournal{
+		writerConfig: journal.WriterConfig{
+			Directory:      directory,
+			MaxSegmentSize: cfg.MaxSegmentSize,
+			MaxSegmentAge:  cfg.MaxSegmentAge,
+			MaxAge:         cfg.MaxAge,
+			MaxTotalSize:   cfg.MaxTotalSize,
+		},
+		kinds:           kinds,
+		flushInterval:   flushInterval,
+		exchangeManager: exchangeManager,
+	}, nil
+}
+
+// Start runs the subsystem
+func (j *EventJournal) Start() error {
+	if j == nil {
+		return fmt.Errorf("%s %w", EventJournalName, ErrNilSubsystem)
+	}
+	j.m.Lock()
+	defer j.m.Unlock()
+	if !atomic.CompareAndSwapInt32(&j.started, 0, 1) {
+		return fmt.Errorf("%s %w", EventJournalName, ErrSubSystemAlreadyStarted)
+	}
+	w, err := journal.NewWriter(j.writerConfig)
+	if err != nil {
+		atomic.StoreInt32(&j.started, 0)
+		return fmt.Errorf("%s %w", EventJournalName, err)
+	}
+	j.writer = w
+	j.pipes = make(map[string]map[journal.Kind]dispatch.Pipe)
+	j.shutdown = make(chan struct{})
+	j.wg.Add(1)
+	go j.run()
+	log.Debugf(log.DispatchMgr, "Event journal %s, writing to %s", MsgSubSystemStarted, j.writerConfig.Directory)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (j *EventJournal) IsRunning() bool {
+	if j == nil {
+		return false
+	}
+	return atomic.LoadInt32(&j.started) == 1
+}
+
+// Stop stops the subsystem, releasing its dispatch subscriptions and
+// flushing and closing the journal
+func (j *EventJournal) Stop() error {
+	if j == nil {
+		return fmt.Errorf("%s %w", EventJournalName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&j.started, 1, 0) {
+		return fmt.Errorf("%s %w", EventJournalName, ErrSubSystemNotStarted)
+	}
+	close(j.shutdown)
+	j.wg.Wait()
+	j.m.Lock()
+	defer j.m.Unlock()
+	var errs error
+	for _, pipes := range j.pipes {
+		for _, pipe := range pipes {
+			errs = common.AppendError(errs, pipe.Release())
+		}
+	}
+	j.pipes = nil
+	errs = common.AppendError(errs, j.writer.Close())
+	j.writer = nil
+	if errs != nil {
+		return fmt.Errorf("%s %w", EventJournalName, errs)
+	}
+	log.Debugf(log.DispatchMgr, "Event journal %s", MsgSubSystemShutdown)
+	return nil
+}
+
+// run flushes the journal and subscribes to the dispatch feeds of exchanges
+// as they become available
+func (j *EventJournal) run() {
+	defer j.wg.Done()
+	t := time.NewTicker(j.flushInterval)
+	defer t.Stop()
+	j.subscribeExchanges()
+	for {
+		select {
+		case <-j.shutdown:
+			return
+		case <-t.C:
+			j.m.RLock()
+			err := j.writer.Flush()
+			j.m.RUnlock()
+			if err != nil {
+				log.Errorf(log.DispatchMgr, "%s flush error: %v", EventJournalName, err)
+			}
+			j.subscribeExchanges()
+		}
+	}
+}
+
+// subscribeExchanges subscribes to the ticker and orderbook dispatch feeds of
+// every loaded exchange. An exchange's feed only exists once it has processed
+// its first ticker or orderbook, so exchanges not yet subscribed are retried
+// on every flush
+func (j *EventJournal) subscribeExchanges() {
+	exchanges, err := j.exchangeManager.GetExchanges()
+	if err != nil {
+		return
+	}
+	j.m.Lock()
+	defer j.m.Unlock()
+	for i := range exchanges {
+		name := strings.ToLower(exchanges[i].GetName())
+		pipes, ok := j.pipes[name]
+		if !ok {
+			pipes = make(map[journal.Kind]dispatch.Pipe)
+			j.pipes[name] = pipes
+		}
+		for _, k := range []journal.Kind{journal.KindTicker, journal.KindOrderbook} {
+			if _, ok = pipes[k]; ok || !j.kinds[k] {
+				continue
+			}
+			var pipe dispatch.Pipe
+			if k == journal.KindTicker {
+				pipe, err = ticker.SubscribeToExchangeTickers(name)
+			} else {
+				pipe, err = orderbook.SubscribeToExchangeOrderbooks(name)
+			}
+			if err != nil {
+				continue
+			}
+			pipes[k] = pipe
+			j.wg.Add(1)
+			go j.readPipe(pipe)
+		}
+	}
+}
+
+// readPipe records events received from a dispatch subscription
+func (j *EventJournal) readPipe(pipe dispatch.Pipe) {
+	defer j.wg.Done()
+	for {
+		select {
+		case <-j.shutdown:
+			return
+		case data, ok := <-pipe.Channel():
+			if !ok {
+				return
+			}
+			if err := j.record(time.Now(), data); err != nil {
+				log.Errorf(log.DispatchMgr, "%s %v", EventJournalName, err)
+			}
+		}
+	}
+}
+
+// websocketDataHandler records trades, orders, fills and klines received
+// from the websocket routine manager. Tickers and orderbooks are ignored as
+// they are recorded from dispatch
+func (j *EventJournal) websocketDataHandler(_ string, data interface{}) error {
+	if !j.IsRunning() {
+		return nil
+	}
+	switch data.(type) {
+	case *ticker.Price, []ticker.Price, *orderbook.Depth:
+		return nil
+	}
+	return j.record(time.Now(), data)
+}
+
+// record writes the supported events held by data to the journal
+func (j *EventJournal) record(t time.Time, data interface{}) error {
+	var errs error
+	switch d := data.(type) {
+	case *ticker.Price:
+		return j.write(t, d)
+	case *orderbook.Depth:
+		if !j.kinds[journal.KindOrderbook] {
+			return nil
+		}
+		base, err := d.Retrieve()
+		if err != nil {
+			return err
+		}
+		return j.write(t, base)
+	case []trade.Data:
+		for i := range d {
+			errs = common.AppendError(errs, j.write(t, &d[i]))
+		}
+	case *order.Detail:
+		return j.write(t, d)
+	case []order.Detail:
+		for i := range d {
+			errs = common.AppendError(errs, j.write(t, &d[i]))
+		}
+	case []fill.Data:
+		for i := range d {
+			errs = common.AppendError(errs, j.write(t, &d[i]))
+		}
+	case stream.KlineData:
+		return j.write(t, &d)
+	case []stream.KlineData:
+		for i := range d {
+			errs = common.AppendError(errs, j.write(t, &d[i]))
+		}
+	}
+	return errs
+}
+
+// write appends an event to the journal when its kind is recorded
+func (j *EventJournal) write(t time.Time, data interface{}) error {
+	k, err := journal.KindOf(data)
+	if err != nil {
+		return err
+	}
+	if !j.kinds[k] {
+		return nil
+	}
+	j.m.RLock()
+	defer j.m.RUnlock()
+	if j.writer == nil {
+		return nil
+	}
+	return j.writer.Write(t, data)
+}
@@ -0,0 +1,160 @@
+package engine
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+	"github.com/thrasher-corp/gocryptotrader/journal"
+)
+
+func TestSetupEventJournal(t *testing.T) {
+	t.Parallel()
+	_, err := SetupEventJournal(nil, nil, "")
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupEventJournal(NewExchangeManager(), nil, "")
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = SetupEventJournal(NewExchangeManager(), &config.EventJournal{Kinds: []string{"candle"}}, "")
+	if err == nil {
+		t.Error("expected an error for an unknown kind")
+	}
+	j, err := SetupEventJournal(NewExchangeManager(), &config.EventJournal{}, "data")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if j.writerConfig.Directory != filepath.Join("data", eventJournalDirectory) {
+		t.Errorf("received '%v', expected '%v'", j.writerConfig.Directory, filepath.Join("data", eventJournalDirectory))
+	}
+	if len(j.kinds) != 6 {
+		t.Errorf("received '%v', expected '%v'", len(j.kinds), 6)
+	}
+}
+
+func TestEventJournalStartStop(t *testing.T) {
+	t.Parallel()
+	var j *EventJournal
+	if j.IsRunning() {
+		t.Error("nil journal should not be running")
+	}
+	err := j.Start()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	err = j.Stop()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	j, err = SetupEventJournal(NewExchangeManager(), &config.EventJournal{Directory: t.TempDir()}, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = j.Stop()
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	err = j.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = j.Start()
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	err = j.Stop()
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestEventJournalRecord(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	j, err := SetupEventJournal(NewExchangeManager(), &config.EventJournal{
+		Directory: dir,
+		Kinds:     []string{"ticker", "orderbook", "trade", "order"},
+	}, "")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	p := currency.NewPair(currency.BTC, currency.USDT)
+	trades := []trade.Data{{Exchange: "fake", CurrencyPair: p, AssetType: asset.Spot, Price: 1, Amount: 1, Timestamp: time.Now()}}
+
+	// nothing is recorded until started
+	err = j.websocketDataHandler("fake", trades)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = j.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for _, data := range []interface{}{
+		trades,
+		[]order.Detail{{Exchange: "fake", Pair: p, AssetType: asset.Spot, OrderID: "1"}},
+		// fills are not a configured kind
+		[]fill.Data{{Exchange: "fake", CurrencyPair: p, AssetType: asset.Spot}},
+		// tickers are recorded from dispatch rather than the websocket
+		&ticker.Price{ExchangeName: "fake", Pair: p, AssetType: asset.Spot, Last: 1},
+		"unhandled",
+	} {
+		err = j.websocketDataHandler("fake", data)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+	}
+	err = j.record(time.Now(), &ticker.Price{ExchangeName: "fake", Pair: p, AssetType: asset.Spot, Last: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	depth := orderbook.NewDepth([16]byte{1})
+	depth.AssignOptions(&orderbook.Base{Exchange: "fake", Pair: p, Asset: asset.Spot})
+	depth.LoadSnapshot(orderbook.Items{{Price: 1, Amount: 1}}, orderbook.Items{{Price: 2, Amount: 1}}, 1, time.Now(), true)
+	err = j.record(time.Now(), depth)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = j.Stop()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	r, err := journal.NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	var kinds []journal.Kind
+	var rec *journal.Record
+	for {
+		rec, err = r.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		kinds = append(kinds, rec.Kind)
+	}
+	expected := []journal.Kind{journal.KindTrade, journal.KindOrder, journal.KindTicker, journal.KindOrderbook}
+	if len(kinds) != len(expected) {
+		t.Fatalf("received '%v', expected '%v'", kinds, expected)
+	}
+	for i := range expected {
+		if kinds[i] != expected[i] {
+			t.Errorf("received '%v', expected '%v'", kinds[i], expected[i])
+		}
+	}
+}
@@ -0,0 +1,36 @@
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/journal"
+)
+
+// EventJournalName is an exported subsystem name
+const EventJournalName = "event_journal"
+
+// eventJournalDirectory is the journal directory within the data directory
+// when one is not configured
+const eventJournalDirectory = "journal"
+
+// EventJournal records market data and order events to an append-only on
+// disk journal so they can be replayed. Tickers and orderbooks are recorded
+// from dispatch so updates from both REST and websocket connections are
+// captured, trades, orders, fills and klines are recorded from the websocket
+// data handler
+type EventJournal struct {
+	started         int32
+	writerConfig    journal.WriterConfig
+	kinds           map[journal.Kind]bool
+	flushInterval   time.Duration
+	exchangeManager iExchangeManager
+	writer          *journal.Writer
+	// pipes holds the dispatch subscriptions of each exchange, keyed by
+	// exchange name then kind
+	pipes    map[string]map[journal.Kind]dispatch.Pipe
+	shutdown chan struct{}
+	wg       sync.WaitGroup
+	m        sync.RWMutex
+}
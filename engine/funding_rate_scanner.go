@@ -0,0 +1,316 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupFundingRateScanner creates a funding rate scanner subsystem
+func SetupFundingRateScanner(em iExchangeManager, cfg *config.FundingRateScanner) (*FundingRateScanner, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	interval := cfg.CheckInterval
+	if interval <= 0 {
+		interval = time.Minute * 5
+	}
+	holdingPeriod := cfg.HoldingPeriod
+	if holdingPeriod <= 0 {
+		holdingPeriod = time.Hour * 24 * 7
+	}
+	takerFees := make(map[string]decimal.Decimal, len(cfg.ExchangeTakerFees))
+	for k, v := range cfg.ExchangeTakerFees {
+		takerFees[strings.ToLower(k)] = decimal.NewFromFloat(v)
+	}
+	return &FundingRateScanner{
+		exchangeManager:         em,
+		shutdown:                make(chan struct{}),
+		interval:                interval,
+		holdingPeriod:           holdingPeriod,
+		minimumAnnualisedSpread: decimal.NewFromFloat(cfg.MinimumAnnualisedSpread),
+		defaultTakerFee:         decimal.NewFromFloat(cfg.DefaultTakerFee),
+		takerFees:               takerFees,
+		verbose:                 cfg.Verbose,
+	}, nil
+}
+
+// Start runs the subsystem
+func (f *FundingRateScanner) Start() error {
+	if f == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&f.started, 0, 1) {
+		return ErrSubSystemAlreadyStarted
+	}
+	f.shutdown = make(chan struct{})
+	f.wg.Add(1)
+	go f.run()
+	log.Debugf(log.Global, "Funding rate scanner %s", MsgSubSystemStarted)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (f *FundingRateScanner) IsRunning() bool {
+	if f == nil {
+		return false
+	}
+	return atomic.LoadInt32(&f.started) == 1
+}
+
+// Stop stops the subsystem
+func (f *FundingRateScanner) Stop() error {
+	if f == nil {
+		return ErrNilSubsystem
+	}
+	if !atomic.CompareAndSwapInt32(&f.started, 1, 0) {
+		return ErrSubSystemNotStarted
+	}
+	close(f.shutdown)
+	f.wg.Wait()
+	log.Debugf(log.Global, "Funding rate scanner %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (f *FundingRateScanner) run() {
+	defer f.wg.Done()
+	t := time.NewTicker(f.interval)
+	defer t.Stop()
+	for {
+		if _, err := f.Scan(context.TODO()); err != nil && !errors.Is(err, errAlreadyRunning) {
+			log.Errorf(log.Global, "Funding rate scanner: %v", err)
+		}
+		select {
+		case <-f.shutdown:
+			return
+		case <-t.C:
+		}
+	}
+}
+
+// LastScan returns the results of the most recent scan
+func (f *FundingRateScanner) LastScan() (*FundingRateScan, error) {
+	if f == nil {
+		return nil, ErrNilSubsystem
+	}
+	f.m.Lock()
+	defer f.m.Unlock()
+	if f.lastScan == nil {
+		return nil, errNoFundingRateScan
+	}
+	scan := *f.lastScan
+	scan.Rates = append([]FundingRateSnapshot(nil), f.lastScan.Rates...)
+	scan.Opportunities = append([]FundingRateOpportunity(nil), f.lastScan.Opportunities...)
+	scan.Errors = append([]string(nil), f.lastScan.Errors...)
+	return &scan, nil
+}
+
+// Scan retrieves the latest funding rates of every enabled perpetual pair and
+// returns any opportunities which meet the minimum net annualised spread
+func (f *FundingRateScanner) Scan(ctx context.Context) (*FundingRateScan, error) {
+	if f == nil {
+		return nil, ErrNilSubsystem
+	}
+	if atomic.LoadInt32(&f.started) == 0 {
+		return nil, ErrSubSystemNotStarted
+	}
+	if !atomic.CompareAndSwapInt32(&f.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot scan funding rates, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&f.processing, 0)
+
+	exchanges, err := f.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	scan := &FundingRateScan{Time: time.Now().UTC()}
+	var wg sync.WaitGroup
+	var mtx sync.Mutex
+	for i := range exchanges {
+		wg.Add(1)
+		go func(exch exchange.IBotExchange) {
+			defer wg.Done()
+			rates, errs := f.getExchangeRates(ctx, exch)
+			mtx.Lock()
+			scan.Rates = append(scan.Rates, rates...)
+			scan.Errors = append(scan.Errors, errs...)
+			mtx.Unlock()
+		}(exchanges[i])
+	}
+	wg.Wait()
+	sort.Slice(scan.Rates, func(i, j int) bool {
+		if scan.Rates[i].Exchange != scan.Rates[j].Exchange {
+			return scan.Rates[i].Exchange < scan.Rates[j].Exchange
+		}
+		return scan.Rates[i].Pair.String() < scan.Rates[j].Pair.String()
+	})
+	sort.Strings(scan.Errors)
+	scan.Opportunities = f.findOpportunities(scan.Rates)
+
+	if f.verbose {
+		log.Debugf(log.Global, "Funding rate scanner retrieved %d rates and found %d opportunities", len(scan.Rates), len(scan.Opportunities))
+		for i := range scan.Errors {
+			log.Warnf(log.Global, "Funding rate scanner: %s", scan.Errors[i])
+		}
+	}
+	f.m.Lock()
+	f.lastScan = scan
+	f.m.Unlock()
+	return f.LastScan()
+}
+
+// getExchangeRates returns the latest funding rate of every enabled perpetual
+// pair of an exchange. Exchanges which do not publish their funding rate
+// frequency are skipped as their rates cannot be annualised
+func (f *FundingRateScanner) getExchangeRates(ctx context.Context, exch exchange.IBotExchange) ([]FundingRateSnapshot, []string) {
+	b := exch.GetBase()
+	frequency := b.Features.Supports.FuturesCapabilities.FundingRateFrequency
+	if frequency <= 0 {
+		return nil, nil
+	}
+	var resp []FundingRateSnapshot
+	var errs []string
+	assets := exch.GetAssetTypes(true)
+	for i := range assets {
+		if !assets[i].IsFutures() {
+			continue
+		}
+		pairs, err := exch.GetEnabledPairs(assets[i])
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s %s: %v", exch.GetName(), assets[i], err))
+			continue
+		}
+		for j := range pairs {
+			isPerp, err := exch.IsPerpetualFutureCurrency(assets[i], pairs[j])
+			if err != nil || !isPerp {
+				continue
+			}
+			req := &fundingrate.LatestRateRequest{
+				Asset:                assets[i],
+				Pair:                 pairs[j],
+				IncludePredictedRate: true,
+			}
+			rate, err := exch.GetLatestFundingRate(ctx, req)
+			if errors.Is(err, common.ErrFunctionNotSupported) {
+				req.IncludePredictedRate = false
+				rate, err = exch.GetLatestFundingRate(ctx, req)
+			}
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s %s %s: %v", exch.GetName(), assets[i], pairs[j], err))
+				continue
+			}
+			resp = append(resp, FundingRateSnapshot{
+				Exchange:         exch.GetName(),
+				Asset:            assets[i],
+				Pair:             pairs[j],
+				Underlying:       fundingRateUnderlying(pairs[j]),
+				Frequency:        frequency,
+				CurrentRate:      rate.LatestRate.Rate,
+				PredictedRate:    rate.PredictedUpcomingRate.Rate,
+				HasPredictedRate: req.IncludePredictedRate && !rate.PredictedUpcomingRate.Time.IsZero(),
+				TimeOfNextRate:   rate.TimeOfNextRate,
+				TakerFee:         f.takerFee(exch.GetName()),
+			})
+		}
+	}
+	return resp, errs
+}
+
+// findOpportunities compares every pair of rates for the same underlying on
+// different exchanges and returns those meeting the minimum net annualised
+// spread, best first
+func (f *FundingRateScanner) findOpportunities(rates []FundingRateSnapshot) []FundingRateOpportunity {
+	var resp []FundingRateOpportunity
+	for i := range rates {
+		for j := i + 1; j < len(rates); j++ {
+			if rates[i].Exchange == rates[j].Exchange ||
+				!rates[i].Underlying.Equal(rates[j].Underlying) {
+				continue
+			}
+			o := f.calculateOpportunity(&rates[i], &rates[j])
+			if o.NetAnnualisedSpread.LessThan(f.minimumAnnualisedSpread) &&
+				(!o.HasPredictedSpread || o.PredictedNetAnnualisedSpread.LessThan(f.minimumAnnualisedSpread)) {
+				continue
+			}
+			resp = append(resp, o)
+		}
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].NetAnnualisedSpread.GreaterThan(resp[j].NetAnnualisedSpread)
+	})
+	return resp
+}
+
+// calculateOpportunity shorts the perpetual with the higher annualised current
+// rate and longs the other
+func (f *FundingRateScanner) calculateOpportunity(a, b *FundingRateSnapshot) FundingRateOpportunity {
+	long, short := a, b
+	if annualiseFundingRate(a.CurrentRate, a.Frequency).GreaterThan(annualiseFundingRate(b.CurrentRate, b.Frequency)) {
+		long, short = b, a
+	}
+	o := FundingRateOpportunity{
+		Underlying: long.Underlying,
+		Long:       *long,
+		Short:      *short,
+		AnnualisedSpread: annualiseFundingRate(short.CurrentRate, short.Frequency).Sub(
+			annualiseFundingRate(long.CurrentRate, long.Frequency)),
+	}
+	// each leg is opened and closed once over the holding period
+	fees := long.TakerFee.Add(short.TakerFee).Mul(decimal.NewFromInt(2))
+	o.AnnualisedFees = fees.Mul(decimal.NewFromInt(int64(fundingRateYear))).Div(decimal.NewFromInt(int64(f.holdingPeriod)))
+	o.NetAnnualisedSpread = o.AnnualisedSpread.Sub(o.AnnualisedFees)
+	if long.HasPredictedRate && short.HasPredictedRate {
+		o.HasPredictedSpread = true
+		o.PredictedAnnualisedSpread = annualiseFundingRate(short.PredictedRate, short.Frequency).Sub(
+			annualiseFundingRate(long.PredictedRate, long.Frequency))
+		o.PredictedNetAnnualisedSpread = o.PredictedAnnualisedSpread.Sub(o.AnnualisedFees)
+	}
+	return o
+}
+
+func (f *FundingRateScanner) takerFee(exchName string) decimal.Decimal {
+	if fee, ok := f.takerFees[strings.ToLower(exchName)]; ok {
+		return fee
+	}
+	return f.defaultTakerFee
+}
+
+// annualiseFundingRate converts a rate paid every frequency into a yearly rate
+func annualiseFundingRate(rate decimal.Decimal, frequency time.Duration) decimal.Decimal {
+	if frequency <= 0 {
+		return decimal.Zero
+	}
+	return rate.Mul(decimal.NewFromInt(int64(fundingRateYear))).Div(decimal.NewFromInt(int64(frequency)))
+}
+
+// fundingRateUnderlying returns the underlying currency of a perpetual.
+// Perpetuals quoted in PERP, eg BTCUSD-PERP, hold their settlement currency
+// in the base
+func fundingRateUnderlying(cp currency.Pair) currency.Code {
+	if !cp.Quote.Equal(currency.PERP) {
+		return cp.Base
+	}
+	base := cp.Base.Upper().String()
+	for _, settlement := range []string{"USDT", "USDC", "BUSD", "USD"} {
+		if len(base) > len(settlement) && strings.HasSuffix(base, settlement) {
+			return currency.NewCode(strings.TrimSuffix(base, settlement))
+		}
+	}
+	return cp.Base
+}
@@ -0,0 +1,242 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+)
+
+// frsExchange aka funding rate scanner fake exchange returns fixed funding
+// rates under a custom name
+type frsExchange struct {
+	exchange.IBotExchange
+	name              string
+	rate              decimal.Decimal
+	predicted         decimal.Decimal
+	supportsPredicted bool
+}
+
+func (f *frsExchange) GetName() string {
+	return f.name
+}
+
+func (f *frsExchange) GetLatestFundingRate(_ context.Context, r *fundingrate.LatestRateRequest) (*fundingrate.LatestRateResponse, error) {
+	if r.IncludePredictedRate && !f.supportsPredicted {
+		return nil, fmt.Errorf("%w IncludePredictedRate", common.ErrFunctionNotSupported)
+	}
+	resp := &fundingrate.LatestRateResponse{
+		Exchange:   f.name,
+		Asset:      r.Asset,
+		Pair:       r.Pair,
+		LatestRate: fundingrate.Rate{Time: time.Now(), Rate: f.rate},
+	}
+	if r.IncludePredictedRate {
+		resp.PredictedUpcomingRate = fundingrate.Rate{Time: time.Now().Add(time.Hour * 8), Rate: f.predicted}
+	}
+	return resp, nil
+}
+
+func addFundingRateScannerExchange(t *testing.T, em *ExchangeManager, f *frsExchange) {
+	t.Helper()
+	exch, err := em.NewExchangeByName("Binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.USDTMarginedFutures: {
+			Available:     currency.Pairs{cp},
+			Enabled:       currency.Pairs{cp},
+			AssetEnabled:  convert.BoolPtr(true),
+			ConfigFormat:  &currency.PairFormat{Uppercase: true},
+			RequestFormat: &currency.PairFormat{Uppercase: true},
+		},
+	}
+	f.IBotExchange = exch
+	if err = em.Add(f); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestSetupFundingRateScanner(t *testing.T) {
+	t.Parallel()
+	_, err := SetupFundingRateScanner(nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupFundingRateScanner(NewExchangeManager(), nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	f, err := SetupFundingRateScanner(NewExchangeManager(), &config.FundingRateScanner{
+		ExchangeTakerFees: map[string]float64{"BiNaNcE": 0.0004},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if f.interval <= 0 || f.holdingPeriod <= 0 {
+		t.Error("expected default interval and holding period")
+	}
+	if !f.takerFee("binance").Equal(decimal.NewFromFloat(0.0004)) {
+		t.Errorf("received '%v', expected '%v'", f.takerFee("binance"), 0.0004)
+	}
+}
+
+func TestFundingRateScannerStartStop(t *testing.T) {
+	t.Parallel()
+	var f *FundingRateScanner
+	if err := f.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := f.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if f.IsRunning() {
+		t.Error("expected false")
+	}
+	f, err := SetupFundingRateScanner(NewExchangeManager(), &config.FundingRateScanner{CheckInterval: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = f.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if err = f.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = f.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !f.IsRunning() {
+		t.Error("expected true")
+	}
+	if err = f.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestFundingRateScannerScan(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	addFundingRateScannerExchange(t, em, &frsExchange{
+		name:              "high",
+		rate:              decimal.NewFromFloat(0.001),
+		predicted:         decimal.NewFromFloat(0.0005),
+		supportsPredicted: true,
+	})
+	addFundingRateScannerExchange(t, em, &frsExchange{
+		name:              "low",
+		rate:              decimal.NewFromFloat(0.0001),
+		predicted:         decimal.NewFromFloat(0.0001),
+		supportsPredicted: true,
+	})
+	addFundingRateScannerExchange(t, em, &frsExchange{
+		name: "nopredicted",
+		rate: decimal.NewFromFloat(0.0009),
+	})
+	f, err := SetupFundingRateScanner(em, &config.FundingRateScanner{
+		CheckInterval:           time.Hour,
+		HoldingPeriod:           fundingRateYear,
+		MinimumAnnualisedSpread: 0.5,
+		DefaultTakerFee:         0.0005,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	_, err = f.LastScan()
+	if !errors.Is(err, errNoFundingRateScan) {
+		t.Errorf("received '%v', expected '%v'", err, errNoFundingRateScan)
+	}
+	_, err = f.Scan(context.Background())
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	f.started = 1
+	scan, err := f.Scan(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(scan.Errors) != 0 {
+		t.Errorf("received '%v', expected no errors", scan.Errors)
+	}
+	if len(scan.Rates) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(scan.Rates), 3)
+	}
+	// high vs low is (0.001-0.0001)*3*365 = 0.9855 and high vs nopredicted
+	// is 0.1095, both less 0.002 in fees
+	if len(scan.Opportunities) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(scan.Opportunities), 2)
+	}
+	o := scan.Opportunities[0]
+	if o.Short.Exchange != "high" || o.Long.Exchange != "low" {
+		t.Errorf("received short '%v' long '%v', expected short 'high' long 'low'", o.Short.Exchange, o.Long.Exchange)
+	}
+	if expected := decimal.NewFromFloat(0.9855); !o.AnnualisedSpread.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", o.AnnualisedSpread, expected)
+	}
+	if expected := decimal.NewFromFloat(0.002); !o.AnnualisedFees.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", o.AnnualisedFees, expected)
+	}
+	if expected := decimal.NewFromFloat(0.9835); !o.NetAnnualisedSpread.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", o.NetAnnualisedSpread, expected)
+	}
+	if !o.HasPredictedSpread {
+		t.Fatal("expected predicted spread")
+	}
+	if expected := decimal.NewFromFloat(0.4360); !o.PredictedNetAnnualisedSpread.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", o.PredictedNetAnnualisedSpread, expected)
+	}
+	if o2 := scan.Opportunities[1]; o2.Short.Exchange != "nopredicted" || o2.HasPredictedSpread {
+		t.Errorf("received '%+v', expected nopredicted short without a predicted spread", o2)
+	}
+
+	last, err := f.LastScan()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(last.Opportunities) != 2 {
+		t.Errorf("received '%v', expected '%v'", len(last.Opportunities), 2)
+	}
+}
+
+func TestAnnualiseFundingRate(t *testing.T) {
+	t.Parallel()
+	r := annualiseFundingRate(decimal.NewFromFloat(0.0001), time.Hour*8)
+	if expected := decimal.NewFromFloat(0.1095); !r.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", r, expected)
+	}
+	if r = annualiseFundingRate(decimal.NewFromFloat(0.0001), 0); !r.IsZero() {
+		t.Errorf("received '%v', expected '%v'", r, 0)
+	}
+}
+
+func TestFundingRateUnderlying(t *testing.T) {
+	t.Parallel()
+	testCases := []struct {
+		pair     currency.Pair
+		expected currency.Code
+	}{
+		{currency.NewPair(currency.BTC, currency.USDT), currency.BTC},
+		{currency.NewPair(currency.NewCode("BTCUSD"), currency.PERP), currency.BTC},
+		{currency.NewPair(currency.NewCode("ETHUSDT"), currency.PERP), currency.ETH},
+		{currency.NewPair(currency.USD, currency.PERP), currency.USD},
+	}
+	for i := range testCases {
+		if c := fundingRateUnderlying(testCases[i].pair); !c.Equal(testCases[i].expected) {
+			t.Errorf("received '%v', expected '%v'", c, testCases[i].expected)
+		}
+	}
+}
@@ -0,0 +1,81 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// FundingRateScannerName is an exported subsystem name
+const FundingRateScannerName = "funding_rate_scanner"
+
+// fundingRateYear is the period funding rate spreads are annualised over
+const fundingRateYear = time.Hour * 24 * 365
+
+var errNoFundingRateScan = errors.New("funding rate scan has not been run")
+
+// FundingRateScanner periodically retrieves the current and predicted funding
+// rates of every enabled perpetual pair and compares rates for the same
+// underlying across exchanges. Spreads are annualised and reduced by the
+// taker fees needed to open and close both legs
+type FundingRateScanner struct {
+	started                 int32
+	processing              int32
+	shutdown                chan struct{}
+	wg                      sync.WaitGroup
+	m                       sync.Mutex
+	exchangeManager         iExchangeManager
+	interval                time.Duration
+	holdingPeriod           time.Duration
+	minimumAnnualisedSpread decimal.Decimal
+	defaultTakerFee         decimal.Decimal
+	takerFees               map[string]decimal.Decimal
+	verbose                 bool
+	lastScan                *FundingRateScan
+}
+
+// FundingRateScan holds the results of a single scan
+type FundingRateScan struct {
+	Time          time.Time
+	Rates         []FundingRateSnapshot
+	Opportunities []FundingRateOpportunity
+	Errors        []string
+}
+
+// FundingRateSnapshot is the funding rate of a perpetual pair at the time of
+// a scan
+type FundingRateSnapshot struct {
+	Exchange   string
+	Asset      asset.Item
+	Pair       currency.Pair
+	Underlying currency.Code
+	// Frequency is how often funding is paid, used to annualise rates
+	Frequency        time.Duration
+	CurrentRate      decimal.Decimal
+	PredictedRate    decimal.Decimal
+	HasPredictedRate bool
+	TimeOfNextRate   time.Time
+	TakerFee         decimal.Decimal
+}
+
+// FundingRateOpportunity pairs two perpetuals with the same underlying on
+// different exchanges. The short leg receives the higher funding rate and the
+// long leg pays the lower rate
+type FundingRateOpportunity struct {
+	Underlying currency.Code
+	Long       FundingRateSnapshot
+	Short      FundingRateSnapshot
+	// AnnualisedSpread is the difference of the annualised current rates
+	AnnualisedSpread decimal.Decimal
+	// AnnualisedFees is the cost of opening and closing both legs spread
+	// over the configured holding period and annualised
+	AnnualisedFees               decimal.Decimal
+	NetAnnualisedSpread          decimal.Decimal
+	PredictedAnnualisedSpread    decimal.Decimal
+	PredictedNetAnnualisedSpread decimal.Decimal
+	HasPredictedSpread           bool
+}
@@ -21,6 +21,7 @@ import (
 	"github.com/pquerna/otp/totp"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/dispatch"
@@ -60,7 +61,16 @@ func (bot *Engine) GetSubsystemsStatus() map[string]bool {
 		WebsocketName:                 bot.Settings.EnableWebsocketRPC,
 		dispatch.Name:                 dispatch.IsRunning(),
 		dataHistoryManagerName:        bot.dataHistoryManager.IsRunning(),
+		DataRetentionManagerName:      bot.dataRetentionManager.IsRunning(),
+		FundingRateScannerName:        bot.fundingRateScanner.IsRunning(),
+		BasisServiceName:              bot.basisService.IsRunning(),
+		CandleBuilderName:             bot.candleBuilder.IsRunning(),
+		EventJournalName:              bot.eventJournal.IsRunning(),
+		AccountingManagerName:         bot.accountingManager.IsRunning(),
+		LedgerManagerName:             bot.ledgerManager.IsRunning(),
+		RebalancerName:                bot.rebalancer.IsRunning(),
 		CurrencyStateManagementName:   bot.currencyStateManager.IsRunning(),
+		ConfigReloadManagerName:       bot.configReloadManager.IsRunning(),
 	}
 }
 
@@ -143,6 +153,12 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 				if err != nil {
 					return err
 				}
+				if bot.Config.OrderManager.MarginEngine.Enabled {
+					err = bot.OrderManager.SetupMarginEngine(&bot.Config.OrderManager.MarginEngine)
+					if err != nil {
+						return err
+					}
+				}
 			}
 			return bot.OrderManager.Start()
 		}
@@ -185,25 +201,7 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 	case SyncManagerName:
 		if enable {
 			if bot.currencyPairSyncer == nil {
-				cfg := bot.Config.SyncManagerConfig
-				cfg.SynchronizeTicker = bot.Settings.EnableTickerSyncing
-				cfg.SynchronizeOrderbook = bot.Settings.EnableOrderbookSyncing
-				cfg.SynchronizeContinuously = bot.Settings.SyncContinuously
-				cfg.SynchronizeTrades = bot.Settings.EnableTradeSyncing
-				cfg.Verbose = bot.Settings.Verbose || cfg.Verbose
-
-				if cfg.TimeoutREST != bot.Settings.SyncTimeoutREST &&
-					bot.Settings.SyncTimeoutREST != config.DefaultSyncerTimeoutREST {
-					cfg.TimeoutREST = bot.Settings.SyncTimeoutREST
-				}
-				if cfg.TimeoutWebsocket != bot.Settings.SyncTimeoutWebsocket &&
-					bot.Settings.SyncTimeoutWebsocket != config.DefaultSyncerTimeoutWebsocket {
-					cfg.TimeoutWebsocket = bot.Settings.SyncTimeoutWebsocket
-				}
-				if cfg.NumWorkers != bot.Settings.SyncWorkersCount &&
-					bot.Settings.SyncWorkersCount != config.DefaultSyncerWorkers {
-					cfg.NumWorkers = bot.Settings.SyncWorkersCount
-				}
+				cfg := bot.getSyncManagerConfig()
 				bot.currencyPairSyncer, err = setupSyncManager(
 					&cfg,
 					bot.ExchangeManager,
@@ -266,6 +264,94 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 			return bot.dataHistoryManager.Start()
 		}
 		return bot.dataHistoryManager.Stop()
+	case DataRetentionManagerName:
+		if enable {
+			if bot.dataRetentionManager == nil {
+				bot.dataRetentionManager, err = SetupDataRetentionManager(bot.DatabaseManager, &bot.Config.DataRetentionManager)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.dataRetentionManager.Start()
+		}
+		return bot.dataRetentionManager.Stop()
+	case FundingRateScannerName:
+		if enable {
+			if bot.fundingRateScanner == nil {
+				bot.fundingRateScanner, err = SetupFundingRateScanner(bot.ExchangeManager, &bot.Config.FundingRateScanner)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.fundingRateScanner.Start()
+		}
+		return bot.fundingRateScanner.Stop()
+	case BasisServiceName:
+		if enable {
+			if bot.basisService == nil {
+				bot.basisService, err = SetupBasisService(bot.ExchangeManager, &bot.Config.BasisService)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.basisService.Start()
+		}
+		return bot.basisService.Stop()
+	case CandleBuilderName:
+		if enable {
+			if bot.candleBuilder == nil {
+				err = bot.setupCandleBuilder()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.candleBuilder.Start()
+		}
+		return bot.candleBuilder.Stop()
+	case EventJournalName:
+		if enable {
+			if bot.eventJournal == nil {
+				err = bot.setupEventJournal()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.eventJournal.Start()
+		}
+		return bot.eventJournal.Stop()
+	case AccountingManagerName:
+		if enable {
+			if bot.accountingManager == nil {
+				err = bot.setupAccountingManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.accountingManager.Start()
+		}
+		return bot.accountingManager.Stop()
+	case LedgerManagerName:
+		if enable {
+			if bot.ledgerManager == nil {
+				err = bot.setupLedgerManager()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.ledgerManager.Start()
+		}
+		return bot.ledgerManager.Stop()
+	case RebalancerName:
+		if enable {
+			if bot.rebalancer == nil {
+				err = bot.setupRebalancer()
+				if err != nil {
+					return err
+				}
+			}
+			return bot.rebalancer.Start()
+		}
+		return bot.rebalancer.Stop()
 	case vm.Name:
 		if enable {
 			if bot.gctScriptManager == nil {
@@ -290,6 +376,22 @@ func (bot *Engine) SetSubsystem(subSystemName string, enable bool) error {
 			return bot.currencyStateManager.Start()
 		}
 		return bot.currencyStateManager.Stop()
+	case ConfigReloadManagerName:
+		if enable {
+			if bot.configReloadManager == nil {
+				var filePath string
+				filePath, _, err = config.GetFilePath(bot.Settings.ConfigFile)
+				if err != nil {
+					return err
+				}
+				bot.configReloadManager, err = setupConfigReloadManager(&bot.Config.HotReload, filePath, bot)
+				if err != nil {
+					return err
+				}
+			}
+			return bot.configReloadManager.Start()
+		}
+		return bot.configReloadManager.Stop()
 	}
 	return fmt.Errorf("%s: %w", subSystemName, errSubsystemNotFound)
 }
@@ -301,6 +403,12 @@ func (bot *Engine) GetExchangeOTPs() (map[string]string, error) {
 	for x := range bot.Config.Exchanges {
 		if otpSecret := bot.Config.Exchanges[x].API.Credentials.OTPSecret; otpSecret != "" {
 			exchName := bot.Config.Exchanges[x].Name
+			otpSecret, err := secrets.Resolve(context.TODO(), otpSecret)
+			if err != nil {
+				log.Errorf(log.Global, "Unable to resolve OTP secret for exchange %s. Err: %s\n",
+					exchName, err)
+				continue
+			}
 			o, err := totp.GenerateCode(otpSecret, time.Now())
 			if err != nil {
 				log.Errorf(log.Global, "Unable to generate OTP code for exchange %s. Err: %s\n",
@@ -327,12 +435,42 @@ func (bot *Engine) GetExchangeOTPByName(exchName string) (string, error) {
 		}
 
 		if otpSecret := bot.Config.Exchanges[x].API.Credentials.OTPSecret; otpSecret != "" {
+			otpSecret, err := secrets.Resolve(context.TODO(), otpSecret)
+			if err != nil {
+				return "", err
+			}
 			return totp.GenerateCode(otpSecret, time.Now())
 		}
 	}
 	return "", errors.New("exchange does not have a OTP secret stored")
 }
 
+// resolveExchangeCredentials returns a copy of the exchange config credentials
+// with any secret references resolved
+func resolveExchangeCredentials(ctx context.Context, creds *config.APICredentialsConfig) (*config.APICredentialsConfig, error) {
+	resolved := *creds
+	for _, field := range []*string{
+		&resolved.Key,
+		&resolved.Secret,
+		&resolved.ClientID,
+		&resolved.Subaccount,
+		&resolved.PEMKey,
+		&resolved.OTPSecret,
+		&resolved.TradePassword,
+		&resolved.PIN,
+	} {
+		if *field == "" {
+			continue
+		}
+		var err error
+		*field, err = secrets.Resolve(ctx, *field)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &resolved, nil
+}
+
 // GetAuthAPISupportedExchanges returns a list of auth api enabled exchanges
 func (bot *Engine) GetAuthAPISupportedExchanges() []string {
 	exchanges := bot.GetExchanges()
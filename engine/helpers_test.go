@@ -19,6 +19,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
 	"github.com/thrasher-corp/gocryptotrader/communications"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
@@ -28,6 +29,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stats"
@@ -98,8 +100,8 @@ func CreateTestBot(t *testing.T) *Engine {
 
 func TestGetSubsystemsStatus(t *testing.T) {
 	m := (&Engine{}).GetSubsystemsStatus()
-	if len(m) != 15 {
-		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", 15, len(m))
+	if len(m) != 24 {
+		t.Fatalf("subsystem count is wrong expecting: %d but received: %d", 24, len(m))
 	}
 }
 
@@ -205,12 +207,66 @@ func TestSetSubsystem(t *testing.T) { //nolint // TO-DO: Fix race t.Parallel() u
 			EnableError:  database.ErrNilInstance,
 			DisableError: ErrNilSubsystem,
 		},
+		{
+			Subsystem:    DataRetentionManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  errNilDatabaseConnectionManager,
+			DisableError: ErrSubSystemNotStarted,
+		},
+		{
+			Subsystem:    FundingRateScannerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  nil,
+			DisableError: nil,
+		},
+		{
+			Subsystem:    BasisServiceName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  nil,
+			DisableError: nil,
+		},
+		{
+			Subsystem:    CandleBuilderName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  kline.ErrInvalidInterval,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    EventJournalName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    AccountingManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    LedgerManagerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
+		{
+			Subsystem:    RebalancerName,
+			Engine:       &Engine{Config: &config.Config{}},
+			EnableError:  ErrNilSubsystem,
+			DisableError: ErrNilSubsystem,
+		},
 		{
 			Subsystem:    vm.Name,
 			Engine:       &Engine{Config: &config.Config{}},
 			EnableError:  nil,
 			DisableError: nil,
 		},
+		{
+			Subsystem:    ConfigReloadManagerName,
+			Engine:       &Engine{Config: &config.Config{}, Settings: Settings{ConfigFile: config.DefaultFilePath()}},
+			EnableError:  errInvalidReloadInterval,
+			DisableError: ErrNilSubsystem,
+		},
 	}
 
 	for _, tt := range testCases {
@@ -304,6 +360,31 @@ func TestGetExchangeoOTPByName(t *testing.T) {
 	bCfg.API.Credentials.OTPSecret = ""
 }
 
+func TestResolveExchangeCredentials(t *testing.T) {
+	t.Setenv("GCT_TEST_RESOLVE_OTP_SECRET", "JBSWY3DPEHPK3PXP")
+	creds := &config.APICredentialsConfig{
+		Key:           "key",
+		OTPSecret:     "env://GCT_TEST_RESOLVE_OTP_SECRET",
+		TradePassword: "password",
+	}
+	resolved, err := resolveExchangeCredentials(context.Background(), creds)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if resolved.Key != "key" || resolved.OTPSecret != "JBSWY3DPEHPK3PXP" || resolved.TradePassword != "password" {
+		t.Errorf("received '%+v', expected resolved credentials", resolved)
+	}
+	if creds.OTPSecret != "env://GCT_TEST_RESOLVE_OTP_SECRET" {
+		t.Error("config credentials should not be modified")
+	}
+
+	creds.PIN = "env://GCT_TEST_RESOLVE_MISSING_PIN"
+	_, err = resolveExchangeCredentials(context.Background(), creds)
+	if !errors.Is(err, secrets.ErrSecretNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, secrets.ErrSecretNotFound)
+	}
+}
+
 func TestGetAuthAPISupportedExchanges(t *testing.T) {
 	t.Parallel()
 	e := CreateTestBot(t)
@@ -0,0 +1,702 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/ledger"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupLedgerManager creates a ledger manager subsystem. The database
+// connection manager is only required when entries are stored
+func SetupLedgerManager(em iExchangeManager, dcm iDatabaseConnectionManager, cm iCommsManager, cfg *config.Ledger) (*LedgerManager, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if cm == nil {
+		return nil, errNilComManager
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if cfg.Tolerance < 0 {
+		return nil, errInvalidLedgerTolerance
+	}
+	interval := cfg.ImportInterval
+	if interval <= 0 {
+		interval = time.Minute * 15
+	}
+	return &LedgerManager{
+		exchangeManager: em,
+		dbManager:       dcm,
+		commsManager:    cm,
+		shutdown:        make(chan struct{}),
+		importInterval:  interval,
+		tolerance:       decimal.NewFromFloat(cfg.Tolerance),
+		dustThreshold:   decimal.NewFromFloat(cfg.DustThreshold),
+		verbose:         cfg.Verbose,
+		exchanges:       make(map[string]*ledgerExchange),
+		balances:        make(map[string]*ledgerBalance),
+		seen:            make(map[string]struct{}),
+		entrySaver:      ledger.Insert,
+		balanceLoader:   ledger.GetBalances,
+		rangeLoader:     ledger.GetExchangeRanges,
+	}, nil
+}
+
+// Start runs the subsystem. When the database is connected the expected
+// balances are rebuilt from the stored ledger so history is imported from
+// where it was left off
+func (l *LedgerManager) Start() error {
+	if l == nil {
+		return fmt.Errorf("%s %w", LedgerManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&l.started, 0, 1) {
+		return fmt.Errorf("%s %w", LedgerManagerName, ErrSubSystemAlreadyStarted)
+	}
+	if l.databaseConnected() {
+		if err := l.load(); err != nil {
+			atomic.StoreInt32(&l.started, 0)
+			return err
+		}
+	}
+	l.shutdown = make(chan struct{})
+	l.wg.Add(1)
+	go l.run()
+	log.Debugf(log.Global, "Ledger manager %s, reconciling every %s", MsgSubSystemStarted, l.importInterval)
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (l *LedgerManager) IsRunning() bool {
+	if l == nil {
+		return false
+	}
+	return atomic.LoadInt32(&l.started) == 1
+}
+
+// Stop stops the subsystem
+func (l *LedgerManager) Stop() error {
+	if l == nil {
+		return fmt.Errorf("%s %w", LedgerManagerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&l.started, 1, 0) {
+		return fmt.Errorf("%s %w", LedgerManagerName, ErrSubSystemNotStarted)
+	}
+	close(l.shutdown)
+	l.wg.Wait()
+	log.Debugf(log.Global, "Ledger manager %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (l *LedgerManager) run() {
+	defer l.wg.Done()
+	t := time.NewTicker(l.importInterval)
+	defer t.Stop()
+	for {
+		select {
+		case <-l.shutdown:
+			return
+		case <-t.C:
+			if _, err := l.Reconcile(context.TODO()); err != nil && !errors.Is(err, errAlreadyRunning) {
+				log.Errorf(log.Global, "Ledger manager: %v", err)
+			}
+		}
+	}
+}
+
+// load rebuilds the expected balances and import times from the stored
+// ledger
+func (l *LedgerManager) load() error {
+	balances, err := l.balanceLoader()
+	if err != nil {
+		return fmt.Errorf("%s unable to load balances: %w", LedgerManagerName, err)
+	}
+	ranges, err := l.rangeLoader()
+	if err != nil {
+		return fmt.Errorf("%s unable to load exchanges: %w", LedgerManagerName, err)
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	for i := range balances {
+		item, parseErr := asset.New(balances[i].Asset)
+		if parseErr != nil {
+			return parseErr
+		}
+		b := l.getBalance(balances[i].Exchange, balances[i].SubAccount, item, currency.NewCode(balances[i].Currency))
+		b.expected = balances[i].Amount
+	}
+	for i := range ranges {
+		l.exchanges[strings.ToLower(ranges[i].Exchange)] = &ledgerExchange{
+			opened:     ranges[i].First,
+			lastImport: ranges[i].Last,
+		}
+	}
+	return nil
+}
+
+// Reconcile imports the history of each exchange which supports
+// authenticated requests since the last import and compares the expected
+// balances to the exchange balances. The first reconciliation of an exchange
+// opens its ledger with the exchange balances
+func (l *LedgerManager) Reconcile(ctx context.Context) (*LedgerReconciliation, error) {
+	if l == nil {
+		return nil, fmt.Errorf("%s %w", LedgerManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&l.started) == 0 {
+		return nil, fmt.Errorf("%s %w", LedgerManagerName, ErrSubSystemNotStarted)
+	}
+	if !atomic.CompareAndSwapInt32(&l.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot reconcile ledger, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&l.processing, 0)
+
+	exchanges, err := l.exchangeManager.GetExchanges()
+	if err != nil {
+		return nil, err
+	}
+	result := &LedgerReconciliation{Time: time.Now()}
+	for i := range exchanges {
+		if !exchanges[i].IsRESTAuthenticationSupported() {
+			continue
+		}
+		imported, errs := l.reconcileExchange(ctx, exchanges[i], result.Time)
+		result.Imported += imported
+		for j := range errs {
+			result.Errors = append(result.Errors, fmt.Sprintf("%s: %v", exchanges[i].GetName(), errs[j]))
+		}
+	}
+	sort.Strings(result.Errors)
+
+	l.m.Lock()
+	result.Balances = l.balanceList("")
+	l.lastRun = result
+	l.m.Unlock()
+	if l.verbose {
+		log.Debugf(log.Global, "Ledger manager imported %d entries", result.Imported)
+		for i := range result.Errors {
+			log.Warnf(log.Global, "Ledger manager: %s", result.Errors[i])
+		}
+	}
+	return l.copyReconciliation(result, ""), nil
+}
+
+// reconcileExchange imports the history of an exchange and reconciles its
+// spot balances, returning the number of entries imported
+func (l *LedgerManager) reconcileExchange(ctx context.Context, exch exchange.IBotExchange, now time.Time) (int, []error) {
+	name := exch.GetName()
+	holdings, err := exch.UpdateAccountInfo(ctx, asset.Spot)
+	if err != nil {
+		return 0, []error{fmt.Errorf("account info: %w", err)}
+	}
+	var subAccount string
+	if len(holdings.Accounts) > 0 {
+		subAccount = holdings.Accounts[0].ID
+	}
+
+	l.m.Lock()
+	state, ok := l.exchanges[strings.ToLower(name)]
+	l.m.Unlock()
+	var (
+		entries []ledger.Data
+		errs    []error
+	)
+	if !ok {
+		state = &ledgerExchange{opened: now, lastImport: now}
+		entries = ledgerOpeningEntries(name, &holdings, now)
+	} else {
+		start := state.lastImport.Add(-ledgerImportOverlap)
+		if start.Before(state.opened) {
+			start = state.opened
+		}
+		entries, errs = ledgerHistory(ctx, exch, subAccount, &holdings, start, now)
+	}
+
+	inserted, err := l.record(entries)
+	if err != nil {
+		return 0, append(errs, err)
+	}
+
+	l.m.Lock()
+	defer l.m.Unlock()
+	l.exchanges[strings.ToLower(name)] = state
+	// History which could not be retrieved is requested again next time
+	if len(errs) == 0 {
+		state.lastImport = now
+	}
+	for _, b := range l.balances {
+		if strings.EqualFold(b.exchange, name) {
+			b.activity = false
+			b.actual = decimal.Zero
+		}
+	}
+	for i := range inserted {
+		item, parseErr := asset.New(inserted[i].Asset)
+		if parseErr != nil {
+			errs = append(errs, parseErr)
+			continue
+		}
+		b := l.getBalance(name, inserted[i].SubAccount, item, currency.NewCode(inserted[i].Currency))
+		b.expected = b.expected.Add(inserted[i].Amount)
+		b.activity = true
+	}
+	for i := range holdings.Accounts {
+		for j := range holdings.Accounts[i].Currencies {
+			bal := &holdings.Accounts[i].Currencies[j]
+			b := l.getBalance(name, holdings.Accounts[i].ID, asset.Spot, bal.Currency)
+			b.actual = b.actual.Add(ledgerHolding(bal))
+		}
+	}
+	for _, b := range l.balances {
+		if strings.EqualFold(b.exchange, name) && b.asset == asset.Spot {
+			l.compare(b, now)
+		}
+	}
+	return len(inserted), errs
+}
+
+// record stores new ledger entries and returns those which were not already
+// in the ledger. Without a database the keys of recorded entries are held in
+// memory
+func (l *LedgerManager) record(entries []ledger.Data) ([]ledger.Data, error) {
+	if len(entries) == 0 {
+		return nil, nil
+	}
+	if l.databaseConnected() {
+		return l.entrySaver(entries...)
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	inserted := make([]ledger.Data, 0, len(entries))
+	for i := range entries {
+		if _, ok := l.seen[entries[i].Key]; ok {
+			continue
+		}
+		l.seen[entries[i].Key] = struct{}{}
+		inserted = append(inserted, entries[i])
+	}
+	return inserted, nil
+}
+
+// compare classifies the difference between the exchange and expected
+// balance. Differences within the tolerance are cleared, a difference worth
+// less than the dust threshold is dust and otherwise a new difference is a
+// missing transfer when no history was imported for the balance or an
+// unexplained change when there was. Changes are pushed to the
+// communications manager. Must be called with the lock held
+func (l *LedgerManager) compare(b *ledgerBalance, now time.Time) {
+	difference := b.actual.Sub(b.expected)
+	limit := decimal.Max(b.actual.Abs(), b.expected.Abs()).Mul(l.tolerance)
+	var discrepancy LedgerDiscrepancy
+	switch {
+	case difference.Abs().LessThanOrEqual(limit):
+	case l.isDust(b.exchange, b.currency, difference.Abs()):
+		discrepancy = LedgerDust
+	case difference.Sub(b.difference).Abs().LessThanOrEqual(limit) && b.discrepancy != "":
+		discrepancy = b.discrepancy
+	case b.activity:
+		discrepancy = LedgerUnexplainedChange
+	default:
+		discrepancy = LedgerMissingTransfer
+	}
+	changed := discrepancy != b.discrepancy || (discrepancy != "" && !difference.Equal(b.difference))
+	if discrepancy != b.discrepancy {
+		b.since = now
+	}
+	if discrepancy == "" {
+		b.since = time.Time{}
+	}
+	resolved := discrepancy == "" && b.discrepancy != ""
+	b.difference = difference
+	b.discrepancy = discrepancy
+	if !changed {
+		return
+	}
+	var msg string
+	if resolved {
+		msg = fmt.Sprintf("Ledger %s %s %s %s reconciled, balance %s", b.exchange, b.subAccount, b.asset, b.currency, b.actual)
+	} else {
+		msg = fmt.Sprintf("Ledger %s %s %s %s %s: exchange balance %s expected %s difference %s",
+			b.exchange, b.subAccount, b.asset, b.currency, discrepancy, b.actual, b.expected, difference)
+	}
+	if discrepancy != LedgerDust {
+		l.commsManager.PushEvent(base.Event{Type: "ledger", Message: msg})
+	}
+	if l.verbose || (discrepancy != "" && discrepancy != LedgerDust) {
+		log.Warnln(log.Global, msg)
+	}
+}
+
+// isDust returns whether an amount of a currency is worth less than the dust
+// threshold in USD. Amounts which cannot be valued are not dust
+func (l *LedgerManager) isDust(exch string, code currency.Code, amount decimal.Decimal) bool {
+	if !l.dustThreshold.IsPositive() {
+		return false
+	}
+	r, ok := ledgerUSDRate(exch, code)
+	return ok && amount.Mul(r).LessThan(l.dustThreshold)
+}
+
+// ledgerUSDRate returns the USD rate of a currency from its direct rate or a
+// spot ticker of the exchange quoted in a USD stablecoin
+func ledgerUSDRate(exch string, code currency.Code) (decimal.Decimal, bool) {
+	if r, ok := directRate(exch, code, currency.USD); ok {
+		return r, true
+	}
+	for _, quote := range taxMarketQuotes {
+		if r, ok := tickerRate(exch, code, quote); ok {
+			return r, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// getBalance returns the balance of a currency in a sub account, creating it
+// when required. Must be called with the lock held
+func (l *LedgerManager) getBalance(exch, subAccount string, item asset.Item, code currency.Code) *ledgerBalance {
+	key := strings.ToLower(exch) + "|" + subAccount + "|" + item.String() + "|" + code.Upper().String()
+	b, ok := l.balances[key]
+	if !ok {
+		b = &ledgerBalance{exchange: exch, subAccount: subAccount, asset: item, currency: code.Upper()}
+		l.balances[key] = b
+	}
+	return b
+}
+
+// balanceList returns the balances of an exchange, or every exchange, sorted
+// by exchange, sub account and currency. Must be called with the lock held
+func (l *LedgerManager) balanceList(exch string) []LedgerBalance {
+	resp := make([]LedgerBalance, 0, len(l.balances))
+	for _, b := range l.balances {
+		if exch != "" && !strings.EqualFold(exch, b.exchange) {
+			continue
+		}
+		if b.expected.IsZero() && b.actual.IsZero() {
+			continue
+		}
+		resp = append(resp, LedgerBalance{
+			Exchange:    b.exchange,
+			SubAccount:  b.subAccount,
+			Asset:       b.asset,
+			Currency:    b.currency,
+			Expected:    b.expected,
+			Actual:      b.actual,
+			Difference:  b.difference,
+			Discrepancy: b.discrepancy,
+			Since:       b.since,
+		})
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if resp[i].SubAccount != resp[j].SubAccount {
+			return resp[i].SubAccount < resp[j].SubAccount
+		}
+		return resp[i].Currency.String() < resp[j].Currency.String()
+	})
+	return resp
+}
+
+// copyReconciliation returns a copy of a reconciliation limited to an
+// exchange
+func (l *LedgerManager) copyReconciliation(r *LedgerReconciliation, exch string) *LedgerReconciliation {
+	cpy := *r
+	cpy.Balances = nil
+	for i := range r.Balances {
+		if exch == "" || strings.EqualFold(exch, r.Balances[i].Exchange) {
+			cpy.Balances = append(cpy.Balances, r.Balances[i])
+		}
+	}
+	cpy.Errors = nil
+	prefix := strings.ToLower(exch) + ":"
+	for i := range r.Errors {
+		if exch == "" || strings.HasPrefix(strings.ToLower(r.Errors[i]), prefix) {
+			cpy.Errors = append(cpy.Errors, r.Errors[i])
+		}
+	}
+	return &cpy
+}
+
+// LastReconciliation returns the result of the last reconciliation,
+// optionally limited to an exchange
+func (l *LedgerManager) LastReconciliation(exch string) (*LedgerReconciliation, error) {
+	if l == nil {
+		return nil, fmt.Errorf("%s %w", LedgerManagerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&l.started) == 0 {
+		return nil, fmt.Errorf("%s %w", LedgerManagerName, ErrSubSystemNotStarted)
+	}
+	l.m.Lock()
+	defer l.m.Unlock()
+	if l.lastRun == nil {
+		return nil, errNoLedgerReconciliation
+	}
+	return l.copyReconciliation(l.lastRun, exch), nil
+}
+
+// databaseConnected returns whether ledger entries can be stored
+func (l *LedgerManager) databaseConnected() bool {
+	if l.dbManager == nil {
+		return false
+	}
+	db := l.dbManager.GetInstance()
+	return db != nil && db.IsConnected()
+}
+
+// ledgerOpeningEntries returns the entries opening the ledger of an exchange
+// with its spot balances
+func ledgerOpeningEntries(exch string, holdings *account.Holdings, t time.Time) []ledger.Data {
+	var entries []ledger.Data
+	for i := range holdings.Accounts {
+		for j := range holdings.Accounts[i].Currencies {
+			bal := &holdings.Accounts[i].Currencies[j]
+			total := ledgerHolding(bal)
+			if total.IsZero() {
+				continue
+			}
+			entries = append(entries, ledger.Data{
+				Key:        ledgerKey(exch, string(LedgerOpening), holdings.Accounts[i].ID, bal.Currency.Upper().String()),
+				Exchange:   exch,
+				SubAccount: holdings.Accounts[i].ID,
+				Asset:      asset.Spot.String(),
+				Kind:       string(LedgerOpening),
+				Currency:   bal.Currency.Upper().String(),
+				Amount:     total,
+				Occurred:   t,
+			})
+		}
+	}
+	return entries
+}
+
+// ledgerHistory returns the ledger entries of the spot order, funding and
+// withdrawal history of an exchange between the start and end times. History
+// is attributed to the sub account given
+func ledgerHistory(ctx context.Context, exch exchange.IBotExchange, subAccount string, holdings *account.Holdings, start, end time.Time) ([]ledger.Data, []error) {
+	var (
+		entries []ledger.Data
+		errs    []error
+	)
+	name := exch.GetName()
+	add := func(kind LedgerEntryKind, key, ref string, code currency.Code, amount decimal.Decimal, t time.Time, description string) {
+		if amount.IsZero() || code.IsEmpty() {
+			return
+		}
+		entries = append(entries, ledger.Data{
+			Key:         ledgerKey(name, key, code.Upper().String(), string(kind)),
+			Exchange:    name,
+			SubAccount:  subAccount,
+			Asset:       asset.Spot.String(),
+			Kind:        string(kind),
+			Reference:   ref,
+			Currency:    code.Upper().String(),
+			Amount:      amount,
+			Occurred:    t,
+			Description: description,
+		})
+	}
+	fill := func(key, ref string, pair currency.Pair, side order.Side, amount, price, fee float64, feeAsset currency.Code, t time.Time, description string) {
+		baseAmount := decimal.NewFromFloat(amount)
+		quoteAmount := baseAmount.Mul(decimal.NewFromFloat(price))
+		if side.IsShort() {
+			baseAmount = baseAmount.Neg()
+		} else {
+			quoteAmount = quoteAmount.Neg()
+		}
+		add(LedgerTrade, key, ref, pair.Base, baseAmount, t, description)
+		add(LedgerTrade, key, ref, pair.Quote, quoteAmount, t, description)
+		if feeAsset.IsEmpty() {
+			feeAsset = pair.Quote
+		}
+		if fee > 0 {
+			add(LedgerFee, key, ref, feeAsset, decimal.NewFromFloat(fee).Neg(), t, description)
+		}
+	}
+
+	if exch.GetAssetTypes(true).Contains(asset.Spot) {
+		pairs, err := exch.GetEnabledPairs(asset.Spot)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			orders, histErr := exch.GetOrderHistory(ctx, &order.MultiOrderRequest{
+				Pairs:     pairs,
+				AssetType: asset.Spot,
+				Type:      order.AnyType,
+				Side:      order.AnySide,
+				StartTime: start,
+				EndTime:   end,
+			})
+			if histErr != nil {
+				errs = append(errs, fmt.Errorf("order history: %w", histErr))
+			}
+			for i := range orders {
+				d := &orders[i]
+				if !d.Side.IsLong() && !d.Side.IsShort() {
+					continue
+				}
+				if len(d.Trades) > 0 {
+					for j := range d.Trades {
+						trade := &d.Trades[j]
+						side := d.Side
+						if trade.Side.IsLong() || trade.Side.IsShort() {
+							side = trade.Side
+						}
+						t := trade.Timestamp
+						if t.IsZero() {
+							t = d.LastUpdated
+						}
+						if trade.Amount <= 0 || t.Before(start) || t.After(end) {
+							continue
+						}
+						tid := trade.TID
+						if tid == "" {
+							tid = strconv.Itoa(j)
+						}
+						feeAsset := d.FeeAsset
+						if trade.FeeAsset != "" {
+							feeAsset = currency.NewCode(trade.FeeAsset)
+						}
+						fill(ledgerKey("order", d.OrderID, tid), d.OrderID, d.Pair, side, trade.Amount, trade.Price, trade.Fee, feeAsset, t, trade.TID)
+					}
+					continue
+				}
+				// Without trades the execution of an order is only known once
+				// it can no longer change
+				if !d.IsInactive() || d.ExecutedAmount <= 0 {
+					continue
+				}
+				t := d.LastUpdated
+				if t.IsZero() {
+					t = d.Date
+				}
+				if t.Before(start) || t.After(end) {
+					continue
+				}
+				price := d.AverageExecutedPrice
+				if price <= 0 {
+					price = d.Price
+				}
+				fill(ledgerKey("order", d.OrderID), d.OrderID, d.Pair, d.Side, d.ExecutedAmount, price, d.Fee, d.FeeAsset, t, "")
+			}
+		}
+	}
+
+	withdrawn := make(map[string]bool)
+	funding, err := exch.GetAccountFundingHistory(ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("funding history: %w", err))
+	}
+	for i := range funding {
+		f := &funding[i]
+		if f.Timestamp.Before(start) || f.Timestamp.After(end) || taxTransferFailed(f.Status) {
+			continue
+		}
+		kind := LedgerEntryKind(taxFundingKind(f.TransferType, f.Description, f.Amount))
+		amount := decimal.NewFromFloat(f.Amount)
+		switch {
+		case strings.Contains(strings.ToLower(f.TransferType+" "+f.Description), "funding"):
+			kind = LedgerFunding
+		case kind == LedgerWithdrawal:
+			amount = amount.Abs().Neg()
+			withdrawn[f.TransferID] = true
+			withdrawn[f.CryptoTxID] = true
+		default:
+			amount = amount.Abs()
+		}
+		ref := ledgerTransferReference(f.TransferID, f.CryptoTxID, f.Timestamp)
+		code := currency.NewCode(f.Currency)
+		add(kind, ledgerKey("transfer", ref), ref, code, amount, f.Timestamp, f.Description)
+		if f.Fee > 0 {
+			add(LedgerFee, ledgerKey("transfer", ref), ref, code, decimal.NewFromFloat(f.Fee).Neg(), f.Timestamp, f.Description)
+		}
+	}
+
+	// Withdrawals can only be requested per currency, so only currencies
+	// which are held or appear in the history are requested
+	var codes currency.Currencies
+	for i := range holdings.Accounts {
+		for j := range holdings.Accounts[i].Currencies {
+			if c := holdings.Accounts[i].Currencies[j].Currency.Upper(); !codes.Contains(c) {
+				codes = append(codes, c)
+			}
+		}
+	}
+	for i := range entries {
+		if c := currency.NewCode(entries[i].Currency); !codes.Contains(c) {
+			codes = append(codes, c)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].String() < codes[j].String()
+	})
+	for i := range codes {
+		withdrawals, histErr := exch.GetWithdrawalsHistory(ctx, codes[i], asset.Spot)
+		if histErr != nil {
+			if errors.Is(histErr, common.ErrFunctionNotSupported) || errors.Is(histErr, common.ErrNotYetImplemented) {
+				break
+			}
+			errs = append(errs, fmt.Errorf("%s withdrawal history: %w", codes[i], histErr))
+			continue
+		}
+		for j := range withdrawals {
+			w := &withdrawals[j]
+			if w.Timestamp.Before(start) || w.Timestamp.After(end) || taxTransferFailed(w.Status) {
+				continue
+			}
+			if (w.TransferID != "" && withdrawn[w.TransferID]) || (w.CryptoTxID != "" && withdrawn[w.CryptoTxID]) {
+				continue
+			}
+			code := codes[i]
+			if w.Currency != "" {
+				code = currency.NewCode(w.Currency)
+			}
+			ref := ledgerTransferReference(w.TransferID, w.CryptoTxID, w.Timestamp)
+			add(LedgerWithdrawal, ledgerKey("transfer", ref), ref, code, decimal.NewFromFloat(w.Amount).Abs().Neg(), w.Timestamp, w.Description)
+			if w.Fee > 0 {
+				add(LedgerFee, ledgerKey("transfer", ref), ref, code, decimal.NewFromFloat(w.Fee).Neg(), w.Timestamp, w.Description)
+			}
+		}
+	}
+	return entries, errs
+}
+
+// ledgerHolding returns the exchange balance of a currency less the amount
+// borrowed
+func ledgerHolding(bal *account.Balance) decimal.Decimal {
+	return decimal.NewFromFloat(bal.Total).Sub(decimal.NewFromFloat(bal.Borrowed))
+}
+
+// ledgerTransferReference returns the transfer ID of a transfer, its
+// transaction ID or otherwise the time it occurred
+func ledgerTransferReference(transferID, txID string, t time.Time) string {
+	switch {
+	case transferID != "":
+		return transferID
+	case txID != "":
+		return txID
+	default:
+		return strconv.FormatInt(t.UnixNano(), 10)
+	}
+}
+
+// ledgerKey joins the parts of a ledger entry key
+func ledgerKey(parts ...string) string {
+	return strings.Join(parts, "|")
+}
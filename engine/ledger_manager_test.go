@@ -0,0 +1,374 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/ledger"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+var errLedgerStore = errors.New("ledger store unavailable")
+
+// lmExchange aka ledger manager fake exchange returns canned history and
+// spot holdings in a single sub account
+type lmExchange struct {
+	taxExchange
+	holdings []account.Balance
+}
+
+func (e *lmExchange) UpdateAccountInfo(context.Context, asset.Item) (account.Holdings, error) {
+	return account.Holdings{
+		Exchange: e.name,
+		Accounts: []account.SubAccount{{ID: "main", AssetType: asset.Spot, Currencies: e.holdings}},
+	}, nil
+}
+
+// newTestLedgerManager returns a started ledger manager without a database
+// which treats differences worth less than 1 USD as dust
+func newTestLedgerManager(t *testing.T, e *lmExchange) (*LedgerManager, *omfComms) {
+	t.Helper()
+	em := NewExchangeManager()
+	if err := em.Add(e); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	comms := &omfComms{}
+	l, err := SetupLedgerManager(em, nil, comms, &config.Ledger{Tolerance: 0.000001, DustThreshold: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	l.started = 1
+	return l, comms
+}
+
+func findLedgerBalance(r *LedgerReconciliation, code currency.Code) *LedgerBalance {
+	for i := range r.Balances {
+		if r.Balances[i].Currency.Equal(code) {
+			return &r.Balances[i]
+		}
+	}
+	return nil
+}
+
+func TestSetupLedgerManager(t *testing.T) {
+	t.Parallel()
+	_, err := SetupLedgerManager(nil, nil, nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupLedgerManager(NewExchangeManager(), nil, nil, nil)
+	if !errors.Is(err, errNilComManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilComManager)
+	}
+	_, err = SetupLedgerManager(NewExchangeManager(), nil, &omfComms{}, nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	_, err = SetupLedgerManager(NewExchangeManager(), nil, &omfComms{}, &config.Ledger{Tolerance: -1})
+	if !errors.Is(err, errInvalidLedgerTolerance) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidLedgerTolerance)
+	}
+	l, err := SetupLedgerManager(NewExchangeManager(), nil, &omfComms{}, &config.Ledger{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if l.importInterval != time.Minute*15 {
+		t.Errorf("received '%v', expected '%v'", l.importInterval, time.Minute*15)
+	}
+}
+
+func TestLedgerManagerStartStop(t *testing.T) {
+	t.Parallel()
+	var l *LedgerManager
+	if err := l.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := l.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if l.IsRunning() {
+		t.Error("nil ledger manager should not be running")
+	}
+	if _, err := l.Reconcile(context.Background()); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+
+	l, err := SetupLedgerManager(NewExchangeManager(), &cbDatabase{}, &omfComms{}, &config.Ledger{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if _, err = l.Reconcile(context.Background()); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if _, err = l.LastReconciliation(""); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	l.balanceLoader = func() ([]ledger.Balance, error) {
+		return nil, errLedgerStore
+	}
+	if err = l.Start(); !errors.Is(err, errLedgerStore) {
+		t.Errorf("received '%v', expected '%v'", err, errLedgerStore)
+	}
+	if l.IsRunning() {
+		t.Error("ledger manager should not be running when the ledger cannot be loaded")
+	}
+
+	// the stored ledger is loaded on start
+	opened := time.Now().Add(-time.Hour * 24)
+	l.balanceLoader = func() ([]ledger.Balance, error) {
+		return []ledger.Balance{{Exchange: "lmstart", SubAccount: "main", Asset: "spot", Currency: "BTC", Amount: decimal.NewFromFloat(1.5)}}, nil
+	}
+	l.rangeLoader = func() ([]ledger.ExchangeRange, error) {
+		return []ledger.ExchangeRange{{Exchange: "lmstart", First: opened, Last: opened.Add(time.Hour)}}, nil
+	}
+	if err = l.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = l.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !l.IsRunning() {
+		t.Error("ledger manager should be running")
+	}
+	l.m.Lock()
+	b := l.getBalance("lmstart", "main", asset.Spot, currency.BTC)
+	state := l.exchanges["lmstart"]
+	l.m.Unlock()
+	if !b.expected.Equal(decimal.NewFromFloat(1.5)) {
+		t.Errorf("received '%v', expected '%v'", b.expected, 1.5)
+	}
+	if state == nil || !state.opened.Equal(opened) {
+		t.Errorf("received '%+v', expected the ledger opened at '%v'", state, opened)
+	}
+	if _, err = l.LastReconciliation(""); !errors.Is(err, errNoLedgerReconciliation) {
+		t.Errorf("received '%v', expected '%v'", err, errNoLedgerReconciliation)
+	}
+
+	if err = l.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err = l.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestLedgerManagerReconcile(t *testing.T) {
+	t.Parallel()
+	e := &lmExchange{
+		taxExchange: taxExchange{name: "lmreconcile", pairs: currency.Pairs{currency.NewPair(currency.BTC, currency.USDT)}},
+		holdings: []account.Balance{
+			{Currency: currency.BTC, Total: 1},
+			{Currency: currency.USDT, Total: 20000},
+		},
+	}
+	l, comms := newTestLedgerManager(t, e)
+
+	// the first reconciliation opens the ledger with the exchange balances
+	r, err := l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if r.Imported != 2 || len(r.Balances) != 2 {
+		t.Fatalf("received '%+v', expected two opening entries", r)
+	}
+	for i := range r.Balances {
+		if r.Balances[i].Discrepancy != "" || !r.Balances[i].Difference.IsZero() {
+			t.Errorf("received '%+v', expected a reconciled balance", r.Balances[i])
+		}
+	}
+
+	// a buy with a fee, a deposit, an unreported transfer and dust
+	filled := time.Now()
+	e.orders = []order.Detail{{
+		Exchange:  e.name,
+		OrderID:   "1",
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USDT),
+		Side:      order.Buy,
+		Status:    order.PartiallyFilled,
+		Trades: []order.TradeHistory{
+			{TID: "t1", Amount: 0.5, Price: 20000, Fee: 10, FeeAsset: "USDT", Timestamp: filled},
+		},
+	}}
+	e.funding = []exchange.FundingHistory{
+		{TransferID: "d1", TransferType: "deposit", Currency: "ETH", Amount: 0.1, Timestamp: filled},
+		{TransferID: "d2", TransferType: "deposit", Currency: "ETH", Amount: 5, Status: "rejected", Timestamp: filled},
+	}
+	e.holdings = []account.Balance{
+		{Currency: currency.BTC, Total: 1.6},
+		{Currency: currency.USDT, Total: 9990.5},
+		{Currency: currency.ETH, Total: 0.1},
+		{Currency: currency.LTC, Total: 5},
+	}
+	r, err = l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if r.Imported != 4 {
+		t.Errorf("received '%v', expected '%v'", r.Imported, 4)
+	}
+	expected := map[currency.Code]LedgerDiscrepancy{
+		currency.BTC:  LedgerUnexplainedChange,
+		currency.USDT: LedgerDust,
+		currency.ETH:  "",
+		currency.LTC:  LedgerMissingTransfer,
+	}
+	for code, discrepancy := range expected {
+		b := findLedgerBalance(r, code)
+		if b == nil {
+			t.Fatalf("expected a %s balance", code)
+		}
+		if b.Discrepancy != discrepancy {
+			t.Errorf("received '%v', expected '%v' for %s", b.Discrepancy, discrepancy, code)
+		}
+	}
+	if b := findLedgerBalance(r, currency.BTC); !b.Expected.Equal(decimal.NewFromFloat(1.5)) || !b.Difference.Equal(decimal.NewFromFloat(0.1)) {
+		t.Errorf("received '%+v', expected 1.5 BTC with a difference of 0.1", b)
+	}
+	comms.m.Lock()
+	alerts := len(comms.events)
+	comms.m.Unlock()
+	// dust is not alerted
+	if alerts != 2 {
+		t.Errorf("received '%v', expected '%v'", alerts, 2)
+	}
+
+	// history already recorded is not imported again and an unchanged
+	// discrepancy is not alerted again
+	since := findLedgerBalance(r, currency.LTC).Since
+	r, err = l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if r.Imported != 0 {
+		t.Errorf("received '%v', expected '%v'", r.Imported, 0)
+	}
+	if b := findLedgerBalance(r, currency.BTC); b.Discrepancy != LedgerUnexplainedChange {
+		t.Errorf("received '%v', expected '%v'", b.Discrepancy, LedgerUnexplainedChange)
+	}
+	if b := findLedgerBalance(r, currency.LTC); b.Discrepancy != LedgerMissingTransfer || !b.Since.Equal(since) {
+		t.Errorf("received '%+v', expected a missing transfer since '%v'", b, since)
+	}
+	comms.m.Lock()
+	alerts = len(comms.events)
+	comms.m.Unlock()
+	if alerts != 2 {
+		t.Errorf("received '%v', expected '%v'", alerts, 2)
+	}
+
+	// a resolved discrepancy is alerted
+	e.holdings[0].Total = 1.5
+	r, err = l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if b := findLedgerBalance(r, currency.BTC); b.Discrepancy != "" || !b.Since.IsZero() {
+		t.Errorf("received '%+v', expected a reconciled balance", b)
+	}
+	comms.m.Lock()
+	alerts = len(comms.events)
+	comms.m.Unlock()
+	if alerts != 3 {
+		t.Errorf("received '%v', expected '%v'", alerts, 3)
+	}
+
+	last, err := l.LastReconciliation("lmreconcile")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(last.Balances) != 4 {
+		t.Errorf("received '%v', expected '%v'", len(last.Balances), 4)
+	}
+	last, err = l.LastReconciliation("other")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(last.Balances) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(last.Balances), 0)
+	}
+}
+
+func TestLedgerManagerStoredEntries(t *testing.T) {
+	t.Parallel()
+	e := &lmExchange{
+		taxExchange: taxExchange{
+			name:        "lmstored",
+			withdrawals: map[string][]exchange.WithdrawalHistory{},
+		},
+		holdings: []account.Balance{{Currency: currency.ETH, Total: 1}},
+	}
+	l, _ := newTestLedgerManager(t, e)
+	var stored []ledger.Data
+	l.dbManager = &cbDatabase{}
+	l.entrySaver = func(d ...ledger.Data) ([]ledger.Data, error) {
+		stored = append(stored, d...)
+		return d, nil
+	}
+	if _, err := l.Reconcile(context.Background()); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(stored) != 1 || stored[0].Kind != string(LedgerOpening) || !stored[0].Amount.Equal(decimal.NewFromInt(1)) || stored[0].SubAccount != "main" {
+		t.Fatalf("received '%+v', expected an opening entry", stored)
+	}
+
+	// withdrawals reported by both funding and withdrawal history are
+	// recorded once
+	withdrawn := time.Now()
+	e.funding = []exchange.FundingHistory{
+		{TransferID: "w1", TransferType: "withdrawal", Currency: "ETH", Amount: 0.4, Fee: 0.01, Timestamp: withdrawn},
+	}
+	e.withdrawals["ETH"] = []exchange.WithdrawalHistory{
+		{TransferID: "w1", Currency: "ETH", Amount: 0.4, Fee: 0.01, Timestamp: withdrawn},
+		{TransferID: "w2", Currency: "ETH", Amount: 0.1, Timestamp: withdrawn},
+	}
+	e.holdings[0].Total = 0.49
+	r, err := l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(stored) != 4 {
+		t.Errorf("received '%v', expected '%v'", len(stored), 4)
+	}
+	if b := findLedgerBalance(r, currency.ETH); b.Discrepancy != "" || !b.Expected.Equal(decimal.NewFromFloat(0.49)) {
+		t.Errorf("received '%+v', expected a reconciled balance of 0.49", b)
+	}
+
+	l.entrySaver = func(...ledger.Data) ([]ledger.Data, error) {
+		return nil, errLedgerStore
+	}
+	r, err = l.Reconcile(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(r.Errors) != 1 {
+		t.Errorf("received '%v', expected one error", r.Errors)
+	}
+}
+
+func TestLedgerHistory(t *testing.T) {
+	t.Parallel()
+	e := &taxExchange{name: "lmhistory"}
+	holdings := &account.Holdings{Accounts: []account.SubAccount{{Currencies: []account.Balance{{Currency: currency.BTC, Total: 1}}}}}
+	start := time.Now().Add(-time.Hour)
+	e.orders = []order.Detail{
+		// an open order without trades is not recorded until it is inactive
+		{OrderID: "1", Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Sell, Status: order.Active, Amount: 2, ExecutedAmount: 1, Price: 100, LastUpdated: time.Now()},
+		{OrderID: "2", Pair: currency.NewPair(currency.BTC, currency.USDT), Side: order.Sell, Status: order.Filled, ExecutedAmount: 1, AverageExecutedPrice: 100, LastUpdated: time.Now()},
+	}
+	entries, errs := ledgerHistory(context.Background(), e, "main", holdings, start, time.Now())
+	if len(errs) != 0 {
+		t.Errorf("received '%v', expected no errors when withdrawal history is %v", errs, common.ErrFunctionNotSupported)
+	}
+	if len(entries) != 2 || !entries[0].Amount.Equal(decimal.NewFromInt(-1)) || !entries[1].Amount.Equal(decimal.NewFromInt(100)) || entries[0].Reference != "2" {
+		t.Errorf("received '%+v', expected the sale of order 2", entries)
+	}
+}
@@ -0,0 +1,138 @@
+package engine
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/ledger"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// LedgerManagerName is an exported subsystem name
+const LedgerManagerName = "ledger_manager"
+
+// ledgerImportOverlap is how far before the last import history is requested
+// again so that entries published late by an exchange are not missed
+const ledgerImportOverlap = time.Hour
+
+// LedgerEntryKind describes the movement of a ledger entry
+type LedgerEntryKind string
+
+// Ledger entry kinds
+const (
+	// LedgerOpening is the balance of a currency when an exchange was first
+	// reconciled
+	LedgerOpening LedgerEntryKind = "opening"
+	// LedgerTrade is a currency bought or sold by a fill
+	LedgerTrade LedgerEntryKind = "trade"
+	// LedgerFee is a currency paid as a trading or transfer fee
+	LedgerFee LedgerEntryKind = "fee"
+	// LedgerIncome is a currency received as interest, rewards or airdrops
+	LedgerIncome LedgerEntryKind = "income"
+	// LedgerDeposit is a currency transferred to an exchange
+	LedgerDeposit LedgerEntryKind = "deposit"
+	// LedgerWithdrawal is a currency transferred from an exchange
+	LedgerWithdrawal LedgerEntryKind = "withdrawal"
+	// LedgerFunding is a funding payment received or paid
+	LedgerFunding LedgerEntryKind = "funding"
+)
+
+// LedgerDiscrepancy describes why an expected balance does not match the
+// exchange balance
+type LedgerDiscrepancy string
+
+// Ledger discrepancies
+const (
+	// LedgerMissingTransfer is a balance which changed without any imported
+	// history, such as a transfer the exchange does not report
+	LedgerMissingTransfer LedgerDiscrepancy = "missing_transfer"
+	// LedgerUnexplainedChange is a balance which changed by a different
+	// amount than its imported history
+	LedgerUnexplainedChange LedgerDiscrepancy = "unexplained_change"
+	// LedgerDust is a difference worth less than the dust threshold
+	LedgerDust LedgerDiscrepancy = "dust"
+)
+
+var (
+	errNoLedgerReconciliation = errors.New("ledger has not been reconciled")
+	errInvalidLedgerTolerance = errors.New("ledger tolerance cannot be negative")
+)
+
+// LedgerManager imports trades, fees, transfers and funding payments of each
+// exchange which supports authenticated requests into a ledger, stored in the
+// database when connected. Expected balances are rebuilt from the ledger and
+// reconciled against exchange balances on an interval, with discrepancies
+// pushed to the communications manager
+type LedgerManager struct {
+	started         int32
+	processing      int32
+	shutdown        chan struct{}
+	wg              sync.WaitGroup
+	m               sync.Mutex
+	exchangeManager iExchangeManager
+	dbManager       iDatabaseConnectionManager
+	commsManager    iCommsManager
+	importInterval  time.Duration
+	tolerance       decimal.Decimal
+	dustThreshold   decimal.Decimal
+	verbose         bool
+
+	exchanges map[string]*ledgerExchange
+	balances  map[string]*ledgerBalance
+	seen      map[string]struct{}
+	lastRun   *LedgerReconciliation
+
+	entrySaver    func(...ledger.Data) ([]ledger.Data, error)
+	balanceLoader func() ([]ledger.Balance, error)
+	rangeLoader   func() ([]ledger.ExchangeRange, error)
+}
+
+// ledgerExchange is when the ledger of an exchange was opened and last
+// imported
+type ledgerExchange struct {
+	opened     time.Time
+	lastImport time.Time
+}
+
+// ledgerBalance is the expected and exchange balance of a currency in a sub
+// account. Activity is set when entries were imported for it in the current
+// reconciliation
+type ledgerBalance struct {
+	exchange    string
+	subAccount  string
+	asset       asset.Item
+	currency    currency.Code
+	expected    decimal.Decimal
+	actual      decimal.Decimal
+	difference  decimal.Decimal
+	discrepancy LedgerDiscrepancy
+	since       time.Time
+	activity    bool
+}
+
+// LedgerBalance is the reconciliation of a currency in a sub account.
+// Difference is the exchange balance less the expected balance
+type LedgerBalance struct {
+	Exchange    string
+	SubAccount  string
+	Asset       asset.Item
+	Currency    currency.Code
+	Expected    decimal.Decimal
+	Actual      decimal.Decimal
+	Difference  decimal.Decimal
+	Discrepancy LedgerDiscrepancy
+	// Since is when the current discrepancy was first found
+	Since time.Time
+}
+
+// LedgerReconciliation is the result of a reconciliation, the balances of
+// every reconciled exchange and the errors encountered importing history
+type LedgerReconciliation struct {
+	Time     time.Time
+	Imported int
+	Balances []LedgerBalance
+	Errors   []string
+}
@@ -13,11 +13,14 @@ import (
 	"github.com/gofrs/uuid"
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/communications/base"
+	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -44,6 +47,9 @@ func SetupOrderManager(exchangeManager iExchangeManager, communicationsManager i
 			commsManager:              communicationsManager,
 			wg:                        wg,
 			futuresPositionController: order.SetupPositionController(),
+			orderFeed:                 newSequencedFeed(defaultSequencedFeedCapacity),
+			fillFeed:                  newSequencedFeed(defaultSequencedFeedCapacity),
+			positionFeed:              newSequencedFeed(defaultSequencedFeedCapacity),
 		},
 		verbose: verbose,
 	}
@@ -304,7 +310,114 @@ func (m *OrderManager) UpdateOpenPositionUnrealisedPNL(e string, item asset.Item
 		return decimal.Zero, fmt.Errorf("%v %w", item, order.ErrNotFuturesAsset)
 	}
 
-	return m.orderStore.futuresPositionController.UpdateOpenPositionUnrealisedPNL(e, item, pair, last, updated)
+	pnl, err := m.orderStore.futuresPositionController.UpdateOpenPositionUnrealisedPNL(e, item, pair, last, updated)
+	if err != nil {
+		return decimal.Zero, err
+	}
+	m.orderStore.publishPosition(e, item, pair)
+	return pnl, nil
+}
+
+// ProcessFills publishes fills received from an exchange websocket to fill
+// stream subscribers
+func (m *OrderManager) ProcessFills(fills []fill.Data) error {
+	if err := m.checkStarted(); err != nil {
+		return err
+	}
+	for i := range fills {
+		m.orderStore.fillFeed.publish(fills[i])
+	}
+	return nil
+}
+
+// subscribeOrderUpdates returns a subscription to order status changes
+func (m *OrderManager) subscribeOrderUpdates(fromSequence uint64) (*feedSubscription, error) {
+	if err := m.checkStarted(); err != nil {
+		return nil, err
+	}
+	return m.orderStore.orderFeed.subscribe(fromSequence)
+}
+
+// subscribeFills returns a subscription to websocket fills
+func (m *OrderManager) subscribeFills(fromSequence uint64) (*feedSubscription, error) {
+	if err := m.checkStarted(); err != nil {
+		return nil, err
+	}
+	return m.orderStore.fillFeed.subscribe(fromSequence)
+}
+
+// subscribeRecentFills returns a subscription to websocket fills which
+// replays the retained fills
+func (m *OrderManager) subscribeRecentFills() (*feedSubscription, error) {
+	if err := m.checkStarted(); err != nil {
+		return nil, err
+	}
+	return m.orderStore.fillFeed.subscribeRetained()
+}
+
+// subscribePositions returns a subscription to futures position updates
+func (m *OrderManager) subscribePositions(fromSequence uint64) (*feedSubscription, error) {
+	if err := m.checkStarted(); err != nil {
+		return nil, err
+	}
+	return m.orderStore.positionFeed.subscribe(fromSequence)
+}
+
+func (m *OrderManager) checkStarted() error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&m.started) == 0 {
+		return fmt.Errorf("order manager %w", ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// SetupMarginEngine loads maintenance margin tiers from config so that
+// liquidation estimates are calculated for tracked futures positions
+func (m *OrderManager) SetupMarginEngine(cfg *config.MarginEngine) error {
+	if m == nil {
+		return fmt.Errorf("order manager %w", ErrNilSubsystem)
+	}
+	if cfg == nil {
+		return fmt.Errorf("%w margin engine", errNilConfig)
+	}
+	if !m.activelyTrackFuturesPositions {
+		return errFuturesTrackingDisabled
+	}
+	me, err := order.SetupMarginEngine(decimal.NewFromFloat(cfg.WarningMarginRatio), decimal.NewFromFloat(cfg.CriticalMarginRatio))
+	if err != nil {
+		return err
+	}
+	for i := range cfg.Exchanges {
+		var a asset.Item
+		a, err = asset.New(cfg.Exchanges[i].Asset)
+		if err != nil {
+			return fmt.Errorf("margin engine %v: %w", cfg.Exchanges[i].Exchange, err)
+		}
+		var mode order.MarginMode
+		mode, err = order.StringToMarginMode(cfg.Exchanges[i].Mode)
+		if err != nil {
+			return fmt.Errorf("margin engine %v %v: %w", cfg.Exchanges[i].Exchange, a, err)
+		}
+		tiers := make([]order.MaintenanceMarginTier, len(cfg.Exchanges[i].Tiers))
+		for j := range cfg.Exchanges[i].Tiers {
+			tiers[j] = order.MaintenanceMarginTier{
+				MaxNotional:           decimal.NewFromFloat(cfg.Exchanges[i].Tiers[j].MaxNotional),
+				MaintenanceMarginRate: decimal.NewFromFloat(cfg.Exchanges[i].Tiers[j].MaintenanceMarginRate),
+				MaintenanceAmount:     decimal.NewFromFloat(cfg.Exchanges[i].Tiers[j].MaintenanceAmount),
+			}
+		}
+		err = me.SetMaintenanceMarginTiers(cfg.Exchanges[i].Exchange, a, mode, tiers)
+		if err != nil {
+			return err
+		}
+	}
+	m.marginRiskMtx.Lock()
+	m.marginEngine = me
+	m.marginRisk = make(map[string]order.MarginRisk)
+	m.marginRiskMtx.Unlock()
+	return nil
 }
 
 // GetOrderInfo calls the exchange's wrapper GetOrderInfo function
@@ -487,10 +600,11 @@ func (m *OrderManager) Submit(ctx context.Context, newOrder *order.Submit) (*Ord
 
 	result, err := exch.SubmitOrder(ctx, newOrder)
 	if err != nil {
+		log.ErrorfWithFields(log.OrderMgr, tracing.LogFields(ctx), "Exchange %s unable to submit order: %v", newOrder.Exchange, err)
 		return nil, err
 	}
 
-	return m.processSubmittedOrder(result)
+	return m.processSubmittedOrder(result, tracing.CorrelationID(ctx))
 }
 
 // SubmitFakeOrder runs through the same process as order submission
@@ -526,7 +640,7 @@ func (m *OrderManager) SubmitFakeOrder(newOrder *order.Submit, resultingOrder *o
 				err)
 		}
 	}
-	return m.processSubmittedOrder(resultingOrder)
+	return m.processSubmittedOrder(resultingOrder, "")
 }
 
 // GetOrdersSnapshot returns a snapshot of all orders in the orderstore. It optionally filters any orders that do not match the status
@@ -573,8 +687,9 @@ func (m *OrderManager) GetOrdersActive(f *order.Filter) ([]order.Detail, error)
 	return m.orderStore.getActiveOrders(f), nil
 }
 
-// processSubmittedOrder adds a new order to the manager
-func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse) (*OrderSubmitResponse, error) {
+// processSubmittedOrder adds a new order to the manager, storing the
+// correlation ID of the request which submitted it
+func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse, correlationID string) (*OrderSubmitResponse, error) {
 	if newOrderResp == nil {
 		return nil, order.ErrOrderDetailIsNil
 	}
@@ -588,6 +703,7 @@ func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse)
 	if err != nil {
 		return nil, err
 	}
+	detail.CorrelationID = correlationID
 
 	msg := fmt.Sprintf("Exchange %s submitted order ID=%v [Ours: %v] pair=%v price=%v amount=%v quoteAmount=%v side=%v type=%v for time %v.",
 		detail.Exchange,
@@ -601,7 +717,11 @@ func (m *OrderManager) processSubmittedOrder(newOrderResp *order.SubmitResponse)
 		detail.Type,
 		detail.Date)
 
-	log.Debugln(log.OrderMgr, msg)
+	var fields log.ExtraFields
+	if correlationID != "" {
+		fields = log.ExtraFields{tracing.CorrelationIDKey: correlationID}
+	}
+	log.DebuglnWithFields(log.OrderMgr, fields, msg)
 	if m.orderStore.commsManager != nil {
 		m.orderStore.commsManager.PushEvent(base.Event{Type: "order", Message: msg})
 	}
@@ -764,6 +884,7 @@ func (m *OrderManager) processFuturesPositions(exch exchange.IBotExchange, posit
 			return err
 		}
 	}
+	m.orderStore.publishPosition(position.Exchange, position.Asset, position.Pair)
 	_, err = m.orderStore.futuresPositionController.GetOpenPosition(position.Exchange, position.Asset, position.Pair)
 	if err != nil {
 		if errors.Is(err, order.ErrPositionNotFound) {
@@ -779,6 +900,10 @@ func (m *OrderManager) processFuturesPositions(exch exchange.IBotExchange, posit
 	if err != nil {
 		return fmt.Errorf("%w when updating unrealised PNL for %v %v %v", err, position.Exchange, position.Asset, position.Pair)
 	}
+	err = m.updateLiquidationEstimate(exch, position, tick.Last)
+	if err != nil {
+		log.Errorf(log.OrderMgr, "unable to estimate liquidation for %v %v %v. err: %v", position.Exchange, position.Asset, position.Pair, err)
+	}
 	isPerp, err := exch.IsPerpetualFutureCurrency(position.Asset, position.Pair)
 	if err != nil {
 		return err
@@ -801,6 +926,123 @@ func (m *OrderManager) processFuturesPositions(exch exchange.IBotExchange, posit
 	return m.orderStore.futuresPositionController.TrackFundingDetails(frp)
 }
 
+// updateLiquidationEstimate estimates the liquidation price of an open
+// position from the exchange's collateral and stores it against the position.
+// Exchanges liquidate against the mark price, which is taken from the
+// positions returned with the collateral. When the exchange does not return
+// one the last traded price is used instead, which can stray from the mark
+// price during volatile markets. Positions without configured margin tiers are
+// skipped
+func (m *OrderManager) updateLiquidationEstimate(exch exchange.IBotExchange, position *order.PositionDetails, lastPrice float64) error {
+	m.marginRiskMtx.Lock()
+	me := m.marginEngine
+	m.marginRiskMtx.Unlock()
+	if me == nil {
+		return nil
+	}
+	mode, err := me.GetMarginMode(position.Exchange, position.Asset)
+	if err != nil {
+		if errors.Is(err, order.ErrNoMaintenanceMarginTiers) {
+			return nil
+		}
+		return err
+	}
+	pos, err := m.orderStore.futuresPositionController.GetOpenPosition(position.Exchange, position.Asset, position.Pair)
+	if err != nil {
+		return err
+	}
+	totalCollateral, err := fetchTotalCollateral(context.TODO(), exch, position.Asset)
+	if err != nil {
+		return err
+	}
+	collateral, err := order.PositionCollateral(mode, position.Pair, pos.UnrealisedPNL, totalCollateral)
+	if err != nil {
+		return err
+	}
+	if pos.CollateralCurrency.IsEmpty() {
+		pos.CollateralCurrency = totalCollateral.CollateralCurrency
+	}
+	markPrice, ok := order.PositionMarkPrice(position.Pair, totalCollateral)
+	if !ok {
+		markPrice = decimal.NewFromFloat(lastPrice)
+	}
+	est, err := me.EstimatePositionLiquidation(pos, collateral, markPrice)
+	if err != nil {
+		return err
+	}
+	err = m.orderStore.futuresPositionController.SetOpenPositionLiquidationEstimate(position.Exchange, position.Asset, position.Pair, est)
+	if err != nil {
+		return err
+	}
+	m.notifyMarginRisk(pos, est)
+	return nil
+}
+
+// notifyMarginRisk pushes an event through the communications manager when
+// a position's margin risk rises to a warning or worse
+func (m *OrderManager) notifyMarginRisk(pos *order.Position, est *order.LiquidationEstimate) {
+	key := strings.ToLower(pos.Exchange) + pos.Asset.String() + pos.Pair.String()
+	m.marginRiskMtx.Lock()
+	previous := m.marginRisk[key]
+	m.marginRisk[key] = est.Risk
+	m.marginRiskMtx.Unlock()
+	if est.Risk <= previous || est.Risk == order.MarginRiskSafe {
+		return
+	}
+	msg := fmt.Sprintf("Exchange %s %v %v %v position margin risk is %v. Margin ratio: %v mark price: %v",
+		pos.Exchange,
+		pos.Asset,
+		pos.Pair,
+		pos.LatestDirection,
+		est.Risk,
+		est.MarginRatio.Round(4),
+		est.MarkPrice)
+	if est.HasLiquidationPrice {
+		msg += fmt.Sprintf(" estimated liquidation price: %v distance: %v%%",
+			est.LiquidationPrice.Round(8),
+			est.DistanceToLiquidation.Mul(decimal.NewFromInt(100)).Round(2))
+	}
+	log.Warnln(log.OrderMgr, msg)
+	m.orderStore.commsManager.PushEvent(base.Event{Type: "margin", Message: msg})
+}
+
+// fetchTotalCollateral calculates an exchange's total collateral from the
+// holdings of the sub account matching its credentials
+func fetchTotalCollateral(ctx context.Context, exch exchange.IBotExchange, a asset.Item) (*order.TotalCollateralResponse, error) {
+	ai, err := exch.FetchAccountInfo(ctx, a)
+	if err != nil {
+		return nil, err
+	}
+	if len(ai.Accounts) == 0 {
+		return nil, fmt.Errorf("%w for %s %s", errNoAccountInformation, exch.GetName(), a)
+	}
+	acc := &ai.Accounts[0]
+	if creds, err := exch.GetCredentials(ctx); err == nil {
+		for i := range ai.Accounts {
+			if strings.EqualFold(creds.SubAccount, ai.Accounts[i].ID) ||
+				(creds.SubAccount == "" && ai.Accounts[i].ID == "main") {
+				acc = &ai.Accounts[i]
+				break
+			}
+		}
+	}
+	calculators := make([]order.CollateralCalculator, len(acc.Currencies))
+	for i := range acc.Currencies {
+		total := decimal.NewFromFloat(acc.Currencies[i].Total)
+		free := decimal.NewFromFloat(acc.Currencies[i].AvailableWithoutBorrow)
+		calculators[i] = order.CollateralCalculator{
+			CollateralCurrency: acc.Currencies[i].Currency,
+			Asset:              a,
+			FreeCollateral:     free,
+			LockedCollateral:   total.Sub(free),
+		}
+	}
+	return exch.CalculateTotalCollateral(ctx, &order.TotalCollateralCalculator{
+		CollateralAssets: calculators,
+		FetchPositions:   true,
+	})
+}
+
 func (m *OrderManager) processMatchingOrders(exch exchange.IBotExchange, orders []order.Detail, wg *sync.WaitGroup) {
 	for x := range orders {
 		if time.Since(orders[x].LastUpdated) < time.Minute {
@@ -965,14 +1207,11 @@ func (s *store) updateExisting(od *order.Detail) error {
 		if err != nil {
 			return err
 		}
+		s.orderFeed.publish(r[x].Copy())
 		if !r[x].AssetType.IsFutures() {
 			return nil
 		}
-		err = s.futuresPositionController.TrackNewOrder(r[x])
-		if err != nil && !errors.Is(err, order.ErrPositionClosed) {
-			return err
-		}
-		return nil
+		return s.trackFuturesOrder(r[x])
 	}
 	return ErrOrderNotFound
 }
@@ -991,14 +1230,11 @@ func (s *store) modifyExisting(id string, mod *order.ModifyResponse) error {
 			continue
 		}
 		r[x].UpdateOrderFromModifyResponse(mod)
+		s.orderFeed.publish(r[x].Copy())
 		if !r[x].AssetType.IsFutures() {
 			return nil
 		}
-		err := s.futuresPositionController.TrackNewOrder(r[x])
-		if err != nil && !errors.Is(err, order.ErrPositionClosed) {
-			return err
-		}
-		return nil
+		return s.trackFuturesOrder(r[x])
 	}
 	return ErrOrderNotFound
 }
@@ -1017,8 +1253,8 @@ func (s *store) upsert(od *order.Detail) (*OrderUpsertResponse, error) {
 	s.m.Lock()
 	defer s.m.Unlock()
 	if od.AssetType.IsFutures() {
-		err = s.futuresPositionController.TrackNewOrder(od)
-		if err != nil && !errors.Is(err, order.ErrPositionClosed) {
+		err = s.trackFuturesOrder(od)
+		if err != nil {
 			return nil, err
 		}
 	}
@@ -1033,6 +1269,7 @@ func (s *store) upsert(od *order.Detail) (*OrderUpsertResponse, error) {
 		if err != nil {
 			return nil, err
 		}
+		s.orderFeed.publish(exchangeOrders[x].Copy())
 		return &OrderUpsertResponse{
 			OrderDetails: exchangeOrders[x].Copy(),
 			IsNewOrder:   false,
@@ -1041,6 +1278,7 @@ func (s *store) upsert(od *order.Detail) (*OrderUpsertResponse, error) {
 	// Untracked websocket orders will not have internalIDs yet
 	od.GenerateInternalOrderID()
 	s.Orders[lName] = append(s.Orders[lName], od)
+	s.orderFeed.publish(od.Copy())
 	return &OrderUpsertResponse{OrderDetails: od.Copy(), IsNewOrder: true}, nil
 }
 
@@ -1079,10 +1317,43 @@ func (s *store) add(det *order.Detail) error {
 	s.m.Lock()
 	defer s.m.Unlock()
 	s.Orders[name] = append(s.Orders[name], det)
+	s.orderFeed.publish(det.Copy())
 	if !det.AssetType.IsFutures() {
 		return nil
 	}
-	return s.futuresPositionController.TrackNewOrder(det)
+	err = s.futuresPositionController.TrackNewOrder(det)
+	if err != nil {
+		return err
+	}
+	s.publishPosition(det.Exchange, det.AssetType, det.Pair)
+	return nil
+}
+
+// trackFuturesOrder tracks a futures order against its position and
+// publishes the resulting position. Orders for closed positions are ignored
+func (s *store) trackFuturesOrder(od *order.Detail) error {
+	err := s.futuresPositionController.TrackNewOrder(od)
+	if err != nil {
+		if errors.Is(err, order.ErrPositionClosed) {
+			return nil
+		}
+		return err
+	}
+	s.publishPosition(od.Exchange, od.AssetType, od.Pair)
+	return nil
+}
+
+// publishPosition publishes the latest tracked position of an exchange asset
+// pair
+func (s *store) publishPosition(exch string, item asset.Item, pair currency.Pair) {
+	if s.positionFeed == nil {
+		return
+	}
+	positions, err := s.futuresPositionController.GetPositionsForExchange(exch, item, pair)
+	if err != nil || len(positions) == 0 {
+		return
+	}
+	s.positionFeed.publish(positions[len(positions)-1])
 }
 
 // getFilteredOrders returns a filtered copy of the orders
@@ -12,10 +12,12 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/communications/base"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -1337,6 +1339,49 @@ func TestSubmitFakeOrder(t *testing.T) {
 	}
 }
 
+func TestProcessSubmittedOrderCorrelationID(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	err = em.Add(exch)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	m := &OrderManager{}
+	m.orderStore.exchangeManager = em
+	m.orderStore.Orders = make(map[string][]*order.Detail)
+	ord := &order.Submit{
+		Exchange:  testExchange,
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USD),
+		Side:      order.Buy,
+		Type:      order.Market,
+		Amount:    1,
+	}
+	resp, err := ord.DeriveSubmitResponse("correlated")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	result, err := m.processSubmittedOrder(resp, "1337")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if result.CorrelationID != "1337" {
+		t.Errorf("received '%v', expected '%v'", result.CorrelationID, "1337")
+	}
+	stored, err := m.orderStore.getByExchangeAndID(testExchange, "correlated")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if stored.CorrelationID != "1337" {
+		t.Errorf("received '%v', expected '%v'", stored.CorrelationID, "1337")
+	}
+}
+
 func TestGetOrdersSnapshot(t *testing.T) {
 	t.Parallel()
 	o := &OrderManager{}
@@ -1658,3 +1703,303 @@ func TestProcessFuturesPositions(t *testing.T) {
 		t.Errorf("received '%v', expected '%v'", err, nil)
 	}
 }
+
+// omfComms aka order manager fake communications manager records events
+type omfComms struct {
+	m      sync.Mutex
+	events []base.Event
+}
+
+func (c *omfComms) PushEvent(evt base.Event) {
+	c.m.Lock()
+	c.events = append(c.events, evt)
+	c.m.Unlock()
+}
+
+func TestOrderManagerSetupMarginEngine(t *testing.T) {
+	t.Parallel()
+	var o *OrderManager
+	err := o.SetupMarginEngine(&config.MarginEngine{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	var wg sync.WaitGroup
+	o, err = SetupOrderManager(NewExchangeManager(), &CommunicationManager{}, &wg, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = o.SetupMarginEngine(nil)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	err = o.SetupMarginEngine(&config.MarginEngine{})
+	if !errors.Is(err, errFuturesTrackingDisabled) {
+		t.Errorf("received '%v', expected '%v'", err, errFuturesTrackingDisabled)
+	}
+	o.activelyTrackFuturesPositions = true
+	cfg := &config.MarginEngine{
+		WarningMarginRatio:  0.5,
+		CriticalMarginRatio: 0.8,
+		Exchanges: []config.MarginEngineTiers{
+			{Exchange: "binance", Asset: "usdtmarginedfutures", Mode: "portfolio", Tiers: []config.MaintenanceMarginTier{{MaintenanceMarginRate: 0.01}}},
+		},
+	}
+	err = o.SetupMarginEngine(cfg)
+	if !errors.Is(err, order.ErrInvalidMarginMode) {
+		t.Errorf("received '%v', expected '%v'", err, order.ErrInvalidMarginMode)
+	}
+	cfg.Exchanges[0].Mode = "cross"
+	err = o.SetupMarginEngine(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	mode, err := o.marginEngine.GetMarginMode("Binance", asset.USDTMarginedFutures)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if mode != order.CrossMargin {
+		t.Errorf("received '%v', expected '%v'", mode, order.CrossMargin)
+	}
+}
+
+func TestUpdateLiquidationEstimate(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("binance")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Name = fakeExchangeName
+	b.Enabled = true
+	cp := currency.NewPairWithDelimiter("BTC", "USDT", "-")
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.USDTMarginedFutures: {
+			AssetEnabled:  convert.BoolPtr(true),
+			RequestFormat: &currency.PairFormat{Delimiter: "-"},
+			ConfigFormat:  &currency.PairFormat{Delimiter: "-"},
+			Available:     currency.Pairs{cp},
+			Enabled:       currency.Pairs{cp},
+		},
+	}
+	fakeExchange := fExchange{IBotExchange: exch}
+	if err = em.Add(fakeExchange); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	comms := &omfComms{}
+	var wg sync.WaitGroup
+	o, err := SetupOrderManager(em, comms, &wg, false, true, time.Hour)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	o.started = 1
+	position := &order.PositionDetails{
+		Exchange: fakeExchangeName,
+		Asset:    asset.USDTMarginedFutures,
+		Pair:     cp,
+		Orders: []order.Detail{
+			{
+				Exchange:  fakeExchangeName,
+				AssetType: asset.USDTMarginedFutures,
+				Pair:      cp,
+				OrderID:   "1337",
+				Side:      order.Long,
+				Type:      order.Market,
+				Amount:    1,
+				Price:     1337,
+				Date:      time.Now().Add(-time.Hour),
+			},
+		},
+	}
+	// without a margin engine no estimate is made
+	err = o.updateLiquidationEstimate(fakeExchange, position, 1337)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = o.SetupMarginEngine(&config.MarginEngine{
+		WarningMarginRatio:  0.5,
+		CriticalMarginRatio: 0.8,
+		Exchanges: []config.MarginEngineTiers{
+			{Exchange: fakeExchangeName, Asset: "usdtmarginedfutures", Mode: "cross", Tiers: []config.MaintenanceMarginTier{{MaintenanceMarginRate: 0.01}}},
+		},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = o.processFuturesPositions(fakeExchange, position)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pos, err := o.GetOpenFuturesPosition(fakeExchangeName, asset.USDTMarginedFutures, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	est := pos.LiquidationEstimate
+	if est == nil {
+		t.Fatal("expected liquidation estimate")
+	}
+	// 1 BTC long at 1337 backed by 1337 USD of cross collateral
+	if est.Mode != order.CrossMargin || est.HasLiquidationPrice || est.Risk != order.MarginRiskSafe {
+		t.Errorf("received '%+v', expected a safe cross position without a liquidation price", est)
+	}
+	// the exchange returns no mark price so the last price is used
+	if !est.MarkPrice.Equal(decimal.NewFromInt(1337)) {
+		t.Errorf("received '%v', expected '%v'", est.MarkPrice, 1337)
+	}
+	err = o.updateLiquidationEstimate(markPriceExchange{fExchange: fakeExchange, pair: cp, markPrice: decimal.NewFromInt(1400)}, position, 1337)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pos, err = o.GetOpenFuturesPosition(fakeExchangeName, asset.USDTMarginedFutures, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !pos.LiquidationEstimate.MarkPrice.Equal(decimal.NewFromInt(1400)) {
+		t.Errorf("received '%v', expected '%v'", pos.LiquidationEstimate.MarkPrice, 1400)
+	}
+	if len(comms.events) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(comms.events), 0)
+	}
+
+	o.notifyMarginRisk(pos, &order.LiquidationEstimate{Risk: order.MarginRiskCritical})
+	o.notifyMarginRisk(pos, &order.LiquidationEstimate{Risk: order.MarginRiskWarning})
+	o.notifyMarginRisk(pos, &order.LiquidationEstimate{Risk: order.MarginRiskLiquidation})
+	if len(comms.events) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(comms.events), 2)
+	}
+	if comms.events[0].Type != "margin" || !strings.Contains(comms.events[1].Message, "liquidation") {
+		t.Errorf("received '%+v', expected critical then liquidation margin events", comms.events)
+	}
+}
+
+// markPriceExchange returns a mark price for a position with its collateral
+type markPriceExchange struct {
+	fExchange
+	pair      currency.Pair
+	markPrice decimal.Decimal
+}
+
+func (m markPriceExchange) CalculateTotalCollateral(ctx context.Context, calc *order.TotalCollateralCalculator) (*order.TotalCollateralResponse, error) {
+	resp, err := m.fExchange.CalculateTotalCollateral(ctx, calc)
+	if err != nil {
+		return nil, err
+	}
+	resp.BreakdownOfPositions = []order.CollateralByPosition{{PositionCurrency: m.pair, MarkPrice: m.markPrice}}
+	return resp, nil
+}
+
+// offlineOrderManager returns a started order manager with an exchange which
+// has not been set up, so no requests are sent
+func offlineOrderManager(t *testing.T) *OrderManager {
+	t.Helper()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exch.SetDefaults()
+	err = em.Add(omfExchange{IBotExchange: exch})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var wg sync.WaitGroup
+	m, err := SetupOrderManager(em, &CommunicationManager{}, &wg, false, false, 0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	m.started = 1
+	return m
+}
+
+func TestOrderLifecycleFeeds(t *testing.T) {
+	t.Parallel()
+	m := offlineOrderManager(t)
+	orders, err := m.subscribeOrderUpdates(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer orders.Release()
+	positions, err := m.subscribePositions(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer positions.Release()
+
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	od := &order.Detail{
+		Exchange:  testExchange,
+		OrderID:   "TestOrderLifecycleFeeds",
+		AssetType: asset.Futures,
+		Pair:      cp,
+		Side:      order.Long,
+		Type:      order.Market,
+		Status:    order.Filled,
+		Price:     1337,
+		Amount:    1,
+		Date:      time.Now(),
+	}
+	err = m.Add(od)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ev := <-orders.Channel()
+	d, ok := ev.Data.(order.Detail)
+	if !ok {
+		t.Fatal(common.GetTypeAssertError("order.Detail", ev.Data))
+	}
+	if d.OrderID != od.OrderID || ev.Sequence != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", d.OrderID, od.OrderID)
+	}
+	ev = <-positions.Channel()
+	p, ok := ev.Data.(order.Position)
+	if !ok {
+		t.Fatal(common.GetTypeAssertError("order.Position", ev.Data))
+	}
+	if !p.Pair.Equal(cp) || p.Status != order.Open {
+		t.Errorf("received: '%v' but expected: '%v'", p.Status, order.Open)
+	}
+
+	od.Status = order.Cancelled
+	err = m.UpdateExistingOrder(od)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ev = <-orders.Channel()
+	if d, ok = ev.Data.(order.Detail); !ok || d.Status != order.Cancelled || ev.Sequence != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", d.Status, order.Cancelled)
+	}
+}
+
+func TestProcessFills(t *testing.T) {
+	t.Parallel()
+	o := &OrderManager{}
+	err := o.ProcessFills(nil)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	_, err = o.subscribeFills(0)
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	m := offlineOrderManager(t)
+	fills, err := m.subscribeFills(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer fills.Release()
+	err = m.ProcessFills([]fill.Data{{Exchange: testExchange, TradeID: "1"}, {Exchange: testExchange, TradeID: "2"}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for _, id := range []string{"1", "2"} {
+		ev := <-fills.Channel()
+		f, ok := ev.Data.(fill.Data)
+		if !ok {
+			t.Fatal(common.GetTypeAssertError("fill.Data", ev.Data))
+		}
+		if f.TradeID != id {
+			t.Errorf("received: '%v' but expected: '%v'", f.TradeID, id)
+		}
+	}
+}
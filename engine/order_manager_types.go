@@ -48,6 +48,9 @@ type OrderManager struct {
 	verbose                       bool
 	activelyTrackFuturesPositions bool
 	futuresPositionSeekDuration   time.Duration
+	marginEngine                  *order.MarginEngine
+	marginRiskMtx                 sync.Mutex
+	marginRisk                    map[string]order.MarginRisk
 }
 
 // store holds all orders by exchange
@@ -58,6 +61,11 @@ type store struct {
 	exchangeManager           iExchangeManager
 	wg                        *sync.WaitGroup
 	futuresPositionController order.PositionController
+	// orderFeed, fillFeed and positionFeed publish order, fill and futures
+	// position lifecycle events for streaming
+	orderFeed    *sequencedFeed
+	fillFeed     *sequencedFeed
+	positionFeed *sequencedFeed
 }
 
 // OrderSubmitResponse contains the order response along with an internal order ID
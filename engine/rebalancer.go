@@ -0,0 +1,571 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync/atomic"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+// SetupRebalancer creates a rebalancer subsystem. The portfolio manager is
+// only required when offline holdings are included. Orders are never
+// submitted when dry run is set
+func SetupRebalancer(em iExchangeManager, om rebalanceOrderSubmitter, pm iPortfolioManager, cfg *config.Rebalancer, dryRun bool) (*Rebalancer, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if om == nil {
+		return nil, errNilOrderSubmitter
+	}
+	if cfg == nil {
+		return nil, errNilConfig
+	}
+	if len(cfg.Targets) == 0 {
+		return nil, errNoRebalanceTargets
+	}
+	if len(cfg.Allocations) == 0 {
+		return nil, errNoRebalanceExchanges
+	}
+	reportingCurrency := currency.NewCode(cfg.ReportingCurrency).Upper()
+	if reportingCurrency.IsEmpty() {
+		reportingCurrency = currency.USD
+	}
+	quoteCurrency := currency.NewCode(cfg.QuoteCurrency).Upper()
+	if quoteCurrency.IsEmpty() {
+		quoteCurrency = currency.USDT
+	}
+	targets := make([]rebalanceTarget, len(cfg.Targets))
+	total := decimal.Zero
+	for i := range cfg.Targets {
+		weight := decimal.NewFromFloat(cfg.Targets[i].Weight)
+		if cfg.Targets[i].Currency == "" || weight.IsNegative() {
+			return nil, fmt.Errorf("%w: %s %v", errInvalidRebalanceWeight, cfg.Targets[i].Currency, cfg.Targets[i].Weight)
+		}
+		total = total.Add(weight)
+		targets[i] = rebalanceTarget{
+			currency: currency.NewCode(cfg.Targets[i].Currency).Upper(),
+			weight:   weight,
+			band:     decimal.Max(decimal.NewFromFloat(cfg.Targets[i].Band), decimal.Zero),
+		}
+	}
+	if total.GreaterThan(decimal.NewFromInt(1)) {
+		return nil, fmt.Errorf("%w: total %s", errInvalidRebalanceWeight, total)
+	}
+	allocations := make([]rebalanceAllocation, len(cfg.Allocations))
+	for i := range cfg.Allocations {
+		share := decimal.NewFromFloat(cfg.Allocations[i].Weight)
+		if !share.IsPositive() {
+			share = decimal.NewFromInt(1)
+		}
+		allocations[i] = rebalanceAllocation{exchange: cfg.Allocations[i].Exchange, share: share}
+	}
+	return &Rebalancer{
+		exchangeManager:   em,
+		orderManager:      om,
+		portfolioManager:  pm,
+		shutdown:          make(chan struct{}),
+		reportingCurrency: reportingCurrency,
+		quoteCurrency:     quoteCurrency,
+		targets:           targets,
+		allocations:       allocations,
+		minimumTradeValue: decimal.NewFromFloat(cfg.MinimumTradeValue),
+		includeOffline:    cfg.IncludeOffline,
+		interval:          cfg.Interval,
+		dryRun:            cfg.DryRun || dryRun,
+		verbose:           cfg.Verbose,
+	}, nil
+}
+
+// Start runs the subsystem. Rebalances are only executed on a schedule when
+// an interval is set
+func (r *Rebalancer) Start() error {
+	if r == nil {
+		return fmt.Errorf("%s %w", RebalancerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&r.started, 0, 1) {
+		return fmt.Errorf("%s %w", RebalancerName, ErrSubSystemAlreadyStarted)
+	}
+	r.shutdown = make(chan struct{})
+	if r.interval > 0 {
+		r.wg.Add(1)
+		go r.run()
+		log.Debugf(log.Global, "Rebalancer %s, rebalancing every %s, dry run %v", MsgSubSystemStarted, r.interval, r.dryRun)
+	} else {
+		log.Debugf(log.Global, "Rebalancer %s, rebalancing on demand, dry run %v", MsgSubSystemStarted, r.dryRun)
+	}
+	return nil
+}
+
+// IsRunning checks whether the subsystem is running
+func (r *Rebalancer) IsRunning() bool {
+	if r == nil {
+		return false
+	}
+	return atomic.LoadInt32(&r.started) == 1
+}
+
+// Stop stops the subsystem
+func (r *Rebalancer) Stop() error {
+	if r == nil {
+		return fmt.Errorf("%s %w", RebalancerName, ErrNilSubsystem)
+	}
+	if !atomic.CompareAndSwapInt32(&r.started, 1, 0) {
+		return fmt.Errorf("%s %w", RebalancerName, ErrSubSystemNotStarted)
+	}
+	close(r.shutdown)
+	r.wg.Wait()
+	log.Debugf(log.Global, "Rebalancer %s", MsgSubSystemShutdown)
+	return nil
+}
+
+func (r *Rebalancer) run() {
+	defer r.wg.Done()
+	t := time.NewTicker(r.interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-r.shutdown:
+			return
+		case <-t.C:
+			if _, err := r.Execute(context.TODO(), false); err != nil && !errors.Is(err, errAlreadyRunning) {
+				log.Errorf(log.Global, "Rebalancer: %v", err)
+			}
+		}
+	}
+}
+
+// Plan returns the trades which would move the holdings towards the target
+// weights without submitting them
+func (r *Rebalancer) Plan(ctx context.Context) (*RebalancePlan, error) {
+	if err := r.checkRunning(); err != nil {
+		return nil, err
+	}
+	if !atomic.CompareAndSwapInt32(&r.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot plan rebalance, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&r.processing, 0)
+	plan, err := r.buildPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan.DryRun = r.dryRun
+	return plan, nil
+}
+
+// Execute plans a rebalance and submits its trades as market orders through
+// the order manager, sales before purchases so their proceeds can fund the
+// purchases. No orders are submitted in dry run mode, either requested or
+// configured
+func (r *Rebalancer) Execute(ctx context.Context, dryRun bool) (*RebalancePlan, error) {
+	if err := r.checkRunning(); err != nil {
+		return nil, err
+	}
+	if !atomic.CompareAndSwapInt32(&r.processing, 0, 1) {
+		return nil, fmt.Errorf("cannot execute rebalance, %w", errAlreadyRunning)
+	}
+	defer atomic.StoreInt32(&r.processing, 0)
+	plan, err := r.buildPlan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	plan.DryRun = dryRun || r.dryRun
+	for i := range plan.Trades {
+		trade := &plan.Trades[i]
+		if trade.Status != RebalancePlanned {
+			continue
+		}
+		if plan.DryRun {
+			trade.Status = RebalanceDryRun
+			continue
+		}
+		resp, submitErr := r.orderManager.Submit(ctx, &order.Submit{
+			Exchange:  trade.Exchange,
+			Pair:      trade.Pair,
+			AssetType: asset.Spot,
+			Side:      trade.Side,
+			Type:      order.Market,
+			Amount:    trade.Amount.InexactFloat64(),
+		})
+		if submitErr != nil {
+			trade.Status = RebalanceFailed
+			trade.Reason = submitErr.Error()
+			plan.Errors = append(plan.Errors, fmt.Sprintf("%s %s %s: %v", trade.Exchange, trade.Side, trade.Pair, submitErr))
+			continue
+		}
+		trade.Status = RebalanceSubmitted
+		trade.OrderID = resp.OrderID
+	}
+	plan.Executed = true
+	for i := range plan.Trades {
+		if plan.Trades[i].Status == RebalanceSkipped && !r.verbose {
+			continue
+		}
+		log.Infof(log.Global, "Rebalancer %s %s %s %s value %s %s: %s %s",
+			plan.Trades[i].Exchange, plan.Trades[i].Side, plan.Trades[i].Amount, plan.Trades[i].Pair,
+			plan.Trades[i].Value, plan.ReportingCurrency, plan.Trades[i].Status, plan.Trades[i].Reason)
+	}
+	return plan, nil
+}
+
+// Exchanges returns the names of the exchanges the rebalancer trades on
+func (r *Rebalancer) Exchanges() []string {
+	if r == nil {
+		return nil
+	}
+	names := make([]string, len(r.allocations))
+	for i := range r.allocations {
+		names[i] = r.allocations[i].exchange
+	}
+	return names
+}
+
+func (r *Rebalancer) checkRunning() error {
+	if r == nil {
+		return fmt.Errorf("%s %w", RebalancerName, ErrNilSubsystem)
+	}
+	if atomic.LoadInt32(&r.started) == 0 {
+		return fmt.Errorf("%s %w", RebalancerName, ErrSubSystemNotStarted)
+	}
+	return nil
+}
+
+// rebalanceExchange is an allocated exchange, its share of each trade, its
+// spot holdings and the pairs and prices of the target currencies
+type rebalanceExchange struct {
+	exch     exchange.IBotExchange
+	share    decimal.Decimal
+	holdings map[string]*rebalanceHolding
+	pairs    map[string]currency.Pair
+	prices   map[string]decimal.Decimal
+}
+
+// buildPlan values the holdings of the allocated exchanges and offline
+// holdings and plans the trades of each currency outside of its band. The
+// difference between the value and target value of a currency is split
+// between the exchanges by their allocation. Currencies without a target are
+// not traded and the quote currency takes up the remaining weight
+func (r *Rebalancer) buildPlan(ctx context.Context) (*RebalancePlan, error) {
+	plan := &RebalancePlan{
+		Time:              time.Now(),
+		ReportingCurrency: r.reportingCurrency,
+		QuoteCurrency:     r.quoteCurrency,
+	}
+	amounts := make(map[string]decimal.Decimal)
+	var exchanges []*rebalanceExchange
+	for i := range r.allocations {
+		exch, err := r.exchangeManager.GetExchangeByName(r.allocations[i].exchange)
+		if err != nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("%s: %v", r.allocations[i].exchange, err))
+			continue
+		}
+		holdings, err := exch.UpdateAccountInfo(ctx, asset.Spot)
+		if err != nil {
+			plan.Errors = append(plan.Errors, fmt.Sprintf("%s: account info: %v", exch.GetName(), err))
+			continue
+		}
+		e := &rebalanceExchange{
+			exch:     exch,
+			share:    r.allocations[i].share,
+			holdings: make(map[string]*rebalanceHolding),
+			pairs:    make(map[string]currency.Pair),
+			prices:   make(map[string]decimal.Decimal),
+		}
+		for j := range holdings.Accounts {
+			for k := range holdings.Accounts[j].Currencies {
+				bal := &holdings.Accounts[j].Currencies[k]
+				code := bal.Currency.Upper().String()
+				h, ok := e.holdings[code]
+				if !ok {
+					h = &rebalanceHolding{}
+					e.holdings[code] = h
+				}
+				total := decimal.NewFromFloat(bal.Total - bal.Borrowed)
+				free := decimal.NewFromFloat(bal.Free)
+				if bal.Free == 0 && bal.Hold == 0 {
+					free = total
+				}
+				h.total = h.total.Add(total)
+				h.free = h.free.Add(free)
+				amounts[code] = amounts[code].Add(total)
+			}
+		}
+		r.loadPrices(ctx, e, plan)
+		exchanges = append(exchanges, e)
+	}
+	if len(exchanges) == 0 {
+		return nil, errNoRebalanceExchanges
+	}
+	shares := decimal.Zero
+	for i := range exchanges {
+		shares = shares.Add(exchanges[i].share)
+	}
+	for i := range exchanges {
+		exchanges[i].share = exchanges[i].share.Div(shares)
+	}
+	if r.includeOffline && r.portfolioManager != nil {
+		summary := r.portfolioManager.GetPortfolioSummary()
+		for i := range summary.Offline {
+			code := summary.Offline[i].Coin.Upper().String()
+			amounts[code] = amounts[code].Add(decimal.NewFromFloat(summary.Offline[i].Balance))
+		}
+	}
+
+	targets := make(map[string]*rebalanceTarget)
+	for i := range r.targets {
+		targets[r.targets[i].currency.String()] = &r.targets[i]
+		if _, ok := amounts[r.targets[i].currency.String()]; !ok {
+			amounts[r.targets[i].currency.String()] = decimal.Zero
+		}
+	}
+	if _, ok := amounts[r.quoteCurrency.String()]; !ok {
+		amounts[r.quoteCurrency.String()] = decimal.Zero
+	}
+	rates := make(map[string]decimal.Decimal)
+	for code, amount := range amounts {
+		rate, ok := r.rate(exchanges, currency.NewCode(code))
+		if !ok {
+			if !amount.IsZero() || targets[code] != nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s: %v to %s", code, errNoConversionRate, r.reportingCurrency))
+			}
+			continue
+		}
+		rates[code] = rate
+		plan.TotalValue = plan.TotalValue.Add(amount.Mul(rate))
+	}
+	if !plan.TotalValue.IsPositive() {
+		return nil, errNoPortfolioValue
+	}
+
+	residual := decimal.NewFromInt(1)
+	for i := range r.targets {
+		residual = residual.Sub(r.targets[i].weight)
+	}
+	for code, amount := range amounts {
+		rate, ok := rates[code]
+		if !ok {
+			continue
+		}
+		w := RebalanceWeight{
+			Currency:   currency.NewCode(code),
+			Amount:     amount,
+			Value:      amount.Mul(rate),
+			WithinBand: true,
+		}
+		w.Weight = w.Value.Div(plan.TotalValue)
+		t, targeted := targets[code]
+		switch {
+		case targeted:
+			w.Target, w.Band = t.weight, t.band
+		case code == r.quoteCurrency.String():
+			w.Target = residual
+		}
+		w.TargetValue = w.Target.Mul(plan.TotalValue)
+		if targeted && code != r.quoteCurrency.String() {
+			w.WithinBand = w.Weight.Sub(w.Target).Abs().LessThanOrEqual(w.Band)
+			if !w.WithinBand {
+				r.planTrades(plan, exchanges, t.currency, w.TargetValue.Sub(w.Value), rate)
+			}
+		}
+		plan.Weights = append(plan.Weights, w)
+	}
+	for i := range exchanges {
+		r.fundPurchases(plan, exchanges[i])
+	}
+
+	sort.Slice(plan.Weights, func(i, j int) bool {
+		return plan.Weights[i].Currency.String() < plan.Weights[j].Currency.String()
+	})
+	sort.SliceStable(plan.Trades, func(i, j int) bool {
+		if plan.Trades[i].Exchange != plan.Trades[j].Exchange {
+			return plan.Trades[i].Exchange < plan.Trades[j].Exchange
+		}
+		if plan.Trades[i].Side != plan.Trades[j].Side {
+			return plan.Trades[i].Side.IsShort()
+		}
+		return plan.Trades[i].Pair.String() < plan.Trades[j].Pair.String()
+	})
+	sort.Strings(plan.Errors)
+	return plan, nil
+}
+
+// loadPrices finds the enabled spot pair of each target currency against the
+// quote currency on an exchange and its last price
+func (r *Rebalancer) loadPrices(ctx context.Context, e *rebalanceExchange, plan *RebalancePlan) {
+	enabled, err := e.exch.GetEnabledPairs(asset.Spot)
+	if err != nil {
+		plan.Errors = append(plan.Errors, fmt.Sprintf("%s: %v", e.exch.GetName(), err))
+		return
+	}
+	for i := range r.targets {
+		code := r.targets[i].currency
+		if code.Equal(r.quoteCurrency) {
+			continue
+		}
+		for j := range enabled {
+			if !enabled[j].Base.Equal(code) || !enabled[j].Quote.Equal(r.quoteCurrency) {
+				continue
+			}
+			e.pairs[code.String()] = enabled[j]
+			t, fetchErr := e.exch.FetchTicker(ctx, enabled[j], asset.Spot)
+			if fetchErr != nil {
+				plan.Errors = append(plan.Errors, fmt.Sprintf("%s %s: %v", e.exch.GetName(), enabled[j], fetchErr))
+				break
+			}
+			if p := tickerPrice(t); p > 0 {
+				e.prices[code.String()] = decimal.NewFromFloat(p)
+			}
+			break
+		}
+	}
+}
+
+// planTrades splits the value to buy, or sell when negative, of a currency
+// between the exchanges and adds a trade for each. Sales are limited to the
+// free balance of the exchange
+func (r *Rebalancer) planTrades(plan *RebalancePlan, exchanges []*rebalanceExchange, code currency.Code, value, rate decimal.Decimal) {
+	side := order.Buy
+	if value.IsNegative() {
+		side = order.Sell
+	}
+	for i := range exchanges {
+		e := exchanges[i]
+		trade := RebalanceTrade{
+			Exchange: e.exch.GetName(),
+			Pair:     currency.NewPair(code, r.quoteCurrency),
+			Side:     side,
+			Value:    value.Abs().Mul(e.share),
+			Status:   RebalancePlanned,
+		}
+		pair, ok := e.pairs[code.String()]
+		if !ok {
+			trade.Status = RebalanceSkipped
+			trade.Reason = fmt.Sprintf("no enabled %s spot pair", trade.Pair)
+			plan.Trades = append(plan.Trades, trade)
+			continue
+		}
+		trade.Pair = pair
+		trade.Price = e.prices[code.String()]
+		if !trade.Price.IsPositive() {
+			trade.Status = RebalanceSkipped
+			trade.Reason = "no market price"
+			plan.Trades = append(plan.Trades, trade)
+			continue
+		}
+		trade.Amount = trade.Value.Div(rate)
+		if side == order.Sell {
+			if h := e.holdings[code.String()]; h == nil || h.free.LessThan(trade.Amount) {
+				trade.Amount = decimal.Zero
+				if h != nil {
+					trade.Amount = h.free
+				}
+				trade.Reason = "limited to free balance"
+			}
+		}
+		r.conform(e.exch, &trade, rate)
+		plan.Trades = append(plan.Trades, trade)
+	}
+}
+
+// fundPurchases limits the purchases on an exchange to its free quote
+// currency balance and the proceeds of its sales, largest purchases first
+func (r *Rebalancer) fundPurchases(plan *RebalancePlan, e *rebalanceExchange) {
+	available := decimal.Zero
+	if h := e.holdings[r.quoteCurrency.String()]; h != nil {
+		available = h.free
+	}
+	var purchases []*RebalanceTrade
+	for i := range plan.Trades {
+		trade := &plan.Trades[i]
+		if trade.Exchange != e.exch.GetName() || trade.Status != RebalancePlanned {
+			continue
+		}
+		if trade.Side == order.Sell {
+			available = available.Add(trade.Amount.Mul(trade.Price))
+			continue
+		}
+		purchases = append(purchases, trade)
+	}
+	sort.SliceStable(purchases, func(i, j int) bool {
+		return purchases[i].Value.GreaterThan(purchases[j].Value)
+	})
+	for _, trade := range purchases {
+		cost := trade.Amount.Mul(trade.Price)
+		if cost.GreaterThan(available) {
+			rate := trade.Value.Div(trade.Amount)
+			trade.Amount = decimal.Max(available, decimal.Zero).Div(trade.Price)
+			trade.Reason = fmt.Sprintf("limited to free %s balance", r.quoteCurrency)
+			r.conform(e.exch, trade, rate)
+			if trade.Status != RebalancePlanned {
+				continue
+			}
+			cost = trade.Amount.Mul(trade.Price)
+		}
+		available = available.Sub(cost)
+	}
+}
+
+// conform rounds the amount of a trade down to the amount step of the
+// exchange and skips it when it is worth less than the minimum trade value or
+// does not meet the execution limits of the pair
+func (r *Rebalancer) conform(exch exchange.IBotExchange, trade *RebalanceTrade, rate decimal.Decimal) {
+	limits, err := exch.GetOrderExecutionLimits(asset.Spot, trade.Pair)
+	if err == nil {
+		trade.Amount = limits.ConformToDecimalAmount(trade.Amount)
+	}
+	trade.Value = trade.Amount.Mul(rate)
+	if !trade.Amount.IsPositive() || trade.Value.LessThan(r.minimumTradeValue) {
+		trade.Status = RebalanceSkipped
+		trade.Reason = fmt.Sprintf("value %s %s below minimum trade value %s", trade.Value.Round(2), r.reportingCurrency, r.minimumTradeValue)
+		return
+	}
+	if err != nil {
+		return
+	}
+	price, amount := trade.Price.InexactFloat64(), trade.Amount.InexactFloat64()
+	if err = limits.Conforms(price, amount, order.Market); err != nil {
+		trade.Status = RebalanceSkipped
+		trade.Reason = err.Error()
+		return
+	}
+	notional := amount * price
+	if (limits.MinNotional > 0 && notional < limits.MinNotional) ||
+		(limits.MinimumQuoteAmount > 0 && notional < limits.MinimumQuoteAmount) {
+		trade.Status = RebalanceSkipped
+		trade.Reason = fmt.Errorf("%w: %v", order.ErrNotionalValue, notional).Error()
+	}
+}
+
+// rate returns the rate to convert one unit of a currency into the reporting
+// currency from the markets of the allocated exchanges, directly or through
+// the quote currency
+func (r *Rebalancer) rate(exchanges []*rebalanceExchange, from currency.Code) (decimal.Decimal, bool) {
+	to := r.reportingCurrency
+	for i := range exchanges {
+		if rate, ok := directRate(exchanges[i].exch.GetName(), from, to); ok {
+			return rate, true
+		}
+	}
+	for i := range exchanges {
+		name := exchanges[i].exch.GetName()
+		r1, ok := directRate(name, from, r.quoteCurrency)
+		if !ok {
+			continue
+		}
+		for j := range exchanges {
+			if r2, ok := directRate(exchanges[j].exch.GetName(), r.quoteCurrency, to); ok {
+				return r1.Mul(r2), true
+			}
+		}
+	}
+	return decimal.Zero, false
+}
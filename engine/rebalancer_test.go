@@ -0,0 +1,269 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+var errRebalanceSubmit = errors.New("order rejected")
+
+// rbExchange aka rebalancer fake exchange returns spot holdings, tickers from
+// the ticker service and canned execution limits
+type rbExchange struct {
+	lmExchange
+	limits map[string]order.MinMaxLevel
+}
+
+func (e *rbExchange) FetchTicker(_ context.Context, p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	return ticker.GetTicker(e.name, p, a)
+}
+
+func (e *rbExchange) GetOrderExecutionLimits(_ asset.Item, p currency.Pair) (order.MinMaxLevel, error) {
+	l, ok := e.limits[p.String()]
+	if !ok {
+		return order.MinMaxLevel{}, order.ErrExchangeLimitNotLoaded
+	}
+	return l, nil
+}
+
+// rbSubmitter records submitted orders and rejects them when err is set
+type rbSubmitter struct {
+	submitted []order.Submit
+	err       error
+}
+
+func (s *rbSubmitter) Submit(_ context.Context, o *order.Submit) (*OrderSubmitResponse, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	s.submitted = append(s.submitted, *o)
+	return &OrderSubmitResponse{Detail: &order.Detail{OrderID: o.Pair.Base.String()}}, nil
+}
+
+// newTestRebalancer returns a started rebalancer on an exchange holding 1 BTC
+// worth 20000 USDT which targets half in BTC and 30% in ETH
+func newTestRebalancer(t *testing.T, name string, s *rbSubmitter) (*Rebalancer, *rbExchange) {
+	t.Helper()
+	btc, eth := currency.NewPair(currency.BTC, currency.USDT), currency.NewPair(currency.ETH, currency.USDT)
+	e := &rbExchange{
+		lmExchange: lmExchange{
+			taxExchange: taxExchange{name: name, pairs: currency.Pairs{btc, eth}},
+			holdings:    []account.Balance{{Currency: currency.BTC, Total: 1}},
+		},
+		limits: map[string]order.MinMaxLevel{
+			eth.String(): {MinimumBaseAmount: 0.1, MaximumBaseAmount: 1000, AmountStepIncrementSize: 0.5},
+		},
+	}
+	addAccountingTicker(t, name, btc, 20000)
+	addAccountingTicker(t, name, eth, 1000)
+	em := NewExchangeManager()
+	if err := em.Add(e); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	r, err := SetupRebalancer(em, s, nil, &config.Rebalancer{
+		ReportingCurrency: "USD",
+		QuoteCurrency:     "USDT",
+		Targets: []config.RebalanceTarget{
+			{Currency: "BTC", Weight: 0.5, Band: 0.05},
+			{Currency: "ETH", Weight: 0.3, Band: 0.05},
+		},
+		Allocations:       []config.RebalanceAllocation{{Exchange: name, Weight: 1}},
+		MinimumTradeValue: 10,
+	}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if err = r.Start(); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	return r, e
+}
+
+func TestSetupRebalancer(t *testing.T) {
+	t.Parallel()
+	_, err := SetupRebalancer(nil, nil, nil, nil, false)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	_, err = SetupRebalancer(NewExchangeManager(), nil, nil, nil, false)
+	if !errors.Is(err, errNilOrderSubmitter) {
+		t.Errorf("received '%v', expected '%v'", err, errNilOrderSubmitter)
+	}
+	_, err = SetupRebalancer(NewExchangeManager(), &rbSubmitter{}, nil, nil, false)
+	if !errors.Is(err, errNilConfig) {
+		t.Errorf("received '%v', expected '%v'", err, errNilConfig)
+	}
+	cfg := &config.Rebalancer{}
+	_, err = SetupRebalancer(NewExchangeManager(), &rbSubmitter{}, nil, cfg, false)
+	if !errors.Is(err, errNoRebalanceTargets) {
+		t.Errorf("received '%v', expected '%v'", err, errNoRebalanceTargets)
+	}
+	cfg.Targets = []config.RebalanceTarget{{Currency: "BTC", Weight: 0.7}, {Currency: "ETH", Weight: 0.7}}
+	_, err = SetupRebalancer(NewExchangeManager(), &rbSubmitter{}, nil, cfg, false)
+	if !errors.Is(err, errNoRebalanceExchanges) {
+		t.Errorf("received '%v', expected '%v'", err, errNoRebalanceExchanges)
+	}
+	cfg.Allocations = []config.RebalanceAllocation{{Exchange: "binance"}}
+	_, err = SetupRebalancer(NewExchangeManager(), &rbSubmitter{}, nil, cfg, false)
+	if !errors.Is(err, errInvalidRebalanceWeight) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidRebalanceWeight)
+	}
+	cfg.Targets[1].Weight = 0.3
+	r, err := SetupRebalancer(NewExchangeManager(), &rbSubmitter{}, nil, cfg, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !r.dryRun {
+		t.Error("expected dry run from engine settings")
+	}
+	if !r.reportingCurrency.Equal(currency.USD) || !r.quoteCurrency.Equal(currency.USDT) {
+		t.Errorf("received '%v' '%v', expected '%v' '%v'", r.reportingCurrency, r.quoteCurrency, currency.USD, currency.USDT)
+	}
+	if r.allocations[0].share.InexactFloat64() != 1 {
+		t.Errorf("received '%v', expected '%v'", r.allocations[0].share, 1)
+	}
+}
+
+func TestRebalancerStartStop(t *testing.T) {
+	t.Parallel()
+	var r *Rebalancer
+	if err := r.Start(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if err := r.Stop(); !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	if r.IsRunning() {
+		t.Error("expected nil rebalancer not to be running")
+	}
+	r, _ = newTestRebalancer(t, "rbstartstop", &rbSubmitter{})
+	if err := r.Start(); !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	if !r.IsRunning() {
+		t.Error("expected rebalancer to be running")
+	}
+	if err := r.Stop(); !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+	if err := r.Stop(); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+	if _, err := r.Plan(context.Background()); !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSubSystemNotStarted)
+	}
+}
+
+func TestRebalancerPlan(t *testing.T) {
+	t.Parallel()
+	r, e := newTestRebalancer(t, "rbplan", &rbSubmitter{})
+	plan, err := r.Plan(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if plan.TotalValue.InexactFloat64() != 20000 {
+		t.Errorf("received '%v', expected '%v'", plan.TotalValue, 20000)
+	}
+	if len(plan.Weights) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(plan.Weights), 3)
+	}
+	if !plan.Weights[2].Currency.Equal(currency.USDT) || plan.Weights[2].Target.InexactFloat64() != 0.2 {
+		t.Errorf("received '%v' '%v', expected '%v' '%v'", plan.Weights[2].Currency, plan.Weights[2].Target, currency.USDT, 0.2)
+	}
+	if len(plan.Trades) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(plan.Trades), 2)
+	}
+	// sales are planned first so their proceeds fund the purchases
+	sell, buy := plan.Trades[0], plan.Trades[1]
+	if sell.Side != order.Sell || !sell.Pair.Base.Equal(currency.BTC) || sell.Amount.InexactFloat64() != 0.5 || sell.Status != RebalancePlanned {
+		t.Errorf("received '%v %v %v %v', expected '%v %v %v %v'", sell.Side, sell.Pair, sell.Amount, sell.Status, order.Sell, "BTC-USDT", 0.5, RebalancePlanned)
+	}
+	if buy.Side != order.Buy || !buy.Pair.Base.Equal(currency.ETH) || buy.Amount.InexactFloat64() != 6 || buy.Status != RebalancePlanned {
+		t.Errorf("received '%v %v %v %v', expected '%v %v %v %v'", buy.Side, buy.Pair, buy.Amount, buy.Status, order.Buy, "ETH-USDT", 6, RebalancePlanned)
+	}
+
+	// purchases are limited to the free quote balance, largest first
+	e.holdings = []account.Balance{{Currency: currency.USDT, Total: 1000, Free: 100, Hold: 900}}
+	plan, err = r.Plan(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(plan.Trades) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(plan.Trades), 2)
+	}
+	if !plan.Trades[0].Pair.Base.Equal(currency.BTC) || plan.Trades[0].Amount.InexactFloat64() != 0.005 || plan.Trades[0].Status != RebalancePlanned {
+		t.Errorf("received '%v %v %v', expected '%v %v %v'", plan.Trades[0].Pair, plan.Trades[0].Amount, plan.Trades[0].Status, "BTC-USDT", 0.005, RebalancePlanned)
+	}
+	if !plan.Trades[1].Pair.Base.Equal(currency.ETH) || plan.Trades[1].Status != RebalanceSkipped {
+		t.Errorf("received '%v %v', expected '%v %v'", plan.Trades[1].Pair, plan.Trades[1].Status, "ETH-USDT", RebalanceSkipped)
+	}
+
+	// weights within their bands are not traded
+	e.holdings = []account.Balance{{Currency: currency.BTC, Total: 0.5}, {Currency: currency.ETH, Total: 6.2}, {Currency: currency.USDT, Total: 3800}}
+	plan, err = r.Plan(context.Background())
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(plan.Trades) != 0 {
+		t.Errorf("received '%v', expected '%v'", len(plan.Trades), 0)
+	}
+	for i := range plan.Weights {
+		if !plan.Weights[i].WithinBand {
+			t.Errorf("received '%v', expected '%v' for %v", plan.Weights[i].WithinBand, true, plan.Weights[i].Currency)
+		}
+	}
+
+	e.holdings = nil
+	if _, err = r.Plan(context.Background()); !errors.Is(err, errNoPortfolioValue) {
+		t.Errorf("received '%v', expected '%v'", err, errNoPortfolioValue)
+	}
+}
+
+func TestRebalancerExecute(t *testing.T) {
+	t.Parallel()
+	s := &rbSubmitter{}
+	r, _ := newTestRebalancer(t, "rbexecute", s)
+	plan, err := r.Execute(context.Background(), true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !plan.DryRun || !plan.Executed || len(s.submitted) != 0 {
+		t.Errorf("received '%v %v %v', expected '%v %v %v'", plan.DryRun, plan.Executed, len(s.submitted), true, true, 0)
+	}
+	for i := range plan.Trades {
+		if plan.Trades[i].Status != RebalanceDryRun {
+			t.Errorf("received '%v', expected '%v'", plan.Trades[i].Status, RebalanceDryRun)
+		}
+	}
+
+	plan, err = r.Execute(context.Background(), false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(s.submitted) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(s.submitted), 2)
+	}
+	if s.submitted[0].Side != order.Sell || s.submitted[0].Type != order.Market || s.submitted[0].AssetType != asset.Spot {
+		t.Errorf("received '%v %v %v', expected '%v %v %v'", s.submitted[0].Side, s.submitted[0].Type, s.submitted[0].AssetType, order.Sell, order.Market, asset.Spot)
+	}
+	if plan.Trades[1].Status != RebalanceSubmitted || plan.Trades[1].OrderID != "ETH" {
+		t.Errorf("received '%v %v', expected '%v %v'", plan.Trades[1].Status, plan.Trades[1].OrderID, RebalanceSubmitted, "ETH")
+	}
+
+	s.err = errRebalanceSubmit
+	plan, err = r.Execute(context.Background(), false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if plan.Trades[0].Status != RebalanceFailed || len(plan.Errors) != 2 {
+		t.Errorf("received '%v %v', expected '%v %v'", plan.Trades[0].Status, len(plan.Errors), RebalanceFailed, 2)
+	}
+}
@@ -0,0 +1,130 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// RebalancerName is an exported subsystem name
+const RebalancerName = "rebalancer"
+
+// RebalanceTradeStatus describes the outcome of a planned trade
+type RebalanceTradeStatus string
+
+// Rebalance trade statuses
+const (
+	// RebalancePlanned is a trade which will be submitted on execution
+	RebalancePlanned RebalanceTradeStatus = "planned"
+	// RebalanceSkipped is a trade which cannot be placed, see its reason
+	RebalanceSkipped RebalanceTradeStatus = "skipped"
+	// RebalanceDryRun is a trade which would have been submitted
+	RebalanceDryRun RebalanceTradeStatus = "dry_run"
+	// RebalanceSubmitted is a trade submitted to the order manager
+	RebalanceSubmitted RebalanceTradeStatus = "submitted"
+	// RebalanceFailed is a trade the order manager could not submit
+	RebalanceFailed RebalanceTradeStatus = "failed"
+)
+
+var (
+	errNoRebalanceTargets     = errors.New("no rebalance targets")
+	errNoRebalanceExchanges   = errors.New("no exchanges allocated to rebalance on")
+	errInvalidRebalanceWeight = errors.New("rebalance target weights must be between 0 and 1 and total no more than 1")
+	errNoPortfolioValue       = errors.New("portfolio has no value to rebalance")
+	errNilOrderSubmitter      = errors.New("order submitter is nil")
+)
+
+// rebalanceOrderSubmitter submits the orders of a rebalance
+type rebalanceOrderSubmitter interface {
+	Submit(context.Context, *order.Submit) (*OrderSubmitResponse, error)
+}
+
+// Rebalancer computes the trades needed to bring holdings on the allocated
+// exchanges to target weights of the portfolio value and executes them as
+// market orders through the order manager, on demand or on an interval
+type Rebalancer struct {
+	started           int32
+	processing        int32
+	shutdown          chan struct{}
+	wg                sync.WaitGroup
+	exchangeManager   iExchangeManager
+	orderManager      rebalanceOrderSubmitter
+	portfolioManager  iPortfolioManager
+	reportingCurrency currency.Code
+	quoteCurrency     currency.Code
+	targets           []rebalanceTarget
+	allocations       []rebalanceAllocation
+	minimumTradeValue decimal.Decimal
+	includeOffline    bool
+	interval          time.Duration
+	dryRun            bool
+	verbose           bool
+}
+
+// rebalanceTarget is the target weight and band of a currency
+type rebalanceTarget struct {
+	currency currency.Code
+	weight   decimal.Decimal
+	band     decimal.Decimal
+}
+
+// rebalanceAllocation is the share of each trade placed on an exchange
+type rebalanceAllocation struct {
+	exchange string
+	share    decimal.Decimal
+}
+
+// rebalanceHolding is the amount of a currency held and the amount free to
+// trade on an exchange
+type rebalanceHolding struct {
+	total decimal.Decimal
+	free  decimal.Decimal
+}
+
+// RebalanceWeight is the current and target weight of a currency. Values are
+// in the reporting currency
+type RebalanceWeight struct {
+	Currency    currency.Code
+	Amount      decimal.Decimal
+	Value       decimal.Decimal
+	Weight      decimal.Decimal
+	Target      decimal.Decimal
+	Band        decimal.Decimal
+	TargetValue decimal.Decimal
+	// WithinBand is set when the weight is close enough to its target that
+	// the currency is not traded
+	WithinBand bool
+}
+
+// RebalanceTrade is a market order of a currency against the quote currency.
+// Value is in the reporting currency
+type RebalanceTrade struct {
+	Exchange string
+	Pair     currency.Pair
+	Side     order.Side
+	Amount   decimal.Decimal
+	Price    decimal.Decimal
+	Value    decimal.Decimal
+	Status   RebalanceTradeStatus
+	Reason   string
+	OrderID  string
+}
+
+// RebalancePlan is the portfolio value, the weights of each currency and the
+// trades which move the holdings towards the target weights
+type RebalancePlan struct {
+	Time              time.Time
+	ReportingCurrency currency.Code
+	QuoteCurrency     currency.Code
+	TotalValue        decimal.Decimal
+	Weights           []RebalanceWeight
+	Trades            []RebalanceTrade
+	DryRun            bool
+	Executed          bool
+	Errors            []string
+}
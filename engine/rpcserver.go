@@ -21,7 +21,6 @@ import (
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gct-ta/indicators"
 	"github.com/thrasher-corp/gocryptotrader/common"
-	"github.com/thrasher-corp/gocryptotrader/common/crypto"
 	"github.com/thrasher-corp/gocryptotrader/common/file"
 	"github.com/thrasher-corp/gocryptotrader/common/file/archive"
 	"github.com/thrasher-corp/gocryptotrader/common/timeperiods"
@@ -34,28 +33,39 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
-	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
-	"github.com/thrasher-corp/gocryptotrader/gctrpc/auth"
 	gctscript "github.com/thrasher-corp/gocryptotrader/gctscript/vm"
 	"github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/gocryptotrader/utils"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
-	"google.golang.org/grpc/metadata"
 	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
+// portfolioUSDStables are valued at par with USD in portfolio valuations
+var portfolioUSDStables = currency.Currencies{
+	currency.USDT,
+	currency.USDC,
+	currency.BUSD,
+	currency.TUSD,
+	currency.DAI,
+	currency.USDP,
+}
+
 var (
 	errExchangeNotLoaded       = errors.New("exchange is not loaded/doesn't exist")
 	errExchangeNotEnabled      = errors.New("exchange is not enabled")
@@ -78,6 +88,9 @@ var (
 	errGRPCShutdownSignalIsNil = errors.New("cannot shutdown, gRPC shutdown channel is nil")
 	errInvalidStrategy         = errors.New("invalid strategy")
 	errSpecificPairNotEnabled  = errors.New("specified pair is not enabled")
+	errNoOptionsContracts      = errors.New("no options contracts found")
+	errNoUnderlyingPrice       = errors.New("underlying price unavailable, supply an underlying price")
+	errNoLoggerDetails         = errors.New("no logger details to set, supply a level, format, output or sampling")
 )
 
 // RPCServer struct
@@ -86,45 +99,6 @@ type RPCServer struct {
 	*Engine
 }
 
-func (s *RPCServer) authenticateClient(ctx context.Context) (context.Context, error) {
-	md, ok := metadata.FromIncomingContext(ctx)
-	if !ok {
-		return ctx, fmt.Errorf("unable to extract metadata")
-	}
-
-	authStr, ok := md["authorization"]
-	if !ok {
-		return ctx, fmt.Errorf("authorization header missing")
-	}
-
-	if !strings.Contains(authStr[0], "Basic") {
-		return ctx, fmt.Errorf("basic not found in authorization header")
-	}
-
-	decoded, err := crypto.Base64Decode(strings.Split(authStr[0], " ")[1])
-	if err != nil {
-		return ctx, fmt.Errorf("unable to base64 decode authorization header")
-	}
-
-	cred := strings.Split(string(decoded), ":")
-	username := cred[0]
-	password := cred[1]
-
-	if username != s.Config.RemoteControl.Username ||
-		password != s.Config.RemoteControl.Password {
-		return ctx, fmt.Errorf("username/password mismatch")
-	}
-	ctx, err = account.ParseCredentialsMetadata(ctx, md)
-	if err != nil {
-		return ctx, err
-	}
-
-	if _, ok := md["verbose"]; ok {
-		ctx = request.WithVerbose(ctx)
-	}
-	return ctx, nil
-}
-
 // StartRPCServer starts a gRPC server with TLS auth
 func StartRPCServer(engine *Engine) {
 	targetDir := utils.GetTLSDir(engine.Settings.DataDir)
@@ -139,7 +113,7 @@ func StartRPCServer(engine *Engine) {
 		return
 	}
 
-	creds, err := credentials.NewServerTLSFromFile(filepath.Join(targetDir, "cert.pem"), filepath.Join(targetDir, "key.pem"))
+	tlsConfig, err := rpcServerTLSConfig(targetDir, &engine.Config.RemoteControl.GRPC)
 	if err != nil {
 		log.Errorf(log.GRPCSys, "gRPC server could not load TLS keys: %s\n", err)
 		return
@@ -147,9 +121,9 @@ func StartRPCServer(engine *Engine) {
 
 	s := RPCServer{Engine: engine}
 	opts := []grpc.ServerOption{
-		grpc.Creds(creds),
-		grpc.UnaryInterceptor(grpcauth.UnaryServerInterceptor(s.authenticateClient)),
-		grpc.StreamInterceptor(grpcauth.StreamServerInterceptor(s.authenticateClient)),
+		grpc.Creds(credentials.NewTLS(tlsConfig)),
+		grpc.ChainUnaryInterceptor(correlateUnary, grpcauth.UnaryServerInterceptor(s.authenticateClient), authoriseUnary),
+		grpc.ChainStreamInterceptor(correlateStream, grpcauth.StreamServerInterceptor(s.authenticateClient), authoriseStream),
 	}
 	server := grpc.NewServer(opts...)
 	gctrpc.RegisterGoCryptoTraderServiceServer(server, &s)
@@ -179,13 +153,10 @@ func (s *RPCServer) StartRPCRESTProxy() {
 		return
 	}
 
+	// The authorization header of each HTTP request is forwarded to the gRPC
+	// server so proxied requests are authorised as the calling user
 	mux := runtime.NewServeMux()
-	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds),
-		grpc.WithPerRPCCredentials(auth.BasicAuth{
-			Username: s.Config.RemoteControl.Username,
-			Password: s.Config.RemoteControl.Password,
-		}),
-	}
+	opts := []grpc.DialOption{grpc.WithTransportCredentials(creds)}
 	err = gctrpc.RegisterGoCryptoTraderServiceHandlerFromEndpoint(context.Background(),
 		mux, s.Config.RemoteControl.GRPC.ListenAddress, opts)
 	if err != nil {
@@ -776,10 +747,25 @@ func (s *RPCServer) GetPortfolio(_ context.Context, _ *gctrpc.GetPortfolioReques
 	return resp, nil
 }
 
-// GetPortfolioSummary returns the portfoliomanager summary
-func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfolioSummaryRequest) (*gctrpc.GetPortfolioSummaryResponse, error) {
+// GetPortfolioSummary returns the portfoliomanager summary, valued in the
+// requested fiat currency when set
+func (s *RPCServer) GetPortfolioSummary(_ context.Context, r *gctrpc.GetPortfolioSummaryRequest) (*gctrpc.GetPortfolioSummaryResponse, error) {
 	result := s.portfolioManager.GetPortfolioSummary()
 	var resp gctrpc.GetPortfolioSummaryResponse
+	if r.ValueCurrency != "" {
+		v, err := result.Value(currency.NewCode(r.ValueCurrency), portfolioPrice(s.ExchangeManager))
+		if err != nil {
+			return nil, err
+		}
+		resp.Valuation = &gctrpc.PortfolioValuation{
+			Currency: v.Currency.String(),
+			Time:     v.Time.Format(common.SimpleTimeFormatWithTimezone),
+			Value:    v.Value,
+		}
+		for i := range v.Unvalued {
+			resp.Valuation.UnvaluedCoins = append(resp.Valuation.UnvaluedCoins, v.Unvalued[i].String())
+		}
+	}
 
 	p := func(coins []portfolio.Coin) []*gctrpc.Coin {
 		var c []*gctrpc.Coin
@@ -832,6 +818,31 @@ func (s *RPCServer) GetPortfolioSummary(_ context.Context, _ *gctrpc.GetPortfoli
 	return &resp, nil
 }
 
+// portfolioPrice returns a price function which prices a cryptocurrency in
+// USD from the spot tickers of the loaded exchanges, USD stablecoins are
+// valued at par with USD
+func portfolioPrice(em iExchangeManager) portfolio.PriceFunc {
+	return func(c currency.Code) (float64, currency.Code, bool) {
+		if portfolioUSDStables.Contains(c) {
+			return 1, currency.USD, true
+		}
+		exchanges, err := em.GetExchanges()
+		if err != nil {
+			return 0, currency.EMPTYCODE, false
+		}
+		quotes := append(currency.Currencies{currency.USD}, portfolioUSDStables...)
+		for x := range quotes {
+			for i := range exchanges {
+				t, err := ticker.GetTicker(exchanges[i].GetName(), currency.NewPair(c, quotes[x]), asset.Spot)
+				if err == nil && t.Last > 0 {
+					return t.Last, currency.USD, true
+				}
+			}
+		}
+		return 0, currency.EMPTYCODE, false
+	}
+}
+
 // AddPortfolioAddress adds an address to the portfoliomanager manager
 func (s *RPCServer) AddPortfolioAddress(_ context.Context, r *gctrpc.AddPortfolioAddressRequest) (*gctrpc.GenericResponse, error) {
 	err := s.portfolioManager.AddAddress(r.Address,
@@ -1001,6 +1012,7 @@ func (s *RPCServer) GetOrders(ctx context.Context, r *gctrpc.GetOrdersRequest) (
 			Exchange:      r.Exchange,
 			Id:            resp[x].OrderID,
 			ClientOrderId: resp[x].ClientOrderID,
+			CorrelationId: resp[x].CorrelationID,
 			BaseCurrency:  resp[x].Pair.Base.String(),
 			QuoteCurrency: resp[x].Pair.Quote.String(),
 			AssetType:     resp[x].AssetType.String(),
@@ -1090,6 +1102,7 @@ func (s *RPCServer) GetManagedOrders(_ context.Context, r *gctrpc.GetOrdersReque
 			Exchange:      r.Exchange,
 			Id:            resp[x].OrderID,
 			ClientOrderId: resp[x].ClientOrderID,
+			CorrelationId: resp[x].CorrelationID,
 			BaseCurrency:  resp[x].Pair.Base.String(),
 			QuoteCurrency: resp[x].Pair.Quote.String(),
 			AssetType:     resp[x].AssetType.String(),
@@ -1181,6 +1194,7 @@ func (s *RPCServer) GetOrder(ctx context.Context, r *gctrpc.GetOrderRequest) (*g
 		Exchange:      result.Exchange,
 		Id:            result.OrderID,
 		ClientOrderId: result.ClientOrderID,
+		CorrelationId: result.CorrelationID,
 		BaseCurrency:  result.Pair.Base.String(),
 		QuoteCurrency: result.Pair.Quote.String(),
 		AssetType:     result.AssetType.String(),
@@ -1264,9 +1278,10 @@ func (s *RPCServer) SubmitOrder(ctx context.Context, r *gctrpc.SubmitOrderReques
 	}
 
 	return &gctrpc.SubmitOrderResponse{
-		OrderId:     resp.OrderID,
-		OrderPlaced: resp.WasOrderPlaced(),
-		Trades:      trades,
+		OrderId:       resp.OrderID,
+		OrderPlaced:   resp.WasOrderPlaced(),
+		Trades:        trades,
+		CorrelationId: resp.CorrelationID,
 	}, nil
 }
 
@@ -1715,8 +1730,13 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.W
 		return nil, err
 	}
 
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
+	creds, err := resolveExchangeCredentials(ctx, &exchCfg.API.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.OTPSecret != "" {
+		code, errOTP := totp.GenerateCode(creds.OTPSecret, time.Now())
 		if errOTP != nil {
 			return nil, errOTP
 		}
@@ -1728,15 +1748,15 @@ func (s *RPCServer) WithdrawCryptocurrencyFunds(ctx context.Context, r *gctrpc.W
 		request.OneTimePassword = codeNum
 	}
 
-	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPin := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
+	if creds.PIN != "" {
+		pinCode, errPin := strconv.ParseInt(creds.PIN, 10, 64)
 		if err != nil {
 			return nil, errPin
 		}
 		request.PIN = pinCode
 	}
 
-	request.TradePassword = exchCfg.API.Credentials.TradePassword
+	request.TradePassword = creds.TradePassword
 
 	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, request)
 	if err != nil {
@@ -1785,8 +1805,13 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 		return nil, err
 	}
 
-	if exchCfg.API.Credentials.OTPSecret != "" {
-		code, errOTP := totp.GenerateCode(exchCfg.API.Credentials.OTPSecret, time.Now())
+	creds, err := resolveExchangeCredentials(ctx, &exchCfg.API.Credentials)
+	if err != nil {
+		return nil, err
+	}
+
+	if creds.OTPSecret != "" {
+		code, errOTP := totp.GenerateCode(creds.OTPSecret, time.Now())
 		if err != nil {
 			return nil, errOTP
 		}
@@ -1798,15 +1823,15 @@ func (s *RPCServer) WithdrawFiatFunds(ctx context.Context, r *gctrpc.WithdrawFia
 		request.OneTimePassword = codeNum
 	}
 
-	if exchCfg.API.Credentials.PIN != "" {
-		pinCode, errPIN := strconv.ParseInt(exchCfg.API.Credentials.PIN, 10, 64)
+	if creds.PIN != "" {
+		pinCode, errPIN := strconv.ParseInt(creds.PIN, 10, 64)
 		if err != nil {
 			return nil, errPIN
 		}
 		request.PIN = pinCode
 	}
 
-	request.TradePassword = exchCfg.API.Credentials.TradePassword
+	request.TradePassword = creds.TradePassword
 
 	resp, err := s.Engine.WithdrawManager.SubmitWithdrawal(ctx, request)
 	if err != nil {
@@ -1942,32 +1967,73 @@ func (s *RPCServer) WithdrawalEventsByDate(_ context.Context, r *gctrpc.Withdraw
 
 // GetLoggerDetails returns a loggers details
 func (s *RPCServer) GetLoggerDetails(_ context.Context, r *gctrpc.GetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
-	levels, err := log.Level(r.Logger)
-	if err != nil {
-		return nil, err
-	}
-
-	return &gctrpc.GetLoggerDetailsResponse{
-		Info:  levels.Info,
-		Debug: levels.Debug,
-		Warn:  levels.Warn,
-		Error: levels.Error,
-	}, nil
+	return loggerDetails(r.Logger)
 }
 
-// SetLoggerDetails sets a loggers details
+// SetLoggerDetails sets a loggers levels, format, outputs and sampling. Unset
+// fields are left unchanged.
 func (s *RPCServer) SetLoggerDetails(_ context.Context, r *gctrpc.SetLoggerDetailsRequest) (*gctrpc.GetLoggerDetailsResponse, error) {
-	levels, err := log.SetLevel(r.Logger, r.Level)
+	if r.Level == "" && r.Format == "" && r.Output == "" && r.Sampling == nil {
+		return nil, errNoLoggerDetails
+	}
+	if r.Sampling != nil {
+		var sampling *log.SamplingConfig
+		if !r.Sampling.Disabled {
+			interval, err := time.ParseDuration(r.Sampling.Interval)
+			if err != nil {
+				return nil, err
+			}
+			sampling = &log.SamplingConfig{
+				Initial:    r.Sampling.Initial,
+				Thereafter: r.Sampling.Thereafter,
+				Interval:   interval,
+			}
+		}
+		if err := log.SetSampling(r.Logger, sampling); err != nil {
+			return nil, err
+		}
+	}
+	if r.Output != "" {
+		if err := log.SetOutput(r.Logger, r.Output); err != nil {
+			return nil, err
+		}
+	}
+	if r.Format != "" {
+		if err := log.SetFormat(r.Logger, r.Format); err != nil {
+			return nil, err
+		}
+	}
+	if r.Level != "" {
+		if _, err := log.SetLevel(r.Logger, r.Level); err != nil {
+			return nil, err
+		}
+	}
+	return loggerDetails(r.Logger)
+}
+
+// loggerDetails returns the current settings of a logger
+func loggerDetails(logger string) (*gctrpc.GetLoggerDetailsResponse, error) {
+	details, err := log.Details(logger)
 	if err != nil {
 		return nil, err
 	}
-
-	return &gctrpc.GetLoggerDetailsResponse{
-		Info:  levels.Info,
-		Debug: levels.Debug,
-		Warn:  levels.Warn,
-		Error: levels.Error,
-	}, nil
+	resp := &gctrpc.GetLoggerDetailsResponse{
+		Info:    details.Info,
+		Debug:   details.Debug,
+		Warn:    details.Warn,
+		Error:   details.Error,
+		Format:  details.Format,
+		Output:  details.Output,
+		Sampled: details.Sampled,
+	}
+	if details.Sampling != nil {
+		resp.Sampling = &gctrpc.LoggerSampling{
+			Initial:    details.Sampling.Initial,
+			Thereafter: details.Sampling.Thereafter,
+			Interval:   details.Sampling.Interval.String(),
+		}
+	}
+	return resp, nil
 }
 
 // GetExchangePairs returns a list of exchange supported assets and related pairs
@@ -4233,6 +4299,22 @@ func (s *RPCServer) buildFuturePosition(position *order.Position, getFundingPaym
 		RealisedPnl:      position.RealisedPNL.String(),
 		OrderCount:       int64(len(position.Orders)),
 	}
+	if est := position.LiquidationEstimate; est != nil {
+		response.LiquidationEstimate = &gctrpc.FuturesLiquidationEstimate{
+			MarginMode:            est.Mode.String(),
+			MarkPrice:             est.MarkPrice.String(),
+			MaintenanceMarginRate: est.MaintenanceMarginRate.String(),
+			MaintenanceMargin:     est.MaintenanceMargin.String(),
+			MarginBalance:         est.MarginBalance.String(),
+			MarginRatio:           est.MarginRatio.String(),
+			Risk:                  est.Risk.String(),
+			CollateralCurrency:    est.CollateralCurrency.String(),
+		}
+		if est.HasLiquidationPrice {
+			response.LiquidationEstimate.LiquidationPrice = est.LiquidationPrice.String()
+			response.LiquidationEstimate.DistanceToLiquidation = est.DistanceToLiquidation.String()
+		}
+	}
 	if getFundingPayments {
 		var sum decimal.Decimal
 		fundingData := &gctrpc.FundingData{}
@@ -4262,6 +4344,7 @@ func (s *RPCServer) buildFuturePosition(position *order.Position, getFundingPaym
 				Exchange:      position.Orders[i].Exchange,
 				Id:            position.Orders[i].OrderID,
 				ClientOrderId: position.Orders[i].ClientOrderID,
+				CorrelationId: position.Orders[i].CorrelationID,
 				BaseCurrency:  position.Orders[i].Pair.Base.String(),
 				QuoteCurrency: position.Orders[i].Pair.Quote.String(),
 				AssetType:     position.Orders[i].AssetType.String(),
@@ -4611,6 +4694,7 @@ func (s *RPCServer) GetFuturesPositions(ctx context.Context, r *gctrpc.GetFuture
 				Exchange:      pos[i].Orders[j].Exchange,
 				Id:            pos[i].Orders[j].OrderID,
 				ClientOrderId: pos[i].Orders[j].ClientOrderID,
+				CorrelationId: pos[i].Orders[j].CorrelationID,
 				BaseCurrency:  pos[i].Orders[j].Pair.Base.String(),
 				QuoteCurrency: pos[i].Orders[j].Pair.Quote.String(),
 				AssetType:     pos[i].Orders[j].AssetType.String(),
@@ -5208,6 +5292,120 @@ func (s *RPCServer) GetTechnicalAnalysis(ctx context.Context, r *gctrpc.GetTechn
 			return nil, err
 		}
 		signals["RSI"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "STOCH":
+		var stochastic *kline.Stochastic
+		stochastic, err = klines.GetStochastic(r.Period, r.KSmoothing, r.DPeriod)
+		if err != nil {
+			return nil, err
+		}
+		signals["K"] = &gctrpc.ListOfSignals{Signals: stochastic.K}
+		signals["D"] = &gctrpc.ListOfSignals{Signals: stochastic.D}
+	case "STOCHRSI":
+		var stochastic *kline.Stochastic
+		stochastic, err = klines.GetStochasticRelativeStrengthIndexOnClose(r.Period,
+			r.StochasticPeriod,
+			r.KSmoothing,
+			r.DPeriod)
+		if err != nil {
+			return nil, err
+		}
+		signals["K"] = &gctrpc.ListOfSignals{Signals: stochastic.K}
+		signals["D"] = &gctrpc.ListOfSignals{Signals: stochastic.D}
+	case "ADX":
+		var dmi *kline.DirectionalMovement
+		dmi, err = klines.GetDirectionalMovementIndex(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["ADX"] = &gctrpc.ListOfSignals{Signals: dmi.ADX}
+		signals["PLUS_DI"] = &gctrpc.ListOfSignals{Signals: dmi.PlusDI}
+		signals["MINUS_DI"] = &gctrpc.ListOfSignals{Signals: dmi.MinusDI}
+	case "ICHIMOKU":
+		var cloud *kline.Ichimoku
+		cloud, err = klines.GetIchimokuCloud(r.ConversionPeriod,
+			r.BasePeriod,
+			r.SpanBPeriod,
+			r.Displacement)
+		if err != nil {
+			return nil, err
+		}
+		signals["CONVERSION"] = &gctrpc.ListOfSignals{Signals: cloud.ConversionLine}
+		signals["BASE"] = &gctrpc.ListOfSignals{Signals: cloud.BaseLine}
+		signals["SPAN_A"] = &gctrpc.ListOfSignals{Signals: cloud.LeadingSpanA}
+		signals["SPAN_B"] = &gctrpc.ListOfSignals{Signals: cloud.LeadingSpanB}
+		signals["LAGGING"] = &gctrpc.ListOfSignals{Signals: cloud.LaggingSpan}
+	case "SUPERTREND":
+		var supertrend *kline.Supertrend
+		supertrend, err = klines.GetSupertrend(r.Period, r.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		signals["SUPERTREND"] = &gctrpc.ListOfSignals{Signals: supertrend.Trend}
+		signals["DIRECTION"] = &gctrpc.ListOfSignals{Signals: supertrend.Direction}
+	case "KELTNER":
+		var keltner *kline.Channel
+		keltner, err = klines.GetKeltnerChannels(r.Period, r.AtrPeriod, r.Multiplier)
+		if err != nil {
+			return nil, err
+		}
+		signals["UPPER"] = &gctrpc.ListOfSignals{Signals: keltner.Upper}
+		signals["MIDDLE"] = &gctrpc.ListOfSignals{Signals: keltner.Middle}
+		signals["LOWER"] = &gctrpc.ListOfSignals{Signals: keltner.Lower}
+	case "DONCHIAN":
+		var donchian *kline.Channel
+		donchian, err = klines.GetDonchianChannels(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["UPPER"] = &gctrpc.ListOfSignals{Signals: donchian.Upper}
+		signals["MIDDLE"] = &gctrpc.ListOfSignals{Signals: donchian.Middle}
+		signals["LOWER"] = &gctrpc.ListOfSignals{Signals: donchian.Lower}
+	case "PSAR":
+		var prices []float64
+		prices, err = klines.GetParabolicSAR(r.AccelerationStep, r.AccelerationMaximum)
+		if err != nil {
+			return nil, err
+		}
+		signals["PSAR"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "CCI":
+		var prices []float64
+		prices, err = klines.GetCommodityChannelIndex(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["CCI"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "WILLR":
+		var prices []float64
+		prices, err = klines.GetWilliamsPercentRange(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["WILLR"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "AVWAP":
+		anchor := r.Start.AsTime()
+		if r.Anchor != nil {
+			anchor = r.Anchor.AsTime()
+		}
+		var prices []float64
+		prices, err = klines.GetAnchoredVolumeWeightedAveragePrice(anchor)
+		if err != nil {
+			return nil, err
+		}
+		signals["AVWAP"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "ZSCORE":
+		var prices []float64
+		prices, err = klines.GetZScoreOnClose(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["ZSCORE"] = &gctrpc.ListOfSignals{Signals: prices}
+	case "HURST":
+		var prices []float64
+		prices, err = klines.GetHurstExponentOnClose(r.Period)
+		if err != nil {
+			return nil, err
+		}
+		signals["HURST"] = &gctrpc.ListOfSignals{Signals: prices}
 	default:
 		return nil, fmt.Errorf("%w '%s'", errInvalidStrategy, r.AlgorithmType)
 	}
@@ -5616,3 +5814,1474 @@ func (s *RPCServer) GetOrderbookAmountByImpact(_ context.Context, r *gctrpc.GetO
 		AverageOrderCost:                    impact.AverageOrderCost,
 	}, nil
 }
+
+// ReloadConfig reads the config file and applies any supported changes to the
+// running engine. Dry runs only report the changes found
+func (s *RPCServer) ReloadConfig(_ context.Context, r *gctrpc.ReloadConfigRequest) (*gctrpc.ReloadConfigResponse, error) {
+	result, err := s.Engine.ReloadConfig(r.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*gctrpc.ConfigChange, len(result.Changes))
+	for i := range result.Changes {
+		changes[i] = &gctrpc.ConfigChange{
+			Section:         result.Changes[i].Section,
+			Item:            result.Changes[i].Item,
+			Description:     result.Changes[i].Description,
+			RestartRequired: result.Changes[i].RestartRequired,
+			Applied:         result.Changes[i].Applied,
+		}
+	}
+	return &gctrpc.ReloadConfigResponse{
+		Changes:         changes,
+		Applied:         result.Applied,
+		RestartRequired: result.RestartRequired,
+	}, nil
+}
+
+// GetDataRetentionStatus returns the data retention policies being applied
+// and the report of the most recent retention run
+func (s *RPCServer) GetDataRetentionStatus(_ context.Context, _ *gctrpc.GetDataRetentionStatusRequest) (*gctrpc.GetDataRetentionStatusResponse, error) {
+	policies, err := s.dataRetentionManager.Policies()
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetDataRetentionStatusResponse{
+		Running:  s.dataRetentionManager.IsRunning(),
+		Policies: make([]*gctrpc.DataRetentionPolicy, len(policies)),
+	}
+	for i := range policies {
+		resp.Policies[i] = &gctrpc.DataRetentionPolicy{
+			Exchange:       policies[i].Exchange,
+			Asset:          policies[i].Asset,
+			DataType:       policies[i].DataType,
+			Interval:       policies[i].Interval.String(),
+			MaxAge:         policies[i].MaxAge.String(),
+			RollupInterval: policies[i].RollupInterval.String(),
+			DiscardTrades:  policies[i].DiscardTrades,
+		}
+	}
+	report, err := s.dataRetentionManager.LastReport()
+	if err != nil && !errors.Is(err, errNoRetentionReport) {
+		return nil, err
+	}
+	if report != nil {
+		resp.LastReport = dataRetentionReportToRPC(report)
+	}
+	return resp, nil
+}
+
+// RunDataRetention applies the data retention policies immediately,
+// a dry run reports what would be removed without changing the database
+func (s *RPCServer) RunDataRetention(_ context.Context, r *gctrpc.RunDataRetentionRequest) (*gctrpc.DataRetentionReport, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	report, err := s.dataRetentionManager.Run(r.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return dataRetentionReportToRPC(report), nil
+}
+
+func dataRetentionReportToRPC(report *DataRetentionReport) *gctrpc.DataRetentionReport {
+	resp := &gctrpc.DataRetentionReport{
+		DryRun:    report.DryRun,
+		Started:   report.Started.Format(common.SimpleTimeFormatWithTimezone),
+		Completed: report.Completed.Format(common.SimpleTimeFormatWithTimezone),
+		Removed:   report.Removed,
+		Created:   report.Created,
+		Compacted: report.Compacted,
+		Series:    make([]*gctrpc.DataRetentionSeriesResult, len(report.Series)),
+	}
+	for i := range report.Series {
+		resp.Series[i] = &gctrpc.DataRetentionSeriesResult{
+			DataType: report.Series[i].DataType,
+			Exchange: report.Series[i].Exchange,
+			Asset:    report.Series[i].Asset.String(),
+			Pair: &gctrpc.CurrencyPair{
+				Delimiter: report.Series[i].Pair.Delimiter,
+				Base:      report.Series[i].Pair.Base.String(),
+				Quote:     report.Series[i].Pair.Quote.String(),
+			},
+			Interval:       report.Series[i].Interval.String(),
+			RollupInterval: report.Series[i].RollupInterval.String(),
+			Cutoff:         report.Series[i].Cutoff.Format(common.SimpleTimeFormatWithTimezone),
+			Removed:        report.Series[i].Removed,
+			Created:        report.Series[i].Created,
+			Error:          report.Series[i].Error,
+		}
+	}
+	return resp
+}
+
+// GetFundingRateOpportunities returns the cross-exchange funding rate
+// opportunities found by the funding rate scanner, optionally running a new
+// scan first
+func (s *RPCServer) GetFundingRateOpportunities(ctx context.Context, r *gctrpc.GetFundingRateOpportunitiesRequest) (*gctrpc.GetFundingRateOpportunitiesResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	var scan *FundingRateScan
+	var err error
+	if r.Refresh {
+		scan, err = s.fundingRateScanner.Scan(ctx)
+	} else {
+		scan, err = s.fundingRateScanner.LastScan()
+	}
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetFundingRateOpportunitiesResponse{
+		ScanTime: scan.Time.Format(common.SimpleTimeFormatWithTimezone),
+		Errors:   scan.Errors,
+	}
+	for i := range scan.Opportunities {
+		o := &scan.Opportunities[i]
+		if r.Underlying != "" && !o.Underlying.Equal(currency.NewCode(r.Underlying)) {
+			continue
+		}
+		opportunity := &gctrpc.FundingRateOpportunity{
+			Underlying:          o.Underlying.String(),
+			Long:                fundingRateLegToRPC(&o.Long),
+			Short:               fundingRateLegToRPC(&o.Short),
+			AnnualisedSpread:    o.AnnualisedSpread.String(),
+			AnnualisedFees:      o.AnnualisedFees.String(),
+			NetAnnualisedSpread: o.NetAnnualisedSpread.String(),
+		}
+		if o.HasPredictedSpread {
+			opportunity.PredictedNetAnnualisedSpread = o.PredictedNetAnnualisedSpread.String()
+		}
+		resp.Opportunities = append(resp.Opportunities, opportunity)
+	}
+	return resp, nil
+}
+
+func fundingRateLegToRPC(leg *FundingRateSnapshot) *gctrpc.FundingRateLeg {
+	resp := &gctrpc.FundingRateLeg{
+		Exchange: leg.Exchange,
+		Asset:    leg.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: leg.Pair.Delimiter,
+			Base:      leg.Pair.Base.String(),
+			Quote:     leg.Pair.Quote.String(),
+		},
+		CurrentRate:    leg.CurrentRate.String(),
+		AnnualisedRate: annualiseFundingRate(leg.CurrentRate, leg.Frequency).String(),
+		TakerFee:       leg.TakerFee.String(),
+	}
+	if leg.HasPredictedRate {
+		resp.PredictedRate = leg.PredictedRate.String()
+	}
+	if !leg.TimeOfNextRate.IsZero() {
+		resp.TimeOfNextRate = leg.TimeOfNextRate.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp
+}
+
+// GetOptionsChain returns the options chains of an exchange's enabled options
+// contracts with the implied volatility and greeks of each contract calculated
+// from its orderbook mid price
+func (s *RPCServer) GetOptionsChain(ctx context.Context, r *gctrpc.GetOptionsChainRequest) (*gctrpc.GetOptionsChainResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	underlying, err := optionsUnderlyingFromRPC(r.Underlying)
+	if err != nil {
+		return nil, err
+	}
+	var expiry time.Time
+	if r.Expiry != "" {
+		expiry, err = time.Parse(time.DateOnly, r.Expiry)
+		if err != nil {
+			return nil, err
+		}
+	}
+	contracts, err := getOptionsContracts(exch, underlying, expiry)
+	if err != nil {
+		return nil, err
+	}
+	chains := options.BuildChains(contracts)
+	now := time.Now()
+	resp := &gctrpc.GetOptionsChainResponse{Chains: make([]*gctrpc.OptionsChain, len(chains))}
+	for i := range chains {
+		chain := &gctrpc.OptionsChain{
+			Exchange: chains[i].Exchange,
+			Underlying: &gctrpc.CurrencyPair{
+				Delimiter: chains[i].Underlying.Delimiter,
+				Base:      chains[i].Underlying.Base.String(),
+				Quote:     chains[i].Underlying.Quote.String(),
+			},
+			Expiry:  chains[i].Expiry.Format(common.SimpleTimeFormatWithTimezone),
+			Strikes: make([]*gctrpc.OptionsChainStrike, len(chains[i].Strikes)),
+		}
+		resp.Chains[i] = chain
+		chain.UnderlyingPrice, err = getOptionsUnderlyingPrice(ctx, exch, chains[i].Underlying, r.UnderlyingPrice)
+		if err != nil {
+			chain.Error = err.Error()
+		}
+		for j := range chains[i].Strikes {
+			strike := &gctrpc.OptionsChainStrike{Strike: chains[i].Strikes[j].Strike}
+			if c := chains[i].Strikes[j].Call; c != nil {
+				strike.Call = optionQuoteToRPC(evaluateOptionsContract(ctx, exch, c, chain.UnderlyingPrice, r.RiskFreeRate, now))
+			}
+			if c := chains[i].Strikes[j].Put; c != nil {
+				strike.Put = optionQuoteToRPC(evaluateOptionsContract(ctx, exch, c, chain.UnderlyingPrice, r.RiskFreeRate, now))
+			}
+			chain.Strikes[j] = strike
+		}
+	}
+	return resp, nil
+}
+
+// GetOptionsPortfolioGreeks aggregates the greeks of an exchange's options
+// positions as returned by GetFuturesPositions
+func (s *RPCServer) GetOptionsPortfolioGreeks(ctx context.Context, r *gctrpc.GetOptionsPortfolioGreeksRequest) (*gctrpc.GetOptionsPortfolioGreeksResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	underlying, err := optionsUnderlyingFromRPC(r.Underlying)
+	if err != nil {
+		return nil, err
+	}
+	contracts, err := getOptionsContracts(exch, underlying, time.Time{})
+	if err != nil {
+		return nil, err
+	}
+	pairs := make(currency.Pairs, len(contracts))
+	for i := range contracts {
+		pairs[i] = contracts[i].Pair
+	}
+	positionDetails, err := exch.GetFuturesPositions(ctx, &order.PositionsRequest{
+		Asset: asset.Options,
+		Pairs: pairs,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gctrpc.GetOptionsPortfolioGreeksResponse{}
+	now := time.Now()
+	underlyingPrices := make(map[string]float64)
+	positions := make([]options.Position, 0, len(positionDetails))
+	for i := range positionDetails {
+		size := optionsPositionSize(positionDetails[i].Orders)
+		if size == 0 {
+			continue
+		}
+		var c *options.Contract
+		c, err = options.Parse(exch.GetName(), positionDetails[i].Pair)
+		if err != nil {
+			resp.Errors = append(resp.Errors, err.Error())
+			continue
+		}
+		if !underlying.IsEmpty() && !c.Underlying.Equal(underlying) {
+			continue
+		}
+		price, ok := underlyingPrices[c.Underlying.String()]
+		if !ok {
+			price, err = getOptionsUnderlyingPrice(ctx, exch, c.Underlying, r.UnderlyingPrice)
+			if err != nil {
+				resp.Errors = append(resp.Errors, err.Error())
+			}
+			underlyingPrices[c.Underlying.String()] = price
+		}
+		quote := evaluateOptionsContract(ctx, exch, c, price, r.RiskFreeRate, now)
+		if quote.err != nil {
+			resp.Errors = append(resp.Errors, quote.err.Error())
+		}
+		position := options.Position{Analytics: quote.analytics, Size: size}
+		positions = append(positions, position)
+		rpcPosition := &gctrpc.OptionPosition{
+			Quote:  optionQuoteToRPC(quote),
+			Expiry: c.Expiry.Format(common.SimpleTimeFormatWithTimezone),
+			Strike: c.Strike,
+			Size:   size,
+		}
+		if quote.analytics != nil {
+			rpcPosition.PositionGreeks = optionGreeksToRPC(options.AggregateGreeks([]options.Position{position}).Greeks)
+		}
+		resp.Positions = append(resp.Positions, rpcPosition)
+	}
+	resp.Total = optionGreeksToRPC(options.AggregateGreeks(positions).Greeks)
+	return resp, nil
+}
+
+// optionsQuote holds the evaluation of a single contract, err is set when the
+// contract could not be evaluated
+type optionsQuote struct {
+	contract  *options.Contract
+	bid, ask  float64
+	analytics *options.Analytics
+	err       error
+}
+
+func optionsUnderlyingFromRPC(p *gctrpc.CurrencyPair) (currency.Pair, error) {
+	if p == nil || (p.Base == "" && p.Quote == "") {
+		return currency.EMPTYPAIR, nil
+	}
+	return currency.NewPairFromStrings(p.Base, p.Quote)
+}
+
+// getOptionsContracts parses an exchange's enabled options pairs, optionally
+// filtered by underlying and expiry date
+func getOptionsContracts(exch exchange.IBotExchange, underlying currency.Pair, expiry time.Time) ([]options.Contract, error) {
+	if _, err := options.GetConvention(exch.GetName()); err != nil {
+		return nil, err
+	}
+	pairs, err := exch.GetEnabledPairs(asset.Options)
+	if err != nil {
+		return nil, err
+	}
+	contracts := make([]options.Contract, 0, len(pairs))
+	for i := range pairs {
+		c, err := options.Parse(exch.GetName(), pairs[i])
+		if err != nil {
+			log.Warnf(log.GRPCSys, "%s %v", exch.GetName(), err)
+			continue
+		}
+		if !underlying.IsEmpty() && !c.Underlying.Equal(underlying) {
+			continue
+		}
+		if !expiry.IsZero() && !c.Expiry.Truncate(time.Hour*24).Equal(expiry) {
+			continue
+		}
+		contracts = append(contracts, *c)
+	}
+	if len(contracts) == 0 {
+		return nil, fmt.Errorf("%s %w", exch.GetName(), errNoOptionsContracts)
+	}
+	return contracts, nil
+}
+
+// getOptionsUnderlyingPrice returns the supplied price when set, otherwise the
+// spot ticker of the underlying is used. USD underlyings fall back to USDT
+func getOptionsUnderlyingPrice(ctx context.Context, exch exchange.IBotExchange, underlying currency.Pair, price float64) (float64, error) {
+	if price > 0 {
+		return price, nil
+	}
+	candidates := []currency.Pair{underlying}
+	if underlying.Quote.Equal(currency.USD) {
+		candidates = append(candidates, currency.NewPair(underlying.Base, currency.USDT))
+	}
+	for i := range candidates {
+		t, err := exch.FetchTicker(ctx, candidates[i], asset.Spot)
+		if err != nil {
+			continue
+		}
+		if t.Bid > 0 && t.Ask > 0 {
+			return (t.Bid + t.Ask) / 2, nil
+		}
+		if t.Last > 0 {
+			return t.Last, nil
+		}
+	}
+	return 0, fmt.Errorf("%s %w", underlying, errNoUnderlyingPrice)
+}
+
+func evaluateOptionsContract(ctx context.Context, exch exchange.IBotExchange, c *options.Contract, underlyingPrice, riskFreeRate float64, at time.Time) *optionsQuote {
+	quote := &optionsQuote{contract: c}
+	ob, err := exch.FetchOrderbook(ctx, c.Pair, asset.Options)
+	if err != nil {
+		quote.err = err
+		return quote
+	}
+	if len(ob.Bids) > 0 {
+		quote.bid = ob.Bids[0].Price
+	}
+	if len(ob.Asks) > 0 {
+		quote.ask = ob.Asks[0].Price
+	}
+	if underlyingPrice <= 0 {
+		quote.err = fmt.Errorf("%s %w", c.Underlying, errNoUnderlyingPrice)
+		return quote
+	}
+	quote.analytics, quote.err = options.Evaluate(c, quote.bid, quote.ask, underlyingPrice, riskFreeRate, at)
+	return quote
+}
+
+// optionsPositionSize returns the net size of a position's orders, long
+// orders are positive and short orders negative
+func optionsPositionSize(orders []order.Detail) float64 {
+	var size float64
+	for i := range orders {
+		amount := orders[i].ExecutedAmount
+		if amount == 0 && orders[i].Status == order.Filled {
+			amount = orders[i].Amount
+		}
+		switch {
+		case orders[i].Side.IsLong():
+			size += amount
+		case orders[i].Side.IsShort():
+			size -= amount
+		}
+	}
+	return size
+}
+
+func optionQuoteToRPC(q *optionsQuote) *gctrpc.OptionQuote {
+	resp := &gctrpc.OptionQuote{
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: q.contract.Pair.Delimiter,
+			Base:      q.contract.Pair.Base.String(),
+			Quote:     q.contract.Pair.Quote.String(),
+		},
+		Type: q.contract.Type.String(),
+		Bid:  q.bid,
+		Ask:  q.ask,
+	}
+	if q.err != nil {
+		resp.Error = q.err.Error()
+	}
+	if q.analytics != nil {
+		resp.Mid = q.analytics.Mid
+		resp.ImpliedVolatility = q.analytics.ImpliedVolatility
+		resp.Greeks = optionGreeksToRPC(q.analytics.Greeks)
+	}
+	return resp
+}
+
+func optionGreeksToRPC(g options.Greeks) *gctrpc.OptionGreeks {
+	return &gctrpc.OptionGreeks{
+		Delta: g.Delta,
+		Gamma: g.Gamma,
+		Vega:  g.Vega,
+		Theta: g.Theta,
+		Rho:   g.Rho,
+	}
+}
+
+// GetBasisTermStructure returns the basis curve of each underlying on each
+// exchange from the basis service
+func (s *RPCServer) GetBasisTermStructure(ctx context.Context, r *gctrpc.GetBasisTermStructureRequest) (*gctrpc.GetBasisTermStructureResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if r.Refresh {
+		if _, err := s.basisService.Calculate(ctx); err != nil {
+			return nil, err
+		}
+	}
+	snapshot, err := s.basisService.LatestSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	terms, err := s.basisService.TermStructures(currency.NewCode(r.Underlying), r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetBasisTermStructureResponse{
+		Time:           snapshot.Time.Format(common.SimpleTimeFormatWithTimezone),
+		Errors:         snapshot.Errors,
+		TermStructures: make([]*gctrpc.BasisTermStructure, len(terms)),
+	}
+	for i := range terms {
+		resp.TermStructures[i] = &gctrpc.BasisTermStructure{
+			Exchange:   terms[i].Exchange,
+			Underlying: terms[i].Underlying.String(),
+			Points:     make([]*gctrpc.BasisPoint, len(terms[i].Points)),
+		}
+		for j := range terms[i].Points {
+			resp.TermStructures[i].Points[j] = basisPointToRPC(&terms[i].Points[j])
+		}
+	}
+	return resp, nil
+}
+
+// GetBasisHistory returns the basis of matching contracts from the basis
+// service's rolling history
+func (s *RPCServer) GetBasisHistory(_ context.Context, r *gctrpc.GetBasisHistoryRequest) (*gctrpc.GetBasisHistoryResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	var a asset.Item
+	if r.Asset != "" {
+		var err error
+		a, err = asset.New(r.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var cp currency.Pair
+	if r.Pair != nil {
+		var err error
+		cp, err = currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+		if err != nil {
+			return nil, err
+		}
+	}
+	points, err := s.basisService.History(currency.NewCode(r.Underlying), r.Exchange, a, cp)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetBasisHistoryResponse{Points: make([]*gctrpc.BasisPoint, len(points))}
+	for i := range points {
+		resp.Points[i] = basisPointToRPC(&points[i])
+	}
+	return resp, nil
+}
+
+func basisPointToRPC(p *BasisPoint) *gctrpc.BasisPoint {
+	resp := &gctrpc.BasisPoint{
+		Exchange: p.Exchange,
+		Asset:    p.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: p.Pair.Delimiter,
+			Base:      p.Pair.Base.String(),
+			Quote:     p.Pair.Quote.String(),
+		},
+		Underlying:   p.Underlying.String(),
+		SpotExchange: p.SpotExchange,
+		SpotPair: &gctrpc.CurrencyPair{
+			Delimiter: p.SpotPair.Delimiter,
+			Base:      p.SpotPair.Base.String(),
+			Quote:     p.SpotPair.Quote.String(),
+		},
+		SpotMid:         p.SpotMid.String(),
+		ContractMid:     p.ContractMid.String(),
+		Perpetual:       p.IsPerpetual,
+		Basis:           p.Basis.String(),
+		BasisRate:       p.BasisRate.String(),
+		AnnualisedBasis: p.AnnualisedBasis.String(),
+		Time:            p.Time.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	if !p.Expiry.IsZero() {
+		resp.Expiry = p.Expiry.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp
+}
+
+// GetCandleStream streams candles built from websocket trades by the candle
+// builder. The candle currently being built is sent first, followed by every
+// update, or only closed candles when requested
+func (s *RPCServer) GetCandleStream(r *gctrpc.GetCandleStreamRequest, stream gctrpc.GoCryptoTraderService_GetCandleStreamServer) error {
+	if r == nil {
+		return errNilRequestData
+	}
+	if r.Exchange == "" {
+		return errExchangeNameUnset
+	}
+	if r.Pair == nil {
+		return errCurrencyPairUnset
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return err
+	}
+	p, err := currency.NewPairFromStrings(r.Pair.Base, r.Pair.Quote)
+	if err != nil {
+		return err
+	}
+	interval := kline.Interval(r.TimeInterval)
+	pipe, err := s.candleBuilder.Subscribe(r.Exchange, p, a, interval)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pipeErr := pipe.Release()
+		if pipeErr != nil {
+			log.Errorln(log.DispatchMgr, pipeErr)
+		}
+	}()
+
+	if !r.ClosedOnly {
+		if current, err := s.candleBuilder.GetCurrentCandle(r.Exchange, p, a, interval); err == nil {
+			if err = stream.Send(candleUpdateToRPC(current)); err != nil {
+				return err
+			}
+		}
+	}
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case data, ok := <-pipe.Channel():
+			if !ok {
+				return errDispatchSystem
+			}
+			update, ok := data.(*CandleUpdate)
+			if !ok {
+				return common.GetTypeAssertError("*CandleUpdate", data)
+			}
+			if r.ClosedOnly && !update.Closed {
+				continue
+			}
+			if err := stream.Send(candleUpdateToRPC(update)); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func candleUpdateToRPC(u *CandleUpdate) *gctrpc.CandleStreamResponse {
+	return &gctrpc.CandleStreamResponse{
+		Exchange: u.Exchange,
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: u.Pair.Delimiter,
+			Base:      u.Pair.Base.String(),
+			Quote:     u.Pair.Quote.String(),
+		},
+		AssetType:    u.Asset.String(),
+		TimeInterval: int64(u.Interval),
+		Candle: &gctrpc.Candle{
+			Time:      u.Candle.Time.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			Low:       u.Candle.Low,
+			High:      u.Candle.High,
+			Open:      u.Candle.Open,
+			Close:     u.Candle.Close,
+			Volume:    u.Candle.Volume,
+			IsPartial: !u.Closed,
+		},
+		Closed: u.Closed,
+	}
+}
+
+// GetAlternativeBars builds alternative bars such as volume, tick, imbalance,
+// heikin-ashi or renko bars from trades or candles retrieved from an exchange
+// or the database
+func (s *RPCServer) GetAlternativeBars(ctx context.Context, r *gctrpc.GetAlternativeBarsRequest) (*gctrpc.GetAlternativeBarsResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if r.Pair == nil {
+		return nil, errCurrencyPairUnset
+	}
+	start, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.Start)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+	}
+	end, err := time.Parse(common.SimpleTimeFormatWithTimezone, r.End)
+	if err != nil {
+		return nil, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+	}
+	err = common.StartEndTimeCheck(start, end)
+	if err != nil {
+		return nil, err
+	}
+	barType, err := kline.BarTypeFromString(r.BarType)
+	if err != nil {
+		return nil, err
+	}
+	settings := kline.BarSettings{Type: barType, Threshold: r.Threshold}
+	err = settings.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if settings.Type.RequiresTrades() && !r.UseTrades {
+		return nil, fmt.Errorf("%w %v, please enable use trades", kline.ErrBarRequiresTrades, settings.Type)
+	}
+	pair := currency.Pair{
+		Delimiter: r.Pair.Delimiter,
+		Base:      currency.NewCode(r.Pair.Base),
+		Quote:     currency.NewCode(r.Pair.Quote),
+	}
+	a, err := asset.New(r.AssetType)
+	if err != nil {
+		return nil, err
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	err = checkParams(r.Exchange, exch, a, pair)
+	if err != nil {
+		return nil, err
+	}
+
+	var bars *kline.Item
+	if r.UseTrades {
+		var trades []trade.Data
+		if r.UseDb {
+			trades, err = trade.GetTradesInRange(r.Exchange, a.String(), r.Pair.Base, r.Pair.Quote, start, end)
+		} else {
+			trades, err = exch.GetHistoricTrades(ctx, pair, a, start, end)
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(trades) == 0 {
+			return nil, errNoTrades
+		}
+		bars, err = trade.ConvertTradesToBars(settings, trades...)
+	} else {
+		interval := kline.Interval(r.TimeInterval)
+		var candles *kline.Item
+		if r.UseDb {
+			candles, err = kline.LoadFromDatabase(r.Exchange, pair, a, interval, start, end)
+		} else {
+			candles, err = exch.GetHistoricCandlesExtended(ctx, pair, a, interval, start, end)
+		}
+		if err != nil {
+			return nil, err
+		}
+		bars, err = candles.ConvertToBars(settings)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &gctrpc.GetAlternativeBarsResponse{
+		Exchange:  exch.GetName(),
+		Pair:      r.Pair,
+		AssetType: a.String(),
+		Start:     start.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		End:       end.UTC().Format(common.SimpleTimeFormatWithTimezone),
+		BarType:   settings.Type.String(),
+		Threshold: settings.Threshold,
+	}
+	if bars.Interval > 0 {
+		resp.Interval = bars.Interval.Short()
+	}
+	resp.Candle = make([]*gctrpc.Candle, len(bars.Candles))
+	for i := range bars.Candles {
+		resp.Candle[i] = &gctrpc.Candle{
+			Time:   bars.Candles[i].Time.UTC().Format(common.SimpleTimeFormatWithTimezone),
+			Low:    bars.Candles[i].Low,
+			High:   bars.Candles[i].High,
+			Open:   bars.Candles[i].Open,
+			Close:  bars.Candles[i].Close,
+			Volume: bars.Candles[i].Volume,
+		}
+	}
+	return resp, nil
+}
+
+// GetInstruments returns the instrument specifications loaded by exchanges
+// which match the request filters
+func (s *RPCServer) GetInstruments(_ context.Context, r *gctrpc.GetInstrumentsRequest) (*gctrpc.GetInstrumentsResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	f := instrument.Filter{
+		Exchange: r.Exchange,
+		Base:     currency.NewCode(r.Base),
+		Quote:    currency.NewCode(r.Quote),
+	}
+	if r.Exchange != "" {
+		if _, err := s.GetExchangeByName(r.Exchange); err != nil {
+			return nil, err
+		}
+	}
+	var err error
+	if r.Asset != "" {
+		f.Asset, err = asset.New(r.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	f.ContractType, err = instrument.NewContractType(r.ContractType)
+	if err != nil {
+		return nil, err
+	}
+	f.Status, err = instrument.NewStatus(r.Status)
+	if err != nil {
+		return nil, err
+	}
+	instruments, err := instrument.GetInstruments(f)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetInstrumentsResponse{
+		Instruments: make([]*gctrpc.Instrument, len(instruments)),
+	}
+	for i := range instruments {
+		resp.Instruments[i] = instrumentToRPC(&instruments[i])
+	}
+	return resp, nil
+}
+
+// GetInstrument returns the instrument specification of an exchange asset
+// and pair
+func (s *RPCServer) GetInstrument(_ context.Context, r *gctrpc.GetInstrumentRequest) (*gctrpc.Instrument, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if r.Pair == nil {
+		return nil, errCurrencyPairUnset
+	}
+	exch, err := s.GetExchangeByName(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	a, err := asset.New(r.Asset)
+	if err != nil {
+		return nil, err
+	}
+	pair := currency.Pair{
+		Delimiter: r.Pair.Delimiter,
+		Base:      currency.NewCode(r.Pair.Base),
+		Quote:     currency.NewCode(r.Pair.Quote),
+	}
+	inst, err := exch.GetInstrument(a, pair)
+	if err != nil {
+		return nil, err
+	}
+	return instrumentToRPC(&inst), nil
+}
+
+// GetInstrumentStream streams instrument specifications as they are added or
+// change. An empty exchange streams the instruments of every exchange
+func (s *RPCServer) GetInstrumentStream(r *gctrpc.GetInstrumentStreamRequest, stream gctrpc.GoCryptoTraderService_GetInstrumentStreamServer) error {
+	if r == nil {
+		return errNilRequestData
+	}
+	if r.Exchange != "" {
+		if _, err := s.GetExchangeByName(r.Exchange); err != nil {
+			return err
+		}
+	}
+	pipe, err := instrument.Subscribe(r.Exchange)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		pipeErr := pipe.Release()
+		if pipeErr != nil {
+			log.Errorln(log.DispatchMgr, pipeErr)
+		}
+	}()
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case data, ok := <-pipe.Channel():
+			if !ok {
+				return errDispatchSystem
+			}
+			update, ok := data.(*instrument.Update)
+			if !ok {
+				return common.GetTypeAssertError("*instrument.Update", data)
+			}
+			err = stream.Send(&gctrpc.InstrumentStreamResponse{
+				Change:     update.Change.String(),
+				Instrument: instrumentToRPC(&update.Instrument),
+			})
+			if err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func instrumentToRPC(inst *instrument.Instrument) *gctrpc.Instrument {
+	resp := &gctrpc.Instrument{
+		Exchange: inst.Exchange,
+		Asset:    inst.Asset.String(),
+		Pair: &gctrpc.CurrencyPair{
+			Delimiter: inst.Pair.Delimiter,
+			Base:      inst.Pair.Base.String(),
+			Quote:     inst.Pair.Quote.String(),
+		},
+		Symbol:       inst.Symbol,
+		ContractType: inst.ContractType.String(),
+		Underlying: &gctrpc.CurrencyPair{
+			Delimiter: inst.Underlying.Delimiter,
+			Base:      inst.Underlying.Base.String(),
+			Quote:     inst.Underlying.Quote.String(),
+		},
+		ContractSize:         inst.ContractSize,
+		ContractSizeCurrency: inst.ContractSizeCurrency.String(),
+		Multiplier:           inst.Multiplier,
+		SettlementCurrency:   inst.SettlementCurrency.String(),
+		MarginCurrency:       inst.MarginCurrency.String(),
+		Inverse:              inst.IsInverse(),
+		Status:               inst.Status.String(),
+		Limits: &gctrpc.InstrumentLimits{
+			MinPrice:       inst.Limits.MinPrice,
+			MaxPrice:       inst.Limits.MaxPrice,
+			PriceStep:      inst.Limits.PriceStepIncrementSize,
+			MinBaseAmount:  inst.Limits.MinimumBaseAmount,
+			MaxBaseAmount:  inst.Limits.MaximumBaseAmount,
+			AmountStep:     inst.Limits.AmountStepIncrementSize,
+			MinQuoteAmount: inst.Limits.MinimumQuoteAmount,
+			MaxQuoteAmount: inst.Limits.MaximumQuoteAmount,
+			QuoteStep:      inst.Limits.QuoteStepIncrementSize,
+			MinNotional:    inst.Limits.MinNotional,
+			MarketMinQty:   inst.Limits.MarketMinQty,
+			MarketMaxQty:   inst.Limits.MarketMaxQty,
+			MarketStep:     inst.Limits.MarketStepIncrementSize,
+			MaxTotalOrders: inst.Limits.MaxTotalOrders,
+		},
+		LastUpdated: inst.LastUpdated.Format(common.SimpleTimeFormatWithTimezone),
+	}
+	if !inst.Expiry.IsZero() {
+		resp.Expiry = inst.Expiry.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if !inst.ListingTime.IsZero() {
+		resp.ListingTime = inst.ListingTime.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return resp
+}
+
+// lifecycleStreamFilter narrows the events sent by the order, fill and
+// position streams, unset fields match every event
+type lifecycleStreamFilter struct {
+	exchange string
+	asset    asset.Item
+	pair     currency.Pair
+	status   order.Status
+}
+
+func (s *RPCServer) newLifecycleStreamFilter(exchangeName, assetType string, pair *gctrpc.CurrencyPair, status string) (*lifecycleStreamFilter, error) {
+	f := &lifecycleStreamFilter{}
+	if exchangeName != "" {
+		exch, err := s.GetExchangeByName(exchangeName)
+		if err != nil {
+			return nil, err
+		}
+		f.exchange = exch.GetName()
+	}
+	if assetType != "" {
+		a, err := asset.New(assetType)
+		if err != nil {
+			return nil, err
+		}
+		f.asset = a
+	}
+	if pair != nil && (pair.Base != "" || pair.Quote != "") {
+		cp, err := currency.NewPairFromStrings(pair.Base, pair.Quote)
+		if err != nil {
+			return nil, err
+		}
+		f.pair = cp
+	}
+	if status != "" {
+		st, err := order.StringToOrderStatus(status)
+		if err != nil {
+			return nil, err
+		}
+		f.status = st
+	}
+	return f, nil
+}
+
+func (f *lifecycleStreamFilter) match(exchangeName string, a asset.Item, p currency.Pair, st order.Status) bool {
+	return (f.exchange == "" || strings.EqualFold(f.exchange, exchangeName)) &&
+		(f.asset == asset.Empty || f.asset == a) &&
+		(f.pair.IsEmpty() || f.pair.Equal(p)) &&
+		(f.status == order.UnknownStatus || f.status == st)
+}
+
+// streamFeed sends the replayed events of a subscription followed by every
+// new event until the client disconnects or falls behind
+func streamFeed(ctx context.Context, sub *feedSubscription, send func(*sequencedEvent) error) error {
+	defer sub.Release()
+	replay := sub.Replay()
+	for i := range replay {
+		if err := send(&replay[i]); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case ev, ok := <-sub.Channel():
+			if !ok {
+				return sub.Err()
+			}
+			if err := send(&ev); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// GetOrderUpdateStream streams order status changes tracked by the order
+// manager. Supplying from_sequence replays retained updates from that sequence
+// so a reconnecting client does not miss updates
+func (s *RPCServer) GetOrderUpdateStream(r *gctrpc.GetOrderUpdateStreamRequest, stream gctrpc.GoCryptoTraderService_GetOrderUpdateStreamServer) error {
+	if r == nil {
+		return errNilRequestData
+	}
+	f, err := s.newLifecycleStreamFilter(r.Exchange, r.Asset, r.Pair, r.Status)
+	if err != nil {
+		return err
+	}
+	sub, err := s.OrderManager.subscribeOrderUpdates(r.FromSequence)
+	if err != nil {
+		return err
+	}
+	return streamFeed(stream.Context(), sub, func(ev *sequencedEvent) error {
+		d, ok := ev.Data.(order.Detail)
+		if !ok {
+			return common.GetTypeAssertError("order.Detail", ev.Data)
+		}
+		if !f.match(d.Exchange, d.AssetType, d.Pair, d.Status) {
+			return nil
+		}
+		return stream.Send(&gctrpc.OrderUpdateStreamResponse{
+			Sequence: ev.Sequence,
+			Time:     ev.Time.Format(common.SimpleTimeFormatWithTimezone),
+			Order:    s.orderDetailToRPC(&d),
+		})
+	})
+}
+
+// GetFillStream streams fills received from exchange websockets. Supplying
+// from_sequence replays retained fills from that sequence
+func (s *RPCServer) GetFillStream(r *gctrpc.GetFillStreamRequest, stream gctrpc.GoCryptoTraderService_GetFillStreamServer) error {
+	if r == nil {
+		return errNilRequestData
+	}
+	f, err := s.newLifecycleStreamFilter(r.Exchange, r.Asset, r.Pair, "")
+	if err != nil {
+		return err
+	}
+	sub, err := s.OrderManager.subscribeFills(r.FromSequence)
+	if err != nil {
+		return err
+	}
+	return streamFeed(stream.Context(), sub, func(ev *sequencedEvent) error {
+		d, ok := ev.Data.(fill.Data)
+		if !ok {
+			return common.GetTypeAssertError("fill.Data", ev.Data)
+		}
+		if !f.match(d.Exchange, d.AssetType, d.CurrencyPair, order.UnknownStatus) {
+			return nil
+		}
+		return stream.Send(&gctrpc.FillStreamResponse{
+			Sequence: ev.Sequence,
+			Time:     ev.Time.Format(common.SimpleTimeFormatWithTimezone),
+			Fill: &gctrpc.Fill{
+				Exchange: d.Exchange,
+				Asset:    d.AssetType.String(),
+				Pair: &gctrpc.CurrencyPair{
+					Delimiter: d.CurrencyPair.Delimiter,
+					Base:      d.CurrencyPair.Base.String(),
+					Quote:     d.CurrencyPair.Quote.String(),
+				},
+				Side:          d.Side.String(),
+				OrderId:       d.OrderID,
+				ClientOrderId: d.ClientOrderID,
+				TradeId:       d.TradeID,
+				Price:         d.Price,
+				Amount:        d.Amount,
+				Time:          d.Timestamp.Format(common.SimpleTimeFormatWithTimezone),
+			},
+		})
+	})
+}
+
+// GetPositionStream streams futures position updates tracked by the order
+// manager. Supplying from_sequence replays retained updates from that
+// sequence
+func (s *RPCServer) GetPositionStream(r *gctrpc.GetPositionStreamRequest, stream gctrpc.GoCryptoTraderService_GetPositionStreamServer) error {
+	if r == nil {
+		return errNilRequestData
+	}
+	f, err := s.newLifecycleStreamFilter(r.Exchange, r.Asset, r.Pair, r.Status)
+	if err != nil {
+		return err
+	}
+	sub, err := s.OrderManager.subscribePositions(r.FromSequence)
+	if err != nil {
+		return err
+	}
+	return streamFeed(stream.Context(), sub, func(ev *sequencedEvent) error {
+		p, ok := ev.Data.(order.Position)
+		if !ok {
+			return common.GetTypeAssertError("order.Position", ev.Data)
+		}
+		if !f.match(p.Exchange, p.Asset, p.Pair, p.Status) {
+			return nil
+		}
+		return stream.Send(&gctrpc.PositionStreamResponse{
+			Sequence: ev.Sequence,
+			Time:     ev.Time.Format(common.SimpleTimeFormatWithTimezone),
+			Position: s.buildFuturePosition(&p, false, false, false, false),
+		})
+	})
+}
+
+func (s *RPCServer) orderDetailToRPC(d *order.Detail) *gctrpc.OrderDetails {
+	trades := make([]*gctrpc.TradeHistory, len(d.Trades))
+	for i := range d.Trades {
+		trades[i] = &gctrpc.TradeHistory{
+			Id:        d.Trades[i].TID,
+			Price:     d.Trades[i].Price,
+			Amount:    d.Trades[i].Amount,
+			Exchange:  d.Exchange,
+			AssetType: d.AssetType.String(),
+			OrderSide: d.Trades[i].Side.String(),
+			Fee:       d.Trades[i].Fee,
+			Total:     d.Trades[i].Total,
+		}
+		if !d.Trades[i].Timestamp.IsZero() {
+			trades[i].CreationTime = s.unixTimestamp(d.Trades[i].Timestamp)
+		}
+	}
+	o := &gctrpc.OrderDetails{
+		Exchange:      d.Exchange,
+		Id:            d.OrderID,
+		ClientOrderId: d.ClientOrderID,
+		CorrelationId: d.CorrelationID,
+		BaseCurrency:  d.Pair.Base.String(),
+		QuoteCurrency: d.Pair.Quote.String(),
+		AssetType:     d.AssetType.String(),
+		OrderSide:     d.Side.String(),
+		OrderType:     d.Type.String(),
+		Status:        d.Status.String(),
+		Price:         d.Price,
+		Amount:        d.Amount,
+		OpenVolume:    d.Amount - d.ExecutedAmount,
+		Fee:           d.Fee,
+		Cost:          d.Cost,
+		Trades:        trades,
+	}
+	if !d.Date.IsZero() {
+		o.CreationTime = d.Date.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	if !d.LastUpdated.IsZero() {
+		o.UpdateTime = d.LastUpdated.Format(common.SimpleTimeFormatWithTimezone)
+	}
+	return o
+}
+
+// parseAccountingTimeRange parses an optional start and end time. An empty
+// start is the zero time and an empty end is now
+func parseAccountingTimeRange(startTime, endTime string) (start, end time.Time, err error) {
+	if startTime != "" {
+		start, err = time.Parse(common.SimpleTimeFormatWithTimezone, startTime)
+		if err != nil {
+			return start, end, fmt.Errorf("%w cannot parse start time %v", errInvalidTimes, err)
+		}
+	}
+	if endTime != "" {
+		end, err = time.Parse(common.SimpleTimeFormatWithTimezone, endTime)
+		if err != nil {
+			return start, end, fmt.Errorf("%w cannot parse end time %v", errInvalidTimes, err)
+		}
+	}
+	return start, end, nil
+}
+
+// GetPNLReport returns the realised and unrealised PNL, fees and funding
+// tracked by the accounting manager over a time range in the reporting
+// currency
+func (s *RPCServer) GetPNLReport(_ context.Context, r *gctrpc.GetPNLReportRequest) (*gctrpc.GetPNLReportResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	start, end, err := parseAccountingTimeRange(r.Start, r.End)
+	if err != nil {
+		return nil, err
+	}
+	filter := &PNLFilter{Exchange: r.Exchange}
+	if r.Asset != "" {
+		filter.Asset, err = asset.New(r.Asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if r.Currency != "" {
+		filter.Currency = currency.NewCode(r.Currency)
+	}
+	report, err := s.accountingManager.PNLReport(start, end, filter)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetPNLReportResponse{
+		Start:             report.Start.Format(common.SimpleTimeFormatWithTimezone),
+		End:               report.End.Format(common.SimpleTimeFormatWithTimezone),
+		ReportingCurrency: report.ReportingCurrency.String(),
+		CostBasisMethod:   report.CostBasisMethod.String(),
+		RealisedPnl:       report.RealisedPNL.String(),
+		UnrealisedPnl:     report.UnrealisedPNL.String(),
+		Fees:              report.Fees.String(),
+		Funding:           report.Funding.String(),
+		TotalPnl:          report.TotalPNL.String(),
+		Breakdown:         make([]*gctrpc.PNLBreakdown, len(report.Breakdown)),
+		Errors:            report.Errors,
+	}
+	for i := range report.Breakdown {
+		b := &report.Breakdown[i]
+		resp.Breakdown[i] = &gctrpc.PNLBreakdown{
+			Exchange:      b.Exchange,
+			Asset:         b.Asset.String(),
+			Currency:      b.Currency.String(),
+			Amount:        b.Amount.String(),
+			CostBasis:     b.CostBasis.String(),
+			MarketValue:   b.MarketValue.String(),
+			RealisedPnl:   b.RealisedPNL.String(),
+			UnrealisedPnl: b.UnrealisedPNL.String(),
+			Fees:          b.Fees.String(),
+			Funding:       b.Funding.String(),
+			Unmatched:     b.Unmatched.String(),
+		}
+	}
+	return resp, nil
+}
+
+// GetNAVHistory returns the daily net asset value snapshots taken by the
+// accounting manager over a time range. Snapshots value every exchange, so
+// exchange scoped users must have every loaded exchange in their scope
+func (s *RPCServer) GetNAVHistory(ctx context.Context, r *gctrpc.GetNAVHistoryRequest) (*gctrpc.GetNAVHistoryResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if u, userErr := rpcUserFromContext(ctx); userErr == nil && len(u.Exchanges) > 0 {
+		exchanges, err := s.ExchangeManager.GetExchanges()
+		if err != nil {
+			return nil, err
+		}
+		for i := range exchanges {
+			if !u.permitsExchange(exchanges[i].GetName()) {
+				return nil, fmt.Errorf("%w %s %s", errRPCExchangeNotInScope, u.Username, exchanges[i].GetName())
+			}
+		}
+	}
+	start, end, err := parseAccountingTimeRange(r.Start, r.End)
+	if err != nil {
+		return nil, err
+	}
+	snapshots, err := s.accountingManager.NAVHistory(start, end)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetNAVHistoryResponse{Snapshots: make([]*gctrpc.NAVSnapshot, len(snapshots))}
+	for i := range snapshots {
+		resp.Snapshots[i] = &gctrpc.NAVSnapshot{
+			Time:              snapshots[i].Time.Format(common.SimpleTimeFormatWithTimezone),
+			ReportingCurrency: snapshots[i].ReportingCurrency.String(),
+			Nav:               snapshots[i].NAV.String(),
+			RealisedPnl:       snapshots[i].RealisedPNL.String(),
+			UnrealisedPnl:     snapshots[i].UnrealisedPNL.String(),
+			Fees:              snapshots[i].Fees.String(),
+			Funding:           snapshots[i].Funding.String(),
+		}
+	}
+	return resp, nil
+}
+
+// GetTaxReport builds a capital gains and income report from the order,
+// funding and withdrawal history of the exchanges and returns it with CSV
+// exports. Exchange scoped users are limited to the exchanges in their scope
+func (s *RPCServer) GetTaxReport(ctx context.Context, r *gctrpc.GetTaxReportRequest) (*gctrpc.GetTaxReportResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	start, end, err := parseAccountingTimeRange(r.Start, r.End)
+	if err != nil {
+		return nil, err
+	}
+	historyStart, _, err := parseAccountingTimeRange(r.HistoryStart, "")
+	if err != nil {
+		return nil, err
+	}
+	methodName := r.CostBasisMethod
+	if methodName == "" && s.Config != nil {
+		methodName = s.Config.Accounting.CostBasisMethod
+	}
+	method, err := costbasis.ParseMethod(methodName)
+	if err != nil {
+		return nil, err
+	}
+	exchanges := r.Exchanges
+	if u, userErr := rpcUserFromContext(ctx); userErr == nil && len(u.Exchanges) > 0 {
+		if len(exchanges) == 0 {
+			exchanges = u.Exchanges
+		}
+		for i := range exchanges {
+			if !u.permitsExchange(exchanges[i]) {
+				return nil, fmt.Errorf("%w %s %s", errRPCExchangeNotInScope, u.Username, exchanges[i])
+			}
+		}
+	}
+	req := &TaxReportRequest{
+		Start:        start,
+		End:          end,
+		HistoryStart: historyStart,
+		Method:       method,
+		Exchanges:    exchanges,
+	}
+	if r.BaseCurrency != "" {
+		req.BaseCurrency = currency.NewCode(r.BaseCurrency)
+	}
+	report, err := BuildTaxReport(ctx, s.ExchangeManager, req)
+	if err != nil {
+		return nil, err
+	}
+	var gains, income, ledger strings.Builder
+	if err = report.WriteCapitalGainsCSV(&gains); err != nil {
+		return nil, err
+	}
+	if err = report.WriteIncomeCSV(&income); err != nil {
+		return nil, err
+	}
+	if err = report.WriteLedgerCSV(&ledger); err != nil {
+		return nil, err
+	}
+	return &gctrpc.GetTaxReportResponse{
+		Start:             report.Start.Format(common.SimpleTimeFormatWithTimezone),
+		End:               report.End.Format(common.SimpleTimeFormatWithTimezone),
+		BaseCurrency:      report.BaseCurrency.String(),
+		CostBasisMethod:   report.Method.String(),
+		Proceeds:          report.Proceeds.String(),
+		Cost:              report.Cost.String(),
+		Gain:              report.Gain.String(),
+		UnmatchedProceeds: report.UnmatchedProceeds.String(),
+		Income:            report.Income.String(),
+		Disposals:         int64(len(report.Disposals)),
+		IncomeEntries:     int64(len(report.IncomeEntries)),
+		LedgerEntries:     int64(len(report.Ledger)),
+		CapitalGainsCsv:   gains.String(),
+		IncomeCsv:         income.String(),
+		LedgerCsv:         ledger.String(),
+		Errors:            report.Errors,
+	}, nil
+}
+
+// GetLedgerReconciliation returns the expected and exchange balances of the
+// last ledger reconciliation and any discrepancies found, optionally
+// reconciling first. Exchange scoped users only receive the exchanges in
+// their scope
+func (s *RPCServer) GetLedgerReconciliation(ctx context.Context, r *gctrpc.GetLedgerReconciliationRequest) (*gctrpc.GetLedgerReconciliationResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	u, userErr := rpcUserFromContext(ctx)
+	scoped := userErr == nil && len(u.Exchanges) > 0
+	if scoped && r.Exchange != "" && !u.permitsExchange(r.Exchange) {
+		return nil, fmt.Errorf("%w %s %s", errRPCExchangeNotInScope, u.Username, r.Exchange)
+	}
+	if r.Refresh {
+		if _, err := s.ledgerManager.Reconcile(ctx); err != nil {
+			return nil, err
+		}
+	}
+	result, err := s.ledgerManager.LastReconciliation(r.Exchange)
+	if err != nil {
+		return nil, err
+	}
+	resp := &gctrpc.GetLedgerReconciliationResponse{
+		Time:     result.Time.Format(common.SimpleTimeFormatWithTimezone),
+		Imported: int64(result.Imported),
+	}
+	for i := range result.Balances {
+		b := &result.Balances[i]
+		if scoped && !u.permitsExchange(b.Exchange) {
+			continue
+		}
+		var since string
+		if !b.Since.IsZero() {
+			since = b.Since.Format(common.SimpleTimeFormatWithTimezone)
+		}
+		resp.Balances = append(resp.Balances, &gctrpc.LedgerBalance{
+			Exchange:    b.Exchange,
+			SubAccount:  b.SubAccount,
+			Asset:       b.Asset.String(),
+			Currency:    b.Currency.String(),
+			Expected:    b.Expected.String(),
+			Actual:      b.Actual.String(),
+			Difference:  b.Difference.String(),
+			Discrepancy: string(b.Discrepancy),
+			Since:       since,
+		})
+	}
+	for i := range result.Errors {
+		if scoped && !u.permitsExchange(strings.SplitN(result.Errors[i], ":", 2)[0]) {
+			continue
+		}
+		resp.Errors = append(resp.Errors, result.Errors[i])
+	}
+	return resp, nil
+}
+
+// GetRebalancePlan returns the trades which would move holdings towards the
+// target portfolio weights without submitting them
+func (s *RPCServer) GetRebalancePlan(ctx context.Context, r *gctrpc.GetRebalancePlanRequest) (*gctrpc.RebalancePlanResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if err := s.checkRebalanceScope(ctx); err != nil {
+		return nil, err
+	}
+	plan, err := s.rebalancer.Plan(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return rebalancePlanResponse(plan), nil
+}
+
+// ExecuteRebalance submits the trades which move holdings towards the target
+// portfolio weights, unless dry run is requested or configured
+func (s *RPCServer) ExecuteRebalance(ctx context.Context, r *gctrpc.ExecuteRebalanceRequest) (*gctrpc.RebalancePlanResponse, error) {
+	if r == nil {
+		return nil, errNilRequestData
+	}
+	if err := s.checkRebalanceScope(ctx); err != nil {
+		return nil, err
+	}
+	plan, err := s.rebalancer.Execute(ctx, r.DryRun)
+	if err != nil {
+		return nil, err
+	}
+	return rebalancePlanResponse(plan), nil
+}
+
+// checkRebalanceScope rejects users scoped to exchanges unless every exchange
+// the rebalancer trades on is in scope, as a rebalance values and trades the
+// whole portfolio
+func (s *RPCServer) checkRebalanceScope(ctx context.Context) error {
+	u, err := rpcUserFromContext(ctx)
+	if err != nil || len(u.Exchanges) == 0 {
+		return nil
+	}
+	exchanges := s.rebalancer.Exchanges()
+	for i := range exchanges {
+		if !u.permitsExchange(exchanges[i]) {
+			return fmt.Errorf("%w %s %s", errRPCExchangeNotInScope, u.Username, exchanges[i])
+		}
+	}
+	return nil
+}
+
+func rebalancePlanResponse(plan *RebalancePlan) *gctrpc.RebalancePlanResponse {
+	resp := &gctrpc.RebalancePlanResponse{
+		Time:              plan.Time.Format(common.SimpleTimeFormatWithTimezone),
+		ReportingCurrency: plan.ReportingCurrency.String(),
+		QuoteCurrency:     plan.QuoteCurrency.String(),
+		TotalValue:        plan.TotalValue.String(),
+		DryRun:            plan.DryRun,
+		Executed:          plan.Executed,
+		Errors:            plan.Errors,
+	}
+	for i := range plan.Weights {
+		w := &plan.Weights[i]
+		resp.Weights = append(resp.Weights, &gctrpc.RebalanceWeight{
+			Currency:    w.Currency.String(),
+			Amount:      w.Amount.String(),
+			Value:       w.Value.String(),
+			Weight:      w.Weight.String(),
+			Target:      w.Target.String(),
+			Band:        w.Band.String(),
+			TargetValue: w.TargetValue.String(),
+			WithinBand:  w.WithinBand,
+		})
+	}
+	for i := range plan.Trades {
+		t := &plan.Trades[i]
+		resp.Trades = append(resp.Trades, &gctrpc.RebalanceTrade{
+			Exchange: t.Exchange,
+			Pair:     t.Pair.String(),
+			Side:     t.Side.String(),
+			Amount:   t.Amount.String(),
+			Price:    t.Price.String(),
+			Value:    t.Value.String(),
+			Status:   string(t.Status),
+			Reason:   t.Reason,
+			OrderId:  t.OrderID,
+		})
+	}
+	return resp
+}
@@ -0,0 +1,530 @@
+package engine
+
+import (
+	"context"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/audit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// rpcRole is a bitmask of remote control roles, a method permits every role
+// set in its mask
+type rpcRole uint8
+
+const (
+	rpcRoleReadOnly rpcRole = 1 << iota
+	rpcRoleTrader
+	rpcRoleRisk
+	rpcRoleAdmin
+
+	rpcRolesAll     = rpcRoleReadOnly | rpcRoleTrader | rpcRoleRisk | rpcRoleAdmin
+	rpcRolesTrading = rpcRoleTrader | rpcRoleAdmin
+	rpcRolesCancel  = rpcRoleTrader | rpcRoleRisk | rpcRoleAdmin
+	rpcRolesRisk    = rpcRoleRisk | rpcRoleAdmin
+)
+
+const rpcAuditEventType = "rpc"
+
+var (
+	errAuthorisationMissing   = errors.New("authorization header missing")
+	errAuthorisationInvalid   = errors.New("invalid authorization header")
+	errInvalidCredentials     = errors.New("invalid credentials")
+	errUnknownCertificate     = errors.New("client certificate common name not permitted")
+	errRPCUserNotFound        = errors.New("rpc user not found in context")
+	errRPCMethodNotPermitted  = errors.New("rpc method not permitted for role")
+	errRPCExchangeNotInScope  = errors.New("exchange not permitted for user")
+	errRPCExchangeUnspecified = errors.New("exchange scoped users must specify an exchange")
+	errInvalidRPCRole         = errors.New("invalid rpc role")
+)
+
+// rpcMethodRoles maps every gRPC method to the roles permitted to call it.
+// Methods which are not listed are restricted to the admin role. Methods
+// which do not permit the read only role are recorded in the audit log
+var rpcMethodRoles = map[string]rpcRole{
+	"GetInfo":                           rpcRolesAll,
+	"GetSubsystems":                     rpcRolesAll,
+	"EnableSubsystem":                   rpcRoleAdmin,
+	"DisableSubsystem":                  rpcRoleAdmin,
+	"GetRPCEndpoints":                   rpcRolesAll,
+	"GetCommunicationRelayers":          rpcRolesAll,
+	"GetExchanges":                      rpcRolesAll,
+	"DisableExchange":                   rpcRolesRisk,
+	"GetExchangeInfo":                   rpcRolesAll,
+	"GetExchangeOTPCode":                rpcRoleAdmin,
+	"GetExchangeOTPCodes":               rpcRoleAdmin,
+	"EnableExchange":                    rpcRoleAdmin,
+	"GetTicker":                         rpcRolesAll,
+	"GetTickers":                        rpcRolesAll,
+	"GetOrderbook":                      rpcRolesAll,
+	"GetOrderbooks":                     rpcRolesAll,
+	"GetAccountInfo":                    rpcRolesAll,
+	"UpdateAccountInfo":                 rpcRolesAll,
+	"GetAccountInfoStream":              rpcRolesAll,
+	"GetConfig":                         rpcRoleAdmin,
+	"GetPortfolio":                      rpcRolesAll,
+	"GetPortfolioSummary":               rpcRolesAll,
+	"AddPortfolioAddress":               rpcRoleAdmin,
+	"RemovePortfolioAddress":            rpcRoleAdmin,
+	"GetForexProviders":                 rpcRolesAll,
+	"GetForexRates":                     rpcRolesAll,
+	"GetOrders":                         rpcRolesAll,
+	"GetOrder":                          rpcRolesAll,
+	"SubmitOrder":                       rpcRolesTrading,
+	"SimulateOrder":                     rpcRolesAll,
+	"WhaleBomb":                         rpcRolesAll,
+	"CancelOrder":                       rpcRolesCancel,
+	"CancelBatchOrders":                 rpcRolesCancel,
+	"CancelAllOrders":                   rpcRolesCancel,
+	"GetEvents":                         rpcRolesAll,
+	"AddEvent":                          rpcRolesTrading,
+	"RemoveEvent":                       rpcRolesTrading,
+	"GetCryptocurrencyDepositAddresses": rpcRolesAll,
+	"GetCryptocurrencyDepositAddress":   rpcRolesAll,
+	"GetAvailableTransferChains":        rpcRolesAll,
+	"WithdrawFiatFunds":                 rpcRoleAdmin,
+	"WithdrawCryptocurrencyFunds":       rpcRoleAdmin,
+	"WithdrawalEventByID":               rpcRolesAll,
+	"WithdrawalEventsByExchange":        rpcRolesAll,
+	"WithdrawalEventsByDate":            rpcRolesAll,
+	"GetLoggerDetails":                  rpcRolesAll,
+	"SetLoggerDetails":                  rpcRoleAdmin,
+	"GetExchangePairs":                  rpcRolesAll,
+	"SetExchangePair":                   rpcRoleAdmin,
+	"GetOrderbookStream":                rpcRolesAll,
+	"GetExchangeOrderbookStream":        rpcRolesAll,
+	"GetTickerStream":                   rpcRolesAll,
+	"GetExchangeTickerStream":           rpcRolesAll,
+	"GetAuditEvent":                     rpcRolesRisk,
+	"GCTScriptExecute":                  rpcRoleAdmin,
+	"GCTScriptUpload":                   rpcRoleAdmin,
+	"GCTScriptReadScript":               rpcRoleAdmin,
+	"GCTScriptStatus":                   rpcRolesAll,
+	"GCTScriptQuery":                    rpcRoleAdmin,
+	"GCTScriptStop":                     rpcRoleAdmin,
+	"GCTScriptStopAll":                  rpcRoleAdmin,
+	"GCTScriptListAll":                  rpcRolesAll,
+	"GCTScriptAutoLoadToggle":           rpcRoleAdmin,
+	"GetHistoricCandles":                rpcRolesAll,
+	"SetExchangeAsset":                  rpcRoleAdmin,
+	"SetAllExchangePairs":               rpcRoleAdmin,
+	"UpdateExchangeSupportedPairs":      rpcRoleAdmin,
+	"GetExchangeAssets":                 rpcRolesAll,
+	"WebsocketGetInfo":                  rpcRolesAll,
+	"WebsocketSetEnabled":               rpcRoleAdmin,
+	"WebsocketGetSubscriptions":         rpcRolesAll,
+	"WebsocketSetProxy":                 rpcRoleAdmin,
+	"WebsocketSetURL":                   rpcRoleAdmin,
+	"GetRecentTrades":                   rpcRolesAll,
+	"GetHistoricTrades":                 rpcRolesAll,
+	"GetSavedTrades":                    rpcRolesAll,
+	"ConvertTradesToCandles":            rpcRoleAdmin,
+	"FindMissingSavedCandleIntervals":   rpcRolesAll,
+	"FindMissingSavedTradeIntervals":    rpcRolesAll,
+	"SetExchangeTradeProcessing":        rpcRoleAdmin,
+	"UpsertDataHistoryJob":              rpcRoleAdmin,
+	"GetDataHistoryJobDetails":          rpcRolesAll,
+	"GetActiveDataHistoryJobs":          rpcRolesAll,
+	"GetDataHistoryJobsBetween":         rpcRolesAll,
+	"GetDataHistoryJobSummary":          rpcRolesAll,
+	"SetDataHistoryJobStatus":           rpcRoleAdmin,
+	"UpdateDataHistoryJobPrerequisite":  rpcRoleAdmin,
+	"GetManagedOrders":                  rpcRolesAll,
+	"ModifyOrder":                       rpcRolesTrading,
+	"CurrencyStateGetAll":               rpcRolesAll,
+	"CurrencyStateTrading":              rpcRolesAll,
+	"CurrencyStateDeposit":              rpcRolesAll,
+	"CurrencyStateWithdraw":             rpcRolesAll,
+	"CurrencyStateTradingPair":          rpcRolesAll,
+	"GetFuturesPositions":               rpcRolesAll,
+	"GetCollateral":                     rpcRolesAll,
+	"Shutdown":                          rpcRoleAdmin,
+	"GetTechnicalAnalysis":              rpcRolesAll,
+	"GetMarginRatesHistory":             rpcRolesAll,
+	"GetManagedPosition":                rpcRolesAll,
+	"GetAllManagedPositions":            rpcRolesAll,
+	"GetFundingRates":                   rpcRolesAll,
+	"GetLatestFundingRate":              rpcRolesAll,
+	"GetOrderbookMovement":              rpcRolesAll,
+	"GetOrderbookAmountByNominal":       rpcRolesAll,
+	"GetOrderbookAmountByImpact":        rpcRolesAll,
+	"ReloadConfig":                      rpcRoleAdmin,
+	"GetDataRetentionStatus":            rpcRolesAll,
+	"RunDataRetention":                  rpcRoleAdmin,
+	"GetFundingRateOpportunities":       rpcRolesAll,
+	"GetOptionsChain":                   rpcRolesAll,
+	"GetOptionsPortfolioGreeks":         rpcRolesAll,
+	"GetBasisTermStructure":             rpcRolesAll,
+	"GetBasisHistory":                   rpcRolesAll,
+	"GetCandleStream":                   rpcRolesAll,
+	"GetAlternativeBars":                rpcRolesAll,
+	"GetInstruments":                    rpcRolesAll,
+	"GetInstrument":                     rpcRolesAll,
+	"GetInstrumentStream":               rpcRolesAll,
+	"GetOrderUpdateStream":              rpcRolesAll,
+	"GetFillStream":                     rpcRolesAll,
+	"GetPositionStream":                 rpcRolesAll,
+	"GetPNLReport":                      rpcRolesAll,
+	"GetNAVHistory":                     rpcRolesAll,
+	"GetTaxReport":                      rpcRolesAll,
+	"GetLedgerReconciliation":           rpcRolesAll,
+	"GetRebalancePlan":                  rpcRolesAll,
+	"ExecuteRebalance":                  rpcRolesTrading,
+}
+
+// rpcUser is the authenticated identity attached to the context of every
+// gRPC request
+type rpcUser struct {
+	Username  string
+	Role      rpcRole
+	Exchanges []string
+}
+
+type rpcUserContextKey struct{}
+
+// String implements the stringer interface
+func (r rpcRole) String() string {
+	switch r {
+	case rpcRoleReadOnly:
+		return config.RPCRoleReadOnly
+	case rpcRoleTrader:
+		return config.RPCRoleTrader
+	case rpcRoleRisk:
+		return config.RPCRoleRisk
+	case rpcRoleAdmin:
+		return config.RPCRoleAdmin
+	default:
+		return "unknown"
+	}
+}
+
+func newRPCRole(s string) (rpcRole, error) {
+	switch strings.ToLower(s) {
+	case config.RPCRoleReadOnly:
+		return rpcRoleReadOnly, nil
+	case config.RPCRoleTrader:
+		return rpcRoleTrader, nil
+	case config.RPCRoleRisk:
+		return rpcRoleRisk, nil
+	case config.RPCRoleAdmin:
+		return rpcRoleAdmin, nil
+	default:
+		return 0, fmt.Errorf("%w %q", errInvalidRPCRole, s)
+	}
+}
+
+// rpcUserFromContext returns the user authenticated for the request
+func rpcUserFromContext(ctx context.Context) (*rpcUser, error) {
+	u, ok := ctx.Value(rpcUserContextKey{}).(*rpcUser)
+	if !ok {
+		return nil, errRPCUserNotFound
+	}
+	return u, nil
+}
+
+// permitsExchange returns whether the user's exchange scope includes the
+// exchange
+func (u *rpcUser) permitsExchange(exch string) bool {
+	if len(u.Exchanges) == 0 {
+		return true
+	}
+	for i := range u.Exchanges {
+		if strings.EqualFold(u.Exchanges[i], exch) {
+			return true
+		}
+	}
+	return false
+}
+
+func newRPCUserFromConfig(u *config.RPCUser) (*rpcUser, error) {
+	role, err := newRPCRole(u.Role)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", u.Username, err)
+	}
+	return &rpcUser{Username: u.Username, Role: role, Exchanges: u.Exchanges}, nil
+}
+
+func secureCompare(a, b string) bool {
+	return subtle.ConstantTimeCompare([]byte(a), []byte(b)) == 1
+}
+
+func (s *RPCServer) authenticateClient(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, fmt.Errorf("unable to extract metadata")
+	}
+
+	user, err := s.identifyClient(ctx, md)
+	if err != nil {
+		return ctx, status.Error(codes.Unauthenticated, err.Error())
+	}
+	ctx = context.WithValue(ctx, rpcUserContextKey{}, user)
+
+	ctx, err = account.ParseCredentialsMetadata(ctx, md)
+	if err != nil {
+		return ctx, err
+	}
+
+	if _, ok := md["verbose"]; ok {
+		ctx = request.WithVerbose(ctx)
+	}
+	return ctx, nil
+}
+
+// identifyClient returns the user of a verified client certificate when
+// certificate authentication is enabled, otherwise the user matching the
+// basic or bearer authorization header
+func (s *RPCServer) identifyClient(ctx context.Context, md metadata.MD) (*rpcUser, error) {
+	rc := &s.Config.RemoteControl
+	if rc.GRPC.ClientCertificateAuth {
+		if p, ok := peer.FromContext(ctx); ok {
+			if info, ok := p.AuthInfo.(credentials.TLSInfo); ok &&
+				len(info.State.VerifiedChains) > 0 &&
+				len(info.State.VerifiedChains[0]) > 0 {
+				cn := info.State.VerifiedChains[0][0].Subject.CommonName
+				for i := range rc.Users {
+					if rc.Users[i].CertificateCommonName != "" && rc.Users[i].CertificateCommonName == cn {
+						return newRPCUserFromConfig(&rc.Users[i])
+					}
+				}
+				return nil, fmt.Errorf("%w %q", errUnknownCertificate, cn)
+			}
+		}
+	}
+
+	authStr := md.Get("authorization")
+	if len(authStr) == 0 {
+		return nil, errAuthorisationMissing
+	}
+	scheme, value, ok := strings.Cut(authStr[0], " ")
+	if !ok {
+		return nil, errAuthorisationInvalid
+	}
+	switch {
+	case strings.EqualFold(scheme, "Basic"):
+		decoded, err := crypto.Base64Decode(value)
+		if err != nil {
+			return nil, fmt.Errorf("%w unable to base64 decode", errAuthorisationInvalid)
+		}
+		username, password, ok := strings.Cut(string(decoded), ":")
+		if !ok {
+			return nil, errAuthorisationInvalid
+		}
+		if rc.Username != "" &&
+			secureCompare(username, rc.Username) &&
+			secureCompare(password, rc.Password) {
+			return &rpcUser{Username: rc.Username, Role: rpcRoleAdmin}, nil
+		}
+		for i := range rc.Users {
+			if rc.Users[i].Password != "" &&
+				secureCompare(username, rc.Users[i].Username) &&
+				secureCompare(password, rc.Users[i].Password) {
+				return newRPCUserFromConfig(&rc.Users[i])
+			}
+		}
+	case strings.EqualFold(scheme, "Bearer"):
+		for i := range rc.Users {
+			if rc.Users[i].Token != "" && secureCompare(value, rc.Users[i].Token) {
+				return newRPCUserFromConfig(&rc.Users[i])
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w unsupported scheme %q", errAuthorisationInvalid, scheme)
+	}
+	return nil, errInvalidCredentials
+}
+
+// authoriseMethod checks the role of the authenticated user permits the
+// method
+func authoriseMethod(ctx context.Context, fullMethod string) error {
+	u, err := rpcUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	method := path.Base(fullMethod)
+	if u.Role&methodRoles(method) == 0 {
+		return fmt.Errorf("%w %s cannot call %s as %s", errRPCMethodNotPermitted, u.Username, method, u.Role)
+	}
+	return nil
+}
+
+// authoriseRequest checks an exchange scoped user only sends requests for
+// the exchanges in its scope. Requests without an exchange are limited to
+// methods permitted to the read only role
+func authoriseRequest(ctx context.Context, fullMethod string, req interface{}) error {
+	u, err := rpcUserFromContext(ctx)
+	if err != nil {
+		return err
+	}
+	if len(u.Exchanges) == 0 {
+		return nil
+	}
+	method := path.Base(fullMethod)
+	exch, ok := requestExchange(req)
+	if !ok {
+		if methodRoles(method)&rpcRoleReadOnly == 0 {
+			return fmt.Errorf("%w %s %s", errRPCExchangeUnspecified, u.Username, method)
+		}
+		return nil
+	}
+	if exch == "" {
+		return fmt.Errorf("%w %s %s", errRPCExchangeUnspecified, u.Username, method)
+	}
+	if !u.permitsExchange(exch) {
+		return fmt.Errorf("%w %s %s %s", errRPCExchangeNotInScope, u.Username, method, exch)
+	}
+	return nil
+}
+
+func methodRoles(method string) rpcRole {
+	roles, ok := rpcMethodRoles[method]
+	if !ok {
+		return rpcRoleAdmin
+	}
+	return roles
+}
+
+// requestExchange returns the exchange named by a request message and
+// whether the message has an exchange field
+func requestExchange(req interface{}) (string, bool) {
+	m, ok := req.(proto.Message)
+	if !ok {
+		return "", false
+	}
+	msg := m.ProtoReflect()
+	fields := msg.Descriptor().Fields()
+	for _, name := range []protoreflect.Name{"exchange", "exchange_name"} {
+		fd := fields.ByName(name)
+		if fd == nil || fd.Kind() != protoreflect.StringKind || fd.IsList() {
+			continue
+		}
+		return msg.Get(fd).String(), true
+	}
+	return "", false
+}
+
+// auditRequest records calls to methods which are not permitted to the read
+// only role along with the identity of the caller
+func auditRequest(ctx context.Context, fullMethod string, req interface{}, err error) {
+	method := path.Base(fullMethod)
+	if methodRoles(method)&rpcRoleReadOnly != 0 {
+		return
+	}
+	u, userErr := rpcUserFromContext(ctx)
+	if userErr != nil {
+		return
+	}
+	result := "success"
+	if err != nil {
+		result = err.Error()
+	}
+	msg := fmt.Sprintf("method: %s role: %s", method, u.Role)
+	if exch, ok := requestExchange(req); ok && exch != "" {
+		msg += " exchange: " + exch
+	}
+	if id := tracing.CorrelationID(ctx); id != "" {
+		msg += " correlation_id: " + id
+	}
+	audit.Event(u.Username, rpcAuditEventType, msg+" result: "+result)
+}
+
+// authoriseUnary is a unary interceptor which enforces the role and exchange
+// scope of the authenticated user
+func authoriseUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	err := authoriseMethod(ctx, info.FullMethod)
+	if err == nil {
+		err = authoriseRequest(ctx, info.FullMethod, req)
+	}
+	if err != nil {
+		auditRequest(ctx, info.FullMethod, req, err)
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	}
+	resp, err := handler(ctx, req)
+	auditRequest(ctx, info.FullMethod, req, err)
+	return resp, err
+}
+
+// authorisedStream checks each received message against the exchange scope
+// of the authenticated user
+type authorisedStream struct {
+	grpc.ServerStream
+	method string
+}
+
+// RecvMsg receives a message and authorises it
+func (a *authorisedStream) RecvMsg(m interface{}) error {
+	if err := a.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if err := authoriseRequest(a.Context(), a.method, m); err != nil {
+		auditRequest(a.Context(), a.method, m, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	return nil
+}
+
+// authoriseStream is a stream interceptor which enforces the role and
+// exchange scope of the authenticated user
+func authoriseStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	if err := authoriseMethod(ss.Context(), info.FullMethod); err != nil {
+		auditRequest(ss.Context(), info.FullMethod, nil, err)
+		return status.Error(codes.PermissionDenied, err.Error())
+	}
+	err := handler(srv, &authorisedStream{ServerStream: ss, method: info.FullMethod})
+	auditRequest(ss.Context(), info.FullMethod, nil, err)
+	return err
+}
+
+// rpcServerTLSConfig returns the gRPC server TLS config, requesting client
+// certificates signed by the configured CA when certificate authentication is
+// enabled
+func rpcServerTLSConfig(targetDir string, cfg *config.GRPCConfig) (*tls.Config, error) {
+	cert, err := tls.LoadX509KeyPair(filepath.Join(targetDir, "cert.pem"), filepath.Join(targetDir, "key.pem"))
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		Certificates: []tls.Certificate{cert},
+		MinVersion:   tls.VersionTLS12,
+	}
+	if !cfg.ClientCertificateAuth {
+		return tlsConfig, nil
+	}
+	caFile := cfg.ClientCAFile
+	if caFile == "" {
+		caFile = filepath.Join(targetDir, "ca.pem")
+	}
+	caPEM, err := os.ReadFile(caFile)
+	if err != nil {
+		return nil, err
+	}
+	pool := x509.NewCertPool()
+	if !pool.AppendCertsFromPEM(caPEM) {
+		return nil, fmt.Errorf("no certificates found in client CA file %s", caFile)
+	}
+	tlsConfig.ClientCAs = pool
+	// Clients without a certificate may still authenticate via basic auth or
+	// a token, which also allows the gRPC proxy to connect
+	tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven
+	return tlsConfig, nil
+}
@@ -0,0 +1,286 @@
+package engine
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/config"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+func newAuthTestRPCServer() *RPCServer {
+	return &RPCServer{Engine: &Engine{Config: &config.Config{
+		RemoteControl: config.RemoteControlConfig{
+			Username: "admin",
+			Password: "Password",
+			Users: []config.RPCUser{
+				{Username: "viewer", Password: "viewpass", Role: config.RPCRoleReadOnly},
+				{Username: "bot", Token: "bottoken", Role: config.RPCRoleTrader, Exchanges: []string{"Bitstamp"}},
+				{Username: "desk", CertificateCommonName: "desk.local", Role: config.RPCRoleRisk},
+			},
+			GRPC: config.GRPCConfig{ClientCertificateAuth: true},
+		},
+	}}}
+}
+
+func basicAuthContext(username, password string) context.Context {
+	enc := base64.StdEncoding.EncodeToString([]byte(username + ":" + password))
+	return metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Basic "+enc))
+}
+
+func TestRPCMethodRolesCoverage(t *testing.T) {
+	t.Parallel()
+	for i := range gctrpc.GoCryptoTraderService_ServiceDesc.Methods {
+		if _, ok := rpcMethodRoles[gctrpc.GoCryptoTraderService_ServiceDesc.Methods[i].MethodName]; !ok {
+			t.Errorf("method %s has no roles", gctrpc.GoCryptoTraderService_ServiceDesc.Methods[i].MethodName)
+		}
+	}
+	for i := range gctrpc.GoCryptoTraderService_ServiceDesc.Streams {
+		if _, ok := rpcMethodRoles[gctrpc.GoCryptoTraderService_ServiceDesc.Streams[i].StreamName]; !ok {
+			t.Errorf("stream %s has no roles", gctrpc.GoCryptoTraderService_ServiceDesc.Streams[i].StreamName)
+		}
+	}
+}
+
+func TestNewRPCRole(t *testing.T) {
+	t.Parallel()
+	r, err := newRPCRole("TRADER")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if r != rpcRoleTrader {
+		t.Errorf("received: '%v' but expected: '%v'", r, rpcRoleTrader)
+	}
+	_, err = newRPCRole("superuser")
+	if !errors.Is(err, errInvalidRPCRole) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidRPCRole)
+	}
+}
+
+func TestIdentifyClient(t *testing.T) {
+	t.Parallel()
+	s := newAuthTestRPCServer()
+	testCases := []struct {
+		name string
+		md   metadata.MD
+		user string
+		role rpcRole
+		err  error
+	}{
+		{name: "missing", md: metadata.MD{}, err: errAuthorisationMissing},
+		{name: "malformed", md: metadata.Pairs("authorization", "Basic"), err: errAuthorisationInvalid},
+		{name: "scheme", md: metadata.Pairs("authorization", "Digest abc"), err: errAuthorisationInvalid},
+		{name: "no colon", md: metadata.Pairs("authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin"))), err: errAuthorisationInvalid},
+		{name: "legacy admin", md: metadata.Pairs("authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("admin:Password"))), user: "admin", role: rpcRoleAdmin},
+		{name: "user", md: metadata.Pairs("authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("viewer:viewpass"))), user: "viewer", role: rpcRoleReadOnly},
+		{name: "wrong password", md: metadata.Pairs("authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("viewer:nope"))), err: errInvalidCredentials},
+		{name: "token", md: metadata.Pairs("authorization", "Bearer bottoken"), user: "bot", role: rpcRoleTrader},
+		{name: "wrong token", md: metadata.Pairs("authorization", "Bearer nope"), err: errInvalidCredentials},
+	}
+	for x := range testCases {
+		tc := testCases[x]
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			u, err := s.identifyClient(context.Background(), tc.md)
+			if !errors.Is(err, tc.err) {
+				t.Fatalf("received: '%v' but expected: '%v'", err, tc.err)
+			}
+			if tc.err != nil {
+				return
+			}
+			if u.Username != tc.user {
+				t.Errorf("received: '%v' but expected: '%v'", u.Username, tc.user)
+			}
+			if u.Role != tc.role {
+				t.Errorf("received: '%v' but expected: '%v'", u.Role, tc.role)
+			}
+		})
+	}
+}
+
+func TestIdentifyClientCertificate(t *testing.T) {
+	t.Parallel()
+	s := newAuthTestRPCServer()
+	certPeer := func(cn string) context.Context {
+		return peer.NewContext(context.Background(), &peer.Peer{
+			AuthInfo: credentials.TLSInfo{State: tls.ConnectionState{
+				VerifiedChains: [][]*x509.Certificate{{{Subject: pkix.Name{CommonName: cn}}}},
+			}},
+		})
+	}
+	u, err := s.identifyClient(certPeer("desk.local"), metadata.MD{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if u.Username != "desk" || u.Role != rpcRoleRisk {
+		t.Errorf("received: '%v' but expected: '%v'", u.Username, "desk")
+	}
+	_, err = s.identifyClient(certPeer("intruder"), metadata.MD{})
+	if !errors.Is(err, errUnknownCertificate) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnknownCertificate)
+	}
+
+	// A peer without a verified certificate falls back to the authorization
+	// header
+	noCert := peer.NewContext(context.Background(), &peer.Peer{AuthInfo: credentials.TLSInfo{}})
+	u, err = s.identifyClient(noCert, metadata.Pairs("authorization", "Bearer bottoken"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if u.Username != "bot" {
+		t.Errorf("received: '%v' but expected: '%v'", u.Username, "bot")
+	}
+}
+
+func TestAuthenticateClient(t *testing.T) {
+	t.Parallel()
+	s := newAuthTestRPCServer()
+	_, err := s.authenticateClient(basicAuthContext("viewer", "nope"))
+	if status.Code(err) != codes.Unauthenticated {
+		t.Fatalf("received: '%v' but expected: '%v'", status.Code(err), codes.Unauthenticated)
+	}
+	ctx, err := s.authenticateClient(basicAuthContext("viewer", "viewpass"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	u, err := rpcUserFromContext(ctx)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if u.Username != "viewer" {
+		t.Errorf("received: '%v' but expected: '%v'", u.Username, "viewer")
+	}
+}
+
+func TestAuthoriseMethod(t *testing.T) {
+	t.Parallel()
+	err := authoriseMethod(context.Background(), "/gctrpc.GoCryptoTraderService/GetInfo")
+	if !errors.Is(err, errRPCUserNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errRPCUserNotFound)
+	}
+	testCases := []struct {
+		role   rpcRole
+		method string
+		err    error
+	}{
+		{role: rpcRoleReadOnly, method: "GetInfo"},
+		{role: rpcRoleReadOnly, method: "SubmitOrder", err: errRPCMethodNotPermitted},
+		{role: rpcRoleReadOnly, method: "GetConfig", err: errRPCMethodNotPermitted},
+		{role: rpcRoleTrader, method: "SubmitOrder"},
+		{role: rpcRoleTrader, method: "CancelOrder"},
+		{role: rpcRoleTrader, method: "WithdrawCryptocurrencyFunds", err: errRPCMethodNotPermitted},
+		{role: rpcRoleRisk, method: "CancelAllOrders"},
+		{role: rpcRoleRisk, method: "DisableExchange"},
+		{role: rpcRoleRisk, method: "SubmitOrder", err: errRPCMethodNotPermitted},
+		{role: rpcRoleAdmin, method: "Shutdown"},
+		{role: rpcRoleTrader, method: "SomeFutureMethod", err: errRPCMethodNotPermitted},
+		{role: rpcRoleAdmin, method: "SomeFutureMethod"},
+	}
+	for x := range testCases {
+		ctx := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: testCases[x].role})
+		err = authoriseMethod(ctx, "/gctrpc.GoCryptoTraderService/"+testCases[x].method)
+		if !errors.Is(err, testCases[x].err) {
+			t.Errorf("%s %s received: '%v' but expected: '%v'", testCases[x].role, testCases[x].method, err, testCases[x].err)
+		}
+	}
+}
+
+func TestAuthoriseRequest(t *testing.T) {
+	t.Parallel()
+	unscoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleTrader})
+	err := authoriseRequest(unscoped, "SubmitOrder", &gctrpc.SubmitOrderRequest{Exchange: "Binance"})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	scoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleTrader, Exchanges: []string{"Bitstamp"}})
+	testCases := []struct {
+		method string
+		req    interface{}
+		err    error
+	}{
+		{method: "SubmitOrder", req: &gctrpc.SubmitOrderRequest{Exchange: "bitstamp"}},
+		{method: "SubmitOrder", req: &gctrpc.SubmitOrderRequest{Exchange: "Binance"}, err: errRPCExchangeNotInScope},
+		{method: "SubmitOrder", req: &gctrpc.SubmitOrderRequest{}, err: errRPCExchangeUnspecified},
+		{method: "FindMissingSavedCandleIntervals", req: &gctrpc.FindMissingCandlePeriodsRequest{ExchangeName: "Binance"}, err: errRPCExchangeNotInScope},
+		{method: "GetInfo", req: &gctrpc.GetInfoRequest{}},
+		{method: "ReloadConfig", req: &gctrpc.ReloadConfigRequest{}, err: errRPCExchangeUnspecified},
+	}
+	for x := range testCases {
+		err = authoriseRequest(scoped, "/gctrpc.GoCryptoTraderService/"+testCases[x].method, testCases[x].req)
+		if !errors.Is(err, testCases[x].err) {
+			t.Errorf("%s received: '%v' but expected: '%v'", testCases[x].method, err, testCases[x].err)
+		}
+	}
+}
+
+func TestAuthoriseUnary(t *testing.T) {
+	t.Parallel()
+	ctx := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleReadOnly})
+	var called bool
+	handler := func(context.Context, interface{}) (interface{}, error) {
+		called = true
+		return &gctrpc.GenericResponse{}, nil
+	}
+	_, err := authoriseUnary(ctx, &gctrpc.SubmitOrderRequest{}, &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTraderService/SubmitOrder"}, handler)
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("received: '%v' but expected: '%v'", status.Code(err), codes.PermissionDenied)
+	}
+	if called {
+		t.Error("handler should not be called when permission is denied")
+	}
+	_, err = authoriseUnary(ctx, &gctrpc.GetInfoRequest{}, &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTraderService/GetInfo"}, handler)
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !called {
+		t.Error("handler should be called")
+	}
+}
+
+func TestRPCServerTLSConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	cfg := &config.GRPCConfig{}
+	_, err := rpcServerTLSConfig(dir, cfg)
+	if err == nil {
+		t.Fatal("expected an error when the server certificate is missing")
+	}
+	err = CheckCerts(dir)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	tlsConfig, err := rpcServerTLSConfig(dir, cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if tlsConfig.ClientAuth != tls.NoClientCert {
+		t.Errorf("received: '%v' but expected: '%v'", tlsConfig.ClientAuth, tls.NoClientCert)
+	}
+
+	cfg.ClientCertificateAuth = true
+	_, err = rpcServerTLSConfig(dir, cfg)
+	if err == nil {
+		t.Fatal("expected an error when the client CA file is missing")
+	}
+	cfg.ClientCAFile = filepath.Join(dir, "cert.pem")
+	tlsConfig, err = rpcServerTLSConfig(dir, cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if tlsConfig.ClientAuth != tls.VerifyClientCertIfGiven {
+		t.Errorf("received: '%v' but expected: '%v'", tlsConfig.ClientAuth, tls.VerifyClientCertIfGiven)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"log"
+	"math"
 	"os"
 	"path/filepath"
 	"reflect"
@@ -22,6 +23,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	"github.com/thrasher-corp/gocryptotrader/database/drivers"
 	"github.com/thrasher-corp/gocryptotrader/database/repository"
+	"github.com/thrasher-corp/gocryptotrader/database/repository/candle"
 	dbexchange "github.com/thrasher-corp/gocryptotrader/database/repository/exchange"
 	sqltrade "github.com/thrasher-corp/gocryptotrader/database/repository/trade"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -30,17 +32,22 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/binance"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/options"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
 	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	gctlog "github.com/thrasher-corp/gocryptotrader/log"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/withdraw"
 	"github.com/thrasher-corp/goose"
 	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 const (
@@ -2789,6 +2796,51 @@ func TestGetTechnicalAnalysis(t *testing.T) {
 	if len(resp.Signals["RSI"].Signals) != 33 {
 		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Signals["RSI"].Signals), 33)
 	}
+
+	for _, tc := range []struct {
+		request *gctrpc.GetTechnicalAnalysisRequest
+		signals []string
+	}{
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "stoch", Period: 14, KSmoothing: 3, DPeriod: 3}, []string{"K", "D"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "stochrsi", Period: 9, StochasticPeriod: 9, KSmoothing: 3, DPeriod: 3}, []string{"K", "D"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "adx", Period: 9}, []string{"ADX", "PLUS_DI", "MINUS_DI"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "ichimoku", ConversionPeriod: 9, BasePeriod: 26, SpanBPeriod: 30, Displacement: 26}, []string{"CONVERSION", "BASE", "SPAN_A", "SPAN_B", "LAGGING"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "supertrend", Period: 10, Multiplier: 3}, []string{"SUPERTREND", "DIRECTION"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "keltner", Period: 20, AtrPeriod: 10, Multiplier: 2}, []string{"UPPER", "MIDDLE", "LOWER"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "donchian", Period: 20}, []string{"UPPER", "MIDDLE", "LOWER"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "psar", AccelerationStep: 0.02, AccelerationMaximum: 0.2}, []string{"PSAR"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "cci", Period: 20}, []string{"CCI"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "willr", Period: 14}, []string{"WILLR"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "avwap"}, []string{"AVWAP"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "zscore", Period: 20}, []string{"ZSCORE"}},
+		{&gctrpc.GetTechnicalAnalysisRequest{AlgorithmType: "hurst", Period: 16}, []string{"HURST"}},
+	} {
+		tc.request.Exchange = fakeExchangeName
+		tc.request.AssetType = "spot"
+		tc.request.Pair = &gctrpc.CurrencyPair{Base: "btc", Quote: "usd"}
+		tc.request.Interval = int64(kline.OneDay)
+		resp, err = s.GetTechnicalAnalysis(context.Background(), tc.request)
+		if !errors.Is(err, nil) {
+			t.Fatalf("%s received: '%v' but expected: '%v'", tc.request.AlgorithmType, err, nil)
+		}
+		for _, signal := range tc.signals {
+			if resp.Signals[signal] == nil || len(resp.Signals[signal].Signals) != 33 {
+				t.Fatalf("%s received no '%s' signals for each candle", tc.request.AlgorithmType, signal)
+			}
+		}
+	}
+
+	_, err = s.GetTechnicalAnalysis(context.Background(), &gctrpc.GetTechnicalAnalysisRequest{
+		Exchange:      fakeExchangeName,
+		AssetType:     "spot",
+		Pair:          &gctrpc.CurrencyPair{Base: "btc", Quote: "usd"},
+		Interval:      int64(kline.OneDay),
+		AlgorithmType: "avwap",
+		Anchor:        timestamppb.New(time.Now().AddDate(1, 0, 0)),
+	})
+	if err == nil {
+		t.Fatal("expected an error for an anchor after the last candle")
+	}
 }
 
 func TestGetMarginRatesHistory(t *testing.T) {
@@ -3128,6 +3180,33 @@ func TestGetLatestFundingRate(t *testing.T) {
 	}
 }
 
+func TestBuildFuturePositionLiquidationEstimate(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{}
+	resp := s.buildFuturePosition(&order.Position{}, false, false, false, false)
+	if resp.LiquidationEstimate != nil {
+		t.Errorf("received '%v', expected '%v'", resp.LiquidationEstimate, nil)
+	}
+	resp = s.buildFuturePosition(&order.Position{
+		LiquidationEstimate: &order.LiquidationEstimate{
+			Mode:                  order.IsolatedMargin,
+			HasLiquidationPrice:   true,
+			LiquidationPrice:      decimal.NewFromInt(18000),
+			DistanceToLiquidation: decimal.NewFromFloat(0.1),
+			Risk:                  order.MarginRiskWarning,
+		},
+	}, false, false, false, false)
+	if resp.LiquidationEstimate == nil {
+		t.Fatal("expected liquidation estimate")
+	}
+	if resp.LiquidationEstimate.MarginMode != "isolated" ||
+		resp.LiquidationEstimate.LiquidationPrice != "18000" ||
+		resp.LiquidationEstimate.DistanceToLiquidation != "0.1" ||
+		resp.LiquidationEstimate.Risk != "warning" {
+		t.Errorf("received '%v', expected isolated warning estimate liquidating at 18000", resp.LiquidationEstimate)
+	}
+}
+
 func TestGetManagedPosition(t *testing.T) {
 	t.Parallel()
 	em := NewExchangeManager()
@@ -3697,3 +3776,1004 @@ func TestGetOrderbookAmountByImpact(t *testing.T) {
 		t.Fatalf("received: '%v' but expected: '%v'", impact.AmountRequired, 1)
 	}
 }
+
+func TestRPCServerReloadConfig(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.ReloadConfig(context.Background(), &gctrpc.ReloadConfigRequest{DryRun: true})
+	if !errors.Is(err, errNilConfig) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilConfig)
+	}
+
+	path := filepath.Join(t.TempDir(), config.File)
+	cfg := &config.Config{}
+	err = cfg.LoadConfig(config.TestFile, true)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = cfg.SaveConfigToFile(path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s.Engine = &Engine{
+		Config:          cfg,
+		ExchangeManager: NewExchangeManager(),
+		Settings:        Settings{ConfigFile: path},
+	}
+	resp, err := s.ReloadConfig(context.Background(), &gctrpc.ReloadConfigRequest{DryRun: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Changes) != 0 || resp.Applied || resp.RestartRequired {
+		t.Fatalf("received: '%+v' but expected an empty result", resp)
+	}
+}
+
+func TestRPCServerDataRetention(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetDataRetentionStatus(context.Background(), &gctrpc.GetDataRetentionStatusRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = s.RunDataRetention(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+
+	s.dataRetentionManager = &DataRetentionManager{
+		started:                    1,
+		databaseConnectionInstance: &connectedDatabase{},
+		policies:                   []config.DataRetentionPolicy{{DataType: DataRetentionTrades, MaxAge: time.Hour}},
+		candleSeriesLister:         func() ([]candle.SeriesSummary, error) { return nil, nil },
+		tradeSeriesLister:          func() ([]sqltrade.SeriesSummary, error) { return nil, nil },
+	}
+	status, err := s.GetDataRetentionStatus(context.Background(), &gctrpc.GetDataRetentionStatusRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !status.Running || len(status.Policies) != 1 || status.Policies[0].MaxAge != "1h0m0s" || status.LastReport != nil {
+		t.Fatalf("received: '%+v' but expected a running manager with one policy", status)
+	}
+	report, err := s.RunDataRetention(context.Background(), &gctrpc.RunDataRetentionRequest{DryRun: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !report.DryRun || report.Removed != 0 {
+		t.Fatalf("received: '%+v' but expected an empty dry run", report)
+	}
+	status, err = s.GetDataRetentionStatus(context.Background(), &gctrpc.GetDataRetentionStatusRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if status.LastReport == nil || !status.LastReport.DryRun {
+		t.Fatalf("received: '%+v' but expected the last report", status.LastReport)
+	}
+}
+
+func TestGetFundingRateOpportunities(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetFundingRateOpportunities(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetFundingRateOpportunities(context.Background(), &gctrpc.GetFundingRateOpportunitiesRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	s.fundingRateScanner = &FundingRateScanner{
+		lastScan: &FundingRateScan{
+			Time: time.Now(),
+			Opportunities: []FundingRateOpportunity{
+				{
+					Underlying: currency.BTC,
+					Long: FundingRateSnapshot{
+						Exchange:    "one",
+						Asset:       asset.USDTMarginedFutures,
+						Pair:        currency.NewPair(currency.BTC, currency.USDT),
+						Frequency:   time.Hour * 8,
+						CurrentRate: decimal.NewFromFloat(0.0001),
+					},
+					Short: FundingRateSnapshot{
+						Exchange:    "two",
+						Asset:       asset.PerpetualSwap,
+						Pair:        currency.NewPair(currency.BTC, currency.USDT),
+						Frequency:   time.Hour * 8,
+						CurrentRate: decimal.NewFromFloat(0.001),
+					},
+					NetAnnualisedSpread: decimal.NewFromFloat(0.9),
+				},
+			},
+		},
+	}
+	_, err = s.GetFundingRateOpportunities(context.Background(), &gctrpc.GetFundingRateOpportunitiesRequest{Refresh: true})
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	resp, err := s.GetFundingRateOpportunities(context.Background(), &gctrpc.GetFundingRateOpportunitiesRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Opportunities) != 1 || resp.Opportunities[0].Short.AnnualisedRate != "1.095" {
+		t.Fatalf("received: '%+v' but expected one opportunity", resp.Opportunities)
+	}
+	resp, err = s.GetFundingRateOpportunities(context.Background(), &gctrpc.GetFundingRateOpportunitiesRequest{Underlying: "eth"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Opportunities) != 0 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Opportunities), 0)
+	}
+}
+
+// optionsExchange is a fake options exchange which prices every contract at a
+// fixed volatility
+type optionsExchange struct {
+	exchange.IBotExchange
+}
+
+func (o optionsExchange) FetchTicker(_ context.Context, p currency.Pair, a asset.Item) (*ticker.Price, error) {
+	if !p.Quote.Equal(currency.USDT) {
+		return nil, errExpectedTestError
+	}
+	return &ticker.Price{Bid: 30000, Ask: 30000, Pair: p, AssetType: a}, nil
+}
+
+func (o optionsExchange) FetchOrderbook(_ context.Context, p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	c, err := options.Parse(o.GetName(), p)
+	if err != nil {
+		return nil, err
+	}
+	price, err := options.Price(c.Type, 30000, c.Strike, options.YearsToExpiry(c.Expiry, time.Now()), 0, 0.6)
+	if err != nil {
+		return nil, err
+	}
+	// premiums are quoted in BTC
+	price /= 30000
+	return &orderbook.Base{
+		Pair:  p,
+		Asset: a,
+		Bids:  orderbook.Items{{Price: price * 0.999, Amount: 1}},
+		Asks:  orderbook.Items{{Price: price * 1.001, Amount: 1}},
+	}, nil
+}
+
+func (o optionsExchange) GetFuturesPositions(_ context.Context, req *order.PositionsRequest) ([]order.PositionDetails, error) {
+	resp := make([]order.PositionDetails, len(req.Pairs))
+	for i := range req.Pairs {
+		resp[i] = order.PositionDetails{
+			Exchange: o.GetName(),
+			Asset:    req.Asset,
+			Pair:     req.Pairs[i],
+			Orders: []order.Detail{
+				{Side: order.Buy, Amount: 3, ExecutedAmount: 3, Status: order.Filled, Pair: req.Pairs[i]},
+				{Side: order.Sell, Amount: 1, Status: order.Filled, Pair: req.Pairs[i]},
+			},
+		}
+	}
+	return resp, nil
+}
+
+func setupOptionsRPC(t *testing.T) *RPCServer {
+	t.Helper()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("okx")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	b := exch.GetBase()
+	b.Enabled = true
+	expiry := time.Now().AddDate(0, 1, 0).Format("060102")
+	call := currency.NewPairWithDelimiter("BTC", "USD-"+expiry+"-30000-C", "-")
+	put := currency.NewPairWithDelimiter("BTC", "USD-"+expiry+"-30000-P", "-")
+	b.CurrencyPairs.Pairs = map[asset.Item]*currency.PairStore{
+		asset.Options: {
+			AssetEnabled:  convert.BoolPtr(true),
+			RequestFormat: &currency.PairFormat{Delimiter: "-", Uppercase: true},
+			ConfigFormat:  &currency.PairFormat{Delimiter: "-", Uppercase: true},
+			Available:     currency.Pairs{call, put},
+			Enabled:       currency.Pairs{call, put},
+		},
+	}
+	if err = em.Add(optionsExchange{IBotExchange: exch}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return &RPCServer{Engine: &Engine{ExchangeManager: em}}
+}
+
+func TestGetOptionsChain(t *testing.T) {
+	t.Parallel()
+	s := setupOptionsRPC(t)
+	_, err := s.GetOptionsChain(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetOptionsChain(context.Background(), &gctrpc.GetOptionsChainRequest{
+		Exchange:   "okx",
+		Underlying: &gctrpc.CurrencyPair{Base: "ETH", Quote: "USD"},
+	})
+	if !errors.Is(err, errNoOptionsContracts) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoOptionsContracts)
+	}
+
+	resp, err := s.GetOptionsChain(context.Background(), &gctrpc.GetOptionsChainRequest{
+		Exchange:   "okx",
+		Underlying: &gctrpc.CurrencyPair{Base: "BTC", Quote: "USD"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Chains) != 1 || len(resp.Chains[0].Strikes) != 1 {
+		t.Fatalf("received: '%+v' but expected a single chain with one strike", resp.Chains)
+	}
+	if resp.Chains[0].UnderlyingPrice != 30000 {
+		t.Errorf("received: '%v' but expected: '%v'", resp.Chains[0].UnderlyingPrice, 30000)
+	}
+	strike := resp.Chains[0].Strikes[0]
+	if strike.Call == nil || strike.Put == nil {
+		t.Fatalf("received: '%+v' but expected a call and put", strike)
+	}
+	if strike.Call.Error != "" || math.Abs(strike.Call.ImpliedVolatility-0.6) > 1e-3 {
+		t.Errorf("received: '%v' '%v' but expected an implied volatility of 0.6", strike.Call.Error, strike.Call.ImpliedVolatility)
+	}
+	if strike.Call.Greeks.Delta <= 0 || strike.Put.Greeks.Delta >= 0 {
+		t.Errorf("received call delta '%v' put delta '%v' but expected positive and negative", strike.Call.Greeks.Delta, strike.Put.Greeks.Delta)
+	}
+
+	resp, err = s.GetOptionsChain(context.Background(), &gctrpc.GetOptionsChainRequest{
+		Exchange: "okx",
+		Expiry:   time.Now().AddDate(0, 0, 1).Format(time.DateOnly),
+	})
+	if !errors.Is(err, errNoOptionsContracts) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoOptionsContracts)
+	}
+	if resp != nil {
+		t.Errorf("received: '%v' but expected: '%v'", resp, nil)
+	}
+}
+
+func TestGetOptionsPortfolioGreeks(t *testing.T) {
+	t.Parallel()
+	s := setupOptionsRPC(t)
+	_, err := s.GetOptionsPortfolioGreeks(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	resp, err := s.GetOptionsPortfolioGreeks(context.Background(), &gctrpc.GetOptionsPortfolioGreeksRequest{
+		Exchange:        "okx",
+		UnderlyingPrice: 30000,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Errors) != 0 {
+		t.Fatalf("received: '%v' but expected no errors", resp.Errors)
+	}
+	if len(resp.Positions) != 2 || resp.Positions[0].Size != 2 {
+		t.Fatalf("received: '%+v' but expected two positions of size 2", resp.Positions)
+	}
+	// portfolio greeks are the sum of each position's greeks
+	var delta float64
+	for i := range resp.Positions {
+		delta += resp.Positions[i].PositionGreeks.Delta
+	}
+	if math.Abs(resp.Total.Delta-delta) > 1e-9 || resp.Total.Vega <= 0 {
+		t.Errorf("received: '%+v' but expected summed position greeks", resp.Total)
+	}
+}
+
+func TestOptionsPositionSize(t *testing.T) {
+	t.Parallel()
+	size := optionsPositionSize([]order.Detail{
+		{Side: order.Long, ExecutedAmount: 5},
+		{Side: order.Short, ExecutedAmount: 2},
+		{Side: order.Sell, Amount: 1, Status: order.Filled},
+		{Side: order.Buy, Amount: 10, Status: order.Open},
+	})
+	if size != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", size, 2)
+	}
+}
+
+func TestGetBasisTermStructure(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetBasisTermStructure(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetBasisTermStructure(context.Background(), &gctrpc.GetBasisTermStructureRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	s.basisService = &BasisService{
+		history: []BasisSnapshot{{
+			Time: time.Now(),
+			Points: []BasisPoint{
+				{
+					Exchange:    "one",
+					Asset:       asset.Futures,
+					Pair:        currency.NewPair(currency.NewCode("BTCUSD"), currency.NewCode("230929")),
+					Underlying:  currency.BTC,
+					SpotPair:    currency.NewPair(currency.BTC, currency.USDT),
+					Expiry:      time.Now().Add(time.Hour),
+					SpotMid:     decimal.NewFromInt(20000),
+					ContractMid: decimal.NewFromInt(20100),
+					Basis:       decimal.NewFromInt(100),
+				},
+			},
+		}},
+	}
+	_, err = s.GetBasisTermStructure(context.Background(), &gctrpc.GetBasisTermStructureRequest{Refresh: true})
+	if !errors.Is(err, ErrSubSystemNotStarted) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrSubSystemNotStarted)
+	}
+	resp, err := s.GetBasisTermStructure(context.Background(), &gctrpc.GetBasisTermStructureRequest{Underlying: "btc"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.TermStructures) != 1 || len(resp.TermStructures[0].Points) != 1 || resp.TermStructures[0].Points[0].Basis != "100" {
+		t.Fatalf("received: '%+v' but expected one term structure", resp.TermStructures)
+	}
+	_, err = s.GetBasisTermStructure(context.Background(), &gctrpc.GetBasisTermStructureRequest{Underlying: "eth"})
+	if !errors.Is(err, errNoBasisForFilter) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoBasisForFilter)
+	}
+}
+
+func TestGetBasisHistory(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetBasisHistory(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetBasisHistory(context.Background(), &gctrpc.GetBasisHistoryRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	_, err = s.GetBasisHistory(context.Background(), &gctrpc.GetBasisHistoryRequest{Asset: "bad"})
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+
+	perp := currency.NewPair(currency.NewCode("BTCUSD"), currency.PERP)
+	s.basisService = &BasisService{}
+	for i := 0; i < 2; i++ {
+		s.basisService.history = append(s.basisService.history, BasisSnapshot{
+			Time: time.Now(),
+			Points: []BasisPoint{
+				{Exchange: "one", Asset: asset.CoinMarginedFutures, Pair: perp, Underlying: currency.BTC, IsPerpetual: true},
+			},
+		})
+	}
+	resp, err := s.GetBasisHistory(context.Background(), &gctrpc.GetBasisHistoryRequest{
+		Exchange: "one",
+		Asset:    asset.CoinMarginedFutures.String(),
+		Pair:     &gctrpc.CurrencyPair{Base: "BTCUSD", Quote: "PERP"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Points) != 2 || !resp.Points[0].Perpetual || resp.Points[0].Expiry != "" {
+		t.Fatalf("received: '%+v' but expected two perpetual points", resp.Points)
+	}
+}
+
+func TestGetCandleStream(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	err := s.GetCandleStream(nil, nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	err = s.GetCandleStream(&gctrpc.GetCandleStreamRequest{}, nil)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	err = s.GetCandleStream(&gctrpc.GetCandleStreamRequest{Exchange: testExchange}, nil)
+	if !errors.Is(err, errCurrencyPairUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCurrencyPairUnset)
+	}
+	req := &gctrpc.GetCandleStreamRequest{
+		Exchange:     testExchange,
+		Pair:         &gctrpc.CurrencyPair{Base: "BTC", Quote: "USDT"},
+		AssetType:    "bad",
+		TimeInterval: int64(kline.OneMin),
+	}
+	err = s.GetCandleStream(req, nil)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	req.AssetType = asset.Spot.String()
+	err = s.GetCandleStream(req, nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+	s.candleBuilder, err = SetupCandleBuilder(&ExchangeManager{}, nil, &config.CandleBuilder{Intervals: []time.Duration{time.Minute}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	req.TimeInterval = int64(kline.FiveMin)
+	err = s.GetCandleStream(req, nil)
+	if !errors.Is(err, errCandleIntervalNotBuilt) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCandleIntervalNotBuilt)
+	}
+}
+
+func TestGetAlternativeBars(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName(testExchange)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	b := exch.GetBase()
+	b.Name = fakeExchangeName
+	b.Enabled = true
+	b.CurrencyPairs.Pairs = make(map[asset.Item]*currency.PairStore)
+	b.CurrencyPairs.Pairs[asset.Spot] = &currency.PairStore{
+		Available:     currency.Pairs{cp},
+		Enabled:       currency.Pairs{cp},
+		AssetEnabled:  convert.BoolPtr(true),
+		ConfigFormat:  &currency.PairFormat{Uppercase: true},
+		RequestFormat: &currency.PairFormat{Uppercase: true},
+	}
+	err = em.Add(fExchange{IBotExchange: exch})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetAlternativeBars(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	req := &gctrpc.GetAlternativeBarsRequest{}
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, errCurrencyPairUnset) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errCurrencyPairUnset)
+	}
+	req.Pair = &gctrpc.CurrencyPair{Base: cp.Base.String(), Quote: cp.Quote.String()}
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	start := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	req.Start = start.Format(common.SimpleTimeFormatWithTimezone)
+	req.End = start.Add(time.Hour * 33).Format(common.SimpleTimeFormatWithTimezone)
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, kline.ErrInvalidBarType) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, kline.ErrInvalidBarType)
+	}
+	req.BarType = kline.VolumeBar.String()
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, kline.ErrInvalidBarThreshold) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, kline.ErrInvalidBarThreshold)
+	}
+	req.BarType = kline.TickBar.String()
+	req.Threshold = 10
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, kline.ErrBarRequiresTrades) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, kline.ErrBarRequiresTrades)
+	}
+	req.BarType = kline.VolumeBar.String()
+	req.Threshold = 1337 * 2
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	req.AssetType = asset.Spot.String()
+	req.Exchange = fakeExchangeName
+	_, err = s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, errExpectedTestError) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errExpectedTestError)
+	}
+	req.TimeInterval = int64(kline.OneHour)
+	resp, err := s.GetAlternativeBars(context.Background(), req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// 33 candles of 1337 volume are built into 16 bars, the last candle does
+	// not reach the threshold
+	if len(resp.Candle) != 16 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Candle), 16)
+	}
+	if resp.Candle[0].Volume != 1337*2 || resp.BarType != "volume" || resp.Interval != kline.OneHour.Short() {
+		t.Errorf("unexpected response %+v", resp)
+	}
+}
+
+func TestGetInstruments(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch, err := em.NewExchangeByName("bitstamp")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	exch.SetDefaults()
+	if err = em.Add(exch); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s := &RPCServer{Engine: &Engine{ExchangeManager: em}}
+
+	_, err = s.GetInstruments(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetInstruments(context.Background(), &gctrpc.GetInstrumentsRequest{Exchange: "fake"})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrExchangeNotFound)
+	}
+	_, err = s.GetInstruments(context.Background(), &gctrpc.GetInstrumentsRequest{Exchange: "bitstamp"})
+	if !errors.Is(err, instrument.ErrNoInstrumentsLoaded) {
+		t.Errorf("received: '%v' but expected: '%v'", err, instrument.ErrNoInstrumentsLoaded)
+	}
+
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	err = exch.GetBase().LoadInstruments([]instrument.Instrument{
+		{Asset: asset.Spot, Pair: pair, ContractType: instrument.Spot, Status: instrument.Trading},
+		{Asset: asset.Spot, Pair: currency.NewPair(currency.ETH, currency.USD), ContractType: instrument.Spot, Status: instrument.Halted},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.GetInstruments(context.Background(), &gctrpc.GetInstrumentsRequest{Exchange: "bitstamp", ContractType: "swap"})
+	if err == nil {
+		t.Error("expected error for invalid contract type")
+	}
+	resp, err := s.GetInstruments(context.Background(), &gctrpc.GetInstrumentsRequest{
+		Exchange: "bitstamp",
+		Asset:    "spot",
+		Status:   "trading",
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Instruments) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(resp.Instruments), 1)
+	}
+	if resp.Instruments[0].ContractType != "spot" || resp.Instruments[0].Pair.Base != "BTC" {
+		t.Errorf("received: '%+v' unexpected instrument", resp.Instruments[0])
+	}
+
+	_, err = s.GetInstrument(context.Background(), &gctrpc.GetInstrumentRequest{Exchange: "bitstamp", Asset: "spot"})
+	if !errors.Is(err, errCurrencyPairUnset) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errCurrencyPairUnset)
+	}
+	_, err = s.GetInstrument(context.Background(), &gctrpc.GetInstrumentRequest{
+		Exchange: "bitstamp",
+		Asset:    "spot",
+		Pair:     &gctrpc.CurrencyPair{Base: "LTC", Quote: "USD"},
+	})
+	if !errors.Is(err, instrument.ErrInstrumentNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, instrument.ErrInstrumentNotFound)
+	}
+	inst, err := s.GetInstrument(context.Background(), &gctrpc.GetInstrumentRequest{
+		Exchange: "bitstamp",
+		Asset:    "spot",
+		Pair:     &gctrpc.CurrencyPair{Base: "btc", Quote: "usd"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if inst.Status != "trading" || inst.Expiry != "" {
+		t.Errorf("received: '%+v' unexpected instrument", inst)
+	}
+}
+
+// orderUpdateStreamServer captures the responses of an order update stream
+type orderUpdateStreamServer struct {
+	dummyServer
+	ctx  context.Context
+	sent chan *gctrpc.OrderUpdateStreamResponse
+}
+
+func (o *orderUpdateStreamServer) Send(r *gctrpc.OrderUpdateStreamResponse) error {
+	o.sent <- r
+	return nil
+}
+
+func (o *orderUpdateStreamServer) Context() context.Context { return o.ctx }
+
+func TestGetOrderUpdateStream(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	err := s.GetOrderUpdateStream(nil, nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	err = s.GetOrderUpdateStream(&gctrpc.GetOrderUpdateStreamRequest{Asset: "bad"}, nil)
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	err = s.GetOrderUpdateStream(&gctrpc.GetOrderUpdateStreamRequest{}, nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	m := offlineOrderManager(t)
+	em, ok := m.orderStore.exchangeManager.(*ExchangeManager)
+	if !ok {
+		t.Fatal(common.GetTypeAssertError("*ExchangeManager", m.orderStore.exchangeManager))
+	}
+	s = RPCServer{Engine: &Engine{OrderManager: m, ExchangeManager: em}}
+	cp := currency.NewPair(currency.BTC, currency.USD)
+	for _, st := range []order.Status{order.Filled, order.Cancelled} {
+		err = m.Add(&order.Detail{
+			Exchange:  testExchange,
+			OrderID:   st.String(),
+			AssetType: asset.Spot,
+			Pair:      cp,
+			Status:    st,
+		})
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &orderUpdateStreamServer{ctx: ctx, sent: make(chan *gctrpc.OrderUpdateStreamResponse, 10)}
+	errs := make(chan error, 1)
+	go func() {
+		errs <- s.GetOrderUpdateStream(&gctrpc.GetOrderUpdateStreamRequest{
+			Exchange:     testExchange,
+			Status:       order.Cancelled.String(),
+			FromSequence: 1,
+		}, stream)
+	}()
+	resp := <-stream.sent
+	if resp.Sequence != 2 || resp.Order.Id != order.Cancelled.String() {
+		t.Errorf("received: '%v' but expected: '%v'", resp.Sequence, 2)
+	}
+	cancel()
+	if err = <-errs; !errors.Is(err, context.Canceled) {
+		t.Errorf("received: '%v' but expected: '%v'", err, context.Canceled)
+	}
+	if len(stream.sent) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(stream.sent), 0)
+	}
+
+	err = s.GetOrderUpdateStream(&gctrpc.GetOrderUpdateStreamRequest{FromSequence: 10}, stream)
+	if !errors.Is(err, errSequenceAhead) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errSequenceAhead)
+	}
+}
+
+func TestSetLoggerDetails(t *testing.T) {
+	t.Parallel()
+	_, err := gctlog.NewSubLogger("RPCTESTLOGGER")
+	if err != nil {
+		t.Fatal(err)
+	}
+	s := RPCServer{Engine: &Engine{}}
+	_, err = s.SetLoggerDetails(context.Background(), &gctrpc.SetLoggerDetailsRequest{Logger: "RPCTESTLOGGER"})
+	if !errors.Is(err, errNoLoggerDetails) {
+		t.Fatalf("received '%v', expected '%v'", err, errNoLoggerDetails)
+	}
+	_, err = s.SetLoggerDetails(context.Background(), &gctrpc.SetLoggerDetailsRequest{
+		Logger:   "RPCTESTLOGGER",
+		Sampling: &gctrpc.LoggerSampling{Initial: 10, Interval: "bad"},
+	})
+	if err == nil {
+		t.Error("expected error on invalid sampling interval")
+	}
+
+	resp, err := s.SetLoggerDetails(context.Background(), &gctrpc.SetLoggerDetailsRequest{
+		Logger:   "RPCTESTLOGGER",
+		Level:    "ERROR|WARN",
+		Format:   "json",
+		Output:   "stderr",
+		Sampling: &gctrpc.LoggerSampling{Initial: 10, Thereafter: 100, Interval: "1s"},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if resp.Info || resp.Debug || !resp.Warn || !resp.Error || resp.Format != "json" || resp.Output != "stderr" {
+		t.Errorf("received '%+v', expected '%v'", resp, "error and warn levels with json stderr output")
+	}
+	if resp.Sampling == nil || resp.Sampling.Initial != 10 || resp.Sampling.Thereafter != 100 || resp.Sampling.Interval != "1s" {
+		t.Errorf("received '%+v', expected '%v'", resp.Sampling, "the sampling settings")
+	}
+
+	resp, err = s.SetLoggerDetails(context.Background(), &gctrpc.SetLoggerDetailsRequest{
+		Logger:   "RPCTESTLOGGER",
+		Sampling: &gctrpc.LoggerSampling{Disabled: true},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if resp.Sampling != nil || resp.Format != "json" {
+		t.Errorf("received '%+v', expected '%v'", resp, "sampling disabled with the format unchanged")
+	}
+
+	_, err = s.GetLoggerDetails(context.Background(), &gctrpc.GetLoggerDetailsRequest{Logger: "totallyinvalidlogger"})
+	if err == nil {
+		t.Error("expected error on invalid logger")
+	}
+}
+
+func TestGetPNLReport(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetPNLReport(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetPNLReport(context.Background(), &gctrpc.GetPNLReportRequest{Start: "bad"})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	_, err = s.GetPNLReport(context.Background(), &gctrpc.GetPNLReportRequest{Asset: "bad"})
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	_, err = s.GetPNLReport(context.Background(), &gctrpc.GetPNLReportRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	s.accountingManager = newTestAccountingManager(t, NewExchangeManager(), "fifo")
+	now := time.Now()
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	s.accountingManager.events = []AccountingEvent{
+		{Time: now.Add(-time.Hour), Kind: AccountingTrade, Exchange: "one", Asset: asset.Spot, Pair: pair, RealisedPNL: decimal.NewFromInt(90), Fees: decimal.NewFromInt(10)},
+		{Time: now.Add(-time.Hour), Kind: AccountingFunding, Exchange: "one", Asset: asset.USDTMarginedFutures, Pair: pair, Funding: decimal.NewFromInt(-5)},
+	}
+	resp, err := s.GetPNLReport(context.Background(), &gctrpc.GetPNLReportRequest{
+		Start: now.Add(-time.Hour * 2).Format(common.SimpleTimeFormatWithTimezone),
+		Asset: asset.Spot.String(),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.RealisedPnl != "90" || resp.Fees != "10" || resp.Funding != "0" || resp.CostBasisMethod != "fifo" || len(resp.Breakdown) != 1 {
+		t.Fatalf("received: '%+v' but expected spot PNL of 90", resp)
+	}
+	if resp.Breakdown[0].Currency != "BTC" || resp.Breakdown[0].Asset != asset.Spot.String() {
+		t.Errorf("received: '%+v' but expected a BTC spot breakdown", resp.Breakdown[0])
+	}
+}
+
+func TestGetNAVHistory(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetNAVHistory(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetNAVHistory(context.Background(), &gctrpc.GetNAVHistoryRequest{End: "bad"})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	_, err = s.GetNAVHistory(context.Background(), &gctrpc.GetNAVHistoryRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	s.accountingManager = newTestAccountingManager(t, NewExchangeManager(), "fifo")
+	_, err = s.GetNAVHistory(context.Background(), &gctrpc.GetNAVHistoryRequest{})
+	if !errors.Is(err, errNoNAVSnapshots) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoNAVSnapshots)
+	}
+	s.accountingManager.snapshots = []NAVSnapshot{
+		{Time: time.Now().Add(-time.Hour), ReportingCurrency: currency.USD, NAV: decimal.NewFromInt(1000)},
+	}
+	resp, err := s.GetNAVHistory(context.Background(), &gctrpc.GetNAVHistoryRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Snapshots) != 1 || resp.Snapshots[0].Nav != "1000" || resp.Snapshots[0].ReportingCurrency != "USD" {
+		t.Fatalf("received: '%+v' but expected a NAV of 1000", resp.Snapshots)
+	}
+
+	s.ExchangeManager = NewExchangeManager()
+	if err = s.ExchangeManager.Add(&fakerino{}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	scoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleReadOnly, Exchanges: []string{"other"}})
+	_, err = s.GetNAVHistory(scoped, &gctrpc.GetNAVHistoryRequest{})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+	scoped = context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleReadOnly, Exchanges: []string{"other", (&fakerino{}).GetName()}})
+	if _, err = s.GetNAVHistory(scoped, &gctrpc.GetNAVHistoryRequest{}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestGetTaxReport(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{Config: &config.Config{}}}
+	_, err := s.GetTaxReport(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetTaxReport(context.Background(), &gctrpc.GetTaxReportRequest{HistoryStart: "bad"})
+	if !errors.Is(err, errInvalidTimes) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidTimes)
+	}
+	_, err = s.GetTaxReport(context.Background(), &gctrpc.GetTaxReportRequest{CostBasisMethod: "bad"})
+	if !errors.Is(err, costbasis.ErrUnknownMethod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, costbasis.ErrUnknownMethod)
+	}
+	day := time.Date(2023, 2, 3, 0, 0, 0, 0, time.UTC)
+	req := &gctrpc.GetTaxReportRequest{
+		Start: day.Format(common.SimpleTimeFormatWithTimezone),
+		End:   day.Add(time.Hour * 24).Format(common.SimpleTimeFormatWithTimezone),
+	}
+	scoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleReadOnly, Exchanges: []string{"rpctax"}})
+	_, err = s.GetTaxReport(scoped, &gctrpc.GetTaxReportRequest{Start: req.Start, End: req.End, Exchanges: []string{"other"}})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+
+	s.ExchangeManager = NewExchangeManager()
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	err = s.ExchangeManager.Add(&taxExchange{
+		name:  "rpctax",
+		pairs: currency.Pairs{pair},
+		orders: []order.Detail{
+			{OrderID: "1", Pair: pair, Side: order.Buy, ExecutedAmount: 1, AverageExecutedPrice: 1000, LastUpdated: day.Add(time.Hour)},
+			{OrderID: "2", Pair: pair, Side: order.Sell, ExecutedAmount: 1, AverageExecutedPrice: 1500, LastUpdated: day.Add(time.Hour * 2)},
+		},
+		withdrawals: map[string][]exchange.WithdrawalHistory{},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s.Config.Accounting.CostBasisMethod = "lifo"
+	resp, err := s.GetTaxReport(scoped, req)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// the USDT spent on the buy has no known acquisition
+	if resp.Gain != "500" || resp.CostBasisMethod != "lifo" || resp.BaseCurrency != "USD" || resp.Disposals != 2 || resp.UnmatchedProceeds != "1000" || len(resp.Errors) != 0 {
+		t.Fatalf("received: '%+v' but expected a gain of 500", resp)
+	}
+	if !strings.HasPrefix(resp.CapitalGainsCsv, strings.Join(taxCapitalGainsHeader, ",")) || resp.LedgerEntries != 4 {
+		t.Errorf("received: '%v' but expected a capital gains CSV", resp.CapitalGainsCsv)
+	}
+}
+
+func TestGetLedgerReconciliation(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetLedgerReconciliation(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetLedgerReconciliation(context.Background(), &gctrpc.GetLedgerReconciliationRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	e := &lmExchange{
+		taxExchange: taxExchange{name: "rpcledger"},
+		holdings:    []account.Balance{{Currency: currency.BTC, Total: 1}},
+	}
+	s.ledgerManager, _ = newTestLedgerManager(t, e)
+	_, err = s.GetLedgerReconciliation(context.Background(), &gctrpc.GetLedgerReconciliationRequest{})
+	if !errors.Is(err, errNoLedgerReconciliation) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoLedgerReconciliation)
+	}
+	resp, err := s.GetLedgerReconciliation(context.Background(), &gctrpc.GetLedgerReconciliationRequest{Refresh: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Imported != 1 || len(resp.Balances) != 1 || resp.Balances[0].Expected != "1" || resp.Balances[0].Discrepancy != "" {
+		t.Fatalf("received: '%+v' but expected a reconciled BTC balance", resp)
+	}
+
+	e.holdings[0].Total = 2
+	resp, err = s.GetLedgerReconciliation(context.Background(), &gctrpc.GetLedgerReconciliationRequest{Exchange: "rpcledger", Refresh: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Balances) != 1 || resp.Balances[0].Difference != "1" || resp.Balances[0].Discrepancy != string(LedgerMissingTransfer) || resp.Balances[0].Since == "" {
+		t.Fatalf("received: '%+v' but expected a missing transfer", resp.Balances)
+	}
+
+	scoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleReadOnly, Exchanges: []string{"other"}})
+	_, err = s.GetLedgerReconciliation(scoped, &gctrpc.GetLedgerReconciliationRequest{Exchange: "rpcledger"})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+	resp, err = s.GetLedgerReconciliation(scoped, &gctrpc.GetLedgerReconciliationRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(resp.Balances) != 0 {
+		t.Fatalf("received: '%+v' but expected no balances outside of scope", resp.Balances)
+	}
+}
+
+func TestRebalanceRPC(t *testing.T) {
+	t.Parallel()
+	s := RPCServer{Engine: &Engine{}}
+	_, err := s.GetRebalancePlan(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.ExecuteRebalance(context.Background(), nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilRequestData)
+	}
+	_, err = s.GetRebalancePlan(context.Background(), &gctrpc.GetRebalancePlanRequest{})
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	submitter := &rbSubmitter{}
+	s.rebalancer, _ = newTestRebalancer(t, "rpcrebalance", submitter)
+	resp, err := s.GetRebalancePlan(context.Background(), &gctrpc.GetRebalancePlanRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.TotalValue != "20000" || len(resp.Weights) != 3 || len(resp.Trades) != 2 || resp.Executed {
+		t.Fatalf("received: '%+v' but expected a plan selling BTC for ETH", resp)
+	}
+	resp, err = s.ExecuteRebalance(context.Background(), &gctrpc.ExecuteRebalanceRequest{DryRun: true})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !resp.DryRun || !resp.Executed || resp.Trades[0].Status != string(RebalanceDryRun) || len(submitter.submitted) != 0 {
+		t.Fatalf("received: '%+v' but expected a dry run", resp)
+	}
+
+	scoped := context.WithValue(context.Background(), rpcUserContextKey{}, &rpcUser{Username: "test", Role: rpcRoleTrader, Exchanges: []string{"other"}})
+	_, err = s.ExecuteRebalance(scoped, &gctrpc.ExecuteRebalanceRequest{})
+	if !errors.Is(err, errRPCExchangeNotInScope) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errRPCExchangeNotInScope)
+	}
+}
+
+func TestGetPortfolioSummaryValuation(t *testing.T) {
+	t.Parallel()
+	em := NewExchangeManager()
+	exch := &fakerino{}
+	if err := em.Add(exch); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err := ticker.ProcessTicker(&ticker.Price{ExchangeName: exch.GetName(), Pair: currency.NewPair(currency.XRP, currency.USDT), AssetType: asset.Spot, Last: 0.5})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	price, fiat, ok := portfolioPrice(em)(currency.XRP)
+	if !ok || price != 0.5 || !fiat.Equal(currency.USD) {
+		t.Errorf("received: '%v %v %v' but expected: '%v %v %v'", price, fiat, ok, 0.5, currency.USD, true)
+	}
+	price, fiat, ok = portfolioPrice(em)(currency.USDC)
+	if !ok || price != 1 || !fiat.Equal(currency.USD) {
+		t.Errorf("received: '%v %v %v' but expected: '%v %v %v'", price, fiat, ok, 1, currency.USD, true)
+	}
+	if _, _, ok = portfolioPrice(em)(currency.DOGE); ok {
+		t.Error("expected an unpriced currency")
+	}
+
+	s := RPCServer{Engine: &Engine{ExchangeManager: em}}
+	_, err = s.GetPortfolioSummary(context.Background(), &gctrpc.GetPortfolioSummaryRequest{ValueCurrency: "XRP"})
+	if err == nil {
+		t.Error("expected an error valuing in a cryptocurrency")
+	}
+	resp, err := s.GetPortfolioSummary(context.Background(), &gctrpc.GetPortfolioSummaryRequest{ValueCurrency: "USD"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Valuation == nil || resp.Valuation.Currency != "USD" || resp.Valuation.Value != 0 {
+		t.Errorf("received: '%+v' but expected an empty USD valuation", resp.Valuation)
+	}
+	resp, err = s.GetPortfolioSummary(context.Background(), &gctrpc.GetPortfolioSummaryRequest{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp.Valuation != nil {
+		t.Errorf("received: '%+v' but expected: '%v'", resp.Valuation, nil)
+	}
+}
@@ -0,0 +1,62 @@
+package engine
+
+import (
+	"context"
+	"path"
+
+	grpcmiddleware "github.com/grpc-ecosystem/go-grpc-middleware"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
+	"github.com/thrasher-corp/gocryptotrader/log"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// correlateContext attaches the correlation ID sent by the client to the
+// context, generating one when it is missing or invalid, and returns it to the
+// client in the response header
+func correlateContext(ctx context.Context) context.Context {
+	var id string
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if ids := md.Get(tracing.MetadataKey); len(ids) > 0 && tracing.IsValidCorrelationID(ids[0]) {
+			id = ids[0]
+		}
+	}
+	if id == "" {
+		id = tracing.NewCorrelationID()
+	}
+	if err := grpc.SetHeader(ctx, metadata.Pairs(tracing.MetadataKey, id)); err != nil {
+		log.Errorf(log.GRPCSys, "Unable to set correlation ID header: %v", err)
+	}
+	return tracing.WithCorrelationID(ctx, id)
+}
+
+// logFailedRequest logs failed calls to methods which are not permitted to
+// the read only role with the correlation ID of the request
+func logFailedRequest(ctx context.Context, fullMethod string, err error) {
+	if err == nil || methodRoles(path.Base(fullMethod))&rpcRoleReadOnly != 0 {
+		return
+	}
+	log.ErrorfWithFields(log.GRPCSys, tracing.LogFields(ctx), "%s failed: %v", fullMethod, err)
+}
+
+// correlateUnary is a unary interceptor which correlates and traces every
+// request
+func correlateUnary(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := tracing.StartSpan(correlateContext(ctx), tracing.KindServer, info.FullMethod)
+	resp, err := handler(ctx, req)
+	span.End(err)
+	logFailedRequest(ctx, info.FullMethod, err)
+	return resp, err
+}
+
+// correlateStream is a stream interceptor which correlates and traces every
+// stream
+func correlateStream(srv interface{}, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := tracing.StartSpan(correlateContext(ss.Context()), tracing.KindServer, info.FullMethod)
+	wrapped := grpcmiddleware.WrapServerStream(ss)
+	wrapped.WrappedContext = ctx
+	err := handler(srv, wrapped)
+	span.End(err)
+	logFailedRequest(ctx, info.FullMethod, err)
+	return err
+}
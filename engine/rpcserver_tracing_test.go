@@ -0,0 +1,100 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
+	"github.com/thrasher-corp/gocryptotrader/gctrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// headerStream captures the response header set by the interceptors
+type headerStream struct {
+	grpc.ServerTransportStream
+	header metadata.MD
+}
+
+func (h *headerStream) SetHeader(md metadata.MD) error {
+	h.header = metadata.Join(h.header, md)
+	return nil
+}
+
+type correlateTestStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (c *correlateTestStream) Context() context.Context {
+	return c.ctx
+}
+
+func TestCorrelateUnary(t *testing.T) {
+	t.Parallel()
+	info := &grpc.UnaryServerInfo{FullMethod: "/gctrpc.GoCryptoTraderService/GetInfo"}
+	var received string
+	handler := func(ctx context.Context, _ interface{}) (interface{}, error) {
+		received = tracing.CorrelationID(ctx)
+		if tracing.SpanFromContext(ctx) == nil {
+			t.Error("expected a server span")
+		}
+		return &gctrpc.GetInfoResponse{}, nil
+	}
+
+	hs := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(context.Background(), hs)
+	_, err := correlateUnary(ctx, &gctrpc.GetInfoRequest{}, info, handler)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !tracing.IsValidCorrelationID(received) {
+		t.Errorf("received: '%v' but expected: '%v'", received, "a generated correlation ID")
+	}
+	if h := hs.header.Get(tracing.MetadataKey); len(h) != 1 || h[0] != received {
+		t.Errorf("received: '%v' but expected: '%v'", h, received)
+	}
+
+	// client supplied IDs are kept when valid
+	hs = &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tracing.MetadataKey, "client-1337")), hs)
+	_, err = correlateUnary(ctx, &gctrpc.GetInfoRequest{}, info, handler)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if received != "client-1337" {
+		t.Errorf("received: '%v' but expected: '%v'", received, "client-1337")
+	}
+
+	hs = &headerStream{}
+	ctx = grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tracing.MetadataKey, "bad id\n")), hs)
+	_, err = correlateUnary(ctx, &gctrpc.GetInfoRequest{}, info, handler)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if received == "bad id\n" || !tracing.IsValidCorrelationID(received) {
+		t.Errorf("received: '%v' but expected: '%v'", received, "a generated correlation ID")
+	}
+}
+
+func TestCorrelateStream(t *testing.T) {
+	t.Parallel()
+	hs := &headerStream{}
+	ctx := grpc.NewContextWithServerTransportStream(metadata.NewIncomingContext(context.Background(), metadata.Pairs(tracing.MetadataKey, "stream-1")), hs)
+	errTest := errors.New("test")
+	var received string
+	err := correlateStream(nil, &correlateTestStream{ctx: ctx}, &grpc.StreamServerInfo{FullMethod: "/gctrpc.GoCryptoTraderService/GetOrderUpdatesStream"}, func(_ interface{}, ss grpc.ServerStream) error {
+		received = tracing.CorrelationID(ss.Context())
+		return errTest
+	})
+	if !errors.Is(err, errTest) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errTest)
+	}
+	if received != "stream-1" {
+		t.Errorf("received: '%v' but expected: '%v'", received, "stream-1")
+	}
+	if h := hs.header.Get(tracing.MetadataKey); len(h) != 1 || h[0] != "stream-1" {
+		t.Errorf("received: '%v' but expected: '%v'", h, "stream-1")
+	}
+}
@@ -0,0 +1,154 @@
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+const (
+	defaultSequencedFeedCapacity   = 10000
+	defaultSequencedFeedSubscriber = 1024
+)
+
+var (
+	errSequenceNotRetained = errors.New("sequence no longer retained")
+	errSequenceAhead       = errors.New("sequence is ahead of the latest event, the engine may have restarted")
+	errSubscriberLagged    = errors.New("subscriber could not keep up and was disconnected, resume from the last received sequence")
+)
+
+// newSequencedFeed returns a feed retaining up to capacity events
+func newSequencedFeed(capacity int) *sequencedFeed {
+	if capacity <= 0 {
+		capacity = defaultSequencedFeedCapacity
+	}
+	return &sequencedFeed{
+		history:     make([]sequencedEvent, 0, capacity),
+		capacity:    capacity,
+		subscribers: make(map[*feedSubscription]struct{}),
+	}
+}
+
+// publish sequences the data, retains it and forwards it to every
+// subscriber. A subscriber which cannot keep up is disconnected rather than
+// blocking the publisher or silently missing events
+func (f *sequencedFeed) publish(data interface{}) {
+	if f == nil {
+		return
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.sequence++
+	ev := sequencedEvent{Sequence: f.sequence, Time: time.Now(), Data: data}
+	if len(f.history) < f.capacity {
+		f.history = append(f.history, ev)
+	} else {
+		f.history[f.start] = ev
+		f.start = (f.start + 1) % f.capacity
+	}
+	for sub := range f.subscribers {
+		select {
+		case sub.ch <- ev:
+		default:
+			sub.lagged = true
+			close(sub.ch)
+			delete(f.subscribers, sub)
+		}
+	}
+}
+
+// subscribe returns a subscription to events published from now on. A
+// non-zero fromSequence replays the retained events from that sequence
+// onwards before any new event, so a client resuming with the sequence after
+// the last one it received neither misses nor repeats events
+func (f *sequencedFeed) subscribe(fromSequence uint64) (*feedSubscription, error) {
+	if f == nil {
+		return nil, fmt.Errorf("sequenced feed %w", ErrNilSubsystem)
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.subscribeLocked(fromSequence)
+}
+
+// subscribeRetained returns a subscription to events published from now on
+// which first replays every retained event
+func (f *sequencedFeed) subscribeRetained() (*feedSubscription, error) {
+	if f == nil {
+		return nil, fmt.Errorf("sequenced feed %w", ErrNilSubsystem)
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	oldest := f.sequence + 1
+	if len(f.history) > 0 {
+		oldest = f.history[f.start].Sequence
+	}
+	return f.subscribeLocked(oldest)
+}
+
+// subscribeLocked subscribes to the feed, the feed must be locked
+func (f *sequencedFeed) subscribeLocked(fromSequence uint64) (*feedSubscription, error) {
+	sub := &feedSubscription{feed: f}
+	if fromSequence > 0 {
+		if fromSequence > f.sequence+1 {
+			return nil, fmt.Errorf("%w requested %d latest %d", errSequenceAhead, fromSequence, f.sequence)
+		}
+		oldest := f.sequence + 1
+		if len(f.history) > 0 {
+			oldest = f.history[f.start].Sequence
+		}
+		if fromSequence < oldest {
+			return nil, fmt.Errorf("%w requested %d oldest retained %d", errSequenceNotRetained, fromSequence, oldest)
+		}
+		for i := range f.history {
+			ev := f.history[(f.start+i)%len(f.history)]
+			if ev.Sequence >= fromSequence {
+				sub.replay = append(sub.replay, ev)
+			}
+		}
+	}
+	sub.ch = make(chan sequencedEvent, defaultSequencedFeedSubscriber)
+	f.subscribers[sub] = struct{}{}
+	return sub, nil
+}
+
+// latestSequence returns the sequence of the last published event
+func (f *sequencedFeed) latestSequence() uint64 {
+	if f == nil {
+		return 0
+	}
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return f.sequence
+}
+
+// Replay returns the retained events requested when subscribing
+func (s *feedSubscription) Replay() []sequencedEvent {
+	return s.replay
+}
+
+// Channel returns the channel new events are received on. The channel is
+// closed when the subscription is released or the subscriber lags
+func (s *feedSubscription) Channel() <-chan sequencedEvent {
+	return s.ch
+}
+
+// Err returns why the channel was closed
+func (s *feedSubscription) Err() error {
+	s.feed.mtx.Lock()
+	defer s.feed.mtx.Unlock()
+	if s.lagged {
+		return errSubscriberLagged
+	}
+	return nil
+}
+
+// Release stops the subscription receiving events
+func (s *feedSubscription) Release() {
+	s.feed.mtx.Lock()
+	defer s.feed.mtx.Unlock()
+	if _, ok := s.feed.subscribers[s]; !ok {
+		return
+	}
+	delete(s.feed.subscribers, s)
+	close(s.ch)
+}
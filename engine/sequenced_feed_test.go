@@ -0,0 +1,144 @@
+package engine
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSequencedFeedSubscribe(t *testing.T) {
+	t.Parallel()
+	var nilFeed *sequencedFeed
+	nilFeed.publish(1)
+	_, err := nilFeed.subscribe(0)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	f := newSequencedFeed(3)
+	f.publish("a")
+	sub, err := f.subscribe(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(sub.Replay()) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(sub.Replay()), 0)
+	}
+	f.publish("b")
+	ev := <-sub.Channel()
+	if ev.Sequence != 2 || ev.Data != "b" {
+		t.Errorf("received: '%v' but expected: '%v'", ev.Sequence, 2)
+	}
+	sub.Release()
+	sub.Release()
+	if _, ok := <-sub.Channel(); ok {
+		t.Error("channel should be closed on release")
+	}
+	if !errors.Is(sub.Err(), nil) {
+		t.Errorf("received: '%v' but expected: '%v'", sub.Err(), nil)
+	}
+}
+
+func TestSequencedFeedResume(t *testing.T) {
+	t.Parallel()
+	f := newSequencedFeed(3)
+	// A client resuming before any event is published receives everything
+	sub, err := f.subscribe(1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	sub.Release()
+
+	for _, d := range []string{"a", "b", "c", "d", "e"} {
+		f.publish(d)
+	}
+	if f.latestSequence() != 5 {
+		t.Fatalf("received: '%v' but expected: '%v'", f.latestSequence(), 5)
+	}
+	sub, err = f.subscribe(4)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	replay := sub.Replay()
+	if len(replay) != 2 || replay[0].Sequence != 4 || replay[1].Sequence != 5 {
+		t.Fatalf("received: '%v' but expected: '%v'", replay, "sequences 4 and 5")
+	}
+	f.publish("f")
+	if ev := <-sub.Channel(); ev.Sequence != 6 {
+		t.Errorf("received: '%v' but expected: '%v'", ev.Sequence, 6)
+	}
+	sub.Release()
+
+	// Resuming from the next sequence replays nothing and misses nothing
+	sub, err = f.subscribe(7)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(sub.Replay()) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(sub.Replay()), 0)
+	}
+	sub.Release()
+
+	_, err = f.subscribe(2)
+	if !errors.Is(err, errSequenceNotRetained) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errSequenceNotRetained)
+	}
+	_, err = f.subscribe(100)
+	if !errors.Is(err, errSequenceAhead) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errSequenceAhead)
+	}
+}
+
+func TestSequencedFeedLaggedSubscriber(t *testing.T) {
+	t.Parallel()
+	f := newSequencedFeed(0)
+	sub, err := f.subscribe(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for i := 0; i <= defaultSequencedFeedSubscriber; i++ {
+		f.publish(i)
+	}
+	var received int
+	for range sub.Channel() {
+		received++
+	}
+	if received != defaultSequencedFeedSubscriber {
+		t.Errorf("received: '%v' but expected: '%v'", received, defaultSequencedFeedSubscriber)
+	}
+	if !errors.Is(sub.Err(), errSubscriberLagged) {
+		t.Errorf("received: '%v' but expected: '%v'", sub.Err(), errSubscriberLagged)
+	}
+	sub.Release()
+}
+
+func TestSequencedFeedSubscribeRetained(t *testing.T) {
+	t.Parallel()
+	var nilFeed *sequencedFeed
+	_, err := nilFeed.subscribeRetained()
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, ErrNilSubsystem)
+	}
+
+	f := newSequencedFeed(2)
+	sub, err := f.subscribeRetained()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(sub.Replay()) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(sub.Replay()), 0)
+	}
+	sub.Release()
+
+	f.publish("a")
+	f.publish("b")
+	f.publish("c")
+	sub, err = f.subscribeRetained()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer sub.Release()
+	replay := sub.Replay()
+	if len(replay) != 2 || replay[0].Data != "b" || replay[1].Data != "c" {
+		t.Errorf("received: '%+v' but expected: '%v'", replay, "b and c")
+	}
+}
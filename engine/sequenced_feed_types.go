@@ -0,0 +1,34 @@
+package engine
+
+import (
+	"sync"
+	"time"
+)
+
+// sequencedFeed retains a bounded history of events, each assigned a
+// monotonically increasing sequence, and forwards new events to subscribers
+type sequencedFeed struct {
+	mtx      sync.Mutex
+	sequence uint64
+	// history is a ring buffer of the most recent events, start is the index
+	// of the oldest retained event once the buffer is full
+	history     []sequencedEvent
+	start       int
+	capacity    int
+	subscribers map[*feedSubscription]struct{}
+}
+
+// sequencedEvent is an event published to a sequenced feed
+type sequencedEvent struct {
+	Sequence uint64
+	Time     time.Time
+	Data     interface{}
+}
+
+// feedSubscription receives the events of a sequenced feed
+type feedSubscription struct {
+	feed   *sequencedFeed
+	replay []sequencedEvent
+	ch     chan sequencedEvent
+	lagged bool
+}
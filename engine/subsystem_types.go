@@ -9,6 +9,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/database"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -58,6 +59,7 @@ type iOrderManager interface {
 	Cancel(context.Context, *order.Cancel) error
 	GetByExchangeAndID(string, string) (*order.Detail, error)
 	UpdateExistingOrder(*order.Detail) error
+	ProcessFills([]fill.Data) error
 }
 
 // iPortfolioManager limits exposure of accessible functions to portfolio manager
@@ -70,6 +72,8 @@ type iPortfolioManager interface {
 // iBot limits exposure of accessible functions to engine bot
 type iBot interface {
 	SetupExchanges() error
+	getOrderManager() *OrderManager
+	getWebsocketRoutineManager() *WebsocketRoutineManager
 }
 
 // iCurrencyPairSyncer defines a limited scoped currency pair syncer
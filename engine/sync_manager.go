@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -228,6 +229,7 @@ func (m *syncManager) Start() error {
 	}
 
 	for i := 0; i < m.config.NumWorkers; i++ {
+		m.workers.Add(1)
 		go m.worker()
 	}
 	m.initSyncWG.Done()
@@ -248,6 +250,36 @@ func (m *syncManager) Stop() error {
 	return nil
 }
 
+// updateConfig replaces the sync timeouts, worker count and logging settings.
+// The subsystem must be stopped, any running workers are waited on before the
+// settings are replaced.
+func (m *syncManager) updateConfig(c *config.SyncManagerConfig) error {
+	if m == nil {
+		return fmt.Errorf("exchange CurrencyPairSyncer %w", ErrNilSubsystem)
+	}
+	if c == nil {
+		return fmt.Errorf("%T %w", c, common.ErrNilPointer)
+	}
+	if m.IsRunning() {
+		return fmt.Errorf("exchange CurrencyPairSyncer %w", ErrSubSystemAlreadyStarted)
+	}
+	m.workers.Wait()
+	if c.NumWorkers > 0 {
+		m.config.NumWorkers = c.NumWorkers
+	}
+	if c.TimeoutREST > 0 {
+		m.config.TimeoutREST = c.TimeoutREST
+	}
+	if c.TimeoutWebsocket > 0 {
+		m.config.TimeoutWebsocket = c.TimeoutWebsocket
+	}
+	m.config.Verbose = c.Verbose
+	m.config.LogSyncUpdateEvents = c.LogSyncUpdateEvents
+	m.config.LogSwitchProtocolEvents = c.LogSwitchProtocolEvents
+	m.config.LogInitialSyncEvents = c.LogInitialSyncEvents
+	return nil
+}
+
 func (m *syncManager) get(k currencyPairKey) *currencyPairSyncAgent {
 	m.mux.Lock()
 	defer m.mux.Unlock()
@@ -432,6 +464,7 @@ func (m *syncManager) worker() {
 	cleanup := func() {
 		log.Debugln(log.SyncMgr,
 			"Exchange CurrencyPairSyncer worker shutting down.")
+		m.workers.Done()
 	}
 	defer cleanup()
 
@@ -550,6 +583,10 @@ func (m *syncManager) syncTicker(c *currencyPairSyncAgent, e exchange.IBotExchan
 	}
 
 	if s.IsUsingREST && time.Since(s.LastUpdated) > m.config.TimeoutREST {
+		// each REST update is correlated so its exchange request and sync
+		// log lines can be connected
+		ctx := tracing.WithCorrelationID(context.Background(), tracing.NewCorrelationID())
+		fields := tracing.LogFields(ctx)
 		var result *ticker.Price
 		var err error
 
@@ -564,28 +601,28 @@ func (m *syncManager) syncTicker(c *currencyPairSyncAgent, e exchange.IBotExchan
 			if batchLastDone.IsZero() || time.Since(batchLastDone) > m.config.TimeoutREST {
 				m.mux.Lock()
 				if m.config.Verbose {
-					log.Debugf(log.SyncMgr, "Initialising %s REST ticker batching", exchangeName)
+					log.DebugfWithFields(log.SyncMgr, fields, "Initialising %s REST ticker batching", exchangeName)
 				}
-				err = e.UpdateTickers(context.TODO(), c.AssetType)
+				err = e.UpdateTickers(ctx, c.AssetType)
 				if err == nil {
-					result, err = e.FetchTicker(context.TODO(), c.Pair, c.AssetType)
+					result, err = e.FetchTicker(ctx, c.Pair, c.AssetType)
 				}
 				m.tickerBatchLastRequested[exchangeName] = time.Now()
 				m.mux.Unlock()
 			} else {
 				if m.config.Verbose {
-					log.Debugf(log.SyncMgr, "%s Using recent batching cache", exchangeName)
+					log.DebugfWithFields(log.SyncMgr, fields, "%s Using recent batching cache", exchangeName)
 				}
-				result, err = e.FetchTicker(context.TODO(),
+				result, err = e.FetchTicker(ctx,
 					c.Pair,
 					c.AssetType)
 			}
 		} else {
-			result, err = e.UpdateTicker(context.TODO(),
+			result, err = e.UpdateTicker(ctx,
 				c.Pair,
 				c.AssetType)
 		}
-		m.PrintTickerSummary(result, "REST", err)
+		m.printTickerSummary(fields, result, "REST", err)
 		if err == nil {
 			if m.remoteConfig.WebsocketRPC.Enabled {
 				relayWebsocketEvent(result, "ticker_update", c.AssetType.String(), exchangeName)
@@ -593,7 +630,7 @@ func (m *syncManager) syncTicker(c *currencyPairSyncAgent, e exchange.IBotExchan
 		}
 		updateErr := m.update(c, SyncItemTicker, err)
 		if updateErr != nil {
-			log.Errorln(log.SyncMgr, updateErr)
+			log.ErrorlnWithFields(log.SyncMgr, fields, updateErr)
 		}
 	}
 }
@@ -625,10 +662,12 @@ func (m *syncManager) syncOrderbook(c *currencyPairSyncAgent, e exchange.IBotExc
 	}
 
 	if s.IsUsingREST && time.Since(s.LastUpdated) > m.config.TimeoutREST {
-		result, err := e.UpdateOrderbook(context.TODO(),
+		ctx := tracing.WithCorrelationID(context.Background(), tracing.NewCorrelationID())
+		fields := tracing.LogFields(ctx)
+		result, err := e.UpdateOrderbook(ctx,
 			c.Pair,
 			c.AssetType)
-		m.PrintOrderbookSummary(result, "REST", err)
+		m.printOrderbookSummary(fields, result, "REST", err)
 		if err == nil {
 			if m.remoteConfig.WebsocketRPC.Enabled {
 				relayWebsocketEvent(result, "orderbook_update", c.AssetType.String(), e.GetName())
@@ -636,7 +675,7 @@ func (m *syncManager) syncOrderbook(c *currencyPairSyncAgent, e exchange.IBotExc
 		}
 		updateErr := m.update(c, SyncItemOrderbook, err)
 		if updateErr != nil {
-			log.Errorln(log.SyncMgr, updateErr)
+			log.ErrorlnWithFields(log.SyncMgr, fields, updateErr)
 		}
 	}
 }
@@ -698,6 +737,12 @@ func printConvertCurrencyFormat(origPrice float64, origCurrency, displayCurrency
 
 // PrintTickerSummary outputs the ticker results
 func (m *syncManager) PrintTickerSummary(result *ticker.Price, protocol string, err error) {
+	m.printTickerSummary(nil, result, protocol, err)
+}
+
+// printTickerSummary outputs the ticker results with the structured log
+// fields of the request which retrieved them
+func (m *syncManager) printTickerSummary(fields log.ExtraFields, result *ticker.Price, protocol string, err error) {
 	if m == nil || atomic.LoadInt32(&m.started) == 0 {
 		return
 	}
@@ -706,12 +751,12 @@ func (m *syncManager) PrintTickerSummary(result *ticker.Price, protocol string,
 	}
 	if err != nil {
 		if err == common.ErrNotYetImplemented {
-			log.Warnf(log.SyncMgr, "Failed to get %s ticker. Error: %s",
+			log.WarnfWithFields(log.SyncMgr, fields, "Failed to get %s ticker. Error: %s",
 				protocol,
 				err)
 			return
 		}
-		log.Errorf(log.SyncMgr, "Failed to get %s ticker. Error: %s",
+		log.ErrorfWithFields(log.SyncMgr, fields, "Failed to get %s ticker. Error: %s",
 			protocol,
 			err)
 		return
@@ -727,7 +772,7 @@ func (m *syncManager) PrintTickerSummary(result *ticker.Price, protocol string,
 		!result.Pair.Quote.Equal(m.fiatDisplayCurrency) &&
 		!m.fiatDisplayCurrency.IsEmpty() {
 		origCurrency := result.Pair.Quote.Upper()
-		log.Infof(log.SyncMgr, "%s %s %s %s TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f",
+		log.InfofWithFields(log.SyncMgr, fields, "%s %s %s %s TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f",
 			result.ExchangeName,
 			protocol,
 			m.FormatCurrency(result.Pair),
@@ -742,7 +787,7 @@ func (m *syncManager) PrintTickerSummary(result *ticker.Price, protocol string,
 		if result.Pair.Quote.IsFiatCurrency() &&
 			result.Pair.Quote.Equal(m.fiatDisplayCurrency) &&
 			!m.fiatDisplayCurrency.IsEmpty() {
-			log.Infof(log.SyncMgr, "%s %s %s %s TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f",
+			log.InfofWithFields(log.SyncMgr, fields, "%s %s %s %s TICKER: Last %s Ask %s Bid %s High %s Low %s Volume %.8f",
 				result.ExchangeName,
 				protocol,
 				m.FormatCurrency(result.Pair),
@@ -754,7 +799,7 @@ func (m *syncManager) PrintTickerSummary(result *ticker.Price, protocol string,
 				printCurrencyFormat(result.Low, m.fiatDisplayCurrency),
 				result.Volume)
 		} else {
-			log.Infof(log.SyncMgr, "%s %s %s %s TICKER: Last %.8f Ask %.8f Bid %.8f High %.8f Low %.8f Volume %.8f",
+			log.InfofWithFields(log.SyncMgr, fields, "%s %s %s %s TICKER: Last %.8f Ask %.8f Bid %.8f High %.8f Low %.8f Volume %.8f",
 				result.ExchangeName,
 				protocol,
 				m.FormatCurrency(result.Pair),
@@ -784,6 +829,12 @@ const (
 
 // PrintOrderbookSummary outputs orderbook results
 func (m *syncManager) PrintOrderbookSummary(result *orderbook.Base, protocol string, err error) {
+	m.printOrderbookSummary(nil, result, protocol, err)
+}
+
+// printOrderbookSummary outputs orderbook results with the structured log
+// fields of the request which retrieved them
+func (m *syncManager) printOrderbookSummary(fields log.ExtraFields, result *orderbook.Base, protocol string, err error) {
 	if m == nil || atomic.LoadInt32(&m.started) == 0 {
 		return
 	}
@@ -792,13 +843,13 @@ func (m *syncManager) PrintOrderbookSummary(result *orderbook.Base, protocol str
 	}
 	if err != nil {
 		if result == nil {
-			log.Errorf(log.OrderBook, "Failed to get %s orderbook. Error: %s",
+			log.ErrorfWithFields(log.OrderBook, fields, "Failed to get %s orderbook. Error: %s",
 				protocol,
 				err)
 			return
 		}
 		if err == common.ErrNotYetImplemented {
-			log.Warnf(log.OrderBook, "Failed to get %s orderbook for %s %s %s. Error: %s",
+			log.WarnfWithFields(log.OrderBook, fields, "Failed to get %s orderbook for %s %s %s. Error: %s",
 				protocol,
 				result.Exchange,
 				result.Pair,
@@ -806,7 +857,7 @@ func (m *syncManager) PrintOrderbookSummary(result *orderbook.Base, protocol str
 				err)
 			return
 		}
-		log.Errorf(log.OrderBook, "Failed to get %s orderbook for %s %s %s. Error: %s",
+		log.ErrorfWithFields(log.OrderBook, fields, "Failed to get %s orderbook for %s %s %s. Error: %s",
 			protocol,
 			result.Exchange,
 			result.Pair,
@@ -838,7 +889,7 @@ func (m *syncManager) PrintOrderbookSummary(result *orderbook.Base, protocol str
 		askValueResult = strconv.FormatFloat(asksValue, 'f', -1, 64)
 	}
 
-	log.Infof(log.SyncMgr, book,
+	log.InfofWithFields(log.SyncMgr, fields, book,
 		result.Exchange,
 		protocol,
 		m.FormatCurrency(result.Pair),
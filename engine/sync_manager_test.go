@@ -1,13 +1,17 @@
 package engine
 
 import (
+	"context"
 	"errors"
 	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
@@ -337,3 +341,82 @@ func TestSyncManagerWebsocketUpdate(t *testing.T) {
 		t.Fatalf("received %v, but expected: %v", err, nil)
 	}
 }
+
+func TestSyncManagerUpdateConfig(t *testing.T) {
+	t.Parallel()
+	var m *syncManager
+	err := m.updateConfig(nil)
+	if !errors.Is(err, ErrNilSubsystem) {
+		t.Errorf("error '%v', expected '%v'", err, ErrNilSubsystem)
+	}
+	m, err = setupSyncManager(&config.SyncManagerConfig{SynchronizeTrades: true, FiatDisplayCurrency: currency.USD, PairFormatDisplay: &currency.EMPTYFORMAT}, &ExchangeManager{}, &config.RemoteControlConfig{}, false)
+	if !errors.Is(err, nil) {
+		t.Fatalf("error '%v', expected '%v'", err, nil)
+	}
+	err = m.updateConfig(nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("error '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	m.started = 1
+	err = m.updateConfig(&config.SyncManagerConfig{})
+	if !errors.Is(err, ErrSubSystemAlreadyStarted) {
+		t.Errorf("error '%v', expected '%v'", err, ErrSubSystemAlreadyStarted)
+	}
+	m.started = 0
+	err = m.updateConfig(&config.SyncManagerConfig{
+		NumWorkers:          2,
+		TimeoutREST:         time.Second,
+		LogSyncUpdateEvents: true,
+	})
+	if !errors.Is(err, nil) {
+		t.Errorf("error '%v', expected '%v'", err, nil)
+	}
+	if m.config.NumWorkers != 2 || m.config.TimeoutREST != time.Second || !m.config.LogSyncUpdateEvents {
+		t.Error("expected sync manager config to be updated")
+	}
+	if m.config.TimeoutWebsocket != config.DefaultSyncerTimeoutWebsocket {
+		t.Errorf("received '%v', expected '%v'", m.config.TimeoutWebsocket, config.DefaultSyncerTimeoutWebsocket)
+	}
+}
+
+// syncCorrelationExchange records the context used to update orderbooks
+type syncCorrelationExchange struct {
+	exchange.IBotExchange
+	ctx context.Context
+}
+
+func (s *syncCorrelationExchange) GetName() string {
+	return "fake"
+}
+
+func (s *syncCorrelationExchange) SupportsREST() bool {
+	return true
+}
+
+func (s *syncCorrelationExchange) UpdateOrderbook(ctx context.Context, p currency.Pair, a asset.Item) (*orderbook.Base, error) {
+	s.ctx = ctx
+	return &orderbook.Base{Exchange: "fake", Pair: p, Asset: a}, nil
+}
+
+func TestSyncOrderbookCorrelation(t *testing.T) {
+	t.Parallel()
+	m := &syncManager{
+		config:       config.SyncManagerConfig{SynchronizeOrderbook: true},
+		remoteConfig: &config.RemoteControlConfig{},
+	}
+	m.initSyncCompleted = 1
+	c := newCurrencyPairSyncAgent(currencyPairKey{
+		Exchange:  "fake",
+		AssetType: asset.Spot,
+		Pair:      currency.NewPair(currency.BTC, currency.USDT),
+	})
+	c.trackers[SyncItemOrderbook] = &syncBase{IsUsingREST: true}
+	e := &syncCorrelationExchange{}
+	m.syncOrderbook(c, e)
+	if e.ctx == nil {
+		t.Fatal("expected orderbook to be updated")
+	}
+	if id := tracing.CorrelationID(e.ctx); !tracing.IsValidCorrelationID(id) {
+		t.Errorf("received '%v', expected a valid correlation ID", id)
+	}
+}
@@ -46,6 +46,7 @@ type syncManager struct {
 	mux                            sync.Mutex
 	initSyncWG                     sync.WaitGroup
 	inService                      sync.WaitGroup
+	workers                        sync.WaitGroup
 
 	currencyPairs            map[currencyPairKey]*currencyPairSyncAgent
 	tickerBatchLastRequested map[string]time.Time
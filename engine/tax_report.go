@@ -0,0 +1,655 @@
+package engine
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// BuildTaxReport retrieves the spot order history, funding history and
+// withdrawals of each exchange, normalises them into a ledger and matches
+// disposals against acquisitions using the cost basis method. Lots are pooled
+// per currency across exchanges, transfers between exchanges are not treated
+// as disposals. Errors retrieving or valuing history are collected in the
+// report so a partial report can still be reviewed
+func BuildTaxReport(ctx context.Context, em iExchangeManager, req *TaxReportRequest) (*TaxReport, error) {
+	if em == nil {
+		return nil, errNilExchangeManager
+	}
+	if req == nil {
+		return nil, errNilRequestData
+	}
+	if err := common.StartEndTimeCheck(req.Start, req.End); err != nil {
+		return nil, err
+	}
+	historyStart := req.HistoryStart
+	if historyStart.IsZero() {
+		historyStart = req.Start
+	}
+	if historyStart.After(req.Start) {
+		return nil, errTaxHistoryBounds
+	}
+	if _, err := costbasis.NewBook(req.Method); err != nil {
+		return nil, err
+	}
+	base := req.BaseCurrency
+	if base.IsEmpty() {
+		base = currency.USD
+	}
+	if !base.IsFiatCurrency() {
+		return nil, fmt.Errorf("%w: %s", errTaxBaseNotFiat, base)
+	}
+	exchanges, err := taxReportExchanges(em, req.Exchanges)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &TaxReport{
+		Start:        req.Start,
+		End:          req.End,
+		BaseCurrency: base,
+		Method:       req.Method,
+	}
+	if !base.Equal(currency.USD) {
+		err = currency.LoadHistoricalRates(currency.USD, base, historyStart, req.End)
+		if err != nil && !errors.Is(err, currency.ErrNoHistoricalRateLoader) {
+			report.Errors = append(report.Errors, fmt.Sprintf("loading %s-%s rates: %v", currency.USD, base, err))
+		}
+	}
+	v := &taxValuer{
+		ctx:    ctx,
+		em:     em,
+		base:   base,
+		prices: make(map[string]decimal.Decimal),
+	}
+	var ledger []TaxLedgerEntry
+	for _, exch := range exchanges {
+		entries, errs := v.exchangeLedger(exch, historyStart, req.End)
+		ledger = append(ledger, entries...)
+		for i := range errs {
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", exch.GetName(), errs[i]))
+		}
+	}
+	sort.SliceStable(ledger, func(i, j int) bool {
+		return ledger[i].Time.Before(ledger[j].Time)
+	})
+	if err = report.match(ledger); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// taxReportExchanges returns the named exchanges, or every exchange which
+// supports authenticated requests, sorted by name
+func taxReportExchanges(em iExchangeManager, names []string) ([]exchange.IBotExchange, error) {
+	var resp []exchange.IBotExchange
+	if len(names) > 0 {
+		for i := range names {
+			exch, err := em.GetExchangeByName(names[i])
+			if err != nil {
+				return nil, err
+			}
+			resp = append(resp, exch)
+		}
+	} else {
+		exchanges, err := em.GetExchanges()
+		if err != nil {
+			return nil, err
+		}
+		for i := range exchanges {
+			if exchanges[i].IsRESTAuthenticationSupported() {
+				resp = append(resp, exchanges[i])
+			}
+		}
+	}
+	if len(resp) == 0 {
+		return nil, errNoTaxExchanges
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		return resp[i].GetName() < resp[j].GetName()
+	})
+	return resp, nil
+}
+
+// exchangeLedger returns the ledger entries of an exchange between the start
+// and end times
+func (v *taxValuer) exchangeLedger(exch exchange.IBotExchange, start, end time.Time) ([]TaxLedgerEntry, []error) {
+	var (
+		ledger []TaxLedgerEntry
+		errs   []error
+	)
+	if exch.GetAssetTypes(true).Contains(asset.Spot) {
+		pairs, err := exch.GetEnabledPairs(asset.Spot)
+		if err != nil {
+			errs = append(errs, err)
+		} else {
+			orders, histErr := exch.GetOrderHistory(v.ctx, &order.MultiOrderRequest{
+				Pairs:     pairs,
+				AssetType: asset.Spot,
+				Type:      order.AnyType,
+				Side:      order.AnySide,
+				StartTime: start,
+				EndTime:   end,
+			})
+			if histErr != nil {
+				errs = append(errs, fmt.Errorf("order history: %w", histErr))
+			}
+			for i := range orders {
+				entries, valueErr := v.orderEntries(exch, &orders[i], start, end)
+				ledger = append(ledger, entries...)
+				if valueErr != nil {
+					errs = append(errs, valueErr)
+				}
+			}
+		}
+	}
+
+	withdrawn := make(map[string]bool)
+	funding, err := exch.GetAccountFundingHistory(v.ctx)
+	if err != nil {
+		errs = append(errs, fmt.Errorf("funding history: %w", err))
+	}
+	for i := range funding {
+		f := &funding[i]
+		if f.Timestamp.Before(start) || f.Timestamp.After(end) || taxTransferFailed(f.Status) {
+			continue
+		}
+		kind := taxFundingKind(f.TransferType, f.Description, f.Amount)
+		if kind == TaxLedgerWithdrawal {
+			withdrawn[f.TransferID] = true
+			withdrawn[f.CryptoTxID] = true
+		}
+		entries, valueErr := v.transferEntries(exch, kind, f.TransferID, currency.NewCode(f.Currency), f.Amount, f.Fee, f.Timestamp, f.Description)
+		ledger = append(ledger, entries...)
+		if valueErr != nil {
+			errs = append(errs, valueErr)
+		}
+	}
+
+	// Withdrawals can only be requested per currency, so only currencies
+	// which appear in the ledger are requested
+	var codes currency.Currencies
+	for i := range ledger {
+		if !codes.Contains(ledger[i].Currency) {
+			codes = append(codes, ledger[i].Currency)
+		}
+	}
+	sort.Slice(codes, func(i, j int) bool {
+		return codes[i].String() < codes[j].String()
+	})
+	for i := range codes {
+		withdrawals, histErr := exch.GetWithdrawalsHistory(v.ctx, codes[i], asset.Spot)
+		if histErr != nil {
+			errs = append(errs, fmt.Errorf("%s withdrawal history: %w", codes[i], histErr))
+			if errors.Is(histErr, common.ErrFunctionNotSupported) || errors.Is(histErr, common.ErrNotYetImplemented) {
+				break
+			}
+			continue
+		}
+		for j := range withdrawals {
+			w := &withdrawals[j]
+			if w.Timestamp.Before(start) || w.Timestamp.After(end) || taxTransferFailed(w.Status) {
+				continue
+			}
+			if (w.TransferID != "" && withdrawn[w.TransferID]) || (w.CryptoTxID != "" && withdrawn[w.CryptoTxID]) {
+				continue
+			}
+			code := codes[i]
+			if w.Currency != "" {
+				code = currency.NewCode(w.Currency)
+			}
+			entries, valueErr := v.transferEntries(exch, TaxLedgerWithdrawal, w.TransferID, code, w.Amount, w.Fee, w.Timestamp, w.Description)
+			ledger = append(ledger, entries...)
+			if valueErr != nil {
+				errs = append(errs, valueErr)
+			}
+		}
+	}
+	return ledger, errs
+}
+
+// taxTransferFailed returns whether a funding status is a transfer which did
+// not complete
+func taxTransferFailed(status string) bool {
+	status = strings.ToLower(status)
+	return strings.Contains(status, "fail") || strings.Contains(status, "cancel") || strings.Contains(status, "reject")
+}
+
+// taxFundingKind classifies a funding history entry
+func taxFundingKind(transferType, description string, amount float64) TaxLedgerKind {
+	text := strings.ToLower(transferType + " " + description)
+	if strings.Contains(text, "withdraw") {
+		return TaxLedgerWithdrawal
+	}
+	for i := range taxIncomeKeywords {
+		if strings.Contains(text, taxIncomeKeywords[i]) {
+			return TaxLedgerIncome
+		}
+	}
+	if amount < 0 {
+		return TaxLedgerWithdrawal
+	}
+	return TaxLedgerDeposit
+}
+
+// orderEntries returns the ledger entries of the fills of an order. The
+// trades of the order are used when available, otherwise the executed amount
+// at the average price
+func (v *taxValuer) orderEntries(exch exchange.IBotExchange, d *order.Detail, start, end time.Time) ([]TaxLedgerEntry, error) {
+	if !d.Side.IsLong() && !d.Side.IsShort() {
+		return nil, nil
+	}
+	var (
+		ledger []TaxLedgerEntry
+		errs   error
+	)
+	if len(d.Trades) > 0 {
+		for i := range d.Trades {
+			trade := &d.Trades[i]
+			side := d.Side
+			if trade.Side.IsLong() || trade.Side.IsShort() {
+				side = trade.Side
+			}
+			t := trade.Timestamp
+			if t.IsZero() {
+				t = d.LastUpdated
+			}
+			feeAsset := d.FeeAsset
+			if trade.FeeAsset != "" {
+				feeAsset = currency.NewCode(trade.FeeAsset)
+			}
+			if trade.Amount <= 0 || t.Before(start) || t.After(end) {
+				continue
+			}
+			entries, err := v.fillEntries(exch, d.OrderID, d.Pair, side, trade.Amount, trade.Price, trade.Fee, feeAsset, t, trade.TID)
+			ledger = append(ledger, entries...)
+			errs = common.AppendError(errs, err)
+		}
+		return ledger, errs
+	}
+	price := d.AverageExecutedPrice
+	if price <= 0 {
+		price = d.Price
+	}
+	t := d.LastUpdated
+	if t.IsZero() {
+		t = d.Date
+	}
+	if d.ExecutedAmount <= 0 || t.Before(start) || t.After(end) {
+		return nil, nil
+	}
+	return v.fillEntries(exch, d.OrderID, d.Pair, d.Side, d.ExecutedAmount, price, d.Fee, d.FeeAsset, t, "")
+}
+
+// fillEntries returns the ledger entries of a fill, the traded currency, the
+// quote currency and the fee. The fee asset defaults to the quote currency
+func (v *taxValuer) fillEntries(exch exchange.IBotExchange, ref string, pair currency.Pair, side order.Side, amount, price, fee float64, feeAsset currency.Code, t time.Time, description string) ([]TaxLedgerEntry, error) {
+	baseAmount := decimal.NewFromFloat(amount)
+	quoteAmount := baseAmount.Mul(decimal.NewFromFloat(price))
+	value, err := v.tradeValue(exch, pair, baseAmount, quoteAmount, t)
+	if err != nil {
+		err = fmt.Errorf("%s %s %s: %w", ref, pair, t.Format(time.RFC3339), err)
+	}
+	if side.IsShort() {
+		baseAmount = baseAmount.Neg()
+	} else {
+		quoteAmount = quoteAmount.Neg()
+	}
+	ledger := []TaxLedgerEntry{
+		{Time: t, Kind: TaxLedgerTrade, Exchange: exch.GetName(), Reference: ref, Pair: pair, Side: side.String(), Currency: pair.Base, Amount: baseAmount, Value: value, Description: description},
+		{Time: t, Kind: TaxLedgerTrade, Exchange: exch.GetName(), Reference: ref, Pair: pair, Side: side.String(), Currency: pair.Quote, Amount: quoteAmount, Value: value, Description: description},
+	}
+	if fee <= 0 {
+		return ledger, err
+	}
+	if feeAsset.IsEmpty() {
+		feeAsset = pair.Quote
+	}
+	feeAmount := decimal.NewFromFloat(fee)
+	var feeValue decimal.Decimal
+	switch {
+	case feeAsset.Equal(pair.Quote) && !quoteAmount.IsZero():
+		feeValue = value.Mul(feeAmount).Div(quoteAmount.Abs())
+	case feeAsset.Equal(pair.Base):
+		feeValue = value.Mul(feeAmount).Div(baseAmount.Abs())
+	default:
+		r, rateErr := v.rate(exch, feeAsset, t)
+		if rateErr != nil {
+			err = common.AppendError(err, fmt.Errorf("%s %s fee: %w", ref, feeAsset, rateErr))
+		}
+		feeValue = feeAmount.Mul(r)
+	}
+	ledger[0].FeeValue = feeValue
+	ledger = append(ledger, TaxLedgerEntry{Time: t, Kind: TaxLedgerFee, Exchange: exch.GetName(), Reference: ref, Pair: pair, Side: side.String(), Currency: feeAsset, Amount: feeAmount.Neg(), Value: feeValue, Description: description})
+	return ledger, err
+}
+
+// transferEntries returns the ledger entries of a deposit, withdrawal or
+// income and its fee
+func (v *taxValuer) transferEntries(exch exchange.IBotExchange, kind TaxLedgerKind, ref string, code currency.Code, amount, fee float64, t time.Time, description string) ([]TaxLedgerEntry, error) {
+	movement := decimal.NewFromFloat(amount).Abs()
+	if kind == TaxLedgerWithdrawal {
+		movement = movement.Neg()
+	}
+	var ledger []TaxLedgerEntry
+	var err error
+	r, rateErr := v.rate(exch, code, t)
+	if !movement.IsZero() {
+		if rateErr != nil && kind == TaxLedgerIncome {
+			err = fmt.Errorf("%s %s income: %w", ref, code, rateErr)
+		}
+		ledger = append(ledger, TaxLedgerEntry{Time: t, Kind: kind, Exchange: exch.GetName(), Reference: ref, Currency: code, Amount: movement, Value: movement.Abs().Mul(r), Description: description})
+	}
+	if fee > 0 {
+		if rateErr != nil {
+			err = common.AppendError(err, fmt.Errorf("%s %s %s fee: %w", ref, code, kind, rateErr))
+		}
+		feeAmount := decimal.NewFromFloat(fee)
+		ledger = append(ledger, TaxLedgerEntry{Time: t, Kind: TaxLedgerFee, Exchange: exch.GetName(), Reference: ref, Currency: code, Amount: feeAmount.Neg(), Value: feeAmount.Mul(r), Description: description})
+	}
+	return ledger, err
+}
+
+// tradeValue returns the value of a fill in the base currency. A leg with a
+// stored rate is preferred over a market price
+func (v *taxValuer) tradeValue(exch exchange.IBotExchange, pair currency.Pair, baseAmount, quoteAmount decimal.Decimal, t time.Time) (decimal.Decimal, error) {
+	var errs error
+	for _, leg := range []accountingLeg{{currency: pair.Quote, amount: quoteAmount}, {currency: pair.Base, amount: baseAmount}} {
+		r, known, err := v.storedRate(leg.currency, t)
+		if known && err == nil {
+			return leg.amount.Mul(r), nil
+		}
+		errs = common.AppendError(errs, err)
+	}
+	for _, leg := range []accountingLeg{{currency: pair.Base, amount: baseAmount}, {currency: pair.Quote, amount: quoteAmount}} {
+		r, err := v.marketRate(exch, leg.currency, t)
+		if err == nil {
+			return leg.amount.Mul(r), nil
+		}
+		errs = common.AppendError(errs, err)
+	}
+	return decimal.Zero, errs
+}
+
+// rate returns the value of one unit of a currency in the base currency
+func (v *taxValuer) rate(exch exchange.IBotExchange, code currency.Code, t time.Time) (decimal.Decimal, error) {
+	r, known, err := v.storedRate(code, t)
+	if known {
+		return r, err
+	}
+	return v.marketRate(exch, code, t)
+}
+
+// storedRate returns the rate of fiat currencies and USD stablecoins using
+// stored foreign exchange rates. Stablecoins are valued at par with USD. The
+// returned bool is false for other currencies
+func (v *taxValuer) storedRate(code currency.Code, t time.Time) (decimal.Decimal, bool, error) {
+	from := code
+	if accountingUSDStables.Contains(code) {
+		from = currency.USD
+	} else if !code.IsFiatCurrency() {
+		return decimal.Zero, false, nil
+	}
+	if from.Equal(v.base) {
+		return decimal.NewFromInt(1), true, nil
+	}
+	r, err := currency.ConvertAt(from, v.base, t)
+	if err != nil {
+		return decimal.Zero, true, err
+	}
+	return decimal.NewFromFloat(r), true, nil
+}
+
+// marketRate returns the value of a currency from the hourly candle of its
+// USD or stablecoin market, trying the exchange of the entry before the
+// others
+func (v *taxValuer) marketRate(exch exchange.IBotExchange, code currency.Code, t time.Time) (decimal.Decimal, error) {
+	exchanges := []exchange.IBotExchange{exch}
+	if others, err := v.em.GetExchanges(); err == nil {
+		for i := range others {
+			if others[i].GetName() != exch.GetName() {
+				exchanges = append(exchanges, others[i])
+			}
+		}
+	}
+	for _, e := range exchanges {
+		pairs, err := e.GetEnabledPairs(asset.Spot)
+		if err != nil {
+			continue
+		}
+		for _, quote := range taxMarketQuotes {
+			for i := range pairs {
+				if !pairs[i].Base.Equal(code) || !pairs[i].Quote.Equal(quote) {
+					continue
+				}
+				price, priceErr := v.candlePrice(e, pairs[i], t)
+				if priceErr != nil {
+					continue
+				}
+				r, known, rateErr := v.storedRate(quote, t)
+				if !known || rateErr != nil {
+					continue
+				}
+				return price.Mul(r), nil
+			}
+		}
+	}
+	return decimal.Zero, fmt.Errorf("%w for %s at %s", errNoMarketPrice, code, t.Format(time.RFC3339))
+}
+
+// candlePrice returns the close of the hourly candle containing the time.
+// Prices are cached including those which could not be found
+func (v *taxValuer) candlePrice(exch exchange.IBotExchange, pair currency.Pair, t time.Time) (decimal.Decimal, error) {
+	start := t.Truncate(time.Hour)
+	key := exch.GetName() + "|" + pair.String() + "|" + strconv.FormatInt(start.Unix(), 10)
+	if price, ok := v.prices[key]; ok {
+		if price.IsZero() {
+			return decimal.Zero, errNoMarketPrice
+		}
+		return price, nil
+	}
+	k, err := exch.GetHistoricCandles(v.ctx, pair, asset.Spot, kline.OneHour, start, start.Add(time.Hour))
+	if err != nil || k == nil || len(k.Candles) == 0 || k.Candles[0].Close <= 0 {
+		v.prices[key] = decimal.Zero
+		return decimal.Zero, errNoMarketPrice
+	}
+	price := decimal.NewFromFloat(k.Candles[0].Close)
+	v.prices[key] = price
+	return price, nil
+}
+
+// match books the ledger in time order and records the disposals, income and
+// ledger entries within the report range. Fiat currencies are not booked
+func (r *TaxReport) match(ledger []TaxLedgerEntry) error {
+	books := make(map[*currency.Item]*costbasis.Book)
+	for i := range ledger {
+		e := &ledger[i]
+		inRange := !e.Time.Before(r.Start) && !e.Time.After(r.End)
+		if inRange {
+			r.Ledger = append(r.Ledger, *e)
+			if e.Kind == TaxLedgerIncome {
+				r.IncomeEntries = append(r.IncomeEntries, TaxIncome{
+					Time:        e.Time,
+					Exchange:    e.Exchange,
+					Currency:    e.Currency,
+					Amount:      e.Amount,
+					Value:       e.Value,
+					Reference:   e.Reference,
+					Description: e.Description,
+				})
+				r.Income = r.Income.Add(e.Value)
+			}
+		}
+		if e.Currency.IsFiatCurrency() || e.Currency.IsEmpty() || e.Amount.IsZero() {
+			continue
+		}
+		if e.Kind != TaxLedgerTrade && e.Kind != TaxLedgerIncome && e.Kind != TaxLedgerFee {
+			continue
+		}
+		book, ok := books[e.Currency.Item]
+		if !ok {
+			var err error
+			book, err = costbasis.NewBook(r.Method)
+			if err != nil {
+				return err
+			}
+			books[e.Currency.Item] = book
+		}
+		ref := e.Exchange + ":" + e.Reference
+		if e.Amount.IsPositive() {
+			if err := book.Acquire(ref, e.Time, e.Amount, e.Value.Add(e.FeeValue)); err != nil {
+				return err
+			}
+			continue
+		}
+		amount := e.Amount.Abs()
+		proceeds := decimal.Max(e.Value.Sub(e.FeeValue), decimal.Zero)
+		disposals, unmatched, err := book.Dispose(ref, e.Time, amount, proceeds)
+		if err != nil {
+			return err
+		}
+		if !inRange {
+			continue
+		}
+		for j := range disposals {
+			gain := disposals[j].Gain()
+			r.Disposals = append(r.Disposals, TaxDisposal{
+				Disposed:     e.Time,
+				Acquired:     disposals[j].Acquired,
+				Exchange:     e.Exchange,
+				Currency:     e.Currency,
+				Amount:       disposals[j].Amount,
+				Proceeds:     disposals[j].Proceeds,
+				Cost:         disposals[j].Cost,
+				Gain:         gain,
+				Reference:    ref,
+				LotReference: disposals[j].LotReference,
+			})
+			r.Proceeds = r.Proceeds.Add(disposals[j].Proceeds)
+			r.Cost = r.Cost.Add(disposals[j].Cost)
+			r.Gain = r.Gain.Add(gain)
+		}
+		if unmatched.IsPositive() {
+			unmatchedProceeds := proceeds.Mul(unmatched).Div(amount)
+			r.Disposals = append(r.Disposals, TaxDisposal{
+				Disposed:  e.Time,
+				Exchange:  e.Exchange,
+				Currency:  e.Currency,
+				Amount:    unmatched,
+				Proceeds:  unmatchedProceeds,
+				Gain:      unmatchedProceeds,
+				Reference: ref,
+				Unmatched: true,
+			})
+			r.UnmatchedProceeds = r.UnmatchedProceeds.Add(unmatchedProceeds)
+		}
+	}
+	return nil
+}
+
+// WriteCapitalGainsCSV writes the disposals of the report as CSV
+func (r *TaxReport) WriteCapitalGainsCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(taxCapitalGainsHeader); err != nil {
+		return err
+	}
+	for i := range r.Disposals {
+		d := &r.Disposals[i]
+		if err := c.Write([]string{
+			taxCSVTime(d.Disposed),
+			taxCSVTime(d.Acquired),
+			d.Exchange,
+			d.Currency.String(),
+			d.Amount.String(),
+			d.Proceeds.String(),
+			d.Cost.String(),
+			d.Gain.String(),
+			d.Reference,
+			d.LotReference,
+			strconv.FormatBool(d.Unmatched),
+		}); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// WriteIncomeCSV writes the income of the report as CSV
+func (r *TaxReport) WriteIncomeCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(taxIncomeHeader); err != nil {
+		return err
+	}
+	for i := range r.IncomeEntries {
+		in := &r.IncomeEntries[i]
+		if err := c.Write([]string{
+			taxCSVTime(in.Time),
+			in.Exchange,
+			in.Currency.String(),
+			in.Amount.String(),
+			in.Value.String(),
+			in.Reference,
+			in.Description,
+		}); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// WriteLedgerCSV writes the ledger entries of the report as CSV
+func (r *TaxReport) WriteLedgerCSV(w io.Writer) error {
+	c := csv.NewWriter(w)
+	if err := c.Write(taxLedgerHeader); err != nil {
+		return err
+	}
+	for i := range r.Ledger {
+		e := &r.Ledger[i]
+		var pair string
+		if !e.Pair.IsEmpty() {
+			pair = e.Pair.String()
+		}
+		if err := c.Write([]string{
+			taxCSVTime(e.Time),
+			string(e.Kind),
+			e.Exchange,
+			e.Reference,
+			pair,
+			e.Side,
+			e.Currency.String(),
+			e.Amount.String(),
+			e.Value.String(),
+			e.FeeValue.String(),
+			e.Description,
+		}); err != nil {
+			return err
+		}
+	}
+	c.Flush()
+	return c.Error()
+}
+
+// taxCSVTime formats a time for a CSV report, a zero time is left empty
+func taxCSVTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339)
+}
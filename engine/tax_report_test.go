@@ -0,0 +1,285 @@
+package engine
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// taxExchange returns canned history and hourly closes keyed by pair and
+// hour
+type taxExchange struct {
+	exchange.IBotExchange
+	name        string
+	pairs       currency.Pairs
+	orders      []order.Detail
+	funding     []exchange.FundingHistory
+	withdrawals map[string][]exchange.WithdrawalHistory
+	closes      map[string]float64
+}
+
+func (e *taxExchange) GetName() string {
+	return e.name
+}
+
+func (e *taxExchange) IsRESTAuthenticationSupported() bool {
+	return true
+}
+
+func (e *taxExchange) GetAssetTypes(bool) asset.Items {
+	return asset.Items{asset.Spot}
+}
+
+func (e *taxExchange) GetEnabledPairs(asset.Item) (currency.Pairs, error) {
+	return e.pairs, nil
+}
+
+func (e *taxExchange) GetOrderHistory(context.Context, *order.MultiOrderRequest) (order.FilteredOrders, error) {
+	return e.orders, nil
+}
+
+func (e *taxExchange) GetAccountFundingHistory(context.Context) ([]exchange.FundingHistory, error) {
+	return e.funding, nil
+}
+
+func (e *taxExchange) GetWithdrawalsHistory(_ context.Context, c currency.Code, _ asset.Item) ([]exchange.WithdrawalHistory, error) {
+	if e.withdrawals == nil {
+		return nil, common.ErrFunctionNotSupported
+	}
+	return e.withdrawals[c.String()], nil
+}
+
+func (e *taxExchange) GetHistoricCandles(_ context.Context, p currency.Pair, a asset.Item, _ kline.Interval, start, _ time.Time) (*kline.Item, error) {
+	c, ok := e.closes[p.String()+start.Format(time.RFC3339)]
+	if !ok {
+		return nil, errNoMarketPrice
+	}
+	return &kline.Item{Exchange: e.name, Pair: p, Asset: a, Candles: []kline.Candle{{Time: start, Close: c}}}, nil
+}
+
+func TestBuildTaxReport(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2023, 12, 31, 23, 59, 59, 0, time.UTC)
+	_, err := BuildTaxReport(context.Background(), nil, nil)
+	if !errors.Is(err, errNilExchangeManager) {
+		t.Errorf("received '%v', expected '%v'", err, errNilExchangeManager)
+	}
+	em := NewExchangeManager()
+	_, err = BuildTaxReport(context.Background(), em, nil)
+	if !errors.Is(err, errNilRequestData) {
+		t.Errorf("received '%v', expected '%v'", err, errNilRequestData)
+	}
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: end, End: start})
+	if !errors.Is(err, common.ErrStartAfterEnd) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrStartAfterEnd)
+	}
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: start, End: end, HistoryStart: end})
+	if !errors.Is(err, errTaxHistoryBounds) {
+		t.Errorf("received '%v', expected '%v'", err, errTaxHistoryBounds)
+	}
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: start, End: end, Method: costbasis.Method(99)})
+	if !errors.Is(err, costbasis.ErrUnknownMethod) {
+		t.Errorf("received '%v', expected '%v'", err, costbasis.ErrUnknownMethod)
+	}
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: start, End: end, BaseCurrency: currency.BTC})
+	if !errors.Is(err, errTaxBaseNotFiat) {
+		t.Errorf("received '%v', expected '%v'", err, errTaxBaseNotFiat)
+	}
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: start, End: end})
+	if !errors.Is(err, errNoTaxExchanges) {
+		t.Errorf("received '%v', expected '%v'", err, errNoTaxExchanges)
+	}
+
+	btcusdt := currency.NewPair(currency.BTC, currency.USDT)
+	ethusdt := currency.NewPair(currency.ETH, currency.USDT)
+	ethbtc := currency.NewPair(currency.ETH, currency.BTC)
+	ltcusdt := currency.NewPair(currency.LTC, currency.USDT)
+	swapped := time.Date(2023, 4, 1, 10, 30, 0, 0, time.UTC)
+	staked := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	withdrawn := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	exch := &taxExchange{
+		name:  "taxexch",
+		pairs: currency.Pairs{btcusdt, ethusdt, ethbtc, ltcusdt},
+		orders: []order.Detail{
+			// acquired before the report range
+			{OrderID: "1", Pair: btcusdt, Side: order.Buy, ExecutedAmount: 1, AverageExecutedPrice: 20000, Fee: 20, LastUpdated: time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)},
+			{OrderID: "2", Pair: btcusdt, Side: order.Sell, Trades: []order.TradeHistory{
+				{TID: "2a", Amount: 0.5, Price: 30000, Fee: 15, Timestamp: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)},
+			}},
+			// valued with the ETH market price as neither currency has a
+			// stored rate
+			{OrderID: "3", Pair: ethbtc, Side: order.Buy, ExecutedAmount: 10, AverageExecutedPrice: 0.05, Fee: 0.01, FeeAsset: currency.ETH, LastUpdated: swapped},
+			// no acquisition of LTC is known
+			{OrderID: "4", Pair: ltcusdt, Side: order.Sell, ExecutedAmount: 1, AverageExecutedPrice: 100, LastUpdated: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+			{OrderID: "5", Pair: ltcusdt, Side: order.AnySide, ExecutedAmount: 1, AverageExecutedPrice: 100, LastUpdated: time.Date(2023, 7, 1, 0, 0, 0, 0, time.UTC)},
+		},
+		funding: []exchange.FundingHistory{
+			{TransferID: "d1", TransferType: "deposit", Currency: "USDT", Amount: 100, Timestamp: time.Date(2023, 1, 15, 0, 0, 0, 0, time.UTC)},
+			{TransferID: "s1", TransferType: "Staking reward", Currency: "ETH", Amount: 1, Timestamp: staked},
+			{TransferID: "w1", TransferType: "withdrawal", Currency: "ETH", Amount: 2, Fee: 0.005, Timestamp: withdrawn},
+			{TransferID: "w2", TransferType: "withdrawal", Status: "Failed", Currency: "ETH", Amount: 2, Fee: 0.005, Timestamp: withdrawn},
+		},
+		withdrawals: map[string][]exchange.WithdrawalHistory{
+			"ETH": {
+				// already returned by the funding history
+				{TransferID: "w1", Currency: "ETH", Amount: 2, Fee: 0.005, Timestamp: withdrawn},
+				{TransferID: "w3", Currency: "ETH", Amount: 1, Fee: 0.005, Timestamp: withdrawn.Add(time.Hour)},
+			},
+		},
+		closes: map[string]float64{
+			ethusdt.String() + swapped.Truncate(time.Hour).Format(time.RFC3339): 1500,
+			ethusdt.String() + staked.Format(time.RFC3339):                      1600,
+			ethusdt.String() + withdrawn.Format(time.RFC3339):                   1800,
+			ethusdt.String() + withdrawn.Add(time.Hour).Format(time.RFC3339):    1800,
+		},
+	}
+	if err = em.Add(exch); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+
+	report, err := BuildTaxReport(context.Background(), em, &TaxReportRequest{
+		Start:        start,
+		End:          end,
+		HistoryStart: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC),
+		Method:       costbasis.FIFO,
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(report.Errors) != 0 {
+		t.Errorf("received '%v', expected no errors", report.Errors)
+	}
+	if !report.BaseCurrency.Equal(currency.USD) {
+		t.Errorf("received '%v', expected '%v'", report.BaseCurrency, currency.USD)
+	}
+
+	var btc []TaxDisposal
+	for i := range report.Disposals {
+		if report.Disposals[i].Currency.Equal(currency.BTC) {
+			btc = append(btc, report.Disposals[i])
+		}
+	}
+	if len(btc) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(btc), 2)
+	}
+	// half of 1 BTC bought for 20000 plus a fee of 20, sold for 15000 less a
+	// fee of 15
+	if !btc[0].Cost.Equal(decimal.NewFromInt(10010)) || !btc[0].Proceeds.Equal(decimal.NewFromInt(14985)) || !btc[0].Gain.Equal(decimal.NewFromInt(4975)) {
+		t.Errorf("received '%+v', expected a gain of 4975", btc[0])
+	}
+	if btc[0].LotReference != "taxexch:1" || !btc[0].Acquired.Equal(time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("received '%v', expected '%v'", btc[0].LotReference, "taxexch:1")
+	}
+	// 0.5 BTC swapped for 10 ETH worth 15000
+	if !btc[1].Proceeds.Equal(decimal.NewFromInt(15000)) || !btc[1].Gain.Equal(decimal.NewFromInt(4990)) {
+		t.Errorf("received '%+v', expected a gain of 4990", btc[1])
+	}
+
+	var unmatched int
+	for i := range report.Disposals {
+		if report.Disposals[i].Unmatched {
+			unmatched++
+			if !report.Disposals[i].Currency.Equal(currency.LTC) || !report.Disposals[i].Proceeds.Equal(decimal.NewFromInt(100)) {
+				t.Errorf("received '%+v', expected unmatched LTC", report.Disposals[i])
+			}
+		}
+	}
+	if unmatched != 1 || !report.UnmatchedProceeds.Equal(decimal.NewFromInt(100)) {
+		t.Errorf("received '%v', expected '%v'", report.UnmatchedProceeds, 100)
+	}
+
+	if len(report.IncomeEntries) != 1 || !report.Income.Equal(decimal.NewFromInt(1600)) {
+		t.Errorf("received '%v', expected '%v'", report.Income, 1600)
+	}
+
+	var fees, withdrawals int
+	for i := range report.Ledger {
+		switch report.Ledger[i].Kind {
+		case TaxLedgerWithdrawal:
+			withdrawals++
+		case TaxLedgerFee:
+			fees++
+		}
+		if report.Ledger[i].Time.Before(start) {
+			t.Errorf("received '%v', expected ledger entries within the report range", report.Ledger[i].Time)
+		}
+	}
+	// w1 and w3, w2 failed
+	if withdrawals != 2 {
+		t.Errorf("received '%v', expected '%v'", withdrawals, 2)
+	}
+	// sell, swap and the two withdrawals
+	if fees != 4 {
+		t.Errorf("received '%v', expected '%v'", fees, 4)
+	}
+
+	for _, write := range []func(*bytes.Buffer) (int, error){
+		func(b *bytes.Buffer) (int, error) { return len(report.Disposals), report.WriteCapitalGainsCSV(b) },
+		func(b *bytes.Buffer) (int, error) { return len(report.IncomeEntries), report.WriteIncomeCSV(b) },
+		func(b *bytes.Buffer) (int, error) { return len(report.Ledger), report.WriteLedgerCSV(b) },
+	} {
+		var b bytes.Buffer
+		rows, writeErr := write(&b)
+		if !errors.Is(writeErr, nil) {
+			t.Fatalf("received '%v', expected '%v'", writeErr, nil)
+		}
+		records, readErr := csv.NewReader(&b).ReadAll()
+		if !errors.Is(readErr, nil) {
+			t.Fatalf("received '%v', expected '%v'", readErr, nil)
+		}
+		if len(records) != rows+1 {
+			t.Errorf("received '%v', expected '%v'", len(records), rows+1)
+		}
+	}
+
+	_, err = BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: start, End: end, Exchanges: []string{"unknown"}})
+	if !errors.Is(err, ErrExchangeNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, ErrExchangeNotFound)
+	}
+}
+
+func TestBuildTaxReportFX(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2021, 2, 3, 0, 0, 0, 0, time.UTC)
+	err := currency.AddHistoricalRates(currency.HistoricalRate{From: currency.USD, To: currency.GBP, Date: day, Rate: 0.8})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	em := NewExchangeManager()
+	btcusdt := currency.NewPair(currency.BTC, currency.USDT)
+	exch := &taxExchange{
+		name:  "taxfx",
+		pairs: currency.Pairs{btcusdt},
+		orders: []order.Detail{
+			{OrderID: "1", Pair: btcusdt, Side: order.Buy, ExecutedAmount: 1, AverageExecutedPrice: 1000, LastUpdated: day.Add(time.Hour)},
+			{OrderID: "2", Pair: btcusdt, Side: order.Sell, ExecutedAmount: 1, AverageExecutedPrice: 2000, LastUpdated: day.Add(time.Hour * 2)},
+		},
+	}
+	if err = em.Add(exch); !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	report, err := BuildTaxReport(context.Background(), em, &TaxReportRequest{Start: day, End: day.Add(time.Hour * 24), BaseCurrency: currency.GBP})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// withdrawal history is not supported by the exchange
+	if len(report.Errors) != 1 {
+		t.Errorf("received '%v', expected '%v'", len(report.Errors), 1)
+	}
+	if !report.Gain.Equal(decimal.NewFromInt(800)) || !report.Proceeds.Equal(decimal.NewFromInt(1600)) {
+		t.Errorf("received '%v', expected '%v'", report.Gain, 800)
+	}
+}
@@ -0,0 +1,142 @@
+package engine
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/costbasis"
+)
+
+// TaxLedgerKind describes the movement of a tax ledger entry
+type TaxLedgerKind string
+
+// Tax ledger entry kinds
+const (
+	// TaxLedgerTrade is a currency bought or sold by a fill
+	TaxLedgerTrade TaxLedgerKind = "trade"
+	// TaxLedgerFee is a currency paid as a trading or withdrawal fee
+	TaxLedgerFee TaxLedgerKind = "fee"
+	// TaxLedgerIncome is a currency received as income such as interest,
+	// staking rewards or airdrops
+	TaxLedgerIncome TaxLedgerKind = "income"
+	// TaxLedgerDeposit is a currency transferred to an exchange
+	TaxLedgerDeposit TaxLedgerKind = "deposit"
+	// TaxLedgerWithdrawal is a currency transferred from an exchange
+	TaxLedgerWithdrawal TaxLedgerKind = "withdrawal"
+)
+
+var (
+	errTaxBaseNotFiat   = errors.New("tax report base currency must be a fiat currency")
+	errNoTaxExchanges   = errors.New("no exchanges to report on")
+	errNoMarketPrice    = errors.New("no market price")
+	errTaxHistoryBounds = errors.New("history start cannot be after the report start")
+)
+
+// taxIncomeKeywords classify funding history entries as income when found in
+// their transfer type or description
+var taxIncomeKeywords = []string{"interest", "staking", "reward", "airdrop", "rebate", "dividend", "distribution", "bonus", "mining", "earn"}
+
+// taxMarketQuotes are the quote currencies used to find the market price of a
+// currency when it cannot be valued from a trade
+var taxMarketQuotes = []currency.Code{currency.USDT, currency.USD, currency.USDC}
+
+var (
+	taxCapitalGainsHeader = []string{"disposed", "acquired", "exchange", "currency", "amount", "proceeds", "cost", "gain", "reference", "lot_reference", "unmatched"}
+	taxIncomeHeader       = []string{"time", "exchange", "currency", "amount", "value", "reference", "description"}
+	taxLedgerHeader       = []string{"time", "kind", "exchange", "reference", "pair", "side", "currency", "amount", "value", "fee_value", "description"}
+)
+
+// TaxReportRequest defines the range, cost basis method and base currency of
+// a tax report
+type TaxReportRequest struct {
+	// Start and End bound the disposals and income reported
+	Start time.Time
+	End   time.Time
+	// HistoryStart is when history is retrieved from so acquisitions before
+	// the report start can be matched. Defaults to Start
+	HistoryStart time.Time
+	Method       costbasis.Method
+	// BaseCurrency is the fiat currency values are reported in
+	BaseCurrency currency.Code
+	// Exchanges limits the report to the named exchanges, otherwise every
+	// enabled exchange which supports authenticated requests is used
+	Exchanges []string
+}
+
+// TaxLedgerEntry is a movement of a single currency on an exchange. Amount is
+// positive when received and negative when spent. Value is the market value of
+// the movement in the base currency. FeeValue is the trading fee of a fill,
+// which is added to the cost of a purchase and deducted from the proceeds of a
+// sale of the traded currency
+type TaxLedgerEntry struct {
+	Time        time.Time
+	Kind        TaxLedgerKind
+	Exchange    string
+	Reference   string
+	Pair        currency.Pair
+	Side        string
+	Currency    currency.Code
+	Amount      decimal.Decimal
+	Value       decimal.Decimal
+	FeeValue    decimal.Decimal
+	Description string
+}
+
+// TaxDisposal is an amount of a currency disposed of and matched against the
+// lot it was acquired in. Unmatched disposals had no known acquisition and are
+// reported without a cost
+type TaxDisposal struct {
+	Disposed     time.Time
+	Acquired     time.Time
+	Exchange     string
+	Currency     currency.Code
+	Amount       decimal.Decimal
+	Proceeds     decimal.Decimal
+	Cost         decimal.Decimal
+	Gain         decimal.Decimal
+	Reference    string
+	LotReference string
+	Unmatched    bool
+}
+
+// TaxIncome is income received and its value in the base currency when it was
+// received
+type TaxIncome struct {
+	Time        time.Time
+	Exchange    string
+	Currency    currency.Code
+	Amount      decimal.Decimal
+	Value       decimal.Decimal
+	Reference   string
+	Description string
+}
+
+// TaxReport holds the capital gains, income and ledger of a tax year or other
+// range, valued in the base currency. Totals exclude unmatched disposals
+type TaxReport struct {
+	Start             time.Time
+	End               time.Time
+	BaseCurrency      currency.Code
+	Method            costbasis.Method
+	Proceeds          decimal.Decimal
+	Cost              decimal.Decimal
+	Gain              decimal.Decimal
+	UnmatchedProceeds decimal.Decimal
+	Income            decimal.Decimal
+	Disposals         []TaxDisposal
+	IncomeEntries     []TaxIncome
+	Ledger            []TaxLedgerEntry
+	Errors            []string
+}
+
+// taxValuer values currencies in the base currency at a point in time using
+// stored foreign exchange rates and exchange candles
+type taxValuer struct {
+	ctx    context.Context
+	em     iExchangeManager
+	base   currency.Code
+	prices map[string]decimal.Decimal
+}
@@ -40,6 +40,7 @@ func setupWebsocketRoutineManager(exchangeManager iExchangeManager, orderManager
 		orderManager:    orderManager,
 		syncer:          syncer,
 		currencyConfig:  cfg,
+		tradeFeed:       newSequencedFeed(defaultSequencedFeedCapacity),
 	}
 	return man, man.registerWebsocketDataHandler(man.websocketDataHandler, false)
 }
@@ -337,6 +338,10 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 		return fmt.Errorf("%w %s", d.Err, d.Error())
 	case stream.UnhandledMessageWarning:
 		log.Warnln(log.WebsocketMgr, d.Message)
+	case stream.Reconnected:
+		if m.verbose {
+			log.Infof(log.WebsocketMgr, "%s websocket reconnected at %v", exchName, d.Timestamp)
+		}
 	case account.Change:
 		if m.verbose {
 			m.printAccountHoldingsChangeSummary(d)
@@ -351,10 +356,14 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 		if m.verbose {
 			log.Infof(log.Trade, "%+v", d)
 		}
+		for x := range d {
+			m.tradeFeed.publish(d[x])
+		}
 	case []fill.Data:
 		if m.verbose {
 			log.Infof(log.Fill, "%+v", d)
 		}
+		return m.orderManager.ProcessFills(d)
 	default:
 		if m.verbose {
 			log.Warnf(log.WebsocketMgr,
@@ -366,6 +375,15 @@ func (m *WebsocketRoutineManager) websocketDataHandler(exchName string, data int
 	return nil
 }
 
+// subscribeTrades returns a subscription to websocket trades which replays
+// the retained trades
+func (m *WebsocketRoutineManager) subscribeTrades() (*feedSubscription, error) {
+	if m == nil {
+		return nil, fmt.Errorf("websocket routine manager %w", ErrNilSubsystem)
+	}
+	return m.tradeFeed.subscribeRetained()
+}
+
 // FormatCurrency is a method that formats and returns a currency pair
 // based on the user currency display preferences
 func (m *WebsocketRoutineManager) FormatCurrency(p currency.Pair) currency.Pair {
@@ -31,10 +31,13 @@ type WebsocketRoutineManager struct {
 	orderManager    iOrderManager
 	syncer          iCurrencyPairSyncer
 	currencyConfig  *currency.Config
-	shutdown        chan struct{}
-	dataHandlers    []WebsocketDataHandler
-	wg              sync.WaitGroup
-	mu              sync.RWMutex
+	// tradeFeed publishes trades received from exchange websockets to
+	// websocket API subscribers
+	tradeFeed    *sequencedFeed
+	shutdown     chan struct{}
+	dataHandlers []WebsocketDataHandler
+	wg           sync.WaitGroup
+	mu           sync.RWMutex
 }
 
 // WebsocketDataHandler defines a function signature for a function that handles
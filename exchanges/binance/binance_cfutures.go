@@ -15,6 +15,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 )
@@ -1454,12 +1455,56 @@ func (b *Binance) FetchCoinMarginExchangeLimits(ctx context.Context) ([]order.Mi
 	if err != nil {
 		return nil, err
 	}
+	return coinMarginLimits(&coinFutures)
+}
+
+// coinMarginInstruments converts coin margined exchange info into instrument
+// specifications
+func coinMarginInstruments(coinFutures *CExchangeInfo) ([]instrument.Instrument, error) {
+	instruments := make([]instrument.Instrument, 0, len(coinFutures.Symbols))
+	for x := range coinFutures.Symbols {
+		symbol := strings.Split(coinFutures.Symbols[x].Symbol, currency.UnderscoreDelimiter)
+		if len(symbol) != 2 {
+			return nil, fmt.Errorf("unexpected coin margined symbol %s", coinFutures.Symbols[x].Symbol)
+		}
+		cp, err := currency.NewPairFromStrings(symbol[0], symbol[1])
+		if err != nil {
+			return nil, err
+		}
+		underlying, err := currency.NewPairFromStrings(coinFutures.Symbols[x].BaseAsset,
+			coinFutures.Symbols[x].QuoteAsset)
+		if err != nil {
+			return nil, err
+		}
+		inst := instrument.Instrument{
+			Asset:                asset.CoinMarginedFutures,
+			Pair:                 cp,
+			Symbol:               coinFutures.Symbols[x].Symbol,
+			ContractType:         futuresContractType(coinFutures.Symbols[x].ContractType),
+			Underlying:           underlying,
+			ContractSize:         float64(coinFutures.Symbols[x].ContractSize),
+			ContractSizeCurrency: underlying.Quote,
+			Multiplier:           1,
+			SettlementCurrency:   currency.NewCode(coinFutures.Symbols[x].MarginAsset),
+			MarginCurrency:       currency.NewCode(coinFutures.Symbols[x].MarginAsset),
+			ListingTime:          time.UnixMilli(coinFutures.Symbols[x].OnboardDate),
+			Status:               futuresContractStatus(coinFutures.Symbols[x].ContractStatus),
+		}
+		if inst.ContractType == instrument.Delivery {
+			inst.Expiry = time.UnixMilli(coinFutures.Symbols[x].DeliveryDate)
+		}
+		instruments = append(instruments, inst)
+	}
+	return instruments, nil
+}
 
+// coinMarginLimits converts coin margined exchange info into order execution
+// limits
+func coinMarginLimits(coinFutures *CExchangeInfo) ([]order.MinMaxLevel, error) {
 	limits := make([]order.MinMaxLevel, 0, len(coinFutures.Symbols))
 	for x := range coinFutures.Symbols {
 		symbol := strings.Split(coinFutures.Symbols[x].Symbol, currency.UnderscoreDelimiter)
-		var cp currency.Pair
-		cp, err = currency.NewPairFromStrings(symbol[0], symbol[1])
+		cp, err := currency.NewPairFromStrings(symbol[0], symbol[1])
 		if err != nil {
 			return nil, err
 		}
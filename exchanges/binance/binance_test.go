@@ -16,6 +16,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
@@ -2938,3 +2939,47 @@ func TestGetUserMarginInterestHistory(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestFuturesInstruments(t *testing.T) {
+	t.Parallel()
+	var uInfo UFuturesExchangeInfo
+	err := json.Unmarshal([]byte(`{"symbols":[{"symbol":"BTCUSDT","pair":"BTCUSDT","contractType":"PERPETUAL","deliveryDate":4133404800000,"onboardDate":1569398400000,"status":"TRADING","baseAsset":"BTC","quoteAsset":"USDT","marginAsset":"USDT"},{"symbol":"BTCUSDT_230929","pair":"BTCUSDT","contractType":"CURRENT_QUARTER","deliveryDate":1695974400000,"onboardDate":1687507200000,"status":"PENDING_TRADING","baseAsset":"BTC","quoteAsset":"USDT","marginAsset":"USDT"}]}`), &uInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uInstruments, err := usdtMarginInstruments(&uInfo)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(uInstruments) != 2 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(uInstruments), 2)
+	}
+	if uInstruments[0].ContractType != instrument.Perpetual || !uInstruments[0].Expiry.IsZero() || uInstruments[0].Status != instrument.Trading {
+		t.Errorf("received: '%+v' unexpected perpetual instrument", uInstruments[0])
+	}
+	if uInstruments[1].ContractType != instrument.Delivery || uInstruments[1].Expiry.UnixMilli() != 1695974400000 || uInstruments[1].Status != instrument.PreTrading {
+		t.Errorf("received: '%+v' unexpected delivery instrument", uInstruments[1])
+	}
+	if uInstruments[1].Pair.Equal(uInstruments[0].Pair) || !uInstruments[1].Underlying.Equal(uInstruments[0].Pair) {
+		t.Errorf("received: '%v' '%v' unexpected delivery pair", uInstruments[1].Pair, uInstruments[1].Underlying)
+	}
+
+	var cInfo CExchangeInfo
+	err = json.Unmarshal([]byte(`{"symbols":[{"symbol":"BTCUSD_PERP","pair":"BTCUSD","contractType":"PERPETUAL","deliveryDate":4133404800000,"onboardDate":1597042800000,"contractStatus":"TRADING","contractSize":100,"baseAsset":"BTC","quoteAsset":"USD","marginAsset":"BTC"}]}`), &cInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cInstruments, err := coinMarginInstruments(&cInfo)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(cInstruments) != 1 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(cInstruments), 1)
+	}
+	if cInstruments[0].ContractSize != 100 || !cInstruments[0].ContractSizeCurrency.Equal(currency.USD) {
+		t.Errorf("received: '%v %v' but expected: '100 USD'", cInstruments[0].ContractSize, cInstruments[0].ContractSizeCurrency)
+	}
+	if !cInstruments[0].IsInverse() {
+		t.Error("expected coin margined contract to be inverse")
+	}
+}
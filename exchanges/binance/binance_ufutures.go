@@ -15,6 +15,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 )
 
@@ -1104,11 +1105,55 @@ func (b *Binance) FetchUSDTMarginExchangeLimits(ctx context.Context) ([]order.Mi
 	if err != nil {
 		return nil, err
 	}
+	return usdtMarginLimits(&usdtFutures)
+}
+
+// usdtMarginInstruments converts USDT margined exchange info into instrument
+// specifications
+func usdtMarginInstruments(usdtFutures *UFuturesExchangeInfo) ([]instrument.Instrument, error) {
+	instruments := make([]instrument.Instrument, 0, len(usdtFutures.Symbols))
+	for x := range usdtFutures.Symbols {
+		underlying, err := currency.NewPairFromStrings(usdtFutures.Symbols[x].BaseAsset,
+			usdtFutures.Symbols[x].QuoteAsset)
+		if err != nil {
+			return nil, err
+		}
+		// dated contracts are named by their symbol, matching tradable pairs
+		cp := underlying
+		if usdtFutures.Symbols[x].ContractType != "PERPETUAL" {
+			cp, err = currency.NewPairFromString(usdtFutures.Symbols[x].Symbol)
+			if err != nil {
+				return nil, err
+			}
+		}
+		inst := instrument.Instrument{
+			Asset:                asset.USDTMarginedFutures,
+			Pair:                 cp,
+			Symbol:               usdtFutures.Symbols[x].Symbol,
+			ContractType:         futuresContractType(usdtFutures.Symbols[x].ContractType),
+			Underlying:           underlying,
+			ContractSize:         1,
+			ContractSizeCurrency: underlying.Base,
+			Multiplier:           1,
+			SettlementCurrency:   currency.NewCode(usdtFutures.Symbols[x].MarginAsset),
+			MarginCurrency:       currency.NewCode(usdtFutures.Symbols[x].MarginAsset),
+			ListingTime:          usdtFutures.Symbols[x].OnboardDate,
+			Status:               futuresContractStatus(usdtFutures.Symbols[x].Status),
+		}
+		if inst.ContractType == instrument.Delivery {
+			inst.Expiry = usdtFutures.Symbols[x].DeliveryDate
+		}
+		instruments = append(instruments, inst)
+	}
+	return instruments, nil
+}
 
+// usdtMarginLimits converts USDT margined exchange info into order execution
+// limits
+func usdtMarginLimits(usdtFutures *UFuturesExchangeInfo) ([]order.MinMaxLevel, error) {
 	limits := make([]order.MinMaxLevel, 0, len(usdtFutures.Symbols))
 	for x := range usdtFutures.Symbols {
-		var cp currency.Pair
-		cp, err = currency.NewPairFromStrings(usdtFutures.Symbols[x].BaseAsset,
+		cp, err := currency.NewPairFromStrings(usdtFutures.Symbols[x].BaseAsset,
 			usdtFutures.Symbols[x].QuoteAsset)
 		if err != nil {
 			return nil, err
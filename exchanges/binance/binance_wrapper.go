@@ -12,6 +12,7 @@ import (
 
 	"github.com/shopspring/decimal"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -19,6 +20,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -1269,7 +1271,7 @@ func (b *Binance) GetOrderInfo(ctx context.Context, orderID string, pair currenc
 		}
 		status, err := order.StringToOrderStatus(resp.Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 		orderType := order.Limit
 		if resp.Type == "MARKET" {
@@ -1439,16 +1441,16 @@ func (b *Binance) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequ
 				var side order.Side
 				side, err = order.StringToOrderSide(resp[x].Side)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				var orderType order.Type
 				orderType, err = order.StringToOrderType(resp[x].Type)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				orderStatus, err := order.StringToOrderStatus(resp[x].Status)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				orders = append(orders, order.Detail{
 					Amount:        resp[x].OrigQty,
@@ -1564,16 +1566,16 @@ func (b *Binance) GetOrderHistory(ctx context.Context, req *order.MultiOrderRequ
 				var side order.Side
 				side, err = order.StringToOrderSide(resp[i].Side)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				var orderType order.Type
 				orderType, err = order.StringToOrderType(resp[i].Type)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				orderStatus, err := order.StringToOrderStatus(resp[i].Status)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 				}
 				// New orders are covered in GetOpenOrders
 				if orderStatus == order.New {
@@ -1958,14 +1960,29 @@ func compatibleOrderVars(side, status, orderType string) OrderVars {
 // UpdateOrderExecutionLimits sets exchange executions for a required asset type
 func (b *Binance) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) error {
 	var limits []order.MinMaxLevel
+	var instruments []instrument.Instrument
 	var err error
 	switch a {
 	case asset.Spot:
 		limits, err = b.FetchSpotExchangeLimits(ctx)
 	case asset.USDTMarginedFutures:
-		limits, err = b.FetchUSDTMarginExchangeLimits(ctx)
+		var info UFuturesExchangeInfo
+		info, err = b.UExchangeInfo(ctx)
+		if err == nil {
+			limits, err = usdtMarginLimits(&info)
+		}
+		if err == nil {
+			instruments, err = usdtMarginInstruments(&info)
+		}
 	case asset.CoinMarginedFutures:
-		limits, err = b.FetchCoinMarginExchangeLimits(ctx)
+		var info CExchangeInfo
+		info, err = b.FuturesExchangeInfo(ctx)
+		if err == nil {
+			limits, err = coinMarginLimits(&info)
+		}
+		if err == nil {
+			instruments, err = coinMarginInstruments(&info)
+		}
 	case asset.Margin:
 		if err = b.CurrencyPairs.IsAssetEnabled(asset.Spot); err != nil {
 			limits, err = b.FetchSpotExchangeLimits(ctx)
@@ -1978,7 +1995,46 @@ func (b *Binance) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item)
 	if err != nil {
 		return fmt.Errorf("cannot update exchange execution limits: %v", err)
 	}
-	return b.LoadLimits(limits)
+	if err = b.LoadLimits(limits); err != nil {
+		return err
+	}
+	if instruments == nil {
+		instruments = instrument.FromLimits(limits)
+	}
+	return b.LoadInstruments(instruments)
+}
+
+// futuresContractType converts a futures contract type into its exchange
+// independent type
+func futuresContractType(contractType string) instrument.ContractType {
+	switch contractType {
+	case "PERPETUAL":
+		return instrument.Perpetual
+	case "CURRENT_MONTH", "NEXT_MONTH", "CURRENT_QUARTER", "NEXT_QUARTER",
+		"CURRENT_QUARTER DELIVERING", "NEXT_QUARTER DELIVERING":
+		return instrument.Delivery
+	default:
+		return instrument.UnknownContract
+	}
+}
+
+// futuresContractStatus converts a futures contract status into its exchange
+// independent status
+func futuresContractStatus(status string) instrument.Status {
+	switch status {
+	case "TRADING":
+		return instrument.Trading
+	case "PENDING_TRADING":
+		return instrument.PreTrading
+	case "PRE_DELIVERING", "DELIVERING", "PRE_SETTLE", "SETTLING":
+		return instrument.Settling
+	case "DELIVERED", "CLOSE":
+		return instrument.Delisted
+	case "BREAK":
+		return instrument.Halted
+	default:
+		return instrument.UnknownStatus
+	}
 }
 
 // GetAvailableTransferChains returns the available transfer blockchains for the specific
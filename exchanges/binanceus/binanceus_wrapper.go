@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -736,15 +737,15 @@ func (bi *Binanceus) GetOrderInfo(ctx context.Context, orderID string, pair curr
 	}
 	orderSide, err := order.StringToOrderSide(resp.Side)
 	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 	}
 	status, err := order.StringToOrderStatus(resp.Status)
 	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 	}
 	orderType, err = order.StringToOrderType(resp.Type)
 	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 	}
 
 	return &order.Detail{
@@ -847,15 +848,15 @@ func (bi *Binanceus) GetActiveOrders(ctx context.Context, getOrdersRequest *orde
 		var orderStatus order.Status
 		orderSide, err = order.StringToOrderSide(strings.ToUpper(resp[x].Side))
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 		}
 		orderType, err = order.StringToOrderType(strings.ToUpper(resp[x].Type))
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 		}
 		orderStatus, err = order.StringToOrderStatus(resp[x].Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", bi.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", bi.Name, err)
 		}
 		orders[x] = order.Detail{
 			Amount:        resp[x].OrigQty,
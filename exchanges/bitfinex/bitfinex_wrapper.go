@@ -18,6 +18,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -344,7 +345,9 @@ func (b *Bitfinex) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item)
 	if err := b.LoadLimits(limits); err != nil {
 		return fmt.Errorf("%s Error loading exchange limits: %v", b.Name, err)
 	}
-	return nil
+	// The pair info config only holds order sizes, there is no status or
+	// listing time to add to the instruments
+	return b.LoadInstruments(instrument.FromLimits(limits))
 }
 
 // UpdateTickers updates the ticker for all currency pairs of a given asset type
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -274,7 +275,7 @@ func (b *Bitmex) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 					// Example: ETHUSD_ETH quoted in USD, paid out in ETH.
 					settlement := strings.Split(marketInfo[x].Symbol, currency.UnderscoreDelimiter)
 					if len(settlement) != 2 {
-						log.Warnf(log.ExchangeSys, "%s currency %s %s cannot be added to tradable pairs",
+						log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s currency %s %s cannot be added to tradable pairs",
 							b.Name,
 							marketInfo[x].Symbol,
 							a)
@@ -293,7 +294,7 @@ func (b *Bitmex) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 			if marketInfo[x].Typ == futuresID {
 				isolate := strings.Split(marketInfo[x].Symbol, currency.UnderscoreDelimiter)
 				if len(isolate[0]) < 3 {
-					log.Warnf(log.ExchangeSys, "%s currency %s %s be cannot added to tradable pairs",
+					log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s currency %s %s be cannot added to tradable pairs",
 						b.Name,
 						marketInfo[x].Symbol,
 						a)
@@ -987,12 +988,12 @@ func (b *Bitmex) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 		var orderStatus order.Status
 		orderStatus, err = order.StringToOrderStatus(resp[i].OrdStatus)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 		var oType order.Type
 		oType, err = b.getOrderType(resp[i].OrdType)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 		orderDetail := order.Detail{
 			Date:            resp[i].Timestamp,
@@ -1041,7 +1042,7 @@ func (b *Bitmex) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 		var orderStatus order.Status
 		orderStatus, err = order.StringToOrderStatus(resp[i].OrdStatus)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 
 		pair := currency.NewPairWithDelimiter(resp[i].Symbol, resp[i].SettlCurrency, format.Delimiter)
@@ -1049,7 +1050,7 @@ func (b *Bitmex) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 		var oType order.Type
 		oType, err = b.getOrderType(resp[i].OrdType)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 
 		orderDetail := order.Detail{
@@ -11,12 +11,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -328,6 +330,7 @@ func (b *Bitstamp) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item)
 		return err
 	}
 	limits := make([]order.MinMaxLevel, 0, len(symbols))
+	instruments := make([]instrument.Instrument, 0, len(symbols))
 	for x, info := range symbols {
 		if symbols[x].Trading != "Enabled" {
 			continue
@@ -343,11 +346,21 @@ func (b *Bitstamp) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item)
 			AmountStepIncrementSize: math.Pow10(-info.BaseDecimals),
 			MinimumQuoteAmount:      info.MinimumOrder,
 		})
+		instruments = append(instruments, instrument.Instrument{
+			Asset:              a,
+			Pair:               pair,
+			Symbol:             info.URLSymbol,
+			ContractType:       instrument.Spot,
+			Underlying:         pair,
+			SettlementCurrency: pair.Quote,
+			Status:             instrument.Trading,
+			Limits:             limits[len(limits)-1],
+		})
 	}
 	if err := b.LoadLimits(limits); err != nil {
 		return fmt.Errorf("%s Error loading exchange limits: %v", b.Name, err)
 	}
-	return nil
+	return b.LoadInstruments(instruments)
 }
 
 // UpdateTickers updates the ticker for all currency pairs of a given asset type
@@ -824,7 +837,7 @@ func (b *Bitstamp) GetActiveOrders(ctx context.Context, req *order.MultiOrderReq
 		var tm time.Time
 		tm, err = parseTime(resp[i].DateTime)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s GetActiveOrders unable to parse time: %s\n", b.Name, err)
 		}
 
@@ -895,7 +908,7 @@ func (b *Bitstamp) GetOrderHistory(ctx context.Context, req *order.MultiOrderReq
 		case resp[i].XRP > 0:
 			baseCurrency = currency.XRP
 		default:
-			log.Warnf(log.ExchangeSys,
+			log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s No base currency found for ID '%d'\n",
 				b.Name,
 				resp[i].OrderID)
@@ -907,7 +920,7 @@ func (b *Bitstamp) GetOrderHistory(ctx context.Context, req *order.MultiOrderReq
 		case resp[i].EUR > 0:
 			quoteCurrency = currency.EUR
 		default:
-			log.Warnf(log.ExchangeSys,
+			log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s No quote currency found for orderID '%d'\n",
 				b.Name,
 				resp[i].OrderID)
@@ -923,7 +936,7 @@ func (b *Bitstamp) GetOrderHistory(ctx context.Context, req *order.MultiOrderReq
 		var tm time.Time
 		tm, err = parseTime(resp[i].Date)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s GetOrderHistory unable to parse time: %s\n", b.Name, err)
 		}
 
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -807,7 +808,7 @@ func (b *Bittrex) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequ
 		pair, err = currency.NewPairDelimiter(orderData[i].MarketSymbol,
 			format.Delimiter)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"Exchange %v Func %v Order %v Could not parse currency pair %v",
 				b.Name,
 				"GetActiveOrders",
@@ -818,13 +819,13 @@ func (b *Bittrex) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequ
 		var orderType order.Type
 		orderType, err = order.StringToOrderType(orderData[i].Type)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 
 		var orderSide order.Side
 		orderSide, err = order.StringToOrderSide(orderData[i].Direction)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "GetActiveOrders - %s - cannot get order side - %s\n", b.Name, err.Error())
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "GetActiveOrders - %s - cannot get order side - %s\n", b.Name, err.Error())
 		}
 
 		resp = append(resp, order.Detail{
@@ -880,7 +881,7 @@ func (b *Bittrex) GetOrderHistory(ctx context.Context, req *order.MultiOrderRequ
 			pair, err = currency.NewPairDelimiter(orderData[i].MarketSymbol,
 				format.Delimiter)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"Exchange %v Func %v Order %v Could not parse currency pair %v",
 					b.Name,
 					"GetOrderHistory",
@@ -890,19 +891,19 @@ func (b *Bittrex) GetOrderHistory(ctx context.Context, req *order.MultiOrderRequ
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(orderData[i].Type)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 			}
 
 			var orderSide order.Side
 			orderSide, err = order.StringToOrderSide(orderData[i].Direction)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "GetActiveOrders - %s - cannot get order side - %s\n", b.Name, err.Error())
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "GetActiveOrders - %s - cannot get order side - %s\n", b.Name, err.Error())
 			}
 
 			var orderStatus order.Status
 			orderStatus, err = order.StringToOrderStatus(orderData[i].Status)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "GetActiveOrders - %s - cannot get order status - %s\n", b.Name, err.Error())
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "GetActiveOrders - %s - cannot get order status - %s\n", b.Name, err.Error())
 			}
 
 			detail := order.Detail{
@@ -12,12 +12,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -626,14 +628,14 @@ func (b *BTCMarkets) SubmitOrder(ctx context.Context, s *order.Submit) (*order.S
 	if tempResp.Amount != 0 {
 		err = submitResp.AdjustBaseAmount(tempResp.Amount)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "Exchange %s: OrderID: %s base amount conversion error: %s\n", b.Name, submitResp.OrderID, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "Exchange %s: OrderID: %s base amount conversion error: %s\n", b.Name, submitResp.OrderID, err)
 		}
 	}
 
 	if tempResp.TargetAmount != 0 {
 		err = submitResp.AdjustQuoteAmount(tempResp.TargetAmount)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "Exchange %s: OrderID: %s quote amount conversion error: %s\n", b.Name, submitResp.OrderID, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "Exchange %s: OrderID: %s quote amount conversion error: %s\n", b.Name, submitResp.OrderID, err)
 		}
 	}
 	// With market orders the price is optional, so we can set it to the
@@ -931,7 +933,7 @@ func (b *BTCMarkets) GetActiveOrders(ctx context.Context, req *order.MultiOrderR
 			case market:
 				tempResp.Type = order.Market
 			default:
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"%s unknown order type %s getting order",
 					b.Name,
 					tempData[y].Type)
@@ -945,7 +947,7 @@ func (b *BTCMarkets) GetActiveOrders(ctx context.Context, req *order.MultiOrderR
 			case orderPartiallyMatched:
 				tempResp.Status = order.PartiallyFilled
 			default:
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"%s unexpected status %s on order %v",
 					b.Name,
 					tempData[y].Status,
@@ -1168,6 +1170,7 @@ func (b *BTCMarkets) UpdateOrderExecutionLimits(ctx context.Context, a asset.Ite
 	}
 
 	limits := make([]order.MinMaxLevel, len(markets))
+	instruments := make([]instrument.Instrument, len(markets))
 	for x := range markets {
 		var pair currency.Pair
 		pair, err = currency.NewPairFromStrings(markets[x].BaseAsset, markets[x].QuoteAsset)
@@ -1183,8 +1186,33 @@ func (b *BTCMarkets) UpdateOrderExecutionLimits(ctx context.Context, a asset.Ite
 			AmountStepIncrementSize: math.Pow(10, -markets[x].AmountDecimals),
 			PriceStepIncrementSize:  math.Pow(10, -markets[x].PriceDecimals),
 		}
+		instruments[x] = instrument.Instrument{
+			Asset:              asset.Spot,
+			Pair:               pair,
+			Symbol:             markets[x].MarketID,
+			ContractType:       instrument.Spot,
+			Underlying:         pair,
+			SettlementCurrency: pair.Quote,
+			Status:             marketStatus(markets[x].Status),
+			Limits:             limits[x],
+		}
+	}
+	if err = b.LoadLimits(limits); err != nil {
+		return err
+	}
+	return b.LoadInstruments(instruments)
+}
+
+// marketStatus converts a market status into an instrument status, post and
+// limit only markets still accept new orders
+func marketStatus(status string) instrument.Status {
+	switch status {
+	case "Online", "Post Only", "Limit Only":
+		return instrument.Trading
+	case "Cancel Only", "Offline":
+		return instrument.Halted
 	}
-	return b.LoadLimits(limits)
+	return instrument.UnknownStatus
 }
 
 func convertToKlineCandle(candle *[6]string) (kline.Candle, error) {
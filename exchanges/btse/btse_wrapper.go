@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -686,7 +687,7 @@ func (b *BTSE) GetOrderInfo(ctx context.Context, orderID string, _ currency.Pair
 		od.Pair, err = currency.NewPairDelimiter(o[i].Symbol,
 			format.Delimiter)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s GetOrderInfo unable to parse currency pair: %s\n",
 				b.Name,
 				err)
@@ -701,7 +702,7 @@ func (b *BTSE) GetOrderInfo(ctx context.Context, orderID string, _ currency.Pair
 
 		od.Price = o[i].Price
 		if od.Status, err = order.StringToOrderStatus(o[i].OrderState); err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 		}
 
 		th, err := b.TradeHistory(ctx,
@@ -717,7 +718,7 @@ func (b *BTSE) GetOrderInfo(ctx context.Context, orderID string, _ currency.Pair
 		for i := range th {
 			createdAt, err := parseOrderTime(th[i].TradeID)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"%s GetOrderInfo unable to parse time: %s\n", b.Name, err)
 			}
 			var orderSide order.Side
@@ -843,13 +844,13 @@ func (b *BTSE) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequest
 
 			status, err := order.StringToOrderStatus(resp[i].OrderState)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 			}
 
 			p, err := currency.NewPairDelimiter(resp[i].Symbol,
 				format.Delimiter)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"%s GetActiveOrders unable to parse currency pair: %s\n",
 					b.Name,
 					err)
@@ -881,7 +882,7 @@ func (b *BTSE) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequest
 				false,
 				"", resp[i].OrderID)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"%s: Unable to get order fills for orderID %s",
 					b.Name,
 					resp[i].OrderID)
@@ -891,7 +892,7 @@ func (b *BTSE) GetActiveOrders(ctx context.Context, req *order.MultiOrderRequest
 			for i := range fills {
 				createdAt, err := parseOrderTime(fills[i].Timestamp)
 				if err != nil {
-					log.Errorf(log.ExchangeSys,
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 						"%s GetActiveOrders unable to parse time: %s\n",
 						b.Name,
 						err)
@@ -956,7 +957,7 @@ func (b *BTSE) GetOrderHistory(ctx context.Context, getOrdersRequest *order.Mult
 			}
 			orderStatus, err := order.StringToOrderStatus(currentOrder[y].OrderState)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", b.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", b.Name, err)
 			}
 			var orderSide order.Side
 			orderSide, err = order.StringToOrderSide(currentOrder[y].Side)
@@ -12,6 +12,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/sharedtestvalues"
@@ -3410,7 +3411,7 @@ func TestGetTickersV5(t *testing.T) {
 func TestUpdateOrderExecutionLimits(t *testing.T) {
 	t.Parallel()
 
-	err := b.UpdateOrderExecutionLimits(context.Background(), asset.USDCMarginedFutures)
+	err := b.UpdateOrderExecutionLimits(context.Background(), asset.Options)
 	if !errors.Is(err, asset.ErrNotSupported) {
 		t.Fatalf("received: %v expected: %v", err, asset.ErrNotSupported)
 	}
@@ -3482,3 +3483,50 @@ func TestForceFileStandard(t *testing.T) {
 		t.Fatal("Please use convert.StringToFloat64 type instead of `float64` and remove `,string` as strings can be empty in unmarshal process. Then call the Float64() method.")
 	}
 }
+
+func TestFuturesInstrument(t *testing.T) {
+	t.Parallel()
+	symbol := &SymbolInfo{Name: "BTCUSD", Status: "Trading", BaseCurrency: "BTC", QuoteCurrency: "USD"}
+	inst, ok, err := futuresInstrument(symbol, asset.CoinMarginedFutures)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v expected: %v", err, nil)
+	}
+	if !ok {
+		t.Fatal("expected coin margined instrument")
+	}
+	if inst.ContractType != instrument.Perpetual || inst.Status != instrument.Trading {
+		t.Errorf("received: %v %v expected: %v %v", inst.ContractType, inst.Status, instrument.Perpetual, instrument.Trading)
+	}
+	if !inst.SettlementCurrency.Equal(currency.BTC) {
+		t.Errorf("received: %v expected: %v", inst.SettlementCurrency, currency.BTC)
+	}
+	if inst.ContractSize != 0 {
+		t.Errorf("received: %v expected: %v", inst.ContractSize, 0)
+	}
+	if _, ok, err = futuresInstrument(symbol, asset.USDTMarginedFutures); !errors.Is(err, nil) || ok {
+		t.Errorf("received: %v %v expected: %v %v", ok, err, false, nil)
+	}
+
+	symbol = &SymbolInfo{Name: "BTCUSDZ23", Status: "Settling", BaseCurrency: "BTC", QuoteCurrency: "USD"}
+	inst, ok, err = futuresInstrument(symbol, asset.Futures)
+	if !errors.Is(err, nil) || !ok {
+		t.Fatalf("received: %v %v expected: %v %v", ok, err, true, nil)
+	}
+	if inst.ContractType != instrument.Delivery || inst.Status != instrument.Settling {
+		t.Errorf("received: %v %v expected: %v %v", inst.ContractType, inst.Status, instrument.Delivery, instrument.Settling)
+	}
+	if !inst.Expiry.IsZero() || !inst.SettlementCurrency.IsEmpty() {
+		t.Errorf("received: %v %v expected expiry and settlement to be unset", inst.Expiry, inst.SettlementCurrency)
+	}
+	if inst.Pair.Quote.String() != "Z23" {
+		t.Errorf("received: %v expected: %v", inst.Pair.Quote, "Z23")
+	}
+
+	inst, err = usdcInstrument(&USDCContract{Symbol: "BTCPERP", Status: "ONLINE", BaseCoin: "BTC", QuoteCoin: "USD"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: %v expected: %v", err, nil)
+	}
+	if inst.Status != instrument.Trading || !inst.SettlementCurrency.Equal(currency.USDC) {
+		t.Errorf("received: %v %v expected: %v %v", inst.Status, inst.SettlementCurrency, instrument.Trading, currency.USDC)
+	}
+}
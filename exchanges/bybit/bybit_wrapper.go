@@ -10,12 +10,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -318,7 +320,7 @@ func (by *Bybit) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 
 			contractSplit := strings.Split(allPairs[x].Name, allPairs[x].BaseCurrency)
 			if len(contractSplit) != 2 {
-				log.Warnf(log.ExchangeSys, "%s base currency %s cannot split contract name %s cannot add to tradable pairs",
+				log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s base currency %s cannot split contract name %s cannot add to tradable pairs",
 					by.Name,
 					allPairs[x].BaseCurrency,
 					allPairs[x].Name)
@@ -1639,7 +1641,7 @@ func (by *Bybit) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 			var side order.Side
 			side, err = order.StringToOrderSide(resp[i].Side)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", by.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", by.Name, err)
 			}
 
 			var pair currency.Pair
@@ -1756,11 +1758,11 @@ func (by *Bybit) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(resp[i].OrderType)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", by.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", by.Name, err)
 			}
 			orderStatus, err := order.StringToOrderStatus(resp[i].OrderStatus)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", by.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", by.Name, err)
 			}
 
 			var pair currency.Pair
@@ -2126,7 +2128,7 @@ func (by *Bybit) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 			var pair currency.Pair
 			pair, err = avail.DeriveFrom(pairsData[x].Name)
 			if err != nil {
-				log.Warnf(log.ExchangeSys, "%s unable to load limits for %v, pair data missing", by.Name, pairsData[x].Name)
+				log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s unable to load limits for %v, pair data missing", by.Name, pairsData[x].Name)
 				continue
 			}
 
@@ -2142,9 +2144,151 @@ func (by *Bybit) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 				PriceStepIncrementSize:  pairsData[x].MinPricePrecision.Float64(),
 			})
 		}
+	case asset.CoinMarginedFutures, asset.USDTMarginedFutures, asset.Futures, asset.USDCMarginedFutures:
+		// Contract order limits are not yet converted, the instruments are
+		// loaded without them
+		var instruments []instrument.Instrument
+		instruments, err = by.fetchContractInstruments(ctx, a)
+		if err != nil {
+			return err
+		}
+		return by.LoadInstruments(instruments)
 	default:
 		// TODO: Add in other assets
 		return fmt.Errorf("%s %w", a, asset.ErrNotSupported)
 	}
-	return by.LoadLimits(limits)
+	if err = by.LoadLimits(limits); err != nil {
+		return err
+	}
+	return by.LoadInstruments(instrument.FromLimits(limits))
+}
+
+// fetchContractInstruments returns the instruments of the futures contracts
+// listed by the exchange, matching the pairs returned by FetchTradablePairs
+func (by *Bybit) fetchContractInstruments(ctx context.Context, a asset.Item) ([]instrument.Instrument, error) {
+	if a == asset.USDCMarginedFutures {
+		contracts, err := by.GetUSDCContracts(ctx, currency.EMPTYPAIR, "", 0)
+		if err != nil {
+			return nil, err
+		}
+		instruments := make([]instrument.Instrument, 0, len(contracts))
+		for x := range contracts {
+			// dated contracts are not listed as tradable pairs
+			if contracts[x].DeliveryTime.Time().UnixMilli() > 0 {
+				continue
+			}
+			inst, err := usdcInstrument(&contracts[x])
+			if err != nil {
+				return nil, err
+			}
+			instruments = append(instruments, inst)
+		}
+		return instruments, nil
+	}
+	symbols, err := by.GetSymbolsInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	instruments := make([]instrument.Instrument, 0, len(symbols))
+	for x := range symbols {
+		inst, ok, err := futuresInstrument(&symbols[x], a)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			instruments = append(instruments, inst)
+		}
+	}
+	return instruments, nil
+}
+
+// futuresInstrument converts symbol info into an instrument of the asset,
+// returning false when the symbol is not listed under the asset. Contract
+// sizes and expiries are not returned by the endpoint and are left unset
+func futuresInstrument(symbol *SymbolInfo, a asset.Item) (instrument.Instrument, bool, error) {
+	underlying, err := currency.NewPairFromStrings(symbol.BaseCurrency, symbol.QuoteCurrency)
+	if err != nil {
+		return instrument.Instrument{}, false, err
+	}
+	inst := instrument.Instrument{
+		Asset:      a,
+		Symbol:     symbol.Name,
+		Underlying: underlying,
+		Status:     symbolStatus(symbol.Status),
+	}
+	switch a {
+	case asset.CoinMarginedFutures:
+		if symbol.QuoteCurrency != "USD" {
+			return instrument.Instrument{}, false, nil
+		}
+		contractSplit := strings.Split(symbol.Name, symbol.BaseCurrency)
+		if len(contractSplit) != 2 {
+			return instrument.Instrument{}, false, nil
+		}
+		inst.Pair, err = currency.NewPairFromStrings(symbol.BaseCurrency, contractSplit[1])
+		inst.ContractType = instrument.Perpetual
+		inst.SettlementCurrency = underlying.Base
+	case asset.USDTMarginedFutures:
+		if symbol.QuoteCurrency != "USDT" {
+			return instrument.Instrument{}, false, nil
+		}
+		inst.Pair = underlying
+		inst.ContractType = instrument.Perpetual
+		inst.SettlementCurrency = underlying.Quote
+	case asset.Futures:
+		pairSymbol := symbol.BaseCurrency + symbol.QuoteCurrency
+		filter := strings.Split(symbol.Name, pairSymbol)
+		if len(filter) != 2 || filter[1] == "" {
+			return instrument.Instrument{}, false, nil
+		}
+		inst.Pair, err = currency.NewPairFromStrings(pairSymbol, filter[1])
+		inst.ContractType = instrument.Delivery
+	default:
+		return instrument.Instrument{}, false, fmt.Errorf("%s %w", a, asset.ErrNotSupported)
+	}
+	if err != nil {
+		return instrument.Instrument{}, false, err
+	}
+	inst.MarginCurrency = inst.SettlementCurrency
+	return inst, true, nil
+}
+
+// usdcInstrument converts a USDC perpetual contract into an instrument
+func usdcInstrument(contract *USDCContract) (instrument.Instrument, error) {
+	pair, err := currency.NewPairFromStrings(contract.BaseCoin, "PERP")
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	underlying, err := currency.NewPairFromStrings(contract.BaseCoin, contract.QuoteCoin)
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	inst := instrument.Instrument{
+		Asset:              asset.USDCMarginedFutures,
+		Pair:               pair,
+		Symbol:             contract.Symbol,
+		ContractType:       instrument.Perpetual,
+		Underlying:         underlying,
+		SettlementCurrency: currency.USDC,
+		MarginCurrency:     currency.USDC,
+	}
+	if contract.Status == "ONLINE" {
+		inst.Status = instrument.Trading
+	}
+	return inst, nil
+}
+
+// symbolStatus converts a futures symbol status into an instrument status
+func symbolStatus(status string) instrument.Status {
+	switch status {
+	case "PreLaunch":
+		return instrument.PreTrading
+	case "Trading":
+		return instrument.Trading
+	case "Settling":
+		return instrument.Settling
+	case "Closed":
+		return instrument.Delisted
+	}
+	return instrument.UnknownStatus
 }
@@ -9,6 +9,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -803,7 +804,7 @@ func (c *CoinbasePro) GetActiveOrders(ctx context.Context, req *order.MultiOrder
 		var orderType order.Type
 		orderType, err = order.StringToOrderType(respOrders[i].Type)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", c.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", c.Name, err)
 		}
 		orders[i] = order.Detail{
 			OrderID:        respOrders[i].ID,
@@ -869,12 +870,12 @@ func (c *CoinbasePro) GetOrderHistory(ctx context.Context, req *order.MultiOrder
 		var orderStatus order.Status
 		orderStatus, err = order.StringToOrderStatus(respOrders[i].Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", c.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", c.Name, err)
 		}
 		var orderType order.Type
 		orderType, err = order.StringToOrderType(respOrders[i].Type)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", c.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", c.Name, err)
 		}
 		detail := order.Detail{
 			OrderID:         respOrders[i].ID,
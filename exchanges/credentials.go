@@ -7,6 +7,7 @@ import (
 	"strings"
 
 	"github.com/thrasher-corp/gocryptotrader/common/crypto"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -227,6 +228,37 @@ func (b *Base) SetCredentials(apiKey, apiSecret, clientID, subaccount, pemKey, o
 	}
 }
 
+// setupCredentials resolves any secret references in the config credentials
+// and sets them as the default credentials. Credentials which reference a
+// secret are updated when the secret is rotated
+func (b *Base) setupCredentials(creds *config.APICredentialsConfig) {
+	refs := []string{creds.Key, creds.Secret, creds.ClientID, creds.Subaccount, creds.PEMKey, creds.OTPSecret}
+	var hasReference bool
+	for i := range refs {
+		if secrets.IsReference(refs[i]) {
+			hasReference = true
+			break
+		}
+	}
+	if !hasReference {
+		secrets.Unsubscribe(b.Name)
+		b.SetCredentials(creds.Key, creds.Secret, creds.ClientID, creds.Subaccount, creds.PEMKey, creds.OTPSecret)
+		return
+	}
+
+	values, err := secrets.Subscribe(b.Name, refs, func(values []string) {
+		b.SetCredentials(values[0], values[1], values[2], values[3], values[4], values[5])
+		log.Infof(log.ExchangeSys, "%s API credentials updated from rotated secrets", b.Name)
+	})
+	if err != nil {
+		b.API.AuthenticatedSupport = false
+		b.API.AuthenticatedWebsocketSupport = false
+		log.Warnf(log.ExchangeSys, warningCredentialSecretsUnresolved, b.Name, err)
+		return
+	}
+	b.SetCredentials(values[0], values[1], values[2], values[3], values[4], values[5])
+}
+
 // SetAPICredentialDefaults sets the API Credential validator defaults
 func (b *Base) SetAPICredentialDefaults() {
 	b.API.credMu.Lock()
@@ -3,8 +3,12 @@ package exchange
 import (
 	"context"
 	"errors"
+	"os"
+	"path/filepath"
 	"testing"
+	"time"
 
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 )
@@ -395,6 +399,52 @@ func TestSetCredentials(t *testing.T) {
 	}
 }
 
+func TestSetupCredentials(t *testing.T) {
+	t.Setenv("GCT_TEST_SETUP_CREDENTIALS_KEY", "envkey")
+	path := filepath.Join(t.TempDir(), "secret")
+	err := os.WriteFile(path, []byte("filesecret\n"), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = secrets.Setup(&secrets.Config{RefreshInterval: time.Millisecond * 10})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = secrets.Start()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	defer func() {
+		if err = secrets.Stop(); !errors.Is(err, nil) {
+			t.Errorf("received '%v', expected '%v'", err, nil)
+		}
+	}()
+
+	b := Base{Name: "TestSetupCredentials", API: API{AuthenticatedSupport: true}}
+	defer secrets.Unsubscribe(b.Name)
+	b.setupCredentials(&config.APICredentialsConfig{Key: "env://GCT_TEST_SETUP_CREDENTIALS_KEY", Secret: "file://" + path, ClientID: "plain"})
+	creds := b.GetDefaultCredentials()
+	if creds.Key != "envkey" || creds.Secret != "filesecret" || creds.ClientID != "plain" {
+		t.Fatalf("received '%+v', expected resolved credentials", creds)
+	}
+
+	err = os.WriteFile(path, []byte("rotated"), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	for i := 0; i < 100 && b.GetDefaultCredentials().Secret != "rotated"; i++ {
+		time.Sleep(time.Millisecond * 10)
+	}
+	if secret := b.GetDefaultCredentials().Secret; secret != "rotated" {
+		t.Errorf("received '%v', expected '%v'", secret, "rotated")
+	}
+
+	b.setupCredentials(&config.APICredentialsConfig{Key: "env://GCT_TEST_SETUP_CREDENTIALS_MISSING"})
+	if b.API.AuthenticatedSupport {
+		t.Error("unresolved secrets should disable authenticated API support")
+	}
+}
+
 func TestGetDefaultCredentials(t *testing.T) {
 	var b Base
 	if b.GetDefaultCredentials() != nil {
@@ -12,12 +12,14 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/common/secrets"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/currencystate"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/margin"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
@@ -30,7 +32,8 @@ import (
 )
 
 const (
-	warningBase64DecryptSecretKeyFailed = "exchange %s unable to base64 decode secret key.. Disabling Authenticated API support" //nolint // False positive (G101: Potential hardcoded credentials)
+	warningBase64DecryptSecretKeyFailed = "exchange %s unable to base64 decode secret key.. Disabling Authenticated API support"          //nolint // False positive (G101: Potential hardcoded credentials)
+	warningCredentialSecretsUnresolved  = "exchange %s unable to resolve API credential secrets: %v. Disabling Authenticated API support" //nolint // False positive (G101: Potential hardcoded credentials)
 	// DefaultHTTPTimeout is the default HTTP/HTTPS Timeout for exchange requests
 	DefaultHTTPTimeout = time.Second * 15
 	// DefaultWebsocketResponseCheckTimeout is the default delay in checking for an expected websocket response
@@ -546,13 +549,7 @@ func (b *Base) SetupDefaults(exch *config.Exchange) error {
 	}
 	b.API.credentials.SubAccount = exch.API.Credentials.Subaccount
 	if b.API.AuthenticatedSupport || b.API.AuthenticatedWebsocketSupport {
-		b.SetCredentials(exch.API.Credentials.Key,
-			exch.API.Credentials.Secret,
-			exch.API.Credentials.ClientID,
-			exch.API.Credentials.Subaccount,
-			exch.API.Credentials.PEMKey,
-			exch.API.Credentials.OTPSecret,
-		)
+		b.setupCredentials(&exch.API.Credentials)
 	}
 
 	if exch.HTTPTimeout <= time.Duration(0) {
@@ -1418,6 +1415,27 @@ func (b *Base) UpdateOrderExecutionLimits(_ context.Context, _ asset.Item) error
 	return common.ErrNotYetImplemented
 }
 
+// LoadInstruments loads instrument specifications into the instrument
+// registry. Instruments supplied without limits use the order execution
+// limits already loaded for their asset and pair
+func (b *Base) LoadInstruments(instruments []instrument.Instrument) error {
+	for x := range instruments {
+		if !instruments[x].Limits.Pair.IsEmpty() {
+			continue
+		}
+		limits, err := b.GetOrderExecutionLimits(instruments[x].Asset, instruments[x].Pair)
+		if err == nil {
+			instruments[x].Limits = limits
+		}
+	}
+	return instrument.Load(b.Name, instruments)
+}
+
+// GetInstrument returns the instrument specification for an asset and pair
+func (b *Base) GetInstrument(a asset.Item, cp currency.Pair) (instrument.Instrument, error) {
+	return instrument.Get(b.Name, a, cp)
+}
+
 // DisableAssetWebsocketSupport disables websocket functionality for the
 // supplied asset item. In the case that websocket functionality has not yet
 // been implemented for that specific asset type. This is a base method to
@@ -1651,6 +1669,7 @@ func (b *Base) GetKlineExtendedRequest(pair currency.Pair, a asset.Item, interva
 // Shutdown closes active websocket connections if available and then cleans up
 // a REST requester instance.
 func (b *Base) Shutdown() error {
+	secrets.Unsubscribe(b.Name)
 	if b.Websocket != nil {
 		err := b.Websocket.Shutdown()
 		if err != nil && !errors.Is(err, stream.ErrNotConnected) {
@@ -12,7 +12,9 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
@@ -2458,6 +2460,31 @@ func TestUpdateOrderExecutionLimits(t *testing.T) {
 	}
 }
 
+func TestLoadInstruments(t *testing.T) {
+	t.Parallel()
+	b := Base{Name: "LoadInstrumentsTest"}
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	err := b.LoadLimits([]order.MinMaxLevel{{Asset: asset.Spot, Pair: pair, MinimumBaseAmount: 0.001}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = b.LoadInstruments([]instrument.Instrument{{Asset: asset.Spot, Pair: pair, ContractType: instrument.Spot}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	inst, err := b.GetInstrument(asset.Spot, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if inst.Limits.MinimumBaseAmount != 0.001 {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Limits.MinimumBaseAmount, 0.001)
+	}
+	_, err = b.GetInstrument(asset.Spot, currency.NewPair(currency.ETH, currency.USDT))
+	if !errors.Is(err, instrument.ErrInstrumentNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, instrument.ErrInstrumentNotFound)
+	}
+}
+
 func TestSetTradeFeedStatus(t *testing.T) {
 	t.Parallel()
 	b := Base{
@@ -16,6 +16,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
@@ -3346,7 +3347,7 @@ func TestUpdateOrderExecutionLimits(t *testing.T) {
 		t.Fatalf("received %v, expected %v", err, asset.ErrNotSupported)
 	}
 
-	err = g.UpdateOrderExecutionLimits(context.Background(), asset.Options)
+	err = g.UpdateOrderExecutionLimits(context.Background(), asset.Margin)
 	if !errors.Is(err, common.ErrNotYetImplemented) {
 		t.Fatalf("received %v, expected %v", err, common.ErrNotYetImplemented)
 	}
@@ -3386,3 +3387,73 @@ func TestUpdateOrderExecutionLimits(t *testing.T) {
 		}
 	}
 }
+
+func TestContractInstruments(t *testing.T) {
+	t.Parallel()
+	var perp FuturesContract
+	err := json.Unmarshal([]byte(`{"name":"BTC_USDT","type":"direct","quanto_multiplier":"0.0001","in_delisting":false}`), &perp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err := futuresInstrument(&perp, settleUSDT)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v, expected %v", err, nil)
+	}
+	if inst.ContractType != instrument.Perpetual || inst.Status != instrument.Trading {
+		t.Errorf("received %v %v, expected %v %v", inst.ContractType, inst.Status, instrument.Perpetual, instrument.Trading)
+	}
+	if inst.ContractSize != 0.0001 || !inst.ContractSizeCurrency.Equal(currency.BTC) {
+		t.Errorf("received %v %v, expected %v %v", inst.ContractSize, inst.ContractSizeCurrency, 0.0001, currency.BTC)
+	}
+	if !inst.SettlementCurrency.Equal(currency.USDT) || !inst.MarginCurrency.Equal(currency.USDT) {
+		t.Errorf("received %v %v, expected %v", inst.SettlementCurrency, inst.MarginCurrency, currency.USDT)
+	}
+
+	var inverse FuturesContract
+	err = json.Unmarshal([]byte(`{"name":"BTC_USD","type":"inverse","quanto_multiplier":"0","in_delisting":true}`), &inverse)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err = futuresInstrument(&inverse, settleBTC)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v, expected %v", err, nil)
+	}
+	if inst.ContractSize != 0 || !inst.ContractSizeCurrency.IsEmpty() {
+		t.Errorf("received %v %v, expected contract size to be unset", inst.ContractSize, inst.ContractSizeCurrency)
+	}
+	if inst.Status != instrument.Delisted {
+		t.Errorf("received %v, expected %v", inst.Status, instrument.Delisted)
+	}
+
+	var delivery DeliveryContract
+	err = json.Unmarshal([]byte(`{"name":"BTC_USDT_20200814","underlying":"BTC_USDT","quanto_multiplier":"0.0001","expire_time":1597392000}`), &delivery)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err = deliveryInstrument(&delivery, settleUSDT)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v, expected %v", err, nil)
+	}
+	if !inst.Underlying.Equal(currency.NewPair(currency.BTC, currency.USDT)) {
+		t.Errorf("received %v, expected %v", inst.Underlying, currency.NewPair(currency.BTC, currency.USDT))
+	}
+	if expiry := time.Unix(1597392000, 0); !inst.Expiry.Equal(expiry) {
+		t.Errorf("received %v, expected %v", inst.Expiry, expiry)
+	}
+
+	var option OptionContract
+	err = json.Unmarshal([]byte(`{"name":"BTC_USDT-20211130-65000-C","underlying":"BTC_USDT","multiplier":"0.0001","create_time":1636702700,"expiration_time":1638259200}`), &option)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err = optionInstrument(&option)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received %v, expected %v", err, nil)
+	}
+	if inst.Status != instrument.UnknownStatus || !inst.SettlementCurrency.IsEmpty() {
+		t.Errorf("received %v %v, expected status and settlement to be unset", inst.Status, inst.SettlementCurrency)
+	}
+	if inst.ContractSize != 0.0001 || inst.ListingTime.IsZero() || inst.Expiry.IsZero() {
+		t.Errorf("received %+v, expected contract size, listing and expiry", inst)
+	}
+}
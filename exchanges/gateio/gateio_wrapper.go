@@ -12,12 +12,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -519,11 +521,7 @@ func (g *Gateio) FetchTradablePairs(ctx context.Context, a asset.Item) (currency
 				if !g.IsValidPairString(contracts[c].Name) {
 					continue
 				}
-				cp, err := currency.NewPairFromString(strings.ReplaceAll(contracts[c].Name, currency.DashDelimiter, currency.UnderscoreDelimiter))
-				if err != nil {
-					return nil, err
-				}
-				cp.Quote = currency.NewCode(strings.ReplaceAll(cp.Quote.String(), currency.UnderscoreDelimiter, currency.DashDelimiter))
+				cp, err := optionContractPair(contracts[c].Name)
 				if err != nil {
 					return nil, err
 				}
@@ -1597,7 +1595,7 @@ func (g *Gateio) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 				var side order.Side
 				side, err = order.StringToOrderSide(spotOrders[x].Orders[x].Side)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", g.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", g.Name, err)
 				}
 				var oType order.Type
 				oType, err = order.StringToOrderType(spotOrders[x].Orders[y].Type)
@@ -1607,7 +1605,7 @@ func (g *Gateio) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 				var status order.Status
 				status, err = order.StringToOrderStatus(spotOrders[x].Orders[y].Status)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", g.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", g.Name, err)
 				}
 				orders = append(orders, order.Detail{
 					Side:                 side,
@@ -1659,7 +1657,7 @@ func (g *Gateio) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 				var status order.Status
 				status, err = order.StringToOrderStatus(futuresOrders[x].Status)
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", g.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", g.Name, err)
 				}
 				orders = append(orders, order.Detail{
 					Status:          status,
@@ -1998,6 +1996,7 @@ func (g *Gateio) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 	}
 
 	var limits []order.MinMaxLevel
+	var instruments []instrument.Instrument
 	switch a {
 	case asset.Spot:
 		var pairsData []CurrencyPairDetail
@@ -2007,6 +2006,7 @@ func (g *Gateio) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 		}
 
 		limits = make([]order.MinMaxLevel, 0, len(pairsData))
+		instruments = make([]instrument.Instrument, 0, len(pairsData))
 		for x := range pairsData {
 			if pairsData[x].TradeStatus == "untradable" {
 				continue
@@ -2032,11 +2032,214 @@ func (g *Gateio) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 				MinimumBaseAmount:       minBaseAmount,
 				MinimumQuoteAmount:      pairsData[x].MinQuoteAmount.Float64(),
 			})
+			instruments = append(instruments, spotInstrument(&pairsData[x], pair, limits[len(limits)-1]))
+		}
+	case asset.Futures, asset.DeliveryFutures, asset.Options:
+		// Contract order limits are not yet converted, the instruments are
+		// loaded without them
+		instruments, err = g.fetchContractInstruments(ctx, a)
+		if err != nil {
+			return err
 		}
+		return g.LoadInstruments(instruments)
 	default:
 		// TODO: Add in other assets
 		return fmt.Errorf("%s %w", a, common.ErrNotYetImplemented)
 	}
 
-	return g.LoadLimits(limits)
+	if err = g.LoadLimits(limits); err != nil {
+		return err
+	}
+	return g.LoadInstruments(instruments)
+}
+
+// spotInstrument converts spot pair details into an instrument
+func spotInstrument(detail *CurrencyPairDetail, pair currency.Pair, limits order.MinMaxLevel) instrument.Instrument {
+	inst := instrument.Instrument{
+		Asset:              asset.Spot,
+		Pair:               pair,
+		Symbol:             detail.ID,
+		ContractType:       instrument.Spot,
+		Underlying:         pair,
+		SettlementCurrency: pair.Quote,
+		Limits:             limits,
+	}
+	if detail.TradeStatus == "tradable" {
+		inst.Status = instrument.Trading
+	}
+	if detail.BuyStart > 0 {
+		inst.ListingTime = time.Unix(int64(detail.BuyStart), 0).UTC()
+	}
+	return inst
+}
+
+// fetchContractInstruments returns the instruments of the futures, delivery
+// or options contracts listed by the exchange
+func (g *Gateio) fetchContractInstruments(ctx context.Context, a asset.Item) ([]instrument.Instrument, error) {
+	var instruments []instrument.Instrument
+	switch a {
+	case asset.Futures:
+		for _, settle := range []string{settleBTC, settleUSDT} {
+			contracts, err := g.GetAllFutureContracts(ctx, settle)
+			if err != nil {
+				return nil, err
+			}
+			for x := range contracts {
+				inst, err := futuresInstrument(&contracts[x], settle)
+				if err != nil {
+					return nil, err
+				}
+				instruments = append(instruments, inst)
+			}
+		}
+	case asset.DeliveryFutures:
+		for _, settle := range []string{settleBTC, settleUSDT} {
+			contracts, err := g.GetAllDeliveryContracts(ctx, settle)
+			if err != nil {
+				return nil, err
+			}
+			for x := range contracts {
+				inst, err := deliveryInstrument(&contracts[x], settle)
+				if err != nil {
+					return nil, err
+				}
+				instruments = append(instruments, inst)
+			}
+		}
+	case asset.Options:
+		underlyings, err := g.GetAllOptionsUnderlyings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		for x := range underlyings {
+			contracts, err := g.GetAllContractOfUnderlyingWithinExpiryDate(ctx, underlyings[x].Name, time.Time{})
+			if err != nil {
+				return nil, err
+			}
+			for c := range contracts {
+				if !g.IsValidPairString(contracts[c].Name) {
+					continue
+				}
+				inst, err := optionInstrument(&contracts[c])
+				if err != nil {
+					return nil, err
+				}
+				instruments = append(instruments, inst)
+			}
+		}
+	default:
+		return nil, fmt.Errorf("%w asset type: %v", asset.ErrNotSupported, a)
+	}
+	return instruments, nil
+}
+
+// futuresInstrument converts a perpetual futures contract into an
+// instrument. Contracts are named by their underlying pair and the quanto
+// multiplier is the base amount of one contract, it is unset for inverse
+// contracts
+func futuresInstrument(contract *FuturesContract, settle string) (instrument.Instrument, error) {
+	pair, err := currency.NewPairFromString(strings.ToUpper(contract.Name))
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	inst := instrument.Instrument{
+		Asset:              asset.Futures,
+		Pair:               pair,
+		Symbol:             contract.Name,
+		ContractType:       instrument.Perpetual,
+		Underlying:         pair,
+		SettlementCurrency: currency.NewCode(settle),
+		MarginCurrency:     currency.NewCode(settle),
+		Status:             contractStatus(contract.InDelisting),
+	}
+	if contract.QuantoMultiplier > 0 {
+		inst.ContractSize = contract.QuantoMultiplier
+		inst.ContractSizeCurrency = pair.Base
+	}
+	return inst, nil
+}
+
+// deliveryInstrument converts a delivery futures contract into an instrument
+func deliveryInstrument(contract *DeliveryContract, settle string) (instrument.Instrument, error) {
+	pair, err := currency.NewPairFromString(strings.ToUpper(contract.Name))
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	underlying, err := currency.NewPairFromString(strings.ToUpper(contract.Underlying))
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	inst := instrument.Instrument{
+		Asset:              asset.DeliveryFutures,
+		Pair:               pair,
+		Symbol:             contract.Name,
+		ContractType:       instrument.Delivery,
+		Underlying:         underlying,
+		SettlementCurrency: currency.NewCode(settle),
+		MarginCurrency:     currency.NewCode(settle),
+		Expiry:             contract.ExpireTime.Time(),
+		Status:             contractStatus(contract.InDelisting),
+	}
+	if contract.QuantoMultiplier != "" {
+		inst.ContractSize, err = strconv.ParseFloat(contract.QuantoMultiplier, 64)
+		if err != nil {
+			return instrument.Instrument{}, err
+		}
+		if inst.ContractSize > 0 {
+			inst.ContractSizeCurrency = underlying.Base
+		}
+	}
+	return inst, nil
+}
+
+// optionInstrument converts an options contract into an instrument, the
+// multiplier is the underlying base amount of one contract
+func optionInstrument(contract *OptionContract) (instrument.Instrument, error) {
+	pair, err := optionContractPair(contract.Name)
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	underlying, err := currency.NewPairFromString(strings.ToUpper(contract.Underlying))
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	inst := instrument.Instrument{
+		Asset:        asset.Options,
+		Pair:         pair,
+		Symbol:       contract.Name,
+		ContractType: instrument.Option,
+		Underlying:   underlying,
+		Expiry:       contract.ExpirationTime.Time(),
+		ListingTime:  contract.CreateTime.Time(),
+	}
+	if contract.Multiplier != "" {
+		inst.ContractSize, err = strconv.ParseFloat(contract.Multiplier, 64)
+		if err != nil {
+			return instrument.Instrument{}, err
+		}
+		if inst.ContractSize > 0 {
+			inst.ContractSizeCurrency = underlying.Base
+		}
+	}
+	return inst, nil
+}
+
+// optionContractPair converts an options contract name such as
+// BTC_USDT-20211130-65000-C into its tradable pair
+func optionContractPair(name string) (currency.Pair, error) {
+	cp, err := currency.NewPairFromString(strings.ReplaceAll(name, currency.DashDelimiter, currency.UnderscoreDelimiter))
+	if err != nil {
+		return currency.EMPTYPAIR, err
+	}
+	cp.Quote = currency.NewCode(strings.ReplaceAll(cp.Quote.String(), currency.UnderscoreDelimiter, currency.DashDelimiter))
+	return cp, nil
+}
+
+// contractStatus returns the status of a listed contract, contracts which are
+// being delisted only accept reducing orders
+func contractStatus(inDelisting bool) instrument.Status {
+	if inDelisting {
+		return instrument.Delisted
+	}
+	return instrument.Trading
 }
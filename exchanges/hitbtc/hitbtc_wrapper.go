@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -853,12 +854,12 @@ func (h *HitBTC) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 		var side order.Side
 		side, err = order.StringToOrderSide(allOrders[i].Side)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", h.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", h.Name, err)
 		}
 		var status order.Status
 		status, err = order.StringToOrderStatus(allOrders[i].Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", h.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", h.Name, err)
 		}
 		detail := order.Detail{
 			OrderID:              allOrders[i].ID,
@@ -0,0 +1,362 @@
+package instrument
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/log"
+)
+
+var service *Service
+
+func init() {
+	service = newService()
+}
+
+func newService() *Service {
+	return &Service{
+		m:   make(map[string]map[asset.Item]map[*currency.Item]map[*currency.Item]*Instrument),
+		ids: make(map[string]uuid.UUID),
+		mux: dispatch.GetNewMux(nil),
+	}
+}
+
+// String implements the stringer interface
+func (c ContractType) String() string {
+	switch c {
+	case Spot:
+		return "spot"
+	case Perpetual:
+		return "perpetual"
+	case Delivery:
+		return "delivery"
+	case Option:
+		return "option"
+	default:
+		return "unknown"
+	}
+}
+
+// NewContractType returns the contract type matching the string, an empty
+// string returns UnknownContract
+func NewContractType(s string) (ContractType, error) {
+	for _, c := range []ContractType{UnknownContract, Spot, Perpetual, Delivery, Option} {
+		if s == "" || strings.EqualFold(s, c.String()) {
+			return c, nil
+		}
+	}
+	return UnknownContract, fmt.Errorf("%w contract type %q", errInvalidContract, s)
+}
+
+// String implements the stringer interface
+func (s Status) String() string {
+	switch s {
+	case PreTrading:
+		return "pretrading"
+	case Trading:
+		return "trading"
+	case Halted:
+		return "halted"
+	case Settling:
+		return "settling"
+	case Delisted:
+		return "delisted"
+	default:
+		return "unknown"
+	}
+}
+
+// NewStatus returns the status matching the string, an empty string returns
+// UnknownStatus
+func NewStatus(s string) (Status, error) {
+	for _, st := range []Status{UnknownStatus, PreTrading, Trading, Halted, Settling, Delisted} {
+		if s == "" || strings.EqualFold(s, st.String()) {
+			return st, nil
+		}
+	}
+	return UnknownStatus, fmt.Errorf("%w status %q", errInvalidContract, s)
+}
+
+// String implements the stringer interface
+func (c ChangeType) String() string {
+	switch c {
+	case Added:
+		return "added"
+	case Updated:
+		return "updated"
+	default:
+		return "unknown"
+	}
+}
+
+// IsInverse returns whether the contract is settled in the base currency of
+// its underlying, such as coin margined futures
+func (i *Instrument) IsInverse() bool {
+	underlying := i.Underlying
+	if underlying.IsEmpty() {
+		underlying = i.Pair
+	}
+	return i.ContractType != Spot &&
+		!i.SettlementCurrency.IsEmpty() &&
+		i.SettlementCurrency.Equal(underlying.Base)
+}
+
+// Validate checks the instrument specification can be loaded
+func (i *Instrument) Validate() error {
+	if !i.Asset.IsValid() {
+		return fmt.Errorf("%w %v", asset.ErrNotSupported, i.Asset)
+	}
+	if i.Pair.IsEmpty() {
+		return currency.ErrCurrencyPairEmpty
+	}
+	if i.ContractSize < 0 || i.Multiplier < 0 {
+		return fmt.Errorf("%w %v %v contract size %v multiplier %v",
+			errInvalidContract, i.Asset, i.Pair, i.ContractSize, i.Multiplier)
+	}
+	if !i.Expiry.IsZero() && (i.ContractType == Spot || i.ContractType == Perpetual) {
+		return fmt.Errorf("%w %v %v %v contract cannot expire",
+			errInvalidContract, i.Asset, i.Pair, i.ContractType)
+	}
+	return nil
+}
+
+// equal returns whether two instruments share the same specification,
+// ignoring when they were last updated
+func (i *Instrument) equal(o *Instrument) bool {
+	if !i.Expiry.Equal(o.Expiry) || !i.ListingTime.Equal(o.ListingTime) {
+		return false
+	}
+	a, b := *i, *o
+	a.Expiry, a.ListingTime, a.LastUpdated = time.Time{}, time.Time{}, time.Time{}
+	b.Expiry, b.ListingTime, b.LastUpdated = time.Time{}, time.Time{}, time.Time{}
+	return a == b
+}
+
+// FromLimits builds instruments from order execution limits for exchanges
+// which do not expose further contract details. Only what the asset implies
+// is set, the contract type and for spot or margined futures the settlement
+// currency. Status, contract size and expiry are left unknown
+func FromLimits(levels []order.MinMaxLevel) []Instrument {
+	resp := make([]Instrument, len(levels))
+	for x := range levels {
+		resp[x] = Instrument{
+			Asset:  levels[x].Asset,
+			Pair:   levels[x].Pair,
+			Limits: levels[x],
+		}
+		switch {
+		case levels[x].Asset == asset.Options:
+			resp[x].ContractType = Option
+		case levels[x].Asset == asset.PerpetualContract || levels[x].Asset == asset.PerpetualSwap:
+			resp[x].ContractType = Perpetual
+		case levels[x].Asset == asset.DeliveryFutures:
+			resp[x].ContractType = Delivery
+		case !levels[x].Asset.IsFutures():
+			resp[x].ContractType = Spot
+			resp[x].Underlying = levels[x].Pair
+			resp[x].SettlementCurrency = levels[x].Pair.Quote
+		}
+		switch levels[x].Asset {
+		case asset.CoinMarginedFutures:
+			resp[x].SettlementCurrency = levels[x].Pair.Base
+		case asset.USDTMarginedFutures, asset.USDCMarginedFutures:
+			resp[x].SettlementCurrency = levels[x].Pair.Quote
+		}
+	}
+	return resp
+}
+
+// Load stores the instruments of an exchange, publishing an update for every
+// instrument which is new or has changed
+func Load(exchangeName string, instruments []Instrument) error {
+	return service.load(exchangeName, instruments)
+}
+
+// Get returns the instrument of an exchange for an asset and pair
+func Get(exchangeName string, a asset.Item, p currency.Pair) (Instrument, error) {
+	return service.get(exchangeName, a, p)
+}
+
+// GetInstruments returns the instruments matching the filter ordered by
+// exchange, asset and pair
+func GetInstruments(f Filter) ([]Instrument, error) {
+	return service.getInstruments(f)
+}
+
+// Subscribe returns a pipe which receives an *Update every time an instrument
+// of the exchange is added or changes. An empty exchange name subscribes to
+// every exchange
+func Subscribe(exchangeName string) (dispatch.Pipe, error) {
+	return service.subscribe(exchangeName)
+}
+
+func (s *Service) load(exchangeName string, instruments []Instrument) error {
+	if exchangeName == "" {
+		return errExchangeNameUnset
+	}
+	if len(instruments) == 0 {
+		return errNoInstruments
+	}
+	for x := range instruments {
+		if err := instruments[x].Validate(); err != nil {
+			return fmt.Errorf("%s %w", exchangeName, err)
+		}
+	}
+	key := strings.ToLower(exchangeName)
+	now := time.Now()
+
+	s.mtx.Lock()
+	m1, ok := s.m[key]
+	if !ok {
+		m1 = make(map[asset.Item]map[*currency.Item]map[*currency.Item]*Instrument)
+		s.m[key] = m1
+	}
+	var updates []*Update
+	for x := range instruments {
+		inst := instruments[x]
+		inst.Exchange = exchangeName
+		if inst.Underlying.IsEmpty() && inst.ContractType == Spot {
+			inst.Underlying = inst.Pair
+		}
+		if inst.LastUpdated.IsZero() {
+			inst.LastUpdated = now
+		}
+		m2, ok := m1[inst.Asset]
+		if !ok {
+			m2 = make(map[*currency.Item]map[*currency.Item]*Instrument)
+			m1[inst.Asset] = m2
+		}
+		m3, ok := m2[inst.Pair.Base.Item]
+		if !ok {
+			m3 = make(map[*currency.Item]*Instrument)
+			m2[inst.Pair.Base.Item] = m3
+		}
+		existing, ok := m3[inst.Pair.Quote.Item]
+		switch {
+		case !ok:
+			updates = append(updates, &Update{Change: Added, Instrument: inst})
+		case !existing.equal(&inst):
+			updates = append(updates, &Update{Change: Updated, Instrument: inst})
+		}
+		m3[inst.Pair.Quote.Item] = &inst
+	}
+	var ids []uuid.UUID
+	if id, ok := s.ids[key]; ok {
+		ids = append(ids, id)
+	}
+	if !s.allID.IsNil() {
+		ids = append(ids, s.allID)
+	}
+	s.mtx.Unlock()
+
+	if len(ids) == 0 {
+		return nil
+	}
+	for x := range updates {
+		if err := s.mux.Publish(updates[x], ids...); err != nil {
+			log.Errorf(log.ExchangeSys, "%s unable to publish %s %s instrument update: %v",
+				exchangeName, updates[x].Instrument.Asset, updates[x].Instrument.Pair, err)
+		}
+	}
+	return nil
+}
+
+func (s *Service) get(exchangeName string, a asset.Item, p currency.Pair) (Instrument, error) {
+	if exchangeName == "" {
+		return Instrument{}, errExchangeNameUnset
+	}
+	if p.IsEmpty() {
+		return Instrument{}, currency.ErrCurrencyPairEmpty
+	}
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	m1, ok := s.m[strings.ToLower(exchangeName)]
+	if !ok {
+		return Instrument{}, fmt.Errorf("%s %w", exchangeName, ErrNoInstrumentsLoaded)
+	}
+	inst, ok := m1[a][p.Base.Item][p.Quote.Item]
+	if !ok {
+		return Instrument{}, fmt.Errorf("%s %s %s %w", exchangeName, a, p, ErrInstrumentNotFound)
+	}
+	return *inst, nil
+}
+
+func (s *Service) getInstruments(f Filter) ([]Instrument, error) {
+	s.mtx.RLock()
+	if _, ok := s.m[strings.ToLower(f.Exchange)]; f.Exchange != "" && !ok {
+		s.mtx.RUnlock()
+		return nil, fmt.Errorf("%s %w", f.Exchange, ErrNoInstrumentsLoaded)
+	}
+	var resp []Instrument
+	for exch, m1 := range s.m {
+		if f.Exchange != "" && !strings.EqualFold(f.Exchange, exch) {
+			continue
+		}
+		for a, m2 := range m1 {
+			if f.Asset != asset.Empty && f.Asset != a {
+				continue
+			}
+			for base, m3 := range m2 {
+				if !f.Base.IsEmpty() && f.Base.Item != base {
+					continue
+				}
+				for quote, inst := range m3 {
+					if !f.Quote.IsEmpty() && f.Quote.Item != quote {
+						continue
+					}
+					if f.ContractType != UnknownContract && f.ContractType != inst.ContractType {
+						continue
+					}
+					if f.Status != UnknownStatus && f.Status != inst.Status {
+						continue
+					}
+					resp = append(resp, *inst)
+				}
+			}
+		}
+	}
+	s.mtx.RUnlock()
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if resp[i].Asset != resp[j].Asset {
+			return resp[i].Asset < resp[j].Asset
+		}
+		return resp[i].Pair.String() < resp[j].Pair.String()
+	})
+	return resp, nil
+}
+
+func (s *Service) subscribe(exchangeName string) (dispatch.Pipe, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if exchangeName == "" {
+		if s.allID.IsNil() {
+			id, err := s.mux.GetID()
+			if err != nil {
+				return dispatch.Pipe{}, err
+			}
+			s.allID = id
+		}
+		return s.mux.Subscribe(s.allID)
+	}
+	key := strings.ToLower(exchangeName)
+	id, ok := s.ids[key]
+	if !ok {
+		var err error
+		id, err = s.mux.GetID()
+		if err != nil {
+			return dispatch.Pipe{}, err
+		}
+		s.ids[key] = id
+	}
+	return s.mux.Subscribe(id)
+}
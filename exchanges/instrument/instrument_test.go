@@ -0,0 +1,304 @@
+package instrument
+
+import (
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+func TestMain(m *testing.M) {
+	err := dispatch.Start(1, dispatch.DefaultJobsLimit)
+	if err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestNewContractType(t *testing.T) {
+	t.Parallel()
+	for _, c := range []ContractType{UnknownContract, Spot, Perpetual, Delivery, Option} {
+		resp, err := NewContractType(c.String())
+		if !errors.Is(err, nil) {
+			t.Errorf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if resp != c {
+			t.Errorf("received: '%v' but expected: '%v'", resp, c)
+		}
+	}
+	resp, err := NewContractType("")
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if resp != UnknownContract {
+		t.Errorf("received: '%v' but expected: '%v'", resp, UnknownContract)
+	}
+	_, err = NewContractType("swap")
+	if !errors.Is(err, errInvalidContract) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidContract)
+	}
+}
+
+func TestNewStatus(t *testing.T) {
+	t.Parallel()
+	for _, s := range []Status{UnknownStatus, PreTrading, Trading, Halted, Settling, Delisted} {
+		resp, err := NewStatus(s.String())
+		if !errors.Is(err, nil) {
+			t.Errorf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if resp != s {
+			t.Errorf("received: '%v' but expected: '%v'", resp, s)
+		}
+	}
+	_, err := NewStatus("live")
+	if !errors.Is(err, errInvalidContract) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidContract)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	i := Instrument{}
+	if err := i.Validate(); !errors.Is(err, asset.ErrNotSupported) {
+		t.Errorf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+	i.Asset = asset.Futures
+	if err := i.Validate(); !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Errorf("received: '%v' but expected: '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
+	i.Pair = currency.NewPair(currency.BTC, currency.USD)
+	i.ContractSize = -1
+	if err := i.Validate(); !errors.Is(err, errInvalidContract) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidContract)
+	}
+	i.ContractSize = 100
+	i.ContractType = Perpetual
+	i.Expiry = time.Date(2023, 9, 29, 8, 0, 0, 0, time.UTC)
+	if err := i.Validate(); !errors.Is(err, errInvalidContract) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidContract)
+	}
+	i.ContractType = Delivery
+	if err := i.Validate(); !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestIsInverse(t *testing.T) {
+	t.Parallel()
+	i := Instrument{
+		Pair:               currency.NewPair(currency.BTC, currency.USD),
+		ContractType:       Perpetual,
+		SettlementCurrency: currency.BTC,
+	}
+	if !i.IsInverse() {
+		t.Error("expected inverse contract")
+	}
+	i.SettlementCurrency = currency.USD
+	if i.IsInverse() {
+		t.Error("expected linear contract")
+	}
+}
+
+func TestFromLimits(t *testing.T) {
+	t.Parallel()
+	pair := currency.NewPair(currency.BTC, currency.USD)
+	instruments := FromLimits([]order.MinMaxLevel{
+		{Asset: asset.Spot, Pair: pair, MinimumBaseAmount: 0.001},
+		{Asset: asset.PerpetualSwap, Pair: pair},
+		{Asset: asset.CoinMarginedFutures, Pair: pair},
+		{Asset: asset.Options, Pair: pair},
+	})
+	if len(instruments) != 4 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(instruments), 4)
+	}
+	for i, expected := range []ContractType{Spot, Perpetual, UnknownContract, Option} {
+		if instruments[i].ContractType != expected {
+			t.Errorf("received: '%v' but expected: '%v'", instruments[i].ContractType, expected)
+		}
+		if instruments[i].Status != UnknownStatus {
+			t.Errorf("received: '%v' but expected: '%v'", instruments[i].Status, UnknownStatus)
+		}
+	}
+	if !instruments[0].Underlying.Equal(pair) {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[0].Underlying, pair)
+	}
+	if !instruments[1].Underlying.IsEmpty() {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[1].Underlying, currency.EMPTYPAIR)
+	}
+	if !instruments[1].SettlementCurrency.IsEmpty() {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[1].SettlementCurrency, currency.EMPTYCODE)
+	}
+	if instruments[0].Limits.MinimumBaseAmount != 0.001 {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[0].Limits.MinimumBaseAmount, 0.001)
+	}
+	if !instruments[0].SettlementCurrency.Equal(currency.USD) {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[0].SettlementCurrency, currency.USD)
+	}
+	if !instruments[2].SettlementCurrency.Equal(currency.BTC) {
+		t.Errorf("received: '%v' but expected: '%v'", instruments[2].SettlementCurrency, currency.BTC)
+	}
+}
+
+func TestLoadAndGet(t *testing.T) {
+	t.Parallel()
+	s := newService()
+	err := s.load("", nil)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	err = s.load("LoadTest", nil)
+	if !errors.Is(err, errNoInstruments) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoInstruments)
+	}
+	err = s.load("LoadTest", []Instrument{{}})
+	if !errors.Is(err, asset.ErrNotSupported) {
+		t.Errorf("received: '%v' but expected: '%v'", err, asset.ErrNotSupported)
+	}
+
+	pair := currency.NewPair(currency.BTC, currency.NewCode("230929"))
+	_, err = s.get("LoadTest", asset.Futures, pair)
+	if !errors.Is(err, ErrNoInstrumentsLoaded) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrNoInstrumentsLoaded)
+	}
+	expiry := time.Date(2023, 9, 29, 8, 0, 0, 0, time.UTC)
+	err = s.load("LoadTest", []Instrument{{
+		Asset:              asset.Futures,
+		Pair:               pair,
+		ContractType:       Delivery,
+		ContractSize:       100,
+		SettlementCurrency: currency.BTC,
+		Expiry:             expiry,
+		Status:             Trading,
+	}})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.get("", asset.Futures, pair)
+	if !errors.Is(err, errExchangeNameUnset) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errExchangeNameUnset)
+	}
+	_, err = s.get("LoadTest", asset.Futures, currency.EMPTYPAIR)
+	if !errors.Is(err, currency.ErrCurrencyPairEmpty) {
+		t.Errorf("received: '%v' but expected: '%v'", err, currency.ErrCurrencyPairEmpty)
+	}
+	_, err = s.get("LoadTest", asset.Spot, pair)
+	if !errors.Is(err, ErrInstrumentNotFound) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrInstrumentNotFound)
+	}
+	inst, err := s.get("loadtest", asset.Futures, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if inst.Exchange != "LoadTest" {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Exchange, "LoadTest")
+	}
+	if !inst.Underlying.IsEmpty() {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Underlying, currency.EMPTYPAIR)
+	}
+	if !inst.Expiry.Equal(expiry) {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Expiry, expiry)
+	}
+	if inst.LastUpdated.IsZero() {
+		t.Error("expected last updated to be set")
+	}
+}
+
+func TestGetInstruments(t *testing.T) {
+	t.Parallel()
+	s := newService()
+	_, err := s.getInstruments(Filter{Exchange: "GetInstrumentsTest"})
+	if !errors.Is(err, ErrNoInstrumentsLoaded) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrNoInstrumentsLoaded)
+	}
+	err = s.load("GetInstrumentsTest", []Instrument{
+		{Asset: asset.Spot, Pair: currency.NewPair(currency.ETH, currency.USDT), ContractType: Spot, Status: Trading},
+		{Asset: asset.Spot, Pair: currency.NewPair(currency.BTC, currency.USDT), ContractType: Spot, Status: Halted},
+		{Asset: asset.PerpetualSwap, Pair: currency.NewPair(currency.BTC, currency.USDT), ContractType: Perpetual, Status: Trading},
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for _, tc := range []struct {
+		filter   Filter
+		expected int
+	}{
+		{filter: Filter{Exchange: "GetInstrumentsTest"}, expected: 3},
+		{filter: Filter{Exchange: "GetInstrumentsTest", Asset: asset.Spot}, expected: 2},
+		{filter: Filter{Exchange: "GetInstrumentsTest", Base: currency.BTC}, expected: 2},
+		{filter: Filter{Exchange: "GetInstrumentsTest", Quote: currency.USD}, expected: 0},
+		{filter: Filter{Exchange: "GetInstrumentsTest", ContractType: Perpetual}, expected: 1},
+		{filter: Filter{Exchange: "GetInstrumentsTest", Status: Trading}, expected: 2},
+	} {
+		resp, err := s.getInstruments(tc.filter)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if len(resp) != tc.expected {
+			t.Errorf("%+v received: '%v' but expected: '%v'", tc.filter, len(resp), tc.expected)
+		}
+	}
+	resp, err := s.getInstruments(Filter{Exchange: "GetInstrumentsTest", Asset: asset.Spot})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !resp[0].Pair.Base.Equal(currency.BTC) {
+		t.Errorf("received: '%v' but expected: '%v'", resp[0].Pair.Base, currency.BTC)
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	t.Parallel()
+	s := newService()
+	pipe, err := s.subscribe("SubscribeTest")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer func() {
+		if err = pipe.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+
+	inst := Instrument{
+		Asset:        asset.Spot,
+		Pair:         currency.NewPair(currency.LTC, currency.USDT),
+		ContractType: Spot,
+		Status:       Trading,
+	}
+	expectUpdate := func(expected ChangeType) {
+		t.Helper()
+		select {
+		case data := <-pipe.Channel():
+			update, ok := data.(*Update)
+			if !ok {
+				t.Fatalf("received: '%T' but expected: '%T'", data, &Update{})
+			}
+			if update.Change != expected {
+				t.Errorf("received: '%v' but expected: '%v'", update.Change, expected)
+			}
+		case <-time.After(time.Second * 5):
+			t.Fatalf("expected %v update", expected)
+		}
+	}
+
+	if err = s.load("SubscribeTest", []Instrument{inst}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	expectUpdate(Added)
+
+	// reloading an unchanged instrument does not publish an update
+	if err = s.load("SubscribeTest", []Instrument{inst}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	inst.Status = Halted
+	if err = s.load("SubscribeTest", []Instrument{inst}); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	expectUpdate(Updated)
+}
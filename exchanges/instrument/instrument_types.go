@@ -0,0 +1,117 @@
+package instrument
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// Contract types
+const (
+	UnknownContract ContractType = iota
+	Spot
+	Perpetual
+	Delivery
+	Option
+)
+
+// Instrument statuses
+const (
+	UnknownStatus Status = iota
+	PreTrading
+	Trading
+	Halted
+	Settling
+	Delisted
+)
+
+// Change types
+const (
+	Added ChangeType = iota + 1
+	Updated
+)
+
+var (
+	// ErrInstrumentNotFound is returned when an instrument has not been
+	// loaded for an exchange, asset and pair
+	ErrInstrumentNotFound = errors.New("instrument not found")
+	// ErrNoInstrumentsLoaded is returned when an exchange has not loaded any
+	// instruments
+	ErrNoInstrumentsLoaded = errors.New("no instruments loaded")
+
+	errExchangeNameUnset = errors.New("exchange name unset")
+	errNoInstruments     = errors.New("no instruments supplied")
+	errInvalidContract   = errors.New("invalid contract specification")
+)
+
+// ContractType defines how an instrument is settled
+type ContractType uint8
+
+// Status defines the trading status of an instrument
+type Status uint8
+
+// ChangeType defines why an instrument update was published
+type ChangeType uint8
+
+// Instrument defines the exchange independent specification of a tradable
+// instrument. Fields which do not apply to the contract type, such as expiry
+// for a spot pair, are left unset
+type Instrument struct {
+	Exchange string
+	Asset    asset.Item
+	Pair     currency.Pair
+	// Symbol is the exchange's own name for the instrument, eg BTCUSD_230929
+	Symbol       string
+	ContractType ContractType
+	// Underlying is the pair the contract derives its price from, it is the
+	// pair itself for spot instruments
+	Underlying currency.Pair
+	// ContractSize is the amount of ContractSizeCurrency one contract
+	// represents
+	ContractSize         float64
+	ContractSizeCurrency currency.Code
+	// Multiplier is applied to the price when calculating contract value
+	Multiplier         float64
+	SettlementCurrency currency.Code
+	MarginCurrency     currency.Code
+	Expiry             time.Time
+	ListingTime        time.Time
+	Status             Status
+	Limits             order.MinMaxLevel
+	LastUpdated        time.Time
+}
+
+// Update is published to subscribers when an instrument is added or its
+// specification changes
+type Update struct {
+	Change     ChangeType
+	Instrument Instrument
+}
+
+// Filter narrows the instruments returned by GetInstruments, unset fields
+// match every instrument
+type Filter struct {
+	Exchange     string
+	Asset        asset.Item
+	Base         currency.Code
+	Quote        currency.Code
+	ContractType ContractType
+	Status       Status
+}
+
+// Service holds the instruments of every exchange
+type Service struct {
+	m map[string]map[asset.Item]map[*currency.Item]map[*currency.Item]*Instrument
+	// ids hold the dispatch IDs of exchanges with subscribers, allID receives
+	// the updates of every exchange
+	ids   map[string]uuid.UUID
+	allID uuid.UUID
+	mux   *dispatch.Mux
+	mtx   sync.RWMutex
+}
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -570,12 +571,12 @@ func (i *ItBit) GetActiveOrders(ctx context.Context, req *order.MultiOrderReques
 		var side order.Side
 		side, err = order.StringToOrderSide(allOrders[j].Side)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", i.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", i.Name, err)
 		}
 		var orderDate time.Time
 		orderDate, err = time.Parse(time.RFC3339, allOrders[j].CreatedTime)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"Exchange %v Func %v Order %v Could not parse date to unix with value of %v",
 				i.Name,
 				"GetActiveOrders",
@@ -639,17 +640,17 @@ func (i *ItBit) GetOrderHistory(ctx context.Context, req *order.MultiOrderReques
 		var side order.Side
 		side, err = order.StringToOrderSide(allOrders[j].Side)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", i.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", i.Name, err)
 		}
 		var status order.Status
 		status, err = order.StringToOrderStatus(allOrders[j].Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", i.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", i.Name, err)
 		}
 		var orderDate time.Time
 		orderDate, err = time.Parse(time.RFC3339, allOrders[j].CreatedTime)
 		if err != nil {
-			log.Errorf(log.ExchangeSys,
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"Exchange %v Func %v Order %v Could not parse date to unix with value of %v",
 				i.Name,
 				"GetActiveOrders",
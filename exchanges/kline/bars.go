@@ -0,0 +1,367 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+// BarType defines how alternative bars are sampled. Unlike candles, which are
+// sampled at a fixed time interval, most alternative bars are sampled once
+// enough market activity has occurred
+type BarType uint8
+
+// Alternative bar types
+const (
+	// VolumeBar closes once the traded amount reaches the threshold
+	VolumeBar BarType = iota + 1
+	// DollarBar closes once the traded notional, price multiplied by amount,
+	// reaches the threshold
+	DollarBar
+	// TickBar closes once the number of trades reaches the threshold
+	TickBar
+	// TickImbalanceBar closes once the absolute sum of signed trades reaches
+	// the threshold. Buys are positive and sells are negative. When the side
+	// of a trade is unknown, the tick rule is used
+	TickImbalanceBar
+	// VolumeImbalanceBar closes once the absolute sum of signed trade amounts
+	// reaches the threshold
+	VolumeImbalanceBar
+	// HeikinAshiBar averages each candle with the previous bar, producing one
+	// bar per candle
+	HeikinAshiBar
+	// RenkoBar creates a brick each time price moves the threshold beyond the
+	// last brick. Reversals require price to move the threshold beyond the
+	// opposite end of the last brick
+	RenkoBar
+)
+
+var (
+	// ErrInvalidBarType is returned when a bar type is not recognised
+	ErrInvalidBarType = errors.New("invalid bar type")
+	// ErrInvalidBarThreshold is returned when a bar threshold is not valid for
+	// its bar type
+	ErrInvalidBarThreshold = errors.New("invalid bar threshold")
+	// ErrBarRequiresTrades is returned when attempting to build a bar type
+	// which needs individual trades from candles
+	ErrBarRequiresTrades = errors.New("bar type requires trade data")
+	// ErrBarRequiresCandles is returned when attempting to build a bar type
+	// which transforms candles from trades
+	ErrBarRequiresCandles = errors.New("bar type requires candle data")
+
+	barTypeNames = map[BarType]string{
+		VolumeBar:          "volume",
+		DollarBar:          "dollar",
+		TickBar:            "tick",
+		TickImbalanceBar:   "tick-imbalance",
+		VolumeImbalanceBar: "volume-imbalance",
+		HeikinAshiBar:      "heikin-ashi",
+		RenkoBar:           "renko",
+	}
+)
+
+// BarSettings defines how alternative bars are built
+type BarSettings struct {
+	Type BarType
+	// Threshold is the amount of activity which closes a bar. It is the
+	// amount for volume bars, notional for dollar bars, trade count for tick
+	// bars, absolute imbalance for imbalance bars and brick size for renko
+	// bars. It is not used by heikin-ashi bars
+	Threshold float64
+}
+
+// String returns the config readable name of the bar type
+func (b BarType) String() string {
+	if name, ok := barTypeNames[b]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// RequiresTrades returns whether the bar type can only be built from trades
+func (b BarType) RequiresTrades() bool {
+	return b == TickBar || b == TickImbalanceBar || b == VolumeImbalanceBar
+}
+
+// BarTypeFromString returns the bar type matching its config readable name
+func BarTypeFromString(s string) (BarType, error) {
+	s = strings.ReplaceAll(strings.ToLower(strings.TrimSpace(s)), "_", "-")
+	for k, v := range barTypeNames {
+		if v == s {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("%w '%v'", ErrInvalidBarType, s)
+}
+
+// Validate checks the bar settings can be used to build bars
+func (b BarSettings) Validate() error {
+	if _, ok := barTypeNames[b.Type]; !ok {
+		return fmt.Errorf("%w '%d'", ErrInvalidBarType, b.Type)
+	}
+	if b.Type == HeikinAshiBar {
+		return nil
+	}
+	if b.Threshold <= 0 || math.IsInf(b.Threshold, 0) || math.IsNaN(b.Threshold) {
+		return fmt.Errorf("%w %v for %v bars", ErrInvalidBarThreshold, b.Threshold, b.Type)
+	}
+	if b.Type == TickBar && b.Threshold != math.Trunc(b.Threshold) {
+		return fmt.Errorf("%w %v for %v bars, must be a whole number of trades", ErrInvalidBarThreshold, b.Threshold, b.Type)
+	}
+	return nil
+}
+
+// CreateBars builds alternative bars from trades. Each bar's time is the time
+// of its first trade and bars which have not reached the threshold by the
+// last trade are not returned. As bars do not have a fixed duration, the
+// interval of the returned item is not set
+func CreateBars(trades []order.TradeHistory, settings BarSettings, pair currency.Pair, a asset.Item, exchName string) (*Item, error) {
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if settings.Type == HeikinAshiBar {
+		return nil, fmt.Errorf("%w %v", ErrBarRequiresCandles, settings.Type)
+	}
+	if len(trades) == 0 {
+		return nil, errInsufficientTradeData
+	}
+	sorted := make([]order.TradeHistory, len(trades))
+	copy(sorted, trades)
+	for i := range sorted {
+		if sorted[i].Timestamp.IsZero() || sorted[i].Timestamp.Unix() == 0 {
+			return nil, fmt.Errorf("timestamp not set for element %d", i)
+		}
+		if sorted[i].Amount == 0 {
+			return nil, fmt.Errorf("amount not set for element %d", i)
+		}
+		if sorted[i].Price == 0 {
+			return nil, fmt.Errorf("price not set for element %d", i)
+		}
+	}
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return sorted[i].Timestamp.Before(sorted[j].Timestamp)
+	})
+
+	resp := &Item{
+		Exchange: exchName,
+		Pair:     pair,
+		Asset:    a,
+	}
+	if settings.Type == RenkoBar {
+		r := renkoBuilder{size: settings.Threshold}
+		for i := range sorted {
+			r.add(sorted[i].Timestamp, math.Abs(sorted[i].Price), math.Abs(sorted[i].Amount))
+		}
+		resp.Candles = r.bars
+		return resp, nil
+	}
+
+	b := thresholdBarBuilder{threshold: settings.Threshold}
+	var lastPrice, lastSign float64
+	for i := range sorted {
+		price := math.Abs(sorted[i].Price)
+		amount := math.Abs(sorted[i].Amount)
+		sign := tradeSign(sorted[i].Side, price, lastPrice, lastSign)
+		lastPrice, lastSign = price, sign
+		var progress float64
+		switch settings.Type {
+		case VolumeBar:
+			progress = amount
+		case DollarBar:
+			progress = price * amount
+		case TickBar:
+			progress = 1
+		case TickImbalanceBar:
+			progress = sign
+		case VolumeImbalanceBar:
+			progress = sign * amount
+		}
+		b.add(Candle{
+			Time:   sorted[i].Timestamp,
+			Open:   price,
+			High:   price,
+			Low:    price,
+			Close:  price,
+			Volume: amount,
+		}, progress)
+	}
+	resp.Candles = b.bars
+	return resp, nil
+}
+
+// ConvertToBars builds alternative bars from the candles of the item. Bar
+// types which require individual trades cannot be built from candles. Each
+// candle contributes to a single bar, so a bar's time is the time of its first
+// candle. Bars which have not reached the threshold by the last candle are not
+// returned. The interval of the returned item is the interval of the candles
+// the bars were built from
+func (k *Item) ConvertToBars(settings BarSettings) (*Item, error) {
+	if k == nil {
+		return nil, errNilKline
+	}
+	if err := settings.Validate(); err != nil {
+		return nil, err
+	}
+	if settings.Type.RequiresTrades() {
+		return nil, fmt.Errorf("%w %v", ErrBarRequiresTrades, settings.Type)
+	}
+	if len(k.Candles) == 0 {
+		return nil, ErrInsufficientCandleData
+	}
+	candles := make([]Candle, len(k.Candles))
+	copy(candles, k.Candles)
+	sort.SliceStable(candles, func(i, j int) bool {
+		return candles[i].Time.Before(candles[j].Time)
+	})
+
+	resp := &Item{
+		Exchange:       k.Exchange,
+		Pair:           k.Pair,
+		UnderlyingPair: k.UnderlyingPair,
+		Asset:          k.Asset,
+		Interval:       k.Interval,
+	}
+	switch settings.Type {
+	case HeikinAshiBar:
+		resp.Candles = heikinAshi(candles)
+	case RenkoBar:
+		r := renkoBuilder{size: settings.Threshold}
+		for i := range candles {
+			r.add(candles[i].Time, candles[i].Close, candles[i].Volume)
+		}
+		resp.Candles = r.bars
+	default:
+		b := thresholdBarBuilder{threshold: settings.Threshold}
+		for i := range candles {
+			progress := candles[i].Volume
+			if settings.Type == DollarBar {
+				progress *= candles[i].GetAveragePrice()
+			}
+			b.add(candles[i], progress)
+		}
+		resp.Candles = b.bars
+	}
+	return resp, nil
+}
+
+// tradeSign classifies a trade as a buy (1) or sell (-1) using its side. When
+// the side is unknown, the tick rule is used where a price rise is a buy, a
+// fall is a sell and an unchanged price keeps the previous classification
+func tradeSign(side order.Side, price, lastPrice, lastSign float64) float64 {
+	switch {
+	case side.IsLong():
+		return 1
+	case side.IsShort():
+		return -1
+	case lastPrice == 0:
+		return 0
+	case price > lastPrice:
+		return 1
+	case price < lastPrice:
+		return -1
+	default:
+		return lastSign
+	}
+}
+
+// thresholdBarBuilder aggregates candles into bars which close once their
+// accumulated progress reaches the threshold
+type thresholdBarBuilder struct {
+	threshold float64
+	progress  float64
+	current   Candle
+	building  bool
+	bars      []Candle
+}
+
+func (t *thresholdBarBuilder) add(c Candle, progress float64) {
+	if !t.building {
+		t.current = Candle{
+			Time: c.Time,
+			Open: c.Open,
+			High: c.High,
+			Low:  c.Low,
+		}
+		t.building = true
+	}
+	t.current.High = math.Max(t.current.High, c.High)
+	t.current.Low = math.Min(t.current.Low, c.Low)
+	t.current.Close = c.Close
+	t.current.Volume += c.Volume
+	t.progress += progress
+	if math.Abs(t.progress) >= t.threshold {
+		t.bars = append(t.bars, t.current)
+		t.building = false
+		t.progress = 0
+	}
+}
+
+// renkoBuilder creates fixed size bricks from a series of prices
+type renkoBuilder struct {
+	size    float64
+	top     float64
+	bottom  float64
+	started bool
+	volume  float64
+	bars    []Candle
+}
+
+func (r *renkoBuilder) add(t time.Time, price, volume float64) {
+	if !r.started {
+		r.top, r.bottom = price, price
+		r.started = true
+	}
+	r.volume += volume
+	for price >= r.top+r.size {
+		r.bottom = r.top
+		r.top += r.size
+		r.appendBrick(t, r.bottom, r.top)
+	}
+	for price <= r.bottom-r.size {
+		r.top = r.bottom
+		r.bottom -= r.size
+		r.appendBrick(t, r.top, r.bottom)
+	}
+}
+
+// appendBrick adds a brick, attributing all volume since the last brick to it
+func (r *renkoBuilder) appendBrick(t time.Time, open, closePrice float64) {
+	r.bars = append(r.bars, Candle{
+		Time:   t,
+		Open:   open,
+		High:   math.Max(open, closePrice),
+		Low:    math.Min(open, closePrice),
+		Close:  closePrice,
+		Volume: r.volume,
+	})
+	r.volume = 0
+}
+
+// heikinAshi transforms candles into heikin-ashi bars
+func heikinAshi(candles []Candle) []Candle {
+	resp := make([]Candle, len(candles))
+	for i := range candles {
+		haClose := candles[i].GetAveragePrice()
+		haOpen := (candles[i].Open + candles[i].Close) / 2
+		if i > 0 {
+			haOpen = (resp[i-1].Open + resp[i-1].Close) / 2
+		}
+		resp[i] = Candle{
+			Time:             candles[i].Time,
+			Open:             haOpen,
+			High:             math.Max(candles[i].High, math.Max(haOpen, haClose)),
+			Low:              math.Min(candles[i].Low, math.Min(haOpen, haClose)),
+			Close:            haClose,
+			Volume:           candles[i].Volume,
+			ValidationIssues: candles[i].ValidationIssues,
+		}
+	}
+	return resp
+}
@@ -0,0 +1,274 @@
+package kline
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+)
+
+var barsStart = time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+
+func barTrades(prices, amounts []float64, sides []order.Side) []order.TradeHistory {
+	trades := make([]order.TradeHistory, len(prices))
+	for i := range prices {
+		trades[i] = order.TradeHistory{
+			Price:     prices[i],
+			Amount:    amounts[i],
+			Timestamp: barsStart.Add(time.Second * time.Duration(i)),
+		}
+		if sides != nil {
+			trades[i].Side = sides[i]
+		}
+	}
+	return trades
+}
+
+func TestBarTypeFromString(t *testing.T) {
+	t.Parallel()
+	for k, v := range barTypeNames {
+		b, err := BarTypeFromString(v)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v' expected '%v'", err, nil)
+		}
+		if b != k {
+			t.Errorf("received '%v' expected '%v'", b, k)
+		}
+		if b.String() != v {
+			t.Errorf("received '%v' expected '%v'", b.String(), v)
+		}
+	}
+	b, err := BarTypeFromString(" Heikin_Ashi ")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if b != HeikinAshiBar {
+		t.Errorf("received '%v' expected '%v'", b, HeikinAshiBar)
+	}
+	_, err = BarTypeFromString("kagi")
+	if !errors.Is(err, ErrInvalidBarType) {
+		t.Errorf("received '%v' expected '%v'", err, ErrInvalidBarType)
+	}
+	if s := BarType(0).String(); s != "unknown" {
+		t.Errorf("received '%v' expected '%v'", s, "unknown")
+	}
+}
+
+func TestBarSettingsValidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		settings BarSettings
+		err      error
+	}{
+		{BarSettings{}, ErrInvalidBarType},
+		{BarSettings{Type: VolumeBar}, ErrInvalidBarThreshold},
+		{BarSettings{Type: DollarBar, Threshold: -1}, ErrInvalidBarThreshold},
+		{BarSettings{Type: RenkoBar, Threshold: math.NaN()}, ErrInvalidBarThreshold},
+		{BarSettings{Type: TickBar, Threshold: 1.5}, ErrInvalidBarThreshold},
+		{BarSettings{Type: TickBar, Threshold: 2}, nil},
+		{BarSettings{Type: HeikinAshiBar}, nil},
+	} {
+		if err := tc.settings.Validate(); !errors.Is(err, tc.err) {
+			t.Errorf("%+v received '%v' expected '%v'", tc.settings, err, tc.err)
+		}
+	}
+}
+
+func TestCreateBars(t *testing.T) {
+	t.Parallel()
+	_, err := CreateBars(nil, BarSettings{}, currency.EMPTYPAIR, asset.Spot, "test")
+	if !errors.Is(err, ErrInvalidBarType) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrInvalidBarType)
+	}
+	_, err = CreateBars(nil, BarSettings{Type: HeikinAshiBar}, currency.EMPTYPAIR, asset.Spot, "test")
+	if !errors.Is(err, ErrBarRequiresCandles) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrBarRequiresCandles)
+	}
+	_, err = CreateBars(nil, BarSettings{Type: TickBar, Threshold: 2}, currency.EMPTYPAIR, asset.Spot, "test")
+	if !errors.Is(err, errInsufficientTradeData) {
+		t.Fatalf("received '%v' expected '%v'", err, errInsufficientTradeData)
+	}
+	_, err = CreateBars([]order.TradeHistory{{Price: 1, Amount: 1}}, BarSettings{Type: TickBar, Threshold: 2}, currency.EMPTYPAIR, asset.Spot, "test")
+	if err == nil {
+		t.Fatal("expected error for unset timestamp")
+	}
+
+	pair := currency.NewPair(currency.BTC, currency.USDT)
+	prices := []float64{10, 11, 12, 11, 10, 9, 10}
+	amounts := []float64{1, 2, 1, 3, 1, 1, 2}
+	trades := barTrades(prices, amounts, nil)
+	// reverse the trades to ensure they are sorted without modifying input
+	reversed := make([]order.TradeHistory, len(trades))
+	for i := range trades {
+		reversed[len(trades)-1-i] = trades[i]
+	}
+
+	for _, tc := range []struct {
+		name     string
+		settings BarSettings
+		expected []Candle
+	}{
+		{
+			name:     "volume",
+			settings: BarSettings{Type: VolumeBar, Threshold: 3},
+			expected: []Candle{
+				{Time: barsStart, Open: 10, High: 11, Low: 10, Close: 11, Volume: 3},
+				{Time: barsStart.Add(time.Second * 2), Open: 12, High: 12, Low: 11, Close: 11, Volume: 4},
+				{Time: barsStart.Add(time.Second * 4), Open: 10, High: 10, Low: 9, Close: 10, Volume: 4},
+			},
+		},
+		{
+			name:     "dollar",
+			settings: BarSettings{Type: DollarBar, Threshold: 40},
+			expected: []Candle{
+				{Time: barsStart, Open: 10, High: 12, Low: 10, Close: 12, Volume: 4},
+				{Time: barsStart.Add(time.Second * 3), Open: 11, High: 11, Low: 10, Close: 10, Volume: 4},
+			},
+		},
+		{
+			name:     "tick",
+			settings: BarSettings{Type: TickBar, Threshold: 3},
+			expected: []Candle{
+				{Time: barsStart, Open: 10, High: 12, Low: 10, Close: 12, Volume: 4},
+				{Time: barsStart.Add(time.Second * 3), Open: 11, High: 11, Low: 9, Close: 9, Volume: 5},
+			},
+		},
+		{
+			// tick rule signs: 0, 1, 1, -1, -1, -1, 1
+			name:     "tick imbalance",
+			settings: BarSettings{Type: TickImbalanceBar, Threshold: 2},
+			expected: []Candle{
+				{Time: barsStart, Open: 10, High: 12, Low: 10, Close: 12, Volume: 4},
+				{Time: barsStart.Add(time.Second * 3), Open: 11, High: 11, Low: 10, Close: 10, Volume: 4},
+			},
+		},
+		{
+			// signed amounts: 0, 2, 1, -3, -1, -1, 2
+			name:     "volume imbalance",
+			settings: BarSettings{Type: VolumeImbalanceBar, Threshold: 3},
+			expected: []Candle{
+				{Time: barsStart, Open: 10, High: 12, Low: 10, Close: 12, Volume: 4},
+				{Time: barsStart.Add(time.Second * 3), Open: 11, High: 11, Low: 11, Close: 11, Volume: 3},
+			},
+		},
+		{
+			name:     "renko",
+			settings: BarSettings{Type: RenkoBar, Threshold: 1},
+			expected: []Candle{
+				{Time: barsStart.Add(time.Second), Open: 10, High: 11, Low: 10, Close: 11, Volume: 3},
+				{Time: barsStart.Add(time.Second * 2), Open: 11, High: 12, Low: 11, Close: 12, Volume: 1},
+				{Time: barsStart.Add(time.Second * 4), Open: 11, High: 11, Low: 10, Close: 10, Volume: 4},
+				{Time: barsStart.Add(time.Second * 5), Open: 10, High: 10, Low: 9, Close: 9, Volume: 1},
+			},
+		},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			bars, err := CreateBars(reversed, tc.settings, pair, asset.Spot, "test")
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v' expected '%v'", err, nil)
+			}
+			if !bars.Pair.Equal(pair) || bars.Asset != asset.Spot || bars.Exchange != "test" || bars.Interval != 0 {
+				t.Errorf("unexpected item details %+v", bars)
+			}
+			compareCandles(t, bars.Candles, tc.expected)
+		})
+	}
+	if !reversed[0].Timestamp.Equal(trades[len(trades)-1].Timestamp) {
+		t.Error("input trades should not be modified")
+	}
+
+	// trade sides take precedence over the tick rule
+	sided := barTrades([]float64{10, 11, 12}, []float64{1, 1, 1}, []order.Side{order.Sell, order.Sell, order.Buy})
+	bars, err := CreateBars(sided, BarSettings{Type: TickImbalanceBar, Threshold: 2}, pair, asset.Spot, "test")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	compareCandles(t, bars.Candles, []Candle{{Time: barsStart, Open: 10, High: 11, Low: 10, Close: 11, Volume: 2}})
+}
+
+func TestConvertToBars(t *testing.T) {
+	t.Parallel()
+	var k *Item
+	_, err := k.ConvertToBars(BarSettings{Type: HeikinAshiBar})
+	if !errors.Is(err, errNilKline) {
+		t.Fatalf("received '%v' expected '%v'", err, errNilKline)
+	}
+	k = &Item{Exchange: "test", Asset: asset.Spot, Interval: OneHour}
+	_, err = k.ConvertToBars(BarSettings{Type: TickBar, Threshold: 1})
+	if !errors.Is(err, ErrBarRequiresTrades) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrBarRequiresTrades)
+	}
+	_, err = k.ConvertToBars(BarSettings{Type: HeikinAshiBar})
+	if !errors.Is(err, ErrInsufficientCandleData) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrInsufficientCandleData)
+	}
+
+	k.Candles = []Candle{
+		{Time: barsStart.Add(time.Hour * 2), Open: 12, High: 14, Low: 11, Close: 13, Volume: 2},
+		{Time: barsStart, Open: 10, High: 12, Low: 9, Close: 11, Volume: 1},
+		{Time: barsStart.Add(time.Hour), Open: 11, High: 13, Low: 10, Close: 12, Volume: 3},
+		{Time: barsStart.Add(time.Hour * 3), Open: 13, High: 13, Low: 9, Close: 9, Volume: 1},
+	}
+
+	bars, err := k.ConvertToBars(BarSettings{Type: VolumeBar, Threshold: 4})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if bars.Interval != OneHour || bars.Exchange != "test" {
+		t.Errorf("unexpected item details %+v", bars)
+	}
+	compareCandles(t, bars.Candles, []Candle{
+		{Time: barsStart, Open: 10, High: 13, Low: 9, Close: 12, Volume: 4},
+	})
+
+	// average prices 10.5, 11.5, 12.5, 11 produce notional of 10.5, 34.5, 25, 11
+	bars, err = k.ConvertToBars(BarSettings{Type: DollarBar, Threshold: 35})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	compareCandles(t, bars.Candles, []Candle{
+		{Time: barsStart, Open: 10, High: 13, Low: 9, Close: 12, Volume: 4},
+		{Time: barsStart.Add(time.Hour * 2), Open: 12, High: 14, Low: 9, Close: 9, Volume: 3},
+	})
+
+	bars, err = k.ConvertToBars(BarSettings{Type: RenkoBar, Threshold: 2})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	compareCandles(t, bars.Candles, []Candle{
+		{Time: barsStart.Add(time.Hour * 2), Open: 11, High: 13, Low: 11, Close: 13, Volume: 6},
+		{Time: barsStart.Add(time.Hour * 3), Open: 11, High: 11, Low: 9, Close: 9, Volume: 1},
+	})
+
+	bars, err = k.ConvertToBars(BarSettings{Type: HeikinAshiBar})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	compareCandles(t, bars.Candles, []Candle{
+		{Time: barsStart, Open: 10.5, High: 12, Low: 9, Close: 10.5, Volume: 1},
+		{Time: barsStart.Add(time.Hour), Open: 10.5, High: 13, Low: 10, Close: 11.5, Volume: 3},
+		{Time: barsStart.Add(time.Hour * 2), Open: 11, High: 14, Low: 11, Close: 12.5, Volume: 2},
+		{Time: barsStart.Add(time.Hour * 3), Open: 11.75, High: 13, Low: 9, Close: 11, Volume: 1},
+	})
+	if !k.Candles[0].Time.Equal(barsStart.Add(time.Hour * 2)) {
+		t.Error("input candles should not be modified")
+	}
+}
+
+func compareCandles(t *testing.T, received, expected []Candle) {
+	t.Helper()
+	if len(received) != len(expected) {
+		t.Fatalf("received '%v' candles expected '%v': %+v", len(received), len(expected), received)
+	}
+	for i := range expected {
+		if received[i] != expected[i] {
+			t.Errorf("candle %d received '%+v' expected '%+v'", i, received[i], expected[i])
+		}
+	}
+}
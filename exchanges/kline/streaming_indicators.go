@@ -0,0 +1,708 @@
+package kline
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/thrasher-corp/gct-ta/indicators"
+)
+
+// IndicatorStream calculates an indicator one candle at a time. State is
+// bounded by the indicator period rather than the length of history and the
+// values returned exactly match the equivalent batch calculation for the same
+// candle, as each stream repeats the batch arithmetic in the same order.
+// Indicators which sum a moving window do so on every update, so updates and
+// peeks take time proportional to the period. Streams are not safe for
+// concurrent use.
+type IndicatorStream interface {
+	// Update commits a closed candle and returns the indicator values for it
+	Update(Candle) []float64
+	// Peek returns the indicator values as if the in-progress candle had
+	// closed, without committing it. Repeated calls replace the prior peek.
+	Peek(Candle) []float64
+	// Ready returns whether enough candles have been committed to produce
+	// values outside of the warm up period
+	Ready() bool
+}
+
+// SimpleMovingAverageStream streams the simple moving average of close prices
+type SimpleMovingAverageStream struct {
+	closes *ringBuffer
+	window []float64
+}
+
+// NewSimpleMovingAverageStream returns a streaming simple moving average
+func NewSimpleMovingAverageStream(period int64) (*SimpleMovingAverageStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new simple moving average stream %w", errInvalidPeriod)
+	}
+	return &SimpleMovingAverageStream{
+		closes: newRingBuffer(int(period)),
+		window: make([]float64, 0, period),
+	}, nil
+}
+
+// Update commits a closed candle and returns the simple moving average
+func (s *SimpleMovingAverageStream) Update(c Candle) []float64 {
+	values := s.Peek(c)
+	s.closes.push(c.Close)
+	return values
+}
+
+// Peek returns the simple moving average including an in-progress candle,
+// zero during the warm up period
+func (s *SimpleMovingAverageStream) Peek(c Candle) []float64 {
+	if !s.closes.fullAfterPush() {
+		return []float64{0}
+	}
+	s.window = s.closes.windowAfterPush(s.window, c.Close)
+	var total float64
+	for i := range s.window {
+		total += s.window[i]
+	}
+	return []float64{total / float64(len(s.window))}
+}
+
+// Ready returns whether a full period of candles has been committed
+func (s *SimpleMovingAverageStream) Ready() bool {
+	return s.closes.full()
+}
+
+// ExponentialMovingAverageStream streams the exponential moving average of
+// close prices
+type ExponentialMovingAverageStream struct {
+	average emaState
+}
+
+// NewExponentialMovingAverageStream returns a streaming exponential moving
+// average
+func NewExponentialMovingAverageStream(period int64) (*ExponentialMovingAverageStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new exponential moving average stream %w", errInvalidPeriod)
+	}
+	return &ExponentialMovingAverageStream{average: newEMAState(int(period))}, nil
+}
+
+// Update commits a closed candle and returns the exponential moving average
+func (e *ExponentialMovingAverageStream) Update(c Candle) []float64 {
+	value, _ := e.average.next(c.Close)
+	return []float64{value}
+}
+
+// Peek returns the exponential moving average including an in-progress candle
+func (e *ExponentialMovingAverageStream) Peek(c Candle) []float64 {
+	preview := *e
+	return preview.Update(c)
+}
+
+// Ready returns whether the average has been seeded
+func (e *ExponentialMovingAverageStream) Ready() bool {
+	return e.average.ready()
+}
+
+// RelativeStrengthIndexStream streams the relative strength index of close
+// prices
+type RelativeStrengthIndexStream struct {
+	period   int
+	count    int
+	previous float64
+	gain     float64
+	loss     float64
+}
+
+// NewRelativeStrengthIndexStream returns a streaming relative strength index
+func NewRelativeStrengthIndexStream(period int64) (*RelativeStrengthIndexStream, error) {
+	if period <= 1 {
+		return nil, fmt.Errorf("new relative strength index stream %w cannot be equal or below 1", errInvalidPeriod)
+	}
+	return &RelativeStrengthIndexStream{period: int(period)}, nil
+}
+
+// Update commits a closed candle and returns the relative strength index
+func (r *RelativeStrengthIndexStream) Update(c Candle) []float64 {
+	index := r.count
+	r.count++
+	if index == 0 {
+		r.previous = c.Close
+		return []float64{0}
+	}
+	if index > r.period {
+		r.loss *= float64(r.period - 1)
+		r.gain *= float64(r.period - 1)
+	}
+	difference := c.Close - r.previous
+	r.previous = c.Close
+	if difference < 0 {
+		r.loss -= difference
+	} else {
+		r.gain += difference
+	}
+	if index < r.period {
+		return []float64{0}
+	}
+	r.loss /= float64(r.period)
+	r.gain /= float64(r.period)
+	total := r.gain + r.loss
+	if -0.00000000000001 < total && total < 0.00000000000001 {
+		return []float64{0}
+	}
+	return []float64{100.0 * (r.gain / total)}
+}
+
+// Peek returns the relative strength index including an in-progress candle
+func (r *RelativeStrengthIndexStream) Peek(c Candle) []float64 {
+	preview := *r
+	return preview.Update(c)
+}
+
+// Ready returns whether a full period of price changes has been committed
+func (r *RelativeStrengthIndexStream) Ready() bool {
+	return r.count > r.period
+}
+
+// AverageTrueRangeStream streams the average true range
+type AverageTrueRangeStream struct {
+	period        int
+	count         int
+	previousClose float64
+	average       float64
+	seed          float64
+}
+
+// NewAverageTrueRangeStream returns a streaming average true range
+func NewAverageTrueRangeStream(period int64) (*AverageTrueRangeStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new average true range stream %w", errInvalidPeriod)
+	}
+	return &AverageTrueRangeStream{period: int(period)}, nil
+}
+
+// Update commits a closed candle and returns the average true range
+func (a *AverageTrueRangeStream) Update(c Candle) []float64 {
+	index := a.count
+	a.count++
+	var tr float64
+	if index > 0 {
+		tr = candleTrueRange(c.High, c.Low, a.previousClose)
+	}
+	a.previousClose = c.Close
+	switch {
+	case a.period == 1:
+		return []float64{tr}
+	case index == 0:
+		return []float64{0}
+	case index <= a.period:
+		a.seed += tr
+		if index < a.period {
+			return []float64{0}
+		}
+		a.average = a.seed / float64(a.period)
+	default:
+		a.average *= float64(a.period) - 1.0
+		a.average += tr
+		a.average /= float64(a.period)
+	}
+	return []float64{a.average}
+}
+
+// Peek returns the average true range including an in-progress candle
+func (a *AverageTrueRangeStream) Peek(c Candle) []float64 {
+	preview := *a
+	return preview.Update(c)
+}
+
+// Ready returns whether the average has been seeded
+func (a *AverageTrueRangeStream) Ready() bool {
+	if a.period == 1 {
+		return a.count > 0
+	}
+	return a.count > a.period
+}
+
+// BollingerBandsStream streams bollinger bands of close prices, values are
+// returned as upper, middle and lower bands. The window totals are kept in the
+// same way as the batch variance, adding each close and removing the trailing
+// close once the window is full
+type BollingerBandsStream struct {
+	period    int
+	deviation [2]float64
+	window    *ringBuffer
+	total     float64
+	squares   float64
+	sma       *SimpleMovingAverageStream
+	ema       *ExponentialMovingAverageStream
+}
+
+// NewBollingerBandsStream returns streaming bollinger bands
+func NewBollingerBandsStream(period int64, nbDevUp, nbDevDown float64, m indicators.MaType) (*BollingerBandsStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new bollinger bands stream %w", errInvalidPeriod)
+	}
+	if nbDevUp <= 0 {
+		return nil, fmt.Errorf("new bollinger bands stream %w upper limit", errInvalidDeviationMultiplier)
+	}
+	if nbDevDown <= 0 {
+		return nil, fmt.Errorf("new bollinger bands stream %w lower limit", errInvalidDeviationMultiplier)
+	}
+	b := &BollingerBandsStream{
+		period:    int(period),
+		deviation: [2]float64{nbDevUp, nbDevDown},
+		window:    newRingBuffer(int(period)),
+	}
+	if period > 1 {
+		switch m {
+		case indicators.Sma:
+			b.sma, _ = NewSimpleMovingAverageStream(period)
+		case indicators.Ema:
+			b.ema, _ = NewExponentialMovingAverageStream(period)
+		}
+	}
+	return b, nil
+}
+
+// Update commits a closed candle and returns the upper, middle and lower bands
+func (b *BollingerBandsStream) Update(c Candle) []float64 {
+	var middle float64
+	switch {
+	case b.period == 1:
+		middle = c.Close
+	case b.sma != nil:
+		middle = b.sma.Update(c)[0]
+	case b.ema != nil:
+		middle = b.ema.Update(c)[0]
+	}
+	b.total, b.squares = b.add(c.Close)
+	values := b.bands(middle, b.total, b.squares, b.window.fullAfterPush())
+	b.window.push(c.Close)
+	if b.window.full() {
+		trailing := b.window.oldest()
+		b.total -= trailing
+		b.squares -= trailing * trailing
+	}
+	return values
+}
+
+// Peek returns the bands including an in-progress candle
+func (b *BollingerBandsStream) Peek(c Candle) []float64 {
+	var middle float64
+	switch {
+	case b.period == 1:
+		middle = c.Close
+	case b.sma != nil:
+		middle = b.sma.Peek(c)[0]
+	case b.ema != nil:
+		middle = b.ema.Peek(c)[0]
+	}
+	total, squares := b.add(c.Close)
+	return b.bands(middle, total, squares, b.window.fullAfterPush())
+}
+
+// Ready returns whether a full period of candles has been committed
+func (b *BollingerBandsStream) Ready() bool {
+	return b.window.full()
+}
+
+// add returns the window totals including a close in the same order of
+// operations as the batch variance
+func (b *BollingerBandsStream) add(closePrice float64) (total, squares float64) {
+	total = b.total + closePrice
+	closePrice *= closePrice
+	return total, b.squares + closePrice
+}
+
+// bands returns the upper, middle and lower bands from the window totals of
+// close prices and their squares
+func (b *BollingerBandsStream) bands(middle, total, squares float64, full bool) []float64 {
+	var deviation float64
+	if full {
+		meanValue1 := total / float64(b.period)
+		meanValue2 := squares / float64(b.period)
+		variance := meanValue2 - meanValue1*meanValue1
+		if !(variance < 0.00000000000001) {
+			deviation = math.Sqrt(variance)
+		}
+	}
+	upper, lower := bollingerBounds(middle, deviation, b.deviation[0], b.deviation[1])
+	return []float64{upper, middle, lower}
+}
+
+// bollingerBounds applies the deviation multipliers in the same order as the
+// batch bollinger bands calculation
+func bollingerBounds(middle, deviation, nbDevUp, nbDevDown float64) (upper, lower float64) {
+	switch nbDevUp {
+	case nbDevDown:
+		if nbDevUp == 1.0 {
+			return middle + deviation, middle - deviation
+		}
+		scaled := deviation * nbDevUp
+		return middle + scaled, middle - scaled
+	case 1.0:
+		return middle + deviation, middle - (deviation * nbDevDown)
+	default:
+		if nbDevDown == 1.0 {
+			return middle + (deviation * nbDevUp), middle - deviation
+		}
+		return middle + (deviation * nbDevUp), middle - (deviation * nbDevDown)
+	}
+}
+
+// MACDStream streams the moving average convergence divergence of close
+// prices, values are returned as macd, signal and histogram
+type MACDStream struct {
+	fast   emaState
+	slow   emaState
+	signal emaState
+}
+
+// NewMACDStream returns a streaming moving average convergence divergence
+func NewMACDStream(fast, slow, signal int64) (*MACDStream, error) {
+	if fast <= 0 {
+		return nil, fmt.Errorf("new macd stream %w fast", errInvalidPeriod)
+	}
+	if slow <= 0 {
+		return nil, fmt.Errorf("new macd stream %w slow", errInvalidPeriod)
+	}
+	if fast >= slow {
+		return nil, fmt.Errorf("new macd stream %w fast should not be equal or exceed slow", errInvalidPeriod)
+	}
+	if signal <= 0 {
+		return nil, fmt.Errorf("new macd stream %w signal", errInvalidPeriod)
+	}
+	return &MACDStream{
+		fast:   newEMAState(int(fast)),
+		slow:   newEMAState(int(slow)),
+		signal: newEMAState(int(signal)),
+	}, nil
+}
+
+// Update commits a closed candle and returns the macd, signal and histogram
+func (m *MACDStream) Update(c Candle) []float64 {
+	fast, _ := m.fast.next(c.Close)
+	slow, ready := m.slow.next(c.Close)
+	if !ready {
+		return []float64{0, 0, 0}
+	}
+	var macd float64
+	if fast != 0 && slow != 0 {
+		macd = fast - slow
+	}
+	signal, ready := m.signal.next(macd)
+	if !ready {
+		return []float64{0, 0, 0}
+	}
+	var histogram float64
+	if macd != 0 && signal != 0 {
+		histogram = macd - signal
+	}
+	return []float64{macd, signal, histogram}
+}
+
+// Peek returns the macd values including an in-progress candle
+func (m *MACDStream) Peek(c Candle) []float64 {
+	preview := *m
+	return preview.Update(c)
+}
+
+// Ready returns whether the signal line has been seeded
+func (m *MACDStream) Ready() bool {
+	return m.signal.ready()
+}
+
+// MoneyFlowIndexStream streams the money flow index
+type MoneyFlowIndexStream struct {
+	period   int
+	count    int
+	slot     int
+	previous float64
+	positive float64
+	negative float64
+	flows    [2][]float64
+}
+
+// NewMoneyFlowIndexStream returns a streaming money flow index
+func NewMoneyFlowIndexStream(period int64) (*MoneyFlowIndexStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new money flow index stream %w", errInvalidPeriod)
+	}
+	return &MoneyFlowIndexStream{
+		period: int(period),
+		flows:  [2][]float64{make([]float64, period), make([]float64, period)},
+	}, nil
+}
+
+// Update commits a closed candle and returns the money flow index
+func (m *MoneyFlowIndexStream) Update(c Candle) []float64 {
+	index := m.count
+	m.count++
+	typical := (c.High + c.Low + c.Close) / 3.0
+	if index == 0 {
+		m.previous = typical
+		return []float64{0}
+	}
+	if index > m.period {
+		m.positive -= m.flows[0][m.slot]
+		m.negative -= m.flows[1][m.slot]
+	}
+	difference := typical - m.previous
+	m.previous = typical
+	flow := typical * c.Volume
+	switch {
+	case difference < 0:
+		m.flows[1][m.slot] = flow
+		m.negative += flow
+		m.flows[0][m.slot] = 0.0
+	case difference > 0:
+		m.flows[0][m.slot] = flow
+		m.positive += flow
+		m.flows[1][m.slot] = 0.0
+	default:
+		m.flows[0][m.slot] = 0.0
+		m.flows[1][m.slot] = 0.0
+	}
+	m.slot++
+	if m.slot >= m.period {
+		m.slot = 0
+	}
+	if index < m.period {
+		return []float64{0}
+	}
+	total := m.positive + m.negative
+	if total < 1.0 {
+		return []float64{0}
+	}
+	return []float64{100.0 * (m.positive / total)}
+}
+
+// Peek returns the money flow index including an in-progress candle. The
+// preview shares the flow windows so only the slot it overwrites is restored
+func (m *MoneyFlowIndexStream) Peek(c Candle) []float64 {
+	positive, negative := m.flows[0][m.slot], m.flows[1][m.slot]
+	preview := *m
+	values := preview.Update(c)
+	m.flows[0][m.slot], m.flows[1][m.slot] = positive, negative
+	return values
+}
+
+// Ready returns whether a full period of money flow has been committed
+func (m *MoneyFlowIndexStream) Ready() bool {
+	return m.count > m.period
+}
+
+// OnBalanceVolumeStream streams the on balance volume
+type OnBalanceVolumeStream struct {
+	count         int
+	previousClose float64
+	value         float64
+}
+
+// NewOnBalanceVolumeStream returns a streaming on balance volume
+func NewOnBalanceVolumeStream() *OnBalanceVolumeStream {
+	return &OnBalanceVolumeStream{}
+}
+
+// Update commits a closed candle and returns the on balance volume
+func (o *OnBalanceVolumeStream) Update(c Candle) []float64 {
+	if o.count > 0 {
+		switch {
+		case c.Close > o.previousClose:
+			o.value += c.Volume
+		case c.Close < o.previousClose:
+			o.value -= c.Volume
+		case c.Close != o.previousClose:
+			// NaN comparisons leave the batch value unset
+			o.value = 0
+		}
+	}
+	o.count++
+	o.previousClose = c.Close
+	return []float64{o.value}
+}
+
+// Peek returns the on balance volume including an in-progress candle
+func (o *OnBalanceVolumeStream) Peek(c Candle) []float64 {
+	preview := *o
+	return preview.Update(c)
+}
+
+// Ready returns whether a candle has been committed
+func (o *OnBalanceVolumeStream) Ready() bool {
+	return o.count > 0
+}
+
+// CorrelationCoefficientStream streams the correlation coefficient between the
+// close prices of two candle sets. It consumes a candle from each set per
+// update so it does not implement IndicatorStream. As with the batch
+// calculation a window in which either set has no variance can return NaN.
+type CorrelationCoefficientStream struct {
+	closes      *ringBuffer
+	other       *ringBuffer
+	window      []float64
+	otherWindow []float64
+}
+
+// NewCorrelationCoefficientStream returns a streaming correlation coefficient
+func NewCorrelationCoefficientStream(period int64) (*CorrelationCoefficientStream, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("new correlation coefficient stream %w", errInvalidPeriod)
+	}
+	if period == 1 {
+		return nil, fmt.Errorf("new correlation coefficient stream %w using period 1 results in NaN return",
+			errInvalidPeriod)
+	}
+	return &CorrelationCoefficientStream{
+		closes:      newRingBuffer(int(period)),
+		other:       newRingBuffer(int(period)),
+		window:      make([]float64, 0, period),
+		otherWindow: make([]float64, 0, period),
+	}, nil
+}
+
+// Update commits a closed candle from each set and returns the correlation
+// coefficient
+func (s *CorrelationCoefficientStream) Update(c, other Candle) float64 {
+	value := s.Peek(c, other)
+	s.closes.push(c.Close)
+	s.other.push(other.Close)
+	return value
+}
+
+// Peek returns the correlation coefficient including an in-progress candle
+// from each set, zero during the warm up period
+func (s *CorrelationCoefficientStream) Peek(c, other Candle) float64 {
+	if !s.closes.fullAfterPush() {
+		return 0
+	}
+	s.window = s.closes.windowAfterPush(s.window, c.Close)
+	s.otherWindow = s.other.windowAfterPush(s.otherWindow, other.Close)
+	return correlation(s.window, s.otherWindow)
+}
+
+// Ready returns whether a full period of candles has been committed
+func (s *CorrelationCoefficientStream) Ready() bool {
+	return s.closes.full()
+}
+
+// correlation returns the correlation coefficient of two windows of close
+// prices in the same order of operations as the batch calculation
+func correlation(c1, c2 []float64) float64 {
+	var sumx, sumy float64
+	for i := range c1 {
+		sumx += c1[i]
+	}
+	for i := range c2 {
+		sumy += c2[i]
+	}
+	var sumxy, sumpx, sumpy float64
+	for i := range c1 {
+		sumxy += c1[i] * c2[i]
+		sumpx += math.Pow(c1[i], 2)
+		sumpy += math.Pow(c2[i], 2)
+	}
+	l := float64(len(c1))
+	return (l*sumxy - (sumx * sumy)) /
+		(math.Sqrt((l*sumpx - math.Pow(sumx, 2)) * (l*sumpy - math.Pow(sumy, 2))))
+}
+
+// emaState is an exponential moving average seeded by the simple average of
+// its first period
+type emaState struct {
+	period     int
+	count      int
+	multiplier float64
+	value      float64
+	seed       float64
+}
+
+func newEMAState(period int) emaState {
+	return emaState{
+		period:     period,
+		multiplier: 2.0 / (float64(period) + 1.0),
+	}
+}
+
+// next adds a value and returns the average and whether it has been seeded
+func (e *emaState) next(v float64) (float64, bool) {
+	e.count++
+	switch {
+	case e.count < e.period:
+		e.seed += v
+		return 0, false
+	case e.count == e.period:
+		e.seed += v
+		e.value = e.seed / float64(e.period)
+	default:
+		e.value = (v-e.value)*e.multiplier + e.value
+	}
+	return e.value, true
+}
+
+func (e *emaState) ready() bool {
+	return e.count >= e.period
+}
+
+// ringBuffer holds a fixed number of the most recent values
+type ringBuffer struct {
+	values []float64
+	start  int
+	length int
+}
+
+func newRingBuffer(size int) *ringBuffer {
+	return &ringBuffer{values: make([]float64, size)}
+}
+
+// push adds a value, evicting the oldest value once the buffer is full
+func (r *ringBuffer) push(v float64) {
+	if r.length < len(r.values) {
+		r.values[(r.start+r.length)%len(r.values)] = v
+		r.length++
+		return
+	}
+	r.values[r.start] = v
+	r.start = (r.start + 1) % len(r.values)
+}
+
+// windowAfterPush writes the values held once another value is pushed to dst
+// in the order they were pushed and returns it
+func (r *ringBuffer) windowAfterPush(dst []float64, v float64) []float64 {
+	dst = dst[:0]
+	var skip int
+	if r.full() {
+		skip = 1
+	}
+	for i := skip; i < r.length; i++ {
+		dst = append(dst, r.values[(r.start+i)%len(r.values)])
+	}
+	return append(dst, v)
+}
+
+// oldest returns the value the next push evicts, zero until the buffer is full
+func (r *ringBuffer) oldest() float64 {
+	if !r.full() {
+		return 0
+	}
+	return r.values[r.start]
+}
+
+func (r *ringBuffer) full() bool {
+	return r.length == len(r.values)
+}
+
+// fullAfterPush returns whether the buffer is full once another value is
+// pushed
+func (r *ringBuffer) fullAfterPush() bool {
+	return r.length+1 >= len(r.values)
+}
+
+// candleTrueRange returns the true range in the same order of operations as
+// the batch average true range
+func candleTrueRange(high, low, previousClose float64) float64 {
+	greatest := high - low
+	if v := math.Abs(previousClose - high); v > greatest {
+		greatest = v
+	}
+	if v := math.Abs(previousClose - low); v > greatest {
+		greatest = v
+	}
+	return greatest
+}
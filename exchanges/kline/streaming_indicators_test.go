@@ -0,0 +1,318 @@
+package kline
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gct-ta/indicators"
+)
+
+// randomWalkItem returns deterministic candles following a random walk
+func randomWalkItem(length int) *Item {
+	r := rand.New(rand.NewSource(1337)) //nolint:gosec // no need to import crypo/rand for testing
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := &Item{Interval: OneHour}
+	price := 1000.0
+	for i := 0; i < length; i++ {
+		open := price
+		price += (r.Float64() - 0.5) * 20
+		k.Candles = append(k.Candles, Candle{
+			Time:   start.Add(OneHour.Duration() * time.Duration(i)),
+			Open:   open,
+			High:   math.Max(open, price) + r.Float64()*5,
+			Low:    math.Min(open, price) - r.Float64()*5,
+			Close:  price,
+			Volume: r.Float64() * 100,
+		})
+	}
+	return k
+}
+
+// assertStreamMatches feeds each candle to the stream and checks the peeked
+// and committed values exactly match the batch series
+func assertStreamMatches(t *testing.T, s IndicatorStream, candles []Candle, expected ...[]float64) {
+	t.Helper()
+	for i := range candles {
+		distorted := candles[i]
+		distorted.Close *= 1.1
+		distorted.High *= 1.1
+		s.Peek(distorted)
+		peeked := s.Peek(candles[i])
+		values := s.Update(candles[i])
+		if len(values) != len(expected) {
+			t.Fatalf("received '%v' values, expected '%v'", len(values), len(expected))
+		}
+		for j := range expected {
+			if values[j] != expected[j][i] {
+				t.Fatalf("candle %v value %v received '%v', expected '%v'", i, j, values[j], expected[j][i])
+			}
+			if peeked[j] != values[j] {
+				t.Fatalf("candle %v value %v peeked '%v', expected '%v'", i, j, peeked[j], values[j])
+			}
+		}
+	}
+	if !s.Ready() {
+		t.Error("expected stream to be ready")
+	}
+}
+
+// sameFloat returns whether two values are equal, treating NaN as equal to
+// itself
+func sameFloat(a, b float64) bool {
+	return a == b || (math.IsNaN(a) && math.IsNaN(b))
+}
+
+func TestSimpleMovingAverageStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewSimpleMovingAverageStream(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	expected, err := k.GetSimpleMovingAverageOnClose(9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewSimpleMovingAverageStream(9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if s.Ready() {
+		t.Error("expected stream not to be ready")
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+}
+
+func TestExponentialMovingAverageStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewExponentialMovingAverageStream(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	expected, err := k.GetExponentialMovingAverageOnClose(9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewExponentialMovingAverageStream(9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+}
+
+func TestRelativeStrengthIndexStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewRelativeStrengthIndexStream(1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	expected, err := k.GetRelativeStrengthIndexOnClose(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewRelativeStrengthIndexStream(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+}
+
+func TestAverageTrueRangeStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewAverageTrueRangeStream(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	for _, period := range []int64{1, 14} {
+		expected, err := k.GetAverageTrueRange(period)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		s, err := NewAverageTrueRangeStream(period)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		assertStreamMatches(t, s, k.Candles, expected)
+	}
+}
+
+func TestBollingerBandsStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewBollingerBandsStream(0, 2, 2, indicators.Sma)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = NewBollingerBandsStream(20, 0, 2, indicators.Sma)
+	if !errors.Is(err, errInvalidDeviationMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidDeviationMultiplier)
+	}
+	_, err = NewBollingerBandsStream(20, 2, 0, indicators.Sma)
+	if !errors.Is(err, errInvalidDeviationMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidDeviationMultiplier)
+	}
+	k := randomWalkItem(200)
+	for _, tc := range []struct {
+		period   int64
+		up, down float64
+		maType   indicators.MaType
+	}{
+		{20, 2, 2, indicators.Sma},
+		{20, 1, 1, indicators.Ema},
+		{10, 1, 2.5, indicators.Sma},
+		{10, 1.5, 1, indicators.Ema},
+		{1, 2, 1.5, indicators.Sma},
+	} {
+		expected, err := k.GetBollingerBands(tc.period, tc.up, tc.down, tc.maType)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		s, err := NewBollingerBandsStream(tc.period, tc.up, tc.down, tc.maType)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		assertStreamMatches(t, s, k.Candles, expected.Upper, expected.Middle, expected.Lower)
+	}
+}
+
+func TestMACDStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewMACDStream(0, 26, 9)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = NewMACDStream(12, 0, 9)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = NewMACDStream(26, 12, 9)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = NewMACDStream(12, 26, 0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	expected, err := k.GetMovingAverageConvergenceDivergenceOnClose(12, 26, 9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewMACDStream(12, 26, 9)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	assertStreamMatches(t, s, k.Candles, expected.Results, expected.SignalVals, expected.Histogram)
+}
+
+func TestMoneyFlowIndexStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewMoneyFlowIndexStream(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	expected, err := k.GetMoneyFlowIndex(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewMoneyFlowIndexStream(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+
+	// less than one unit of money flow in the first window
+	for i := 0; i <= 14; i++ {
+		k.Candles[i].Volume = 0
+	}
+	expected, err = k.GetMoneyFlowIndex(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err = NewMoneyFlowIndexStream(14)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+}
+
+func TestOnBalanceVolumeStream(t *testing.T) {
+	t.Parallel()
+	k := randomWalkItem(200)
+	k.Candles[10].Close = k.Candles[9].Close
+	expected, err := k.GetOnBalanceVolume()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s := NewOnBalanceVolumeStream()
+	if s.Ready() {
+		t.Error("expected stream not to be ready")
+	}
+	assertStreamMatches(t, s, k.Candles, expected)
+}
+
+func TestCorrelationCoefficientStream(t *testing.T) {
+	t.Parallel()
+	_, err := NewCorrelationCoefficientStream(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = NewCorrelationCoefficientStream(1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	k := randomWalkItem(200)
+	other := randomWalkItem(400)
+	other.Candles = other.Candles[200:]
+	expected, err := k.GetCorrelationCoefficient(other, 20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewCorrelationCoefficientStream(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for i := range k.Candles {
+		peeked := s.Peek(k.Candles[i], other.Candles[i])
+		value := s.Update(k.Candles[i], other.Candles[i])
+		if value != expected[i] || peeked != value {
+			t.Fatalf("candle %v received '%v' peeked '%v', expected '%v'", i, value, peeked, expected[i])
+		}
+	}
+	if !s.Ready() {
+		t.Error("expected stream to be ready")
+	}
+}
+
+func TestCorrelationCoefficientStreamNoVariance(t *testing.T) {
+	t.Parallel()
+	k := randomWalkItem(100)
+	flat := &Item{Candles: make([]Candle, len(k.Candles))}
+	for i := range flat.Candles {
+		flat.Candles[i].Close = 2
+	}
+	expected, err := k.GetCorrelationCoefficient(flat, 20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s, err := NewCorrelationCoefficientStream(20)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	for i := range k.Candles {
+		peeked := s.Peek(k.Candles[i], flat.Candles[i])
+		value := s.Update(k.Candles[i], flat.Candles[i])
+		if !sameFloat(value, expected[i]) || !sameFloat(peeked, value) {
+			t.Fatalf("candle %v received '%v' peeked '%v', expected '%v'", i, value, peeked, expected[i])
+		}
+		if i >= 19 && !math.IsNaN(value) {
+			t.Fatalf("candle %v received '%v', expected NaN", i, value)
+		}
+	}
+}
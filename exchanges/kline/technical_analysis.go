@@ -304,7 +304,36 @@ func (o *OHLC) GetMoneyFlowIndex(period int64) ([]float64, error) {
 		return nil, fmt.Errorf("get money flow index %w '%v' should not exceed or equal close data length '%v'",
 			errInvalidPeriod, period, len(o.Close))
 	}
-	return indicators.MFI(o.High, o.Low, o.Close, o.Volume, int(period)), nil
+	mfi := indicators.MFI(o.High, o.Low, o.Close, o.Volume, int(period))
+	if o.firstMoneyFlow(int(period)) < 1.0 {
+		// The batch calculation does not output the first full window when
+		// it holds less than one unit of money flow, which shifts every later
+		// value back by one candle. Realign them to the candles which
+		// produced them.
+		copy(mfi[period+1:], mfi[period:])
+		mfi[period] = 0
+	}
+	return mfi, nil
+}
+
+// firstMoneyFlow returns the total money flow of the first full window in the
+// same order of operations as the batch money flow index
+func (o *OHLC) firstMoneyFlow(period int) float64 {
+	var positive, negative float64
+	previous := (o.High[0] + o.Low[0] + o.Close[0]) / 3.0
+	for i := 1; i <= period; i++ {
+		typical := (o.High[i] + o.Low[i] + o.Close[i]) / 3.0
+		difference := typical - previous
+		previous = typical
+		typical *= o.Volume[i]
+		switch {
+		case difference < 0:
+			negative += typical
+		case difference > 0:
+			positive += typical
+		}
+	}
+	return positive + negative
 }
 
 // GetOnBalanceVolume returns On Balance Volume.
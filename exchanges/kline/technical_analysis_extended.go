@@ -0,0 +1,783 @@
+package kline
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// minimumHurstPeriod is the fewest returns a Hurst exponent window can hold,
+// allowing rescaled ranges over at least two sub-window sizes
+const minimumHurstPeriod = 16
+
+var (
+	errInvalidMultiplier         = errors.New("invalid multiplier")
+	errInvalidAccelerationFactor = errors.New("invalid acceleration factor")
+	errInvalidAnchor             = errors.New("invalid anchor")
+	errNonPositivePrice          = errors.New("price must be greater than zero")
+)
+
+// Stochastic defines a return type for the stochastic oscillator, K is the
+// smoothed %K line and D is its signal line
+type Stochastic struct {
+	K []float64
+	D []float64
+}
+
+// DirectionalMovement defines a return type for the directional movement
+// index
+type DirectionalMovement struct {
+	PlusDI  []float64
+	MinusDI []float64
+	ADX     []float64
+}
+
+// Ichimoku defines a return type for the Ichimoku cloud. Leading spans are
+// shifted forward by the displacement so each value is the cloud projected
+// onto its candle, the lagging span is the close price displacement candles
+// ahead
+type Ichimoku struct {
+	ConversionLine []float64
+	BaseLine       []float64
+	LeadingSpanA   []float64
+	LeadingSpanB   []float64
+	LaggingSpan    []float64
+}
+
+// Supertrend defines a return type for the supertrend indicator. Direction is
+// 1 during an uptrend and -1 during a downtrend
+type Supertrend struct {
+	Trend     []float64
+	Direction []float64
+}
+
+// Channel defines a return type for price channels
+type Channel struct {
+	Upper  []float64
+	Middle []float64
+	Lower  []float64
+}
+
+// GetStochastic returns the stochastic oscillator for the given %K period,
+// %K smoothing period and %D period.
+func (k *Item) GetStochastic(kPeriod, kSmoothing, dPeriod int64) (*Stochastic, error) {
+	return k.GetOHLC().GetStochastic(kPeriod, kSmoothing, dPeriod)
+}
+
+// GetStochastic returns the stochastic oscillator for the given %K period,
+// %K smoothing period and %D period.
+func (o *OHLC) GetStochastic(kPeriod, kSmoothing, dPeriod int64) (*Stochastic, error) {
+	if kPeriod <= 0 || kSmoothing <= 0 || dPeriod <= 0 {
+		return nil, fmt.Errorf("get stochastic %w", errInvalidPeriod)
+	}
+	if err := o.validateHLC("get stochastic", kPeriod+kSmoothing+dPeriod-2); err != nil {
+		return nil, err
+	}
+	fastK := stochasticRange(o.Close, rollingMax(o.High, int(kPeriod), 0), rollingMin(o.Low, int(kPeriod), 0), int(kPeriod-1))
+	return smoothStochastic(fastK, int(kPeriod-1), int(kSmoothing), int(dPeriod)), nil
+}
+
+// GetStochasticRelativeStrengthIndexOnClose returns the stochastic RSI of the
+// close price set.
+func (k *Item) GetStochasticRelativeStrengthIndexOnClose(rsiPeriod, stochasticPeriod, kSmoothing, dPeriod int64) (*Stochastic, error) {
+	ohlc := k.GetOHLC()
+	return ohlc.GetStochasticRelativeStrengthIndex(ohlc.Close, rsiPeriod, stochasticPeriod, kSmoothing, dPeriod)
+}
+
+// GetStochasticRelativeStrengthIndex returns the stochastic oscillator applied
+// to the relative strength index of the supplied price set.
+func (o *OHLC) GetStochasticRelativeStrengthIndex(option []float64, rsiPeriod, stochasticPeriod, kSmoothing, dPeriod int64) (*Stochastic, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get stochastic rsi %w", errNilOHLC)
+	}
+	if rsiPeriod <= 1 {
+		return nil, fmt.Errorf("get stochastic rsi %w rsi period cannot be equal or below 1", errInvalidPeriod)
+	}
+	if stochasticPeriod <= 0 || kSmoothing <= 0 || dPeriod <= 0 {
+		return nil, fmt.Errorf("get stochastic rsi %w", errInvalidPeriod)
+	}
+	if len(option) == 0 {
+		return nil, fmt.Errorf("get stochastic rsi %w", errNoData)
+	}
+	if minimum := rsiPeriod + stochasticPeriod + kSmoothing + dPeriod - 2; int64(len(option)) < minimum {
+		return nil, fmt.Errorf("get stochastic rsi %w %v data points are less than minimum %v",
+			errNotEnoughData, len(option), minimum)
+	}
+	rsi := relativeStrength(option, int(rsiPeriod))
+	start := int(rsiPeriod + stochasticPeriod - 1)
+	raw := stochasticRange(rsi,
+		rollingMax(rsi, int(stochasticPeriod), int(rsiPeriod)),
+		rollingMin(rsi, int(stochasticPeriod), int(rsiPeriod)),
+		start)
+	return smoothStochastic(raw, start, int(kSmoothing), int(dPeriod)), nil
+}
+
+// GetDirectionalMovementIndex returns the +DI, -DI and average directional
+// index for the given period.
+func (k *Item) GetDirectionalMovementIndex(period int64) (*DirectionalMovement, error) {
+	return k.GetOHLC().GetDirectionalMovementIndex(period)
+}
+
+// GetDirectionalMovementIndex returns the +DI, -DI and average directional
+// index for the given period.
+func (o *OHLC) GetDirectionalMovementIndex(period int64) (*DirectionalMovement, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("get directional movement index %w", errInvalidPeriod)
+	}
+	if err := o.validateHLC("get directional movement index", 2*period); err != nil {
+		return nil, err
+	}
+	plusDM := make([]float64, len(o.Close))
+	minusDM := make([]float64, len(o.Close))
+	for i := 1; i < len(o.Close); i++ {
+		up := o.High[i] - o.High[i-1]
+		down := o.Low[i-1] - o.Low[i]
+		if up > down && up > 0 {
+			plusDM[i] = up
+		}
+		if down > up && down > 0 {
+			minusDM[i] = down
+		}
+	}
+	p := int(period)
+	tr := wilderAverage(trueRange(o.High, o.Low, o.Close), p, 1)
+	smoothedPlus := wilderAverage(plusDM, p, 1)
+	smoothedMinus := wilderAverage(minusDM, p, 1)
+	resp := &DirectionalMovement{
+		PlusDI:  make([]float64, len(o.Close)),
+		MinusDI: make([]float64, len(o.Close)),
+	}
+	dx := make([]float64, len(o.Close))
+	for i := p; i < len(o.Close); i++ {
+		if tr[i] == 0 {
+			continue
+		}
+		resp.PlusDI[i] = 100 * smoothedPlus[i] / tr[i]
+		resp.MinusDI[i] = 100 * smoothedMinus[i] / tr[i]
+		if sum := resp.PlusDI[i] + resp.MinusDI[i]; sum != 0 {
+			dx[i] = 100 * math.Abs(resp.PlusDI[i]-resp.MinusDI[i]) / sum
+		}
+	}
+	resp.ADX = wilderAverage(dx, p, p)
+	return resp, nil
+}
+
+// GetIchimokuCloud returns the Ichimoku cloud for the given conversion line,
+// base line and leading span B periods and displacement.
+func (k *Item) GetIchimokuCloud(conversionPeriod, basePeriod, spanBPeriod, displacement int64) (*Ichimoku, error) {
+	return k.GetOHLC().GetIchimokuCloud(conversionPeriod, basePeriod, spanBPeriod, displacement)
+}
+
+// GetIchimokuCloud returns the Ichimoku cloud for the given conversion line,
+// base line and leading span B periods and displacement.
+func (o *OHLC) GetIchimokuCloud(conversionPeriod, basePeriod, spanBPeriod, displacement int64) (*Ichimoku, error) {
+	if conversionPeriod <= 0 || basePeriod <= 0 || spanBPeriod <= 0 {
+		return nil, fmt.Errorf("get ichimoku cloud %w", errInvalidPeriod)
+	}
+	if displacement < 0 {
+		return nil, fmt.Errorf("get ichimoku cloud %w displacement cannot be negative", errInvalidPeriod)
+	}
+	longest := spanBPeriod
+	if basePeriod > longest {
+		longest = basePeriod
+	}
+	if conversionPeriod > longest {
+		longest = conversionPeriod
+	}
+	if err := o.validateHLC("get ichimoku cloud", longest); err != nil {
+		return nil, err
+	}
+	resp := &Ichimoku{
+		ConversionLine: midpoint(o.High, o.Low, int(conversionPeriod)),
+		BaseLine:       midpoint(o.High, o.Low, int(basePeriod)),
+		LeadingSpanA:   make([]float64, len(o.Close)),
+		LeadingSpanB:   make([]float64, len(o.Close)),
+		LaggingSpan:    make([]float64, len(o.Close)),
+	}
+	spanB := midpoint(o.High, o.Low, int(spanBPeriod))
+	spanAStart := int(conversionPeriod - 1)
+	if basePeriod > conversionPeriod {
+		spanAStart = int(basePeriod - 1)
+	}
+	d := int(displacement)
+	for i := range o.Close {
+		if j := i - d; j >= spanAStart {
+			resp.LeadingSpanA[i] = (resp.ConversionLine[j] + resp.BaseLine[j]) / 2
+		}
+		if j := i - d; j >= int(spanBPeriod-1) {
+			resp.LeadingSpanB[i] = spanB[j]
+		}
+		if j := i + d; j < len(o.Close) {
+			resp.LaggingSpan[i] = o.Close[j]
+		}
+	}
+	return resp, nil
+}
+
+// GetSupertrend returns the supertrend for the given average true range period
+// and multiplier.
+func (k *Item) GetSupertrend(period int64, multiplier float64) (*Supertrend, error) {
+	return k.GetOHLC().GetSupertrend(period, multiplier)
+}
+
+// GetSupertrend returns the supertrend for the given average true range period
+// and multiplier.
+func (o *OHLC) GetSupertrend(period int64, multiplier float64) (*Supertrend, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("get supertrend %w", errInvalidPeriod)
+	}
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("get supertrend %w", errInvalidMultiplier)
+	}
+	if err := o.validateHLC("get supertrend", period); err != nil {
+		return nil, err
+	}
+	atr := wilderAverage(trueRange(o.High, o.Low, o.Close), int(period), 0)
+	resp := &Supertrend{
+		Trend:     make([]float64, len(o.Close)),
+		Direction: make([]float64, len(o.Close)),
+	}
+	var finalUpper, finalLower float64
+	for i := int(period - 1); i < len(o.Close); i++ {
+		hl2 := (o.High[i] + o.Low[i]) / 2
+		upper := hl2 + multiplier*atr[i]
+		lower := hl2 - multiplier*atr[i]
+		if i == int(period-1) {
+			finalUpper, finalLower = upper, lower
+			resp.Direction[i] = -1
+			if o.Close[i] > hl2 {
+				resp.Direction[i] = 1
+			}
+		} else {
+			if upper < finalUpper || o.Close[i-1] > finalUpper {
+				finalUpper = upper
+			}
+			if lower > finalLower || o.Close[i-1] < finalLower {
+				finalLower = lower
+			}
+			resp.Direction[i] = resp.Direction[i-1]
+			switch {
+			case resp.Direction[i] < 0 && o.Close[i] > finalUpper:
+				resp.Direction[i] = 1
+			case resp.Direction[i] > 0 && o.Close[i] < finalLower:
+				resp.Direction[i] = -1
+			}
+		}
+		resp.Trend[i] = finalLower
+		if resp.Direction[i] < 0 {
+			resp.Trend[i] = finalUpper
+		}
+	}
+	return resp, nil
+}
+
+// GetKeltnerChannels returns Keltner channels around an exponential moving
+// average of the close price for the given period using the average true range
+// of the ATR period scaled by the multiplier.
+func (k *Item) GetKeltnerChannels(period, atrPeriod int64, multiplier float64) (*Channel, error) {
+	return k.GetOHLC().GetKeltnerChannels(period, atrPeriod, multiplier)
+}
+
+// GetKeltnerChannels returns Keltner channels around an exponential moving
+// average of the close price for the given period using the average true range
+// of the ATR period scaled by the multiplier.
+func (o *OHLC) GetKeltnerChannels(period, atrPeriod int64, multiplier float64) (*Channel, error) {
+	if period <= 0 || atrPeriod <= 0 {
+		return nil, fmt.Errorf("get keltner channels %w", errInvalidPeriod)
+	}
+	if multiplier <= 0 {
+		return nil, fmt.Errorf("get keltner channels %w", errInvalidMultiplier)
+	}
+	longest := period
+	if atrPeriod > longest {
+		longest = atrPeriod
+	}
+	if err := o.validateHLC("get keltner channels", longest); err != nil {
+		return nil, err
+	}
+	atr := wilderAverage(trueRange(o.High, o.Low, o.Close), int(atrPeriod), 0)
+	resp := &Channel{
+		Upper:  make([]float64, len(o.Close)),
+		Middle: exponentialAverage(o.Close, int(period)),
+		Lower:  make([]float64, len(o.Close)),
+	}
+	for i := int(longest - 1); i < len(o.Close); i++ {
+		resp.Upper[i] = resp.Middle[i] + multiplier*atr[i]
+		resp.Lower[i] = resp.Middle[i] - multiplier*atr[i]
+	}
+	return resp, nil
+}
+
+// GetDonchianChannels returns the highest high, lowest low and their midpoint
+// for the given period.
+func (k *Item) GetDonchianChannels(period int64) (*Channel, error) {
+	return k.GetOHLC().GetDonchianChannels(period)
+}
+
+// GetDonchianChannels returns the highest high, lowest low and their midpoint
+// for the given period.
+func (o *OHLC) GetDonchianChannels(period int64) (*Channel, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("get donchian channels %w", errInvalidPeriod)
+	}
+	if err := o.validateHLC("get donchian channels", period); err != nil {
+		return nil, err
+	}
+	resp := &Channel{
+		Upper:  rollingMax(o.High, int(period), 0),
+		Middle: make([]float64, len(o.Close)),
+		Lower:  rollingMin(o.Low, int(period), 0),
+	}
+	for i := int(period - 1); i < len(o.Close); i++ {
+		resp.Middle[i] = (resp.Upper[i] + resp.Lower[i]) / 2
+	}
+	return resp, nil
+}
+
+// GetParabolicSAR returns the parabolic stop and reverse for the given
+// acceleration factor step and maximum.
+func (k *Item) GetParabolicSAR(step, maximum float64) ([]float64, error) {
+	return k.GetOHLC().GetParabolicSAR(step, maximum)
+}
+
+// GetParabolicSAR returns the parabolic stop and reverse for the given
+// acceleration factor step and maximum.
+func (o *OHLC) GetParabolicSAR(step, maximum float64) ([]float64, error) {
+	if step <= 0 {
+		return nil, fmt.Errorf("get parabolic sar %w step", errInvalidAccelerationFactor)
+	}
+	if maximum < step {
+		return nil, fmt.Errorf("get parabolic sar %w maximum should not be below step", errInvalidAccelerationFactor)
+	}
+	if err := o.validateHLC("get parabolic sar", 2); err != nil {
+		return nil, err
+	}
+	sar := make([]float64, len(o.Close))
+	rising := o.High[1]+o.Low[1] >= o.High[0]+o.Low[0]
+	sar[1] = o.High[0]
+	extreme := o.Low[1]
+	if rising {
+		sar[1], extreme = o.Low[0], o.High[1]
+	}
+	af := step
+	for i := 2; i < len(o.Close); i++ {
+		next := sar[i-1] + af*(extreme-sar[i-1])
+		if rising {
+			next = math.Min(next, math.Min(o.Low[i-1], o.Low[i-2]))
+			if o.Low[i] < next {
+				rising, next, extreme, af = false, extreme, o.Low[i], step
+			} else if o.High[i] > extreme {
+				extreme, af = o.High[i], math.Min(af+step, maximum)
+			}
+		} else {
+			next = math.Max(next, math.Max(o.High[i-1], o.High[i-2]))
+			if o.High[i] > next {
+				rising, next, extreme, af = true, extreme, o.High[i], step
+			} else if o.Low[i] < extreme {
+				extreme, af = o.Low[i], math.Min(af+step, maximum)
+			}
+		}
+		sar[i] = next
+	}
+	return sar, nil
+}
+
+// GetCommodityChannelIndex returns the commodity channel index for the given
+// period.
+func (k *Item) GetCommodityChannelIndex(period int64) ([]float64, error) {
+	return k.GetOHLC().GetCommodityChannelIndex(period)
+}
+
+// GetCommodityChannelIndex returns the commodity channel index for the given
+// period.
+func (o *OHLC) GetCommodityChannelIndex(period int64) ([]float64, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("get commodity channel index %w", errInvalidPeriod)
+	}
+	if err := o.validateHLC("get commodity channel index", period); err != nil {
+		return nil, err
+	}
+	typical := make([]float64, len(o.Close))
+	for i := range o.Close {
+		typical[i] = (o.High[i] + o.Low[i] + o.Close[i]) / 3
+	}
+	p := int(period)
+	resp := make([]float64, len(o.Close))
+	for i := p - 1; i < len(o.Close); i++ {
+		window := typical[i-p+1 : i+1]
+		mean := average(window)
+		var deviation float64
+		for j := range window {
+			deviation += math.Abs(window[j] - mean)
+		}
+		deviation /= float64(p)
+		if deviation != 0 {
+			resp[i] = (typical[i] - mean) / (0.015 * deviation)
+		}
+	}
+	return resp, nil
+}
+
+// GetWilliamsPercentRange returns Williams %R for the given period. Values
+// range from -100 at the period low to 0 at the period high.
+func (k *Item) GetWilliamsPercentRange(period int64) ([]float64, error) {
+	return k.GetOHLC().GetWilliamsPercentRange(period)
+}
+
+// GetWilliamsPercentRange returns Williams %R for the given period. Values
+// range from -100 at the period low to 0 at the period high.
+func (o *OHLC) GetWilliamsPercentRange(period int64) ([]float64, error) {
+	if period <= 0 {
+		return nil, fmt.Errorf("get williams percent range %w", errInvalidPeriod)
+	}
+	if err := o.validateHLC("get williams percent range", period); err != nil {
+		return nil, err
+	}
+	resp := stochasticRange(o.Close, rollingMax(o.High, int(period), 0), rollingMin(o.Low, int(period), 0), int(period-1))
+	for i := int(period - 1); i < len(resp); i++ {
+		resp[i] -= 100
+	}
+	return resp, nil
+}
+
+// GetAnchoredVolumeWeightedAveragePrice returns the volume weighted average
+// typical price accumulated from the first candle at or after the anchor time.
+func (k *Item) GetAnchoredVolumeWeightedAveragePrice(anchor time.Time) ([]float64, error) {
+	for i := range k.Candles {
+		if !k.Candles[i].Time.Before(anchor) {
+			return k.GetOHLC().GetAnchoredVolumeWeightedAveragePrice(int64(i))
+		}
+	}
+	return nil, fmt.Errorf("get anchored vwap %w no candles at or after %v", errInvalidAnchor, anchor)
+}
+
+// GetAnchoredVolumeWeightedAveragePrice returns the volume weighted average
+// typical price accumulated from the anchor index.
+func (o *OHLC) GetAnchoredVolumeWeightedAveragePrice(anchor int64) ([]float64, error) {
+	if err := o.validateHLC("get anchored vwap", 1); err != nil {
+		return nil, err
+	}
+	if len(o.Volume) != len(o.Close) {
+		return nil, fmt.Errorf("get anchored vwap volume %w", errInvalidDataSetLengths)
+	}
+	if anchor < 0 || int(anchor) >= len(o.Close) {
+		return nil, fmt.Errorf("get anchored vwap %w '%v' should be within close data length '%v'",
+			errInvalidAnchor, anchor, len(o.Close))
+	}
+	resp := make([]float64, len(o.Close))
+	var cumulativeValue, cumulativeVolume float64
+	for i := int(anchor); i < len(o.Close); i++ {
+		typical := (o.High[i] + o.Low[i] + o.Close[i]) / 3
+		cumulativeValue += typical * o.Volume[i]
+		cumulativeVolume += o.Volume[i]
+		if cumulativeVolume == 0 {
+			resp[i] = typical
+			continue
+		}
+		resp[i] = cumulativeValue / cumulativeVolume
+	}
+	return resp, nil
+}
+
+// GetZScoreOnClose returns the rolling z-score of the close price set for the
+// given period.
+func (k *Item) GetZScoreOnClose(period int64) ([]float64, error) {
+	ohlc := k.GetOHLC()
+	return ohlc.GetZScore(ohlc.Close, period)
+}
+
+// GetZScore returns the number of standard deviations each value of the
+// supplied price set is from the mean of its rolling period.
+func (o *OHLC) GetZScore(option []float64, period int64) ([]float64, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get z-score %w", errNilOHLC)
+	}
+	if period <= 1 {
+		return nil, fmt.Errorf("get z-score %w cannot be equal or below 1", errInvalidPeriod)
+	}
+	if len(option) == 0 {
+		return nil, fmt.Errorf("get z-score %w", errNoData)
+	}
+	if int(period) > len(option) {
+		return nil, fmt.Errorf("get z-score %w exceeds data length, please reduce", errInvalidPeriod)
+	}
+	p := int(period)
+	resp := make([]float64, len(option))
+	for i := p - 1; i < len(option); i++ {
+		mean, deviation := meanAndDeviation(option[i-p+1 : i+1])
+		if deviation != 0 {
+			resp[i] = (option[i] - mean) / deviation
+		}
+	}
+	return resp, nil
+}
+
+// GetHurstExponentOnClose returns the rolling Hurst exponent of the close
+// price set for the given period of returns.
+func (k *Item) GetHurstExponentOnClose(period int64) ([]float64, error) {
+	ohlc := k.GetOHLC()
+	return ohlc.GetHurstExponent(ohlc.Close, period)
+}
+
+// GetHurstExponent returns the rolling Hurst exponent estimated by rescaled
+// range analysis of the log returns of the supplied price set over the given
+// period. Values above 0.5 indicate trending behaviour and values below 0.5
+// indicate mean reversion.
+func (o *OHLC) GetHurstExponent(option []float64, period int64) ([]float64, error) {
+	if o == nil {
+		return nil, fmt.Errorf("get hurst exponent %w", errNilOHLC)
+	}
+	if period < minimumHurstPeriod {
+		return nil, fmt.Errorf("get hurst exponent %w cannot be below %v", errInvalidPeriod, minimumHurstPeriod)
+	}
+	if len(option) == 0 {
+		return nil, fmt.Errorf("get hurst exponent %w", errNoData)
+	}
+	if int(period) >= len(option) {
+		return nil, fmt.Errorf("get hurst exponent %w '%v' should be below close data length '%v'",
+			errInvalidPeriod, period, len(option))
+	}
+	returns := make([]float64, len(option))
+	for i := range option {
+		if option[i] <= 0 {
+			return nil, fmt.Errorf("get hurst exponent %w", errNonPositivePrice)
+		}
+		if i > 0 {
+			returns[i] = math.Log(option[i] / option[i-1])
+		}
+	}
+	p := int(period)
+	resp := make([]float64, len(option))
+	for i := p; i < len(option); i++ {
+		resp[i] = hurstExponent(returns[i-p+1 : i+1])
+	}
+	return resp, nil
+}
+
+// validateHLC ensures high, low and close data are present, of equal length
+// and cover the minimum number of data points an indicator requires
+func (o *OHLC) validateHLC(indicator string, minimum int64) error {
+	if o == nil {
+		return fmt.Errorf("%s %w", indicator, errNilOHLC)
+	}
+	if len(o.High) == 0 {
+		return fmt.Errorf("%s high %w", indicator, errNoData)
+	}
+	if len(o.Low) == 0 {
+		return fmt.Errorf("%s low %w", indicator, errNoData)
+	}
+	if len(o.Close) == 0 {
+		return fmt.Errorf("%s close %w", indicator, errNoData)
+	}
+	if len(o.High) != len(o.Close) || len(o.Low) != len(o.Close) {
+		return fmt.Errorf("%s %w", indicator, errInvalidDataSetLengths)
+	}
+	if minimum > int64(len(o.Close)) {
+		return fmt.Errorf("%s %w %v data points are less than minimum %v",
+			indicator, errNotEnoughData, len(o.Close), minimum)
+	}
+	return nil
+}
+
+// trueRange returns the true range of each candle, the first candle uses its
+// high low range
+func trueRange(high, low, closePrices []float64) []float64 {
+	resp := make([]float64, len(closePrices))
+	for i := range closePrices {
+		resp[i] = high[i] - low[i]
+		if i > 0 {
+			resp[i] = math.Max(resp[i], math.Max(math.Abs(high[i]-closePrices[i-1]), math.Abs(low[i]-closePrices[i-1])))
+		}
+	}
+	return resp
+}
+
+// wilderAverage returns Wilder's smoothed average of data from the offset,
+// seeded with the simple average of the first period
+func wilderAverage(data []float64, period, offset int) []float64 {
+	resp := make([]float64, len(data))
+	start := offset + period - 1
+	if start >= len(data) {
+		return resp
+	}
+	resp[start] = average(data[offset : start+1])
+	for i := start + 1; i < len(data); i++ {
+		resp[i] = resp[i-1] + (data[i]-resp[i-1])/float64(period)
+	}
+	return resp
+}
+
+// exponentialAverage returns the exponential moving average of data seeded
+// with the simple average of the first period
+func exponentialAverage(data []float64, period int) []float64 {
+	resp := make([]float64, len(data))
+	if period > len(data) {
+		return resp
+	}
+	alpha := 2 / float64(period+1)
+	resp[period-1] = average(data[:period])
+	for i := period; i < len(data); i++ {
+		resp[i] = alpha*data[i] + (1-alpha)*resp[i-1]
+	}
+	return resp
+}
+
+// simpleAverage returns the simple moving average of data from the offset
+func simpleAverage(data []float64, period, offset int) []float64 {
+	resp := make([]float64, len(data))
+	for i := offset + period - 1; i < len(data); i++ {
+		resp[i] = average(data[i-period+1 : i+1])
+	}
+	return resp
+}
+
+func rollingMax(data []float64, period, offset int) []float64 {
+	resp := make([]float64, len(data))
+	for i := offset + period - 1; i < len(data); i++ {
+		resp[i] = data[i-period+1]
+		for j := i - period + 2; j <= i; j++ {
+			resp[i] = math.Max(resp[i], data[j])
+		}
+	}
+	return resp
+}
+
+func rollingMin(data []float64, period, offset int) []float64 {
+	resp := make([]float64, len(data))
+	for i := offset + period - 1; i < len(data); i++ {
+		resp[i] = data[i-period+1]
+		for j := i - period + 2; j <= i; j++ {
+			resp[i] = math.Min(resp[i], data[j])
+		}
+	}
+	return resp
+}
+
+// midpoint returns the middle of the highest high and lowest low of a period
+func midpoint(high, low []float64, period int) []float64 {
+	highest := rollingMax(high, period, 0)
+	lowest := rollingMin(low, period, 0)
+	resp := make([]float64, len(high))
+	for i := period - 1; i < len(resp); i++ {
+		resp[i] = (highest[i] + lowest[i]) / 2
+	}
+	return resp
+}
+
+// stochasticRange returns where each value sits between its highest and
+// lowest as a percentage from the start index, a flat range returns the
+// midpoint
+func stochasticRange(data, highest, lowest []float64, start int) []float64 {
+	resp := make([]float64, len(data))
+	for i := start; i < len(data); i++ {
+		if spread := highest[i] - lowest[i]; spread != 0 {
+			resp[i] = 100 * (data[i] - lowest[i]) / spread
+		} else {
+			resp[i] = 50
+		}
+	}
+	return resp
+}
+
+// smoothStochastic smooths a raw stochastic which starts at the start index
+// into its %K and %D lines
+func smoothStochastic(raw []float64, start, kSmoothing, dPeriod int) *Stochastic {
+	k := simpleAverage(raw, kSmoothing, start)
+	return &Stochastic{
+		K: k,
+		D: simpleAverage(k, dPeriod, start+kSmoothing-1),
+	}
+}
+
+// relativeStrength returns Wilder's relative strength index of data, the
+// first value is at the period index
+func relativeStrength(data []float64, period int) []float64 {
+	gains := make([]float64, len(data))
+	losses := make([]float64, len(data))
+	for i := 1; i < len(data); i++ {
+		if change := data[i] - data[i-1]; change > 0 {
+			gains[i] = change
+		} else {
+			losses[i] = -change
+		}
+	}
+	averageGain := wilderAverage(gains, period, 1)
+	averageLoss := wilderAverage(losses, period, 1)
+	resp := make([]float64, len(data))
+	for i := period; i < len(data); i++ {
+		switch {
+		case averageLoss[i] == 0 && averageGain[i] == 0:
+			resp[i] = 50
+		case averageLoss[i] == 0:
+			resp[i] = 100
+		default:
+			resp[i] = 100 - 100/(1+averageGain[i]/averageLoss[i])
+		}
+	}
+	return resp
+}
+
+// hurstExponent estimates the Hurst exponent of returns as the slope of the
+// log of the average rescaled range against the log of sub-window sizes
+// doubling from eight
+func hurstExponent(returns []float64) float64 {
+	var sizes, ranges []float64
+	for size := 8; size <= len(returns); size *= 2 {
+		var total float64
+		var count int
+		for start := 0; start+size <= len(returns); start += size {
+			if rs := rescaledRange(returns[start : start+size]); rs > 0 {
+				total += rs
+				count++
+			}
+		}
+		if count > 0 {
+			sizes = append(sizes, math.Log(float64(size)))
+			ranges = append(ranges, math.Log(total/float64(count)))
+		}
+	}
+	if len(sizes) < 2 {
+		return 0
+	}
+	meanSize, meanRange := average(sizes), average(ranges)
+	var covariance, variance float64
+	for i := range sizes {
+		covariance += (sizes[i] - meanSize) * (ranges[i] - meanRange)
+		variance += (sizes[i] - meanSize) * (sizes[i] - meanSize)
+	}
+	return covariance / variance
+}
+
+// rescaledRange returns the range of cumulative deviations from the mean
+// divided by the standard deviation
+func rescaledRange(data []float64) float64 {
+	mean, deviation := meanAndDeviation(data)
+	if deviation == 0 {
+		return 0
+	}
+	var cumulative, highest, lowest float64
+	for i := range data {
+		cumulative += data[i] - mean
+		highest = math.Max(highest, cumulative)
+		lowest = math.Min(lowest, cumulative)
+	}
+	return (highest - lowest) / deviation
+}
+
+func average(data []float64) float64 {
+	var sum float64
+	for i := range data {
+		sum += data[i]
+	}
+	return sum / float64(len(data))
+}
+
+// meanAndDeviation returns the mean and population standard deviation
+func meanAndDeviation(data []float64) (mean, deviation float64) {
+	mean = average(data)
+	for i := range data {
+		deviation += (data[i] - mean) * (data[i] - mean)
+	}
+	return mean, math.Sqrt(deviation / float64(len(data)))
+}
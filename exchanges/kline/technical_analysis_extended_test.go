@@ -0,0 +1,456 @@
+package kline
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+)
+
+// risingItem returns candles which rise by one each interval
+func risingItem(length int) *Item {
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	k := &Item{Interval: OneDay}
+	for i := 0; i < length; i++ {
+		k.Candles = append(k.Candles, Candle{
+			Time:   start.Add(OneDay.Duration() * time.Duration(i)),
+			Open:   float64(i) + 1.5,
+			High:   float64(i) + 3,
+			Low:    float64(i) + 1,
+			Close:  float64(i) + 2.5,
+			Volume: 1,
+		})
+	}
+	return k
+}
+
+func TestValidateHLC(t *testing.T) {
+	t.Parallel()
+	var ohlc *OHLC
+	if err := ohlc.validateHLC("test", 1); !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+	ohlc = &OHLC{}
+	if err := ohlc.validateHLC("test", 1); !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	ohlc.High = []float64{1, 2}
+	if err := ohlc.validateHLC("test", 1); !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	ohlc.Low = []float64{1, 2}
+	if err := ohlc.validateHLC("test", 1); !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	ohlc.Close = []float64{1}
+	if err := ohlc.validateHLC("test", 1); !errors.Is(err, errInvalidDataSetLengths) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidDataSetLengths)
+	}
+	ohlc.Close = []float64{1, 2}
+	if err := ohlc.validateHLC("test", 3); !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	if err := ohlc.validateHLC("test", 2); !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestGetStochastic(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetStochastic(0, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetStochastic(9, 3, 3)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	stoch, err := k.GetStochastic(4, 3, 3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// close sits 4.5 above the lowest low of a 5 point range
+	if stoch.K[4] != 0 || stoch.K[5] != 90 || stoch.D[6] != 0 || stoch.D[7] != 90 {
+		t.Errorf("received K '%v' D '%v', expected 90 once smoothed", stoch.K, stoch.D)
+	}
+}
+
+func TestGetStochasticRelativeStrengthIndex(t *testing.T) {
+	t.Parallel()
+	var ohlc *OHLC
+	_, err := ohlc.GetStochasticRelativeStrengthIndex(nil, 14, 14, 3, 3)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+	ohlc = &OHLC{}
+	_, err = ohlc.GetStochasticRelativeStrengthIndex(nil, 1, 14, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = ohlc.GetStochasticRelativeStrengthIndex(nil, 14, 0, 3, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = ohlc.GetStochasticRelativeStrengthIndex(nil, 14, 14, 3, 3)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	_, err = ohlc.GetStochasticRelativeStrengthIndex([]float64{1, 2, 3}, 2, 2, 2, 2)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	prices := []float64{10, 11, 10, 12, 11, 13, 12, 14}
+	stoch, err := ohlc.GetStochasticRelativeStrengthIndex(prices, 2, 2, 1, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// RSI alternates so each rise is the top of its range
+	if math.Abs(stoch.K[5]-100) > 1e-9 || stoch.K[6] != 0 || math.Abs(stoch.D[7]-100) > 1e-9 {
+		t.Errorf("received '%v', expected alternating 100 and 0", stoch.K)
+	}
+
+	stoch, err = risingItem(10).GetStochasticRelativeStrengthIndexOnClose(2, 2, 2, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if stoch.D[9] != 50 {
+		t.Errorf("received '%v', expected '%v' for a flat RSI", stoch.D[9], 50)
+	}
+}
+
+func TestGetDirectionalMovementIndex(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetDirectionalMovementIndex(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetDirectionalMovementIndex(6)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	dmi, err := k.GetDirectionalMovementIndex(3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// each candle moves up by one with a true range of two
+	if dmi.PlusDI[9] != 50 || dmi.MinusDI[9] != 0 || dmi.ADX[9] != 100 || dmi.ADX[4] != 0 {
+		t.Errorf("received '%+v', expected a strong uptrend", dmi)
+	}
+}
+
+func TestGetIchimokuCloud(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetIchimokuCloud(0, 3, 5, 2)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetIchimokuCloud(2, 3, 5, -1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetIchimokuCloud(2, 3, 11, 2)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	cloud, err := k.GetIchimokuCloud(2, 3, 5, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cloud.ConversionLine[1] != 2.5 || cloud.BaseLine[2] != 3 {
+		t.Errorf("received conversion '%v' base '%v'", cloud.ConversionLine, cloud.BaseLine)
+	}
+	// span A of candle two is projected onto candle four
+	if cloud.LeadingSpanA[3] != 0 || cloud.LeadingSpanA[4] != (cloud.ConversionLine[2]+cloud.BaseLine[2])/2 {
+		t.Errorf("received span A '%v'", cloud.LeadingSpanA)
+	}
+	if cloud.LeadingSpanB[5] != 0 || cloud.LeadingSpanB[6] != 4 {
+		t.Errorf("received span B '%v'", cloud.LeadingSpanB)
+	}
+	if cloud.LaggingSpan[0] != k.Candles[2].Close || cloud.LaggingSpan[8] != 0 {
+		t.Errorf("received lagging span '%v'", cloud.LaggingSpan)
+	}
+}
+
+func TestGetSupertrend(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetSupertrend(0, 3)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetSupertrend(3, 0)
+	if !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+	st, err := k.GetSupertrend(3, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if st.Direction[1] != 0 || st.Direction[9] != 1 || st.Trend[9] >= k.Candles[9].Low {
+		t.Errorf("received '%+v', expected an uptrend below price", st)
+	}
+
+	falling := &Item{}
+	for i := 10; i > 0; i-- {
+		falling.Candles = append(falling.Candles, Candle{High: float64(i) + 1, Low: float64(i) - 1, Close: float64(i) - 0.5})
+	}
+	st, err = falling.GetSupertrend(3, 1)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if st.Direction[9] != -1 || st.Trend[9] <= falling.Candles[9].High {
+		t.Errorf("received '%+v', expected a downtrend above price", st)
+	}
+}
+
+func TestGetKeltnerChannels(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetKeltnerChannels(0, 3, 2)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetKeltnerChannels(3, 3, 0)
+	if !errors.Is(err, errInvalidMultiplier) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidMultiplier)
+	}
+	_, err = k.GetKeltnerChannels(3, 11, 2)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	channels, err := k.GetKeltnerChannels(3, 2, 2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// the seed average of closes 2.5, 3.5 and 4.5 with a true range of two
+	if channels.Middle[2] != 3.5 || channels.Upper[2] != 7.5 || channels.Lower[2] != -0.5 || channels.Upper[1] != 0 {
+		t.Errorf("received '%+v'", channels)
+	}
+}
+
+func TestGetDonchianChannels(t *testing.T) {
+	t.Parallel()
+	k := risingItem(5)
+	_, err := k.GetDonchianChannels(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = k.GetDonchianChannels(6)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	channels, err := k.GetDonchianChannels(3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if channels.Upper[2] != 5 || channels.Lower[2] != 1 || channels.Middle[2] != 3 || channels.Middle[1] != 0 {
+		t.Errorf("received '%+v'", channels)
+	}
+}
+
+func TestGetParabolicSAR(t *testing.T) {
+	t.Parallel()
+	k := risingItem(10)
+	_, err := k.GetParabolicSAR(0, 0.2)
+	if !errors.Is(err, errInvalidAccelerationFactor) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAccelerationFactor)
+	}
+	_, err = k.GetParabolicSAR(0.02, 0.01)
+	if !errors.Is(err, errInvalidAccelerationFactor) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAccelerationFactor)
+	}
+	_, err = (&Item{Candles: []Candle{{High: 1, Low: 1, Close: 1}}}).GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, errNotEnoughData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNotEnoughData)
+	}
+	sar, err := k.GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if sar[1] != 1 {
+		t.Errorf("received '%v', expected '%v'", sar[1], 1)
+	}
+	for i := 2; i < len(sar); i++ {
+		if sar[i] >= k.Candles[i].Low || sar[i] < sar[i-1] {
+			t.Fatalf("received '%v', expected a rising stop below price", sar)
+		}
+	}
+
+	reversal := &OHLC{
+		High:  []float64{10, 11, 12, 8},
+		Low:   []float64{9, 10, 11, 6},
+		Close: []float64{9.5, 10.5, 11.5, 7},
+	}
+	sar, err = reversal.GetParabolicSAR(0.02, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// the reversal places the stop at the prior extreme high
+	if sar[3] != 12 {
+		t.Errorf("received '%v', expected '%v'", sar[3], 12)
+	}
+}
+
+func TestGetCommodityChannelIndex(t *testing.T) {
+	t.Parallel()
+	k := risingItem(5)
+	_, err := k.GetCommodityChannelIndex(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	cci, err := k.GetCommodityChannelIndex(3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// typical prices one apart have a mean deviation of 2/3
+	if math.Abs(cci[4]-100) > 1e-9 || cci[1] != 0 {
+		t.Errorf("received '%v', expected '%v'", cci, 100)
+	}
+	flat := &Item{Candles: []Candle{{High: 1, Low: 1, Close: 1}, {High: 1, Low: 1, Close: 1}}}
+	cci, err = flat.GetCommodityChannelIndex(2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if cci[1] != 0 {
+		t.Errorf("received '%v', expected '%v'", cci[1], 0)
+	}
+}
+
+func TestGetWilliamsPercentRange(t *testing.T) {
+	t.Parallel()
+	k := risingItem(5)
+	_, err := k.GetWilliamsPercentRange(0)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	r, err := k.GetWilliamsPercentRange(3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// close of 4.5 within a range of 1 to 5
+	if r[2] != -12.5 || r[1] != 0 {
+		t.Errorf("received '%v', expected '%v'", r, -12.5)
+	}
+}
+
+func TestGetAnchoredVolumeWeightedAveragePrice(t *testing.T) {
+	t.Parallel()
+	var ohlc *OHLC
+	_, err := ohlc.GetAnchoredVolumeWeightedAveragePrice(0)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+	ohlc = &OHLC{High: []float64{1}, Low: []float64{1}, Close: []float64{1}}
+	_, err = ohlc.GetAnchoredVolumeWeightedAveragePrice(0)
+	if !errors.Is(err, errInvalidDataSetLengths) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidDataSetLengths)
+	}
+	ohlc.Volume = []float64{0}
+	_, err = ohlc.GetAnchoredVolumeWeightedAveragePrice(1)
+	if !errors.Is(err, errInvalidAnchor) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAnchor)
+	}
+	vwap, err := ohlc.GetAnchoredVolumeWeightedAveragePrice(0)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if vwap[0] != 1 {
+		t.Errorf("received '%v', expected '%v'", vwap[0], 1)
+	}
+
+	k := risingItem(4)
+	k.Candles[3].Volume = 2
+	_, err = k.GetAnchoredVolumeWeightedAveragePrice(k.Candles[3].Time.Add(time.Second))
+	if !errors.Is(err, errInvalidAnchor) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidAnchor)
+	}
+	vwap, err = k.GetAnchoredVolumeWeightedAveragePrice(k.Candles[2].Time)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// typical prices of 12.5/3 and 15.5/3 weighted one and two
+	if vwap[1] != 0 || math.Abs(vwap[2]-12.5/3) > 1e-9 || math.Abs(vwap[3]-14.5/3) > 1e-9 {
+		t.Errorf("received '%v'", vwap)
+	}
+}
+
+func TestGetZScore(t *testing.T) {
+	t.Parallel()
+	var ohlc *OHLC
+	_, err := ohlc.GetZScore(nil, 2)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+	ohlc = &OHLC{}
+	_, err = ohlc.GetZScore(nil, 1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = ohlc.GetZScore(nil, 2)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	_, err = ohlc.GetZScore([]float64{1}, 2)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	z, err := risingItem(4).GetZScoreOnClose(3)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if expected := math.Sqrt(1.5); math.Abs(z[3]-expected) > 1e-9 || z[1] != 0 {
+		t.Errorf("received '%v', expected '%v'", z, expected)
+	}
+}
+
+func TestGetHurstExponent(t *testing.T) {
+	t.Parallel()
+	var ohlc *OHLC
+	_, err := ohlc.GetHurstExponent(nil, minimumHurstPeriod)
+	if !errors.Is(err, errNilOHLC) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNilOHLC)
+	}
+	ohlc = &OHLC{}
+	_, err = ohlc.GetHurstExponent(nil, minimumHurstPeriod-1)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = ohlc.GetHurstExponent(nil, minimumHurstPeriod)
+	if !errors.Is(err, errNoData) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNoData)
+	}
+	_, err = ohlc.GetHurstExponent(make([]float64, minimumHurstPeriod), minimumHurstPeriod)
+	if !errors.Is(err, errInvalidPeriod) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errInvalidPeriod)
+	}
+	_, err = ohlc.GetHurstExponent(make([]float64, minimumHurstPeriod+1), minimumHurstPeriod)
+	if !errors.Is(err, errNonPositivePrice) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errNonPositivePrice)
+	}
+
+	// alternating returns strongly mean revert
+	prices := make([]float64, 65)
+	for i := range prices {
+		prices[i] = 100
+		if i%2 == 1 {
+			prices[i] = 101
+		}
+	}
+	h, err := ohlc.GetHurstExponent(prices, 64)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if h[63] != 0 || h[64] >= 0.5 {
+		t.Errorf("received '%v', expected below 0.5", h[64])
+	}
+	h, err = risingItem(40).GetHurstExponentOnClose(32)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if h[39] <= 0.5 {
+		t.Errorf("received '%v', expected above 0.5", h[39])
+	}
+}
@@ -246,20 +246,23 @@ type TimeResponse struct {
 
 // FuturesInstrumentData stores info for futures market
 type FuturesInstrumentData struct {
-	Instruments []struct {
-		Symbol          string  `json:"symbol"`
-		FutureType      string  `json:"type"`
-		Underlying      string  `json:"underlying"`
-		LastTradingTime string  `json:"lastTradingTime"`
-		TickSize        float64 `json:"tickSize"`
-		ContractSize    float64 `json:"contractSize"`
-		Tradable        bool    `json:"tradeable"`
-		MarginLevels    []struct {
-			Contracts         float64 `json:"contracts"`
-			InitialMargin     float64 `json:"initialMargin"`
-			MaintenanceMargin float64 `json:"maintenanceMargin"`
-		} `json:"marginLevels"`
-	} `json:"instruments"`
+	Instruments []FuturesInstrument `json:"instruments"`
+}
+
+// FuturesInstrument stores the specification of a futures contract
+type FuturesInstrument struct {
+	Symbol          string  `json:"symbol"`
+	FutureType      string  `json:"type"`
+	Underlying      string  `json:"underlying"`
+	LastTradingTime string  `json:"lastTradingTime"`
+	TickSize        float64 `json:"tickSize"`
+	ContractSize    float64 `json:"contractSize"`
+	Tradable        bool    `json:"tradeable"`
+	MarginLevels    []struct {
+		Contracts         float64 `json:"contracts"`
+		InitialMargin     float64 `json:"initialMargin"`
+		MaintenanceMargin float64 `json:"maintenanceMargin"`
+	} `json:"marginLevels"`
 }
 
 // FuturesTradeHistoryData stores trade history data for futures
@@ -2,6 +2,7 @@ package kraken
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -20,6 +21,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -1268,7 +1270,7 @@ func TestGetWSToken(t *testing.T) {
 
 func TestWsAddOrder(t *testing.T) {
 	setupWsTests(t)
-	_, err := k.wsAddOrder(&WsAddOrderRequest{
+	_, err := k.wsAddOrder(context.Background(), &WsAddOrderRequest{
 		OrderType: order.Limit.Lower(),
 		OrderSide: order.Buy.Lower(),
 		Pair:      "XBT/USD",
@@ -1281,14 +1283,14 @@ func TestWsAddOrder(t *testing.T) {
 
 func TestWsCancelOrder(t *testing.T) {
 	setupWsTests(t)
-	if err := k.wsCancelOrders([]string{"1337"}); err != nil {
+	if err := k.wsCancelOrders(context.Background(), []string{"1337"}); err != nil {
 		t.Error(err)
 	}
 }
 
 func TestWsCancelAllOrders(t *testing.T) {
 	setupWsTests(t)
-	if _, err := k.wsCancelAllOrders(); err != nil {
+	if _, err := k.wsCancelAllOrders(context.Background()); err != nil {
 		t.Error(err)
 	}
 }
@@ -2257,3 +2259,32 @@ func TestWsOrderbookMax10Depth(t *testing.T) {
 		}
 	}
 }
+
+func TestFuturesInstrument(t *testing.T) {
+	t.Parallel()
+	var markets FuturesInstrumentData
+	err := json.Unmarshal([]byte(`{"instruments":[{"symbol":"PI_XBTUSD","type":"futures_inverse","underlying":"rr_xbtusd","tickSize":0.5,"contractSize":1,"tradeable":true},{"symbol":"FI_XBTUSD_231229","type":"futures_inverse","underlying":"rr_xbtusd","lastTradingTime":"2023-12-29T16:00:00.000Z","tickSize":0.5,"contractSize":1,"tradeable":true}]}`), &markets)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err := futuresInstrument(&markets.Instruments[0])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if inst.ContractType != instrument.Perpetual || !inst.Expiry.IsZero() {
+		t.Errorf("received: '%v' '%v' but expected a perpetual contract", inst.ContractType, inst.Expiry)
+	}
+	if inst.ContractSize != 1 || inst.Limits.PriceStepIncrementSize != 0.5 {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", inst.ContractSize, inst.Limits.PriceStepIncrementSize, 1, 0.5)
+	}
+	if !inst.Underlying.IsEmpty() || !inst.SettlementCurrency.IsEmpty() {
+		t.Errorf("received: '%v' '%v' but expected underlying and settlement to be unset", inst.Underlying, inst.SettlementCurrency)
+	}
+	inst, err = futuresInstrument(&markets.Instruments[1])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if expiry := time.Date(2023, 12, 29, 16, 0, 0, 0, time.UTC); inst.ContractType != instrument.Delivery || !inst.Expiry.Equal(expiry) {
+		t.Errorf("received: '%v' '%v' but expected: '%v' '%v'", inst.ContractType, inst.Expiry, instrument.Delivery, expiry)
+	}
+}
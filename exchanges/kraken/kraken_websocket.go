@@ -1356,12 +1356,12 @@ channels:
 }
 
 // wsAddOrder creates an order, returned order ID if success
-func (k *Kraken) wsAddOrder(request *WsAddOrderRequest) (string, error) {
+func (k *Kraken) wsAddOrder(ctx context.Context, request *WsAddOrderRequest) (string, error) {
 	id := k.Websocket.AuthConn.GenerateMessageID(false)
 	request.RequestID = id
 	request.Event = krakenWsAddOrder
 	request.Token = authToken
-	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponse(id, request)
+	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponseContext(ctx, id, request)
 	if err != nil {
 		return "", err
 	}
@@ -1377,7 +1377,7 @@ func (k *Kraken) wsAddOrder(request *WsAddOrderRequest) (string, error) {
 }
 
 // wsCancelOrders cancels one or more open orders passed in orderIDs param
-func (k *Kraken) wsCancelOrders(orderIDs []string) error {
+func (k *Kraken) wsCancelOrders(ctx context.Context, orderIDs []string) error {
 	id := k.Websocket.AuthConn.GenerateMessageID(false)
 	request := WsCancelOrderRequest{
 		Event:          krakenWsCancelOrder,
@@ -1397,7 +1397,7 @@ func (k *Kraken) wsCancelOrders(orderIDs []string) error {
 
 	defer delete(cancelOrdersStatus, id)
 
-	_, err := k.Websocket.AuthConn.SendMessageReturnResponse(id, request)
+	_, err := k.Websocket.AuthConn.SendMessageReturnResponseContext(ctx, id, request)
 	if err != nil {
 		return err
 	}
@@ -1417,7 +1417,7 @@ func (k *Kraken) wsCancelOrders(orderIDs []string) error {
 
 // wsCancelAllOrders cancels all opened orders
 // Returns number (count param) of affected orders or 0 if no open orders found
-func (k *Kraken) wsCancelAllOrders() (*WsCancelOrderResponse, error) {
+func (k *Kraken) wsCancelAllOrders(ctx context.Context) (*WsCancelOrderResponse, error) {
 	id := k.Websocket.AuthConn.GenerateMessageID(false)
 	request := WsCancelOrderRequest{
 		Event:     krakenWsCancelAll,
@@ -1425,7 +1425,7 @@ func (k *Kraken) wsCancelAllOrders() (*WsCancelOrderResponse, error) {
 		RequestID: id,
 	}
 
-	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponse(id, request)
+	jsonResp, err := k.Websocket.AuthConn.SendMessageReturnResponseContext(ctx, id, request)
 	if err != nil {
 		return &WsCancelOrderResponse{}, err
 	}
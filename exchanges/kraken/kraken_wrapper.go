@@ -12,12 +12,14 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -346,31 +348,92 @@ func (k *Kraken) Run(ctx context.Context) {
 
 // UpdateOrderExecutionLimits sets exchange execution order limits for an asset type
 func (k *Kraken) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) error {
-	if a != asset.Spot {
+	var limits []order.MinMaxLevel
+	var instruments []instrument.Instrument
+	switch a {
+	case asset.Spot:
+		pairInfo, err := k.fetchSpotPairInfo(ctx)
+		if err != nil {
+			return fmt.Errorf("%s failed to load %s pair execution limits. Err: %s", k.Name, a, err)
+		}
+		limits = make([]order.MinMaxLevel, 0, len(pairInfo))
+		instruments = make([]instrument.Instrument, 0, len(pairInfo))
+		for pair, info := range pairInfo {
+			limits = append(limits, order.MinMaxLevel{
+				Asset:                  a,
+				Pair:                   pair,
+				PriceStepIncrementSize: info.TickSize,
+				MinimumBaseAmount:      info.OrderMinimum,
+			})
+			// fetchSpotPairInfo only returns online pairs
+			instruments = append(instruments, instrument.Instrument{
+				Asset:              a,
+				Pair:               pair,
+				Symbol:             info.Altname,
+				ContractType:       instrument.Spot,
+				Underlying:         pair,
+				SettlementCurrency: pair.Quote,
+				Status:             instrument.Trading,
+				Limits:             limits[len(limits)-1],
+			})
+		}
+	case asset.Futures:
+		markets, err := k.GetFuturesMarkets(ctx)
+		if err != nil {
+			return fmt.Errorf("%s failed to load %s pair execution limits. Err: %s", k.Name, a, err)
+		}
+		limits = make([]order.MinMaxLevel, 0, len(markets.Instruments))
+		instruments = make([]instrument.Instrument, 0, len(markets.Instruments))
+		for x := range markets.Instruments {
+			if !markets.Instruments[x].Tradable {
+				continue
+			}
+			inst, err := futuresInstrument(&markets.Instruments[x])
+			if err != nil {
+				return err
+			}
+			limits = append(limits, inst.Limits)
+			instruments = append(instruments, inst)
+		}
+	default:
 		return common.ErrNotYetImplemented
 	}
 
-	pairInfo, err := k.fetchSpotPairInfo(ctx)
-	if err != nil {
-		return fmt.Errorf("%s failed to load %s pair execution limits. Err: %s", k.Name, a, err)
+	if err := k.LoadLimits(limits); err != nil {
+		return fmt.Errorf("%s Error loading %s exchange limits: %w", k.Name, a, err)
 	}
+	return k.LoadInstruments(instruments)
+}
 
-	limits := make([]order.MinMaxLevel, 0, len(pairInfo))
-
-	for pair, info := range pairInfo {
-		limits = append(limits, order.MinMaxLevel{
-			Asset:                  a,
+// futuresInstrument converts a tradable futures contract into an instrument.
+// Contracts with a last trading time are dated, the rest are perpetual. The
+// underlying is a reference rate rather than a pair so it is left unset
+func futuresInstrument(contract *FuturesInstrument) (instrument.Instrument, error) {
+	pair, err := currency.NewPairFromString(contract.Symbol)
+	if err != nil {
+		return instrument.Instrument{}, err
+	}
+	inst := instrument.Instrument{
+		Asset:        asset.Futures,
+		Pair:         pair,
+		Symbol:       contract.Symbol,
+		ContractType: instrument.Perpetual,
+		ContractSize: contract.ContractSize,
+		Status:       instrument.Trading,
+		Limits: order.MinMaxLevel{
+			Asset:                  asset.Futures,
 			Pair:                   pair,
-			PriceStepIncrementSize: info.TickSize,
-			MinimumBaseAmount:      info.OrderMinimum,
-		})
+			PriceStepIncrementSize: contract.TickSize,
+		},
 	}
-
-	if err := k.LoadLimits(limits); err != nil {
-		return fmt.Errorf("%s Error loading %s exchange limits: %w", k.Name, a, err)
+	if contract.LastTradingTime != "" {
+		inst.ContractType = instrument.Delivery
+		inst.Expiry, err = time.Parse(time.RFC3339, contract.LastTradingTime)
+		if err != nil {
+			return instrument.Instrument{}, err
+		}
 	}
-
-	return nil
+	return inst, nil
 }
 
 func (k *Kraken) fetchSpotPairInfo(ctx context.Context) (map[currency.Pair]*AssetPairs, error) {
@@ -387,7 +450,7 @@ func (k *Kraken) fetchSpotPairInfo(ctx context.Context) (map[currency.Pair]*Asse
 		}
 		base := assetTranslator.LookupAltname(info.Base)
 		if base == "" {
-			log.Warnf(log.ExchangeSys,
+			log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s unable to lookup altname for base currency %s",
 				k.Name,
 				info.Base)
@@ -395,7 +458,7 @@ func (k *Kraken) fetchSpotPairInfo(ctx context.Context) (map[currency.Pair]*Asse
 		}
 		quote := assetTranslator.LookupAltname(info.Quote)
 		if quote == "" {
-			log.Warnf(log.ExchangeSys,
+			log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 				"%s unable to lookup altname for quote currency %s",
 				k.Name,
 				info.Quote)
@@ -650,7 +713,7 @@ func (k *Kraken) UpdateAccountInfo(ctx context.Context, assetType asset.Item) (a
 		for key := range bal {
 			translatedCurrency := assetTranslator.LookupAltname(key)
 			if translatedCurrency == "" {
-				log.Warnf(log.ExchangeSys, "%s unable to translate currency: %s\n",
+				log.WarnfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s unable to translate currency: %s\n",
 					k.Name,
 					key)
 				continue
@@ -824,7 +887,7 @@ func (k *Kraken) SubmitOrder(ctx context.Context, s *order.Submit) (*order.Submi
 			timeInForce = RequestParamsTimeIOC
 		}
 		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-			orderID, err = k.wsAddOrder(&WsAddOrderRequest{
+			orderID, err = k.wsAddOrder(ctx, &WsAddOrderRequest{
 				OrderType:   s.Type.Lower(),
 				OrderSide:   s.Side.Lower(),
 				Pair:        s.Pair.Format(currency.PairFormat{Uppercase: true, Delimiter: "/"}).String(), // required pair format: ISO 4217-A3
@@ -906,7 +969,7 @@ func (k *Kraken) CancelOrder(ctx context.Context, o *order.Cancel) error {
 	switch o.AssetType {
 	case asset.Spot:
 		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-			return k.wsCancelOrders([]string{o.OrderID})
+			return k.wsCancelOrders(ctx, []string{o.OrderID})
 		}
 		_, err := k.CancelExistingOrder(ctx, o.OrderID)
 		return err
@@ -923,7 +986,7 @@ func (k *Kraken) CancelOrder(ctx context.Context, o *order.Cancel) error {
 }
 
 // CancelBatchOrders cancels an orders by their corresponding ID numbers
-func (k *Kraken) CancelBatchOrders(_ context.Context, o []order.Cancel) (*order.CancelBatchResponse, error) {
+func (k *Kraken) CancelBatchOrders(ctx context.Context, o []order.Cancel) (*order.CancelBatchResponse, error) {
 	if !k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
 		return nil, common.ErrFunctionNotSupported
 	}
@@ -936,7 +999,7 @@ func (k *Kraken) CancelBatchOrders(_ context.Context, o []order.Cancel) (*order.
 		ordersList[i] = o[i].OrderID
 	}
 
-	err := k.wsCancelOrders(ordersList)
+	err := k.wsCancelOrders(ctx, ordersList)
 	return nil, err
 }
 
@@ -951,7 +1014,7 @@ func (k *Kraken) CancelAllOrders(ctx context.Context, req *order.Cancel) (order.
 	switch req.AssetType {
 	case asset.Spot:
 		if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-			resp, err := k.wsCancelAllOrders()
+			resp, err := k.wsCancelAllOrders(ctx)
 			if err != nil {
 				return cancelAllOrdersResponse, err
 			}
@@ -968,7 +1031,7 @@ func (k *Kraken) CancelAllOrders(ctx context.Context, req *order.Cancel) (order.
 		for orderID := range openOrders.Open {
 			var err error
 			if k.Websocket.CanUseAuthenticatedWebsocketForWrapper() {
-				err = k.wsCancelOrders([]string{orderID})
+				err = k.wsCancelOrders(ctx, []string{orderID})
 			} else {
 				_, err = k.CancelExistingOrder(ctx, orderID)
 			}
@@ -1036,7 +1099,7 @@ func (k *Kraken) GetOrderInfo(ctx context.Context, orderID string, _ currency.Pa
 		}
 		status, err := order.StringToOrderStatus(orderInfo.Status)
 		if err != nil {
-			log.Errorf(log.ExchangeSys, "%s %v", k.Name, err)
+			log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", k.Name, err)
 		}
 		oType, err := order.StringToOrderType(orderInfo.Description.OrderType)
 		if err != nil {
@@ -1255,7 +1318,7 @@ func (k *Kraken) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(resp.Open[i].Description.OrderType)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", k.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", k.Name, err)
 			}
 			orders = append(orders, order.Detail{
 				OrderID:         i,
@@ -1377,16 +1440,16 @@ func (k *Kraken) GetOrderHistory(ctx context.Context, getOrdersRequest *order.Mu
 			var side order.Side
 			side, err = order.StringToOrderSide(resp.Closed[i].Description.Type)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", k.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", k.Name, err)
 			}
 			status, err := order.StringToOrderStatus(resp.Closed[i].Status)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", k.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", k.Name, err)
 			}
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(resp.Closed[i].Description.OrderType)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", k.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", k.Name, err)
 			}
 			detail := order.Detail{
 				OrderID:         i,
@@ -9,12 +9,14 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -813,12 +815,12 @@ func (o *Okcoin) GetOrderInfo(ctx context.Context, orderID string, pair currency
 	}
 	status, err := order.StringToOrderStatus(tradeOrder.State)
 	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 	}
 
 	side, err := order.StringToOrderSide(tradeOrder.Side)
 	if err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 	}
 	orderType, err := order.StringToOrderType(tradeOrder.OrderType)
 	if err != nil {
@@ -982,17 +984,17 @@ func (o *Okcoin) GetActiveOrders(ctx context.Context, req *order.MultiOrderReque
 			var status order.Status
 			status, err = order.StringToOrderStatus(tradeOrders[i].State)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			var side order.Side
 			side, err = order.StringToOrderSide(tradeOrders[i].Side)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(tradeOrders[i].OrderType)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			resp = append(resp, order.Detail{
 				Date:           tradeOrders[i].CreationTime.Time(),
@@ -1035,17 +1037,17 @@ func (o *Okcoin) GetOrderHistory(ctx context.Context, req *order.MultiOrderReque
 			var status order.Status
 			status, err = order.StringToOrderStatus(spotOrders[i].State)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			var side order.Side
 			side, err = order.StringToOrderSide(spotOrders[i].Side)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			var orderType order.Type
 			orderType, err = order.StringToOrderType(spotOrders[i].OrderType)
 			if err != nil {
-				log.Errorf(log.ExchangeSys, "%s %v", o.Name, err)
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", o.Name, err)
 			}
 			detail := order.Detail{
 				OrderID:              spotOrders[i].OrderID,
@@ -1237,6 +1239,7 @@ func (o *Okcoin) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 	}
 
 	limits := make([]order.MinMaxLevel, 0, len(instrumentsList))
+	instruments := make([]instrument.Instrument, 0, len(instrumentsList))
 	for index := range instrumentsList {
 		pair, err := currency.NewPairFromString(instrumentsList[index].InstrumentID)
 		if err != nil {
@@ -1250,9 +1253,33 @@ func (o *Okcoin) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) e
 			MaxIcebergParts:        instrumentsList[index].MaxIcebergSz.Int64(),
 			MarketMaxQty:           instrumentsList[index].MaxMarketSize.Float64(),
 		})
+		instruments = append(instruments, instrument.Instrument{
+			Asset:              a,
+			Pair:               pair,
+			Symbol:             instrumentsList[index].InstrumentID,
+			ContractType:       instrument.Spot,
+			Underlying:         pair,
+			SettlementCurrency: pair.Quote,
+			ListingTime:        instrumentsList[index].ListTime.Time(),
+			Status:             instrumentStatus(instrumentsList[index].State),
+			Limits:             limits[len(limits)-1],
+		})
 	}
 	if err := o.LoadLimits(limits); err != nil {
 		return fmt.Errorf("%s Error loading %s exchange limits: %v", o.Name, a, err)
 	}
-	return nil
+	return o.LoadInstruments(instruments)
+}
+
+// instrumentStatus converts an instrument state into an instrument status
+func instrumentStatus(state string) instrument.Status {
+	switch state {
+	case "live":
+		return instrument.Trading
+	case "suspend":
+		return instrument.Halted
+	case "preopen":
+		return instrument.PreTrading
+	}
+	return instrument.UnknownStatus
 }
@@ -19,6 +19,7 @@ import (
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -3375,3 +3376,38 @@ func TestGetAssetsFromInstrumentTypeOrID(t *testing.T) {
 		t.Errorf("received %v expected %v", assets, asset.Spot)
 	}
 }
+
+func TestInstrumentFromOkx(t *testing.T) {
+	t.Parallel()
+	var i Instrument
+	err := json.Unmarshal([]byte(instrumentJSON), &i)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pair, err := currency.NewPairFromString(i.InstrumentID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	inst, err := instrumentFromOkx(&i, asset.PerpetualSwap, pair)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if inst.ContractType != instrument.Perpetual {
+		t.Errorf("received: '%v' but expected: '%v'", inst.ContractType, instrument.Perpetual)
+	}
+	if inst.ContractSize != 0.0001 || !inst.ContractSizeCurrency.Equal(currency.BTC) {
+		t.Errorf("received: '%v %v' but expected: '0.0001 BTC'", inst.ContractSize, inst.ContractSizeCurrency)
+	}
+	if inst.Multiplier != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Multiplier, 1)
+	}
+	if inst.ListingTime.UnixMilli() != 1666076190000 {
+		t.Errorf("received: '%v' but expected: '%v'", inst.ListingTime.UnixMilli(), 1666076190000)
+	}
+	if !inst.Expiry.IsZero() {
+		t.Errorf("received: '%v' but expected an empty expiry", inst.Expiry)
+	}
+	if inst.Symbol != "BTC-USDC-SWAP" {
+		t.Errorf("received: '%v' but expected: '%v'", inst.Symbol, "BTC-USDC-SWAP")
+	}
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -19,6 +20,7 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/deposit"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/fundingrate"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/instrument"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
@@ -342,6 +344,7 @@ func (ok *Okx) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) err
 		return errNoInstrumentFound
 	}
 	limits := make([]order.MinMaxLevel, len(insts))
+	instruments := make([]instrument.Instrument, len(insts))
 	for x := range insts {
 		pair, err := currency.NewPairFromString(insts[x].InstrumentID)
 		if err != nil {
@@ -354,9 +357,73 @@ func (ok *Okx) UpdateOrderExecutionLimits(ctx context.Context, a asset.Item) err
 			PriceStepIncrementSize: insts[x].TickSize,
 			MinimumBaseAmount:      insts[x].MinimumOrderSize,
 		}
+		instruments[x], err = instrumentFromOkx(&insts[x], a, pair)
+		if err != nil {
+			return err
+		}
+		instruments[x].Limits = limits[x]
 	}
 
-	return ok.LoadLimits(limits)
+	if err = ok.LoadLimits(limits); err != nil {
+		return err
+	}
+	return ok.LoadInstruments(instruments)
+}
+
+// instrumentFromOkx converts an instrument into its exchange independent
+// specification
+func instrumentFromOkx(inst *Instrument, a asset.Item, pair currency.Pair) (instrument.Instrument, error) {
+	resp := instrument.Instrument{
+		Asset:                a,
+		Pair:                 pair,
+		Symbol:               inst.InstrumentID,
+		ContractSizeCurrency: currency.NewCode(inst.ContractValueCurrency),
+		SettlementCurrency:   currency.NewCode(inst.SettlementCurrency),
+		Expiry:               inst.ExpTime,
+		ListingTime:          inst.ListTime,
+	}
+	switch a {
+	case asset.Spot, asset.Margin:
+		resp.ContractType = instrument.Spot
+		resp.SettlementCurrency = currency.NewCode(inst.QuoteCurrency)
+		resp.Underlying = pair
+	case asset.PerpetualSwap:
+		resp.ContractType = instrument.Perpetual
+	case asset.Futures:
+		resp.ContractType = instrument.Delivery
+	case asset.Options:
+		resp.ContractType = instrument.Option
+	}
+	if inst.Underlying != "" {
+		underlying, err := currency.NewPairFromString(inst.Underlying)
+		if err != nil {
+			return resp, err
+		}
+		resp.Underlying = underlying
+	}
+	resp.MarginCurrency = resp.SettlementCurrency
+	var err error
+	if inst.ContractValue != "" {
+		resp.ContractSize, err = strconv.ParseFloat(inst.ContractValue, 64)
+		if err != nil {
+			return resp, err
+		}
+	}
+	if inst.ContractMultiplier != "" {
+		resp.Multiplier, err = strconv.ParseFloat(inst.ContractMultiplier, 64)
+		if err != nil {
+			return resp, err
+		}
+	}
+	switch inst.State {
+	case "live":
+		resp.Status = instrument.Trading
+	case "suspend":
+		resp.Status = instrument.Halted
+	case "preopen", "test":
+		resp.Status = instrument.PreTrading
+	}
+	return resp, nil
 }
 
 // UpdateTicker updates and returns the ticker for a currency pair
@@ -1340,7 +1407,7 @@ allOrders:
 				var orderStatus order.Status
 				orderStatus, err = order.StringToOrderStatus(strings.ToUpper(orderList[i].State))
 				if err != nil {
-					log.Errorf(log.ExchangeSys, "%s %v", ok.Name, err)
+					log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", ok.Name, err)
 				}
 				if orderStatus == order.Active {
 					continue
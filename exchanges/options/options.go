@@ -0,0 +1,216 @@
+package options
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var (
+	conventionsMtx sync.RWMutex
+	// conventions are keyed by lower case exchange name
+	conventions = map[string]Convention{
+		"okx":    {ExpiryHour: 8, InversePremium: true},
+		"gateio": {ExpiryHour: 8},
+	}
+)
+
+// String implements the stringer interface
+func (t Type) String() string {
+	switch t {
+	case Call:
+		return "call"
+	case Put:
+		return "put"
+	default:
+		return "unknown"
+	}
+}
+
+// RegisterConvention sets the naming convention used to parse an exchange's
+// options instruments
+func RegisterConvention(exchangeName string, c Convention) {
+	conventionsMtx.Lock()
+	conventions[strings.ToLower(exchangeName)] = c
+	conventionsMtx.Unlock()
+}
+
+// GetConvention returns the registered naming convention of an exchange
+func GetConvention(exchangeName string) (Convention, error) {
+	conventionsMtx.RLock()
+	defer conventionsMtx.RUnlock()
+	c, ok := conventions[strings.ToLower(exchangeName)]
+	if !ok {
+		return Convention{}, fmt.Errorf("%s %w", exchangeName, ErrUnsupportedExchange)
+	}
+	return c, nil
+}
+
+// Parse converts an options pair into a contract. Instruments are expected in
+// the form BASE-QUOTE-EXPIRY-STRIKE-TYPE, with underscores treated as dashes,
+// where expiry is YYMMDD or YYYYMMDD and type is C or P. eg
+// BTC-USD-230929-30000-C or BTC_USDT-20230929-30000-P
+func Parse(exchangeName string, cp currency.Pair) (*Contract, error) {
+	convention, err := GetConvention(exchangeName)
+	if err != nil {
+		return nil, err
+	}
+	instrument := strings.ReplaceAll(strings.ToUpper(cp.Base.String()+"-"+cp.Quote.String()), "_", "-")
+	parts := strings.Split(instrument, "-")
+	if len(parts) != 5 {
+		return nil, fmt.Errorf("%w %s", ErrInvalidInstrument, cp)
+	}
+	c := &Contract{
+		Exchange:       exchangeName,
+		Asset:          asset.Options,
+		Pair:           cp,
+		Underlying:     currency.NewPair(currency.NewCode(parts[0]), currency.NewCode(parts[1])),
+		InversePremium: convention.InversePremium,
+	}
+	switch parts[4] {
+	case "C":
+		c.Type = Call
+	case "P":
+		c.Type = Put
+	default:
+		return nil, fmt.Errorf("%w %s: %w %q", ErrInvalidInstrument, cp, errInvalidType, parts[4])
+	}
+	c.Strike, err = strconv.ParseFloat(parts[3], 64)
+	if err != nil || c.Strike <= 0 {
+		return nil, fmt.Errorf("%w %s: strike %q", ErrInvalidInstrument, cp, parts[3])
+	}
+	var layout string
+	switch len(parts[2]) {
+	case 6:
+		layout = "060102"
+	case 8:
+		layout = "20060102"
+	default:
+		return nil, fmt.Errorf("%w %s: expiry %q", ErrInvalidInstrument, cp, parts[2])
+	}
+	expiry, err := time.Parse(layout, parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w %s: expiry %q", ErrInvalidInstrument, cp, parts[2])
+	}
+	c.Expiry = expiry.Add(time.Duration(convention.ExpiryHour) * time.Hour)
+	return c, nil
+}
+
+// BuildChains groups contracts into chains per underlying and expiry. Chains
+// are ordered by underlying then expiry and strikes are ascending
+func BuildChains(contracts []Contract) []Chain {
+	type chainKey struct {
+		exchange   string
+		underlying string
+		expiry     int64
+	}
+	chains := make(map[chainKey]*Chain)
+	strikes := make(map[chainKey]map[float64]*Strike)
+	for i := range contracts {
+		key := chainKey{
+			exchange:   strings.ToLower(contracts[i].Exchange),
+			underlying: contracts[i].Underlying.Upper().String(),
+			expiry:     contracts[i].Expiry.Unix(),
+		}
+		if _, ok := chains[key]; !ok {
+			chains[key] = &Chain{
+				Exchange:   contracts[i].Exchange,
+				Underlying: contracts[i].Underlying,
+				Expiry:     contracts[i].Expiry,
+			}
+			strikes[key] = make(map[float64]*Strike)
+		}
+		s, ok := strikes[key][contracts[i].Strike]
+		if !ok {
+			s = &Strike{Strike: contracts[i].Strike}
+			strikes[key][contracts[i].Strike] = s
+		}
+		switch contracts[i].Type {
+		case Call:
+			s.Call = &contracts[i]
+		case Put:
+			s.Put = &contracts[i]
+		}
+	}
+	resp := make([]Chain, 0, len(chains))
+	for key, chain := range chains {
+		for _, s := range strikes[key] {
+			chain.Strikes = append(chain.Strikes, *s)
+		}
+		sort.Slice(chain.Strikes, func(i, j int) bool {
+			return chain.Strikes[i].Strike < chain.Strikes[j].Strike
+		})
+		resp = append(resp, *chain)
+	}
+	sort.Slice(resp, func(i, j int) bool {
+		if resp[i].Exchange != resp[j].Exchange {
+			return resp[i].Exchange < resp[j].Exchange
+		}
+		if u1, u2 := resp[i].Underlying.String(), resp[j].Underlying.String(); u1 != u2 {
+			return u1 < u2
+		}
+		return resp[i].Expiry.Before(resp[j].Expiry)
+	})
+	return resp
+}
+
+// Evaluate calculates the implied volatility and greeks of a contract from its
+// best bid and ask. Premiums of inverse contracts are converted into the
+// quote currency using the underlying price
+func Evaluate(c *Contract, bid, ask, underlyingPrice, riskFreeRate float64, at time.Time) (*Analytics, error) {
+	if bid <= 0 || ask <= 0 || underlyingPrice <= 0 {
+		return nil, ErrInvalidPrice
+	}
+	a := &Analytics{
+		Contract:        c,
+		Bid:             bid,
+		Ask:             ask,
+		Mid:             (bid + ask) / 2,
+		UnderlyingPrice: underlyingPrice,
+		TimeToExpiry:    YearsToExpiry(c.Expiry, at),
+	}
+	if a.TimeToExpiry <= 0 {
+		return nil, fmt.Errorf("%s %w", c.Pair, ErrContractExpired)
+	}
+	premium := a.Mid
+	if c.InversePremium {
+		premium *= underlyingPrice
+	}
+	var err error
+	a.ImpliedVolatility, err = ImpliedVolatility(c.Type, premium, underlyingPrice, c.Strike, a.TimeToExpiry, riskFreeRate)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", c.Pair, err)
+	}
+	a.Greeks, err = CalculateGreeks(c.Type, underlyingPrice, c.Strike, a.TimeToExpiry, riskFreeRate, a.ImpliedVolatility)
+	if err != nil {
+		return nil, fmt.Errorf("%s %w", c.Pair, err)
+	}
+	return a, nil
+}
+
+// AggregateGreeks sums the greeks of each position weighted by its size
+func AggregateGreeks(positions []Position) PortfolioGreeks {
+	resp := PortfolioGreeks{Positions: positions}
+	for i := range positions {
+		if positions[i].Analytics == nil {
+			continue
+		}
+		resp.Delta += positions[i].Analytics.Delta * positions[i].Size
+		resp.Gamma += positions[i].Analytics.Gamma * positions[i].Size
+		resp.Vega += positions[i].Analytics.Vega * positions[i].Size
+		resp.Theta += positions[i].Analytics.Theta * positions[i].Size
+		resp.Rho += positions[i].Analytics.Rho * positions[i].Size
+	}
+	return resp
+}
+
+// YearsToExpiry returns the time remaining until expiry in years
+func YearsToExpiry(expiry, at time.Time) float64 {
+	return expiry.Sub(at).Hours() / 24 / daysPerYear
+}
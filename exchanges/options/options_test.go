@@ -0,0 +1,257 @@
+package options
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func approx(a, b, tolerance float64) bool {
+	return math.Abs(a-b) <= tolerance
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+	_, err := Parse("bitstamp", currency.NewPair(currency.BTC, currency.USD))
+	if !errors.Is(err, ErrUnsupportedExchange) {
+		t.Errorf("received '%v', expected '%v'", err, ErrUnsupportedExchange)
+	}
+
+	c, err := Parse("Okx", currency.NewPairWithDelimiter("BTC", "USD-230929-30000-C", "-"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !c.Underlying.Equal(currency.NewPair(currency.BTC, currency.USD)) ||
+		c.Strike != 30000 ||
+		c.Type != Call ||
+		!c.InversePremium ||
+		!c.Expiry.Equal(time.Date(2023, 9, 29, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("received '%+v', expected BTC-USD 30000 call expiring 2023-09-29 08:00", c)
+	}
+
+	c, err = Parse("GateIO", currency.NewPairWithDelimiter("BTC", "USDT-20211231-59800-P", "_"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !c.Underlying.Equal(currency.NewPair(currency.BTC, currency.USDT)) ||
+		c.Strike != 59800 ||
+		c.Type != Put ||
+		c.InversePremium ||
+		!c.Expiry.Equal(time.Date(2021, 12, 31, 8, 0, 0, 0, time.UTC)) {
+		t.Errorf("received '%+v', expected BTC_USDT 59800 put expiring 2021-12-31 08:00", c)
+	}
+
+	for _, instrument := range []string{
+		"USD-230929-30000",
+		"USD-230929-30000-X",
+		"USD-230929-abc-C",
+		"USD-2309-30000-C",
+		"USD-231399-30000-C",
+	} {
+		_, err = Parse("okx", currency.NewPairWithDelimiter("BTC", instrument, "-"))
+		if !errors.Is(err, ErrInvalidInstrument) {
+			t.Errorf("%s received '%v', expected '%v'", instrument, err, ErrInvalidInstrument)
+		}
+	}
+
+	RegisterConvention("TestExchange", Convention{ExpiryHour: 0})
+	c, err = Parse("testexchange", currency.NewPairWithDelimiter("ETH", "USDC-240105-2000-P", "-"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !c.Expiry.Equal(time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("received '%v', expected '%v'", c.Expiry, time.Date(2024, 1, 5, 0, 0, 0, 0, time.UTC))
+	}
+}
+
+func TestBuildChains(t *testing.T) {
+	t.Parallel()
+	var contracts []Contract
+	for _, instrument := range []string{
+		"USD-230929-30000-C",
+		"USD-230929-30000-P",
+		"USD-230929-25000-P",
+		"USD-230830-30000-C",
+	} {
+		c, err := Parse("okx", currency.NewPairWithDelimiter("BTC", instrument, "-"))
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		contracts = append(contracts, *c)
+	}
+	c, err := Parse("okx", currency.NewPairWithDelimiter("ETH", "USD-230929-2000-C", "-"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	contracts = append(contracts, *c)
+
+	chains := BuildChains(contracts)
+	if len(chains) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(chains), 3)
+	}
+	if chains[0].Underlying.Base != currency.BTC || chains[0].Expiry.Month() != time.August {
+		t.Errorf("received '%v %v', expected BTC August chain first", chains[0].Underlying, chains[0].Expiry)
+	}
+	september := chains[1]
+	if len(september.Strikes) != 2 {
+		t.Fatalf("received '%v', expected '%v'", len(september.Strikes), 2)
+	}
+	if september.Strikes[0].Strike != 25000 || september.Strikes[0].Call != nil || september.Strikes[0].Put == nil {
+		t.Errorf("received '%+v', expected a 25000 put only", september.Strikes[0])
+	}
+	if september.Strikes[1].Call == nil || september.Strikes[1].Put == nil {
+		t.Errorf("received '%+v', expected a 30000 call and put", september.Strikes[1])
+	}
+	if chains[2].Underlying.Base != currency.ETH {
+		t.Errorf("received '%v', expected '%v'", chains[2].Underlying.Base, currency.ETH)
+	}
+}
+
+func TestPrice(t *testing.T) {
+	t.Parallel()
+	p, err := Price(Call, 100, 100, 1, 0.05, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !approx(p, 10.4506, 1e-4) {
+		t.Errorf("received '%v', expected '%v'", p, 10.4506)
+	}
+	p, err = Price(Put, 100, 100, 1, 0.05, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !approx(p, 5.5735, 1e-4) {
+		t.Errorf("received '%v', expected '%v'", p, 5.5735)
+	}
+	_, err = Price(UnknownType, 100, 100, 1, 0.05, 0.2)
+	if !errors.Is(err, errInvalidType) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidType)
+	}
+	_, err = Price(Call, 100, 100, 0, 0.05, 0.2)
+	if !errors.Is(err, ErrContractExpired) {
+		t.Errorf("received '%v', expected '%v'", err, ErrContractExpired)
+	}
+	_, err = Price(Call, 100, 100, 1, 0.05, 0)
+	if !errors.Is(err, errInvalidVolatility) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidVolatility)
+	}
+}
+
+func TestCalculateGreeks(t *testing.T) {
+	t.Parallel()
+	g, err := CalculateGreeks(Call, 100, 100, 1, 0.05, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	expected := Greeks{Delta: 0.6368, Gamma: 0.0188, Vega: 0.3752, Theta: -0.0176, Rho: 0.5323}
+	if !approx(g.Delta, expected.Delta, 1e-4) ||
+		!approx(g.Gamma, expected.Gamma, 1e-4) ||
+		!approx(g.Vega, expected.Vega, 1e-4) ||
+		!approx(g.Theta, expected.Theta, 1e-4) ||
+		!approx(g.Rho, expected.Rho, 1e-4) {
+		t.Errorf("received '%+v', expected '%+v'", g, expected)
+	}
+	g, err = CalculateGreeks(Put, 100, 100, 1, 0.05, 0.2)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	expected = Greeks{Delta: -0.3632, Gamma: 0.0188, Vega: 0.3752, Theta: -0.0045, Rho: -0.4189}
+	if !approx(g.Delta, expected.Delta, 1e-4) ||
+		!approx(g.Gamma, expected.Gamma, 1e-4) ||
+		!approx(g.Vega, expected.Vega, 1e-4) ||
+		!approx(g.Theta, expected.Theta, 1e-4) ||
+		!approx(g.Rho, expected.Rho, 1e-4) {
+		t.Errorf("received '%+v', expected '%+v'", g, expected)
+	}
+}
+
+func TestImpliedVolatility(t *testing.T) {
+	t.Parallel()
+	for _, vol := range []float64{0.05, 0.2, 0.8, 2.5} {
+		for _, optionType := range []Type{Call, Put} {
+			p, err := Price(optionType, 100, 110, 0.25, 0.03, vol)
+			if !errors.Is(err, nil) {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			iv, err := ImpliedVolatility(optionType, p, 100, 110, 0.25, 0.03)
+			if !errors.Is(err, nil) {
+				t.Fatalf("%v %v received '%v', expected '%v'", optionType, vol, err, nil)
+			}
+			if !approx(iv, vol, 1e-6) {
+				t.Errorf("%v received '%v', expected '%v'", optionType, iv, vol)
+			}
+		}
+	}
+	_, err := ImpliedVolatility(Call, 0.5, 100, 50, 1, 0)
+	if !errors.Is(err, ErrPriceOutsideBounds) {
+		t.Errorf("received '%v', expected '%v'", err, ErrPriceOutsideBounds)
+	}
+	_, err = ImpliedVolatility(Put, 0, 100, 50, 1, 0)
+	if !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("received '%v', expected '%v'", err, ErrInvalidPrice)
+	}
+}
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+	c, err := Parse("okx", currency.NewPairWithDelimiter("BTC", "USD-230929-30000-C", "-"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	at := c.Expiry.Add(-time.Hour * 24 * 73)
+	_, err = Evaluate(c, 0, 0.05, 30000, 0, at)
+	if !errors.Is(err, ErrInvalidPrice) {
+		t.Errorf("received '%v', expected '%v'", err, ErrInvalidPrice)
+	}
+	_, err = Evaluate(c, 0.04, 0.05, 30000, 0, c.Expiry)
+	if !errors.Is(err, ErrContractExpired) {
+		t.Errorf("received '%v', expected '%v'", err, ErrContractExpired)
+	}
+	premium, err := Price(Call, 30000, 30000, 0.2, 0, 0.5)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// inverse premiums are quoted in the base currency
+	mid := premium / 30000
+	a, err := Evaluate(c, mid*0.99, mid*1.01, 30000, 0, at)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !approx(a.TimeToExpiry, 0.2, 1e-9) || !approx(a.ImpliedVolatility, 0.5, 1e-6) {
+		t.Errorf("received '%+v', expected 0.2 years to expiry and 0.5 implied volatility", a)
+	}
+	if !approx(a.Delta, 0.5445, 1e-4) {
+		t.Errorf("received '%v', expected '%v'", a.Delta, 0.5445)
+	}
+}
+
+func TestAggregateGreeks(t *testing.T) {
+	t.Parallel()
+	positions := []Position{
+		{Analytics: &Analytics{Greeks: Greeks{Delta: 0.5, Gamma: 0.01, Vega: 2, Theta: -1, Rho: 0.3}}, Size: 2},
+		{Analytics: &Analytics{Greeks: Greeks{Delta: -0.25, Gamma: 0.02, Vega: 1, Theta: -0.5, Rho: -0.1}}, Size: -4},
+		{Size: 10},
+	}
+	p := AggregateGreeks(positions)
+	expected := Greeks{Delta: 2, Gamma: -0.06, Vega: 0, Theta: 0, Rho: 1}
+	if !approx(p.Delta, expected.Delta, 1e-9) ||
+		!approx(p.Gamma, expected.Gamma, 1e-9) ||
+		!approx(p.Vega, expected.Vega, 1e-9) ||
+		!approx(p.Theta, expected.Theta, 1e-9) ||
+		!approx(p.Rho, expected.Rho, 1e-9) {
+		t.Errorf("received '%+v', expected '%+v'", p.Greeks, expected)
+	}
+	if len(p.Positions) != 3 {
+		t.Errorf("received '%v', expected '%v'", len(p.Positions), 3)
+	}
+}
+
+func TestTypeString(t *testing.T) {
+	t.Parallel()
+	if Call.String() != "call" || Put.String() != "put" || UnknownType.String() != "unknown" {
+		t.Error("unexpected option type strings")
+	}
+}
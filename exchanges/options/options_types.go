@@ -0,0 +1,120 @@
+package options
+
+import (
+	"errors"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Option types
+const (
+	UnknownType Type = iota
+	Call
+	Put
+)
+
+// daysPerYear is used to convert time to expiry into years and theta into a
+// daily value
+const daysPerYear = 365
+
+var (
+	// ErrUnsupportedExchange is returned when an exchange has no registered
+	// naming convention for its options instruments
+	ErrUnsupportedExchange = errors.New("exchange options naming convention not supported")
+	// ErrInvalidInstrument is returned when an instrument does not match an
+	// exchange's naming convention
+	ErrInvalidInstrument = errors.New("invalid options instrument")
+	// ErrContractExpired is returned when pricing a contract at or after its
+	// expiry
+	ErrContractExpired = errors.New("options contract has expired")
+	// ErrPriceOutsideBounds is returned when an option price is outside the
+	// arbitrage bounds and has no implied volatility
+	ErrPriceOutsideBounds = errors.New("option price outside arbitrage bounds")
+	// ErrInvalidPrice is returned when a price is zero or negative
+	ErrInvalidPrice = errors.New("price must be greater than zero")
+	// ErrImpliedVolatilityNotFound is returned when implied volatility does
+	// not converge
+	ErrImpliedVolatilityNotFound = errors.New("implied volatility did not converge")
+
+	errInvalidVolatility = errors.New("volatility must be greater than zero")
+	errInvalidType       = errors.New("invalid option type")
+)
+
+// Type is either a call or put
+type Type uint8
+
+// Convention describes how an exchange names its options instruments
+type Convention struct {
+	// ExpiryHour is the UTC hour contracts expire on their expiry date
+	ExpiryHour int
+	// InversePremium is set when premiums are quoted in the underlying base
+	// currency rather than the quote currency, eg OKX BTC-USD options are
+	// priced in BTC
+	InversePremium bool
+}
+
+// Contract is an options instrument parsed into its terms
+type Contract struct {
+	Exchange       string
+	Asset          asset.Item
+	Pair           currency.Pair
+	Underlying     currency.Pair
+	Expiry         time.Time
+	Strike         float64
+	Type           Type
+	InversePremium bool
+}
+
+// Chain holds every contract for an underlying and expiry ordered by strike
+type Chain struct {
+	Exchange   string
+	Underlying currency.Pair
+	Expiry     time.Time
+	Strikes    []Strike
+}
+
+// Strike holds the call and put contracts at a single strike, either may be
+// nil when only one side is listed
+type Strike struct {
+	Strike float64
+	Call   *Contract
+	Put    *Contract
+}
+
+// Greeks are the sensitivities of an option price. Delta is per unit of the
+// underlying, vega and rho are per one percentage point change in volatility
+// and rate, theta is per calendar day
+type Greeks struct {
+	Delta float64
+	Gamma float64
+	Vega  float64
+	Theta float64
+	Rho   float64
+}
+
+// Analytics holds the pricing of a contract from a market price
+type Analytics struct {
+	Contract        *Contract
+	Bid             float64
+	Ask             float64
+	Mid             float64
+	UnderlyingPrice float64
+	// TimeToExpiry is in years
+	TimeToExpiry      float64
+	ImpliedVolatility float64
+	Greeks
+}
+
+// Position is a net holding of a contract. A negative size is short
+type Position struct {
+	Analytics *Analytics
+	Size      float64
+}
+
+// PortfolioGreeks are the position weighted greeks of a set of positions
+type PortfolioGreeks struct {
+	Positions []Position
+	Greeks
+}
@@ -0,0 +1,142 @@
+package options
+
+import (
+	"math"
+)
+
+const (
+	minimumVolatility    = 1e-4
+	maximumVolatility    = 10.0
+	volatilityTolerance  = 1e-8
+	maxVolatilityRetries = 100
+)
+
+// Price returns the Black-Scholes price of a European option. timeToExpiry
+// is in years, riskFreeRate and volatility are annualised decimals
+func Price(t Type, underlyingPrice, strike, timeToExpiry, riskFreeRate, volatility float64) (float64, error) {
+	if err := checkInputs(t, underlyingPrice, strike, timeToExpiry); err != nil {
+		return 0, err
+	}
+	if volatility <= 0 {
+		return 0, errInvalidVolatility
+	}
+	d1, d2 := d1d2(underlyingPrice, strike, timeToExpiry, riskFreeRate, volatility)
+	discount := math.Exp(-riskFreeRate * timeToExpiry)
+	if t == Call {
+		return underlyingPrice*normCDF(d1) - strike*discount*normCDF(d2), nil
+	}
+	return strike*discount*normCDF(-d2) - underlyingPrice*normCDF(-d1), nil
+}
+
+// CalculateGreeks returns the Black-Scholes greeks of a European option
+func CalculateGreeks(t Type, underlyingPrice, strike, timeToExpiry, riskFreeRate, volatility float64) (Greeks, error) {
+	if err := checkInputs(t, underlyingPrice, strike, timeToExpiry); err != nil {
+		return Greeks{}, err
+	}
+	if volatility <= 0 {
+		return Greeks{}, errInvalidVolatility
+	}
+	d1, d2 := d1d2(underlyingPrice, strike, timeToExpiry, riskFreeRate, volatility)
+	discount := math.Exp(-riskFreeRate * timeToExpiry)
+	sqrtT := math.Sqrt(timeToExpiry)
+	g := Greeks{
+		Gamma: normPDF(d1) / (underlyingPrice * volatility * sqrtT),
+		Vega:  underlyingPrice * normPDF(d1) * sqrtT / 100,
+	}
+	decay := -underlyingPrice * normPDF(d1) * volatility / (2 * sqrtT)
+	if t == Call {
+		g.Delta = normCDF(d1)
+		g.Theta = (decay - riskFreeRate*strike*discount*normCDF(d2)) / daysPerYear
+		g.Rho = strike * timeToExpiry * discount * normCDF(d2) / 100
+	} else {
+		g.Delta = normCDF(d1) - 1
+		g.Theta = (decay + riskFreeRate*strike*discount*normCDF(-d2)) / daysPerYear
+		g.Rho = -strike * timeToExpiry * discount * normCDF(-d2) / 100
+	}
+	return g, nil
+}
+
+// ImpliedVolatility returns the volatility which prices a European option at
+// the supplied price. Newton-Raphson is used with bisection as a fallback
+func ImpliedVolatility(t Type, price, underlyingPrice, strike, timeToExpiry, riskFreeRate float64) (float64, error) {
+	if err := checkInputs(t, underlyingPrice, strike, timeToExpiry); err != nil {
+		return 0, err
+	}
+	if price <= 0 {
+		return 0, ErrInvalidPrice
+	}
+	discountedStrike := strike * math.Exp(-riskFreeRate*timeToExpiry)
+	lower, upper := math.Max(underlyingPrice-discountedStrike, 0), underlyingPrice
+	if t == Put {
+		lower, upper = math.Max(discountedStrike-underlyingPrice, 0), discountedStrike
+	}
+	if price < lower || price >= upper {
+		return 0, ErrPriceOutsideBounds
+	}
+
+	vol := 0.5
+	for i := 0; i < maxVolatilityRetries; i++ {
+		p, err := Price(t, underlyingPrice, strike, timeToExpiry, riskFreeRate, vol)
+		if err != nil {
+			return 0, err
+		}
+		diff := p - price
+		if math.Abs(diff) < volatilityTolerance {
+			return vol, nil
+		}
+		d1, _ := d1d2(underlyingPrice, strike, timeToExpiry, riskFreeRate, vol)
+		vega := underlyingPrice * normPDF(d1) * math.Sqrt(timeToExpiry)
+		if vega < volatilityTolerance {
+			break
+		}
+		vol -= diff / vega
+		if vol <= minimumVolatility || vol >= maximumVolatility || math.IsNaN(vol) {
+			break
+		}
+	}
+
+	low, high := minimumVolatility, maximumVolatility
+	for i := 0; i < maxVolatilityRetries*2; i++ {
+		mid := (low + high) / 2
+		p, err := Price(t, underlyingPrice, strike, timeToExpiry, riskFreeRate, mid)
+		if err != nil {
+			return 0, err
+		}
+		if math.Abs(p-price) < volatilityTolerance || high-low < volatilityTolerance {
+			return mid, nil
+		}
+		if p > price {
+			high = mid
+		} else {
+			low = mid
+		}
+	}
+	return 0, ErrImpliedVolatilityNotFound
+}
+
+func checkInputs(t Type, underlyingPrice, strike, timeToExpiry float64) error {
+	if t != Call && t != Put {
+		return errInvalidType
+	}
+	if underlyingPrice <= 0 || strike <= 0 {
+		return ErrInvalidPrice
+	}
+	if timeToExpiry <= 0 {
+		return ErrContractExpired
+	}
+	return nil
+}
+
+func d1d2(underlyingPrice, strike, timeToExpiry, riskFreeRate, volatility float64) (d1, d2 float64) {
+	volSqrtT := volatility * math.Sqrt(timeToExpiry)
+	d1 = (math.Log(underlyingPrice/strike) + (riskFreeRate+volatility*volatility/2)*timeToExpiry) / volSqrtT
+	return d1, d1 - volSqrtT
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
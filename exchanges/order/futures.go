@@ -258,6 +258,42 @@ func (c *PositionController) UpdateOpenPositionUnrealisedPNL(exch string, item a
 	return latestPos.unrealisedPNL, nil
 }
 
+// SetOpenPositionLiquidationEstimate stores the latest margin engine
+// estimate against an open position so it is returned with its stats
+func (c *PositionController) SetOpenPositionLiquidationEstimate(exch string, item asset.Item, pair currency.Pair, estimate *LiquidationEstimate) error {
+	if c == nil {
+		return fmt.Errorf("position controller %w", common.ErrNilPointer)
+	}
+	if estimate == nil {
+		return fmt.Errorf("liquidation estimate %w", common.ErrNilPointer)
+	}
+	var err error
+	exch, err = checkTrackerPrerequisitesLowerExchange(exch, item, pair)
+	if err != nil {
+		return err
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	tracker := c.multiPositionTrackers[exch][item][pair.Base.Item][pair.Quote.Item]
+	if tracker == nil {
+		return fmt.Errorf("%v %v %v %w", exch, item, pair, ErrPositionNotFound)
+	}
+	tracker.m.Lock()
+	defer tracker.m.Unlock()
+	if len(tracker.positions) == 0 {
+		return fmt.Errorf("%v %v %v %w", exch, item, pair, ErrPositionNotFound)
+	}
+	latestPos := tracker.positions[len(tracker.positions)-1]
+	latestPos.m.Lock()
+	defer latestPos.m.Unlock()
+	if latestPos.status != Open {
+		return fmt.Errorf("%v %v %v %w", exch, item, pair, ErrPositionClosed)
+	}
+	est := *estimate
+	latestPos.liquidationEstimate = &est
+	return nil
+}
+
 // SetupMultiPositionTracker creates a futures order tracker for a specific exchange
 func SetupMultiPositionTracker(setup *MultiPositionTrackerSetup) (*MultiPositionTracker, error) {
 	if setup == nil {
@@ -550,6 +586,11 @@ func (p *PositionTracker) GetStats() *Position {
 		LastUpdated:      p.lastUpdated,
 	}
 
+	if p.liquidationEstimate != nil && p.status == Open {
+		est := *p.liquidationEstimate
+		pos.LiquidationEstimate = &est
+	}
+
 	if p.fundingRateDetails != nil {
 		frs := make([]fundingrate.Rate, len(p.fundingRateDetails.FundingRates))
 		copy(frs, p.fundingRateDetails.FundingRates)
@@ -175,27 +175,28 @@ type PositionTracker struct {
 	collateralCurrency        currency.Code
 	offlinePNLCalculation     bool
 	PNLCalculation
-	exchange           string
-	asset              asset.Item
-	contractPair       currency.Pair
-	underlying         currency.Code
-	exposure           decimal.Decimal
-	openingDirection   Side
-	openingPrice       decimal.Decimal
-	openingSize        decimal.Decimal
-	openingDate        time.Time
-	latestDirection    Side
-	latestPrice        decimal.Decimal
-	lastUpdated        time.Time
-	unrealisedPNL      decimal.Decimal
-	realisedPNL        decimal.Decimal
-	status             Status
-	closingPrice       decimal.Decimal
-	closingDate        time.Time
-	shortPositions     []Detail
-	longPositions      []Detail
-	pnlHistory         []PNLResult
-	fundingRateDetails *fundingrate.Rates
+	exchange            string
+	asset               asset.Item
+	contractPair        currency.Pair
+	underlying          currency.Code
+	exposure            decimal.Decimal
+	openingDirection    Side
+	openingPrice        decimal.Decimal
+	openingSize         decimal.Decimal
+	openingDate         time.Time
+	latestDirection     Side
+	latestPrice         decimal.Decimal
+	lastUpdated         time.Time
+	unrealisedPNL       decimal.Decimal
+	realisedPNL         decimal.Decimal
+	status              Status
+	closingPrice        decimal.Decimal
+	closingDate         time.Time
+	shortPositions      []Detail
+	longPositions       []Detail
+	pnlHistory          []PNLResult
+	fundingRateDetails  *fundingrate.Rates
+	liquidationEstimate *LiquidationEstimate
 }
 
 // PositionTrackerSetup contains all required fields to
@@ -304,6 +305,8 @@ type Position struct {
 	Orders             []Detail
 	PNLHistory         []PNLResult
 	FundingRates       fundingrate.Rates
+	// LiquidationEstimate is set by the margin engine on open positions
+	LiquidationEstimate *LiquidationEstimate
 }
 
 // PositionSummaryRequest is used to request a summary of an open position
@@ -0,0 +1,303 @@
+package order
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// String implements the stringer interface
+func (m MarginMode) String() string {
+	switch m {
+	case IsolatedMargin:
+		return "isolated"
+	case CrossMargin:
+		return "cross"
+	default:
+		return "unknown"
+	}
+}
+
+// StringToMarginMode converts a string to a margin mode
+func StringToMarginMode(mode string) (MarginMode, error) {
+	switch strings.ToLower(mode) {
+	case "isolated":
+		return IsolatedMargin, nil
+	case "cross":
+		return CrossMargin, nil
+	default:
+		return UnknownMarginMode, fmt.Errorf("%w %q", ErrInvalidMarginMode, mode)
+	}
+}
+
+// String implements the stringer interface
+func (r MarginRisk) String() string {
+	switch r {
+	case MarginRiskSafe:
+		return "safe"
+	case MarginRiskWarning:
+		return "warning"
+	case MarginRiskCritical:
+		return "critical"
+	case MarginRiskLiquidation:
+		return "liquidation"
+	default:
+		return "unknown"
+	}
+}
+
+// SetupMarginEngine returns a margin engine which classifies positions as a
+// warning or critical once their margin ratio reaches the supplied thresholds
+func SetupMarginEngine(warningMarginRatio, criticalMarginRatio decimal.Decimal) (*MarginEngine, error) {
+	if warningMarginRatio.LessThanOrEqual(decimal.Zero) ||
+		criticalMarginRatio.GreaterThan(decimal.NewFromInt(1)) ||
+		warningMarginRatio.GreaterThanOrEqual(criticalMarginRatio) {
+		return nil, fmt.Errorf("%w warning: %v critical: %v", errInvalidRiskThresholds, warningMarginRatio, criticalMarginRatio)
+	}
+	return &MarginEngine{
+		settings:            make(map[string]map[asset.Item]*marginSettings),
+		warningMarginRatio:  warningMarginRatio,
+		criticalMarginRatio: criticalMarginRatio,
+	}, nil
+}
+
+// SetMaintenanceMarginTiers sets the margin mode and maintenance margin tiers
+// used for an exchange's futures asset. Tiers must be in ascending notional
+// order and only the final tier may be unbounded
+func (e *MarginEngine) SetMaintenanceMarginTiers(exch string, item asset.Item, mode MarginMode, tiers []MaintenanceMarginTier) error {
+	if e == nil {
+		return fmt.Errorf("margin engine %w", common.ErrNilPointer)
+	}
+	if exch == "" {
+		return errExchangeNameEmpty
+	}
+	if !item.IsFutures() {
+		return fmt.Errorf("%v %w", item, ErrNotFuturesAsset)
+	}
+	if mode != IsolatedMargin && mode != CrossMargin {
+		return fmt.Errorf("%w %v", ErrInvalidMarginMode, mode)
+	}
+	if len(tiers) == 0 {
+		return fmt.Errorf("%v %v %w", exch, item, ErrNoMaintenanceMarginTiers)
+	}
+	for i := range tiers {
+		if tiers[i].MaintenanceMarginRate.LessThan(decimal.Zero) ||
+			tiers[i].MaintenanceMarginRate.GreaterThanOrEqual(decimal.NewFromInt(1)) ||
+			tiers[i].MaintenanceAmount.LessThan(decimal.Zero) ||
+			tiers[i].MaxNotional.LessThan(decimal.Zero) {
+			return fmt.Errorf("%w %v for %v %v", errInvalidMarginTier, i, exch, item)
+		}
+		if tiers[i].MaxNotional.IsZero() && i != len(tiers)-1 {
+			return fmt.Errorf("%w %v for %v %v: only the final tier may be unbounded", errInvalidMarginTier, i, exch, item)
+		}
+		if i > 0 && !tiers[i].MaxNotional.IsZero() && tiers[i].MaxNotional.LessThanOrEqual(tiers[i-1].MaxNotional) {
+			return fmt.Errorf("%w %v for %v %v: tiers must be in ascending notional order", errInvalidMarginTier, i, exch, item)
+		}
+	}
+	t := make([]MaintenanceMarginTier, len(tiers))
+	copy(t, tiers)
+	exch = strings.ToLower(exch)
+	e.m.Lock()
+	defer e.m.Unlock()
+	if e.settings == nil {
+		e.settings = make(map[string]map[asset.Item]*marginSettings)
+	}
+	if e.settings[exch] == nil {
+		e.settings[exch] = make(map[asset.Item]*marginSettings)
+	}
+	e.settings[exch][item] = &marginSettings{mode: mode, tiers: t}
+	return nil
+}
+
+// GetMarginMode returns the margin mode configured for an exchange asset
+func (e *MarginEngine) GetMarginMode(exch string, item asset.Item) (MarginMode, error) {
+	if e == nil {
+		return UnknownMarginMode, fmt.Errorf("margin engine %w", common.ErrNilPointer)
+	}
+	e.m.RLock()
+	defer e.m.RUnlock()
+	s := e.settings[strings.ToLower(exch)][item]
+	if s == nil {
+		return UnknownMarginMode, fmt.Errorf("%v %v %w", exch, item, ErrNoMaintenanceMarginTiers)
+	}
+	return s.mode, nil
+}
+
+// EstimatePositionLiquidation estimates the liquidation price and margin
+// standing of an open position at the supplied mark price. Collateral is
+// the margin backing the position excluding its unrealised PNL, see
+// PositionCollateral
+func (e *MarginEngine) EstimatePositionLiquidation(p *Position, collateral, markPrice decimal.Decimal) (*LiquidationEstimate, error) {
+	if e == nil {
+		return nil, fmt.Errorf("margin engine %w", common.ErrNilPointer)
+	}
+	if p == nil {
+		return nil, fmt.Errorf("position %w", common.ErrNilPointer)
+	}
+	if p.Asset == asset.CoinMarginedFutures {
+		return nil, fmt.Errorf("%v %v %v %w", p.Exchange, p.Asset, p.Pair, errInverseMarginUnsupported)
+	}
+	e.m.RLock()
+	s := e.settings[strings.ToLower(p.Exchange)][p.Asset]
+	warning, critical := e.warningMarginRatio, e.criticalMarginRatio
+	e.m.RUnlock()
+	if s == nil {
+		return nil, fmt.Errorf("%v %v %w", p.Exchange, p.Asset, ErrNoMaintenanceMarginTiers)
+	}
+	est, err := EstimateLiquidation(&LiquidationRequest{
+		Mode:                s.mode,
+		Direction:           p.LatestDirection,
+		Size:                p.LatestSize.Abs(),
+		MarkPrice:           markPrice,
+		UnrealisedPNL:       p.UnrealisedPNL,
+		Collateral:          collateral,
+		Tiers:               s.tiers,
+		WarningMarginRatio:  warning,
+		CriticalMarginRatio: critical,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%v %v %v %w", p.Exchange, p.Asset, p.Pair, err)
+	}
+	est.CollateralCurrency = p.CollateralCurrency
+	return est, nil
+}
+
+// EstimateLiquidation estimates where a linear futures position would be
+// liquidated. A position is liquidated once its margin balance, being
+// collateral plus unrealised PNL, falls to the maintenance margin of the
+// tier matching its notional value
+func EstimateLiquidation(r *LiquidationRequest) (*LiquidationEstimate, error) {
+	if r == nil {
+		return nil, fmt.Errorf("liquidation request %w", common.ErrNilPointer)
+	}
+	if r.Mode != IsolatedMargin && r.Mode != CrossMargin {
+		return nil, fmt.Errorf("%w %v", ErrInvalidMarginMode, r.Mode)
+	}
+	if !r.Direction.IsLong() && !r.Direction.IsShort() {
+		return nil, fmt.Errorf("%w %v", ErrSideIsInvalid, r.Direction)
+	}
+	if r.Size.LessThanOrEqual(decimal.Zero) {
+		return nil, errZeroPositionSize
+	}
+	if r.MarkPrice.LessThanOrEqual(decimal.Zero) {
+		return nil, errInvalidMarkPrice
+	}
+	if len(r.Tiers) == 0 {
+		return nil, ErrNoMaintenanceMarginTiers
+	}
+
+	notional := r.Size.Mul(r.MarkPrice)
+	tier := tierForNotional(r.Tiers, notional)
+	est := &LiquidationEstimate{
+		Mode:                  r.Mode,
+		MarkPrice:             r.MarkPrice,
+		MaintenanceMarginRate: tier.MaintenanceMarginRate,
+		MaintenanceMargin:     decimal.Max(notional.Mul(tier.MaintenanceMarginRate).Sub(tier.MaintenanceAmount), decimal.Zero),
+		MarginBalance:         r.Collateral.Add(r.UnrealisedPNL),
+	}
+
+	// the tier applied at liquidation depends on the notional value at the
+	// liquidation price, so the price is recalculated until the tier settles
+	liquidationTier := tier
+	for i := 0; i <= len(r.Tiers); i++ {
+		price, ok := liquidationPrice(r.Direction.IsLong(), r.Size, r.MarkPrice, est.MarginBalance, liquidationTier)
+		est.HasLiquidationPrice = ok
+		if !ok {
+			est.LiquidationPrice = decimal.Zero
+			break
+		}
+		est.LiquidationPrice = price
+		next := tierForNotional(r.Tiers, r.Size.Mul(price))
+		if next.MaxNotional.Equal(liquidationTier.MaxNotional) {
+			break
+		}
+		liquidationTier = next
+	}
+
+	if est.MarginBalance.LessThanOrEqual(decimal.Zero) {
+		est.MarginRatio = decimal.NewFromInt(1)
+	} else {
+		est.MarginRatio = est.MaintenanceMargin.Div(est.MarginBalance)
+	}
+	if est.HasLiquidationPrice {
+		est.DistanceToLiquidation = r.MarkPrice.Sub(est.LiquidationPrice).Abs().Div(r.MarkPrice)
+	}
+	switch {
+	case est.MarginRatio.GreaterThanOrEqual(decimal.NewFromInt(1)):
+		est.Risk = MarginRiskLiquidation
+	case !r.CriticalMarginRatio.IsZero() && est.MarginRatio.GreaterThanOrEqual(r.CriticalMarginRatio):
+		est.Risk = MarginRiskCritical
+	case !r.WarningMarginRatio.IsZero() && est.MarginRatio.GreaterThanOrEqual(r.WarningMarginRatio):
+		est.Risk = MarginRiskWarning
+	}
+	return est, nil
+}
+
+// PositionCollateral returns the collateral backing a position, excluding its
+// unrealised PNL, from an exchange's total collateral. Isolated positions are
+// backed by the collateral allocated to them and cross positions by all of
+// the account's available and used collateral
+func PositionCollateral(mode MarginMode, pair currency.Pair, unrealisedPNL decimal.Decimal, collateral *TotalCollateralResponse) (decimal.Decimal, error) {
+	if collateral == nil {
+		return decimal.Zero, fmt.Errorf("total collateral %w", common.ErrNilPointer)
+	}
+	switch mode {
+	case IsolatedMargin:
+		for i := range collateral.BreakdownOfPositions {
+			if collateral.BreakdownOfPositions[i].PositionCurrency.Equal(pair) {
+				return collateral.BreakdownOfPositions[i].CollateralUsed, nil
+			}
+		}
+		return decimal.Zero, fmt.Errorf("%v %w", pair, errPositionCollateralNotFound)
+	case CrossMargin:
+		return collateral.AvailableCollateral.Add(collateral.UsedCollateral).Sub(unrealisedPNL), nil
+	default:
+		return decimal.Zero, fmt.Errorf("%w %v", ErrInvalidMarginMode, mode)
+	}
+}
+
+// PositionMarkPrice returns the exchange's mark price of a position from an
+// exchange's total collateral. ok is false when the exchange did not return a
+// mark price for the position
+func PositionMarkPrice(pair currency.Pair, collateral *TotalCollateralResponse) (markPrice decimal.Decimal, ok bool) {
+	if collateral == nil {
+		return decimal.Zero, false
+	}
+	for i := range collateral.BreakdownOfPositions {
+		if collateral.BreakdownOfPositions[i].PositionCurrency.Equal(pair) &&
+			collateral.BreakdownOfPositions[i].MarkPrice.GreaterThan(decimal.Zero) {
+			return collateral.BreakdownOfPositions[i].MarkPrice, true
+		}
+	}
+	return decimal.Zero, false
+}
+
+// liquidationPrice solves margin balance + PNL from the mark price equal to
+// the maintenance margin at the liquidation price. ok is false when no
+// positive price liquidates the position
+func liquidationPrice(isLong bool, size, markPrice, marginBalance decimal.Decimal, tier MaintenanceMarginTier) (price decimal.Decimal, ok bool) {
+	one := decimal.NewFromInt(1)
+	notional := size.Mul(markPrice)
+	if isLong {
+		// balance + size*(p-mark) = size*p*rate - amount
+		price = notional.Sub(marginBalance).Sub(tier.MaintenanceAmount).Div(size.Mul(one.Sub(tier.MaintenanceMarginRate)))
+	} else {
+		// balance - size*(p-mark) = size*p*rate - amount
+		price = notional.Add(marginBalance).Add(tier.MaintenanceAmount).Div(size.Mul(one.Add(tier.MaintenanceMarginRate)))
+	}
+	return price, price.GreaterThan(decimal.Zero)
+}
+
+// tierForNotional returns the first tier which can hold the notional value
+func tierForNotional(tiers []MaintenanceMarginTier, notional decimal.Decimal) MaintenanceMarginTier {
+	for i := range tiers {
+		if tiers[i].MaxNotional.IsZero() || notional.LessThanOrEqual(tiers[i].MaxNotional) {
+			return tiers[i]
+		}
+	}
+	return tiers[len(tiers)-1]
+}
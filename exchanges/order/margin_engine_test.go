@@ -0,0 +1,380 @@
+package order
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+var testMarginTiers = []MaintenanceMarginTier{
+	{MaxNotional: decimal.NewFromInt(50000), MaintenanceMarginRate: decimal.NewFromFloat(0.004)},
+	{MaxNotional: decimal.NewFromInt(250000), MaintenanceMarginRate: decimal.NewFromFloat(0.005), MaintenanceAmount: decimal.NewFromInt(50)},
+	{MaintenanceMarginRate: decimal.NewFromFloat(0.01), MaintenanceAmount: decimal.NewFromInt(1300)},
+}
+
+func TestStringToMarginMode(t *testing.T) {
+	t.Parallel()
+	m, err := StringToMarginMode("ISOLATED")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if m != IsolatedMargin || m.String() != "isolated" {
+		t.Errorf("received '%v', expected '%v'", m, IsolatedMargin)
+	}
+	m, err = StringToMarginMode("cross")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if m != CrossMargin || m.String() != "cross" {
+		t.Errorf("received '%v', expected '%v'", m, CrossMargin)
+	}
+	_, err = StringToMarginMode("portfolio")
+	if !errors.Is(err, ErrInvalidMarginMode) {
+		t.Errorf("received '%v', expected '%v'", err, ErrInvalidMarginMode)
+	}
+	if MarginRiskCritical.String() != "critical" || MarginRisk(99).String() != "unknown" {
+		t.Error("unexpected margin risk strings")
+	}
+}
+
+func TestSetupMarginEngine(t *testing.T) {
+	t.Parallel()
+	_, err := SetupMarginEngine(decimal.Zero, decimal.NewFromFloat(0.8))
+	if !errors.Is(err, errInvalidRiskThresholds) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidRiskThresholds)
+	}
+	_, err = SetupMarginEngine(decimal.NewFromFloat(0.8), decimal.NewFromFloat(0.5))
+	if !errors.Is(err, errInvalidRiskThresholds) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidRiskThresholds)
+	}
+	_, err = SetupMarginEngine(decimal.NewFromFloat(0.5), decimal.NewFromFloat(1.5))
+	if !errors.Is(err, errInvalidRiskThresholds) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidRiskThresholds)
+	}
+	_, err = SetupMarginEngine(decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.8))
+	if !errors.Is(err, nil) {
+		t.Errorf("received '%v', expected '%v'", err, nil)
+	}
+}
+
+func TestSetMaintenanceMarginTiers(t *testing.T) {
+	t.Parallel()
+	var e *MarginEngine
+	err := e.SetMaintenanceMarginTiers(testExchange, asset.USDTMarginedFutures, IsolatedMargin, testMarginTiers)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	e, err = SetupMarginEngine(decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.8))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = e.SetMaintenanceMarginTiers("", asset.USDTMarginedFutures, IsolatedMargin, testMarginTiers)
+	if !errors.Is(err, errExchangeNameEmpty) {
+		t.Errorf("received '%v', expected '%v'", err, errExchangeNameEmpty)
+	}
+	err = e.SetMaintenanceMarginTiers(testExchange, asset.Spot, IsolatedMargin, testMarginTiers)
+	if !errors.Is(err, ErrNotFuturesAsset) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNotFuturesAsset)
+	}
+	err = e.SetMaintenanceMarginTiers(testExchange, asset.USDTMarginedFutures, UnknownMarginMode, testMarginTiers)
+	if !errors.Is(err, ErrInvalidMarginMode) {
+		t.Errorf("received '%v', expected '%v'", err, ErrInvalidMarginMode)
+	}
+	err = e.SetMaintenanceMarginTiers(testExchange, asset.USDTMarginedFutures, IsolatedMargin, nil)
+	if !errors.Is(err, ErrNoMaintenanceMarginTiers) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNoMaintenanceMarginTiers)
+	}
+	for _, tiers := range [][]MaintenanceMarginTier{
+		{{MaintenanceMarginRate: decimal.NewFromInt(1)}},
+		{{MaintenanceMarginRate: decimal.NewFromFloat(0.01), MaintenanceAmount: decimal.NewFromInt(-1)}},
+		{{MaintenanceMarginRate: decimal.NewFromFloat(0.01)}, {MaxNotional: decimal.NewFromInt(10), MaintenanceMarginRate: decimal.NewFromFloat(0.01)}},
+		{{MaxNotional: decimal.NewFromInt(10), MaintenanceMarginRate: decimal.NewFromFloat(0.01)}, {MaxNotional: decimal.NewFromInt(5), MaintenanceMarginRate: decimal.NewFromFloat(0.01)}},
+	} {
+		err = e.SetMaintenanceMarginTiers(testExchange, asset.USDTMarginedFutures, IsolatedMargin, tiers)
+		if !errors.Is(err, errInvalidMarginTier) {
+			t.Errorf("received '%v', expected '%v'", err, errInvalidMarginTier)
+		}
+	}
+	_, err = e.GetMarginMode(testExchange, asset.USDTMarginedFutures)
+	if !errors.Is(err, ErrNoMaintenanceMarginTiers) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNoMaintenanceMarginTiers)
+	}
+	err = e.SetMaintenanceMarginTiers("TeSt", asset.USDTMarginedFutures, CrossMargin, testMarginTiers)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	m, err := e.GetMarginMode(testExchange, asset.USDTMarginedFutures)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if m != CrossMargin {
+		t.Errorf("received '%v', expected '%v'", m, CrossMargin)
+	}
+}
+
+func TestEstimateLiquidation(t *testing.T) {
+	t.Parallel()
+	_, err := EstimateLiquidation(nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	r := &LiquidationRequest{
+		Mode:                IsolatedMargin,
+		Direction:           Long,
+		Size:                decimal.NewFromInt(1),
+		MarkPrice:           decimal.NewFromInt(20000),
+		Collateral:          decimal.NewFromInt(2000),
+		Tiers:               testMarginTiers,
+		WarningMarginRatio:  decimal.NewFromFloat(0.5),
+		CriticalMarginRatio: decimal.NewFromFloat(0.8),
+	}
+	est, err := EstimateLiquidation(r)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// (20000 - 2000) / (1 - 0.004)
+	if expected := decimal.NewFromFloat(18072.2892); !est.HasLiquidationPrice || !est.LiquidationPrice.Round(4).Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.LiquidationPrice, expected)
+	}
+	if expected := decimal.NewFromInt(80); !est.MaintenanceMargin.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.MaintenanceMargin, expected)
+	}
+	if expected := decimal.NewFromFloat(0.04); !est.MarginRatio.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.MarginRatio, expected)
+	}
+	if expected := decimal.NewFromFloat(0.0964); !est.DistanceToLiquidation.Round(4).Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.DistanceToLiquidation, expected)
+	}
+	if est.Risk != MarginRiskSafe {
+		t.Errorf("received '%v', expected '%v'", est.Risk, MarginRiskSafe)
+	}
+
+	r.Direction = Short
+	est, err = EstimateLiquidation(r)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// (20000 + 2000) / (1 + 0.004)
+	if expected := decimal.NewFromFloat(21912.3506); !est.LiquidationPrice.Round(4).Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.LiquidationPrice, expected)
+	}
+
+	// the notional at the liquidation price moves the position into the
+	// second tier
+	r.Size = decimal.NewFromFloat(2.4)
+	r.Collateral = decimal.NewFromInt(20000)
+	est, err = EstimateLiquidation(r)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	// (48000 + 20000 + 50) / (2.4 * (1 + 0.005))
+	if expected := decimal.NewFromFloat(28213.1012); !est.LiquidationPrice.Round(4).Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.LiquidationPrice, expected)
+	}
+	if expected := decimal.NewFromFloat(0.004); !est.MaintenanceMarginRate.Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.MaintenanceMarginRate, expected)
+	}
+
+	r.Direction = Long
+	r.Size = decimal.NewFromInt(1)
+	r.Collateral = decimal.NewFromInt(25000)
+	est, err = EstimateLiquidation(r)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if est.HasLiquidationPrice || !est.DistanceToLiquidation.IsZero() {
+		t.Errorf("received '%+v', expected no liquidation price", est)
+	}
+
+	for _, tc := range []struct {
+		collateral float64
+		unrealised float64
+		risk       MarginRisk
+	}{
+		{150, 0, MarginRiskWarning},
+		{200, -100, MarginRiskCritical},
+		{50, -55, MarginRiskLiquidation},
+	} {
+		r.Collateral = decimal.NewFromFloat(tc.collateral)
+		r.UnrealisedPNL = decimal.NewFromFloat(tc.unrealised)
+		est, err = EstimateLiquidation(r)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if est.Risk != tc.risk {
+			t.Errorf("received '%v', expected '%v'", est.Risk, tc.risk)
+		}
+	}
+
+	r.Direction = UnknownSide
+	_, err = EstimateLiquidation(r)
+	if !errors.Is(err, ErrSideIsInvalid) {
+		t.Errorf("received '%v', expected '%v'", err, ErrSideIsInvalid)
+	}
+	r.Direction = Long
+	r.MarkPrice = decimal.Zero
+	_, err = EstimateLiquidation(r)
+	if !errors.Is(err, errInvalidMarkPrice) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidMarkPrice)
+	}
+	r.Size = decimal.Zero
+	_, err = EstimateLiquidation(r)
+	if !errors.Is(err, errZeroPositionSize) {
+		t.Errorf("received '%v', expected '%v'", err, errZeroPositionSize)
+	}
+}
+
+func TestEstimatePositionLiquidation(t *testing.T) {
+	t.Parallel()
+	e, err := SetupMarginEngine(decimal.NewFromFloat(0.5), decimal.NewFromFloat(0.8))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	p := &Position{
+		Exchange:           testExchange,
+		Asset:              asset.USDTMarginedFutures,
+		Pair:               currency.NewPair(currency.BTC, currency.USDT),
+		CollateralCurrency: currency.USDT,
+		LatestDirection:    Short,
+		LatestSize:         decimal.NewFromInt(1),
+		UnrealisedPNL:      decimal.NewFromInt(-500),
+	}
+	_, err = e.EstimatePositionLiquidation(p, decimal.NewFromInt(2500), decimal.NewFromInt(20000))
+	if !errors.Is(err, ErrNoMaintenanceMarginTiers) {
+		t.Errorf("received '%v', expected '%v'", err, ErrNoMaintenanceMarginTiers)
+	}
+	err = e.SetMaintenanceMarginTiers(testExchange, asset.USDTMarginedFutures, IsolatedMargin, testMarginTiers)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	est, err := e.EstimatePositionLiquidation(p, decimal.NewFromInt(2500), decimal.NewFromInt(20000))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if est.Mode != IsolatedMargin || !est.CollateralCurrency.Equal(currency.USDT) {
+		t.Errorf("received '%+v', expected isolated USDT estimate", est)
+	}
+	if expected := decimal.NewFromFloat(21912.3506); !est.LiquidationPrice.Round(4).Equal(expected) {
+		t.Errorf("received '%v', expected '%v'", est.LiquidationPrice, expected)
+	}
+	p.Asset = asset.CoinMarginedFutures
+	_, err = e.EstimatePositionLiquidation(p, decimal.NewFromInt(2500), decimal.NewFromInt(20000))
+	if !errors.Is(err, errInverseMarginUnsupported) {
+		t.Errorf("received '%v', expected '%v'", err, errInverseMarginUnsupported)
+	}
+	_, err = e.EstimatePositionLiquidation(nil, decimal.Zero, decimal.Zero)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+}
+
+func TestPositionCollateral(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	_, err := PositionCollateral(IsolatedMargin, cp, decimal.Zero, nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	resp := &TotalCollateralResponse{
+		AvailableCollateral: decimal.NewFromInt(1000),
+		UsedCollateral:      decimal.NewFromInt(500),
+		BreakdownOfPositions: []CollateralByPosition{
+			{PositionCurrency: cp, CollateralUsed: decimal.NewFromInt(300)},
+		},
+	}
+	c, err := PositionCollateral(IsolatedMargin, cp, decimal.NewFromInt(-100), resp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !c.Equal(decimal.NewFromInt(300)) {
+		t.Errorf("received '%v', expected '%v'", c, 300)
+	}
+	_, err = PositionCollateral(IsolatedMargin, currency.NewPair(currency.ETH, currency.USDT), decimal.Zero, resp)
+	if !errors.Is(err, errPositionCollateralNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, errPositionCollateralNotFound)
+	}
+	c, err = PositionCollateral(CrossMargin, cp, decimal.NewFromInt(-100), resp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !c.Equal(decimal.NewFromInt(1600)) {
+		t.Errorf("received '%v', expected '%v'", c, 1600)
+	}
+	_, err = PositionCollateral(UnknownMarginMode, cp, decimal.Zero, resp)
+	if !errors.Is(err, ErrInvalidMarginMode) {
+		t.Errorf("received '%v', expected '%v'", err, ErrInvalidMarginMode)
+	}
+}
+
+func TestPositionMarkPrice(t *testing.T) {
+	t.Parallel()
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	if _, ok := PositionMarkPrice(cp, nil); ok {
+		t.Error("expected no mark price")
+	}
+	resp := &TotalCollateralResponse{
+		BreakdownOfPositions: []CollateralByPosition{
+			{PositionCurrency: currency.NewPair(currency.ETH, currency.USDT), MarkPrice: decimal.NewFromInt(1337)},
+			{PositionCurrency: cp},
+		},
+	}
+	if _, ok := PositionMarkPrice(cp, resp); ok {
+		t.Error("expected no mark price")
+	}
+	resp.BreakdownOfPositions[1].MarkPrice = decimal.NewFromInt(31337)
+	markPrice, ok := PositionMarkPrice(cp, resp)
+	if !ok || !markPrice.Equal(decimal.NewFromInt(31337)) {
+		t.Errorf("received '%v' '%v', expected '%v' '%v'", markPrice, ok, 31337, true)
+	}
+}
+
+func TestSetOpenPositionLiquidationEstimate(t *testing.T) {
+	t.Parallel()
+	var c *PositionController
+	cp := currency.NewPair(currency.BTC, currency.USDT)
+	err := c.SetOpenPositionLiquidationEstimate(testExchange, asset.USDTMarginedFutures, cp, &LiquidationEstimate{})
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	pc := SetupPositionController()
+	err = pc.SetOpenPositionLiquidationEstimate(testExchange, asset.USDTMarginedFutures, cp, nil)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received '%v', expected '%v'", err, common.ErrNilPointer)
+	}
+	err = pc.SetOpenPositionLiquidationEstimate(testExchange, asset.USDTMarginedFutures, cp, &LiquidationEstimate{})
+	if !errors.Is(err, ErrPositionNotFound) {
+		t.Errorf("received '%v', expected '%v'", err, ErrPositionNotFound)
+	}
+	err = pc.TrackNewOrder(&Detail{
+		Exchange:  testExchange,
+		AssetType: asset.USDTMarginedFutures,
+		Pair:      cp,
+		Side:      Long,
+		OrderID:   "1",
+		Amount:    1,
+		Price:     20000,
+		Date:      time.Now(),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	err = pc.SetOpenPositionLiquidationEstimate(testExchange, asset.USDTMarginedFutures, cp, &LiquidationEstimate{
+		HasLiquidationPrice: true,
+		LiquidationPrice:    decimal.NewFromInt(18000),
+	})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	pos, err := pc.GetOpenPosition(testExchange, asset.USDTMarginedFutures, cp)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if pos.LiquidationEstimate == nil || !pos.LiquidationEstimate.LiquidationPrice.Equal(decimal.NewFromInt(18000)) {
+		t.Errorf("received '%+v', expected a liquidation price of 18000", pos.LiquidationEstimate)
+	}
+}
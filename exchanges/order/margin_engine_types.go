@@ -0,0 +1,111 @@
+package order
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/shopspring/decimal"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+)
+
+// Margin modes
+const (
+	UnknownMarginMode MarginMode = iota
+	IsolatedMargin
+	CrossMargin
+)
+
+// Margin risk levels ordered from safest to most dangerous
+const (
+	MarginRiskSafe MarginRisk = iota
+	MarginRiskWarning
+	MarginRiskCritical
+	MarginRiskLiquidation
+)
+
+var (
+	// ErrNoMaintenanceMarginTiers is returned when estimating liquidation
+	// for an exchange asset without configured maintenance margin tiers
+	ErrNoMaintenanceMarginTiers = errors.New("no maintenance margin tiers configured")
+	// ErrInvalidMarginMode is returned when a margin mode is not isolated or cross
+	ErrInvalidMarginMode = errors.New("invalid margin mode")
+
+	errInvalidMarginTier          = errors.New("invalid maintenance margin tier")
+	errInvalidRiskThresholds      = errors.New("margin ratio thresholds must be between zero and one with warning below critical")
+	errInverseMarginUnsupported   = errors.New("liquidation estimation for inverse contracts is unsupported")
+	errPositionCollateralNotFound = errors.New("position not found in collateral breakdown")
+	errInvalidMarkPrice           = errors.New("mark price must be greater than zero")
+	errZeroPositionSize           = errors.New("position size is zero")
+)
+
+// MarginMode determines whether a position is liquidated against its own
+// allocated margin or against the whole account's collateral
+type MarginMode uint8
+
+// MarginRisk classifies how close a position is to being liquidated
+type MarginRisk uint8
+
+// MaintenanceMarginTier is a bracket of position notional with its
+// maintenance margin rate. MaintenanceAmount is the cumulative deduction
+// applied at the tier, as published by exchanges using tiered brackets eg
+// Binance's "cum" field
+type MaintenanceMarginTier struct {
+	// MaxNotional is the upper notional bound of the tier, zero is unbounded
+	MaxNotional           decimal.Decimal
+	MaintenanceMarginRate decimal.Decimal
+	MaintenanceAmount     decimal.Decimal
+}
+
+// MarginEngine holds maintenance margin tiers and margin modes per exchange
+// asset and estimates where tracked positions would be liquidated
+type MarginEngine struct {
+	m                   sync.RWMutex
+	settings            map[string]map[asset.Item]*marginSettings
+	warningMarginRatio  decimal.Decimal
+	criticalMarginRatio decimal.Decimal
+}
+
+type marginSettings struct {
+	mode  MarginMode
+	tiers []MaintenanceMarginTier
+}
+
+// LiquidationRequest holds the details of a linear futures position required
+// to estimate its liquidation price
+type LiquidationRequest struct {
+	Mode      MarginMode
+	Direction Side
+	// Size is the absolute position size in the contract's base currency
+	Size          decimal.Decimal
+	MarkPrice     decimal.Decimal
+	UnrealisedPNL decimal.Decimal
+	// Collateral is the margin backing the position excluding its
+	// unrealised PNL. For isolated margin this is the margin allocated to the
+	// position and for cross margin this is the account's collateral
+	Collateral          decimal.Decimal
+	Tiers               []MaintenanceMarginTier
+	WarningMarginRatio  decimal.Decimal
+	CriticalMarginRatio decimal.Decimal
+}
+
+// LiquidationEstimate is the estimated margin standing of a position
+type LiquidationEstimate struct {
+	Mode      MarginMode
+	MarkPrice decimal.Decimal
+	// HasLiquidationPrice is false when a position is collateralised such
+	// that no positive price would liquidate it
+	HasLiquidationPrice   bool
+	LiquidationPrice      decimal.Decimal
+	MaintenanceMarginRate decimal.Decimal
+	MaintenanceMargin     decimal.Decimal
+	MarginBalance         decimal.Decimal
+	// MarginRatio is maintenance margin divided by margin balance, a
+	// position is liquidated when it reaches one
+	MarginRatio decimal.Decimal
+	// DistanceToLiquidation is the price move required to be liquidated as
+	// a fraction of the mark price
+	DistanceToLiquidation decimal.Decimal
+	Risk                  MarginRisk
+	CollateralCurrency    currency.Code
+}
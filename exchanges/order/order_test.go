@@ -1102,6 +1102,7 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 		AccountID:         "1",
 		ClientID:          "1",
 		ClientOrderID:     "DukeOfWombleton",
+		CorrelationID:     "1",
 		WalletAddress:     "1",
 		Type:              1,
 		Side:              1,
@@ -1180,6 +1181,9 @@ func TestUpdateOrderFromDetail(t *testing.T) {
 	if od.ClientOrderID != "DukeOfWombleton" {
 		t.Error("Failed to update")
 	}
+	if od.CorrelationID != "1" {
+		t.Error("Failed to update")
+	}
 	if od.WalletAddress != "1" {
 		t.Error("Failed to update")
 	}
@@ -182,6 +182,7 @@ type Detail struct {
 	ClientOrderID        string
 	AccountID            string
 	ClientID             string
+	CorrelationID        string
 	WalletAddress        string
 	Type                 Type
 	Side                 Side
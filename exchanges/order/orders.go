@@ -192,6 +192,10 @@ func (d *Detail) UpdateOrderFromDetail(m *Detail) error {
 		d.ClientOrderID = m.ClientOrderID
 		updated = true
 	}
+	if m.CorrelationID != "" && m.CorrelationID != d.CorrelationID {
+		d.CorrelationID = m.CorrelationID
+		updated = true
+	}
 	if m.WalletAddress != "" && m.WalletAddress != d.WalletAddress {
 		d.WalletAddress = m.WalletAddress
 		updated = true
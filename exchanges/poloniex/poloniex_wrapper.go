@@ -11,6 +11,7 @@ import (
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	exchange "github.com/thrasher-corp/gocryptotrader/exchanges"
@@ -788,7 +789,7 @@ func (p *Poloniex) GetOrderInfo(ctx context.Context, orderID string, pair curren
 	}
 
 	if orderInfo.Status, err = order.StringToOrderStatus(resp.Status); err != nil {
-		log.Errorf(log.ExchangeSys, "%s %v", p.Name, err)
+		log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx), "%s %v", p.Name, err)
 	}
 	orderInfo.Price = resp.Rate
 	orderInfo.Amount = resp.Amount
@@ -955,7 +956,7 @@ func (p *Poloniex) GetActiveOrders(ctx context.Context, req *order.MultiOrderReq
 			var orderDate time.Time
 			orderDate, err = time.Parse(time.RFC3339, resp.Data[key][i].Date)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"Exchange %v Func %v Order %v Could not parse date to unix with value of %v",
 					p.Name,
 					"GetActiveOrders",
@@ -1014,7 +1015,7 @@ func (p *Poloniex) GetOrderHistory(ctx context.Context, req *order.MultiOrderReq
 			orderDate, err := time.Parse(time.RFC3339,
 				resp.Data[key][i].Date)
 			if err != nil {
-				log.Errorf(log.ExchangeSys,
+				log.ErrorfWithFields(log.ExchangeSys, tracing.LogFields(ctx),
 					"Exchange %v Func %v Order %v Could not parse date to unix with value of %v",
 					p.Name,
 					"GetActiveOrders",
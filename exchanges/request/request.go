@@ -14,6 +14,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/common/timedmutex"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/mock"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/nonce"
 	"github.com/thrasher-corp/gocryptotrader/log"
@@ -97,12 +98,15 @@ func (r *Requester) SendPayload(ctx context.Context, ep EndpointLimit, newReques
 		return errMaxRequestJobs
 	}
 
+	ctx, span := tracing.StartSpan(ctx, tracing.KindClient, "request.SendPayload")
+	span.SetAttribute("exchange", r.name)
 	atomic.AddInt32(&r.jobs, 1)
 	err := r.doRequest(ctx, ep, newRequest)
 	atomic.AddInt32(&r.jobs, -1)
 	if err != nil && requestType == AuthenticatedRequest {
 		err = common.AppendError(err, ErrAuthRequestFailed)
 	}
+	span.End(err)
 	return err
 }
 
@@ -131,7 +135,7 @@ func (i *Item) validateRequest(ctx context.Context, r *Requester) (*http.Request
 	if i.HTTPDebugging {
 		// Err not evaluated due to validation check above
 		dump, _ := httputil.DumpRequestOut(req, true)
-		log.Debugf(log.RequestSys, "DumpRequest:\n%s", dump)
+		log.DebugfWithFields(log.RequestSys, tracing.LogFields(ctx), "DumpRequest:\n%s", dump)
 	}
 
 	for k, v := range i.Headers {
@@ -147,6 +151,8 @@ func (i *Item) validateRequest(ctx context.Context, r *Requester) (*http.Request
 
 // DoRequest performs a HTTP/HTTPS request with the supplied params
 func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRequest Generate) error {
+	fields := tracing.LogFields(ctx)
+	span := tracing.SpanFromContext(ctx)
 	for attempt := 1; ; attempt++ {
 		// Check if context has finished before executing new attempt.
 		select {
@@ -171,16 +177,22 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 			return err
 		}
 
+		if attempt == 1 {
+			// the query is excluded as it can contain signatures
+			span.SetAttribute("http.method", p.Method)
+			span.SetAttribute("http.url", req.URL.Scheme+"://"+req.URL.Host+req.URL.Path)
+		}
+
 		verbose := isVerbose(ctx, p.Verbose)
 
 		if verbose {
-			log.Debugf(log.RequestSys, "%s attempt %d request path: %s", r.name, attempt, p.Path)
+			log.DebugfWithFields(log.RequestSys, fields, "%s attempt %d request path: %s", r.name, attempt, p.Path)
 			for k, d := range req.Header {
-				log.Debugf(log.RequestSys, "%s request header [%s]: %s", r.name, k, d)
+				log.DebugfWithFields(log.RequestSys, fields, "%s request header [%s]: %s", r.name, k, d)
 			}
-			log.Debugf(log.RequestSys, "%s request type: %s", r.name, p.Method)
+			log.DebugfWithFields(log.RequestSys, fields, "%s request type: %s", r.name, p.Method)
 			if p.Body != nil {
-				log.Debugf(log.RequestSys, "%s request body: %v", r.name, p.Body)
+				log.DebugfWithFields(log.RequestSys, fields, "%s request body: %v", r.name, p.Body)
 			}
 		}
 
@@ -222,7 +234,7 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 			}
 
 			if verbose {
-				log.Errorf(log.RequestSys,
+				log.ErrorfWithFields(log.RequestSys, fields,
 					"%s request has failed. Retrying request in %s, attempt %d",
 					r.name,
 					delay,
@@ -233,6 +245,9 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 			continue
 		}
 
+		span.SetAttribute("http.status_code", resp.StatusCode)
+		span.SetAttribute("http.attempts", attempt)
+
 		contents, err := io.ReadAll(resp.Body)
 		if err != nil {
 			return err
@@ -269,26 +284,26 @@ func (r *Requester) doRequest(ctx context.Context, endpoint EndpointLimit, newRe
 		if p.HTTPDebugging {
 			dump, dumpErr := httputil.DumpResponse(resp, false)
 			if err != nil {
-				log.Errorf(log.RequestSys, "DumpResponse invalid response: %v:", dumpErr)
+				log.ErrorfWithFields(log.RequestSys, fields, "DumpResponse invalid response: %v:", dumpErr)
 			}
-			log.Debugf(log.RequestSys, "DumpResponse Headers (%v):\n%s", p.Path, dump)
-			log.Debugf(log.RequestSys, "DumpResponse Body (%v):\n %s", p.Path, string(contents))
+			log.DebugfWithFields(log.RequestSys, fields, "DumpResponse Headers (%v):\n%s", p.Path, dump)
+			log.DebugfWithFields(log.RequestSys, fields, "DumpResponse Body (%v):\n %s", p.Path, string(contents))
 		}
 
 		err = resp.Body.Close()
 		if err != nil {
-			log.Errorf(log.RequestSys,
+			log.ErrorfWithFields(log.RequestSys, fields,
 				"%s failed to close request body %s",
 				r.name,
 				err)
 		}
 		if verbose {
-			log.Debugf(log.RequestSys,
+			log.DebugfWithFields(log.RequestSys, fields,
 				"HTTP status: %s, Code: %v",
 				resp.Status,
 				resp.StatusCode)
 			if !p.HTTPDebugging {
-				log.Debugf(log.RequestSys,
+				log.DebugfWithFields(log.RequestSys, fields,
 					"%s raw response: %s",
 					r.name,
 					string(contents))
@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"context"
 	"net/http"
 	"time"
 
@@ -15,9 +16,11 @@ type Connection interface {
 	Dial(*websocket.Dialer, http.Header) error
 	ReadMessage() Response
 	SendJSONMessage(interface{}) error
+	SendJSONMessageContext(context.Context, interface{}) error
 	SetupPingHandler(PingHandler)
 	GenerateMessageID(highPrecision bool) int64
 	SendMessageReturnResponse(signature interface{}, request interface{}) ([]byte, error)
+	SendMessageReturnResponseContext(ctx context.Context, signature interface{}, request interface{}) ([]byte, error)
 	SendRawMessage(messageType int, message []byte) error
 	SetURL(string)
 	SetProxy(string)
@@ -94,6 +97,14 @@ type WebsocketPositionUpdated struct {
 	Exchange  string
 }
 
+// Reconnected is sent to the data handler when a websocket connection is
+// re-established after the previous connection was lost or shut down, data
+// sent by the exchange in the meantime has been missed
+type Reconnected struct {
+	Exchange  string
+	Timestamp time.Time
+}
+
 // UnhandledMessageWarning defines a container for unhandled message warnings
 type UnhandledMessageWarning struct {
 	Message string
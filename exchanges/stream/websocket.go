@@ -263,6 +263,17 @@ func (w *Websocket) Connect() error {
 	w.setConnectingStatus(false)
 	w.setInit(true)
 
+	if w.hasConnected {
+		select {
+		case w.DataHandler <- Reconnected{Exchange: w.exchangeName, Timestamp: time.Now()}:
+		default:
+			log.Warnf(log.WebsocketMgr,
+				"%v websocket: unable to send reconnection notification, data handler is full",
+				w.exchangeName)
+		}
+	}
+	w.hasConnected = true
+
 	if !w.IsConnectionMonitorRunning() {
 		err = w.connectionMonitor()
 		if err != nil {
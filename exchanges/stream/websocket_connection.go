@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
+	"context"
 	"crypto/rand"
 	"encoding/json"
 	"fmt"
@@ -16,12 +17,29 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/log"
 )
 
 // SendMessageReturnResponse will send a WS message to the connection and wait
 // for response
 func (w *WebsocketConnection) SendMessageReturnResponse(signature, request interface{}) ([]byte, error) {
+	return w.sendMessageReturnResponse(context.Background(), signature, request)
+}
+
+// SendMessageReturnResponseContext will send a WS message to the connection
+// and wait for a response or for the context to be done. The message is traced
+// and logged with the correlation ID of the context
+func (w *WebsocketConnection) SendMessageReturnResponseContext(ctx context.Context, signature, request interface{}) ([]byte, error) {
+	ctx, span := tracing.StartSpan(ctx, tracing.KindClient, "stream.SendMessageReturnResponse")
+	span.SetAttribute("exchange", w.ExchangeName)
+	span.SetAttribute("websocket.url", w.URL)
+	resp, err := w.sendMessageReturnResponse(ctx, signature, request)
+	span.End(err)
+	return resp, err
+}
+
+func (w *WebsocketConnection) sendMessageReturnResponse(ctx context.Context, signature, request interface{}) ([]byte, error) {
 	m, err := w.Match.set(signature)
 	if err != nil {
 		return nil, err
@@ -34,12 +52,13 @@ func (w *WebsocketConnection) SendMessageReturnResponse(signature, request inter
 	}
 
 	start := time.Now()
-	err = w.SendRawMessage(websocket.TextMessage, b)
+	err = w.sendRawMessage(tracing.LogFields(ctx), websocket.TextMessage, b)
 	if err != nil {
 		return nil, err
 	}
 
 	timer := time.NewTimer(w.ResponseMaxLimit)
+	defer timer.Stop()
 
 	select {
 	case payload := <-m.C:
@@ -49,10 +68,11 @@ func (w *WebsocketConnection) SendMessageReturnResponse(signature, request inter
 
 		return payload, nil
 	case <-timer.C:
-		timer.Stop()
 		return nil, fmt.Errorf("%s websocket connection: timeout waiting for response with signature: %v",
 			w.ExchangeName,
 			signature)
+	case <-ctx.Done():
+		return nil, ctx.Err()
 	}
 }
 
@@ -102,6 +122,21 @@ func (w *WebsocketConnection) Dial(dialer *websocket.Dialer, headers http.Header
 
 // SendJSONMessage sends a JSON encoded message over the connection
 func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
+	return w.sendJSONMessage(nil, data)
+}
+
+// SendJSONMessageContext sends a JSON encoded message over the connection. The
+// message is traced and logged with the correlation ID of the context
+func (w *WebsocketConnection) SendJSONMessageContext(ctx context.Context, data interface{}) error {
+	ctx, span := tracing.StartSpan(ctx, tracing.KindClient, "stream.SendJSONMessage")
+	span.SetAttribute("exchange", w.ExchangeName)
+	span.SetAttribute("websocket.url", w.URL)
+	err := w.sendJSONMessage(tracing.LogFields(ctx), data)
+	span.End(err)
+	return err
+}
+
+func (w *WebsocketConnection) sendJSONMessage(fields log.ExtraFields, data interface{}) error {
 	if !w.IsConnected() {
 		return fmt.Errorf("%s websocket connection: cannot send message to a disconnected websocket",
 			w.ExchangeName)
@@ -111,7 +146,7 @@ func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
 	defer w.writeControl.Unlock()
 
 	if w.Verbose {
-		log.Debugf(log.WebsocketMgr,
+		log.DebugfWithFields(log.WebsocketMgr, fields,
 			"%s websocket connection: sending message to websocket %+v\n",
 			w.ExchangeName,
 			data)
@@ -129,6 +164,10 @@ func (w *WebsocketConnection) SendJSONMessage(data interface{}) error {
 
 // SendRawMessage sends a message over the connection without JSON encoding it
 func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) error {
+	return w.sendRawMessage(nil, messageType, message)
+}
+
+func (w *WebsocketConnection) sendRawMessage(fields log.ExtraFields, messageType int, message []byte) error {
 	if !w.IsConnected() {
 		return fmt.Errorf("%v websocket connection: cannot send message to a disconnected websocket",
 			w.ExchangeName)
@@ -138,7 +177,7 @@ func (w *WebsocketConnection) SendRawMessage(messageType int, message []byte) er
 	defer w.writeControl.Unlock()
 
 	if w.Verbose {
-		log.Debugf(log.WebsocketMgr,
+		log.DebugfWithFields(log.WebsocketMgr, fields,
 			"%v websocket connection: sending message [%s]\n",
 			w.ExchangeName,
 			message)
@@ -4,11 +4,13 @@ import (
 	"bytes"
 	"compress/flate"
 	"compress/gzip"
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
 	"net/http"
+	"net/http/httptest"
 	"strconv"
 	"strings"
 	"sync"
@@ -16,6 +18,7 @@ import (
 	"time"
 
 	"github.com/gorilla/websocket"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/config"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/protocol"
@@ -1261,6 +1264,56 @@ func TestEnable(t *testing.T) {
 	fmt.Print()
 }
 
+func TestConnectReconnected(t *testing.T) {
+	t.Parallel()
+	web := Websocket{
+		enabled:      true,
+		exchangeName: "test",
+		connector:    connect,
+		Wg:           new(sync.WaitGroup),
+		ShutdownC:    make(chan struct{}),
+		DataHandler:  make(chan interface{}, 1),
+		ToRoutine:    make(chan interface{}, 1),
+		GenerateSubs: func() ([]ChannelSubscription, error) {
+			return nil, nil
+		},
+		Subscriber: func(cs []ChannelSubscription) error { return nil },
+		// monitors are marked as running so they do not alter the connection
+		trafficMonitorRunning:    true,
+		connectionMonitorRunning: true,
+	}
+	err := web.Connect()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	select {
+	case d := <-web.ToRoutine:
+		t.Fatalf("received: '%v' but expected no data on first connection", d)
+	case <-time.After(time.Millisecond * 50):
+	}
+
+	err = web.Shutdown()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = web.Connect()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	select {
+	case d := <-web.ToRoutine:
+		r, ok := d.(Reconnected)
+		if !ok {
+			t.Fatalf("received: '%T' but expected: '%T'", d, Reconnected{})
+		}
+		if r.Exchange != "test" || r.Timestamp.IsZero() {
+			t.Errorf("received: '%+v' but expected exchange and timestamp to be set", r)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("expected reconnection notification")
+	}
+}
+
 func TestSetupNewConnection(t *testing.T) {
 	t.Parallel()
 	var nonsenseWebsock *Websocket
@@ -1388,3 +1441,79 @@ func TestLatency(t *testing.T) {
 		t.Errorf("expected %v, got %v", exch, r.name)
 	}
 }
+
+func TestSendMessageReturnResponseContext(t *testing.T) {
+	t.Parallel()
+	upgrader := websocket.Upgrader{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			t.Error(err)
+			return
+		}
+		defer conn.Close()
+		for {
+			var req testRequest
+			if err = conn.ReadJSON(&req); err != nil {
+				return
+			}
+			if req.Event == "ignore" {
+				continue
+			}
+			if err = conn.WriteJSON(req); err != nil {
+				return
+			}
+		}
+	}))
+	defer srv.Close()
+
+	wc := &WebsocketConnection{
+		ExchangeName:     "test",
+		URL:              "ws" + strings.TrimPrefix(srv.URL, "http"),
+		ResponseMaxLimit: time.Second * 5,
+		Match:            NewMatch(),
+	}
+	err := wc.Dial(&websocket.Dialer{}, http.Header{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	go func() {
+		for {
+			resp := wc.ReadMessage()
+			if resp.Raw == nil {
+				return
+			}
+			var req testRequest
+			if json.Unmarshal(resp.Raw, &req) == nil {
+				wc.Match.IncomingWithData(req.RequestID, resp.Raw)
+			}
+		}
+	}()
+
+	ctx := tracing.WithCorrelationID(context.Background(), "test")
+	resp, err := wc.SendMessageReturnResponseContext(ctx, int64(1), testRequest{Event: "echo", RequestID: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !strings.Contains(string(resp), "echo") {
+		t.Errorf("received: '%s' but expected: '%v'", resp, "echo")
+	}
+
+	err = wc.SendJSONMessageContext(ctx, testRequest{Event: "ignore"})
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// the response wait ends with the context
+	timeout, cancel := context.WithTimeout(ctx, time.Millisecond*50)
+	defer cancel()
+	_, err = wc.SendMessageReturnResponseContext(timeout, int64(2), testRequest{Event: "ignore", RequestID: 2})
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+
+	err = wc.Shutdown()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
@@ -34,6 +34,7 @@ type Websocket struct {
 	connectionMonitorRunning     bool
 	trafficMonitorRunning        bool
 	dataMonitorRunning           bool
+	hasConnected                 bool
 	trafficTimeout               time.Duration
 	connectionMonitorDelay       time.Duration
 	proxyAddr                    string
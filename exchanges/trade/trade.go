@@ -247,6 +247,26 @@ func ConvertTradesToCandles(interval kline.Interval, trades ...Data) (*kline.Ite
 	return &candles, nil
 }
 
+// ConvertTradesToBars turns trade data into alternative bars such as volume,
+// tick or renko bars
+func ConvertTradesToBars(settings kline.BarSettings, trades ...Data) (*kline.Item, error) {
+	if len(trades) == 0 {
+		return nil, ErrNoTradesSupplied
+	}
+	history := make([]order.TradeHistory, len(trades))
+	for i := range trades {
+		history[i] = order.TradeHistory{
+			Price:     trades[i].Price,
+			Amount:    trades[i].Amount,
+			Exchange:  trades[i].Exchange,
+			TID:       trades[i].TID,
+			Side:      trades[i].Side,
+			Timestamp: trades[i].Timestamp,
+		}
+	}
+	return kline.CreateBars(history, settings, trades[0].CurrencyPair, trades[0].AssetType, trades[0].Exchange)
+}
+
 func groupTradesToInterval(interval kline.Interval, times ...Data) map[int64][]Data {
 	groupedData := make(map[int64][]Data)
 	for i := range times {
@@ -1,6 +1,7 @@
 package trade
 
 import (
+	"errors"
 	"sync"
 	"sync/atomic"
 	"testing"
@@ -198,6 +199,41 @@ func TestConvertTradesToCandles(t *testing.T) {
 	}
 }
 
+func TestConvertTradesToBars(t *testing.T) {
+	t.Parallel()
+	_, err := ConvertTradesToBars(kline.BarSettings{Type: kline.TickBar, Threshold: 2})
+	if !errors.Is(err, ErrNoTradesSupplied) {
+		t.Fatalf("received '%v' expected '%v'", err, ErrNoTradesSupplied)
+	}
+	cp, _ := currency.NewPairFromString("BTC-USD")
+	startDate := time.Date(2020, 1, 1, 1, 0, 0, 0, time.UTC)
+	trades := make([]Data, 5)
+	for i := range trades {
+		trades[i] = Data{
+			Timestamp:    startDate.Add(time.Second * time.Duration(i)),
+			Exchange:     "test!",
+			CurrencyPair: cp,
+			AssetType:    asset.Spot,
+			Price:        1337,
+			Amount:       1,
+			Side:         order.Buy,
+		}
+	}
+	bars, err := ConvertTradesToBars(kline.BarSettings{Type: kline.TickBar, Threshold: 2}, trades...)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v' expected '%v'", err, nil)
+	}
+	if len(bars.Candles) != 2 {
+		t.Fatalf("received '%v' expected '%v'", len(bars.Candles), 2)
+	}
+	if bars.Exchange != "test!" || !bars.Pair.Equal(cp) || bars.Asset != asset.Spot {
+		t.Errorf("unexpected bar details %+v", bars)
+	}
+	if !bars.Candles[1].Time.Equal(startDate.Add(time.Second*2)) || bars.Candles[1].Volume != 2 {
+		t.Errorf("unexpected bar %+v", bars.Candles[1])
+	}
+}
+
 func TestShutdown(t *testing.T) {
 	t.Parallel()
 	var p Processor
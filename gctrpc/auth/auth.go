@@ -24,3 +24,20 @@ func (b BasicAuth) GetRequestMetadata(_ context.Context, _ ...string) (map[strin
 func (BasicAuth) RequireTransportSecurity() bool {
 	return true
 }
+
+// TokenAuth stores a bearer token
+type TokenAuth struct {
+	Token string
+}
+
+// GetRequestMetadata is a implementation of the GetRequestMetadata function
+func (t TokenAuth) GetRequestMetadata(_ context.Context, _ ...string) (map[string]string, error) {
+	return map[string]string{
+		"authorization": "Bearer " + t.Token,
+	}, nil
+}
+
+// RequireTransportSecurity is required for token auth
+func (TokenAuth) RequireTransportSecurity() bool {
+	return true
+}
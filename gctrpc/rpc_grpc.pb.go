@@ -125,6 +125,28 @@ const (
 	GoCryptoTraderService_GetOrderbookMovement_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetOrderbookMovement"
 	GoCryptoTraderService_GetOrderbookAmountByNominal_FullMethodName       = "/gctrpc.GoCryptoTraderService/GetOrderbookAmountByNominal"
 	GoCryptoTraderService_GetOrderbookAmountByImpact_FullMethodName        = "/gctrpc.GoCryptoTraderService/GetOrderbookAmountByImpact"
+	GoCryptoTraderService_ReloadConfig_FullMethodName                      = "/gctrpc.GoCryptoTraderService/ReloadConfig"
+	GoCryptoTraderService_GetDataRetentionStatus_FullMethodName            = "/gctrpc.GoCryptoTraderService/GetDataRetentionStatus"
+	GoCryptoTraderService_RunDataRetention_FullMethodName                  = "/gctrpc.GoCryptoTraderService/RunDataRetention"
+	GoCryptoTraderService_GetFundingRateOpportunities_FullMethodName       = "/gctrpc.GoCryptoTraderService/GetFundingRateOpportunities"
+	GoCryptoTraderService_GetOptionsChain_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetOptionsChain"
+	GoCryptoTraderService_GetOptionsPortfolioGreeks_FullMethodName         = "/gctrpc.GoCryptoTraderService/GetOptionsPortfolioGreeks"
+	GoCryptoTraderService_GetBasisTermStructure_FullMethodName             = "/gctrpc.GoCryptoTraderService/GetBasisTermStructure"
+	GoCryptoTraderService_GetBasisHistory_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetBasisHistory"
+	GoCryptoTraderService_GetCandleStream_FullMethodName                   = "/gctrpc.GoCryptoTraderService/GetCandleStream"
+	GoCryptoTraderService_GetAlternativeBars_FullMethodName                = "/gctrpc.GoCryptoTraderService/GetAlternativeBars"
+	GoCryptoTraderService_GetInstruments_FullMethodName                    = "/gctrpc.GoCryptoTraderService/GetInstruments"
+	GoCryptoTraderService_GetInstrument_FullMethodName                     = "/gctrpc.GoCryptoTraderService/GetInstrument"
+	GoCryptoTraderService_GetInstrumentStream_FullMethodName               = "/gctrpc.GoCryptoTraderService/GetInstrumentStream"
+	GoCryptoTraderService_GetOrderUpdateStream_FullMethodName              = "/gctrpc.GoCryptoTraderService/GetOrderUpdateStream"
+	GoCryptoTraderService_GetFillStream_FullMethodName                     = "/gctrpc.GoCryptoTraderService/GetFillStream"
+	GoCryptoTraderService_GetPositionStream_FullMethodName                 = "/gctrpc.GoCryptoTraderService/GetPositionStream"
+	GoCryptoTraderService_GetPNLReport_FullMethodName                      = "/gctrpc.GoCryptoTraderService/GetPNLReport"
+	GoCryptoTraderService_GetNAVHistory_FullMethodName                     = "/gctrpc.GoCryptoTraderService/GetNAVHistory"
+	GoCryptoTraderService_GetTaxReport_FullMethodName                      = "/gctrpc.GoCryptoTraderService/GetTaxReport"
+	GoCryptoTraderService_GetLedgerReconciliation_FullMethodName           = "/gctrpc.GoCryptoTraderService/GetLedgerReconciliation"
+	GoCryptoTraderService_GetRebalancePlan_FullMethodName                  = "/gctrpc.GoCryptoTraderService/GetRebalancePlan"
+	GoCryptoTraderService_ExecuteRebalance_FullMethodName                  = "/gctrpc.GoCryptoTraderService/ExecuteRebalance"
 )
 
 // GoCryptoTraderServiceClient is the client API for GoCryptoTraderService service.
@@ -237,6 +259,28 @@ type GoCryptoTraderServiceClient interface {
 	GetOrderbookMovement(ctx context.Context, in *GetOrderbookMovementRequest, opts ...grpc.CallOption) (*GetOrderbookMovementResponse, error)
 	GetOrderbookAmountByNominal(ctx context.Context, in *GetOrderbookAmountByNominalRequest, opts ...grpc.CallOption) (*GetOrderbookAmountByNominalResponse, error)
 	GetOrderbookAmountByImpact(ctx context.Context, in *GetOrderbookAmountByImpactRequest, opts ...grpc.CallOption) (*GetOrderbookAmountByImpactResponse, error)
+	ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error)
+	GetDataRetentionStatus(ctx context.Context, in *GetDataRetentionStatusRequest, opts ...grpc.CallOption) (*GetDataRetentionStatusResponse, error)
+	RunDataRetention(ctx context.Context, in *RunDataRetentionRequest, opts ...grpc.CallOption) (*DataRetentionReport, error)
+	GetFundingRateOpportunities(ctx context.Context, in *GetFundingRateOpportunitiesRequest, opts ...grpc.CallOption) (*GetFundingRateOpportunitiesResponse, error)
+	GetOptionsChain(ctx context.Context, in *GetOptionsChainRequest, opts ...grpc.CallOption) (*GetOptionsChainResponse, error)
+	GetOptionsPortfolioGreeks(ctx context.Context, in *GetOptionsPortfolioGreeksRequest, opts ...grpc.CallOption) (*GetOptionsPortfolioGreeksResponse, error)
+	GetBasisTermStructure(ctx context.Context, in *GetBasisTermStructureRequest, opts ...grpc.CallOption) (*GetBasisTermStructureResponse, error)
+	GetBasisHistory(ctx context.Context, in *GetBasisHistoryRequest, opts ...grpc.CallOption) (*GetBasisHistoryResponse, error)
+	GetCandleStream(ctx context.Context, in *GetCandleStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetCandleStreamClient, error)
+	GetAlternativeBars(ctx context.Context, in *GetAlternativeBarsRequest, opts ...grpc.CallOption) (*GetAlternativeBarsResponse, error)
+	GetInstruments(ctx context.Context, in *GetInstrumentsRequest, opts ...grpc.CallOption) (*GetInstrumentsResponse, error)
+	GetInstrument(ctx context.Context, in *GetInstrumentRequest, opts ...grpc.CallOption) (*Instrument, error)
+	GetInstrumentStream(ctx context.Context, in *GetInstrumentStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetInstrumentStreamClient, error)
+	GetOrderUpdateStream(ctx context.Context, in *GetOrderUpdateStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetOrderUpdateStreamClient, error)
+	GetFillStream(ctx context.Context, in *GetFillStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetFillStreamClient, error)
+	GetPositionStream(ctx context.Context, in *GetPositionStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetPositionStreamClient, error)
+	GetPNLReport(ctx context.Context, in *GetPNLReportRequest, opts ...grpc.CallOption) (*GetPNLReportResponse, error)
+	GetNAVHistory(ctx context.Context, in *GetNAVHistoryRequest, opts ...grpc.CallOption) (*GetNAVHistoryResponse, error)
+	GetTaxReport(ctx context.Context, in *GetTaxReportRequest, opts ...grpc.CallOption) (*GetTaxReportResponse, error)
+	GetLedgerReconciliation(ctx context.Context, in *GetLedgerReconciliationRequest, opts ...grpc.CallOption) (*GetLedgerReconciliationResponse, error)
+	GetRebalancePlan(ctx context.Context, in *GetRebalancePlanRequest, opts ...grpc.CallOption) (*RebalancePlanResponse, error)
+	ExecuteRebalance(ctx context.Context, in *ExecuteRebalanceRequest, opts ...grpc.CallOption) (*RebalancePlanResponse, error)
 }
 
 type goCryptoTraderServiceClient struct {
@@ -1339,6 +1383,319 @@ func (c *goCryptoTraderServiceClient) GetOrderbookAmountByImpact(ctx context.Con
 	return out, nil
 }
 
+func (c *goCryptoTraderServiceClient) ReloadConfig(ctx context.Context, in *ReloadConfigRequest, opts ...grpc.CallOption) (*ReloadConfigResponse, error) {
+	out := new(ReloadConfigResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_ReloadConfig_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetDataRetentionStatus(ctx context.Context, in *GetDataRetentionStatusRequest, opts ...grpc.CallOption) (*GetDataRetentionStatusResponse, error) {
+	out := new(GetDataRetentionStatusResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetDataRetentionStatus_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) RunDataRetention(ctx context.Context, in *RunDataRetentionRequest, opts ...grpc.CallOption) (*DataRetentionReport, error) {
+	out := new(DataRetentionReport)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_RunDataRetention_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetFundingRateOpportunities(ctx context.Context, in *GetFundingRateOpportunitiesRequest, opts ...grpc.CallOption) (*GetFundingRateOpportunitiesResponse, error) {
+	out := new(GetFundingRateOpportunitiesResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetFundingRateOpportunities_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetOptionsChain(ctx context.Context, in *GetOptionsChainRequest, opts ...grpc.CallOption) (*GetOptionsChainResponse, error) {
+	out := new(GetOptionsChainResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetOptionsChain_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetOptionsPortfolioGreeks(ctx context.Context, in *GetOptionsPortfolioGreeksRequest, opts ...grpc.CallOption) (*GetOptionsPortfolioGreeksResponse, error) {
+	out := new(GetOptionsPortfolioGreeksResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetOptionsPortfolioGreeks_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetBasisTermStructure(ctx context.Context, in *GetBasisTermStructureRequest, opts ...grpc.CallOption) (*GetBasisTermStructureResponse, error) {
+	out := new(GetBasisTermStructureResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetBasisTermStructure_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetBasisHistory(ctx context.Context, in *GetBasisHistoryRequest, opts ...grpc.CallOption) (*GetBasisHistoryResponse, error) {
+	out := new(GetBasisHistoryResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetBasisHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetCandleStream(ctx context.Context, in *GetCandleStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetCandleStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[6], GoCryptoTraderService_GetCandleStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetCandleStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetCandleStreamClient interface {
+	Recv() (*CandleStreamResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetCandleStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetCandleStreamClient) Recv() (*CandleStreamResponse, error) {
+	m := new(CandleStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetAlternativeBars(ctx context.Context, in *GetAlternativeBarsRequest, opts ...grpc.CallOption) (*GetAlternativeBarsResponse, error) {
+	out := new(GetAlternativeBarsResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetAlternativeBars_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetInstruments(ctx context.Context, in *GetInstrumentsRequest, opts ...grpc.CallOption) (*GetInstrumentsResponse, error) {
+	out := new(GetInstrumentsResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetInstruments_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetInstrument(ctx context.Context, in *GetInstrumentRequest, opts ...grpc.CallOption) (*Instrument, error) {
+	out := new(Instrument)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetInstrument_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetInstrumentStream(ctx context.Context, in *GetInstrumentStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetInstrumentStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[7], GoCryptoTraderService_GetInstrumentStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetInstrumentStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetInstrumentStreamClient interface {
+	Recv() (*InstrumentStreamResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetInstrumentStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetInstrumentStreamClient) Recv() (*InstrumentStreamResponse, error) {
+	m := new(InstrumentStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetOrderUpdateStream(ctx context.Context, in *GetOrderUpdateStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetOrderUpdateStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[8], GoCryptoTraderService_GetOrderUpdateStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetOrderUpdateStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetOrderUpdateStreamClient interface {
+	Recv() (*OrderUpdateStreamResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetOrderUpdateStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetOrderUpdateStreamClient) Recv() (*OrderUpdateStreamResponse, error) {
+	m := new(OrderUpdateStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetFillStream(ctx context.Context, in *GetFillStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetFillStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[9], GoCryptoTraderService_GetFillStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetFillStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetFillStreamClient interface {
+	Recv() (*FillStreamResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetFillStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetFillStreamClient) Recv() (*FillStreamResponse, error) {
+	m := new(FillStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetPositionStream(ctx context.Context, in *GetPositionStreamRequest, opts ...grpc.CallOption) (GoCryptoTraderService_GetPositionStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &GoCryptoTraderService_ServiceDesc.Streams[10], GoCryptoTraderService_GetPositionStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &goCryptoTraderServiceGetPositionStreamClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type GoCryptoTraderService_GetPositionStreamClient interface {
+	Recv() (*PositionStreamResponse, error)
+	grpc.ClientStream
+}
+
+type goCryptoTraderServiceGetPositionStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *goCryptoTraderServiceGetPositionStreamClient) Recv() (*PositionStreamResponse, error) {
+	m := new(PositionStreamResponse)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetPNLReport(ctx context.Context, in *GetPNLReportRequest, opts ...grpc.CallOption) (*GetPNLReportResponse, error) {
+	out := new(GetPNLReportResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetPNLReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetNAVHistory(ctx context.Context, in *GetNAVHistoryRequest, opts ...grpc.CallOption) (*GetNAVHistoryResponse, error) {
+	out := new(GetNAVHistoryResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetNAVHistory_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetTaxReport(ctx context.Context, in *GetTaxReportRequest, opts ...grpc.CallOption) (*GetTaxReportResponse, error) {
+	out := new(GetTaxReportResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetTaxReport_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetLedgerReconciliation(ctx context.Context, in *GetLedgerReconciliationRequest, opts ...grpc.CallOption) (*GetLedgerReconciliationResponse, error) {
+	out := new(GetLedgerReconciliationResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetLedgerReconciliation_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) GetRebalancePlan(ctx context.Context, in *GetRebalancePlanRequest, opts ...grpc.CallOption) (*RebalancePlanResponse, error) {
+	out := new(RebalancePlanResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_GetRebalancePlan_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *goCryptoTraderServiceClient) ExecuteRebalance(ctx context.Context, in *ExecuteRebalanceRequest, opts ...grpc.CallOption) (*RebalancePlanResponse, error) {
+	out := new(RebalancePlanResponse)
+	err := c.cc.Invoke(ctx, GoCryptoTraderService_ExecuteRebalance_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // GoCryptoTraderServiceServer is the server API for GoCryptoTraderService service.
 // All implementations must embed UnimplementedGoCryptoTraderServiceServer
 // for forward compatibility
@@ -1449,6 +1806,28 @@ type GoCryptoTraderServiceServer interface {
 	GetOrderbookMovement(context.Context, *GetOrderbookMovementRequest) (*GetOrderbookMovementResponse, error)
 	GetOrderbookAmountByNominal(context.Context, *GetOrderbookAmountByNominalRequest) (*GetOrderbookAmountByNominalResponse, error)
 	GetOrderbookAmountByImpact(context.Context, *GetOrderbookAmountByImpactRequest) (*GetOrderbookAmountByImpactResponse, error)
+	ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error)
+	GetDataRetentionStatus(context.Context, *GetDataRetentionStatusRequest) (*GetDataRetentionStatusResponse, error)
+	RunDataRetention(context.Context, *RunDataRetentionRequest) (*DataRetentionReport, error)
+	GetFundingRateOpportunities(context.Context, *GetFundingRateOpportunitiesRequest) (*GetFundingRateOpportunitiesResponse, error)
+	GetOptionsChain(context.Context, *GetOptionsChainRequest) (*GetOptionsChainResponse, error)
+	GetOptionsPortfolioGreeks(context.Context, *GetOptionsPortfolioGreeksRequest) (*GetOptionsPortfolioGreeksResponse, error)
+	GetBasisTermStructure(context.Context, *GetBasisTermStructureRequest) (*GetBasisTermStructureResponse, error)
+	GetBasisHistory(context.Context, *GetBasisHistoryRequest) (*GetBasisHistoryResponse, error)
+	GetCandleStream(*GetCandleStreamRequest, GoCryptoTraderService_GetCandleStreamServer) error
+	GetAlternativeBars(context.Context, *GetAlternativeBarsRequest) (*GetAlternativeBarsResponse, error)
+	GetInstruments(context.Context, *GetInstrumentsRequest) (*GetInstrumentsResponse, error)
+	GetInstrument(context.Context, *GetInstrumentRequest) (*Instrument, error)
+	GetInstrumentStream(*GetInstrumentStreamRequest, GoCryptoTraderService_GetInstrumentStreamServer) error
+	GetOrderUpdateStream(*GetOrderUpdateStreamRequest, GoCryptoTraderService_GetOrderUpdateStreamServer) error
+	GetFillStream(*GetFillStreamRequest, GoCryptoTraderService_GetFillStreamServer) error
+	GetPositionStream(*GetPositionStreamRequest, GoCryptoTraderService_GetPositionStreamServer) error
+	GetPNLReport(context.Context, *GetPNLReportRequest) (*GetPNLReportResponse, error)
+	GetNAVHistory(context.Context, *GetNAVHistoryRequest) (*GetNAVHistoryResponse, error)
+	GetTaxReport(context.Context, *GetTaxReportRequest) (*GetTaxReportResponse, error)
+	GetLedgerReconciliation(context.Context, *GetLedgerReconciliationRequest) (*GetLedgerReconciliationResponse, error)
+	GetRebalancePlan(context.Context, *GetRebalancePlanRequest) (*RebalancePlanResponse, error)
+	ExecuteRebalance(context.Context, *ExecuteRebalanceRequest) (*RebalancePlanResponse, error)
 	mustEmbedUnimplementedGoCryptoTraderServiceServer()
 }
 
@@ -1774,6 +2153,72 @@ func (UnimplementedGoCryptoTraderServiceServer) GetOrderbookAmountByNominal(cont
 func (UnimplementedGoCryptoTraderServiceServer) GetOrderbookAmountByImpact(context.Context, *GetOrderbookAmountByImpactRequest) (*GetOrderbookAmountByImpactResponse, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetOrderbookAmountByImpact not implemented")
 }
+func (UnimplementedGoCryptoTraderServiceServer) ReloadConfig(context.Context, *ReloadConfigRequest) (*ReloadConfigResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReloadConfig not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetDataRetentionStatus(context.Context, *GetDataRetentionStatusRequest) (*GetDataRetentionStatusResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetDataRetentionStatus not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) RunDataRetention(context.Context, *RunDataRetentionRequest) (*DataRetentionReport, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RunDataRetention not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetFundingRateOpportunities(context.Context, *GetFundingRateOpportunitiesRequest) (*GetFundingRateOpportunitiesResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetFundingRateOpportunities not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetOptionsChain(context.Context, *GetOptionsChainRequest) (*GetOptionsChainResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOptionsChain not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetOptionsPortfolioGreeks(context.Context, *GetOptionsPortfolioGreeksRequest) (*GetOptionsPortfolioGreeksResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOptionsPortfolioGreeks not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetBasisTermStructure(context.Context, *GetBasisTermStructureRequest) (*GetBasisTermStructureResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBasisTermStructure not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetBasisHistory(context.Context, *GetBasisHistoryRequest) (*GetBasisHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBasisHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetCandleStream(*GetCandleStreamRequest, GoCryptoTraderService_GetCandleStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetCandleStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetAlternativeBars(context.Context, *GetAlternativeBarsRequest) (*GetAlternativeBarsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAlternativeBars not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetInstruments(context.Context, *GetInstrumentsRequest) (*GetInstrumentsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstruments not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetInstrument(context.Context, *GetInstrumentRequest) (*Instrument, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInstrument not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetInstrumentStream(*GetInstrumentStreamRequest, GoCryptoTraderService_GetInstrumentStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetInstrumentStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetOrderUpdateStream(*GetOrderUpdateStreamRequest, GoCryptoTraderService_GetOrderUpdateStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetOrderUpdateStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetFillStream(*GetFillStreamRequest, GoCryptoTraderService_GetFillStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetFillStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetPositionStream(*GetPositionStreamRequest, GoCryptoTraderService_GetPositionStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method GetPositionStream not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetPNLReport(context.Context, *GetPNLReportRequest) (*GetPNLReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPNLReport not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetNAVHistory(context.Context, *GetNAVHistoryRequest) (*GetNAVHistoryResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetNAVHistory not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetTaxReport(context.Context, *GetTaxReportRequest) (*GetTaxReportResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetTaxReport not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetLedgerReconciliation(context.Context, *GetLedgerReconciliationRequest) (*GetLedgerReconciliationResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLedgerReconciliation not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) GetRebalancePlan(context.Context, *GetRebalancePlanRequest) (*RebalancePlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetRebalancePlan not implemented")
+}
+func (UnimplementedGoCryptoTraderServiceServer) ExecuteRebalance(context.Context, *ExecuteRebalanceRequest) (*RebalancePlanResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ExecuteRebalance not implemented")
+}
 func (UnimplementedGoCryptoTraderServiceServer) mustEmbedUnimplementedGoCryptoTraderServiceServer() {}
 
 // UnsafeGoCryptoTraderServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -3713,18 +4158,429 @@ func _GoCryptoTraderService_GetOrderbookAmountByImpact_Handler(srv interface{},
 	return interceptor(ctx, in, info, handler)
 }
 
-// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
-// It's only intended for direct use with grpc.RegisterService,
-// and not to be introspected or modified (even as a copy)
-var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
-	ServiceName: "gctrpc.GoCryptoTraderService",
-	HandlerType: (*GoCryptoTraderServiceServer)(nil),
-	Methods: []grpc.MethodDesc{
-		{
-			MethodName: "GetInfo",
-			Handler:    _GoCryptoTraderService_GetInfo_Handler,
-		},
-		{
+func _GoCryptoTraderService_ReloadConfig_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReloadConfigRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ReloadConfig(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_ReloadConfig_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ReloadConfig(ctx, req.(*ReloadConfigRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetDataRetentionStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetDataRetentionStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetDataRetentionStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetDataRetentionStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetDataRetentionStatus(ctx, req.(*GetDataRetentionStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_RunDataRetention_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RunDataRetentionRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).RunDataRetention(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_RunDataRetention_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).RunDataRetention(ctx, req.(*RunDataRetentionRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetFundingRateOpportunities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetFundingRateOpportunitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetFundingRateOpportunities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetFundingRateOpportunities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetFundingRateOpportunities(ctx, req.(*GetFundingRateOpportunitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetOptionsChain_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOptionsChainRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetOptionsChain(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetOptionsChain_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetOptionsChain(ctx, req.(*GetOptionsChainRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetOptionsPortfolioGreeks_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetOptionsPortfolioGreeksRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetOptionsPortfolioGreeks(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetOptionsPortfolioGreeks_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetOptionsPortfolioGreeks(ctx, req.(*GetOptionsPortfolioGreeksRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetBasisTermStructure_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasisTermStructureRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetBasisTermStructure(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetBasisTermStructure_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetBasisTermStructure(ctx, req.(*GetBasisTermStructureRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetBasisHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetBasisHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetBasisHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetBasisHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetBasisHistory(ctx, req.(*GetBasisHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetCandleStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetCandleStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetCandleStream(m, &goCryptoTraderServiceGetCandleStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetCandleStreamServer interface {
+	Send(*CandleStreamResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetCandleStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetCandleStreamServer) Send(m *CandleStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetAlternativeBars_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetAlternativeBarsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetAlternativeBars(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetAlternativeBars_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetAlternativeBars(ctx, req.(*GetAlternativeBarsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetInstruments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstrumentsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetInstruments(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetInstruments_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetInstruments(ctx, req.(*GetInstrumentsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetInstrument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInstrumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetInstrument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetInstrument_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetInstrument(ctx, req.(*GetInstrumentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetInstrumentStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetInstrumentStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetInstrumentStream(m, &goCryptoTraderServiceGetInstrumentStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetInstrumentStreamServer interface {
+	Send(*InstrumentStreamResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetInstrumentStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetInstrumentStreamServer) Send(m *InstrumentStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetOrderUpdateStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetOrderUpdateStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetOrderUpdateStream(m, &goCryptoTraderServiceGetOrderUpdateStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetOrderUpdateStreamServer interface {
+	Send(*OrderUpdateStreamResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetOrderUpdateStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetOrderUpdateStreamServer) Send(m *OrderUpdateStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetFillStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetFillStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetFillStream(m, &goCryptoTraderServiceGetFillStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetFillStreamServer interface {
+	Send(*FillStreamResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetFillStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetFillStreamServer) Send(m *FillStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetPositionStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(GetPositionStreamRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(GoCryptoTraderServiceServer).GetPositionStream(m, &goCryptoTraderServiceGetPositionStreamServer{stream})
+}
+
+type GoCryptoTraderService_GetPositionStreamServer interface {
+	Send(*PositionStreamResponse) error
+	grpc.ServerStream
+}
+
+type goCryptoTraderServiceGetPositionStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *goCryptoTraderServiceGetPositionStreamServer) Send(m *PositionStreamResponse) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _GoCryptoTraderService_GetPNLReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPNLReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetPNLReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetPNLReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetPNLReport(ctx, req.(*GetPNLReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetNAVHistory_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetNAVHistoryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetNAVHistory(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetNAVHistory_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetNAVHistory(ctx, req.(*GetNAVHistoryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetTaxReport_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetTaxReportRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetTaxReport(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetTaxReport_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetTaxReport(ctx, req.(*GetTaxReportRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetLedgerReconciliation_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLedgerReconciliationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetLedgerReconciliation(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetLedgerReconciliation_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetLedgerReconciliation(ctx, req.(*GetLedgerReconciliationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_GetRebalancePlan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetRebalancePlanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).GetRebalancePlan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_GetRebalancePlan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).GetRebalancePlan(ctx, req.(*GetRebalancePlanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _GoCryptoTraderService_ExecuteRebalance_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ExecuteRebalanceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(GoCryptoTraderServiceServer).ExecuteRebalance(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GoCryptoTraderService_ExecuteRebalance_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(GoCryptoTraderServiceServer).ExecuteRebalance(ctx, req.(*ExecuteRebalanceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+// GoCryptoTraderService_ServiceDesc is the grpc.ServiceDesc for GoCryptoTraderService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "gctrpc.GoCryptoTraderService",
+	HandlerType: (*GoCryptoTraderServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "GetInfo",
+			Handler:    _GoCryptoTraderService_GetInfo_Handler,
+		},
+		{
 			MethodName: "GetSubsystems",
 			Handler:    _GoCryptoTraderService_GetSubsystems_Handler,
 		},
@@ -4120,6 +4976,74 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetOrderbookAmountByImpact",
 			Handler:    _GoCryptoTraderService_GetOrderbookAmountByImpact_Handler,
 		},
+		{
+			MethodName: "ReloadConfig",
+			Handler:    _GoCryptoTraderService_ReloadConfig_Handler,
+		},
+		{
+			MethodName: "GetDataRetentionStatus",
+			Handler:    _GoCryptoTraderService_GetDataRetentionStatus_Handler,
+		},
+		{
+			MethodName: "RunDataRetention",
+			Handler:    _GoCryptoTraderService_RunDataRetention_Handler,
+		},
+		{
+			MethodName: "GetFundingRateOpportunities",
+			Handler:    _GoCryptoTraderService_GetFundingRateOpportunities_Handler,
+		},
+		{
+			MethodName: "GetOptionsChain",
+			Handler:    _GoCryptoTraderService_GetOptionsChain_Handler,
+		},
+		{
+			MethodName: "GetOptionsPortfolioGreeks",
+			Handler:    _GoCryptoTraderService_GetOptionsPortfolioGreeks_Handler,
+		},
+		{
+			MethodName: "GetBasisTermStructure",
+			Handler:    _GoCryptoTraderService_GetBasisTermStructure_Handler,
+		},
+		{
+			MethodName: "GetBasisHistory",
+			Handler:    _GoCryptoTraderService_GetBasisHistory_Handler,
+		},
+		{
+			MethodName: "GetAlternativeBars",
+			Handler:    _GoCryptoTraderService_GetAlternativeBars_Handler,
+		},
+		{
+			MethodName: "GetInstruments",
+			Handler:    _GoCryptoTraderService_GetInstruments_Handler,
+		},
+		{
+			MethodName: "GetInstrument",
+			Handler:    _GoCryptoTraderService_GetInstrument_Handler,
+		},
+		{
+			MethodName: "GetPNLReport",
+			Handler:    _GoCryptoTraderService_GetPNLReport_Handler,
+		},
+		{
+			MethodName: "GetNAVHistory",
+			Handler:    _GoCryptoTraderService_GetNAVHistory_Handler,
+		},
+		{
+			MethodName: "GetTaxReport",
+			Handler:    _GoCryptoTraderService_GetTaxReport_Handler,
+		},
+		{
+			MethodName: "GetLedgerReconciliation",
+			Handler:    _GoCryptoTraderService_GetLedgerReconciliation_Handler,
+		},
+		{
+			MethodName: "GetRebalancePlan",
+			Handler:    _GoCryptoTraderService_GetRebalancePlan_Handler,
+		},
+		{
+			MethodName: "ExecuteRebalance",
+			Handler:    _GoCryptoTraderService_ExecuteRebalance_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -4152,6 +5076,31 @@ var GoCryptoTraderService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _GoCryptoTraderService_GetHistoricTrades_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "GetCandleStream",
+			Handler:       _GoCryptoTraderService_GetCandleStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetInstrumentStream",
+			Handler:       _GoCryptoTraderService_GetInstrumentStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetOrderUpdateStream",
+			Handler:       _GoCryptoTraderService_GetOrderUpdateStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetFillStream",
+			Handler:       _GoCryptoTraderService_GetFillStream_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "GetPositionStream",
+			Handler:       _GoCryptoTraderService_GetPositionStream_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "rpc.proto",
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/request"
 )
@@ -151,8 +152,10 @@ func setSubAccount(args ...objects.Object) (objects.Object, error) {
 	return ctx, nil
 }
 
+// processScriptContext returns the context for a script call, every call is
+// given its own correlation ID
 func processScriptContext(scriptCtx *Context) context.Context {
-	ctx := context.Background()
+	ctx := tracing.WithCorrelationID(context.Background(), tracing.NewCorrelationID())
 	if scriptCtx == nil || scriptCtx.Value == nil {
 		return ctx
 	}
@@ -9,6 +9,7 @@ import (
 
 	objects "github.com/d5/tengo/v2"
 	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/tracing"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/account"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
@@ -498,6 +499,9 @@ func TestProcessScriptContext(t *testing.T) {
 	if ctx == nil {
 		t.Fatal("should not be nil")
 	}
+	if id := tracing.CorrelationID(ctx); !tracing.IsValidCorrelationID(id) {
+		t.Errorf("received: '%v' but expected: '%v'", id, "a correlation ID")
+	}
 
 	fromScript, err := setAccount(&Context{}, dummyStr, dummyStr, dummyStr, dummyStr, dummyStr, dummyStr)
 	if !errors.Is(err, nil) {
@@ -518,6 +522,9 @@ func TestProcessScriptContext(t *testing.T) {
 	if ctx == nil {
 		t.Fatal("should not be nil")
 	}
+	if id := tracing.CorrelationID(ctx); !tracing.IsValidCorrelationID(id) {
+		t.Errorf("received: '%v' but expected: '%v'", id, "a correlation ID")
+	}
 }
 
 func TestScriptCredentialTypeName(t *testing.T) {
@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// AdxModule Average Directional Index indicator commands
+var AdxModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: adx},
+}
+
+// AverageDirectionalIndex is the string constant
+const AverageDirectionalIndex = "Average Directional Index"
+
+// ADX defines a custom Average Directional Index tengo indicator object type
+type ADX struct {
+	objects.Array
+	Period int
+}
+
+// TypeName returns the name of the custom type.
+func (o *ADX) TypeName() string {
+	return AverageDirectionalIndex
+}
+
+func adx(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(ADX)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inTimePeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inTimePeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Period = inTimePeriod
+
+	ret, err := item.GetDirectionalMovementIndex(int64(inTimePeriod))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret.ADX, ret.PlusDI, ret.MinusDI)
+	return r, nil
+}
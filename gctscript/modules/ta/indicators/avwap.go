@@ -0,0 +1,66 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// AvwapModule Anchored Volume Weighted Average Price indicator commands
+var AvwapModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: avwap},
+}
+
+// AnchoredVolumeWeightedAveragePrice is the string constant
+const AnchoredVolumeWeightedAveragePrice = "Anchored Volume Weighted Average Price"
+
+// AVWAP defines a custom Anchored Volume Weighted Average Price tengo indicator object type
+type AVWAP struct {
+	objects.Array
+	Anchor time.Time
+}
+
+// TypeName returns the name of the custom type.
+func (o *AVWAP) TypeName() string {
+	return AnchoredVolumeWeightedAveragePrice
+}
+
+func avwap(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(AVWAP)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inAnchor, ok := objects.ToTime(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inAnchor))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Anchor = inAnchor
+
+	ret, err := item.GetAnchoredVolumeWeightedAveragePrice(inAnchor)
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret)
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// CciModule Commodity Channel Index indicator commands
+var CciModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: cci},
+}
+
+// CommodityChannelIndex is the string constant
+const CommodityChannelIndex = "Commodity Channel Index"
+
+// CCI defines a custom Commodity Channel Index tengo indicator object type
+type CCI struct {
+	objects.Array
+	Period int
+}
+
+// TypeName returns the name of the custom type.
+func (o *CCI) TypeName() string {
+	return CommodityChannelIndex
+}
+
+func cci(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(CCI)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inTimePeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inTimePeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Period = inTimePeriod
+
+	ret, err := item.GetCommodityChannelIndex(int64(inTimePeriod))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret)
+	return r, nil
+}
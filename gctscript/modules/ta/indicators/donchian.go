@@ -0,0 +1,65 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// DonchianModule Donchian Channels indicator commands
+var DonchianModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: donchian},
+}
+
+// DonchianChannels is the string constant
+const DonchianChannels = "Donchian Channels"
+
+// Donchian defines a custom Donchian Channels tengo indicator object type
+type Donchian struct {
+	objects.Array
+	Period int
+}
+
+// TypeName returns the name of the custom type.
+func (o *Donchian) TypeName() string {
+	return DonchianChannels
+}
+
+func donchian(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 2 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Donchian)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inTimePeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inTimePeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Period = inTimePeriod
+
+	ret, err := item.GetDonchianChannels(int64(inTimePeriod))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret.Upper, ret.Middle, ret.Lower)
+	return r, nil
+}
@@ -0,0 +1,83 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// IchimokuModule Ichimoku Cloud indicator commands
+var IchimokuModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: ichimoku},
+}
+
+// IchimokuCloud is the string constant
+const IchimokuCloud = "Ichimoku Cloud"
+
+// Ichimoku defines a custom Ichimoku Cloud tengo indicator object type
+type Ichimoku struct {
+	objects.Array
+	ConversionPeriod, BasePeriod, SpanBPeriod, Displacement int
+}
+
+// TypeName returns the name of the custom type.
+func (o *Ichimoku) TypeName() string {
+	return IchimokuCloud
+}
+
+func ichimoku(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(Ichimoku)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inConversionPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inConversionPeriod))
+	}
+
+	inBasePeriod, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inBasePeriod))
+	}
+
+	inSpanBPeriod, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inSpanBPeriod))
+	}
+
+	inDisplacement, ok := objects.ToInt(args[4])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDisplacement))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.ConversionPeriod = inConversionPeriod
+	r.BasePeriod = inBasePeriod
+	r.SpanBPeriod = inSpanBPeriod
+	r.Displacement = inDisplacement
+
+	ret, err := item.GetIchimokuCloud(int64(inConversionPeriod), int64(inBasePeriod), int64(inSpanBPeriod), int64(inDisplacement))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret.ConversionLine, ret.BaseLine, ret.LeadingSpanA, ret.LeadingSpanB, ret.LaggingSpan)
+	return r, nil
+}
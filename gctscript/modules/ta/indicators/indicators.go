@@ -4,8 +4,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
+	objects "github.com/d5/tengo/v2"
 	"github.com/thrasher-corp/gct-ta/indicators"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
 	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
 )
 
@@ -29,6 +32,92 @@ func toFloat64(data interface{}) (float64, error) {
 	}
 }
 
+func toTime(data interface{}) (time.Time, error) {
+	switch d := data.(type) {
+	case time.Time:
+		return d, nil
+	case int64:
+		return time.Unix(d, 0), nil
+	case int:
+		return time.Unix(int64(d), 0), nil
+	default:
+		return time.Time{}, fmt.Errorf(modules.ErrParameterConvertFailed, d)
+	}
+}
+
+// toKlineItem converts script OHLCV candles to a kline item so the kline
+// technical analysis methods can be used
+func toKlineItem(data objects.Object) (*kline.Item, error) {
+	ohlcvInputData, valid := objects.ToInterface(data).([]interface{})
+	if !valid {
+		return nil, fmt.Errorf(modules.ErrParameterConvertFailed, OHLCV)
+	}
+
+	item := &kline.Item{Candles: make([]kline.Candle, len(ohlcvInputData))}
+	for x := range ohlcvInputData {
+		var err error
+		item.Candles[x], err = toCandle(ohlcvInputData[x])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return item, nil
+}
+
+// toCandle converts a single script [time, open, high, low, close, volume]
+// candle
+func toCandle(data interface{}) (kline.Candle, error) {
+	t, ok := data.([]interface{})
+	if !ok {
+		return kline.Candle{}, errors.New("ohlcvInputData type assert failed")
+	}
+	if len(t) < 6 {
+		return kline.Candle{}, errors.New("ohlcvInputData invalid data length")
+	}
+	var allErrors []string
+	candleTime, err := toTime(t[0])
+	if err != nil {
+		allErrors = append(allErrors, err.Error())
+	}
+	var values [5]float64
+	for y := range values {
+		values[y], err = toFloat64(t[y+1])
+		if err != nil {
+			allErrors = append(allErrors, err.Error())
+		}
+	}
+	if len(allErrors) > 0 {
+		return kline.Candle{}, errors.New(strings.Join(allErrors, ", "))
+	}
+	return kline.Candle{
+		Time:   candleTime,
+		Open:   values[0],
+		High:   values[1],
+		Low:    values[2],
+		Close:  values[3],
+		Volume: values[4],
+	}, nil
+}
+
+// appendSeries adds indicator output to a tengo array, a single series is
+// added as values while multiple series are grouped per candle
+func appendSeries(r *objects.Array, series ...[]float64) {
+	if len(series) == 0 {
+		return
+	}
+	for x := range series[0] {
+		if len(series) == 1 {
+			r.Value = append(r.Value, &objects.Float{Value: series[0][x]})
+			continue
+		}
+		temp := &objects.Array{}
+		for y := range series {
+			temp.Value = append(temp.Value, &objects.Float{Value: series[y][x]})
+		}
+		r.Value = append(r.Value, temp)
+	}
+}
+
 // ParseIndicatorSelector returns indicator number from string for slice selection
 func ParseIndicatorSelector(in string) (int, error) {
 	switch in {
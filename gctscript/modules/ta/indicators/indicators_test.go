@@ -2,6 +2,7 @@ package indicators
 
 import (
 	"errors"
+	"math"
 	"math/rand"
 	"os"
 	"reflect"
@@ -429,6 +430,539 @@ func TestOBV(t *testing.T) {
 	validator.IsTestExecution.Store(false)
 }
 
+func TestStochastic(t *testing.T) {
+	_, err := stochastic()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = stochastic(ohlcvData, v, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := stochastic(ohlcvData, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*STOCH); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = stochastic(v, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = stochastic(ohlcvDataInvalid, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = stochastic(ohlcvData, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestStochasticRSI(t *testing.T) {
+	_, err := stochasticRSI()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = stochasticRSI(ohlcvData, v, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := stochasticRSI(ohlcvData, &objects.Int{Value: 14}, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*STOCHRSI); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = stochasticRSI(v, &objects.Int{Value: 14}, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = stochasticRSI(ohlcvDataInvalid, &objects.Int{Value: 14}, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = stochasticRSI(ohlcvData, &objects.Int{Value: 14}, &objects.Int{Value: 14}, &objects.Int{Value: 3}, &objects.Int{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestADX(t *testing.T) {
+	_, err := adx()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = adx(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := adx(ohlcvData, &objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*ADX); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = adx(v, &objects.Int{Value: 14})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = adx(ohlcvDataInvalid, &objects.Int{Value: 14})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = adx(ohlcvData, &objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestIchimoku(t *testing.T) {
+	_, err := ichimoku()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = ichimoku(ohlcvData, v, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := ichimoku(ohlcvData, &objects.Int{Value: 9}, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*Ichimoku); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = ichimoku(v, &objects.Int{Value: 9}, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = ichimoku(ohlcvDataInvalid, &objects.Int{Value: 9}, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = ichimoku(ohlcvData, &objects.Int{Value: 9}, &objects.Int{Value: 26}, &objects.Int{Value: 52}, &objects.Int{Value: 26})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestSupertrend(t *testing.T) {
+	_, err := supertrend()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = supertrend(ohlcvData, v, &objects.Float{Value: 3})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := supertrend(ohlcvData, &objects.Int{Value: 10}, &objects.Float{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*Supertrend); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = supertrend(v, &objects.Int{Value: 10}, &objects.Float{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = supertrend(ohlcvDataInvalid, &objects.Int{Value: 10}, &objects.Float{Value: 3})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = supertrend(ohlcvData, &objects.Int{Value: 10}, &objects.Float{Value: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestKeltner(t *testing.T) {
+	_, err := keltner()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = keltner(ohlcvData, v, &objects.Int{Value: 10}, &objects.Float{Value: 2})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := keltner(ohlcvData, &objects.Int{Value: 20}, &objects.Int{Value: 10}, &objects.Float{Value: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*Keltner); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = keltner(v, &objects.Int{Value: 20}, &objects.Int{Value: 10}, &objects.Float{Value: 2})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = keltner(ohlcvDataInvalid, &objects.Int{Value: 20}, &objects.Int{Value: 10}, &objects.Float{Value: 2})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = keltner(ohlcvData, &objects.Int{Value: 20}, &objects.Int{Value: 10}, &objects.Float{Value: 2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestDonchian(t *testing.T) {
+	_, err := donchian()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = donchian(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := donchian(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*Donchian); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = donchian(v, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = donchian(ohlcvDataInvalid, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = donchian(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestPSAR(t *testing.T) {
+	_, err := psar()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = psar(ohlcvData, v, &objects.Float{Value: 0.2})
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := psar(ohlcvData, &objects.Float{Value: 0.02}, &objects.Float{Value: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*PSAR); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = psar(v, &objects.Float{Value: 0.02}, &objects.Float{Value: 0.2})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = psar(ohlcvDataInvalid, &objects.Float{Value: 0.02}, &objects.Float{Value: 0.2})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = psar(ohlcvData, &objects.Float{Value: 0.02}, &objects.Float{Value: 0.2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestCCI(t *testing.T) {
+	_, err := cci()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = cci(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := cci(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*CCI); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = cci(v, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = cci(ohlcvDataInvalid, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = cci(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestWILLR(t *testing.T) {
+	_, err := willr()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = willr(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := willr(ohlcvData, &objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*WILLR); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = willr(v, &objects.Int{Value: 14})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = willr(ohlcvDataInvalid, &objects.Int{Value: 14})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = willr(ohlcvData, &objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestAVWAP(t *testing.T) {
+	_, err := avwap()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = avwap(ohlcvData, v)
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	ret, err := avwap(ohlcvData, ohlcvData.Value[50].(*objects.Array).Value[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*AVWAP); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = avwap(v, ohlcvData.Value[50].(*objects.Array).Value[0])
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = avwap(ohlcvDataInvalid, ohlcvData.Value[50].(*objects.Array).Value[0])
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = avwap(ohlcvData, ohlcvData.Value[50].(*objects.Array).Value[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestZScore(t *testing.T) {
+	_, err := zscore()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = zscore(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := zscore(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*ZScore); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = zscore(v, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = zscore(ohlcvDataInvalid, &objects.Int{Value: 20})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = zscore(ohlcvData, &objects.Int{Value: 20})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
+func TestHurst(t *testing.T) {
+	_, err := hurst()
+	if !errors.Is(err, objects.ErrWrongNumArguments) {
+		t.Errorf("received '%v', expected '%v'", err, objects.ErrWrongNumArguments)
+	}
+
+	v := &objects.String{Value: testString}
+	_, err = hurst(ohlcvData, v)
+	if err == nil || err.Error() != errFailedConversion {
+		t.Errorf("received '%v', expected '%v'", err, errFailedConversion)
+	}
+
+	ret, err := hurst(ohlcvData, &objects.Int{Value: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r, ok := ret.(*Hurst); !ok || len(r.Value) != len(ohlcvData.Value) {
+		t.Errorf("expected a value for each candle, received '%v'", ret)
+	}
+
+	_, err = hurst(v, &objects.Int{Value: 32})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	_, err = hurst(ohlcvDataInvalid, &objects.Int{Value: 32})
+	if err == nil {
+		t.Error("expected conversion failed error")
+	}
+
+	validator.IsTestExecution.Store(true)
+	ret, err = hurst(ohlcvData, &objects.Int{Value: 32})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if (ret == &objects.Array{}) {
+		t.Error("expected empty Array on test execution received data")
+	}
+	validator.IsTestExecution.Store(false)
+}
+
 func TestToFloat64(t *testing.T) {
 	value := 54.0
 	v, err := toFloat64(value)
@@ -561,3 +1095,114 @@ func TestParseMAType(t *testing.T) {
 		})
 	}
 }
+
+func callStream(t *testing.T, s objects.Object, fn string, args ...objects.Object) objects.Object {
+	t.Helper()
+	stream, ok := s.(*Stream)
+	if !ok {
+		t.Fatalf("received '%T', expected '%T'", s, &Stream{})
+	}
+	f, err := stream.IndexGet(&objects.String{Value: fn})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret, err := f.(*objects.UserFunction).Value(args...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ret
+}
+
+func TestStream(t *testing.T) {
+	for name, args := range map[string][]objects.Object{
+		"sma":         {&objects.Int{Value: 9}},
+		"ema":         {&objects.Int{Value: 9}},
+		"rsi":         {&objects.Int{Value: 14}},
+		"atr":         {&objects.Int{Value: 14}},
+		"bbands":      {&objects.Int{Value: 20}, &objects.Float{Value: 2}, &objects.Float{Value: 2}, &objects.String{Value: "sma"}},
+		"macd":        {&objects.Int{Value: 12}, &objects.Int{Value: 26}, &objects.Int{Value: 9}},
+		"mfi":         {&objects.Int{Value: 14}},
+		"obv":         {},
+		"correlation": {&objects.Int{Value: 20}},
+	} {
+		constructor, ok := StreamModule[name].(*objects.UserFunction)
+		if !ok {
+			t.Fatalf("received '%T', expected '%T'", StreamModule[name], &objects.UserFunction{})
+		}
+		_, err := constructor.Value(append(args, &objects.Int{Value: 1})...)
+		if !errors.Is(err, objects.ErrWrongNumArguments) {
+			t.Fatalf("%v received '%v', expected '%v'", name, err, objects.ErrWrongNumArguments)
+		}
+		if len(args) > 0 {
+			invalid := append([]objects.Object{&objects.String{Value: testString}}, args[1:]...)
+			_, err = constructor.Value(invalid...)
+			if err == nil {
+				t.Fatalf("%v expected conversion failed error", name)
+			}
+		}
+		s, err := constructor.Value(args...)
+		if err != nil {
+			t.Fatal(err)
+		}
+		candles := []objects.Object{ohlcvData.Value[0]}
+		if name == "correlation" {
+			candles = append(candles, ohlcvData.Value[1])
+		}
+		_, err = s.(*Stream).Value["update"].(*objects.UserFunction).Value(ohlcvDataInvalid.Value[0])
+		if err == nil {
+			t.Fatalf("%v expected error", name)
+		}
+		for x := range ohlcvData.Value {
+			candles[0] = ohlcvData.Value[x]
+			peeked := callStream(t, s, "peek", candles...)
+			updated := callStream(t, s, "update", candles...)
+			// compared as strings as a window without variance may be NaN
+			if peeked.String() != updated.String() {
+				t.Fatalf("%v peeked '%v', expected '%v'", name, peeked, updated)
+			}
+		}
+		if callStream(t, s, "ready") != objects.TrueValue {
+			t.Fatalf("%v expected stream to be ready", name)
+		}
+		if name == "correlation" {
+			var ret objects.Object
+			for x := range ohlcvData.Value {
+				candles[0] = ohlcvData.Value[x]
+				candles[1] = ohlcvData.Value[len(ohlcvData.Value)-1-x]
+				ret = callStream(t, s, "update", candles...)
+			}
+			if v := ret.(*objects.Float).Value; math.IsNaN(v) || v == 0 {
+				t.Fatalf("%v received '%v', expected a coefficient", name, v)
+			}
+		}
+	}
+
+	s, err := rsiStream(&objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected, err := rsi(ohlcvData, &objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for x := range ohlcvData.Value {
+		ret := callStream(t, s, "update", ohlcvData.Value[x])
+		if !reflect.DeepEqual(ret, expected.(*RSI).Value[x]) {
+			t.Fatalf("received '%v', expected '%v'", ret, expected.(*RSI).Value[x])
+		}
+	}
+
+	validator.IsTestExecution.Store(true)
+	s, err = rsiStream(&objects.Int{Value: 14})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ret := callStream(t, s, "update", ohlcvDataInvalid.Value[0])
+	if !reflect.DeepEqual(ret, &objects.Array{}) {
+		t.Errorf("received '%v', expected empty Array on test execution", ret)
+	}
+	if callStream(t, s, "ready") != objects.FalseValue {
+		t.Error("expected stream not to be ready on test execution")
+	}
+	validator.IsTestExecution.Store(false)
+}
@@ -0,0 +1,71 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// PsarModule Parabolic Stop and Reverse indicator commands
+var PsarModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: psar},
+}
+
+// ParabolicSAR is the string constant
+const ParabolicSAR = "Parabolic Stop and Reverse"
+
+// PSAR defines a custom Parabolic Stop and Reverse tengo indicator object type
+type PSAR struct {
+	objects.Array
+	Step, Maximum float64
+}
+
+// TypeName returns the name of the custom type.
+func (o *PSAR) TypeName() string {
+	return ParabolicSAR
+}
+
+func psar(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 3 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(PSAR)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inStep, ok := objects.ToFloat64(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inStep))
+	}
+
+	inMaximum, ok := objects.ToFloat64(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inMaximum))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Step = inStep
+	r.Maximum = inMaximum
+
+	ret, err := item.GetParabolicSAR(inStep, inMaximum)
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret)
+	return r, nil
+}
@@ -0,0 +1,77 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// StochasticModule Stochastic Oscillator indicator commands
+var StochasticModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: stochastic},
+}
+
+// StochasticOscillator is the string constant
+const StochasticOscillator = "Stochastic Oscillator"
+
+// STOCH defines a custom Stochastic Oscillator tengo indicator object type
+type STOCH struct {
+	objects.Array
+	Period, KSmoothing, DPeriod int
+}
+
+// TypeName returns the name of the custom type.
+func (o *STOCH) TypeName() string {
+	return StochasticOscillator
+}
+
+func stochastic(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(STOCH)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inKPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inKPeriod))
+	}
+
+	inKSmoothing, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inKSmoothing))
+	}
+
+	inDPeriod, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDPeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.Period = inKPeriod
+	r.KSmoothing = inKSmoothing
+	r.DPeriod = inDPeriod
+
+	ret, err := item.GetStochastic(int64(inKPeriod), int64(inKSmoothing), int64(inDPeriod))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret.K, ret.D)
+	return r, nil
+}
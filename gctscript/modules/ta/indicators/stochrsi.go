@@ -0,0 +1,83 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// StochasticRSIModule Stochastic Relative Strength Index indicator commands
+var StochasticRSIModule = map[string]objects.Object{
+	"calculate": &objects.UserFunction{Name: "calculate", Value: stochasticRSI},
+}
+
+// StochasticRelativeStrengthIndex is the string constant
+const StochasticRelativeStrengthIndex = "Stochastic Relative Strength Index"
+
+// STOCHRSI defines a custom Stochastic Relative Strength Index tengo indicator object type
+type STOCHRSI struct {
+	objects.Array
+	RSIPeriod, Period, KSmoothing, DPeriod int
+}
+
+// TypeName returns the name of the custom type.
+func (o *STOCHRSI) TypeName() string {
+	return StochasticRelativeStrengthIndex
+}
+
+func stochasticRSI(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 5 {
+		return nil, objects.ErrWrongNumArguments
+	}
+
+	r := new(STOCHRSI)
+	if validator.IsTestExecution.Load() == true {
+		return r, nil
+	}
+
+	item, err := toKlineItem(args[0])
+	if err != nil {
+		return nil, err
+	}
+
+	var allErrors []string
+	inRSIPeriod, ok := objects.ToInt(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inRSIPeriod))
+	}
+
+	inStochasticPeriod, ok := objects.ToInt(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inStochasticPeriod))
+	}
+
+	inKSmoothing, ok := objects.ToInt(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inKSmoothing))
+	}
+
+	inDPeriod, ok := objects.ToInt(args[4])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, inDPeriod))
+	}
+
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+
+	r.RSIPeriod = inRSIPeriod
+	r.Period = inStochasticPeriod
+	r.KSmoothing = inKSmoothing
+	r.DPeriod = inDPeriod
+
+	ret, err := item.GetStochasticRelativeStrengthIndexOnClose(int64(inRSIPeriod), int64(inStochasticPeriod), int64(inKSmoothing), int64(inDPeriod))
+	if err != nil {
+		return nil, err
+	}
+	appendSeries(&r.Array, ret.K, ret.D)
+	return r, nil
+}
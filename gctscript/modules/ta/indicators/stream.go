@@ -0,0 +1,306 @@
+package indicators
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	objects "github.com/d5/tengo/v2"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/kline"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/modules"
+	"github.com/thrasher-corp/gocryptotrader/gctscript/wrappers/validator"
+)
+
+// StreamModule streaming indicator commands, each returns a stream object
+// with update, peek and ready functions which process one candle at a time
+var StreamModule = map[string]objects.Object{
+	"sma":         &objects.UserFunction{Name: "sma", Value: smaStream},
+	"ema":         &objects.UserFunction{Name: "ema", Value: emaStream},
+	"rsi":         &objects.UserFunction{Name: "rsi", Value: rsiStream},
+	"atr":         &objects.UserFunction{Name: "atr", Value: atrStream},
+	"bbands":      &objects.UserFunction{Name: "bbands", Value: bbandsStream},
+	"macd":        &objects.UserFunction{Name: "macd", Value: macdStream},
+	"mfi":         &objects.UserFunction{Name: "mfi", Value: mfiStream},
+	"obv":         &objects.UserFunction{Name: "obv", Value: obvStream},
+	"correlation": &objects.UserFunction{Name: "correlation", Value: correlationStream},
+}
+
+// StreamingIndicator is the string constant
+const StreamingIndicator = "Streaming Indicator"
+
+// Stream defines a custom streaming indicator tengo object type
+type Stream struct {
+	objects.ImmutableMap
+	Indicator string
+}
+
+// TypeName returns the name of the custom type.
+func (s *Stream) TypeName() string {
+	return StreamingIndicator
+}
+
+// streamFuncs holds the underlying stream functions wrapped by a Stream, nil
+// functions return empty values for script validation
+type streamFuncs struct {
+	candles int
+	update  func([]kline.Candle) []float64
+	peek    func([]kline.Candle) []float64
+	ready   func() bool
+}
+
+// newStream wraps the stream functions with tengo update, peek and ready
+// functions
+func newStream(indicator string, f *streamFuncs) *Stream {
+	return &Stream{
+		Indicator: indicator,
+		ImmutableMap: objects.ImmutableMap{
+			Value: map[string]objects.Object{
+				"update": &objects.UserFunction{Name: "update", Value: f.call(f.update)},
+				"peek":   &objects.UserFunction{Name: "peek", Value: f.call(f.peek)},
+				"ready": &objects.UserFunction{Name: "ready", Value: func(args ...objects.Object) (objects.Object, error) {
+					if len(args) != 0 {
+						return nil, objects.ErrWrongNumArguments
+					}
+					if f.ready == nil || !f.ready() {
+						return objects.FalseValue, nil
+					}
+					return objects.TrueValue, nil
+				}},
+			},
+		},
+	}
+}
+
+func (f *streamFuncs) call(fn func([]kline.Candle) []float64) objects.CallableFunc {
+	return func(args ...objects.Object) (objects.Object, error) {
+		if len(args) != f.candles {
+			return nil, objects.ErrWrongNumArguments
+		}
+		if fn == nil {
+			return &objects.Array{}, nil
+		}
+		candles := make([]kline.Candle, len(args))
+		for x := range args {
+			var err error
+			candles[x], err = toCandle(objects.ToInterface(args[x]))
+			if err != nil {
+				return nil, err
+			}
+		}
+		values := fn(candles)
+		if len(values) == 1 {
+			return &objects.Float{Value: values[0]}, nil
+		}
+		r := &objects.Array{}
+		for x := range values {
+			r.Value = append(r.Value, &objects.Float{Value: values[x]})
+		}
+		return r, nil
+	}
+}
+
+// singleStream adapts an indicator stream which processes one candle
+func singleStream(s kline.IndicatorStream) *streamFuncs {
+	return &streamFuncs{
+		candles: 1,
+		update:  func(c []kline.Candle) []float64 { return s.Update(c[0]) },
+		peek:    func(c []kline.Candle) []float64 { return s.Peek(c[0]) },
+		ready:   s.Ready,
+	}
+}
+
+// toStreamInts converts stream constructor arguments to integers
+func toStreamInts(args ...objects.Object) ([]int64, error) {
+	resp := make([]int64, len(args))
+	var allErrors []string
+	for x := range args {
+		v, ok := objects.ToInt64(args[x])
+		if !ok {
+			allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, args[x]))
+		}
+		resp[x] = v
+	}
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+	return resp, nil
+}
+
+func smaStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(SimpleMovingAverage, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewSimpleMovingAverageStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(SimpleMovingAverage, singleStream(s)), nil
+}
+
+func emaStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(ExponentialMovingAverage, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewExponentialMovingAverageStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(ExponentialMovingAverage, singleStream(s)), nil
+}
+
+func rsiStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(RelativeStrengthIndex, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewRelativeStrengthIndexStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(RelativeStrengthIndex, singleStream(s)), nil
+}
+
+func atrStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(AverageTrueRange, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewAverageTrueRangeStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(AverageTrueRange, singleStream(s)), nil
+}
+
+func bbandsStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 4 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(BollingerBands, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args[0])
+	if err != nil {
+		return nil, err
+	}
+	var allErrors []string
+	inNbDevUp, ok := objects.ToFloat64(args[1])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, args[1]))
+	}
+	inNbDevDown, ok := objects.ToFloat64(args[2])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, args[2]))
+	}
+	inMAType, ok := objects.ToString(args[3])
+	if !ok {
+		allErrors = append(allErrors, fmt.Sprintf(modules.ErrParameterConvertFailed, args[3]))
+	}
+	if len(allErrors) > 0 {
+		return nil, errors.New(strings.Join(allErrors, ", "))
+	}
+	maType, err := ParseMAType(inMAType)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewBollingerBandsStream(params[0], inNbDevUp, inNbDevDown, maType)
+	if err != nil {
+		return nil, err
+	}
+	return newStream(BollingerBands, singleStream(s)), nil
+}
+
+func macdStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 3 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(MovingAverageConvergenceDivergence, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewMACDStream(params[0], params[1], params[2])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(MovingAverageConvergenceDivergence, singleStream(s)), nil
+}
+
+func mfiStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(MoneyFlowIndex, &streamFuncs{candles: 1}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewMoneyFlowIndexStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(MoneyFlowIndex, singleStream(s)), nil
+}
+
+func obvStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 0 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(OnBalanceVolume, &streamFuncs{candles: 1}), nil
+	}
+	return newStream(OnBalanceVolume, singleStream(kline.NewOnBalanceVolumeStream())), nil
+}
+
+func correlationStream(args ...objects.Object) (objects.Object, error) {
+	if len(args) != 1 {
+		return nil, objects.ErrWrongNumArguments
+	}
+	if validator.IsTestExecution.Load() == true {
+		return newStream(CorrelationCoefficient, &streamFuncs{candles: 2}), nil
+	}
+	params, err := toStreamInts(args...)
+	if err != nil {
+		return nil, err
+	}
+	s, err := kline.NewCorrelationCoefficientStream(params[0])
+	if err != nil {
+		return nil, err
+	}
+	return newStream(CorrelationCoefficient, &streamFuncs{
+		candles: 2,
+		update:  func(c []kline.Candle) []float64 { return []float64{s.Update(c[0], c[1])} },
+		peek:    func(c []kline.Candle) []float64 { return []float64{s.Peek(c[0], c[1])} },
+		ready:   s.Ready,
+	}), nil
+}
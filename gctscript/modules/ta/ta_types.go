@@ -16,4 +16,18 @@ var Modules = map[string]map[string]tengo.Object{
 	"indicator/mfi":                    indicators.MfiModule,
 	"indicator/atr":                    indicators.AtrModule,
 	"indicator/correlationcoefficient": indicators.CorrelationCoefficientModule,
+	"indicator/stochastic":             indicators.StochasticModule,
+	"indicator/stochrsi":               indicators.StochasticRSIModule,
+	"indicator/adx":                    indicators.AdxModule,
+	"indicator/ichimoku":               indicators.IchimokuModule,
+	"indicator/supertrend":             indicators.SupertrendModule,
+	"indicator/keltner":                indicators.KeltnerModule,
+	"indicator/donchian":               indicators.DonchianModule,
+	"indicator/psar":                   indicators.PsarModule,
+	"indicator/cci":                    indicators.CciModule,
+	"indicator/willr":                  indicators.WillrModule,
+	"indicator/avwap":                  indicators.AvwapModule,
+	"indicator/zscore":                 indicators.ZScoreModule,
+	"indicator/hurst":                  indicators.HurstModule,
+	"indicator/stream":                 indicators.StreamModule,
 }
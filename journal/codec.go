@@ -0,0 +1,518 @@
+package journal
+
+import (
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"math"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+// String returns the name of the kind
+func (k Kind) String() string {
+	switch k {
+	case KindTicker:
+		return "ticker"
+	case KindOrderbook:
+		return "orderbook"
+	case KindTrade:
+		return "trade"
+	case KindOrder:
+		return "order"
+	case KindFill:
+		return "fill"
+	case KindKline:
+		return "kline"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(k))
+	}
+}
+
+// KindFromString returns the kind matching a name returned by Kind.String
+func KindFromString(s string) (Kind, error) {
+	for k := KindTicker; k <= KindKline; k++ {
+		if k.String() == s {
+			return k, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", errUnknownKind, s)
+}
+
+// KindOf returns the kind a supported event type is journaled as
+func KindOf(data interface{}) (Kind, error) {
+	switch data.(type) {
+	case *ticker.Price:
+		return KindTicker, nil
+	case *orderbook.Base:
+		return KindOrderbook, nil
+	case *trade.Data:
+		return KindTrade, nil
+	case *order.Detail:
+		return KindOrder, nil
+	case *fill.Data:
+		return KindFill, nil
+	case *stream.KlineData:
+		return KindKline, nil
+	default:
+		return 0, fmt.Errorf("%w %T", errUnsupportedEventType, data)
+	}
+}
+
+// appendRecord appends a framed record to dst. A frame is the uvarint length
+// of the body, the body and the little endian CRC32 of the body. The body is
+// the varint nanosecond timestamp, the kind and the encoded event
+func appendRecord(dst []byte, t time.Time, data interface{}) ([]byte, error) {
+	kind, err := KindOf(data)
+	if err != nil {
+		return dst, err
+	}
+	e := encoder{b: make([]byte, 0, 256)}
+	e.putVarint(t.UnixNano())
+	e.b = append(e.b, byte(kind))
+	switch d := data.(type) {
+	case *ticker.Price:
+		e.putTicker(d)
+	case *orderbook.Base:
+		e.putOrderbook(d)
+	case *trade.Data:
+		e.putTrade(d)
+	case *order.Detail:
+		e.putOrder(d)
+	case *fill.Data:
+		e.putFill(d)
+	case *stream.KlineData:
+		e.putKline(d)
+	}
+	dst = binary.AppendUvarint(dst, uint64(len(e.b)))
+	dst = append(dst, e.b...)
+	return binary.LittleEndian.AppendUint32(dst, crc32.ChecksumIEEE(e.b)), nil
+}
+
+// decodeRecord decodes a record body once its checksum has been verified
+func decodeRecord(body []byte) (*Record, error) {
+	d := decoder{b: body}
+	r := &Record{Time: time.Unix(0, d.varint())}
+	if len(d.b) == 0 {
+		return nil, ErrCorruptRecord
+	}
+	r.Kind = Kind(d.b[0])
+	d.b = d.b[1:]
+	switch r.Kind {
+	case KindTicker:
+		r.Data = d.ticker()
+	case KindOrderbook:
+		r.Data = d.orderbook()
+	case KindTrade:
+		r.Data = d.trade()
+	case KindOrder:
+		r.Data = d.order()
+	case KindFill:
+		r.Data = d.fill()
+	case KindKline:
+		r.Data = d.kline()
+	default:
+		return nil, fmt.Errorf("%w %v %v", ErrCorruptRecord, errUnknownKind, r.Kind)
+	}
+	if d.err != nil {
+		return nil, fmt.Errorf("%w %v: %v", ErrCorruptRecord, r.Kind, d.err)
+	}
+	return r, nil
+}
+
+// encoder appends compact binary values
+type encoder struct {
+	b []byte
+}
+
+func (e *encoder) putUvarint(v uint64) {
+	e.b = binary.AppendUvarint(e.b, v)
+}
+
+func (e *encoder) putVarint(v int64) {
+	e.b = binary.AppendVarint(e.b, v)
+}
+
+func (e *encoder) putFloat(v float64) {
+	e.b = binary.LittleEndian.AppendUint64(e.b, math.Float64bits(v))
+}
+
+func (e *encoder) putBool(v bool) {
+	if v {
+		e.b = append(e.b, 1)
+		return
+	}
+	e.b = append(e.b, 0)
+}
+
+func (e *encoder) putString(s string) {
+	e.putUvarint(uint64(len(s)))
+	e.b = append(e.b, s...)
+}
+
+// putTime stores a zero time as math.MinInt64 as it cannot be represented as
+// unix nanoseconds
+func (e *encoder) putTime(t time.Time) {
+	if t.IsZero() {
+		e.putVarint(math.MinInt64)
+		return
+	}
+	e.putVarint(t.UnixNano())
+}
+
+func (e *encoder) putPair(p currency.Pair) {
+	e.putString(p.Base.String())
+	e.putString(p.Quote.String())
+	e.putString(p.Delimiter)
+}
+
+func (e *encoder) putAsset(a asset.Item) {
+	if a == asset.Empty {
+		e.putString("")
+		return
+	}
+	e.putString(a.String())
+}
+
+func (e *encoder) putTicker(p *ticker.Price) {
+	e.putString(p.ExchangeName)
+	e.putPair(p.Pair)
+	e.putAsset(p.AssetType)
+	e.putTime(p.LastUpdated)
+	for _, v := range []float64{p.Last, p.High, p.Low, p.Bid, p.Ask, p.Volume,
+		p.QuoteVolume, p.PriceATH, p.Open, p.Close, p.FlashReturnRate,
+		p.BidPeriod, p.BidSize, p.AskPeriod, p.AskSize, p.FlashReturnRateAmount} {
+		e.putFloat(v)
+	}
+}
+
+func (e *encoder) putOrderbook(b *orderbook.Base) {
+	e.putString(b.Exchange)
+	e.putPair(b.Pair)
+	e.putAsset(b.Asset)
+	e.putTime(b.LastUpdated)
+	e.putVarint(b.LastUpdateID)
+	e.putBool(b.PriceDuplication)
+	e.putBool(b.IsFundingRate)
+	e.putBool(b.IDAlignment)
+	e.putItems(b.Bids)
+	e.putItems(b.Asks)
+}
+
+func (e *encoder) putItems(items orderbook.Items) {
+	e.putUvarint(uint64(len(items)))
+	for i := range items {
+		e.putFloat(items[i].Price)
+		e.putFloat(items[i].Amount)
+		e.putVarint(items[i].ID)
+		e.putVarint(items[i].Period)
+	}
+}
+
+func (e *encoder) putTrade(t *trade.Data) {
+	e.putString(t.Exchange)
+	e.putPair(t.CurrencyPair)
+	e.putAsset(t.AssetType)
+	e.putString(t.TID)
+	e.putString(t.Side.String())
+	e.putFloat(t.Price)
+	e.putFloat(t.Amount)
+	e.putTime(t.Timestamp)
+}
+
+func (e *encoder) putOrder(o *order.Detail) {
+	e.putString(o.Exchange)
+	e.putPair(o.Pair)
+	e.putAsset(o.AssetType)
+	e.putString(o.OrderID)
+	e.putString(o.ClientOrderID)
+	e.putString(o.Side.String())
+	e.putString(o.Type.String())
+	e.putString(o.Status.String())
+	for _, v := range []float64{o.Price, o.Amount, o.ExecutedAmount,
+		o.RemainingAmount, o.AverageExecutedPrice, o.TriggerPrice, o.Fee,
+		o.Cost, o.Leverage} {
+		e.putFloat(v)
+	}
+	e.putBool(o.ReduceOnly)
+	e.putBool(o.PostOnly)
+	e.putTime(o.Date)
+	e.putTime(o.LastUpdated)
+	e.putTime(o.CloseTime)
+}
+
+func (e *encoder) putFill(f *fill.Data) {
+	e.putString(f.Exchange)
+	e.putPair(f.CurrencyPair)
+	e.putAsset(f.AssetType)
+	e.putString(f.ID)
+	e.putString(f.OrderID)
+	e.putString(f.ClientOrderID)
+	e.putString(f.TradeID)
+	e.putString(f.Side.String())
+	e.putFloat(f.Price)
+	e.putFloat(f.Amount)
+	e.putTime(f.Timestamp)
+}
+
+func (e *encoder) putKline(k *stream.KlineData) {
+	e.putString(k.Exchange)
+	e.putPair(k.Pair)
+	e.putAsset(k.AssetType)
+	e.putString(k.Interval)
+	e.putTime(k.Timestamp)
+	e.putTime(k.StartTime)
+	e.putTime(k.CloseTime)
+	for _, v := range []float64{k.OpenPrice, k.ClosePrice, k.HighPrice, k.LowPrice, k.Volume} {
+		e.putFloat(v)
+	}
+}
+
+// decoder reads values written by an encoder, the first failure is retained
+// and every later read returns a zero value
+type decoder struct {
+	b   []byte
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Uvarint(d.b)
+	if n <= 0 {
+		d.fail(errShortRecord)
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+	v, n := binary.Varint(d.b)
+	if n <= 0 {
+		d.fail(errShortRecord)
+		return 0
+	}
+	d.b = d.b[n:]
+	return v
+}
+
+func (d *decoder) float() float64 {
+	if d.err != nil {
+		return 0
+	}
+	if len(d.b) < 8 {
+		d.fail(errShortRecord)
+		return 0
+	}
+	v := math.Float64frombits(binary.LittleEndian.Uint64(d.b))
+	d.b = d.b[8:]
+	return v
+}
+
+func (d *decoder) bool() bool {
+	if d.err != nil {
+		return false
+	}
+	if len(d.b) == 0 {
+		d.fail(errShortRecord)
+		return false
+	}
+	v := d.b[0] == 1
+	d.b = d.b[1:]
+	return v
+}
+
+func (d *decoder) string() string {
+	l := d.uvarint()
+	if d.err != nil {
+		return ""
+	}
+	if l > uint64(len(d.b)) {
+		d.fail(errShortRecord)
+		return ""
+	}
+	s := string(d.b[:l])
+	d.b = d.b[l:]
+	return s
+}
+
+func (d *decoder) time() time.Time {
+	v := d.varint()
+	if d.err != nil || v == math.MinInt64 {
+		return time.Time{}
+	}
+	return time.Unix(0, v)
+}
+
+func (d *decoder) pair() currency.Pair {
+	base, quote, delimiter := d.string(), d.string(), d.string()
+	if base == "" && quote == "" {
+		return currency.EMPTYPAIR
+	}
+	return currency.NewPairWithDelimiter(base, quote, delimiter)
+}
+
+func (d *decoder) asset() asset.Item {
+	s := d.string()
+	if s == "" {
+		return asset.Empty
+	}
+	a, err := asset.New(s)
+	if err != nil {
+		d.fail(err)
+	}
+	return a
+}
+
+// side, orderType and status tolerate unrecognised values so that records
+// written with values a later version no longer knows can still be read
+func (d *decoder) side() order.Side {
+	s, _ := order.StringToOrderSide(d.string())
+	return s
+}
+
+func (d *decoder) orderType() order.Type {
+	t, _ := order.StringToOrderType(d.string())
+	return t
+}
+
+func (d *decoder) status() order.Status {
+	s, _ := order.StringToOrderStatus(d.string())
+	return s
+}
+
+func (d *decoder) ticker() *ticker.Price {
+	p := &ticker.Price{
+		ExchangeName: d.string(),
+		Pair:         d.pair(),
+		AssetType:    d.asset(),
+		LastUpdated:  d.time(),
+	}
+	for _, v := range []*float64{&p.Last, &p.High, &p.Low, &p.Bid, &p.Ask, &p.Volume,
+		&p.QuoteVolume, &p.PriceATH, &p.Open, &p.Close, &p.FlashReturnRate,
+		&p.BidPeriod, &p.BidSize, &p.AskPeriod, &p.AskSize, &p.FlashReturnRateAmount} {
+		*v = d.float()
+	}
+	return p
+}
+
+func (d *decoder) orderbook() *orderbook.Base {
+	return &orderbook.Base{
+		Exchange:         d.string(),
+		Pair:             d.pair(),
+		Asset:            d.asset(),
+		LastUpdated:      d.time(),
+		LastUpdateID:     d.varint(),
+		PriceDuplication: d.bool(),
+		IsFundingRate:    d.bool(),
+		IDAlignment:      d.bool(),
+		Bids:             d.items(),
+		Asks:             d.items(),
+	}
+}
+
+func (d *decoder) items() orderbook.Items {
+	l := d.uvarint()
+	// every item is at least 18 bytes, which bounds the allocation by the
+	// size of the record
+	if d.err != nil || l > uint64(len(d.b)/18) {
+		d.fail(errShortRecord)
+		return nil
+	}
+	items := make(orderbook.Items, l)
+	for i := range items {
+		items[i].Price = d.float()
+		items[i].Amount = d.float()
+		items[i].ID = d.varint()
+		items[i].Period = d.varint()
+	}
+	return items
+}
+
+func (d *decoder) trade() *trade.Data {
+	return &trade.Data{
+		Exchange:     d.string(),
+		CurrencyPair: d.pair(),
+		AssetType:    d.asset(),
+		TID:          d.string(),
+		Side:         d.side(),
+		Price:        d.float(),
+		Amount:       d.float(),
+		Timestamp:    d.time(),
+	}
+}
+
+func (d *decoder) order() *order.Detail {
+	o := &order.Detail{
+		Exchange:      d.string(),
+		Pair:          d.pair(),
+		AssetType:     d.asset(),
+		OrderID:       d.string(),
+		ClientOrderID: d.string(),
+		Side:          d.side(),
+		Type:          d.orderType(),
+		Status:        d.status(),
+	}
+	for _, v := range []*float64{&o.Price, &o.Amount, &o.ExecutedAmount,
+		&o.RemainingAmount, &o.AverageExecutedPrice, &o.TriggerPrice, &o.Fee,
+		&o.Cost, &o.Leverage} {
+		*v = d.float()
+	}
+	o.ReduceOnly = d.bool()
+	o.PostOnly = d.bool()
+	o.Date = d.time()
+	o.LastUpdated = d.time()
+	o.CloseTime = d.time()
+	return o
+}
+
+func (d *decoder) fill() *fill.Data {
+	return &fill.Data{
+		Exchange:      d.string(),
+		CurrencyPair:  d.pair(),
+		AssetType:     d.asset(),
+		ID:            d.string(),
+		OrderID:       d.string(),
+		ClientOrderID: d.string(),
+		TradeID:       d.string(),
+		Side:          d.side(),
+		Price:         d.float(),
+		Amount:        d.float(),
+		Timestamp:     d.time(),
+	}
+}
+
+func (d *decoder) kline() *stream.KlineData {
+	k := &stream.KlineData{
+		Exchange:  d.string(),
+		Pair:      d.pair(),
+		AssetType: d.asset(),
+		Interval:  d.string(),
+		Timestamp: d.time(),
+		StartTime: d.time(),
+		CloseTime: d.time(),
+	}
+	for _, v := range []*float64{&k.OpenPrice, &k.ClosePrice, &k.HighPrice, &k.LowPrice, &k.Volume} {
+		*v = d.float()
+	}
+	return k
+}
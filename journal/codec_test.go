@@ -0,0 +1,177 @@
+package journal
+
+import (
+	"encoding/binary"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/fill"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/order"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/stream"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/trade"
+)
+
+var (
+	testPair = currency.NewPairWithDelimiter("BTC", "USDT", "-")
+	testTime = time.Unix(1672531200, 123456789)
+)
+
+func testEvents() []interface{} {
+	return []interface{}{
+		&ticker.Price{
+			ExchangeName: "binance",
+			Pair:         testPair,
+			AssetType:    asset.Spot,
+			LastUpdated:  testTime,
+			Last:         20000.5,
+			Bid:          20000,
+			Ask:          20001,
+			Volume:       1337,
+		},
+		&orderbook.Base{
+			Exchange:     "binance",
+			Pair:         testPair,
+			Asset:        asset.Spot,
+			LastUpdated:  testTime,
+			LastUpdateID: 42,
+			Bids:         orderbook.Items{{Price: 20000, Amount: 1}, {Price: 19999, Amount: 2}},
+			Asks:         orderbook.Items{{Price: 20001, Amount: 3, ID: 7}},
+		},
+		&trade.Data{
+			Exchange:     "binance",
+			CurrencyPair: testPair,
+			AssetType:    asset.Spot,
+			TID:          "1",
+			Side:         order.Buy,
+			Price:        20000,
+			Amount:       0.5,
+			Timestamp:    testTime,
+		},
+		&order.Detail{
+			Exchange:       "binance",
+			Pair:           testPair,
+			AssetType:      asset.Spot,
+			OrderID:        "123",
+			ClientOrderID:  "abc",
+			Side:           order.Sell,
+			Type:           order.Limit,
+			Status:         order.PartiallyFilled,
+			Price:          20000,
+			Amount:         1,
+			ExecutedAmount: 0.25,
+			Date:           testTime,
+		},
+		&fill.Data{
+			Exchange:     "binance",
+			CurrencyPair: testPair,
+			AssetType:    asset.Spot,
+			ID:           "f1",
+			OrderID:      "123",
+			TradeID:      "t1",
+			Side:         order.Sell,
+			Price:        20000,
+			Amount:       0.25,
+			Timestamp:    testTime,
+		},
+		&stream.KlineData{
+			Exchange:   "binance",
+			Pair:       testPair,
+			AssetType:  asset.Spot,
+			Interval:   "1m",
+			Timestamp:  testTime,
+			StartTime:  testTime.Truncate(time.Minute),
+			CloseTime:  testTime.Truncate(time.Minute).Add(time.Minute),
+			OpenPrice:  1,
+			ClosePrice: 2,
+			HighPrice:  3,
+			LowPrice:   0.5,
+			Volume:     10,
+		},
+	}
+}
+
+// frameBody strips the length and checksum of a single framed record
+func frameBody(t *testing.T, frame []byte) []byte {
+	t.Helper()
+	length, n := binary.Uvarint(frame)
+	if n <= 0 || len(frame) != n+int(length)+4 {
+		t.Fatalf("received: '%v' but expected: '%v'", len(frame), n+int(length)+4)
+	}
+	return frame[n : n+int(length)]
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, event := range testEvents() {
+		kind, err := KindOf(event)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		frame, err := appendRecord(nil, testTime, event)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		rec, err := decodeRecord(frameBody(t, frame))
+		if !errors.Is(err, nil) {
+			t.Fatalf("%v received: '%v' but expected: '%v'", kind, err, nil)
+		}
+		if rec.Kind != kind {
+			t.Errorf("received: '%v' but expected: '%v'", rec.Kind, kind)
+		}
+		if !rec.Time.Equal(testTime) {
+			t.Errorf("received: '%v' but expected: '%v'", rec.Time, testTime)
+		}
+		if !reflect.DeepEqual(rec.Data, event) {
+			t.Errorf("%v received: '%+v' but expected: '%+v'", kind, rec.Data, event)
+		}
+	}
+
+	_, err := appendRecord(nil, testTime, "bad")
+	if !errors.Is(err, errUnsupportedEventType) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnsupportedEventType)
+	}
+}
+
+func TestDecodeRecordCorrupt(t *testing.T) {
+	t.Parallel()
+	frame, err := appendRecord(nil, testTime, testEvents()[2])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	body := frameBody(t, frame)
+	_, err = decodeRecord(body[:len(body)-3])
+	if !errors.Is(err, ErrCorruptRecord) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrCorruptRecord)
+	}
+	_, err = decodeRecord(append(binary.AppendVarint(nil, 1), 255))
+	if !errors.Is(err, ErrCorruptRecord) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrCorruptRecord)
+	}
+	_, err = decodeRecord(nil)
+	if !errors.Is(err, ErrCorruptRecord) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrCorruptRecord)
+	}
+}
+
+func TestKindFromString(t *testing.T) {
+	t.Parallel()
+	for k := KindTicker; k <= KindKline; k++ {
+		received, err := KindFromString(k.String())
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if received != k {
+			t.Errorf("received: '%v' but expected: '%v'", received, k)
+		}
+	}
+	_, err := KindFromString("candle")
+	if !errors.Is(err, errUnknownKind) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnknownKind)
+	}
+}
@@ -0,0 +1,109 @@
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"sync"
+	"time"
+)
+
+// Kind identifies the type of event held by a record
+type Kind uint8
+
+// Journaled event kinds. Values are stored on disk so must never be changed
+// or reused
+const (
+	KindTicker Kind = iota + 1
+	KindOrderbook
+	KindTrade
+	KindOrder
+	KindFill
+	KindKline
+)
+
+const (
+	// segmentExtension is the file extension of journal segments
+	segmentExtension = ".gctj"
+	// formatVersion is written to every segment header and bumped whenever
+	// the record encoding changes
+	formatVersion byte = 1
+	// maxRecordSize protects readers from allocating for a corrupt length
+	maxRecordSize = 64 << 20
+
+	// DefaultMaxSegmentSize is the size a segment can reach before a new one
+	// is started
+	DefaultMaxSegmentSize = 64 << 20
+	// DefaultMaxSegmentAge is how long a segment is written to before a new
+	// one is started
+	DefaultMaxSegmentAge = time.Hour
+)
+
+var (
+	segmentMagic = []byte("GCTJ")
+
+	errNoDirectory          = errors.New("journal directory not set")
+	errWriterClosed         = errors.New("journal writer is closed")
+	errUnsupportedEventType = errors.New("unsupported journal event type")
+	errUnknownKind          = errors.New("unknown journal record kind")
+	errInvalidSegment       = errors.New("invalid journal segment")
+	errUnsupportedVersion   = errors.New("unsupported journal segment version")
+	errInvalidSpeed         = errors.New("replay speed cannot be negative")
+	errShortRecord          = errors.New("record shorter than its fields")
+
+	// ErrCorruptRecord is returned when a record fails its checksum or
+	// cannot be decoded
+	ErrCorruptRecord = errors.New("corrupt journal record")
+)
+
+// Record is a single journaled event. Data is one of *ticker.Price,
+// *orderbook.Base, *trade.Data, *order.Detail, *fill.Data or
+// *stream.KlineData depending on Kind
+type Record struct {
+	Time time.Time
+	Kind Kind
+	Data interface{}
+}
+
+// WriterConfig defines where a journal is written and how segments are
+// rotated and retained. A zero retention value disables that limit
+type WriterConfig struct {
+	Directory      string
+	MaxSegmentSize int64
+	MaxSegmentAge  time.Duration
+	MaxAge         time.Duration
+	MaxTotalSize   int64
+}
+
+// Writer appends records to segment files, starting a new segment once the
+// current one exceeds its size or age and pruning old segments outside of the
+// retention limits
+type Writer struct {
+	cfg           WriterConfig
+	m             sync.Mutex
+	file          *os.File
+	buf           *bufio.Writer
+	segmentOpened time.Time
+	segmentSize   int64
+	scratch       []byte
+	closed        bool
+}
+
+// Reader iterates records of a journal directory in the order they were
+// written, filtered by time and kind
+type Reader struct {
+	segments []segment
+	index    int
+	file     *os.File
+	buf      *bufio.Reader
+	start    time.Time
+	end      time.Time
+	kinds    map[Kind]bool
+}
+
+// segment is a journal file and the time of its first record
+type segment struct {
+	path  string
+	start time.Time
+	size  int64
+}
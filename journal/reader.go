@@ -0,0 +1,170 @@
+package journal
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+// NewReader returns a reader of the records in a journal directory written
+// between start and end inclusive. A zero start or end leaves that side of
+// the range open and when no kinds are supplied every kind is read
+func NewReader(directory string, start, end time.Time, kinds ...Kind) (*Reader, error) {
+	if directory == "" {
+		return nil, errNoDirectory
+	}
+	if !start.IsZero() && !end.IsZero() && start.After(end) {
+		return nil, common.ErrStartAfterEnd
+	}
+	segments, err := listSegments(directory)
+	if err != nil {
+		return nil, err
+	}
+	r := &Reader{start: start, end: end}
+	for i := range segments {
+		if !end.IsZero() && segments[i].start.After(end) {
+			break
+		}
+		// a segment holds records up until the first of the next segment
+		if !start.IsZero() && i+1 < len(segments) && !segments[i+1].start.After(start) {
+			continue
+		}
+		r.segments = append(r.segments, segments[i])
+	}
+	if len(kinds) > 0 {
+		r.kinds = make(map[Kind]bool, len(kinds))
+		for i := range kinds {
+			r.kinds[kinds[i]] = true
+		}
+	}
+	return r, nil
+}
+
+// Next returns the next record, io.EOF is returned once every segment has
+// been read. A segment which ends part way through a record, such as when the
+// process exited while writing, is treated as ending at its last full record.
+// When a record fails its checksum or cannot be decoded ErrCorruptRecord is returned, the rest of
+// that segment is skipped and Next can be called again to continue with the
+// following segment
+func (r *Reader) Next() (*Record, error) {
+	if r == nil {
+		return nil, fmt.Errorf("journal reader %w", common.ErrNilPointer)
+	}
+	for {
+		if r.buf == nil {
+			if r.index >= len(r.segments) {
+				return nil, io.EOF
+			}
+			r.index++
+			if err := r.openSegment(r.segments[r.index-1].path); err != nil {
+				return nil, err
+			}
+			if r.buf == nil {
+				continue
+			}
+		}
+		rec, err := r.readRecord()
+		if errors.Is(err, io.EOF) {
+			if err = r.closeSegment(); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err != nil {
+			path := r.file.Name()
+			if closeErr := r.closeSegment(); closeErr != nil {
+				err = common.AppendError(err, closeErr)
+			}
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if r.kinds != nil && !r.kinds[rec.Kind] {
+			continue
+		}
+		if !r.start.IsZero() && rec.Time.Before(r.start) {
+			continue
+		}
+		if !r.end.IsZero() && rec.Time.After(r.end) {
+			continue
+		}
+		return rec, nil
+	}
+}
+
+// Close closes the segment being read
+func (r *Reader) Close() error {
+	if r == nil {
+		return fmt.Errorf("journal reader %w", common.ErrNilPointer)
+	}
+	r.index = len(r.segments)
+	return r.closeSegment()
+}
+
+// openSegment opens a segment and verifies its header
+func (r *Reader) openSegment(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	r.file = f
+	r.buf = bufio.NewReaderSize(f, 64<<10)
+	header := make([]byte, len(segmentMagic)+1)
+	if _, err = io.ReadFull(r.buf, header); err != nil {
+		if errors.Is(err, io.EOF) {
+			// opened but nothing was written before exiting
+			return r.closeSegment()
+		}
+		return common.AppendError(fmt.Errorf("%w %s: %v", errInvalidSegment, path, err), r.closeSegment())
+	}
+	if !bytes.Equal(header[:len(segmentMagic)], segmentMagic) {
+		return common.AppendError(fmt.Errorf("%w %s", errInvalidSegment, path), r.closeSegment())
+	}
+	if header[len(segmentMagic)] != formatVersion {
+		return common.AppendError(fmt.Errorf("%w %s version %d", errUnsupportedVersion, path, header[len(segmentMagic)]), r.closeSegment())
+	}
+	return nil
+}
+
+// readRecord reads and verifies the next framed record of the segment. A
+// partially written record is reported as io.EOF
+func (r *Reader) readRecord() (*Record, error) {
+	length, err := binary.ReadUvarint(r.buf)
+	if err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	if length > maxRecordSize {
+		return nil, fmt.Errorf("%w length %d", ErrCorruptRecord, length)
+	}
+	frame := make([]byte, length+4)
+	if _, err = io.ReadFull(r.buf, frame); err != nil {
+		if errors.Is(err, io.ErrUnexpectedEOF) {
+			return nil, io.EOF
+		}
+		return nil, err
+	}
+	body := frame[:length]
+	if crc32.ChecksumIEEE(body) != binary.LittleEndian.Uint32(frame[length:]) {
+		return nil, fmt.Errorf("%w checksum mismatch", ErrCorruptRecord)
+	}
+	return decodeRecord(body)
+}
+
+// closeSegment closes the segment being read
+func (r *Reader) closeSegment() error {
+	if r.file == nil {
+		return nil
+	}
+	err := r.file.Close()
+	r.file, r.buf = nil, nil
+	return err
+}
@@ -0,0 +1,189 @@
+package journal
+
+import (
+	"errors"
+	"io"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+)
+
+// writeJournal writes every test event a second apart from testTime
+func writeJournal(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	w, err := NewWriter(WriterConfig{Directory: dir})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	events := testEvents()
+	for i := range events {
+		err = w.Write(testTime.Add(time.Duration(i)*time.Second), events[i])
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	return dir
+}
+
+func readAll(t *testing.T, r *Reader) []*Record {
+	t.Helper()
+	var records []*Record
+	for {
+		rec, err := r.Next()
+		if errors.Is(err, io.EOF) {
+			return records
+		}
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		records = append(records, rec)
+	}
+}
+
+func TestNewReader(t *testing.T) {
+	t.Parallel()
+	_, err := NewReader("", time.Time{}, time.Time{})
+	if !errors.Is(err, errNoDirectory) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoDirectory)
+	}
+	_, err = NewReader(t.TempDir(), testTime.Add(time.Second), testTime)
+	if !errors.Is(err, common.ErrStartAfterEnd) {
+		t.Errorf("received: '%v' but expected: '%v'", err, common.ErrStartAfterEnd)
+	}
+	_, err = NewReader(t.TempDir()+"/missing", time.Time{}, time.Time{})
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("received: '%v' but expected: '%v'", err, os.ErrNotExist)
+	}
+	var r *Reader
+	_, err = r.Next()
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+}
+
+func TestReaderFilters(t *testing.T) {
+	t.Parallel()
+	dir := writeJournal(t)
+	r, err := NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if records := readAll(t, r); len(records) != len(testEvents()) {
+		t.Errorf("received: '%v' but expected: '%v'", len(records), len(testEvents()))
+	}
+
+	r, err = NewReader(dir, testTime.Add(time.Second), testTime.Add(time.Second*3))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	records := readAll(t, r)
+	if len(records) != 3 || records[0].Kind != KindOrderbook || records[2].Kind != KindOrder {
+		t.Errorf("received: '%v' but expected: '%v'", len(records), 3)
+	}
+
+	r, err = NewReader(dir, time.Time{}, time.Time{}, KindTrade, KindFill)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	records = readAll(t, r)
+	if len(records) != 2 || records[0].Kind != KindTrade || records[1].Kind != KindFill {
+		t.Errorf("received: '%v' but expected: '%v'", len(records), 2)
+	}
+	err = r.Close()
+	if !errors.Is(err, nil) {
+		t.Errorf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestReaderTruncatedAndCorrupt(t *testing.T) {
+	t.Parallel()
+	dir := writeJournal(t)
+	segments, err := listSegments(dir)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	data, err := os.ReadFile(segments[0].path)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+
+	// a record cut short while being written ends the segment
+	err = os.WriteFile(segments[0].path, data[:len(data)-5], 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r, err := NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if records := readAll(t, r); len(records) != len(testEvents())-1 {
+		t.Errorf("received: '%v' but expected: '%v'", len(records), len(testEvents())-1)
+	}
+
+	// a changed byte fails the checksum of the first record
+	corrupt := append([]byte(nil), data...)
+	corrupt[len(segmentMagic)+4] ^= 0xff
+	err = os.WriteFile(segments[0].path, corrupt, 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r, err = NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = r.Next()
+	if !errors.Is(err, ErrCorruptRecord) {
+		t.Errorf("received: '%v' but expected: '%v'", err, ErrCorruptRecord)
+	}
+	_, err = r.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("received: '%v' but expected: '%v'", err, io.EOF)
+	}
+
+	err = os.WriteFile(segments[0].path, []byte("NOPE1"), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r, err = NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = r.Next()
+	if !errors.Is(err, errInvalidSegment) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidSegment)
+	}
+
+	err = os.WriteFile(segments[0].path, append(append([]byte(nil), segmentMagic...), formatVersion+1), 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r, err = NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = r.Next()
+	if !errors.Is(err, errUnsupportedVersion) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnsupportedVersion)
+	}
+
+	// an empty segment left by an exit before the header was flushed is skipped
+	err = os.WriteFile(segments[0].path, nil, 0o600)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	r, err = NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = r.Next()
+	if !errors.Is(err, io.EOF) {
+		t.Errorf("received: '%v' but expected: '%v'", err, io.EOF)
+	}
+}
@@ -0,0 +1,117 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/gofrs/uuid"
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+var (
+	replayMux = dispatch.GetNewMux(nil)
+	replayID  uuid.UUID
+	replayMtx sync.Mutex
+)
+
+// SubscribeToReplay returns a pipe which receives every *Record fed back by
+// Replay
+func SubscribeToReplay() (dispatch.Pipe, error) {
+	id, err := getReplayID()
+	if err != nil {
+		return dispatch.Pipe{}, err
+	}
+	return replayMux.Subscribe(id)
+}
+
+// Replay reads every record from the reader and feeds it back through
+// dispatch. Tickers and orderbooks are processed by the ticker and orderbook
+// packages so their existing subscribers receive them as they did when they
+// were recorded, and every record is published to SubscribeToReplay
+// subscribers. A speed of 1 keeps the original spacing between records, 10
+// replays ten times faster and 0 replays as fast as possible. The number of
+// records replayed is returned
+func Replay(ctx context.Context, r *Reader, speed float64) (int, error) {
+	if r == nil {
+		return 0, fmt.Errorf("journal reader %w", common.ErrNilPointer)
+	}
+	if speed < 0 {
+		return 0, errInvalidSpeed
+	}
+	id, err := getReplayID()
+	if err != nil {
+		return 0, err
+	}
+	var first time.Time
+	var started time.Time
+	var replayed int
+	var rec *Record
+	for {
+		rec, err = r.Next()
+		if errors.Is(err, io.EOF) {
+			return replayed, nil
+		}
+		if err != nil {
+			return replayed, err
+		}
+		if speed > 0 {
+			if first.IsZero() {
+				first, started = rec.Time, time.Now()
+			}
+			wait := time.Duration(float64(rec.Time.Sub(first))/speed) - time.Since(started)
+			if wait > 0 {
+				timer := time.NewTimer(wait)
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return replayed, ctx.Err()
+				case <-timer.C:
+				}
+			}
+		}
+		if err = ctx.Err(); err != nil {
+			return replayed, err
+		}
+		if err = process(rec); err != nil {
+			return replayed, fmt.Errorf("replaying %v recorded at %v: %w", rec.Kind, rec.Time, err)
+		}
+		if err = replayMux.Publish(rec, id); err != nil {
+			return replayed, err
+		}
+		replayed++
+	}
+}
+
+// process loads tickers and orderbooks into their services, which publishes
+// them to existing dispatch subscribers
+func process(rec *Record) error {
+	switch d := rec.Data.(type) {
+	case *ticker.Price:
+		return ticker.ProcessTicker(d)
+	case *orderbook.Base:
+		return d.Process()
+	}
+	return nil
+}
+
+// getReplayID returns the dispatch ID replayed records are published on
+func getReplayID() (uuid.UUID, error) {
+	replayMtx.Lock()
+	defer replayMtx.Unlock()
+	if !replayID.IsNil() {
+		return replayID, nil
+	}
+	id, err := replayMux.GetID()
+	if err != nil {
+		return uuid.Nil, err
+	}
+	replayID = id
+	return replayID, nil
+}
@@ -0,0 +1,119 @@
+package journal
+
+import (
+	"context"
+	"errors"
+	"log"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/dispatch"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/asset"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/orderbook"
+	"github.com/thrasher-corp/gocryptotrader/exchanges/ticker"
+)
+
+func TestMain(m *testing.M) {
+	if err := dispatch.Start(1, dispatch.DefaultJobsLimit); err != nil {
+		log.Fatal(err)
+	}
+	os.Exit(m.Run())
+}
+
+func TestReplay(t *testing.T) {
+	t.Parallel()
+	_, err := Replay(context.Background(), nil, 0)
+	if !errors.Is(err, common.ErrNilPointer) {
+		t.Errorf("received: '%v' but expected: '%v'", err, common.ErrNilPointer)
+	}
+	dir := writeJournal(t)
+	r, err := NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = Replay(context.Background(), r, -1)
+	if !errors.Is(err, errInvalidSpeed) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errInvalidSpeed)
+	}
+
+	pipe, err := SubscribeToReplay()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	defer func() {
+		if err = pipe.Release(); err != nil {
+			t.Error(err)
+		}
+	}()
+	// dispatch drops records when a subscriber is not waiting so ensure the
+	// receiver is running before replaying
+	ready := make(chan struct{})
+	received := make(chan int)
+	go func() {
+		var count int
+		close(ready)
+		for range pipe.Channel() {
+			count++
+			if count == len(testEvents()) {
+				break
+			}
+		}
+		received <- count
+	}()
+	<-ready
+
+	start := time.Now()
+	replayed, err := Replay(context.Background(), r, 50)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if replayed != len(testEvents()) {
+		t.Errorf("received: '%v' but expected: '%v'", replayed, len(testEvents()))
+	}
+	// five seconds of records replayed fifty times faster
+	if elapsed := time.Since(start); elapsed < time.Millisecond*100 {
+		t.Errorf("received: '%v' but expected: '%v'", elapsed, "at least 100ms")
+	}
+	select {
+	case count := <-received:
+		if count != len(testEvents()) {
+			t.Errorf("received: '%v' but expected: '%v'", count, len(testEvents()))
+		}
+	case <-time.After(time.Second * 5):
+		t.Error("replayed records not published")
+	}
+
+	tick, err := ticker.GetTicker("binance", testPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if tick.Last != 20000.5 {
+		t.Errorf("received: '%v' but expected: '%v'", tick.Last, 20000.5)
+	}
+	book, err := orderbook.Get("binance", testPair, asset.Spot)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(book.Bids) != 2 || len(book.Asks) != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", len(book.Bids), 2)
+	}
+}
+
+func TestReplayCancelled(t *testing.T) {
+	t.Parallel()
+	r, err := NewReader(writeJournal(t), time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	replayed, err := Replay(ctx, r, 1)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("received: '%v' but expected: '%v'", err, context.DeadlineExceeded)
+	}
+	if replayed != 1 {
+		t.Errorf("received: '%v' but expected: '%v'", replayed, 1)
+	}
+}
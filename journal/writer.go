@@ -0,0 +1,214 @@
+package journal
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/thrasher-corp/gocryptotrader/common"
+	"github.com/thrasher-corp/gocryptotrader/common/file"
+)
+
+// NewWriter returns a writer for a journal directory, creating it if needed.
+// Existing segments are never appended to, the first record written starts a
+// new segment
+func NewWriter(cfg WriterConfig) (*Writer, error) {
+	if cfg.Directory == "" {
+		return nil, errNoDirectory
+	}
+	if cfg.MaxSegmentSize <= 0 {
+		cfg.MaxSegmentSize = DefaultMaxSegmentSize
+	}
+	if cfg.MaxSegmentAge <= 0 {
+		cfg.MaxSegmentAge = DefaultMaxSegmentAge
+	}
+	if err := os.MkdirAll(cfg.Directory, file.DefaultPermissionOctal); err != nil {
+		return nil, err
+	}
+	return &Writer{cfg: cfg}, nil
+}
+
+// Write appends an event with the time it occurred. Records are expected to be
+// written in time order as segments are named by the time of their first
+// record and readers skip segments by name. Records are buffered until Flush
+// or Close is called or the buffer fills
+func (w *Writer) Write(t time.Time, data interface{}) error {
+	if w == nil {
+		return fmt.Errorf("journal writer %w", common.ErrNilPointer)
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.closed {
+		return errWriterClosed
+	}
+	var err error
+	w.scratch, err = appendRecord(w.scratch[:0], t, data)
+	if err != nil {
+		return err
+	}
+	if w.file == nil ||
+		w.segmentSize >= w.cfg.MaxSegmentSize ||
+		time.Since(w.segmentOpened) >= w.cfg.MaxSegmentAge {
+		if err = w.rotate(t); err != nil {
+			return err
+		}
+	}
+	n, err := w.buf.Write(w.scratch)
+	w.segmentSize += int64(n)
+	return err
+}
+
+// Flush writes buffered records to the current segment
+func (w *Writer) Flush() error {
+	if w == nil {
+		return fmt.Errorf("journal writer %w", common.ErrNilPointer)
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.buf == nil {
+		return nil
+	}
+	return w.buf.Flush()
+}
+
+// Close flushes and closes the current segment, the writer cannot be used
+// afterwards
+func (w *Writer) Close() error {
+	if w == nil {
+		return fmt.Errorf("journal writer %w", common.ErrNilPointer)
+	}
+	w.m.Lock()
+	defer w.m.Unlock()
+	if w.closed {
+		return errWriterClosed
+	}
+	w.closed = true
+	return w.closeSegment()
+}
+
+// rotate closes the current segment, starts a new one named by the time of
+// its first record and removes segments outside of the retention limits
+func (w *Writer) rotate(first time.Time) error {
+	if err := w.closeSegment(); err != nil {
+		return err
+	}
+	var f *os.File
+	var err error
+	// segments opened within the same nanosecond are offset so names are
+	// unique and still sort in the order they were written
+	for i := 0; i < 10; i++ {
+		f, err = os.OpenFile(segmentPath(w.cfg.Directory, first), os.O_WRONLY|os.O_CREATE|os.O_EXCL, file.DefaultPermissionOctal)
+		if !errors.Is(err, os.ErrExist) {
+			break
+		}
+		first = first.Add(1)
+	}
+	if err != nil {
+		return err
+	}
+	w.file = f
+	w.buf = bufio.NewWriterSize(f, 64<<10)
+	if _, err = w.buf.Write(append(append([]byte(nil), segmentMagic...), formatVersion)); err != nil {
+		return err
+	}
+	w.segmentOpened = time.Now()
+	w.segmentSize = int64(len(segmentMagic) + 1)
+	return w.prune(w.segmentOpened)
+}
+
+// closeSegment flushes, syncs and closes the current segment
+func (w *Writer) closeSegment() error {
+	if w.file == nil {
+		return nil
+	}
+	var errs error
+	if err := w.buf.Flush(); err != nil {
+		errs = common.AppendError(errs, err)
+	}
+	if err := w.file.Sync(); err != nil {
+		errs = common.AppendError(errs, err)
+	}
+	if err := w.file.Close(); err != nil {
+		errs = common.AppendError(errs, err)
+	}
+	w.file, w.buf = nil, nil
+	return errs
+}
+
+// prune removes segments whose records are all older than MaxAge, then the
+// oldest segments until the journal is within MaxTotalSize. The current
+// segment is never removed
+func (w *Writer) prune(now time.Time) error {
+	if w.cfg.MaxAge <= 0 && w.cfg.MaxTotalSize <= 0 {
+		return nil
+	}
+	segments, err := listSegments(w.cfg.Directory)
+	if err != nil {
+		return err
+	}
+	var total int64
+	for i := range segments {
+		total += segments[i].size
+	}
+	var errs error
+	// the last segment is the one just opened
+	for i := 0; i < len(segments)-1; i++ {
+		// a segment holds records up until the first of the next segment
+		expired := w.cfg.MaxAge > 0 && now.Sub(segments[i+1].start) > w.cfg.MaxAge
+		oversized := w.cfg.MaxTotalSize > 0 && total > w.cfg.MaxTotalSize
+		if !expired && !oversized {
+			break
+		}
+		if err = os.Remove(segments[i].path); err != nil {
+			errs = common.AppendError(errs, err)
+			continue
+		}
+		total -= segments[i].size
+	}
+	return errs
+}
+
+// segmentPath returns the path of a segment starting at t. Names are zero
+// padded so they sort lexically in time order
+func segmentPath(directory string, t time.Time) string {
+	return filepath.Join(directory, fmt.Sprintf("%020d%s", t.UnixNano(), segmentExtension))
+}
+
+// listSegments returns the segments of a journal directory oldest first
+func listSegments(directory string) ([]segment, error) {
+	entries, err := os.ReadDir(directory)
+	if err != nil {
+		return nil, err
+	}
+	segments := make([]segment, 0, len(entries))
+	for i := range entries {
+		name := entries[i].Name()
+		if entries[i].IsDir() || !strings.HasSuffix(name, segmentExtension) {
+			continue
+		}
+		nanos, parseErr := strconv.ParseInt(strings.TrimSuffix(name, segmentExtension), 10, 64)
+		if parseErr != nil {
+			continue
+		}
+		info, infoErr := entries[i].Info()
+		if infoErr != nil {
+			// removed since the directory was read
+			continue
+		}
+		segments = append(segments, segment{
+			path:  filepath.Join(directory, name),
+			start: time.Unix(0, nanos),
+			size:  info.Size(),
+		})
+	}
+	sort.Slice(segments, func(i, j int) bool {
+		return segments[i].start.Before(segments[j].start)
+	})
+	return segments, nil
+}
@@ -0,0 +1,144 @@
+package journal
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+)
+
+func TestNewWriter(t *testing.T) {
+	t.Parallel()
+	_, err := NewWriter(WriterConfig{})
+	if !errors.Is(err, errNoDirectory) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNoDirectory)
+	}
+	w, err := NewWriter(WriterConfig{Directory: t.TempDir()})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if w.cfg.MaxSegmentSize != DefaultMaxSegmentSize || w.cfg.MaxSegmentAge != DefaultMaxSegmentAge {
+		t.Errorf("received: '%v' but expected: '%v'", w.cfg, "default rotation")
+	}
+	// nothing is created until the first record is written
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	segments, err := listSegments(w.cfg.Directory)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if len(segments) != 0 {
+		t.Errorf("received: '%v' but expected: '%v'", len(segments), 0)
+	}
+	err = w.Write(testTime, testEvents()[0])
+	if !errors.Is(err, errWriterClosed) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errWriterClosed)
+	}
+	err = w.Close()
+	if !errors.Is(err, errWriterClosed) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errWriterClosed)
+	}
+}
+
+func TestWriterRotation(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	w, err := NewWriter(WriterConfig{Directory: dir, MaxSegmentSize: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	events := testEvents()
+	for i := range events {
+		err = w.Write(testTime.Add(time.Duration(i)), events[i])
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	err = w.Write(testTime, "bad")
+	if !errors.Is(err, errUnsupportedEventType) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errUnsupportedEventType)
+	}
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	segments, err := listSegments(dir)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// every record exceeds the segment size so each has its own segment
+	if len(segments) != len(events) {
+		t.Fatalf("received: '%v' but expected: '%v'", len(segments), len(events))
+	}
+
+	r, err := NewReader(dir, time.Time{}, time.Time{})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	var rec *Record
+	for i := range events {
+		rec, err = r.Next()
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+		if !rec.Time.Equal(testTime.Add(time.Duration(i))) {
+			t.Errorf("received: '%v' but expected: '%v'", rec.Time, testTime.Add(time.Duration(i)))
+		}
+	}
+}
+
+func TestWriterRetention(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	old := time.Now().Add(-time.Hour * 48)
+	for _, start := range []time.Time{old, old.Add(time.Hour), time.Now().Add(-time.Minute)} {
+		err := os.WriteFile(segmentPath(dir, start), append(append([]byte(nil), segmentMagic...), formatVersion), 0o600)
+		if !errors.Is(err, nil) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+		}
+	}
+	w, err := NewWriter(WriterConfig{Directory: dir, MaxAge: time.Hour * 24})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.Write(time.Now(), testEvents()[2])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	segments, err := listSegments(dir)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// the first segment ended when the second was opened over a day ago, the
+	// second ended a minute ago so it is retained
+	if len(segments) != 3 || !segments[0].start.Equal(time.Unix(0, old.Add(time.Hour).UnixNano())) {
+		t.Fatalf("received: '%v' but expected: '%v'", segments, "3 segments")
+	}
+
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	w, err = NewWriter(WriterConfig{Directory: dir, MaxTotalSize: 1})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = w.Write(time.Now(), testEvents()[2])
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	segments, err = listSegments(dir)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	// only the segment being written to is kept
+	if len(segments) != 1 || segments[0].path != w.file.Name() {
+		t.Errorf("received: '%v' but expected: '%v'", segments, w.file.Name())
+	}
+	err = w.Close()
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
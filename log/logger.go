@@ -3,6 +3,7 @@ package log
 import (
 	"errors"
 	"fmt"
+	"io"
 
 	"github.com/thrasher-corp/gocryptotrader/common/convert"
 )
@@ -35,7 +36,21 @@ func CloseLogger() error {
 	globalLogConfig.Enabled = convert.BoolPtr(false)
 	jobsChannel <- &job{Passback: ch}
 	<-ch
-	return globalLogFile.Close()
+	closers := []io.Closer{globalLogFile}
+	for _, r := range subLoggerFiles {
+		closers = append(closers, r)
+	}
+	if syslogSink != nil {
+		closers = append(closers, syslogSink)
+	}
+	if shipperSink != nil {
+		closers = append(closers, shipperSink)
+	}
+	var errs error
+	for i := range closers {
+		errs = errors.Join(errs, closers[i].Close())
+	}
+	return errs
 }
 
 // Level retrieves the current sublogger levels
@@ -60,3 +75,89 @@ func SetLevel(s, level string) (Levels, error) {
 	subLogger.setLevels(splitLevel(level))
 	return subLogger.levels, nil
 }
+
+// SetFormat sets the output format of a sub logger to text, json or logfmt. An
+// empty format reverts to the global format.
+func SetFormat(name, format string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	subLogger, found := SubLoggers[name]
+	if !found {
+		return fmt.Errorf("sub logger %v not found", name)
+	}
+	enc, err := subLoggerEncoding(format)
+	if err != nil {
+		return err
+	}
+	subLogger.encoding = enc
+	subLogger.config.Format = format
+	return nil
+}
+
+// SetOutput sets the outputs of a sub logger from a pipe separated list e.g.
+// stdout|file|shipper
+func SetOutput(name, output string) error {
+	mu.Lock()
+	defer mu.Unlock()
+	subLogger, found := SubLoggers[name]
+	if !found {
+		return fmt.Errorf("sub logger %v not found", name)
+	}
+	cfg := subLogger.config
+	cfg.Output = output
+	writers, err := getWriters(&cfg)
+	if err != nil {
+		return err
+	}
+	err = subLogger.setOutput(writers)
+	if err != nil {
+		return err
+	}
+	subLogger.config.Output = output
+	return nil
+}
+
+// SetSampling sets the sampling of a sub logger. A nil config disables
+// sampling.
+func SetSampling(name string, cfg *SamplingConfig) error {
+	mu.Lock()
+	defer mu.Unlock()
+	subLogger, found := SubLoggers[name]
+	if !found {
+		return fmt.Errorf("sub logger %v not found", name)
+	}
+	smp, err := newSampler(cfg)
+	if err != nil {
+		return err
+	}
+	subLogger.sampler = smp
+	subLogger.config.Sampling = smp.config()
+	return nil
+}
+
+// Details retrieves the current sub logger settings
+func Details(name string) (SubLoggerDetails, error) {
+	mu.RLock()
+	defer mu.RUnlock()
+	subLogger, found := SubLoggers[name]
+	if !found {
+		return SubLoggerDetails{}, fmt.Errorf("logger %s not found", name)
+	}
+	return SubLoggerDetails{
+		Levels:   subLogger.levels,
+		Output:   subLogger.config.Output,
+		Format:   subLogger.encoding.String(),
+		Sampling: subLogger.sampler.config(),
+		Sampled:  subLogger.sampler.droppedEvents(),
+	}, nil
+}
+
+// subLoggerEncoding returns the encoding of a sub logger format, using the
+// global encoding when the format is empty.
+// Note: Calling function must have mutex lock in place.
+func subLoggerEncoding(format string) (encoding, error) {
+	if format == "" {
+		return logger.encoding, nil
+	}
+	return parseFormat(format, false)
+}
@@ -0,0 +1,197 @@
+package log
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+var errUnhandledFormat = errors.New("unhandled log format")
+
+// encoding defines how a log event is written
+type encoding uint8
+
+const (
+	textEncoding encoding = iota
+	jsonEncoding
+	logfmtEncoding
+	encodingCount
+)
+
+// encodingOverrider is implemented by writers which require a specific
+// encoding regardless of the sub logger format
+type encodingOverrider interface {
+	encoding() encoding
+}
+
+// parseFormat returns the encoding for a format name. An empty format returns
+// the text encoding, or the JSON encoding when structured logging is enabled.
+func parseFormat(format string, structuredLogging bool) (encoding, error) {
+	switch strings.ToLower(format) {
+	case "":
+		if structuredLogging {
+			return jsonEncoding, nil
+		}
+		return textEncoding, nil
+	case FormatText:
+		return textEncoding, nil
+	case FormatJSON:
+		return jsonEncoding, nil
+	case FormatLogfmt:
+		return logfmtEncoding, nil
+	default:
+		return textEncoding, fmt.Errorf("%w: %s", errUnhandledFormat, format)
+	}
+}
+
+// String returns the format name of the encoding
+func (e encoding) String() string {
+	switch e {
+	case jsonEncoding:
+		return FormatJSON
+	case logfmtEncoding:
+		return FormatLogfmt
+	default:
+		return FormatText
+	}
+}
+
+// eventEncoder encodes log events for the logger worker. It is not safe for
+// concurrent use.
+type eventEncoder struct {
+	structured ExtraFields
+	keys       []string
+}
+
+// encode appends the encoded event to dst
+func (e *eventEncoder) encode(dst []byte, j *job, enc encoding, msg string, now time.Time) []byte {
+	switch enc {
+	case jsonEncoding:
+		return e.encodeJSON(dst, j, msg, now)
+	case logfmtEncoding:
+		return e.encodeLogfmt(dst, j, msg, now)
+	default:
+		dst = append(dst, j.Header...)
+		if j.ShowLogSystemName {
+			dst = append(dst, j.Spacer...)
+			dst = append(dst, j.SubLoggerName...)
+		}
+		dst = append(dst, j.Spacer...)
+		if j.TimestampFormat != "" {
+			dst = now.AppendFormat(dst, j.TimestampFormat)
+		}
+		dst = append(dst, j.Spacer...)
+		dst = append(dst, msg...)
+		if msg == "" || msg[len(msg)-1] != '\n' {
+			dst = append(dst, '\n')
+		}
+		return dst
+	}
+}
+
+func (e *eventEncoder) encodeJSON(dst []byte, j *job, msg string, now time.Time) []byte {
+	if e.structured == nil {
+		e.structured = ExtraFields{}
+	}
+	e.structured[message] = strings.TrimSuffix(msg, "\n")
+	e.structured[timestamp] = now.UnixMilli()
+	e.structured[severity] = j.Severity
+	e.structured[subLoggerName] = j.SubLoggerName
+	e.structured[botName] = j.Instance
+	for k, v := range j.StructuredFields {
+		if isDefaultKey(k) {
+			// Disallow overwriting of key values
+			displayError(fmt.Errorf("structured logging: cannot overwrite key [%s]", k))
+			continue
+		}
+		e.structured[k] = v
+	}
+	data, err := json.Marshal(e.structured)
+	for k := range j.StructuredFields {
+		if !isDefaultKey(k) {
+			// Delete non-persistent structured fields
+			delete(e.structured, k)
+		}
+	}
+	if err != nil {
+		displayError(fmt.Errorf("failed to marshal structured log data: %w", err))
+		return dst
+	}
+	dst = append(dst, data...)
+	return append(dst, '\n')
+}
+
+func (e *eventEncoder) encodeLogfmt(dst []byte, j *job, msg string, now time.Time) []byte {
+	dst = append(dst, timestamp+"="...)
+	dst = now.UTC().AppendFormat(dst, time.RFC3339Nano)
+	dst = appendLogfmtPair(dst, severity, j.Severity)
+	dst = appendLogfmtPair(dst, subLoggerName, j.SubLoggerName)
+	if j.Instance != "" {
+		dst = appendLogfmtPair(dst, botName, j.Instance)
+	}
+	dst = appendLogfmtPair(dst, message, strings.TrimSuffix(msg, "\n"))
+
+	e.keys = e.keys[:0]
+	for k := range j.StructuredFields {
+		if isDefaultKey(k) {
+			displayError(fmt.Errorf("structured logging: cannot overwrite key [%s]", k))
+			continue
+		}
+		e.keys = append(e.keys, string(k))
+	}
+	sort.Strings(e.keys)
+	for i := range e.keys {
+		dst = appendLogfmtPair(dst, Key(e.keys[i]), j.StructuredFields[Key(e.keys[i])])
+	}
+	return append(dst, '\n')
+}
+
+// isDefaultKey returns whether the key is written with every structured event
+func isDefaultKey(k Key) bool {
+	return k == message || k == timestamp || k == severity || k == subLoggerName || k == botName
+}
+
+// appendLogfmtPair appends a space separated key=value pair, quoting the value
+// when required
+func appendLogfmtPair(dst []byte, k Key, v interface{}) []byte {
+	dst = append(dst, ' ')
+	for _, r := range k {
+		if r <= ' ' || r == '=' || r == '"' || r == utf8.RuneError {
+			r = '_'
+		}
+		dst = utf8.AppendRune(dst, r)
+	}
+	dst = append(dst, '=')
+	var s string
+	switch val := v.(type) {
+	case string:
+		s = val
+	case error:
+		s = val.Error()
+	case fmt.Stringer:
+		s = val.String()
+	default:
+		s = fmt.Sprint(val)
+	}
+	if !logfmtNeedsQuoting(s) {
+		return append(dst, s...)
+	}
+	return strconv.AppendQuote(dst, s)
+}
+
+func logfmtNeedsQuoting(s string) bool {
+	if s == "" {
+		return true
+	}
+	for _, r := range s {
+		if r <= ' ' || r == '=' || r == '"' || r == '\\' || r == utf8.RuneError || !strconv.IsPrint(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -1,7 +1,6 @@
 package log
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -23,75 +22,56 @@ var (
 // loggerWorker handles all work staged to be written to configured io.Writer(s)
 // This worker is generated in init() to handle full workload.
 func loggerWorker() {
-	// Localise a persistent buffer for a worker, this does not need to be
-	// garbage collected.
-	buffer := make([]byte, 0, defaultBufferCapacity)
+	// Localise persistent buffers for a worker, these do not need to be
+	// garbage collected. Each encoding has its own buffer so that an event is
+	// only encoded once per encoding required by its writers.
+	var buffers [encodingCount][]byte
+	buffers[textEncoding] = make([]byte, 0, defaultBufferCapacity)
+	var encoded [encodingCount]bool
+	var enc eventEncoder
 	var n int
 	var err error
 
-	structuredOutbound := ExtraFields{}
 	for j := range jobsChannel {
 		if j.Passback != nil {
 			j.Passback <- struct{}{}
 			continue
 		}
 		msg := j.fn()
-		if j.StructuredLogging {
-			structuredOutbound[message] = msg
-			structuredOutbound[timestamp] = time.Now().UnixMilli()
-			structuredOutbound[severity] = j.Severity
-			structuredOutbound[subLoggerName] = j.SubLoggerName
-			structuredOutbound[botName] = j.Instance
-			for k, v := range j.StructuredFields {
-				_, ok := structuredOutbound[k]
-				if ok {
-					// Disallow overwriting of key values
-					displayError(fmt.Errorf("structured logging: cannot overwrite key [%s]", k))
-					continue
-				}
-				structuredOutbound[k] = v
-			}
-			buffer, err = json.Marshal(structuredOutbound)
-			if err != nil {
-				log.Println("log: failed to marshal structured log data:", err)
-			}
-			for k := range j.StructuredFields {
-				// Delete non-persistent structured fields
-				delete(structuredOutbound, k)
-			}
-			buffer = append(buffer, '\n')
-		} else {
-			buffer = append(buffer, j.Header...)
-			if j.ShowLogSystemName {
-				buffer = append(buffer, j.Spacer...)
-				buffer = append(buffer, []byte(j.SubLoggerName)...)
-			}
-			buffer = append(buffer, j.Spacer...)
-			if j.TimestampFormat != "" {
-				buffer = time.Now().AppendFormat(buffer, j.TimestampFormat)
+		now := time.Now()
+		encoded = [encodingCount]bool{}
+		for x := range j.Writers {
+			e := j.Encoding
+			if o, ok := j.Writers[x].(encodingOverrider); ok {
+				e = o.encoding()
 			}
-			buffer = append(buffer, j.Spacer...)
-			buffer = append(buffer, msg...)
-			if msg == "" || msg[len(msg)-1] != '\n' {
-				buffer = append(buffer, '\n')
+			if !encoded[e] {
+				buffers[e] = enc.encode(buffers[e][:0], j, e, msg, now)
+				encoded[e] = true
 			}
-		}
-
-		for x := range j.Writers {
 			// NOTE: byte slice is not copied, this is a pointer to the buffer.
 			// This is only safe if the buffer is not modified after this point.
-			n, err = j.Writers[x].Write(buffer)
+			if sw, ok := j.Writers[x].(severityWriter); ok {
+				n, err = sw.writeSeverity(j.Severity, buffers[e])
+			} else {
+				n, err = j.Writers[x].Write(buffers[e])
+			}
 			if err != nil {
 				displayError(fmt.Errorf("%T %w", j.Writers[x], err))
-			} else if n != len(buffer) {
+			} else if n != len(buffers[e]) {
 				displayError(fmt.Errorf("%T %w", j.Writers[x], io.ErrShortWrite))
 			}
 		}
-		buffer = buffer[:0] // Clean buffer for next use
 		jobsPool.Put(j)
 	}
 }
 
+// severityWriter is implemented by writers which need the severity of each
+// event, such as syslog
+type severityWriter interface {
+	writeSeverity(severity string, p []byte) (int, error)
+}
+
 // deferral defines functionality that will capture data string processing and
 // defer that to the worker pool if needed.
 type deferral func() string
@@ -99,7 +79,7 @@ type deferral func() string
 // StageLogEvent stages a new logger event in a jobs channel to be processed by
 // a worker pool. This segregates the need to process the log string and the
 // writes to the required io.Writer.
-func (mw *multiWriterHolder) StageLogEvent(fn deferral, header, slName, spacer, timestampFormat, instance, level string, showLogSystemName, bypassWarning, structuredLog bool, fields map[Key]interface{}) {
+func (mw *multiWriterHolder) StageLogEvent(fn deferral, header, slName, spacer, timestampFormat, instance, level string, showLogSystemName, bypassWarning bool, enc encoding, fields map[Key]interface{}) {
 	newJob := jobsPool.Get().(*job) //nolint:forcetypeassert // Not necessary from a pool
 	newJob.Writers = mw.writers
 	newJob.fn = fn
@@ -110,7 +90,7 @@ func (mw *multiWriterHolder) StageLogEvent(fn deferral, header, slName, spacer,
 	newJob.TimestampFormat = timestampFormat
 	newJob.Instance = instance
 	newJob.StructuredFields = fields
-	newJob.StructuredLogging = structuredLog
+	newJob.Encoding = enc
 	newJob.Severity = level
 
 	select {
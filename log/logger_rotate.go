@@ -121,6 +121,18 @@ func (r *Rotate) rotateFile() (err error) {
 	return r.openNew()
 }
 
+// hasSettings returns whether the file writer is set up with the supplied file
+// logging settings
+func (r *Rotate) hasSettings(cfg *loggerFileConfig) bool {
+	if r == nil || cfg == nil || r.FileName == "" {
+		return false
+	}
+	return r.FileName == cfg.FileName &&
+		r.MaxSize == cfg.MaxSize &&
+		r.Rotate != nil && cfg.Rotate != nil &&
+		*r.Rotate == *cfg.Rotate
+}
+
 func (r *Rotate) maxSize() int64 {
 	if r.MaxSize == 0 {
 		return defaultMaxSize * megabyte
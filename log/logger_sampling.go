@@ -0,0 +1,84 @@
+package log
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var errSamplingIntervalInvalid = errors.New("sampling interval must be greater than zero")
+
+// sampler limits the events written by a sub logger. Events are counted per
+// severity and message format within each interval.
+type sampler struct {
+	initial    uint64
+	thereafter uint64
+	interval   time.Duration
+
+	mtx         sync.Mutex
+	windowStart time.Time
+	counts      map[samplerKey]uint64
+	dropped     uint64
+}
+
+type samplerKey struct {
+	severity string
+	format   string
+}
+
+// newSampler returns a sampler for the configuration, or nil when sampling is
+// disabled
+func newSampler(cfg *SamplingConfig) (*sampler, error) {
+	if cfg == nil {
+		return nil, nil
+	}
+	if cfg.Interval <= 0 {
+		return nil, errSamplingIntervalInvalid
+	}
+	return &sampler{
+		initial:    cfg.Initial,
+		thereafter: cfg.Thereafter,
+		interval:   cfg.Interval,
+		counts:     make(map[samplerKey]uint64),
+	}, nil
+}
+
+// allow returns whether an event should be written
+func (s *sampler) allow(sev, format string, now time.Time) bool {
+	if s == nil {
+		return true
+	}
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if now.Sub(s.windowStart) >= s.interval {
+		s.windowStart = now
+		for k := range s.counts {
+			delete(s.counts, k)
+		}
+	}
+	k := samplerKey{severity: sev, format: format}
+	s.counts[k]++
+	n := s.counts[k]
+	if n <= s.initial || (s.thereafter > 0 && (n-s.initial)%s.thereafter == 0) {
+		return true
+	}
+	atomic.AddUint64(&s.dropped, 1)
+	return false
+}
+
+// config returns the sampling configuration
+func (s *sampler) config() *SamplingConfig {
+	if s == nil {
+		return nil
+	}
+	return &SamplingConfig{Initial: s.initial, Thereafter: s.thereafter, Interval: s.interval}
+}
+
+// droppedEvents returns the number of events which have not been written
+func (s *sampler) droppedEvents() uint64 {
+	if s == nil {
+		return 0
+	}
+	return atomic.LoadUint64(&s.dropped)
+}
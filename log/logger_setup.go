@@ -16,6 +16,7 @@ var (
 	errLogPathIsEmpty                    = errors.New("log path is empty")
 	errConfigNil                         = errors.New("config is nil")
 	errFileLoggingNotConfiguredCorrectly = errors.New("file logging not configured correctly")
+	errOutputNotSetUp                    = errors.New("output has not been set up")
 )
 
 // getWriters returns a new multi writer holder from sub logger configuration.
@@ -35,10 +36,28 @@ func getWriters(s *SubLoggerConfig) (*multiWriterHolder, error) {
 		case "stderr":
 			writer = os.Stderr
 		case "file":
+			if s.FileSettings != nil {
+				var err error
+				writer, err = subLoggerFile(s.FileSettings)
+				if err != nil {
+					return nil, err
+				}
+				break
+			}
 			if !fileLoggingConfiguredCorrectly {
 				return nil, errFileLoggingNotConfiguredCorrectly
 			}
 			writer = globalLogFile
+		case "syslog":
+			if syslogSink == nil {
+				return nil, fmt.Errorf("%w: %s", errOutputNotSetUp, outputWriters[x])
+			}
+			writer = syslogSink
+		case "shipper":
+			if shipperSink == nil {
+				return nil, errShipperNotConfigured
+			}
+			writer = shipperSink
 		default:
 			// Note: Do not want to add an io.Discard here as this adds
 			// additional write calls for no reason.
@@ -49,6 +68,85 @@ func getWriters(s *SubLoggerConfig) (*multiWriterHolder, error) {
 	return multiWriter(writers...)
 }
 
+// subLoggerFile returns the file writer for a sub logger with its own file
+// settings, reusing the global or an existing writer for the same file.
+// Note: Calling function must have mutex lock in place.
+func subLoggerFile(cfg *loggerFileConfig) (*Rotate, error) {
+	if cfg.FileName == "" {
+		return nil, errFileNameIsEmpty
+	}
+	if fileLoggingConfiguredCorrectly && cfg.FileName == globalLogFile.FileName {
+		return globalLogFile, nil
+	}
+	settings := *cfg
+	if settings.Rotate == nil {
+		settings.Rotate = convert.BoolPtr(false)
+	}
+	if r, ok := subLoggerFiles[settings.FileName]; ok && r.hasSettings(&settings) {
+		return r, nil
+	}
+	r := &Rotate{
+		FileName: settings.FileName,
+		MaxSize:  settings.MaxSize,
+		Rotate:   settings.Rotate,
+	}
+	// A replaced writer is left to be closed by the garbage collector as
+	// other sub loggers may still be writing to it
+	subLoggerFiles[settings.FileName] = r
+	return r, nil
+}
+
+// setupSinks creates the syslog and shipper writers from the global config,
+// returning replaced writers to be closed with retireWriters.
+// Note: Calling function must have mutex lock in place.
+func setupSinks() ([]io.Closer, error) {
+	var stale []io.Closer
+	if !syslogSink.hasSettings(globalLogConfig.Syslog) {
+		s, err := newSyslogWriter(globalLogConfig.Syslog)
+		if err != nil {
+			return nil, err
+		}
+		if syslogSink != nil {
+			stale = append(stale, syslogSink)
+		}
+		syslogSink = s
+	}
+	if globalLogConfig.Shipper == nil {
+		if shipperSink != nil {
+			stale = append(stale, shipperSink)
+			shipperSink = nil
+		}
+		return stale, nil
+	}
+	if !shipperSink.hasSettings(globalLogConfig.Shipper) {
+		s, err := newShipperWriter(globalLogConfig.Shipper)
+		if err != nil {
+			return nil, err
+		}
+		if shipperSink != nil {
+			stale = append(stale, shipperSink)
+		}
+		shipperSink = s
+	}
+	return stale, nil
+}
+
+// retireWriters closes replaced writers once every event already staged to
+// them has been written. Calling function must not hold the mutex lock.
+func retireWriters(stale []io.Closer) {
+	if len(stale) == 0 {
+		return
+	}
+	ch := make(chan struct{})
+	jobsChannel <- &job{Passback: ch}
+	<-ch
+	for i := range stale {
+		if err := stale[i].Close(); err != nil {
+			displayError(err)
+		}
+	}
+}
+
 // GenDefaultSettings return struct with known sane/working logger settings
 func GenDefaultSettings() *Config {
 	return &Config{
@@ -92,6 +190,16 @@ func SetGlobalLogConfig(incoming *Config) error {
 	globalLogConfig.Enabled = convert.BoolPtr(incoming.Enabled != nil && *incoming.Enabled)
 	globalLogConfig.LoggerFileConfig = &fileConf
 	globalLogConfig.AdvancedSettings = incoming.AdvancedSettings
+	globalLogConfig.Syslog = nil
+	if incoming.Syslog != nil {
+		syslogConf := *incoming.Syslog
+		globalLogConfig.Syslog = &syslogConf
+	}
+	globalLogConfig.Shipper = nil
+	if incoming.Shipper != nil {
+		shipperConf := *incoming.Shipper
+		globalLogConfig.Shipper = &shipperConf
+	}
 	return nil
 }
 
@@ -135,14 +243,39 @@ func SetupSubLoggers(s []SubLoggerConfig) error {
 	mu.Lock()
 	defer mu.Unlock()
 	for x := range s {
+		enc, err := subLoggerEncoding(s[x].Format)
+		if err != nil {
+			return err
+		}
+		var smp *sampler
+		if s[x].Sampling != nil {
+			smp, err = newSampler(s[x].Sampling)
+			if err != nil {
+				return err
+			}
+		}
 		output, err := getWriters(&s[x])
 		if err != nil {
 			return err
 		}
-		err = configureSubLogger(strings.ToUpper(s[x].Name), s[x].Level, output)
+		name := strings.ToUpper(s[x].Name)
+		err = configureSubLogger(name, s[x].Level, output)
 		if err != nil {
 			return err
 		}
+		subLogger := SubLoggers[name]
+		subLogger.encoding = enc
+		if smp != nil {
+			subLogger.sampler = smp
+		}
+		subLogger.config.Name = name
+		subLogger.config.Level = s[x].Level
+		subLogger.config.Output = s[x].Output
+		subLogger.config.Format = s[x].Format
+		subLogger.config.FileSettings = s[x].FileSettings
+		if s[x].Sampling != nil {
+			subLogger.config.Sampling = s[x].Sampling
+		}
 	}
 	return nil
 }
@@ -150,10 +283,14 @@ func SetupSubLoggers(s []SubLoggerConfig) error {
 // SetupGlobalLogger setup the global loggers with the default global config
 // values.
 func SetupGlobalLogger(botName string, structuredOutput bool) error {
+	var stale []io.Closer
+	defer func() { retireWriters(stale) }()
 	mu.Lock()
 	defer mu.Unlock()
 
-	if fileLoggingConfiguredCorrectly {
+	// Reuse the current file writer when its settings are unchanged so that
+	// reapplying the config does not leave an open file behind
+	if fileLoggingConfiguredCorrectly && !globalLogFile.hasSettings(globalLogConfig.LoggerFileConfig) {
 		globalLogFile = &Rotate{
 			FileName: globalLogConfig.LoggerFileConfig.FileName,
 			MaxSize:  globalLogConfig.LoggerFileConfig.MaxSize,
@@ -161,21 +298,39 @@ func SetupGlobalLogger(botName string, structuredOutput bool) error {
 		}
 	}
 
+	enc, err := parseFormat(globalLogConfig.Format, structuredOutput)
+	if err != nil {
+		return err
+	}
+
+	stale, err = setupSinks()
+	if err != nil {
+		return err
+	}
+
 	writers, err := getWriters(&globalLogConfig.SubLoggerConfig)
 	if err != nil {
 		return err
 	}
 
-	for _, subLogger := range SubLoggers {
+	for name, subLogger := range SubLoggers {
 		subLogger.setLevels(splitLevel(globalLogConfig.Level))
-		subLogger.structuredLogging = structuredOutput
+		subLogger.encoding = enc
+		// Each sub logger samples its own events
+		subLogger.sampler, err = newSampler(globalLogConfig.Sampling)
+		if err != nil {
+			return err
+		}
 		err = subLogger.setOutput(writers)
 		if err != nil {
 			return err
 		}
 		subLogger.botName = botName
+		subLogger.config = globalLogConfig.SubLoggerConfig
+		subLogger.config.Name = name
 	}
 	logger = newLogger(globalLogConfig, botName)
+	logger.encoding = enc
 	return nil
 }
 
@@ -207,20 +362,22 @@ func splitLevel(level string) (l Levels) {
 // registerNewSubLogger registers a new sub logger. Note: Calling function must
 // have mutex lock in place.
 func registerNewSubLogger(subLogger string) *SubLogger {
-	tempHolder, err := getWriters(&SubLoggerConfig{
-		Name:   strings.ToUpper(subLogger),
-		Level:  "INFO|WARN|DEBUG|ERROR",
-		Output: "stdout"})
+	tempHolder, err := getWriters(&SubLoggerConfig{Output: "stdout"})
 	if err != nil {
 		return nil
 	}
 
 	temp := &SubLogger{
-		name:              strings.ToUpper(subLogger),
-		output:            tempHolder,
-		levels:            splitLevel("INFO|WARN|DEBUG|ERROR"),
-		botName:           logger.botName,
-		structuredLogging: globalLogConfig != nil && globalLogConfig.AdvancedSettings.StructuredLogging,
+		name:     strings.ToUpper(subLogger),
+		output:   tempHolder,
+		levels:   splitLevel("INFO|WARN|DEBUG|ERROR"),
+		botName:  logger.botName,
+		encoding: logger.encoding,
+		config: SubLoggerConfig{
+			Name:   strings.ToUpper(subLogger),
+			Level:  "INFO|WARN|DEBUG|ERROR",
+			Output: "stdout",
+		},
 	}
 	SubLoggers[subLogger] = temp
 	return temp
@@ -0,0 +1,160 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// shipperQueueSize is the number of events buffered for a shipper before new
+// events are dropped
+const shipperQueueSize = 1024
+
+var (
+	errUnsupportedShipperNetwork = errors.New("shipper network must be tcp or udp")
+	errShipperAddressEmpty       = errors.New("shipper address is empty")
+	errShipperNotConfigured      = errors.New("shipper output is not configured")
+	errShipperQueueFull          = errors.New("shipper queue is full, events dropped")
+)
+
+// shipperWriter ships log events as JSON lines to a TCP or UDP endpoint. TCP
+// events are newline delimited on a persistent connection and UDP events are
+// sent one per datagram. Events are shipped by their own routine so a slow or
+// unavailable endpoint does not stall the logger worker, events are dropped
+// when the queue is full.
+type shipperWriter struct {
+	settings shipperConfig
+
+	queue     chan []byte
+	shutdown  chan struct{}
+	closeOnce sync.Once
+	wg        sync.WaitGroup
+	dropped   uint64
+
+	// conn and retryAt are only accessed by the shipping routine
+	conn    net.Conn
+	retryAt time.Time
+}
+
+// newShipperWriter validates the settings and returns a shipper writer with
+// its shipping routine running
+func newShipperWriter(cfg *shipperConfig) (*shipperWriter, error) {
+	if cfg == nil {
+		return nil, errShipperNotConfigured
+	}
+	switch cfg.Network {
+	case "tcp", "tcp4", "tcp6", "udp", "udp4", "udp6":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedShipperNetwork, cfg.Network)
+	}
+	if cfg.Address == "" {
+		return nil, errShipperAddressEmpty
+	}
+	s := &shipperWriter{
+		settings: *cfg,
+		queue:    make(chan []byte, shipperQueueSize),
+		shutdown: make(chan struct{}),
+	}
+	s.wg.Add(1)
+	go s.run()
+	return s, nil
+}
+
+// hasSettings returns whether the writer is set up with the supplied settings
+func (s *shipperWriter) hasSettings(cfg *shipperConfig) bool {
+	return s != nil && cfg != nil && s.settings == *cfg
+}
+
+// encoding ships every event as JSON regardless of the sub logger format
+func (s *shipperWriter) encoding() encoding {
+	return jsonEncoding
+}
+
+// Write queues a copy of an encoded event to be shipped. The event is dropped
+// when the queue is full or the writer has been closed
+func (s *shipperWriter) Write(p []byte) (int, error) {
+	select {
+	case <-s.shutdown:
+		return len(p), nil
+	default:
+	}
+	select {
+	case s.queue <- append([]byte(nil), p...):
+	default:
+		atomic.AddUint64(&s.dropped, 1)
+	}
+	return len(p), nil
+}
+
+// run ships queued events until the writer is closed, events queued before
+// closing are shipped before the connection is closed
+func (s *shipperWriter) run() {
+	defer s.wg.Done()
+	defer s.closeConn()
+	for {
+		select {
+		case p := <-s.queue:
+			s.ship(p)
+		case <-s.shutdown:
+			for {
+				select {
+				case p := <-s.queue:
+					s.ship(p)
+				default:
+					return
+				}
+			}
+		}
+	}
+}
+
+// ship writes an event to the endpoint, connecting when required. Events are
+// dropped while the endpoint cannot be retried
+func (s *shipperWriter) ship(p []byte) {
+	if dropped := atomic.SwapUint64(&s.dropped, 0); dropped > 0 {
+		displayError(fmt.Errorf("%w: %d", errShipperQueueFull, dropped))
+	}
+	if s.conn == nil {
+		if time.Now().Before(s.retryAt) {
+			return
+		}
+		conn, err := net.DialTimeout(s.settings.Network, s.settings.Address, sinkTimeout)
+		if err != nil {
+			s.retryAt = time.Now().Add(sinkRetryInterval)
+			displayError(err)
+			return
+		}
+		s.conn = conn
+	}
+	if err := s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		displayError(err)
+		return
+	}
+	if _, err := s.conn.Write(p); err != nil {
+		displayError(err)
+		s.closeConn()
+	}
+}
+
+func (s *shipperWriter) closeConn() {
+	if s.conn == nil {
+		return
+	}
+	if err := s.conn.Close(); err != nil {
+		displayError(err)
+	}
+	s.conn = nil
+}
+
+// Close stops the shipping routine once queued events have been shipped and
+// closes the shipper connection
+func (s *shipperWriter) Close() error {
+	s.closeOnce.Do(func() {
+		close(s.shutdown)
+	})
+	s.wg.Wait()
+	return nil
+}
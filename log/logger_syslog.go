@@ -0,0 +1,186 @@
+package log
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultSyslogTag = "gocryptotrader"
+	// sinkTimeout is the dial and write timeout of network sinks
+	sinkTimeout = time.Second * 5
+	// sinkRetryInterval is the minimum time between connection attempts of a
+	// network sink. Events written in between are dropped so that an
+	// unavailable sink does not stall the logger worker.
+	sinkRetryInterval = time.Second * 5
+)
+
+var (
+	errUnsupportedSyslogNetwork = errors.New("syslog network must be unix or unixgram")
+	errUnknownSyslogFacility    = errors.New("unknown syslog facility")
+	errSyslogUnavailable        = errors.New("no local syslog socket available")
+
+	// defaultSyslogAddresses are the local syslog sockets which are tried in
+	// order when no address is configured
+	defaultSyslogAddresses = []string{"/dev/log", "/var/run/syslog", "/var/run/log"}
+
+	syslogFacilities = map[string]int{
+		"kern":   0,
+		"user":   1,
+		"mail":   2,
+		"daemon": 3,
+		"auth":   4,
+		"local0": 16,
+		"local1": 17,
+		"local2": 18,
+		"local3": 19,
+		"local4": 20,
+		"local5": 21,
+		"local6": 22,
+		"local7": 23,
+	}
+)
+
+// syslogWriter writes log events to a local syslog socket, connecting on the
+// first write and reconnecting after a failed write
+type syslogWriter struct {
+	settings syslogConfig
+	facility int
+
+	mtx     sync.Mutex
+	conn    net.Conn
+	retryAt time.Time
+}
+
+// newSyslogWriter validates the settings and returns a syslog writer
+func newSyslogWriter(cfg *syslogConfig) (*syslogWriter, error) {
+	settings := cfg.withDefaults()
+	switch settings.Network {
+	case "", "unix", "unixgram":
+	default:
+		return nil, fmt.Errorf("%w: %s", errUnsupportedSyslogNetwork, settings.Network)
+	}
+	facility, ok := syslogFacilities[strings.ToLower(settings.Facility)]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errUnknownSyslogFacility, settings.Facility)
+	}
+	return &syslogWriter{settings: settings, facility: facility}, nil
+}
+
+// hasSettings returns whether the writer is set up with the supplied settings
+func (s *syslogWriter) hasSettings(cfg *syslogConfig) bool {
+	return s != nil && s.settings == cfg.withDefaults()
+}
+
+// withDefaults returns a copy of the settings with defaults applied
+func (c *syslogConfig) withDefaults() syslogConfig {
+	var settings syslogConfig
+	if c != nil {
+		settings = *c
+	}
+	if settings.Tag == "" {
+		settings.Tag = defaultSyslogTag
+	}
+	if settings.Facility == "" {
+		settings.Facility = "user"
+	}
+	return settings
+}
+
+// Write writes an informational event
+func (s *syslogWriter) Write(p []byte) (int, error) {
+	return s.writeSeverity("info", p)
+}
+
+// writeSeverity writes an event with the syslog priority of its severity
+func (s *syslogWriter) writeSeverity(sev string, p []byte) (int, error) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.conn == nil {
+		if time.Now().Before(s.retryAt) {
+			return len(p), nil
+		}
+		if err := s.connect(); err != nil {
+			s.retryAt = time.Now().Add(sinkRetryInterval)
+			return 0, err
+		}
+	}
+	msg := strings.TrimSuffix(string(p), "\n")
+	// The local socket format omits the hostname, as per the standard
+	// library syslog package
+	line := fmt.Sprintf("<%d>%s %s[%d]: %s\n",
+		s.facility*8+syslogSeverity(sev),
+		time.Now().Format(time.Stamp),
+		s.settings.Tag,
+		os.Getpid(),
+		msg)
+	if err := s.conn.SetWriteDeadline(time.Now().Add(sinkTimeout)); err != nil {
+		return 0, err
+	}
+	if _, err := s.conn.Write([]byte(line)); err != nil {
+		s.closeConn()
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// connect dials the configured socket, or the first available default socket
+func (s *syslogWriter) connect() error {
+	networks := []string{"unixgram", "unix"}
+	if s.settings.Network != "" {
+		networks = []string{s.settings.Network}
+	}
+	addresses := defaultSyslogAddresses
+	if s.settings.Address != "" {
+		addresses = []string{s.settings.Address}
+	}
+	var errs error
+	for _, address := range addresses {
+		for _, network := range networks {
+			conn, err := net.DialTimeout(network, address, sinkTimeout)
+			if err == nil {
+				s.conn = conn
+				return nil
+			}
+			errs = errors.Join(errs, err)
+		}
+	}
+	return fmt.Errorf("%w: %v", errSyslogUnavailable, errs)
+}
+
+func (s *syslogWriter) closeConn() {
+	if s.conn == nil {
+		return
+	}
+	if err := s.conn.Close(); err != nil {
+		displayError(err)
+	}
+	s.conn = nil
+}
+
+// Close closes the syslog connection
+func (s *syslogWriter) Close() error {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.closeConn()
+	return nil
+}
+
+// syslogSeverity returns the syslog severity of a log severity
+func syslogSeverity(sev string) int {
+	switch sev {
+	case "error":
+		return 3
+	case "warn":
+		return 4
+	case "debug":
+		return 7
+	default:
+		return 6
+	}
+}
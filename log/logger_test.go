@@ -5,7 +5,11 @@ import (
 	"errors"
 	"io"
 	"log"
+	"net"
 	"os"
+	"path/filepath"
+	"runtime"
+	"strconv"
 	"strings"
 	"testing"
 	"time"
@@ -207,7 +211,7 @@ func TestMultiWriterWrite(t *testing.T) {
 	}
 
 	payload := "woooooooooooooooooooooooooooooooooooow"
-	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, false, nil)
+	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, textEncoding, nil)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -221,13 +225,13 @@ func TestMultiWriterWrite(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, false, nil) // Will display error: Logger write error: *log.WriteShorter short write
+	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, textEncoding, nil) // Will display error: Logger write error: *log.WriteShorter short write
 
 	f.output, err = multiWriter(&WriteError{}, io.Discard)
 	if err != nil {
 		t.Fatal(err)
 	}
-	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, false, nil) // Will display error: Logger write error: *log.WriteError write error
+	f.output.StageLogEvent(func() string { return payload }, "", "", "", "", "", "", false, false, textEncoding, nil) // Will display error: Logger write error: *log.WriteError write error
 }
 
 func TestGetWriters(t *testing.T) {
@@ -346,7 +350,7 @@ func TestStageNewLogEvent(t *testing.T) {
 	mw := &multiWriterHolder{writers: []io.Writer{w}}
 
 	f := &fields{output: mw}
-	f.output.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", " space ", "", "", "", false, false, false, nil)
+	f.output.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", " space ", "", "", "", false, false, textEncoding, nil)
 
 	<-w.Finished
 	if contents := w.Read(); contents != "header space  space out\n" { //nolint:dupword // False positive
@@ -565,14 +569,14 @@ func TestSubLoggerName(t *testing.T) {
 	w := newTestBuffer()
 	mw := &multiWriterHolder{writers: []io.Writer{w}}
 
-	mw.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", "||", "", "", time.RFC3339, true, false, false, nil)
+	mw.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", "||", "", "", time.RFC3339, true, false, textEncoding, nil)
 	<-w.Finished
 	contents := w.Read()
 	if !strings.Contains(contents, "SUBLOGGER") {
 		t.Error("Expected SUBLOGGER in output")
 	}
 
-	mw.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", "||", "", "", time.RFC3339, false, false, false, nil)
+	mw.StageLogEvent(func() string { return "out" }, "header", "SUBLOGGER", "||", "", "", time.RFC3339, false, false, textEncoding, nil)
 	<-w.Finished
 	contents = w.Read()
 	if strings.Contains(contents, "SUBLOGGER") {
@@ -636,6 +640,24 @@ func TestRotateWrite(t *testing.T) {
 	}
 }
 
+func TestRotateHasSettings(t *testing.T) {
+	t.Parallel()
+	var r *Rotate
+	if r.hasSettings(&loggerFileConfig{}) {
+		t.Error("expected false for nil writer")
+	}
+	r = &Rotate{FileName: "log.txt", MaxSize: 10, Rotate: convert.BoolPtr(true)}
+	if r.hasSettings(nil) {
+		t.Error("expected false for nil settings")
+	}
+	if !r.hasSettings(&loggerFileConfig{FileName: "log.txt", MaxSize: 10, Rotate: convert.BoolPtr(true)}) {
+		t.Error("expected true for matching settings")
+	}
+	if r.hasSettings(&loggerFileConfig{FileName: "log.txt", MaxSize: 10, Rotate: convert.BoolPtr(false)}) {
+		t.Error("expected false for different rotate setting")
+	}
+}
+
 func TestOpenNew(t *testing.T) {
 	t.Parallel()
 	empty := Rotate{}
@@ -689,7 +711,7 @@ func newTestBuffer() *testBuffer {
 func BenchmarkNewLogEvent(b *testing.B) {
 	mw := &multiWriterHolder{writers: []io.Writer{io.Discard}}
 	for i := 0; i < b.N; i++ {
-		mw.StageLogEvent(func() string { return "somedata" }, "header", "sublog", "||", "", "", time.RFC3339, true, false, false, nil)
+		mw.StageLogEvent(func() string { return "somedata" }, "header", "sublog", "||", "", "", time.RFC3339, true, false, textEncoding, nil)
 	}
 }
 
@@ -747,7 +769,7 @@ func TestWithFields(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	sl.structuredLogging = true
+	sl.encoding = jsonEncoding
 	sl.setLevelsProtected(splitLevel("DEBUG|ERROR|INFO|WARN"))
 	err = sl.setOutputProtected(mwh)
 	if err != nil {
@@ -844,6 +866,19 @@ func TestWithFields(t *testing.T) {
 	if captured.BotName != "test" {
 		t.Fatalf("received: '%v' but expected: '%v'", captured.BotName, "test")
 	}
+
+	// Extra fields are not carried over to later events
+	InfolnWithFields(sl, ExtraFields{"id": id}, "with id")
+	<-writer.Finished
+	writer.ReadRaw()
+	Infoln(sl, "plain")
+	<-writer.Finished
+	var plain testCapture
+	err = json.Unmarshal(writer.ReadRaw(), &plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkCapture(t, &plain, uuid.Nil, "plain", "info")
 }
 
 func checkCapture(t *testing.T, c *testCapture, expID uuid.UUID, expMessage, expSeverity string) {
@@ -869,3 +904,349 @@ func checkCapture(t *testing.T, c *testCapture, expID uuid.UUID, expMessage, exp
 		t.Errorf("received: '%v' but expected: '%v'", c.BotName, "test")
 	}
 }
+
+func TestParseFormat(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		format     string
+		structured bool
+		expected   encoding
+		err        error
+	}{
+		{"", false, textEncoding, nil},
+		{"", true, jsonEncoding, nil},
+		{"TEXT", true, textEncoding, nil},
+		{"json", false, jsonEncoding, nil},
+		{"logfmt", false, logfmtEncoding, nil},
+		{"xml", false, textEncoding, errUnhandledFormat},
+	} {
+		enc, err := parseFormat(tc.format, tc.structured)
+		if !errors.Is(err, tc.err) {
+			t.Errorf("received: '%v' but expected: '%v'", err, tc.err)
+		}
+		if enc != tc.expected {
+			t.Errorf("received: '%v' but expected: '%v'", enc, tc.expected)
+		}
+	}
+}
+
+func TestEncodeEvent(t *testing.T) {
+	t.Parallel()
+	var e eventEncoder
+	now := time.Date(2023, 5, 1, 12, 30, 0, 0, time.UTC)
+	j := &job{
+		Header:        "[INFO]",
+		SubLoggerName: "ORDER",
+		Spacer:        " | ",
+		Instance:      "bot",
+		Severity:      "info",
+		StructuredFields: ExtraFields{
+			"pair":   "BTC-USD",
+			"note":   "has space",
+			"amount": 1.5,
+			botName:  "overwrite",
+		},
+	}
+
+	if out := string(e.encode(nil, j, textEncoding, "hello\n", now)); out != "[INFO] |  | hello\n" {
+		t.Errorf("received: '%v' but expected: '%v'", out, "[INFO] |  | hello\n")
+	}
+
+	expected := `timestamp=2023-05-01T12:30:00Z severity=info sublogger=ORDER botname=bot message="hello world" amount=1.5 note="has space" pair=BTC-USD` + "\n"
+	if out := string(e.encode(nil, j, logfmtEncoding, "hello world\n", now)); out != expected {
+		t.Errorf("received: '%v' but expected: '%v'", out, expected)
+	}
+
+	var captured map[string]interface{}
+	err := json.Unmarshal(e.encode(nil, j, jsonEncoding, "hello\n", now), &captured)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if captured["message"] != "hello" || captured["botname"] != "bot" || captured["pair"] != "BTC-USD" {
+		t.Errorf("received: '%v' but expected: '%v'", captured, "message, botname and pair fields")
+	}
+	if _, ok := e.structured[botName]; !ok {
+		t.Error("default fields should persist between events")
+	}
+	if _, ok := e.structured["pair"]; ok {
+		t.Error("extra fields should not persist between events")
+	}
+}
+
+func TestSampler(t *testing.T) {
+	t.Parallel()
+	s, err := newSampler(nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if s != nil || !s.allow("info", "", time.Now()) {
+		t.Error("a nil sampler should allow every event")
+	}
+	_, err = newSampler(&SamplingConfig{Initial: 1})
+	if !errors.Is(err, errSamplingIntervalInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errSamplingIntervalInvalid)
+	}
+
+	s, err = newSampler(&SamplingConfig{Initial: 2, Thereafter: 3, Interval: time.Minute})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	now := time.Now()
+	var allowed []int
+	for i := 1; i <= 8; i++ {
+		if s.allow("info", "%v", now) {
+			allowed = append(allowed, i)
+		}
+	}
+	if len(allowed) != 4 || allowed[2] != 5 || allowed[3] != 8 {
+		t.Errorf("received: '%v' but expected: '%v'", allowed, []int{1, 2, 5, 8})
+	}
+	if !s.allow("error", "%v", now) || !s.allow("info", "other %v", now) {
+		t.Error("events of other severities and formats should be counted separately")
+	}
+	if !s.allow("info", "%v", now.Add(time.Minute)) {
+		t.Error("counts should reset each interval")
+	}
+	if s.droppedEvents() != 4 {
+		t.Errorf("received: '%v' but expected: '%v'", s.droppedEvents(), 4)
+	}
+}
+
+func TestSubLoggerRuntimeSettings(t *testing.T) {
+	t.Parallel()
+	sl, err := NewSubLogger("TESTRUNTIMESETTINGS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	name := "TESTRUNTIMESETTINGS"
+	err = SetFormat(name, "xml")
+	if !errors.Is(err, errUnhandledFormat) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnhandledFormat)
+	}
+	err = SetFormat(name, FormatLogfmt)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = SetOutput(name, "noobs")
+	if !errors.Is(err, errUnhandledOutputWriter) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnhandledOutputWriter)
+	}
+	err = SetOutput(name, "stderr")
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = SetSampling(name, &SamplingConfig{Initial: 1})
+	if !errors.Is(err, errSamplingIntervalInvalid) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errSamplingIntervalInvalid)
+	}
+	err = SetSampling(name, &SamplingConfig{Initial: 1, Interval: time.Hour})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	err = SetSampling("totallyinvalidlogger", nil)
+	if err == nil {
+		t.Error("expected error on invalid logger")
+	}
+
+	w := newTestBuffer()
+	err = sl.setOutputProtected(&multiWriterHolder{writers: []io.Writer{w}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	Warnf(sl, "hot loop %v", 1)
+	Warnf(sl, "hot loop %v", 2)
+	Warnf(sl, "hot loop %v", 3)
+	<-w.Finished
+	if contents := w.Read(); !strings.Contains(contents, `sublogger=TESTRUNTIMESETTINGS botname=test message="hot loop 1"`) {
+		t.Errorf("received: '%v' but expected: '%v'", contents, "a logfmt event")
+	}
+
+	details, err := Details(name)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if details.Format != FormatLogfmt || details.Output != "stderr" || details.Sampled != 2 ||
+		details.Sampling == nil || details.Sampling.Interval != time.Hour {
+		t.Errorf("received: '%+v' but expected: '%v'", details, "logfmt stderr output with two sampled events")
+	}
+
+	err = SetSampling(name, nil)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if details, err = Details(name); err != nil || details.Sampling != nil {
+		t.Errorf("received: '%+v' but expected: '%v'", details.Sampling, nil)
+	}
+	_, err = Details("totallyinvalidlogger")
+	if err == nil {
+		t.Error("expected error on invalid logger")
+	}
+}
+
+func TestShipperWriter(t *testing.T) {
+	t.Parallel()
+	_, err := newShipperWriter(nil)
+	if !errors.Is(err, errShipperNotConfigured) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShipperNotConfigured)
+	}
+	_, err = newShipperWriter(&shipperConfig{Network: "http", Address: "localhost:1337"})
+	if !errors.Is(err, errUnsupportedShipperNetwork) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnsupportedShipperNetwork)
+	}
+	_, err = newShipperWriter(&shipperConfig{Network: "udp"})
+	if !errors.Is(err, errShipperAddressEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errShipperAddressEmpty)
+	}
+
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	cfg := &shipperConfig{Network: "tcp", Address: l.Addr().String()}
+	s, err := newShipperWriter(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if !s.hasSettings(cfg) || s.hasSettings(&shipperConfig{Network: "udp", Address: cfg.Address}) {
+		t.Error("hasSettings returned an unexpected result")
+	}
+	if s.encoding() != jsonEncoding {
+		t.Errorf("received: '%v' but expected: '%v'", s.encoding(), jsonEncoding)
+	}
+
+	payload := []byte(`{"message":"shipped"}` + "\n")
+	n, err := s.Write(payload)
+	if !errors.Is(err, nil) || n != len(payload) {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", n, err, len(payload), nil)
+	}
+	conn, err := l.Accept()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer conn.Close()
+	buf := make([]byte, len(payload))
+	if _, err = io.ReadFull(conn, buf); err != nil {
+		t.Fatal(err)
+	}
+	if string(buf) != string(payload) {
+		t.Errorf("received: '%s' but expected: '%s'", buf, payload)
+	}
+	if err = s.Close(); err != nil {
+		t.Fatal(err)
+	}
+	// Events written after closing are dropped
+	n, err = s.Write(payload)
+	if !errors.Is(err, nil) || n != len(payload) {
+		t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", n, err, len(payload), nil)
+	}
+
+	// Events are dropped without blocking when the queue is full
+	full := &shipperWriter{queue: make(chan []byte, 1), shutdown: make(chan struct{})}
+	for i := 0; i < 3; i++ {
+		n, err = full.Write(payload)
+		if !errors.Is(err, nil) || n != len(payload) {
+			t.Fatalf("received: '%v' '%v' but expected: '%v' '%v'", n, err, len(payload), nil)
+		}
+	}
+	if full.dropped != 2 {
+		t.Errorf("received: '%v' but expected: '%v'", full.dropped, 2)
+	}
+	// The queued event is a copy of the staged buffer
+	payload[0] = '['
+	if queued := <-full.queue; queued[0] != '{' {
+		t.Errorf("received: '%s' but expected a copy of the event", queued)
+	}
+}
+
+func TestSyslogWriter(t *testing.T) {
+	t.Parallel()
+	_, err := newSyslogWriter(&syslogConfig{Network: "tcp"})
+	if !errors.Is(err, errUnsupportedSyslogNetwork) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnsupportedSyslogNetwork)
+	}
+	_, err = newSyslogWriter(&syslogConfig{Facility: "nope"})
+	if !errors.Is(err, errUnknownSyslogFacility) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errUnknownSyslogFacility)
+	}
+	if s, _ := newSyslogWriter(nil); !s.hasSettings(&syslogConfig{Tag: defaultSyslogTag, Facility: "user"}) {
+		t.Error("default settings should match")
+	}
+	if runtime.GOOS == "windows" {
+		t.Skip("unix sockets are not supported")
+	}
+
+	address := filepath.Join(t.TempDir(), "syslog.sock")
+	l, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: address, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	s, err := newSyslogWriter(&syslogConfig{Address: address, Facility: "local0", Tag: "gct"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.writeSeverity("error", []byte("order rejected\n"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	buf := make([]byte, 1024)
+	n, err := l.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	received := string(buf[:n])
+	// local0 error priority is 16*8+3
+	if !strings.HasPrefix(received, "<131>") || !strings.HasSuffix(received, "gct["+strconv.Itoa(os.Getpid())+"]: order rejected\n") {
+		t.Errorf("received: '%v' but expected: '%v'", received, "a local0 error priority message")
+	}
+	if err = s.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err = newSyslogWriter(&syslogConfig{Address: filepath.Join(t.TempDir(), "missing.sock")})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	_, err = s.Write([]byte("dropped"))
+	if !errors.Is(err, errSyslogUnavailable) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errSyslogUnavailable)
+	}
+	// Events are dropped until the sink can be retried
+	_, err = s.Write([]byte("dropped"))
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
+
+func TestGetWritersSinks(t *testing.T) {
+	t.Parallel()
+	mu.Lock()
+	defer mu.Unlock()
+	_, err := getWriters(&SubLoggerConfig{Output: "file", FileSettings: &loggerFileConfig{}})
+	if !errors.Is(err, errFileNameIsEmpty) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, errFileNameIsEmpty)
+	}
+	cfg := &SubLoggerConfig{Output: "file", FileSettings: &loggerFileConfig{FileName: "orders.log"}}
+	mw, err := getWriters(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	again, err := getWriters(cfg)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if mw.writers[0] != again.writers[0] || mw.writers[0] == globalLogFile {
+		t.Error("sub logger file writers should be reused and separate to the global file")
+	}
+	if shipperSink == nil {
+		_, err = getWriters(&SubLoggerConfig{Output: "shipper"})
+		if !errors.Is(err, errShipperNotConfigured) {
+			t.Fatalf("received: '%v' but expected: '%v'", err, errShipperNotConfigured)
+		}
+	}
+	_, err = getWriters(&SubLoggerConfig{Output: "syslog"})
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+}
@@ -3,6 +3,7 @@ package log
 import (
 	"io"
 	"sync"
+	"time"
 )
 
 const (
@@ -15,6 +16,14 @@ const (
 	// instances where it was 3/4 of this. This size so as to not need a resize.
 	defaultBufferCapacity     = 200000
 	defaultJobChannelCapacity = 10000
+
+	// FormatText writes log events as formatted text lines with headers and
+	// spacers
+	FormatText = "text"
+	// FormatJSON writes log events as JSON lines
+	FormatJSON = "json"
+	// FormatLogfmt writes log events as logfmt key=value lines
+	FormatLogfmt = "logfmt"
 )
 
 var (
@@ -25,6 +34,13 @@ var (
 	globalLogConfig = &Config{}
 	// GlobalLogFile hold global configuration options for file logger
 	globalLogFile = &Rotate{}
+	// subLoggerFiles holds the file writers of sub loggers which log to their
+	// own file, keyed by file name
+	subLoggerFiles = map[string]*Rotate{}
+	// syslogSink and shipperSink are shared by every sub logger which outputs
+	// to them
+	syslogSink  *syslogWriter
+	shipperSink *shipperWriter
 
 	jobsPool    = &sync.Pool{New: func() interface{} { return new(job) }}
 	jobsChannel = make(chan *job, defaultJobChannelCapacity)
@@ -50,7 +66,7 @@ type job struct {
 	ShowLogSystemName bool
 	Instance          string
 	StructuredFields  map[Key]interface{}
-	StructuredLogging bool
+	Encoding          encoding
 	Severity          string
 	Passback          chan<- struct{}
 }
@@ -61,6 +77,8 @@ type Config struct {
 	SubLoggerConfig
 	LoggerFileConfig *loggerFileConfig `json:"fileSettings,omitempty"`
 	AdvancedSettings advancedSettings  `json:"advancedSettings"`
+	Syslog           *syslogConfig     `json:"syslog,omitempty"`
+	Shipper          *shipperConfig    `json:"shipper,omitempty"`
 	SubLoggers       []SubLoggerConfig `json:"subloggers,omitempty"`
 }
 
@@ -85,6 +103,49 @@ type SubLoggerConfig struct {
 	Name   string `json:"name,omitempty"`
 	Level  string `json:"level"`
 	Output string `json:"output"`
+	// Format is one of text, json or logfmt. When unset the global format is
+	// used
+	Format string `json:"format,omitempty"`
+	// FileSettings writes the file output of a sub logger to its own file
+	// instead of the global log file
+	FileSettings *loggerFileConfig `json:"fileSettings,omitempty"`
+	Sampling     *SamplingConfig   `json:"sampling,omitempty"`
+}
+
+// SamplingConfig limits the log events of a sub logger to protect hot loops.
+// Within each interval the first Initial events with the same severity and
+// message format are written, after which only every Thereafter-th event is
+// written. A zero Thereafter drops every event past Initial.
+type SamplingConfig struct {
+	Initial    uint64        `json:"initial"`
+	Thereafter uint64        `json:"thereafter"`
+	Interval   time.Duration `json:"interval"`
+}
+
+// syslogConfig holds the local syslog socket settings used by the syslog
+// output
+type syslogConfig struct {
+	Network  string `json:"network,omitempty"`
+	Address  string `json:"address,omitempty"`
+	Tag      string `json:"tag,omitempty"`
+	Facility string `json:"facility,omitempty"`
+}
+
+// shipperConfig holds the TCP or UDP endpoint used by the shipper output,
+// which always writes JSON lines
+type shipperConfig struct {
+	Network string `json:"network"`
+	Address string `json:"address"`
+}
+
+// SubLoggerDetails holds the current settings of a sub logger
+type SubLoggerDetails struct {
+	Levels
+	Output   string
+	Format   string
+	Sampling *SamplingConfig
+	// Sampled is the number of events dropped by sampling
+	Sampled uint64
 }
 
 type loggerFileConfig struct {
@@ -102,6 +163,8 @@ type Logger struct {
 	Spacer                                           string
 	Level                                            string
 	botName                                          string
+	// encoding is the default encoding of sub loggers without a format
+	encoding encoding
 }
 
 // Levels flags for each sub logger type
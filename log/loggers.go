@@ -3,6 +3,7 @@ package log
 import (
 	"fmt"
 	"log"
+	"time"
 )
 
 // Infoln is a logging function that takes a sublogger and an arbitrary number
@@ -243,12 +244,13 @@ func (l *fields) enabled(header string) string {
 	return ""
 }
 
-// stage stages a log event
-func (l *fields) stage(header string, deferFunc deferral) {
+// stage stages a log event. The format is used to sample events of the same
+// kind.
+func (l *fields) stage(header, format string, deferFunc deferral) {
 	if l == nil {
 		return
 	}
-	if level := l.enabled(header); level != "" {
+	if level := l.enabled(header); level != "" && l.sampler.allow(level, format, time.Now()) {
 		l.output.StageLogEvent(deferFunc,
 			header,
 			l.name,
@@ -258,7 +260,7 @@ func (l *fields) stage(header string, deferFunc deferral) {
 			level,
 			l.logger.ShowLogSystemName,
 			l.logger.BypassJobChannelFilledWarning,
-			l.structuredLogging,
+			l.encoding,
 			l.structuredFields)
 	}
 	logFieldsPool.Put(l)
@@ -266,10 +268,10 @@ func (l *fields) stage(header string, deferFunc deferral) {
 
 // stageln stages a log event
 func (l *fields) stageln(header string, a ...interface{}) {
-	l.stage(header, func() string { return fmt.Sprint(a...) })
+	l.stage(header, "", func() string { return fmt.Sprint(a...) })
 }
 
 // stagef stages a log event
 func (l *fields) stagef(header, format string, a ...interface{}) {
-	l.stage(header, func() string { return fmt.Sprintf(format, a...) })
+	l.stage(header, format, func() string { return fmt.Sprintf(format, a...) })
 }
@@ -50,6 +50,9 @@ func (sl *SubLogger) getFields() *fields {
 	f.name = sl.name
 	f.output = sl.output
 	f.botName = sl.botName
-	f.structuredLogging = sl.structuredLogging
+	f.encoding = sl.encoding
+	f.sampler = sl.sampler
+	// fields are pooled so extra fields from a previous event must be cleared
+	f.structuredFields = nil
 	return f
 }
@@ -35,24 +35,28 @@ var (
 // SubLogger defines a sub logger can be used externally for packages wanted to
 // leverage GCT library logger features.
 type SubLogger struct {
-	name              string
-	levels            Levels
-	output            *multiWriterHolder
-	botName           string
-	structuredLogging bool
+	name     string
+	levels   Levels
+	output   *multiWriterHolder
+	botName  string
+	encoding encoding
+	// config holds the output, format and sampling settings last applied
+	config  SubLoggerConfig
+	sampler *sampler
 }
 
 // fields is used to store data in a non-global and thread-safe manner
 // so logs cannot be modified mid-log causing a data-race issue
 type fields struct {
-	info              bool
-	warn              bool
-	debug             bool
-	error             bool
-	structuredLogging bool
-	name              string
-	output            *multiWriterHolder
-	logger            Logger
-	botName           string
-	structuredFields  ExtraFields
+	info             bool
+	warn             bool
+	debug            bool
+	error            bool
+	encoding         encoding
+	name             string
+	output           *multiWriterHolder
+	logger           Logger
+	botName          string
+	structuredFields ExtraFields
+	sampler          *sampler
 }
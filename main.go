@@ -37,6 +37,14 @@ func main() {
 	flag.BoolVar(&settings.EnableAllPairs, "enableallpairs", false, "enables all pairs for enabled exchanges")
 	flag.BoolVar(&settings.EnablePortfolioManager, "portfoliomanager", true, "enables the portfolio manager")
 	flag.BoolVar(&settings.EnableDataHistoryManager, "datahistorymanager", false, "enables the data history manager")
+	flag.BoolVar(&settings.EnableDataRetentionManager, "dataretentionmanager", false, "enables the data retention manager")
+	flag.BoolVar(&settings.EnableFundingRateScanner, "fundingratescanner", false, "enables the funding rate arbitrage scanner")
+	flag.BoolVar(&settings.EnableBasisService, "basisservice", false, "enables the futures basis and term structure service")
+	flag.BoolVar(&settings.EnableCandleBuilder, "candlebuilder", false, "enables building candles from websocket trade streams")
+	flag.BoolVar(&settings.EnableEventJournal, "eventjournal", false, "enables recording market data and order events to the on disk event journal")
+	flag.BoolVar(&settings.EnableAccountingManager, "accountingmanager", false, "enables live cost basis, PNL and NAV accounting of order manager fills")
+	flag.BoolVar(&settings.EnableLedgerManager, "ledgermanager", false, "enables importing exchange history into a ledger and reconciling it against exchange balances")
+	flag.BoolVar(&settings.EnableRebalancer, "rebalancer", false, "enables trading holdings towards the configured target portfolio weights")
 	flag.DurationVar(&settings.PortfolioManagerDelay, "portfoliomanagerdelay", 0, "sets the portfolio managers sleep delay between updates")
 	flag.BoolVar(&settings.EnableGRPC, "grpc", true, "enables the grpc server")
 	flag.BoolVar(&settings.EnableGRPCProxy, "grpcproxy", false, "enables the grpc proxy server")
@@ -0,0 +1,167 @@
+package costbasis
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// ParseMethod returns the cost basis method for a name. An empty name returns
+// FIFO
+func ParseMethod(name string) (Method, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "", "fifo":
+		return FIFO, nil
+	case "lifo":
+		return LIFO, nil
+	case "hifo":
+		return HIFO, nil
+	case "average", "avg":
+		return Average, nil
+	default:
+		return FIFO, fmt.Errorf("%w: %s", ErrUnknownMethod, name)
+	}
+}
+
+// String returns the name of the method
+func (m Method) String() string {
+	switch m {
+	case FIFO:
+		return "fifo"
+	case LIFO:
+		return "lifo"
+	case HIFO:
+		return "hifo"
+	case Average:
+		return "average"
+	default:
+		return "unknown"
+	}
+}
+
+// Gain returns the proceeds less the cost of the disposal
+func (d *Disposal) Gain() decimal.Decimal {
+	return d.Proceeds.Sub(d.Cost)
+}
+
+// NewBook returns an empty book using the cost basis method
+func NewBook(m Method) (*Book, error) {
+	if m > Average {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownMethod, m)
+	}
+	return &Book{method: m}, nil
+}
+
+// Method returns the cost basis method of the book
+func (b *Book) Method() Method {
+	return b.method
+}
+
+// Acquire adds an acquired amount at its total cost to the book
+func (b *Book) Acquire(ref string, t time.Time, amount, cost decimal.Decimal) error {
+	if !amount.IsPositive() {
+		return errInvalidAmount
+	}
+	if cost.IsNegative() {
+		return errNegativeValue
+	}
+	if b.method == Average && len(b.lots) > 0 {
+		b.lots[0].Amount = b.lots[0].Amount.Add(amount)
+		b.lots[0].Cost = b.lots[0].Cost.Add(cost)
+		return nil
+	}
+	b.lots = append(b.lots, Lot{Reference: ref, Acquired: t, Amount: amount, Cost: cost})
+	return nil
+}
+
+// Dispose matches a disposed amount against the open lots and returns the
+// matched disposals. Proceeds are shared between the matched lots by amount.
+// Any amount exceeding the open lots is returned as unmatched and is not
+// included in the disposals
+func (b *Book) Dispose(ref string, t time.Time, amount, proceeds decimal.Decimal) (disposals []Disposal, unmatched decimal.Decimal, err error) {
+	if !amount.IsPositive() {
+		return nil, decimal.Zero, errInvalidAmount
+	}
+	if proceeds.IsNegative() {
+		return nil, decimal.Zero, errNegativeValue
+	}
+	remaining := amount
+	for remaining.IsPositive() && len(b.lots) > 0 {
+		i := b.nextLot()
+		lot := &b.lots[i]
+		take := decimal.Min(remaining, lot.Amount)
+		cost := lot.Cost
+		if !take.Equal(lot.Amount) {
+			cost = lot.Cost.Mul(take).Div(lot.Amount)
+		}
+		disposals = append(disposals, Disposal{
+			Reference:    ref,
+			LotReference: lot.Reference,
+			Acquired:     lot.Acquired,
+			Disposed:     t,
+			Amount:       take,
+			Cost:         cost,
+			Proceeds:     proceeds.Mul(take).Div(amount),
+		})
+		lot.Amount = lot.Amount.Sub(take)
+		lot.Cost = lot.Cost.Sub(cost)
+		if !lot.Amount.IsPositive() {
+			b.lots = append(b.lots[:i], b.lots[i+1:]...)
+		}
+		remaining = remaining.Sub(take)
+	}
+	return disposals, remaining, nil
+}
+
+// nextLot returns the index of the lot the next disposal is matched against
+func (b *Book) nextLot() int {
+	switch b.method {
+	case LIFO:
+		return len(b.lots) - 1
+	case HIFO:
+		highest := 0
+		for i := 1; i < len(b.lots); i++ {
+			// Compare unit costs without dividing, a.Cost/a.Amount > b.Cost/b.Amount
+			if b.lots[i].Cost.Mul(b.lots[highest].Amount).GreaterThan(b.lots[highest].Cost.Mul(b.lots[i].Amount)) {
+				highest = i
+			}
+		}
+		return highest
+	default:
+		return 0
+	}
+}
+
+// Lots returns a copy of the open lots in acquisition order
+func (b *Book) Lots() []Lot {
+	return append([]Lot(nil), b.lots...)
+}
+
+// Amount returns the total open amount
+func (b *Book) Amount() decimal.Decimal {
+	total := decimal.Zero
+	for i := range b.lots {
+		total = total.Add(b.lots[i].Amount)
+	}
+	return total
+}
+
+// Cost returns the total cost of the open amount
+func (b *Book) Cost() decimal.Decimal {
+	total := decimal.Zero
+	for i := range b.lots {
+		total = total.Add(b.lots[i].Cost)
+	}
+	return total
+}
+
+// AverageCost returns the average unit cost of the open amount
+func (b *Book) AverageCost() decimal.Decimal {
+	amount := b.Amount()
+	if amount.IsZero() {
+		return decimal.Zero
+	}
+	return b.Cost().Div(amount)
+}
@@ -0,0 +1,176 @@
+package costbasis
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+func TestParseMethod(t *testing.T) {
+	t.Parallel()
+	for name, expected := range map[string]Method{"": FIFO, "FIFO": FIFO, "lifo": LIFO, " hifo ": HIFO, "average": Average, "avg": Average} {
+		m, err := ParseMethod(name)
+		if err != nil {
+			t.Fatalf("received '%v', expected '%v'", err, nil)
+		}
+		if m != expected {
+			t.Errorf("received '%v', expected '%v'", m, expected)
+		}
+	}
+	if _, err := ParseMethod("lofi"); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("received '%v', expected '%v'", err, ErrUnknownMethod)
+	}
+	if s := Average.String(); s != "average" {
+		t.Errorf("received '%v', expected '%v'", s, "average")
+	}
+}
+
+func TestNewBook(t *testing.T) {
+	t.Parallel()
+	if _, err := NewBook(Method(99)); !errors.Is(err, ErrUnknownMethod) {
+		t.Errorf("received '%v', expected '%v'", err, ErrUnknownMethod)
+	}
+	b, err := NewBook(LIFO)
+	if err != nil {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if b.Method() != LIFO {
+		t.Errorf("received '%v', expected '%v'", b.Method(), LIFO)
+	}
+}
+
+// newTestBook returns a book holding 1 unit at 100, 1 unit at 300 and 1 unit
+// at 200 acquired in that order
+func newTestBook(t *testing.T, m Method) *Book {
+	t.Helper()
+	b, err := NewBook(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	for i, cost := range []int64{100, 300, 200} {
+		err = b.Acquire(string(rune('a'+i)), start.AddDate(0, 0, i), decimal.NewFromInt(1), decimal.NewFromInt(cost))
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+	return b
+}
+
+func TestAcquire(t *testing.T) {
+	t.Parallel()
+	b := newTestBook(t, FIFO)
+	if err := b.Acquire("d", time.Now(), decimal.Zero, decimal.Zero); !errors.Is(err, errInvalidAmount) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidAmount)
+	}
+	if err := b.Acquire("d", time.Now(), decimal.NewFromInt(1), decimal.NewFromInt(-1)); !errors.Is(err, errNegativeValue) {
+		t.Errorf("received '%v', expected '%v'", err, errNegativeValue)
+	}
+	if len(b.Lots()) != 3 {
+		t.Errorf("received '%v', expected '%v'", len(b.Lots()), 3)
+	}
+	if !b.Amount().Equal(decimal.NewFromInt(3)) {
+		t.Errorf("received '%v', expected '%v'", b.Amount(), 3)
+	}
+	if !b.Cost().Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v', expected '%v'", b.Cost(), 600)
+	}
+	if !b.AverageCost().Equal(decimal.NewFromInt(200)) {
+		t.Errorf("received '%v', expected '%v'", b.AverageCost(), 200)
+	}
+
+	avg := newTestBook(t, Average)
+	if len(avg.Lots()) != 1 {
+		t.Errorf("received '%v', expected '%v'", len(avg.Lots()), 1)
+	}
+	if !avg.Cost().Equal(decimal.NewFromInt(600)) {
+		t.Errorf("received '%v', expected '%v'", avg.Cost(), 600)
+	}
+}
+
+func TestDispose(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		method Method
+		cost   int64
+		lots   []string
+	}{
+		{method: FIFO, cost: 250, lots: []string{"a", "b"}},
+		{method: LIFO, cost: 350, lots: []string{"c", "b"}},
+		{method: HIFO, cost: 400, lots: []string{"b", "c"}},
+		{method: Average, cost: 300, lots: []string{"a"}},
+	} {
+		tc := tc
+		t.Run(tc.method.String(), func(t *testing.T) {
+			t.Parallel()
+			b := newTestBook(t, tc.method)
+			disposed := time.Date(2023, 2, 1, 0, 0, 0, 0, time.UTC)
+			disposals, unmatched, err := b.Dispose("sell", disposed, decimal.NewFromFloat(1.5), decimal.NewFromInt(600))
+			if err != nil {
+				t.Fatalf("received '%v', expected '%v'", err, nil)
+			}
+			if !unmatched.IsZero() {
+				t.Errorf("received '%v', expected '%v'", unmatched, 0)
+			}
+			if len(disposals) != len(tc.lots) {
+				t.Fatalf("received '%v', expected '%v'", len(disposals), len(tc.lots))
+			}
+			cost, proceeds, gain := decimal.Zero, decimal.Zero, decimal.Zero
+			for i := range disposals {
+				if disposals[i].LotReference != tc.lots[i] {
+					t.Errorf("received '%v', expected '%v'", disposals[i].LotReference, tc.lots[i])
+				}
+				if disposals[i].Reference != "sell" || !disposals[i].Disposed.Equal(disposed) {
+					t.Errorf("received '%v', expected '%v'", disposals[i].Reference, "sell")
+				}
+				cost = cost.Add(disposals[i].Cost)
+				proceeds = proceeds.Add(disposals[i].Proceeds)
+				gain = gain.Add(disposals[i].Gain())
+			}
+			if !cost.Equal(decimal.NewFromInt(tc.cost)) {
+				t.Errorf("received '%v', expected '%v'", cost, tc.cost)
+			}
+			if !proceeds.Equal(decimal.NewFromInt(600)) {
+				t.Errorf("received '%v', expected '%v'", proceeds, 600)
+			}
+			if !gain.Equal(decimal.NewFromInt(600 - tc.cost)) {
+				t.Errorf("received '%v', expected '%v'", gain, 600-tc.cost)
+			}
+			if !b.Amount().Equal(decimal.NewFromFloat(1.5)) {
+				t.Errorf("received '%v', expected '%v'", b.Amount(), 1.5)
+			}
+			if !b.Cost().Equal(decimal.NewFromInt(600 - tc.cost)) {
+				t.Errorf("received '%v', expected '%v'", b.Cost(), 600-tc.cost)
+			}
+		})
+	}
+}
+
+func TestDisposeUnmatched(t *testing.T) {
+	t.Parallel()
+	b := newTestBook(t, FIFO)
+	if _, _, err := b.Dispose("sell", time.Now(), decimal.Zero, decimal.Zero); !errors.Is(err, errInvalidAmount) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidAmount)
+	}
+	if _, _, err := b.Dispose("sell", time.Now(), decimal.NewFromInt(1), decimal.NewFromInt(-1)); !errors.Is(err, errNegativeValue) {
+		t.Errorf("received '%v', expected '%v'", err, errNegativeValue)
+	}
+	disposals, unmatched, err := b.Dispose("sell", time.Now(), decimal.NewFromInt(4), decimal.NewFromInt(800))
+	if err != nil {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if !unmatched.Equal(decimal.NewFromInt(1)) {
+		t.Errorf("received '%v', expected '%v'", unmatched, 1)
+	}
+	if len(disposals) != 3 {
+		t.Fatalf("received '%v', expected '%v'", len(disposals), 3)
+	}
+	if !disposals[0].Proceeds.Equal(decimal.NewFromInt(200)) {
+		t.Errorf("received '%v', expected '%v'", disposals[0].Proceeds, 200)
+	}
+	if len(b.Lots()) != 0 || !b.AverageCost().IsZero() {
+		t.Errorf("received '%v', expected '%v'", b.Lots(), "no lots")
+	}
+}
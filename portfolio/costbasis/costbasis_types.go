@@ -0,0 +1,60 @@
+package costbasis
+
+import (
+	"errors"
+	"time"
+
+	"github.com/shopspring/decimal"
+)
+
+// Method defines how disposals are matched against acquired lots
+type Method uint8
+
+// Supported cost basis methods
+const (
+	// FIFO matches disposals against the oldest lots first
+	FIFO Method = iota
+	// LIFO matches disposals against the newest lots first
+	LIFO
+	// HIFO matches disposals against the lots with the highest unit cost first
+	HIFO
+	// Average pools every acquisition into a single lot at the weighted
+	// average cost
+	Average
+)
+
+var (
+	// ErrUnknownMethod is returned when a cost basis method is not supported
+	ErrUnknownMethod = errors.New("unknown cost basis method")
+
+	errInvalidAmount = errors.New("amount must be greater than zero")
+	errNegativeValue = errors.New("cost or proceeds cannot be negative")
+)
+
+// Lot is an acquired amount which has not yet been disposed of. Cost is the
+// total cost of the remaining amount including fees
+type Lot struct {
+	Reference string
+	Acquired  time.Time
+	Amount    decimal.Decimal
+	Cost      decimal.Decimal
+}
+
+// Disposal is the part of a disposal matched against a single lot. Cost and
+// Proceeds are the share of the lot cost and the disposal proceeds for Amount
+type Disposal struct {
+	Reference    string
+	LotReference string
+	Acquired     time.Time
+	Disposed     time.Time
+	Amount       decimal.Decimal
+	Cost         decimal.Decimal
+	Proceeds     decimal.Decimal
+}
+
+// Book holds the open lots of a single asset. It is not safe for concurrent
+// use
+type Book struct {
+	method Method
+	lots   []Lot
+}
@@ -0,0 +1,237 @@
+package cryptoaddress
+
+import (
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+	"golang.org/x/crypto/sha3"
+)
+
+// Validate checks an address, and its tag or memo if required, against the
+// offline format rules for the supplied chain. If no chain is supplied the
+// native chain for the currency code is used. ErrUnsupported is returned when
+// there is no known validator for the combination.
+func Validate(code currency.Code, chain, address, tag string) error {
+	if address == "" {
+		return fmt.Errorf("%w: %w", ErrInvalidAddress, errEmptyAddress)
+	}
+	validator, err := getValidator(code, chain)
+	if err != nil {
+		return err
+	}
+	return validator(address, tag)
+}
+
+// IsSupported returns whether an address for the currency code and chain can
+// be validated offline
+func IsSupported(code currency.Code, chain string) bool {
+	_, err := getValidator(code, chain)
+	return err == nil
+}
+
+// getValidator returns the validator keyed by chain, falling back to the
+// currency code when no chain is supplied
+func getValidator(code currency.Code, chain string) (validatorFunc, error) {
+	if chain != "" {
+		validator, ok := chainValidators[normaliseChain(chain)]
+		if !ok {
+			return nil, fmt.Errorf("%w for %s chain %s", ErrUnsupported, code, chain)
+		}
+		return validator, nil
+	}
+	if code.Item == nil {
+		return nil, fmt.Errorf("%w for empty currency code", ErrUnsupported)
+	}
+	validator, ok := currencyValidators[code.Item]
+	if !ok {
+		return nil, fmt.Errorf("%w for %s", ErrUnsupported, code)
+	}
+	return validator, nil
+}
+
+// normaliseChain lowercases a chain name and strips separators so that
+// exchange specific variants such as "BEP20(BSC)" or "Arbitrum-One" match
+func normaliseChain(chain string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			return unicode.ToLower(r)
+		}
+		return -1
+	}, chain)
+}
+
+// validateBitcoin validates legacy P2PKH, P2SH and segwit bitcoin addresses
+func validateBitcoin(address, _ string) error {
+	return validateUTXO(address, bitcoinSegwitHRP, bitcoinP2PKHVersion, bitcoinP2SHVersion)
+}
+
+// validateLitecoin validates legacy, P2SH and segwit litecoin addresses.
+// Deprecated P2SH addresses sharing the bitcoin version byte are accepted.
+func validateLitecoin(address, _ string) error {
+	return validateUTXO(address, litecoinSegwitHRP, litecoinP2PKHVersion, litecoinP2SHVersion, bitcoinP2SHVersion)
+}
+
+// validateDogecoin validates legacy P2PKH and P2SH dogecoin addresses
+func validateDogecoin(address, _ string) error {
+	return validateUTXO(address, "", dogecoinP2PKHVersion, dogecoinP2SHVersion)
+}
+
+// validateUTXO validates either a segwit address with the supplied human
+// readable prefix or a base58check address with one of the supplied versions
+func validateUTXO(address, segwitHRP string, versions ...byte) error {
+	if segwitHRP != "" && strings.HasPrefix(strings.ToLower(address), segwitHRP+"1") {
+		if err := validateSegwit(address, segwitHRP); err != nil {
+			return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+		}
+		return nil
+	}
+	if err := validateBase58Check(address, bitcoinAlphabet, hash160Length, versions...); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+	}
+	return nil
+}
+
+// validateSegwit validates a BIP173 (bech32) or BIP350 (bech32m) segregated
+// witness address
+func validateSegwit(address, hrp string) error {
+	decodedHRP, data, encoding, err := bech32Decode(address, segwitMaxLength)
+	if err != nil {
+		return err
+	}
+	if decodedHRP != hrp {
+		return fmt.Errorf("%w %q", errInvalidPrefix, decodedHRP)
+	}
+	if len(data) == 0 {
+		return errInvalidLength
+	}
+	witnessVersion := data[0]
+	if witnessVersion > 16 {
+		return fmt.Errorf("%w: witness version %d", errInvalidVersion, witnessVersion)
+	}
+	program, err := convertBits(data[1:], 5, 8, false)
+	if err != nil {
+		return err
+	}
+	if len(program) < 2 || len(program) > 40 {
+		return fmt.Errorf("%w: witness program %d bytes", errInvalidLength, len(program))
+	}
+	if witnessVersion == 0 {
+		if len(program) != 20 && len(program) != 32 {
+			return fmt.Errorf("%w: witness v0 program %d bytes", errInvalidLength, len(program))
+		}
+		if encoding != bech32Encoding {
+			return fmt.Errorf("%w: witness v0 requires bech32", errInvalidEncoding)
+		}
+		return nil
+	}
+	if encoding != bech32mEncoding {
+		return fmt.Errorf("%w: witness v%d requires bech32m", errInvalidEncoding, witnessVersion)
+	}
+	return nil
+}
+
+// validateRipple validates a classic XRP Ledger account address and its
+// optional numeric destination tag
+func validateRipple(address, tag string) error {
+	if err := validateBase58Check(address, rippleAlphabet, hash160Length, rippleAccountVersion); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+	}
+	if tag == "" {
+		return nil
+	}
+	if _, err := strconv.ParseUint(tag, 10, 32); err != nil {
+		return fmt.Errorf("%w %q: must be an unsigned 32-bit integer", ErrInvalidDestinationTag, tag)
+	}
+	return nil
+}
+
+// validateTron validates a base58check encoded Tron address
+func validateTron(address, _ string) error {
+	if err := validateBase58Check(address, bitcoinAlphabet, hash160Length, tronAccountVersion); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+	}
+	return nil
+}
+
+// validateSolana validates a base58 encoded ed25519 public key
+func validateSolana(address, _ string) error {
+	decoded, err := base58Decode(address, bitcoinAlphabet)
+	if err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+	}
+	if len(decoded) != publicKeyLength {
+		return fmt.Errorf("%w %s: %w %d bytes", ErrInvalidAddress, address, errInvalidLength, len(decoded))
+	}
+	return nil
+}
+
+// cosmosValidator returns a validator for bech32 encoded Cosmos SDK account
+// addresses with the supplied human readable prefix
+func cosmosValidator(hrp string) validatorFunc {
+	return func(address, _ string) error {
+		decodedHRP, data, encoding, err := bech32Decode(address, cosmosMaxLength)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+		}
+		if decodedHRP != hrp {
+			return fmt.Errorf("%w %s: %w %q, expected %q", ErrInvalidAddress, address, errInvalidPrefix, decodedHRP, hrp)
+		}
+		if encoding != bech32Encoding {
+			return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, errInvalidEncoding)
+		}
+		payload, err := convertBits(data, 5, 8, false)
+		if err != nil {
+			return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, err)
+		}
+		if len(payload) != hash160Length && len(payload) != publicKeyLength {
+			return fmt.Errorf("%w %s: %w %d bytes", ErrInvalidAddress, address, errInvalidLength, len(payload))
+		}
+		return nil
+	}
+}
+
+// validateEVM validates a hex encoded EVM address. Mixed case addresses must
+// match their EIP-55 checksum, single case addresses carry no checksum.
+func validateEVM(address, _ string) error {
+	if len(address) != evmHexLength+2 || (address[:2] != "0x" && address[:2] != "0X") {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, errInvalidLength)
+	}
+	hexAddress := address[2:]
+	if _, err := hex.DecodeString(hexAddress); err != nil {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, errInvalidCharacter)
+	}
+	lower := strings.ToLower(hexAddress)
+	if hexAddress == lower || hexAddress == strings.ToUpper(hexAddress) {
+		return nil
+	}
+	if hexAddress != checksumEVM(lower) {
+		return fmt.Errorf("%w %s: %w", ErrInvalidAddress, address, errInvalidChecksum)
+	}
+	return nil
+}
+
+// checksumEVM applies EIP-55 mixed case checksum encoding to a lowercase hex
+// address without its 0x prefix
+func checksumEVM(lowerHex string) string {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(lowerHex))
+	hash := hasher.Sum(nil)
+	checksummed := []byte(lowerHex)
+	for i := range checksummed {
+		if checksummed[i] < 'a' {
+			continue
+		}
+		nibble := hash[i/2]
+		if i%2 == 0 {
+			nibble >>= 4
+		}
+		if nibble&0x0f >= 8 {
+			checksummed[i] -= 'a' - 'A'
+		}
+	}
+	return string(checksummed)
+}
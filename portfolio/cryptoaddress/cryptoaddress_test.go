@@ -0,0 +1,119 @@
+package cryptoaddress
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/thrasher-corp/gocryptotrader/core"
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+	for _, tc := range []struct {
+		name    string
+		code    currency.Code
+		chain   string
+		address string
+		tag     string
+		err     error
+	}{
+		{name: "empty", code: currency.BTC, err: ErrInvalidAddress},
+		{name: "unsupported currency", code: currency.NewCode("MEOWCOIN"), address: "meow", err: ErrUnsupported},
+		{name: "unsupported chain", code: currency.USDT, chain: "meowchain", address: "meow", err: ErrUnsupported},
+		{name: "btc p2pkh", code: currency.BTC, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa"},
+		{name: "btc p2sh", code: currency.BTC, address: "3J98t1WpEZ73CNmQviecrnyiWrnqRhWNLy"},
+		{name: "btc bad checksum", code: currency.BTC, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNb", err: ErrInvalidAddress},
+		{name: "btc litecoin version", code: currency.BTC, address: "LdP8Qox1VAhCzLJNqrr74YovaWYyNBUWvL", err: ErrInvalidAddress},
+		{name: "btc segwit v0", code: currency.BTC, address: core.BitcoinDonationAddress},
+		{name: "btc segwit v0 uppercase", code: currency.BTC, address: "BC1QW508D6QEJXTDG4Y5R3ZARVARY0C5XW7KV8F3T4"},
+		{name: "btc segwit mixed case", code: currency.BTC, address: "bc1qW508d6qejxtdg4y5r3zarvary0c5xw7kv8f3t4", err: ErrInvalidAddress},
+		{name: "btc taproot", code: currency.BTC, chain: "BTC", address: "bc1p0xlxvlhemja6c4dqv22uapctqupfhlxm9h8z3k2e72q4k9hcz7vqzk5jj0"},
+		{name: "btc taproot bech32 checksum", code: currency.BTC, address: "bc1pw508d6qejxtdg4y5r3zarvary0c5xw7kw508d6qejxtdg4y5r3zarvary0c5xw7k7grplx", err: ErrInvalidAddress},
+		{name: "btc testnet segwit", code: currency.BTC, address: "tb1qw508d6qejxtdg4y5r3zarvary0c5xw7kxpjzsx", err: ErrInvalidAddress},
+		{name: "ltc p2pkh", code: currency.LTC, address: "LdP8Qox1VAhCzLJNqrr74YovaWYyNBUWvL"},
+		{name: "ltc bitcoin address", code: currency.LTC, address: "1A1zP1eP5QGefi2DMPTfTL5SLmv7DivfNa", err: ErrInvalidAddress},
+		{name: "doge p2pkh", code: currency.DOGE, address: "DH5yaieqoZN36fDVciNyRueRGvGLR3mr7L"},
+		{name: "doge segwit", code: currency.DOGE, address: core.BitcoinDonationAddress, err: ErrInvalidAddress},
+		{name: "eth lowercase", code: currency.ETH, address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1beaed"},
+		{name: "eth checksum", code: currency.ETH, address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed"},
+		{name: "eth bad checksum", code: currency.ETH, address: "0x5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAeD", err: ErrInvalidAddress},
+		{name: "eth short", code: currency.ETH, address: "0x5aaeb6053f3e94c9b9a09f33669435e7ef1bea", err: ErrInvalidAddress},
+		{name: "usdt erc20", code: currency.USDT, chain: "ERC20", address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359"},
+		{name: "usdt bep20", code: currency.USDT, chain: "BEP20(BSC)", address: "0xdbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB"},
+		{name: "usdt trc20", code: currency.USDT, chain: "TRC20", address: "TR7NHqjeKQxGTCi8q8ZY4pL8otSzgjLj6t"},
+		{name: "usdt trc20 evm address", code: currency.USDT, chain: "trc20", address: "0xfB6916095ca1df60bB79Ce92cE3Ea74c37c5d359", err: ErrInvalidAddress},
+		{name: "xrp", code: currency.XRP, address: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", tag: "1337"},
+		{name: "xrp bad tag", code: currency.XRP, address: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", tag: "meow", err: ErrInvalidDestinationTag},
+		{name: "xrp tag overflow", code: currency.XRP, address: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTh", tag: "4294967296", err: ErrInvalidDestinationTag},
+		{name: "xrp bad checksum", code: currency.XRP, address: "rHb9CJAWyB4rj91VRWn96DkukG4bwdtyTj", err: ErrInvalidAddress},
+		{name: "sol", code: currency.SOL, address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v"},
+		{name: "sol system program", code: currency.USDC, chain: "solana", address: "11111111111111111111111111111111"},
+		{name: "sol too short", code: currency.SOL, address: "EPjFWdd5AufqSSqeM2qN1xzybapC8G4wE", err: ErrInvalidAddress},
+		{name: "sol invalid character", code: currency.SOL, address: "0PjFWdd5AufqSSqeM2qN1xzybapC8G4wEGGkZwyTDt1v", err: ErrInvalidAddress},
+		{name: "atom", code: currency.ATOM, address: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xu"},
+		{name: "atom contract", code: currency.ATOM, address: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5z5tpwxqergd3c8g7rusqqlvp8l"},
+		{name: "atom osmo prefix", code: currency.ATOM, address: "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw", err: ErrInvalidAddress},
+		{name: "osmo", code: currency.NewCode("OSMO"), chain: "Osmosis", address: "osmo1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5helwsw"},
+		{name: "atom bad checksum", code: currency.ATOM, address: "cosmos1qypqxpq9qcrsszg2pvxq6rs0zqg3yyc5lzv7xv", err: ErrInvalidAddress},
+	} {
+		tc := tc
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			err := Validate(tc.code, tc.chain, tc.address, tc.tag)
+			if !errors.Is(err, tc.err) {
+				t.Errorf("received '%v', expected '%v'", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	t.Parallel()
+	if !IsSupported(currency.BTC, "") {
+		t.Error("expected BTC to be supported")
+	}
+	if IsSupported(currency.USDT, "") {
+		t.Error("expected USDT without a chain to be unsupported")
+	}
+	if !IsSupported(currency.USDT, "Arbitrum-One") {
+		t.Error("expected USDT on arbitrum to be supported")
+	}
+	if IsSupported(currency.EMPTYCODE, "") {
+		t.Error("expected empty code to be unsupported")
+	}
+}
+
+func TestChecksumEVM(t *testing.T) {
+	t.Parallel()
+	for _, expected := range []string{
+		"5aAeb6053F3E94C9b9A09f33669435E7Ef1BeAed",
+		"fB6916095ca1df60bB79Ce92cE3Ea74c37c5d359",
+		"dbF03B407c01E7cD3CBea99509d93f8DDDC8C6FB",
+		"D1220A0cf47c7B9Be7A2E6BA89F429762e7b9aDb",
+	} {
+		if received := checksumEVM(strings.ToLower(expected)); received != expected {
+			t.Errorf("received '%v', expected '%v'", received, expected)
+		}
+	}
+}
+
+func TestBase58Decode(t *testing.T) {
+	t.Parallel()
+	decoded, err := base58Decode("1112", bitcoinAlphabet)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received '%v', expected '%v'", err, nil)
+	}
+	if len(decoded) != 4 || decoded[3] != 1 {
+		t.Errorf("received '%v', expected '%v'", decoded, []byte{0, 0, 0, 1})
+	}
+	_, err = base58Decode("", bitcoinAlphabet)
+	if !errors.Is(err, errEmptyAddress) {
+		t.Errorf("received '%v', expected '%v'", err, errEmptyAddress)
+	}
+	_, err = base58Decode("0OIl", bitcoinAlphabet)
+	if !errors.Is(err, errInvalidCharacter) {
+		t.Errorf("received '%v', expected '%v'", err, errInvalidCharacter)
+	}
+}
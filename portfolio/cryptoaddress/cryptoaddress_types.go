@@ -0,0 +1,119 @@
+package cryptoaddress
+
+import (
+	"errors"
+
+	"github.com/thrasher-corp/gocryptotrader/currency"
+)
+
+const (
+	bitcoinP2PKHVersion  = 0x00
+	bitcoinP2SHVersion   = 0x05
+	litecoinP2PKHVersion = 0x30
+	litecoinP2SHVersion  = 0x32
+	dogecoinP2PKHVersion = 0x1e
+	dogecoinP2SHVersion  = 0x16
+	rippleAccountVersion = 0x00
+	tronAccountVersion   = 0x41
+
+	bitcoinSegwitHRP  = "bc"
+	litecoinSegwitHRP = "ltc"
+
+	hash160Length   = 20
+	publicKeyLength = 32
+	evmHexLength    = 40
+)
+
+var (
+	// ErrUnsupported is returned when there is no offline validator for the
+	// supplied currency and chain combination. Callers should treat this as
+	// "unable to verify" rather than as an invalid address.
+	ErrUnsupported = errors.New("address validation unsupported")
+	// ErrInvalidAddress is returned when an address fails validation
+	ErrInvalidAddress = errors.New("invalid address")
+	// ErrInvalidDestinationTag is returned when an address tag or memo is
+	// required to be a specific format and fails validation
+	ErrInvalidDestinationTag = errors.New("invalid destination tag")
+
+	errEmptyAddress     = errors.New("address cannot be empty")
+	errInvalidChecksum  = errors.New("invalid checksum")
+	errInvalidCharacter = errors.New("invalid character")
+	errInvalidLength    = errors.New("invalid length")
+	errInvalidVersion   = errors.New("invalid version")
+	errInvalidPrefix    = errors.New("invalid human readable prefix")
+	errMixedCase        = errors.New("mixed case")
+	errInvalidPadding   = errors.New("invalid padding")
+	errInvalidEncoding  = errors.New("invalid bech32 encoding variant")
+)
+
+// validatorFunc checks an address and its optional tag or memo
+type validatorFunc func(address, tag string) error
+
+// chainValidators maps normalised chain or network names, as supplied in a
+// withdrawal's chain field, to their address validators
+var chainValidators = map[string]validatorFunc{
+	"btc":           validateBitcoin,
+	"bitcoin":       validateBitcoin,
+	"ltc":           validateLitecoin,
+	"litecoin":      validateLitecoin,
+	"doge":          validateDogecoin,
+	"dogecoin":      validateDogecoin,
+	"xrp":           validateRipple,
+	"ripple":        validateRipple,
+	"trx":           validateTron,
+	"tron":          validateTron,
+	"trc20":         validateTron,
+	"sol":           validateSolana,
+	"solana":        validateSolana,
+	"spl":           validateSolana,
+	"atom":          cosmosValidator("cosmos"),
+	"cosmos":        cosmosValidator("cosmos"),
+	"osmo":          cosmosValidator("osmo"),
+	"osmosis":       cosmosValidator("osmo"),
+	"inj":           cosmosValidator("inj"),
+	"injective":     cosmosValidator("inj"),
+	"kava":          cosmosValidator("kava"),
+	"tia":           cosmosValidator("celestia"),
+	"celestia":      cosmosValidator("celestia"),
+	"sei":           cosmosValidator("sei"),
+	"akt":           cosmosValidator("akash"),
+	"akash":         cosmosValidator("akash"),
+	"scrt":          cosmosValidator("secret"),
+	"secret":        cosmosValidator("secret"),
+	"eth":           validateEVM,
+	"ethereum":      validateEVM,
+	"erc20":         validateEVM,
+	"bep20":         validateEVM,
+	"bep20bsc":      validateEVM,
+	"bsc":           validateEVM,
+	"bnbsmartchain": validateEVM,
+	"arbitrum":      validateEVM,
+	"arbitrumone":   validateEVM,
+	"arb":           validateEVM,
+	"optimism":      validateEVM,
+	"op":            validateEVM,
+	"polygon":       validateEVM,
+	"matic":         validateEVM,
+	"avaxc":         validateEVM,
+	"avaxcchain":    validateEVM,
+	"base":          validateEVM,
+	"etc":           validateEVM,
+	"ftm":           validateEVM,
+	"fantom":        validateEVM,
+}
+
+// currencyValidators maps currency codes to their native chain address
+// validator for when a withdrawal does not specify a chain
+var currencyValidators = map[*currency.Item]validatorFunc{
+	currency.BTC.Item:  validateBitcoin,
+	currency.LTC.Item:  validateLitecoin,
+	currency.DOGE.Item: validateDogecoin,
+	currency.XRP.Item:  validateRipple,
+	currency.TRX.Item:  validateTron,
+	currency.SOL.Item:  validateSolana,
+	currency.ATOM.Item: cosmosValidator("cosmos"),
+	currency.INJ.Item:  cosmosValidator("inj"),
+	currency.KAVA.Item: cosmosValidator("kava"),
+	currency.ETH.Item:  validateEVM,
+	currency.ETC.Item:  validateEVM,
+}
@@ -0,0 +1,173 @@
+package cryptoaddress
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"strings"
+)
+
+const (
+	bitcoinAlphabet = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+	rippleAlphabet  = "rpshnaf39wBUDNEGHJKLM4PQRST7VWXYZ2bcdeCg65jkm8oFqi1tuvAxyz"
+
+	bech32Charset    = "qpzry9x8gf2tvdw0s3jn54khce6mua7l"
+	bech32Encoding   = 1
+	bech32mEncoding  = 0x2bc830a3
+	bech32MinLength  = 8
+	bech32ChecksumSz = 6
+	segwitMaxLength  = 90
+	cosmosMaxLength  = 128
+
+	checksumLength = 4
+)
+
+// base58Decode decodes a base58 string using the supplied alphabet
+func base58Decode(s, alphabet string) ([]byte, error) {
+	if s == "" {
+		return nil, errEmptyAddress
+	}
+	var leadingZeros int
+	for leadingZeros < len(s) && s[leadingZeros] == alphabet[0] {
+		leadingZeros++
+	}
+	// log(58)/log(256) rounded up gives the maximum decoded size
+	decoded := make([]byte, 0, len(s)*733/1000+1)
+	for i := leadingZeros; i < len(s); i++ {
+		carry := strings.IndexByte(alphabet, s[i])
+		if carry < 0 {
+			return nil, fmt.Errorf("%w %q", errInvalidCharacter, s[i])
+		}
+		for j := range decoded {
+			carry += int(decoded[j]) * 58
+			decoded[j] = byte(carry)
+			carry >>= 8
+		}
+		for carry > 0 {
+			decoded = append(decoded, byte(carry))
+			carry >>= 8
+		}
+	}
+	// decoded is little endian, reverse and prepend zero bytes
+	result := make([]byte, leadingZeros+len(decoded))
+	for i := range decoded {
+		result[len(result)-1-i] = decoded[i]
+	}
+	return result, nil
+}
+
+// validateBase58Check decodes a base58check string and verifies its double
+// SHA256 checksum, payload length and version byte
+func validateBase58Check(s, alphabet string, payloadLength int, versions ...byte) error {
+	decoded, err := base58Decode(s, alphabet)
+	if err != nil {
+		return err
+	}
+	if len(decoded) != 1+payloadLength+checksumLength {
+		return fmt.Errorf("%w %d bytes", errInvalidLength, len(decoded))
+	}
+	body := decoded[:len(decoded)-checksumLength]
+	first := sha256.Sum256(body)
+	second := sha256.Sum256(first[:])
+	if !bytes.Equal(second[:checksumLength], decoded[len(decoded)-checksumLength:]) {
+		return errInvalidChecksum
+	}
+	for i := range versions {
+		if body[0] == versions[i] {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w 0x%02x", errInvalidVersion, body[0])
+}
+
+// bech32Polymod computes the BCH checksum over the supplied 5-bit values
+func bech32Polymod(values []byte) uint32 {
+	generator := [5]uint32{0x3b6a57b2, 0x26508e6d, 0x1ea119fa, 0x3d4233dd, 0x2a1462b3}
+	chk := uint32(1)
+	for _, v := range values {
+		top := chk >> 25
+		chk = (chk&0x1ffffff)<<5 ^ uint32(v)
+		for i := range generator {
+			if (top>>uint(i))&1 == 1 {
+				chk ^= generator[i]
+			}
+		}
+	}
+	return chk
+}
+
+// bech32HRPExpand expands the human readable part for checksum computation
+func bech32HRPExpand(hrp string) []byte {
+	expanded := make([]byte, 0, len(hrp)*2+1)
+	for i := range hrp {
+		expanded = append(expanded, hrp[i]>>5)
+	}
+	expanded = append(expanded, 0)
+	for i := range hrp {
+		expanded = append(expanded, hrp[i]&31)
+	}
+	return expanded
+}
+
+// bech32Decode decodes a bech32 or bech32m string, returning the lowercase
+// human readable part, the 5-bit data without checksum and which checksum
+// constant matched
+func bech32Decode(s string, maxLength int) (hrp string, data []byte, encoding uint32, err error) {
+	if len(s) < bech32MinLength || len(s) > maxLength {
+		return "", nil, 0, fmt.Errorf("%w %d characters", errInvalidLength, len(s))
+	}
+	lower := strings.ToLower(s)
+	if lower != s && strings.ToUpper(s) != s {
+		return "", nil, 0, errMixedCase
+	}
+	separator := strings.LastIndexByte(lower, '1')
+	if separator < 1 || separator+bech32ChecksumSz+1 > len(lower) {
+		return "", nil, 0, fmt.Errorf("%w: separator position", errInvalidLength)
+	}
+	hrp = lower[:separator]
+	for i := range hrp {
+		if hrp[i] < 33 || hrp[i] > 126 {
+			return "", nil, 0, fmt.Errorf("%w %q", errInvalidCharacter, hrp[i])
+		}
+	}
+	data = make([]byte, 0, len(lower)-separator-1)
+	for i := separator + 1; i < len(lower); i++ {
+		idx := strings.IndexByte(bech32Charset, lower[i])
+		if idx < 0 {
+			return "", nil, 0, fmt.Errorf("%w %q", errInvalidCharacter, lower[i])
+		}
+		data = append(data, byte(idx))
+	}
+	encoding = bech32Polymod(append(bech32HRPExpand(hrp), data...))
+	if encoding != bech32Encoding && encoding != bech32mEncoding {
+		return "", nil, 0, errInvalidChecksum
+	}
+	return hrp, data[:len(data)-bech32ChecksumSz], encoding, nil
+}
+
+// convertBits regroups a slice of fromBits sized values into toBits sized
+// values
+func convertBits(data []byte, fromBits, toBits uint, pad bool) ([]byte, error) {
+	var acc, bits uint
+	maxValue := uint(1)<<toBits - 1
+	result := make([]byte, 0, len(data)*int(fromBits)/int(toBits)+1)
+	for _, v := range data {
+		if uint(v)>>fromBits != 0 {
+			return nil, fmt.Errorf("%w value %d", errInvalidCharacter, v)
+		}
+		acc = acc<<fromBits | uint(v)
+		bits += fromBits
+		for bits >= toBits {
+			bits -= toBits
+			result = append(result, byte(acc>>bits&maxValue))
+		}
+	}
+	if pad {
+		if bits > 0 {
+			result = append(result, byte(acc<<(toBits-bits)&maxValue))
+		}
+	} else if bits >= fromBits || acc<<(toBits-bits)&maxValue != 0 {
+		return nil, errInvalidPadding
+	}
+	return result, nil
+}
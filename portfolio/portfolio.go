@@ -6,12 +6,14 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"strings"
 	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/common"
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/log"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/cryptoaddress"
 )
 
 const (
@@ -27,7 +29,10 @@ const (
 	PersonalAddress = "Personal"
 )
 
-var errNotEthAddress = errors.New("not an Ethereum address")
+var (
+	errNotEthAddress   = errors.New("not an Ethereum address")
+	errNotFiatCurrency = errors.New("valuation currency is not a fiat currency")
+)
 
 // GetEthereumBalance single or multiple address information as
 // EtherchainBalanceResponse
@@ -205,6 +210,11 @@ func (b *Base) AddAddress(address, description string, coinType currency.Code, b
 
 	if description == ExchangeAddress {
 		b.AddExchangeAddress(address, coinType, balance)
+	} else {
+		err := cryptoaddress.Validate(coinType, "", address, "")
+		if err != nil && !errors.Is(err, cryptoaddress.ErrUnsupported) {
+			return err
+		}
 	}
 	if !b.AddressExists(address) {
 		b.Addresses = append(
@@ -461,6 +471,53 @@ func (b *Base) GetPortfolioSummary() Summary {
 	return portfolioOutput
 }
 
+// Value values the coin totals of the summary in a fiat currency using the
+// current foreign exchange rates, cryptocurrency totals are priced in a fiat
+// currency by the price function and that amount is then converted
+func (s *Summary) Value(to currency.Code, price PriceFunc) (Valuation, error) {
+	return s.value(to, time.Now(), price, func(from, to currency.Code, _ time.Time) (float64, error) {
+		return currency.ConvertFiat(1, from, to)
+	})
+}
+
+// ValueAt values the coin totals of the summary in a fiat currency at a point
+// in time. Fiat totals are converted with the historical rate of the day,
+// cryptocurrency totals are priced in a fiat currency by the price function
+// and that amount is then converted
+func (s *Summary) ValueAt(to currency.Code, t time.Time, price PriceFunc) (Valuation, error) {
+	return s.value(to, t, price, currency.ConvertAt)
+}
+
+// value values the coin totals of the summary in a fiat currency using the
+// supplied conversion rate function
+func (s *Summary) value(to currency.Code, t time.Time, price PriceFunc, convert func(from, to currency.Code, t time.Time) (float64, error)) (Valuation, error) {
+	if !to.IsFiatCurrency() {
+		return Valuation{}, fmt.Errorf("%w %v", errNotFiatCurrency, to)
+	}
+	v := Valuation{Currency: to, Time: t}
+	for i := range s.Totals {
+		amount, from := s.Totals[i].Balance, s.Totals[i].Coin
+		if !from.IsFiatCurrency() {
+			p, fiat, ok := price(from)
+			if !ok {
+				v.Unvalued = append(v.Unvalued, from)
+				continue
+			}
+			amount, from = amount*p, fiat
+		}
+		rate, err := convert(from, to, t)
+		if err != nil {
+			v.Unvalued = append(v.Unvalued, s.Totals[i].Coin)
+			continue
+		}
+		v.Value += amount * rate
+	}
+	sort.Slice(v.Unvalued, func(i, j int) bool {
+		return v.Unvalued[i].String() < v.Unvalued[j].String()
+	})
+	return v, nil
+}
+
 // GetPortfolioGroupedCoin returns portfolio base information grouped by coin
 func (b *Base) GetPortfolioGroupedCoin() map[currency.Code][]string {
 	result := make(map[currency.Code][]string)
@@ -3,13 +3,17 @@ package portfolio
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/thrasher-corp/gocryptotrader/core"
 	"github.com/thrasher-corp/gocryptotrader/currency"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/cryptoaddress"
 )
 
 const (
-	testBTCAddress = "0x1D01TH0R53"
+	testBTCAddress  = "1QJXx5X8qZS75DUP4csav8ALaWxELKSzHr"
+	testLTCAddress  = "LWYF8a3jVZtTLzowWGaL7Xxoe7S3x2u5uL"
+	testLTCAddress2 = "LUknE4ivBSrba7T75mgPsyfqHRUavxkd64"
 )
 
 func TestGetEthereumBalance(t *testing.T) {
@@ -133,7 +137,7 @@ func TestAddressExists(t *testing.T) {
 func TestExchangeAddressExists(t *testing.T) {
 	t.Parallel()
 	newBase := Base{}
-	err := newBase.AddAddress("someaddress",
+	err := newBase.AddAddress(testLTCAddress,
 		currency.LTC.String(),
 		currency.LTC,
 		0.02)
@@ -141,7 +145,7 @@ func TestExchangeAddressExists(t *testing.T) {
 		t.Error(err)
 	}
 
-	if !newBase.ExchangeAddressExists("someaddress", currency.LTC) {
+	if !newBase.ExchangeAddressExists(testLTCAddress, currency.LTC) {
 		t.Error("expected exchange address to exist")
 	}
 	if newBase.ExchangeAddressExists("TEST", currency.LTC) {
@@ -261,6 +265,10 @@ func TestAddAddress(t *testing.T) {
 		t.Error("invalid coin type should throw an error")
 	}
 
+	if err := newBase.AddAddress(core.BitcoinDonationAddress, PersonalAddress, currency.LTC, 1); !errors.Is(err, cryptoaddress.ErrInvalidAddress) {
+		t.Errorf("received '%v', expected '%v'", err, cryptoaddress.ErrInvalidAddress)
+	}
+
 	// test adding an exchange address
 	err := newBase.AddAddress("COINUT", ExchangeAddress, currency.LTC, 0)
 	if err != nil {
@@ -407,7 +415,7 @@ func TestGetExchangePortfolio(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = newBase.AddAddress("someaddress", PersonalAddress, currency.LTC, 0.03)
+	err = newBase.AddAddress(testLTCAddress, PersonalAddress, currency.LTC, 0.03)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -455,15 +463,15 @@ func TestGetPortfolioSummary(t *testing.T) {
 	t.Parallel()
 	newBase := Base{}
 	// Personal holdings
-	err := newBase.AddAddress("someaddress", PersonalAddress, currency.LTC, 1)
+	err := newBase.AddAddress(testLTCAddress, PersonalAddress, currency.LTC, 1)
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = newBase.AddAddress("someaddress2", PersonalAddress, currency.LTC, 2)
+	err = newBase.AddAddress(testLTCAddress2, PersonalAddress, currency.LTC, 2)
 	if err != nil {
 		t.Fatal(err)
 	}
-	err = newBase.AddAddress("someaddress3", PersonalAddress, currency.BTC, 100)
+	err = newBase.AddAddress(testBTCAddress, PersonalAddress, currency.BTC, 100)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -514,7 +522,7 @@ func TestGetPortfolioSummary(t *testing.T) {
 func TestGetPortfolioGroupedCoin(t *testing.T) {
 	t.Parallel()
 	newBase := Base{}
-	err := newBase.AddAddress("someaddress", currency.LTC.String(), currency.LTC, 0.02)
+	err := newBase.AddAddress(testLTCAddress, currency.LTC.String(), currency.LTC, 0.02)
 	if err != nil {
 		t.Fatal(err)
 	}
@@ -524,7 +532,7 @@ func TestGetPortfolioGroupedCoin(t *testing.T) {
 	}
 
 	value := newBase.GetPortfolioGroupedCoin()
-	if value[currency.LTC][0] != "someaddress" && len(value[currency.LTC][0]) != 1 {
+	if value[currency.LTC][0] != testLTCAddress && len(value[currency.LTC][0]) != 1 {
 		t.Error("incorrect balance")
 	}
 }
@@ -532,11 +540,11 @@ func TestGetPortfolioGroupedCoin(t *testing.T) {
 func TestSeed(t *testing.T) {
 	t.Parallel()
 	newBase := Base{}
-	err := newBase.AddAddress("someaddress", currency.LTC.String(), currency.LTC, 0.02)
+	err := newBase.AddAddress(testLTCAddress, currency.LTC.String(), currency.LTC, 0.02)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if !newBase.AddressExists("someaddress") {
+	if !newBase.AddressExists(testLTCAddress) {
 		t.Error("Seed error")
 	}
 }
@@ -638,3 +646,80 @@ func seedPortFolioForTest(t *testing.T) *Base {
 	}
 	return &b
 }
+
+func TestValueAt(t *testing.T) {
+	t.Parallel()
+	day := time.Date(2021, 3, 4, 0, 0, 0, 0, time.UTC)
+	err := currency.AddHistoricalRates(
+		currency.HistoricalRate{From: currency.USD, To: currency.EUR, Date: day, Rate: 0.8},
+		currency.HistoricalRate{From: currency.USD, To: currency.EUR, Date: day.AddDate(0, 0, 1), Rate: 0.9},
+	)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	s := Summary{Totals: []Coin{
+		{Coin: currency.USD, Balance: 100},
+		{Coin: currency.BTC, Balance: 2},
+		{Coin: currency.LTC, Balance: 10},
+		{Coin: currency.JPY, Balance: 1000},
+	}}
+	price := func(c currency.Code) (float64, currency.Code, bool) {
+		if c.Equal(currency.BTC) {
+			return 50000, currency.USD, true
+		}
+		return 0, currency.EMPTYCODE, false
+	}
+
+	_, err = s.ValueAt(currency.BTC, day, price)
+	if !errors.Is(err, errNotFiatCurrency) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNotFiatCurrency)
+	}
+
+	// the rate of the valuation day is used rather than the latest rate
+	v, err := s.ValueAt(currency.EUR, day.Add(time.Hour*12), price)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if expected := (100 + 2*50000) * 0.8; v.Value != expected {
+		t.Errorf("received: '%v' but expected: '%v'", v.Value, expected)
+	}
+	if len(v.Unvalued) != 2 || !v.Unvalued[0].Equal(currency.JPY) || !v.Unvalued[1].Equal(currency.LTC) {
+		t.Errorf("received: '%v' but expected: '%v'", v.Unvalued, []currency.Code{currency.JPY, currency.LTC})
+	}
+}
+
+func TestValue(t *testing.T) {
+	t.Parallel()
+	s := Summary{Totals: []Coin{
+		{Coin: currency.USD, Balance: 100},
+		{Coin: currency.BTC, Balance: 2},
+		{Coin: currency.USDT, Balance: 50},
+		{Coin: currency.LTC, Balance: 10},
+	}}
+	price := func(c currency.Code) (float64, currency.Code, bool) {
+		switch {
+		case c.Equal(currency.BTC):
+			return 50000, currency.USD, true
+		case c.Equal(currency.USDT):
+			return 1, currency.USD, true
+		}
+		return 0, currency.EMPTYCODE, false
+	}
+
+	_, err := s.Value(currency.BTC, price)
+	if !errors.Is(err, errNotFiatCurrency) {
+		t.Errorf("received: '%v' but expected: '%v'", err, errNotFiatCurrency)
+	}
+
+	// valuing in USD requires no stored rates
+	v, err := s.Value(currency.USD, price)
+	if !errors.Is(err, nil) {
+		t.Fatalf("received: '%v' but expected: '%v'", err, nil)
+	}
+	if expected := 100 + 2*50000 + 50.0; v.Value != expected {
+		t.Errorf("received: '%v' but expected: '%v'", v.Value, expected)
+	}
+	if len(v.Unvalued) != 1 || !v.Unvalued[0].Equal(currency.LTC) {
+		t.Errorf("received: '%v' but expected: '%v'", v.Unvalued, []currency.Code{currency.LTC})
+	}
+}
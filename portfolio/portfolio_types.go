@@ -127,6 +127,20 @@ type Summary struct {
 	OnlineSummary  map[string]map[currency.Code]OnlineCoinSummary `json:"online_summary"`
 }
 
+// PriceFunc returns the price of one unit of a cryptocurrency in a fiat
+// currency, the returned bool is false when the currency cannot be priced
+type PriceFunc func(c currency.Code) (price float64, fiat currency.Code, ok bool)
+
+// Valuation stores the value of the portfolio coin totals in a fiat currency
+type Valuation struct {
+	Currency currency.Code
+	Time     time.Time
+	Value    float64
+	// Unvalued are the coins which could not be priced or converted, they
+	// are excluded from the value
+	Unvalued []currency.Code
+}
+
 // XRPScanAccount defines the return type for account data
 type XRPScanAccount struct {
 	Sequence                                  int     `json:"sequence"`
@@ -6,6 +6,7 @@ import (
 
 	"github.com/thrasher-corp/gocryptotrader/currency"
 	"github.com/thrasher-corp/gocryptotrader/exchanges/validate"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/cryptoaddress"
 )
 
 // Validate takes interface and passes to asset type to check the request meets requirements to submit
@@ -74,6 +75,11 @@ func (r *Request) validateCrypto() []string {
 
 	if r.Crypto.Address == "" {
 		resp = append(resp, ErrStrAddressNotSet)
+	} else {
+		err := cryptoaddress.Validate(r.Currency, r.Crypto.Chain, r.Crypto.Address, r.Crypto.AddressTag)
+		if err != nil && !errors.Is(err, cryptoaddress.ErrUnsupported) {
+			resp = append(resp, err.Error())
+		}
 	}
 
 	if r.Crypto.FeeAmount < 0 {
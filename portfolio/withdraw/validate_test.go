@@ -11,10 +11,12 @@ import (
 	"github.com/thrasher-corp/gocryptotrader/exchanges/validate"
 	"github.com/thrasher-corp/gocryptotrader/portfolio"
 	"github.com/thrasher-corp/gocryptotrader/portfolio/banking"
+	"github.com/thrasher-corp/gocryptotrader/portfolio/cryptoaddress"
 )
 
 const (
-	testBTCAddress = "0xTHISISALEGITBTCADDRESSHONEST"
+	testBTCAddress        = "1QJXx5X8qZS75DUP4csav8ALaWxELKSzHr"
+	testInvalidBTCAddress = "1QJXx5X8qZS75DUP4csav8ALaWxELKSzHs"
 )
 
 var (
@@ -92,6 +94,29 @@ var (
 		Type:        Crypto,
 	}
 
+	invalidCryptoAddressRequest = &Request{
+		Exchange: "Binance",
+		Crypto: CryptoRequest{
+			Address: testInvalidBTCAddress,
+		},
+		Currency:    currency.BTC,
+		Description: "Test Withdrawal",
+		Amount:      0.1,
+		Type:        Crypto,
+	}
+
+	invalidCryptoChainRequest = &Request{
+		Exchange: "Binance",
+		Crypto: CryptoRequest{
+			Address: core.BitcoinDonationAddress,
+			Chain:   "erc20",
+		},
+		Currency:    currency.USDT,
+		Description: "Test Withdrawal",
+		Amount:      0.1,
+		Type:        Crypto,
+	}
+
 	invalidType = &Request{
 		Exchange: "test",
 		Type:     Unknown,
@@ -302,6 +327,16 @@ func TestValidateCrypto(t *testing.T) {
 			invalidCryptoNegativeFeeRequest,
 			errors.New(ErrStrFeeCannotBeNegative),
 		},
+		{
+			"InvalidAddress",
+			invalidCryptoAddressRequest,
+			cryptoaddress.Validate(currency.BTC, "", testInvalidBTCAddress, ""),
+		},
+		{
+			"InvalidAddressForChain",
+			invalidCryptoChainRequest,
+			cryptoaddress.Validate(currency.USDT, "erc20", core.BitcoinDonationAddress, ""),
+		},
 	}
 
 	for _, tests := range testCases {